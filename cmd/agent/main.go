@@ -29,13 +29,18 @@ import (
 
 	"github.com/kelseyhightower/envconfig"
 	"github.com/kserve/kserve/pkg/agent"
+	"github.com/kserve/kserve/pkg/agent/status"
 	"github.com/kserve/kserve/pkg/agent/storage"
 	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
 	"github.com/kserve/kserve/pkg/batcher"
 	kfslogger "github.com/kserve/kserve/pkg/logger"
+	"github.com/kserve/kserve/pkg/tracing"
 	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	flag "github.com/spf13/pflag"
 	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/api/resource"
 
 	"knative.dev/networking/pkg/http/header"
 	proxy "knative.dev/networking/pkg/http/proxy"
@@ -52,14 +57,32 @@ var (
 	port          = flag.String("port", "9081", "Agent port")
 	componentPort = flag.Int("component-port", 8080, "Component port")
 	// model puller flags
-	enablePuller = flag.Bool("enable-puller", false, "Enable model puller")
-	configDir    = flag.String("config-dir", "/mnt/configs", "directory for model config files")
-	modelDir     = flag.String("model-dir", "/mnt/models", "directory for model files")
+	enablePuller      = flag.Bool("enable-puller", false, "Enable model puller")
+	configDir         = flag.String("config-dir", "/mnt/configs", "directory for model config files")
+	modelDir          = flag.String("model-dir", "/mnt/models", "directory for model files")
+	verifyChecksum    = flag.Bool("verify-checksum", false, "Verify the downloaded model files against their checksum manifest")
+	reclaimModelStore = flag.Bool("reclaim-model-store", false, "Keep the files of the unloaded models and evict the least recently used ones when the model store is full")
+	pullerWorkers     = flag.Int("puller-workers", agent.DefaultPullerWorkers, "Max number of models downloaded and loaded concurrently")
+	pullerRetries     = flag.Int("puller-max-retries", agent.DefaultPullerMaxRetries, "Number of retries of the failed model loads")
+	metricsPort       = flag.String("metrics-port", "9082", "Port of the metrics endpoint of the puller, the logger and the batcher")
+	// metrics aggregation
+	componentMetricsPort = flag.String("component-metrics-port", "", "Port of the metrics of the component merged into the agent metrics, not merged if empty")
+	componentMetricsPath = flag.String("component-metrics-path", "/metrics", "Path of the metrics of the component merged into the agent metrics")
 	// logger flags
 	logUrl           = flag.String("log-url", "", "The URL to send request/response logs to")
+	logSecretDir     = flag.String("log-secret-dir", "", "The directory of the SASL/TLS configuration of the kafka log-url")
+	logSamplingRate  = flag.Float64("log-sampling-rate", 1, "The fraction of the inferences to log, between 0 and 1")
+	logMaxPayload    = flag.Int64("log-max-payload-bytes", 0, "The max size of the logged payloads, unlimited if 0")
+	logOverflow      = flag.String("log-payload-overflow", string(v1beta1.LogPayloadTruncate), "Whether to 'truncate' or 'drop' the payloads larger than log-max-payload-bytes")
+	logContentTypes  = flag.StringSlice("log-content-types", nil, "The content types of the logged payloads, all are logged if empty")
+	logMetadata      = flag.StringSlice("log-metadata-headers", nil, "The request headers to capture in the metadata of the log events")
+	logMaxRetries    = flag.Int("log-max-retries", kfslogger.DefaultLoggerMaxRetries, "The number of retries of the log events the sink failed to receive")
+	logQueueSize     = flag.Int("log-queue-size", kfslogger.LoggerWorkerQueueSize, "The number of log events waiting in memory for a worker")
+	logBufferPath    = flag.String("log-disk-buffer-path", "", "The directory storing the log events when the queue is full, they are dropped if empty")
+	logBufferSize    = flag.String("log-disk-buffer-size", "1Gi", "The max size of the log events stored in log-disk-buffer-path")
 	workers          = flag.Int("workers", 5, "Number of workers")
 	sourceUri        = flag.String("source-uri", "", "The source URI to use when publishing cloudevents")
-	logMode          = flag.String("log-mode", string(v1beta1.LogAll), "Whether to log 'request', 'response' or 'all'")
+	logMode          = flag.String("log-mode", string(v1beta1.LogAll), "Whether to log 'request', 'response', 'all' or 'errors'")
 	inferenceService = flag.String("inference-service", "", "The InferenceService name to add as header to log events")
 	namespace        = flag.String("namespace", "", "The namespace to add as header to log events")
 	endpoint         = flag.String("endpoint", "", "The endpoint name to add as header to log events")
@@ -68,6 +91,8 @@ var (
 	enableBatcher = flag.Bool("enable-batcher", false, "Enable request batcher")
 	maxBatchSize  = flag.String("max-batchsize", "32", "Max Batch Size")
 	maxLatency    = flag.String("max-latency", "5000", "Max Latency in milliseconds")
+	maxInFlight   = flag.String("max-inflight", "0", "Max number of batches sent concurrently to the model, unlimited if 0")
+	maxQueueSize  = flag.String("max-queue-size", "0", "Max number of requests waiting to be sent to the model, unlimited if 0")
 	// probing flags
 	readinessProbeTimeout = flag.Duration("probe-period", -1, "run readiness probe with given timeout") //nolint: unused
 	// This creates an abstract socket instead of an actual file.
@@ -97,6 +122,9 @@ type config struct {
 	ServingRequestLogTemplate    string `split_words:"true"` // optional
 	ServingEnableRequestLog      bool   `split_words:"true"` // optional
 	ServingEnableProbeRequestLog bool   `split_words:"true"` // optional
+	// Tracing configuration, injected by the pod mutator when tracing is enabled
+	OtelExporterOtlpEndpoint string `envconfig:"OTEL_EXPORTER_OTLP_ENDPOINT"` // optional
+	OtelTracesSamplerArg     string `envconfig:"OTEL_TRACES_SAMPLER_ARG"`     // optional
 }
 
 type loggerArgs struct {
@@ -107,11 +135,19 @@ type loggerArgs struct {
 	namespace        string
 	endpoint         string
 	component        string
+	policy           *kfslogger.Policy
+	metadataHeaders  []string
+}
+
+type tracingArgs struct {
+	samplingRatio float64
 }
 
 type batcherArgs struct {
 	maxBatchSize int
 	maxLatency   int
+	maxInFlight  int
+	maxQueueSize int
 }
 
 func main() {
@@ -130,9 +166,10 @@ func main() {
 		probe = buildProbe(logger, env.ServingReadinessProbe).ProbeContainer
 	}
 
+	var modelStatuses *status.Tracker
 	if *enablePuller {
 		logger.Infof("Initializing model agent with config-dir %s, model-dir %s", *configDir, *modelDir)
-		startModelPuller(logger)
+		modelStatuses = startModelPuller(logger)
 	}
 
 	var loggerArgs *loggerArgs
@@ -146,12 +183,21 @@ func main() {
 		logger.Info("Starting batcher")
 		batcherArgs = startBatcher(logger)
 	}
+
+	var tracingArgs *tracingArgs
+	if env.OtelExporterOtlpEndpoint != "" {
+		logger.Info("Starting tracing")
+		tracingArgs = startTracing(env.OtelTracesSamplerArg, logger)
+	}
 	logger.Info("Starting agent http server...")
 	ctx := signals.NewContext()
-	mainServer, drain := buildServer(ctx, *port, *componentPort, loggerArgs, batcherArgs, probe, logger)
+	mainServer, drain := buildServer(ctx, *port, *componentPort, loggerArgs, batcherArgs, tracingArgs, probe, logger)
 	servers := map[string]*http.Server{
 		"main": mainServer,
 	}
+	if *enablePuller || loggerArgs != nil || batcherArgs != nil {
+		servers["metrics"] = buildMetricsServer(*metricsPort, *componentMetricsPort, *componentMetricsPath, modelStatuses)
+	}
 	errCh := make(chan error)
 	listenCh := make(chan struct{})
 	for name, server := range servers {
@@ -243,16 +289,50 @@ func startBatcher(logger *zap.SugaredLogger) *batcherArgs {
 		os.Exit(1)
 	}
 
+	maxInFlightInt, err := strconv.Atoi(*maxInFlight)
+	if err != nil || maxInFlightInt < 0 {
+		logger.Error(errors.New("Invalid max in-flight batches"), *maxInFlight)
+		os.Exit(1)
+	}
+
+	maxQueueSizeInt, err := strconv.Atoi(*maxQueueSize)
+	if err != nil || maxQueueSizeInt < 0 {
+		logger.Error(errors.New("Invalid max queue size"), *maxQueueSize)
+		os.Exit(1)
+	}
+
+	if err := batcher.RegisterMetrics(agent.MetricsRegistry); err != nil {
+		logger.Errorf("Failed to register the batcher metrics: %v", err)
+		os.Exit(1)
+	}
+
 	return &batcherArgs{
 		maxLatency:   maxLatencyInt,
 		maxBatchSize: maxBatchSizeInt,
+		maxInFlight:  maxInFlightInt,
+		maxQueueSize: maxQueueSizeInt,
+	}
+}
+
+func startTracing(samplerArg string, logger *zap.SugaredLogger) *tracingArgs {
+	samplingRatio := 1.0
+	if samplerArg != "" {
+		ratio, err := strconv.ParseFloat(samplerArg, 64)
+		if err != nil || ratio < 0 || ratio > 1 {
+			logger.Errorf("Malformed tracing sampling ratio %s, sampling every trace", samplerArg)
+		} else {
+			samplingRatio = ratio
+		}
+	}
+	return &tracingArgs{
+		samplingRatio: samplingRatio,
 	}
 }
 
 func startLogger(workers int, logger *zap.SugaredLogger) *loggerArgs {
 	loggingMode := v1beta1.LoggerType(*logMode)
 	switch loggingMode {
-	case v1beta1.LogAll, v1beta1.LogRequest, v1beta1.LogResponse:
+	case v1beta1.LogAll, v1beta1.LogRequest, v1beta1.LogResponse, v1beta1.LogErrors:
 	default:
 		logger.Errorf("Malformed log-mode %s", *logMode)
 		os.Exit(-1)
@@ -273,8 +353,43 @@ func startLogger(workers int, logger *zap.SugaredLogger) *loggerArgs {
 		logger.Errorf("Malformed source_uri %s", *sourceUri)
 		os.Exit(-1)
 	}
+	if *logSamplingRate < 0 || *logSamplingRate > 1 {
+		logger.Errorf("Malformed log-sampling-rate %v", *logSamplingRate)
+		os.Exit(-1)
+	}
+	payloadOverflow := v1beta1.LoggerPayloadOverflow(*logOverflow)
+	switch payloadOverflow {
+	case v1beta1.LogPayloadTruncate, v1beta1.LogPayloadDrop:
+	default:
+		logger.Errorf("Malformed log-payload-overflow %s", *logOverflow)
+		os.Exit(-1)
+	}
+	if err := kfslogger.RegisterMetrics(agent.MetricsRegistry); err != nil {
+		logger.Errorf("Failed to register the logger metrics: %v", err)
+		os.Exit(-1)
+	}
+
+	sink, err := kfslogger.NewSink(logUrlParsed, *logSecretDir)
+	if err != nil {
+		logger.Errorf("Failed to create the logger sink of log-url %s: %v", *logUrl, err)
+		os.Exit(-1)
+	}
+	delivery := kfslogger.DefaultDeliveryConfig()
+	delivery.MaxRetries = *logMaxRetries
+	delivery.QueueSize = *logQueueSize
+	delivery.DiskBufferPath = *logBufferPath
+	bufferSize, err := resource.ParseQuantity(*logBufferSize)
+	if err != nil || delivery.MaxRetries < 0 || delivery.QueueSize < 0 {
+		logger.Errorf("Malformed logger delivery settings: max retries %d, queue size %d, disk buffer size %s",
+			*logMaxRetries, *logQueueSize, *logBufferSize)
+		os.Exit(-1)
+	}
+	delivery.DiskBufferMaxBytes = bufferSize.Value()
 	logger.Info("Starting the log dispatcher")
-	kfslogger.StartDispatcher(workers, logger)
+	if err := kfslogger.StartDispatcher(workers, sink, delivery, logger); err != nil {
+		logger.Errorf("Failed to start the log dispatcher: %v", err)
+		os.Exit(-1)
+	}
 	return &loggerArgs{
 		loggerType:       loggingMode,
 		logUrl:           logUrlParsed,
@@ -283,19 +398,54 @@ func startLogger(workers int, logger *zap.SugaredLogger) *loggerArgs {
 		endpoint:         *endpoint,
 		namespace:        *namespace,
 		component:        *component,
+		policy: &kfslogger.Policy{
+			SamplingRate:         *logSamplingRate,
+			MaxPayloadBytes:      *logMaxPayload,
+			PayloadOverflow:      payloadOverflow,
+			ContentTypeAllowList: *logContentTypes,
+		},
+		metadataHeaders: *logMetadata,
 	}
 }
 
-func startModelPuller(logger *zap.SugaredLogger) {
+func startModelPuller(logger *zap.SugaredLogger) *status.Tracker {
 	downloader := agent.Downloader{
-		ModelDir:  *modelDir,
-		Providers: map[storage.Protocol]storage.Provider{},
-		Logger:    logger,
+		ModelDir:       *modelDir,
+		Providers:      map[storage.Protocol]storage.Provider{},
+		Logger:         logger,
+		VerifyChecksum: *verifyChecksum,
+		Disk:           agent.NewDiskManager(*modelDir, *reclaimModelStore, logger),
+	}
+	downloader.Disk.UpdateMetrics()
+	if *pullerWorkers < 0 || *pullerRetries < 0 {
+		logger.Errorf("Malformed puller settings: workers %d, max retries %d", *pullerWorkers, *pullerRetries)
+		os.Exit(1)
 	}
+	config := agent.DefaultPullerConfig()
+	config.Workers = *pullerWorkers
+	config.MaxRetries = *pullerRetries
+	config.ModelServerURL = fmt.Sprintf("http://localhost:%d", *componentPort)
+	modelStatuses := status.NewTracker()
 	watcher := agent.NewWatcher(*configDir, *modelDir, logger)
 	logger.Info("Starting puller")
-	agent.StartPullerAndProcessModels(&downloader, watcher.ModelEvents, logger)
+	agent.StartPullerAndProcessModels(&downloader, watcher.ModelEvents, config, modelStatuses, logger)
 	go watcher.Start()
+	return modelStatuses
+}
+
+func buildMetricsServer(port string, componentPort string, componentPath string, modelStatuses *status.Tracker) *http.Server {
+	mux := http.NewServeMux()
+	gatherers := prometheus.Gatherers{agent.MetricsRegistry}
+	if componentPort != "" {
+		// the pod exposes a single prometheus port, the agent serves the metrics of the component along its own
+		componentURL := fmt.Sprintf("http://%s%s", net.JoinHostPort("127.0.0.1", componentPort), componentPath)
+		gatherers = append(gatherers, agent.NewComponentGatherer(componentURL))
+	}
+	mux.Handle("/metrics", promhttp.HandlerFor(gatherers, promhttp.HandlerOpts{ErrorHandling: promhttp.ContinueOnError}))
+	if modelStatuses != nil {
+		mux.Handle(status.Path, modelStatuses)
+	}
+	return pkgnet.NewServer(":"+port, mux)
 }
 
 func buildProbe(logger *zap.SugaredLogger, probeJSON string) *readiness.Probe {
@@ -312,7 +462,7 @@ func buildProbe(logger *zap.SugaredLogger, probeJSON string) *readiness.Probe {
 }
 
 func buildServer(ctx context.Context, port string, userPort int, loggerArgs *loggerArgs, batcherArgs *batcherArgs, // nolint unparam
-	probeContainer func() bool, logging *zap.SugaredLogger) (server *http.Server, drain func()) {
+	tracingArgs *tracingArgs, probeContainer func() bool, logging *zap.SugaredLogger) (server *http.Server, drain func()) {
 	logging.Infof("Building server user port %s port %s", userPort, port)
 	target := &url.URL{
 		Scheme: "http",
@@ -332,11 +482,15 @@ func buildServer(ctx context.Context, port string, userPort int, loggerArgs *log
 	var composedHandler http.Handler = httpProxy
 
 	if batcherArgs != nil {
-		composedHandler = batcher.New(batcherArgs.maxBatchSize, batcherArgs.maxLatency, composedHandler, logging)
+		composedHandler = batcher.New(batcherArgs.maxBatchSize, batcherArgs.maxLatency, batcherArgs.maxInFlight,
+			batcherArgs.maxQueueSize, composedHandler, logging)
 	}
 	if loggerArgs != nil {
 		composedHandler = kfslogger.New(loggerArgs.logUrl, loggerArgs.sourceUrl, loggerArgs.loggerType,
-			loggerArgs.inferenceService, loggerArgs.namespace, loggerArgs.endpoint, loggerArgs.component, composedHandler)
+			loggerArgs.inferenceService, loggerArgs.namespace, loggerArgs.endpoint, loggerArgs.component, loggerArgs.policy, loggerArgs.metadataHeaders, composedHandler)
+	}
+	if tracingArgs != nil {
+		composedHandler = tracing.New(tracingArgs.samplingRatio, composedHandler)
 	}
 
 	composedHandler = queue.ForwardedShimHandler(composedHandler)
@@ -20,6 +20,7 @@ import (
 	"flag"
 	"net/http"
 	"os"
+	"time"
 
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
@@ -33,6 +34,7 @@ import (
 	_ "k8s.io/client-go/plugin/pkg/client/auth/gcp"
 	"k8s.io/client-go/tools/record"
 	knservingv1 "knative.dev/serving/pkg/apis/serving/v1"
+	knservingv1beta1 "knative.dev/serving/pkg/apis/serving/v1beta1"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client/config"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
@@ -40,10 +42,14 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/manager/signals"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	gatewayapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayapiv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 
+	"github.com/kserve/kserve/pkg/activator"
 	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
 	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
 	"github.com/kserve/kserve/pkg/constants"
+	"github.com/kserve/kserve/pkg/controller/metrics"
 	graphcontroller "github.com/kserve/kserve/pkg/controller/v1alpha1/inferencegraph"
 	trainedmodelcontroller "github.com/kserve/kserve/pkg/controller/v1alpha1/trainedmodel"
 	"github.com/kserve/kserve/pkg/controller/v1alpha1/trainedmodel/reconcilers/modelconfig"
@@ -67,6 +73,11 @@ type Options struct {
 	webhookPort          int
 	enableLeaderElection bool
 	probeAddr            string
+	modelReadyTimeout    time.Duration
+	memoryFraction       float64
+	perIsvcMetrics       bool
+	activatorAddr        string
+	activatorTimeout     time.Duration
 	zapOpts              zap.Options
 }
 
@@ -77,6 +88,11 @@ func DefaultOptions() Options {
 		webhookPort:          9443,
 		enableLeaderElection: false,
 		probeAddr:            ":8081",
+		modelReadyTimeout:    trainedmodelcontroller.DefaultModelReadyTimeout,
+		memoryFraction:       trainedmodelcontroller.DefaultMemoryCapacityFraction,
+		perIsvcMetrics:       false,
+		activatorAddr:        "",
+		activatorTimeout:     activator.DefaultTimeout,
 		zapOpts:              zap.Options{},
 	}
 }
@@ -90,6 +106,16 @@ func GetOptions() Options {
 		"Enable leader election for kserve controller manager. "+
 			"Enabling this will ensure there is only one active kserve controller manager.")
 	flag.StringVar(&opts.probeAddr, "health-probe-addr", opts.probeAddr, "The address the probe endpoint binds to.")
+	flag.DurationVar(&opts.modelReadyTimeout, "trainedmodel-ready-timeout", opts.modelReadyTimeout,
+		"The time a TrainedModel has to become ready on the model server before it is marked as not ready.")
+	flag.Float64Var(&opts.memoryFraction, "trainedmodel-memory-fraction", opts.memoryFraction,
+		"The fraction of the memory limit of the predictor container the TrainedModels of an InferenceService may request.")
+	flag.BoolVar(&opts.perIsvcMetrics, "metrics-per-inferenceservice", opts.perIsvcMetrics,
+		"Enable the metrics with a series per InferenceService, their cardinality grows with the number of InferenceServices.")
+	flag.StringVar(&opts.activatorAddr, "activator-addr", opts.activatorAddr,
+		"The address the activator of the raw deployment components scaled to zero binds to, e.g. :8082. The activator is disabled when empty.")
+	flag.DurationVar(&opts.activatorTimeout, "activator-timeout", opts.activatorTimeout,
+		"The time the activator holds a request while the component is scaled up from zero.")
 	opts.zapOpts.BindFlags(flag.CommandLine)
 	flag.Parse()
 	return opts
@@ -104,6 +130,9 @@ func init() {
 func main() {
 	options := GetOptions()
 	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&options.zapOpts)))
+	if options.perIsvcMetrics {
+		metrics.EnablePerInferenceServiceMetrics()
+	}
 
 	// Get a config to talk to the apiserver
 	setupLog.Info("Setting up client for manager")
@@ -172,6 +201,10 @@ func main() {
 			setupLog.Error(err, "unable to add Knative APIs to scheme")
 			os.Exit(1)
 		}
+		if err := knservingv1beta1.AddToScheme(mgr.GetScheme()); err != nil {
+			setupLog.Error(err, "unable to add Knative v1beta1 APIs to scheme")
+			os.Exit(1)
+		}
 	}
 	if !ingressConfig.DisableIstioVirtualHost {
 		vsFound, vsCheckErr := utils.IsCrdAvailable(cfg, istioclientv1beta1.SchemeGroupVersion.String(), constants.IstioVirtualServiceKind)
@@ -188,6 +221,18 @@ func main() {
 		}
 	}
 
+	if ingressConfig.EnableGatewayAPI {
+		setupLog.Info("Setting up Gateway API scheme")
+		if err := gatewayapiv1.AddToScheme(mgr.GetScheme()); err != nil {
+			setupLog.Error(err, "unable to add Gateway APIs to scheme")
+			os.Exit(1)
+		}
+		if err := gatewayapiv1alpha2.AddToScheme(mgr.GetScheme()); err != nil {
+			setupLog.Error(err, "unable to add Gateway v1alpha2 APIs to scheme")
+			os.Exit(1)
+		}
+	}
+
 	setupLog.Info("Setting up core scheme")
 	if err := v1.AddToScheme(mgr.GetScheme()); err != nil {
 		setupLog.Error(err, "unable to add Core APIs to scheme")
@@ -215,11 +260,14 @@ func main() {
 	setupLog.Info("Setting up v1beta1 TrainedModel controller")
 	trainedModelEventBroadcaster.StartRecordingToSink(&typedcorev1.EventSinkImpl{Interface: clientSet.CoreV1().Events("")})
 	if err = (&trainedmodelcontroller.TrainedModelReconciler{
-		Client:                mgr.GetClient(),
-		Log:                   ctrl.Log.WithName("v1beta1Controllers").WithName("TrainedModel"),
-		Scheme:                mgr.GetScheme(),
-		Recorder:              eventBroadcaster.NewRecorder(mgr.GetScheme(), v1.EventSource{Component: "v1beta1Controllers"}),
-		ModelConfigReconciler: modelconfig.NewModelConfigReconciler(mgr.GetClient(), clientSet, mgr.GetScheme()),
+		Client:                 mgr.GetClient(),
+		Log:                    ctrl.Log.WithName("v1beta1Controllers").WithName("TrainedModel"),
+		Scheme:                 mgr.GetScheme(),
+		Recorder:               eventBroadcaster.NewRecorder(mgr.GetScheme(), v1.EventSource{Component: "v1beta1Controllers"}),
+		ModelConfigReconciler:  modelconfig.NewModelConfigReconciler(mgr.GetClient(), clientSet, mgr.GetScheme()),
+		ModelStatusReader:      trainedmodelcontroller.NewAgentModelStatusReader(mgr.GetClient()),
+		ModelReadyProber:       trainedmodelcontroller.NewModelReadyProber(options.modelReadyTimeout),
+		MemoryCapacityFraction: options.memoryFraction,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "v1beta1Controllers", "TrainedModel")
 		os.Exit(1)
@@ -248,10 +296,10 @@ func main() {
 		Handler: &pod.Mutator{Client: mgr.GetClient(), Clientset: clientSet, Decoder: admission.NewDecoder(mgr.GetScheme())},
 	})
 
-	// log.Info("registering cluster serving runtime validator webhook to the webhook server")
-	// hookServer.Register("/validate-serving-kserve-io-v1alpha1-clusterservingruntime", &webhook.Admission{
-	// 	Handler: &servingruntime.ClusterServingRuntimeValidator{Client: mgr.GetClient(), Decoder: admission.NewDecoder(mgr.GetScheme())},
-	// })
+	setupLog.Info("registering cluster serving runtime validator webhook to the webhook server")
+	hookServer.Register("/validate-serving-kserve-io-v1alpha1-clusterservingruntime", &webhook.Admission{
+		Handler: &servingruntime.ClusterServingRuntimeValidator{Client: mgr.GetClient(), Decoder: admission.NewDecoder(mgr.GetScheme())},
+	})
 
 	setupLog.Info("registering serving runtime validator webhook to the webhook server")
 	hookServer.Register("/validate-serving-kserve-io-v1alpha1-servingruntime", &webhook.Admission{
@@ -274,11 +322,23 @@ func main() {
 
 	if err = ctrl.NewWebhookManagedBy(mgr).
 		For(&v1beta1.InferenceService{}).
+		WithValidator(&v1beta1.InferenceServiceValidator{Client: mgr.GetAPIReader()}).
 		Complete(); err != nil {
 		setupLog.Error(err, "unable to create webhook", "webhook", "v1beta1")
 		os.Exit(1)
 	}
 
+	if options.activatorAddr != "" {
+		setupLog.Info("Setting up activator", "addr", options.activatorAddr)
+		if err = mgr.Add(&activator.Server{
+			Addr:    options.activatorAddr,
+			Handler: activator.New(clientSet, mgr.GetClient(), options.activatorTimeout),
+		}); err != nil {
+			setupLog.Error(err, "unable to add activator")
+			os.Exit(1)
+		}
+	}
+
 	if err := mgr.AddHealthzCheck("healthz", func(req *http.Request) error {
 		return mgr.GetWebhookServer().StartedChecker()(req)
 	}); err != nil {
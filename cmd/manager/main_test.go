@@ -20,6 +20,7 @@ import (
 	"flag"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
@@ -42,6 +43,11 @@ func TestGetOptions(t *testing.T) {
 				webhookPort:          8000,
 				enableLeaderElection: defaults.enableLeaderElection,
 				probeAddr:            defaults.probeAddr,
+				modelReadyTimeout:    defaults.modelReadyTimeout,
+				memoryFraction:       defaults.memoryFraction,
+				perIsvcMetrics:       defaults.perIsvcMetrics,
+				activatorAddr:        defaults.activatorAddr,
+				activatorTimeout:     defaults.activatorTimeout,
 				zapOpts:              defaults.zapOpts,
 			}},
 		{"withMetricsAddr", []string{"-metrics-addr=:9090"},
@@ -50,6 +56,11 @@ func TestGetOptions(t *testing.T) {
 				webhookPort:          defaults.webhookPort,
 				enableLeaderElection: defaults.enableLeaderElection,
 				probeAddr:            defaults.probeAddr,
+				modelReadyTimeout:    defaults.modelReadyTimeout,
+				memoryFraction:       defaults.memoryFraction,
+				perIsvcMetrics:       defaults.perIsvcMetrics,
+				activatorAddr:        defaults.activatorAddr,
+				activatorTimeout:     defaults.activatorTimeout,
 				zapOpts:              defaults.zapOpts,
 			}},
 		{"withEnableLeaderElection", []string{"-leader-elect=true"},
@@ -58,6 +69,11 @@ func TestGetOptions(t *testing.T) {
 				webhookPort:          defaults.webhookPort,
 				enableLeaderElection: true,
 				probeAddr:            defaults.probeAddr,
+				modelReadyTimeout:    defaults.modelReadyTimeout,
+				memoryFraction:       defaults.memoryFraction,
+				perIsvcMetrics:       defaults.perIsvcMetrics,
+				activatorAddr:        defaults.activatorAddr,
+				activatorTimeout:     defaults.activatorTimeout,
 				zapOpts:              defaults.zapOpts,
 			}},
 		{"withHealthProbeAddr", []string{"-health-probe-addr=:8090"},
@@ -66,6 +82,63 @@ func TestGetOptions(t *testing.T) {
 				webhookPort:          defaults.webhookPort,
 				enableLeaderElection: defaults.enableLeaderElection,
 				probeAddr:            ":8090",
+				modelReadyTimeout:    defaults.modelReadyTimeout,
+				memoryFraction:       defaults.memoryFraction,
+				perIsvcMetrics:       defaults.perIsvcMetrics,
+				activatorAddr:        defaults.activatorAddr,
+				activatorTimeout:     defaults.activatorTimeout,
+				zapOpts:              defaults.zapOpts,
+			}},
+		{"withModelReadyTimeout", []string{"-trainedmodel-ready-timeout=1m"},
+			Options{
+				metricsAddr:          defaults.metricsAddr,
+				webhookPort:          defaults.webhookPort,
+				enableLeaderElection: defaults.enableLeaderElection,
+				probeAddr:            defaults.probeAddr,
+				modelReadyTimeout:    time.Minute,
+				memoryFraction:       defaults.memoryFraction,
+				perIsvcMetrics:       defaults.perIsvcMetrics,
+				activatorAddr:        defaults.activatorAddr,
+				activatorTimeout:     defaults.activatorTimeout,
+				zapOpts:              defaults.zapOpts,
+			}},
+		{"withMemoryFraction", []string{"-trainedmodel-memory-fraction=0.8"},
+			Options{
+				metricsAddr:          defaults.metricsAddr,
+				webhookPort:          defaults.webhookPort,
+				enableLeaderElection: defaults.enableLeaderElection,
+				probeAddr:            defaults.probeAddr,
+				modelReadyTimeout:    defaults.modelReadyTimeout,
+				memoryFraction:       0.8,
+				perIsvcMetrics:       defaults.perIsvcMetrics,
+				activatorAddr:        defaults.activatorAddr,
+				activatorTimeout:     defaults.activatorTimeout,
+				zapOpts:              defaults.zapOpts,
+			}},
+		{"withPerIsvcMetrics", []string{"-metrics-per-inferenceservice=true"},
+			Options{
+				metricsAddr:          defaults.metricsAddr,
+				webhookPort:          defaults.webhookPort,
+				enableLeaderElection: defaults.enableLeaderElection,
+				probeAddr:            defaults.probeAddr,
+				modelReadyTimeout:    defaults.modelReadyTimeout,
+				memoryFraction:       defaults.memoryFraction,
+				perIsvcMetrics:       true,
+				activatorAddr:        defaults.activatorAddr,
+				activatorTimeout:     defaults.activatorTimeout,
+				zapOpts:              defaults.zapOpts,
+			}},
+		{"withActivator", []string{"-activator-addr=:9082", "-activator-timeout=10m"},
+			Options{
+				metricsAddr:          defaults.metricsAddr,
+				webhookPort:          defaults.webhookPort,
+				enableLeaderElection: defaults.enableLeaderElection,
+				probeAddr:            defaults.probeAddr,
+				modelReadyTimeout:    defaults.modelReadyTimeout,
+				memoryFraction:       defaults.memoryFraction,
+				perIsvcMetrics:       defaults.perIsvcMetrics,
+				activatorAddr:        ":9082",
+				activatorTimeout:     10 * time.Minute,
 				zapOpts:              defaults.zapOpts,
 			}},
 		{"withZapFlags", []string{"-zap-devel"},
@@ -74,6 +147,11 @@ func TestGetOptions(t *testing.T) {
 				webhookPort:          defaults.webhookPort,
 				enableLeaderElection: defaults.enableLeaderElection,
 				probeAddr:            defaults.probeAddr,
+				modelReadyTimeout:    defaults.modelReadyTimeout,
+				memoryFraction:       defaults.memoryFraction,
+				perIsvcMetrics:       defaults.perIsvcMetrics,
+				activatorAddr:        defaults.activatorAddr,
+				activatorTimeout:     defaults.activatorTimeout,
 				zapOpts: zap.Options{
 					Development: true,
 				},
@@ -84,6 +162,11 @@ func TestGetOptions(t *testing.T) {
 				webhookPort:          8000,
 				enableLeaderElection: true,
 				probeAddr:            defaults.probeAddr,
+				modelReadyTimeout:    defaults.modelReadyTimeout,
+				memoryFraction:       defaults.memoryFraction,
+				perIsvcMetrics:       defaults.perIsvcMetrics,
+				activatorAddr:        defaults.activatorAddr,
+				activatorTimeout:     defaults.activatorTimeout,
 				zapOpts:              defaults.zapOpts,
 			}},
 		{"withAll", []string{"-metrics-addr=:9090", "-webhook-port=8000", "-leader-elect=true", "-health-probe-addr=:8080", "-zap-devel"},
@@ -92,6 +175,11 @@ func TestGetOptions(t *testing.T) {
 				webhookPort:          8000,
 				enableLeaderElection: true,
 				probeAddr:            ":8080",
+				modelReadyTimeout:    defaults.modelReadyTimeout,
+				memoryFraction:       defaults.memoryFraction,
+				perIsvcMetrics:       defaults.perIsvcMetrics,
+				activatorAddr:        defaults.activatorAddr,
+				activatorTimeout:     defaults.activatorTimeout,
 				zapOpts: zap.Options{
 					Development: true,
 				},
@@ -0,0 +1,78 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+// tokenRefreshInterval is how long a service account token read from the projected volume is reused, the kubelet
+// rotates the tokens well before they expire.
+const tokenRefreshInterval = time.Minute
+
+// serviceAccountTokensDir is the directory the service account tokens of the step audiences are projected into
+var serviceAccountTokensDir = constants.RouterServiceAccountTokensMountPath
+
+type serviceAccountToken struct {
+	value  string
+	readAt time.Time
+}
+
+// serviceAccountTokenCache keeps the last read service account token of every audience
+type serviceAccountTokenCache struct {
+	mu     sync.Mutex
+	tokens map[string]serviceAccountToken
+}
+
+var serviceAccountTokens = &serviceAccountTokenCache{tokens: map[string]serviceAccountToken{}}
+
+func (c *serviceAccountTokenCache) get(audience string) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if token, ok := c.tokens[audience]; ok && now().Sub(token.readAt) < tokenRefreshInterval {
+		return token.value, nil
+	}
+	content, err := os.ReadFile(filepath.Join(serviceAccountTokensDir, constants.RouterServiceAccountTokenFileName(audience)))
+	if err != nil {
+		return "", err
+	}
+	value := strings.TrimSpace(string(content))
+	c.tokens[audience] = serviceAccountToken{value: value, readAt: now()}
+	return value, nil
+}
+
+// stepAuthHeaders returns the Authorization header carrying the service account token of the step audience, the
+// token replaces any propagated Authorization header of the request.
+func stepAuthHeaders(step *v1alpha1.InferenceStep) (http.Header, error) {
+	if step.TokenAudience == "" {
+		return nil, nil
+	}
+	token, err := serviceAccountTokens.get(step.TokenAudience)
+	if err != nil {
+		log.Error(err, "Failed to read the service account token of the step", "stepName", stepLabel(step), "audience", step.TokenAudience)
+		return nil, err
+	}
+	return http.Header{"Authorization": {"Bearer " + token}}, nil
+}
@@ -0,0 +1,153 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+// newHeaderEchoModel responds with the headers of the request of the router
+func newHeaderEchoModel(t *testing.T) *httptest.Server {
+	model := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		response, _ := json.Marshal(req.Header)
+		_, _ = rw.Write(response)
+	}))
+	t.Cleanup(model.Close)
+	return model
+}
+
+func writeServiceAccountTokens(t *testing.T, tokens map[string]string) {
+	dir := t.TempDir()
+	for audience, token := range tokens {
+		assert.Nil(t, os.WriteFile(filepath.Join(dir, constants.RouterServiceAccountTokenFileName(audience)), []byte(token+"\n"), 0600))
+	}
+	serviceAccountTokensDir = dir
+	serviceAccountTokens = &serviceAccountTokenCache{tokens: map[string]serviceAccountToken{}}
+	t.Cleanup(func() {
+		serviceAccountTokensDir = constants.RouterServiceAccountTokensMountPath
+		serviceAccountTokens = &serviceAccountTokenCache{tokens: map[string]serviceAccountToken{}}
+	})
+}
+
+func routeEchoedHeaders(t *testing.T, graph v1alpha1.InferenceGraphSpec, headers http.Header) map[string]http.Header {
+	response, statusCode, err := routeStep(context.Background(), v1alpha1.GraphRootNodeName, graph, []byte(`{"instances": [1]}`), headers)
+	assert.Nil(t, err)
+	assert.Equal(t, 200, statusCode)
+	echoed := map[string]http.Header{}
+	assert.Nil(t, json.Unmarshal(response, &echoed))
+	return echoed
+}
+
+func TestStepServiceAccountTokens(t *testing.T) {
+	writeServiceAccountTokens(t, map[string]string{"model-a": "token-a", "model-b": "token-b"})
+	compiledHeaderPatterns = []*regexp.Regexp{regexp.MustCompile("Authorization")}
+	defer func() { compiledHeaderPatterns = nil }()
+
+	model := newHeaderEchoModel(t)
+	graph := v1alpha1.InferenceGraphSpec{
+		Nodes: map[string]v1alpha1.InferenceRouter{
+			v1alpha1.GraphRootNodeName: {
+				RouterType: v1alpha1.Ensemble,
+				Steps: []v1alpha1.InferenceStep{
+					{StepName: "a", InferenceTarget: v1alpha1.InferenceTarget{ServiceURL: model.URL}, TokenAudience: "model-a"},
+					{StepName: "b", InferenceTarget: v1alpha1.InferenceTarget{ServiceURL: model.URL}, TokenAudience: "model-b"},
+					{StepName: "c", InferenceTarget: v1alpha1.InferenceTarget{ServiceURL: model.URL}},
+				},
+			},
+		},
+	}
+	echoed := routeEchoedHeaders(t, graph, http.Header{"Authorization": {"Bearer client-token"}})
+	assert.Equal(t, "Bearer token-a", echoed["a"].Get("Authorization"))
+	assert.Equal(t, []string{"Bearer token-b"}, echoed["b"].Values("Authorization"))
+	// a step without an audience receives the propagated header of the client
+	assert.Equal(t, "Bearer client-token", echoed["c"].Get("Authorization"))
+}
+
+func TestStepServiceAccountTokenRefresh(t *testing.T) {
+	writeServiceAccountTokens(t, map[string]string{"model-refresh": "first"})
+	current := time.Now()
+	now = func() time.Time { return current }
+	defer func() { now = time.Now }()
+
+	step := &v1alpha1.InferenceStep{TokenAudience: "model-refresh"}
+	headers, err := stepAuthHeaders(step)
+	assert.Nil(t, err)
+	assert.Equal(t, "Bearer first", headers.Get("Authorization"))
+
+	// the rotated token is picked up once the read token is older than the refresh interval
+	assert.Nil(t, os.WriteFile(filepath.Join(serviceAccountTokensDir, constants.RouterServiceAccountTokenFileName("model-refresh")), []byte("second"), 0600))
+	headers, _ = stepAuthHeaders(step)
+	assert.Equal(t, "Bearer first", headers.Get("Authorization"))
+	current = current.Add(tokenRefreshInterval)
+	headers, _ = stepAuthHeaders(step)
+	assert.Equal(t, "Bearer second", headers.Get("Authorization"))
+}
+
+func TestStepServiceAccountTokenMissing(t *testing.T) {
+	writeServiceAccountTokens(t, map[string]string{})
+	model := newHeaderEchoModel(t)
+	graph := makeStepGraph("auth-missing-token", v1alpha1.InferenceStep{
+		StepName:        "step",
+		InferenceTarget: v1alpha1.InferenceTarget{ServiceURL: model.URL},
+		TokenAudience:   "unknown",
+	})
+	_, statusCode, err := routeStep(context.Background(), "auth-missing-token", graph, []byte(`{"instances": [1]}`), http.Header{})
+	assert.NotNil(t, err)
+	assert.Equal(t, 500, statusCode)
+}
+
+func TestGraphPropagateHeaders(t *testing.T) {
+	compiledHeaderPatterns = nil
+	defer func() { compiledHeaderPatterns = nil }()
+	model := newHeaderEchoModel(t)
+	graph := v1alpha1.InferenceGraphSpec{
+		Nodes: map[string]v1alpha1.InferenceRouter{
+			v1alpha1.GraphRootNodeName: {
+				RouterType: v1alpha1.Ensemble,
+				Steps: []v1alpha1.InferenceStep{
+					{StepName: "step", InferenceTarget: v1alpha1.InferenceTarget{ServiceURL: model.URL}},
+				},
+			},
+		},
+		PropagateHeaders: []string{"X-Request-Id", "X-B3-.*"},
+	}
+	addGraphHeaderPatterns(&graph)
+
+	echoed := routeEchoedHeaders(t, graph, http.Header{
+		"X-Request-Id":    {"1234"},
+		"X-B3-Traceid":    {"abcd"},
+		"Authorization":   {"Bearer client-token"},
+		"X-Internal-Only": {"secret"},
+	})
+	assert.Equal(t, "1234", echoed["step"].Get("X-Request-Id"))
+	assert.Equal(t, "abcd", echoed["step"].Get("X-B3-Traceid"))
+	assert.Empty(t, echoed["step"].Get("Authorization"))
+	assert.Empty(t, echoed["step"].Get("X-Internal-Only"))
+}
@@ -0,0 +1,300 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/tidwall/gjson"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+)
+
+const (
+	defaultCacheMaxEntries = 1000
+	// cacheHitsHeader lists the steps, as node/step, whose responses were served from the cache
+	cacheHitsHeader = "X-Kserve-Cache-Hits"
+	cacheKeyPrefix  = "kserve-router:"
+)
+
+// cachedResponse is a successful step response kept in the cache
+type cachedResponse struct {
+	StatusCode int    `json:"statusCode"`
+	Body       []byte `json:"body"`
+}
+
+// cacheBackend stores the cached responses, a missing or expired entry is reported as not found
+type cacheBackend interface {
+	get(ctx context.Context, key string) (*cachedResponse, bool, error)
+	set(ctx context.Context, key string, response *cachedResponse, ttl time.Duration) error
+}
+
+type memoryCacheEntry struct {
+	response *cachedResponse
+	expires  time.Time
+}
+
+// memoryCache keeps the least recently used responses of a step in the memory of the router
+type memoryCache struct {
+	entries *lru.Cache
+}
+
+func newMemoryCache(maxEntries int) *memoryCache {
+	// lru.New only fails for a non positive size, which the validation webhook rejects
+	entries, err := lru.New(maxEntries)
+	if err != nil {
+		entries, _ = lru.New(defaultCacheMaxEntries)
+	}
+	return &memoryCache{entries: entries}
+}
+
+func (c *memoryCache) get(_ context.Context, key string) (*cachedResponse, bool, error) {
+	value, ok := c.entries.Get(key)
+	if !ok {
+		return nil, false, nil
+	}
+	entry := value.(memoryCacheEntry)
+	if !now().Before(entry.expires) {
+		c.entries.Remove(key)
+		return nil, false, nil
+	}
+	return entry.response, true, nil
+}
+
+func (c *memoryCache) set(_ context.Context, key string, response *cachedResponse, ttl time.Duration) error {
+	c.entries.Add(key, memoryCacheEntry{response: response, expires: now().Add(ttl)})
+	return nil
+}
+
+// stepCache serves the responses of a step to identical requests
+type stepCache struct {
+	nodeName  string
+	stepName  string
+	target    string
+	ttl       time.Duration
+	keyFields []string
+	backend   cacheBackend
+}
+
+// key returns the cache key of the step input and of the headers propagated to the step, an empty key for a nil
+// cache. The propagated headers are part of the key so that a response is only served to the callers sending the
+// same credentials, e.g. the same Authorization header, as the caller the step responded to.
+func (c *stepCache) key(input []byte, headers http.Header) string {
+	if c == nil {
+		return ""
+	}
+	hash := sha256.New()
+	for _, part := range []string{c.nodeName, c.stepName, c.target} {
+		hash.Write([]byte(part))
+		hash.Write([]byte{0})
+	}
+	hash.Write(cacheKeyMaterial(input, c.keyFields))
+	hash.Write([]byte{0})
+	hash.Write(cacheKeyHeaders(headers))
+	return cacheKeyPrefix + hex.EncodeToString(hash.Sum(nil))
+}
+
+// cacheKeyHeaders returns the names and the values of the headers in a canonical form, except the tracing and
+// request id headers which differ for every request
+func cacheKeyHeaders(headers http.Header) []byte {
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		if !isPerRequestHeader(name) {
+			names = append(names, http.CanonicalHeaderKey(name))
+		}
+	}
+	sort.Strings(names)
+	material, _ := json.Marshal(names)
+	for _, name := range names {
+		values, _ := json.Marshal(headers.Values(name))
+		material = append(material, values...)
+	}
+	return material
+}
+
+// isPerRequestHeader returns whether the header identifies the request or its trace rather than the caller
+func isPerRequestHeader(name string) bool {
+	name = strings.ToLower(name)
+	switch {
+	case name == "traceparent", name == "tracestate", name == "x-request-id", strings.HasPrefix(name, "x-b3-"):
+		return true
+	}
+	return strings.Contains(name, "trace-id") || strings.Contains(name, "span-id")
+}
+
+// load returns the cached response of the key, a nil cache never has one
+func (c *stepCache) load(ctx context.Context, key string) (*cachedResponse, bool) {
+	if c == nil {
+		return nil, false
+	}
+	response, ok, err := c.backend.get(ctx, key)
+	switch {
+	case err != nil:
+		log.Error(err, "failed to read the step cache, calling the step", "node", c.nodeName, "stepName", c.stepName)
+		stepCacheRequests.WithLabelValues(c.nodeName, c.stepName, cacheResultError).Inc()
+	case ok:
+		stepCacheRequests.WithLabelValues(c.nodeName, c.stepName, cacheResultHit).Inc()
+	default:
+		stepCacheRequests.WithLabelValues(c.nodeName, c.stepName, cacheResultMiss).Inc()
+	}
+	return response, ok
+}
+
+// store caches a successful response of the step, other responses are ignored
+func (c *stepCache) store(ctx context.Context, key string, statusCode int, body []byte) {
+	if c == nil || !isSuccessFul(statusCode) {
+		return
+	}
+	if err := c.backend.set(ctx, key, &cachedResponse{StatusCode: statusCode, Body: body}, c.ttl); err != nil {
+		log.Error(err, "failed to write the step cache", "node", c.nodeName, "stepName", c.stepName)
+		stepCacheRequests.WithLabelValues(c.nodeName, c.stepName, cacheResultError).Inc()
+	}
+}
+
+// cacheKeyMaterial returns the part of the input the cache key is computed from. The keyFields are
+// gjson paths, without them the whole request is used except its id which differs for every request.
+func cacheKeyMaterial(input []byte, keyFields []string) []byte {
+	if len(keyFields) > 0 {
+		values := make([]json.RawMessage, len(keyFields))
+		for i, field := range keyFields {
+			result := gjson.GetBytes(input, field)
+			if result.Exists() {
+				values[i] = canonicalJSON([]byte(result.Raw))
+			} else {
+				values[i] = json.RawMessage("null")
+			}
+		}
+		material, _ := json.Marshal(values)
+		return material
+	}
+	var request map[string]interface{}
+	if err := unmarshalJSONNumbers(input, &request); err != nil {
+		return input
+	}
+	delete(request, "id")
+	material, err := json.Marshal(request)
+	if err != nil {
+		return input
+	}
+	return material
+}
+
+// canonicalJSON re-encodes a json value so that formatting and the order of object keys do not change the key
+func canonicalJSON(raw []byte) []byte {
+	var value interface{}
+	if err := unmarshalJSONNumbers(raw, &value); err != nil {
+		return raw
+	}
+	canonical, err := json.Marshal(value)
+	if err != nil {
+		return raw
+	}
+	return canonical
+}
+
+// unmarshalJSONNumbers decodes the numbers as json.Number, so that they are re-encoded as they were sent.
+// Decoding them as float64 would give integers above 2^53 which differ in their last digits the same key.
+func unmarshalJSONNumbers(data []byte, value interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(value); err != nil {
+		return err
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return errors.New("unexpected data after the json value")
+	}
+	return nil
+}
+
+// stepCacheRegistry holds the caches of the steps, keyed by node and step. The steps share the
+// redis backend when the graph configures one and get their own in memory cache otherwise.
+type stepCacheRegistry struct {
+	mu     sync.Mutex
+	caches map[string]*stepCache
+	redis  cacheBackend
+}
+
+var stepCaches = &stepCacheRegistry{caches: map[string]*stepCache{}}
+
+// get returns the cache of the step, or nil when the step does not define one
+func (r *stepCacheRegistry) get(nodeName string, stepName string, step *v1alpha1.InferenceStep) *stepCache {
+	if step.Cache == nil {
+		return nil
+	}
+	key := nodeName + "/" + stepName
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	cache, ok := r.caches[key]
+	if !ok {
+		cache = &stepCache{
+			nodeName:  nodeName,
+			stepName:  stepName,
+			target:    strings.Join([]string{string(step.Protocol), step.NodeName, step.ServiceName, step.ServiceURL}, "|"),
+			ttl:       time.Duration(step.Cache.TTLSeconds) * time.Second,
+			keyFields: step.Cache.KeyFields,
+			backend:   r.redis,
+		}
+		if cache.backend == nil {
+			maxEntries := defaultCacheMaxEntries
+			if step.Cache.MaxEntries != nil {
+				maxEntries = int(*step.Cache.MaxEntries)
+			}
+			cache.backend = newMemoryCache(maxEntries)
+		}
+		r.caches[key] = cache
+	}
+	return cache
+}
+
+type cacheHitsKey struct{}
+
+// cacheHits collects the steps of a request served from the cache, the steps of an ensemble run concurrently
+type cacheHits struct {
+	mu    sync.Mutex
+	steps []string
+}
+
+func withCacheHits(ctx context.Context) (context.Context, *cacheHits) {
+	hits := &cacheHits{}
+	return context.WithValue(ctx, cacheHitsKey{}, hits), hits
+}
+
+func recordCacheHit(ctx context.Context, nodeName string, stepName string) {
+	if hits, ok := ctx.Value(cacheHitsKey{}).(*cacheHits); ok {
+		hits.mu.Lock()
+		defer hits.mu.Unlock()
+		hits.steps = append(hits.steps, nodeName+"/"+stepName)
+	}
+}
+
+func (h *cacheHits) header() string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return strings.Join(h.steps, ",")
+}
@@ -0,0 +1,332 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+)
+
+func TestStepCacheTTL(t *testing.T) {
+	defer func() { now = time.Now }()
+	current := time.Now()
+	now = func() time.Time { return current }
+
+	model, calls := newFlakyModel(t, 0)
+	graphSpec := makeStepGraph("cache-ttl", v1alpha1.InferenceStep{
+		StepName:        "model1",
+		InferenceTarget: v1alpha1.InferenceTarget{ServiceURL: model.URL},
+		Cache:           &v1alpha1.InferenceStepCache{TTLSeconds: 10},
+	})
+	hits := testutil.ToFloat64(stepCacheRequests.WithLabelValues("cache-ttl", "model1", cacheResultHit))
+	call := func(input string) {
+		res, statusCode, err := routeStep(context.Background(), "cache-ttl", graphSpec, []byte(input), http.Header{})
+		assert.Nil(t, err)
+		assert.Equal(t, 200, statusCode)
+		assert.JSONEq(t, `{"predictions": "1"}`, string(res))
+	}
+
+	call(`{"id": "1", "instances": [1]}`)
+	current = current.Add(9 * time.Second)
+	// the id of the request is not part of the cache key
+	call(`{"id": "2", "instances": [1]}`)
+	assert.Equal(t, int32(1), atomic.LoadInt32(calls))
+	assert.Equal(t, float64(1), testutil.ToFloat64(stepCacheRequests.WithLabelValues("cache-ttl", "model1", cacheResultHit))-hits)
+
+	// the cached response expires after the ttl
+	current = current.Add(time.Second)
+	call(`{"id": "3", "instances": [1]}`)
+	assert.Equal(t, int32(2), atomic.LoadInt32(calls))
+}
+
+func TestStepCacheDoesNotStoreFailures(t *testing.T) {
+	model, calls := newFlakyModel(t, 1)
+	graphSpec := makeStepGraph("cache-failures", v1alpha1.InferenceStep{
+		StepName:        "model1",
+		InferenceTarget: v1alpha1.InferenceTarget{ServiceURL: model.URL},
+		Cache:           &v1alpha1.InferenceStepCache{TTLSeconds: 10},
+	})
+	for _, expectedStatusCode := range []int{503, 200, 200} {
+		_, statusCode, _ := routeStep(context.Background(), "cache-failures", graphSpec, []byte(`{}`), http.Header{})
+		assert.Equal(t, expectedStatusCode, statusCode)
+	}
+	assert.Equal(t, int32(2), atomic.LoadInt32(calls))
+}
+
+func TestCacheKeyMaterial(t *testing.T) {
+	scenarios := map[string]struct {
+		keyFields []string
+		input1    string
+		input2    string
+		sameKey   bool
+	}{
+		"request id": {
+			input1:  `{"id": "1", "inputs": [{"name": "x", "data": [1]}]}`,
+			input2:  `{"id": "2", "inputs": [{"name": "x", "data": [1]}]}`,
+			sameKey: true,
+		},
+		"formatting and key order": {
+			input1:  `{"inputs": [{"name": "x", "data": [1]}], "parameters": {"a": 1, "b": 2}}`,
+			input2:  `{"parameters":{"b":2,"a":1},"inputs":[{"data":[1],"name":"x"}]}`,
+			sameKey: true,
+		},
+		"different data": {
+			input1:  `{"inputs": [{"name": "x", "data": [1]}]}`,
+			input2:  `{"inputs": [{"name": "x", "data": [2]}]}`,
+			sameKey: false,
+		},
+		"key fields ignore the other fields": {
+			keyFields: []string{"inputs.#.data"},
+			input1:    `{"inputs": [{"name": "x", "data": [1]}], "parameters": {"trace": "a"}}`,
+			input2:    `{"inputs": [{"name": "y", "data": [1]}], "parameters": {"trace": "b"}}`,
+			sameKey:   true,
+		},
+		"key fields with different values": {
+			keyFields: []string{"inputs.#.data", "parameters.top_k"},
+			input1:    `{"inputs": [{"data": [1]}], "parameters": {"top_k": 1}}`,
+			input2:    `{"inputs": [{"data": [1]}], "parameters": {"top_k": 2}}`,
+			sameKey:   false,
+		},
+		"large integers": {
+			input1:  `{"inputs": [{"name": "x", "data": [12345678901234567890]}]}`,
+			input2:  `{"inputs": [{"name": "x", "data": [12345678901234567891]}]}`,
+			sameKey: false,
+		},
+		"key fields with large integers": {
+			keyFields: []string{"parameters.seed"},
+			input1:    `{"parameters": {"seed": 9007199254740993}}`,
+			input2:    `{"parameters": {"seed": 9007199254740992}}`,
+			sameKey:   false,
+		},
+		"missing key field": {
+			keyFields: []string{"inputs.#.data", "parameters.top_k"},
+			input1:    `{"inputs": [{"data": [1]}]}`,
+			input2:    `{"inputs": [{"data": [1]}], "parameters": {"top_k": null}}`,
+			sameKey:   true,
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			cache := &stepCache{nodeName: "node", stepName: "step", keyFields: scenario.keyFields}
+			key1 := cache.key([]byte(scenario.input1), nil)
+			key2 := cache.key([]byte(scenario.input2), nil)
+			assert.Equal(t, scenario.sameKey, key1 == key2)
+		})
+	}
+}
+
+func TestCacheKeyHeaders(t *testing.T) {
+	cache := &stepCache{nodeName: "node", stepName: "step"}
+	input := []byte(`{"instances": [1]}`)
+	alice := http.Header{"Authorization": {"Bearer alice"}, "Traceparent": {"00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01"}}
+	aliceAgain := http.Header{"Authorization": {"Bearer alice"}, "Traceparent": {"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"}}
+	bob := http.Header{"Authorization": {"Bearer bob"}}
+
+	assert.Equal(t, cache.key(input, alice), cache.key(input, aliceAgain))
+	assert.NotEqual(t, cache.key(input, alice), cache.key(input, bob))
+	assert.NotEqual(t, cache.key(input, bob), cache.key(input, nil))
+}
+
+func TestStepCacheIsPerCaller(t *testing.T) {
+	calls := new(int32)
+	model := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		atomic.AddInt32(calls, 1)
+		if req.Header.Get("Authorization") != "Bearer alice" {
+			rw.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = rw.Write([]byte(`{"predictions": "1"}`))
+	}))
+	defer model.Close()
+	inferenceGraph = &v1alpha1.InferenceGraphSpec{
+		Nodes: map[string]v1alpha1.InferenceRouter{
+			v1alpha1.GraphRootNodeName: {
+				RouterType: v1alpha1.Sequence,
+				Steps: []v1alpha1.InferenceStep{
+					{
+						StepName:        "per-caller-model",
+						InferenceTarget: v1alpha1.InferenceTarget{ServiceURL: model.URL},
+						Cache:           &v1alpha1.InferenceStepCache{TTLSeconds: 60},
+					},
+				},
+			},
+		},
+	}
+	compiledHeaderPatterns = []*regexp.Regexp{regexp.MustCompile("Authorization")}
+	defer func() {
+		inferenceGraph = nil
+		compiledHeaderPatterns = nil
+	}()
+
+	send := func(token string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"instances": [1]}`))
+		req.Header.Set("Authorization", "Bearer "+token)
+		graphHandler(recorder, req)
+		return recorder
+	}
+	assert.Equal(t, 200, send("alice").Code)
+	// the response cached for alice is not served to bob, the step rejects his token
+	assert.Equal(t, http.StatusUnauthorized, send("bob").Code)
+	recorder := send("alice")
+	assert.Equal(t, 200, recorder.Code)
+	assert.Equal(t, "root/per-caller-model", recorder.Header().Get(cacheHitsHeader))
+	assert.Equal(t, int32(2), atomic.LoadInt32(calls))
+}
+
+func TestStepCacheRedis(t *testing.T) {
+	server := miniredis.RunT(t)
+	server.RequireAuth("secret")
+	stepCaches.redis = newRedisCache(&v1alpha1.RedisCache{Address: server.Addr(), Database: 2}, "secret")
+	defer func() { stepCaches.redis = nil }()
+
+	model, calls := newFlakyModel(t, 0)
+	graphSpec := makeStepGraph("cache-redis", v1alpha1.InferenceStep{
+		StepName:        "model1",
+		InferenceTarget: v1alpha1.InferenceTarget{ServiceURL: model.URL},
+		Cache:           &v1alpha1.InferenceStepCache{TTLSeconds: 10, MaxEntries: proto.Int32(1)},
+	})
+	for i := 0; i < 2; i++ {
+		res, statusCode, err := routeStep(context.Background(), "cache-redis", graphSpec, []byte(`{"instances": [1]}`), http.Header{})
+		assert.Nil(t, err)
+		assert.Equal(t, 200, statusCode)
+		assert.JSONEq(t, `{"predictions": "1"}`, string(res))
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(calls))
+	keys := server.DB(2).Keys()
+	assert.Len(t, keys, 1)
+	assert.Equal(t, 10*time.Second, server.DB(2).TTL(keys[0]))
+}
+
+func TestStepCacheRedisTLS(t *testing.T) {
+	// borrow the self signed localhost certificate of httptest
+	tlsServer := httptest.NewTLSServer(http.NotFoundHandler())
+	defer tlsServer.Close()
+	server, err := miniredis.RunTLS(&tls.Config{Certificates: tlsServer.TLS.Certificates, MinVersion: tls.VersionTLS12})
+	assert.Nil(t, err)
+	defer server.Close()
+
+	scenarios := map[string]struct {
+		tls        *v1alpha1.RedisCacheTLS
+		expectHits bool
+	}{
+		"UntrustedCertificate": {
+			tls: &v1alpha1.RedisCacheTLS{},
+		},
+		"InsecureSkipVerify": {
+			tls:        &v1alpha1.RedisCacheTLS{InsecureSkipVerify: true},
+			expectHits: true,
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			server.FlushAll()
+			stepCaches.redis = newRedisCache(&v1alpha1.RedisCache{Address: server.Addr(), TLS: scenario.tls}, "")
+			defer func() { stepCaches.redis = nil }()
+
+			model, calls := newFlakyModel(t, 0)
+			graphSpec := makeStepGraph("cache-redis-tls-"+name, v1alpha1.InferenceStep{
+				StepName:        "model1",
+				InferenceTarget: v1alpha1.InferenceTarget{ServiceURL: model.URL},
+				Cache:           &v1alpha1.InferenceStepCache{TTLSeconds: 10},
+			})
+			for i := 0; i < 2; i++ {
+				res, statusCode, err := routeStep(context.Background(), "cache-redis-tls-"+name, graphSpec, []byte(`{"instances": [1]}`), http.Header{})
+				assert.Nil(t, err)
+				assert.Equal(t, 200, statusCode)
+				assert.JSONEq(t, `{"predictions": "1"}`, string(res))
+			}
+			if scenario.expectHits {
+				assert.Equal(t, int32(1), atomic.LoadInt32(calls))
+				assert.Len(t, server.Keys(), 1)
+			} else {
+				assert.Equal(t, int32(2), atomic.LoadInt32(calls))
+				assert.Empty(t, server.Keys())
+			}
+		})
+	}
+}
+
+func TestStepCacheRedisUnavailable(t *testing.T) {
+	// reserve an address nothing listens on
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	address := listener.Addr().String()
+	listener.Close()
+	stepCaches.redis = newRedisCache(&v1alpha1.RedisCache{Address: address}, "")
+	defer func() { stepCaches.redis = nil }()
+
+	model, calls := newFlakyModel(t, 0)
+	graphSpec := makeStepGraph("cache-redis-unavailable", v1alpha1.InferenceStep{
+		StepName:        "model1",
+		InferenceTarget: v1alpha1.InferenceTarget{ServiceURL: model.URL},
+		Cache:           &v1alpha1.InferenceStepCache{TTLSeconds: 10},
+	})
+	cacheErrors := testutil.ToFloat64(stepCacheRequests.WithLabelValues("cache-redis-unavailable", "model1", cacheResultError))
+	for i := 0; i < 2; i++ {
+		res, statusCode, err := routeStep(context.Background(), "cache-redis-unavailable", graphSpec, []byte(`{"instances": [1]}`), http.Header{})
+		assert.Nil(t, err)
+		assert.Equal(t, 200, statusCode)
+		assert.JSONEq(t, `{"predictions": "1"}`, string(res))
+	}
+	// the steps are called directly, the failed cache reads and writes are counted
+	assert.Equal(t, int32(2), atomic.LoadInt32(calls))
+	assert.Equal(t, float64(4), testutil.ToFloat64(stepCacheRequests.WithLabelValues("cache-redis-unavailable", "model1", cacheResultError))-cacheErrors)
+}
+
+func TestStepCacheHitsHeader(t *testing.T) {
+	model, calls := newFlakyModel(t, 0)
+	inferenceGraph = &v1alpha1.InferenceGraphSpec{
+		Nodes: map[string]v1alpha1.InferenceRouter{
+			v1alpha1.GraphRootNodeName: {
+				RouterType: v1alpha1.Sequence,
+				Steps: []v1alpha1.InferenceStep{
+					{
+						StepName:        "cached-model",
+						InferenceTarget: v1alpha1.InferenceTarget{ServiceURL: model.URL},
+						Cache:           &v1alpha1.InferenceStepCache{TTLSeconds: 60},
+					},
+				},
+			},
+		},
+	}
+	defer func() { inferenceGraph = nil }()
+
+	for _, expectedHeader := range []string{"", "root/cached-model"} {
+		recorder := httptest.NewRecorder()
+		graphHandler(recorder, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"instances": [1]}`)))
+		assert.Equal(t, 200, recorder.Code)
+		assert.JSONEq(t, `{"predictions": "1"}`, recorder.Body.String())
+		assert.Equal(t, expectedHeader, recorder.Header().Get(cacheHitsHeader))
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(calls))
+}
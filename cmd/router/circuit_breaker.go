@@ -0,0 +1,118 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"sync"
+	"time"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+)
+
+const defaultCircuitBreakerCooldown = 30 * time.Second
+
+// now is replaced in tests to move the circuit breakers through their cooldown
+var now = time.Now
+
+// circuitBreaker stops calling a step after a number of consecutive failures. Once the cooldown
+// has elapsed a single call is let through, closing the circuit on success and opening it again on failure.
+type circuitBreaker struct {
+	mu        sync.Mutex
+	threshold int32
+	cooldown  time.Duration
+	failures  int32
+	open      bool
+	probing   bool
+	openedAt  time.Time
+}
+
+func newCircuitBreaker(spec *v1alpha1.InferenceStepCircuitBreaker) *circuitBreaker {
+	cooldown := defaultCircuitBreakerCooldown
+	if spec.CooldownSeconds != nil {
+		cooldown = time.Duration(*spec.CooldownSeconds) * time.Second
+	}
+	return &circuitBreaker{
+		threshold: spec.ConsecutiveFailures,
+		cooldown:  cooldown,
+	}
+}
+
+// allow reports whether the step can be called, a nil circuit breaker always allows the call
+func (cb *circuitBreaker) allow() bool {
+	if cb == nil {
+		return true
+	}
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	if !cb.open {
+		return true
+	}
+	if cb.probing || now().Sub(cb.openedAt) < cb.cooldown {
+		return false
+	}
+	cb.probing = true
+	return true
+}
+
+func (cb *circuitBreaker) success() {
+	if cb == nil {
+		return
+	}
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	cb.failures = 0
+	cb.open = false
+	cb.probing = false
+}
+
+func (cb *circuitBreaker) failure() {
+	if cb == nil {
+		return
+	}
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	cb.failures++
+	if cb.probing || cb.failures >= cb.threshold {
+		cb.open = true
+		cb.probing = false
+		cb.openedAt = now()
+	}
+}
+
+// circuitBreakerRegistry holds the circuit breakers of the steps, keyed by node and step
+type circuitBreakerRegistry struct {
+	mu       sync.Mutex
+	breakers map[string]*circuitBreaker
+}
+
+var circuitBreakers = &circuitBreakerRegistry{breakers: map[string]*circuitBreaker{}}
+
+// get returns the circuit breaker of the step, or nil when the step does not define one
+func (r *circuitBreakerRegistry) get(nodeName string, stepName string, spec *v1alpha1.InferenceStepCircuitBreaker) *circuitBreaker {
+	if spec == nil {
+		return nil
+	}
+	key := nodeName + "/" + stepName
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	cb, ok := r.breakers[key]
+	if !ok {
+		cb = newCircuitBreaker(spec)
+		r.breakers[key] = cb
+	}
+	return cb
+}
@@ -0,0 +1,97 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/google/cel-go/cel"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+)
+
+// conditionRegistry holds the compiled CEL conditions, keyed by router type and expression
+type conditionRegistry struct {
+	mu       sync.Mutex
+	programs map[string]cel.Program
+}
+
+var conditions = &conditionRegistry{programs: map[string]cel.Program{}}
+
+// get returns the compiled condition, compiling it on first use
+func (r *conditionRegistry) get(routerType v1alpha1.InferenceRouterType, expression string) (cel.Program, error) {
+	key := string(routerType) + "/" + expression
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	program, ok := r.programs[key]
+	if !ok {
+		var err error
+		if program, err = v1alpha1.CompileStepCondition(routerType, expression); err != nil {
+			return nil, err
+		}
+		r.programs[key] = program
+	}
+	return program, nil
+}
+
+// compileGraph compiles the CEL conditions of all the steps of the graph when the router starts
+func (r *conditionRegistry) compileGraph(graph *v1alpha1.InferenceGraphSpec) error {
+	for nodeName, node := range graph.Nodes {
+		for i, step := range node.Steps {
+			if step.ConditionLanguage != v1alpha1.ConditionLanguageCEL {
+				continue
+			}
+			if _, err := r.get(node.RouterType, step.Condition); err != nil {
+				return fmt.Errorf("invalid condition of step %d in node %q: %w", i, nodeName, err)
+			}
+		}
+	}
+	return nil
+}
+
+// matchCELCondition evaluates the CEL condition of the step against the parsed JSON of the node input and, when
+// given, of the previous step response. A condition which fails to evaluate, because a field is missing or has
+// an unexpected type, does not match.
+func matchCELCondition(routerType v1alpha1.InferenceRouterType, step *v1alpha1.InferenceStep, request []byte, response []byte) bool {
+	program, err := conditions.get(routerType, step.Condition)
+	if err != nil {
+		log.Error(err, "invalid condition", "stepName", step.StepName, "condition", step.Condition)
+		return false
+	}
+	activation := map[string]interface{}{}
+	var requestValue, responseValue interface{}
+	if json.Unmarshal(request, &requestValue) == nil {
+		activation[v1alpha1.ConditionRequestVariable] = requestValue
+	}
+	if response != nil && json.Unmarshal(response, &responseValue) == nil {
+		activation[v1alpha1.ConditionResponseVariable] = responseValue
+	}
+	result, _, err := program.Eval(activation)
+	if err != nil {
+		log.Info("Failed to evaluate the condition, the step does not match", "stepName", step.StepName,
+			"condition", step.Condition, "error", err.Error())
+		return false
+	}
+	matched, ok := result.Value().(bool)
+	if !ok {
+		log.Info("The condition does not evaluate to a bool, the step does not match", "stepName", step.StepName,
+			"condition", step.Condition, "result", fmt.Sprint(result.Value()))
+	}
+	return matched
+}
@@ -0,0 +1,193 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+)
+
+func newStaticModel(t *testing.T, response string) *httptest.Server {
+	model := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = rw.Write([]byte(response))
+	}))
+	t.Cleanup(model.Close)
+	return model
+}
+
+func TestSwitchCELCondition(t *testing.T) {
+	modelA := newStaticModel(t, `{"predictions": "A"}`)
+	modelB := newStaticModel(t, `{"predictions": "B"}`)
+	graphSpec := v1alpha1.InferenceGraphSpec{
+		Nodes: map[string]v1alpha1.InferenceRouter{
+			"cel-switch": {
+				RouterType: v1alpha1.Switch,
+				Steps: []v1alpha1.InferenceStep{
+					{
+						StepName:          "stepA",
+						InferenceTarget:   v1alpha1.InferenceTarget{ServiceURL: modelA.URL},
+						Condition:         "request.instances[0].score > 0.8",
+						ConditionLanguage: v1alpha1.ConditionLanguageCEL,
+					},
+					{
+						StepName:          "stepB",
+						InferenceTarget:   v1alpha1.InferenceTarget{ServiceURL: modelB.URL},
+						Condition:         "request.instances[0].score <= 0.8",
+						ConditionLanguage: v1alpha1.ConditionLanguageCEL,
+					},
+				},
+			},
+		},
+	}
+	scenarios := map[string]struct {
+		input              string
+		expectedStatusCode int
+		expectedResponse   string
+	}{
+		"greater than": {
+			input:              `{"instances": [{"score": 0.9}]}`,
+			expectedStatusCode: 200,
+			expectedResponse:   `{"predictions": "A"}`,
+		},
+		"less than": {
+			input:              `{"instances": [{"score": 0.5}]}`,
+			expectedStatusCode: 200,
+			expectedResponse:   `{"predictions": "B"}`,
+		},
+		"integer compared to a double": {
+			input:              `{"instances": [{"score": 1}]}`,
+			expectedStatusCode: 200,
+			expectedResponse:   `{"predictions": "A"}`,
+		},
+		"missing field": {
+			input:              `{"instances": [{"label": "cat"}]}`,
+			expectedStatusCode: 404,
+		},
+		"missing list element": {
+			input:              `{"instances": []}`,
+			expectedStatusCode: 404,
+		},
+		"type error": {
+			input:              `{"instances": [{"score": "high"}]}`,
+			expectedStatusCode: 404,
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			res, statusCode, err := routeStep(context.Background(), "cel-switch", graphSpec, []byte(scenario.input), http.Header{})
+			assert.Equal(t, scenario.expectedStatusCode, statusCode)
+			if scenario.expectedResponse != "" {
+				assert.Nil(t, err)
+				assert.JSONEq(t, scenario.expectedResponse, string(res))
+			} else {
+				assert.NotNil(t, err)
+			}
+		})
+	}
+}
+
+func TestSequenceCELConditionOnResponse(t *testing.T) {
+	scenarios := map[string]struct {
+		classifierResponse string
+		expectedStatusCode int
+		expectedResponse   string
+	}{
+		"condition matches": {
+			classifierResponse: `{"predictions": [{"label": "dog", "score": 0.95}]}`,
+			expectedStatusCode: 200,
+			expectedResponse:   `{"predictions": "detector"}`,
+		},
+		"condition does not match": {
+			classifierResponse: `{"predictions": [{"label": "dog", "score": 0.5}]}`,
+			expectedStatusCode: 500,
+			expectedResponse:   `{"predictions": [{"label": "dog", "score": 0.5}]}`,
+		},
+		"missing field": {
+			classifierResponse: `{"predictions": [{"label": "dog"}]}`,
+			expectedStatusCode: 500,
+			expectedResponse:   `{"predictions": [{"label": "dog"}]}`,
+		},
+		"type error": {
+			classifierResponse: `{"predictions": [{"label": "dog", "score": "0.95"}]}`,
+			expectedStatusCode: 500,
+			expectedResponse:   `{"predictions": [{"label": "dog", "score": "0.95"}]}`,
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			classifier := newStaticModel(t, scenario.classifierResponse)
+			detector := newStaticModel(t, `{"predictions": "detector"}`)
+			graphSpec := v1alpha1.InferenceGraphSpec{
+				Nodes: map[string]v1alpha1.InferenceRouter{
+					"cel-sequence": {
+						RouterType: v1alpha1.Sequence,
+						Steps: []v1alpha1.InferenceStep{
+							{
+								StepName:        "classifier",
+								InferenceTarget: v1alpha1.InferenceTarget{ServiceURL: classifier.URL},
+							},
+							{
+								StepName:          "detector",
+								InferenceTarget:   v1alpha1.InferenceTarget{ServiceURL: detector.URL},
+								Data:              "$request",
+								Condition:         `response.predictions[0].score > 0.8 && request.instances[0] == "img"`,
+								ConditionLanguage: v1alpha1.ConditionLanguageCEL,
+							},
+						},
+					},
+				},
+			}
+			res, statusCode, err := routeStep(context.Background(), "cel-sequence", graphSpec, []byte(`{"instances": ["img"]}`), http.Header{})
+			assert.Nil(t, err)
+			assert.Equal(t, scenario.expectedStatusCode, statusCode)
+			assert.JSONEq(t, scenario.expectedResponse, string(res))
+		})
+	}
+}
+
+func TestCompileGraphConditions(t *testing.T) {
+	graphSpec := &v1alpha1.InferenceGraphSpec{
+		Nodes: map[string]v1alpha1.InferenceRouter{
+			v1alpha1.GraphRootNodeName: {
+				RouterType: v1alpha1.Switch,
+				Steps: []v1alpha1.InferenceStep{
+					{
+						InferenceTarget:   v1alpha1.InferenceTarget{ServiceURL: "http://model"},
+						Condition:         "instances",
+						ConditionLanguage: v1alpha1.ConditionLanguageGJSON,
+					},
+					{
+						InferenceTarget:   v1alpha1.InferenceTarget{ServiceURL: "http://model"},
+						Condition:         "request.score > 0.8",
+						ConditionLanguage: v1alpha1.ConditionLanguageCEL,
+					},
+				},
+			},
+		},
+	}
+	assert.Nil(t, conditions.compileGraph(graphSpec))
+
+	// the response of the previous step is only available in a Sequence node
+	graphSpec.Nodes[v1alpha1.GraphRootNodeName].Steps[1].Condition = "response.score > 0.8"
+	assert.ErrorContains(t, conditions.compileGraph(graphSpec), "undeclared reference to 'response'")
+}
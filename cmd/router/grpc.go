@@ -0,0 +1,186 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	goerrors "errors"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	"github.com/kserve/kserve/pkg/protocol/inference"
+)
+
+// grpcKeepaliveTime matches the minimum ping interval the grpc servers accept by default
+const grpcKeepaliveTime = 5 * time.Minute
+
+var v2ModelPathRegexp = regexp.MustCompile(`^/v2/models/([^/]+)(?:/versions/([^/]+))?(?:/infer)?/?$`)
+
+// grpcConnectionPool reuses a single client connection per target
+type grpcConnectionPool struct {
+	mu    sync.Mutex
+	conns map[string]*grpc.ClientConn
+}
+
+var grpcConnections = &grpcConnectionPool{conns: map[string]*grpc.ClientConn{}}
+
+func (p *grpcConnectionPool) get(target string, secure bool) (*grpc.ClientConn, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if conn, ok := p.conns[target]; ok {
+		return conn, nil
+	}
+	transportCredentials := insecure.NewCredentials()
+	if secure {
+		transportCredentials = credentials.NewTLS(&tls.Config{MinVersion: tls.VersionTLS12})
+	}
+	conn, err := grpc.Dial(target,
+		grpc.WithTransportCredentials(transportCredentials),
+		grpc.WithKeepaliveParams(keepalive.ClientParameters{Time: grpcKeepaliveTime, Timeout: 20 * time.Second}),
+		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(math.MaxInt32), grpc.MaxCallSendMsgSize(math.MaxInt32)),
+	)
+	if err != nil {
+		return nil, err
+	}
+	p.conns[target] = conn
+	return conn, nil
+}
+
+// grpcTarget returns the host and port of the service url, the port defaults to the one of the url scheme
+func grpcTarget(serviceUrl *url.URL) (string, bool) {
+	secure := serviceUrl.Scheme == "https"
+	if serviceUrl.Port() != "" {
+		return serviceUrl.Host, secure
+	}
+	if secure {
+		return net.JoinHostPort(serviceUrl.Hostname(), "443"), secure
+	}
+	return net.JoinHostPort(serviceUrl.Hostname(), "80"), secure
+}
+
+// grpcModel returns the name and version of the model called by a grpc step, taken from the /v2/models/{name}
+// path of the service url or from the name of the InferenceService
+func grpcModel(step *v1alpha1.InferenceStep, serviceUrl *url.URL) (string, string, error) {
+	if match := v2ModelPathRegexp.FindStringSubmatch(serviceUrl.Path); match != nil {
+		return match[1], match[2], nil
+	}
+	if step.ServiceName != "" {
+		return step.ServiceName, "", nil
+	}
+	return "", "", fmt.Errorf("the model name of step %q cannot be determined from the service url %q", stepLabel(step), step.ServiceURL)
+}
+
+// httpStatusFromCode maps the grpc status codes to the http status codes of the open inference protocol
+func httpStatusFromCode(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return http.StatusOK
+	case codes.InvalidArgument, codes.FailedPrecondition, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Canceled:
+		return 499
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	}
+	return http.StatusInternalServerError
+}
+
+// callGRPCService converts the JSON request to a ModelInferRequest, calls the service over grpc and converts the
+// ModelInferResponse back to JSON. Error statuses of the service are returned as {"error": message} responses.
+func callGRPCService(ctx context.Context, step *v1alpha1.InferenceStep, input []byte, headers http.Header, extraHeaders http.Header) ([]byte, int, error) {
+	defer timeTrack(time.Now(), "step", step.ServiceURL)
+	log.Info("Entering callGRPCService", "url", step.ServiceURL)
+	serviceUrl, err := url.Parse(step.ServiceURL)
+	if err != nil {
+		log.Error(err, "An error occurred while parsing the serviceUrl", "serviceUrl", step.ServiceURL)
+		return nil, 500, err
+	}
+	modelName, modelVersion, err := grpcModel(step, serviceUrl)
+	if err != nil {
+		return nil, 500, err
+	}
+	request, err := toModelInferRequest(input, modelName, modelVersion)
+	if err != nil {
+		log.Error(err, "Failed to convert the request of the grpc step", "stepName", stepLabel(step))
+		return nil, 400, err
+	}
+	target, secure := grpcTarget(serviceUrl)
+	conn, err := grpcConnections.get(target, secure)
+	if err != nil {
+		log.Error(err, "An error has occurred while connecting to service", "target", target)
+		return nil, 500, err
+	}
+
+	md := metadata.MD{}
+	for h, values := range propagatedHeaders(headers) {
+		md.Append(h, values...)
+	}
+	for h, values := range extraHeaders {
+		md.Set(h, values...)
+	}
+	response, err := inference.NewGRPCInferenceServiceClient(conn).ModelInfer(metadata.NewOutgoingContext(ctx, md), request)
+	if err != nil {
+		if ctxErr := ctx.Err(); goerrors.Is(ctxErr, context.DeadlineExceeded) {
+			return nil, 504, ctxErr
+		}
+		st, ok := status.FromError(err)
+		if !ok {
+			log.Error(err, "An error has occurred while calling service", "target", target)
+			return nil, 500, err
+		}
+		log.Info("The grpc service returned an error status", "target", target, "code", st.Code(), "message", st.Message())
+		body, _ := json.Marshal(map[string]string{"error": st.Message()})
+		return body, httpStatusFromCode(st.Code()), nil
+	}
+	body, err := fromModelInferResponse(response)
+	if err != nil {
+		log.Error(err, "Failed to convert the response of the grpc step", "stepName", stepLabel(step))
+		return nil, 502, err
+	}
+	return body, 200, nil
+}
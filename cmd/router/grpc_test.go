@@ -0,0 +1,309 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"math"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	"github.com/kserve/kserve/pkg/protocol/inference"
+)
+
+// testInferenceServer doubles the FP32 inputs of model1
+type testInferenceServer struct {
+	inference.UnimplementedGRPCInferenceServiceServer
+	raw      bool
+	mu       sync.Mutex
+	metadata metadata.MD
+}
+
+func (s *testInferenceServer) ModelInfer(ctx context.Context, req *inference.ModelInferRequest) (*inference.ModelInferResponse, error) {
+	if req.ModelName != "model1" {
+		return nil, status.Errorf(codes.NotFound, "model %s not found", req.ModelName)
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	s.mu.Lock()
+	s.metadata = md
+	s.mu.Unlock()
+	response := &inference.ModelInferResponse{ModelName: req.ModelName, Id: req.Id}
+	for i, input := range req.Inputs {
+		output := &inference.ModelInferResponse_InferOutputTensor{
+			Name:     "output-" + input.Name[len("input-"):],
+			Datatype: input.Datatype,
+			Shape:    input.Shape,
+		}
+		var doubled []float32
+		for _, v := range input.GetContents().GetFp32Contents() {
+			doubled = append(doubled, 2*v)
+		}
+		if s.raw {
+			raw := make([]byte, 4*len(doubled))
+			for j, v := range doubled {
+				binary.LittleEndian.PutUint32(raw[4*j:], math.Float32bits(v))
+			}
+			response.RawOutputContents = append(response.RawOutputContents, raw)
+		} else {
+			output.Contents = &inference.InferTensorContents{Fp32Contents: doubled}
+		}
+		response.Outputs = append(response.Outputs, output)
+		if i == 0 && req.Parameters["echo"] != nil {
+			output.Parameters = map[string]*inference.InferParameter{"echo": req.Parameters["echo"]}
+		}
+	}
+	return response, nil
+}
+
+func startGRPCModel(t *testing.T, server *testInferenceServer) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	s := grpc.NewServer()
+	inference.RegisterGRPCInferenceServiceServer(s, server)
+	go func() {
+		_ = s.Serve(listener)
+	}()
+	t.Cleanup(s.Stop)
+	return "http://" + listener.Addr().String()
+}
+
+const v2Request = `{"id": "1", "parameters": {"echo": "hello"}, "inputs": [{"name": "input-0", "shape": [2, 2], "datatype": "FP32", "data": [[1, 2], [3, 4]]}]}`
+
+func TestGRPCStepInMixedGraph(t *testing.T) {
+	server := &testInferenceServer{}
+	grpcUrl := startGRPCModel(t, server)
+	preprocessor := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = rw.Write([]byte(v2Request))
+	}))
+	defer preprocessor.Close()
+	compiledHeaderPatterns = []*regexp.Regexp{regexp.MustCompile("Test-Header")}
+	defer func() { compiledHeaderPatterns = nil }()
+
+	expectedResponse := `{"model_name": "model1", "id": "1", "outputs": [{"name": "output-0", "shape": [2, 2], "datatype": "FP32", "parameters": {"echo": "hello"}, "data": [2, 4, 6, 8]}]}`
+	scenarios := map[string]struct {
+		graphSpec v1alpha1.InferenceGraphSpec
+		input     string
+	}{
+		"http step feeding a grpc step": {
+			graphSpec: v1alpha1.InferenceGraphSpec{
+				Nodes: map[string]v1alpha1.InferenceRouter{
+					"root": {
+						RouterType: v1alpha1.Sequence,
+						Steps: []v1alpha1.InferenceStep{
+							{
+								StepName:        "preprocessor",
+								InferenceTarget: v1alpha1.InferenceTarget{ServiceURL: preprocessor.URL},
+							},
+							{
+								StepName:        "model1",
+								InferenceTarget: v1alpha1.InferenceTarget{ServiceURL: grpcUrl + "/v2/models/model1/infer"},
+								Data:            "$response",
+								Protocol:        v1alpha1.StepProtocolGRPCV2,
+							},
+						},
+					},
+				},
+			},
+			input: `{}`,
+		},
+		"http splitter routing to a grpc step": {
+			graphSpec: v1alpha1.InferenceGraphSpec{
+				Nodes: map[string]v1alpha1.InferenceRouter{
+					"root": {
+						RouterType: v1alpha1.Splitter,
+						Steps: []v1alpha1.InferenceStep{
+							{
+								InferenceTarget: v1alpha1.InferenceTarget{ServiceName: "model1", ServiceURL: grpcUrl},
+								Weight:          proto.Int64(100),
+								Protocol:        v1alpha1.StepProtocolGRPCV2,
+							},
+						},
+					},
+				},
+			},
+			input: v2Request,
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			headers := http.Header{"Test-Header": {"test"}}
+			res, statusCode, err := routeStep(context.Background(), "root", scenario.graphSpec, []byte(scenario.input), headers)
+			assert.Nil(t, err)
+			assert.Equal(t, 200, statusCode)
+			assert.JSONEq(t, expectedResponse, string(res))
+			server.mu.Lock()
+			assert.Equal(t, []string{"test"}, server.metadata.Get("test-header"))
+			server.mu.Unlock()
+		})
+	}
+
+	// a single connection is kept for the target
+	target := strings.TrimPrefix(grpcUrl, "http://")
+	conn, err := grpcConnections.get(target, false)
+	assert.Nil(t, err)
+	grpcConnections.mu.Lock()
+	assert.Same(t, conn, grpcConnections.conns[target])
+	grpcConnections.mu.Unlock()
+}
+
+func TestGRPCStepRawOutputContents(t *testing.T) {
+	grpcUrl := startGRPCModel(t, &testInferenceServer{raw: true})
+	graphSpec := makeStepGraph("grpc-raw", v1alpha1.InferenceStep{
+		InferenceTarget: v1alpha1.InferenceTarget{ServiceURL: grpcUrl + "/v2/models/model1"},
+		Protocol:        v1alpha1.StepProtocolGRPCV2,
+	})
+
+	res, statusCode, err := routeStep(context.Background(), "grpc-raw", graphSpec,
+		[]byte(`{"inputs": [{"name": "input-0", "shape": [3], "datatype": "FP32", "data": [0.5, 1, 1.5]}]}`), http.Header{})
+	assert.Nil(t, err)
+	assert.Equal(t, 200, statusCode)
+	assert.JSONEq(t, `{"model_name": "model1", "outputs": [{"name": "output-0", "shape": [3], "datatype": "FP32", "data": [1, 2, 3]}]}`, string(res))
+}
+
+func TestGRPCStepErrors(t *testing.T) {
+	grpcUrl := startGRPCModel(t, &testInferenceServer{})
+	scenarios := map[string]struct {
+		serviceUrl         string
+		input              string
+		expectedStatusCode int
+		expectedResponse   string
+	}{
+		"data not matching the shape": {
+			serviceUrl:         grpcUrl + "/v2/models/model1/infer",
+			input:              `{"inputs": [{"name": "input-0", "shape": [2, 2], "datatype": "FP32", "data": [1, 2, 3]}]}`,
+			expectedStatusCode: 400,
+			expectedResponse: `{"error": "Failed to process request", "field": "inputs[0].data",
+				"cause": "inputs[0].data: has 3 elements but the shape [2 2] requires 4"}`,
+		},
+		"invalid element": {
+			serviceUrl:         grpcUrl + "/v2/models/model1/infer",
+			input:              `{"inputs": [{"name": "input-0", "shape": [2], "datatype": "INT32", "data": [1, "two"]}]}`,
+			expectedStatusCode: 400,
+			expectedResponse: `{"error": "Failed to process request", "field": "inputs[0].data[1]",
+				"cause": "inputs[0].data[1]: must be a number for the INT32 datatype"}`,
+		},
+		"unsupported parameter": {
+			serviceUrl:         grpcUrl + "/v2/models/model1/infer",
+			input:              `{"parameters": {"p": 0.5}, "inputs": [{"name": "input-0", "shape": [1], "datatype": "FP32", "data": [1]}]}`,
+			expectedStatusCode: 400,
+			expectedResponse: `{"error": "Failed to process request", "field": "parameters.p",
+				"cause": "parameters.p: only integer numbers are supported"}`,
+		},
+		"model not found": {
+			serviceUrl:         grpcUrl + "/v2/models/model2/infer",
+			input:              `{"inputs": [{"name": "input-0", "shape": [1], "datatype": "FP32", "data": [1]}]}`,
+			expectedStatusCode: 404,
+			expectedResponse:   `{"error": "model model2 not found"}`,
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			inferenceGraph = &v1alpha1.InferenceGraphSpec{
+				Nodes: map[string]v1alpha1.InferenceRouter{
+					v1alpha1.GraphRootNodeName: {
+						RouterType: v1alpha1.Sequence,
+						Steps: []v1alpha1.InferenceStep{
+							{
+								InferenceTarget: v1alpha1.InferenceTarget{ServiceURL: scenario.serviceUrl},
+								Protocol:        v1alpha1.StepProtocolGRPCV2,
+							},
+						},
+					},
+				},
+			}
+			defer func() { inferenceGraph = nil }()
+			recorder := httptest.NewRecorder()
+			graphHandler(recorder, httptest.NewRequest(http.MethodPost, "/", strings.NewReader(scenario.input)))
+			assert.Equal(t, scenario.expectedStatusCode, recorder.Code)
+			assert.JSONEq(t, scenario.expectedResponse, recorder.Body.String())
+		})
+	}
+}
+
+func TestFromRawContents(t *testing.T) {
+	bytesRaw := []byte{}
+	for _, s := range []string{"a", "bc"} {
+		bytesRaw = binary.LittleEndian.AppendUint32(bytesRaw, uint32(len(s)))
+		bytesRaw = append(bytesRaw, s...)
+	}
+	scenarios := map[string]struct {
+		datatype     string
+		raw          []byte
+		expectedData string
+		expectedErr  string
+	}{
+		"INT64": {
+			datatype:     "INT64",
+			raw:          binary.LittleEndian.AppendUint64(binary.LittleEndian.AppendUint64(nil, 1), uint64(1<<63)),
+			expectedData: `[1, -9223372036854775808]`,
+		},
+		"BOOL": {
+			datatype:     "BOOL",
+			raw:          []byte{1, 0},
+			expectedData: `[true, false]`,
+		},
+		"BYTES": {
+			datatype:     "BYTES",
+			raw:          bytesRaw,
+			expectedData: `["a", "bc"]`,
+		},
+		"truncated BYTES": {
+			datatype:    "BYTES",
+			raw:         bytesRaw[:len(bytesRaw)-1],
+			expectedErr: "outputs[0]: truncated BYTES raw contents",
+		},
+		"invalid size": {
+			datatype:    "INT32",
+			raw:         []byte{1, 2, 3},
+			expectedErr: "outputs[0]: raw contents of 3 bytes are not a multiple of the INT32 size",
+		},
+		"FP16": {
+			datatype:    "FP16",
+			raw:         []byte{1, 2},
+			expectedErr: "outputs[0].datatype: the \"FP16\" datatype is not supported",
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			data, err := fromRawContents("outputs[0]", scenario.datatype, scenario.raw)
+			if scenario.expectedErr != "" {
+				assert.EqualError(t, err, scenario.expectedErr)
+				return
+			}
+			assert.Nil(t, err)
+			dataBytes, _ := json.Marshal(data)
+			assert.JSONEq(t, scenario.expectedData, string(dataBytes))
+		})
+	}
+}
@@ -0,0 +1,193 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	guuid "github.com/google/uuid"
+	uberzap "go.uber.org/zap"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	"github.com/kserve/kserve/pkg/constants"
+	kfslogger "github.com/kserve/kserve/pkg/logger"
+)
+
+// graphLoggerWorkers is the number of workers delivering the events of the graph logger
+const graphLoggerWorkers = 5
+
+// graphLogger sends the requests and the responses of the graph, and of its steps when enabled, as cloud events
+// to the url of the graph logger
+type graphLogger struct {
+	logURL    *url.URL
+	sourceURI *url.URL
+	mode      v1alpha1.InferenceGraphLoggerMode
+	steps     bool
+	policy    *kfslogger.Policy
+	graphName string
+	namespace string
+}
+
+// requestLogger is nil when the graph has no logger
+var requestLogger *graphLogger
+
+func newGraphLogger(spec *v1alpha1.InferenceGraphLogger, graphName string, namespace string) (*graphLogger, error) {
+	logURL, err := url.Parse(spec.URL)
+	if err != nil {
+		return nil, fmt.Errorf("malformed logger url %q: %w", spec.URL, err)
+	}
+	policy := &kfslogger.Policy{SamplingRate: 1}
+	if spec.SamplingRate != nil {
+		if policy.SamplingRate, err = strconv.ParseFloat(*spec.SamplingRate, 64); err != nil {
+			return nil, fmt.Errorf("malformed logger sampling rate %q: %w", *spec.SamplingRate, err)
+		}
+	}
+	mode := spec.Mode
+	if mode == "" {
+		mode = v1alpha1.GraphLogAll
+	}
+	return &graphLogger{
+		logURL:    logURL,
+		sourceURI: &url.URL{Scheme: "http", Host: fmt.Sprintf("localhost:%d", constants.RouterDefaultHttpPort), Path: "/"},
+		mode:      mode,
+		steps:     spec.Steps,
+		policy:    policy,
+		graphName: graphName,
+		namespace: namespace,
+	}, nil
+}
+
+// startGraphLogger starts the workers delivering the events of the graph logger, the logger of the router is only
+// enabled once they are running
+func startGraphLogger(spec *v1alpha1.InferenceGraphLogger, graphName string, namespace string) error {
+	logger, err := newGraphLogger(spec, graphName, namespace)
+	if err != nil {
+		return err
+	}
+	sink, err := kfslogger.NewSink(logger.logURL, "")
+	if err != nil {
+		return err
+	}
+	zapLogger, err := uberzap.NewProduction()
+	if err != nil {
+		return err
+	}
+	if err := kfslogger.StartDispatcher(graphLoggerWorkers, sink, kfslogger.DefaultDeliveryConfig(), zapLogger.Sugar()); err != nil {
+		return err
+	}
+	requestLogger = logger
+	return nil
+}
+
+type inferenceLogKey struct{}
+
+// inferenceLog logs the events of an inference of the graph or of one of its steps. The events of an inference and
+// of its steps are either all logged or all skipped, a nil inferenceLog logs nothing.
+type inferenceLog struct {
+	logger      *graphLogger
+	inferenceID string
+	sampled     bool
+	eventID     string
+	step        string
+}
+
+// withInferenceLog returns the context carrying the log of the inference of the request, the id of the inference is
+// taken from the cloud event id of the request when there is one
+func (l *graphLogger) withInferenceLog(ctx context.Context, headers http.Header) (context.Context, *inferenceLog) {
+	if l == nil {
+		return ctx, nil
+	}
+	id := headers.Get(kfslogger.CloudEventsIdHeader)
+	if id == "" {
+		id = guuid.New().String()
+	}
+	inference := &inferenceLog{
+		logger:      l,
+		inferenceID: id,
+		sampled:     l.policy.Sampled(id),
+		eventID:     id,
+	}
+	return context.WithValue(ctx, inferenceLogKey{}, inference), inference
+}
+
+// stepLog returns the log of the given step of the inference of the context, or nil when the steps are not logged
+func stepLog(ctx context.Context, nodeName string, stepName string) *inferenceLog {
+	inference, _ := ctx.Value(inferenceLogKey{}).(*inferenceLog)
+	if inference == nil || !inference.logger.steps {
+		return nil
+	}
+	return &inferenceLog{
+		logger:      inference.logger,
+		inferenceID: inference.inferenceID,
+		sampled:     inference.sampled,
+		eventID:     fmt.Sprintf("%s-%s-%s", inference.inferenceID, nodeName, stepName),
+		step:        stepName,
+	}
+}
+
+func (i *inferenceLog) send(reqType string, contentType string, payload []byte) {
+	err := i.logger.policy.Queue(kfslogger.LogRequest{
+		Url:            i.logger.logURL,
+		Bytes:          &payload,
+		ContentType:    contentType,
+		ReqType:        reqType,
+		Id:             i.eventID,
+		SourceUri:      i.logger.sourceURI,
+		Namespace:      i.logger.namespace,
+		InferenceGraph: i.logger.graphName,
+		Step:           i.step,
+		InferenceId:    i.inferenceID,
+	}, i.sampled)
+	if err != nil {
+		log.Error(err, "Failed to log event", "type", reqType, "step", i.step, "id", i.eventID)
+	}
+}
+
+// logRequest logs the request before it is processed, in the errors mode it is only logged with the response
+func (i *inferenceLog) logRequest(contentType string, request []byte) {
+	if i == nil {
+		return
+	}
+	if i.logger.mode == v1alpha1.GraphLogAll || i.logger.mode == v1alpha1.GraphLogRequest {
+		i.send(kfslogger.CEInferenceRequest, contentType, request)
+	}
+}
+
+// logResponse logs the successful responses, or both the request and the response of the failed inferences in the
+// errors mode
+func (i *inferenceLog) logResponse(requestContentType string, request []byte, statusCode int, response []byte) {
+	if i == nil {
+		return
+	}
+	contentType := "application/octet-stream"
+	if json.Valid(response) {
+		contentType = "application/json"
+	}
+	if i.logger.mode == v1alpha1.GraphLogErrors && kfslogger.IsErrorResponse(statusCode, response) {
+		i.send(kfslogger.CEInferenceRequest, requestContentType, request)
+		i.send(kfslogger.CEInferenceResponse, contentType, response)
+		return
+	}
+	if (i.logger.mode == v1alpha1.GraphLogAll || i.logger.mode == v1alpha1.GraphLogResponse) && isSuccessFul(statusCode) {
+		i.send(kfslogger.CEInferenceResponse, contentType, response)
+	}
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	kfslogger "github.com/kserve/kserve/pkg/logger"
+)
+
+// startEventReceiver starts the graph logger with a sink receiving the cloud events of the router
+func startEventReceiver(t *testing.T, spec v1alpha1.InferenceGraphLogger) chan http.Header {
+	events := make(chan http.Header, 16)
+	receiver := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		events <- req.Header.Clone()
+		rw.WriteHeader(http.StatusAccepted)
+	}))
+	t.Cleanup(receiver.Close)
+	spec.URL = receiver.URL
+	require.NoError(t, startGraphLogger(&spec, "logged-graph", "logged-namespace"))
+	t.Cleanup(func() { requestLogger = nil })
+	return events
+}
+
+// receiveEvents returns the headers of the next n events by event type and step
+func receiveEvents(t *testing.T, events chan http.Header, n int) map[string]http.Header {
+	received := map[string]http.Header{}
+	for i := 0; i < n; i++ {
+		select {
+		case event := <-events:
+			received[event.Get("Ce-Type")+"/"+event.Get("Ce-Step")] = event
+		case <-time.After(5 * time.Second):
+			t.Fatalf("received %d events, want %d", len(received), n)
+		}
+	}
+	return received
+}
+
+func TestGraphLoggerStepEvents(t *testing.T) {
+	events := startEventReceiver(t, v1alpha1.InferenceGraphLogger{Steps: true})
+	model := newStaticModel(t, `{"predictions": "1"}`)
+	inferenceGraph = &v1alpha1.InferenceGraphSpec{
+		Nodes: map[string]v1alpha1.InferenceRouter{
+			v1alpha1.GraphRootNodeName: {
+				RouterType: v1alpha1.Sequence,
+				Steps: []v1alpha1.InferenceStep{
+					{
+						StepName:        "logged-model",
+						InferenceTarget: v1alpha1.InferenceTarget{ServiceURL: model.URL},
+					},
+				},
+			},
+		},
+	}
+	defer func() { inferenceGraph = nil }()
+
+	request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"instances": [1]}`))
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set(kfslogger.CloudEventsIdHeader, "inference-1")
+	recorder := httptest.NewRecorder()
+	graphHandler(recorder, request)
+	assert.Equal(t, 200, recorder.Code)
+
+	received := receiveEvents(t, events, 4)
+	expectedIds := map[string]string{
+		kfslogger.CEInferenceRequest + "/":              "inference-1",
+		kfslogger.CEInferenceResponse + "/":             "inference-1",
+		kfslogger.CEInferenceRequest + "/logged-model":  "inference-1-root-logged-model",
+		kfslogger.CEInferenceResponse + "/logged-model": "inference-1-root-logged-model",
+	}
+	for key, id := range expectedIds {
+		require.Contains(t, received, key)
+		event := received[key]
+		assert.Equal(t, id, event.Get("Ce-Id"), key)
+		assert.Equal(t, "inference-1", event.Get("Ce-Inferenceid"), key)
+		assert.Equal(t, "logged-graph", event.Get("Ce-Inferencegraphname"), key)
+		assert.Equal(t, "logged-namespace", event.Get("Ce-Namespace"), key)
+	}
+	assert.Empty(t, received[kfslogger.CEInferenceRequest+"/"].Values("Ce-Step"))
+	assert.Equal(t, "application/json", received[kfslogger.CEInferenceResponse+"/logged-model"].Get("Content-Type"))
+}
+
+func TestGraphLoggerErrorsMode(t *testing.T) {
+	events := startEventReceiver(t, v1alpha1.InferenceGraphLogger{Mode: v1alpha1.GraphLogErrors})
+	model, _ := newFlakyModel(t, 0)
+	failing, _ := newFlakyModel(t, 1)
+	defer func() { inferenceGraph = nil }()
+	for _, step := range []struct {
+		url  string
+		code int
+		id   string
+	}{
+		{url: model.URL, code: 200, id: "inference-ok"},
+		{url: failing.URL, code: 503, id: "inference-failed"},
+	} {
+		graphSpec := makeStepGraph(v1alpha1.GraphRootNodeName, v1alpha1.InferenceStep{
+			StepName:        "errors-model",
+			InferenceTarget: v1alpha1.InferenceTarget{ServiceURL: step.url},
+		})
+		inferenceGraph = &graphSpec
+		request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"instances": [1]}`))
+		request.Header.Set(kfslogger.CloudEventsIdHeader, step.id)
+		recorder := httptest.NewRecorder()
+		graphHandler(recorder, request)
+		assert.Equal(t, step.code, recorder.Code)
+	}
+
+	// only the failed inference is logged, its steps are not
+	received := receiveEvents(t, events, 2)
+	for _, key := range []string{kfslogger.CEInferenceRequest + "/", kfslogger.CEInferenceResponse + "/"} {
+		require.Contains(t, received, key)
+		assert.Equal(t, "inference-failed", received[key].Get("Ce-Inferenceid"), key)
+	}
+}
@@ -18,6 +18,7 @@ package main
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	goerrors "errors"
 	"fmt"
@@ -31,6 +32,7 @@ import (
 
 	"github.com/kserve/kserve/pkg/constants"
 	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 
 	"github.com/tidwall/gjson"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
@@ -45,30 +47,38 @@ import (
 
 var log = logf.Log.WithName("InferenceGraphRouter")
 
-func callService(serviceUrl string, input []byte, headers http.Header) ([]byte, int, error) {
+const defaultRetryBackoff = 100 * time.Millisecond
+
+func callService(ctx context.Context, serviceUrl string, input []byte, headers http.Header, extraHeaders http.Header) ([]byte, int, error) {
 	defer timeTrack(time.Now(), "step", serviceUrl)
 	log.Info("Entering callService", "url", serviceUrl)
-	req, err := http.NewRequest("POST", serviceUrl, bytes.NewBuffer(input))
+	resp, statusCode, err := sendServiceRequest(ctx, serviceUrl, input, headers, extraHeaders)
+	if err != nil {
+		return nil, statusCode, err
+	}
+	defer closeResponseBody(resp)
+	return readServiceResponse(resp)
+}
+
+// sendServiceRequest posts the input to the service with the propagated and the extra headers
+func sendServiceRequest(ctx context.Context, serviceUrl string, input []byte, headers http.Header, extraHeaders http.Header) (*http.Response, int, error) {
+	req, err := http.NewRequestWithContext(ctx, "POST", serviceUrl, bytes.NewBuffer(input))
 	if err != nil {
 		log.Error(err, "An error occurred while preparing request object with serviceUrl.", "serviceUrl", serviceUrl)
 		return nil, 500, err
 	}
 
-	// To avoid headers matched more than one time which will lead to duplication of header values
-	matchedHeaders := map[string]bool{}
 	var headersToPropagate []string
-	for _, p := range compiledHeaderPatterns {
-		for h, values := range headers {
-			if _, ok := matchedHeaders[h]; !ok && p.MatchString(h) {
-				matchedHeaders[h] = true
-				headersToPropagate = append(headersToPropagate, h)
-				for _, v := range values {
-					req.Header.Add(h, v)
-				}
-			}
+	for h, values := range propagatedHeaders(headers) {
+		headersToPropagate = append(headersToPropagate, h)
+		for _, v := range values {
+			req.Header.Add(h, v)
 		}
 	}
 	log.Info("These headers will be propagated by the router to all the steps", "headers", headersToPropagate)
+	for h, values := range extraHeaders {
+		req.Header[h] = values
+	}
 	if val := req.Header.Get("Content-Type"); val == "" {
 		req.Header.Add("Content-Type", "application/json")
 	}
@@ -76,27 +86,49 @@ func callService(serviceUrl string, input []byte, headers http.Header) ([]byte,
 
 	if err != nil {
 		log.Error(err, "An error has occurred while calling service", "service", serviceUrl)
+		if goerrors.Is(err, context.DeadlineExceeded) {
+			return nil, 504, err
+		}
 		return nil, 500, err
 	}
+	return resp, resp.StatusCode, nil
+}
 
-	defer func() {
-		if resp.Body != nil {
-			err := resp.Body.Close()
-			if err != nil {
-				log.Error(err, "An error has occurred while closing the response body")
-			}
+func closeResponseBody(resp *http.Response) {
+	if resp.Body != nil {
+		err := resp.Body.Close()
+		if err != nil {
+			log.Error(err, "An error has occurred while closing the response body")
 		}
-	}()
+	}
+}
 
+func readServiceResponse(resp *http.Response) ([]byte, int, error) {
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
 		log.Error(err, "Error while reading the response")
+		if goerrors.Is(err, context.DeadlineExceeded) {
+			return body, 504, err
+		}
 	}
 	return body, resp.StatusCode, err
 }
 
+func propagatedHeaders(headers http.Header) http.Header {
+	propagated := http.Header{}
+	for _, p := range compiledHeaderPatterns {
+		for h, values := range headers {
+			// To avoid headers matched more than one time which will lead to duplication of header values
+			if _, ok := propagated[h]; !ok && p.MatchString(h) {
+				propagated[h] = values
+			}
+		}
+	}
+	return propagated
+}
+
 func pickupRoute(routes []v1alpha1.InferenceStep) *v1alpha1.InferenceStep {
-	randomNumber, err := rand.Int(rand.Reader, big.NewInt(101))
+	randomNumber, err := rand.Int(rand.Reader, big.NewInt(100))
 	if err != nil {
 		panic(err)
 	}
@@ -117,6 +149,12 @@ func pickupRouteByCondition(input []byte, routes []v1alpha1.InferenceStep) *v1al
 		return nil
 	}
 	for _, route := range routes {
+		if route.ConditionLanguage == v1alpha1.ConditionLanguageCEL {
+			if matchCELCondition(v1alpha1.Switch, &route, input, nil) {
+				return &route
+			}
+			continue
+		}
 		if gjson.GetBytes(input, route.Condition).Exists() {
 			return &route
 		}
@@ -135,7 +173,7 @@ type EnsembleStepOutput struct {
 }
 
 // See if reviewer suggests a better name for this function
-func handleSplitterORSwitchNode(route *v1alpha1.InferenceStep, graph v1alpha1.InferenceGraphSpec, input []byte, headers http.Header) ([]byte, int, error) {
+func handleSplitterORSwitchNode(ctx context.Context, nodeName string, route *v1alpha1.InferenceStep, graph v1alpha1.InferenceGraphSpec, input []byte, headers http.Header) ([]byte, int, error) {
 	var statusCode int
 	var responseBytes []byte
 	var err error
@@ -144,8 +182,8 @@ func handleSplitterORSwitchNode(route *v1alpha1.InferenceStep, graph v1alpha1.In
 		stepType = "node"
 	}
 	log.Info("Starting execution of step", "type", stepType, "stepName", route.StepName)
-	if responseBytes, statusCode, err = executeStep(route, graph, input, headers); err != nil {
-		return nil, 500, err
+	if responseBytes, statusCode, err = executeStep(ctx, nodeName, route, graph, input, headers); err != nil {
+		return nil, statusCode, err
 	}
 
 	if route.Dependency == v1alpha1.Hard && !isSuccessFul(statusCode) {
@@ -154,13 +192,13 @@ func handleSplitterORSwitchNode(route *v1alpha1.InferenceStep, graph v1alpha1.In
 	return responseBytes, statusCode, nil
 }
 
-func routeStep(nodeName string, graph v1alpha1.InferenceGraphSpec, input []byte, headers http.Header) ([]byte, int, error) {
+func routeStep(ctx context.Context, nodeName string, graph v1alpha1.InferenceGraphSpec, input []byte, headers http.Header) ([]byte, int, error) {
 	defer timeTrack(time.Now(), "node", nodeName)
 	currentNode := graph.Nodes[nodeName]
 
 	if currentNode.RouterType == v1alpha1.Splitter {
-		route := pickupRoute(currentNode.Steps)
-		return handleSplitterORSwitchNode(route, graph, input, headers)
+		route := pickupSplitterRoute(nodeName, currentNode, headers)
+		return handleSplitterORSwitchNode(ctx, nodeName, route, graph, input, headers)
 	}
 	if currentNode.RouterType == v1alpha1.Switch {
 		var err error
@@ -171,7 +209,7 @@ func routeStep(nodeName string, graph v1alpha1.InferenceGraphSpec, input []byte,
 			log.Error(err, errorMessage)
 			return nil, 404, err
 		}
-		return handleSplitterORSwitchNode(route, graph, input, headers)
+		return handleSplitterORSwitchNode(ctx, nodeName, route, graph, input, headers)
 	}
 	if currentNode.RouterType == v1alpha1.Ensemble {
 		ensembleRes := make([]chan EnsembleStepOutput, len(currentNode.Steps))
@@ -186,7 +224,7 @@ func routeStep(nodeName string, graph v1alpha1.InferenceGraphSpec, input []byte,
 			resultChan := make(chan EnsembleStepOutput)
 			ensembleRes[i] = resultChan
 			go func() {
-				output, statusCode, err := executeStep(step, graph, input, headers)
+				output, statusCode, err := executeStep(ctx, nodeName, step, graph, input, headers)
 				if err == nil {
 					var res map[string]interface{}
 					if err = json.Unmarshal(output, &res); err == nil {
@@ -201,7 +239,8 @@ func routeStep(nodeName string, graph v1alpha1.InferenceGraphSpec, input []byte,
 			}()
 		}
 		// merge responses from parallel steps
-		response := map[string]interface{}{}
+		keys := make([]string, len(ensembleRes))
+		outputs := make([]EnsembleStepOutput, len(ensembleRes))
 		ensembleStepOutput := EnsembleStepOutput{}
 		for i, resultChan := range ensembleRes {
 			key := currentNode.Steps[i].StepName
@@ -215,15 +254,14 @@ func routeStep(nodeName string, graph v1alpha1.InferenceGraphSpec, input []byte,
 					stepResponse, _ := json.Marshal(ensembleStepOutput.StepResponse) // TODO check if you need err handling for Marshalling
 					return stepResponse, ensembleStepOutput.StepStatusCode, nil      // First failed hard dependency will decide the response and response code for ensemble node
 				} else {
-					response[key] = ensembleStepOutput.StepResponse
+					keys[i] = key
+					outputs[i] = ensembleStepOutput
 				}
 			case err := <-errChan:
 				return nil, 500, err
 			}
 		}
-		// return json.Marshal(response)
-		combinedResponse, _ := json.Marshal(response) // TODO check if you need err handling for Marshalling
-		return combinedResponse, 200, nil
+		return mergeEnsembleResponses(ctx, currentNode.Merge, keys, outputs, headers)
 	}
 	if currentNode.RouterType == v1alpha1.Sequence {
 		var statusCode int
@@ -242,7 +280,12 @@ func routeStep(nodeName string, graph v1alpha1.InferenceGraphSpec, input []byte,
 				request = responseBytes
 			}
 
-			if step.Condition != "" {
+			if step.Condition != "" && step.ConditionLanguage == v1alpha1.ConditionLanguageCEL {
+				// if the condition does not match for the step in the sequence we stop and return the response
+				if !matchCELCondition(v1alpha1.Sequence, step, input, responseBytes) {
+					return responseBytes, 500, nil
+				}
+			} else if step.Condition != "" {
 				if !gjson.ValidBytes(responseBytes) {
 					return nil, 500, fmt.Errorf("invalid response")
 				}
@@ -251,8 +294,8 @@ func routeStep(nodeName string, graph v1alpha1.InferenceGraphSpec, input []byte,
 					return responseBytes, 500, nil
 				}
 			}
-			if responseBytes, statusCode, err = executeStep(step, graph, request, headers); err != nil {
-				return nil, 500, err
+			if responseBytes, statusCode, err = executeStep(ctx, nodeName, step, graph, request, headers); err != nil {
+				return nil, statusCode, err
 			}
 			/*
 			   Only if a step is a hard dependency, we will check for its success.
@@ -279,18 +322,145 @@ func isSuccessFul(statusCode int) bool {
 	return false
 }
 
-func executeStep(step *v1alpha1.InferenceStep, graph v1alpha1.InferenceGraphSpec, input []byte, headers http.Header) ([]byte, int, error) {
+// stepLabel identifies a step within its node for the metrics and the circuit breakers
+func stepLabel(step *v1alpha1.InferenceStep) string {
+	if step.StepName != "" {
+		return step.StepName
+	}
+	if step.NodeName != "" {
+		return step.NodeName
+	}
+	return step.ServiceURL
+}
+
+// stepFailureReason returns the reason why an attempt of a step failed, or an empty string when it succeeded
+func stepFailureReason(statusCode int, err error) string {
+	switch {
+	case goerrors.Is(err, context.DeadlineExceeded):
+		return failureReasonTimeout
+	case err != nil:
+		return failureReasonError
+	case statusCode == http.StatusTooManyRequests || statusCode >= 500:
+		return failureReasonStatus
+	}
+	return ""
+}
+
+// executeStep calls the step and logs its request and response when the graph logger logs the steps
+func executeStep(ctx context.Context, nodeName string, step *v1alpha1.InferenceStep, graph v1alpha1.InferenceGraphSpec, input []byte, headers http.Header) ([]byte, int, error) {
+	inference := stepLog(ctx, nodeName, stepLabel(step))
+	inference.logRequest("application/json", input)
+	responseBytes, statusCode, err := executeStepWithPolicies(ctx, nodeName, step, graph, input, headers)
+	if stream := responseStreamFrom(ctx); stream != nil && stream.started {
+		// the streamed response has been forwarded to the client without being kept
+		return responseBytes, statusCode, err
+	}
+	if err != nil {
+		inference.logResponse("application/json", input, statusCode, prepareErrorResponse(err, "Failed to execute step"))
+	} else {
+		inference.logResponse("application/json", input, statusCode, responseBytes)
+	}
+	return responseBytes, statusCode, err
+}
+
+// executeStepWithPolicies calls the step, retrying the failed attempts according to its retry policy. While the
+// circuit breaker of the step is open the fallback response is returned, or the step fails immediately when there
+// is none.
+func executeStepWithPolicies(ctx context.Context, nodeName string, step *v1alpha1.InferenceStep, graph v1alpha1.InferenceGraphSpec, input []byte, headers http.Header) ([]byte, int, error) {
+	name := stepLabel(step)
+	defer func(start time.Time) {
+		stepDuration.WithLabelValues(nodeName, name).Observe(time.Since(start).Seconds())
+	}(time.Now())
+
+	cache := stepCaches.get(nodeName, name, step)
+	cacheKey := cache.key(input, propagatedHeaders(headers))
+	if cached, ok := cache.load(ctx, cacheKey); ok {
+		recordCacheHit(ctx, nodeName, name)
+		return cached.Body, cached.StatusCode, nil
+	}
+
+	breaker := circuitBreakers.get(nodeName, name, step.CircuitBreaker)
+	if !breaker.allow() {
+		stepFailures.WithLabelValues(nodeName, name, failureReasonCircuitOpen).Inc()
+		if step.CircuitBreaker.Fallback != "" {
+			log.Info("Circuit breaker is open, returning the fallback response", "node", nodeName, "stepName", name)
+			return []byte(step.CircuitBreaker.Fallback), 200, nil
+		}
+		return nil, 503, fmt.Errorf("circuit breaker of step %q in node %q is open", name, nodeName)
+	}
+
+	attempts := 1
+	backoff := defaultRetryBackoff
+	if step.Retry != nil {
+		attempts = int(step.Retry.Attempts)
+		if step.Retry.BackoffMilliseconds != nil {
+			backoff = time.Duration(*step.Retry.BackoffMilliseconds) * time.Millisecond
+		}
+	}
+	var responseBytes []byte
+	var statusCode int
+	var err error
+	for attempt := 1; ; attempt++ {
+		responseBytes, statusCode, err = executeStepAttempt(ctx, step, graph, input, headers)
+		reason := stepFailureReason(statusCode, err)
+		if reason == "" {
+			breaker.success()
+			cache.store(ctx, cacheKey, statusCode, responseBytes)
+			return responseBytes, statusCode, nil
+		}
+		stepFailures.WithLabelValues(nodeName, name, reason).Inc()
+		if attempt >= attempts {
+			break
+		}
+		log.Info("Retrying step", "node", nodeName, "stepName", name, "attempt", attempt, "statusCode", statusCode, "backoff", backoff)
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			breaker.failure()
+			return nil, 500, ctx.Err()
+		}
+		backoff *= 2
+		stepRetries.WithLabelValues(nodeName, name).Inc()
+	}
+	breaker.failure()
+	return responseBytes, statusCode, err
+}
+
+func executeStepAttempt(ctx context.Context, step *v1alpha1.InferenceStep, graph v1alpha1.InferenceGraphSpec, input []byte, headers http.Header) ([]byte, int, error) {
+	if step.TimeoutSeconds != nil {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(*step.TimeoutSeconds)*time.Second)
+		defer cancel()
+	}
 	if step.NodeName != "" {
 		// when nodeName is specified make a recursive call for routing to next step
-		return routeStep(step.NodeName, graph, input, headers)
+		return routeStep(ctx, step.NodeName, graph, input, headers)
 	}
-	return callService(step.ServiceURL, input, headers)
+	authHeaders, err := stepAuthHeaders(step)
+	if err != nil {
+		return nil, 500, err
+	}
+	if step.Protocol == v1alpha1.StepProtocolGRPCV2 {
+		return callGRPCService(ctx, step, input, headers, authHeaders)
+	}
+	if step.Streaming {
+		return callStreamingService(ctx, step.ServiceURL, input, headers, authHeaders)
+	}
+	return callService(ctx, step.ServiceURL, input, headers, authHeaders)
 }
 
 func prepareErrorResponse(err error, errorMessage string) []byte {
 	igRoutingErr := &InferenceGraphRoutingError{
-		errorMessage,
-		fmt.Sprintf("%v", err),
+		ErrorMessage: errorMessage,
+		Cause:        fmt.Sprintf("%v", err),
+	}
+	var conversionErr *ConversionError
+	if goerrors.As(err, &conversionErr) {
+		igRoutingErr.Field = conversionErr.Field
+	}
+	var mergeErr *MergeError
+	if goerrors.As(err, &mergeErr) {
+		igRoutingErr.Field = mergeErr.Field
 	}
 	errorResponseBytes, err := json.Marshal(igRoutingErr)
 	if err != nil {
@@ -303,14 +473,36 @@ var inferenceGraph *v1alpha1.InferenceGraphSpec
 
 func graphHandler(w http.ResponseWriter, req *http.Request) {
 	inputBytes, _ := io.ReadAll(req.Body)
-	if response, statusCode, err := routeStep(v1alpha1.GraphRootNodeName, *inferenceGraph, inputBytes, req.Header); err != nil {
+	ctx := req.Context()
+	if inferenceGraph.TimeoutSeconds != nil {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(*inferenceGraph.TimeoutSeconds)*time.Second)
+		defer cancel()
+	}
+	ctx, hits := withCacheHits(ctx)
+	ctx, stream := withResponseStream(ctx, w)
+	ctx, inference := requestLogger.withInferenceLog(ctx, req.Header)
+	requestContentType := req.Header.Get("Content-Type")
+	inference.logRequest(requestContentType, inputBytes)
+	response, statusCode, err := routeStep(ctx, v1alpha1.GraphRootNodeName, *inferenceGraph, inputBytes, req.Header)
+	if stream.started {
+		// the response of the streaming step has already been forwarded to the client
+		return
+	}
+	if cached := hits.header(); cached != "" {
+		w.Header().Set(cacheHitsHeader, cached)
+	}
+	if err != nil {
 		log.Error(err, "failed to process request")
+		errorResponse := prepareErrorResponse(err, "Failed to process request")
+		inference.logResponse(requestContentType, inputBytes, statusCode, errorResponse)
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(statusCode)
-		if _, err := w.Write(prepareErrorResponse(err, "Failed to process request")); err != nil {
+		if _, err := w.Write(errorResponse); err != nil {
 			log.Error(err, "failed to write graphHandler response")
 		}
 	} else {
+		inference.logResponse(requestContentType, inputBytes, statusCode, response)
 		if json.Valid(response) {
 			w.Header().Set("Content-Type", "application/json")
 		}
@@ -321,6 +513,23 @@ func graphHandler(w http.ResponseWriter, req *http.Request) {
 	}
 }
 
+// readyHandler reports the router ready once the inference graph is loaded
+func readyHandler(w http.ResponseWriter, _ *http.Request) {
+	if inferenceGraph == nil {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// routerHandler serves the readiness endpoint and routes all the other requests through the inference graph
+func routerHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(constants.RouterReadinessPath, readyHandler)
+	mux.HandleFunc("/", graphHandler)
+	return mux
+}
+
 func compilePatterns(patterns []string) ([]*regexp.Regexp, error) {
 	var allErrors []error
 	var compiled []*regexp.Regexp
@@ -335,8 +544,23 @@ func compilePatterns(patterns []string) ([]*regexp.Regexp, error) {
 	return compiled, goerrors.Join(allErrors...)
 }
 
+// addGraphHeaderPatterns adds the header patterns of the graph to the ones configured for the router
+func addGraphHeaderPatterns(graph *v1alpha1.InferenceGraphSpec) {
+	if len(graph.PropagateHeaders) == 0 {
+		return
+	}
+	log.Info("The headers that will match the patterns of the graph will be propagated by the router to all the steps",
+		"propagateHeaders", graph.PropagateHeaders)
+	patterns, err := compilePatterns(graph.PropagateHeaders)
+	if err != nil {
+		log.Error(err, "Failed to compile some header patterns of the graph")
+	}
+	compiledHeaderPatterns = append(compiledHeaderPatterns, patterns...)
+}
+
 var (
 	jsonGraph              = flag.String("graph-json", "", "serialized json graph def")
+	metricsPort            = flag.Int("metrics-port", 9091, "port of the metrics endpoint")
 	compiledHeaderPatterns []*regexp.Regexp
 )
 
@@ -358,15 +582,44 @@ func main() {
 		log.Error(err, "failed to unmarshall inference graph json")
 		os.Exit(1)
 	}
+	addGraphHeaderPatterns(inferenceGraph)
+	if err := conditions.compileGraph(inferenceGraph); err != nil {
+		log.Error(err, "failed to compile the conditions of the inference graph")
+		os.Exit(1)
+	}
+	if inferenceGraph.Cache != nil && inferenceGraph.Cache.Redis != nil {
+		log.Info("Caching the step responses in redis", "address", inferenceGraph.Cache.Redis.Address)
+		stepCaches.redis = newRedisCache(inferenceGraph.Cache.Redis, os.Getenv(constants.RouterRedisPasswordEnvVar))
+	}
+	if inferenceGraph.Logger != nil {
+		log.Info("Logging the inferences of the graph", "url", inferenceGraph.Logger.URL, "mode", inferenceGraph.Logger.Mode,
+			"steps", inferenceGraph.Logger.Steps)
+		graphName, namespace := os.Getenv(constants.RouterGraphNameEnvVar), os.Getenv(constants.RouterGraphNamespaceEnvVar)
+		if err := startGraphLogger(inferenceGraph.Logger, graphName, namespace); err != nil {
+			log.Error(err, "failed to start the logger of the inference graph")
+			os.Exit(1)
+		}
+	}
 
-	http.HandleFunc("/", graphHandler)
+	go func() {
+		mux := http.NewServeMux()
+		mux.Handle("/metrics", promhttp.HandlerFor(metricsRegistry, promhttp.HandlerOpts{}))
+		metricsServer := &http.Server{
+			Addr:              fmt.Sprintf(":%d", *metricsPort),
+			Handler:           mux,
+			ReadHeaderTimeout: time.Minute,
+		}
+		if err := metricsServer.ListenAndServe(); err != nil {
+			log.Error(err, "failed to serve metrics", "port", *metricsPort)
+		}
+	}()
 
 	server := &http.Server{
-		Addr:         ":8080",                        // specify the address and port
-		Handler:      http.HandlerFunc(graphHandler), // specify your HTTP handler
-		ReadTimeout:  time.Minute,                    // set the maximum duration for reading the entire request, including the body
-		WriteTimeout: time.Minute,                    // set the maximum duration before timing out writes of the response
-		IdleTimeout:  3 * time.Minute,                // set the maximum amount of time to wait for the next request when keep-alives are enabled
+		Addr:         ":8080",         // specify the address and port
+		Handler:      routerHandler(), // specify your HTTP handler
+		ReadTimeout:  time.Minute,     // set the maximum duration for reading the entire request, including the body
+		WriteTimeout: time.Minute,     // set the maximum duration before timing out writes of the response
+		IdleTimeout:  3 * time.Minute, // set the maximum amount of time to wait for the next request when keep-alives are enabled
 	}
 	err = server.ListenAndServe()
 
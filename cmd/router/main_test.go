@@ -17,10 +17,13 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
 	"io"
 	"knative.dev/pkg/apis"
 	"net/http"
@@ -28,7 +31,10 @@ import (
 	"regexp"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 )
 
 func init() {
@@ -99,7 +105,7 @@ func TestSimpleModelChainer(t *testing.T) {
 		"Authorization": {"Bearer Token"},
 	}
 
-	res, _, err := routeStep("root", graphSpec, jsonBytes, headers)
+	res, _, err := routeStep(context.Background(), "root", graphSpec, jsonBytes, headers)
 	var response map[string]interface{}
 	err = json.Unmarshal(res, &response)
 	expectedResponse := map[string]interface{}{
@@ -171,7 +177,7 @@ func TestSimpleModelEnsemble(t *testing.T) {
 	headers := http.Header{
 		"Authorization": {"Bearer Token"},
 	}
-	res, _, err := routeStep("root", graphSpec, jsonBytes, headers)
+	res, _, err := routeStep(context.Background(), "root", graphSpec, jsonBytes, headers)
 	var response map[string]interface{}
 	err = json.Unmarshal(res, &response)
 	expectedResponse := map[string]interface{}{
@@ -350,7 +356,7 @@ func TestInferenceGraphWithCondition(t *testing.T) {
 	headers := http.Header{
 		"Authorization": {"Bearer Token"},
 	}
-	res, _, err := routeStep("root", graphSpec, jsonBytes, headers)
+	res, _, err := routeStep(context.Background(), "root", graphSpec, jsonBytes, headers)
 	var response map[string]interface{}
 	err = json.Unmarshal(res, &response)
 	expectedModel3Response := map[string]interface{}{
@@ -420,7 +426,7 @@ func TestCallServiceWhenNoneHeadersToPropagateIsEmpty(t *testing.T) {
 	}
 	// Propagating no header
 	compiledHeaderPatterns = []*regexp.Regexp{}
-	res, _, err := callService(model1Url.String(), jsonBytes, headers)
+	res, _, err := callService(context.Background(), model1Url.String(), jsonBytes, headers, nil)
 	var response map[string]interface{}
 	err = json.Unmarshal(res, &response)
 	expectedResponse := map[string]interface{}{
@@ -474,7 +480,7 @@ func TestCallServiceWhen1HeaderToPropagate(t *testing.T) {
 	compiledHeaderPatterns, err = compilePatterns(headersToPropagate)
 	assert.Nil(t, err)
 
-	res, _, err := callService(model1Url.String(), jsonBytes, headers)
+	res, _, err := callService(context.Background(), model1Url.String(), jsonBytes, headers, nil)
 	var response map[string]interface{}
 	err = json.Unmarshal(res, &response)
 	expectedResponse := map[string]interface{}{
@@ -529,7 +535,7 @@ func TestCallServiceWhenMultipleHeadersToPropagate(t *testing.T) {
 	compiledHeaderPatterns, err = compilePatterns(headersToPropagate)
 	assert.Nil(t, err)
 
-	res, _, err := callService(model1Url.String(), jsonBytes, headers)
+	res, _, err := callService(context.Background(), model1Url.String(), jsonBytes, headers, nil)
 	var response map[string]interface{}
 	err = json.Unmarshal(res, &response)
 	expectedResponse := map[string]interface{}{
@@ -543,7 +549,7 @@ func TestCallServiceWhenMultipleHeadersToPropagate(t *testing.T) {
 
 func TestMalformedURL(t *testing.T) {
 	malformedURL := "http://single-1.default.{$your-domain}/switch"
-	_, response, err := callService(malformedURL, []byte{}, http.Header{})
+	_, response, err := callService(context.Background(), malformedURL, []byte{}, http.Header{}, nil)
 	if err != nil {
 		assert.Equal(t, 500, response)
 	}
@@ -595,7 +601,7 @@ func TestCallServiceWhenMultipleHeadersToPropagateUsingPatterns(t *testing.T) {
 	compiledHeaderPatterns, err = compilePatterns(headersToPropagate)
 	assert.Nil(t, err)
 
-	res, _, err := callService(model1Url.String(), jsonBytes, headers)
+	res, _, err := callService(context.Background(), model1Url.String(), jsonBytes, headers, nil)
 	var response map[string]interface{}
 	err = json.Unmarshal(res, &response)
 	expectedResponse := map[string]interface{}{
@@ -655,7 +661,7 @@ func TestCallServiceWhenMultipleHeadersToPropagateUsingInvalidPattern(t *testing
 	compiledHeaderPatterns, err = compilePatterns(headersToPropagate)
 	assert.NotNil(t, err)
 
-	res, _, err := callService(model1Url.String(), jsonBytes, headers)
+	res, _, err := callService(context.Background(), model1Url.String(), jsonBytes, headers, nil)
 	var response map[string]interface{}
 	err = json.Unmarshal(res, &response)
 	// Invalid pattern should be ignored.
@@ -666,3 +672,199 @@ func TestCallServiceWhenMultipleHeadersToPropagateUsingInvalidPattern(t *testing
 	fmt.Printf("final response:%v\n", response)
 	assert.Equal(t, expectedResponse, response)
 }
+
+// newFlakyModel starts a model which responds with the 503 status code to the first failures calls
+func newFlakyModel(t *testing.T, failures int32) (*httptest.Server, *int32) {
+	calls := new(int32)
+	model := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if atomic.AddInt32(calls, 1) <= atomic.LoadInt32(&failures) {
+			rw.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = rw.Write([]byte(`{"predictions": "1"}`))
+	}))
+	t.Cleanup(model.Close)
+	return model, calls
+}
+
+func makeStepGraph(nodeName string, step v1alpha1.InferenceStep) v1alpha1.InferenceGraphSpec {
+	return v1alpha1.InferenceGraphSpec{
+		Nodes: map[string]v1alpha1.InferenceRouter{
+			nodeName: {
+				RouterType: v1alpha1.Sequence,
+				Steps:      []v1alpha1.InferenceStep{step},
+			},
+		},
+	}
+}
+
+func TestStepRetrySucceeds(t *testing.T) {
+	model, calls := newFlakyModel(t, 2)
+	graphSpec := makeStepGraph("retry-succeeds", v1alpha1.InferenceStep{
+		StepName:        "model1",
+		InferenceTarget: v1alpha1.InferenceTarget{ServiceURL: model.URL},
+		Retry: &v1alpha1.InferenceStepRetry{
+			Attempts:            3,
+			BackoffMilliseconds: proto.Int64(1),
+		},
+	})
+	retries := testutil.ToFloat64(stepRetries.WithLabelValues("retry-succeeds", "model1"))
+
+	res, statusCode, err := routeStep(context.Background(), "retry-succeeds", graphSpec, []byte(`{}`), http.Header{})
+	assert.Nil(t, err)
+	assert.Equal(t, 200, statusCode)
+	assert.JSONEq(t, `{"predictions": "1"}`, string(res))
+	assert.Equal(t, int32(3), atomic.LoadInt32(calls))
+	assert.Equal(t, float64(2), testutil.ToFloat64(stepRetries.WithLabelValues("retry-succeeds", "model1"))-retries)
+}
+
+func TestStepRetryExhausted(t *testing.T) {
+	model, calls := newFlakyModel(t, 5)
+	graphSpec := makeStepGraph("retry-exhausted", v1alpha1.InferenceStep{
+		StepName:        "model1",
+		InferenceTarget: v1alpha1.InferenceTarget{ServiceURL: model.URL},
+		Retry: &v1alpha1.InferenceStepRetry{
+			Attempts:            2,
+			BackoffMilliseconds: proto.Int64(1),
+		},
+	})
+	failures := testutil.ToFloat64(stepFailures.WithLabelValues("retry-exhausted", "model1", failureReasonStatus))
+
+	_, statusCode, err := routeStep(context.Background(), "retry-exhausted", graphSpec, []byte(`{}`), http.Header{})
+	assert.Nil(t, err)
+	assert.Equal(t, 503, statusCode)
+	assert.Equal(t, int32(2), atomic.LoadInt32(calls))
+	assert.Equal(t, float64(2), testutil.ToFloat64(stepFailures.WithLabelValues("retry-exhausted", "model1", failureReasonStatus))-failures)
+}
+
+func TestStepTimeout(t *testing.T) {
+	model := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = io.ReadAll(req.Body)
+		select {
+		case <-time.After(2 * time.Second):
+		case <-req.Context().Done():
+		}
+	}))
+	defer model.Close()
+	graphSpec := makeStepGraph("timeout", v1alpha1.InferenceStep{
+		StepName:        "model1",
+		InferenceTarget: v1alpha1.InferenceTarget{ServiceURL: model.URL},
+		TimeoutSeconds:  proto.Int64(1),
+	})
+	failures := testutil.ToFloat64(stepFailures.WithLabelValues("timeout", "model1", failureReasonTimeout))
+
+	_, statusCode, err := routeStep(context.Background(), "timeout", graphSpec, []byte(`{}`), http.Header{})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, 504, statusCode)
+	assert.Equal(t, float64(1), testutil.ToFloat64(stepFailures.WithLabelValues("timeout", "model1", failureReasonTimeout))-failures)
+}
+
+func TestStepCircuitBreaker(t *testing.T) {
+	defer func() { now = time.Now }()
+	current := time.Now()
+	now = func() time.Time { return current }
+
+	scenarios := map[string]struct {
+		fallback           string
+		expectedStatusCode int
+		expectedResponse   string
+	}{
+		"fallback": {
+			fallback:           `{"predictions": "fallback"}`,
+			expectedStatusCode: 200,
+			expectedResponse:   `{"predictions": "fallback"}`,
+		},
+		"no fallback": {
+			expectedStatusCode: 503,
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			model, calls := newFlakyModel(t, 3)
+			nodeName := "circuit-breaker-" + name
+			graphSpec := makeStepGraph(nodeName, v1alpha1.InferenceStep{
+				StepName:        "model1",
+				InferenceTarget: v1alpha1.InferenceTarget{ServiceURL: model.URL},
+				CircuitBreaker: &v1alpha1.InferenceStepCircuitBreaker{
+					ConsecutiveFailures: 2,
+					CooldownSeconds:     proto.Int64(10),
+					Fallback:            scenario.fallback,
+				},
+			})
+			call := func() ([]byte, int, error) {
+				return routeStep(context.Background(), nodeName, graphSpec, []byte(`{}`), http.Header{})
+			}
+
+			// the circuit opens after 2 consecutive failures
+			for i := 0; i < 2; i++ {
+				_, statusCode, err := call()
+				assert.Nil(t, err)
+				assert.Equal(t, 503, statusCode)
+			}
+			res, statusCode, err := call()
+			assert.Equal(t, scenario.expectedStatusCode, statusCode)
+			if scenario.fallback != "" {
+				assert.Nil(t, err)
+				assert.JSONEq(t, scenario.expectedResponse, string(res))
+			} else {
+				assert.NotNil(t, err)
+			}
+			assert.Equal(t, int32(2), atomic.LoadInt32(calls))
+
+			// the probe after the cooldown fails and opens the circuit again
+			current = current.Add(11 * time.Second)
+			_, statusCode, _ = call()
+			assert.Equal(t, 503, statusCode)
+			assert.Equal(t, int32(3), atomic.LoadInt32(calls))
+			_, statusCode, _ = call()
+			assert.Equal(t, scenario.expectedStatusCode, statusCode)
+			assert.Equal(t, int32(3), atomic.LoadInt32(calls))
+
+			// the successful probe closes the circuit
+			current = current.Add(11 * time.Second)
+			for i := 0; i < 2; i++ {
+				res, statusCode, err = call()
+				assert.Nil(t, err)
+				assert.Equal(t, 200, statusCode)
+				assert.JSONEq(t, `{"predictions": "1"}`, string(res))
+			}
+			assert.Equal(t, int32(5), atomic.LoadInt32(calls))
+		})
+	}
+}
+
+func TestReadinessEndpoint(t *testing.T) {
+	router := httptest.NewServer(routerHandler())
+	defer router.Close()
+
+	inferenceGraph = nil
+	resp, err := http.Get(router.URL + "/readyz")
+	assert.Nil(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+
+	model := newStaticModel(t, `{"predictions": [1]}`)
+	inferenceGraph = &v1alpha1.InferenceGraphSpec{
+		Nodes: map[string]v1alpha1.InferenceRouter{
+			v1alpha1.GraphRootNodeName: {
+				RouterType: v1alpha1.Sequence,
+				Steps: []v1alpha1.InferenceStep{
+					{StepName: "model", InferenceTarget: v1alpha1.InferenceTarget{ServiceURL: model.URL}},
+				},
+			},
+		},
+	}
+	defer func() { inferenceGraph = nil }()
+	resp, err = http.Get(router.URL + "/readyz")
+	assert.Nil(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// the other paths are routed through the graph
+	resp, err = http.Post(router.URL+"/v1/models/model:predict", "application/json", strings.NewReader(`{"instances": [1]}`))
+	assert.Nil(t, err)
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.JSONEq(t, `{"predictions": [1]}`, string(body))
+}
@@ -0,0 +1,266 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+)
+
+// ensembleStepValue is the value of the merge field in the response of an ensemble step
+type ensembleStepValue struct {
+	step  string
+	value interface{}
+}
+
+// mergeEnsembleResponses combines the responses of the steps of an ensemble node, keys holds the names of the
+// steps in the order of the steps.
+func mergeEnsembleResponses(ctx context.Context, merge *v1alpha1.EnsembleMerge, keys []string, outputs []EnsembleStepOutput, headers http.Header) ([]byte, int, error) {
+	aggregated := map[string]interface{}{}
+	for i, key := range keys {
+		aggregated[key] = outputs[i].StepResponse
+	}
+	if merge == nil {
+		return marshalMergedResponse(aggregated)
+	}
+	switch merge.Strategy {
+	case v1alpha1.MergeMajorityVote, v1alpha1.MergeAverage:
+		field := merge.Field
+		if field == "" {
+			field = v1alpha1.DefaultEnsembleMergeField
+		}
+		response, values, err := ensembleFieldValues(field, keys, outputs)
+		var merged interface{}
+		if err == nil && merge.Strategy == v1alpha1.MergeMajorityVote {
+			merged, err = majorityVote(field, values)
+		} else if err == nil {
+			merged, err = averageValues(field, values)
+		}
+		if err != nil {
+			log.Error(err, "Failed to merge the responses of the ensemble steps", "strategy", merge.Strategy)
+			return nil, 500, err
+		}
+		setFieldValue(response, field, merged)
+		return marshalMergedResponse(response)
+	case v1alpha1.MergeCustom:
+		input, err := json.Marshal(aggregated)
+		if err != nil {
+			return nil, 500, err
+		}
+		return callService(ctx, merge.CombinerURL, input, headers, nil)
+	}
+	return marshalMergedResponse(aggregated)
+}
+
+func marshalMergedResponse(response interface{}) ([]byte, int, error) {
+	merged, err := json.Marshal(response)
+	if err != nil {
+		return nil, 500, err
+	}
+	return merged, 200, nil
+}
+
+// ensembleFieldValues returns a copy of the response of the first successful step and the values of the field in
+// the responses of the successful steps, unsuccessful soft dependencies do not take part in the merge.
+func ensembleFieldValues(field string, keys []string, outputs []EnsembleStepOutput) (map[string]interface{}, []ensembleStepValue, error) {
+	var first map[string]interface{}
+	var values []ensembleStepValue
+	for i, output := range outputs {
+		if !isSuccessFul(output.StepStatusCode) {
+			continue
+		}
+		value, ok := fieldValue(output.StepResponse, field)
+		if !ok {
+			return nil, nil, &MergeError{Field: field, Reason: fmt.Sprintf("missing in the response of step %q", keys[i])}
+		}
+		if first == nil {
+			first = output.StepResponse
+		}
+		values = append(values, ensembleStepValue{step: keys[i], value: value})
+	}
+	if len(values) == 0 {
+		return nil, nil, &MergeError{Field: field, Reason: "none of the steps returned a successful response"}
+	}
+	response, err := deepCopyJSON(first)
+	if err != nil {
+		return nil, nil, err
+	}
+	return response.(map[string]interface{}), values, nil
+}
+
+func deepCopyJSON(value interface{}) (interface{}, error) {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var copied interface{}
+	err = json.Unmarshal(encoded, &copied)
+	return copied, err
+}
+
+// fieldValue walks the dot separated path of object keys and array indices
+func fieldValue(response interface{}, field string) (interface{}, bool) {
+	value := response
+	for _, segment := range strings.Split(field, ".") {
+		switch current := value.(type) {
+		case map[string]interface{}:
+			var ok bool
+			if value, ok = current[segment]; !ok {
+				return nil, false
+			}
+		case []interface{}:
+			index, err := strconv.Atoi(segment)
+			if err != nil || index < 0 || index >= len(current) {
+				return nil, false
+			}
+			value = current[index]
+		default:
+			return nil, false
+		}
+	}
+	return value, true
+}
+
+// setFieldValue replaces the value at the path, which must exist in the response
+func setFieldValue(response map[string]interface{}, field string, value interface{}) {
+	segments := strings.Split(field, ".")
+	parentPath := strings.Join(segments[:len(segments)-1], ".")
+	parent := interface{}(response)
+	if parentPath != "" {
+		parent, _ = fieldValue(response, parentPath)
+	}
+	last := segments[len(segments)-1]
+	switch current := parent.(type) {
+	case map[string]interface{}:
+		current[last] = value
+	case []interface{}:
+		index, _ := strconv.Atoi(last)
+		current[index] = value
+	}
+}
+
+// majorityVote returns the value returned by most of the steps, arrays are voted on element-wise. A tie is won by
+// the value of the earliest step among the tied values.
+func majorityVote(field string, values []ensembleStepValue) (interface{}, error) {
+	arrays, err := ensembleArrays(field, values)
+	if err != nil {
+		return nil, err
+	}
+	if arrays == nil {
+		return vote(values), nil
+	}
+	merged := make([]interface{}, len(arrays[0]))
+	for j := range merged {
+		elements := make([]ensembleStepValue, len(values))
+		for k := range values {
+			elements[k] = ensembleStepValue{step: values[k].step, value: arrays[k][j]}
+		}
+		merged[j] = vote(elements)
+	}
+	return merged, nil
+}
+
+func vote(values []ensembleStepValue) interface{} {
+	// values are compared by their JSON encoding, maps are encoded with sorted keys
+	encoded := make([]string, len(values))
+	counts := map[string]int{}
+	best := 0
+	for k, v := range values {
+		key, _ := json.Marshal(v.value)
+		encoded[k] = string(key)
+		counts[encoded[k]]++
+		if counts[encoded[k]] > best {
+			best = counts[encoded[k]]
+		}
+	}
+	for k, v := range values {
+		if counts[encoded[k]] == best {
+			return v.value
+		}
+	}
+	return nil
+}
+
+// ensembleArrays returns the values as arrays of the same length, or nil when none of the values is an array
+func ensembleArrays(field string, values []ensembleStepValue) ([][]interface{}, error) {
+	arrays := make([][]interface{}, len(values))
+	count := 0
+	for k, v := range values {
+		if array, ok := v.value.([]interface{}); ok {
+			arrays[k] = array
+			count++
+		}
+	}
+	if count == 0 {
+		return nil, nil
+	}
+	for k, array := range arrays {
+		if array == nil {
+			return nil, &MergeError{Field: field, Reason: fmt.Sprintf("step %q did not return an array like step %q", values[k].step, firstArrayStep(values, arrays))}
+		}
+		if len(array) != len(arrays[0]) {
+			return nil, &MergeError{Field: field, Reason: fmt.Sprintf("step %q returned %d values but step %q returned %d",
+				values[k].step, len(array), values[0].step, len(arrays[0]))}
+		}
+	}
+	return arrays, nil
+}
+
+func firstArrayStep(values []ensembleStepValue, arrays [][]interface{}) string {
+	for k, array := range arrays {
+		if array != nil {
+			return values[k].step
+		}
+	}
+	return ""
+}
+
+// averageValues returns the element-wise average of the numbers or the nested numeric arrays of the same shape
+func averageValues(field string, values []ensembleStepValue) (interface{}, error) {
+	arrays, err := ensembleArrays(field, values)
+	if err != nil {
+		return nil, err
+	}
+	if arrays == nil {
+		sum := 0.0
+		for _, v := range values {
+			number, ok := v.value.(float64)
+			if !ok {
+				return nil, &MergeError{Field: field, Reason: fmt.Sprintf("step %q returned a non numeric value", v.step)}
+			}
+			sum += number
+		}
+		return sum / float64(len(values)), nil
+	}
+	merged := make([]interface{}, len(arrays[0]))
+	for j := range merged {
+		elements := make([]ensembleStepValue, len(values))
+		for k := range values {
+			elements[k] = ensembleStepValue{step: values[k].step, value: arrays[k][j]}
+		}
+		if merged[j], err = averageValues(fmt.Sprintf("%s.%d", field, j), elements); err != nil {
+			return nil, err
+		}
+	}
+	return merged, nil
+}
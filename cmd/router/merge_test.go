@@ -0,0 +1,231 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+)
+
+// makeEnsembleGraph builds an ensemble node calling a static model per response
+func makeEnsembleGraph(t *testing.T, nodeName string, merge *v1alpha1.EnsembleMerge, responses ...string) v1alpha1.InferenceGraphSpec {
+	steps := make([]v1alpha1.InferenceStep, len(responses))
+	for i, response := range responses {
+		model := newStaticModel(t, response)
+		steps[i] = v1alpha1.InferenceStep{
+			StepName:        string(rune('a' + i)),
+			InferenceTarget: v1alpha1.InferenceTarget{ServiceURL: model.URL},
+		}
+	}
+	return v1alpha1.InferenceGraphSpec{
+		Nodes: map[string]v1alpha1.InferenceRouter{
+			nodeName: {
+				RouterType: v1alpha1.Ensemble,
+				Steps:      steps,
+				Merge:      merge,
+			},
+		},
+	}
+}
+
+func TestEnsembleMergeStrategies(t *testing.T) {
+	scenarios := map[string]struct {
+		merge            *v1alpha1.EnsembleMerge
+		responses        []string
+		expectedResponse string
+	}{
+		"aggregate by default": {
+			responses:        []string{`{"predictions": [1]}`, `{"predictions": [0]}`},
+			expectedResponse: `{"a": {"predictions": [1]}, "b": {"predictions": [0]}}`,
+		},
+		"aggregate": {
+			merge:            &v1alpha1.EnsembleMerge{Strategy: v1alpha1.MergeAggregate},
+			responses:        []string{`{"predictions": [1]}`, `{"predictions": [0]}`},
+			expectedResponse: `{"a": {"predictions": [1]}, "b": {"predictions": [0]}}`,
+		},
+		"majority vote over predictions": {
+			merge:            &v1alpha1.EnsembleMerge{Strategy: v1alpha1.MergeMajorityVote},
+			responses:        []string{`{"model": "a", "predictions": [1, 0, 1]}`, `{"model": "b", "predictions": [1, 1, 0]}`, `{"model": "c", "predictions": [0, 1, 1]}`},
+			expectedResponse: `{"model": "a", "predictions": [1, 1, 1]}`,
+		},
+		"majority vote over a nested field": {
+			merge: &v1alpha1.EnsembleMerge{Strategy: v1alpha1.MergeMajorityVote, Field: "outputs.0.data"},
+			responses: []string{
+				`{"outputs": [{"name": "label", "data": ["cat"]}]}`,
+				`{"outputs": [{"name": "label", "data": ["dog"]}]}`,
+				`{"outputs": [{"name": "label", "data": ["dog"]}]}`,
+			},
+			expectedResponse: `{"outputs": [{"name": "label", "data": ["dog"]}]}`,
+		},
+		"average of probabilities": {
+			merge:            &v1alpha1.EnsembleMerge{Strategy: v1alpha1.MergeAverage},
+			responses:        []string{`{"predictions": [[0.25, 0.75], [1, 0]]}`, `{"predictions": [[0.5, 0.5], [0, 1]]}`},
+			expectedResponse: `{"predictions": [[0.375, 0.625], [0.5, 0.5]]}`,
+		},
+		"average of scalars": {
+			merge:            &v1alpha1.EnsembleMerge{Strategy: v1alpha1.MergeAverage, Field: "score"},
+			responses:        []string{`{"score": 1}`, `{"score": 2}`, `{"score": 6}`},
+			expectedResponse: `{"score": 3}`,
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			graph := makeEnsembleGraph(t, "merge-strategies", scenario.merge, scenario.responses...)
+			res, statusCode, err := routeStep(context.Background(), "merge-strategies", graph, []byte(`{"instances": [1]}`), http.Header{})
+			assert.Nil(t, err)
+			assert.Equal(t, 200, statusCode)
+			assert.JSONEq(t, scenario.expectedResponse, string(res))
+		})
+	}
+}
+
+func TestEnsembleMergeErrors(t *testing.T) {
+	scenarios := map[string]struct {
+		merge         *v1alpha1.EnsembleMerge
+		responses     []string
+		expectedError string
+	}{
+		"vote over mismatched array lengths": {
+			merge:         &v1alpha1.EnsembleMerge{Strategy: v1alpha1.MergeMajorityVote},
+			responses:     []string{`{"predictions": [1, 0]}`, `{"predictions": [1, 0, 1]}`},
+			expectedError: `predictions: step "b" returned 3 values but step "a" returned 2`,
+		},
+		"average over mismatched array lengths": {
+			merge:         &v1alpha1.EnsembleMerge{Strategy: v1alpha1.MergeAverage},
+			responses:     []string{`{"predictions": [[0.5, 0.5]]}`, `{"predictions": [[0.2, 0.3, 0.5]]}`},
+			expectedError: `predictions.0: step "b" returned 3 values but step "a" returned 2`,
+		},
+		"array and scalar": {
+			merge:         &v1alpha1.EnsembleMerge{Strategy: v1alpha1.MergeMajorityVote},
+			responses:     []string{`{"predictions": 1}`, `{"predictions": [1]}`},
+			expectedError: `predictions: step "a" did not return an array like step "b"`,
+		},
+		"average of labels": {
+			merge:         &v1alpha1.EnsembleMerge{Strategy: v1alpha1.MergeAverage},
+			responses:     []string{`{"predictions": ["cat"]}`, `{"predictions": ["dog"]}`},
+			expectedError: `predictions.0: step "a" returned a non numeric value`,
+		},
+		"missing field": {
+			merge:         &v1alpha1.EnsembleMerge{Strategy: v1alpha1.MergeAverage, Field: "outputs.0.data"},
+			responses:     []string{`{"outputs": [{"data": [1]}]}`, `{"outputs": []}`},
+			expectedError: `outputs.0.data: missing in the response of step "b"`,
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			graph := makeEnsembleGraph(t, "merge-errors", scenario.merge, scenario.responses...)
+			_, statusCode, err := routeStep(context.Background(), "merge-errors", graph, []byte(`{"instances": [1]}`), http.Header{})
+			assert.Equal(t, 500, statusCode)
+			assert.EqualError(t, err, scenario.expectedError)
+		})
+	}
+}
+
+func TestMajorityVoteTies(t *testing.T) {
+	scenarios := map[string]struct {
+		values   []ensembleStepValue
+		expected interface{}
+	}{
+		"scalar tie is won by the earliest step": {
+			values:   []ensembleStepValue{{"a", "dog"}, {"b", "cat"}, {"c", "cat"}, {"d", "dog"}},
+			expected: "dog",
+		},
+		"element-wise ties": {
+			values: []ensembleStepValue{
+				{"a", []interface{}{1.0, 0.0}},
+				{"b", []interface{}{0.0, 1.0}},
+			},
+			expected: []interface{}{1.0, 0.0},
+		},
+		"majority wins over the earliest step": {
+			values:   []ensembleStepValue{{"a", "dog"}, {"b", "cat"}, {"c", "cat"}},
+			expected: "cat",
+		},
+		"objects are compared by value": {
+			values: []ensembleStepValue{
+				{"a", map[string]interface{}{"label": "cat", "score": 1.0}},
+				{"b", map[string]interface{}{"score": 1.0, "label": "dog"}},
+				{"c", map[string]interface{}{"score": 1.0, "label": "dog"}},
+			},
+			expected: map[string]interface{}{"label": "dog", "score": 1.0},
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			merged, err := majorityVote("predictions", scenario.values)
+			assert.Nil(t, err)
+			assert.Equal(t, scenario.expected, merged)
+		})
+	}
+}
+
+func TestEnsembleMergeSkipsFailedSoftSteps(t *testing.T) {
+	failing := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusInternalServerError)
+		_, _ = rw.Write([]byte(`{"error": "model unavailable"}`))
+	}))
+	defer failing.Close()
+	graph := makeEnsembleGraph(t, "merge-soft", &v1alpha1.EnsembleMerge{Strategy: v1alpha1.MergeAverage},
+		`{"predictions": [1]}`, `{"predictions": [3]}`)
+	node := graph.Nodes["merge-soft"]
+	node.Steps = append([]v1alpha1.InferenceStep{{
+		StepName:        "failing",
+		InferenceTarget: v1alpha1.InferenceTarget{ServiceURL: failing.URL},
+		Dependency:      v1alpha1.Soft,
+	}}, node.Steps...)
+	graph.Nodes["merge-soft"] = node
+
+	res, statusCode, err := routeStep(context.Background(), "merge-soft", graph, []byte(`{"instances": [1]}`), http.Header{})
+	assert.Nil(t, err)
+	assert.Equal(t, 200, statusCode)
+	assert.JSONEq(t, `{"predictions": [2]}`, string(res))
+}
+
+func TestEnsembleCustomMerge(t *testing.T) {
+	var received map[string]interface{}
+	combiner := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+		_ = json.Unmarshal(body, &received)
+		rw.WriteHeader(http.StatusAccepted)
+		_, _ = rw.Write([]byte(`{"predictions": ["combined"]}`))
+	}))
+	defer combiner.Close()
+	graph := makeEnsembleGraph(t, "merge-custom", &v1alpha1.EnsembleMerge{Strategy: v1alpha1.MergeCustom, CombinerURL: combiner.URL},
+		`{"predictions": [1]}`, `{"predictions": [0]}`)
+
+	res, statusCode, err := routeStep(context.Background(), "merge-custom", graph, []byte(`{"instances": [1]}`), http.Header{})
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusAccepted, statusCode)
+	assert.JSONEq(t, `{"predictions": ["combined"]}`, string(res))
+	assert.Equal(t, map[string]interface{}{
+		"a": map[string]interface{}{"predictions": []interface{}{1.0}},
+		"b": map[string]interface{}{"predictions": []interface{}{0.0}},
+	}, received)
+}
+
+func TestMergeErrorResponse(t *testing.T) {
+	response := prepareErrorResponse(&MergeError{Field: "predictions", Reason: "step \"b\" returned 3 values but step \"a\" returned 2"}, "Failed to process request")
+	assert.JSONEq(t, `{"error": "Failed to process request", "cause": "predictions: step \"b\" returned 3 values but step \"a\" returned 2", "field": "predictions"}`, string(response))
+}
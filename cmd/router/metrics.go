@@ -0,0 +1,65 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	failureReasonError       = "error"
+	failureReasonTimeout     = "timeout"
+	failureReasonStatus      = "status"
+	failureReasonCircuitOpen = "circuit_open"
+
+	cacheResultHit   = "hit"
+	cacheResultMiss  = "miss"
+	cacheResultError = "error"
+
+	splitterAssignmentSticky = "sticky"
+	splitterAssignmentRandom = "random"
+)
+
+var (
+	metricsRegistry = prometheus.NewRegistry()
+
+	stepDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "kserve_router_step_duration_seconds",
+		Help:    "Duration of the inference graph steps, including retries",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"node", "step"})
+	stepFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "kserve_router_step_failures_total",
+		Help: "Number of failed attempts of the inference graph steps and of calls rejected by an open circuit breaker",
+	}, []string{"node", "step", "reason"})
+	stepRetries = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "kserve_router_step_retries_total",
+		Help: "Number of retried attempts of the inference graph steps",
+	}, []string{"node", "step"})
+	stepCacheRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "kserve_router_step_cache_requests_total",
+		Help: "Number of cache lookups of the inference graph steps by result, and of failed cache writes",
+	}, []string{"node", "step", "result"})
+	splitterRoutes = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "kserve_router_splitter_routes_total",
+		Help: "Number of requests routed to the steps of the splitter nodes, by assignment of the step from the request key or at random",
+	}, []string{"node", "step", "assignment"})
+)
+
+func init() {
+	metricsRegistry.MustRegister(stepDuration, stepFailures, stepRetries, stepCacheRequests, splitterRoutes)
+}
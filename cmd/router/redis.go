@@ -0,0 +1,121 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+)
+
+const (
+	redisTimeout = 200 * time.Millisecond
+	// redisRetryInterval is how long the steps are called directly after the redis server failed
+	redisRetryInterval = 5 * time.Second
+)
+
+var errRedisUnavailable = errors.New("redis cache is unavailable")
+
+// redisCache stores the cached responses in a redis server shared by the router replicas. The
+// client keeps a pool of connections, so that the concurrent steps do not wait for each other.
+type redisCache struct {
+	client *redis.Client
+
+	mu               sync.Mutex
+	unavailableUntil time.Time
+}
+
+func newRedisCache(spec *v1alpha1.RedisCache, password string) *redisCache {
+	options := &redis.Options{
+		Addr:         spec.Address,
+		Password:     password,
+		DB:           int(spec.Database),
+		DialTimeout:  redisTimeout,
+		ReadTimeout:  redisTimeout,
+		WriteTimeout: redisTimeout,
+		// a failed cache access falls back to calling the step, retrying it only delays the request
+		MaxRetries:      -1,
+		DisableIdentity: true,
+	}
+	if spec.TLS != nil {
+		options.TLSConfig = &tls.Config{
+			MinVersion:         tls.VersionTLS12,
+			ServerName:         spec.TLS.ServerName,
+			InsecureSkipVerify: spec.TLS.InsecureSkipVerify, // #nosec G402
+		}
+	}
+	return &redisCache{client: redis.NewClient(options)}
+}
+
+func (c *redisCache) get(ctx context.Context, key string) (*cachedResponse, bool, error) {
+	if !c.available() {
+		return nil, false, errRedisUnavailable
+	}
+	value, err := c.client.Get(ctx, key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, false, nil
+	}
+	if err != nil {
+		c.failed(err)
+		return nil, false, err
+	}
+	response := &cachedResponse{}
+	if err := json.Unmarshal(value, response); err != nil {
+		return nil, false, errors.Wrap(err, "failed to decode the cached response")
+	}
+	return response, true, nil
+}
+
+func (c *redisCache) set(ctx context.Context, key string, response *cachedResponse, ttl time.Duration) error {
+	if !c.available() {
+		return errRedisUnavailable
+	}
+	value, err := json.Marshal(response)
+	if err != nil {
+		return err
+	}
+	if err := c.client.Set(ctx, key, value, ttl).Err(); err != nil {
+		c.failed(err)
+		return err
+	}
+	return nil
+}
+
+func (c *redisCache) available() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return !now().Before(c.unavailableUntil)
+}
+
+// failed stops using the redis server for the retry interval after a connection failure, so that the
+// steps are called directly meanwhile. An error reply of the server leaves the server in use.
+func (c *redisCache) failed(err error) {
+	var redisErr redis.Error
+	if errors.As(err, &redisErr) {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.unavailableUntil = now().Add(redisRetryInterval)
+}
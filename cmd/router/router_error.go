@@ -21,8 +21,30 @@ import "fmt"
 type InferenceGraphRoutingError struct {
 	ErrorMessage string `json:"error"`
 	Cause        string `json:"cause"`
+	Field        string `json:"field,omitempty"`
 }
 
 func (e *InferenceGraphRoutingError) Error() string {
 	return fmt.Sprintf("%s. %s", e.ErrorMessage, e.Cause)
 }
+
+// ConversionError reports the field of a request or response which cannot be converted between the
+// JSON and gRPC encodings of the open inference protocol
+type ConversionError struct {
+	Field  string
+	Reason string
+}
+
+func (e *ConversionError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Field, e.Reason)
+}
+
+// MergeError reports the field of the step responses which cannot be merged by the strategy of an ensemble node
+type MergeError struct {
+	Field  string
+	Reason string
+}
+
+func (e *MergeError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Field, e.Reason)
+}
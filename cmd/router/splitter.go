@@ -0,0 +1,83 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"math"
+	"net/http"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+)
+
+// pickupSplitterRoute picks the step of a splitter node, from the key of the request when the node is sticky and
+// the request has a key, or randomly according to the weights of the steps otherwise
+func pickupSplitterRoute(nodeName string, node v1alpha1.InferenceRouter, headers http.Header) *v1alpha1.InferenceStep {
+	var route *v1alpha1.InferenceStep
+	assignment := splitterAssignmentRandom
+	if node.Stickiness != nil {
+		if key := stickyKey(node.Stickiness, headers); key != "" {
+			route = pickupStickyRoute(key, node.Steps)
+			assignment = splitterAssignmentSticky
+		}
+	}
+	if route == nil {
+		route = pickupRoute(node.Steps)
+		assignment = splitterAssignmentRandom
+	}
+	if route != nil {
+		splitterRoutes.WithLabelValues(nodeName, stepLabel(route), assignment).Inc()
+	}
+	return route
+}
+
+// stickyKey returns the value of the header or of the cookie holding the key of the request, or an empty string
+// when the request has none
+func stickyKey(stickiness *v1alpha1.SplitterStickiness, headers http.Header) string {
+	if stickiness.Header != "" {
+		return headers.Get(stickiness.Header)
+	}
+	request := http.Request{Header: headers}
+	if cookie, err := request.Cookie(stickiness.Cookie); err == nil {
+		return cookie.Value
+	}
+	return ""
+}
+
+// pickupStickyRoute assigns the key to a step by weighted rendezvous hashing. Every step scores the key with a hash
+// of the key and of the step scaled by its weight, and the step with the highest score is picked, so that the keys
+// are spread according to the weights and a key only moves when the weights change in favor of another step.
+func pickupStickyRoute(key string, routes []v1alpha1.InferenceStep) *v1alpha1.InferenceStep {
+	var picked *v1alpha1.InferenceStep
+	bestScore := math.Inf(-1)
+	for i := range routes {
+		route := &routes[i]
+		if route.Weight == nil || *route.Weight <= 0 {
+			continue
+		}
+		sum := sha256.Sum256([]byte(key + "\x00" + stepLabel(route)))
+		// uniform in (0, 1), the score -weight/ln(u) is the highest for a step with a probability proportional
+		// to its weight
+		u := (float64(binary.BigEndian.Uint64(sum[:8])>>11) + 0.5) / (1 << 53)
+		if score := -float64(*route.Weight) / math.Log(u); score > bestScore {
+			bestScore = score
+			picked = route
+		}
+	}
+	return picked
+}
@@ -0,0 +1,163 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+)
+
+func makeWeightedSteps(weights ...int64) []v1alpha1.InferenceStep {
+	steps := make([]v1alpha1.InferenceStep, len(weights))
+	for i, weight := range weights {
+		steps[i] = v1alpha1.InferenceStep{
+			StepName:        fmt.Sprintf("branch-%d", i),
+			InferenceTarget: v1alpha1.InferenceTarget{ServiceURL: fmt.Sprintf("http://branch-%d", i)},
+			Weight:          proto.Int64(weight),
+		}
+	}
+	return steps
+}
+
+func TestPickupStickyRouteDistribution(t *testing.T) {
+	steps := makeWeightedSteps(70, 20, 10)
+	const keys = 20000
+	counts := map[string]int{}
+	for i := 0; i < keys; i++ {
+		counts[pickupStickyRoute(fmt.Sprintf("user-%d", i), steps).StepName]++
+	}
+	for i, share := range []float64{0.7, 0.2, 0.1} {
+		assert.InDelta(t, share, float64(counts[fmt.Sprintf("branch-%d", i)])/keys, 0.015, "branch-%d", i)
+	}
+}
+
+func TestPickupStickyRouteReshuffling(t *testing.T) {
+	before := makeWeightedSteps(50, 50)
+	after := makeWeightedSteps(60, 40)
+	const keys = 20000
+	moved := 0
+	for i := 0; i < keys; i++ {
+		key := fmt.Sprintf("user-%d", i)
+		from, to := pickupStickyRoute(key, before).StepName, pickupStickyRoute(key, after).StepName
+		if from != to {
+			// the keys only move to the step whose weight increased
+			assert.Equal(t, "branch-1", from, key)
+			moved++
+		}
+	}
+	// only the keys needed to shift 10% of the traffic are reassigned
+	assert.InDelta(t, 0.1, float64(moved)/keys, 0.015)
+}
+
+func TestPickupStickyRouteSkipsZeroWeights(t *testing.T) {
+	steps := makeWeightedSteps(0, 100)
+	for i := 0; i < 100; i++ {
+		assert.Equal(t, "branch-1", pickupStickyRoute(fmt.Sprintf("user-%d", i), steps).StepName)
+	}
+}
+
+func TestStickySplitterRouting(t *testing.T) {
+	modelA := newStaticModel(t, `{"predictions": "A"}`)
+	modelB := newStaticModel(t, `{"predictions": "B"}`)
+	for _, scenario := range []struct {
+		name       string
+		stickiness v1alpha1.SplitterStickiness
+		setKey     func(req *http.Request, key string)
+	}{
+		{
+			name:       "sticky-header",
+			stickiness: v1alpha1.SplitterStickiness{Header: "X-User-Id"},
+			setKey:     func(req *http.Request, key string) { req.Header.Set("X-User-Id", key) },
+		},
+		{
+			name:       "sticky-cookie",
+			stickiness: v1alpha1.SplitterStickiness{Cookie: "session"},
+			setKey:     func(req *http.Request, key string) { req.AddCookie(&http.Cookie{Name: "session", Value: key}) },
+		},
+	} {
+		t.Run(scenario.name, func(t *testing.T) {
+			stickiness := scenario.stickiness
+			inferenceGraph = &v1alpha1.InferenceGraphSpec{
+				Nodes: map[string]v1alpha1.InferenceRouter{
+					v1alpha1.GraphRootNodeName: {
+						RouterType: v1alpha1.Splitter,
+						Steps: []v1alpha1.InferenceStep{
+							{
+								StepName:        scenario.name + "-a",
+								InferenceTarget: v1alpha1.InferenceTarget{ServiceURL: modelA.URL},
+								Weight:          proto.Int64(50),
+							},
+							{
+								StepName:        scenario.name + "-b",
+								InferenceTarget: v1alpha1.InferenceTarget{ServiceURL: modelB.URL},
+								Weight:          proto.Int64(50),
+							},
+						},
+						Stickiness: &stickiness,
+					},
+				},
+			}
+			defer func() { inferenceGraph = nil }()
+
+			responses := map[string]int{}
+			for user := 0; user < 20; user++ {
+				key := fmt.Sprintf("user-%d", user)
+				var first string
+				for call := 0; call < 5; call++ {
+					req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"instances": [1]}`))
+					scenario.setKey(req, key)
+					recorder := httptest.NewRecorder()
+					graphHandler(recorder, req)
+					assert.Equal(t, 200, recorder.Code)
+					if call == 0 {
+						first = recorder.Body.String()
+						responses[first]++
+					}
+					assert.Equal(t, first, recorder.Body.String(), "user %s changed branch", key)
+				}
+			}
+			// the users are spread over both branches
+			assert.Len(t, responses, 2)
+
+			sticky := testutil.ToFloat64(splitterRoutes.WithLabelValues(v1alpha1.GraphRootNodeName, scenario.name+"-a", splitterAssignmentSticky)) +
+				testutil.ToFloat64(splitterRoutes.WithLabelValues(v1alpha1.GraphRootNodeName, scenario.name+"-b", splitterAssignmentSticky))
+			assert.Equal(t, float64(100), sticky)
+		})
+	}
+}
+
+func TestStickySplitterWithoutKey(t *testing.T) {
+	steps := makeWeightedSteps(100)
+	node := v1alpha1.InferenceRouter{
+		RouterType: v1alpha1.Splitter,
+		Steps:      steps,
+		Stickiness: &v1alpha1.SplitterStickiness{Header: "X-User-Id"},
+	}
+	random := testutil.ToFloat64(splitterRoutes.WithLabelValues("sticky-without-key", "branch-0", splitterAssignmentRandom))
+	route := pickupSplitterRoute("sticky-without-key", node, http.Header{})
+	assert.Equal(t, "branch-0", route.StepName)
+	assert.Equal(t, random+1, testutil.ToFloat64(splitterRoutes.WithLabelValues("sticky-without-key", "branch-0", splitterAssignmentRandom)))
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const eventStreamMediaType = "text/event-stream"
+
+type responseStreamKey struct{}
+
+// responseStream lets the streaming step of a request write its response to the client as it arrives,
+// validation guarantees that only the terminal step of the graph streams
+type responseStream struct {
+	writer  http.ResponseWriter
+	started bool
+}
+
+func withResponseStream(ctx context.Context, writer http.ResponseWriter) (context.Context, *responseStream) {
+	stream := &responseStream{writer: writer}
+	return context.WithValue(ctx, responseStreamKey{}, stream), stream
+}
+
+func responseStreamFrom(ctx context.Context) *responseStream {
+	stream, _ := ctx.Value(responseStreamKey{}).(*responseStream)
+	return stream
+}
+
+// isStreamingResponse reports whether the step responded with server-sent events, or with a chunked
+// response to a client accepting server-sent events
+func isStreamingResponse(resp *http.Response, headers http.Header) bool {
+	if mediaType, _, err := mime.ParseMediaType(resp.Header.Get("Content-Type")); err == nil && mediaType == eventStreamMediaType {
+		return true
+	}
+	chunked := len(resp.TransferEncoding) > 0 && resp.TransferEncoding[0] == "chunked"
+	return chunked && strings.Contains(headers.Get("Accept"), eventStreamMediaType)
+}
+
+// callStreamingService calls the service of a streaming step. A streamed response is forwarded to the client and
+// not returned, any other response is read as usual.
+func callStreamingService(ctx context.Context, serviceUrl string, input []byte, headers http.Header, extraHeaders http.Header) ([]byte, int, error) {
+	defer timeTrack(time.Now(), "step", serviceUrl)
+	log.Info("Entering callStreamingService", "url", serviceUrl)
+	if accept := headers.Get("Accept"); accept != "" {
+		extraHeaders = extraHeaders.Clone()
+		if extraHeaders == nil {
+			extraHeaders = http.Header{}
+		}
+		extraHeaders.Set("Accept", accept)
+	}
+	resp, statusCode, err := sendServiceRequest(ctx, serviceUrl, input, headers, extraHeaders)
+	if err != nil {
+		return nil, statusCode, err
+	}
+	defer closeResponseBody(resp)
+	stream := responseStreamFrom(ctx)
+	if stream == nil || !isStreamingResponse(resp, headers) {
+		return readServiceResponse(resp)
+	}
+	stream.forward(ctx, resp)
+	return nil, resp.StatusCode, nil
+}
+
+// forward copies the response to the client, flushing every chunk as soon as it is read. The copy stops when the
+// client disconnects or the graph times out, errors are only logged as the status has already been sent.
+func (s *responseStream) forward(ctx context.Context, resp *http.Response) {
+	s.started = true
+	controller := http.NewResponseController(s.writer)
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := controller.SetWriteDeadline(deadline); err != nil && !errors.Is(err, http.ErrNotSupported) {
+			log.Error(err, "failed to extend the write deadline of the stream")
+		}
+	}
+	s.writer.Header().Set("Content-Type", resp.Header.Get("Content-Type"))
+	s.writer.Header().Set("Cache-Control", "no-cache")
+	if hits, ok := ctx.Value(cacheHitsKey{}).(*cacheHits); ok {
+		if cached := hits.header(); cached != "" {
+			s.writer.Header().Set(cacheHitsHeader, cached)
+		}
+	}
+	s.writer.WriteHeader(resp.StatusCode)
+
+	buffer := make([]byte, 32*1024)
+	for {
+		n, err := resp.Body.Read(buffer)
+		if n > 0 {
+			if _, writeErr := s.writer.Write(buffer[:n]); writeErr != nil {
+				log.Info("The client stopped reading the stream", "error", writeErr.Error())
+				return
+			}
+			if flushErr := controller.Flush(); flushErr != nil {
+				log.Error(flushErr, "failed to flush the stream")
+				return
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			return
+		}
+		if err != nil {
+			log.Info("The stream was interrupted", "error", err.Error())
+			return
+		}
+	}
+}
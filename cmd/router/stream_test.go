@@ -0,0 +1,215 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+)
+
+// newSSEModel streams the events, waiting for a value on next before sending each event after the first one. The
+// done channel is closed when the request of the router is cancelled or completed.
+func newSSEModel(t *testing.T, events []string, next <-chan struct{}) (*httptest.Server, <-chan struct{}) {
+	done := make(chan struct{})
+	model := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		defer close(done)
+		rw.Header().Set("Content-Type", "text/event-stream")
+		for i, event := range events {
+			if i > 0 {
+				select {
+				case <-next:
+				case <-req.Context().Done():
+					return
+				}
+			}
+			_, _ = fmt.Fprintf(rw, "data: %s\n\n", event)
+			rw.(http.Flusher).Flush()
+		}
+	}))
+	t.Cleanup(model.Close)
+	return model, done
+}
+
+func startStreamingGraph(t *testing.T, modelURL string, timeoutSeconds *int64) *httptest.Server {
+	preprocessor := newStaticModel(t, `{"instances": ["prompt"]}`)
+	inferenceGraph = &v1alpha1.InferenceGraphSpec{
+		Nodes: map[string]v1alpha1.InferenceRouter{
+			v1alpha1.GraphRootNodeName: {
+				RouterType: v1alpha1.Sequence,
+				Steps: []v1alpha1.InferenceStep{
+					{
+						StepName:        "preprocessor",
+						InferenceTarget: v1alpha1.InferenceTarget{ServiceURL: preprocessor.URL},
+					},
+					{
+						StepName:        "llm",
+						InferenceTarget: v1alpha1.InferenceTarget{ServiceURL: modelURL},
+						Data:            "$response",
+						Streaming:       true,
+					},
+				},
+			},
+		},
+		TimeoutSeconds: timeoutSeconds,
+	}
+	router := httptest.NewServer(http.HandlerFunc(graphHandler))
+	t.Cleanup(func() {
+		router.Close()
+		inferenceGraph = nil
+	})
+	return router
+}
+
+func postStream(ctx context.Context, t *testing.T, url string) *http.Response {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, strings.NewReader(`{"instances": ["text"]}`))
+	assert.Nil(t, err)
+	req.Header.Set("Accept", "text/event-stream")
+	resp, err := http.DefaultClient.Do(req)
+	assert.Nil(t, err)
+	return resp
+}
+
+func readEvent(t *testing.T, reader *bufio.Reader) string {
+	line, err := reader.ReadString('\n')
+	assert.Nil(t, err)
+	blank, err := reader.ReadString('\n')
+	assert.Nil(t, err)
+	assert.Equal(t, "\n", blank)
+	return strings.TrimSuffix(line, "\n")
+}
+
+func TestStreamingStepForwardsChunks(t *testing.T) {
+	next := make(chan struct{})
+	model, done := newSSEModel(t, []string{"Hello", "world", "[DONE]"}, next)
+	router := startStreamingGraph(t, model.URL, nil)
+
+	resp := postStream(context.Background(), t, router.URL)
+	defer resp.Body.Close()
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	// every event reaches the client before the model sends the next one
+	reader := bufio.NewReader(resp.Body)
+	assert.Equal(t, "data: Hello", readEvent(t, reader))
+	next <- struct{}{}
+	assert.Equal(t, "data: world", readEvent(t, reader))
+	next <- struct{}{}
+	assert.Equal(t, "data: [DONE]", readEvent(t, reader))
+	rest, err := io.ReadAll(reader)
+	assert.Nil(t, err)
+	assert.Empty(t, rest)
+	<-done
+}
+
+func TestStreamingStepClientDisconnect(t *testing.T) {
+	model, done := newSSEModel(t, []string{"Hello", "world"}, make(chan struct{}))
+	router := startStreamingGraph(t, model.URL, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	resp := postStream(ctx, t, router.URL)
+	defer resp.Body.Close()
+	assert.Equal(t, "data: Hello", readEvent(t, bufio.NewReader(resp.Body)))
+
+	// the router cancels the call of the model when the client goes away
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the request of the model was not cancelled after the client disconnected")
+	}
+}
+
+func TestStreamingStepTimeout(t *testing.T) {
+	model, done := newSSEModel(t, []string{"Hello", "world"}, make(chan struct{}))
+	router := startStreamingGraph(t, model.URL, proto.Int64(1))
+
+	start := time.Now()
+	resp := postStream(context.Background(), t, router.URL)
+	defer resp.Body.Close()
+	reader := bufio.NewReader(resp.Body)
+	assert.Equal(t, "data: Hello", readEvent(t, reader))
+
+	// the stream ends when the timeout of the graph expires
+	_, _ = io.ReadAll(reader)
+	<-done
+	assert.Less(t, time.Since(start), 5*time.Second)
+}
+
+func TestStreamingStepBufferedResponse(t *testing.T) {
+	model := newStaticModel(t, `{"predictions": ["text"]}`)
+	router := startStreamingGraph(t, model.URL, nil)
+
+	resp := postStream(context.Background(), t, router.URL)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	assert.Nil(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+	assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+	assert.JSONEq(t, `{"predictions": ["text"]}`, string(body))
+}
+
+func TestIsStreamingResponse(t *testing.T) {
+	scenarios := map[string]struct {
+		contentType      string
+		transferEncoding []string
+		accept           string
+		expected         bool
+	}{
+		"event stream": {
+			contentType: "text/event-stream; charset=utf-8",
+			expected:    true,
+		},
+		"chunked response to an event stream client": {
+			contentType:      "application/json",
+			transferEncoding: []string{"chunked"},
+			accept:           "text/event-stream",
+			expected:         true,
+		},
+		"chunked response": {
+			contentType:      "application/json",
+			transferEncoding: []string{"chunked"},
+			accept:           "application/json",
+			expected:         false,
+		},
+		"json": {
+			contentType: "application/json",
+			accept:      "text/event-stream",
+			expected:    false,
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			resp := &http.Response{
+				Header:           http.Header{"Content-Type": {scenario.contentType}},
+				TransferEncoding: scenario.transferEncoding,
+			}
+			assert.Equal(t, scenario.expected, isStreamingResponse(resp, http.Header{"Accept": {scenario.accept}}))
+		})
+	}
+}
@@ -0,0 +1,373 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/kserve/kserve/pkg/protocol/inference"
+)
+
+// v2InferRequest is the JSON encoding of an open inference protocol request
+type v2InferRequest struct {
+	ID         string                 `json:"id,omitempty"`
+	Parameters map[string]interface{} `json:"parameters,omitempty"`
+	Inputs     []v2InputTensor        `json:"inputs"`
+	Outputs    []v2RequestedOutput    `json:"outputs,omitempty"`
+}
+
+type v2InputTensor struct {
+	Name       string                 `json:"name"`
+	Shape      []int64                `json:"shape"`
+	Datatype   string                 `json:"datatype"`
+	Parameters map[string]interface{} `json:"parameters,omitempty"`
+	Data       interface{}            `json:"data"`
+}
+
+type v2RequestedOutput struct {
+	Name       string                 `json:"name"`
+	Parameters map[string]interface{} `json:"parameters,omitempty"`
+}
+
+// v2InferResponse is the JSON encoding of an open inference protocol response
+type v2InferResponse struct {
+	ModelName    string                 `json:"model_name"`
+	ModelVersion string                 `json:"model_version,omitempty"`
+	ID           string                 `json:"id,omitempty"`
+	Parameters   map[string]interface{} `json:"parameters,omitempty"`
+	Outputs      []v2OutputTensor       `json:"outputs"`
+}
+
+type v2OutputTensor struct {
+	Name       string                 `json:"name"`
+	Shape      []int64                `json:"shape"`
+	Datatype   string                 `json:"datatype"`
+	Parameters map[string]interface{} `json:"parameters,omitempty"`
+	Data       interface{}            `json:"data"`
+}
+
+// toModelInferRequest converts the JSON body of an open inference protocol request to a ModelInferRequest
+func toModelInferRequest(body []byte, modelName string, modelVersion string) (*inference.ModelInferRequest, error) {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	request := v2InferRequest{}
+	if err := decoder.Decode(&request); err != nil {
+		return nil, &ConversionError{Field: "body", Reason: err.Error()}
+	}
+	parameters, err := toInferParameters("parameters", request.Parameters)
+	if err != nil {
+		return nil, err
+	}
+	inferRequest := &inference.ModelInferRequest{
+		ModelName:    modelName,
+		ModelVersion: modelVersion,
+		Id:           request.ID,
+		Parameters:   parameters,
+	}
+	if len(request.Inputs) == 0 {
+		return nil, &ConversionError{Field: "inputs", Reason: "at least one input is required"}
+	}
+	for i, input := range request.Inputs {
+		field := fmt.Sprintf("inputs[%d]", i)
+		parameters, err := toInferParameters(field+".parameters", input.Parameters)
+		if err != nil {
+			return nil, err
+		}
+		contents, err := toTensorContents(field, input)
+		if err != nil {
+			return nil, err
+		}
+		inferRequest.Inputs = append(inferRequest.Inputs, &inference.ModelInferRequest_InferInputTensor{
+			Name:       input.Name,
+			Datatype:   input.Datatype,
+			Shape:      input.Shape,
+			Parameters: parameters,
+			Contents:   contents,
+		})
+	}
+	for i, output := range request.Outputs {
+		parameters, err := toInferParameters(fmt.Sprintf("outputs[%d].parameters", i), output.Parameters)
+		if err != nil {
+			return nil, err
+		}
+		inferRequest.Outputs = append(inferRequest.Outputs, &inference.ModelInferRequest_InferRequestedOutputTensor{
+			Name:       output.Name,
+			Parameters: parameters,
+		})
+	}
+	return inferRequest, nil
+}
+
+func toInferParameters(field string, parameters map[string]interface{}) (map[string]*inference.InferParameter, error) {
+	if len(parameters) == 0 {
+		return nil, nil
+	}
+	converted := make(map[string]*inference.InferParameter, len(parameters))
+	for name, value := range parameters {
+		switch v := value.(type) {
+		case bool:
+			converted[name] = &inference.InferParameter{ParameterChoice: &inference.InferParameter_BoolParam{BoolParam: v}}
+		case string:
+			converted[name] = &inference.InferParameter{ParameterChoice: &inference.InferParameter_StringParam{StringParam: v}}
+		case json.Number:
+			i, err := v.Int64()
+			if err != nil {
+				return nil, &ConversionError{Field: field + "." + name, Reason: "only integer numbers are supported"}
+			}
+			converted[name] = &inference.InferParameter{ParameterChoice: &inference.InferParameter_Int64Param{Int64Param: i}}
+		default:
+			return nil, &ConversionError{Field: field + "." + name, Reason: "must be a boolean, an integer or a string"}
+		}
+	}
+	return converted, nil
+}
+
+// flatten appends the elements of the possibly nested data of a tensor in row-major order
+func flatten(data interface{}, elements []interface{}) []interface{} {
+	if nested, ok := data.([]interface{}); ok {
+		for _, element := range nested {
+			elements = flatten(element, elements)
+		}
+		return elements
+	}
+	return append(elements, data)
+}
+
+func toTensorContents(field string, input v2InputTensor) (*inference.InferTensorContents, error) {
+	elements := flatten(input.Data, nil)
+	size := int64(1)
+	for _, dim := range input.Shape {
+		size *= dim
+	}
+	if int64(len(elements)) != size {
+		return nil, &ConversionError{Field: field + ".data",
+			Reason: fmt.Sprintf("has %d elements but the shape %v requires %d", len(elements), input.Shape, size)}
+	}
+	contents := &inference.InferTensorContents{}
+	for j, element := range elements {
+		elementField := fmt.Sprintf("%s.data[%d]", field, j)
+		if input.Datatype == "BOOL" {
+			b, ok := element.(bool)
+			if !ok {
+				return nil, &ConversionError{Field: elementField, Reason: "must be a boolean for the BOOL datatype"}
+			}
+			contents.BoolContents = append(contents.BoolContents, b)
+			continue
+		}
+		if input.Datatype == "BYTES" {
+			s, ok := element.(string)
+			if !ok {
+				return nil, &ConversionError{Field: elementField, Reason: "must be a string for the BYTES datatype"}
+			}
+			contents.BytesContents = append(contents.BytesContents, []byte(s))
+			continue
+		}
+		number, ok := element.(json.Number)
+		if !ok {
+			return nil, &ConversionError{Field: elementField, Reason: fmt.Sprintf("must be a number for the %s datatype", input.Datatype)}
+		}
+		var err error
+		switch input.Datatype {
+		case "INT8", "INT16", "INT32":
+			var v int64
+			if v, err = strconv.ParseInt(number.String(), 10, 32); err == nil {
+				contents.IntContents = append(contents.IntContents, int32(v))
+			}
+		case "INT64":
+			var v int64
+			if v, err = strconv.ParseInt(number.String(), 10, 64); err == nil {
+				contents.Int64Contents = append(contents.Int64Contents, v)
+			}
+		case "UINT8", "UINT16", "UINT32":
+			var v uint64
+			if v, err = strconv.ParseUint(number.String(), 10, 32); err == nil {
+				contents.UintContents = append(contents.UintContents, uint32(v))
+			}
+		case "UINT64":
+			var v uint64
+			if v, err = strconv.ParseUint(number.String(), 10, 64); err == nil {
+				contents.Uint64Contents = append(contents.Uint64Contents, v)
+			}
+		case "FP32":
+			var v float64
+			if v, err = strconv.ParseFloat(number.String(), 32); err == nil {
+				contents.Fp32Contents = append(contents.Fp32Contents, float32(v))
+			}
+		case "FP64":
+			var v float64
+			if v, err = strconv.ParseFloat(number.String(), 64); err == nil {
+				contents.Fp64Contents = append(contents.Fp64Contents, v)
+			}
+		default:
+			return nil, &ConversionError{Field: field + ".datatype", Reason: fmt.Sprintf("the %q datatype is not supported", input.Datatype)}
+		}
+		if err != nil {
+			return nil, &ConversionError{Field: elementField, Reason: fmt.Sprintf("%s is not a valid %s value", number, input.Datatype)}
+		}
+	}
+	return contents, nil
+}
+
+// fromModelInferResponse converts a ModelInferResponse to the JSON body of an open inference protocol response
+func fromModelInferResponse(response *inference.ModelInferResponse) ([]byte, error) {
+	converted := v2InferResponse{
+		ModelName:    response.ModelName,
+		ModelVersion: response.ModelVersion,
+		ID:           response.Id,
+		Parameters:   fromInferParameters(response.Parameters),
+		Outputs:      make([]v2OutputTensor, 0, len(response.Outputs)),
+	}
+	if len(response.RawOutputContents) > 0 && len(response.RawOutputContents) != len(response.Outputs) {
+		return nil, &ConversionError{Field: "raw_output_contents",
+			Reason: fmt.Sprintf("has %d entries for %d outputs", len(response.RawOutputContents), len(response.Outputs))}
+	}
+	for i, output := range response.Outputs {
+		field := fmt.Sprintf("outputs[%d]", i)
+		var data interface{}
+		var err error
+		if len(response.RawOutputContents) > 0 {
+			data, err = fromRawContents(field, output.Datatype, response.RawOutputContents[i])
+		} else {
+			data, err = fromTensorContents(field, output.Datatype, output.Contents)
+		}
+		if err != nil {
+			return nil, err
+		}
+		converted.Outputs = append(converted.Outputs, v2OutputTensor{
+			Name:       output.Name,
+			Shape:      output.Shape,
+			Datatype:   output.Datatype,
+			Parameters: fromInferParameters(output.Parameters),
+			Data:       data,
+		})
+	}
+	return json.Marshal(converted)
+}
+
+func fromInferParameters(parameters map[string]*inference.InferParameter) map[string]interface{} {
+	if len(parameters) == 0 {
+		return nil
+	}
+	converted := make(map[string]interface{}, len(parameters))
+	for name, parameter := range parameters {
+		switch v := parameter.GetParameterChoice().(type) {
+		case *inference.InferParameter_BoolParam:
+			converted[name] = v.BoolParam
+		case *inference.InferParameter_Int64Param:
+			converted[name] = v.Int64Param
+		case *inference.InferParameter_StringParam:
+			converted[name] = v.StringParam
+		}
+	}
+	return converted
+}
+
+func fromTensorContents(field string, datatype string, contents *inference.InferTensorContents) (interface{}, error) {
+	switch datatype {
+	case "BOOL":
+		return nonNil(contents.GetBoolContents()), nil
+	case "INT8", "INT16", "INT32":
+		return nonNil(contents.GetIntContents()), nil
+	case "INT64":
+		return nonNil(contents.GetInt64Contents()), nil
+	case "UINT8", "UINT16", "UINT32":
+		return nonNil(contents.GetUintContents()), nil
+	case "UINT64":
+		return nonNil(contents.GetUint64Contents()), nil
+	case "FP32":
+		return nonNil(contents.GetFp32Contents()), nil
+	case "FP64":
+		return nonNil(contents.GetFp64Contents()), nil
+	case "BYTES":
+		data := make([]string, 0, len(contents.GetBytesContents()))
+		for _, b := range contents.GetBytesContents() {
+			data = append(data, string(b))
+		}
+		return data, nil
+	}
+	return nil, &ConversionError{Field: field + ".datatype", Reason: fmt.Sprintf("the %q datatype is not supported", datatype)}
+}
+
+// nonNil makes empty contents marshal to an empty JSON array instead of null
+func nonNil[T any](data []T) []T {
+	if data == nil {
+		return []T{}
+	}
+	return data
+}
+
+// fromRawContents decodes the little-endian raw contents of a tensor, BYTES elements are prefixed by their 4 bytes length
+func fromRawContents(field string, datatype string, raw []byte) (interface{}, error) {
+	sizes := map[string]int{"BOOL": 1, "INT8": 1, "UINT8": 1, "INT16": 2, "UINT16": 2, "INT32": 4, "UINT32": 4,
+		"FP32": 4, "INT64": 8, "UINT64": 8, "FP64": 8}
+	if datatype == "BYTES" {
+		data := []string{}
+		for len(raw) > 0 {
+			if len(raw) < 4 {
+				return nil, &ConversionError{Field: field, Reason: "truncated BYTES raw contents"}
+			}
+			length := binary.LittleEndian.Uint32(raw)
+			if uint64(len(raw)-4) < uint64(length) {
+				return nil, &ConversionError{Field: field, Reason: "truncated BYTES raw contents"}
+			}
+			data = append(data, string(raw[4:4+length]))
+			raw = raw[4+length:]
+		}
+		return data, nil
+	}
+	size, ok := sizes[datatype]
+	if !ok {
+		return nil, &ConversionError{Field: field + ".datatype", Reason: fmt.Sprintf("the %q datatype is not supported", datatype)}
+	}
+	if len(raw)%size != 0 {
+		return nil, &ConversionError{Field: field, Reason: fmt.Sprintf("raw contents of %d bytes are not a multiple of the %s size", len(raw), datatype)}
+	}
+	data := make([]interface{}, 0, len(raw)/size)
+	for i := 0; i < len(raw); i += size {
+		element := raw[i : i+size]
+		switch datatype {
+		case "BOOL":
+			data = append(data, element[0] != 0)
+		case "INT8":
+			data = append(data, int8(element[0]))
+		case "UINT8":
+			data = append(data, element[0])
+		case "INT16":
+			data = append(data, int16(binary.LittleEndian.Uint16(element)))
+		case "UINT16":
+			data = append(data, binary.LittleEndian.Uint16(element))
+		case "INT32":
+			data = append(data, int32(binary.LittleEndian.Uint32(element)))
+		case "UINT32":
+			data = append(data, binary.LittleEndian.Uint32(element))
+		case "FP32":
+			data = append(data, math.Float32frombits(binary.LittleEndian.Uint32(element)))
+		case "INT64":
+			data = append(data, int64(binary.LittleEndian.Uint64(element)))
+		case "UINT64":
+			data = append(data, binary.LittleEndian.Uint64(element))
+		case "FP64":
+			data = append(data, math.Float64frombits(binary.LittleEndian.Uint64(element)))
+		}
+	}
+	return data, nil
+}
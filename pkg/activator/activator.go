@@ -0,0 +1,320 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package activator wakes up the raw deployment components scaled to zero. While a component is scaled to zero its
+// service is an ExternalName service of the activator, the activator holds the requests, scales the deployment to one
+// replica and forwards the requests once a pod is ready.
+package activator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var log = logf.Log.WithName("Activator")
+
+const (
+	// DefaultTimeout is the time a request is held while the component is scaled up
+	DefaultTimeout = 5 * time.Minute
+	// endpointsTTL is the time the ready pods of a component are reused before they are listed again
+	endpointsTTL = 5 * time.Second
+	// routedGracePeriod is the time the requests are still forwarded to a component after its service stopped being
+	// routed to the activator, the clients resolving the service before the switch keep sending requests for a while
+	routedGracePeriod = 30 * time.Second
+	pollInterval      = 500 * time.Millisecond
+)
+
+var (
+	errNotComponent = errors.New("the service is not an InferenceService component")
+	errNotRouted    = errors.New("the service is not routed to the activator")
+)
+
+// Activator is the http handler holding the requests sent to the components scaled to zero.
+type Activator struct {
+	clientset kubernetes.Interface
+	// reader reads the InferenceServices, it is the cached client of the manager
+	reader  client.Reader
+	timeout time.Duration
+
+	mu      sync.Mutex
+	targets map[types.NamespacedName]*target
+}
+
+// target is a component served by the activator, its lock makes the concurrent requests wait for a single scale up
+type target struct {
+	mu        sync.Mutex
+	endpoints []string
+	next      int
+	expires   time.Time
+	// routedUntil is the end of the grace period of the last time the service was seen routed to the activator
+	routedUntil time.Time
+}
+
+// New creates an activator waking up the components for at most the given timeout.
+func New(clientset kubernetes.Interface, reader client.Reader, timeout time.Duration) *Activator {
+	return &Activator{
+		clientset: clientset,
+		reader:    reader,
+		timeout:   timeout,
+		targets:   map[types.NamespacedName]*target{},
+	}
+}
+
+func (a *Activator) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// the timeout only applies to the activation, not to the forwarded request
+	ctx, cancel := context.WithTimeout(r.Context(), a.timeout)
+	defer cancel()
+	component, err := a.resolve(ctx, r.Host)
+	if err != nil {
+		log.Error(err, "Failed to resolve the component of the request", "host", r.Host)
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	endpoint, err := a.activate(ctx, component)
+	if errors.Is(err, errNotComponent) || errors.Is(err, errNotRouted) || apierr.IsNotFound(err) {
+		log.Info("Rejecting the request of a component not routed to the activator", "component", component, "reason", err.Error())
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		log.Error(err, "Failed to activate the component", "component", component)
+		http.Error(w, fmt.Sprintf("failed to activate %s: %v", component, err), http.StatusServiceUnavailable)
+		return
+	}
+	proxy := &httputil.ReverseProxy{
+		Director: func(req *http.Request) {
+			req.URL.Scheme = "http"
+			req.URL.Host = endpoint
+		},
+	}
+	proxy.ServeHTTP(w, r)
+}
+
+// resolve returns the service of the component a request is sent to. The host is either the cluster local host of
+// the component service, or the host of an InferenceService or of one of its components.
+func (a *Activator) resolve(ctx context.Context, host string) (types.NamespacedName, error) {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	if parts := strings.Split(host, "."); len(parts) == 2 || (len(parts) > 2 && parts[2] == "svc") {
+		return types.NamespacedName{Name: parts[0], Namespace: parts[1]}, nil
+	}
+	isvcs := &v1beta1.InferenceServiceList{}
+	if err := a.reader.List(ctx, isvcs); err != nil {
+		return types.NamespacedName{}, err
+	}
+	for _, isvc := range isvcs.Items {
+		component := ""
+		if isvc.Status.URL != nil && isvc.Status.URL.Host == host {
+			// the top level host routes to the transformer if any
+			component = string(constants.Predictor)
+			if isvc.Spec.Transformer != nil {
+				component = string(constants.Transformer)
+			}
+		}
+		for componentType, status := range isvc.Status.Components {
+			if status.URL != nil && status.URL.Host == host {
+				component = string(componentType)
+			}
+		}
+		if component != "" {
+			return a.componentService(ctx, &isvc, component)
+		}
+	}
+	return types.NamespacedName{}, fmt.Errorf("no InferenceService found for host %s", host)
+}
+
+// componentService returns the service of a component, it is named with or without the default suffix
+func (a *Activator) componentService(ctx context.Context, isvc *v1beta1.InferenceService, component string) (types.NamespacedName, error) {
+	prefix := isvc.GeneratedNamePrefix()
+	candidates := []string{prefix + "-" + component, prefix + "-" + component + "-" + constants.InferenceServiceDefault}
+	for _, name := range candidates {
+		_, err := a.clientset.CoreV1().Services(isvc.Namespace).Get(ctx, name, metav1.GetOptions{})
+		if err == nil {
+			return types.NamespacedName{Name: name, Namespace: isvc.Namespace}, nil
+		}
+		if !apierr.IsNotFound(err) {
+			return types.NamespacedName{}, err
+		}
+	}
+	return types.NamespacedName{}, fmt.Errorf("no %s service found for InferenceService %s/%s", component, isvc.Namespace, isvc.Name)
+}
+
+func (a *Activator) target(component types.NamespacedName) *target {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	t, ok := a.targets[component]
+	if !ok {
+		t = &target{}
+		a.targets[component] = t
+	}
+	return t
+}
+
+// forget removes the target of a component which no longer exists or is no longer routed to the activator
+func (a *Activator) forget(component types.NamespacedName, t *target) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.targets[component] == t {
+		delete(a.targets, component)
+	}
+}
+
+// activate scales the deployment of a component routed to the activator to one replica if it is scaled to zero,
+// waits for a ready pod and returns the address of a ready pod.
+func (a *Activator) activate(ctx context.Context, component types.NamespacedName) (string, error) {
+	t := a.target(component)
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if len(t.endpoints) == 0 || time.Now().After(t.expires) {
+		endpoints, err := a.wakeUp(ctx, component, t)
+		if err != nil {
+			if errors.Is(err, errNotComponent) || errors.Is(err, errNotRouted) || apierr.IsNotFound(err) {
+				a.forget(component, t)
+			}
+			return "", err
+		}
+		t.endpoints, t.expires = endpoints, time.Now().Add(endpointsTTL)
+	}
+	t.next = (t.next + 1) % len(t.endpoints)
+	return t.endpoints[t.next], nil
+}
+
+// wakeUp only serves the components whose service is an ExternalName service of the activator, or was one within
+// the grace period. The activator forwards the requests from the controller pod, which is not subject to the network
+// and authorization policies of the components, so it must not be usable to reach the other components.
+func (a *Activator) wakeUp(ctx context.Context, component types.NamespacedName, t *target) ([]string, error) {
+	service, err := a.clientset.CoreV1().Services(component.Namespace).Get(ctx, component.Name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := service.Labels[constants.InferenceServicePodLabelKey]; !ok || len(service.Spec.Ports) == 0 {
+		return nil, errNotComponent
+	}
+	port := service.Spec.Ports[0].TargetPort.IntValue()
+	if port == 0 {
+		port = int(service.Spec.Ports[0].Port)
+	}
+	// the requests still sent to the activator shortly after the service is switched back are forwarded to the ready
+	// pods, the component is only scaled up while its service is routed to the activator
+	routed := service.Spec.Type == corev1.ServiceTypeExternalName && service.Spec.ExternalName == constants.ActivatorServiceHost
+	if routed {
+		t.routedUntil = time.Now().Add(routedGracePeriod)
+	} else if time.Now().After(t.routedUntil) {
+		return nil, errNotRouted
+	}
+	deployments := a.clientset.AppsV1().Deployments(component.Namespace)
+	deployment, err := deployments.Get(ctx, component.Name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if routed && deployment.Spec.Replicas != nil && *deployment.Spec.Replicas == 0 {
+		log.Info("Scaling up the component scaled to zero", "component", component)
+		scale := &autoscalingv1.Scale{
+			ObjectMeta: metav1.ObjectMeta{Name: deployment.Name, Namespace: deployment.Namespace},
+			Spec:       autoscalingv1.ScaleSpec{Replicas: 1},
+		}
+		if _, err := deployments.UpdateScale(ctx, deployment.Name, scale, metav1.UpdateOptions{}); err != nil {
+			return nil, err
+		}
+	}
+	var endpoints []string
+	err = wait.PollUntilContextCancel(ctx, pollInterval, true, func(ctx context.Context) (bool, error) {
+		endpoints, err = a.readyEndpoints(ctx, deployment, port)
+		return len(endpoints) > 0, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("no ready pod: %w", err)
+	}
+	return endpoints, nil
+}
+
+// readyEndpoints returns the addresses of the ready pods of a deployment
+func (a *Activator) readyEndpoints(ctx context.Context, deployment *appsv1.Deployment, port int) ([]string, error) {
+	pods, err := a.clientset.CoreV1().Pods(deployment.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: labels.SelectorFromSet(deployment.Spec.Selector.MatchLabels).String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	var endpoints []string
+	for _, pod := range pods.Items {
+		if pod.Status.PodIP == "" || pod.DeletionTimestamp != nil {
+			continue
+		}
+		for _, condition := range pod.Status.Conditions {
+			if condition.Type == corev1.PodReady && condition.Status == corev1.ConditionTrue {
+				endpoints = append(endpoints, net.JoinHostPort(pod.Status.PodIP, strconv.Itoa(port)))
+			}
+		}
+	}
+	return endpoints, nil
+}
+
+// Server serves the activator, it implements the manager Runnable interface.
+type Server struct {
+	Addr    string
+	Handler http.Handler
+}
+
+// Start serves the activator until the context is cancelled.
+func (s *Server) Start(ctx context.Context) error {
+	server := &http.Server{
+		Addr:              s.Addr,
+		Handler:           s.Handler,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	errCh := make(chan error, 1)
+	go func() {
+		log.Info("Starting the activator", "address", s.Addr)
+		errCh <- server.ListenAndServe()
+	}()
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		return server.Shutdown(shutdownCtx)
+	}
+}
+
+// NeedLeaderElection returns false, every replica of the manager serves the activator.
+func (s *Server) NeedLeaderElection() bool {
+	return false
+}
@@ -0,0 +1,214 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package activator
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/ptr"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newComponent(name string, replicas int32, port int, externalName string) (*appsv1.Deployment, *corev1.Service) {
+	labels := map[string]string{
+		"app":                                 constants.GetRawServiceLabel(name),
+		constants.InferenceServicePodLabelKey: "sklearn",
+	}
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: labels},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: ptr.Int32(replicas),
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": constants.GetRawServiceLabel(name)}},
+		},
+	}
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: labels},
+		Spec: corev1.ServiceSpec{
+			Ports: []corev1.ServicePort{{Port: constants.CommonDefaultHttpPort, TargetPort: intstr.FromInt(port)}},
+		},
+	}
+	if externalName != "" {
+		service.Spec.Type = corev1.ServiceTypeExternalName
+		service.Spec.ExternalName = externalName
+	}
+	return deployment, service
+}
+
+func readyPod(name string) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name + "-abcde",
+			Namespace: "default",
+			Labels:    map[string]string{"app": constants.GetRawServiceLabel(name)},
+		},
+		Status: corev1.PodStatus{
+			PodIP:      "127.0.0.1",
+			Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}},
+		},
+	}
+}
+
+func TestActivatorWakesUpComponent(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, "predicted")
+	}))
+	defer backend.Close()
+	backendURL, _ := url.Parse(backend.URL)
+	_, portStr, _ := net.SplitHostPort(backendURL.Host)
+	port, _ := strconv.Atoi(portStr)
+
+	deployment, service := newComponent("sklearn-predictor", 0, port, constants.ActivatorServiceHost)
+	clientset := fake.NewSimpleClientset(deployment, service)
+	scaled := 0
+	clientset.PrependReactor("update", "deployments", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if action.GetSubresource() != "scale" {
+			return false, nil, nil
+		}
+		scaled++
+		scale := action.(k8stesting.UpdateAction).GetObject().(*autoscalingv1.Scale)
+		// the pod becomes ready once the deployment is scaled up
+		go func() {
+			time.Sleep(100 * time.Millisecond)
+			_, _ = clientset.CoreV1().Pods("default").Create(context.TODO(), readyPod("sklearn-predictor"), metav1.CreateOptions{})
+		}()
+		return true, scale, nil
+	})
+	a := New(clientset, clientfake.NewClientBuilder().Build(), 10*time.Second)
+
+	// concurrent requests wait for a single scale up
+	results := make(chan *httptest.ResponseRecorder, 3)
+	for i := 0; i < 3; i++ {
+		go func() {
+			recorder := httptest.NewRecorder()
+			a.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "http://sklearn-predictor.default.svc.cluster.local/v1/models/sklearn:predict", nil))
+			results <- recorder
+		}()
+	}
+	for i := 0; i < 3; i++ {
+		recorder := <-results
+		g.Expect(recorder.Code).To(gomega.Equal(http.StatusOK))
+		g.Expect(recorder.Body.String()).To(gomega.Equal("predicted"))
+	}
+	g.Expect(scaled).To(gomega.Equal(1))
+}
+
+func TestActivatorResolvesInferenceServiceHost(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	scheme := runtime.NewScheme()
+	g.Expect(v1beta1.AddToScheme(scheme)).To(gomega.Succeed())
+	isvc := &v1beta1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{Name: "sklearn", Namespace: "default"},
+		Status: v1beta1.InferenceServiceStatus{
+			URL: &apis.URL{Scheme: "http", Host: "sklearn-default.example.com"},
+			Components: map[v1beta1.ComponentType]v1beta1.ComponentStatusSpec{
+				v1beta1.PredictorComponent: {URL: &apis.URL{Scheme: "http", Host: "sklearn-predictor-default.example.com"}},
+			},
+		},
+	}
+	_, service := newComponent("sklearn-predictor", 0, 8080, constants.ActivatorServiceHost)
+	a := New(fake.NewSimpleClientset(service), clientfake.NewClientBuilder().WithScheme(scheme).WithObjects(isvc).Build(), time.Second)
+
+	for _, host := range []string{"sklearn-default.example.com", "sklearn-predictor-default.example.com:80", "sklearn-predictor.default"} {
+		component, err := a.resolve(context.TODO(), host)
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+		g.Expect(component.Name).To(gomega.Equal("sklearn-predictor"))
+		g.Expect(component.Namespace).To(gomega.Equal("default"))
+	}
+	_, err := a.resolve(context.TODO(), "unknown.example.com")
+	g.Expect(err).To(gomega.HaveOccurred())
+}
+
+func TestActivatorDoesNotScaleComponentNotRoutedToIt(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	deployment, service := newComponent("sklearn-predictor", 0, 8080, "")
+	clientset := fake.NewSimpleClientset(deployment, service)
+	clientset.PrependReactor("update", "deployments", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if action.GetSubresource() == "scale" {
+			t.Error("the component must not be scaled up")
+		}
+		return false, nil, nil
+	})
+	a := New(clientset, clientfake.NewClientBuilder().Build(), time.Second)
+
+	recorder := httptest.NewRecorder()
+	a.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "http://sklearn-predictor.default/v1/models/sklearn:predict", nil))
+	g.Expect(recorder.Code).To(gomega.Equal(http.StatusNotFound))
+	g.Expect(a.targets).To(gomega.BeEmpty())
+}
+
+func TestActivatorForwardsWithinRoutedGracePeriod(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, "predicted")
+	}))
+	defer backend.Close()
+	backendURL, _ := url.Parse(backend.URL)
+	_, portStr, _ := net.SplitHostPort(backendURL.Host)
+	port, _ := strconv.Atoi(portStr)
+
+	// the service was switched back to its selector once the component had a ready pod
+	deployment, service := newComponent("sklearn-predictor", 1, port, "")
+	clientset := fake.NewSimpleClientset(deployment, service, readyPod("sklearn-predictor"))
+	a := New(clientset, clientfake.NewClientBuilder().Build(), time.Second)
+	component := types.NamespacedName{Name: "sklearn-predictor", Namespace: "default"}
+	a.target(component).routedUntil = time.Now().Add(routedGracePeriod)
+
+	recorder := httptest.NewRecorder()
+	a.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "http://sklearn-predictor.default/v1/models/sklearn:predict", nil))
+	g.Expect(recorder.Code).To(gomega.Equal(http.StatusOK))
+	g.Expect(recorder.Body.String()).To(gomega.Equal("predicted"))
+
+	// once the grace period is over the requests are rejected
+	a.target(component).routedUntil = time.Now().Add(-time.Second)
+	a.target(component).expires = time.Time{}
+	recorder = httptest.NewRecorder()
+	a.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "http://sklearn-predictor.default/v1/models/sklearn:predict", nil))
+	g.Expect(recorder.Code).To(gomega.Equal(http.StatusNotFound))
+	g.Expect(a.targets).To(gomega.BeEmpty())
+}
+
+func TestActivatorForgetsDeletedComponent(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	a := New(fake.NewSimpleClientset(), clientfake.NewClientBuilder().Build(), time.Second)
+
+	recorder := httptest.NewRecorder()
+	a.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "http://deleted-predictor.default/v1/models/deleted:predict", nil))
+	g.Expect(recorder.Code).To(gomega.Equal(http.StatusNotFound))
+	g.Expect(a.targets).To(gomega.BeEmpty())
+}
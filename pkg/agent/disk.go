@@ -0,0 +1,245 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package agent
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/kserve/kserve/pkg/agent/storage"
+	"go.uber.org/zap"
+)
+
+// InsufficientDiskSpace is the code of the load errors of the models which do not fit in the model store
+const InsufficientDiskSpace = "InsufficientDiskSpace"
+
+// DiskUsage is the usage of the filesystem of the model store
+type DiskUsage struct {
+	// Free is the number of bytes available to the agent
+	Free  int64
+	Total int64
+}
+
+// InsufficientDiskSpaceError is returned when the model store has not enough free space to download a model
+type InsufficientDiskSpaceError struct {
+	ModelName string
+	Required  int64
+	Available int64
+}
+
+func (e *InsufficientDiskSpaceError) Error() string {
+	return fmt.Sprintf("insufficient disk space for model %s: %d bytes required, %d bytes available",
+		e.ModelName, e.Required, e.Available)
+}
+
+// Code returns the code reported in the status of the model
+func (e *InsufficientDiskSpaceError) Code() string {
+	return InsufficientDiskSpace
+}
+
+// DiskManager checks the free space of the model store before the models are downloaded. When Reclaim is set, the
+// files of the unloaded models are kept as a cache and the least recently used ones are evicted to make room for the
+// models to download. Its methods can be called on a nil DiskManager, which does not check anything.
+type DiskManager struct {
+	ModelDir string
+	Reclaim  bool
+	logger   *zap.SugaredLogger
+	statfs   func(path string) (DiskUsage, error)
+	mu       sync.Mutex
+	// inUse holds the models loaded or being loaded, which are never evicted
+	inUse    map[string]bool
+	lastUsed map[string]time.Time
+	// reserved holds the bytes required by the downloads in progress
+	reserved map[string]int64
+}
+
+func NewDiskManager(modelDir string, reclaim bool, logger *zap.SugaredLogger) *DiskManager {
+	return &DiskManager{
+		ModelDir: modelDir,
+		Reclaim:  reclaim,
+		logger:   logger,
+		statfs:   statfs,
+		inUse:    map[string]bool{},
+		lastUsed: map[string]time.Time{},
+		reserved: map[string]int64{},
+	}
+}
+
+func statfs(path string) (DiskUsage, error) {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(path, &stat); err != nil {
+		return DiskUsage{}, err
+	}
+	return DiskUsage{
+		Free:  int64(stat.Bavail) * int64(stat.Bsize),
+		Total: int64(stat.Blocks) * int64(stat.Bsize),
+	}, nil
+}
+
+// Acquire marks the model as in use before it is loaded
+func (d *DiskManager) Acquire(modelName string) {
+	if d == nil {
+		return
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.inUse[modelName] = true
+	d.lastUsed[modelName] = time.Now()
+}
+
+// Release marks the model as no longer in use once it is unloaded or failed to load, and returns whether its files
+// are kept to be reclaimed later instead of being deleted
+func (d *DiskManager) Release(modelName string) bool {
+	if d == nil {
+		return false
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	delete(d.inUse, modelName)
+	d.lastUsed[modelName] = time.Now()
+	return d.Reclaim
+}
+
+// Reserve checks that the model store has room for the size bytes of the model, minus the bytes of the model
+// already downloaded, evicting the least recently used models not in use when Reclaim is set. The space is reserved
+// until Unreserve is called. A negative size is unknown and not checked.
+func (d *DiskManager) Reserve(modelName string, size int64) error {
+	if d == nil || size < 0 {
+		return nil
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	required := size - dirSize(filepath.Join(d.ModelDir, modelName))
+	if required <= 0 {
+		return nil
+	}
+	usage, err := d.statfs(d.ModelDir)
+	if err != nil {
+		return fmt.Errorf("failed to get the disk usage of %s: %w", d.ModelDir, err)
+	}
+	available := usage.Free
+	for _, reserved := range d.reserved {
+		available -= reserved
+	}
+	if available < required && d.Reclaim {
+		available += d.evict(modelName, required-available)
+	}
+	defer d.updateMetrics()
+	if available < required {
+		return &InsufficientDiskSpaceError{ModelName: modelName, Required: required, Available: available}
+	}
+	d.reserved[modelName] = required
+	return nil
+}
+
+// Unreserve releases the space reserved for the model once its download is over
+func (d *DiskManager) Unreserve(modelName string) {
+	if d == nil {
+		return
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	delete(d.reserved, modelName)
+	d.updateMetrics()
+}
+
+// evict deletes the least recently used models not in use until bytes are freed, and returns the freed bytes
+func (d *DiskManager) evict(modelName string, bytes int64) int64 {
+	entries, err := os.ReadDir(d.ModelDir)
+	if err != nil {
+		d.logger.Errorf("Failed to list the models of %s: %v", d.ModelDir, err)
+		return 0
+	}
+	type candidate struct {
+		name     string
+		lastUsed time.Time
+	}
+	candidates := make([]candidate, 0, len(entries))
+	for _, entry := range entries {
+		if !entry.IsDir() || entry.Name() == modelName || d.inUse[entry.Name()] {
+			continue
+		}
+		lastUsed, ok := d.lastUsed[entry.Name()]
+		if !ok {
+			// the models downloaded before the agent restarted were last used when they were modified
+			info, err := entry.Info()
+			if err != nil {
+				continue
+			}
+			lastUsed = info.ModTime()
+		}
+		candidates = append(candidates, candidate{name: entry.Name(), lastUsed: lastUsed})
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].lastUsed.Before(candidates[j].lastUsed)
+	})
+	var freed int64
+	for _, candidate := range candidates {
+		if freed >= bytes {
+			break
+		}
+		modelPath := filepath.Join(d.ModelDir, candidate.name)
+		size := dirSize(modelPath)
+		if err := storage.RemoveDir(modelPath); err != nil {
+			d.logger.Errorf("Failed to evict model %s: %v", candidate.name, err)
+			continue
+		}
+		d.logger.Infof("Evicted model %s to free %d bytes for model %s", candidate.name, size, modelName)
+		delete(d.lastUsed, candidate.name)
+		modelStoreEvictions.Inc()
+		freed += size
+	}
+	return freed
+}
+
+// UpdateMetrics records the used and free bytes of the model store
+func (d *DiskManager) UpdateMetrics() {
+	if d == nil {
+		return
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.updateMetrics()
+}
+
+func (d *DiskManager) updateMetrics() {
+	modelStoreUsedBytes.Set(float64(dirSize(d.ModelDir)))
+	if usage, err := d.statfs(d.ModelDir); err == nil {
+		modelStoreFreeBytes.Set(float64(usage.Free))
+	}
+}
+
+// dirSize returns the total size of the regular files under path, 0 if it does not exist
+func dirSize(path string) int64 {
+	var size int64
+	_ = filepath.WalkDir(path, func(_ string, entry fs.DirEntry, err error) error {
+		if err != nil || !entry.Type().IsRegular() {
+			return nil
+		}
+		if info, err := entry.Info(); err == nil {
+			size += info.Size()
+		}
+		return nil
+	})
+	return size
+}
@@ -0,0 +1,158 @@
+/*
+Copyright 2022 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package agent
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/kserve/kserve/pkg/agent/status"
+	"github.com/kserve/kserve/pkg/agent/storage"
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+)
+
+// sizedProvider is a provider of the models of a known size which downloads nothing
+type sizedProvider struct {
+	size       int64
+	downloaded []string
+}
+
+func (p *sizedProvider) DownloadModel(modelDir string, modelName string, storageUri string) error {
+	p.downloaded = append(p.downloaded, modelName)
+	return os.MkdirAll(filepath.Join(modelDir, modelName), os.ModePerm)
+}
+
+func (p *sizedProvider) ModelSize(storageUri string) (int64, error) {
+	return p.size, nil
+}
+
+var _ = Describe("DiskManager", func() {
+	var modelDir string
+	var disk *DiskManager
+	var free int64
+	// writeModel writes a model of size bytes last used at lastUsed
+	writeModel := func(modelName string, size int, lastUsed time.Time) {
+		modelPath := filepath.Join(modelDir, modelName)
+		Expect(os.MkdirAll(modelPath, os.ModePerm)).Should(Succeed())
+		Expect(os.WriteFile(filepath.Join(modelPath, "model.bin"), make([]byte, size), 0644)).Should(Succeed())
+		Expect(os.Chtimes(modelPath, lastUsed, lastUsed)).Should(Succeed())
+	}
+	BeforeEach(func() {
+		modelDir = GinkgoT().TempDir()
+		zapLogger, _ := zap.NewProduction()
+		disk = NewDiskManager(modelDir, false, zapLogger.Sugar())
+		free = 100
+		disk.statfs = func(path string) (DiskUsage, error) {
+			return DiskUsage{Free: free, Total: 1000}, nil
+		}
+	})
+
+	Context("When the model store has enough free space", func() {
+		It("Should reserve the space of the downloads in progress", func() {
+			Expect(disk.Reserve("model1", 60)).Should(Succeed())
+			err := disk.Reserve("model2", 60)
+			var diskErr *InsufficientDiskSpaceError
+			Expect(errors.As(err, &diskErr)).Should(BeTrue())
+			Expect(diskErr.Required).Should(Equal(int64(60)))
+			Expect(diskErr.Available).Should(Equal(int64(40)))
+
+			disk.Unreserve("model1")
+			Expect(disk.Reserve("model2", 60)).Should(Succeed())
+		})
+
+		It("Should only reserve the bytes which are not downloaded yet", func() {
+			writeModel("model1", 50, time.Now())
+			Expect(disk.Reserve("model1", 140)).Should(Succeed())
+		})
+
+		It("Should not check the models of unknown size", func() {
+			Expect(disk.Reserve("model1", -1)).Should(Succeed())
+		})
+	})
+
+	Context("When the model store is full", func() {
+		It("Should refuse the load without reclaiming the unloaded models", func() {
+			writeModel("cached", 80, time.Now().Add(-time.Hour))
+			err := disk.Reserve("model1", 150)
+			Expect(err).Should(HaveOccurred())
+			Expect(err.(*InsufficientDiskSpaceError).Code()).Should(Equal(InsufficientDiskSpace))
+			Expect(filepath.Join(modelDir, "cached")).Should(BeADirectory())
+		})
+
+		It("Should evict the least recently used models which are not in use", func() {
+			disk.Reclaim = true
+			writeModel("oldest", 30, time.Now().Add(-3*time.Hour))
+			writeModel("loaded", 30, time.Now().Add(-2*time.Hour))
+			writeModel("older", 30, time.Now().Add(-time.Hour))
+			writeModel("newest", 30, time.Now().Add(-time.Hour))
+			disk.Acquire("loaded")
+			// newest was used after older even though its files are older
+			disk.Acquire("newest")
+			Expect(disk.Release("newest")).Should(BeTrue())
+
+			Expect(disk.Reserve("model1", 150)).Should(Succeed())
+			Expect(filepath.Join(modelDir, "oldest")).ShouldNot(BeAnExistingFile())
+			Expect(filepath.Join(modelDir, "older")).ShouldNot(BeAnExistingFile())
+			Expect(filepath.Join(modelDir, "loaded")).Should(BeADirectory())
+			Expect(filepath.Join(modelDir, "newest")).Should(BeADirectory())
+		})
+
+		It("Should fail when evicting all the unloaded models is not enough", func() {
+			disk.Reclaim = true
+			writeModel("cached", 30, time.Now())
+			writeModel("loaded", 100, time.Now())
+			disk.Acquire("loaded")
+
+			err := disk.Reserve("model1", 150)
+			var diskErr *InsufficientDiskSpaceError
+			Expect(errors.As(err, &diskErr)).Should(BeTrue())
+			Expect(diskErr.Available).Should(Equal(int64(130)))
+			Expect(filepath.Join(modelDir, "loaded")).Should(BeADirectory())
+		})
+	})
+
+	Context("When the downloader checks the free space", func() {
+		It("Should surface the insufficient disk space in the status of the model", func() {
+			provider := &sizedProvider{size: 150}
+			zapLogger, _ := zap.NewProduction()
+			downloader := &Downloader{
+				ModelDir:  modelDir,
+				Providers: map[storage.Protocol]storage.Provider{storage.S3: provider},
+				Logger:    zapLogger.Sugar(),
+				Disk:      disk,
+			}
+			err := downloader.DownloadModel("model1", &v1alpha1.ModelSpec{StorageURI: "s3://models/model1"})
+			Expect(err).Should(HaveOccurred())
+			Expect(provider.downloaded).Should(BeEmpty())
+
+			statuses := status.NewTracker()
+			statuses.Set("model1", status.Failed, 1, err)
+			modelStatus, _ := statuses.Get("model1")
+			Expect(modelStatus.Code).Should(Equal(InsufficientDiskSpace))
+			Expect(modelStatus.Reason).Should(ContainSubstring("insufficient disk space"))
+
+			provider.size = 50
+			Expect(downloader.DownloadModel("model1", &v1alpha1.ModelSpec{StorageURI: "s3://models/model1"})).Should(Succeed())
+			Expect(provider.downloaded).Should(Equal([]string{"model1"}))
+		})
+	})
+})
@@ -25,6 +25,7 @@ import (
 	"regexp"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/kserve/kserve/pkg/agent/storage"
 	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
@@ -37,6 +38,10 @@ type Downloader struct {
 	mu        sync.Mutex
 	Providers map[storage.Protocol]storage.Provider
 	Logger    *zap.SugaredLogger
+	// VerifyChecksum verifies the downloaded files against the checksum manifest of the model when there is one
+	VerifyChecksum bool
+	// Disk checks the free space of the model store before the downloads when set
+	Disk *DiskManager
 }
 
 func (d *Downloader) DownloadModel(modelName string, modelSpec *v1alpha1.ModelSpec) error {
@@ -91,12 +96,44 @@ func (d *Downloader) download(modelName string, storageUri string) error {
 	if err != nil {
 		return errors.Wrapf(err, "unable to create or get provider for protocol %s", protocol)
 	}
+	if err := d.reserveSpace(modelName, storageUri, provider); err != nil {
+		return err
+	}
+	defer d.Disk.Unreserve(modelName)
+	start := time.Now()
 	if err := provider.DownloadModel(d.ModelDir, modelName, storageUri); err != nil {
 		return errors.Wrapf(err, "failed to download model")
 	}
+	if d.VerifyChecksum {
+		if err := storage.VerifyChecksums(filepath.Join(d.ModelDir, modelName)); err != nil {
+			return errors.Wrapf(err, "failed to verify model")
+		}
+	}
+	status, err := storage.WriteDownloadStatus(filepath.Join(d.ModelDir, modelName), time.Since(start))
+	if err != nil {
+		return errors.Wrapf(err, "failed to write the download status")
+	}
+	recordDownload(modelName, status)
+	d.Logger.Infof("Downloaded %d files (%d bytes) of model %s in %.3f seconds", status.Files, status.Bytes, modelName,
+		status.DurationSeconds)
 	return nil
 }
 
+// reserveSpace reserves the space of the model in the model store when the provider can look up its size
+func (d *Downloader) reserveSpace(modelName string, storageUri string, provider storage.Provider) error {
+	sizer, ok := provider.(storage.Sizer)
+	if d.Disk == nil || !ok {
+		return nil
+	}
+	size, err := sizer.ModelSize(storageUri)
+	if err != nil {
+		// the download reports the errors of the storage
+		d.Logger.Warnf("Failed to get the size of model %s: %v", modelName, err)
+		return nil
+	}
+	return d.Disk.Reserve(modelName, size)
+}
+
 // nolint: unused
 func hash(s string) string {
 	src := []byte(s)
@@ -17,8 +17,10 @@ limitations under the License.
 package agent
 
 import (
+	"encoding/json"
 	logger "log"
 	"os"
+	"path/filepath"
 
 	"github.com/kserve/kserve/pkg/agent/mocks"
 	"github.com/kserve/kserve/pkg/agent/storage"
@@ -26,6 +28,7 @@ import (
 	"github.com/kserve/kserve/pkg/modelconfig"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"go.uber.org/zap"
 )
 
@@ -57,6 +60,30 @@ var _ = Describe("Downloader", func() {
 		logger.Printf("Deleted temp dir %v\n", modelDir)
 	})
 
+	Context("When the download succeeds", func() {
+		It("Should write the download status and record the download metrics", func() {
+			modelConfig := modelconfig.ModelConfig{
+				Name: "model-status",
+				Spec: v1alpha1.ModelSpec{
+					StorageURI: "s3://models/model-status",
+					Framework:  "sklearn",
+				},
+			}
+			err := downloader.DownloadModel(modelConfig.Name, &modelConfig.Spec)
+			Expect(err).Should(BeNil())
+
+			content, err := os.ReadFile(filepath.Join(downloader.ModelDir, modelConfig.Name, storage.DownloadStatusFileName))
+			Expect(err).Should(BeNil())
+			status := storage.DownloadStatus{}
+			Expect(json.Unmarshal(content, &status)).Should(Succeed())
+			// the mock downloader creates the empty model files
+			Expect(status.Files).Should(Equal(1))
+			Expect(status.Bytes).Should(Equal(int64(0)))
+			Expect(testutil.ToFloat64(modelDownloadFiles.WithLabelValues(modelConfig.Name))).Should(Equal(1.0))
+			Expect(testutil.CollectAndCount(modelDownloadDuration)).Should(BeNumerically(">=", 1))
+		})
+	})
+
 	Context("When protocol is invalid", func() {
 		It("Should fail out and return error", func() {
 			modelConfig := modelconfig.ModelConfig{
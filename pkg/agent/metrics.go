@@ -0,0 +1,102 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package agent
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/kserve/kserve/pkg/agent/storage"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+)
+
+// componentMetricsTimeout bounds the scrape of the metrics of the component so that it does not hold the scrape of the
+// agent metrics
+const componentMetricsTimeout = 5 * time.Second
+
+var (
+	// MetricsRegistry is the registry of the model download and model store metrics served by the agent
+	MetricsRegistry = prometheus.NewRegistry()
+
+	modelDownloadDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "kserve_agent_model_download_duration_seconds",
+		Help:    "Wall-clock duration of the model downloads",
+		Buckets: prometheus.ExponentialBuckets(1, 2, 14),
+	}, []string{"model"})
+	modelDownloadBytes = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "kserve_agent_model_download_bytes_total",
+		Help: "Number of bytes downloaded for the models",
+	}, []string{"model"})
+	modelDownloadFiles = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "kserve_agent_model_download_files_total",
+		Help: "Number of files downloaded for the models",
+	}, []string{"model"})
+	modelStoreUsedBytes = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "kserve_agent_model_store_used_bytes",
+		Help: "Number of bytes of the files of the models in the model store",
+	})
+	modelStoreFreeBytes = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "kserve_agent_model_store_free_bytes",
+		Help: "Number of bytes available on the filesystem of the model store",
+	})
+	modelStoreEvictions = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "kserve_agent_model_store_evictions_total",
+		Help: "Number of unloaded models evicted from the model store to make room for other models",
+	})
+)
+
+func init() {
+	MetricsRegistry.MustRegister(modelDownloadDuration, modelDownloadBytes, modelDownloadFiles, modelStoreUsedBytes,
+		modelStoreFreeBytes, modelStoreEvictions)
+}
+
+// recordDownload records the statistics of the download of a model
+func recordDownload(modelName string, status *storage.DownloadStatus) {
+	modelDownloadDuration.WithLabelValues(modelName).Observe(status.DurationSeconds)
+	modelDownloadBytes.WithLabelValues(modelName).Add(float64(status.Bytes))
+	modelDownloadFiles.WithLabelValues(modelName).Add(float64(status.Files))
+}
+
+// NewComponentGatherer returns a gatherer scraping the metrics the component exposes in the text format at url, so that
+// the metrics endpoint of the agent serves the metrics of both containers on a single port. A component without
+// metrics fails its scrape, which is reported alongside the metrics gathered from the other gatherers.
+func NewComponentGatherer(url string) prometheus.Gatherer {
+	client := &http.Client{Timeout: componentMetricsTimeout}
+	return prometheus.GathererFunc(func() ([]*dto.MetricFamily, error) {
+		resp, err := client.Get(url)
+		if err != nil {
+			return nil, fmt.Errorf("error scraping the component metrics %s: %w", url, err)
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("error scraping the component metrics %s, status code: %d", url, resp.StatusCode)
+		}
+		var parser expfmt.TextParser
+		families, err := parser.TextToMetricFamilies(resp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing the component metrics %s: %w", url, err)
+		}
+		result := make([]*dto.MetricFamily, 0, len(families))
+		for _, family := range families {
+			result = append(result, family)
+		}
+		return result, nil
+	})
+}
@@ -0,0 +1,63 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package agent
+
+import (
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var _ = Describe("Component metrics", func() {
+	Context("When the component exposes metrics", func() {
+		It("Should merge them with the agent metrics", func() {
+			component := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				Expect(r.URL.Path).To(Equal("/metrics"))
+				_, _ = w.Write([]byte("# HELP request_predict_seconds predict latency\n" +
+					"# TYPE request_predict_seconds counter\n" +
+					"request_predict_seconds{model_name=\"sklearn\"} 1.5\n"))
+			}))
+			defer component.Close()
+
+			families, err := prometheus.Gatherers{MetricsRegistry, NewComponentGatherer(component.URL + "/metrics")}.Gather()
+			Expect(err).ToNot(HaveOccurred())
+			names := []string{}
+			for _, family := range families {
+				names = append(names, family.GetName())
+			}
+			Expect(names).To(ContainElements("request_predict_seconds", "kserve_agent_model_store_evictions_total"))
+		})
+	})
+
+	Context("When the component does not expose metrics", func() {
+		It("Should still gather the agent metrics", func() {
+			component := httptest.NewServer(http.NotFoundHandler())
+			defer component.Close()
+
+			families, err := prometheus.Gatherers{MetricsRegistry, NewComponentGatherer(component.URL + "/metrics")}.Gather()
+			Expect(err).To(MatchError(ContainSubstring("status code: 404")))
+			names := []string{}
+			for _, family := range families {
+				names = append(names, family.GetName())
+			}
+			Expect(names).To(ContainElement("kserve_agent_model_store_evictions_total"))
+		})
+	})
+})
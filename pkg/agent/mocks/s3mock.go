@@ -40,6 +40,15 @@ func (m *MockS3Client) ListObjects(*s3.ListObjectsInput) (*s3.ListObjectsOutput,
 	}, nil
 }
 
+func (m *MockS3Client) ListObjectsPages(input *s3.ListObjectsInput, fn func(*s3.ListObjectsOutput, bool) bool) error {
+	output, err := m.ListObjects(input)
+	if err != nil {
+		return err
+	}
+	fn(output, true)
+	return nil
+}
+
 type MockS3Downloader struct {
 }
 
@@ -24,7 +24,9 @@ import (
 	"path/filepath"
 	"sync"
 	"syscall"
+	"time"
 
+	"github.com/kserve/kserve/pkg/agent/status"
 	"github.com/kserve/kserve/pkg/agent/storage"
 	v1 "github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
 	"go.uber.org/zap"
@@ -37,6 +39,12 @@ const (
 	Remove OpType = "Remove"
 )
 
+const (
+	DefaultPullerWorkers    = 4
+	DefaultPullerMaxRetries = 3
+	DefaultModelServerURL   = "http://localhost:8080"
+)
+
 type Puller struct {
 	channelMap  map[string]*ModelChannel
 	completions chan *ModelOp
@@ -44,6 +52,44 @@ type Puller struct {
 	waitGroup   WaitGroupWrapper
 	Downloader  *Downloader
 	logger      *zap.SugaredLogger
+	config      PullerConfig
+	// slots holds a token per model operation in progress, it is nil when the operations are not limited
+	slots    chan struct{}
+	statuses *status.Tracker
+}
+
+// PullerConfig configures the concurrency and the retries of the model operations
+type PullerConfig struct {
+	// Workers is the max number of model operations in progress, unlimited if not positive
+	Workers int
+	// MaxRetries is the number of retries of the failed model loads
+	MaxRetries     int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	// ModelServerURL is the URL of the model repository API of the model server
+	ModelServerURL string
+}
+
+func DefaultPullerConfig() PullerConfig {
+	return PullerConfig{
+		Workers:        DefaultPullerWorkers,
+		MaxRetries:     DefaultPullerMaxRetries,
+		InitialBackoff: time.Second,
+		MaxBackoff:     time.Minute,
+		ModelServerURL: DefaultModelServerURL,
+	}
+}
+
+// backoff returns the delay before the retry, doubling from InitialBackoff up to MaxBackoff
+func (c *PullerConfig) backoff(retry int) time.Duration {
+	delay := c.InitialBackoff
+	for i := 0; i < retry && delay < c.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > c.MaxBackoff {
+		delay = c.MaxBackoff
+	}
+	return delay
 }
 
 type ModelOp struct {
@@ -57,7 +103,8 @@ type WaitGroupWrapper struct {
 	wg sync.WaitGroup
 }
 
-func StartPullerAndProcessModels(downloader *Downloader, commands <-chan ModelOp, logger *zap.SugaredLogger) {
+func StartPullerAndProcessModels(downloader *Downloader, commands <-chan ModelOp, config PullerConfig,
+	statuses *status.Tracker, logger *zap.SugaredLogger) {
 	puller := Puller{
 		channelMap:  make(map[string]*ModelChannel),
 		completions: make(chan *ModelOp, 4),
@@ -65,6 +112,11 @@ func StartPullerAndProcessModels(downloader *Downloader, commands <-chan ModelOp
 		waitGroup:   WaitGroupWrapper{sync.WaitGroup{}},
 		Downloader:  downloader,
 		logger:      logger,
+		config:      config,
+		statuses:    statuses,
+	}
+	if config.Workers > 0 {
+		puller.slots = make(chan struct{}, config.Workers)
 	}
 
 	// Change umask to ensure we have control over the downloaded file
@@ -145,78 +197,108 @@ func (p *Puller) modelProcessor(modelName string, ops <-chan *ModelOp) {
 	// this is important for handling Load --> Unload requests sent in tandem
 	// Load --> Unload = 0 (cancel first load)
 	// Load --> Unload --> Load = 1 Load (cancel second load?)
-	var processOp = func(modelOp *ModelOp) {
+	for modelOp := range ops {
 		switch modelOp.Op {
 		case Add:
-			p.logger.Infof("Downloading model from %s", modelOp.Spec.StorageURI)
-			err := p.Downloader.DownloadModel(modelName, modelOp.Spec)
-			if err != nil {
-				// If there is an error, we will NOT send a request. As such, to know about errors, you will
-				// need to call the error endpoint of the puller
-				p.logger.Errorf("Failed to download model %s with err %v", modelName, err)
-				break
-			}
-			// Load the model onto the model server
-			resp, err := http.Post(fmt.Sprintf("http://localhost:8080/v2/repository/models/%s/load", modelName),
-				"application/json",
-				bytes.NewBufferString("{}"))
-			if err != nil {
-				// handle error
-				p.logger.Errorf("Failed to Load model %s", modelName)
-			} else {
-				defer func() {
-					if resp.Body != nil {
-						closeErr := resp.Body.Close()
-						if closeErr != nil {
-							p.logger.Error(closeErr, "failed to close body")
-						}
-					}
-				}()
-				if resp.StatusCode == 200 {
-					p.logger.Infof("Successfully loaded model %s", modelName)
-				} else {
-					body, err := io.ReadAll(resp.Body)
-					if err == nil {
-						p.logger.Infof("Failed to load model %s with status [%d] and resp:%v", modelName, resp.StatusCode, body)
-					}
-				}
-			}
+			p.loadModel(modelName, modelOp.Spec, ops)
 		case Remove:
-			p.logger.Infof("unloading model %s", modelName)
-			// If there is an error, we will NOT do a delete... that could be problematic
-			if err := storage.RemoveDir(filepath.Join(p.Downloader.ModelDir, modelName)); err != nil {
-				p.logger.Error(err, "failing to delete model directory")
-				break
-			}
-			// unload model from model server
-			resp, err := http.Post(fmt.Sprintf("http://localhost:8080/v2/repository/models/%s/unload", modelName),
-				"application/json",
-				bytes.NewBufferString("{}"))
-			if err != nil {
-				// handle error
-				p.logger.Errorf("Failed to Unload model %s", modelName)
-			} else {
-				if resp != nil {
-					defer func() {
-						closeErr := resp.Body.Close()
-						if closeErr != nil {
-							p.logger.Error(closeErr, "failed to close body")
-						}
-					}()
-				}
-				if resp.StatusCode == 200 {
-					p.logger.Infof("Successfully unloaded model %s", modelName)
-				} else {
-					body, err := io.ReadAll(resp.Body)
-					if err == nil {
-						p.logger.Infof("Failed to unload model %s with status [%d] and resp:%v", modelName, resp.StatusCode, body)
-					}
-				}
-			}
+			p.unloadModel(modelName)
 		}
 		p.completions <- modelOp
 	}
-	for modelOp := range ops {
-		processOp(modelOp)
+}
+
+// withSlot runs the model operation once less than Workers operations are in progress, so that the operations of
+// the other models wait, and not fail
+func (p *Puller) withSlot(op func() error) error {
+	if p.slots != nil {
+		p.slots <- struct{}{}
+		defer func() { <-p.slots }()
+	}
+	return op()
+}
+
+// loadModel downloads and loads the model, retrying with backoff until it succeeds, MaxRetries is reached, or a
+// newer operation of the model is queued
+func (p *Puller) loadModel(modelName string, spec *v1.ModelSpec, ops <-chan *ModelOp) {
+	p.statuses.Set(modelName, status.Pending, 0, nil)
+	p.Downloader.Disk.Acquire(modelName)
+	for attempt := 1; ; attempt++ {
+		err := p.withSlot(func() error {
+			p.statuses.Set(modelName, status.Loading, attempt, nil)
+			return p.downloadAndLoad(modelName, spec)
+		})
+		if err == nil {
+			p.logger.Infof("Successfully loaded model %s", modelName)
+			p.statuses.Set(modelName, status.Loaded, attempt, nil)
+			return
+		}
+		// If there is an error, we will NOT send a request. As such, to know about errors, you will
+		// need to call the status endpoint of the agent
+		p.logger.Errorf("Failed to load model %s with err %v", modelName, err)
+		if attempt > p.config.MaxRetries || len(ops) > 0 {
+			p.statuses.Set(modelName, status.Failed, attempt, err)
+			p.Downloader.Disk.Release(modelName)
+			return
+		}
+		delay := p.config.backoff(attempt - 1)
+		p.logger.Infof("Retrying to load model %s in %v", modelName, delay)
+		p.statuses.Set(modelName, status.Pending, attempt, err)
+		time.Sleep(delay)
+	}
+}
+
+func (p *Puller) downloadAndLoad(modelName string, spec *v1.ModelSpec) error {
+	p.logger.Infof("Downloading model from %s", spec.StorageURI)
+	if err := p.Downloader.DownloadModel(modelName, spec); err != nil {
+		return fmt.Errorf("failed to download model: %w", err)
+	}
+	// Load the model onto the model server
+	return p.modelRepositoryRequest(modelName, "load")
+}
+
+func (p *Puller) unloadModel(modelName string) {
+	p.logger.Infof("unloading model %s", modelName)
+	err := p.withSlot(func() error {
+		// The files of the model are kept when the disk manager reclaims them later
+		if p.Downloader.Disk.Release(modelName) {
+			return p.modelRepositoryRequest(modelName, "unload")
+		}
+		// If there is an error, we will NOT do a delete... that could be problematic
+		if err := storage.RemoveDir(filepath.Join(p.Downloader.ModelDir, modelName)); err != nil {
+			return fmt.Errorf("failed to delete model directory: %w", err)
+		}
+		// unload model from model server
+		return p.modelRepositoryRequest(modelName, "unload")
+	})
+	if err != nil {
+		p.logger.Errorf("Failed to unload model %s with err %v", modelName, err)
+	} else {
+		p.logger.Infof("Successfully unloaded model %s", modelName)
+	}
+	p.statuses.Delete(modelName)
+}
+
+// modelRepositoryRequest sends the load or unload request of the model to the model server
+func (p *Puller) modelRepositoryRequest(modelName string, action string) error {
+	modelServerURL := p.config.ModelServerURL
+	if modelServerURL == "" {
+		modelServerURL = DefaultModelServerURL
+	}
+	resp, err := http.Post(fmt.Sprintf("%s/v2/repository/models/%s/%s", modelServerURL, modelName, action),
+		"application/json",
+		bytes.NewBufferString("{}"))
+	if err != nil {
+		return fmt.Errorf("failed to %s model: %w", action, err)
+	}
+	defer func() {
+		if closeErr := resp.Body.Close(); closeErr != nil {
+			p.logger.Error(closeErr, "failed to close body")
+		}
+	}()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to %s model with status [%d] and resp: %s", action, resp.StatusCode, body)
 	}
+	return nil
 }
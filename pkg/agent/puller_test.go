@@ -0,0 +1,142 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package agent
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/kserve/kserve/pkg/agent/mocks"
+	"github.com/kserve/kserve/pkg/agent/status"
+	"github.com/kserve/kserve/pkg/agent/storage"
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+var _ = Describe("Puller", func() {
+	var modelDir string
+	var sugar *zap.SugaredLogger
+	BeforeEach(func() {
+		dir, err := os.MkdirTemp("", "puller")
+		Expect(err).ToNot(HaveOccurred())
+		modelDir = dir
+		zapLogger, _ := zap.NewProduction()
+		sugar = zapLogger.Sugar()
+	})
+	AfterEach(func() {
+		os.RemoveAll(modelDir)
+	})
+
+	Describe("Load models concurrently", func() {
+		Context("When the models complete out of order and a model fails", func() {
+			It("Should report the state of each model", func() {
+				defer GinkgoRecover()
+				releaseSlow := make(chan struct{})
+				var inFlight, maxInFlight, badAttempts int32
+				server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					current := atomic.AddInt32(&inFlight, 1)
+					defer atomic.AddInt32(&inFlight, -1)
+					for {
+						observed := atomic.LoadInt32(&maxInFlight)
+						if current <= observed || atomic.CompareAndSwapInt32(&maxInFlight, observed, current) {
+							break
+						}
+					}
+					switch {
+					case strings.Contains(r.URL.Path, "/slow/"):
+						<-releaseSlow
+					case strings.Contains(r.URL.Path, "/bad/"):
+						atomic.AddInt32(&badAttempts, 1)
+						w.WriteHeader(http.StatusInternalServerError)
+						return
+					}
+					w.WriteHeader(http.StatusOK)
+				}))
+				defer server.Close()
+
+				statuses := status.NewTracker()
+				config := PullerConfig{
+					Workers:        2,
+					MaxRetries:     2,
+					InitialBackoff: 10 * time.Millisecond,
+					MaxBackoff:     20 * time.Millisecond,
+					ModelServerURL: server.URL,
+				}
+				puller := Puller{
+					channelMap:  make(map[string]*ModelChannel),
+					completions: make(chan *ModelOp, 4),
+					opStats:     make(map[string]map[OpType]int),
+					waitGroup:   WaitGroupWrapper{sync.WaitGroup{}},
+					Downloader: &Downloader{
+						ModelDir: modelDir,
+						Providers: map[storage.Protocol]storage.Provider{
+							storage.S3: &storage.S3Provider{
+								Client:     &mocks.MockS3Client{},
+								Downloader: &mocks.MockS3Downloader{},
+							},
+						},
+						Logger: sugar,
+					},
+					logger:   sugar,
+					config:   config,
+					slots:    make(chan struct{}, config.Workers),
+					statuses: statuses,
+				}
+				commands := make(chan ModelOp, 4)
+				for _, name := range []string{"slow", "fast", "bad", "other"} {
+					commands <- ModelOp{
+						ModelName: name,
+						Op:        Add,
+						Spec: &v1alpha1.ModelSpec{
+							StorageURI: "s3://models/" + name,
+							Framework:  "sklearn",
+							Memory:     resource.MustParse("100Mi"),
+						},
+					}
+				}
+				go puller.processCommands(commands)
+
+				state := func(name string) status.ModelState {
+					modelStatus, _ := statuses.Get(name)
+					return modelStatus.State
+				}
+				// the slow model holds a worker, the other models are loaded with the remaining worker
+				Eventually(func() status.ModelState { return state("fast") }).Should(Equal(status.Loaded))
+				Eventually(func() status.ModelState { return state("other") }).Should(Equal(status.Loaded))
+				Eventually(func() status.ModelState { return state("bad") }).Should(Equal(status.Failed))
+				Expect(state("slow")).To(Equal(status.Loading))
+
+				badStatus, _ := statuses.Get("bad")
+				Expect(badStatus.Attempts).To(Equal(3))
+				Expect(badStatus.Reason).To(ContainSubstring("500"))
+				Expect(atomic.LoadInt32(&badAttempts)).To(Equal(int32(3)))
+
+				close(releaseSlow)
+				Eventually(func() status.ModelState { return state("slow") }).Should(Equal(status.Loaded))
+				Expect(atomic.LoadInt32(&maxInFlight)).To(BeNumerically("<=", 2))
+			})
+		})
+	})
+})
@@ -0,0 +1,148 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package status tracks the states of the models the agent pulls, and serves them to the TrainedModel controller.
+package status
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	// Path is the path of the model states on the metrics port of the agent
+	Path = "/agent/models"
+	// DefaultPort is the default metrics port of the agent
+	DefaultPort = 9082
+)
+
+// ModelState is the state of a model of the agent
+type ModelState string
+
+const (
+	// Pending models wait for a puller worker, or for a retry of their load
+	Pending ModelState = "Pending"
+	// Loading models are downloaded and loaded onto the model server
+	Loading ModelState = "Loading"
+	// Loaded models are ready on the model server
+	Loaded ModelState = "Loaded"
+	// Failed models could not be loaded after all the retries
+	Failed ModelState = "Failed"
+)
+
+// ModelStatus is the status of a model of the agent
+type ModelStatus struct {
+	State ModelState `json:"state"`
+	// Reason is the last load error of the model
+	Reason string `json:"reason,omitempty"`
+	// Code is the machine-readable cause of the last load error, when the error has one
+	Code string `json:"code,omitempty"`
+	// Attempts is the number of load attempts of the model
+	Attempts           int       `json:"attempts,omitempty"`
+	LastTransitionTime time.Time `json:"lastTransitionTime"`
+}
+
+// Coder is implemented by the load errors with a machine-readable cause
+type Coder interface {
+	Code() string
+}
+
+// Tracker holds the statuses of the models by model name, its methods can be called on a nil Tracker
+type Tracker struct {
+	mu       sync.RWMutex
+	statuses map[string]ModelStatus
+}
+
+func NewTracker() *Tracker {
+	return &Tracker{
+		statuses: map[string]ModelStatus{},
+	}
+}
+
+// Set records the state of the model along with its last load error if any
+func (t *Tracker) Set(modelName string, state ModelState, attempts int, err error) {
+	if t == nil {
+		return
+	}
+	modelStatus := ModelStatus{
+		State:              state,
+		Attempts:           attempts,
+		LastTransitionTime: time.Now().UTC(),
+	}
+	if err != nil {
+		modelStatus.Reason = err.Error()
+		var coder Coder
+		if errors.As(err, &coder) {
+			modelStatus.Code = coder.Code()
+		}
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.statuses[modelName] = modelStatus
+}
+
+// Delete forgets the model once it is unloaded
+func (t *Tracker) Delete(modelName string) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.statuses, modelName)
+}
+
+// Get returns the status of the model, and whether the model is tracked
+func (t *Tracker) Get(modelName string) (ModelStatus, bool) {
+	if t == nil {
+		return ModelStatus{}, false
+	}
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	modelStatus, ok := t.statuses[modelName]
+	return modelStatus, ok
+}
+
+// List returns a copy of the statuses of all the models
+func (t *Tracker) List() map[string]ModelStatus {
+	statuses := map[string]ModelStatus{}
+	if t == nil {
+		return statuses
+	}
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	for name, modelStatus := range t.statuses {
+		statuses[name] = modelStatus
+	}
+	return statuses
+}
+
+// ServeHTTP serves the statuses of all the models as a json object keyed by model name
+func (t *Tracker) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	body, err := json.Marshal(t.List())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(body)
+}
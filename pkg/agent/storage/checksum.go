@@ -0,0 +1,159 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const (
+	// KServeManifestFileName is the name of the json manifest listing the sha256 digests of the model files
+	KServeManifestFileName = "kserve-manifest.json"
+	// SHA256SumsFileName is the name of the manifest in the format of the output of sha256sum
+	SHA256SumsFileName = "SHA256SUMS"
+)
+
+// ChecksumManifestFileNames are the manifests looked up at the root of the model, in order of precedence
+var ChecksumManifestFileNames = []string{KServeManifestFileName, SHA256SumsFileName}
+
+var sha256SumsLineRegex = regexp.MustCompile(`^([0-9a-fA-F]{64}) [ *](.+)$`)
+
+// kserveManifest is the content of the kserve-manifest.json file
+type kserveManifest struct {
+	Files []kserveManifestFile `json:"files"`
+}
+
+type kserveManifestFile struct {
+	Path   string `json:"path"`
+	Sha256 string `json:"sha256"`
+}
+
+// VerifyChecksums verifies the sha256 digest of the files listed by the manifest found at the root of the model
+// path. The verification is skipped when the model has no manifest. Files which are not listed are not verified.
+func VerifyChecksums(modelPath string) error {
+	manifestPath := ""
+	for _, name := range ChecksumManifestFileNames {
+		if FileExists(filepath.Join(modelPath, name)) {
+			manifestPath = filepath.Join(modelPath, name)
+			break
+		}
+	}
+	if manifestPath == "" {
+		log.Info("No checksum manifest found, skipping checksum verification", "modelPath", modelPath)
+		return nil
+	}
+	checksums, err := readChecksumManifest(manifestPath)
+	if err != nil {
+		return fmt.Errorf("unable to read checksum manifest %s: %w", manifestPath, err)
+	}
+
+	paths := make([]string, 0, len(checksums))
+	for path := range checksums {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	var mismatches []string
+	for _, path := range paths {
+		actual, err := fileSha256(filepath.Join(modelPath, path))
+		switch {
+		case os.IsNotExist(err):
+			mismatches = append(mismatches, fmt.Sprintf("%s (missing)", path))
+		case err != nil:
+			return fmt.Errorf("unable to compute the checksum of %s: %w", path, err)
+		case actual != checksums[path]:
+			mismatches = append(mismatches, fmt.Sprintf("%s (expected sha256 %s, got %s)", path, checksums[path], actual))
+		}
+	}
+	if len(mismatches) > 0 {
+		return fmt.Errorf("checksum verification against %s failed for %d file(s): %s",
+			filepath.Base(manifestPath), len(mismatches), strings.Join(mismatches, ", "))
+	}
+	log.Info("Verified model checksums", "manifest", manifestPath, "files", len(paths))
+	return nil
+}
+
+// readChecksumManifest returns the expected sha256 digests of the manifest by path relative to the model root
+func readChecksumManifest(manifestPath string) (map[string]string, error) {
+	file, err := os.Open(manifestPath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	entries := map[string]string{}
+	if filepath.Base(manifestPath) == KServeManifestFileName {
+		manifest := kserveManifest{}
+		if err := json.NewDecoder(file).Decode(&manifest); err != nil {
+			return nil, err
+		}
+		for _, f := range manifest.Files {
+			entries[f.Path] = f.Sha256
+		}
+	} else {
+		scanner := bufio.NewScanner(file)
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			match := sha256SumsLineRegex.FindStringSubmatch(line)
+			if match == nil {
+				return nil, fmt.Errorf("invalid line %q", line)
+			}
+			entries[match[2]] = match[1]
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	checksums := make(map[string]string, len(entries))
+	for path, digest := range entries {
+		cleanPath := filepath.Clean(strings.TrimPrefix(path, "./"))
+		if path == "" || filepath.IsAbs(cleanPath) || cleanPath == ".." || strings.HasPrefix(cleanPath, "../") {
+			return nil, fmt.Errorf("invalid path %q, paths must be relative to the model root", path)
+		}
+		if _, err := hex.DecodeString(digest); err != nil || len(digest) != 2*sha256.Size {
+			return nil, fmt.Errorf("invalid sha256 digest %q of %s", digest, path)
+		}
+		checksums[cleanPath] = strings.ToLower(digest)
+	}
+	return checksums, nil
+}
+
+func fileSha256(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, file); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/onsi/gomega"
+)
+
+func writeModelFiles(g *gomega.WithT, dir string, files map[string]string) {
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		g.Expect(os.MkdirAll(filepath.Dir(path), 0755)).To(gomega.Succeed())
+		g.Expect(os.WriteFile(path, []byte(content), 0600)).To(gomega.Succeed())
+	}
+}
+
+func TestVerifyChecksums(t *testing.T) {
+	modelFiles := map[string]string{
+		"config.json":              `{"model_type": "bert"}`,
+		"shards/model.safetensors": "weights",
+	}
+	configDigest := strings.TrimPrefix(sha256Digest([]byte(`{"model_type": "bert"}`)), "sha256:")
+	weightsDigest := strings.TrimPrefix(sha256Digest([]byte("weights")), "sha256:")
+	truncatedDigest := strings.TrimPrefix(sha256Digest([]byte("weigh")), "sha256:")
+
+	scenarios := map[string]struct {
+		manifests   map[string]string
+		expectedErr string
+	}{
+		"NoManifest": {},
+		"SHA256SUMSMatch": {
+			manifests: map[string]string{
+				SHA256SumsFileName: fmt.Sprintf("%s  config.json\n%s *./shards/model.safetensors\n", configDigest, weightsDigest),
+			},
+		},
+		"KServeManifestMatch": {
+			manifests: map[string]string{
+				KServeManifestFileName: fmt.Sprintf(`{"files": [{"path": "config.json", "sha256": "%s"}, {"path": "shards/model.safetensors", "sha256": "%s"}]}`,
+					configDigest, weightsDigest),
+			},
+		},
+		"Mismatch": {
+			manifests: map[string]string{
+				SHA256SumsFileName: fmt.Sprintf("%s  config.json\n%s  shards/model.safetensors\n%s  tokenizer.json\n",
+					configDigest, truncatedDigest, weightsDigest),
+			},
+			expectedErr: fmt.Sprintf("checksum verification against SHA256SUMS failed for 2 file(s): "+
+				"shards/model.safetensors (expected sha256 %s, got %s), tokenizer.json (missing)", truncatedDigest, weightsDigest),
+		},
+		"KServeManifestTakesPrecedence": {
+			manifests: map[string]string{
+				KServeManifestFileName: fmt.Sprintf(`{"files": [{"path": "shards/model.safetensors", "sha256": "%s"}]}`, truncatedDigest),
+				SHA256SumsFileName:     fmt.Sprintf("%s  shards/model.safetensors\n", weightsDigest),
+			},
+			expectedErr: "checksum verification against kserve-manifest.json failed for 1 file(s)",
+		},
+		"PathOutsideOfTheModel": {
+			manifests: map[string]string{
+				SHA256SumsFileName: fmt.Sprintf("%s  ../config.json\n", configDigest),
+			},
+			expectedErr: `invalid path "../config.json"`,
+		},
+		"InvalidLine": {
+			manifests: map[string]string{
+				SHA256SumsFileName: "config.json\n",
+			},
+			expectedErr: `invalid line "config.json"`,
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			modelDir := t.TempDir()
+			writeModelFiles(g, modelDir, modelFiles)
+			writeModelFiles(g, modelDir, scenario.manifests)
+
+			err := VerifyChecksums(modelDir)
+			if scenario.expectedErr == "" {
+				g.Expect(err).NotTo(gomega.HaveOccurred())
+			} else {
+				g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring(scenario.expectedErr)))
+			}
+		})
+	}
+}
@@ -28,13 +28,24 @@ import (
 	gstorage "cloud.google.com/go/storage"
 	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/googleapis/google-cloud-go-testing/storage/stiface"
+	"google.golang.org/api/googleapi"
 	"google.golang.org/api/iterator"
 )
 
+// GCSXMLAPIEndpoint is the endpoint of the XML API of GCS, which is interoperable with s3 using HMAC keys
+const GCSXMLAPIEndpoint = "https://storage.googleapis.com"
+
 type GCSProvider struct {
 	Client stiface.Client
+	// HMAC downloads the objects through the XML API with HMAC keys instead of Client when set
+	HMAC *S3Provider
+	// Retry configures the retries of the objects which failed to download
+	Retry RetryConfig
 }
 
+// DownloadModel downloads the objects under the prefix of the storage uri. The last segments of the uri may be a
+// glob, e.g. gs://bucket/models/bert/*.safetensors, matched against the object names relative to the prefix before
+// the glob, in which case only the matching objects are downloaded and their directory structure is preserved.
 func (p *GCSProvider) DownloadModel(modelDir string, modelName string, storageUri string) error {
 	log.Info("Downloading model ", "modelName", modelName, "storageUri", storageUri, "modelDir", modelDir)
 	gcsUri := strings.TrimPrefix(storageUri, string(GCS))
@@ -43,6 +54,10 @@ func (p *GCSProvider) DownloadModel(modelDir string, modelName string, storageUr
 	if len(tokens) == 2 {
 		prefix = tokens[1]
 	}
+	prefix, pattern := splitObjectGlob(prefix)
+	if p.HMAC != nil {
+		return p.downloadModelWithHMAC(modelDir, modelName, storageUri, tokens[0], prefix, pattern)
+	}
 	ctx := context.Background()
 	gcsObjectDownloader := &GCSObjectDownloader{
 		Context:    ctx,
@@ -51,6 +66,8 @@ func (p *GCSProvider) DownloadModel(modelDir string, modelName string, storageUr
 		ModelName:  modelName,
 		Bucket:     tokens[0],
 		Item:       prefix,
+		Pattern:    pattern,
+		Retry:      p.Retry,
 	}
 	it, err := gcsObjectDownloader.GetObjectIterator(p.Client)
 	if err != nil {
@@ -62,6 +79,42 @@ func (p *GCSProvider) DownloadModel(modelDir string, modelName string, storageUr
 	return nil
 }
 
+// downloadModelWithHMAC downloads the objects through the XML API of GCS with the s3 downloader
+func (p *GCSProvider) downloadModelWithHMAC(modelDir string, modelName string, storageUri string, bucket string,
+	prefix string, pattern string) error {
+	s3ObjectDownloader := &S3ObjectDownloader{
+		StorageUri:  storageUri,
+		ModelDir:    modelDir,
+		ModelName:   modelName,
+		Bucket:      bucket,
+		Prefix:      prefix,
+		Pattern:     pattern,
+		Concurrency: p.HMAC.Concurrency,
+		PartSize:    p.HMAC.PartSize,
+		Retry:       p.Retry,
+		downloader:  p.HMAC.Downloader,
+	}
+	objects, err := s3ObjectDownloader.GetAllObjects(p.HMAC.Client)
+	if err != nil {
+		return fmt.Errorf("unable to get object list because: %w", err)
+	}
+	if err := s3ObjectDownloader.Download(objects); err != nil {
+		return fmt.Errorf("unable to download object/s because: %w", err)
+	}
+	return nil
+}
+
+// splitObjectGlob splits the object path at the last "/" before the first glob metacharacter, returning the prefix
+// the objects are listed with and the glob matched against the object names relative to it, empty without glob
+func splitObjectGlob(objectPath string) (string, string) {
+	index := strings.IndexAny(objectPath, "*?[")
+	if index < 0 {
+		return objectPath, ""
+	}
+	index = strings.LastIndex(objectPath[:index], "/") + 1
+	return objectPath[:index], objectPath[index:]
+}
+
 type GCSObjectDownloader struct {
 	Context    context.Context
 	StorageUri string
@@ -69,6 +122,8 @@ type GCSObjectDownloader struct {
 	ModelName  string
 	Bucket     string
 	Item       string
+	Pattern    string
+	Retry      RetryConfig
 }
 
 func (g *GCSObjectDownloader) GetObjectIterator(client stiface.Client) (stiface.ObjectIterator, error) {
@@ -76,6 +131,7 @@ func (g *GCSObjectDownloader) GetObjectIterator(client stiface.Client) (stiface.
 	return client.Bucket(g.Bucket).Objects(g.Context, query), nil
 }
 
+// Download downloads the objects of the iterator, only the objects whose names relative to Item match Pattern when set
 func (g *GCSObjectDownloader) Download(client stiface.Client, it stiface.ObjectIterator) error {
 	var errs []error
 	// flag to help determine if query prefix returned an empty iterator
@@ -90,20 +146,28 @@ func (g *GCSObjectDownloader) Download(client stiface.Client, it stiface.ObjectI
 			return fmt.Errorf("an error occurred while iterating: %w", err)
 		}
 		objectValue := strings.TrimPrefix(attrs.Name, g.Item)
+		if g.Pattern != "" && !matchesAnyGlob([]string{g.Pattern}, objectValue) {
+			continue
+		}
 		fileName := filepath.Join(g.ModelDir, g.ModelName, objectValue)
 
 		foundObject = true
-		if FileExists(fileName) {
-			log.Info("Deleting", fileName)
-			if err := os.Remove(fileName); err != nil {
-				return fmt.Errorf("file is unable to be deleted: %w", err)
-			}
+		if fileSize(fileName) == attrs.Size {
+			log.Info("Skipping downloaded object", "name", attrs.Name, "fileName", fileName)
+			continue
 		}
-		file, err := Create(fileName)
+		err = g.Retry.Do(g.Context, attrs.Name, func() error {
+			err := g.DownloadFile(client, attrs, fileName)
+			if errors.Is(err, gstorage.ErrObjectNotExist) {
+				return permanent(err)
+			}
+			var apiErr *googleapi.Error
+			if errors.As(err, &apiErr) && !isRetryableStatusCode(apiErr.Code) {
+				return permanent(err)
+			}
+			return err
+		})
 		if err != nil {
-			return fmt.Errorf("file is already created: %w", err)
-		}
-		if err := g.DownloadFile(client, attrs, file); err != nil {
 			errs = append(errs, err)
 		}
 	}
@@ -116,35 +180,56 @@ func (g *GCSObjectDownloader) Download(client stiface.Client, it stiface.ObjectI
 	return nil
 }
 
-func (g *GCSObjectDownloader) DownloadFile(client stiface.Client, attrs *gstorage.ObjectAttrs, file *os.File) error {
-	reader, err := client.Bucket(attrs.Bucket).Object(attrs.Name).NewReader(g.Context)
+// DownloadFile downloads the object to its partial download file, resuming the previous download if any, and renames
+// it once complete
+func (g *GCSObjectDownloader) DownloadFile(client stiface.Client, attrs *gstorage.ObjectAttrs, fileName string) error {
+	// the objects are read sequentially, so all the data of the partial download file was downloaded
+	offset := fileSize(fileName + PartialDownloadSuffix)
+	if offset < 0 || offset > attrs.Size {
+		offset = 0
+	}
+	file, err := openPartialFile(fileName, offset)
 	if err != nil {
-		return fmt.Errorf("failed to create reader for object(%s) in bucket(%s): %w",
-			attrs.Name,
-			attrs.Bucket,
-			err,
-		)
+		return fmt.Errorf("unable to create file: %w", err)
 	}
-	defer func(reader stiface.Reader) {
-		closeErr := reader.Close()
-		if closeErr != nil {
-			log.Error(closeErr, "failed to close reader")
+	defer func(file *os.File) {
+		closeErr := file.Close()
+		if closeErr != nil && !errors.Is(closeErr, os.ErrClosed) {
+			log.Error(closeErr, "failed to close file")
+		}
+	}(file)
+	// a partial download file of the size of the object is complete, it only has to be renamed
+	if offset == 0 || offset < attrs.Size {
+		object := client.Bucket(attrs.Bucket).Object(attrs.Name)
+		var reader stiface.Reader
+		if offset > 0 {
+			log.Info("Resuming download", "name", attrs.Name, "offset", offset)
+			reader, err = object.NewRangeReader(g.Context, offset, -1)
+		} else {
+			reader, err = object.NewReader(g.Context)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to create reader for object(%s) in bucket(%s): %w",
+				attrs.Name,
+				attrs.Bucket,
+				err,
+			)
+		}
+		defer func(reader stiface.Reader) {
+			closeErr := reader.Close()
+			if closeErr != nil {
+				log.Error(closeErr, "failed to close reader")
+			}
+		}(reader)
+		if _, err := io.Copy(file, reader); err != nil {
+			return fmt.Errorf("failed to read object(%s) in bucket(%s): %w",
+				attrs.Name,
+				attrs.Bucket,
+				err,
+			)
 		}
-	}(reader)
-	data, err := io.ReadAll(reader)
-	if err != nil {
-		return fmt.Errorf("failed to read object(%s) in bucket(%s): %w",
-			attrs.Name,
-			attrs.Bucket,
-			err,
-		)
 	}
-	return g.WriteToFile(data, attrs, file)
-}
-
-func (g *GCSObjectDownloader) WriteToFile(data []byte, attrs *gstorage.ObjectAttrs, file *os.File) error {
-	_, err := file.Write(data)
-	if err != nil {
+	if err := file.Close(); err != nil {
 		return fmt.Errorf("failed to write data to file(%s): from object(%s) in bucket(%s): %w",
 			file.Name(),
 			attrs.Name,
@@ -152,12 +237,6 @@ func (g *GCSObjectDownloader) WriteToFile(data []byte, attrs *gstorage.ObjectAtt
 			err,
 		)
 	}
-	defer func(file *os.File) {
-		closeErr := file.Close()
-		if closeErr != nil {
-			log.Error(closeErr, "failed to close file")
-		}
-	}(file)
-	log.Info("Wrote " + attrs.Prefix + " to file " + file.Name())
-	return nil
+	log.Info("Wrote " + attrs.Name + " to file " + fileName)
+	return os.Rename(fileName+PartialDownloadSuffix, fileName)
 }
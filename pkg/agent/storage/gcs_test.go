@@ -0,0 +1,233 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	gstorage "cloud.google.com/go/storage"
+	"github.com/googleapis/google-cloud-go-testing/storage/stiface"
+	"github.com/onsi/gomega"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/iterator"
+)
+
+// fakeGCS serves the objects of a single bucket, the readers of the interrupted objects fail after reading half of
+// the requested range
+type fakeGCS struct {
+	stiface.Client
+	objects     map[string]string
+	interrupted map[string]int
+	err         error
+
+	offsets []int64
+}
+
+func (f *fakeGCS) Bucket(name string) stiface.BucketHandle {
+	return &fakeGCSBucket{f: f, name: name}
+}
+
+type fakeGCSBucket struct {
+	stiface.BucketHandle
+	f    *fakeGCS
+	name string
+}
+
+func (b *fakeGCSBucket) Objects(_ context.Context, query *gstorage.Query) stiface.ObjectIterator {
+	var items []*gstorage.ObjectAttrs
+	for name, content := range b.f.objects {
+		if strings.HasPrefix(name, query.Prefix) {
+			items = append(items, &gstorage.ObjectAttrs{Bucket: b.name, Name: name, Size: int64(len(content))})
+		}
+	}
+	return &fakeGCSIterator{items: items}
+}
+
+func (b *fakeGCSBucket) Object(name string) stiface.ObjectHandle {
+	return &fakeGCSObject{f: b.f, name: name}
+}
+
+type fakeGCSIterator struct {
+	stiface.ObjectIterator
+	items []*gstorage.ObjectAttrs
+}
+
+func (i *fakeGCSIterator) Next() (*gstorage.ObjectAttrs, error) {
+	if len(i.items) == 0 {
+		return nil, iterator.Done
+	}
+	item := i.items[0]
+	i.items = i.items[1:]
+	return item, nil
+}
+
+type fakeGCSObject struct {
+	stiface.ObjectHandle
+	f    *fakeGCS
+	name string
+}
+
+func (o *fakeGCSObject) NewReader(ctx context.Context) (stiface.Reader, error) {
+	return o.NewRangeReader(ctx, 0, -1)
+}
+
+func (o *fakeGCSObject) NewRangeReader(_ context.Context, offset, _ int64) (stiface.Reader, error) {
+	o.f.offsets = append(o.f.offsets, offset)
+	if o.f.err != nil {
+		return nil, o.f.err
+	}
+	content := o.f.objects[o.name][offset:]
+	if o.f.interrupted[o.name] > 0 {
+		o.f.interrupted[o.name]--
+		return &fakeGCSReader{r: io.MultiReader(strings.NewReader(content[:len(content)/2]),
+			&failingReader{err: fmt.Errorf("connection reset by peer")})}, nil
+	}
+	return &fakeGCSReader{r: strings.NewReader(content)}, nil
+}
+
+type fakeGCSReader struct {
+	stiface.Reader
+	r io.Reader
+}
+
+func (r *fakeGCSReader) Read(p []byte) (int, error) {
+	return r.r.Read(p)
+}
+
+func (r *fakeGCSReader) Close() error {
+	return nil
+}
+
+type failingReader struct {
+	err error
+}
+
+func (r *failingReader) Read([]byte) (int, error) {
+	return 0, r.err
+}
+
+func TestGCSProviderDownloadModelResume(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	content := strings.Repeat("0123456789", 10)
+	fake := &fakeGCS{
+		objects:     map[string]string{"models/bert/model.bin": content},
+		interrupted: map[string]int{"models/bert/model.bin": 2},
+	}
+	modelDir := t.TempDir()
+	retry := RetryConfig{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond}
+	provider := &GCSProvider{Client: fake, Retry: retry}
+	g.Expect(provider.DownloadModel(modelDir, "bert", "gs://bucket/models/bert/")).To(gomega.Succeed())
+	g.Expect(readLayout(g, modelDir)).To(gomega.Equal(map[string]string{"./": "", "bert/": "", "bert/model.bin": content}))
+	// every attempt resumes from the data read by the previous ones
+	g.Expect(fake.offsets).To(gomega.Equal([]int64{0, 50, 75}))
+
+	// the downloaded objects are skipped
+	g.Expect(provider.DownloadModel(modelDir, "bert", "gs://bucket/models/bert/")).To(gomega.Succeed())
+	g.Expect(fake.offsets).To(gomega.HaveLen(3))
+
+	// the failures which do not change on retries are not retried
+	fake = &fakeGCS{
+		objects: map[string]string{"models/bert/model.bin": content},
+		err:     &googleapi.Error{Code: http.StatusForbidden, Message: "access denied"},
+	}
+	provider = &GCSProvider{Client: fake, Retry: retry}
+	g.Expect(provider.DownloadModel(t.TempDir(), "bert", "gs://bucket/models/bert/")).NotTo(gomega.Succeed())
+	g.Expect(fake.offsets).To(gomega.HaveLen(1))
+
+	// the other failures are retried
+	fake = &fakeGCS{
+		objects: map[string]string{"models/bert/model.bin": content},
+		err:     &googleapi.Error{Code: http.StatusServiceUnavailable, Message: "backend error"},
+	}
+	provider = &GCSProvider{Client: fake, Retry: retry}
+	g.Expect(provider.DownloadModel(t.TempDir(), "bert", "gs://bucket/models/bert/")).NotTo(gomega.Succeed())
+	g.Expect(fake.offsets).To(gomega.HaveLen(3))
+}
+
+func TestSplitObjectGlob(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	scenarios := map[string]struct {
+		objectPath      string
+		expectedPrefix  string
+		expectedPattern string
+	}{
+		"NoGlob":        {objectPath: "models/bert/", expectedPrefix: "models/bert/"},
+		"FileGlob":      {objectPath: "models/bert/*.safetensors", expectedPrefix: "models/bert/", expectedPattern: "*.safetensors"},
+		"DirectoryGlob": {objectPath: "models/bert-?/config.json", expectedPrefix: "models/", expectedPattern: "bert-?/config.json"},
+		"BucketGlob":    {objectPath: "[a-z]*.bin", expectedPrefix: "", expectedPattern: "[a-z]*.bin"},
+	}
+	for name, scenario := range scenarios {
+		prefix, pattern := splitObjectGlob(scenario.objectPath)
+		g.Expect(prefix).To(gomega.Equal(scenario.expectedPrefix), name)
+		g.Expect(pattern).To(gomega.Equal(scenario.expectedPattern), name)
+	}
+}
+
+var globObjects = map[string]string{
+	"models/bert/config.json":            "config",
+	"models/bert/model.safetensors":      "weights",
+	"models/bert/onnx/model.safetensors": "onnx weights",
+	"models/bert/pytorch_model.bin":      "pickle",
+	"models/gpt2/model.safetensors":      "gpt2 weights",
+}
+
+func TestGCSProviderDownloadModelGlob(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	modelDir := t.TempDir()
+	provider := &GCSProvider{Client: &fakeGCS{objects: globObjects}}
+	g.Expect(provider.DownloadModel(modelDir, "bert", "gs://bucket/models/bert/*.safetensors")).To(gomega.Succeed())
+	// the matching objects are downloaded, keeping their directory structure
+	g.Expect(readLayout(g, modelDir)).To(gomega.Equal(map[string]string{
+		"./":                          "",
+		"bert/":                       "",
+		"bert/model.safetensors":      "weights",
+		"bert/onnx/":                  "",
+		"bert/onnx/model.safetensors": "onnx weights",
+	}))
+
+	g.Expect(provider.DownloadModel(t.TempDir(), "bert", "gs://bucket/models/bert/*.gguf")).To(
+		gomega.MatchError(gomega.ContainSubstring("object doesn't exist")))
+}
+
+func TestGCSProviderDownloadModelHMAC(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	modelDir := t.TempDir()
+	fake := &fakeS3{objects: globObjects}
+	provider := &GCSProvider{HMAC: &S3Provider{Client: fake, Downloader: fake}}
+	g.Expect(provider.DownloadModel(modelDir, "bert", "gs://bucket/models/bert/*.safetensors")).To(gomega.Succeed())
+	g.Expect(readLayout(g, modelDir)).To(gomega.Equal(map[string]string{
+		"./":                          "",
+		"bert/":                       "",
+		"bert/model.safetensors":      "weights",
+		"bert/onnx/":                  "",
+		"bert/onnx/model.safetensors": "onnx weights",
+	}))
+
+	modelDir = t.TempDir()
+	g.Expect(provider.DownloadModel(modelDir, "gpt2", "gs://bucket/models/gpt2")).To(gomega.Succeed())
+	g.Expect(readLayout(g, modelDir)).To(gomega.Equal(map[string]string{
+		"./":                     "",
+		"gpt2/":                  "",
+		"gpt2/model.safetensors": "gpt2 weights",
+	}))
+}
@@ -0,0 +1,387 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+
+	hfcredential "github.com/kserve/kserve/pkg/credentials/hf"
+)
+
+const (
+	// HFEndpointEnvKey is the env of the url of the Hugging Face Hub, following the huggingface_hub library
+	HFEndpointEnvKey  = "HF_ENDPOINT"
+	DefaultHFEndpoint = "https://huggingface.co"
+	HFDefaultRevision = "main"
+	// HFDownloadConcurrencyEnvKey is the env of the number of files downloaded in parallel
+	HFDownloadConcurrencyEnvKey  = "HF_DOWNLOAD_CONCURRENCY"
+	DefaultHFDownloadConcurrency = 4
+	// HFAllowPatternsEnvKey is the env of the comma separated globs of the files downloaded, all the files when not set
+	HFAllowPatternsEnvKey = "HF_ALLOW_PATTERNS"
+	// HFIgnorePatternsEnvKey is the env of the comma separated globs of the files which are not downloaded
+	HFIgnorePatternsEnvKey = "HF_IGNORE_PATTERNS"
+	// HFPreferSafetensorsEnvKey is the env disabling the skip of the *.bin weights of the repositories which have
+	// *.safetensors weights when set to false
+	HFPreferSafetensorsEnvKey = "HF_PREFER_SAFETENSORS"
+)
+
+var (
+	// hf://[<organization>/]<repository>[@<revision>]
+	hfReferenceRegexp = regexp.MustCompile(`^` +
+		`((?:[a-zA-Z0-9](?:[\w.-]*[a-zA-Z0-9])?/)?[a-zA-Z0-9](?:[\w.-]*[a-zA-Z0-9])?)` +
+		`(?:@([\w][\w./-]*))?$`)
+)
+
+// HFReference is the reference of a model repository of the Hugging Face Hub
+type HFReference struct {
+	RepoID   string
+	Revision string
+}
+
+// ParseHFReference parses a storage uri in the hf://[<organization>/]<repository>[@<revision>] format. The revision
+// is a branch, a tag or a commit and defaults to main.
+func ParseHFReference(storageUri string) (*HFReference, error) {
+	if !strings.HasPrefix(storageUri, string(HF)) {
+		return nil, fmt.Errorf("invalid hf uri %s: must start with %s", storageUri, HF)
+	}
+	parts := hfReferenceRegexp.FindStringSubmatch(strings.TrimPrefix(storageUri, string(HF)))
+	if parts == nil || strings.Contains(parts[2], "..") {
+		return nil, fmt.Errorf("invalid hf uri %s: must be in the %s[<organization>/]<repository>[@<revision>] format",
+			storageUri, HF)
+	}
+	ref := &HFReference{
+		RepoID:   parts[1],
+		Revision: parts[2],
+	}
+	if ref.Revision == "" {
+		ref.Revision = HFDefaultRevision
+	}
+	return ref, nil
+}
+
+type HFProvider struct {
+	Client *http.Client
+	// Endpoint is the url of the Hub, DefaultHFEndpoint when not set
+	Endpoint string
+	// Token is the token of the Hub sent as a bearer token
+	Token string
+	// Concurrency is the number of files downloaded in parallel, DefaultHFDownloadConcurrency when not set
+	Concurrency int
+	// AllowPatterns are the globs of the files downloaded, all the files when empty
+	AllowPatterns []string
+	// IgnorePatterns are the globs of the files which are not downloaded
+	IgnorePatterns []string
+	// PreferSafetensors skips the *.bin weights of the repositories which have *.safetensors weights
+	PreferSafetensors bool
+	// Retry configures the retries of the files which failed to download
+	Retry RetryConfig
+}
+
+var _ Provider = (*HFProvider)(nil)
+
+func (m *HFProvider) DownloadModel(modelDir string, modelName string, storageUri string) error {
+	log.Info("Download model ", "modelName", modelName, "storageUri", storageUri, "modelDir", modelDir)
+	ref, err := ParseHFReference(storageUri)
+	if err != nil {
+		return err
+	}
+	endpoint := m.Endpoint
+	if endpoint == "" {
+		endpoint = DefaultHFEndpoint
+	}
+	HFDownloader := &HFDownloader{
+		Client:            m.Client,
+		Endpoint:          strings.TrimSuffix(endpoint, "/"),
+		Token:             m.Token,
+		Ref:               ref,
+		ModelDir:          filepath.Join(modelDir, modelName),
+		Concurrency:       m.Concurrency,
+		AllowPatterns:     m.AllowPatterns,
+		IgnorePatterns:    m.IgnorePatterns,
+		PreferSafetensors: m.PreferSafetensors,
+		Retry:             m.Retry,
+	}
+	return HFDownloader.Download()
+}
+
+// hfModelInfo is the model info returned by the Hub API with the sizes of the files
+type hfModelInfo struct {
+	SHA      string      `json:"sha"`
+	Siblings []hfSibling `json:"siblings"`
+}
+
+type hfSibling struct {
+	RFilename string `json:"rfilename"`
+	Size      *int64 `json:"size,omitempty"`
+}
+
+// HFDownloader lists the files of a revision of a repository with the Hub API and downloads them to the model dir
+type HFDownloader struct {
+	Client            *http.Client
+	Endpoint          string
+	Token             string
+	Ref               *HFReference
+	ModelDir          string
+	Concurrency       int
+	AllowPatterns     []string
+	IgnorePatterns    []string
+	PreferSafetensors bool
+	Retry             RetryConfig
+}
+
+// Download downloads the files of the commit the revision resolves to with a pool of Concurrency workers. The first
+// failure cancels the downloads in progress and the errors of all the failed downloads are returned.
+func (h *HFDownloader) Download() error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	info, err := h.getModelInfo(ctx)
+	if err != nil {
+		return err
+	}
+	files := h.selectFiles(info.Siblings)
+	if len(files) == 0 {
+		return fmt.Errorf("%s%s@%s has no files matching the allow and ignore patterns", HF, h.Ref.RepoID, h.Ref.Revision)
+	}
+	// the files are downloaded from the commit so that they are consistent even if the revision is a moving branch
+	commit := info.SHA
+	if commit == "" {
+		commit = h.Ref.Revision
+	}
+	log.Info("Downloading files", "repo", h.Ref.RepoID, "revision", h.Ref.Revision, "commit", commit, "files", len(files))
+
+	concurrency := h.Concurrency
+	if concurrency < 1 {
+		concurrency = DefaultHFDownloadConcurrency
+	}
+	fileCh := make(chan hfSibling)
+	var mu sync.Mutex
+	var errs []error
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for file := range fileCh {
+				err := h.downloadFile(ctx, commit, file)
+				if err == nil || (ctx.Err() != nil && errors.Is(err, context.Canceled)) {
+					continue
+				}
+				mu.Lock()
+				errs = append(errs, fmt.Errorf("unable to download %s: %w", file.RFilename, err))
+				mu.Unlock()
+				cancel()
+			}
+		}()
+	}
+sendFiles:
+	for _, file := range files {
+		select {
+		case fileCh <- file:
+		case <-ctx.Done():
+			break sendFiles
+		}
+	}
+	close(fileCh)
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+func (h *HFDownloader) getModelInfo(ctx context.Context) (*hfModelInfo, error) {
+	infoUrl := fmt.Sprintf("%s/api/models/%s/revision/%s?blobs=true", h.Endpoint, h.Ref.RepoID,
+		url.PathEscape(h.Ref.Revision))
+	info := &hfModelInfo{}
+	err := h.Retry.Do(ctx, infoUrl, func() error {
+		resp, err := h.get(ctx, infoUrl, 0)
+		if err != nil {
+			return err
+		}
+		defer closeBody(resp)
+		return json.NewDecoder(resp.Body).Decode(info)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to get the files of %s%s@%s: %w", HF, h.Ref.RepoID, h.Ref.Revision, err)
+	}
+	return info, nil
+}
+
+// selectFiles returns the files matching the allow patterns and none of the ignore patterns. The *.bin weights are
+// skipped when PreferSafetensors is set and the repository has *.safetensors weights.
+func (h *HFDownloader) selectFiles(siblings []hfSibling) []hfSibling {
+	hasSafetensors := false
+	for _, sibling := range siblings {
+		if strings.HasSuffix(sibling.RFilename, ".safetensors") {
+			hasSafetensors = true
+			break
+		}
+	}
+	files := make([]hfSibling, 0, len(siblings))
+	for _, sibling := range siblings {
+		name := sibling.RFilename
+		switch {
+		case len(h.AllowPatterns) > 0 && !matchesAnyGlob(h.AllowPatterns, name):
+		case matchesAnyGlob(h.IgnorePatterns, name):
+		case h.PreferSafetensors && hasSafetensors && strings.HasSuffix(name, ".bin"):
+		default:
+			files = append(files, sibling)
+		}
+	}
+	return files
+}
+
+// matchesAnyGlob returns true when the path matches one of the globs. The globs without a separator also match the
+// base name of the path, e.g. *.bin matches shards/model-00001.bin.
+func matchesAnyGlob(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(pattern, name); matched {
+			return true
+		}
+		if !strings.Contains(pattern, "/") {
+			if matched, _ := path.Match(pattern, path.Base(name)); matched {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (h *HFDownloader) downloadFile(ctx context.Context, commit string, file hfSibling) error {
+	fileName := filepath.Join(h.ModelDir, filepath.FromSlash(file.RFilename))
+	if !strings.HasPrefix(fileName, filepath.Clean(h.ModelDir)+string(os.PathSeparator)) {
+		return fmt.Errorf("%s: illegal file path", fileName)
+	}
+	size := int64(-1)
+	if file.Size != nil {
+		size = *file.Size
+	}
+	if size >= 0 && fileSize(fileName) == size {
+		log.Info("Skipping downloaded file", "file", file.RFilename, "fileName", fileName)
+		return nil
+	}
+	segments := strings.Split(file.RFilename, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	fileUrl := fmt.Sprintf("%s/%s/resolve/%s/%s", h.Endpoint, h.Ref.RepoID, url.PathEscape(commit),
+		strings.Join(segments, "/"))
+	return h.Retry.Do(ctx, file.RFilename, func() error {
+		return h.download(ctx, fileUrl, fileName, size)
+	})
+}
+
+// download downloads the file to its partial download file, resuming the previous download if any, and renames it
+// once complete
+func (h *HFDownloader) download(ctx context.Context, fileUrl string, fileName string, size int64) error {
+	partialFileName := fileName + PartialDownloadSuffix
+	offset := max(0, fileSize(partialFileName))
+	if size >= 0 && offset > size {
+		offset = 0
+	}
+	resp, err := h.get(ctx, fileUrl, offset)
+	if err != nil {
+		if errors.Is(err, errRangeNotSatisfiable) {
+			return restartDownload(partialFileName, err)
+		}
+		return err
+	}
+	defer closeBody(resp)
+	if resp.StatusCode == http.StatusOK {
+		// the whole content is sent when the range is not supported
+		offset = 0
+	} else {
+		if !strings.HasPrefix(resp.Header.Get("Content-Range"), fmt.Sprintf("bytes %d-", offset)) {
+			return restartDownload(partialFileName, fmt.Errorf("%s returned an unexpected content range %q",
+				fileUrl, resp.Header.Get("Content-Range")))
+		}
+		log.Info("Resuming download", "url", fileUrl, "offset", offset)
+	}
+	file, err := openPartialFile(fileName, offset)
+	if err != nil {
+		return fmt.Errorf("unable to create file: %w", err)
+	}
+	if _, err = io.Copy(file, resp.Body); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("unable to copy file content: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	return os.Rename(partialFileName, fileName)
+}
+
+var errRangeNotSatisfiable = errors.New("range not satisfiable")
+
+// get sends a request to the Hub, with a range starting at offset when it is set. The failures with a response code
+// which does not change on retries are permanent.
+func (h *HFDownloader) get(ctx context.Context, target string, offset int64) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return nil, permanent(err)
+	}
+	headers := map[string]string{}
+	if h.Token != "" {
+		headers["Authorization"] = "Bearer " + h.Token
+	}
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+	if offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
+	// the files stored with lfs are redirected to a cdn, which must not receive the token
+	client := *h.Client
+	client.CheckRedirect = checkRedirect(DefaultHTTPSMaxRedirects, headers)
+	resp, err := client.Do(req)
+	if err != nil {
+		if errors.Is(err, errTooManyRedirects) {
+			return nil, permanent(err)
+		}
+		return nil, err
+	}
+	switch {
+	case resp.StatusCode == http.StatusOK, resp.StatusCode == http.StatusPartialContent && offset > 0:
+		return resp, nil
+	case resp.StatusCode == http.StatusRequestedRangeNotSatisfiable && offset > 0:
+		closeBody(resp)
+		return nil, fmt.Errorf("%s: %w", target, errRangeNotSatisfiable)
+	}
+	closeBody(resp)
+	err = fmt.Errorf("%s returned a %d response code", target, resp.StatusCode)
+	switch resp.StatusCode {
+	case http.StatusUnauthorized, http.StatusForbidden:
+		err = fmt.Errorf("%w, the repository may be gated or private and require a token with access to it in %s",
+			err, hfcredential.HFTokenKey)
+	case http.StatusNotFound:
+		err = fmt.Errorf("%w, the repository, the revision or the file does not exist", err)
+	}
+	if !isRetryableStatusCode(resp.StatusCode) {
+		return nil, permanent(err)
+	}
+	return nil, err
+}
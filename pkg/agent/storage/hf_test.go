@@ -0,0 +1,331 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+
+	hfcredential "github.com/kserve/kserve/pkg/credentials/hf"
+)
+
+func TestParseHFReference(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	scenarios := map[string]struct {
+		uri         string
+		expected    *HFReference
+		expectedErr bool
+	}{
+		"DefaultRevision": {
+			uri:      "hf://meta-llama/Llama-2-7b-hf",
+			expected: &HFReference{RepoID: "meta-llama/Llama-2-7b-hf", Revision: "main"},
+		},
+		"Revision": {
+			uri:      "hf://google-bert/bert-base-uncased@86b5e0934494bd15c9632b12f734a8a67f723594",
+			expected: &HFReference{RepoID: "google-bert/bert-base-uncased", Revision: "86b5e0934494bd15c9632b12f734a8a67f723594"},
+		},
+		"PullRequestRevision": {
+			uri:      "hf://org/model.v2@refs/pr/1",
+			expected: &HFReference{RepoID: "org/model.v2", Revision: "refs/pr/1"},
+		},
+		"NoOrganization": {
+			uri:      "hf://gpt2",
+			expected: &HFReference{RepoID: "gpt2", Revision: "main"},
+		},
+		"FilePath":         {uri: "hf://org/repo/config.json", expectedErr: true},
+		"EmptyRevision":    {uri: "hf://org/repo@", expectedErr: true},
+		"RelativeRevision": {uri: "hf://org/repo@../main", expectedErr: true},
+		"NoRepository":     {uri: "hf://", expectedErr: true},
+		"OtherProtocol":    {uri: "s3://org/repo", expectedErr: true},
+	}
+	for name, scenario := range scenarios {
+		ref, err := ParseHFReference(scenario.uri)
+		if scenario.expectedErr {
+			g.Expect(err).To(gomega.HaveOccurred(), name)
+		} else {
+			g.Expect(err).NotTo(gomega.HaveOccurred(), name)
+			g.Expect(ref).To(gomega.Equal(scenario.expected), name)
+		}
+	}
+}
+
+// fakeHub serves the model info and the files of a repository. The *.safetensors files are redirected to the cdn, and
+// the downloads of the interrupted files fail after sending half of the requested range.
+type fakeHub struct {
+	repoID      string
+	commit      string
+	files       map[string]string
+	interrupted map[string]int
+	status      int
+	cdnURL      string
+
+	mu             sync.Mutex
+	requests       []string
+	ranges         map[string][]string
+	authorizations []string
+}
+
+func (f *fakeHub) record(r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.requests = append(f.requests, r.URL.Path)
+	f.authorizations = append(f.authorizations, r.Header.Get("Authorization"))
+}
+
+func (f *fakeHub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.record(r)
+	if f.status != 0 {
+		w.WriteHeader(f.status)
+		return
+	}
+	if strings.HasPrefix(r.URL.Path, "/api/models/") {
+		if r.URL.Path != "/api/models/"+f.repoID+"/revision/main" || r.URL.Query().Get("blobs") != "true" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		info := hfModelInfo{SHA: f.commit}
+		for name, content := range f.files {
+			size := int64(len(content))
+			info.Siblings = append(info.Siblings, hfSibling{RFilename: name, Size: &size})
+		}
+		_ = json.NewEncoder(w).Encode(info)
+		return
+	}
+	name, found := strings.CutPrefix(r.URL.Path, "/"+f.repoID+"/resolve/"+f.commit+"/")
+	if _, ok := f.files[name]; !found || !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if strings.HasSuffix(name, ".safetensors") && f.cdnURL != "" {
+		http.Redirect(w, r, f.cdnURL+"/"+name+"?signature=abc", http.StatusFound)
+		return
+	}
+	f.serveFile(w, r, name)
+}
+
+func (f *fakeHub) serveFile(w http.ResponseWriter, r *http.Request, name string) {
+	f.mu.Lock()
+	if f.ranges == nil {
+		f.ranges = map[string][]string{}
+	}
+	f.ranges[name] = append(f.ranges[name], r.Header.Get("Range"))
+	fail := f.interrupted[name] > 0
+	f.interrupted[name]--
+	f.mu.Unlock()
+
+	content := f.files[name]
+	offset := 0
+	if rangeHeader := r.Header.Get("Range"); rangeHeader != "" {
+		offset, _ = strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(rangeHeader, "bytes="), "-"))
+		w.Header().Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", offset, len(content)-1, len(content)))
+	}
+	remaining := content[offset:]
+	w.Header().Set("Content-Length", strconv.Itoa(len(remaining)))
+	if offset > 0 {
+		w.WriteHeader(http.StatusPartialContent)
+	}
+	if fail {
+		_, _ = w.Write([]byte(remaining[:len(remaining)/2]))
+		w.(http.Flusher).Flush()
+		panic(http.ErrAbortHandler)
+	}
+	_, _ = w.Write([]byte(remaining))
+}
+
+func newFakeHub(t *testing.T, files map[string]string) (*fakeHub, *httptest.Server) {
+	hub := &fakeHub{repoID: "org/bert", commit: "0123abcd", files: files, interrupted: map[string]int{}}
+	server := httptest.NewServer(hub)
+	t.Cleanup(server.Close)
+	return hub, server
+}
+
+func TestHFProviderDownloadModel(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	weights := strings.Repeat("0123456789", 100)
+	files := map[string]string{
+		"config.json":             `{"model_type": "bert"}`,
+		"model.safetensors":       weights,
+		"pytorch_model.bin":       "pytorch weights",
+		"tokenizer/vocab.txt":     "[PAD]\n[UNK]",
+		"tokenizer/special chars": "chars",
+	}
+	hub, server := newFakeHub(t, files)
+	hub.interrupted["model.safetensors"] = 1
+	var cdnAuthorizations []string
+	cdn := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hub.mu.Lock()
+		cdnAuthorizations = append(cdnAuthorizations, r.Header.Get("Authorization"))
+		hub.mu.Unlock()
+		hub.serveFile(w, r, strings.TrimPrefix(r.URL.Path, "/"))
+	}))
+	defer cdn.Close()
+	hub.cdnURL = cdn.URL
+
+	modelDir := t.TempDir()
+	provider := &HFProvider{
+		Client:            server.Client(),
+		Endpoint:          server.URL,
+		Token:             "hf_token",
+		Concurrency:       2,
+		PreferSafetensors: true,
+		Retry:             RetryConfig{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond},
+	}
+	g.Expect(provider.DownloadModel(modelDir, "bert", "hf://org/bert")).To(gomega.Succeed())
+	// the *.bin weights are skipped as the repository has *.safetensors weights
+	g.Expect(readLayout(g, modelDir)).To(gomega.Equal(map[string]string{
+		"./":                           "",
+		"bert/":                        "",
+		"bert/config.json":             `{"model_type": "bert"}`,
+		"bert/model.safetensors":       weights,
+		"bert/tokenizer/":              "",
+		"bert/tokenizer/vocab.txt":     "[PAD]\n[UNK]",
+		"bert/tokenizer/special chars": "chars",
+	}))
+	// the files are downloaded from the commit of the revision
+	g.Expect(hub.requests).To(gomega.ContainElements("/api/models/org/bert/revision/main",
+		"/org/bert/resolve/0123abcd/tokenizer/special chars"))
+	// the token is sent to the hub only
+	g.Expect(hub.authorizations).To(gomega.HaveEach("Bearer hf_token"))
+	g.Expect(cdnAuthorizations).To(gomega.Equal([]string{"", ""}))
+	// the interrupted download resumes from the data received before the failure
+	g.Expect(hub.ranges["model.safetensors"]).To(gomega.Equal([]string{"", "bytes=500-"}))
+
+	// the downloaded files are skipped
+	hub.requests = nil
+	g.Expect(provider.DownloadModel(modelDir, "bert", "hf://org/bert")).To(gomega.Succeed())
+	g.Expect(hub.requests).To(gomega.Equal([]string{"/api/models/org/bert/revision/main"}))
+}
+
+func TestHFProviderDownloadModelPatterns(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	files := map[string]string{
+		"config.json":         "{}",
+		"model.safetensors":   "safetensors weights",
+		"pytorch_model.bin":   "pytorch weights",
+		"onnx/model.onnx":     "onnx weights",
+		"tokenizer/vocab.txt": "vocab",
+	}
+	_, server := newFakeHub(t, files)
+	scenarios := map[string]struct {
+		provider HFProvider
+		expected []string
+	}{
+		"AllFiles": {
+			provider: HFProvider{},
+			expected: []string{"config.json", "model.safetensors", "pytorch_model.bin", "onnx/model.onnx", "tokenizer/vocab.txt"},
+		},
+		"AllowPatterns": {
+			provider: HFProvider{AllowPatterns: []string{"*.json", "tokenizer/*"}},
+			expected: []string{"config.json", "tokenizer/vocab.txt"},
+		},
+		"IgnorePatterns": {
+			provider: HFProvider{IgnorePatterns: []string{"*.onnx", "*.safetensors"}, PreferSafetensors: true},
+			expected: []string{"config.json", "tokenizer/vocab.txt"},
+		},
+		"AllowedBinWeights": {
+			provider: HFProvider{AllowPatterns: []string{"*.bin"}},
+			expected: []string{"pytorch_model.bin"},
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			modelDir := t.TempDir()
+			provider := scenario.provider
+			provider.Client = server.Client()
+			provider.Endpoint = server.URL
+			g.Expect(provider.DownloadModel(modelDir, "bert", "hf://org/bert")).To(gomega.Succeed())
+			downloaded := []string{}
+			for path := range readLayout(g, modelDir) {
+				if name, ok := strings.CutPrefix(path, "bert/"); ok && !strings.HasSuffix(name, "/") && name != "" {
+					downloaded = append(downloaded, name)
+				}
+			}
+			g.Expect(downloaded).To(gomega.ConsistOf(scenario.expected))
+		})
+	}
+
+	// the patterns which match no file are an error
+	provider := &HFProvider{Client: server.Client(), Endpoint: server.URL, AllowPatterns: []string{"*.gguf"}}
+	g.Expect(provider.DownloadModel(t.TempDir(), "bert", "hf://org/bert")).To(
+		gomega.MatchError(gomega.ContainSubstring("has no files matching the allow and ignore patterns")))
+}
+
+func TestHFProviderDownloadModelFailure(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	retry := RetryConfig{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond}
+	hub, server := newFakeHub(t, map[string]string{"config.json": "{}"})
+	provider := &HFProvider{Client: server.Client(), Endpoint: server.URL, Retry: retry}
+
+	// gated repositories are not retried
+	hub.status = http.StatusUnauthorized
+	err := provider.DownloadModel(t.TempDir(), "bert", "hf://org/bert")
+	g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("require a token with access to it in " + hfcredential.HFTokenKey)))
+	g.Expect(hub.requests).To(gomega.HaveLen(1))
+
+	// unknown revisions are not retried
+	hub.status = 0
+	hub.requests = nil
+	err = provider.DownloadModel(t.TempDir(), "bert", "hf://org/bert@v2")
+	g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("the repository, the revision or the file does not exist")))
+	g.Expect(hub.requests).To(gomega.HaveLen(1))
+
+	// server errors are retried
+	hub.status = http.StatusBadGateway
+	hub.requests = nil
+	err = provider.DownloadModel(t.TempDir(), "bert", "hf://org/bert")
+	g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("returned a 502 response code")))
+	g.Expect(hub.requests).To(gomega.HaveLen(3))
+}
+
+func TestGetHFProvider(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	provider, err := getHFProvider()
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(provider.Endpoint).To(gomega.BeEmpty())
+	g.Expect(provider.Concurrency).To(gomega.Equal(DefaultHFDownloadConcurrency))
+	g.Expect(provider.PreferSafetensors).To(gomega.BeTrue())
+	g.Expect(provider.AllowPatterns).To(gomega.BeEmpty())
+
+	t.Setenv(HFEndpointEnvKey, "https://hub.example.com")
+	t.Setenv(hfcredential.HFTokenKey, "hf_token")
+	t.Setenv(HFDownloadConcurrencyEnvKey, "8")
+	t.Setenv(HFAllowPatternsEnvKey, "*.json, *.safetensors")
+	t.Setenv(HFIgnorePatternsEnvKey, "original/*")
+	t.Setenv(HFPreferSafetensorsEnvKey, "false")
+	provider, err = getHFProvider()
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(provider.Endpoint).To(gomega.Equal("https://hub.example.com"))
+	g.Expect(provider.Token).To(gomega.Equal("hf_token"))
+	g.Expect(provider.Concurrency).To(gomega.Equal(8))
+	g.Expect(provider.AllowPatterns).To(gomega.Equal([]string{"*.json", "*.safetensors"}))
+	g.Expect(provider.IgnorePatterns).To(gomega.Equal([]string{"original/*"}))
+	g.Expect(provider.PreferSafetensors).To(gomega.BeFalse())
+
+	t.Setenv(HFIgnorePatternsEnvKey, "[")
+	_, err = getHFProvider()
+	g.Expect(err).To(gomega.HaveOccurred())
+}
@@ -19,26 +19,51 @@ package storage
 import (
 	"archive/tar"
 	"archive/zip"
+	"bufio"
 	"bytes"
 	"compress/gzip"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"mime"
+	"net"
 	"net/http"
 	"net/url"
 	"os"
+	"path"
 	"path/filepath"
+	"strconv"
 	"strings"
 )
 
 const (
 	HEADER_SUFFIX                  = "-headers"
+	TOKEN_SUFFIX                   = "-token"
 	DEFAULT_MAX_DECOMPRESSION_SIZE = 1024 * 1024 * 1024 // 1 GB
+
+	// HTTPSMaxRedirectsEnvKey is the env of the maximum number of redirects followed by the downloads
+	HTTPSMaxRedirectsEnvKey  = "STORAGE_HTTPS_MAX_REDIRECTS"
+	DefaultHTTPSMaxRedirects = 10
+)
+
+var errTooManyRedirects = errors.New("stopped following redirects")
+
+type archiveFormat int
+
+const (
+	noArchive archiveFormat = iota
+	zipArchive
+	tarArchive
 )
 
 type HTTPSProvider struct {
 	Client *http.Client
+	// Retry configures the retries of the failed downloads
+	Retry RetryConfig
+	// MaxRedirects is the maximum number of redirects followed, DefaultHTTPSMaxRedirects when not set
+	MaxRedirects int
 }
 
 func (m *HTTPSProvider) DownloadModel(modelDir string, modelName string, storageUri string) error {
@@ -48,10 +73,12 @@ func (m *HTTPSProvider) DownloadModel(modelDir string, modelName string, storage
 		return fmt.Errorf("unable to parse storage uri: %w", err)
 	}
 	HTTPSDownloader := &HTTPSDownloader{
-		StorageUri: storageUri,
-		ModelDir:   modelDir,
-		ModelName:  modelName,
-		Uri:        uri,
+		StorageUri:   storageUri,
+		ModelDir:     modelDir,
+		ModelName:    modelName,
+		Uri:          uri,
+		Retry:        m.Retry,
+		MaxRedirects: m.MaxRedirects,
 	}
 	if err := HTTPSDownloader.Download(*m.Client); err != nil {
 		return err
@@ -60,30 +87,54 @@ func (m *HTTPSProvider) DownloadModel(modelDir string, modelName string, storage
 }
 
 type HTTPSDownloader struct {
-	StorageUri string
-	ModelDir   string
-	ModelName  string
-	Uri        *url.URL
+	StorageUri   string
+	ModelDir     string
+	ModelName    string
+	Uri          *url.URL
+	Retry        RetryConfig
+	MaxRedirects int
 }
 
+// Download downloads the content of the uri, retrying the failed attempts. The download of a file which is not an
+// archive resumes from the data downloaded by the previous attempts when the server supports range requests.
 func (h *HTTPSDownloader) Download(client http.Client) error {
+	return h.Retry.Do(context.Background(), h.StorageUri, func() error {
+		return h.download(client)
+	})
+}
+
+func (h *HTTPSDownloader) download(client http.Client) error {
+	fileDirectory := filepath.Join(h.ModelDir, h.ModelName)
+	paths := strings.Split(h.Uri.Path, "/")
+	fileFullName := filepath.Join(fileDirectory, paths[len(paths)-1])
+	partialFileName := fileFullName + PartialDownloadSuffix
+	offset := max(0, fileSize(partialFileName))
+
 	// Create request
 	req, err := http.NewRequest("GET", h.StorageUri, nil)
 	if err != nil {
-		return err
+		return permanent(err)
 	}
 
 	headers, err := h.extractHeaders()
 	if err != nil {
-		return err
+		return permanent(err)
 	}
 	for key, element := range headers {
 		req.Header.Add(key, element)
 	}
+	if offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
+	client.CheckRedirect = checkRedirect(h.MaxRedirects, headers)
 
 	// Query request
 	resp, err := client.Do(req)
 	if err != nil {
+		var dnsErr *net.DNSError
+		if errors.Is(err, errTooManyRedirects) || (errors.As(err, &dnsErr) && dnsErr.IsNotFound) {
+			return permanent(fmt.Errorf("failed to make a request: %w", err))
+		}
 		return fmt.Errorf("failed to make a request: %w", err)
 	}
 
@@ -96,39 +147,147 @@ func (h *HTTPSDownloader) Download(client http.Client) error {
 		}
 	}()
 
-	if resp.StatusCode != 200 {
-		return fmt.Errorf("URI: %s returned a %d response code", h.StorageUri, resp.StatusCode)
+	switch resp.StatusCode {
+	case http.StatusOK:
+		// the server sends the whole content when it does not support range requests
+		offset = 0
+	case http.StatusPartialContent:
+		if !strings.HasPrefix(resp.Header.Get("Content-Range"), fmt.Sprintf("bytes %d-", offset)) {
+			return restartDownload(partialFileName, fmt.Errorf("URI: %s returned an unexpected content range %q",
+				h.StorageUri, resp.Header.Get("Content-Range")))
+		}
+		log.Info("Resuming download", "storageUri", h.StorageUri, "offset", offset)
+	case http.StatusRequestedRangeNotSatisfiable:
+		return restartDownload(partialFileName, fmt.Errorf("URI: %s returned a %d response code", h.StorageUri, resp.StatusCode))
+	default:
+		err := fmt.Errorf("URI: %s returned a %d response code", h.StorageUri, resp.StatusCode)
+		if !isRetryableStatusCode(resp.StatusCode) {
+			return permanent(err)
+		}
+		return err
 	}
 	// Write content into file(s)
-	contentType := resp.Header.Get("Content-type")
-	fileDirectory := filepath.Join(h.ModelDir, h.ModelName)
+	archive := getArchiveFormat(resp.Header.Get("Content-type"), h.Uri.Path)
+	if archive != noArchive && offset > 0 {
+		return restartDownload(partialFileName, fmt.Errorf("URI: %s returned a partial archive", h.StorageUri))
+	}
 
-	switch {
-	case strings.Contains(contentType, "application/zip"):
+	switch archive {
+	case zipArchive:
 		if err := extractZipFiles(resp.Body, fileDirectory); err != nil {
 			return err
 		}
-	case strings.Contains(contentType, "application/x-tar") || strings.Contains(contentType, "application/x-gtar") ||
-		strings.Contains(contentType, "application/x-gzip") || strings.Contains(contentType, "application/gzip"):
-		if err := extractTarFiles(resp.Body, fileDirectory); err != nil {
+	case tarArchive:
+		// the archive is compressed or not regardless of the Content-Type of the response
+		reader := bufio.NewReader(resp.Body)
+		if magic, _ := reader.Peek(2); bytes.Equal(magic, []byte{0x1f, 0x8b}) {
+			if err := extractTarFiles(reader, fileDirectory); err != nil {
+				return err
+			}
+		} else if err := extractTarArchive(reader, fileDirectory); err != nil {
 			return err
 		}
 	default:
-		paths := strings.Split(h.Uri.Path, "/")
-		fileName := paths[len(paths)-1]
-		fileFullName := filepath.Join(fileDirectory, fileName)
-		file, err := createNewFile(fileFullName)
+		file, err := openPartialFile(fileFullName, offset)
 		if err != nil {
-			return err
+			return fmt.Errorf("unable to create file: %w", err)
 		}
 		if _, err = io.Copy(file, resp.Body); err != nil {
+			_ = file.Close()
 			return fmt.Errorf("unable to copy file content: %w", err)
 		}
+		if err := file.Close(); err != nil {
+			return err
+		}
+		return os.Rename(partialFileName, fileFullName)
 	}
 
 	return nil
 }
 
+// restartDownload discards the partial download file so that the next attempt downloads the whole content
+func restartDownload(partialFileName string, err error) error {
+	if removeErr := os.Remove(partialFileName); removeErr != nil && !os.IsNotExist(removeErr) {
+		return permanent(fmt.Errorf("%w: unable to delete the partial download: %w", err, removeErr))
+	}
+	return err
+}
+
+// getHTTPSMaxRedirects returns the maximum number of redirects followed by the downloads set by the
+// STORAGE_HTTPS_MAX_REDIRECTS env
+func getHTTPSMaxRedirects() (int, error) {
+	value, ok := os.LookupEnv(HTTPSMaxRedirectsEnvKey)
+	if !ok {
+		return DefaultHTTPSMaxRedirects, nil
+	}
+	maxRedirects, err := strconv.Atoi(value)
+	if err != nil || maxRedirects < 1 {
+		return 0, fmt.Errorf("invalid %s %q, must be a positive integer", HTTPSMaxRedirectsEnvKey, value)
+	}
+	return maxRedirects, nil
+}
+
+// getArchiveFormat returns the archive format of the content from its Content-Type, or from the extension of the uri
+// path when the Content-Type does not tell the format of the content
+func getArchiveFormat(contentType string, uriPath string) archiveFormat {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mediaType = strings.ToLower(contentType)
+	}
+	switch mediaType {
+	case "application/zip":
+		return zipArchive
+	case "application/x-tar", "application/x-gtar", "application/x-gzip", "application/gzip":
+		return tarArchive
+	case "", "application/octet-stream", "binary/octet-stream":
+		name := strings.ToLower(path.Base(uriPath))
+		switch {
+		case strings.HasSuffix(name, ".zip"):
+			return zipArchive
+		case strings.HasSuffix(name, ".tar"), strings.HasSuffix(name, ".tar.gz"), strings.HasSuffix(name, ".tgz"):
+			return tarArchive
+		}
+	}
+	return noArchive
+}
+
+// checkRedirect follows up to maxRedirects redirects, DefaultHTTPSMaxRedirects when not set. Once the redirects leave
+// the origin of the uri, e.g. to the signed urls of the backend of an artifact store, the headers set from the
+// credentials are no longer sent.
+func checkRedirect(maxRedirects int, headers map[string]string) func(req *http.Request, via []*http.Request) error {
+	if maxRedirects <= 0 {
+		maxRedirects = DefaultHTTPSMaxRedirects
+	}
+	return func(req *http.Request, via []*http.Request) error {
+		if len(via) > maxRedirects {
+			return fmt.Errorf("%w after %d redirects", errTooManyRedirects, maxRedirects)
+		}
+		origin := getOrigin(via[0].URL)
+		crossOrigin := getOrigin(req.URL) != origin
+		for _, r := range via[1:] {
+			crossOrigin = crossOrigin || getOrigin(r.URL) != origin
+		}
+		if crossOrigin {
+			for key := range headers {
+				req.Header.Del(key)
+			}
+			req.Header.Del("Authorization")
+		}
+		return nil
+	}
+}
+
+// getOrigin returns the scheme, host and port of the url
+func getOrigin(u *url.URL) string {
+	port := u.Port()
+	if port == "" {
+		port = map[string]string{"http": "80", "https": "443"}[strings.ToLower(u.Scheme)]
+	}
+	return strings.ToLower(u.Scheme) + "://" + net.JoinHostPort(strings.ToLower(u.Hostname()), port)
+}
+
+// extractHeaders returns the headers of the <hostname>-headers env, with the bearer token of the <hostname>-token env
+// in the Authorization header unless the headers already set it
 func (h *HTTPSDownloader) extractHeaders() (headers map[string]string, err error) {
 	hostname := h.Uri.Hostname()
 	headerJSON := os.Getenv(hostname + HEADER_SUFFIX)
@@ -136,9 +295,21 @@ func (h *HTTPSDownloader) extractHeaders() (headers map[string]string, err error
 		err = json.Unmarshal([]byte(headerJSON), &headers)
 		if err != nil {
 			log.Error(err, "failed to unmarshal headers")
+			return headers, err
 		}
 	}
-	return headers, err
+	if token := os.Getenv(hostname + TOKEN_SUFFIX); token != "" {
+		for key := range headers {
+			if strings.EqualFold(key, "Authorization") {
+				return headers, nil
+			}
+		}
+		if headers == nil {
+			headers = map[string]string{}
+		}
+		headers["Authorization"] = "Bearer " + token
+	}
+	return headers, nil
 }
 
 func createNewFile(fileFullName string) (*os.File, error) {
@@ -219,7 +390,11 @@ func extractTarFiles(reader io.Reader, dest string) error {
 		}
 	}(gzr)
 
-	tr := tar.NewReader(gzr)
+	return extractTarArchive(gzr, dest)
+}
+
+func extractTarArchive(reader io.Reader, dest string) error {
+	tr := tar.NewReader(reader)
 
 	// Read all the files from tar archive
 	for {
@@ -245,8 +420,8 @@ func extractTarFiles(reader io.Reader, dest string) error {
 			return err
 		}
 
-		// gosec G110
-		if _, err := io.CopyN(newFile, tr, DEFAULT_MAX_DECOMPRESSION_SIZE); err != nil {
+		// gosec G110, io.EOF is returned when the file is smaller than the max decompression size
+		if _, err := io.CopyN(newFile, tr, DEFAULT_MAX_DECOMPRESSION_SIZE); err != nil && !errors.Is(err, io.EOF) {
 			return fmt.Errorf("unable to copy contents to %s: %w", header.Name, err)
 		}
 	}
@@ -0,0 +1,290 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+)
+
+// flakyServer serves the content with range requests, and drops the connection in the middle of the first failures
+// responses
+type flakyServer struct {
+	content     string
+	failures    int
+	contentType string
+
+	mu     sync.Mutex
+	ranges []string
+}
+
+func (f *flakyServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	f.ranges = append(f.ranges, r.Header.Get("Range"))
+	fail := f.failures > 0
+	f.failures--
+	f.mu.Unlock()
+
+	if f.contentType != "" {
+		w.Header().Set("Content-Type", f.contentType)
+	}
+	offset := 0
+	if rangeHeader := r.Header.Get("Range"); rangeHeader != "" {
+		offset, _ = strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(rangeHeader, "bytes="), "-"))
+		if offset >= len(f.content) {
+			w.WriteHeader(http.StatusRequestedRangeNotSatisfiable)
+			return
+		}
+		w.Header().Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", offset, len(f.content)-1, len(f.content)))
+	}
+	remaining := f.content[offset:]
+	w.Header().Set("Content-Length", strconv.Itoa(len(remaining)))
+	if offset > 0 {
+		w.WriteHeader(http.StatusPartialContent)
+	}
+	if fail {
+		// send half of the content and drop the connection
+		_, _ = w.Write([]byte(remaining[:len(remaining)/2]))
+		w.(http.Flusher).Flush()
+		panic(http.ErrAbortHandler)
+	}
+	_, _ = w.Write([]byte(remaining))
+}
+
+func TestHTTPSProviderDownloadModelResume(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	content := strings.Repeat("0123456789", 1000)
+	server := &flakyServer{content: content, failures: 2}
+	ts := httptest.NewServer(server)
+	defer ts.Close()
+
+	modelDir := t.TempDir()
+	provider := &HTTPSProvider{
+		Client: ts.Client(),
+		Retry:  RetryConfig{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond},
+	}
+	g.Expect(provider.DownloadModel(modelDir, "bert", ts.URL+"/models/model.bin")).To(gomega.Succeed())
+	g.Expect(os.ReadFile(filepath.Join(modelDir, "bert", "model.bin"))).To(gomega.Equal([]byte(content)))
+	g.Expect(filepath.Join(modelDir, "bert", "model.bin"+PartialDownloadSuffix)).NotTo(gomega.BeAnExistingFile())
+	// every attempt resumes from the data received by the previous ones
+	g.Expect(server.ranges).To(gomega.Equal([]string{"", "bytes=5000-", "bytes=7500-"}))
+}
+
+func TestHTTPSProviderDownloadModelFailure(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	retry := RetryConfig{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond}
+
+	// the attempts are exhausted, the partial download is kept for the next download
+	server := &flakyServer{content: strings.Repeat("0123456789", 1000), failures: 3}
+	ts := httptest.NewServer(server)
+	defer ts.Close()
+	modelDir := t.TempDir()
+	provider := &HTTPSProvider{Client: ts.Client(), Retry: retry}
+	g.Expect(provider.DownloadModel(modelDir, "bert", ts.URL+"/model.bin")).NotTo(gomega.Succeed())
+	g.Expect(server.ranges).To(gomega.HaveLen(3))
+	g.Expect(filepath.Join(modelDir, "bert", "model.bin"+PartialDownloadSuffix)).To(gomega.BeAnExistingFile())
+	g.Expect(provider.DownloadModel(modelDir, "bert", ts.URL+"/model.bin")).To(gomega.Succeed())
+	g.Expect(server.ranges[3]).To(gomega.Equal("bytes=8750-"))
+
+	// a partial download larger than the content is downloaded again
+	server = &flakyServer{content: "weights"}
+	ts = httptest.NewServer(server)
+	defer ts.Close()
+	g.Expect(os.WriteFile(filepath.Join(modelDir, "bert", "weights.bin"+PartialDownloadSuffix), []byte("stale weights"), 0600)).To(gomega.Succeed())
+	provider = &HTTPSProvider{Client: ts.Client(), Retry: retry}
+	g.Expect(provider.DownloadModel(modelDir, "bert", ts.URL+"/weights.bin")).To(gomega.Succeed())
+	g.Expect(os.ReadFile(filepath.Join(modelDir, "bert", "weights.bin"))).To(gomega.Equal([]byte("weights")))
+	g.Expect(server.ranges).To(gomega.Equal([]string{"bytes=13-", ""}))
+
+	// client errors are not retried
+	attempts := 0
+	ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer ts.Close()
+	provider = &HTTPSProvider{Client: ts.Client(), Retry: retry}
+	err := provider.DownloadModel(modelDir, "bert", ts.URL+"/missing.bin")
+	g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("returned a 404 response code")))
+	g.Expect(attempts).To(gomega.Equal(1))
+
+	// server errors are retried
+	attempts = 0
+	ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer ts.Close()
+	provider = &HTTPSProvider{Client: ts.Client(), Retry: retry}
+	err = provider.DownloadModel(modelDir, "bert", ts.URL+"/unavailable.bin")
+	g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("returned a 503 response code")))
+	g.Expect(attempts).To(gomega.Equal(3))
+}
+
+func TestHTTPSProviderDownloadModelHeaders(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	var received http.Header
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r.Header.Clone()
+		_, _ = w.Write([]byte("weights"))
+	}))
+	defer ts.Close()
+	provider := &HTTPSProvider{Client: ts.Client()}
+
+	// the token is sent as a bearer token along with the headers
+	t.Setenv("127.0.0.1"+HEADER_SUFFIX, `{"X-Api-Key": "some-key"}`)
+	t.Setenv("127.0.0.1"+TOKEN_SUFFIX, "some-token")
+	g.Expect(provider.DownloadModel(t.TempDir(), "bert", ts.URL+"/model.bin")).To(gomega.Succeed())
+	g.Expect(received.Get("X-Api-Key")).To(gomega.Equal("some-key"))
+	g.Expect(received.Get("Authorization")).To(gomega.Equal("Bearer some-token"))
+
+	// the Authorization header of the headers takes precedence over the token
+	t.Setenv("127.0.0.1"+HEADER_SUFFIX, `{"authorization": "Basic c29tZTp1c2Vy"}`)
+	g.Expect(provider.DownloadModel(t.TempDir(), "bert", ts.URL+"/model.bin")).To(gomega.Succeed())
+	g.Expect(received.Values("Authorization")).To(gomega.Equal([]string{"Basic c29tZTp1c2Vy"}))
+}
+
+func TestHTTPSProviderDownloadModelRedirects(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	var signedHeaders http.Header
+	signed := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		signedHeaders = r.Header.Clone()
+		_, _ = w.Write([]byte("weights"))
+	}))
+	defer signed.Close()
+	var originHeaders []http.Header
+	origin := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		originHeaders = append(originHeaders, r.Header.Clone())
+		switch r.URL.Path {
+		case "/model.bin":
+			http.Redirect(w, r, "/resolve/model.bin", http.StatusFound)
+		case "/resolve/model.bin":
+			http.Redirect(w, r, signed.URL+"/model.bin?signature=abc", http.StatusFound)
+		default:
+			// redirects forever
+			http.Redirect(w, r, r.URL.Path+"/loop", http.StatusFound)
+		}
+	}))
+	defer origin.Close()
+	t.Setenv("127.0.0.1"+HEADER_SUFFIX, `{"X-Api-Key": "some-key"}`)
+	t.Setenv("127.0.0.1"+TOKEN_SUFFIX, "some-token")
+
+	// the credentials are sent to the redirects to the same origin only
+	modelDir := t.TempDir()
+	provider := &HTTPSProvider{Client: origin.Client()}
+	g.Expect(provider.DownloadModel(modelDir, "bert", origin.URL+"/model.bin")).To(gomega.Succeed())
+	g.Expect(os.ReadFile(filepath.Join(modelDir, "bert", "model.bin"))).To(gomega.Equal([]byte("weights")))
+	g.Expect(originHeaders).To(gomega.HaveLen(2))
+	for _, headers := range originHeaders {
+		g.Expect(headers.Get("X-Api-Key")).To(gomega.Equal("some-key"))
+		g.Expect(headers.Get("Authorization")).To(gomega.Equal("Bearer some-token"))
+	}
+	g.Expect(signedHeaders).NotTo(gomega.HaveKey("X-Api-Key"))
+	g.Expect(signedHeaders).NotTo(gomega.HaveKey("Authorization"))
+
+	// the download fails without retries once the redirects exceed the maximum
+	originHeaders = nil
+	provider = &HTTPSProvider{
+		Client:       origin.Client(),
+		MaxRedirects: 2,
+		Retry:        RetryConfig{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond},
+	}
+	err := provider.DownloadModel(t.TempDir(), "bert", origin.URL+"/loop")
+	g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("stopped following redirects after 2 redirects")))
+	g.Expect(originHeaders).To(gomega.HaveLen(3))
+}
+
+func TestHTTPSProviderDownloadModelArchive(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	var tarContent bytes.Buffer
+	tw := tar.NewWriter(&tarContent)
+	g.Expect(tw.WriteHeader(&tar.Header{Name: "model.pt", Mode: 0600, Size: int64(len("weights"))})).To(gomega.Succeed())
+	_, err := tw.Write([]byte("weights"))
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(tw.Close()).To(gomega.Succeed())
+	var tarGzContent bytes.Buffer
+	gw := gzip.NewWriter(&tarGzContent)
+	_, err = gw.Write(tarContent.Bytes())
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(gw.Close()).To(gomega.Succeed())
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/octet-stream")
+		if strings.HasSuffix(r.URL.Path, ".tar") {
+			_, _ = w.Write(tarContent.Bytes())
+		} else {
+			_, _ = w.Write(tarGzContent.Bytes())
+		}
+	}))
+	defer ts.Close()
+	provider := &HTTPSProvider{Client: ts.Client()}
+
+	// the archives served as binary content are extracted from their extension
+	for _, name := range []string{"model.tar", "model.tar.gz", "model.tgz"} {
+		modelDir := t.TempDir()
+		g.Expect(provider.DownloadModel(modelDir, "bert", ts.URL+"/"+name+"?download=true")).To(gomega.Succeed())
+		g.Expect(readLayout(g, modelDir)).To(gomega.Equal(map[string]string{"./": "", "bert/": "", "bert/model.pt": "weights"}))
+	}
+}
+
+func TestGetArchiveFormat(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	scenarios := []struct {
+		contentType string
+		uriPath     string
+		expected    archiveFormat
+	}{
+		{"application/zip", "/model", zipArchive},
+		{"application/x-tar", "/model", tarArchive},
+		{"application/gzip", "/model.bin", tarArchive},
+		{"application/octet-stream", "/model.zip", zipArchive},
+		{"binary/octet-stream", "/model.TGZ", tarArchive},
+		{"", "/model.tar.gz", tarArchive},
+		{"application/octet-stream", "/model.bin", noArchive},
+		// the Content-Type of the content takes precedence over the extension
+		{"text/plain; charset=utf-8", "/model.zip", noArchive},
+	}
+	for _, scenario := range scenarios {
+		g.Expect(getArchiveFormat(scenario.contentType, scenario.uriPath)).To(gomega.Equal(scenario.expected),
+			"Content-Type %q, path %s", scenario.contentType, scenario.uriPath)
+	}
+}
+
+func TestGetHTTPSMaxRedirects(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	g.Expect(getHTTPSMaxRedirects()).To(gomega.Equal(DefaultHTTPSMaxRedirects))
+
+	t.Setenv(HTTPSMaxRedirectsEnvKey, "3")
+	g.Expect(getHTTPSMaxRedirects()).To(gomega.Equal(3))
+
+	t.Setenv(HTTPSMaxRedirectsEnvKey, "0")
+	_, err := getHTTPSMaxRedirects()
+	g.Expect(err).To(gomega.HaveOccurred())
+}
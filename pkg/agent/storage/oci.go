@@ -0,0 +1,449 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	ocicredential "github.com/kserve/kserve/pkg/credentials/oci"
+)
+
+const (
+	OCIManifestMediaType    = "application/vnd.oci.image.manifest.v1+json"
+	DockerManifestMediaType = "application/vnd.docker.distribution.manifest.v2+json"
+	// OCITitleAnnotation is the annotation ORAS sets to the file name of the layers
+	OCITitleAnnotation = "org.opencontainers.image.title"
+	// ORASUnpackAnnotation is the annotation ORAS sets on the layers packing a directory as a tar+gzip archive
+	ORASUnpackAnnotation = "io.deis.oras.content.unpack"
+	OCIDefaultTag        = "latest"
+	maxOCIManifestSize   = 4 * 1024 * 1024
+)
+
+var (
+	// oci://<registry>/<repository>[:<tag>][@<digest>], following the grammar of the distribution references
+	ociReferenceRegexp = regexp.MustCompile(`^` +
+		`((?:[a-zA-Z0-9](?:[a-zA-Z0-9-]*[a-zA-Z0-9])?)(?:\.[a-zA-Z0-9](?:[a-zA-Z0-9-]*[a-zA-Z0-9])?)*(?::[0-9]+)?)` +
+		`/([a-z0-9]+(?:(?:[._]|__|-+)[a-z0-9]+)*(?:/[a-z0-9]+(?:(?:[._]|__|-+)[a-z0-9]+)*)*)` +
+		`(?::([\w][\w.-]{0,127}))?` +
+		`(?:@(sha256:[a-f0-9]{64}))?$`)
+	authChallengeParamRegexp = regexp.MustCompile(`(\w+)="([^"]*)"`)
+)
+
+// OCIReference is the reference of an artifact in an OCI registry
+type OCIReference struct {
+	Registry   string
+	Repository string
+	Tag        string
+	Digest     string
+}
+
+// ParseOCIReference parses a storage uri in the oci://<registry>/<repository>[:<tag>][@<digest>] format. The tag
+// defaults to latest when the uri has neither a tag nor a digest.
+func ParseOCIReference(storageUri string) (*OCIReference, error) {
+	if !strings.HasPrefix(storageUri, string(OCI)) {
+		return nil, fmt.Errorf("invalid oci uri %s: must start with %s", storageUri, OCI)
+	}
+	parts := ociReferenceRegexp.FindStringSubmatch(strings.TrimPrefix(storageUri, string(OCI)))
+	if parts == nil {
+		return nil, fmt.Errorf("invalid oci uri %s: must be in the %s<registry>/<repository>[:<tag>][@<digest>] format",
+			storageUri, OCI)
+	}
+	ref := &OCIReference{
+		Registry:   parts[1],
+		Repository: parts[2],
+		Tag:        parts[3],
+		Digest:     parts[4],
+	}
+	if ref.Tag == "" && ref.Digest == "" {
+		ref.Tag = OCIDefaultTag
+	}
+	return ref, nil
+}
+
+// Reference returns the digest of the artifact when it is set, its tag otherwise
+func (r *OCIReference) Reference() string {
+	if r.Digest != "" {
+		return r.Digest
+	}
+	return r.Tag
+}
+
+type OCIProvider struct {
+	Client *http.Client
+}
+
+func (m *OCIProvider) DownloadModel(modelDir string, modelName string, storageUri string) error {
+	log.Info("Download model ", "modelName", modelName, "storageUri", storageUri, "modelDir", modelDir)
+	ref, err := ParseOCIReference(storageUri)
+	if err != nil {
+		return err
+	}
+	auth, err := getRegistryAuth(os.Getenv(ocicredential.OCIDockerConfigEnvKey), ref.Registry)
+	if err != nil {
+		return err
+	}
+	OCIDownloader := &OCIDownloader{
+		Client:   m.Client,
+		Ref:      ref,
+		Auth:     auth,
+		ModelDir: filepath.Join(modelDir, modelName),
+	}
+	return OCIDownloader.Download()
+}
+
+// RegistryAuth is the basic auth credentials of a registry
+type RegistryAuth struct {
+	Username string
+	Password string
+}
+
+type dockerConfig struct {
+	Auths map[string]dockerConfigAuth `json:"auths"`
+}
+
+type dockerConfigAuth struct {
+	Auth     string `json:"auth,omitempty"`
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+}
+
+// getRegistryAuth returns the credentials of the registry in the docker config file projected from the pull secret,
+// nil when there is no config file or no credentials for the registry.
+func getRegistryAuth(configFile string, registry string) (*RegistryAuth, error) {
+	if configFile == "" {
+		return nil, nil
+	}
+	data, err := os.ReadFile(configFile)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read docker config: %w", err)
+	}
+	config := dockerConfig{}
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("unable to parse docker config: %w", err)
+	}
+	for key, entry := range config.Auths {
+		// the registries may be keyed by url, e.g. https://registry.example.com/v1/
+		host := strings.TrimPrefix(strings.TrimPrefix(key, "https://"), "http://")
+		host, _, _ = strings.Cut(host, "/")
+		if host != registry {
+			continue
+		}
+		if entry.Auth == "" {
+			return &RegistryAuth{Username: entry.Username, Password: entry.Password}, nil
+		}
+		decoded, err := base64.StdEncoding.DecodeString(entry.Auth)
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode the auth of registry %s: %w", registry, err)
+		}
+		username, password, found := strings.Cut(string(decoded), ":")
+		if !found {
+			return nil, fmt.Errorf("the auth of registry %s must be in the <username>:<password> format", registry)
+		}
+		return &RegistryAuth{Username: username, Password: password}, nil
+	}
+	return nil, nil
+}
+
+type ociDescriptor struct {
+	MediaType   string            `json:"mediaType"`
+	Digest      string            `json:"digest"`
+	Size        int64             `json:"size"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+type ociManifest struct {
+	MediaType string          `json:"mediaType"`
+	Layers    []ociDescriptor `json:"layers"`
+}
+
+// OCIDownloader pulls the layers of an artifact with the OCI distribution API and unpacks them to the model dir
+type OCIDownloader struct {
+	Client   *http.Client
+	Ref      *OCIReference
+	Auth     *RegistryAuth
+	ModelDir string
+	// authorization is the Authorization header negotiated with the registry
+	authorization string
+}
+
+func (o *OCIDownloader) Download() error {
+	manifest, err := o.fetchManifest()
+	if err != nil {
+		return err
+	}
+	for _, layer := range manifest.Layers {
+		if err := o.downloadLayer(layer); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (o *OCIDownloader) fetchManifest() (*ociManifest, error) {
+	resp, err := o.get("manifests/"+o.Ref.Reference(), OCIManifestMediaType, DockerManifestMediaType)
+	if err != nil {
+		return nil, err
+	}
+	defer closeBody(resp)
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxOCIManifestSize))
+	if err != nil {
+		return nil, fmt.Errorf("unable to read manifest: %w", err)
+	}
+	if o.Ref.Digest != "" {
+		verifier, err := newDigestVerifier(o.Ref.Digest)
+		if err != nil {
+			return nil, err
+		}
+		verifier.Write(body)
+		if err := verifyDigest(verifier, o.Ref.Digest); err != nil {
+			return nil, fmt.Errorf("invalid manifest: %w", err)
+		}
+	}
+	manifest := &ociManifest{}
+	if err := json.Unmarshal(body, manifest); err != nil {
+		return nil, fmt.Errorf("unable to parse manifest: %w", err)
+	}
+	mediaType := manifest.MediaType
+	if mediaType == "" {
+		mediaType = resp.Header.Get("Content-Type")
+	}
+	if mediaType != OCIManifestMediaType && mediaType != DockerManifestMediaType {
+		return nil, fmt.Errorf("unsupported manifest media type %s of %s%s/%s, the reference must be an image manifest",
+			mediaType, OCI, o.Ref.Registry, o.Ref.Repository)
+	}
+	return manifest, nil
+}
+
+func (o *OCIDownloader) downloadLayer(layer ociDescriptor) error {
+	blob, err := o.downloadBlob(layer)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := blob.Close(); closeErr != nil {
+			log.Error(closeErr, "failed to close blob")
+		}
+		if removeErr := os.Remove(blob.Name()); removeErr != nil {
+			log.Error(removeErr, "failed to remove blob")
+		}
+	}()
+
+	title := layer.Annotations[OCITitleAnnotation]
+	switch {
+	case title != "" && layer.Annotations[ORASUnpackAnnotation] != "true":
+		fileFullPath := filepath.Join(o.ModelDir, title)
+		if !strings.HasPrefix(fileFullPath, filepath.Clean(o.ModelDir)+string(os.PathSeparator)) {
+			return fmt.Errorf("%s: illegal file path", fileFullPath)
+		}
+		file, err := createNewFile(fileFullPath)
+		if err != nil {
+			return err
+		}
+		_, err = io.Copy(file, blob)
+		if closeErr := file.Close(); closeErr != nil {
+			return closeErr
+		}
+		if err != nil {
+			return fmt.Errorf("unable to copy file content: %w", err)
+		}
+		return nil
+	case strings.HasSuffix(layer.MediaType, "gzip"):
+		return extractTarFiles(blob, o.ModelDir)
+	case strings.HasSuffix(layer.MediaType, ".tar"):
+		return extractTarArchive(blob, o.ModelDir)
+	default:
+		return fmt.Errorf("unsupported layer %s of media type %s without %s annotation", layer.Digest, layer.MediaType,
+			OCITitleAnnotation)
+	}
+}
+
+// downloadBlob downloads the blob of the layer to a temporary file and verifies its size and digest
+func (o *OCIDownloader) downloadBlob(layer ociDescriptor) (*os.File, error) {
+	verifier, err := newDigestVerifier(layer.Digest)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := o.get("blobs/" + layer.Digest)
+	if err != nil {
+		return nil, err
+	}
+	defer closeBody(resp)
+	file, err := os.CreateTemp("", "oci-blob-")
+	if err != nil {
+		return nil, err
+	}
+	written, err := io.Copy(io.MultiWriter(file, verifier), resp.Body)
+	if err == nil && written != layer.Size {
+		err = fmt.Errorf("layer %s has size %d, expected %d", layer.Digest, written, layer.Size)
+	}
+	if err == nil {
+		err = verifyDigest(verifier, layer.Digest)
+	}
+	if err == nil {
+		_, err = file.Seek(0, io.SeekStart)
+	}
+	if err != nil {
+		_ = file.Close()
+		_ = os.Remove(file.Name())
+		return nil, fmt.Errorf("unable to download layer %s: %w", layer.Digest, err)
+	}
+	return file, nil
+}
+
+// get requests a path of the repository, authenticating with the registry when it challenges the request
+func (o *OCIDownloader) get(path string, accept ...string) (*http.Response, error) {
+	uri := fmt.Sprintf("https://%s/v2/%s/%s", o.Ref.Registry, o.Ref.Repository, path)
+	resp, err := o.doGet(uri, accept)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusUnauthorized && o.authorization == "" {
+		challenge := resp.Header.Get("WWW-Authenticate")
+		closeBody(resp)
+		if err := o.authenticate(challenge); err != nil {
+			return nil, err
+		}
+		if resp, err = o.doGet(uri, accept); err != nil {
+			return nil, err
+		}
+	}
+	if resp.StatusCode != http.StatusOK {
+		closeBody(resp)
+		return nil, fmt.Errorf("URI: %s returned a %d response code", uri, resp.StatusCode)
+	}
+	return resp, nil
+}
+
+func (o *OCIDownloader) doGet(uri string, accept []string) (*http.Response, error) {
+	req, err := http.NewRequest(http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(accept) != 0 {
+		req.Header.Set("Accept", strings.Join(accept, ", "))
+	}
+	if o.authorization != "" {
+		req.Header.Set("Authorization", o.authorization)
+	}
+	resp, err := o.Client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make a request: %w", err)
+	}
+	return resp, nil
+}
+
+// authenticate negotiates the Authorization header answering the WWW-Authenticate challenge of the registry, with a
+// bearer token from the token service of the registry or with basic auth.
+func (o *OCIDownloader) authenticate(challenge string) error {
+	scheme, rawParams, _ := strings.Cut(challenge, " ")
+	params := map[string]string{}
+	for _, param := range authChallengeParamRegexp.FindAllStringSubmatch(rawParams, -1) {
+		params[param[1]] = param[2]
+	}
+	switch strings.ToLower(scheme) {
+	case "basic":
+		if o.Auth == nil {
+			return fmt.Errorf("registry %s requires credentials", o.Ref.Registry)
+		}
+		o.authorization = "Basic " + base64.StdEncoding.EncodeToString([]byte(o.Auth.Username+":"+o.Auth.Password))
+		return nil
+	case "bearer":
+		return o.requestToken(params)
+	default:
+		return fmt.Errorf("unsupported authentication challenge %q of registry %s", challenge, o.Ref.Registry)
+	}
+}
+
+func (o *OCIDownloader) requestToken(params map[string]string) error {
+	tokenUrl, err := url.Parse(params["realm"])
+	if err != nil || tokenUrl.Host == "" {
+		return fmt.Errorf("invalid token realm %q of registry %s", params["realm"], o.Ref.Registry)
+	}
+	query := tokenUrl.Query()
+	if service := params["service"]; service != "" {
+		query.Set("service", service)
+	}
+	scope := params["scope"]
+	if scope == "" {
+		scope = fmt.Sprintf("repository:%s:pull", o.Ref.Repository)
+	}
+	query.Set("scope", scope)
+	tokenUrl.RawQuery = query.Encode()
+	req, err := http.NewRequest(http.MethodGet, tokenUrl.String(), nil)
+	if err != nil {
+		return err
+	}
+	if o.Auth != nil {
+		req.SetBasicAuth(o.Auth.Username, o.Auth.Password)
+	}
+	resp, err := o.Client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to request a token: %w", err)
+	}
+	defer closeBody(resp)
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("token request to %s returned a %d response code", tokenUrl.Host, resp.StatusCode)
+	}
+	token := struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}{}
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return fmt.Errorf("unable to parse token: %w", err)
+	}
+	if token.Token == "" {
+		token.Token = token.AccessToken
+	}
+	if token.Token == "" {
+		return fmt.Errorf("token request to %s returned no token", tokenUrl.Host)
+	}
+	o.authorization = "Bearer " + token.Token
+	return nil
+}
+
+func newDigestVerifier(digest string) (hash.Hash, error) {
+	algorithm, encoded, _ := strings.Cut(digest, ":")
+	if algorithm != "sha256" || len(encoded) != sha256.Size*2 {
+		return nil, fmt.Errorf("unsupported digest %s, only sha256 digests are supported", digest)
+	}
+	return sha256.New(), nil
+}
+
+func verifyDigest(verifier hash.Hash, digest string) error {
+	if actual := "sha256:" + hex.EncodeToString(verifier.Sum(nil)); actual != digest {
+		return fmt.Errorf("digest %s does not match the expected digest %s", actual, digest)
+	}
+	return nil
+}
+
+func closeBody(resp *http.Response) {
+	if closeErr := resp.Body.Close(); closeErr != nil {
+		log.Error(closeErr, "failed to close body")
+	}
+}
@@ -0,0 +1,276 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/onsi/gomega"
+
+	ocicredential "github.com/kserve/kserve/pkg/credentials/oci"
+)
+
+// fakeRegistry is a test double of an OCI registry serving a single repository, which requires a bearer token when
+// credentials are set.
+type fakeRegistry struct {
+	repository string
+	manifests  map[string][]byte
+	blobs      map[string][]byte
+	username   string
+	password   string
+}
+
+func newFakeRegistry(repository string) *fakeRegistry {
+	return &fakeRegistry{
+		repository: repository,
+		manifests:  map[string][]byte{},
+		blobs:      map[string][]byte{},
+	}
+}
+
+func sha256Digest(content []byte) string {
+	sum := sha256.Sum256(content)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// push adds the layers and their manifest to the registry and returns the digest of the manifest
+func (r *fakeRegistry) push(tag string, layers []ociDescriptor, contents [][]byte) string {
+	for i, content := range contents {
+		layers[i].Digest = sha256Digest(content)
+		layers[i].Size = int64(len(content))
+		r.blobs[layers[i].Digest] = content
+	}
+	manifest, _ := json.Marshal(ociManifest{MediaType: OCIManifestMediaType, Layers: layers})
+	digest := sha256Digest(manifest)
+	r.manifests[tag] = manifest
+	r.manifests[digest] = manifest
+	return digest
+}
+
+func (r *fakeRegistry) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.URL.Path == "/token" {
+		if username, password, ok := req.BasicAuth(); !ok || username != r.username || password != r.password {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]string{"token": "pull-token"})
+		return
+	}
+	if r.username != "" && req.Header.Get("Authorization") != "Bearer pull-token" {
+		w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="https://%s/token",service="registry"`, req.Host))
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	prefix := "/v2/" + r.repository + "/"
+	if !strings.HasPrefix(req.URL.Path, prefix) {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	kind, reference, _ := strings.Cut(strings.TrimPrefix(req.URL.Path, prefix), "/")
+	var content []byte
+	switch kind {
+	case "manifests":
+		content = r.manifests[reference]
+		w.Header().Set("Content-Type", OCIManifestMediaType)
+	case "blobs":
+		content = r.blobs[reference]
+	}
+	if content == nil {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	_, _ = w.Write(content)
+}
+
+func createTarGz(files map[string]string) []byte {
+	buf := &bytes.Buffer{}
+	gzw := gzip.NewWriter(buf)
+	tw := tar.NewWriter(gzw)
+	for name, content := range files {
+		_ = tw.WriteHeader(&tar.Header{Name: name, Mode: 0600, Size: int64(len(content)), Typeflag: tar.TypeReg})
+		_, _ = tw.Write([]byte(content))
+	}
+	_ = tw.Close()
+	_ = gzw.Close()
+	return buf.Bytes()
+}
+
+func TestParseOCIReference(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	digest := "sha256:" + strings.Repeat("a", 64)
+	scenarios := map[string]*OCIReference{
+		"oci://registry.example.com/models/bert:v3": {
+			Registry: "registry.example.com", Repository: "models/bert", Tag: "v3",
+		},
+		"oci://localhost:5000/bert": {
+			Registry: "localhost:5000", Repository: "bert", Tag: OCIDefaultTag,
+		},
+		"oci://registry.example.com/models/bert@" + digest: {
+			Registry: "registry.example.com", Repository: "models/bert", Digest: digest,
+		},
+		"oci://registry.example.com/models/bert:v3@" + digest: {
+			Registry: "registry.example.com", Repository: "models/bert", Tag: "v3", Digest: digest,
+		},
+		"oci://registry.example.com":                    nil,
+		"oci://registry.example.com/Models/bert":        nil,
+		"oci://registry.example.com/models/bert:":       nil,
+		"oci://registry.example.com/models/bert@sha256": nil,
+		"https://registry.example.com/models/bert":      nil,
+	}
+	for uri, expected := range scenarios {
+		ref, err := ParseOCIReference(uri)
+		if expected == nil {
+			g.Expect(err).To(gomega.HaveOccurred(), uri)
+			continue
+		}
+		g.Expect(err).NotTo(gomega.HaveOccurred(), uri)
+		g.Expect(ref).To(gomega.Equal(expected), uri)
+	}
+}
+
+func TestGetRegistryAuth(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	tmpDir, _ := os.MkdirTemp("", "test-oci-auth-")
+	defer os.RemoveAll(tmpDir)
+	configFile := filepath.Join(tmpDir, ocicredential.OCIDockerConfigFileName)
+	g.Expect(os.WriteFile(configFile, []byte(`{"auths": {
+		"registry.example.com": {"auth": "dXNlcjpwYXNzd29yZA=="},
+		"https://registry.example.org/v1/": {"username": "other", "password": "secret"}
+	}}`), 0600)).To(gomega.Succeed())
+
+	auth, err := getRegistryAuth(configFile, "registry.example.com")
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(auth).To(gomega.Equal(&RegistryAuth{Username: "user", Password: "password"}))
+
+	auth, err = getRegistryAuth(configFile, "registry.example.org")
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(auth).To(gomega.Equal(&RegistryAuth{Username: "other", Password: "secret"}))
+
+	auth, err = getRegistryAuth(configFile, "registry.example.net")
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(auth).To(gomega.BeNil())
+
+	auth, err = getRegistryAuth("", "registry.example.com")
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(auth).To(gomega.BeNil())
+}
+
+func TestOCIProviderDownloadModel(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	registry := newFakeRegistry("models/bert")
+	server := httptest.NewTLSServer(registry)
+	defer server.Close()
+	host := server.Listener.Addr().String()
+
+	manifestDigest := registry.push("v3", []ociDescriptor{
+		{MediaType: "application/vnd.oci.image.layer.v1.tar", Annotations: map[string]string{OCITitleAnnotation: "config.json"}},
+		{MediaType: "application/vnd.oci.image.layer.v1.tar+gzip", Annotations: map[string]string{
+			OCITitleAnnotation:   "weights",
+			ORASUnpackAnnotation: "true",
+		}},
+	}, [][]byte{
+		[]byte(`{"model_type": "bert"}`),
+		createTarGz(map[string]string{"weights/model.bin": "weights"}),
+	})
+
+	scenarios := map[string]struct {
+		storageUri  string
+		username    string
+		password    string
+		dockerCfg   string
+		expectedErr string
+	}{
+		"Tag": {
+			storageUri: fmt.Sprintf("oci://%s/models/bert:v3", host),
+		},
+		"Digest": {
+			storageUri: fmt.Sprintf("oci://%s/models/bert@%s", host, manifestDigest),
+		},
+		"PullSecret": {
+			storageUri: fmt.Sprintf("oci://%s/models/bert:v3", host),
+			username:   "user",
+			password:   "password",
+			dockerCfg:  fmt.Sprintf(`{"auths": {"%s": {"username": "user", "password": "password"}}}`, host),
+		},
+		"WrongCredentials": {
+			storageUri:  fmt.Sprintf("oci://%s/models/bert:v3", host),
+			username:    "user",
+			password:    "password",
+			dockerCfg:   fmt.Sprintf(`{"auths": {"%s": {"username": "user", "password": "wrong"}}}`, host),
+			expectedErr: "401 response code",
+		},
+		"UnknownTag": {
+			storageUri:  fmt.Sprintf("oci://%s/models/bert:v4", host),
+			expectedErr: "404 response code",
+		},
+		"UnknownDigest": {
+			storageUri:  fmt.Sprintf("oci://%s/models/bert@sha256:%s", host, strings.Repeat("0", 64)),
+			expectedErr: "404 response code",
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			registry.username, registry.password = scenario.username, scenario.password
+			tmpDir, _ := os.MkdirTemp("", "test-oci-")
+			defer os.RemoveAll(tmpDir)
+			if scenario.dockerCfg != "" {
+				configFile := filepath.Join(tmpDir, ocicredential.OCIDockerConfigFileName)
+				g.Expect(os.WriteFile(configFile, []byte(scenario.dockerCfg), 0600)).To(gomega.Succeed())
+				t.Setenv(ocicredential.OCIDockerConfigEnvKey, configFile)
+			}
+
+			provider := &OCIProvider{Client: server.Client()}
+			err := provider.DownloadModel(tmpDir, "bert", scenario.storageUri)
+			if scenario.expectedErr != "" {
+				g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring(scenario.expectedErr)))
+				return
+			}
+			g.Expect(err).NotTo(gomega.HaveOccurred())
+			g.Expect(os.ReadFile(filepath.Join(tmpDir, "bert", "config.json"))).To(gomega.Equal([]byte(`{"model_type": "bert"}`)))
+			g.Expect(os.ReadFile(filepath.Join(tmpDir, "bert", "weights", "model.bin"))).To(gomega.Equal([]byte("weights")))
+		})
+	}
+
+	// a layer whose content does not match its digest is rejected
+	registry.username, registry.password = "", ""
+	corrupted := registry.push("corrupted", []ociDescriptor{
+		{MediaType: "application/vnd.oci.image.layer.v1.tar", Annotations: map[string]string{OCITitleAnnotation: "model.bin"}},
+	}, [][]byte{[]byte("weights")})
+	for digest := range registry.blobs {
+		if digest == sha256Digest([]byte("weights")) {
+			registry.blobs[digest] = []byte("weightz")
+		}
+	}
+	tmpDir, _ := os.MkdirTemp("", "test-oci-")
+	defer os.RemoveAll(tmpDir)
+	err := (&OCIProvider{Client: server.Client()}).DownloadModel(tmpDir, "bert", fmt.Sprintf("oci://%s/models/bert@%s", host, corrupted))
+	g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("does not match the expected digest")))
+	g.Expect(filepath.Join(tmpDir, "bert", "model.bin")).NotTo(gomega.BeAnExistingFile())
+}
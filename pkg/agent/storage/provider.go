@@ -29,9 +29,12 @@ const (
 	// File  Protocol = "file://"
 	HTTPS Protocol = "https://"
 	HTTP  Protocol = "http://"
+	OCI   Protocol = "oci://"
+	HF    Protocol = "hf://"
+	SFTP  Protocol = "sftp://"
 )
 
-var SupportedProtocols = []Protocol{S3, GCS, HTTPS, HTTP}
+var SupportedProtocols = []Protocol{S3, GCS, HTTPS, HTTP, OCI, HF, SFTP}
 
 func GetAllProtocol() (protocols []string) {
 	for _, protocol := range SupportedProtocols {
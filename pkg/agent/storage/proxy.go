@@ -0,0 +1,58 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	gstorage "cloud.google.com/go/storage"
+	"golang.org/x/net/http/httpproxy"
+	"google.golang.org/api/option"
+	htransport "google.golang.org/api/transport/http"
+)
+
+// newHTTPTransport returns the transport of the requests of the storage providers. The requests go through the
+// proxies of the HTTP_PROXY, HTTPS_PROXY and NO_PROXY envs, which are read when the transport is created rather than
+// once per process as done by http.ProxyFromEnvironment.
+func newHTTPTransport() *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	proxyFunc := httpproxy.FromEnvironment().ProxyFunc()
+	transport.Proxy = func(req *http.Request) (*url.URL, error) {
+		return proxyFunc(req.URL)
+	}
+	return transport
+}
+
+// newHTTPClient returns the http client of the storage providers using the proxy transport
+func newHTTPClient() *http.Client {
+	return &http.Client{
+		Transport: newHTTPTransport(),
+	}
+}
+
+// newGCSClient returns the gcs client using the proxy transport, the client is authenticated with the Application
+// Default Credentials unless overridden by opts.
+func newGCSClient(ctx context.Context, opts ...option.ClientOption) (*gstorage.Client, error) {
+	opts = append(opts, option.WithScopes(gstorage.ScopeReadOnly))
+	transport, err := htransport.NewTransport(ctx, newHTTPTransport(), opts...)
+	if err != nil {
+		return nil, err
+	}
+	return gstorage.NewClient(ctx, option.WithHTTPClient(&http.Client{Transport: transport}))
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	s3credential "github.com/kserve/kserve/pkg/credentials/s3"
+	"github.com/onsi/gomega"
+)
+
+func proxyOf(g *gomega.WithT, transport *http.Transport, rawURL string) *url.URL {
+	req, err := http.NewRequest(http.MethodGet, rawURL, nil)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	proxy, err := transport.Proxy(req)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	return proxy
+}
+
+func TestNewHTTPTransportProxy(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	t.Setenv("HTTP_PROXY", "http://proxy.example.com:3128")
+	t.Setenv("HTTPS_PROXY", "http://secure-proxy.example.com:3129")
+	t.Setenv("NO_PROXY", ".svc.cluster.local,minio.internal")
+
+	transport := newHTTPTransport()
+	g.Expect(proxyOf(g, transport, "http://models.example.com/model.onnx").String()).To(gomega.Equal("http://proxy.example.com:3128"))
+	g.Expect(proxyOf(g, transport, "https://models.example.com/model.onnx").String()).To(gomega.Equal("http://secure-proxy.example.com:3129"))
+	g.Expect(proxyOf(g, transport, "http://minio.internal:9000/models")).To(gomega.BeNil())
+	g.Expect(proxyOf(g, transport, "http://logger.default.svc.cluster.local")).To(gomega.BeNil())
+
+	// the envs are read when the transport is created
+	t.Setenv("HTTPS_PROXY", "")
+	g.Expect(proxyOf(g, newHTTPTransport(), "https://models.example.com/model.onnx")).To(gomega.BeNil())
+}
+
+func TestS3SessionProxy(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	t.Setenv("AWS_ACCESS_KEY_ID", "key")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+	t.Setenv(s3credential.AWSRegion, "us-east-1")
+	t.Setenv("HTTPS_PROXY", "http://proxy.example.com:3128")
+	t.Setenv("NO_PROXY", "minio.internal")
+
+	sess, err := newS3Session()
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	transport := sess.Config.HTTPClient.Transport.(*http.Transport)
+	g.Expect(proxyOf(g, transport, "https://models.s3.amazonaws.com/model.onnx").String()).To(gomega.Equal("http://proxy.example.com:3128"))
+	g.Expect(proxyOf(g, transport, "https://minio.internal/models/model.onnx")).To(gomega.BeNil())
+
+	// the proxy is kept when the ssl verification is disabled
+	t.Setenv(s3credential.S3VerifySSL, "0")
+	sess, err = newS3Session()
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	transport = sess.Config.HTTPClient.Transport.(*http.Transport)
+	g.Expect(transport.TLSClientConfig.InsecureSkipVerify).To(gomega.BeTrue())
+	g.Expect(proxyOf(g, transport, "https://models.s3.amazonaws.com/model.onnx").String()).To(gomega.Equal("http://proxy.example.com:3128"))
+}
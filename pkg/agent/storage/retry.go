@@ -0,0 +1,162 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+const (
+	// DownloadMaxAttemptsEnvKey is the env of the number of attempts of the download of an object
+	DownloadMaxAttemptsEnvKey = "STORAGE_DOWNLOAD_MAX_ATTEMPTS"
+	// DownloadInitialBackoffEnvKey is the env of the backoff before the first retry, doubled at every retry
+	DownloadInitialBackoffEnvKey = "STORAGE_DOWNLOAD_INITIAL_BACKOFF"
+	// DownloadMaxBackoffEnvKey is the env of the maximum backoff between two retries
+	DownloadMaxBackoffEnvKey = "STORAGE_DOWNLOAD_MAX_BACKOFF"
+
+	DefaultDownloadMaxAttempts    = 5
+	DefaultDownloadInitialBackoff = time.Second
+	DefaultDownloadMaxBackoff     = 30 * time.Second
+
+	// PartialDownloadSuffix is the suffix of the files being downloaded, they are renamed once complete
+	PartialDownloadSuffix = ".part"
+)
+
+// RetryConfig configures the retries of the failed downloads with an exponential backoff. The zero value downloads
+// the objects once.
+type RetryConfig struct {
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+}
+
+// permanentError is a download failure which is not retried
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string {
+	return e.err.Error()
+}
+
+func (e *permanentError) Unwrap() error {
+	return e.err
+}
+
+// permanent marks the error as a failure which is not retried
+func permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
+}
+
+// isRetryableStatusCode returns true for the response codes of the failures which may succeed when retried
+func isRetryableStatusCode(code int) bool {
+	return code == http.StatusRequestTimeout || code == http.StatusTooManyRequests || code >= http.StatusInternalServerError
+}
+
+// Do calls fn until it succeeds, it fails with a permanent error, the attempts are exhausted or the context is done
+func (c RetryConfig) Do(ctx context.Context, name string, fn func() error) error {
+	backoff := c.InitialBackoff
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		var permanentErr *permanentError
+		if err == nil || errors.As(err, &permanentErr) || attempt >= c.MaxAttempts || ctx.Err() != nil {
+			return err
+		}
+		log.Info("Retrying failed download", "name", name, "attempt", attempt, "backoff", backoff.String(),
+			"error", err.Error())
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return err
+		}
+		backoff = min(2*backoff, c.MaxBackoff)
+	}
+}
+
+// getRetryConfig returns the retry configuration of the downloads set by the STORAGE_DOWNLOAD_MAX_ATTEMPTS,
+// STORAGE_DOWNLOAD_INITIAL_BACKOFF and STORAGE_DOWNLOAD_MAX_BACKOFF envs.
+func getRetryConfig() (RetryConfig, error) {
+	config := RetryConfig{
+		MaxAttempts:    DefaultDownloadMaxAttempts,
+		InitialBackoff: DefaultDownloadInitialBackoff,
+		MaxBackoff:     DefaultDownloadMaxBackoff,
+	}
+	if value, ok := os.LookupEnv(DownloadMaxAttemptsEnvKey); ok {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 1 {
+			return RetryConfig{}, fmt.Errorf("invalid %s %q, must be a positive integer", DownloadMaxAttemptsEnvKey, value)
+		}
+		config.MaxAttempts = parsed
+	}
+	for key, backoff := range map[string]*time.Duration{
+		DownloadInitialBackoffEnvKey: &config.InitialBackoff,
+		DownloadMaxBackoffEnvKey:     &config.MaxBackoff,
+	} {
+		if value, ok := os.LookupEnv(key); ok {
+			parsed, err := time.ParseDuration(value)
+			if err != nil || parsed < 0 {
+				return RetryConfig{}, fmt.Errorf("invalid %s %q, must be a non-negative duration", key, value)
+			}
+			*backoff = parsed
+		}
+	}
+	if config.MaxBackoff < config.InitialBackoff {
+		return RetryConfig{}, fmt.Errorf("%s must not be lower than %s", DownloadMaxBackoffEnvKey, DownloadInitialBackoffEnvKey)
+	}
+	return config, nil
+}
+
+// fileSize returns the size of the file, -1 when it does not exist
+func fileSize(fileName string) int64 {
+	info, err := os.Stat(fileName)
+	if err != nil || info.IsDir() {
+		return -1
+	}
+	return info.Size()
+}
+
+// openPartialFile opens the partial download file of fileName, discarding the data after offset
+func openPartialFile(fileName string, offset int64) (*os.File, error) {
+	// same permissions as Create, without truncating the data downloaded so far
+	if err := os.MkdirAll(filepath.Dir(fileName), 0777); err != nil {
+		return nil, err
+	}
+	file, err := os.OpenFile(fileName+PartialDownloadSuffix, os.O_WRONLY|os.O_CREATE, 0666)
+	if err != nil {
+		return nil, err
+	}
+	if err := file.Truncate(offset); err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+	if _, err := file.Seek(offset, io.SeekStart); err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+	return file, nil
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+)
+
+func TestRetryConfigDo(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	retry := RetryConfig{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: 2 * time.Millisecond}
+
+	// the failed attempts are retried until they succeed
+	attempts := 0
+	g.Expect(retry.Do(context.Background(), "model.bin", func() error {
+		attempts++
+		if attempts < 3 {
+			return fmt.Errorf("connection reset by peer")
+		}
+		return nil
+	})).To(gomega.Succeed())
+	g.Expect(attempts).To(gomega.Equal(3))
+
+	// the last error is returned when the attempts are exhausted
+	attempts = 0
+	err := retry.Do(context.Background(), "model.bin", func() error {
+		attempts++
+		return fmt.Errorf("attempt %d failed", attempts)
+	})
+	g.Expect(err).To(gomega.MatchError("attempt 3 failed"))
+	g.Expect(attempts).To(gomega.Equal(3))
+
+	// permanent failures are not retried
+	attempts = 0
+	err = retry.Do(context.Background(), "model.bin", func() error {
+		attempts++
+		return permanent(fmt.Errorf("access denied"))
+	})
+	g.Expect(err).To(gomega.MatchError("access denied"))
+	g.Expect(attempts).To(gomega.Equal(1))
+
+	// the downloads are not retried once the context is done
+	ctx, cancel := context.WithCancel(context.Background())
+	attempts = 0
+	err = retry.Do(ctx, "model.bin", func() error {
+		attempts++
+		cancel()
+		return fmt.Errorf("canceled")
+	})
+	g.Expect(err).To(gomega.MatchError("canceled"))
+	g.Expect(attempts).To(gomega.Equal(1))
+
+	// the zero value downloads once
+	attempts = 0
+	g.Expect(RetryConfig{}.Do(context.Background(), "model.bin", func() error {
+		attempts++
+		return fmt.Errorf("failed")
+	})).NotTo(gomega.Succeed())
+	g.Expect(attempts).To(gomega.Equal(1))
+}
+
+func TestGetRetryConfig(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	config, err := getRetryConfig()
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(config).To(gomega.Equal(RetryConfig{
+		MaxAttempts:    DefaultDownloadMaxAttempts,
+		InitialBackoff: DefaultDownloadInitialBackoff,
+		MaxBackoff:     DefaultDownloadMaxBackoff,
+	}))
+
+	t.Setenv(DownloadMaxAttemptsEnvKey, "10")
+	t.Setenv(DownloadInitialBackoffEnvKey, "500ms")
+	t.Setenv(DownloadMaxBackoffEnvKey, "2m")
+	config, err = getRetryConfig()
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(config).To(gomega.Equal(RetryConfig{MaxAttempts: 10, InitialBackoff: 500 * time.Millisecond, MaxBackoff: 2 * time.Minute}))
+
+	t.Setenv(DownloadMaxBackoffEnvKey, "100ms")
+	_, err = getRetryConfig()
+	g.Expect(err).To(gomega.HaveOccurred())
+
+	t.Setenv(DownloadMaxAttemptsEnvKey, "0")
+	_, err = getRetryConfig()
+	g.Expect(err).To(gomega.HaveOccurred())
+}
@@ -17,12 +17,18 @@ limitations under the License.
 package storage
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/aws/aws-sdk-go/service/s3/s3iface"
 	"github.com/aws/aws-sdk-go/service/s3/s3manager"
@@ -30,9 +36,25 @@ import (
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 )
 
+const (
+	// S3DownloadConcurrencyEnvKey is the env of the number of objects downloaded in parallel
+	S3DownloadConcurrencyEnvKey = "S3_DOWNLOAD_CONCURRENCY"
+	// S3DownloadPartSizeEnvKey is the env of the size in bytes of the parts of the objects downloaded in parallel
+	S3DownloadPartSizeEnvKey     = "S3_DOWNLOAD_PART_SIZE"
+	DefaultS3DownloadConcurrency = 4
+	// s3PartConcurrency is the number of parts of an object downloaded in parallel
+	s3PartConcurrency = s3manager.DefaultDownloadConcurrency
+)
+
 type S3Provider struct {
 	Client     s3iface.S3API
 	Downloader s3manageriface.DownloadWithIterator
+	// Concurrency is the number of objects downloaded in parallel, DefaultS3DownloadConcurrency when not set
+	Concurrency int
+	// PartSize is the size of the parts of the objects, the default part size of the s3manager when not set
+	PartSize int64
+	// Retry configures the retries of the objects which failed to download
+	Retry RetryConfig
 }
 
 var log = logf.Log.WithName("modelAgent")
@@ -40,12 +62,16 @@ var log = logf.Log.WithName("modelAgent")
 var _ Provider = (*S3Provider)(nil)
 
 type S3ObjectDownloader struct {
-	StorageUri string
-	ModelDir   string
-	ModelName  string
-	Bucket     string
-	Prefix     string
-	downloader s3manageriface.DownloadWithIterator
+	StorageUri  string
+	ModelDir    string
+	ModelName   string
+	Bucket      string
+	Prefix      string
+	Pattern     string
+	Concurrency int
+	PartSize    int64
+	Retry       RetryConfig
+	downloader  s3manageriface.DownloadWithIterator
 }
 
 func (m *S3Provider) DownloadModel(modelDir string, modelName string, storageUri string) error {
@@ -57,12 +83,15 @@ func (m *S3Provider) DownloadModel(modelDir string, modelName string, storageUri
 		prefix = tokens[1]
 	}
 	s3ObjectDownloader := &S3ObjectDownloader{
-		StorageUri: storageUri,
-		ModelDir:   modelDir,
-		ModelName:  modelName,
-		Bucket:     tokens[0],
-		Prefix:     prefix,
-		downloader: m.Downloader,
+		StorageUri:  storageUri,
+		ModelDir:    modelDir,
+		ModelName:   modelName,
+		Bucket:      tokens[0],
+		Prefix:      prefix,
+		Concurrency: m.Concurrency,
+		PartSize:    m.PartSize,
+		Retry:       m.Retry,
+		downloader:  m.Downloader,
 	}
 	objects, err := s3ObjectDownloader.GetAllObjects(m.Client)
 	if err != nil {
@@ -74,71 +103,199 @@ func (m *S3Provider) DownloadModel(modelDir string, modelName string, storageUri
 	return nil
 }
 
-func (s *S3ObjectDownloader) GetAllObjects(s3Svc s3iface.S3API) ([]s3manager.BatchDownloadObject, error) {
-	resp, err := s3Svc.ListObjects(&s3.ListObjectsInput{
+// GetAllObjects lists the objects under the prefix, including the zero-byte directory markers. When Pattern is set,
+// only the objects whose keys relative to the prefix match the glob are listed.
+func (s *S3ObjectDownloader) GetAllObjects(s3Svc s3iface.S3API) ([]*s3.Object, error) {
+	results := make([]*s3.Object, 0)
+	foundObject := false
+	err := s3Svc.ListObjectsPages(&s3.ListObjectsInput{
 		Bucket: aws.String(s.Bucket),
 		Prefix: aws.String(s.Prefix),
+	}, func(page *s3.ListObjectsOutput, lastPage bool) bool {
+		for _, object := range page.Contents {
+			if s.Pattern != "" && !matchesAnyGlob([]string{s.Pattern}, strings.TrimPrefix(*object.Key, s.Prefix)) {
+				continue
+			}
+			if !strings.HasSuffix(*object.Key, "/") {
+				foundObject = true
+			}
+			results = append(results, object)
+		}
+		return true
 	})
 	if err != nil {
 		return nil, err
 	}
-	results := make([]s3manager.BatchDownloadObject, 0)
-
-	if len(resp.Contents) == 0 {
+	if !foundObject {
 		return nil, fmt.Errorf("%s has no objects or does not exist", s.StorageUri)
 	}
+	return results, nil
+}
 
-	var foundObject = false
+// Download downloads the objects with a pool of Concurrency workers. The first failure cancels the downloads in
+// progress and the errors of all the failed downloads are returned.
+func (s *S3ObjectDownloader) Download(objects []*s3.Object) error {
+	ctx, cancel := context.WithCancel(aws.BackgroundContext())
+	defer cancel()
 
-	for _, object := range resp.Contents {
-		if strings.HasSuffix(*object.Key, "/") {
-			continue
-		}
-		subObjectKey := strings.TrimPrefix(*object.Key, s.Prefix)
-		fileName := filepath.Join(s.ModelDir, s.ModelName, subObjectKey)
-
-		if FileExists(fileName) {
-			// File got corrupted or is mid-download :(
-			// TODO: Figure out if we can maybe continue?
-			if err := os.Remove(fileName); err != nil {
-				return nil, fmt.Errorf("file is unable to be deleted: %w", err)
+	concurrency := s.Concurrency
+	if concurrency < 1 {
+		concurrency = DefaultS3DownloadConcurrency
+	}
+	objectCh := make(chan *s3.Object)
+	var mu sync.Mutex
+	var errs []error
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for object := range objectCh {
+				err := s.downloadObject(ctx, object)
+				if err == nil || (ctx.Err() != nil && isS3RequestCanceled(err)) {
+					continue
+				}
+				mu.Lock()
+				errs = append(errs, fmt.Errorf("unable to download %s: %w", *object.Key, err))
+				mu.Unlock()
+				cancel()
 			}
+		}()
+	}
+sendObjects:
+	for _, object := range objects {
+		select {
+		case objectCh <- object:
+		case <-ctx.Done():
+			break sendObjects
 		}
-		file, err := Create(fileName)
-		if err != nil {
-			return nil, fmt.Errorf("file is already created: %w", err)
+	}
+	close(objectCh)
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+func (s *S3ObjectDownloader) downloadObject(ctx context.Context, object *s3.Object) error {
+	if ctx.Err() != nil {
+		return nil
+	}
+	subObjectKey := strings.TrimPrefix(*object.Key, s.Prefix)
+	fileName := filepath.Join(s.ModelDir, s.ModelName, subObjectKey)
+	if strings.HasSuffix(*object.Key, "/") {
+		// zero-byte directory markers keep the empty directories of the model
+		return os.MkdirAll(fileName, 0777)
+	}
+	if object.Size != nil && fileSize(fileName) == *object.Size {
+		log.Info("Skipping downloaded object", "key", *object.Key, "fileName", fileName)
+		return nil
+	}
+	return s.Retry.Do(ctx, *object.Key, func() error {
+		err := s.downloadFile(ctx, object, fileName)
+		if err != nil && !isS3RetryableError(err) {
+			return permanent(err)
 		}
-		object := s3manager.BatchDownloadObject{
-			Object: &s3.GetObjectInput{
-				Key:    aws.String(*object.Key),
-				Bucket: aws.String(s.Bucket),
-			},
-			Writer: file,
-			After: func() error {
-				defer func(file *os.File) {
-					closeErr := file.Close()
-					if closeErr != nil {
-						log.Error(closeErr, "failed to close file")
-					}
-				}(file)
-				return nil
-			},
+		return err
+	})
+}
+
+// downloadFile downloads the object to its partial download file, resuming the previous download if any, and renames
+// it once complete
+func (s *S3ObjectDownloader) downloadFile(ctx context.Context, object *s3.Object, fileName string) error {
+	offset := s.resumeOffset(object, fileSize(fileName+PartialDownloadSuffix))
+	file, err := openPartialFile(fileName, offset)
+	if err != nil {
+		return fmt.Errorf("unable to create file: %w", err)
+	}
+	defer func(file *os.File) {
+		closeErr := file.Close()
+		if closeErr != nil && !errors.Is(closeErr, os.ErrClosed) {
+			log.Error(closeErr, "failed to close file")
 		}
-		foundObject = true
-		results = append(results, object)
+	}(file)
+	input := &s3.GetObjectInput{
+		Key:    aws.String(*object.Key),
+		Bucket: aws.String(s.Bucket),
+	}
+	var writer io.WriterAt = file
+	if offset > 0 {
+		log.Info("Resuming download", "key", *object.Key, "offset", offset)
+		input.Range = aws.String(fmt.Sprintf("bytes=%d-", offset))
+		writer = &offsetWriterAt{writer: file, offset: offset}
 	}
+	iter := &s3manager.DownloadObjectsIterator{Objects: []s3manager.BatchDownloadObject{
+		{
+			Object: input,
+			Writer: writer,
+		},
+	}}
+	err = s.downloader.DownloadWithIterator(ctx, iter, func(d *s3manager.Downloader) {
+		d.Concurrency = s3PartConcurrency
+		if s.PartSize > 0 {
+			d.PartSize = s.PartSize
+		}
+	})
+	if err != nil {
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	return os.Rename(fileName+PartialDownloadSuffix, fileName)
+}
 
-	if !foundObject {
-		return nil, fmt.Errorf("%s has no objects or does not exist", s.StorageUri)
+// resumeOffset returns the offset the download of the object resumes from given the size of its partial download.
+// The parts of the object are downloaded concurrently, so the data of the last parts, which may have been in progress
+// when the download failed, is downloaded again.
+func (s *S3ObjectDownloader) resumeOffset(object *s3.Object, partialSize int64) int64 {
+	if object.Size == nil || partialSize <= 0 || partialSize > *object.Size {
+		return 0
 	}
+	partSize := s.PartSize
+	if partSize <= 0 {
+		partSize = s3manager.DefaultDownloadPartSize
+	}
+	return max(0, partialSize-s3PartConcurrency*partSize)
+}
 
-	return results, nil
+// offsetWriterAt writes the data of a ranged download at its offset in the file
+type offsetWriterAt struct {
+	writer io.WriterAt
+	offset int64
 }
 
-func (s *S3ObjectDownloader) Download(objects []s3manager.BatchDownloadObject) error {
-	iter := &s3manager.DownloadObjectsIterator{Objects: objects}
-	if err := s.downloader.DownloadWithIterator(aws.BackgroundContext(), iter); err != nil {
-		return err
+func (w *offsetWriterAt) WriteAt(p []byte, off int64) (int, error) {
+	return w.writer.WriteAt(p, w.offset+off)
+}
+
+// isS3RetryableError returns false when the download failed with a response code which does not change on retries
+func isS3RetryableError(err error) bool {
+	var batchErr *s3manager.BatchError
+	if errors.As(err, &batchErr) {
+		for _, objectErr := range batchErr.Errors {
+			if !isS3RetryableError(objectErr.OrigErr) {
+				return false
+			}
+		}
+		return true
 	}
-	return nil
+	var requestErr awserr.RequestFailure
+	if errors.As(err, &requestErr) {
+		return isRetryableStatusCode(requestErr.StatusCode())
+	}
+	return true
+}
+
+// isS3RequestCanceled returns true when the download failed because it was canceled by the failure of another download
+func isS3RequestCanceled(err error) bool {
+	var batchErr *s3manager.BatchError
+	if errors.As(err, &batchErr) {
+		for _, objectErr := range batchErr.Errors {
+			if !isS3RequestCanceled(objectErr.OrigErr) {
+				return false
+			}
+		}
+		return len(batchErr.Errors) != 0
+	}
+	var awsErr awserr.Error
+	return errors.As(err, &awsErr) && awsErr.Code() == request.CanceledErrorCode
 }
@@ -0,0 +1,272 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+	"github.com/onsi/gomega"
+)
+
+// fakeS3 lists and downloads the objects of a bucket, listing one object per page
+type fakeS3 struct {
+	s3iface.S3API
+	objects map[string]string
+	failing map[string]bool
+	// interrupted is the number of downloads of the objects failing after writing half of the requested range
+	interrupted map[string]int
+	// failErr is the error of the failing downloads
+	failErr error
+
+	mu         sync.Mutex
+	downloaded []string
+	partSizes  []int64
+	ranges     []string
+}
+
+func (f *fakeS3) ListObjectsPages(input *s3.ListObjectsInput, fn func(*s3.ListObjectsOutput, bool) bool) error {
+	keys := make([]string, 0, len(f.objects))
+	for key := range f.objects {
+		if strings.HasPrefix(key, aws.StringValue(input.Prefix)) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	for i, key := range keys {
+		object := &s3.Object{Key: aws.String(key), Size: aws.Int64(int64(len(f.objects[key])))}
+		if !fn(&s3.ListObjectsOutput{Contents: []*s3.Object{object}}, i == len(keys)-1) {
+			break
+		}
+	}
+	return nil
+}
+
+func (f *fakeS3) DownloadWithIterator(ctx aws.Context, iter s3manager.BatchDownloadIterator, opts ...func(*s3manager.Downloader)) error {
+	downloader := &s3manager.Downloader{}
+	for _, opt := range opts {
+		opt(downloader)
+	}
+	for iter.Next() {
+		object := iter.DownloadObject()
+		key := *object.Object.Key
+		content := f.objects[key]
+		if object.Object.Range != nil {
+			offset, _ := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(*object.Object.Range, "bytes="), "-"))
+			content = content[offset:]
+		}
+		f.mu.Lock()
+		f.downloaded = append(f.downloaded, key)
+		f.partSizes = append(f.partSizes, downloader.PartSize)
+		f.ranges = append(f.ranges, aws.StringValue(object.Object.Range))
+		interrupted := f.interrupted[key] > 0
+		if interrupted {
+			f.interrupted[key]--
+		}
+		f.mu.Unlock()
+		var err error
+		switch {
+		case ctx.Err() != nil:
+			err = awserr.New(request.CanceledErrorCode, "request context canceled", ctx.Err())
+		case f.failing[key] && f.failErr != nil:
+			err = f.failErr
+		case f.failing[key]:
+			err = fmt.Errorf("failed to download")
+		case interrupted:
+			_, _ = object.Writer.WriteAt([]byte(content[:len(content)/2]), 0)
+			err = fmt.Errorf("connection reset by peer")
+		default:
+			_, err = object.Writer.WriteAt([]byte(content), 0)
+		}
+		if err != nil {
+			return s3manager.NewBatchError("BatchedDownloadIncomplete", "some objects have failed to download.",
+				[]s3manager.Error{{OrigErr: err, Bucket: object.Object.Bucket, Key: object.Object.Key}})
+		}
+	}
+	return nil
+}
+
+// readLayout returns the content of the files and the directories of the model dir by relative path
+func readLayout(g *gomega.WithT, dir string) map[string]string {
+	layout := map[string]string{}
+	g.Expect(filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, _ := filepath.Rel(dir, path)
+		if d.IsDir() {
+			layout[rel+"/"] = ""
+			return nil
+		}
+		content, err := os.ReadFile(path)
+		layout[rel] = string(content)
+		return err
+	})).To(gomega.Succeed())
+	return layout
+}
+
+func TestS3ProviderDownloadModel(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	objects := map[string]string{
+		"models/bert/":             "",
+		"models/bert/config.json":  `{"model_type": "bert"}`,
+		"models/bert/empty/":       "",
+		"models/bert/shards/0.bin": "shard-0",
+		"models/bert/shards/1.bin": "shard-1",
+		"models/bert/shards/2.bin": "shard-2",
+	}
+	expectedLayout := map[string]string{
+		"./":                "",
+		"bert/":             "",
+		"bert/config.json":  `{"model_type": "bert"}`,
+		"bert/empty/":       "",
+		"bert/shards/":      "",
+		"bert/shards/0.bin": "shard-0",
+		"bert/shards/1.bin": "shard-1",
+		"bert/shards/2.bin": "shard-2",
+	}
+
+	layouts := map[int]map[string]string{}
+	for _, concurrency := range []int{1, 4} {
+		fake := &fakeS3{objects: objects}
+		tmpDir, _ := os.MkdirTemp("", "test-s3-")
+		defer os.RemoveAll(tmpDir)
+		provider := &S3Provider{Client: fake, Downloader: fake, Concurrency: concurrency, PartSize: 64 * 1024 * 1024}
+		g.Expect(provider.DownloadModel(tmpDir, "bert", "s3://bucket/models/bert/")).To(gomega.Succeed())
+		layouts[concurrency] = readLayout(g, tmpDir)
+		g.Expect(fake.partSizes).To(gomega.HaveEach(int64(64 * 1024 * 1024)))
+	}
+	// downloading in parallel produces the same layout as downloading sequentially
+	g.Expect(layouts[1]).To(gomega.Equal(expectedLayout))
+	g.Expect(layouts[4]).To(gomega.Equal(layouts[1]))
+}
+
+func TestS3ProviderDownloadModelFailure(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	fake := &fakeS3{
+		objects: map[string]string{
+			"models/bert/0.bin": "shard-0",
+			"models/bert/1.bin": "shard-1",
+			"models/bert/2.bin": "shard-2",
+		},
+		failing: map[string]bool{"models/bert/0.bin": true},
+	}
+	tmpDir, _ := os.MkdirTemp("", "test-s3-")
+	defer os.RemoveAll(tmpDir)
+	provider := &S3Provider{Client: fake, Downloader: fake, Concurrency: 1}
+	err := provider.DownloadModel(tmpDir, "bert", "s3://bucket/models/bert/")
+	g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("unable to download models/bert/0.bin")))
+	// the failure cancels the remaining downloads
+	g.Expect(fake.downloaded).To(gomega.Equal([]string{"models/bert/0.bin"}))
+
+	// the errors of all the failed downloads are returned
+	fake = &fakeS3{objects: fake.objects, failing: map[string]bool{"models/bert/0.bin": true, "models/bert/1.bin": true}}
+	objects := []*s3.Object{{Key: aws.String("models/bert/0.bin")}, {Key: aws.String("models/bert/1.bin")}}
+	downloader := &S3ObjectDownloader{ModelDir: tmpDir, ModelName: "bert", Bucket: "bucket", Prefix: "models/bert/",
+		Concurrency: 2, downloader: newBlockingDownloader(fake, 2)}
+	err = downloader.Download(objects)
+	g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("unable to download models/bert/0.bin")))
+	g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("unable to download models/bert/1.bin")))
+
+	// directory markers alone are not a model
+	fake = &fakeS3{objects: map[string]string{"models/bert/": ""}}
+	provider = &S3Provider{Client: fake, Downloader: fake}
+	g.Expect(provider.DownloadModel(tmpDir, "bert", "s3://bucket/models/bert/")).To(
+		gomega.MatchError(gomega.ContainSubstring("has no objects or does not exist")))
+}
+
+func TestS3ProviderDownloadModelResume(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	content := strings.Repeat("0123456789", 10)
+	fake := &fakeS3{
+		objects:     map[string]string{"models/bert/model.bin": content},
+		interrupted: map[string]int{"models/bert/model.bin": 2},
+	}
+	modelDir := t.TempDir()
+	retry := RetryConfig{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond}
+	provider := &S3Provider{Client: fake, Downloader: fake, Concurrency: 1, PartSize: 4, Retry: retry}
+	g.Expect(provider.DownloadModel(modelDir, "bert", "s3://bucket/models/bert/")).To(gomega.Succeed())
+	g.Expect(readLayout(g, modelDir)).To(gomega.Equal(map[string]string{"./": "", "bert/": "", "bert/model.bin": content}))
+	// the retries resume before the parts which may have been in progress when the download failed
+	g.Expect(fake.ranges).To(gomega.Equal([]string{"", "bytes=30-", "bytes=45-"}))
+
+	// the downloaded objects are skipped
+	g.Expect(provider.DownloadModel(modelDir, "bert", "s3://bucket/models/bert/")).To(gomega.Succeed())
+	g.Expect(fake.downloaded).To(gomega.HaveLen(3))
+
+	// the failures which do not change on retries are not retried
+	fake = &fakeS3{
+		objects: map[string]string{"models/bert/model.bin": content},
+		failing: map[string]bool{"models/bert/model.bin": true},
+		failErr: awserr.NewRequestFailure(awserr.New("AccessDenied", "access denied", nil), 403, "request-id"),
+	}
+	provider = &S3Provider{Client: fake, Downloader: fake, Concurrency: 1, Retry: retry}
+	g.Expect(provider.DownloadModel(t.TempDir(), "bert", "s3://bucket/models/bert/")).NotTo(gomega.Succeed())
+	g.Expect(fake.downloaded).To(gomega.HaveLen(1))
+}
+
+// blockingDownloader starts the downloads together so that they all fail before any of them cancels the others
+type blockingDownloader struct {
+	*fakeS3
+	started sync.WaitGroup
+}
+
+func newBlockingDownloader(fake *fakeS3, downloads int) *blockingDownloader {
+	b := &blockingDownloader{fakeS3: fake}
+	b.started.Add(downloads)
+	return b
+}
+
+func (b *blockingDownloader) DownloadWithIterator(ctx aws.Context, iter s3manager.BatchDownloadIterator, opts ...func(*s3manager.Downloader)) error {
+	b.started.Done()
+	b.started.Wait()
+	// the downloads in progress fail regardless of the cancellation
+	return b.fakeS3.DownloadWithIterator(aws.BackgroundContext(), iter, opts...)
+}
+
+func TestGetS3DownloadConfig(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	concurrency, partSize, err := getS3DownloadConfig()
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(concurrency).To(gomega.Equal(DefaultS3DownloadConcurrency))
+	g.Expect(partSize).To(gomega.BeZero())
+
+	t.Setenv(S3DownloadConcurrencyEnvKey, "16")
+	t.Setenv(S3DownloadPartSizeEnvKey, "67108864")
+	concurrency, partSize, err = getS3DownloadConfig()
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(concurrency).To(gomega.Equal(16))
+	g.Expect(partSize).To(gomega.Equal(int64(67108864)))
+
+	t.Setenv(S3DownloadConcurrencyEnvKey, "0")
+	_, _, err = getS3DownloadConfig()
+	g.Expect(err).To(gomega.HaveOccurred())
+}
@@ -0,0 +1,176 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pkg/sftp"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+const (
+	// SFTPConnectTimeoutEnvKey is the env of the timeout of the connection to the sftp server
+	SFTPConnectTimeoutEnvKey  = "SFTP_CONNECT_TIMEOUT"
+	DefaultSFTPConnectTimeout = 30 * time.Second
+	// SFTPInsecureIgnoreHostKeyEnvKey is the env disabling the verification of the host keys of the sftp servers
+	// when set to true, they are otherwise verified against the known_hosts file of the storage secret
+	SFTPInsecureIgnoreHostKeyEnvKey = "SFTP_INSECURE_IGNORE_HOST_KEY"
+	DefaultSFTPPort                 = "22"
+)
+
+// ParseSFTPURI parses a storage uri in the sftp://[<user>@]<host>[:<port>]/<path> format, the path is absolute
+func ParseSFTPURI(storageUri string) (*url.URL, error) {
+	uri, err := url.Parse(storageUri)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse storage uri: %w", err)
+	}
+	if uri.Scheme+"://" != string(SFTP) || uri.Hostname() == "" || strings.Trim(uri.Path, "/") == "" {
+		return nil, fmt.Errorf("invalid sftp uri %s: must be in the %s[<user>@]<host>[:<port>]/<path> format",
+			storageUri, SFTP)
+	}
+	return uri, nil
+}
+
+type SFTPProvider struct {
+	// User is the user authenticated on the servers, the user of the storage uri takes precedence
+	User string
+	// Auth are the methods authenticating the user, a password and/or a private key
+	Auth []ssh.AuthMethod
+	// HostKeyCallback verifies the host keys of the servers
+	HostKeyCallback ssh.HostKeyCallback
+	// Timeout is the timeout of the connection to the servers, DefaultSFTPConnectTimeout when not set
+	Timeout time.Duration
+	// Retry configures the retries of the failed downloads
+	Retry RetryConfig
+}
+
+var _ Provider = (*SFTPProvider)(nil)
+
+func (m *SFTPProvider) DownloadModel(modelDir string, modelName string, storageUri string) error {
+	log.Info("Download model ", "modelName", modelName, "storageUri", storageUri, "modelDir", modelDir)
+	uri, err := ParseSFTPURI(storageUri)
+	if err != nil {
+		return err
+	}
+	return m.Retry.Do(context.Background(), storageUri, func() error {
+		return m.download(uri, filepath.Join(modelDir, modelName))
+	})
+}
+
+// download downloads the file or, recursively, the directory of the uri into targetDir, preserving the permissions
+// of the files
+func (m *SFTPProvider) download(uri *url.URL, targetDir string) error {
+	address := uri.Host
+	if uri.Port() == "" {
+		address = net.JoinHostPort(uri.Hostname(), DefaultSFTPPort)
+	}
+	conn, err := ssh.Dial("tcp", address, m.clientConfig(uri))
+	if err != nil {
+		var keyErr *knownhosts.KeyError
+		if errors.As(err, &keyErr) {
+			return permanent(fmt.Errorf("failed to verify the host key of %s: %w", address, err))
+		}
+		return fmt.Errorf("failed to connect to %s: %w", address, err)
+	}
+	defer conn.Close()
+	client, err := sftp.NewClient(conn)
+	if err != nil {
+		return fmt.Errorf("failed to start the sftp session with %s: %w", address, err)
+	}
+	defer client.Close()
+
+	root := path.Clean(uri.Path)
+	info, err := client.Stat(root)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return permanent(fmt.Errorf("%s not found on %s: %w", root, address, err))
+		}
+		return err
+	}
+	if !info.IsDir() {
+		return downloadSFTPFile(client, root, filepath.Join(targetDir, path.Base(root)), info.Mode())
+	}
+
+	files := 0
+	walker := client.Walk(root)
+	for walker.Step() {
+		if err := walker.Err(); err != nil {
+			return err
+		}
+		// the directories are created with the files, the symbolic links are not followed
+		if !walker.Stat().Mode().IsRegular() {
+			continue
+		}
+		relPath := strings.TrimPrefix(walker.Path(), root+"/")
+		if err := downloadSFTPFile(client, walker.Path(), filepath.Join(targetDir, relPath), walker.Stat().Mode()); err != nil {
+			return err
+		}
+		files++
+	}
+	if files == 0 {
+		return permanent(fmt.Errorf("no files found in %s on %s", root, address))
+	}
+	return nil
+}
+
+func (m *SFTPProvider) clientConfig(uri *url.URL) *ssh.ClientConfig {
+	config := &ssh.ClientConfig{
+		User:            m.User,
+		Auth:            m.Auth,
+		HostKeyCallback: m.HostKeyCallback,
+		Timeout:         m.Timeout,
+	}
+	if uri.User != nil && uri.User.Username() != "" {
+		config.User = uri.User.Username()
+	}
+	if config.Timeout == 0 {
+		config.Timeout = DefaultSFTPConnectTimeout
+	}
+	return config
+}
+
+// downloadSFTPFile downloads the remote file to fileName with the permissions of mode
+func downloadSFTPFile(client *sftp.Client, remotePath string, fileName string, mode os.FileMode) error {
+	source, err := client.Open(remotePath)
+	if err != nil {
+		return err
+	}
+	defer source.Close()
+	file, err := Create(fileName)
+	if err != nil {
+		return err
+	}
+	if _, err := source.WriteTo(file); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("failed to download %s: %w", remotePath, err)
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	return os.Chmod(fileName, mode.Perm())
+}
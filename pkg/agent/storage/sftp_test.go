@@ -0,0 +1,233 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	sftpcredential "github.com/kserve/kserve/pkg/credentials/sftp"
+	"github.com/onsi/gomega"
+	"github.com/pkg/sftp"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+// startSFTPServer starts an in-process sftp server of the local filesystem accepting the models user with either
+// the password or the client key, it returns the address and the host key of the server
+func startSFTPServer(g *gomega.WithT, t *testing.T, clientKey ssh.PublicKey) (string, ssh.PublicKey) {
+	_, hostPrivateKey, err := ed25519.GenerateKey(rand.Reader)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	hostSigner, err := ssh.NewSignerFromKey(hostPrivateKey)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+
+	config := &ssh.ServerConfig{
+		PasswordCallback: func(conn ssh.ConnMetadata, password []byte) (*ssh.Permissions, error) {
+			if conn.User() == "models" && string(password) == "password" {
+				return nil, nil
+			}
+			return nil, os.ErrPermission
+		},
+		PublicKeyCallback: func(conn ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+			if conn.User() == "models" && clientKey != nil && string(key.Marshal()) == string(clientKey.Marshal()) {
+				return nil, nil
+			}
+			return nil, os.ErrPermission
+		},
+	}
+	config.AddHostKey(hostSigner)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	t.Cleanup(func() { _ = listener.Close() })
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go serveSFTP(conn, config)
+		}
+	}()
+	return listener.Addr().String(), hostSigner.PublicKey()
+}
+
+func serveSFTP(conn net.Conn, config *ssh.ServerConfig) {
+	_, channels, requests, err := ssh.NewServerConn(conn, config)
+	if err != nil {
+		return
+	}
+	go ssh.DiscardRequests(requests)
+	for newChannel := range channels {
+		if newChannel.ChannelType() != "session" {
+			_ = newChannel.Reject(ssh.UnknownChannelType, "unknown channel type")
+			continue
+		}
+		channel, channelRequests, err := newChannel.Accept()
+		if err != nil {
+			return
+		}
+		go func() {
+			for req := range channelRequests {
+				// the payload of the subsystem request is the length prefixed name of the subsystem
+				_ = req.Reply(req.Type == "subsystem" && string(req.Payload[4:]) == "sftp", nil)
+			}
+		}()
+		server, err := sftp.NewServer(channel)
+		if err != nil {
+			return
+		}
+		_ = server.Serve()
+		_ = server.Close()
+	}
+}
+
+func writeKnownHosts(g *gomega.WithT, t *testing.T, address string, hostKey ssh.PublicKey) string {
+	knownHostsFile := filepath.Join(t.TempDir(), "known_hosts")
+	line := knownhosts.Line([]string{knownhosts.Normalize(address)}, hostKey)
+	g.Expect(os.WriteFile(knownHostsFile, []byte(line+"\n"), 0600)).To(gomega.Succeed())
+	return knownHostsFile
+}
+
+func TestSFTPProviderDownloadModel(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	address, hostKey := startSFTPServer(g, t, nil)
+
+	sourceDir := filepath.Join(t.TempDir(), "sklearn")
+	writeModelFiles(g, sourceDir, map[string]string{
+		"model.joblib":         "weights",
+		"preprocess/config.ym": "steps: []",
+	})
+	g.Expect(os.WriteFile(filepath.Join(sourceDir, "preprocess", "run.sh"), []byte("#!/bin/sh"), 0755)).To(gomega.Succeed())
+
+	t.Setenv(sftpcredential.SFTPUsername, "models")
+	t.Setenv(sftpcredential.SFTPPassword, "password")
+	t.Setenv(sftpcredential.SFTPKnownHostsFile, writeKnownHosts(g, t, address, hostKey))
+	provider, err := GetProvider(map[Protocol]Provider{}, SFTP)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+
+	// the directory is downloaded recursively with the permissions of the files
+	modelDir := t.TempDir()
+	g.Expect(provider.DownloadModel(modelDir, "model1", "sftp://"+address+sourceDir)).To(gomega.Succeed())
+	content, err := os.ReadFile(filepath.Join(modelDir, "model1", "preprocess", "config.ym"))
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(string(content)).To(gomega.Equal("steps: []"))
+	info, err := os.Stat(filepath.Join(modelDir, "model1", "preprocess", "run.sh"))
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(info.Mode().Perm()).To(gomega.Equal(os.FileMode(0755)))
+
+	// a single file is downloaded in the model directory
+	g.Expect(provider.DownloadModel(modelDir, "model2", "sftp://"+address+sourceDir+"/model.joblib")).To(gomega.Succeed())
+	g.Expect(FileExists(filepath.Join(modelDir, "model2", "model.joblib"))).To(gomega.BeTrue())
+
+	// the missing paths are not retried
+	err = provider.DownloadModel(modelDir, "model3", "sftp://"+address+sourceDir+"/missing")
+	g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("not found")))
+	var permanentErr *permanentError
+	g.Expect(err).To(gomega.BeAssignableToTypeOf(permanentErr))
+}
+
+func TestSFTPProviderPrivateKey(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	_, clientPrivateKey, err := ed25519.GenerateKey(rand.Reader)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	clientSigner, err := ssh.NewSignerFromKey(clientPrivateKey)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	address, hostKey := startSFTPServer(g, t, clientSigner.PublicKey())
+
+	block, err := ssh.MarshalPrivateKey(clientPrivateKey, "")
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	keyFile := filepath.Join(t.TempDir(), "ssh-privatekey")
+	g.Expect(os.WriteFile(keyFile, pem.EncodeToMemory(block), 0600)).To(gomega.Succeed())
+
+	sourceDir := filepath.Join(t.TempDir(), "model")
+	writeModelFiles(g, sourceDir, map[string]string{"model.onnx": "weights"})
+
+	t.Setenv(sftpcredential.SFTPPrivateKeyFile, keyFile)
+	t.Setenv(sftpcredential.SFTPKnownHostsFile, writeKnownHosts(g, t, address, hostKey))
+	provider, err := getSFTPProvider()
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+
+	// the user of the uri is authenticated with the private key
+	modelDir := t.TempDir()
+	g.Expect(provider.DownloadModel(modelDir, "model1", "sftp://models@"+address+sourceDir)).To(gomega.Succeed())
+	g.Expect(FileExists(filepath.Join(modelDir, "model1", "model.onnx"))).To(gomega.BeTrue())
+
+	// an other user is denied
+	g.Expect(provider.DownloadModel(modelDir, "model2", "sftp://other@"+address+sourceDir)).NotTo(gomega.Succeed())
+}
+
+func TestSFTPProviderHostKeyVerification(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	address, _ := startSFTPServer(g, t, nil)
+	_, otherAddressHostKey := startSFTPServer(g, t, nil)
+	sourceDir := filepath.Join(t.TempDir(), "model")
+	writeModelFiles(g, sourceDir, map[string]string{"model.onnx": "weights"})
+	uri := "sftp://" + address + sourceDir
+
+	t.Setenv(sftpcredential.SFTPUsername, "models")
+	t.Setenv(sftpcredential.SFTPPassword, "password")
+
+	// the host key is not verified without known_hosts
+	provider, err := getSFTPProvider()
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(provider.DownloadModel(t.TempDir(), "model", uri)).To(gomega.MatchError(gomega.ContainSubstring("cannot verify the host key")))
+
+	// the host key does not match the known_hosts
+	t.Setenv(sftpcredential.SFTPKnownHostsFile, writeKnownHosts(g, t, address, otherAddressHostKey))
+	provider, err = getSFTPProvider()
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(provider.DownloadModel(t.TempDir(), "model", uri)).To(gomega.MatchError(gomega.ContainSubstring("failed to verify the host key")))
+
+	// the verification is disabled
+	t.Setenv(sftpcredential.SFTPKnownHostsFile, "")
+	t.Setenv(SFTPInsecureIgnoreHostKeyEnvKey, "true")
+	provider, err = getSFTPProvider()
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(provider.DownloadModel(t.TempDir(), "model", uri)).To(gomega.Succeed())
+}
+
+func TestSFTPProviderConfig(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	t.Setenv(SFTPConnectTimeoutEnvKey, "5s")
+	provider, err := getSFTPProvider()
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(provider.Timeout.Seconds()).To(gomega.Equal(5.0))
+
+	t.Setenv(SFTPConnectTimeoutEnvKey, "-1s")
+	_, err = getSFTPProvider()
+	g.Expect(err).To(gomega.HaveOccurred())
+}
+
+func TestParseSFTPURI(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	uri, err := ParseSFTPURI("sftp://models@sftp.example.com:2222/srv/models/sklearn")
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(uri.User.Username()).To(gomega.Equal("models"))
+	g.Expect(uri.Port()).To(gomega.Equal("2222"))
+	g.Expect(uri.Path).To(gomega.Equal("/srv/models/sklearn"))
+
+	for _, invalid := range []string{"sftp://sftp.example.com", "sftp:///srv/models", "s3://bucket/models"} {
+		_, err = ParseSFTPURI(invalid)
+		g.Expect(err).To(gomega.HaveOccurred(), invalid)
+	}
+}
@@ -0,0 +1,146 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	gstorage "cloud.google.com/go/storage"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"google.golang.org/api/iterator"
+)
+
+// Sizer is implemented by the providers which can look up the size of a model before downloading it
+type Sizer interface {
+	// ModelSize returns the total size in bytes of the files of the model, or -1 when it is unknown
+	ModelSize(storageUri string) (int64, error)
+}
+
+var (
+	_ Sizer = (*S3Provider)(nil)
+	_ Sizer = (*GCSProvider)(nil)
+	_ Sizer = (*HTTPSProvider)(nil)
+)
+
+// ModelSize sums the sizes of the objects under the prefix of the storage uri
+func (m *S3Provider) ModelSize(storageUri string) (int64, error) {
+	s3Uri := strings.TrimPrefix(storageUri, string(S3))
+	tokens := strings.SplitN(s3Uri, "/", 2)
+	prefix := ""
+	if len(tokens) == 2 {
+		prefix = tokens[1]
+	}
+	s3ObjectDownloader := &S3ObjectDownloader{
+		StorageUri: storageUri,
+		Bucket:     tokens[0],
+		Prefix:     prefix,
+	}
+	return s3ObjectDownloader.size(m.Client)
+}
+
+// ModelSize sums the sizes of the objects under the prefix of the storage uri, matching its glob if any
+func (p *GCSProvider) ModelSize(storageUri string) (int64, error) {
+	gcsUri := strings.TrimPrefix(storageUri, string(GCS))
+	tokens := strings.SplitN(gcsUri, "/", 2)
+	prefix := ""
+	if len(tokens) == 2 {
+		prefix = tokens[1]
+	}
+	prefix, pattern := splitObjectGlob(prefix)
+	if p.HMAC != nil {
+		s3ObjectDownloader := &S3ObjectDownloader{
+			StorageUri: storageUri,
+			Bucket:     tokens[0],
+			Prefix:     prefix,
+			Pattern:    pattern,
+		}
+		return s3ObjectDownloader.size(p.HMAC.Client)
+	}
+	it := p.Client.Bucket(tokens[0]).Objects(context.Background(), &gstorage.Query{Prefix: prefix})
+	var size int64
+	foundObject := false
+	for {
+		attrs, err := it.Next()
+		if errors.Is(err, iterator.Done) {
+			break
+		}
+		if err != nil {
+			return 0, fmt.Errorf("an error occurred while iterating: %w", err)
+		}
+		if pattern != "" && !matchesAnyGlob([]string{pattern}, strings.TrimPrefix(attrs.Name, prefix)) {
+			continue
+		}
+		foundObject = true
+		size += attrs.Size
+	}
+	if !foundObject {
+		return 0, gstorage.ErrObjectNotExist
+	}
+	return size, nil
+}
+
+// ModelSize returns the content length of a HEAD request of the storage uri. The size of an archive is the size of
+// the archive itself, the extracted files may need more space.
+func (m *HTTPSProvider) ModelSize(storageUri string) (int64, error) {
+	uri, err := url.Parse(storageUri)
+	if err != nil {
+		return 0, fmt.Errorf("unable to parse storage uri: %w", err)
+	}
+	req, err := http.NewRequest(http.MethodHead, storageUri, nil)
+	if err != nil {
+		return 0, err
+	}
+	headers, err := (&HTTPSDownloader{Uri: uri}).extractHeaders()
+	if err != nil {
+		return 0, err
+	}
+	for key, element := range headers {
+		req.Header.Add(key, element)
+	}
+	client := *m.Client
+	client.CheckRedirect = checkRedirect(m.MaxRedirects, headers)
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to make a request: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		// servers which do not support HEAD requests do not prevent the download
+		return -1, nil
+	}
+	return resp.ContentLength, nil
+}
+
+// size sums the sizes of the objects listed by GetAllObjects
+func (s *S3ObjectDownloader) size(s3Svc s3iface.S3API) (int64, error) {
+	objects, err := s.GetAllObjects(s3Svc)
+	if err != nil {
+		return 0, err
+	}
+	var size int64
+	for _, object := range objects {
+		if object.Size != nil {
+			size += *object.Size
+		}
+	}
+	return size, nil
+}
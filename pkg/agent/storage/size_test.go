@@ -0,0 +1,58 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/onsi/gomega"
+)
+
+func TestS3ProviderModelSize(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	client := &fakeS3{objects: map[string]string{
+		"models/bert/config.json":       "{}",
+		"models/bert/model.safetensors": "0123456789",
+		"models/gpt/model.safetensors":  "01234",
+	}}
+	provider := &S3Provider{Client: client}
+
+	g.Expect(provider.ModelSize("s3://bucket/models/bert")).To(gomega.Equal(int64(12)))
+	g.Expect(provider.ModelSize("s3://bucket/models")).To(gomega.Equal(int64(17)))
+	_, err := provider.ModelSize("s3://bucket/models/t5")
+	g.Expect(err).To(gomega.HaveOccurred())
+}
+
+func TestHTTPSProviderModelSize(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/models/model.bin" {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		g.Expect(r.Method).To(gomega.Equal(http.MethodHead))
+		w.Header().Set("Content-Length", "1024")
+	}))
+	defer ts.Close()
+	provider := &HTTPSProvider{Client: ts.Client()}
+
+	g.Expect(provider.ModelSize(ts.URL + "/models/model.bin")).To(gomega.Equal(int64(1024)))
+	// the size is unknown when the server does not support HEAD requests
+	g.Expect(provider.ModelSize(ts.URL + "/models/other.bin")).To(gomega.Equal(int64(-1)))
+}
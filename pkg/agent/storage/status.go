@@ -0,0 +1,75 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"encoding/json"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// DownloadStatusFileName is the name of the file written at the root of the model with the statistics of its download.
+// It is hidden so that it is not picked up as a model file by the model servers.
+const DownloadStatusFileName = ".kserve-download-status.json"
+
+// DownloadStatus is the content of the download status file, it has the same format as the one written by the
+// storage initializer
+type DownloadStatus struct {
+	// Bytes is the total size of the downloaded files
+	Bytes int64 `json:"bytes"`
+	// Files is the number of downloaded files
+	Files int `json:"files"`
+	// DurationSeconds is the wall-clock duration of the download
+	DurationSeconds float64 `json:"durationSeconds"`
+}
+
+// WriteDownloadStatus counts the files and bytes downloaded in modelPath and writes them along with the duration of
+// the download to the download status file of the model.
+func WriteDownloadStatus(modelPath string, duration time.Duration) (*DownloadStatus, error) {
+	status := &DownloadStatus{
+		DurationSeconds: duration.Seconds(),
+	}
+	if err := os.MkdirAll(modelPath, os.ModePerm); err != nil {
+		return nil, err
+	}
+	err := filepath.WalkDir(modelPath, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || !entry.Type().IsRegular() || entry.Name() == DownloadStatusFileName {
+			return err
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		status.Files++
+		status.Bytes += info.Size()
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := json.Marshal(status)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(filepath.Join(modelPath, DownloadStatusFileName), content, 0644); err != nil { //#nosec
+		return nil, err
+	}
+	return status, nil
+}
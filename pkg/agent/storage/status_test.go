@@ -0,0 +1,61 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+)
+
+func TestWriteDownloadStatus(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	modelPath := filepath.Join(t.TempDir(), "model")
+	writeModelFiles(g, modelPath, map[string]string{
+		"config.json":              `{"model_type": "bert"}`,
+		"shards/model.safetensors": "weights",
+	})
+
+	status, err := WriteDownloadStatus(modelPath, 1500*time.Millisecond)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(*status).To(gomega.Equal(DownloadStatus{Bytes: 29, Files: 2, DurationSeconds: 1.5}))
+
+	content, err := os.ReadFile(filepath.Join(modelPath, DownloadStatusFileName))
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	written := DownloadStatus{}
+	g.Expect(json.Unmarshal(content, &written)).To(gomega.Succeed())
+	g.Expect(written).To(gomega.Equal(*status))
+
+	// the status file of a previous download is not counted
+	status, err = WriteDownloadStatus(modelPath, time.Second)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(*status).To(gomega.Equal(DownloadStatus{Bytes: 29, Files: 2, DurationSeconds: 1}))
+}
+
+func TestWriteDownloadStatusEmptyModel(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	modelPath := filepath.Join(t.TempDir(), "model")
+
+	status, err := WriteDownloadStatus(modelPath, time.Second)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(*status).To(gomega.Equal(DownloadStatus{DurationSeconds: 1}))
+	g.Expect(FileExists(filepath.Join(modelPath, DownloadStatusFileName))).To(gomega.BeTrue())
+}
@@ -19,21 +19,31 @@ package storage
 import (
 	"context"
 	"crypto/sha256"
+	"crypto/tls"
 	"fmt"
+	"net"
 	"net/http"
 	"os"
+	"path"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"time"
 
 	gstorage "cloud.google.com/go/storage"
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/aws/aws-sdk-go/service/s3/s3manager"
 	"github.com/googleapis/google-cloud-go-testing/storage/stiface"
 	gcscredential "github.com/kserve/kserve/pkg/credentials/gcs"
+	hfcredential "github.com/kserve/kserve/pkg/credentials/hf"
 	s3credential "github.com/kserve/kserve/pkg/credentials/s3"
+	sftpcredential "github.com/kserve/kserve/pkg/credentials/sftp"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
 	"google.golang.org/api/option"
 )
 
@@ -107,8 +117,26 @@ func GetProvider(providers map[Protocol]Provider, protocol Protocol) (Provider,
 		return provider, nil
 	}
 
+	retry, err := getRetryConfig()
+	if err != nil {
+		return nil, err
+	}
+
 	switch protocol {
 	case GCS:
+		accessKeyId, secret := os.Getenv(gcscredential.GCSHMACAccessKeyIdEnvKey), os.Getenv(gcscredential.GCSHMACSecretEnvKey)
+		if accessKeyId != "" && secret != "" {
+			hmacProvider, err := newGCSHMACProvider(accessKeyId, secret)
+			if err != nil {
+				return nil, err
+			}
+			providers[GCS] = &GCSProvider{
+				HMAC:  hmacProvider,
+				Retry: retry,
+			}
+			break
+		}
+
 		var gcsClient *gstorage.Client
 		var err error
 
@@ -116,9 +144,15 @@ func GetProvider(providers map[Protocol]Provider, protocol Protocol) (Provider,
 		if _, ok := os.LookupEnv(gcscredential.GCSCredentialEnvKey); ok {
 			// GCS relies on environment variable GOOGLE_APPLICATION_CREDENTIALS to point to the service-account-key
 			// If set, it will be automatically be picked up by the client.
-			gcsClient, err = gstorage.NewClient(ctx)
+			gcsClient, err = newGCSClient(ctx)
 		} else {
-			gcsClient, err = gstorage.NewClient(ctx, option.WithoutAuthentication())
+			// Without key, the Application Default Credentials are used when found, e.g. the workload identity of GKE,
+			// otherwise the public buckets are accessed anonymously
+			gcsClient, err = newGCSClient(ctx)
+			if err != nil {
+				log.Info("Application Default Credentials not found, accessing gcs anonymously", "error", err.Error())
+				gcsClient, err = newGCSClient(ctx, option.WithoutAuthentication())
+			}
 		}
 
 		if err != nil {
@@ -127,59 +161,264 @@ func GetProvider(providers map[Protocol]Provider, protocol Protocol) (Provider,
 
 		providers[GCS] = &GCSProvider{
 			Client: stiface.AdaptClient(gcsClient),
+			Retry:  retry,
 		}
 	case S3:
-		var sess *session.Session
-		var err error
-
-		region, _ := os.LookupEnv(s3credential.AWSRegion)
-		useVirtualBucketString, ok := os.LookupEnv(s3credential.S3UseVirtualBucket)
-		useVirtualBucket := true
-		if ok && strings.ToLower(useVirtualBucketString) == "false" {
-			useVirtualBucket = false
-		}
-		useAccelerateString, ok := os.LookupEnv(s3credential.S3UseAccelerate)
-		useAccelerate := false
-		if ok && strings.ToLower(useAccelerateString) == "true" {
-			useAccelerate = true
-		}
-
-		awsConfig := aws.Config{
-			Region:           aws.String(region),
-			S3ForcePathStyle: aws.Bool(!useVirtualBucket),
-			S3UseAccelerate:  aws.Bool(useAccelerate),
-		}
-
-		if endpoint, ok := os.LookupEnv(s3credential.AWSEndpointUrl); ok {
-			awsConfig.Endpoint = aws.String(endpoint)
-		}
-
-		if useAnonCred, ok := os.LookupEnv(s3credential.AWSAnonymousCredential); ok && strings.ToLower(useAnonCred) == "true" {
-			awsConfig.Credentials = credentials.AnonymousCredentials
+		sess, err := newS3Session()
+		if err != nil {
+			return nil, err
 		}
 
-		sess, err = session.NewSession(&awsConfig)
-
+		concurrency, partSize, err := getS3DownloadConfig()
 		if err != nil {
 			return nil, err
 		}
 
 		sessionClient := s3.New(sess)
 		providers[S3] = &S3Provider{
-			Client:     sessionClient,
-			Downloader: s3manager.NewDownloaderWithClient(sessionClient, func(d *s3manager.Downloader) {}),
+			Client:      sessionClient,
+			Downloader:  s3manager.NewDownloaderWithClient(sessionClient, func(d *s3manager.Downloader) {}),
+			Concurrency: concurrency,
+			PartSize:    partSize,
+			Retry:       retry,
 		}
 	case HTTPS:
-		httpsClient := &http.Client{}
+		maxRedirects, err := getHTTPSMaxRedirects()
+		if err != nil {
+			return nil, err
+		}
+		httpsClient := newHTTPClient()
 		providers[HTTPS] = &HTTPSProvider{
-			Client: httpsClient,
+			Client:       httpsClient,
+			Retry:        retry,
+			MaxRedirects: maxRedirects,
 		}
 	case HTTP:
-		httpsClient := &http.Client{}
+		maxRedirects, err := getHTTPSMaxRedirects()
+		if err != nil {
+			return nil, err
+		}
+		httpsClient := newHTTPClient()
 		providers[HTTP] = &HTTPSProvider{
-			Client: httpsClient,
+			Client:       httpsClient,
+			Retry:        retry,
+			MaxRedirects: maxRedirects,
+		}
+	case HF:
+		hfProvider, err := getHFProvider()
+		if err != nil {
+			return nil, err
+		}
+		hfProvider.Retry = retry
+		providers[HF] = hfProvider
+	case SFTP:
+		sftpProvider, err := getSFTPProvider()
+		if err != nil {
+			return nil, err
+		}
+		sftpProvider.Retry = retry
+		providers[SFTP] = sftpProvider
+	case OCI:
+		providers[OCI] = &OCIProvider{
+			Client: newHTTPClient(),
 		}
 	}
 
 	return providers[protocol], nil
 }
+
+// newS3Session returns the session of the s3 provider configured by the envs set by the s3 credential builder
+func newS3Session() (*session.Session, error) {
+	region, _ := os.LookupEnv(s3credential.AWSRegion)
+	useVirtualBucketString, ok := os.LookupEnv(s3credential.S3UseVirtualBucket)
+	useVirtualBucket := true
+	if ok && strings.ToLower(useVirtualBucketString) == "false" {
+		useVirtualBucket = false
+	}
+	// S3_FORCE_PATH_STYLE takes precedence over S3_USER_VIRTUAL_BUCKET
+	forcePathStyle := !useVirtualBucket
+	if forcePathStyleString, ok := os.LookupEnv(s3credential.S3ForcePathStyle); ok && forcePathStyleString != "" {
+		forcePathStyle = strings.ToLower(forcePathStyleString) == "true"
+	}
+	useAccelerateString, ok := os.LookupEnv(s3credential.S3UseAccelerate)
+	useAccelerate := false
+	if ok && strings.ToLower(useAccelerateString) == "true" {
+		useAccelerate = true
+	}
+
+	awsConfig := aws.Config{
+		Region:           aws.String(region),
+		S3ForcePathStyle: aws.Bool(forcePathStyle),
+		S3UseAccelerate:  aws.Bool(useAccelerate),
+	}
+
+	if endpoint, ok := os.LookupEnv(s3credential.AWSEndpointUrl); ok {
+		awsConfig.Endpoint = aws.String(endpoint)
+	}
+
+	// Only used for the endpoints without a scheme, AWS_ENDPOINT_URL already has the scheme matching S3_USE_HTTPS
+	if useHttps, ok := os.LookupEnv(s3credential.S3UseHttps); ok && useHttps == "0" {
+		awsConfig.DisableSSL = aws.Bool(true)
+	}
+
+	transport := newHTTPTransport()
+	if verifySSL, ok := os.LookupEnv(s3credential.S3VerifySSL); ok && (verifySSL == "0" || strings.ToLower(verifySSL) == "false") {
+		transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true} // #nosec G402
+	}
+	awsConfig.HTTPClient = &http.Client{
+		Transport: transport,
+	}
+
+	if useAnonCred, ok := os.LookupEnv(s3credential.AWSAnonymousCredential); ok && strings.ToLower(useAnonCred) == "true" {
+		awsConfig.Credentials = credentials.AnonymousCredentials
+	}
+
+	// The custom CA bundle of AWS_CA_BUNDLE is loaded by the session
+	sess, err := session.NewSession(&awsConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	// The role is assumed with the credentials of the session, the STS requests are sent to AWS_ENDPOINT_URL when set
+	if roleArn, ok := os.LookupEnv(s3credential.AWSAssumeRoleArn); ok && roleArn != "" {
+		sess = sess.Copy(&aws.Config{Credentials: stscreds.NewCredentials(sess, roleArn)})
+	}
+	return sess, nil
+}
+
+// newGCSHMACProvider returns the s3 provider of the XML API of GCS authenticated with the HMAC keys
+func newGCSHMACProvider(accessKeyId string, secret string) (*S3Provider, error) {
+	sess, err := session.NewSession(&aws.Config{
+		Endpoint:         aws.String(GCSXMLAPIEndpoint),
+		Region:           aws.String("auto"),
+		S3ForcePathStyle: aws.Bool(true),
+		Credentials:      credentials.NewStaticCredentials(accessKeyId, secret, ""),
+		HTTPClient:       newHTTPClient(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	concurrency, partSize, err := getS3DownloadConfig()
+	if err != nil {
+		return nil, err
+	}
+	client := s3.New(sess)
+	return &S3Provider{
+		Client:      client,
+		Downloader:  s3manager.NewDownloaderWithClient(client, func(d *s3manager.Downloader) {}),
+		Concurrency: concurrency,
+		PartSize:    partSize,
+	}, nil
+}
+
+// getS3DownloadConfig returns the number of objects downloaded in parallel and the part size of the s3 downloads
+// set by the S3_DOWNLOAD_CONCURRENCY and S3_DOWNLOAD_PART_SIZE envs.
+func getS3DownloadConfig() (int, int64, error) {
+	concurrency := DefaultS3DownloadConcurrency
+	if value, ok := os.LookupEnv(S3DownloadConcurrencyEnvKey); ok {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 1 {
+			return 0, 0, fmt.Errorf("invalid %s %q, must be a positive integer", S3DownloadConcurrencyEnvKey, value)
+		}
+		concurrency = parsed
+	}
+	var partSize int64
+	if value, ok := os.LookupEnv(S3DownloadPartSizeEnvKey); ok {
+		parsed, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || parsed < 1 {
+			return 0, 0, fmt.Errorf("invalid %s %q, must be a positive number of bytes", S3DownloadPartSizeEnvKey, value)
+		}
+		partSize = parsed
+	}
+	return concurrency, partSize, nil
+}
+
+// getHFProvider returns the Hugging Face Hub provider configured by the HF_ENDPOINT, HF_TOKEN, HF_DOWNLOAD_CONCURRENCY,
+// HF_ALLOW_PATTERNS, HF_IGNORE_PATTERNS and HF_PREFER_SAFETENSORS envs
+func getHFProvider() (*HFProvider, error) {
+	provider := &HFProvider{
+		Client:            newHTTPClient(),
+		Endpoint:          os.Getenv(HFEndpointEnvKey),
+		Token:             os.Getenv(hfcredential.HFTokenKey),
+		Concurrency:       DefaultHFDownloadConcurrency,
+		PreferSafetensors: true,
+	}
+	if value, ok := os.LookupEnv(HFDownloadConcurrencyEnvKey); ok {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 1 {
+			return nil, fmt.Errorf("invalid %s %q, must be a positive integer", HFDownloadConcurrencyEnvKey, value)
+		}
+		provider.Concurrency = parsed
+	}
+	if value, ok := os.LookupEnv(HFPreferSafetensorsEnvKey); ok {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s %q, must be a boolean", HFPreferSafetensorsEnvKey, value)
+		}
+		provider.PreferSafetensors = parsed
+	}
+	for key, patterns := range map[string]*[]string{
+		HFAllowPatternsEnvKey:  &provider.AllowPatterns,
+		HFIgnorePatternsEnvKey: &provider.IgnorePatterns,
+	} {
+		for _, pattern := range strings.Split(os.Getenv(key), ",") {
+			if pattern = strings.TrimSpace(pattern); pattern == "" {
+				continue
+			}
+			if _, err := path.Match(pattern, ""); err != nil {
+				return nil, fmt.Errorf("invalid %s pattern %q: %w", key, pattern, err)
+			}
+			*patterns = append(*patterns, pattern)
+		}
+	}
+	return provider, nil
+}
+
+// getSFTPProvider returns the sftp provider authenticated by the SFTP_USERNAME, SFTP_PASSWORD and SFTP_PRIVATE_KEY_FILE
+// envs set by the sftp credential builder. The host keys are verified against the SFTP_KNOWN_HOSTS_FILE unless
+// SFTP_INSECURE_IGNORE_HOST_KEY is true and the connection times out after SFTP_CONNECT_TIMEOUT.
+func getSFTPProvider() (*SFTPProvider, error) {
+	provider := &SFTPProvider{
+		User:    os.Getenv(sftpcredential.SFTPUsername),
+		Timeout: DefaultSFTPConnectTimeout,
+	}
+	if keyFile, ok := os.LookupEnv(sftpcredential.SFTPPrivateKeyFile); ok && keyFile != "" {
+		key, err := os.ReadFile(keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the sftp private key: %w", err)
+		}
+		signer, err := ssh.ParsePrivateKey(key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse the sftp private key: %w", err)
+		}
+		provider.Auth = append(provider.Auth, ssh.PublicKeys(signer))
+	}
+	if password, ok := os.LookupEnv(sftpcredential.SFTPPassword); ok && password != "" {
+		provider.Auth = append(provider.Auth, ssh.Password(password))
+	}
+
+	insecure, _ := strconv.ParseBool(os.Getenv(SFTPInsecureIgnoreHostKeyEnvKey))
+	if knownHostsFile, ok := os.LookupEnv(sftpcredential.SFTPKnownHostsFile); ok && knownHostsFile != "" {
+		callback, err := knownhosts.New(knownHostsFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the sftp known_hosts: %w", err)
+		}
+		provider.HostKeyCallback = callback
+	} else if insecure {
+		provider.HostKeyCallback = ssh.InsecureIgnoreHostKey() // #nosec G106
+	} else {
+		provider.HostKeyCallback = func(hostname string, remote net.Addr, key ssh.PublicKey) error {
+			return fmt.Errorf("cannot verify the host key of %s: the storage secret has no %s and %s is not true",
+				hostname, sftpcredential.SFTPKnownHostsName, SFTPInsecureIgnoreHostKeyEnvKey)
+		}
+	}
+
+	if value, ok := os.LookupEnv(SFTPConnectTimeoutEnvKey); ok {
+		timeout, err := time.ParseDuration(value)
+		if err != nil || timeout <= 0 {
+			return nil, fmt.Errorf("invalid %s %q, must be a positive duration", SFTPConnectTimeoutEnvKey, value)
+		}
+		provider.Timeout = timeout
+	}
+	return provider, nil
+}
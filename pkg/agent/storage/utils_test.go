@@ -17,13 +17,19 @@ limitations under the License.
 package storage
 
 import (
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path"
 	"path/filepath"
 	"syscall"
 	"testing"
 
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/kserve/kserve/pkg/agent/mocks"
+	gcscredential "github.com/kserve/kserve/pkg/credentials/gcs"
+	s3credential "github.com/kserve/kserve/pkg/credentials/s3"
 	"github.com/onsi/gomega"
 )
 
@@ -108,4 +114,62 @@ func TestGetProvider(t *testing.T) {
 		g.Expect(err).To(gomega.BeNil())
 		g.Expect(provider).ShouldNot(gomega.BeNil())
 	}
+
+	// The GCS provider uses the XML API when the HMAC keys are set
+	t.Setenv(gcscredential.GCSHMACAccessKeyIdEnvKey, "GOOG1EXAMPLE")
+	t.Setenv(gcscredential.GCSHMACSecretEnvKey, "secret")
+	provider, err = GetProvider(map[Protocol]Provider{}, GCS)
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(provider.(*GCSProvider).Client).To(gomega.BeNil())
+	g.Expect(provider.(*GCSProvider).HMAC).NotTo(gomega.BeNil())
+	g.Expect(aws.StringValue(provider.(*GCSProvider).HMAC.Client.(*s3.S3).Config.Endpoint)).To(gomega.Equal(GCSXMLAPIEndpoint))
+}
+
+func TestNewS3Session(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	t.Setenv("AWS_ACCESS_KEY_ID", "base-key")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "base-secret")
+	t.Setenv(s3credential.AWSRegion, "us-east-1")
+
+	sess, err := newS3Session()
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(aws.BoolValue(sess.Config.S3ForcePathStyle)).To(gomega.BeFalse())
+	g.Expect(aws.BoolValue(sess.Config.DisableSSL)).To(gomega.BeFalse())
+
+	// S3_FORCE_PATH_STYLE takes precedence over S3_USER_VIRTUAL_BUCKET
+	t.Setenv(s3credential.S3UseVirtualBucket, "false")
+	sess, err = newS3Session()
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(aws.BoolValue(sess.Config.S3ForcePathStyle)).To(gomega.BeTrue())
+	t.Setenv(s3credential.S3ForcePathStyle, "false")
+	sess, err = newS3Session()
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(aws.BoolValue(sess.Config.S3ForcePathStyle)).To(gomega.BeFalse())
+
+	t.Setenv(s3credential.S3UseHttps, "0")
+	t.Setenv(s3credential.S3VerifySSL, "false")
+	sess, err = newS3Session()
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(aws.BoolValue(sess.Config.DisableSSL)).To(gomega.BeTrue())
+	g.Expect(sess.Config.HTTPClient.Transport.(*http.Transport).TLSClientConfig.InsecureSkipVerify).To(gomega.BeTrue())
+
+	// the role is assumed through the STS API of the endpoint
+	sts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		g.Expect(r.ParseForm()).To(gomega.Succeed())
+		g.Expect(r.Form.Get("Action")).To(gomega.Equal("AssumeRole"))
+		g.Expect(r.Form.Get("RoleArn")).To(gomega.Equal("arn:aws:iam::123456789012:role/models"))
+		_, _ = w.Write([]byte(`<AssumeRoleResponse xmlns="https://sts.amazonaws.com/doc/2011-06-15/"><AssumeRoleResult>` +
+			`<Credentials><AccessKeyId>assumed-key</AccessKeyId><SecretAccessKey>assumed-secret</SecretAccessKey>` +
+			`<SessionToken>token</SessionToken><Expiration>2100-01-01T00:00:00Z</Expiration></Credentials>` +
+			`</AssumeRoleResult></AssumeRoleResponse>`))
+	}))
+	defer sts.Close()
+	t.Setenv(s3credential.AWSEndpointUrl, sts.URL)
+	t.Setenv(s3credential.AWSAssumeRoleArn, "arn:aws:iam::123456789012:role/models")
+	sess, err = newS3Session()
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	value, err := sess.Config.Credentials.Get()
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(value.AccessKeyID).To(gomega.Equal("assumed-key"))
+	g.Expect(value.SessionToken).To(gomega.Equal("token"))
 }
@@ -69,6 +69,98 @@ type InferenceGraphSpec struct {
 	// Knative Pod Autoscaler(https://knative.dev/docs/serving/autoscaling/autoscaling-metrics).
 	// +optional
 	ScaleMetric *ScaleMetric `json:"scaleMetric,omitempty"`
+	// Cache configures the backend of the step caches, the cached responses are kept in the memory
+	// of the router when not specified.
+	// +optional
+	Cache *InferenceGraphCache `json:"cache,omitempty"`
+	// PropagateHeaders are regular expressions matching the names of the request headers forwarded to the steps,
+	// in addition to the headers propagated by the router configuration. The other headers are dropped.
+	// +optional
+	PropagateHeaders []string `json:"propagateHeaders,omitempty"`
+	// ServiceAccountName is the name of the service account the router runs as. The steps with a token audience
+	// are called with a token of this service account.
+	// +optional
+	ServiceAccountName string `json:"serviceAccountName,omitempty"`
+	// Logger sends the requests and the responses of the graph, and optionally of its steps, as cloud events.
+	// +optional
+	Logger *InferenceGraphLogger `json:"logger,omitempty"`
+}
+
+// InferenceGraphCache defines the backend of the caches of the graph steps
+// +k8s:openapi-gen=true
+type InferenceGraphCache struct {
+	// Redis stores the cached responses in a Redis server shared by the router replicas.
+	// +optional
+	Redis *RedisCache `json:"redis,omitempty"`
+}
+
+// RedisCache defines the Redis server storing the cached step responses
+// +k8s:openapi-gen=true
+type RedisCache struct {
+	// Address of the Redis server in the host:port form.
+	Address string `json:"address"`
+	// Database number, defaults to 0.
+	// +optional
+	Database int32 `json:"database,omitempty"`
+	// PasswordSecretRef references the secret key holding the password of the Redis server.
+	// +optional
+	PasswordSecretRef *corev1.SecretKeySelector `json:"passwordSecretRef,omitempty"`
+	// TLS connects to the Redis server over TLS, the server certificate is verified against the system roots.
+	// +optional
+	TLS *RedisCacheTLS `json:"tls,omitempty"`
+}
+
+// RedisCacheTLS defines the TLS connection to the Redis server
+type RedisCacheTLS struct {
+	// ServerName is the name the server certificate is verified against, defaults to the host of the address.
+	// +optional
+	ServerName string `json:"serverName,omitempty"`
+	// InsecureSkipVerify skips the verification of the server certificate, only for testing.
+	// +optional
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty"`
+}
+
+// InferenceGraphLoggerMode selects the payloads logged by the router
+// +kubebuilder:validation:Enum=all;request;response;errors
+type InferenceGraphLoggerMode string
+
+// InferenceGraphLoggerMode Enum
+const (
+	// GraphLogAll logs both the requests and the responses
+	GraphLogAll InferenceGraphLoggerMode = "all"
+	// GraphLogRequest logs only the requests
+	GraphLogRequest InferenceGraphLoggerMode = "request"
+	// GraphLogResponse logs only the responses
+	GraphLogResponse InferenceGraphLoggerMode = "response"
+	// GraphLogErrors logs the request and the response of the failed inferences only
+	GraphLogErrors InferenceGraphLoggerMode = "errors"
+)
+
+// InferenceGraphLogger configures the payload logging of the router, the events are the same as the ones of the
+// InferenceService loggers and carry the name of the graph, the name of the step and the id of the inference.
+// +k8s:openapi-gen=true
+type InferenceGraphLogger struct {
+	// URL to send logging events. <br />
+	// The events are posted as cloud events over http, or published to a kafka topic when the url has the form
+	// kafka://broker1:9092,broker2:9092/topic
+	URL string `json:"url"`
+	// Specifies the scope of the loggers. <br />
+	// Valid values are: <br />
+	// - "all" (default): log both request and response; <br />
+	// - "request": log only request; <br />
+	// - "response": log only response <br />
+	// - "errors": log both request and response of the inferences for which the graph returns a non-2xx status or
+	// an error response <br />
+	// +optional
+	Mode InferenceGraphLoggerMode `json:"mode,omitempty"`
+	// Fraction of the inferences logged, a decimal number between "0.0" and "1.0" (default). The events of an
+	// inference, including the events of its steps, are either all logged or all skipped.
+	// +optional
+	SamplingRate *string `json:"samplingRate,omitempty"`
+	// Steps also logs the request and the response of every step of the graph, the events are tagged with the
+	// name of the step.
+	// +optional
+	Steps bool `json:"steps,omitempty"`
 }
 
 // ScaleMetric enum
@@ -236,6 +328,70 @@ type InferenceRouter struct {
 	// Steps defines destinations for the current router node
 	// +optional
 	Steps []InferenceStep `json:"steps,omitempty"`
+
+	// Merge defines how the responses of the steps of an Ensemble node are combined,
+	// the responses are returned as a map of step name to response by default
+	// +optional
+	Merge *EnsembleMerge `json:"merge,omitempty"`
+
+	// Stickiness routes the requests with the same key to the same step of a Splitter node as long as the weights of
+	// the steps are unchanged, the requests without a key are routed randomly according to the weights
+	// +optional
+	Stickiness *SplitterStickiness `json:"stickiness,omitempty"`
+}
+
+// EnsembleMergeStrategy constant for the merge strategies of ensemble nodes
+// +k8s:openapi-gen=true
+// +kubebuilder:validation:Enum=aggregate;majorityVote;average;custom
+type EnsembleMergeStrategy string
+
+// EnsembleMergeStrategy Enum
+const (
+	// MergeAggregate returns a map of step name to step response
+	MergeAggregate EnsembleMergeStrategy = "aggregate"
+
+	// MergeMajorityVote returns the value of the field returned by most of the steps
+	MergeMajorityVote EnsembleMergeStrategy = "majorityVote"
+
+	// MergeAverage returns the element-wise average of the numeric arrays of the field
+	MergeAverage EnsembleMergeStrategy = "average"
+
+	// MergeCustom posts the map of step name to step response to a combiner service and returns its response
+	MergeCustom EnsembleMergeStrategy = "custom"
+)
+
+// DefaultEnsembleMergeField is the field of the step responses voted on or averaged when no field is given
+const DefaultEnsembleMergeField = "predictions"
+
+// EnsembleMerge defines how the responses of the steps of an Ensemble node are combined
+// +k8s:openapi-gen=true
+type EnsembleMerge struct {
+	// Strategy used to merge the step responses
+	Strategy EnsembleMergeStrategy `json:"strategy"`
+
+	// Field is the dot separated path, e.g. `outputs.0.data`, of the value voted on or averaged in every step response,
+	// defaults to `predictions`. Arrays are merged element-wise and the merged value replaces the field in the response
+	// of the first step.
+	// +optional
+	Field string `json:"field,omitempty"`
+
+	// CombinerURL of the service receiving the step responses for the custom strategy
+	// +optional
+	CombinerURL string `json:"combinerUrl,omitempty"`
+}
+
+// SplitterStickiness defines the key of the requests assigned to the same step of a Splitter node, exactly one of
+// header and cookie must be specified. The steps are assigned by weighted rendezvous hashing of the key, so that
+// changing the weights only moves the keys needed to reach the new traffic shares.
+// +k8s:openapi-gen=true
+type SplitterStickiness struct {
+	// Header is the name of the request header holding the key
+	// +optional
+	Header string `json:"header,omitempty"`
+
+	// Cookie is the name of the request cookie holding the key
+	// +optional
+	Cookie string `json:"cookie,omitempty"`
 }
 
 // +k8s:openapi-gen=true
@@ -267,6 +423,34 @@ const (
 	Hard InferenceStepDependencyType = "Hard"
 )
 
+// InferenceStepProtocol is the protocol the router uses to call the service of a step
+// +k8s:openapi-gen=true
+// +kubebuilder:validation:Enum=http;grpc-v2
+type InferenceStepProtocol string
+
+// StepProtocol Enum
+const (
+	// StepProtocolHTTP sends the JSON request to the service over HTTP
+	StepProtocolHTTP InferenceStepProtocol = "http"
+
+	// StepProtocolGRPCV2 converts the JSON request to an open inference protocol ModelInferRequest sent over gRPC
+	StepProtocolGRPCV2 InferenceStepProtocol = "grpc-v2"
+)
+
+// InferenceStepConditionLanguage is the language of the condition of a step
+// +k8s:openapi-gen=true
+// +kubebuilder:validation:Enum=gjson;cel
+type InferenceStepConditionLanguage string
+
+// ConditionLanguage Enum
+const (
+	// ConditionLanguageGJSON matches when the gjson path of the condition exists
+	ConditionLanguageGJSON InferenceStepConditionLanguage = "gjson"
+
+	// ConditionLanguageCEL matches when the CEL expression of the condition evaluates to true
+	ConditionLanguageCEL InferenceStepConditionLanguage = "cel"
+)
+
 // InferenceStep defines the inference target of the current step with condition, weights and data.
 // +k8s:openapi-gen=true
 type InferenceStep struct {
@@ -292,9 +476,98 @@ type InferenceStep struct {
 	// +optional
 	Condition string `json:"condition,omitempty"`
 
+	// ConditionLanguage is the language of the condition, defaults to gjson.
+	// CEL expressions read the parsed JSON of the node input from the request variable and, in a Sequence node,
+	// the response of the previous step from the response variable, e.g. response.predictions[0].score > 0.8
+	// +optional
+	ConditionLanguage InferenceStepConditionLanguage `json:"conditionLanguage,omitempty"`
+
 	// to decide whether a step is a hard or a soft dependency in the Inference Graph
 	// +optional
 	Dependency InferenceStepDependencyType `json:"dependency,omitempty"`
+
+	// Protocol used to call the service of the step, defaults to http.
+	// With grpc-v2 the model name is taken from the /v2/models/{name} path of the service url,
+	// or from the service name when the url has no such path.
+	// +optional
+	Protocol InferenceStepProtocol `json:"protocol,omitempty"`
+
+	// Streaming proxies the text/event-stream response of the step to the client as it arrives instead of buffering it,
+	// e.g. the tokens generated by an LLM. Only a terminal step of the graph, whose response is returned to the client,
+	// can stream, and the stream is bounded by the timeout of the graph.
+	// +optional
+	Streaming bool `json:"streaming,omitempty"`
+
+	// TokenAudience attaches a projected token of the router service account, issued for this audience, to the
+	// calls of the step as a Bearer token in the Authorization header, replacing a propagated Authorization header.
+	// +optional
+	TokenAudience string `json:"tokenAudience,omitempty"`
+
+	// TimeoutSeconds specifies the number of seconds to wait for each attempt of the step.
+	// +optional
+	TimeoutSeconds *int64 `json:"timeout,omitempty"`
+
+	// Retry policy of the step, the step is attempted once when not specified.
+	// +optional
+	Retry *InferenceStepRetry `json:"retry,omitempty"`
+
+	// CircuitBreaker stops calling the step after consecutive failures.
+	// +optional
+	CircuitBreaker *InferenceStepCircuitBreaker `json:"circuitBreaker,omitempty"`
+
+	// Cache serves the responses of the step to identical requests without calling it.
+	// +optional
+	Cache *InferenceStepCache `json:"cache,omitempty"`
+}
+
+// InferenceStepCache defines the cache of the successful responses of a step.
+// +k8s:openapi-gen=true
+type InferenceStepCache struct {
+	// TTLSeconds is the number of seconds a response is served from the cache.
+	// +kubebuilder:validation:Minimum=1
+	TTLSeconds int64 `json:"ttlSeconds"`
+
+	// MaxEntries is the maximum number of responses kept in the memory of the router, defaults to 1000.
+	// The least recently used responses are evicted first.
+	// +optional
+	MaxEntries *int32 `json:"maxEntries,omitempty"`
+
+	// KeyFields are the paths of the request fields the cache key is computed from, e.g. inputs.#.data.
+	// When not specified the whole request is used, except its id.
+	// +optional
+	KeyFields []string `json:"keyFields,omitempty"`
+}
+
+// InferenceStepRetry defines how a failed step is retried. A step fails when the call
+// returns an error, times out or responds with a 429 or 5xx status code.
+// +k8s:openapi-gen=true
+type InferenceStepRetry struct {
+	// Attempts is the maximum number of attempts of the step, including the first one.
+	// +kubebuilder:validation:Minimum=1
+	Attempts int32 `json:"attempts"`
+
+	// BackoffMilliseconds is the delay before the first retry, doubled for each subsequent retry.
+	// Defaults to 100 milliseconds.
+	// +optional
+	BackoffMilliseconds *int64 `json:"backoffMilliseconds,omitempty"`
+}
+
+// InferenceStepCircuitBreaker defines when the router stops calling a failing step.
+// +k8s:openapi-gen=true
+type InferenceStepCircuitBreaker struct {
+	// ConsecutiveFailures is the number of consecutive failed calls, after retries, which opens the circuit.
+	// +kubebuilder:validation:Minimum=1
+	ConsecutiveFailures int32 `json:"consecutiveFailures"`
+
+	// CooldownSeconds is the number of seconds the circuit stays open before a call is let through
+	// to probe the step again. Defaults to 30 seconds.
+	// +optional
+	CooldownSeconds *int64 `json:"cooldownSeconds,omitempty"`
+
+	// Fallback is the JSON response returned while the circuit is open. When not specified
+	// the step fails immediately with the 503 status code.
+	// +optional
+	Fallback string `json:"fallback,omitempty"`
 }
 
 // InferenceGraphStatus defines the InferenceGraph conditions and status
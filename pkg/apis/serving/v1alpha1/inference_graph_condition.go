@@ -0,0 +1,54 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+
+	"github.com/google/cel-go/cel"
+)
+
+const (
+	// ConditionRequestVariable holds the parsed JSON input of the node in CEL conditions
+	ConditionRequestVariable = "request"
+	// ConditionResponseVariable holds the parsed JSON response of the previous step in the CEL conditions of a Sequence node
+	ConditionResponseVariable = "response"
+)
+
+// CompileStepCondition compiles the CEL condition of a step in a node of the given router type. The router and the
+// validation webhook share it, so that invalid expressions are rejected when the InferenceGraph is admitted.
+func CompileStepCondition(routerType InferenceRouterType, expression string) (cel.Program, error) {
+	options := []cel.EnvOption{
+		cel.Variable(ConditionRequestVariable, cel.DynType),
+		cel.CrossTypeNumericComparisons(true),
+	}
+	if routerType == Sequence {
+		options = append(options, cel.Variable(ConditionResponseVariable, cel.DynType))
+	}
+	env, err := cel.NewEnv(options...)
+	if err != nil {
+		return nil, err
+	}
+	ast, issues := env.Compile(expression)
+	if issues.Err() != nil {
+		return nil, issues.Err()
+	}
+	if outputType := ast.OutputType(); outputType != cel.DynType && !cel.BoolType.IsAssignableType(outputType) {
+		return nil, fmt.Errorf("expression must evaluate to a bool, found %s", outputType)
+	}
+	return env.Program(ast)
+}
@@ -17,8 +17,15 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"encoding/json"
 	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
 
+	"github.com/kserve/kserve/pkg/utils"
+	"golang.org/x/net/http/httpguts"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
@@ -44,6 +51,20 @@ const (
 	TargetNotProvidedError = "Step %d (\"%s\") in node \"%s\" of InferenceGraph \"%s\" does not specify an inference target"
 	// InvalidTargetError defines the error message for inference graph target specifies more than one of nodeName, serviceName, serviceUrl
 	InvalidTargetError = "Step %d (\"%s\") in node \"%s\" of InferenceGraph \"%s\" specifies more than one of nodeName, serviceName, serviceUrl"
+	// InvalidStepPolicyError defines the error message for an invalid condition, protocol, streaming, token audience, timeout, retry, circuit breaker or cache of an inference step
+	InvalidStepPolicyError = "Step %d (\"%s\") in node \"%s\" of InferenceGraph \"%s\" has an invalid %s: %s"
+	// NonTerminalStreamingStepError defines the error message for a streaming step whose response is not returned to the client
+	NonTerminalStreamingStepError = "Step %d (\"%s\") in node \"%s\" of InferenceGraph \"%s\" streams its response but is not a terminal step of the graph, only a response returned to the client can be streamed"
+	// InvalidPropagateHeadersError defines the error message for an invalid header pattern of an InferenceGraph
+	InvalidPropagateHeadersError = "InferenceGraph \"%s\" has an invalid propagateHeaders pattern \"%s\": %s"
+	// InvalidEnsembleMergeError defines the error message for an invalid merge of the responses of a node
+	InvalidEnsembleMergeError = "Node \"%s\" of InferenceGraph \"%s\" has an invalid merge.%s: %s"
+	// InvalidGraphCacheError defines the error message for an invalid cache backend of an InferenceGraph
+	InvalidGraphCacheError = "InferenceGraph \"%s\" has an invalid cache.%s: %s"
+	// InvalidSplitterStickinessError defines the error message for an invalid stickiness of a splitter node
+	InvalidSplitterStickinessError = "Node \"%s\" of InferenceGraph \"%s\" has an invalid stickiness: %s"
+	// InvalidGraphLoggerError defines the error message for an invalid logger configuration
+	InvalidGraphLoggerError = "InferenceGraph \"%s\" has an invalid logger.%s: %s"
 )
 
 const (
@@ -70,29 +91,80 @@ func (ig *InferenceGraph) ValidateCreate() (admission.Warnings, error) {
 		return nil, err
 	}
 
+	warnings, err := utils.CheckAnnotationKeys(ig.Annotations)
+	if err != nil {
+		return warnings, err
+	}
+
+	return warnings, ig.validateInferenceGraph()
+}
+
+// validateInferenceGraph validates the InferenceGraph on create and on update
+func (ig *InferenceGraph) validateInferenceGraph() error {
 	if err := validateInferenceGraphRouterRoot(ig); err != nil {
-		return nil, err
+		return err
 	}
 
 	if err := validateInferenceGraphStepNameUniqueness(ig); err != nil {
-		return nil, err
+		return err
 	}
 
 	if err := validateInferenceGraphSingleStepTargets(ig); err != nil {
-		return nil, err
+		return err
 	}
 
 	if err := validateInferenceGraphSplitterWeight(ig); err != nil {
-		return nil, err
+		return err
 	}
-	return nil, nil
+
+	if err := validateInferenceGraphStepPolicies(ig); err != nil {
+		return err
+	}
+
+	if err := validateInferenceGraphCache(ig); err != nil {
+		return err
+	}
+
+	if err := validateInferenceGraphSplitterStickiness(ig); err != nil {
+		return err
+	}
+
+	if err := validateInferenceGraphLogger(ig); err != nil {
+		return err
+	}
+
+	if err := validateInferenceGraphStreamingSteps(ig); err != nil {
+		return err
+	}
+
+	if err := validateInferenceGraphPropagateHeaders(ig); err != nil {
+		return err
+	}
+
+	if err := validateInferenceGraphEnsembleMerges(ig); err != nil {
+		return err
+	}
+	return nil
 }
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
 func (ig *InferenceGraph) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
 	validatorLogger.Info("validate update", "name", ig.Name)
+	oldIg, ok := old.(*InferenceGraph)
+	if !ok {
+		return nil, fmt.Errorf("expected an InferenceGraph but got a %T", old)
+	}
+
+	if err := validateInferenceGraphName(ig); err != nil {
+		return nil, err
+	}
+
+	warnings, err := utils.CheckAddedAnnotationKeys(ig.Annotations, oldIg.Annotations)
+	if err != nil {
+		return warnings, err
+	}
 
-	return ig.ValidateCreate()
+	return warnings, ig.validateInferenceGraph()
 }
 
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type
@@ -184,3 +256,250 @@ func validateInferenceGraphSplitterWeight(ig *InferenceGraph) error {
 	}
 	return nil
 }
+
+// Validation of the condition, protocol, streaming, token audience, timeout, retry, circuit breaker and cache of inference steps
+func validateInferenceGraphStepPolicies(ig *InferenceGraph) error {
+	nodes := ig.Spec.Nodes
+	for nodeName, node := range nodes {
+		for i, route := range node.Steps {
+			invalid := func(field string, reason string) error {
+				return fmt.Errorf(InvalidStepPolicyError, i, route.StepName, nodeName, ig.Name, field, reason)
+			}
+			if route.ConditionLanguage == ConditionLanguageCEL {
+				if route.Condition == "" {
+					return invalid("condition", "must not be empty when the condition language is cel")
+				}
+				if _, err := CompileStepCondition(node.RouterType, route.Condition); err != nil {
+					return invalid("condition", err.Error())
+				}
+			}
+			if route.Protocol == StepProtocolGRPCV2 && route.NodeName != "" {
+				return invalid("protocol", "grpc-v2 requires a serviceName or serviceUrl target")
+			}
+			if route.Streaming {
+				switch {
+				case route.NodeName != "":
+					return invalid("streaming", "requires a serviceName or serviceUrl target")
+				case route.Protocol == StepProtocolGRPCV2:
+					return invalid("streaming", "is not supported with the grpc-v2 protocol")
+				case route.Cache != nil:
+					return invalid("streaming", "cannot be combined with a cache")
+				}
+			}
+			if route.TokenAudience != "" && route.NodeName != "" {
+				return invalid("tokenAudience", "requires a serviceName or serviceUrl target")
+			}
+			if route.TimeoutSeconds != nil && *route.TimeoutSeconds <= 0 {
+				return invalid("timeout", "must be greater than 0")
+			}
+			if retry := route.Retry; retry != nil {
+				if retry.Attempts < 1 {
+					return invalid("retry.attempts", "must be greater than or equal to 1")
+				}
+				if retry.BackoffMilliseconds != nil && *retry.BackoffMilliseconds < 0 {
+					return invalid("retry.backoffMilliseconds", "must not be negative")
+				}
+			}
+			if breaker := route.CircuitBreaker; breaker != nil {
+				if breaker.ConsecutiveFailures < 1 {
+					return invalid("circuitBreaker.consecutiveFailures", "must be greater than or equal to 1")
+				}
+				if breaker.CooldownSeconds != nil && *breaker.CooldownSeconds < 0 {
+					return invalid("circuitBreaker.cooldownSeconds", "must not be negative")
+				}
+				if breaker.Fallback != "" && !json.Valid([]byte(breaker.Fallback)) {
+					return invalid("circuitBreaker.fallback", "must be a valid JSON document")
+				}
+			}
+			if cache := route.Cache; cache != nil {
+				if cache.TTLSeconds < 1 {
+					return invalid("cache.ttlSeconds", "must be greater than or equal to 1")
+				}
+				if cache.MaxEntries != nil && *cache.MaxEntries < 1 {
+					return invalid("cache.maxEntries", "must be greater than or equal to 1")
+				}
+				for _, field := range cache.KeyFields {
+					if field == "" {
+						return invalid("cache.keyFields", "must not contain empty paths")
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// Validation of the cache backend shared by the step caches of the graph
+func validateInferenceGraphCache(ig *InferenceGraph) error {
+	if ig.Spec.Cache == nil || ig.Spec.Cache.Redis == nil {
+		return nil
+	}
+	redis := ig.Spec.Cache.Redis
+	if redis.Address == "" {
+		return fmt.Errorf(InvalidGraphCacheError, ig.Name, "redis.address", "must not be empty")
+	}
+	if redis.Database < 0 {
+		return fmt.Errorf(InvalidGraphCacheError, ig.Name, "redis.database", "must not be negative")
+	}
+	return nil
+}
+
+// Validation of the request keys assigning the requests of splitter nodes to their steps
+func validateInferenceGraphSplitterStickiness(ig *InferenceGraph) error {
+	for nodeName, node := range ig.Spec.Nodes {
+		stickiness := node.Stickiness
+		if stickiness == nil {
+			continue
+		}
+		if node.RouterType != Splitter {
+			return fmt.Errorf(InvalidSplitterStickinessError, nodeName, ig.Name, "only Splitter nodes can be sticky")
+		}
+		if (stickiness.Header == "") == (stickiness.Cookie == "") {
+			return fmt.Errorf(InvalidSplitterStickinessError, nodeName, ig.Name, "exactly one of header and cookie must be specified")
+		}
+		if name := stickiness.Header + stickiness.Cookie; !httpguts.ValidHeaderFieldName(name) {
+			return fmt.Errorf(InvalidSplitterStickinessError, nodeName, ig.Name, fmt.Sprintf("%q is not a valid header or cookie name", name))
+		}
+	}
+	return nil
+}
+
+// Validation of the payload logging configuration of the router
+func validateInferenceGraphLogger(ig *InferenceGraph) error {
+	logger := ig.Spec.Logger
+	if logger == nil {
+		return nil
+	}
+	if logger.URL == "" {
+		return fmt.Errorf(InvalidGraphLoggerError, ig.Name, "url", "must not be empty")
+	}
+	logURL, err := url.Parse(logger.URL)
+	if err != nil {
+		return fmt.Errorf(InvalidGraphLoggerError, ig.Name, "url", err)
+	}
+	switch logURL.Scheme {
+	case "http", "https":
+	case "kafka":
+		// kafka://broker1:9092,broker2:9092/topic
+		if logURL.Host == "" || strings.Trim(logURL.Path, "/") == "" || strings.Contains(strings.Trim(logURL.Path, "/"), "/") {
+			return fmt.Errorf(InvalidGraphLoggerError, ig.Name, "url", "a kafka url must have at least one broker and exactly one topic")
+		}
+	default:
+		return fmt.Errorf(InvalidGraphLoggerError, ig.Name, "url", "must be an http, https or kafka url")
+	}
+	switch logger.Mode {
+	case "", GraphLogAll, GraphLogRequest, GraphLogResponse, GraphLogErrors:
+	default:
+		return fmt.Errorf(InvalidGraphLoggerError, ig.Name, "mode", fmt.Sprintf("unknown mode %q, must be one of %s, %s, %s or %s",
+			logger.Mode, GraphLogAll, GraphLogRequest, GraphLogResponse, GraphLogErrors))
+	}
+	if logger.SamplingRate != nil {
+		rate, err := strconv.ParseFloat(*logger.SamplingRate, 64)
+		if err != nil || rate < 0 || rate > 1 {
+			return fmt.Errorf(InvalidGraphLoggerError, ig.Name, "samplingRate", fmt.Sprintf("%q must be a decimal number between 0.0 and 1.0", *logger.SamplingRate))
+		}
+	}
+	return nil
+}
+
+// Validation of the patterns of the headers propagated to the steps
+func validateInferenceGraphPropagateHeaders(ig *InferenceGraph) error {
+	for _, pattern := range ig.Spec.PropagateHeaders {
+		if _, err := regexp.Compile(pattern); err != nil {
+			return fmt.Errorf(InvalidPropagateHeadersError, ig.Name, pattern, err)
+		}
+	}
+	return nil
+}
+
+// Validation of the merge strategies of the ensemble nodes
+func validateInferenceGraphEnsembleMerges(ig *InferenceGraph) error {
+	for nodeName, node := range ig.Spec.Nodes {
+		merge := node.Merge
+		if merge == nil {
+			continue
+		}
+		invalid := func(field string, reason string) error {
+			return fmt.Errorf(InvalidEnsembleMergeError, nodeName, ig.Name, field, reason)
+		}
+		if node.RouterType != Ensemble {
+			return invalid("strategy", "responses can only be merged by Ensemble nodes")
+		}
+		switch merge.Strategy {
+		case MergeAggregate, MergeMajorityVote, MergeAverage, MergeCustom:
+		default:
+			return invalid("strategy", fmt.Sprintf("unknown strategy %q, must be one of %s, %s, %s or %s",
+				merge.Strategy, MergeAggregate, MergeMajorityVote, MergeAverage, MergeCustom))
+		}
+		if merge.Field != "" && merge.Strategy != MergeMajorityVote && merge.Strategy != MergeAverage {
+			return invalid("field", "only used by the majorityVote and average strategies")
+		}
+		if merge.Field != "" && slices.Contains(strings.Split(merge.Field, "."), "") {
+			return invalid("field", "must be a dot separated path of object keys and array indices")
+		}
+		if merge.Strategy != MergeCustom {
+			if merge.CombinerURL != "" {
+				return invalid("combinerUrl", "only used by the custom strategy")
+			}
+			continue
+		}
+		if merge.CombinerURL == "" {
+			return invalid("combinerUrl", "required by the custom strategy")
+		}
+		combinerURL, err := url.ParseRequestURI(merge.CombinerURL)
+		if err != nil {
+			return invalid("combinerUrl", err.Error())
+		}
+		if combinerURL.Scheme != "http" && combinerURL.Scheme != "https" {
+			return invalid("combinerUrl", "must be an http or https url")
+		}
+	}
+	return nil
+}
+
+// Validation of the streaming steps, only the response of a terminal step is returned to the client as is
+func validateInferenceGraphStreamingSteps(ig *InferenceGraph) error {
+	terminalNodes := terminalInferenceGraphNodes(ig)
+	for nodeName, node := range ig.Spec.Nodes {
+		for i, step := range node.Steps {
+			if step.Streaming && !(terminalNodes[nodeName] && isTerminalInferenceStep(node, i)) {
+				return fmt.Errorf(NonTerminalStreamingStepError, i, step.StepName, nodeName, ig.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// terminalInferenceGraphNodes returns the nodes reachable from the root node, mapped to whether the response of the
+// node is returned to the client from every step referencing it
+func terminalInferenceGraphNodes(ig *InferenceGraph) map[string]bool {
+	terminalNodes := map[string]bool{}
+	var visit func(nodeName string, terminal bool)
+	visit = func(nodeName string, terminal bool) {
+		// a node is visited again only when it turns out to be referenced from a non terminal step
+		if visited, ok := terminalNodes[nodeName]; ok && (!visited || terminal) {
+			return
+		}
+		terminalNodes[nodeName] = terminal
+		node := ig.Spec.Nodes[nodeName]
+		for i, step := range node.Steps {
+			if step.NodeName != "" {
+				visit(step.NodeName, terminal && isTerminalInferenceStep(node, i))
+			}
+		}
+	}
+	visit(GraphRootNodeName, true)
+	return terminalNodes
+}
+
+// isTerminalInferenceStep reports whether the response of the step is the response of its node
+func isTerminalInferenceStep(node InferenceRouter, index int) bool {
+	switch node.RouterType {
+	case Sequence:
+		return index == len(node.Steps)-1
+	case Switch, Splitter:
+		return true
+	default:
+		return false
+	}
+}
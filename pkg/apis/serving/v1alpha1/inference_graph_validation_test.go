@@ -18,10 +18,13 @@ package v1alpha1
 
 import (
 	"fmt"
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/kserve/kserve/pkg/utils"
 	"github.com/onsi/gomega"
 	"github.com/onsi/gomega/types"
 	"google.golang.org/protobuf/proto"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"regexp"
 	"testing"
 )
 
@@ -258,6 +261,1206 @@ func TestInferenceGraph_ValidateCreate(t *testing.T) {
 			errMatcher:      gomega.MatchError(fmt.Errorf(DuplicateStepNameError, GraphRootNodeName, "foo-bar", "step1")),
 			warningsMatcher: gomega.BeEmpty(),
 		},
+		"valid step timeout, retry and circuit breaker": {
+			ig: makeTestInferenceGraph(),
+			nodes: map[string]InferenceRouter{
+				GraphRootNodeName: {
+					RouterType: Sequence,
+					Steps: []InferenceStep{
+						{
+							StepName: "step1",
+							InferenceTarget: InferenceTarget{
+								ServiceName: "service1",
+							},
+							TimeoutSeconds: proto.Int64(10),
+							Retry: &InferenceStepRetry{
+								Attempts:            3,
+								BackoffMilliseconds: proto.Int64(0),
+							},
+							CircuitBreaker: &InferenceStepCircuitBreaker{
+								ConsecutiveFailures: 5,
+								CooldownSeconds:     proto.Int64(30),
+								Fallback:            `{"predictions": []}`,
+							},
+						},
+					},
+				},
+			},
+			errMatcher:      gomega.MatchError(nil),
+			warningsMatcher: gomega.BeEmpty(),
+		},
+		"grpc-v2 protocol for a node step": {
+			ig: makeTestInferenceGraph(),
+			nodes: map[string]InferenceRouter{
+				GraphRootNodeName: {
+					RouterType: Sequence,
+					Steps: []InferenceStep{
+						{
+							StepName: "step1",
+							InferenceTarget: InferenceTarget{
+								NodeName: "node1",
+							},
+							Protocol: StepProtocolGRPCV2,
+						},
+					},
+				},
+			},
+			errMatcher:      gomega.MatchError(fmt.Errorf(InvalidStepPolicyError, 0, "step1", GraphRootNodeName, "foo-bar", "protocol", "grpc-v2 requires a serviceName or serviceUrl target")),
+			warningsMatcher: gomega.BeEmpty(),
+		},
+		"negative step timeout": {
+			ig: makeTestInferenceGraph(),
+			nodes: map[string]InferenceRouter{
+				GraphRootNodeName: {
+					RouterType: Sequence,
+					Steps: []InferenceStep{
+						{
+							StepName: "step1",
+							InferenceTarget: InferenceTarget{
+								ServiceName: "service1",
+							},
+							TimeoutSeconds: proto.Int64(-1),
+						},
+					},
+				},
+			},
+			errMatcher:      gomega.MatchError(fmt.Errorf(InvalidStepPolicyError, 0, "step1", GraphRootNodeName, "foo-bar", "timeout", "must be greater than 0")),
+			warningsMatcher: gomega.BeEmpty(),
+		},
+		"zero retry attempts": {
+			ig: makeTestInferenceGraph(),
+			nodes: map[string]InferenceRouter{
+				GraphRootNodeName: {
+					RouterType: Sequence,
+					Steps: []InferenceStep{
+						{
+							StepName: "step1",
+							InferenceTarget: InferenceTarget{
+								ServiceName: "service1",
+							},
+							Retry: &InferenceStepRetry{
+								Attempts: 0,
+							},
+						},
+					},
+				},
+			},
+			errMatcher:      gomega.MatchError(fmt.Errorf(InvalidStepPolicyError, 0, "step1", GraphRootNodeName, "foo-bar", "retry.attempts", "must be greater than or equal to 1")),
+			warningsMatcher: gomega.BeEmpty(),
+		},
+		"negative retry backoff": {
+			ig: makeTestInferenceGraph(),
+			nodes: map[string]InferenceRouter{
+				GraphRootNodeName: {
+					RouterType: Sequence,
+					Steps: []InferenceStep{
+						{
+							StepName: "step1",
+							InferenceTarget: InferenceTarget{
+								ServiceName: "service1",
+							},
+							Retry: &InferenceStepRetry{
+								Attempts:            2,
+								BackoffMilliseconds: proto.Int64(-100),
+							},
+						},
+					},
+				},
+			},
+			errMatcher:      gomega.MatchError(fmt.Errorf(InvalidStepPolicyError, 0, "step1", GraphRootNodeName, "foo-bar", "retry.backoffMilliseconds", "must not be negative")),
+			warningsMatcher: gomega.BeEmpty(),
+		},
+		"negative circuit breaker consecutive failures": {
+			ig: makeTestInferenceGraph(),
+			nodes: map[string]InferenceRouter{
+				GraphRootNodeName: {
+					RouterType: Sequence,
+					Steps: []InferenceStep{
+						{
+							StepName: "step1",
+							InferenceTarget: InferenceTarget{
+								ServiceName: "service1",
+							},
+							CircuitBreaker: &InferenceStepCircuitBreaker{
+								ConsecutiveFailures: -1,
+							},
+						},
+					},
+				},
+			},
+			errMatcher:      gomega.MatchError(fmt.Errorf(InvalidStepPolicyError, 0, "step1", GraphRootNodeName, "foo-bar", "circuitBreaker.consecutiveFailures", "must be greater than or equal to 1")),
+			warningsMatcher: gomega.BeEmpty(),
+		},
+		"negative circuit breaker cooldown": {
+			ig: makeTestInferenceGraph(),
+			nodes: map[string]InferenceRouter{
+				GraphRootNodeName: {
+					RouterType: Sequence,
+					Steps: []InferenceStep{
+						{
+							StepName: "step1",
+							InferenceTarget: InferenceTarget{
+								ServiceName: "service1",
+							},
+							CircuitBreaker: &InferenceStepCircuitBreaker{
+								ConsecutiveFailures: 1,
+								CooldownSeconds:     proto.Int64(-30),
+							},
+						},
+					},
+				},
+			},
+			errMatcher:      gomega.MatchError(fmt.Errorf(InvalidStepPolicyError, 0, "step1", GraphRootNodeName, "foo-bar", "circuitBreaker.cooldownSeconds", "must not be negative")),
+			warningsMatcher: gomega.BeEmpty(),
+		},
+		"invalid circuit breaker fallback": {
+			ig: makeTestInferenceGraph(),
+			nodes: map[string]InferenceRouter{
+				GraphRootNodeName: {
+					RouterType: Sequence,
+					Steps: []InferenceStep{
+						{
+							StepName: "step1",
+							InferenceTarget: InferenceTarget{
+								ServiceName: "service1",
+							},
+							CircuitBreaker: &InferenceStepCircuitBreaker{
+								ConsecutiveFailures: 1,
+								Fallback:            "not json",
+							},
+						},
+					},
+				},
+			},
+			errMatcher:      gomega.MatchError(fmt.Errorf(InvalidStepPolicyError, 0, "step1", GraphRootNodeName, "foo-bar", "circuitBreaker.fallback", "must be a valid JSON document")),
+			warningsMatcher: gomega.BeEmpty(),
+		},
+		"with cel condition": {
+			ig: makeTestInferenceGraph(),
+			nodes: map[string]InferenceRouter{
+				GraphRootNodeName: {
+					RouterType: Sequence,
+					Steps: []InferenceStep{
+						{
+							StepName: "step1",
+							InferenceTarget: InferenceTarget{
+								ServiceName: "service1",
+							},
+							Condition:         "response.predictions[0].score > 0.8",
+							ConditionLanguage: ConditionLanguageCEL,
+						},
+					},
+				},
+			},
+			errMatcher:      gomega.MatchError(nil),
+			warningsMatcher: gomega.BeEmpty(),
+		},
+		"empty cel condition": {
+			ig: makeTestInferenceGraph(),
+			nodes: map[string]InferenceRouter{
+				GraphRootNodeName: {
+					RouterType: Switch,
+					Steps: []InferenceStep{
+						{
+							StepName: "step1",
+							InferenceTarget: InferenceTarget{
+								ServiceName: "service1",
+							},
+							Condition:         "",
+							ConditionLanguage: ConditionLanguageCEL,
+						},
+					},
+				},
+			},
+			errMatcher:      gomega.MatchError(fmt.Errorf(InvalidStepPolicyError, 0, "step1", GraphRootNodeName, "foo-bar", "condition", "must not be empty when the condition language is cel")),
+			warningsMatcher: gomega.BeEmpty(),
+		},
+		"invalid cel condition syntax": {
+			ig: makeTestInferenceGraph(),
+			nodes: map[string]InferenceRouter{
+				GraphRootNodeName: {
+					RouterType: Switch,
+					Steps: []InferenceStep{
+						{
+							StepName: "step1",
+							InferenceTarget: InferenceTarget{
+								ServiceName: "service1",
+							},
+							Condition:         "request.score >",
+							ConditionLanguage: ConditionLanguageCEL,
+						},
+					},
+				},
+			},
+			errMatcher:      gomega.MatchError(fmt.Errorf(InvalidStepPolicyError, 0, "step1", GraphRootNodeName, "foo-bar", "condition", conditionCompileError(Switch, "request.score >"))),
+			warningsMatcher: gomega.BeEmpty(),
+		},
+		"cel condition on the response in a switch": {
+			ig: makeTestInferenceGraph(),
+			nodes: map[string]InferenceRouter{
+				GraphRootNodeName: {
+					RouterType: Switch,
+					Steps: []InferenceStep{
+						{
+							StepName: "step1",
+							InferenceTarget: InferenceTarget{
+								ServiceName: "service1",
+							},
+							Condition:         "response.score > 0.8",
+							ConditionLanguage: ConditionLanguageCEL,
+						},
+					},
+				},
+			},
+			errMatcher:      gomega.MatchError(fmt.Errorf(InvalidStepPolicyError, 0, "step1", GraphRootNodeName, "foo-bar", "condition", conditionCompileError(Switch, "response.score > 0.8"))),
+			warningsMatcher: gomega.BeEmpty(),
+		},
+		"cel condition not evaluating to a bool": {
+			ig: makeTestInferenceGraph(),
+			nodes: map[string]InferenceRouter{
+				GraphRootNodeName: {
+					RouterType: Switch,
+					Steps: []InferenceStep{
+						{
+							StepName: "step1",
+							InferenceTarget: InferenceTarget{
+								ServiceName: "service1",
+							},
+							Condition:         "request.score + 1",
+							ConditionLanguage: ConditionLanguageCEL,
+						},
+					},
+				},
+			},
+			errMatcher:      gomega.MatchError(fmt.Errorf(InvalidStepPolicyError, 0, "step1", GraphRootNodeName, "foo-bar", "condition", "expression must evaluate to a bool, found int")),
+			warningsMatcher: gomega.BeEmpty(),
+		},
+		"streaming last step": {
+			ig: makeTestInferenceGraph(),
+			nodes: map[string]InferenceRouter{
+				GraphRootNodeName: {
+					RouterType: Sequence,
+					Steps: []InferenceStep{
+						{
+							StepName: "step1",
+							InferenceTarget: InferenceTarget{
+								ServiceName: "service1",
+							},
+						},
+						{
+							StepName: "step2",
+							InferenceTarget: InferenceTarget{
+								ServiceName: "llm",
+							},
+							Streaming: true,
+						},
+					},
+				},
+			},
+			errMatcher:      gomega.MatchError(nil),
+			warningsMatcher: gomega.BeEmpty(),
+		},
+		"streaming step of a terminal node": {
+			ig: makeTestInferenceGraph(),
+			nodes: map[string]InferenceRouter{
+				GraphRootNodeName: {
+					RouterType: Sequence,
+					Steps: []InferenceStep{
+						{
+							StepName: "step1",
+							InferenceTarget: InferenceTarget{
+								ServiceName: "service1",
+							},
+						},
+						{
+							StepName: "step2",
+							InferenceTarget: InferenceTarget{
+								NodeName: "generate",
+							},
+						},
+					},
+				},
+				"generate": {
+					RouterType: Switch,
+					Steps: []InferenceStep{
+						{
+							StepName: "llm1",
+							InferenceTarget: InferenceTarget{
+								ServiceName: "llm1",
+							},
+							Streaming: true,
+						},
+						{
+							StepName: "llm2",
+							InferenceTarget: InferenceTarget{
+								ServiceName: "llm2",
+							},
+							Streaming: true,
+						},
+					},
+				},
+			},
+			errMatcher:      gomega.MatchError(nil),
+			warningsMatcher: gomega.BeEmpty(),
+		},
+		"streaming step not last in a sequence": {
+			ig: makeTestInferenceGraph(),
+			nodes: map[string]InferenceRouter{
+				GraphRootNodeName: {
+					RouterType: Sequence,
+					Steps: []InferenceStep{
+						{
+							StepName: "step1",
+							InferenceTarget: InferenceTarget{
+								ServiceName: "llm",
+							},
+							Streaming: true,
+						},
+						{
+							StepName: "step2",
+							InferenceTarget: InferenceTarget{
+								ServiceName: "service1",
+							},
+						},
+					},
+				},
+			},
+			errMatcher:      gomega.MatchError(fmt.Errorf(NonTerminalStreamingStepError, 0, "step1", GraphRootNodeName, "foo-bar")),
+			warningsMatcher: gomega.BeEmpty(),
+		},
+		"streaming step in an ensemble": {
+			ig: makeTestInferenceGraph(),
+			nodes: map[string]InferenceRouter{
+				GraphRootNodeName: {
+					RouterType: Ensemble,
+					Steps: []InferenceStep{
+						{
+							StepName: "step1",
+							InferenceTarget: InferenceTarget{
+								ServiceName: "llm",
+							},
+							Streaming: true,
+						},
+						{
+							StepName: "step2",
+							InferenceTarget: InferenceTarget{
+								ServiceName: "service1",
+							},
+						},
+					},
+				},
+			},
+			errMatcher:      gomega.MatchError(fmt.Errorf(NonTerminalStreamingStepError, 0, "step1", GraphRootNodeName, "foo-bar")),
+			warningsMatcher: gomega.BeEmpty(),
+		},
+		"streaming step of a node also used as a non terminal step": {
+			ig: makeTestInferenceGraph(),
+			nodes: map[string]InferenceRouter{
+				GraphRootNodeName: {
+					RouterType: Sequence,
+					Steps: []InferenceStep{
+						{
+							StepName: "step1",
+							InferenceTarget: InferenceTarget{
+								NodeName: "generate",
+							},
+						},
+						{
+							StepName: "step2",
+							InferenceTarget: InferenceTarget{
+								NodeName: "generate",
+							},
+						},
+					},
+				},
+				"generate": {
+					RouterType: Sequence,
+					Steps: []InferenceStep{
+						{
+							StepName: "llm",
+							InferenceTarget: InferenceTarget{
+								ServiceName: "llm",
+							},
+							Streaming: true,
+						},
+					},
+				},
+			},
+			errMatcher:      gomega.MatchError(fmt.Errorf(NonTerminalStreamingStepError, 0, "llm", "generate", "foo-bar")),
+			warningsMatcher: gomega.BeEmpty(),
+		},
+		"streaming step with a cache": {
+			ig: makeTestInferenceGraph(),
+			nodes: map[string]InferenceRouter{
+				GraphRootNodeName: {
+					RouterType: Sequence,
+					Steps: []InferenceStep{
+						{
+							StepName: "step1",
+							InferenceTarget: InferenceTarget{
+								ServiceName: "llm",
+							},
+							Streaming: true,
+							Cache: &InferenceStepCache{
+								TTLSeconds: 60,
+							},
+						},
+					},
+				},
+			},
+			errMatcher:      gomega.MatchError(fmt.Errorf(InvalidStepPolicyError, 0, "step1", GraphRootNodeName, "foo-bar", "streaming", "cannot be combined with a cache")),
+			warningsMatcher: gomega.BeEmpty(),
+		},
+		"token audience on a node step": {
+			ig: makeTestInferenceGraph(),
+			nodes: map[string]InferenceRouter{
+				GraphRootNodeName: {
+					RouterType: Sequence,
+					Steps: []InferenceStep{
+						{
+							StepName: "step1",
+							InferenceTarget: InferenceTarget{
+								NodeName: "node1",
+							},
+							TokenAudience: "service1",
+						},
+					},
+				},
+				"node1": {
+					RouterType: Sequence,
+					Steps: []InferenceStep{
+						{
+							StepName: "step1",
+							InferenceTarget: InferenceTarget{
+								ServiceName: "service1",
+							},
+						},
+					},
+				},
+			},
+			errMatcher:      gomega.MatchError(fmt.Errorf(InvalidStepPolicyError, 0, "step1", GraphRootNodeName, "foo-bar", "tokenAudience", "requires a serviceName or serviceUrl target")),
+			warningsMatcher: gomega.BeEmpty(),
+		},
+		"ensemble with majority vote merge": {
+			ig: makeTestInferenceGraph(),
+			nodes: map[string]InferenceRouter{
+				GraphRootNodeName: {
+					RouterType: Ensemble,
+					Steps: []InferenceStep{
+						{
+							StepName: "step1",
+							InferenceTarget: InferenceTarget{
+								ServiceName: "service1",
+							},
+						},
+						{
+							StepName: "step2",
+							InferenceTarget: InferenceTarget{
+								ServiceName: "service2",
+							},
+						},
+					},
+					Merge: &EnsembleMerge{
+						Strategy: MergeMajorityVote,
+						Field:    "predictions.0.label",
+					},
+				},
+			},
+			errMatcher:      gomega.MatchError(nil),
+			warningsMatcher: gomega.BeEmpty(),
+		},
+		"ensemble with custom merge": {
+			ig: makeTestInferenceGraph(),
+			nodes: map[string]InferenceRouter{
+				GraphRootNodeName: {
+					RouterType: Ensemble,
+					Steps: []InferenceStep{
+						{
+							StepName: "step1",
+							InferenceTarget: InferenceTarget{
+								ServiceName: "service1",
+							},
+						},
+						{
+							StepName: "step2",
+							InferenceTarget: InferenceTarget{
+								ServiceName: "service2",
+							},
+						},
+					},
+					Merge: &EnsembleMerge{
+						Strategy:    MergeCustom,
+						CombinerURL: "http://combiner.default.svc/v1/models/combiner:predict",
+					},
+				},
+			},
+			errMatcher:      gomega.MatchError(nil),
+			warningsMatcher: gomega.BeEmpty(),
+		},
+		"merge on a sequence node": {
+			ig: makeTestInferenceGraph(),
+			nodes: map[string]InferenceRouter{
+				GraphRootNodeName: {
+					RouterType: Sequence,
+					Steps: []InferenceStep{
+						{
+							StepName: "step1",
+							InferenceTarget: InferenceTarget{
+								ServiceName: "service1",
+							},
+						},
+						{
+							StepName: "step2",
+							InferenceTarget: InferenceTarget{
+								ServiceName: "service2",
+							},
+						},
+					},
+					Merge: &EnsembleMerge{
+						Strategy: MergeAverage,
+					},
+				},
+			},
+			errMatcher:      gomega.MatchError(fmt.Errorf(InvalidEnsembleMergeError, GraphRootNodeName, "foo-bar", "strategy", "responses can only be merged by Ensemble nodes")),
+			warningsMatcher: gomega.BeEmpty(),
+		},
+		"unknown merge strategy": {
+			ig: makeTestInferenceGraph(),
+			nodes: map[string]InferenceRouter{
+				GraphRootNodeName: {
+					RouterType: Ensemble,
+					Steps: []InferenceStep{
+						{
+							StepName: "step1",
+							InferenceTarget: InferenceTarget{
+								ServiceName: "service1",
+							},
+						},
+						{
+							StepName: "step2",
+							InferenceTarget: InferenceTarget{
+								ServiceName: "service2",
+							},
+						},
+					},
+					Merge: &EnsembleMerge{
+						Strategy: "median",
+					},
+				},
+			},
+			errMatcher:      gomega.MatchError(fmt.Errorf(InvalidEnsembleMergeError, GraphRootNodeName, "foo-bar", "strategy", "unknown strategy \"median\", must be one of aggregate, majorityVote, average or custom")),
+			warningsMatcher: gomega.BeEmpty(),
+		},
+		"merge field with aggregate strategy": {
+			ig: makeTestInferenceGraph(),
+			nodes: map[string]InferenceRouter{
+				GraphRootNodeName: {
+					RouterType: Ensemble,
+					Steps: []InferenceStep{
+						{
+							StepName: "step1",
+							InferenceTarget: InferenceTarget{
+								ServiceName: "service1",
+							},
+						},
+						{
+							StepName: "step2",
+							InferenceTarget: InferenceTarget{
+								ServiceName: "service2",
+							},
+						},
+					},
+					Merge: &EnsembleMerge{
+						Strategy: MergeAggregate,
+						Field:    "predictions",
+					},
+				},
+			},
+			errMatcher:      gomega.MatchError(fmt.Errorf(InvalidEnsembleMergeError, GraphRootNodeName, "foo-bar", "field", "only used by the majorityVote and average strategies")),
+			warningsMatcher: gomega.BeEmpty(),
+		},
+		"merge field with an empty path segment": {
+			ig: makeTestInferenceGraph(),
+			nodes: map[string]InferenceRouter{
+				GraphRootNodeName: {
+					RouterType: Ensemble,
+					Steps: []InferenceStep{
+						{
+							StepName: "step1",
+							InferenceTarget: InferenceTarget{
+								ServiceName: "service1",
+							},
+						},
+						{
+							StepName: "step2",
+							InferenceTarget: InferenceTarget{
+								ServiceName: "service2",
+							},
+						},
+					},
+					Merge: &EnsembleMerge{
+						Strategy: MergeAverage,
+						Field:    "outputs..data",
+					},
+				},
+			},
+			errMatcher:      gomega.MatchError(fmt.Errorf(InvalidEnsembleMergeError, GraphRootNodeName, "foo-bar", "field", "must be a dot separated path of object keys and array indices")),
+			warningsMatcher: gomega.BeEmpty(),
+		},
+		"custom merge without combiner": {
+			ig: makeTestInferenceGraph(),
+			nodes: map[string]InferenceRouter{
+				GraphRootNodeName: {
+					RouterType: Ensemble,
+					Steps: []InferenceStep{
+						{
+							StepName: "step1",
+							InferenceTarget: InferenceTarget{
+								ServiceName: "service1",
+							},
+						},
+						{
+							StepName: "step2",
+							InferenceTarget: InferenceTarget{
+								ServiceName: "service2",
+							},
+						},
+					},
+					Merge: &EnsembleMerge{
+						Strategy: MergeCustom,
+					},
+				},
+			},
+			errMatcher:      gomega.MatchError(fmt.Errorf(InvalidEnsembleMergeError, GraphRootNodeName, "foo-bar", "combinerUrl", "required by the custom strategy")),
+			warningsMatcher: gomega.BeEmpty(),
+		},
+		"combiner with average strategy": {
+			ig: makeTestInferenceGraph(),
+			nodes: map[string]InferenceRouter{
+				GraphRootNodeName: {
+					RouterType: Ensemble,
+					Steps: []InferenceStep{
+						{
+							StepName: "step1",
+							InferenceTarget: InferenceTarget{
+								ServiceName: "service1",
+							},
+						},
+						{
+							StepName: "step2",
+							InferenceTarget: InferenceTarget{
+								ServiceName: "service2",
+							},
+						},
+					},
+					Merge: &EnsembleMerge{
+						Strategy:    MergeAverage,
+						CombinerURL: "http://combiner",
+					},
+				},
+			},
+			errMatcher:      gomega.MatchError(fmt.Errorf(InvalidEnsembleMergeError, GraphRootNodeName, "foo-bar", "combinerUrl", "only used by the custom strategy")),
+			warningsMatcher: gomega.BeEmpty(),
+		},
+		"custom merge with non http combiner": {
+			ig: makeTestInferenceGraph(),
+			nodes: map[string]InferenceRouter{
+				GraphRootNodeName: {
+					RouterType: Ensemble,
+					Steps: []InferenceStep{
+						{
+							StepName: "step1",
+							InferenceTarget: InferenceTarget{
+								ServiceName: "service1",
+							},
+						},
+						{
+							StepName: "step2",
+							InferenceTarget: InferenceTarget{
+								ServiceName: "service2",
+							},
+						},
+					},
+					Merge: &EnsembleMerge{
+						Strategy:    MergeCustom,
+						CombinerURL: "grpc://combiner:8081",
+					},
+				},
+			},
+			errMatcher:      gomega.MatchError(fmt.Errorf(InvalidEnsembleMergeError, GraphRootNodeName, "foo-bar", "combinerUrl", "must be an http or https url")),
+			warningsMatcher: gomega.BeEmpty(),
+		},
+		"with propagated headers and token audience": {
+			ig: func() InferenceGraph {
+				ig := makeTestInferenceGraph()
+				ig.Spec.PropagateHeaders = []string{"X-Request-Id", "X-B3-.*"}
+				return ig
+			}(),
+			nodes: map[string]InferenceRouter{
+				GraphRootNodeName: {
+					RouterType: Sequence,
+					Steps: []InferenceStep{
+						{
+							StepName: "step1",
+							InferenceTarget: InferenceTarget{
+								ServiceName: "service1",
+							},
+							TokenAudience: "service1.default.svc",
+						},
+					},
+				},
+			},
+			errMatcher:      gomega.MatchError(nil),
+			warningsMatcher: gomega.BeEmpty(),
+		},
+		"invalid propagated header pattern": {
+			ig: func() InferenceGraph {
+				ig := makeTestInferenceGraph()
+				ig.Spec.PropagateHeaders = []string{"X-B3-(.*"}
+				return ig
+			}(),
+			nodes: map[string]InferenceRouter{
+				GraphRootNodeName: {},
+			},
+			errMatcher:      gomega.MatchError(fmt.Errorf(InvalidPropagateHeadersError, "foo-bar", "X-B3-(.*", propagateHeadersPatternError("X-B3-(.*"))),
+			warningsMatcher: gomega.BeEmpty(),
+		},
+		"with step cache": {
+			ig: makeTestInferenceGraph(),
+			nodes: map[string]InferenceRouter{
+				GraphRootNodeName: {
+					RouterType: Sequence,
+					Steps: []InferenceStep{
+						{
+							StepName: "step1",
+							InferenceTarget: InferenceTarget{
+								ServiceName: "service1",
+							},
+							Cache: &InferenceStepCache{
+								TTLSeconds: 60,
+								MaxEntries: proto.Int32(100),
+								KeyFields:  []string{"inputs.#.data"},
+							},
+						},
+					},
+				},
+			},
+			errMatcher:      gomega.MatchError(nil),
+			warningsMatcher: gomega.BeEmpty(),
+		},
+		"invalid cache ttl": {
+			ig: makeTestInferenceGraph(),
+			nodes: map[string]InferenceRouter{
+				GraphRootNodeName: {
+					RouterType: Sequence,
+					Steps: []InferenceStep{
+						{
+							StepName: "step1",
+							InferenceTarget: InferenceTarget{
+								ServiceName: "service1",
+							},
+							Cache: &InferenceStepCache{
+								TTLSeconds: 0,
+							},
+						},
+					},
+				},
+			},
+			errMatcher:      gomega.MatchError(fmt.Errorf(InvalidStepPolicyError, 0, "step1", GraphRootNodeName, "foo-bar", "cache.ttlSeconds", "must be greater than or equal to 1")),
+			warningsMatcher: gomega.BeEmpty(),
+		},
+		"invalid cache max entries": {
+			ig: makeTestInferenceGraph(),
+			nodes: map[string]InferenceRouter{
+				GraphRootNodeName: {
+					RouterType: Sequence,
+					Steps: []InferenceStep{
+						{
+							StepName: "step1",
+							InferenceTarget: InferenceTarget{
+								ServiceName: "service1",
+							},
+							Cache: &InferenceStepCache{
+								TTLSeconds: 60,
+								MaxEntries: proto.Int32(0),
+							},
+						},
+					},
+				},
+			},
+			errMatcher:      gomega.MatchError(fmt.Errorf(InvalidStepPolicyError, 0, "step1", GraphRootNodeName, "foo-bar", "cache.maxEntries", "must be greater than or equal to 1")),
+			warningsMatcher: gomega.BeEmpty(),
+		},
+		"invalid cache key fields": {
+			ig: makeTestInferenceGraph(),
+			nodes: map[string]InferenceRouter{
+				GraphRootNodeName: {
+					RouterType: Sequence,
+					Steps: []InferenceStep{
+						{
+							StepName: "step1",
+							InferenceTarget: InferenceTarget{
+								ServiceName: "service1",
+							},
+							Cache: &InferenceStepCache{
+								TTLSeconds: 60,
+								KeyFields:  []string{""},
+							},
+						},
+					},
+				},
+			},
+			errMatcher:      gomega.MatchError(fmt.Errorf(InvalidStepPolicyError, 0, "step1", GraphRootNodeName, "foo-bar", "cache.keyFields", "must not contain empty paths")),
+			warningsMatcher: gomega.BeEmpty(),
+		},
+		"with redis cache": {
+			ig: func() InferenceGraph {
+				ig := makeTestInferenceGraph()
+				ig.Spec.Cache = &InferenceGraphCache{
+					Redis: &RedisCache{Address: "redis:6379", Database: 1},
+				}
+				return ig
+			}(),
+			nodes: map[string]InferenceRouter{
+				GraphRootNodeName: {
+					RouterType: Sequence,
+					Steps: []InferenceStep{
+						{
+							StepName: "step1",
+							InferenceTarget: InferenceTarget{
+								ServiceName: "service1",
+							},
+							Cache: &InferenceStepCache{
+								TTLSeconds: 60,
+							},
+						},
+					},
+				},
+			},
+			errMatcher:      gomega.MatchError(nil),
+			warningsMatcher: gomega.BeEmpty(),
+		},
+		"missing redis address": {
+			ig: func() InferenceGraph {
+				ig := makeTestInferenceGraph()
+				ig.Spec.Cache = &InferenceGraphCache{
+					Redis: &RedisCache{},
+				}
+				return ig
+			}(),
+			nodes: map[string]InferenceRouter{
+				GraphRootNodeName: {
+					RouterType: Sequence,
+					Steps: []InferenceStep{
+						{
+							StepName: "step1",
+							InferenceTarget: InferenceTarget{
+								ServiceName: "service1",
+							},
+							Cache: &InferenceStepCache{
+								TTLSeconds: 60,
+							},
+						},
+					},
+				},
+			},
+			errMatcher:      gomega.MatchError(fmt.Errorf(InvalidGraphCacheError, "foo-bar", "redis.address", "must not be empty")),
+			warningsMatcher: gomega.BeEmpty(),
+		},
+		"sticky splitter": {
+			ig: makeTestInferenceGraph(),
+			nodes: map[string]InferenceRouter{
+				GraphRootNodeName: {
+					RouterType: Splitter,
+					Steps: []InferenceStep{
+						{
+							StepName: "step1",
+							InferenceTarget: InferenceTarget{
+								ServiceName: "service1",
+							},
+							Weight: proto.Int64(60),
+						},
+						{
+							StepName: "step2",
+							InferenceTarget: InferenceTarget{
+								ServiceName: "service2",
+							},
+							Weight: proto.Int64(40),
+						},
+					},
+					Stickiness: &SplitterStickiness{Header: "X-User-Id"},
+				},
+			},
+			errMatcher:      gomega.MatchError(nil),
+			warningsMatcher: gomega.BeEmpty(),
+		},
+		"sticky splitter with cookie": {
+			ig: makeTestInferenceGraph(),
+			nodes: map[string]InferenceRouter{
+				GraphRootNodeName: {
+					RouterType: Splitter,
+					Steps: []InferenceStep{
+						{
+							StepName: "step1",
+							InferenceTarget: InferenceTarget{
+								ServiceName: "service1",
+							},
+							Weight: proto.Int64(60),
+						},
+						{
+							StepName: "step2",
+							InferenceTarget: InferenceTarget{
+								ServiceName: "service2",
+							},
+							Weight: proto.Int64(40),
+						},
+					},
+					Stickiness: &SplitterStickiness{Cookie: "session"},
+				},
+			},
+			errMatcher:      gomega.MatchError(nil),
+			warningsMatcher: gomega.BeEmpty(),
+		},
+		"sticky sequence": {
+			ig: makeTestInferenceGraph(),
+			nodes: map[string]InferenceRouter{
+				GraphRootNodeName: {
+					RouterType: Sequence,
+					Steps: []InferenceStep{
+						{
+							StepName: "step1",
+							InferenceTarget: InferenceTarget{
+								ServiceName: "service1",
+							},
+							Weight: proto.Int64(60),
+						},
+						{
+							StepName: "step2",
+							InferenceTarget: InferenceTarget{
+								ServiceName: "service2",
+							},
+							Weight: proto.Int64(40),
+						},
+					},
+					Stickiness: &SplitterStickiness{Header: "X-User-Id"},
+				},
+			},
+			errMatcher:      gomega.MatchError(fmt.Errorf(InvalidSplitterStickinessError, GraphRootNodeName, "foo-bar", "only Splitter nodes can be sticky")),
+			warningsMatcher: gomega.BeEmpty(),
+		},
+		"stickiness with header and cookie": {
+			ig: makeTestInferenceGraph(),
+			nodes: map[string]InferenceRouter{
+				GraphRootNodeName: {
+					RouterType: Splitter,
+					Steps: []InferenceStep{
+						{
+							StepName: "step1",
+							InferenceTarget: InferenceTarget{
+								ServiceName: "service1",
+							},
+							Weight: proto.Int64(60),
+						},
+						{
+							StepName: "step2",
+							InferenceTarget: InferenceTarget{
+								ServiceName: "service2",
+							},
+							Weight: proto.Int64(40),
+						},
+					},
+					Stickiness: &SplitterStickiness{Header: "X-User-Id", Cookie: "session"},
+				},
+			},
+			errMatcher:      gomega.MatchError(fmt.Errorf(InvalidSplitterStickinessError, GraphRootNodeName, "foo-bar", "exactly one of header and cookie must be specified")),
+			warningsMatcher: gomega.BeEmpty(),
+		},
+		"stickiness without key": {
+			ig: makeTestInferenceGraph(),
+			nodes: map[string]InferenceRouter{
+				GraphRootNodeName: {
+					RouterType: Splitter,
+					Steps: []InferenceStep{
+						{
+							StepName: "step1",
+							InferenceTarget: InferenceTarget{
+								ServiceName: "service1",
+							},
+							Weight: proto.Int64(60),
+						},
+						{
+							StepName: "step2",
+							InferenceTarget: InferenceTarget{
+								ServiceName: "service2",
+							},
+							Weight: proto.Int64(40),
+						},
+					},
+					Stickiness: &SplitterStickiness{},
+				},
+			},
+			errMatcher:      gomega.MatchError(fmt.Errorf(InvalidSplitterStickinessError, GraphRootNodeName, "foo-bar", "exactly one of header and cookie must be specified")),
+			warningsMatcher: gomega.BeEmpty(),
+		},
+		"stickiness with invalid header": {
+			ig: makeTestInferenceGraph(),
+			nodes: map[string]InferenceRouter{
+				GraphRootNodeName: {
+					RouterType: Splitter,
+					Steps: []InferenceStep{
+						{
+							StepName: "step1",
+							InferenceTarget: InferenceTarget{
+								ServiceName: "service1",
+							},
+							Weight: proto.Int64(60),
+						},
+						{
+							StepName: "step2",
+							InferenceTarget: InferenceTarget{
+								ServiceName: "service2",
+							},
+							Weight: proto.Int64(40),
+						},
+					},
+					Stickiness: &SplitterStickiness{Header: "X User"},
+				},
+			},
+			errMatcher:      gomega.MatchError(fmt.Errorf(InvalidSplitterStickinessError, GraphRootNodeName, "foo-bar", "\"X User\" is not a valid header or cookie name")),
+			warningsMatcher: gomega.BeEmpty(),
+		},
+		"with logger": {
+			ig: func() InferenceGraph {
+				ig := makeTestInferenceGraph()
+				ig.Spec.Logger = &InferenceGraphLogger{URL: "http://message-dumper.default/", Mode: GraphLogErrors, SamplingRate: proto.String("0.5"), Steps: true}
+				return ig
+			}(),
+			nodes: map[string]InferenceRouter{
+				GraphRootNodeName: {
+					RouterType: Sequence,
+					Steps: []InferenceStep{
+						{
+							StepName: "step1",
+							InferenceTarget: InferenceTarget{
+								ServiceName: "service1",
+							},
+						},
+					},
+				},
+			},
+			errMatcher:      gomega.MatchError(nil),
+			warningsMatcher: gomega.BeEmpty(),
+		},
+		"with kafka logger": {
+			ig: func() InferenceGraph {
+				ig := makeTestInferenceGraph()
+				ig.Spec.Logger = &InferenceGraphLogger{URL: "kafka://broker1:9092,broker2:9092/inference-logs"}
+				return ig
+			}(),
+			nodes: map[string]InferenceRouter{
+				GraphRootNodeName: {
+					RouterType: Sequence,
+					Steps: []InferenceStep{
+						{
+							StepName: "step1",
+							InferenceTarget: InferenceTarget{
+								ServiceName: "service1",
+							},
+						},
+					},
+				},
+			},
+			errMatcher:      gomega.MatchError(nil),
+			warningsMatcher: gomega.BeEmpty(),
+		},
+		"missing logger url": {
+			ig: func() InferenceGraph {
+				ig := makeTestInferenceGraph()
+				ig.Spec.Logger = &InferenceGraphLogger{Mode: GraphLogAll}
+				return ig
+			}(),
+			nodes: map[string]InferenceRouter{
+				GraphRootNodeName: {
+					RouterType: Sequence,
+					Steps: []InferenceStep{
+						{
+							StepName: "step1",
+							InferenceTarget: InferenceTarget{
+								ServiceName: "service1",
+							},
+						},
+					},
+				},
+			},
+			errMatcher:      gomega.MatchError(fmt.Errorf(InvalidGraphLoggerError, "foo-bar", "url", "must not be empty")),
+			warningsMatcher: gomega.BeEmpty(),
+		},
+		"kafka logger url without topic": {
+			ig: func() InferenceGraph {
+				ig := makeTestInferenceGraph()
+				ig.Spec.Logger = &InferenceGraphLogger{URL: "kafka://broker1:9092"}
+				return ig
+			}(),
+			nodes: map[string]InferenceRouter{
+				GraphRootNodeName: {
+					RouterType: Sequence,
+					Steps: []InferenceStep{
+						{
+							StepName: "step1",
+							InferenceTarget: InferenceTarget{
+								ServiceName: "service1",
+							},
+						},
+					},
+				},
+			},
+			errMatcher:      gomega.MatchError(fmt.Errorf(InvalidGraphLoggerError, "foo-bar", "url", "a kafka url must have at least one broker and exactly one topic")),
+			warningsMatcher: gomega.BeEmpty(),
+		},
+		"invalid logger mode": {
+			ig: func() InferenceGraph {
+				ig := makeTestInferenceGraph()
+				ig.Spec.Logger = &InferenceGraphLogger{URL: "http://message-dumper.default/", Mode: "steps"}
+				return ig
+			}(),
+			nodes: map[string]InferenceRouter{
+				GraphRootNodeName: {
+					RouterType: Sequence,
+					Steps: []InferenceStep{
+						{
+							StepName: "step1",
+							InferenceTarget: InferenceTarget{
+								ServiceName: "service1",
+							},
+						},
+					},
+				},
+			},
+			errMatcher:      gomega.MatchError(fmt.Errorf(InvalidGraphLoggerError, "foo-bar", "mode", "unknown mode \"steps\", must be one of all, request, response or errors")),
+			warningsMatcher: gomega.BeEmpty(),
+		},
+		"invalid logger sampling rate": {
+			ig: func() InferenceGraph {
+				ig := makeTestInferenceGraph()
+				ig.Spec.Logger = &InferenceGraphLogger{URL: "http://message-dumper.default/", SamplingRate: proto.String("1.5")}
+				return ig
+			}(),
+			nodes: map[string]InferenceRouter{
+				GraphRootNodeName: {
+					RouterType: Sequence,
+					Steps: []InferenceStep{
+						{
+							StepName: "step1",
+							InferenceTarget: InferenceTarget{
+								ServiceName: "service1",
+							},
+						},
+					},
+				},
+			},
+			errMatcher:      gomega.MatchError(fmt.Errorf(InvalidGraphLoggerError, "foo-bar", "samplingRate", "\"1.5\" must be a decimal number between 0.0 and 1.0")),
+			warningsMatcher: gomega.BeEmpty(),
+		},
 	}
 
 	for testName, scenario := range scenarios {
@@ -281,10 +1484,10 @@ func TestInferenceGraph_ValidateCreate(t *testing.T) {
 
 func TestInferenceGraph_ValidateUpdate(t *testing.T) {
 	g := gomega.NewGomegaWithT(t)
-	temptIg := makeTestTrainModel()
-	old := temptIg.DeepCopyObject()
 	scenarios := map[string]struct {
 		ig              InferenceGraph
+		oldAnnotations  map[string]string
+		annotations     map[string]string
 		update          map[string]string
 		nodes           map[string]InferenceRouter
 		errMatcher      types.GomegaMatcher
@@ -298,6 +1501,26 @@ func TestInferenceGraph_ValidateUpdate(t *testing.T) {
 			errMatcher:      gomega.MatchError(nil),
 			warningsMatcher: gomega.BeEmpty(),
 		},
+		"existing annotation typo": {
+			ig:             makeTestInferenceGraph(),
+			oldAnnotations: map[string]string{"serving.kserve.io/deploymentmode": "RawDeployment"},
+			annotations:    map[string]string{"serving.kserve.io/deploymentmode": "RawDeployment"},
+			nodes: map[string]InferenceRouter{
+				GraphRootNodeName: {},
+			},
+			errMatcher:      gomega.MatchError(nil),
+			warningsMatcher: gomega.BeEmpty(),
+		},
+		"added annotation typo": {
+			ig:          makeTestInferenceGraph(),
+			annotations: map[string]string{"serving.kserve.io/deploymentmode": "RawDeployment"},
+			nodes: map[string]InferenceRouter{
+				GraphRootNodeName: {},
+			},
+			errMatcher: gomega.MatchError(fmt.Errorf(utils.AnnotationTypoError, "serving.kserve.io/deploymentmode",
+				constants.DeploymentMode, constants.KServeAPIGroupName, constants.CustomAnnotationsAnnotationKey)),
+			warningsMatcher: gomega.BeEmpty(),
+		},
 	}
 
 	for testName, scenario := range scenarios {
@@ -307,7 +1530,10 @@ func TestInferenceGraph_ValidateUpdate(t *testing.T) {
 				ig.update(igField, value)
 			}
 			ig.Spec.Nodes = scenario.nodes
-			warnings, err := scenario.ig.ValidateUpdate(old)
+			ig.Annotations = scenario.annotations
+			old := makeTestInferenceGraph()
+			old.Annotations = scenario.oldAnnotations
+			warnings, err := scenario.ig.ValidateUpdate(&old)
 			if !g.Expect(gomega.MatchError(err)).To(gomega.Equal(scenario.errMatcher)) {
 				t.Errorf("got %t, want %t", err, scenario.errMatcher)
 			}
@@ -355,8 +1581,62 @@ func TestInferenceGraph_ValidateDelete(t *testing.T) {
 	}
 }
 
+func propagateHeadersPatternError(pattern string) error {
+	_, err := regexp.Compile(pattern)
+	return err
+}
+
+func conditionCompileError(routerType InferenceRouterType, expression string) string {
+	_, err := CompileStepCondition(routerType, expression)
+	return err.Error()
+}
+
 func (ig *InferenceGraph) update(igField string, value string) {
 	if igField == "Name" {
 		ig.Name = value
 	}
 }
+
+func TestInferenceGraph_ValidateAnnotationKeys(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	scenarios := map[string]struct {
+		annotations     map[string]string
+		errMatcher      types.GomegaMatcher
+		warningsMatcher types.GomegaMatcher
+	}{
+		"known annotation": {
+			annotations:     map[string]string{"serving.kserve.io/deploymentMode": "RawDeployment"},
+			errMatcher:      gomega.BeNil(),
+			warningsMatcher: gomega.BeEmpty(),
+		},
+		"annotation typo": {
+			annotations:     map[string]string{"serving.kserve.io/deploymentmode": "RawDeployment"},
+			errMatcher:      gomega.MatchError(gomega.ContainSubstring("did you mean \"serving.kserve.io/deploymentMode\"?")),
+			warningsMatcher: gomega.BeEmpty(),
+		},
+		"unknown annotation": {
+			annotations:     map[string]string{"serving.kserve.io/team": "fraud"},
+			errMatcher:      gomega.BeNil(),
+			warningsMatcher: gomega.ConsistOf("the annotation \"serving.kserve.io/team\" is not known to KServe and is ignored"),
+		},
+		"custom annotation": {
+			annotations: map[string]string{
+				"serving.kserve.io/custom-annotations": "serving.kserve.io/team",
+				"serving.kserve.io/team":               "fraud",
+			},
+			errMatcher:      gomega.BeNil(),
+			warningsMatcher: gomega.BeEmpty(),
+		},
+	}
+
+	for testName, scenario := range scenarios {
+		t.Run(testName, func(t *testing.T) {
+			ig := makeTestInferenceGraph()
+			ig.Annotations = scenario.annotations
+			ig.Spec.Nodes = map[string]InferenceRouter{GraphRootNodeName: {}}
+			warnings, err := ig.ValidateCreate()
+			g.Expect(err).To(scenario.errMatcher)
+			g.Expect(warnings).To(scenario.warningsMatcher)
+		})
+	}
+}
@@ -17,9 +17,23 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+
 	"github.com/kserve/kserve/pkg/constants"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// InheritFromNotFoundError is returned when the ClusterServingRuntime a ServingRuntime inherits from does not exist
+	InheritFromNotFoundError = "the ClusterServingRuntime %s inherited by the ServingRuntime %s does not exist"
+	// InheritFromChainError is returned when the ClusterServingRuntime a ServingRuntime inherits from inherits itself
+	InheritFromChainError = "the ClusterServingRuntime %s inherited by the ServingRuntime %s cannot inherit from %s, inheritFrom is only supported by namespaced ServingRuntimes"
 )
 
 // +k8s:openapi-gen=true
@@ -29,7 +43,8 @@ type SupportedModelFormat struct {
 	Name string `json:"name"`
 	// Version of the model format.
 	// Used in validating that a predictor is supported by a runtime.
-	// Can be "major", "major.minor" or "major.minor.patch".
+	// Can be "major", "major.minor" or "major.minor.patch", or a range of versions
+	// such as ">=1.2, <2", "^1.2" or "~1.2", alternatives being separated by "||".
 	// +optional
 	Version *string `json:"version,omitempty"`
 	// Set to true to allow the ServingRuntime to be used for automatic model placement if
@@ -128,6 +143,12 @@ type ServingRuntimeSpec struct {
 
 	ServingRuntimePodSpec `json:",inline"`
 
+	// Name of the ClusterServingRuntime this ServingRuntime inherits from. The fields set in this ServingRuntime are
+	// strategic merged over the spec of the ClusterServingRuntime: containers are merged by name, other lists are
+	// replaced. Only supported by namespaced ServingRuntimes.
+	// +optional
+	InheritFrom *string `json:"inheritFrom,omitempty"`
+
 	// The following fields apply to ModelMesh deployments.
 
 	// Name for each of the Endpoint fields is either like "port:1234" or "unix:/tmp/kserve/grpc.sock"
@@ -252,6 +273,10 @@ type ClusterServingRuntimeList struct {
 type SupportedRuntime struct {
 	Name string
 	Spec ServingRuntimeSpec
+	// Generation of the ServingRuntime
+	Generation int64
+	// Generation of the ClusterServingRuntime the ServingRuntime inherits from
+	InheritFromGeneration int64
 }
 
 func init() {
@@ -292,3 +317,70 @@ func (srSpec *ServingRuntimeSpec) GetPriority(modelName string) *int32 {
 func (m *SupportedModelFormat) IsAutoSelectEnabled() bool {
 	return m.AutoSelect != nil && *m.AutoSelect
 }
+
+// MergeServingRuntimeSpec strategic merges the fields set in the spec of a ServingRuntime over the spec of the
+// ClusterServingRuntime it inherits from. Containers, volumes and environment variables are merged by name, the
+// other lists are replaced.
+func MergeServingRuntimeSpec(parent *ServingRuntimeSpec, spec *ServingRuntimeSpec) (*ServingRuntimeSpec, error) {
+	parentJSON, err := json.Marshal(parent)
+	if err != nil {
+		return nil, err
+	}
+	specJSON, err := json.Marshal(spec)
+	if err != nil {
+		return nil, err
+	}
+	// Unset fields are marshalled as null, which would delete the field of the parent
+	patch := map[string]interface{}{}
+	if err := json.Unmarshal(specJSON, &patch); err != nil {
+		return nil, err
+	}
+	for key, value := range patch {
+		if value == nil {
+			delete(patch, key)
+		}
+	}
+	patchJSON, err := json.Marshal(patch)
+	if err != nil {
+		return nil, err
+	}
+	mergedJSON, err := strategicpatch.StrategicMergePatch(parentJSON, patchJSON, ServingRuntimeSpec{})
+	if err != nil {
+		return nil, err
+	}
+	merged := &ServingRuntimeSpec{}
+	if err := json.Unmarshal(mergedJSON, merged); err != nil {
+		return nil, err
+	}
+	return merged, nil
+}
+
+// ResolveServingRuntime returns the effective runtime of a ServingRuntime: its spec merged over the spec of the
+// ClusterServingRuntime it inherits from, if any, with the generations both were observed at.
+func ResolveServingRuntime(cl client.Client, runtime *ServingRuntime) (*SupportedRuntime, error) {
+	resolved := &SupportedRuntime{
+		Name:       runtime.Name,
+		Spec:       *runtime.Spec.DeepCopy(),
+		Generation: runtime.Generation,
+	}
+	if runtime.Spec.InheritFrom == nil {
+		return resolved, nil
+	}
+	parent := &ClusterServingRuntime{}
+	if err := cl.Get(context.TODO(), client.ObjectKey{Name: *runtime.Spec.InheritFrom}, parent); err != nil {
+		if errors.IsNotFound(err) {
+			return nil, fmt.Errorf(InheritFromNotFoundError, *runtime.Spec.InheritFrom, runtime.Name)
+		}
+		return nil, err
+	}
+	if parent.Spec.InheritFrom != nil {
+		return nil, fmt.Errorf(InheritFromChainError, parent.Name, runtime.Name, *parent.Spec.InheritFrom)
+	}
+	merged, err := MergeServingRuntimeSpec(&parent.Spec, &runtime.Spec)
+	if err != nil {
+		return nil, err
+	}
+	resolved.Spec = *merged
+	resolved.InheritFromGeneration = parent.Generation
+	return resolved, nil
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"context"
 	"fmt"
 	"github.com/kserve/kserve/pkg/constants"
 	"github.com/onsi/gomega"
@@ -25,6 +26,10 @@ import (
 
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/yaml"
 )
 
@@ -643,3 +648,181 @@ func TestSupportedModelFormat_IsAutoSelectEnabled(t *testing.T) {
 		})
 	}
 }
+
+func TestMergeServingRuntimeSpec(t *testing.T) {
+	parent := &ServingRuntimeSpec{
+		SupportedModelFormats: []SupportedModelFormat{
+			{Name: "sklearn", Version: proto.String("1"), AutoSelect: proto.Bool(true), Priority: proto.Int32(1)},
+			{Name: "xgboost", Version: proto.String("1"), AutoSelect: proto.Bool(true), Priority: proto.Int32(1)},
+		},
+		ProtocolVersions: []constants.InferenceServiceProtocol{constants.ProtocolV1, constants.ProtocolV2},
+		ServingRuntimePodSpec: ServingRuntimePodSpec{
+			Containers: []v1.Container{
+				{
+					Name:  constants.InferenceServiceContainerName,
+					Image: "kserve/sklearnserver:v0.11.0",
+					Args:  []string{"--model_name={{.Name}}", "--model_dir=/mnt/models", "--http_port=8080"},
+					Env: []v1.EnvVar{
+						{Name: "MODELS_DIR", Value: "/mnt/models"},
+						{Name: "WORKERS", Value: "1"},
+					},
+					Resources: v1.ResourceRequirements{
+						Limits: v1.ResourceList{v1.ResourceCPU: resource.MustParse("1"), v1.ResourceMemory: resource.MustParse("2Gi")},
+					},
+				},
+				{Name: "sidecar", Image: "sidecar:latest"},
+			},
+			Labels: map[string]string{"team": "platform"},
+		},
+	}
+
+	scenarios := map[string]struct {
+		spec     *ServingRuntimeSpec
+		expected *ServingRuntimeSpec
+	}{
+		"Empty spec keeps the parent": {
+			spec: &ServingRuntimeSpec{InheritFrom: proto.String("kserve-sklearnserver")},
+			expected: func() *ServingRuntimeSpec {
+				s := parent.DeepCopy()
+				s.InheritFrom = proto.String("kserve-sklearnserver")
+				return s
+			}(),
+		},
+		"Containers are merged by name": {
+			spec: &ServingRuntimeSpec{
+				InheritFrom: proto.String("kserve-sklearnserver"),
+				ServingRuntimePodSpec: ServingRuntimePodSpec{
+					Containers: []v1.Container{
+						{
+							Name:  constants.InferenceServiceContainerName,
+							Image: "kserve/sklearnserver:v0.11.1",
+							Env:   []v1.EnvVar{{Name: "WORKERS", Value: "4"}, {Name: "LOG_LEVEL", Value: "debug"}},
+							Resources: v1.ResourceRequirements{
+								Limits: v1.ResourceList{v1.ResourceMemory: resource.MustParse("4Gi")},
+							},
+						},
+					},
+				},
+			},
+			expected: func() *ServingRuntimeSpec {
+				s := parent.DeepCopy()
+				s.InheritFrom = proto.String("kserve-sklearnserver")
+				s.Containers[0].Image = "kserve/sklearnserver:v0.11.1"
+				s.Containers[0].Env = []v1.EnvVar{
+					{Name: "MODELS_DIR", Value: "/mnt/models"},
+					{Name: "WORKERS", Value: "4"},
+					{Name: "LOG_LEVEL", Value: "debug"},
+				}
+				s.Containers[0].Resources.Limits[v1.ResourceMemory] = resource.MustParse("4Gi")
+				return s
+			}(),
+		},
+		"Other lists are replaced": {
+			spec: &ServingRuntimeSpec{
+				InheritFrom: proto.String("kserve-sklearnserver"),
+				SupportedModelFormats: []SupportedModelFormat{
+					{Name: "sklearn", Version: proto.String("1"), AutoSelect: proto.Bool(true), Priority: proto.Int32(2)},
+				},
+				ProtocolVersions: []constants.InferenceServiceProtocol{constants.ProtocolV2},
+				ServingRuntimePodSpec: ServingRuntimePodSpec{
+					Containers: []v1.Container{
+						{Name: constants.InferenceServiceContainerName, Args: []string{"--model_name={{.Name}}"}},
+					},
+				},
+			},
+			expected: func() *ServingRuntimeSpec {
+				s := parent.DeepCopy()
+				s.InheritFrom = proto.String("kserve-sklearnserver")
+				s.SupportedModelFormats = []SupportedModelFormat{
+					{Name: "sklearn", Version: proto.String("1"), AutoSelect: proto.Bool(true), Priority: proto.Int32(2)},
+				}
+				s.ProtocolVersions = []constants.InferenceServiceProtocol{constants.ProtocolV2}
+				s.Containers[0].Args = []string{"--model_name={{.Name}}"}
+				return s
+			}(),
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			merged, err := MergeServingRuntimeSpec(parent, scenario.spec)
+			g.Expect(err).NotTo(gomega.HaveOccurred())
+			g.Expect(merged).To(gomega.BeComparableTo(scenario.expected))
+		})
+	}
+}
+
+func TestResolveServingRuntime(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	newClusterRuntime := func(name string, inheritFrom *string) *ClusterServingRuntime {
+		return &ClusterServingRuntime{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: ServingRuntimeSpec{
+				InheritFrom: inheritFrom,
+				ServingRuntimePodSpec: ServingRuntimePodSpec{
+					Containers: []v1.Container{{Name: constants.InferenceServiceContainerName, Image: "kserve/sklearnserver:v0.11.0"}},
+				},
+			},
+		}
+	}
+	s := runtime.NewScheme()
+	g.Expect(AddToScheme(s)).To(gomega.Succeed())
+	mockClient := fake.NewClientBuilder().WithScheme(s).WithObjects(
+		newClusterRuntime("kserve-sklearnserver", nil),
+		newClusterRuntime("sklearn-chained", proto.String("kserve-sklearnserver")),
+	).Build()
+	parent := &ClusterServingRuntime{}
+	g.Expect(mockClient.Get(context.TODO(), client.ObjectKey{Name: "kserve-sklearnserver"}, parent)).To(gomega.Succeed())
+
+	scenarios := map[string]struct {
+		inheritFrom *string
+		expected    *SupportedRuntime
+		err         gomega.OmegaMatcher
+	}{
+		"No inheritFrom": {
+			expected: &SupportedRuntime{Name: "sklearn", Generation: 3, Spec: ServingRuntimeSpec{
+				ServingRuntimePodSpec: ServingRuntimePodSpec{
+					Containers: []v1.Container{{Name: constants.InferenceServiceContainerName, Image: "kserve/sklearnserver:v0.11.1"}},
+				},
+			}},
+			err: gomega.BeNil(),
+		},
+		"Inherit from a ClusterServingRuntime": {
+			inheritFrom: proto.String("kserve-sklearnserver"),
+			expected: &SupportedRuntime{Name: "sklearn", Generation: 3, InheritFromGeneration: parent.Generation, Spec: ServingRuntimeSpec{
+				InheritFrom: proto.String("kserve-sklearnserver"),
+				ServingRuntimePodSpec: ServingRuntimePodSpec{
+					Containers: []v1.Container{{Name: constants.InferenceServiceContainerName, Image: "kserve/sklearnserver:v0.11.1"}},
+				},
+			}},
+			err: gomega.BeNil(),
+		},
+		"Missing ClusterServingRuntime": {
+			inheritFrom: proto.String("kserve-missing"),
+			err:         gomega.MatchError(fmt.Sprintf(InheritFromNotFoundError, "kserve-missing", "sklearn")),
+		},
+		"ClusterServingRuntime inheriting from another one": {
+			inheritFrom: proto.String("sklearn-chained"),
+			err:         gomega.MatchError(fmt.Sprintf(InheritFromChainError, "sklearn-chained", "sklearn", "kserve-sklearnserver")),
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			sr := &ServingRuntime{
+				ObjectMeta: metav1.ObjectMeta{Name: "sklearn", Namespace: "default", Generation: 3},
+				Spec: ServingRuntimeSpec{
+					InheritFrom: scenario.inheritFrom,
+					ServingRuntimePodSpec: ServingRuntimePodSpec{
+						Containers: []v1.Container{{Name: constants.InferenceServiceContainerName, Image: "kserve/sklearnserver:v0.11.1"}},
+					},
+				},
+			}
+			resolved, err := ResolveServingRuntime(mockClient, sr)
+			g.Expect(err).To(scenario.err)
+			if scenario.expected != nil {
+				g.Expect(resolved).To(gomega.BeComparableTo(scenario.expected))
+			}
+		})
+	}
+}
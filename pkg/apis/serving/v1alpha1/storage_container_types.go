@@ -32,6 +32,11 @@ type StorageContainerSpec struct {
 
 	// List of URI formats that this container supports
 	SupportedUriFormats []SupportedUriFormat `json:"supportedUriFormats" validate:"required"`
+
+	// Priority of this container when several storage containers support the same storage URI, the container with
+	// the highest priority is selected. Defaults to 0.
+	// +optional
+	Priority *int32 `json:"priority,omitempty"`
 }
 
 // SupportedUriFormat can be either prefix or regex. Todo: Add validation that only one of them is set.
@@ -73,6 +78,14 @@ func (sc *ClusterStorageContainer) IsDisabled() bool {
 	return sc.Disabled != nil && *sc.Disabled
 }
 
+// GetPriority returns the priority of the storage container, 0 if not set
+func (spec *StorageContainerSpec) GetPriority() int32 {
+	if spec.Priority == nil {
+		return 0
+	}
+	return *spec.Priority
+}
+
 func (spec *StorageContainerSpec) IsStorageUriSupported(storageUri string) (bool, error) {
 	for _, supportedUriFormat := range spec.SupportedUriFormats {
 		if supportedUriFormat.Prefix != "" {
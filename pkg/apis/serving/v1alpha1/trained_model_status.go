@@ -43,6 +43,11 @@ const (
 	MemoryResourceAvailable apis.ConditionType = "MemoryResourceAvailable"
 	// IsMMSPredictor is set when inference service predictor is set to multi-model serving
 	IsMMSPredictor apis.ConditionType = "IsMMSPredictor"
+	// ModelLoaded is set from the model states reported by the agents of the inference service, it does not affect
+	// the readiness as the agents may not report them
+	ModelLoaded apis.ConditionType = "ModelLoaded"
+	// ModelReady is set when the model server of the inference service reported the readiness of the model
+	ModelReady apis.ConditionType = "ModelReady"
 )
 
 // TrainedModel Ready condition is depending on inference service readiness condition
@@ -51,6 +56,7 @@ var conditionSet = apis.NewLivingConditionSet(
 	InferenceServiceReady,
 	MemoryResourceAvailable,
 	IsMMSPredictor,
+	ModelReady,
 )
 
 var _ apis.ConditionsAccessor = (*TrainedModelStatus)(nil)
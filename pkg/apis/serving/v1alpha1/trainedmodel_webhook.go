@@ -39,6 +39,12 @@ const (
 	InvalidTmMemoryModification         = "the Trained Model \"%s\" memory field is immutable. The memory was \"%s\" but it is updated to \"%s\""
 )
 
+const (
+	InvalidOCIStorageUriFormatError  = "the Trained Model \"%s\" storageUri field is invalid. The oci storage uri must be in the oci://<registry>/<repository>[:<tag>][@<digest>] format. (the storage uri given is \"%s\")"
+	InvalidHFStorageUriFormatError   = "the Trained Model \"%s\" storageUri field is invalid. The hf storage uri must be in the hf://[<organization>/]<repository>[@<revision>] format. (the storage uri given is \"%s\")"
+	InvalidSFTPStorageUriFormatError = "the Trained Model \"%s\" storageUri field is invalid. The sftp storage uri must be in the sftp://[<user>@]<host>[:<port>]/<path> format. (the storage uri given is \"%s\")"
+)
+
 var (
 	// log is for logging in this package.
 	tmLogger = logf.Log.WithName("trainedmodel-alpha1-validator")
@@ -114,5 +120,20 @@ func (tm *TrainedModel) validateStorageURI() error {
 	if !utils.IsPrefixSupported(tm.Spec.Model.StorageURI, storage.GetAllProtocol()) {
 		return fmt.Errorf(InvalidStorageUriFormatError, tm.Name, StorageUriProtocols, tm.Spec.Model.StorageURI)
 	}
+	if strings.HasPrefix(tm.Spec.Model.StorageURI, string(storage.OCI)) {
+		if _, err := storage.ParseOCIReference(tm.Spec.Model.StorageURI); err != nil {
+			return fmt.Errorf(InvalidOCIStorageUriFormatError, tm.Name, tm.Spec.Model.StorageURI)
+		}
+	}
+	if strings.HasPrefix(tm.Spec.Model.StorageURI, string(storage.HF)) {
+		if _, err := storage.ParseHFReference(tm.Spec.Model.StorageURI); err != nil {
+			return fmt.Errorf(InvalidHFStorageUriFormatError, tm.Name, tm.Spec.Model.StorageURI)
+		}
+	}
+	if strings.HasPrefix(tm.Spec.Model.StorageURI, string(storage.SFTP)) {
+		if _, err := storage.ParseSFTPURI(tm.Spec.Model.StorageURI); err != nil {
+			return fmt.Errorf(InvalidSFTPStorageUriFormatError, tm.Name, tm.Spec.Model.StorageURI)
+		}
+	}
 	return nil
 }
@@ -125,6 +125,54 @@ func TestValidateCreate(t *testing.T) {
 			errMatcher:      gomega.MatchError(fmt.Errorf(InvalidStorageUriFormatError, "bar", StorageUriProtocols, "foo://kfserving/sklearn/iris")),
 			warningsMatcher: gomega.BeEmpty(),
 		},
+		"oci storageURI": {
+			tm: makeTestTrainModel(),
+			update: map[string]string{
+				storageURI: "oci://registry.example.com/models/bert:v3",
+			},
+			errMatcher:      gomega.MatchError(nil),
+			warningsMatcher: gomega.BeEmpty(),
+		},
+		"invalid oci storageURI": {
+			tm: makeTestTrainModel(),
+			update: map[string]string{
+				storageURI: "oci://registry.example.com/Models/bert:v3",
+			},
+			errMatcher:      gomega.MatchError(fmt.Errorf(InvalidOCIStorageUriFormatError, "bar", "oci://registry.example.com/Models/bert:v3")),
+			warningsMatcher: gomega.BeEmpty(),
+		},
+		"hf storageURI": {
+			tm: makeTestTrainModel(),
+			update: map[string]string{
+				storageURI: "hf://meta-llama/Llama-2-7b-hf@v1.0",
+			},
+			errMatcher:      gomega.MatchError(nil),
+			warningsMatcher: gomega.BeEmpty(),
+		},
+		"invalid hf storageURI": {
+			tm: makeTestTrainModel(),
+			update: map[string]string{
+				storageURI: "hf://meta-llama/Llama-2-7b-hf/main",
+			},
+			errMatcher:      gomega.MatchError(fmt.Errorf(InvalidHFStorageUriFormatError, "bar", "hf://meta-llama/Llama-2-7b-hf/main")),
+			warningsMatcher: gomega.BeEmpty(),
+		},
+		"sftp storageURI": {
+			tm: makeTestTrainModel(),
+			update: map[string]string{
+				storageURI: "sftp://models@sftp.example.com:2222/srv/models/sklearn",
+			},
+			errMatcher:      gomega.MatchError(nil),
+			warningsMatcher: gomega.BeEmpty(),
+		},
+		"invalid sftp storageURI": {
+			tm: makeTestTrainModel(),
+			update: map[string]string{
+				storageURI: "sftp://sftp.example.com",
+			},
+			errMatcher:      gomega.MatchError(fmt.Errorf(InvalidSFTPStorageUriFormatError, "bar", "sftp://sftp.example.com")),
+			warningsMatcher: gomega.BeEmpty(),
+		},
 	}
 
 	for testName, scenario := range scenarios {
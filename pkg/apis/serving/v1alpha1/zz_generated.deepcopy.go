@@ -173,6 +173,21 @@ func (in *ClusterStorageContainerList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EnsembleMerge) DeepCopyInto(out *EnsembleMerge) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EnsembleMerge.
+func (in *EnsembleMerge) DeepCopy() *EnsembleMerge {
+	if in == nil {
+		return nil
+	}
+	out := new(EnsembleMerge)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *InferenceGraph) DeepCopyInto(out *InferenceGraph) {
 	*out = *in
@@ -200,6 +215,26 @@ func (in *InferenceGraph) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InferenceGraphCache) DeepCopyInto(out *InferenceGraphCache) {
+	*out = *in
+	if in.Redis != nil {
+		in, out := &in.Redis, &out.Redis
+		*out = new(RedisCache)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InferenceGraphCache.
+func (in *InferenceGraphCache) DeepCopy() *InferenceGraphCache {
+	if in == nil {
+		return nil
+	}
+	out := new(InferenceGraphCache)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *InferenceGraphList) DeepCopyInto(out *InferenceGraphList) {
 	*out = *in
@@ -232,6 +267,26 @@ func (in *InferenceGraphList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InferenceGraphLogger) DeepCopyInto(out *InferenceGraphLogger) {
+	*out = *in
+	if in.SamplingRate != nil {
+		in, out := &in.SamplingRate, &out.SamplingRate
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InferenceGraphLogger.
+func (in *InferenceGraphLogger) DeepCopy() *InferenceGraphLogger {
+	if in == nil {
+		return nil
+	}
+	out := new(InferenceGraphLogger)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *InferenceGraphSpec) DeepCopyInto(out *InferenceGraphSpec) {
 	*out = *in
@@ -268,6 +323,21 @@ func (in *InferenceGraphSpec) DeepCopyInto(out *InferenceGraphSpec) {
 		*out = new(ScaleMetric)
 		**out = **in
 	}
+	if in.Cache != nil {
+		in, out := &in.Cache, &out.Cache
+		*out = new(InferenceGraphCache)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PropagateHeaders != nil {
+		in, out := &in.PropagateHeaders, &out.PropagateHeaders
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Logger != nil {
+		in, out := &in.Logger, &out.Logger
+		*out = new(InferenceGraphLogger)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InferenceGraphSpec.
@@ -311,6 +381,16 @@ func (in *InferenceRouter) DeepCopyInto(out *InferenceRouter) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Merge != nil {
+		in, out := &in.Merge, &out.Merge
+		*out = new(EnsembleMerge)
+		**out = **in
+	}
+	if in.Stickiness != nil {
+		in, out := &in.Stickiness, &out.Stickiness
+		*out = new(SplitterStickiness)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InferenceRouter.
@@ -332,6 +412,26 @@ func (in *InferenceStep) DeepCopyInto(out *InferenceStep) {
 		*out = new(int64)
 		**out = **in
 	}
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Retry != nil {
+		in, out := &in.Retry, &out.Retry
+		*out = new(InferenceStepRetry)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CircuitBreaker != nil {
+		in, out := &in.CircuitBreaker, &out.CircuitBreaker
+		*out = new(InferenceStepCircuitBreaker)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Cache != nil {
+		in, out := &in.Cache, &out.Cache
+		*out = new(InferenceStepCache)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InferenceStep.
@@ -344,6 +444,71 @@ func (in *InferenceStep) DeepCopy() *InferenceStep {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InferenceStepCache) DeepCopyInto(out *InferenceStepCache) {
+	*out = *in
+	if in.MaxEntries != nil {
+		in, out := &in.MaxEntries, &out.MaxEntries
+		*out = new(int32)
+		**out = **in
+	}
+	if in.KeyFields != nil {
+		in, out := &in.KeyFields, &out.KeyFields
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InferenceStepCache.
+func (in *InferenceStepCache) DeepCopy() *InferenceStepCache {
+	if in == nil {
+		return nil
+	}
+	out := new(InferenceStepCache)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InferenceStepCircuitBreaker) DeepCopyInto(out *InferenceStepCircuitBreaker) {
+	*out = *in
+	if in.CooldownSeconds != nil {
+		in, out := &in.CooldownSeconds, &out.CooldownSeconds
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InferenceStepCircuitBreaker.
+func (in *InferenceStepCircuitBreaker) DeepCopy() *InferenceStepCircuitBreaker {
+	if in == nil {
+		return nil
+	}
+	out := new(InferenceStepCircuitBreaker)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InferenceStepRetry) DeepCopyInto(out *InferenceStepRetry) {
+	*out = *in
+	if in.BackoffMilliseconds != nil {
+		in, out := &in.BackoffMilliseconds, &out.BackoffMilliseconds
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InferenceStepRetry.
+func (in *InferenceStepRetry) DeepCopy() *InferenceStepRetry {
+	if in == nil {
+		return nil
+	}
+	out := new(InferenceStepRetry)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *InferenceTarget) DeepCopyInto(out *InferenceTarget) {
 	*out = *in
@@ -375,6 +540,46 @@ func (in *ModelSpec) DeepCopy() *ModelSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RedisCache) DeepCopyInto(out *RedisCache) {
+	*out = *in
+	if in.PasswordSecretRef != nil {
+		in, out := &in.PasswordSecretRef, &out.PasswordSecretRef
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(RedisCacheTLS)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RedisCache.
+func (in *RedisCache) DeepCopy() *RedisCache {
+	if in == nil {
+		return nil
+	}
+	out := new(RedisCache)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RedisCacheTLS) DeepCopyInto(out *RedisCacheTLS) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RedisCacheTLS.
+func (in *RedisCacheTLS) DeepCopy() *RedisCacheTLS {
+	if in == nil {
+		return nil
+	}
+	out := new(RedisCacheTLS)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServingRuntime) DeepCopyInto(out *ServingRuntime) {
 	*out = *in
@@ -527,6 +732,11 @@ func (in *ServingRuntimeSpec) DeepCopyInto(out *ServingRuntimeSpec) {
 		copy(*out, *in)
 	}
 	in.ServingRuntimePodSpec.DeepCopyInto(&out.ServingRuntimePodSpec)
+	if in.InheritFrom != nil {
+		in, out := &in.InheritFrom, &out.InheritFrom
+		*out = new(string)
+		**out = **in
+	}
 	if in.GrpcMultiModelManagementEndpoint != nil {
 		in, out := &in.GrpcMultiModelManagementEndpoint, &out.GrpcMultiModelManagementEndpoint
 		*out = new(string)
@@ -584,6 +794,21 @@ func (in *ServingRuntimeStatus) DeepCopy() *ServingRuntimeStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SplitterStickiness) DeepCopyInto(out *SplitterStickiness) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SplitterStickiness.
+func (in *SplitterStickiness) DeepCopy() *SplitterStickiness {
+	if in == nil {
+		return nil
+	}
+	out := new(SplitterStickiness)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StorageContainerSpec) DeepCopyInto(out *StorageContainerSpec) {
 	*out = *in
@@ -593,6 +818,11 @@ func (in *StorageContainerSpec) DeepCopyInto(out *StorageContainerSpec) {
 		*out = make([]SupportedUriFormat, len(*in))
 		copy(*out, *in)
 	}
+	if in.Priority != nil {
+		in, out := &in.Priority, &out.Priority
+		*out = new(int32)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageContainerSpec.
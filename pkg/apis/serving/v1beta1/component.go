@@ -17,28 +17,54 @@ limitations under the License.
 package v1beta1
 
 import (
+	"encoding/json"
 	"fmt"
+	"net/url"
 	"reflect"
+	"strconv"
 	"strings"
 
 	"github.com/kserve/kserve/pkg/constants"
 	"github.com/kserve/kserve/pkg/utils"
+	"golang.org/x/net/http/httpguts"
+	"google.golang.org/protobuf/proto"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // Known error messages
 const (
-	MinReplicasShouldBeLessThanMaxError = "MinReplicas cannot be greater than MaxReplicas."
-	MinReplicasLowerBoundExceededError  = "MinReplicas cannot be less than 0."
-	MaxReplicasLowerBoundExceededError  = "MaxReplicas cannot be less than 0."
-	ParallelismLowerBoundExceededError  = "Parallelism cannot be less than 0."
-	UnsupportedStorageURIFormatError    = "storageUri, must be one of: [%s] or match https://{}.blob.core.windows.net/{}/{} or be an absolute or relative local path. StorageUri [%s] is not supported."
-	UnsupportedStorageSpecFormatError   = "storage.spec.type, must be one of: [%s]. storage.spec.type [%s] is not supported."
-	InvalidLoggerType                   = "Invalid logger type"
-	InvalidISVCNameFormatError          = "The InferenceService \"%s\" is invalid: a InferenceService name must consist of lower case alphanumeric characters or '-', and must start with alphabetical character. (e.g. \"my-name\" or \"abc-123\", regex used for validation is '%s')"
-	InvalidProtocol                     = "Invalid protocol %s. Must be one of [%s]"
+	MinReplicasShouldBeLessThanMaxError       = "MinReplicas cannot be greater than MaxReplicas."
+	MinReplicasLowerBoundExceededError        = "MinReplicas cannot be less than 0."
+	MaxReplicasLowerBoundExceededError        = "MaxReplicas cannot be less than 0."
+	ParallelismLowerBoundExceededError        = "Parallelism cannot be less than 0."
+	UnsupportedStorageURIFormatError          = "storageUri, must be one of: [%s] or match https://{}.blob.core.windows.net/{}/{} or be an absolute or relative local path. StorageUri [%s] is not supported."
+	UnsupportedStorageSpecFormatError         = "storage.spec.type, must be one of: [%s]. storage.spec.type [%s] is not supported."
+	InvalidLoggerType                         = "Invalid logger type"
+	InvalidKafkaLoggerURLError                = "logger url %q must be of the form kafka://broker1:9092,broker2:9092/topic."
+	LoggerSecretWithoutKafkaURLError          = "logger secretName is only supported by kafka:// logger urls."
+	InvalidLoggerSamplingRateError            = "logger samplingRate %q must be a decimal number between 0.0 and 1.0."
+	InvalidLoggerMaxPayloadBytesError         = "logger maxPayloadBytes must be greater than 0."
+	InvalidLoggerPayloadOverflowError         = "logger payloadOverflow %q must be one of [truncate, drop]."
+	InvalidLoggerContentTypeError             = "logger contentTypeAllowList entry %q must be a media type of the form type/subtype or type/*."
+	InvalidLoggerMetadataHeaderError          = "logger metadataHeaders entry %q must be a valid http header name."
+	RetryAttemptsLowerBoundExceededError      = "Retry attempts cannot be less than 0."
+	RetryPerTryTimeoutLowerBoundExceededError = "Retry perTryTimeout must be greater than 0."
+	RetryTimeoutExceededError                 = "Retry perTryTimeout %d multiplied by attempts %d cannot be greater than the timeout %d."
+	ModelAdaptersWithoutStorageURIError       = "adapters require the storageUri or the storage of the model."
+	InvalidModelAdapterNameError              = "adapter name %q must be a DNS-1123 label of at most %d characters."
+	ReservedModelAdapterNameError             = "adapter name %q is reserved for the model."
+	DuplicateModelAdapterNameError            = "adapter name %q must be unique."
+	ModelAdapterStorageURIRequiredError       = "adapter %q must have a storageUri."
+	InvalidModelAdapterMountPathError         = "mountPath %q of adapter %q must be a clean path under /mnt/models, distinct from and not nested with the mount paths of the other adapters."
+	InvalidISVCNameFormatError                = "The InferenceService \"%s\" is invalid: a InferenceService name must consist of lower case alphanumeric characters or '-', and must start with alphabetical character. (e.g. \"my-name\" or \"abc-123\", regex used for validation is '%s')"
+	InvalidISVCNameLengthError                = "The InferenceService \"%s\" is invalid: the names generated for it in the %s deployment mode would exceed %d characters, the name must be at most %d characters long or the %s annotation must be set to \"true\" to truncate the generated names"
+	InvalidISVCHostError                      = "The InferenceService \"%s\" is invalid: the hosts generated for it by the domain template %q are invalid, shorten its name or its namespace: %v"
+	TruncateGeneratedNamesUpdateError         = "The %s annotation of the InferenceService \"%s\" can not be changed, it would rename the resources generated for it"
+	InvalidProtocol                           = "Invalid protocol %s. Must be one of [%s]"
 )
 
 // Constants
@@ -83,13 +109,30 @@ type ComponentExtensionSpec struct {
 	// Knative Pod Autoscaler(https://knative.dev/docs/serving/autoscaling/autoscaling-metrics).
 	// +optional
 	ScaleMetric *ScaleMetric `json:"scaleMetric,omitempty"`
+	// InitialScale is the number of replicas a new revision scales to before it is marked ready, it sets the
+	// autoscaling.knative.dev/initial-scale annotation. Only applicable for serverless mode.
+	// +optional
+	InitialScale *int `json:"initialScale,omitempty"`
+	// ScaleDownDelay is the duration the request load must stay low before the revision scales down, e.g. 15m,
+	// it sets the autoscaling.knative.dev/scale-down-delay annotation. Only applicable for serverless mode.
+	// +optional
+	ScaleDownDelay *string `json:"scaleDownDelay,omitempty"`
+	// ScaleWindow is the duration of the window over which the autoscaler averages the metric, e.g. 120s,
+	// it sets the autoscaling.knative.dev/window annotation. Only applicable for serverless mode.
+	// +optional
+	ScaleWindow *string `json:"scaleWindow,omitempty"`
 	// ContainerConcurrency specifies how many requests can be processed concurrently, this sets the hard limit of the container
-	// concurrency(https://knative.dev/docs/serving/autoscaling/concurrency).
+	// concurrency(https://knative.dev/docs/serving/autoscaling/concurrency) independently of the soft ScaleTarget,
+	// 0 means unlimited.
 	// +optional
 	ContainerConcurrency *int64 `json:"containerConcurrency,omitempty"`
 	// TimeoutSeconds specifies the number of seconds to wait before timing out a request to the component.
 	// +optional
 	TimeoutSeconds *int64 `json:"timeout,omitempty"`
+	// Retries defines the retry policy of the requests routed to the component by the Istio VirtualService of the
+	// InferenceService. The routes of the Gateway API do not support retries yet.
+	// +optional
+	Retries *RetrySpec `json:"retries,omitempty"`
 	// CanaryTrafficPercent defines the traffic split percentage between the candidate revision and the last ready revision
 	// +optional
 	CanaryTrafficPercent *int64 `json:"canaryTrafficPercent,omitempty"`
@@ -113,6 +156,20 @@ type ComponentExtensionSpec struct {
 	DeploymentStrategy *appsv1.DeploymentStrategy `json:"deploymentStrategy,omitempty"`
 }
 
+// RetrySpec defines the retry policy of the requests to a component
+type RetrySpec struct {
+	// Attempts is the number of retries of a request.
+	Attempts int32 `json:"attempts"`
+	// PerTryTimeoutSeconds specifies the number of seconds to wait before timing out an attempt, defaults to the
+	// timeout of the component.
+	// +optional
+	PerTryTimeoutSeconds *int64 `json:"perTryTimeout,omitempty"`
+	// RetryOn specifies the conditions under which a request is retried as a comma separated list, e.g.
+	// 5xx,connect-failure,refused-stream. See https://istio.io/latest/docs/reference/config/networking/virtual-service/#HTTPRetry
+	// +optional
+	RetryOn string `json:"retryOn,omitempty"`
+}
+
 // ScaleMetric enum
 // +kubebuilder:validation:Enum=cpu;memory;concurrency;rps
 type ScaleMetric string
@@ -124,6 +181,198 @@ const (
 	MetricRPS         ScaleMetric = "rps"
 )
 
+// HPACustomMetric is a Pods or External metric read from the hpa custom metrics annotation, e.g.
+// [{"type": "External", "name": "vllm_queue_depth", "averageValue": "10"}]
+// +kubebuilder:object:generate=false
+// +k8s:openapi-gen=false
+type HPACustomMetric struct {
+	// Type of the metric source, Pods or External
+	Type autoscalingv2.MetricSourceType `json:"type"`
+	// Name of the metric
+	Name string `json:"name"`
+	// Selector of the metric series, passed to the metrics server
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+	// AverageValue is the target value of the metric averaged across all pods
+	AverageValue *resource.Quantity `json:"averageValue,omitempty"`
+	// Value is the target value of an External metric
+	Value *resource.Quantity `json:"value,omitempty"`
+}
+
+// GetHPACustomMetrics parses and validates the custom metrics of the hpa custom metrics annotation.
+// It returns nil if the annotation is not set.
+func GetHPACustomMetrics(annotations map[string]string) ([]HPACustomMetric, error) {
+	value, ok := annotations[constants.HPACustomMetricsAnnotationKey]
+	if !ok {
+		return nil, nil
+	}
+	var metrics []HPACustomMetric
+	if err := json.Unmarshal([]byte(value), &metrics); err != nil {
+		return nil, fmt.Errorf("invalid value for annotation %s: %w", constants.HPACustomMetricsAnnotationKey, err)
+	}
+	for _, metric := range metrics {
+		if metric.Name == "" {
+			return nil, fmt.Errorf("the metrics of annotation %s must have a name", constants.HPACustomMetricsAnnotationKey)
+		}
+		switch metric.Type {
+		case autoscalingv2.PodsMetricSourceType:
+			if metric.AverageValue == nil || metric.Value != nil {
+				return nil, fmt.Errorf("the Pods metric %s of annotation %s must have an averageValue target",
+					metric.Name, constants.HPACustomMetricsAnnotationKey)
+			}
+		case autoscalingv2.ExternalMetricSourceType:
+			if (metric.AverageValue == nil) == (metric.Value == nil) {
+				return nil, fmt.Errorf("the External metric %s of annotation %s must have exactly one of averageValue or value target",
+					metric.Name, constants.HPACustomMetricsAnnotationKey)
+			}
+		default:
+			return nil, fmt.Errorf("the metric type %q of annotation %s is not supported, must be one of %s or %s",
+				metric.Type, constants.HPACustomMetricsAnnotationKey, autoscalingv2.PodsMetricSourceType, autoscalingv2.ExternalMetricSourceType)
+		}
+	}
+	return metrics, nil
+}
+
+// GetHPAScalingBehavior parses and validates the hpa scale up and scale down annotations, e.g.
+// serving.kserve.io/hpa-scale-down-stabilization: "600" and
+// serving.kserve.io/hpa-scale-down-policies: '[{"type": "Pods", "value": 1, "periodSeconds": 300}]'.
+// It returns nil if none of the annotations is set.
+func GetHPAScalingBehavior(annotations map[string]string) (*autoscalingv2.HorizontalPodAutoscalerBehavior, error) {
+	scaleUp, err := getHPAScalingRules(annotations, constants.HPAScaleUpStabilizationAnnotationKey, constants.HPAScaleUpPoliciesAnnotationKey)
+	if err != nil {
+		return nil, err
+	}
+	scaleDown, err := getHPAScalingRules(annotations, constants.HPAScaleDownStabilizationAnnotationKey, constants.HPAScaleDownPoliciesAnnotationKey)
+	if err != nil {
+		return nil, err
+	}
+	if scaleUp == nil && scaleDown == nil {
+		return nil, nil
+	}
+	return &autoscalingv2.HorizontalPodAutoscalerBehavior{
+		ScaleUp:   scaleUp,
+		ScaleDown: scaleDown,
+	}, nil
+}
+
+func getHPAScalingRules(annotations map[string]string, stabilizationKey string, policiesKey string) (*autoscalingv2.HPAScalingRules, error) {
+	stabilization, hasStabilization := annotations[stabilizationKey]
+	policies, hasPolicies := annotations[policiesKey]
+	if !hasStabilization && !hasPolicies {
+		return nil, nil
+	}
+	rules := &autoscalingv2.HPAScalingRules{}
+	if hasStabilization {
+		window, err := strconv.ParseInt(stabilization, 10, 32)
+		if err != nil || window < 0 || window > constants.MaxHPAStabilizationWindowSeconds {
+			return nil, fmt.Errorf("the %s annotation should be an integer between 0 and %d",
+				stabilizationKey, constants.MaxHPAStabilizationWindowSeconds)
+		}
+		rules.StabilizationWindowSeconds = proto.Int32(int32(window))
+	}
+	if hasPolicies {
+		if err := json.Unmarshal([]byte(policies), &rules.Policies); err != nil {
+			return nil, fmt.Errorf("invalid value for annotation %s: %w", policiesKey, err)
+		}
+		for _, policy := range rules.Policies {
+			if policy.Type != autoscalingv2.PodsScalingPolicy && policy.Type != autoscalingv2.PercentScalingPolicy {
+				return nil, fmt.Errorf("the policy type %q of annotation %s is not supported, must be one of %s or %s",
+					policy.Type, policiesKey, autoscalingv2.PodsScalingPolicy, autoscalingv2.PercentScalingPolicy)
+			}
+			if policy.Value <= 0 {
+				return nil, fmt.Errorf("the policy value of annotation %s should be greater than 0", policiesKey)
+			}
+			if policy.PeriodSeconds <= 0 || policy.PeriodSeconds > constants.MaxHPAScalingPolicyPeriodSeconds {
+				return nil, fmt.Errorf("the policy periodSeconds of annotation %s should be between 1 and %d",
+					policiesKey, constants.MaxHPAScalingPolicyPeriodSeconds)
+			}
+		}
+	}
+	return rules, nil
+}
+
+// KedaTrigger is a KEDA scaler trigger read from the keda triggers annotation, e.g.
+// [{"type": "prometheus", "metadata": {"serverAddress": "http://prometheus:9090", "query": "...", "threshold": "10"}}]
+// +kubebuilder:object:generate=false
+// +k8s:openapi-gen=false
+type KedaTrigger struct {
+	// Type of the KEDA scaler
+	Type string `json:"type"`
+	// Name of the trigger
+	Name string `json:"name,omitempty"`
+	// Metadata is the configuration of the KEDA scaler
+	Metadata map[string]string `json:"metadata"`
+}
+
+// GetKedaTriggers parses and validates the triggers of the keda triggers annotation.
+// It returns nil if the annotation is not set.
+func GetKedaTriggers(annotations map[string]string) ([]KedaTrigger, error) {
+	value, ok := annotations[constants.KedaTriggersAnnotationKey]
+	if !ok {
+		return nil, nil
+	}
+	var triggers []KedaTrigger
+	if err := json.Unmarshal([]byte(value), &triggers); err != nil {
+		return nil, fmt.Errorf("invalid value for annotation %s: %w", constants.KedaTriggersAnnotationKey, err)
+	}
+	for _, trigger := range triggers {
+		if trigger.Type == "" {
+			return nil, fmt.Errorf("the triggers of annotation %s must have a type", constants.KedaTriggersAnnotationKey)
+		}
+		if len(trigger.Metadata) == 0 {
+			return nil, fmt.Errorf("the %s trigger of annotation %s must have metadata", trigger.Type, constants.KedaTriggersAnnotationKey)
+		}
+	}
+	return triggers, nil
+}
+
+// GetVPAResourceList parses the min or max allowed resources of a vpa annotation, e.g. {"memory": "4Gi", "cpu": "1"}.
+// It returns nil if the annotation is not set.
+func GetVPAResourceList(annotations map[string]string, key string) (v1.ResourceList, error) {
+	value, ok := annotations[key]
+	if !ok {
+		return nil, nil
+	}
+	var resources v1.ResourceList
+	if err := json.Unmarshal([]byte(value), &resources); err != nil {
+		return nil, fmt.Errorf("invalid value for annotation %s: %w", key, err)
+	}
+	for name := range resources {
+		if name != v1.ResourceCPU && name != v1.ResourceMemory {
+			return nil, fmt.Errorf("the resource %s of annotation %s is not supported, must be one of %s or %s",
+				name, key, v1.ResourceCPU, v1.ResourceMemory)
+		}
+	}
+	return resources, nil
+}
+
+// GetStorageInitializerResources parses the resource overrides of the storage initializer containers, e.g.
+// {"limits": {"memory": "16Gi"}, "requests": {"cpu": "2"}}. It returns nil if the annotation is not set.
+func GetStorageInitializerResources(annotations map[string]string) (*v1.ResourceRequirements, error) {
+	key := constants.StorageInitializerResourcesAnnotationKey
+	value, ok := annotations[key]
+	if !ok {
+		return nil, nil
+	}
+	resources := &v1.ResourceRequirements{}
+	if err := json.Unmarshal([]byte(value), resources); err != nil {
+		return nil, fmt.Errorf("invalid value for annotation %s: %w", key, err)
+	}
+	for _, list := range []v1.ResourceList{resources.Limits, resources.Requests} {
+		for name := range list {
+			if name != v1.ResourceCPU && name != v1.ResourceMemory && name != v1.ResourceEphemeralStorage {
+				return nil, fmt.Errorf("the resource %s of annotation %s is not supported, must be one of %s, %s or %s",
+					name, key, v1.ResourceCPU, v1.ResourceMemory, v1.ResourceEphemeralStorage)
+			}
+		}
+	}
+	for name, request := range resources.Requests {
+		if limit, ok := resources.Limits[name]; ok && request.Cmp(limit) > 0 {
+			return nil, fmt.Errorf("the %s request of annotation %s must be less than or equal to the limit", name, key)
+		}
+	}
+	return resources, nil
+}
+
 // Default the ComponentExtensionSpec
 func (s *ComponentExtensionSpec) Default(config *InferenceServicesConfig) {}
 
@@ -131,6 +380,7 @@ func (s *ComponentExtensionSpec) Default(config *InferenceServicesConfig) {}
 func (s *ComponentExtensionSpec) Validate() error {
 	return utils.FirstNonNilError([]error{
 		validateContainerConcurrency(s.ContainerConcurrency),
+		validateRetries(s.Retries, s.TimeoutSeconds),
 		validateReplicas(s.MinReplicas, s.MaxReplicas),
 		validateLogger(s.Logger),
 	})
@@ -187,15 +437,87 @@ func validateContainerConcurrency(containerConcurrency *int64) error {
 	return nil
 }
 
+func validateRetries(retries *RetrySpec, timeoutSeconds *int64) error {
+	if retries == nil {
+		return nil
+	}
+	if retries.Attempts < 0 {
+		return fmt.Errorf(RetryAttemptsLowerBoundExceededError)
+	}
+	if retries.PerTryTimeoutSeconds == nil {
+		return nil
+	}
+	if *retries.PerTryTimeoutSeconds <= 0 {
+		return fmt.Errorf(RetryPerTryTimeoutLowerBoundExceededError)
+	}
+	if timeoutSeconds != nil && *retries.PerTryTimeoutSeconds*int64(retries.Attempts) > *timeoutSeconds {
+		return fmt.Errorf(RetryTimeoutExceededError, *retries.PerTryTimeoutSeconds, retries.Attempts, *timeoutSeconds)
+	}
+	return nil
+}
+
 func validateLogger(logger *LoggerSpec) error {
 	if logger != nil {
-		if !(logger.Mode == LogAll || logger.Mode == LogRequest || logger.Mode == LogResponse) {
+		if !(logger.Mode == LogAll || logger.Mode == LogRequest || logger.Mode == LogResponse || logger.Mode == LogErrors) {
 			return fmt.Errorf(InvalidLoggerType)
 		}
+		if logger.URL != nil {
+			isKafka := strings.HasPrefix(*logger.URL, LoggerKafkaURLPrefix)
+			if isKafka && !isValidKafkaLoggerURL(*logger.URL) {
+				return fmt.Errorf(InvalidKafkaLoggerURLError, *logger.URL)
+			}
+			if !isKafka && logger.SecretName != nil {
+				return fmt.Errorf(LoggerSecretWithoutKafkaURLError)
+			}
+		}
+		if logger.SamplingRate != nil {
+			rate, err := strconv.ParseFloat(*logger.SamplingRate, 64)
+			if err != nil || rate < 0 || rate > 1 {
+				return fmt.Errorf(InvalidLoggerSamplingRateError, *logger.SamplingRate)
+			}
+		}
+		if logger.MaxPayloadBytes != nil && *logger.MaxPayloadBytes <= 0 {
+			return fmt.Errorf(InvalidLoggerMaxPayloadBytesError)
+		}
+		if !(logger.PayloadOverflow == "" || logger.PayloadOverflow == LogPayloadTruncate || logger.PayloadOverflow == LogPayloadDrop) {
+			return fmt.Errorf(InvalidLoggerPayloadOverflowError, logger.PayloadOverflow)
+		}
+		for _, contentType := range logger.ContentTypeAllowList {
+			if !isValidLoggerContentType(contentType) {
+				return fmt.Errorf(InvalidLoggerContentTypeError, contentType)
+			}
+		}
+		for _, header := range logger.MetadataHeaders {
+			if !httpguts.ValidHeaderFieldName(header) {
+				return fmt.Errorf(InvalidLoggerMetadataHeaderError, header)
+			}
+		}
 	}
 	return nil
 }
 
+// isValidKafkaLoggerURL checks that a kafka:// logger url has at least one broker and exactly one topic
+func isValidKafkaLoggerURL(logUrl string) bool {
+	u, err := url.Parse(logUrl)
+	if err != nil {
+		return false
+	}
+	hasBroker := false
+	for _, broker := range strings.Split(u.Host, ",") {
+		hasBroker = hasBroker || broker != ""
+	}
+	topic := strings.TrimPrefix(u.Path, "/")
+	return hasBroker && topic != "" && !strings.Contains(topic, "/")
+}
+
+// isValidLoggerContentType checks that an entry of the logger content type allow list is a type/subtype media type,
+// the subtype may be a * wildcard
+func isValidLoggerContentType(contentType string) bool {
+	mediaType, subType, found := strings.Cut(contentType, "/")
+	return found && mediaType != "" && subType != "" && !strings.ContainsAny(contentType, " ,;") &&
+		!strings.Contains(subType, "/")
+}
+
 func validateExactlyOneImplementation(component Component) error {
 	if len(component.GetImplementations()) != 1 {
 		return ExactlyOneErrorFor(component)
@@ -46,6 +46,32 @@ func TestComponentExtensionSpec_Validate(t *testing.T) {
 			},
 			matcher: gomega.Not(gomega.BeNil()),
 		},
+		"ValidRetries": {
+			spec: ComponentExtensionSpec{
+				TimeoutSeconds: proto.Int64(30),
+				Retries:        &RetrySpec{Attempts: 3, PerTryTimeoutSeconds: proto.Int64(10), RetryOn: "5xx"},
+			},
+			matcher: gomega.BeNil(),
+		},
+		"InvalidRetryAttempts": {
+			spec: ComponentExtensionSpec{
+				Retries: &RetrySpec{Attempts: -1},
+			},
+			matcher: gomega.MatchError(RetryAttemptsLowerBoundExceededError),
+		},
+		"InvalidRetryPerTryTimeout": {
+			spec: ComponentExtensionSpec{
+				Retries: &RetrySpec{Attempts: 3, PerTryTimeoutSeconds: proto.Int64(0)},
+			},
+			matcher: gomega.MatchError(RetryPerTryTimeoutLowerBoundExceededError),
+		},
+		"RetriesExceedTimeout": {
+			spec: ComponentExtensionSpec{
+				TimeoutSeconds: proto.Int64(30),
+				Retries:        &RetrySpec{Attempts: 4, PerTryTimeoutSeconds: proto.Int64(10)},
+			},
+			matcher: gomega.MatchError(fmt.Sprintf(RetryTimeoutExceededError, 10, 4, 30)),
+		},
 	}
 
 	for name, scenario := range scenarios {
@@ -138,6 +164,20 @@ func TestComponentExtensionSpec_validateLogger(t *testing.T) {
 			},
 			matcher: gomega.BeNil(),
 		},
+		"LoggerWithLogErrorsMode": {
+			logger: &LoggerSpec{
+				Mode:            LogErrors,
+				MetadataHeaders: []string{"X-Request-Id", "x-tenant"},
+			},
+			matcher: gomega.BeNil(),
+		},
+		"LoggerWithInvalidMetadataHeader": {
+			logger: &LoggerSpec{
+				Mode:            LogAll,
+				MetadataHeaders: []string{"X-Request-Id", "x tenant"},
+			},
+			matcher: gomega.MatchError(fmt.Errorf(InvalidLoggerMetadataHeaderError, "x tenant")),
+		},
 		"InvalidLoggerMode": {
 			logger: &LoggerSpec{
 				Mode: "InvalidMode",
@@ -148,6 +188,81 @@ func TestComponentExtensionSpec_validateLogger(t *testing.T) {
 			logger:  nil,
 			matcher: gomega.BeNil(),
 		},
+		"LoggerWithKafkaURL": {
+			logger: &LoggerSpec{
+				URL:        proto.String("kafka://kafka-0:9092,kafka-1:9092/inference-logs"),
+				Mode:       LogAll,
+				SecretName: proto.String("kafka-secret"),
+			},
+			matcher: gomega.BeNil(),
+		},
+		"LoggerWithKafkaURLWithoutTopic": {
+			logger: &LoggerSpec{
+				URL:  proto.String("kafka://kafka-0:9092"),
+				Mode: LogAll,
+			},
+			matcher: gomega.MatchError(fmt.Errorf(InvalidKafkaLoggerURLError, "kafka://kafka-0:9092")),
+		},
+		"LoggerWithKafkaURLWithoutBroker": {
+			logger: &LoggerSpec{
+				URL:  proto.String("kafka:///inference-logs"),
+				Mode: LogAll,
+			},
+			matcher: gomega.MatchError(fmt.Errorf(InvalidKafkaLoggerURLError, "kafka:///inference-logs")),
+		},
+		"LoggerWithSamplingAndLimits": {
+			logger: &LoggerSpec{
+				Mode:                 LogAll,
+				SamplingRate:         proto.String("0.25"),
+				MaxPayloadBytes:      proto.Int64(1024),
+				PayloadOverflow:      LogPayloadDrop,
+				ContentTypeAllowList: []string{"application/json", "text/*"},
+			},
+			matcher: gomega.BeNil(),
+		},
+		"LoggerWithSamplingRateOutOfRange": {
+			logger: &LoggerSpec{
+				Mode:         LogAll,
+				SamplingRate: proto.String("1.5"),
+			},
+			matcher: gomega.MatchError(fmt.Errorf(InvalidLoggerSamplingRateError, "1.5")),
+		},
+		"LoggerWithMalformedSamplingRate": {
+			logger: &LoggerSpec{
+				Mode:         LogAll,
+				SamplingRate: proto.String("10%"),
+			},
+			matcher: gomega.MatchError(fmt.Errorf(InvalidLoggerSamplingRateError, "10%")),
+		},
+		"LoggerWithZeroMaxPayloadBytes": {
+			logger: &LoggerSpec{
+				Mode:            LogAll,
+				MaxPayloadBytes: proto.Int64(0),
+			},
+			matcher: gomega.MatchError(fmt.Errorf(InvalidLoggerMaxPayloadBytesError)),
+		},
+		"LoggerWithInvalidPayloadOverflow": {
+			logger: &LoggerSpec{
+				Mode:            LogAll,
+				PayloadOverflow: "compress",
+			},
+			matcher: gomega.MatchError(fmt.Errorf(InvalidLoggerPayloadOverflowError, "compress")),
+		},
+		"LoggerWithInvalidContentType": {
+			logger: &LoggerSpec{
+				Mode:                 LogAll,
+				ContentTypeAllowList: []string{"application/json", "json"},
+			},
+			matcher: gomega.MatchError(fmt.Errorf(InvalidLoggerContentTypeError, "json")),
+		},
+		"LoggerWithHttpURLAndSecret": {
+			logger: &LoggerSpec{
+				URL:        proto.String("http://message-dumper.default"),
+				Mode:       LogAll,
+				SecretName: proto.String("kafka-secret"),
+			},
+			matcher: gomega.MatchError(fmt.Errorf(LoggerSecretWithoutKafkaURLError)),
+		},
 	}
 	for name, scenario := range scenarios {
 		t.Run(name, func(t *testing.T) {
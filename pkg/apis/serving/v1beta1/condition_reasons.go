@@ -0,0 +1,150 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"strings"
+
+	"github.com/kserve/kserve/pkg/constants"
+	v1 "k8s.io/api/core/v1"
+	"knative.dev/pkg/apis"
+)
+
+// Reasons of the False InferenceService conditions. The controller classifies the failures reported by the underlying
+// Deployments, Knative Services and Pods into one of these reasons and keeps their detailed message, so that clients
+// can switch on the reason of a condition to remediate the failure.
+const (
+	// No ServingRuntime is found for the predictor: the named runtime does not exist or is disabled, or no runtime
+	// supports the model format and protocol version
+	RuntimeNotFound = "RuntimeNotFound"
+	// The predictor spec or the selected ServingRuntime is invalid
+	InvalidConfiguration = "InvalidConfiguration"
+	// The storage initializer failed to download the model from the storage URI
+	InvalidStorageURI = "InvalidStorageURI"
+	// The container image can not be pulled
+	ImagePullError = "ImagePullError"
+	// The pods are rejected by a resource quota
+	InsufficientQuota = "InsufficientQuota"
+	// The pods can not be scheduled on any node
+	InsufficientResources = "InsufficientResources"
+	// The pods are not created for another reason than a resource quota, e.g. they are denied by an admission webhook
+	PodCreationFailed = "PodCreationFailed"
+	// A container keeps exiting or crashing
+	ContainerFailed = "ContainerFailed"
+	// A readiness, liveness or startup probe fails
+	ProbeFailure = "ProbeFailure"
+	// The rollout did not complete within the progress deadline
+	ProgressDeadlineExceeded = "ProgressDeadlineExceeded"
+	// The ingress of the InferenceService is not created or not ready
+	IngressNotReady = "IngressNotReady"
+	// The component is not ready and the underlying resource reports no more specific failure
+	ComponentNotReady = "ComponentNotReady"
+)
+
+var conditionReasons = map[string]bool{
+	RuntimeNotFound:          true,
+	InvalidConfiguration:     true,
+	InvalidStorageURI:        true,
+	ImagePullError:           true,
+	InsufficientQuota:        true,
+	InsufficientResources:    true,
+	PodCreationFailed:        true,
+	ContainerFailed:          true,
+	ProbeFailure:             true,
+	ProgressDeadlineExceeded: true,
+	IngressNotReady:          true,
+	ComponentNotReady:        true,
+}
+
+// IsConditionReason returns true if the reason is one of the reasons of the False InferenceService conditions.
+func IsConditionReason(reason string) bool {
+	return conditionReasons[reason]
+}
+
+// ClassifyConditionReason maps the reason and message of a failed Deployment, Knative Service or Pod condition, or the
+// waiting or terminated reason of a container, to one of the reasons of the False InferenceService conditions.
+func ClassifyConditionReason(reason string, message string) string {
+	if IsConditionReason(reason) {
+		return reason
+	}
+	// Knative surfaces the failure of a revision in the message of the service conditions,
+	// e.g. `Revision "x" failed with message: Back-off pulling image "y".`
+	msg := strings.ToLower(message)
+	containsAny := func(substrs ...string) bool {
+		for _, substr := range substrs {
+			if strings.Contains(msg, substr) {
+				return true
+			}
+		}
+		return false
+	}
+	switch {
+	case containsAny("exceeded quota", "exceeds quota"):
+		return InsufficientQuota
+	case containsAny(constants.StorageInitializerContainerName, "storage uri", "storageuri", "storage type"):
+		return InvalidStorageURI
+	case reason == constants.StateReasonImagePullBackOff || reason == constants.StateReasonErrImagePull ||
+		reason == "ContainerMissing" || reason == "InvalidImageName" ||
+		containsAny("imagepullbackoff", "errimagepull", "pulling image", "pull image", "unable to fetch image"):
+		return ImagePullError
+	case reason == "Unhealthy" || containsAny("probe failed", "probe timed out"):
+		return ProbeFailure
+	case reason == constants.StateReasonCrashLoopBackOff || reason == constants.StateReasonError ||
+		reason == "OOMKilled" || strings.HasPrefix(reason, "ExitCode") ||
+		containsAny("crashloopbackoff", "container failed with", "oomkilled", "exit code"):
+		return ContainerFailed
+	case reason == "Unschedulable" || reason == "FailedScheduling" ||
+		containsAny("nodes are available", "insufficient cpu", "insufficient memory", "insufficient nvidia.com/gpu"):
+		return InsufficientResources
+	case reason == constants.DeploymentReasonProgressDeadlineExceeded ||
+		containsAny("timed out progressing", "initial scale was never achieved"):
+		return ProgressDeadlineExceeded
+	case reason == "FailedCreate":
+		return PodCreationFailed
+	case reason == "IngressNotConfigured" || strings.HasPrefix(reason, "Ingress"):
+		return IngressNotReady
+	}
+	return ComponentNotReady
+}
+
+// classifyCondition returns a copy of a False condition with its reason classified by ClassifyConditionReason,
+// the original reason is kept as the message if the condition has no message.
+func classifyCondition(condition *apis.Condition) *apis.Condition {
+	if condition == nil || condition.Status != v1.ConditionFalse || IsConditionReason(condition.Reason) {
+		return condition
+	}
+	classified := condition.DeepCopy()
+	classified.Reason = ClassifyConditionReason(condition.Reason, condition.Message)
+	if classified.Message == "" {
+		classified.Message = condition.Reason
+	}
+	return classified
+}
+
+// failureConditionReason returns the condition reason of the failure of a predictor with an invalid spec.
+func failureConditionReason(info *FailureInfo) string {
+	switch info.Reason {
+	case RuntimeNotRecognized, NoSupportingRuntime, RuntimeDisabled:
+		return RuntimeNotFound
+	case ResourceRejected:
+		if reason := ClassifyConditionReason("", info.Message); reason == InsufficientQuota {
+			return reason
+		}
+		return PodCreationFailed
+	}
+	return InvalidConfiguration
+}
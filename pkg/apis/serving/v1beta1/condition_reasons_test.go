@@ -0,0 +1,211 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"testing"
+
+	"github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	knservingv1 "knative.dev/serving/pkg/apis/serving/v1"
+)
+
+func TestClassifyConditionReason(t *testing.T) {
+	cases := map[string]struct {
+		reason   string
+		message  string
+		expected string
+	}{
+		"DeploymentQuotaExceeded": {
+			reason:   "FailedCreate",
+			message:  "pods \"test-predictor-7c9d-abcde\" is forbidden: exceeded quota: compute, requested: nvidia.com/gpu=1, used: nvidia.com/gpu=4, limited: nvidia.com/gpu=4",
+			expected: InsufficientQuota,
+		},
+		"DeploymentPodCreationDenied": {
+			reason:   "FailedCreate",
+			message:  "admission webhook \"validation.gatekeeper.sh\" denied the request",
+			expected: PodCreationFailed,
+		},
+		"DeploymentProgressDeadlineExceeded": {
+			reason:   "ProgressDeadlineExceeded",
+			message:  "ReplicaSet \"test-predictor-7c9d\" has timed out progressing.",
+			expected: ProgressDeadlineExceeded,
+		},
+		"DeploymentMinimumReplicasUnavailable": {
+			reason:   "MinimumReplicasUnavailable",
+			message:  "Deployment does not have minimum availability.",
+			expected: ComponentNotReady,
+		},
+		"KnativeImagePullBackOff": {
+			reason:   "RevisionFailed",
+			message:  "Revision \"test-predictor-00001\" failed with message: Back-off pulling image \"kserve/sklearnserver:missing\".",
+			expected: ImagePullError,
+		},
+		"KnativeContainerMissing": {
+			reason:   "ContainerMissing",
+			message:  "Unable to fetch image \"kserve/sklearnserver:missing\": failed to resolve image to digest",
+			expected: ImagePullError,
+		},
+		"KnativeContainerFailed": {
+			reason:   "RevisionFailed",
+			message:  "Revision \"test-predictor-00001\" failed with message: Container failed with: ModuleNotFoundError.",
+			expected: ContainerFailed,
+		},
+		"KnativeStorageInitializerFailed": {
+			reason:   "RevisionFailed",
+			message:  "Revision \"test-predictor-00001\" failed with message: Init container storage-initializer failed with: Cannot recognize storage type for s4://models.",
+			expected: InvalidStorageURI,
+		},
+		"KnativeUnschedulable": {
+			reason:   "Unschedulable",
+			message:  "0/3 nodes are available: 3 Insufficient nvidia.com/gpu.",
+			expected: InsufficientResources,
+		},
+		"KnativeProbeFailure": {
+			reason:   "RevisionFailed",
+			message:  "Revision \"test-predictor-00001\" failed with message: Readiness probe failed: HTTP probe failed with statuscode: 503.",
+			expected: ProbeFailure,
+		},
+		"KnativeInitialScaleNotAchieved": {
+			reason:   "RevisionFailed",
+			message:  "Revision \"test-predictor-00001\" failed with message: Initial scale was never achieved.",
+			expected: ProgressDeadlineExceeded,
+		},
+		"KnativeRevisionMissing": {
+			reason:   "RevisionMissing",
+			message:  "Configuration \"test-predictor\" does not have any ready Revision.",
+			expected: ComponentNotReady,
+		},
+		"KnativeIngressNotConfigured": {
+			reason:   "IngressNotConfigured",
+			message:  "Ingress has not yet been reconciled.",
+			expected: IngressNotReady,
+		},
+		"PodImagePullBackOff": {
+			reason:   "ImagePullBackOff",
+			expected: ImagePullError,
+		},
+		"PodErrImagePull": {
+			reason:   "ErrImagePull",
+			expected: ImagePullError,
+		},
+		"PodCrashLoopBackOff": {
+			reason:   "CrashLoopBackOff",
+			message:  "back-off 5m0s restarting failed container=kserve-container",
+			expected: ContainerFailed,
+		},
+		"PodOOMKilled": {
+			reason:   "OOMKilled",
+			expected: ContainerFailed,
+		},
+		"AlreadyClassified": {
+			reason:   IngressNotReady,
+			message:  "Predictor ingress not created",
+			expected: IngressNotReady,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			g.Expect(ClassifyConditionReason(tc.reason, tc.message)).To(gomega.Equal(tc.expected))
+		})
+	}
+}
+
+func TestPropagateStatusClassifiesFailure(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	message := "Revision \"test-predictor-00001\" failed with message: Back-off pulling image \"kserve/sklearnserver:missing\"."
+	serviceStatus := &knservingv1.ServiceStatus{
+		Status: duckv1.Status{
+			Conditions: duckv1.Conditions{
+				{Type: "RoutesReady", Status: v1.ConditionTrue},
+				{Type: "ConfigurationsReady", Status: v1.ConditionFalse, Reason: "RevisionFailed", Message: message},
+				{Type: knservingv1.ServiceConditionReady, Status: v1.ConditionFalse, Reason: "RevisionFailed", Message: message},
+			},
+		},
+	}
+	status := &InferenceServiceStatus{}
+	status.PropagateStatus(PredictorComponent, serviceStatus)
+	for _, conditionType := range []apis.ConditionType{PredictorReady, PredictorConfigurationReady} {
+		condition := status.GetCondition(conditionType)
+		g.Expect(condition.Status).To(gomega.Equal(v1.ConditionFalse))
+		g.Expect(condition.Reason).To(gomega.Equal(ImagePullError))
+		g.Expect(condition.Message).To(gomega.Equal(message))
+	}
+	// the knative service status is left untouched
+	g.Expect(serviceStatus.GetCondition(knservingv1.ServiceConditionReady).Reason).To(gomega.Equal("RevisionFailed"))
+
+	status.PropagateCrossComponentStatus([]ComponentType{PredictorComponent}, LatestDeploymentReady)
+	condition := status.GetCondition(LatestDeploymentReady)
+	g.Expect(condition.Status).To(gomega.Equal(v1.ConditionFalse))
+	g.Expect(condition.Reason).To(gomega.Equal(ImagePullError))
+	g.Expect(condition.Message).To(gomega.Equal("PredictorConfigurationReady not ready"))
+}
+
+func TestUpdateModelTransitionStatusInvalidSpec(t *testing.T) {
+	cases := map[string]struct {
+		info           *FailureInfo
+		wasReady       bool
+		expectedStatus v1.ConditionStatus
+		expectedReason string
+	}{
+		"RuntimeNotRecognized": {
+			info:           &FailureInfo{Reason: RuntimeNotRecognized, Message: "Waiting for runtime to become available"},
+			expectedStatus: v1.ConditionFalse,
+			expectedReason: RuntimeNotFound,
+		},
+		"NoSupportingRuntime": {
+			info:           &FailureInfo{Reason: NoSupportingRuntime, Message: "No runtime found to support specified framework/version"},
+			expectedStatus: v1.ConditionFalse,
+			expectedReason: RuntimeNotFound,
+		},
+		"InvalidPredictorSpec": {
+			info:           &FailureInfo{Reason: InvalidPredictorSpec, Message: "Failed to get runtime container"},
+			expectedStatus: v1.ConditionFalse,
+			expectedReason: InvalidConfiguration,
+		},
+		"ResourceRejectedByQuota": {
+			info:           &FailureInfo{Reason: ResourceRejected, Message: "deployments.apps \"test-predictor\" is forbidden: exceeded quota: compute"},
+			expectedStatus: v1.ConditionFalse,
+			expectedReason: InsufficientQuota,
+		},
+		"ReadyPredictorStaysReady": {
+			info:           &FailureInfo{Reason: NoSupportingRuntime, Message: "Specified runtime does not support specified framework/version"},
+			wasReady:       true,
+			expectedStatus: v1.ConditionTrue,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			status := &InferenceServiceStatus{}
+			status.InitializeConditions()
+			if tc.wasReady {
+				status.SetCondition(PredictorReady, &apis.Condition{Status: v1.ConditionTrue})
+			}
+			status.UpdateModelTransitionStatus(InvalidSpec, tc.info)
+			condition := status.GetCondition(PredictorReady)
+			g.Expect(condition.Status).To(gomega.Equal(tc.expectedStatus))
+			g.Expect(condition.Reason).To(gomega.Equal(tc.expectedReason))
+			if tc.expectedStatus == v1.ConditionFalse {
+				g.Expect(condition.Message).To(gomega.Equal(tc.info.Message))
+			}
+		})
+	}
+}
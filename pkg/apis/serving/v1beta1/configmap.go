@@ -17,13 +17,17 @@ limitations under the License.
 package v1beta1
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"text/template"
+	"time"
 
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/client-go/kubernetes"
 
 	"github.com/kserve/kserve/pkg/constants"
@@ -31,7 +35,8 @@ import (
 
 // ConfigMap Keys
 const (
-	ExplainerConfigKeyName = "explainers"
+	ExplainerConfigKeyName        = "explainers"
+	ResourceProfilesConfigKeyName = "resourceProfiles"
 )
 
 const (
@@ -42,6 +47,9 @@ const (
 	DefaultIngressDomain  = "example.com"
 
 	DefaultUrlScheme = "http"
+
+	// IngressKindIngress is the default ingressKind, the raw deployments are exposed by a networking.k8s.io Ingress
+	IngressKindIngress = "Ingress"
 )
 
 // +kubebuilder:object:generate=false
@@ -61,6 +69,9 @@ type ExplainersConfig struct {
 type InferenceServicesConfig struct {
 	// Explainer configurations
 	Explainers ExplainersConfig `json:"explainers"`
+	// ResourceProfiles are the resources applied to the kserve-container of the predictors selecting them by name
+	// with the resource-profile annotation
+	ResourceProfiles map[string]v1.ResourceRequirements `json:"resourceProfiles,omitempty"`
 }
 
 // +kubebuilder:object:generate=false
@@ -77,11 +88,82 @@ type IngressConfig struct {
 	DisableIstioVirtualHost  bool      `json:"disableIstioVirtualHost,omitempty"`
 	PathTemplate             string    `json:"pathTemplate,omitempty"`
 	DisableIngressCreation   bool      `json:"disableIngressCreation,omitempty"`
+	// EnableGatewayAPI makes the raw deployment ingress reconciler create gateway.networking.k8s.io HTTPRoutes
+	// attached to the Gateway GatewayNamespace/GatewayName instead of a networking.k8s.io Ingress.
+	EnableGatewayAPI bool   `json:"enableGatewayAPI,omitempty"`
+	GatewayName      string `json:"gatewayName,omitempty"`
+	GatewayNamespace string `json:"gatewayNamespace,omitempty"`
+	// DefaultTLSSecret is the TLS secret of the raw deployment Ingresses, it is overridden per InferenceService by
+	// the tls-secret annotation.
+	DefaultTLSSecret string `json:"defaultTLSSecret,omitempty"`
+	// CertManagerIssuer is the cert-manager ClusterIssuer annotated on the raw deployment Ingresses, so that
+	// cert-manager issues the certificate of their TLS secret.
+	CertManagerIssuer string `json:"certManagerIssuer,omitempty"`
+	// EnableDestinationRule makes the controller create an istio DestinationRule per InferenceService component
+	// service with the traffic policy of DestinationRule.
+	EnableDestinationRule bool                  `json:"enableDestinationRule,omitempty"`
+	DestinationRule       DestinationRuleConfig `json:"destinationRule,omitempty"`
+	// Cors is the CORS policy of the routes to the InferenceServices, it is overridden per InferenceService by the
+	// cors annotations.
+	Cors CorsConfig `json:"cors,omitempty"`
+	// IngressKind is the kind of the object exposing the raw deployments when the Gateway API is not enabled, an
+	// Ingress by default or an OpenShift Route.
+	IngressKind string `json:"ingressKind,omitempty"`
+}
+
+// CorsConfig is the CORS policy of the VirtualServices and HTTPRoutes of the InferenceServices. No CORS policy is
+// generated when AllowOrigins is empty.
+// +kubebuilder:object:generate=false
+type CorsConfig struct {
+	// AllowOrigins are the origins allowed to make cross origin requests, e.g. https://example.com or *
+	AllowOrigins []string `json:"allowOrigins,omitempty"`
+	// AllowMethods are the methods allowed in cross origin requests, e.g. GET, POST
+	AllowMethods []string `json:"allowMethods,omitempty"`
+	// AllowHeaders are the request headers allowed in cross origin requests
+	AllowHeaders []string `json:"allowHeaders,omitempty"`
+	// MaxAge is the number of seconds the result of a preflight request can be cached
+	MaxAge int64 `json:"maxAge,omitempty"`
+}
+
+// DestinationRuleConfig is the traffic policy of the DestinationRules of the InferenceService components. The
+// connection pool and outlier detection settings are overridden per InferenceService by the destination-rule
+// annotations, unset settings are left to the istio defaults.
+// +kubebuilder:object:generate=false
+type DestinationRuleConfig struct {
+	// MaxConnections is the maximum number of connections to the component service
+	MaxConnections int32 `json:"maxConnections,omitempty"`
+	// HTTP2MaxRequests is the maximum number of active requests to the component service
+	HTTP2MaxRequests int32 `json:"http2MaxRequests,omitempty"`
+	// Consecutive5xxErrors is the number of consecutive 5xx errors after which a pod is ejected from the load
+	// balancing pool, outlier detection is disabled when it is not set.
+	Consecutive5xxErrors int32 `json:"consecutive5xxErrors,omitempty"`
+	// Interval is the time between the outlier detection sweeps, e.g. 10s
+	Interval string `json:"interval,omitempty"`
+	// BaseEjectionTime is the minimum ejection duration of a pod, e.g. 30s
+	BaseEjectionTime string `json:"baseEjectionTime,omitempty"`
+	// MaxEjectionPercent is the maximum percentage of the pods which can be ejected
+	MaxEjectionPercent int32 `json:"maxEjectionPercent,omitempty"`
+	// IstioMutualTLS sets the TLS mode of the traffic to the components to ISTIO_MUTUAL, which is required when the
+	// mesh enforces mutual TLS without automatic mTLS.
+	IstioMutualTLS bool `json:"istioMutualTLS,omitempty"`
 }
 
 // +kubebuilder:object:generate=false
 type DeployConfig struct {
 	DefaultDeploymentMode string `json:"defaultDeploymentMode,omitempty"`
+	// DefaultTopologySpreadConstraints are added to the pods of raw deployments which do not define any
+	// topology spread constraints. The label selector is always set to match the pods of the deployment.
+	DefaultTopologySpreadConstraints []v1.TopologySpreadConstraint `json:"defaultTopologySpreadConstraints,omitempty"`
+	// PreStopSleepSeconds is the duration of the preStop sleep hook added to the model server container of raw
+	// deployments, so the endpoint is removed from the Service before the container receives SIGTERM.
+	// The hook runs /bin/sh in the model server image, so it is disabled unless set to a positive value.
+	PreStopSleepSeconds *int64 `json:"preStopSleepSeconds,omitempty"`
+	// AutomountServiceAccountToken is the default automountServiceAccountToken of raw deployment pods which do not
+	// set it in the pod spec or with the automount service account token annotation. Unset leaves the field nil.
+	AutomountServiceAccountToken *bool `json:"automountServiceAccountToken,omitempty"`
+	// DryRunChildResources enables a server side dry-run create of the predictor Deployment or Knative Service and
+	// of its pod before they are created, so quota and admission policy rejections fail the InferenceService at once.
+	DryRunChildResources bool `json:"dryRunChildResources,omitempty"`
 }
 
 func NewInferenceServicesConfig(clientset kubernetes.Interface) (*InferenceServicesConfig, error) {
@@ -92,6 +174,7 @@ func NewInferenceServicesConfig(clientset kubernetes.Interface) (*InferenceServi
 	icfg := &InferenceServicesConfig{}
 	for _, err := range []error{
 		getComponentConfig(ExplainerConfigKeyName, configMap, &icfg.Explainers),
+		getComponentConfig(ResourceProfilesConfigKeyName, configMap, &icfg.ResourceProfiles),
 	} {
 		if err != nil {
 			return nil, err
@@ -105,6 +188,11 @@ func NewIngressConfig(clientset kubernetes.Interface) (*IngressConfig, error) {
 	if err != nil {
 		return nil, err
 	}
+	return NewIngressConfigFromConfigMap(configMap)
+}
+
+// NewIngressConfigFromConfigMap parses and defaults the ingress config of the inferenceservice config map
+func NewIngressConfigFromConfigMap(configMap *v1.ConfigMap) (*IngressConfig, error) {
 	ingressConfig := &IngressConfig{}
 	if ingress, ok := configMap.Data[IngressConfigKeyName]; ok {
 		err := json.Unmarshal([]byte(ingress), &ingressConfig)
@@ -128,6 +216,33 @@ func NewIngressConfig(clientset kubernetes.Interface) (*IngressConfig, error) {
 				return nil, fmt.Errorf("invalid ingress config - ingressDomain is required if pathTemplate is given")
 			}
 		}
+		if ingressConfig.EnableGatewayAPI && ingressConfig.GatewayName == "" {
+			return nil, fmt.Errorf("invalid ingress config - gatewayName is required if enableGatewayAPI is true")
+		}
+		for _, duration := range []struct{ name, value string }{
+			{"interval", ingressConfig.DestinationRule.Interval},
+			{"baseEjectionTime", ingressConfig.DestinationRule.BaseEjectionTime},
+		} {
+			if _, err := time.ParseDuration(duration.value); duration.value != "" && err != nil {
+				return nil, fmt.Errorf("invalid ingress config - unable to parse destinationRule %s: %w", duration.name, err)
+			}
+		}
+		for _, origin := range ingressConfig.Cors.AllowOrigins {
+			if err := ValidateCorsOrigin(origin); err != nil {
+				return nil, fmt.Errorf("invalid ingress config - invalid cors origin %q: %w", origin, err)
+			}
+		}
+		if ingressConfig.Cors.MaxAge < 0 {
+			return nil, fmt.Errorf("invalid ingress config - cors maxAge must not be negative")
+		}
+		switch ingressConfig.IngressKind {
+		case "", IngressKindIngress, constants.OpenShiftRouteKind:
+		default:
+			return nil, fmt.Errorf("invalid ingress config - ingressKind must be %s or %s", IngressKindIngress, constants.OpenShiftRouteKind)
+		}
+		if ingressConfig.IngressKind == constants.OpenShiftRouteKind && ingressConfig.EnableGatewayAPI {
+			return nil, fmt.Errorf("invalid ingress config - ingressKind %s can not be used with enableGatewayAPI", constants.OpenShiftRouteKind)
+		}
 	}
 
 	if ingressConfig.DomainTemplate == "" {
@@ -142,9 +257,50 @@ func NewIngressConfig(clientset kubernetes.Interface) (*IngressConfig, error) {
 		ingressConfig.UrlScheme = DefaultUrlScheme
 	}
 
+	if ingressConfig.EnableGatewayAPI && ingressConfig.GatewayNamespace == "" {
+		ingressConfig.GatewayNamespace = constants.KServeNamespace
+	}
+
 	return ingressConfig, nil
 }
 
+// DomainTemplateValues are the values the domain template is rendered with
+type DomainTemplateValues struct {
+	Name          string
+	Namespace     string
+	IngressDomain string
+	Annotations   map[string]string
+	Labels        map[string]string
+}
+
+// GenerateDomainName renders the domain template for the name and the namespace, annotations and labels of the object
+func (ingressConfig *IngressConfig) GenerateDomainName(name string, obj metav1.ObjectMeta) (string, error) {
+	values := DomainTemplateValues{
+		Name:          name,
+		Namespace:     obj.Namespace,
+		IngressDomain: ingressConfig.IngressDomain,
+		Annotations:   obj.Annotations,
+		Labels:        obj.Labels,
+	}
+
+	tpl, err := template.New("domain-template").Parse(ingressConfig.DomainTemplate)
+	if err != nil {
+		return "", err
+	}
+
+	buf := bytes.Buffer{}
+	if err := tpl.Execute(&buf, values); err != nil {
+		return "", fmt.Errorf("error rendering the domain template: %w", err)
+	}
+
+	urlErrs := validation.IsFullyQualifiedDomainName(field.NewPath("url"), buf.String())
+	if urlErrs != nil {
+		return "", fmt.Errorf("invalid domain name %q: %w", buf.String(), urlErrs.ToAggregate())
+	}
+
+	return buf.String(), nil
+}
+
 func getComponentConfig(key string, configMap *v1.ConfigMap, componentConfig interface{}) error {
 	if data, ok := configMap.Data[key]; ok {
 		err := json.Unmarshal([]byte(data), componentConfig)
@@ -177,6 +333,10 @@ func NewDeployConfig(clientset kubernetes.Interface) (*DeployConfig, error) {
 			return nil, fmt.Errorf("invalid deployment mode. Supported modes are Serverless," +
 				" RawDeployment and ModelMesh")
 		}
+
+		if deployConfig.PreStopSleepSeconds != nil && *deployConfig.PreStopSleepSeconds < 0 {
+			return nil, fmt.Errorf("invalid deploy config, preStopSleepSeconds must not be negative")
+		}
 	}
 	return deployConfig, nil
 }
@@ -18,6 +18,7 @@ package v1beta1
 
 import (
 	"fmt"
+	"strconv"
 	"testing"
 
 	"github.com/kserve/kserve/pkg/constants"
@@ -79,6 +80,107 @@ func TestNewIngressConfig(t *testing.T) {
 	g.Expect(*ingressCfg.AdditionalIngressDomains).To(gomega.Equal([]string{AdditionalDomain, AdditionalDomainExtra}))
 }
 
+func TestNewIngressConfigWithGatewayAPI(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	for data, expectErr := range map[string]bool{
+		`{"ingressGateway": "kserve/gateway", "ingressService": "gateway", "enableGatewayAPI": true, "gatewayName": "kserve-gateway"}`: false,
+		`{"ingressGateway": "kserve/gateway", "ingressService": "gateway", "enableGatewayAPI": true}`:                                  true,
+	} {
+		clientset := fakeclientset.NewSimpleClientset(&v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: constants.InferenceServiceConfigMapName, Namespace: constants.KServeNamespace},
+			Data: map[string]string{
+				IngressConfigKeyName: data,
+			},
+		})
+		ingressCfg, err := NewIngressConfig(clientset)
+		if expectErr {
+			g.Expect(err).ShouldNot(gomega.BeNil())
+			continue
+		}
+		g.Expect(err).Should(gomega.BeNil())
+		g.Expect(ingressCfg.EnableGatewayAPI).To(gomega.BeTrue())
+		g.Expect(ingressCfg.GatewayName).To(gomega.Equal("kserve-gateway"))
+		g.Expect(ingressCfg.GatewayNamespace).To(gomega.Equal(constants.KServeNamespace))
+	}
+}
+
+func TestNewIngressConfigWithDestinationRule(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	for data, expectErr := range map[string]bool{
+		`{"ingressGateway": "kserve/gateway", "ingressService": "gateway", "enableDestinationRule": true, "destinationRule": {"maxConnections": 100, "consecutive5xxErrors": 5, "interval": "10s", "istioMutualTLS": true}}`: false,
+		`{"ingressGateway": "kserve/gateway", "ingressService": "gateway", "enableDestinationRule": true, "destinationRule": {"baseEjectionTime": "30"}}`:                                                                    true,
+	} {
+		clientset := fakeclientset.NewSimpleClientset(&v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: constants.InferenceServiceConfigMapName, Namespace: constants.KServeNamespace},
+			Data: map[string]string{
+				IngressConfigKeyName: data,
+			},
+		})
+		ingressCfg, err := NewIngressConfig(clientset)
+		if expectErr {
+			g.Expect(err).ShouldNot(gomega.BeNil())
+			continue
+		}
+		g.Expect(err).Should(gomega.BeNil())
+		g.Expect(ingressCfg.EnableDestinationRule).To(gomega.BeTrue())
+		g.Expect(ingressCfg.DestinationRule).To(gomega.Equal(DestinationRuleConfig{
+			MaxConnections:       100,
+			Consecutive5xxErrors: 5,
+			Interval:             "10s",
+			IstioMutualTLS:       true,
+		}))
+	}
+}
+
+func TestNewIngressConfigWithCors(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	for data, expectErr := range map[string]bool{
+		`{"ingressGateway": "kserve/gateway", "ingressService": "gateway", "cors": {"allowOrigins": ["https://example.com", "*"], "maxAge": 600}}`: false,
+		`{"ingressGateway": "kserve/gateway", "ingressService": "gateway", "cors": {"allowOrigins": ["example.com"]}}`:                             true,
+		`{"ingressGateway": "kserve/gateway", "ingressService": "gateway", "cors": {"maxAge": -1}}`:                                                true,
+	} {
+		clientset := fakeclientset.NewSimpleClientset(&v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: constants.InferenceServiceConfigMapName, Namespace: constants.KServeNamespace},
+			Data: map[string]string{
+				IngressConfigKeyName: data,
+			},
+		})
+		ingressCfg, err := NewIngressConfig(clientset)
+		if expectErr {
+			g.Expect(err).ShouldNot(gomega.BeNil())
+			continue
+		}
+		g.Expect(err).Should(gomega.BeNil())
+		g.Expect(ingressCfg.Cors).To(gomega.Equal(CorsConfig{
+			AllowOrigins: []string{"https://example.com", "*"},
+			MaxAge:       600,
+		}))
+	}
+}
+
+func TestNewIngressConfigWithIngressKind(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	for data, expectErr := range map[string]bool{
+		`{"ingressGateway": "kserve/gateway", "ingressService": "gateway", "ingressKind": "Route"}`:                           false,
+		`{"ingressGateway": "kserve/gateway", "ingressService": "gateway", "ingressKind": "Ingress"}`:                         false,
+		`{"ingressGateway": "kserve/gateway", "ingressService": "gateway", "ingressKind": "HTTPRoute"}`:                       true,
+		`{"ingressGateway": "kserve/gateway", "ingressService": "gateway", "ingressKind": "Route", "enableGatewayAPI": true}`: true,
+	} {
+		clientset := fakeclientset.NewSimpleClientset(&v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: constants.InferenceServiceConfigMapName, Namespace: constants.KServeNamespace},
+			Data: map[string]string{
+				IngressConfigKeyName: data,
+			},
+		})
+		_, err := NewIngressConfig(clientset)
+		if expectErr {
+			g.Expect(err).ShouldNot(gomega.BeNil(), data)
+			continue
+		}
+		g.Expect(err).Should(gomega.BeNil(), data)
+	}
+}
+
 func TestNewDeployConfig(t *testing.T) {
 	g := gomega.NewGomegaWithT(t)
 	clientset := fakeclientset.NewSimpleClientset(&v1.ConfigMap{
@@ -88,3 +190,46 @@ func TestNewDeployConfig(t *testing.T) {
 	g.Expect(err).Should(gomega.BeNil())
 	g.Expect(deployConfig).ShouldNot(gomega.BeNil())
 }
+
+func TestNewDeployConfigWithTopologySpreadConstraints(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	clientset := fakeclientset.NewSimpleClientset(&v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: constants.InferenceServiceConfigMapName, Namespace: constants.KServeNamespace},
+		Data: map[string]string{
+			DeployConfigName: `{
+				"defaultDeploymentMode": "RawDeployment",
+				"defaultTopologySpreadConstraints": [
+					{"maxSkew": 1, "topologyKey": "topology.kubernetes.io/zone", "whenUnsatisfiable": "ScheduleAnyway"}
+				]
+			}`,
+		},
+	})
+	deployConfig, err := NewDeployConfig(clientset)
+	g.Expect(err).Should(gomega.BeNil())
+	g.Expect(deployConfig.DefaultTopologySpreadConstraints).To(gomega.Equal([]v1.TopologySpreadConstraint{
+		{
+			MaxSkew:           1,
+			TopologyKey:       "topology.kubernetes.io/zone",
+			WhenUnsatisfiable: v1.ScheduleAnyway,
+		},
+	}))
+}
+
+func TestNewDeployConfigWithPreStopSleepSeconds(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	for value, expectErr := range map[string]bool{"0": false, "15": false, "-1": true} {
+		clientset := fakeclientset.NewSimpleClientset(&v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: constants.InferenceServiceConfigMapName, Namespace: constants.KServeNamespace},
+			Data: map[string]string{
+				DeployConfigName: `{"defaultDeploymentMode": "RawDeployment", "preStopSleepSeconds": ` + value + `}`,
+			},
+		})
+		deployConfig, err := NewDeployConfig(clientset)
+		if expectErr {
+			g.Expect(err).ShouldNot(gomega.BeNil())
+			continue
+		}
+		g.Expect(err).Should(gomega.BeNil())
+		g.Expect(strconv.FormatInt(*deployConfig.PreStopSleepSeconds, 10)).To(gomega.Equal(value))
+	}
+}
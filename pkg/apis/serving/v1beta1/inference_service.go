@@ -36,7 +36,7 @@ type InferenceServiceSpec struct {
 }
 
 // LoggerType controls the scope of log publishing
-// +kubebuilder:validation:Enum=all;request;response
+// +kubebuilder:validation:Enum=all;request;response;errors
 type LoggerType string
 
 // LoggerType Enum
@@ -47,20 +47,67 @@ const (
 	LogRequest LoggerType = "request"
 	// Logger mode to log only response
 	LogResponse LoggerType = "response"
+	// Logger mode to log the request and the response of the failed inferences only
+	LogErrors LoggerType = "errors"
 )
 
+// LoggerPayloadOverflow controls how the payloads larger than the max payload size are logged
+// +kubebuilder:validation:Enum=truncate;drop
+type LoggerPayloadOverflow string
+
+// LoggerPayloadOverflow Enum
+const (
+	// Logger truncates the payloads to the max payload size
+	LogPayloadTruncate LoggerPayloadOverflow = "truncate"
+	// Logger sends the events without their payload
+	LogPayloadDrop LoggerPayloadOverflow = "drop"
+)
+
+// LoggerKafkaURLPrefix is the prefix of the logger urls publishing the logs to a kafka topic
+const LoggerKafkaURLPrefix = "kafka://"
+
 // LoggerSpec specifies optional payload logging available for all components
 type LoggerSpec struct {
-	// URL to send logging events
+	// URL to send logging events. <br />
+	// The events are posted as cloud events over http, or published to a kafka topic when the url has the form
+	// kafka://broker1:9092,broker2:9092/topic
 	// +optional
 	URL *string `json:"url,omitempty"`
+	// Name of the secret in the namespace of the InferenceService with the SASL/TLS configuration of the kafka
+	// logger url. The supported keys are sasl.mechanism (PLAIN, SCRAM-SHA-256 or SCRAM-SHA-512), sasl.username,
+	// sasl.password, tls.enabled, ca.crt, tls.crt and tls.key.
+	// +optional
+	SecretName *string `json:"secretName,omitempty"`
 	// Specifies the scope of the loggers. <br />
 	// Valid values are: <br />
 	// - "all" (default): log both request and response; <br />
 	// - "request": log only request; <br />
 	// - "response": log only response <br />
+	// - "errors": log both request and response of the inferences for which the model returns a non-2xx status or
+	// an error response <br />
 	// +optional
 	Mode LoggerType `json:"mode,omitempty"`
+	// Fraction of the inferences logged, a decimal number between "0.0" and "1.0" (default). The request and the
+	// response of an inference are either both logged or both skipped.
+	// +optional
+	SamplingRate *string `json:"samplingRate,omitempty"`
+	// Max size in bytes of the logged payloads, the larger payloads are logged according to payloadOverflow.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxPayloadBytes *int64 `json:"maxPayloadBytes,omitempty"`
+	// Specifies how the payloads larger than maxPayloadBytes are logged. <br />
+	// Valid values are: <br />
+	// - "truncate" (default): the payload is truncated to maxPayloadBytes; <br />
+	// - "drop": the event is sent without the payload <br />
+	// The events of these payloads have the payloadlimit and payloadsize attributes.
+	// +optional
+	PayloadOverflow LoggerPayloadOverflow `json:"payloadOverflow,omitempty"`
+	// Content types of the logged payloads, e.g. application/json or text/*. All the payloads are logged when empty.
+	// +optional
+	ContentTypeAllowList []string `json:"contentTypeAllowList,omitempty"`
+	// Request headers captured in the metadata attribute of the events, e.g. x-request-id.
+	// +optional
+	MetadataHeaders []string `json:"metadataHeaders,omitempty"`
 }
 
 // Batcher specifies optional payload batching available for all components
@@ -68,9 +115,18 @@ type Batcher struct {
 	// Specifies the max number of requests to trigger a batch
 	// +optional
 	MaxBatchSize *int `json:"maxBatchSize,omitempty"`
+	// Specifies the max number of batches sent concurrently to the model, unlimited if not set
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxInFlight *int `json:"maxInFlight,omitempty"`
 	// Specifies the max latency to trigger a batch
 	// +optional
 	MaxLatency *int `json:"maxLatency,omitempty"`
+	// Specifies the max number of requests waiting to be sent to the model, the further requests being rejected
+	// with the 429 status code, unlimited if not set
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxQueueSize *int `json:"maxQueueSize,omitempty"`
 	// Specifies the timeout of a batch
 	// +optional
 	Timeout *int `json:"timeout,omitempty"`
@@ -88,6 +144,9 @@ type Batcher struct {
 // +kubebuilder:printcolumn:name="Latest",type="integer",JSONPath=".status.components.predictor.traffic[?(@.latestRevision==true)].percent"
 // +kubebuilder:printcolumn:name="PrevRolledoutRevision",type="string",JSONPath=".status.components.predictor.traffic[?(@.tag=='prev')].revisionName"
 // +kubebuilder:printcolumn:name="LatestReadyRevision",type="string",JSONPath=".status.components.predictor.traffic[?(@.latestRevision==true)].revisionName"
+// +kubebuilder:printcolumn:name="ReadyReplicas",type="integer",JSONPath=".status.components.predictor.readyReplicas",priority=1
+// +kubebuilder:printcolumn:name="DesiredReplicas",type="integer",JSONPath=".status.components.predictor.desiredReplicas",priority=1
+// +kubebuilder:printcolumn:name="CanaryModel",type="string",JSONPath=".status.modelStatus.canaryRevision.state",priority=1
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 // +kubebuilder:resource:path=inferenceservices,shortName=isvc
 // +kubebuilder:storageversion
@@ -103,6 +103,8 @@ func (isvc *InferenceService) DefaultInferenceService(config *InferenceServicesC
 	if !ok || deploymentMode != string(constants.ModelMeshDeployment) {
 		// Only attempt to assign runtimes and apply defaulting logic for non-modelmesh predictors
 		isvc.setPredictorModelDefaults()
+		// the profile is applied before the global resource defaults fill the unset resources
+		isvc.setResourceProfileDefaults(config)
 		components = append(components, &isvc.Spec.Predictor)
 	} else {
 		// If this is a modelmesh predictor, we still want to do "Exactly One" validation.
@@ -123,6 +125,63 @@ func (isvc *InferenceService) DefaultInferenceService(config *InferenceServicesC
 	}
 }
 
+// setResourceProfileDefaults fills the resources of the kserve-container of the predictor left unset with the resource
+// profile named by the resource-profile annotation. Unknown profiles are rejected by the validating webhook.
+func (isvc *InferenceService) setResourceProfileDefaults(config *InferenceServicesConfig) {
+	name, ok := isvc.Annotations[constants.ResourceProfileAnnotationKey]
+	if !ok || config == nil {
+		return
+	}
+	profile, ok := config.ResourceProfiles[name]
+	if !ok {
+		return
+	}
+	var container *v1.Container
+	if isvc.Spec.Predictor.Model != nil {
+		container = &isvc.Spec.Predictor.Model.Container
+	} else if len(isvc.Spec.Predictor.Containers) != 0 {
+		// the first container is named kserve-container by the custom predictor defaulting
+		container = &isvc.Spec.Predictor.Containers[0]
+		for i := range isvc.Spec.Predictor.Containers {
+			if isvc.Spec.Predictor.Containers[i].Name == constants.InferenceServiceContainerName {
+				container = &isvc.Spec.Predictor.Containers[i]
+			}
+		}
+	}
+	if container != nil {
+		setResourceProfile(&container.Resources, profile)
+	}
+}
+
+// setResourceProfile fills the requests and limits left unset with the ones of the profile. A request larger than
+// the limit set by the user, or a limit smaller than the request set by the user, is not applied.
+func setResourceProfile(requirements *v1.ResourceRequirements, profile v1.ResourceRequirements) {
+	for name, quantity := range profile.Requests {
+		if _, ok := requirements.Requests[name]; ok {
+			continue
+		}
+		if limit, ok := requirements.Limits[name]; ok && quantity.Cmp(limit) > 0 {
+			continue
+		}
+		if requirements.Requests == nil {
+			requirements.Requests = v1.ResourceList{}
+		}
+		requirements.Requests[name] = quantity
+	}
+	for name, quantity := range profile.Limits {
+		if _, ok := requirements.Limits[name]; ok {
+			continue
+		}
+		if request, ok := requirements.Requests[name]; ok && quantity.Cmp(request) < 0 {
+			continue
+		}
+		if requirements.Limits == nil {
+			requirements.Limits = v1.ResourceList{}
+		}
+		requirements.Limits[name] = quantity
+	}
+}
+
 func (isvc *InferenceService) setPredictorModelDefaults() {
 	switch {
 	case isvc.Spec.Predictor.SKLearn != nil:
@@ -26,6 +26,7 @@ import (
 	"github.com/onsi/gomega"
 	"github.com/onsi/gomega/types"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -749,3 +750,119 @@ func TestMlServerDefaults(t *testing.T) {
 		g.Expect(scenario.isvc.ObjectMeta.Labels).To(scenario.matcher["labels"])
 	}
 }
+
+func TestResourceProfileDefaults(t *testing.T) {
+	config := &InferenceServicesConfig{
+		ResourceProfiles: map[string]v1.ResourceRequirements{
+			"medium": {
+				Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse("2"), v1.ResourceMemory: resource.MustParse("8Gi")},
+				Limits:   v1.ResourceList{v1.ResourceCPU: resource.MustParse("4"), v1.ResourceMemory: resource.MustParse("8Gi")},
+			},
+			"gpu-a10": {
+				Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse("4"), v1.ResourceMemory: resource.MustParse("16Gi")},
+				Limits: v1.ResourceList{
+					v1.ResourceCPU:                  resource.MustParse("8"),
+					v1.ResourceMemory:               resource.MustParse("16Gi"),
+					constants.NvidiaGPUResourceType: resource.MustParse("1"),
+				},
+			},
+		},
+	}
+	scenarios := map[string]struct {
+		profile   string
+		custom    bool
+		resources v1.ResourceRequirements
+		expected  v1.ResourceRequirements
+	}{
+		"NoProfile": {
+			resources: v1.ResourceRequirements{},
+			expected:  v1.ResourceRequirements{},
+		},
+		"UnknownProfile": {
+			profile:   "huge",
+			resources: v1.ResourceRequirements{},
+			expected:  v1.ResourceRequirements{},
+		},
+		"Profile": {
+			profile:   "medium",
+			resources: v1.ResourceRequirements{},
+			expected:  config.ResourceProfiles["medium"],
+		},
+		"PartialUserResources": {
+			profile: "medium",
+			resources: v1.ResourceRequirements{
+				Requests: v1.ResourceList{v1.ResourceMemory: resource.MustParse("4Gi")},
+				Limits:   v1.ResourceList{v1.ResourceCPU: resource.MustParse("3")},
+			},
+			expected: v1.ResourceRequirements{
+				Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse("2"), v1.ResourceMemory: resource.MustParse("4Gi")},
+				Limits:   v1.ResourceList{v1.ResourceCPU: resource.MustParse("3"), v1.ResourceMemory: resource.MustParse("8Gi")},
+			},
+		},
+		"ProfileNotExceedingUserResources": {
+			profile: "medium",
+			resources: v1.ResourceRequirements{
+				Requests: v1.ResourceList{v1.ResourceMemory: resource.MustParse("12Gi")},
+				Limits:   v1.ResourceList{v1.ResourceCPU: resource.MustParse("1")},
+			},
+			expected: v1.ResourceRequirements{
+				Requests: v1.ResourceList{v1.ResourceMemory: resource.MustParse("12Gi")},
+				Limits:   v1.ResourceList{v1.ResourceCPU: resource.MustParse("1")},
+			},
+		},
+		"GPUProfile": {
+			profile: "gpu-a10",
+			resources: v1.ResourceRequirements{
+				Limits: v1.ResourceList{v1.ResourceMemory: resource.MustParse("24Gi")},
+			},
+			expected: v1.ResourceRequirements{
+				Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse("4"), v1.ResourceMemory: resource.MustParse("16Gi")},
+				Limits: v1.ResourceList{
+					v1.ResourceCPU:                  resource.MustParse("8"),
+					v1.ResourceMemory:               resource.MustParse("24Gi"),
+					constants.NvidiaGPUResourceType: resource.MustParse("1"),
+				},
+			},
+		},
+		"CustomPredictor": {
+			profile: "gpu-a10",
+			custom:  true,
+			resources: v1.ResourceRequirements{
+				Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse("6")},
+			},
+			expected: v1.ResourceRequirements{
+				Requests: v1.ResourceList{v1.ResourceCPU: resource.MustParse("6"), v1.ResourceMemory: resource.MustParse("16Gi")},
+				Limits: v1.ResourceList{
+					v1.ResourceCPU:                  resource.MustParse("8"),
+					v1.ResourceMemory:               resource.MustParse("16Gi"),
+					constants.NvidiaGPUResourceType: resource.MustParse("1"),
+				},
+			},
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			isvc := InferenceService{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo", Namespace: "default", Annotations: map[string]string{}},
+			}
+			if scenario.profile != "" {
+				isvc.Annotations[constants.ResourceProfileAnnotationKey] = scenario.profile
+			}
+			if scenario.custom {
+				isvc.Spec.Predictor.Containers = []v1.Container{{Image: "custom:latest", Resources: scenario.resources}}
+			} else {
+				isvc.Spec.Predictor.Model = &ModelSpec{
+					ModelFormat:            ModelFormat{Name: "sklearn"},
+					PredictorExtensionSpec: PredictorExtensionSpec{Container: v1.Container{Resources: scenario.resources}},
+				}
+			}
+			isvc.DefaultInferenceService(config, nil)
+			if scenario.custom {
+				g.Expect(isvc.Spec.Predictor.Containers[0].Resources).To(gomega.Equal(scenario.expected))
+			} else {
+				g.Expect(isvc.Spec.Predictor.Model.Resources).To(gomega.Equal(scenario.expected))
+			}
+		})
+	}
+}
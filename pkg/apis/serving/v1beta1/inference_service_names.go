@@ -0,0 +1,120 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/kserve/kserve/pkg/utils"
+)
+
+const (
+	// GeneratedNameMaxLength is the maximum length of the resource names, label values and host name labels
+	// generated from the name of an InferenceService
+	GeneratedNameMaxLength = validation.DNS1123LabelMaxLength
+	// minTruncatedNameLength is the length of the shortest name truncated by utils.TruncateWithHash
+	minTruncatedNameLength = utils.TruncatedNameHashLength + 2
+)
+
+// allComponents are the components an InferenceService can define
+var allComponents = []constants.InferenceServiceComponent{constants.Predictor, constants.Transformer, constants.Explainer}
+
+// generatedNames returns the resource names and label values generated from the name prefix for the component in
+// the deployment mode. The host names are checked against the domain template by validateGeneratedHosts.
+func generatedNames(prefix string, deploymentMode constants.DeploymentModeType, component constants.InferenceServiceComponent) []string {
+	serviceName := prefix + "-" + component.String()
+	switch deploymentMode {
+	case constants.ModelMeshDeployment:
+		// the predictors are served by the ModelMesh deployments shared by the namespace
+		return nil
+	case constants.RawDeployment:
+		return []string{serviceName, constants.GetRawServiceLabel(serviceName)}
+	default:
+		// knative suffixes the revisions with their generation and creates a private service for each of them
+		revisionName := serviceName + "-00001"
+		return []string{revisionName, revisionName + "-private"}
+	}
+}
+
+// maxGeneratedNamePrefixLength returns the longest name prefix the names generated for the components fit in
+func maxGeneratedNamePrefixLength(deploymentMode constants.DeploymentModeType, components []constants.InferenceServiceComponent) int {
+	maxLength := GeneratedNameMaxLength
+	for _, component := range components {
+		for _, name := range generatedNames("", deploymentMode, component) {
+			maxLength = min(maxLength, GeneratedNameMaxLength-len(name))
+		}
+	}
+	return maxLength
+}
+
+// validateGeneratedHosts validates that the hosts the raw deployment controller generates for the InferenceService
+// and its components from the domain template of the ingress config are valid host names
+func validateGeneratedHosts(isvc *InferenceService, ingressConfig *IngressConfig) error {
+	if isvc.deploymentMode() != constants.RawDeployment {
+		return nil
+	}
+	names := []string{isvc.Name}
+	for _, component := range isvc.components() {
+		names = append(names, isvc.GeneratedNamePrefix()+"-"+component.String())
+	}
+	for _, name := range names {
+		if _, err := ingressConfig.GenerateDomainName(name, isvc.ObjectMeta); err != nil {
+			return fmt.Errorf(InvalidISVCHostError, isvc.Name, ingressConfig.DomainTemplate, err)
+		}
+	}
+	return nil
+}
+
+// deploymentMode returns the deployment mode set on the InferenceService by the defaulting webhook
+func (isvc *InferenceService) deploymentMode() constants.DeploymentModeType {
+	if deploymentMode, ok := isvc.Annotations[constants.DeploymentMode]; ok {
+		return constants.DeploymentModeType(deploymentMode)
+	}
+	return constants.Serverless
+}
+
+// components returns the components defined in the spec of the InferenceService
+func (isvc *InferenceService) components() []constants.InferenceServiceComponent {
+	components := []constants.InferenceServiceComponent{constants.Predictor}
+	if isvc.Spec.Transformer != nil {
+		components = append(components, constants.Transformer)
+	}
+	if isvc.Spec.Explainer != nil {
+		components = append(components, constants.Explainer)
+	}
+	return components
+}
+
+// truncatesGeneratedNames returns whether the names generated for the InferenceService are truncated when too long
+func (isvc *InferenceService) truncatesGeneratedNames() bool {
+	return isvc.Annotations[constants.TruncateGeneratedNamesAnnotationKey] == "true"
+}
+
+// GeneratedNamePrefix returns the prefix of the names generated for the components of the InferenceService. It is
+// the name of the InferenceService, truncated with a hash when it is too long and the
+// TruncateGeneratedNamesAnnotationKey annotation is set. The prefix fits every component so that adding a component
+// later does not rename the others.
+func (isvc *InferenceService) GeneratedNamePrefix() string {
+	if !isvc.truncatesGeneratedNames() {
+		return isvc.Name
+	}
+	maxLength := maxGeneratedNamePrefixLength(isvc.deploymentMode(), allComponents)
+	return utils.TruncateWithHash(isvc.Name, max(maxLength, minTruncatedNameLength))
+}
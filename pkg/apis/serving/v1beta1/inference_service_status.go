@@ -17,11 +17,17 @@ limitations under the License.
 package v1beta1
 
 import (
+	"encoding/json"
 	"reflect"
+	"strings"
+	"time"
 
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
 	"github.com/kserve/kserve/pkg/constants"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
@@ -41,10 +47,18 @@ type InferenceServiceStatus struct {
 	// Addressable endpoint for the InferenceService
 	// +optional
 	Address *duckv1.Addressable `json:"address,omitempty"`
+	// Addresses holds the addressable endpoints of all the hosts the InferenceService is exposed at, the host of its url
+	// followed by the hosts of the additional ingress domains and of the serving.kserve.io/additional-hosts annotation.
+	// +optional
+	Addresses []duckv1.Addressable `json:"addresses,omitempty"`
 	// URL holds the url that will distribute traffic over the provided traffic targets.
 	// It generally has the form http[s]://{route-name}.{route-namespace}.{cluster-level-suffix}
 	// +optional
 	URL *apis.URL `json:"url,omitempty"`
+	// ExternalURLs holds the additional urls the InferenceService is reachable at, e.g. the custom domain set with the
+	// serving.kserve.io/custom-domain annotation.
+	// +optional
+	ExternalURLs []*apis.URL `json:"externalUrls,omitempty"`
 	// Statuses for the components of the InferenceService
 	Components map[ComponentType]ComponentStatusSpec `json:"components,omitempty"`
 	// Model related statuses
@@ -82,6 +96,19 @@ type ComponentStatusSpec struct {
 	// Addressable endpoint for the InferenceService
 	// +optional
 	Address *duckv1.Addressable `json:"address,omitempty"`
+	// Total number of pods targeted by the raw deployment of the component.
+	// +optional
+	Replicas int32 `json:"replicas,omitempty"`
+	// Number of pods of the raw deployment of the component with a Ready condition.
+	// +optional
+	ReadyReplicas int32 `json:"readyReplicas,omitempty"`
+	// Number of replicas desired by the autoscaler of the component, unset when the component is not scaled by an HPA.
+	// +optional
+	DesiredReplicas *int32 `json:"desiredReplicas,omitempty"`
+	// Name of the service account created for the component to access its storage with a cloud IAM identity, it is
+	// deleted once the component no longer uses it.
+	// +optional
+	IAMServiceAccountName string `json:"iamServiceAccountName,omitempty"`
 }
 
 // ComponentType contains the different types of components of the service
@@ -120,6 +147,19 @@ const (
 	RoutesReady apis.ConditionType = "RoutesReady"
 	// LatestDeploymentReady is set when underlying configurations for all components have reported readiness.
 	LatestDeploymentReady apis.ConditionType = "LatestDeploymentReady"
+	// Activating is set while a raw deployment predictor scaled to zero is scaled back up.
+	Activating apis.ConditionType = "Activating"
+	// AutoscalingPaused is set while the autoscaling of a raw deployment is paused by annotation.
+	AutoscalingPaused apis.ConditionType = "AutoscalingPaused"
+	// Degraded is set while the predictor is served by a disabled runtime it was already using.
+	Degraded apis.ConditionType = "Degraded"
+)
+
+// Activating condition reasons
+const (
+	ActivatingReasonScaledToZero = "ScaledToZero"
+	ActivatingReasonWakingUp     = "WakingUp"
+	ActivatingReasonActive       = "Active"
 )
 
 type ModelStatus struct {
@@ -138,6 +178,77 @@ type ModelStatus struct {
 	// Model copy information of the predictor's model.
 	// +optional
 	ModelCopies *ModelCopies `json:"copies,omitempty"`
+
+	// Download state of the model and of each of its adapters, reported when the predictor has adapters.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Artifacts []ArtifactStatus `json:"artifacts,omitempty"`
+
+	// Wall-clock duration of the last successful download of the model by the storage initializer.
+	// +optional
+	LastDownloadDuration *metav1.Duration `json:"lastDownloadDuration,omitempty"`
+
+	// Runtime serving the model, with the generations its effective spec was built from.
+	// +optional
+	Runtime *RuntimeStatus `json:"runtime,omitempty"`
+
+	// Model state of the last rolled out revision, reported while a canary revision is rolled out.
+	// +optional
+	StableRevision *RevisionModelStatus `json:"stableRevision,omitempty"`
+
+	// Model state of the canary revision, reported while it is rolled out.
+	// +optional
+	CanaryRevision *RevisionModelStatus `json:"canaryRevision,omitempty"`
+
+	// Memory requested by the TrainedModels allocated to the multi-model predictor.
+	// +optional
+	AllocatedModelMemory *resource.Quantity `json:"allocatedModelMemory,omitempty"`
+}
+
+// RevisionModelStatus is the state of the predictor's model on one of the revisions of a canary rollout
+type RevisionModelStatus struct {
+	// Name of the revision.
+	Name string `json:"name"`
+	// State of the model on the revision: Pending, Loading, Loaded, FailedToLoad
+	// +kubebuilder:default=Pending
+	State ModelState `json:"state"`
+	// Whether the model of the revision is loaded, or is in transition
+	// +kubebuilder:default=InProgress
+	TransitionStatus TransitionStatus `json:"transitionStatus"`
+	// Details of last failure, when load of the model of the revision is failed.
+	// +optional
+	LastFailureInfo *FailureInfo `json:"lastFailureInfo,omitempty"`
+	// Storage URI of the model downloaded by the revision.
+	// +optional
+	StorageUri string `json:"storageUri,omitempty"`
+}
+
+// ArtifactStatus is the download state of one of the storage sources of the predictor's model
+type ArtifactStatus struct {
+	// Name of the adapter, or "model" for the storageUri of the model.
+	Name string `json:"name"`
+	// Download state of the artifact: Pending, Loading, Loaded, FailedToLoad
+	// +kubebuilder:default=Pending
+	State ModelState `json:"state"`
+	// Details of the failure, when the download of the artifact is failed.
+	// +optional
+	FailureInfo *FailureInfo `json:"failureInfo,omitempty"`
+}
+
+// RuntimeStatus identifies the ServingRuntime serving the predictor's model
+type RuntimeStatus struct {
+	// Name of the ServingRuntime.
+	Name string `json:"name"`
+	// Generation of the ServingRuntime.
+	// +optional
+	Generation int64 `json:"generation,omitempty"`
+	// Name of the ClusterServingRuntime the ServingRuntime inherits from.
+	// +optional
+	InheritFrom string `json:"inheritFrom,omitempty"`
+	// Generation of the ClusterServingRuntime the ServingRuntime inherits from.
+	// +optional
+	InheritFromGeneration int64 `json:"inheritFromGeneration,omitempty"`
 }
 
 type ModelRevisionStates struct {
@@ -192,7 +303,7 @@ const (
 )
 
 // FailureReason enum
-// +kubebuilder:validation:Enum=ModelLoadFailed;RuntimeUnhealthy;RuntimeDisabled;NoSupportingRuntime;RuntimeNotRecognized;InvalidPredictorSpec
+// +kubebuilder:validation:Enum=ModelLoadFailed;RuntimeUnhealthy;RuntimeDisabled;NoSupportingRuntime;RuntimeNotRecognized;InvalidPredictorSpec;ResourceRejected
 type FailureReason string
 
 // FailureReason enum values
@@ -209,6 +320,8 @@ const (
 	RuntimeNotRecognized FailureReason = "RuntimeNotRecognized"
 	// The current Predictor Spec is invalid or unsupported
 	InvalidPredictorSpec FailureReason = "InvalidPredictorSpec"
+	// The resources generated for the Predictor were rejected by the API server, e.g. by a quota or an admission policy
+	ResourceRejected FailureReason = "ResourceRejected"
 )
 
 type FailureInfo struct {
@@ -308,16 +421,111 @@ func (ss *InferenceServiceStatus) PropagateRawStatus(
 	}
 
 	statusSpec.LatestCreatedRevision = deployment.GetObjectMeta().GetAnnotations()["deployment.kubernetes.io/revision"]
+	statusSpec.Replicas = deployment.Status.Replicas
+	statusSpec.ReadyReplicas = deployment.Status.ReadyReplicas
 	condition := getDeploymentCondition(deployment, appsv1.DeploymentAvailable)
 	if condition != nil && condition.Status == v1.ConditionTrue {
 		statusSpec.URL = url
 	}
 	readyCondition := readyConditionsMap[component]
-	ss.SetCondition(readyCondition, condition)
+	// Surface the reason of a failed rollout, e.g. pods denied by a resource quota, instead of the generic
+	// minimum availability message
+	if failure := getDeploymentFailureCondition(deployment); failure != nil {
+		if condition.Status != v1.ConditionTrue {
+			condition.Status = v1.ConditionFalse
+			condition.Reason = failure.Reason
+			condition.Message = failure.Message
+		}
+		if component == PredictorComponent {
+			ss.SetModelFailureInfo(&FailureInfo{
+				Location: deployment.Name,
+				Reason:   RuntimeUnhealthy,
+				Message:  failure.Message,
+			})
+		}
+	}
+	// A ready component stays ready while its rollout is progressing within the deployment's progress deadline
+	if condition.Status != v1.ConditionTrue && ss.IsConditionReady(readyCondition) && isDeploymentProgressing(deployment) {
+		condition = ss.GetCondition(readyCondition)
+	}
+	ss.SetCondition(readyCondition, classifyCondition(condition))
+	if component == PredictorComponent {
+		ss.propagateActivatingStatus(deployment)
+	}
 	ss.Components[component] = statusSpec
 	ss.ObservedGeneration = deployment.Status.ObservedGeneration
 }
 
+// SetIAMServiceAccountName records the name of the IAM service account created for the component, an empty name
+// records that the component has none.
+func (ss *InferenceServiceStatus) SetIAMServiceAccountName(component ComponentType, name string) {
+	if len(ss.Components) == 0 {
+		ss.Components = make(map[ComponentType]ComponentStatusSpec)
+	}
+	statusSpec := ss.Components[component]
+	statusSpec.IAMServiceAccountName = name
+	ss.Components[component] = statusSpec
+}
+
+// PropagateRawAutoscalerStatus propagates the replicas desired by the HPA of the component, the desired replicas are
+// cleared when the component has no HPA, e.g. while autoscaling is paused.
+func (ss *InferenceServiceStatus) PropagateRawAutoscalerStatus(component ComponentType,
+	hpa *autoscalingv2.HorizontalPodAutoscaler) {
+	statusSpec, ok := ss.Components[component]
+	if !ok {
+		return
+	}
+	statusSpec.DesiredReplicas = nil
+	if hpa != nil {
+		desiredReplicas := hpa.Status.DesiredReplicas
+		statusSpec.DesiredReplicas = &desiredReplicas
+	}
+	ss.Components[component] = statusSpec
+}
+
+// propagateActivatingStatus sets the Activating condition once the predictor deployment has been scaled to zero,
+// the condition is True while the deployment is scaled back up and has no available replica yet.
+func (ss *InferenceServiceStatus) propagateActivatingStatus(deployment *appsv1.Deployment) {
+	switch {
+	case deployment.Spec.Replicas != nil && *deployment.Spec.Replicas == 0:
+		ss.SetCondition(Activating, &apis.Condition{
+			Status:  v1.ConditionFalse,
+			Reason:  ActivatingReasonScaledToZero,
+			Message: "The predictor is scaled to zero",
+		})
+	case ss.GetCondition(Activating) == nil:
+		// the predictor has never been scaled to zero
+	case deployment.Status.AvailableReplicas == 0:
+		conditionSet.Manage(ss).MarkTrueWithReason(Activating, ActivatingReasonWakingUp, "The predictor is scaling up from zero")
+	default:
+		ss.SetCondition(Activating, &apis.Condition{
+			Status: v1.ConditionFalse,
+			Reason: ActivatingReasonActive,
+		})
+	}
+}
+
+// SetAutoscalingPaused sets the AutoscalingPaused condition while autoscaling is paused and clears it once resumed.
+func (ss *InferenceServiceStatus) SetAutoscalingPaused(paused bool) {
+	if !paused {
+		ss.ClearCondition(AutoscalingPaused)
+		return
+	}
+	conditionSet.Manage(ss).MarkTrueWithReason(AutoscalingPaused, "PausedByAnnotation",
+		"Autoscaling is paused, the deployment replicas are not managed by the autoscaler")
+}
+
+// SetRuntimeDisabled sets the Degraded condition while the predictor keeps being served by the disabled runtime and
+// clears it otherwise.
+func (ss *InferenceServiceStatus) SetRuntimeDisabled(runtime string, disabled bool) {
+	if !disabled {
+		ss.ClearCondition(Degraded)
+		return
+	}
+	conditionSet.Manage(ss).MarkTrueWithReason(Degraded, string(RuntimeDisabled),
+		"The runtime %s is disabled, it keeps serving the model until the InferenceService moves to another runtime", runtime)
+}
+
 func getDeploymentCondition(deployment *appsv1.Deployment, conditionType appsv1.DeploymentConditionType) *apis.Condition {
 	condition := apis.Condition{}
 	for _, con := range deployment.Status.Conditions {
@@ -335,6 +543,31 @@ func getDeploymentCondition(deployment *appsv1.Deployment, conditionType appsv1.
 	return &condition
 }
 
+// getDeploymentFailureCondition returns the ReplicaFailure condition, or the Progressing condition once the
+// progress deadline is exceeded. It returns nil if the rollout has not failed.
+func getDeploymentFailureCondition(deployment *appsv1.Deployment) *appsv1.DeploymentCondition {
+	var progressDeadlineExceeded *appsv1.DeploymentCondition
+	for i, con := range deployment.Status.Conditions {
+		switch {
+		case con.Type == appsv1.DeploymentReplicaFailure && con.Status == v1.ConditionTrue:
+			return &deployment.Status.Conditions[i]
+		case con.Type == appsv1.DeploymentProgressing && con.Reason == constants.DeploymentReasonProgressDeadlineExceeded:
+			progressDeadlineExceeded = &deployment.Status.Conditions[i]
+		}
+	}
+	return progressDeadlineExceeded
+}
+
+// isDeploymentProgressing returns true if the deployment rollout has not exceeded its progress deadline
+func isDeploymentProgressing(deployment *appsv1.Deployment) bool {
+	for _, con := range deployment.Status.Conditions {
+		if con.Type == appsv1.DeploymentProgressing {
+			return con.Status == v1.ConditionTrue && con.Reason != constants.DeploymentReasonProgressDeadlineExceeded
+		}
+	}
+	return false
+}
+
 // PropagateCrossComponentStatus aggregates the RoutesReady or ConfigurationsReady condition across all available components
 // and propagates the RoutesReady or LatestDeploymentReady status accordingly.
 func (ss *InferenceServiceStatus) PropagateCrossComponentStatus(componentList []ComponentType, conditionType apis.ConditionType) {
@@ -348,14 +581,19 @@ func (ss *InferenceServiceStatus) PropagateCrossComponentStatus(componentList []
 	}
 	for _, component := range componentList {
 		if !ss.IsConditionReady(conditionsMap[component]) {
-			crossComponentCondition.Status = v1.ConditionFalse
+			notReady := string(conditionsMap[component]) + " not ready"
 			if ss.IsConditionUnknown(conditionsMap[component]) { // include check for nil condition
 				crossComponentCondition.Status = v1.ConditionUnknown
+				crossComponentCondition.Reason, crossComponentCondition.Message = notReady, ""
+			} else {
+				// the reason of the failed component condition is already classified
+				crossComponentCondition.Status = v1.ConditionFalse
+				crossComponentCondition.Reason = ss.GetCondition(conditionsMap[component]).Reason
+				crossComponentCondition.Message = notReady
 			}
-			crossComponentCondition.Reason = string(conditionsMap[component]) + " not ready"
 		}
 	}
-	ss.SetCondition(conditionType, crossComponentCondition)
+	ss.SetCondition(conditionType, classifyCondition(crossComponentCondition))
 }
 
 func (ss *InferenceServiceStatus) PropagateStatus(component ComponentType, serviceStatus *knservingv1.ServiceStatus) {
@@ -413,17 +651,17 @@ func (ss *InferenceServiceStatus) PropagateStatus(component ComponentType, servi
 		}
 	}
 	readyConditionType := readyConditionsMap[component]
-	ss.SetCondition(readyConditionType, readyCondition)
+	ss.SetCondition(readyConditionType, classifyCondition(readyCondition))
 	// propagate route condition for each component
 	routeCondition := serviceStatus.GetCondition("RoutesReady")
 	routeConditionType := routeConditionsMap[component]
-	ss.SetCondition(routeConditionType, routeCondition)
+	ss.SetCondition(routeConditionType, classifyCondition(routeCondition))
 	// propagate configuration condition for each component
 	configurationCondition := serviceStatus.GetCondition("ConfigurationsReady")
 	configurationConditionType := configurationConditionsMap[component]
 	// propagate traffic status for each component
 	statusSpec.Traffic = serviceStatus.Traffic
-	ss.SetCondition(configurationConditionType, configurationCondition)
+	ss.SetCondition(configurationConditionType, classifyCondition(configurationCondition))
 
 	ss.Components[component] = statusSpec
 	ss.ObservedGeneration = serviceStatus.ObservedGeneration
@@ -480,12 +718,29 @@ func (ss *InferenceServiceStatus) UpdateModelTransitionStatus(status TransitionS
 		} else {
 			ss.ModelStatus.ModelRevisionStates.TargetModelState = FailedToLoad
 		}
+		// A predictor already serving a previous spec stays ready
+		if info != nil && !ss.IsConditionReady(PredictorReady) {
+			conditionSet.Manage(ss).MarkFalse(PredictorReady, failureConditionReason(info), info.Message)
+		}
 	}
 	if info != nil {
 		ss.SetModelFailureInfo(info)
 	}
 }
 
+// SetRuntimeStatus records the runtime serving the predictor's model and the generations of its effective spec
+func (ss *InferenceServiceStatus) SetRuntimeStatus(runtime *v1alpha1.SupportedRuntime) {
+	runtimeStatus := &RuntimeStatus{
+		Name:       runtime.Name,
+		Generation: runtime.Generation,
+	}
+	if runtime.Spec.InheritFrom != nil {
+		runtimeStatus.InheritFrom = *runtime.Spec.InheritFrom
+		runtimeStatus.InheritFromGeneration = runtime.InheritFromGeneration
+	}
+	ss.ModelStatus.Runtime = runtimeStatus
+}
+
 func (ss *InferenceServiceStatus) SetModelFailureInfo(info *FailureInfo) bool {
 	if reflect.DeepEqual(info, ss.ModelStatus.LastFailureInfo) {
 		return false
@@ -501,6 +756,19 @@ func (ss *InferenceServiceStatus) PropagateModelStatus(statusSpec ComponentStatu
 		ss.UpdateModelRevisionStates(Pending, totalCopies, nil)
 		return
 	}
+	// Report the download state of each artifact when the model has adapters
+	ss.ModelStatus.Artifacts = nil
+	if artifacts := getArtifactStatuses(podList.Items[0].Status.InitContainerStatuses); len(artifacts) > 1 {
+		ss.ModelStatus.Artifacts = artifacts
+	}
+	// Report the duration of the model download once the storage initializer is completed
+	for _, cs := range podList.Items[0].Status.InitContainerStatuses {
+		if cs.Name == constants.StorageInitializerContainerName {
+			if duration, ok := GetStorageInitializerDownloadDuration(cs); ok {
+				ss.ModelStatus.LastDownloadDuration = &metav1.Duration{Duration: duration}
+			}
+		}
+	}
 	// Update model state to 'Loaded' if inferenceservice status is ready.
 	// For serverless deployment, the latest created revision and the latest ready revision should be equal
 	if ss.IsReady() {
@@ -512,30 +780,24 @@ func (ss *InferenceServiceStatus) PropagateModelStatus(statusSpec ComponentStatu
 			return
 		}
 	}
-	// Update model state to 'Loading' if storage initializer is running.
-	// If the storage initializer is terminated due to error or crashloopbackoff, update model
-	// state to 'ModelLoadFailed' with failure info.
+	// Update model state to 'Loading' if a storage initializer is running.
+	// If a storage initializer is terminated due to error or crashloopbackoff, update model
+	// state to 'ModelLoadFailed' with failure info, located at the adapter it downloads.
 	for _, cs := range podList.Items[0].Status.InitContainerStatuses {
-		if cs.Name == constants.StorageInitializerContainerName {
-			switch {
-			case cs.State.Running != nil:
-				ss.UpdateModelRevisionStates(Loading, totalCopies, nil)
-				return
-			case cs.State.Terminated != nil && cs.State.Terminated.Reason == constants.StateReasonError:
-				ss.UpdateModelRevisionStates(FailedToLoad, totalCopies, &FailureInfo{
-					Reason:   ModelLoadFailed,
-					Message:  cs.State.Terminated.Message,
-					ExitCode: cs.State.Terminated.ExitCode,
-				})
-				return
-			case cs.State.Waiting != nil && cs.State.Waiting.Reason == constants.StateReasonCrashLoopBackOff:
-				ss.UpdateModelRevisionStates(FailedToLoad, totalCopies, &FailureInfo{
-					Reason:   ModelLoadFailed,
-					Message:  cs.LastTerminationState.Terminated.Message,
-					ExitCode: cs.LastTerminationState.Terminated.ExitCode,
-				})
-				return
+		artifactName, ok := getArtifactName(cs.Name)
+		if !ok {
+			continue
+		}
+		switch state, info := getArtifactState(cs); state {
+		case Loading:
+			ss.UpdateModelRevisionStates(Loading, totalCopies, nil)
+			return
+		case FailedToLoad:
+			if artifactName != ModelArtifactName {
+				info.Location = artifactName
 			}
+			ss.UpdateModelRevisionStates(FailedToLoad, totalCopies, info)
+			return
 		}
 	}
 	// If the kserve container is terminated due to error or crashloopbackoff, update model
@@ -555,9 +817,168 @@ func (ss *InferenceServiceStatus) PropagateModelStatus(statusSpec ComponentStatu
 					Message:  cs.LastTerminationState.Terminated.Message,
 					ExitCode: cs.LastTerminationState.Terminated.ExitCode,
 				})
+			case cs.State.Waiting != nil && (cs.State.Waiting.Reason == constants.StateReasonImagePullBackOff ||
+				cs.State.Waiting.Reason == constants.StateReasonErrImagePull):
+				ss.UpdateModelRevisionStates(FailedToLoad, totalCopies, &FailureInfo{
+					Location: podList.Items[0].Name,
+					Reason:   RuntimeUnhealthy,
+					Message:  cs.State.Waiting.Reason + ": " + cs.State.Waiting.Message,
+				})
 			default:
 				ss.UpdateModelRevisionStates(Pending, totalCopies, nil)
 			}
 		}
 	}
 }
+
+// PropagateRevisionModelStatus reports the model state of the last rolled out revision and of the canary revision
+// from their pods while a canary revision is rolled out, and clears them otherwise.
+func (ss *InferenceServiceStatus) PropagateRevisionModelStatus(statusSpec ComponentStatusSpec, stablePods *v1.PodList,
+	canaryPods *v1.PodList) {
+	if stablePods == nil || canaryPods == nil {
+		ss.ModelStatus.StableRevision = nil
+		ss.ModelStatus.CanaryRevision = nil
+		return
+	}
+	// The last rolled out revision has been ready, it keeps its model loaded when scaled to zero
+	ss.ModelStatus.StableRevision = getRevisionModelStatus(statusSpec.LatestRolledoutRevision, stablePods, true)
+	ss.ModelStatus.CanaryRevision = getRevisionModelStatus(statusSpec.LatestCreatedRevision, canaryPods,
+		statusSpec.LatestReadyRevision == statusSpec.LatestCreatedRevision)
+}
+
+// getRevisionModelStatus returns the model state of the revision from the state of its pods, a ready revision without
+// pods is scaled to zero and has loaded its model
+func getRevisionModelStatus(revision string, podList *v1.PodList, revisionReady bool) *RevisionModelStatus {
+	status := &RevisionModelStatus{
+		Name:             revision,
+		State:            Pending,
+		TransitionStatus: InProgress,
+	}
+	if len(podList.Items) == 0 {
+		if revisionReady {
+			status.State = Loaded
+			status.TransitionStatus = UpToDate
+		}
+		return status
+	}
+	pod := podList.Items[0]
+	status.StorageUri = pod.Annotations[constants.StorageInitializerSourceUriInternalAnnotationKey]
+	for _, p := range podList.Items {
+		for _, condition := range p.Status.Conditions {
+			if condition.Type == v1.PodReady && condition.Status == v1.ConditionTrue {
+				status.State = Loaded
+				status.TransitionStatus = UpToDate
+				return status
+			}
+		}
+	}
+	for _, cs := range pod.Status.InitContainerStatuses {
+		artifactName, ok := getArtifactName(cs.Name)
+		if !ok {
+			continue
+		}
+		switch state, info := getArtifactState(cs); state {
+		case Loading:
+			status.State = Loading
+			return status
+		case FailedToLoad:
+			if artifactName != ModelArtifactName {
+				info.Location = artifactName
+			}
+			status.State = FailedToLoad
+			status.TransitionStatus = BlockedByFailedLoad
+			status.LastFailureInfo = info
+			return status
+		}
+	}
+	for _, cs := range pod.Status.ContainerStatuses {
+		if cs.Name != constants.InferenceServiceContainerName {
+			continue
+		}
+		var info *FailureInfo
+		switch {
+		case cs.State.Terminated != nil && cs.State.Terminated.Reason == constants.StateReasonError:
+			info = &FailureInfo{
+				Reason:   ModelLoadFailed,
+				Message:  cs.State.Terminated.Message,
+				ExitCode: cs.State.Terminated.ExitCode,
+			}
+		case cs.State.Waiting != nil && cs.State.Waiting.Reason == constants.StateReasonCrashLoopBackOff:
+			info = &FailureInfo{Reason: ModelLoadFailed}
+			if cs.LastTerminationState.Terminated != nil {
+				info.Message = cs.LastTerminationState.Terminated.Message
+				info.ExitCode = cs.LastTerminationState.Terminated.ExitCode
+			}
+		}
+		if info != nil {
+			status.State = FailedToLoad
+			status.TransitionStatus = BlockedByFailedLoad
+			status.LastFailureInfo = info
+		}
+	}
+	return status
+}
+
+// storageDownloadStatus is the download status reported by the storage initializer in its termination message
+type storageDownloadStatus struct {
+	DurationSeconds *float64 `json:"durationSeconds"`
+}
+
+// GetStorageInitializerDownloadDuration returns the duration of the download of a successfully terminated storage
+// initializer container, as reported in its termination message
+func GetStorageInitializerDownloadDuration(cs v1.ContainerStatus) (time.Duration, bool) {
+	if cs.State.Terminated == nil || cs.State.Terminated.ExitCode != 0 {
+		return 0, false
+	}
+	status := storageDownloadStatus{}
+	if err := json.Unmarshal([]byte(cs.State.Terminated.Message), &status); err != nil || status.DurationSeconds == nil {
+		return 0, false
+	}
+	return time.Duration(*status.DurationSeconds * float64(time.Second)).Round(time.Millisecond), true
+}
+
+// getArtifactName returns the name of the artifact downloaded by the storage initializer container, the adapters are
+// downloaded by the storage-initializer-<adapter name> containers.
+func getArtifactName(containerName string) (string, bool) {
+	if containerName == constants.StorageInitializerContainerName {
+		return ModelArtifactName, true
+	}
+	return strings.CutPrefix(containerName, constants.StorageInitializerContainerName+"-")
+}
+
+// getArtifactState returns the download state of the artifact of the storage initializer container, with the failure
+// info when the download is failed
+func getArtifactState(cs v1.ContainerStatus) (ModelState, *FailureInfo) {
+	switch {
+	case cs.State.Running != nil:
+		return Loading, nil
+	case cs.State.Terminated != nil && cs.State.Terminated.Reason == constants.StateReasonError:
+		return FailedToLoad, &FailureInfo{
+			Reason:   ModelLoadFailed,
+			Message:  cs.State.Terminated.Message,
+			ExitCode: cs.State.Terminated.ExitCode,
+		}
+	case cs.State.Waiting != nil && cs.State.Waiting.Reason == constants.StateReasonCrashLoopBackOff:
+		return FailedToLoad, &FailureInfo{
+			Reason:   ModelLoadFailed,
+			Message:  cs.LastTerminationState.Terminated.Message,
+			ExitCode: cs.LastTerminationState.Terminated.ExitCode,
+		}
+	case cs.State.Terminated != nil && cs.State.Terminated.ExitCode == 0:
+		return Loaded, nil
+	default:
+		return Pending, nil
+	}
+}
+
+// getArtifactStatuses returns the download state of the artifacts of the storage initializer containers
+func getArtifactStatuses(initContainerStatuses []v1.ContainerStatus) []ArtifactStatus {
+	var artifacts []ArtifactStatus
+	for _, cs := range initContainerStatuses {
+		if artifactName, ok := getArtifactName(cs.Name); ok {
+			state, info := getArtifactState(cs)
+			artifacts = append(artifacts, ArtifactStatus{Name: artifactName, State: state, FailureInfo: info})
+		}
+	}
+	return artifacts
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package v1beta1
 
 import (
+	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
 	"github.com/kserve/kserve/pkg/constants"
 	"github.com/onsi/gomega"
 	"net/url"
@@ -25,6 +26,7 @@ import (
 
 	"google.golang.org/protobuf/proto"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	"k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"knative.dev/pkg/apis"
@@ -219,6 +221,257 @@ func TestPropagateRawStatus(t *testing.T) {
 	}
 }
 
+func TestPropagateRawStatusDuringRollout(t *testing.T) {
+	unavailableDeployment := func(progressingStatus v1.ConditionStatus, progressingReason string) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			Status: appsv1.DeploymentStatus{
+				Conditions: []appsv1.DeploymentCondition{
+					{
+						Type:    appsv1.DeploymentAvailable,
+						Status:  v1.ConditionFalse,
+						Reason:  "MinimumReplicasUnavailable",
+						Message: "Deployment does not have minimum availability.",
+					},
+					{
+						Type:   appsv1.DeploymentProgressing,
+						Status: progressingStatus,
+						Reason: progressingReason,
+					},
+				},
+			},
+		}
+	}
+	cases := map[string]struct {
+		deployment *appsv1.Deployment
+		wasReady   bool
+		isReady    bool
+	}{
+		"ReadyComponentStaysReadyWhileProgressing": {
+			deployment: unavailableDeployment(v1.ConditionTrue, "ReplicaSetUpdated"),
+			wasReady:   true,
+			isReady:    true,
+		},
+		"ReadyComponentNotReadyAfterProgressDeadline": {
+			deployment: unavailableDeployment(v1.ConditionFalse, constants.DeploymentReasonProgressDeadlineExceeded),
+			wasReady:   true,
+			isReady:    false,
+		},
+		"NewComponentNotReadyWhileProgressing": {
+			deployment: unavailableDeployment(v1.ConditionTrue, "ReplicaSetUpdated"),
+			wasReady:   false,
+			isReady:    false,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			status := &InferenceServiceStatus{}
+			if tc.wasReady {
+				status.SetCondition(PredictorReady, &apis.Condition{
+					Type:   PredictorReady,
+					Status: v1.ConditionTrue,
+				})
+			}
+			status.PropagateRawStatus(PredictorComponent, tc.deployment, &apis.URL{})
+			if res := status.IsConditionReady(PredictorReady); res != tc.isReady {
+				t.Errorf("expected: %v got: %v conditions: %v", tc.isReady, res, status.Conditions)
+			}
+		})
+	}
+}
+
+func TestPropagateRawStatusRolloutFailure(t *testing.T) {
+	cases := map[string]struct {
+		conditions          []appsv1.DeploymentCondition
+		expectedReason      string
+		expectedMessage     string
+		expectedFailureInfo *FailureInfo
+	}{
+		"QuotaExceeded": {
+			conditions: []appsv1.DeploymentCondition{
+				{
+					Type:    appsv1.DeploymentAvailable,
+					Status:  v1.ConditionFalse,
+					Reason:  "MinimumReplicasUnavailable",
+					Message: "Deployment does not have minimum availability.",
+				},
+				{
+					Type:    appsv1.DeploymentReplicaFailure,
+					Status:  v1.ConditionTrue,
+					Reason:  "FailedCreate",
+					Message: "pods \"test-predictor-7c9d-abcde\" is forbidden: exceeded quota: compute, requested: nvidia.com/gpu=1, used: nvidia.com/gpu=4, limited: nvidia.com/gpu=4",
+				},
+			},
+			expectedReason:  InsufficientQuota,
+			expectedMessage: "pods \"test-predictor-7c9d-abcde\" is forbidden: exceeded quota: compute, requested: nvidia.com/gpu=1, used: nvidia.com/gpu=4, limited: nvidia.com/gpu=4",
+			expectedFailureInfo: &FailureInfo{
+				Location: "test-predictor",
+				Reason:   RuntimeUnhealthy,
+				Message:  "pods \"test-predictor-7c9d-abcde\" is forbidden: exceeded quota: compute, requested: nvidia.com/gpu=1, used: nvidia.com/gpu=4, limited: nvidia.com/gpu=4",
+			},
+		},
+		"ProgressDeadlineExceeded": {
+			conditions: []appsv1.DeploymentCondition{
+				{
+					Type:    appsv1.DeploymentAvailable,
+					Status:  v1.ConditionFalse,
+					Reason:  "MinimumReplicasUnavailable",
+					Message: "Deployment does not have minimum availability.",
+				},
+				{
+					Type:    appsv1.DeploymentProgressing,
+					Status:  v1.ConditionFalse,
+					Reason:  constants.DeploymentReasonProgressDeadlineExceeded,
+					Message: "ReplicaSet \"test-predictor-7c9d\" has timed out progressing.",
+				},
+			},
+			expectedReason:  ProgressDeadlineExceeded,
+			expectedMessage: "ReplicaSet \"test-predictor-7c9d\" has timed out progressing.",
+			expectedFailureInfo: &FailureInfo{
+				Location: "test-predictor",
+				Reason:   RuntimeUnhealthy,
+				Message:  "ReplicaSet \"test-predictor-7c9d\" has timed out progressing.",
+			},
+		},
+		"NoFailure": {
+			conditions: []appsv1.DeploymentCondition{
+				{
+					Type:    appsv1.DeploymentAvailable,
+					Status:  v1.ConditionFalse,
+					Reason:  "MinimumReplicasUnavailable",
+					Message: "Deployment does not have minimum availability.",
+				},
+				{
+					Type:   appsv1.DeploymentProgressing,
+					Status: v1.ConditionTrue,
+					Reason: "ReplicaSetUpdated",
+				},
+			},
+			expectedReason:  ComponentNotReady,
+			expectedMessage: "Deployment does not have minimum availability.",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			deployment := &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-predictor"},
+				Status:     appsv1.DeploymentStatus{Conditions: tc.conditions},
+			}
+			status := &InferenceServiceStatus{}
+			status.PropagateRawStatus(PredictorComponent, deployment, &apis.URL{})
+			condition := status.GetCondition(PredictorReady)
+			g.Expect(condition.Status).To(gomega.Equal(v1.ConditionFalse))
+			g.Expect(condition.Reason).To(gomega.Equal(tc.expectedReason))
+			g.Expect(condition.Message).To(gomega.Equal(tc.expectedMessage))
+			g.Expect(status.ModelStatus.LastFailureInfo).To(gomega.Equal(tc.expectedFailureInfo))
+		})
+	}
+}
+
+func TestPropagateRawStatusActivating(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	available := []appsv1.DeploymentCondition{{Type: appsv1.DeploymentAvailable, Status: v1.ConditionTrue}}
+	deployment := func(replicas int32, availableReplicas int32) *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-predictor"},
+			Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+			Status:     appsv1.DeploymentStatus{Conditions: available, AvailableReplicas: availableReplicas},
+		}
+	}
+	status := &InferenceServiceStatus{}
+
+	// no condition until the predictor is scaled to zero
+	status.PropagateRawStatus(PredictorComponent, deployment(1, 1), &apis.URL{})
+	g.Expect(status.GetCondition(Activating)).To(gomega.BeNil())
+
+	status.PropagateRawStatus(PredictorComponent, deployment(0, 0), &apis.URL{})
+	g.Expect(status.GetCondition(Activating).Status).To(gomega.Equal(v1.ConditionFalse))
+	g.Expect(status.GetCondition(Activating).Reason).To(gomega.Equal(ActivatingReasonScaledToZero))
+	g.Expect(status.IsConditionReady(PredictorReady)).To(gomega.BeTrue())
+
+	status.PropagateRawStatus(PredictorComponent, deployment(1, 0), &apis.URL{})
+	g.Expect(status.GetCondition(Activating).Status).To(gomega.Equal(v1.ConditionTrue))
+	g.Expect(status.GetCondition(Activating).Reason).To(gomega.Equal(ActivatingReasonWakingUp))
+
+	status.PropagateRawStatus(PredictorComponent, deployment(1, 1), &apis.URL{})
+	g.Expect(status.GetCondition(Activating).Status).To(gomega.Equal(v1.ConditionFalse))
+	g.Expect(status.GetCondition(Activating).Reason).To(gomega.Equal(ActivatingReasonActive))
+
+	// the transformer deployment does not change the predictor activation
+	status.PropagateRawStatus(TransformerComponent, deployment(0, 0), &apis.URL{})
+	g.Expect(status.GetCondition(Activating).Reason).To(gomega.Equal(ActivatingReasonActive))
+}
+
+func TestPropagateRawStatusReplicas(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	available := []appsv1.DeploymentCondition{{Type: appsv1.DeploymentAvailable, Status: v1.ConditionTrue}}
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-predictor"},
+		Status:     appsv1.DeploymentStatus{Conditions: available, Replicas: 1, ReadyReplicas: 1},
+	}
+	hpa := &autoscalingv2.HorizontalPodAutoscaler{
+		Status: autoscalingv2.HorizontalPodAutoscalerStatus{CurrentReplicas: 1, DesiredReplicas: 3},
+	}
+	status := &InferenceServiceStatus{}
+
+	status.PropagateRawStatus(PredictorComponent, deployment, &apis.URL{})
+	status.PropagateRawAutoscalerStatus(PredictorComponent, hpa)
+	g.Expect(status.Components[PredictorComponent].Replicas).To(gomega.Equal(int32(1)))
+	g.Expect(status.Components[PredictorComponent].ReadyReplicas).To(gomega.Equal(int32(1)))
+	g.Expect(status.Components[PredictorComponent].DesiredReplicas).To(gomega.Equal(proto.Int32(3)))
+
+	// the deployment is scaling up to the replicas desired by the hpa
+	deployment.Status.Replicas = 3
+	deployment.Status.ReadyReplicas = 2
+	status.PropagateRawStatus(PredictorComponent, deployment, &apis.URL{})
+	status.PropagateRawAutoscalerStatus(PredictorComponent, hpa)
+	g.Expect(status.Components[PredictorComponent].Replicas).To(gomega.Equal(int32(3)))
+	g.Expect(status.Components[PredictorComponent].ReadyReplicas).To(gomega.Equal(int32(2)))
+	g.Expect(status.Components[PredictorComponent].DesiredReplicas).To(gomega.Equal(proto.Int32(3)))
+
+	// the desired replicas are cleared once the component is no longer scaled by an hpa
+	status.PropagateRawAutoscalerStatus(PredictorComponent, nil)
+	g.Expect(status.Components[PredictorComponent].DesiredReplicas).To(gomega.BeNil())
+	g.Expect(status.Components[PredictorComponent].ReadyReplicas).To(gomega.Equal(int32(2)))
+}
+
+func TestSetAutoscalingPaused(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	status := &InferenceServiceStatus{}
+	status.InitializeConditions()
+
+	status.SetAutoscalingPaused(true)
+	g.Expect(status.IsConditionReady(AutoscalingPaused)).To(gomega.BeTrue())
+
+	status.SetAutoscalingPaused(false)
+	g.Expect(status.GetCondition(AutoscalingPaused)).To(gomega.BeNil())
+}
+
+func TestSetRuntimeDisabled(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	status := &InferenceServiceStatus{}
+	status.InitializeConditions()
+
+	status.SetRuntimeDisabled("tf-serving", true)
+	g.Expect(status.IsConditionReady(Degraded)).To(gomega.BeTrue())
+	g.Expect(status.GetCondition(Degraded).Reason).To(gomega.Equal(string(RuntimeDisabled)))
+	g.Expect(status.GetCondition(Degraded).Message).To(gomega.ContainSubstring("tf-serving"))
+
+	status.SetRuntimeDisabled("tf-serving", false)
+	g.Expect(status.GetCondition(Degraded)).To(gomega.BeNil())
+}
+
+func TestSetIAMServiceAccountName(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	status := &InferenceServiceStatus{}
+
+	status.SetIAMServiceAccountName(PredictorComponent, "sklearn-sa")
+	g.Expect(status.Components[PredictorComponent].IAMServiceAccountName).To(gomega.Equal("sklearn-sa"))
+
+	status.SetIAMServiceAccountName(PredictorComponent, "")
+	g.Expect(status.Components[PredictorComponent].IAMServiceAccountName).To(gomega.BeEmpty())
+}
+
 func TestPropagateStatus(t *testing.T) {
 	parsedUrl, _ := url.Parse("http://test-predictor-default.default.example.com")
 	cases := []struct {
@@ -342,6 +595,7 @@ func TestInferenceServiceStatus_PropagateModelStatus(t *testing.T) {
 		expectedRevisionStates   *ModelRevisionStates
 		expectedTransitionStatus TransitionStatus
 		expectedFailureInfo      *FailureInfo
+		expectedArtifacts        []ArtifactStatus
 	}{
 		"pod list is empty": {
 			isvcStatus: &InferenceServiceStatus{
@@ -1096,6 +1350,140 @@ func TestInferenceServiceStatus_PropagateModelStatus(t *testing.T) {
 			expectedTransitionStatus: "",
 			expectedFailureInfo:      nil,
 		},
+		"kserve container failed due to image pull backoff": {
+			isvcStatus: &InferenceServiceStatus{
+				Status: duckv1.Status{
+					Conditions: duckv1.Conditions{
+						{
+							Type:   "Ready",
+							Status: v1.ConditionFalse,
+						},
+					},
+				},
+				Address:     &duckv1.Addressable{},
+				URL:         &apis.URL{},
+				ModelStatus: ModelStatus{},
+			},
+			statusSpec: ComponentStatusSpec{},
+			podList: &v1.PodList{
+				Items: []v1.Pod{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "test-predictor-5d8f9c7b6-x2v4q",
+						},
+						Status: v1.PodStatus{
+							ContainerStatuses: []v1.ContainerStatus{
+								{
+									Name: constants.InferenceServiceContainerName,
+									State: v1.ContainerState{
+										Waiting: &v1.ContainerStateWaiting{
+											Reason:  constants.StateReasonImagePullBackOff,
+											Message: "Back-off pulling image \"kserve/sklearnserver:missing\"",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			rawDeployment: true,
+			expectedRevisionStates: &ModelRevisionStates{
+				ActiveModelState: "",
+				TargetModelState: FailedToLoad,
+			},
+			expectedTransitionStatus: BlockedByFailedLoad,
+			expectedFailureInfo: &FailureInfo{
+				Location: "test-predictor-5d8f9c7b6-x2v4q",
+				Reason:   RuntimeUnhealthy,
+				Message:  "ImagePullBackOff: Back-off pulling image \"kserve/sklearnserver:missing\"",
+			},
+		},
+		"adapter storage initializer failed due to an error": {
+			isvcStatus: &InferenceServiceStatus{
+				Status: duckv1.Status{
+					Conditions: duckv1.Conditions{
+						{
+							Type:   "Ready",
+							Status: v1.ConditionFalse,
+						},
+					},
+				},
+				ModelStatus: ModelStatus{},
+			},
+			statusSpec: ComponentStatusSpec{},
+			podList: &v1.PodList{
+				Items: []v1.Pod{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "test-predictor-5d8f9c7b6-x2v4q",
+						},
+						Status: v1.PodStatus{
+							InitContainerStatuses: []v1.ContainerStatus{
+								{
+									Name: constants.StorageInitializerContainerName,
+									State: v1.ContainerState{
+										Terminated: &v1.ContainerStateTerminated{
+											Reason:   "Completed",
+											ExitCode: 0,
+										},
+									},
+								},
+								{
+									Name: constants.StorageInitializerContainerName + "-sql-lora",
+									State: v1.ContainerState{
+										Terminated: &v1.ContainerStateTerminated{
+											Reason:   constants.StateReasonError,
+											Message:  "Access Denied",
+											ExitCode: 1,
+										},
+									},
+								},
+								{
+									Name: constants.StorageInitializerContainerName + "-chat-lora",
+									State: v1.ContainerState{
+										Waiting: &v1.ContainerStateWaiting{
+											Reason: "PodInitializing",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			rawDeployment: true,
+			expectedRevisionStates: &ModelRevisionStates{
+				ActiveModelState: "",
+				TargetModelState: FailedToLoad,
+			},
+			expectedTransitionStatus: BlockedByFailedLoad,
+			expectedFailureInfo: &FailureInfo{
+				Location: "sql-lora",
+				Reason:   ModelLoadFailed,
+				Message:  "Access Denied",
+				ExitCode: 1,
+			},
+			expectedArtifacts: []ArtifactStatus{
+				{
+					Name:  ModelArtifactName,
+					State: Loaded,
+				},
+				{
+					Name:  "sql-lora",
+					State: FailedToLoad,
+					FailureInfo: &FailureInfo{
+						Reason:   ModelLoadFailed,
+						Message:  "Access Denied",
+						ExitCode: 1,
+					},
+				},
+				{
+					Name:  "chat-lora",
+					State: Pending,
+				},
+			},
+		},
 	}
 
 	for name, scenario := range scenarios {
@@ -1105,6 +1493,237 @@ func TestInferenceServiceStatus_PropagateModelStatus(t *testing.T) {
 			g.Expect(scenario.isvcStatus.ModelStatus.ModelRevisionStates).To(gomega.Equal(scenario.expectedRevisionStates))
 			g.Expect(scenario.isvcStatus.ModelStatus.TransitionStatus).To(gomega.Equal(scenario.expectedTransitionStatus))
 			g.Expect(scenario.isvcStatus.ModelStatus.LastFailureInfo).To(gomega.Equal(scenario.expectedFailureInfo))
+			g.Expect(scenario.isvcStatus.ModelStatus.Artifacts).To(gomega.Equal(scenario.expectedArtifacts))
+		})
+	}
+}
+
+func TestGetStorageInitializerDownloadDuration(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	scenarios := map[string]struct {
+		state            v1.ContainerState
+		expectedDuration time.Duration
+		expectedOk       bool
+	}{
+		"completed download": {
+			state: v1.ContainerState{
+				Terminated: &v1.ContainerStateTerminated{
+					ExitCode: 0,
+					Message:  `{"bytes": 1024, "files": 2, "durationSeconds": 92.5004}`,
+				},
+			},
+			expectedDuration: 92500 * time.Millisecond,
+			expectedOk:       true,
+		},
+		"running download": {
+			state: v1.ContainerState{
+				Running: &v1.ContainerStateRunning{},
+			},
+		},
+		"failed download": {
+			state: v1.ContainerState{
+				Terminated: &v1.ContainerStateTerminated{
+					ExitCode: 1,
+					Message:  `{"durationSeconds": 1}`,
+				},
+			},
+		},
+		"no download status": {
+			state: v1.ContainerState{
+				Terminated: &v1.ContainerStateTerminated{
+					ExitCode: 0,
+					Message:  "Successfully copied the model",
+				},
+			},
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			duration, ok := GetStorageInitializerDownloadDuration(v1.ContainerStatus{
+				Name:  constants.StorageInitializerContainerName,
+				State: scenario.state,
+			})
+			g.Expect(ok).To(gomega.Equal(scenario.expectedOk))
+			g.Expect(duration).To(gomega.Equal(scenario.expectedDuration))
+
+			status := &InferenceServiceStatus{}
+			status.PropagateModelStatus(ComponentStatusSpec{}, &v1.PodList{
+				Items: []v1.Pod{
+					{
+						Status: v1.PodStatus{
+							InitContainerStatuses: []v1.ContainerStatus{
+								{
+									Name:  constants.StorageInitializerContainerName,
+									State: scenario.state,
+								},
+							},
+						},
+					},
+				},
+			}, false)
+			if scenario.expectedOk {
+				g.Expect(status.ModelStatus.LastDownloadDuration).To(gomega.Equal(&metav1.Duration{Duration: scenario.expectedDuration}))
+			} else {
+				g.Expect(status.ModelStatus.LastDownloadDuration).To(gomega.BeNil())
+			}
+		})
+	}
+}
+
+func TestInferenceServiceStatus_PropagateRevisionModelStatus(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	statusSpec := ComponentStatusSpec{
+		LatestRolledoutRevision: "test-predictor-00001",
+		LatestReadyRevision:     "test-predictor-00001",
+		LatestCreatedRevision:   "test-predictor-00002",
+	}
+	readyPod := v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{
+				constants.StorageInitializerSourceUriInternalAnnotationKey: "gs://kfserving-examples/models/sklearn/1.0/model",
+			},
+		},
+		Status: v1.PodStatus{
+			Conditions: []v1.PodCondition{
+				{
+					Type:   v1.PodReady,
+					Status: v1.ConditionTrue,
+				},
+			},
+		},
+	}
+	canaryPod := func(initContainerState v1.ContainerState, lastTerminationState v1.ContainerState) v1.Pod {
+		return v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{
+					constants.StorageInitializerSourceUriInternalAnnotationKey: "gs://kfserving-examples/models/sklearn/2.0/model",
+				},
+			},
+			Status: v1.PodStatus{
+				InitContainerStatuses: []v1.ContainerStatus{
+					{
+						Name:                 constants.StorageInitializerContainerName,
+						State:                initContainerState,
+						LastTerminationState: lastTerminationState,
+					},
+				},
+			},
+		}
+	}
+
+	scenarios := map[string]struct {
+		statusSpec     ComponentStatusSpec
+		stablePods     *v1.PodList
+		canaryPods     *v1.PodList
+		expectedStable *RevisionModelStatus
+		expectedCanary *RevisionModelStatus
+	}{
+		"no canary rollout": {
+			statusSpec: statusSpec,
+			canaryPods: &v1.PodList{Items: []v1.Pod{readyPod}},
+		},
+		"canary model downloading": {
+			statusSpec: statusSpec,
+			stablePods: &v1.PodList{Items: []v1.Pod{readyPod}},
+			canaryPods: &v1.PodList{Items: []v1.Pod{
+				canaryPod(v1.ContainerState{Running: &v1.ContainerStateRunning{}}, v1.ContainerState{}),
+			}},
+			expectedStable: &RevisionModelStatus{
+				Name:             "test-predictor-00001",
+				State:            Loaded,
+				TransitionStatus: UpToDate,
+				StorageUri:       "gs://kfserving-examples/models/sklearn/1.0/model",
+			},
+			expectedCanary: &RevisionModelStatus{
+				Name:             "test-predictor-00002",
+				State:            Loading,
+				TransitionStatus: InProgress,
+				StorageUri:       "gs://kfserving-examples/models/sklearn/2.0/model",
+			},
+		},
+		"canary model download failed": {
+			statusSpec: statusSpec,
+			stablePods: &v1.PodList{Items: []v1.Pod{readyPod}},
+			canaryPods: &v1.PodList{Items: []v1.Pod{
+				canaryPod(v1.ContainerState{
+					Waiting: &v1.ContainerStateWaiting{Reason: constants.StateReasonCrashLoopBackOff},
+				}, v1.ContainerState{
+					Terminated: &v1.ContainerStateTerminated{
+						Reason:   constants.StateReasonError,
+						Message:  "Invalid Storage URI provided",
+						ExitCode: 1,
+					},
+				}),
+			}},
+			expectedStable: &RevisionModelStatus{
+				Name:             "test-predictor-00001",
+				State:            Loaded,
+				TransitionStatus: UpToDate,
+				StorageUri:       "gs://kfserving-examples/models/sklearn/1.0/model",
+			},
+			expectedCanary: &RevisionModelStatus{
+				Name:             "test-predictor-00002",
+				State:            FailedToLoad,
+				TransitionStatus: BlockedByFailedLoad,
+				StorageUri:       "gs://kfserving-examples/models/sklearn/2.0/model",
+				LastFailureInfo: &FailureInfo{
+					Reason:   ModelLoadFailed,
+					Message:  "Invalid Storage URI provided",
+					ExitCode: 1,
+				},
+			},
+		},
+		"canary pods not created": {
+			statusSpec: statusSpec,
+			stablePods: &v1.PodList{Items: []v1.Pod{readyPod}},
+			canaryPods: &v1.PodList{},
+			expectedStable: &RevisionModelStatus{
+				Name:             "test-predictor-00001",
+				State:            Loaded,
+				TransitionStatus: UpToDate,
+				StorageUri:       "gs://kfserving-examples/models/sklearn/1.0/model",
+			},
+			expectedCanary: &RevisionModelStatus{
+				Name:             "test-predictor-00002",
+				State:            Pending,
+				TransitionStatus: InProgress,
+			},
+		},
+		"canary revision ready and scaled to zero": {
+			statusSpec: ComponentStatusSpec{
+				LatestRolledoutRevision: "test-predictor-00001",
+				LatestReadyRevision:     "test-predictor-00002",
+				LatestCreatedRevision:   "test-predictor-00002",
+			},
+			stablePods: &v1.PodList{},
+			canaryPods: &v1.PodList{},
+			expectedStable: &RevisionModelStatus{
+				Name:             "test-predictor-00001",
+				State:            Loaded,
+				TransitionStatus: UpToDate,
+			},
+			expectedCanary: &RevisionModelStatus{
+				Name:             "test-predictor-00002",
+				State:            Loaded,
+				TransitionStatus: UpToDate,
+			},
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			status := &InferenceServiceStatus{
+				ModelStatus: ModelStatus{
+					StableRevision: &RevisionModelStatus{Name: "test-predictor-00000"},
+					CanaryRevision: &RevisionModelStatus{Name: "test-predictor-00001"},
+				},
+			}
+			status.PropagateRevisionModelStatus(scenario.statusSpec, scenario.stablePods, scenario.canaryPods)
+			g.Expect(status.ModelStatus.StableRevision).To(gomega.Equal(scenario.expectedStable))
+			g.Expect(status.ModelStatus.CanaryRevision).To(gomega.Equal(scenario.expectedCanary))
 		})
 	}
 }
@@ -1226,3 +1845,32 @@ func TestInferenceServiceStatus_UpdateModelRevisionStates(t *testing.T) {
 		})
 	}
 }
+
+func TestInferenceServiceStatus_SetRuntimeStatus(t *testing.T) {
+	scenarios := map[string]struct {
+		runtime  *v1alpha1.SupportedRuntime
+		expected *RuntimeStatus
+	}{
+		"ServingRuntime": {
+			runtime:  &v1alpha1.SupportedRuntime{Name: "kserve-sklearnserver", Generation: 2},
+			expected: &RuntimeStatus{Name: "kserve-sklearnserver", Generation: 2},
+		},
+		"ServingRuntime inheriting from a ClusterServingRuntime": {
+			runtime: &v1alpha1.SupportedRuntime{
+				Name:                  "sklearn-debug",
+				Spec:                  v1alpha1.ServingRuntimeSpec{InheritFrom: proto.String("kserve-sklearnserver")},
+				Generation:            1,
+				InheritFromGeneration: 4,
+			},
+			expected: &RuntimeStatus{Name: "sklearn-debug", Generation: 1, InheritFrom: "kserve-sklearnserver", InheritFromGeneration: 4},
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			status := &InferenceServiceStatus{}
+			status.SetRuntimeStatus(scenario.runtime)
+			g.Expect(status.ModelStatus.Runtime).To(gomega.Equal(scenario.expected))
+		})
+	}
+}
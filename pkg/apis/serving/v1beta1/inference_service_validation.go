@@ -17,18 +17,32 @@ limitations under the License.
 package v1beta1
 
 import (
+	"context"
 	"fmt"
+	"net"
+	"net/url"
 	"reflect"
 	"strconv"
+	"strings"
+	"time"
 
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	"regexp"
+	"sort"
 
 	"github.com/kserve/kserve/pkg/constants"
 	"github.com/kserve/kserve/pkg/utils"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/client-go/kubernetes"
 	"knative.dev/serving/pkg/apis/autoscaling"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/config"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 )
@@ -37,6 +51,14 @@ import (
 const (
 	IsvcNameFmt                         string = "[a-z]([-a-z0-9]*[a-z0-9])?"
 	StorageUriPresentInTransformerError string = "storage uri should not be specified in transformer container"
+	UnknownResourceProfileError         string = "the resource profile %q of the %s annotation is not defined in the inferenceservice config, the defined profiles are: [%s]"
+	// errors of the transformer container collocated in the predictor pod
+	CollocatedTransformerComponentError    string = "the transformer-container is collocated in the predictor pod, remove either the transformer-container or the transformer component"
+	DuplicatePredictorContainerNameError   string = "the predictor has several containers named %q, the container names must be unique and the first container is always named kserve-container"
+	MisnamedCollocatedTransformerError     string = "the predictor container %q sets the predictor host of a transformer, name it transformer-container to collocate the transformer with the predictor"
+	CollocatedTransformerPortConflictError string = "the port %d of the transformer-container is also used by the %s, set different ports with the --http_port and --grpc_port arguments and the container ports of the transformer-container"
+	CollocatedTransformerHostError         string = "the transformer-container does not know the predictor host, set the --predictor_host argument or the PREDICTOR_HOST env var to localhost:<port of the kserve-container>, or declare the container port of the kserve-container"
+	CollocatedPredictorPortsError          string = "the kserve-container can not declare ports in the Serverless deployment mode, only the transformer-container receiving the traffic can"
 )
 
 var (
@@ -44,31 +66,206 @@ var (
 	validatorLogger = logf.Log.WithName("inferenceservice-v1beta1-validation-webhook")
 	// regular expressions for validation of isvc name
 	IsvcRegexp = regexp.MustCompile("^" + IsvcNameFmt + "$")
+	// regular expression for validation of the http methods of the cors-allow-methods annotation
+	httpMethodRegexp = regexp.MustCompile("^[A-Z]+$")
 )
 
+// pvcURIPrefix is the prefix of the storage uris of the models stored on a pvc
+const pvcURIPrefix = "pvc://"
+
 // +kubebuilder:webhook:verbs=create;update,path=/validate-inferenceservices,mutating=false,failurePolicy=fail,groups=serving.kserve.io,resources=inferenceservices,versions=v1beta1,name=inferenceservice.kserve-webhook-server.validator
 var _ webhook.Validator = &InferenceService{}
 
+// InferenceServiceValidator validates the InferenceServices against the state of the cluster on top of the checks of
+// the webhook.Validator implemented by the InferenceService, it is registered in its place by the manager.
+// +kubebuilder:object:generate=false
+type InferenceServiceValidator struct {
+	// Client reads the cluster bypassing the cache of the manager
+	Client client.Reader
+}
+
+var _ admission.CustomValidator = &InferenceServiceValidator{}
+
+// ValidateCreate implements admission.CustomValidator so a webhook will be registered for the type
+func (v *InferenceServiceValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	isvc, err := convertToInferenceService(obj)
+	if err != nil {
+		return nil, err
+	}
+	warnings, err := isvc.ValidateCreate()
+	if err != nil {
+		return warnings, err
+	}
+	if isvc.deploymentMode() == constants.RawDeployment {
+		ingressConfig, err := v.getIngressConfig(ctx)
+		if err != nil {
+			return warnings, err
+		}
+		if err := validateGeneratedHosts(isvc, ingressConfig); err != nil {
+			return warnings, err
+		}
+	}
+	return warnings, v.validatePvcSources(ctx, isvc)
+}
+
+// ValidateUpdate implements admission.CustomValidator so a webhook will be registered for the type
+func (v *InferenceServiceValidator) ValidateUpdate(ctx context.Context, oldObj runtime.Object, newObj runtime.Object) (admission.Warnings, error) {
+	isvc, err := convertToInferenceService(newObj)
+	if err != nil {
+		return nil, err
+	}
+	warnings, err := isvc.ValidateUpdate(oldObj)
+	if err != nil {
+		return warnings, err
+	}
+	return warnings, v.validatePvcSources(ctx, isvc)
+}
+
+// ValidateDelete implements admission.CustomValidator so a webhook will be registered for the type
+func (v *InferenceServiceValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	isvc, err := convertToInferenceService(obj)
+	if err != nil {
+		return nil, err
+	}
+	return isvc.ValidateDelete()
+}
+
+// getIngressConfig reads the ingress config from the inferenceservice config map
+func (v *InferenceServiceValidator) getIngressConfig(ctx context.Context) (*IngressConfig, error) {
+	configMap := &v1.ConfigMap{}
+	if err := v.Client.Get(ctx, types.NamespacedName{Name: constants.InferenceServiceConfigMapName, Namespace: constants.KServeNamespace}, configMap); err != nil {
+		return nil, fmt.Errorf("unable to get the inferenceservice config map: %w", err)
+	}
+	return NewIngressConfigFromConfigMap(configMap)
+}
+
+// validatePvcSources denies the isvc if the pvc of a pvc:// storage uri of its components does not exist in its
+// namespace, pvcs of other namespaces cannot be mounted and would otherwise only fail when the pods are scheduled.
+func (v *InferenceServiceValidator) validatePvcSources(ctx context.Context, isvc *InferenceService) error {
+	for _, component := range []Component{
+		&isvc.Spec.Predictor,
+		isvc.Spec.Transformer,
+		isvc.Spec.Explainer,
+	} {
+		if reflect.ValueOf(component).IsNil() {
+			continue
+		}
+		storageURI := component.GetImplementation().GetStorageUri()
+		if storageURI == nil || !strings.HasPrefix(*storageURI, pvcURIPrefix) {
+			continue
+		}
+		pvcName, err := parsePvcSource(*storageURI)
+		if err != nil {
+			return err
+		}
+		pvc := &v1.PersistentVolumeClaim{}
+		if err := v.Client.Get(ctx, types.NamespacedName{Name: pvcName, Namespace: isvc.Namespace}, pvc); err != nil {
+			if apierrors.IsNotFound(err) {
+				return fmt.Errorf("PVC %s of storage uri %s not found in namespace %s, the PVC must be in the namespace "+
+					"of the InferenceService", pvcName, *storageURI, isvc.Namespace)
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// parsePvcSource returns the name of the pvc of a pvc://<pvc name>/<path> storage uri after validating the uri
+func parsePvcSource(storageURI string) (string, error) {
+	pvcName, pvcPath, _ := strings.Cut(strings.TrimPrefix(storageURI, pvcURIPrefix), "/")
+	if errs := validation.IsDNS1123Subdomain(pvcName); len(errs) > 0 {
+		return "", fmt.Errorf("invalid PVC name %q in storage uri %s: %s", pvcName, storageURI, strings.Join(errs, ", "))
+	}
+	for _, element := range strings.Split(pvcPath, "/") {
+		if element == ".." {
+			return "", fmt.Errorf("the path of storage uri %s must not reference a parent directory of the PVC", storageURI)
+		}
+	}
+	return pvcName, nil
+}
+
+func convertToInferenceService(obj runtime.Object) (*InferenceService, error) {
+	isvc, ok := obj.(*InferenceService)
+	if !ok {
+		return nil, fmt.Errorf("expected an InferenceService but got a %T", obj)
+	}
+	return isvc, nil
+}
+
 // ValidateCreate implements webhook.Validator so a webhook will be registered for the type
 func (isvc *InferenceService) ValidateCreate() (admission.Warnings, error) {
 	validatorLogger.Info("validate create", "name", isvc.Name)
-	var allWarnings admission.Warnings
-	annotations := isvc.Annotations
 
 	if err := validateInferenceServiceName(isvc); err != nil {
-		return allWarnings, err
+		return nil, err
+	}
+
+	warnings, err := utils.CheckAnnotationKeys(isvc.Annotations)
+	if err != nil {
+		return warnings, err
 	}
 
+	if err := validateGeneratedNamesLength(isvc); err != nil {
+		return warnings, err
+	}
+
+	return warnings, isvc.validateInferenceService()
+}
+
+// validateInferenceService validates the isvc on create and on update
+func (isvc *InferenceService) validateInferenceService() error {
+	annotations := isvc.Annotations
+
 	if err := validateInferenceServiceAutoscaler(isvc); err != nil {
-		return allWarnings, err
+		return err
 	}
 
 	if err := validateAutoscalerTargetUtilizationPercentage(isvc); err != nil {
-		return allWarnings, err
+		return err
+	}
+
+	if err := validateRawDeploymentAnnotations(isvc); err != nil {
+		return err
 	}
 
 	if err := validateCollocationStorageURI(isvc.Spec.Predictor); err != nil {
-		return allWarnings, err
+		return err
+	}
+
+	if err := validateCollocatedTransformer(isvc); err != nil {
+		return err
+	}
+
+	if err := validateCustomDomainAnnotation(isvc); err != nil {
+		return err
+	}
+
+	if err := validateIngressAnnotations(isvc); err != nil {
+		return err
+	}
+
+	if err := validateAdditionalHostsAnnotation(isvc); err != nil {
+		return err
+	}
+
+	if err := validateDestinationRuleAnnotations(isvc); err != nil {
+		return err
+	}
+
+	if err := validateCorsAnnotations(isvc); err != nil {
+		return err
+	}
+
+	if err := validateStorageAnnotations(isvc); err != nil {
+		return err
+	}
+
+	if err := validateResourceProfileAnnotation(isvc); err != nil {
+		return err
+	}
+
+	if err := validateTracingAnnotations(isvc); err != nil {
+		return err
 	}
 
 	for _, component := range []Component{
@@ -78,18 +275,18 @@ func (isvc *InferenceService) ValidateCreate() (admission.Warnings, error) {
 	} {
 		if !reflect.ValueOf(component).IsNil() {
 			if err := validateExactlyOneImplementation(component); err != nil {
-				return allWarnings, err
+				return err
 			}
 			if err := utils.FirstNonNilError([]error{
 				component.GetImplementation().Validate(),
 				component.GetExtensions().Validate(),
 				validateAutoScalingCompExtension(annotations, component.GetExtensions()),
 			}); err != nil {
-				return allWarnings, err
+				return err
 			}
 		}
 	}
-	return allWarnings, nil
+	return nil
 }
 
 // Validate scaling options component extensions
@@ -97,6 +294,12 @@ func validateAutoScalingCompExtension(annotations map[string]string, compExtSpec
 	deploymentMode := annotations["serving.kserve.io/deploymentMode"]
 	annotationClass := annotations[autoscaling.ClassAnnotationKey]
 	if deploymentMode == string(constants.RawDeployment) || annotationClass == string(autoscaling.HPA) {
+		if _, err := GetHPACustomMetrics(annotations); err != nil {
+			return err
+		}
+		if _, err := GetHPAScalingBehavior(annotations); err != nil {
+			return err
+		}
 		return validateScalingHPACompExtension(compExtSpec)
 	}
 
@@ -106,8 +309,25 @@ func validateAutoScalingCompExtension(annotations map[string]string, compExtSpec
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
 func (isvc *InferenceService) ValidateUpdate(old runtime.Object) (admission.Warnings, error) {
 	validatorLogger.Info("validate update", "name", isvc.Name)
+	oldIsvc, err := convertToInferenceService(old)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateInferenceServiceName(isvc); err != nil {
+		return nil, err
+	}
+
+	warnings, err := utils.CheckAddedAnnotationKeys(isvc.Annotations, oldIsvc.Annotations)
+	if err != nil {
+		return warnings, err
+	}
 
-	return isvc.ValidateCreate()
+	if err := validateTruncateGeneratedNamesUpdate(isvc, oldIsvc); err != nil {
+		return warnings, err
+	}
+
+	return warnings, isvc.validateInferenceService()
 }
 
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type
@@ -130,6 +350,31 @@ func validateInferenceServiceName(isvc *InferenceService) error {
 	return nil
 }
 
+// Validation of the length of the names generated for the components of the isvc
+func validateGeneratedNamesLength(isvc *InferenceService) error {
+	deploymentMode := isvc.deploymentMode()
+	maxLength := maxGeneratedNamePrefixLength(deploymentMode, isvc.components())
+	if isvc.truncatesGeneratedNames() {
+		// the name itself is still used as a label value
+		maxLength = GeneratedNameMaxLength
+	}
+	if len(isvc.Name) > maxLength {
+		return fmt.Errorf(InvalidISVCNameLengthError, isvc.Name, deploymentMode, GeneratedNameMaxLength, maxLength,
+			constants.TruncateGeneratedNamesAnnotationKey)
+	}
+	return nil
+}
+
+// Validation that the truncate-generated-names annotation is not changed, which would rename the generated resources
+func validateTruncateGeneratedNamesUpdate(isvc *InferenceService, oldIsvc *InferenceService) error {
+	value, ok := isvc.Annotations[constants.TruncateGeneratedNamesAnnotationKey]
+	oldValue, oldOk := oldIsvc.Annotations[constants.TruncateGeneratedNamesAnnotationKey]
+	if ok != oldOk || value != oldValue {
+		return fmt.Errorf(TruncateGeneratedNamesUpdateError, constants.TruncateGeneratedNamesAnnotationKey, isvc.Name)
+	}
+	return nil
+}
+
 // Validation of isvc autoscaler class
 func validateInferenceServiceAutoscaler(isvc *InferenceService) error {
 	annotations := isvc.ObjectMeta.Annotations
@@ -147,6 +392,18 @@ func validateInferenceServiceAutoscaler(isvc *InferenceService) error {
 					}
 				case constants.AutoscalerClassExternal:
 					return nil
+				case constants.AutoscalerClassKeda:
+					if annotations[constants.DeploymentMode] != string(constants.RawDeployment) {
+						return fmt.Errorf("the %s autoscaler class is only supported for raw deployment mode", class)
+					}
+					triggers, err := GetKedaTriggers(annotations)
+					if err != nil {
+						return err
+					}
+					if len(triggers) == 0 {
+						return fmt.Errorf("the %s annotation is required for the %s autoscaler class", constants.KedaTriggersAnnotationKey, class)
+					}
+					return nil
 				default:
 					return fmt.Errorf("unknown autoscaler class [%s]", class)
 				}
@@ -183,6 +440,51 @@ func validateAutoscalerTargetUtilizationPercentage(isvc *InferenceService) error
 	return nil
 }
 
+// Validation of the raw deployment minReadySeconds, progressDeadlineSeconds, termination grace period and
+// automount service account token annotations
+func validateRawDeploymentAnnotations(isvc *InferenceService) error {
+	annotations := isvc.ObjectMeta.Annotations
+	var minReadySeconds int64
+	progressDeadlineSeconds := int64(constants.DefaultProgressDeadlineSeconds)
+	var err error
+	if value, ok := annotations[constants.DeploymentMinReadySecondsAnnotationKey]; ok {
+		if minReadySeconds, err = strconv.ParseInt(value, 10, 32); err != nil || minReadySeconds < 0 {
+			return fmt.Errorf("the %s annotation should be a non-negative integer", constants.DeploymentMinReadySecondsAnnotationKey)
+		}
+	}
+	if value, ok := annotations[constants.ProgressDeadlineSecondsAnnotationKey]; ok {
+		if progressDeadlineSeconds, err = strconv.ParseInt(value, 10, 32); err != nil || progressDeadlineSeconds < 0 {
+			return fmt.Errorf("the %s annotation should be a non-negative integer", constants.ProgressDeadlineSecondsAnnotationKey)
+		}
+	}
+	// without the annotation the deployment gets the default progress deadline
+	if progressDeadlineSeconds <= minReadySeconds {
+		return fmt.Errorf("the %s annotation should be greater than the %s annotation",
+			constants.ProgressDeadlineSecondsAnnotationKey, constants.DeploymentMinReadySecondsAnnotationKey)
+	}
+	if value, ok := annotations[constants.TerminationGracePeriodAnnotationKey]; ok {
+		if gracePeriod, err := strconv.ParseInt(value, 10, 64); err != nil || gracePeriod < 0 {
+			return fmt.Errorf("the %s annotation should be a non-negative integer", constants.TerminationGracePeriodAnnotationKey)
+		}
+	}
+	if value, ok := annotations[constants.AutomountServiceAccountTokenAnnotationKey]; ok {
+		if _, err := strconv.ParseBool(value); err != nil {
+			return fmt.Errorf("the %s annotation should be a boolean", constants.AutomountServiceAccountTokenAnnotationKey)
+		}
+	}
+	if err := validateVPAAnnotations(isvc); err != nil {
+		return err
+	}
+	for _, key := range []string{constants.AutoscalingPausedAnnotationKey, constants.HPAContainerMetricAnnotationKey} {
+		if value, ok := annotations[key]; ok {
+			if _, err := strconv.ParseBool(value); err != nil {
+				return fmt.Errorf("the %s annotation should be a boolean", key)
+			}
+		}
+	}
+	return nil
+}
+
 func validateScalingHPACompExtension(compExtSpec *ComponentExtensionSpec) error {
 	metric := MetricCPU
 	if compExtSpec.ScaleMetric != nil {
@@ -239,6 +541,36 @@ func validateScalingKPACompExtension(compExtSpec *ComponentExtensionSpec) error
 		if metric == MetricRPS && target < 1 {
 			return fmt.Errorf("the target for rps should be greater than 1")
 		}
+
+		// the hard concurrency limit can not be lower than the soft target, 0 means unlimited
+		if metric == MetricConcurrency && compExtSpec.ContainerConcurrency != nil &&
+			*compExtSpec.ContainerConcurrency > 0 && *compExtSpec.ContainerConcurrency < int64(target) {
+			return fmt.Errorf("the containerConcurrency %d should not be less than the concurrency scaleTarget %d",
+				*compExtSpec.ContainerConcurrency, target)
+		}
+	}
+
+	if compExtSpec.InitialScale != nil && *compExtSpec.InitialScale < 0 {
+		return fmt.Errorf("the initialScale should not be less than 0")
+	}
+	if compExtSpec.ScaleDownDelay != nil {
+		delay, err := time.ParseDuration(*compExtSpec.ScaleDownDelay)
+		if err != nil {
+			return fmt.Errorf("the scaleDownDelay %q is not a valid duration: %w", *compExtSpec.ScaleDownDelay, err)
+		}
+		if delay < 0 || delay > time.Hour {
+			return fmt.Errorf("the scaleDownDelay %q should be between 0s and 1h", *compExtSpec.ScaleDownDelay)
+		}
+	}
+	if compExtSpec.ScaleWindow != nil {
+		window, err := time.ParseDuration(*compExtSpec.ScaleWindow)
+		if err != nil {
+			return fmt.Errorf("the scaleWindow %q is not a valid duration: %w", *compExtSpec.ScaleWindow, err)
+		}
+		if window < autoscaling.WindowMin || window > autoscaling.WindowMax {
+			return fmt.Errorf("the scaleWindow %q should be between %v and %v", *compExtSpec.ScaleWindow,
+				autoscaling.WindowMin, autoscaling.WindowMax)
+		}
 	}
 
 	return nil
@@ -258,3 +590,348 @@ func validateCollocationStorageURI(predictorSpec PredictorSpec) error {
 	}
 	return nil
 }
+
+// validateCollocatedTransformer validates the transformer-container collocated in the predictor pod: it must be the
+// only transformer, know the predictor host and listen on ports not used by the kserve-container nor the agent.
+func validateCollocatedTransformer(isvc *InferenceService) error {
+	containers := isvc.Spec.Predictor.Containers
+	names := map[string]bool{}
+	for i := range containers {
+		if names[containers[i].Name] {
+			return fmt.Errorf(DuplicatePredictorContainerNameError, containers[i].Name)
+		}
+		names[containers[i].Name] = true
+		if containers[i].Name != constants.InferenceServiceContainerName &&
+			containers[i].Name != constants.TransformerContainerName && hasPredictorHost(&containers[i]) {
+			return fmt.Errorf(MisnamedCollocatedTransformerError, containers[i].Name)
+		}
+	}
+	transformer := getContainer(containers, constants.TransformerContainerName)
+	if transformer == nil {
+		return nil
+	}
+	if isvc.Spec.Transformer != nil {
+		return fmt.Errorf(CollocatedTransformerComponentError)
+	}
+
+	usedPorts := map[int32]string{}
+	predictor := getContainer(containers, constants.InferenceServiceContainerName)
+	if predictor != nil {
+		if len(predictor.Ports) > 0 && isvc.deploymentMode() == constants.Serverless {
+			return fmt.Errorf(CollocatedPredictorPortsError)
+		}
+		for _, port := range getListeningPorts(predictor) {
+			usedPorts[port] = constants.InferenceServiceContainerName
+		}
+	}
+	if isvc.Spec.Predictor.Logger != nil || isvc.Spec.Predictor.Batcher != nil {
+		usedPorts[constants.InferenceServiceDefaultAgentPort] = "agent"
+	}
+	for _, port := range getListeningPorts(transformer) {
+		if container, ok := usedPorts[port]; ok {
+			return fmt.Errorf(CollocatedTransformerPortConflictError, port, container)
+		}
+	}
+
+	if !hasPredictorHost(transformer) && (predictor == nil || len(predictor.Ports) == 0) {
+		return fmt.Errorf(CollocatedTransformerHostError)
+	}
+	return nil
+}
+
+// validateResourceProfileAnnotation validates that the resource profile requested by the isvc is defined in the
+// inferenceservice config map, so a typo does not silently fall back to the global resource defaults.
+func validateResourceProfileAnnotation(isvc *InferenceService) error {
+	profile, ok := isvc.Annotations[constants.ResourceProfileAnnotationKey]
+	if !ok {
+		return nil
+	}
+	cfg, err := config.GetConfig()
+	if err != nil {
+		return fmt.Errorf("unable to set up client config: %w", err)
+	}
+	clientSet, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return fmt.Errorf("unable to create clientSet: %w", err)
+	}
+	isvcConfig, err := NewInferenceServicesConfig(clientSet)
+	if err != nil {
+		return err
+	}
+	return validateResourceProfile(profile, isvcConfig)
+}
+
+func validateResourceProfile(profile string, isvcConfig *InferenceServicesConfig) error {
+	if _, ok := isvcConfig.ResourceProfiles[profile]; ok {
+		return nil
+	}
+	profiles := make([]string, 0, len(isvcConfig.ResourceProfiles))
+	for name := range isvcConfig.ResourceProfiles {
+		profiles = append(profiles, name)
+	}
+	sort.Strings(profiles)
+	return fmt.Errorf(UnknownResourceProfileError, profile, constants.ResourceProfileAnnotationKey, strings.Join(profiles, ", "))
+}
+
+// validateVPAAnnotations validates the vertical pod autoscaler annotations of the predictor. A VPA which applies its
+// memory recommendations can not be combined with an HPA scaling on memory as both would act on the same signal.
+func validateVPAAnnotations(isvc *InferenceService) error {
+	annotations := isvc.ObjectMeta.Annotations
+	mode, ok := annotations[constants.VPAUpdateModeAnnotationKey]
+	if !ok {
+		return nil
+	}
+	if !utils.Includes(constants.VPAUpdateModes, mode) {
+		return fmt.Errorf("the %s annotation should be one of %s", constants.VPAUpdateModeAnnotationKey,
+			strings.Join(constants.VPAUpdateModes, ", "))
+	}
+	minAllowed, err := GetVPAResourceList(annotations, constants.VPAMinAllowedAnnotationKey)
+	if err != nil {
+		return err
+	}
+	maxAllowed, err := GetVPAResourceList(annotations, constants.VPAMaxAllowedAnnotationKey)
+	if err != nil {
+		return err
+	}
+	for name, minValue := range minAllowed {
+		if maxValue, ok := maxAllowed[name]; ok && minValue.Cmp(maxValue) > 0 {
+			return fmt.Errorf("the %s min allowed by annotation %s should not be greater than the max allowed by annotation %s",
+				name, constants.VPAMinAllowedAnnotationKey, constants.VPAMaxAllowedAnnotationKey)
+		}
+	}
+	if mode == constants.VPAUpdateModeOff {
+		return nil
+	}
+	scaleMetric := isvc.Spec.Predictor.ScaleMetric
+	hpaOnMemory := (scaleMetric != nil && *scaleMetric == MetricMemory) ||
+		annotations[constants.AutoscalerMetrics] == string(constants.AutoScalerMetricsMemory)
+	autoscalerClass, ok := annotations[constants.AutoscalerClass]
+	if hpaOnMemory && (!ok || autoscalerClass == string(constants.AutoscalerClassHPA)) {
+		return fmt.Errorf("the %s update mode of annotation %s can not be combined with an HPA scaling on memory",
+			mode, constants.VPAUpdateModeAnnotationKey)
+	}
+	return nil
+}
+
+// Validate the custom domain requested with the serving.kserve.io/custom-domain annotation, the domain is mapped to
+// the InferenceService with a knative DomainMapping which is only available in the serverless mode.
+func validateCustomDomainAnnotation(isvc *InferenceService) error {
+	annotations := isvc.ObjectMeta.Annotations
+	domain, ok := annotations[constants.CustomDomainAnnotationKey]
+	if !ok {
+		if _, ok := annotations[constants.CustomDomainTLSSecretAnnotationKey]; ok {
+			return fmt.Errorf("the %s annotation requires the %s annotation",
+				constants.CustomDomainTLSSecretAnnotationKey, constants.CustomDomainAnnotationKey)
+		}
+		return nil
+	}
+	if annotations[constants.DeploymentMode] == string(constants.RawDeployment) {
+		return fmt.Errorf("the %s annotation is not supported in %s mode", constants.CustomDomainAnnotationKey, constants.RawDeployment)
+	}
+	if errs := validation.IsFullyQualifiedDomainName(field.NewPath("metadata", "annotations").Key(constants.CustomDomainAnnotationKey), domain); len(errs) > 0 {
+		return errs.ToAggregate()
+	}
+	return nil
+}
+
+// Validation of the connection pool and outlier detection annotations of the DestinationRules
+func validateDestinationRuleAnnotations(isvc *InferenceService) error {
+	annotations := isvc.ObjectMeta.Annotations
+	annotationsPath := field.NewPath("metadata", "annotations")
+	for _, key := range []string{
+		constants.DestinationRuleMaxConnectionsAnnotationKey,
+		constants.DestinationRuleHTTP2MaxRequestsAnnotationKey,
+		constants.DestinationRuleConsecutive5xxErrorsAnnotationKey,
+	} {
+		if value, ok := annotations[key]; ok {
+			if parsed, err := strconv.ParseInt(value, 10, 32); err != nil || parsed < 1 {
+				return field.Invalid(annotationsPath.Key(key), value, "must be a positive integer")
+			}
+		}
+	}
+	if value, ok := annotations[constants.DestinationRuleBaseEjectionTimeAnnotationKey]; ok {
+		if duration, err := time.ParseDuration(value); err != nil || duration <= 0 {
+			return field.Invalid(annotationsPath.Key(constants.DestinationRuleBaseEjectionTimeAnnotationKey), value,
+				"must be a positive duration, e.g. 30s")
+		}
+	}
+	return nil
+}
+
+// Validation of the ingress gateway, ingress class and tls secret annotations
+func validateIngressAnnotations(isvc *InferenceService) error {
+	annotations := isvc.ObjectMeta.Annotations
+	annotationsPath := field.NewPath("metadata", "annotations")
+	if gateway, ok := annotations[constants.IngressGatewayAnnotationKey]; ok {
+		gatewayPath := annotationsPath.Key(constants.IngressGatewayAnnotationKey)
+		namespace, name, found := strings.Cut(gateway, "/")
+		if !found {
+			return field.Invalid(gatewayPath, gateway, "must be in the <namespace>/<name> format")
+		}
+		if errs := validation.IsDNS1123Label(namespace); len(errs) > 0 {
+			return field.Invalid(gatewayPath, gateway, "invalid namespace: "+strings.Join(errs, ", "))
+		}
+		if errs := validation.IsDNS1123Subdomain(name); len(errs) > 0 {
+			return field.Invalid(gatewayPath, gateway, "invalid name: "+strings.Join(errs, ", "))
+		}
+	}
+	if ingressClass, ok := annotations[constants.IngressClassAnnotationKey]; ok {
+		if errs := validation.IsDNS1123Subdomain(ingressClass); len(errs) > 0 {
+			return field.Invalid(annotationsPath.Key(constants.IngressClassAnnotationKey), ingressClass, strings.Join(errs, ", "))
+		}
+	}
+	if secret, ok := annotations[constants.TLSSecretAnnotationKey]; ok {
+		if errs := validation.IsDNS1123Subdomain(secret); len(errs) > 0 {
+			return field.Invalid(annotationsPath.Key(constants.TLSSecretAnnotationKey), secret, strings.Join(errs, ", "))
+		}
+	}
+	if termination, ok := annotations[constants.RouteTLSTerminationAnnotationKey]; ok {
+		switch termination {
+		case constants.RouteTLSTerminationEdge, constants.RouteTLSTerminationReencrypt:
+		default:
+			return field.Invalid(annotationsPath.Key(constants.RouteTLSTerminationAnnotationKey), termination,
+				fmt.Sprintf("must be %s or %s", constants.RouteTLSTerminationEdge, constants.RouteTLSTerminationReencrypt))
+		}
+	}
+	return nil
+}
+
+// GetAdditionalHosts returns the hosts of the comma separated additional-hosts annotation.
+// It returns nil if the annotation is not set.
+func GetAdditionalHosts(annotations map[string]string) []string {
+	value, ok := annotations[constants.AdditionalHostsAnnotationKey]
+	if !ok {
+		return nil
+	}
+	return splitAnnotationList(value)
+}
+
+// splitAnnotationList returns the trimmed, non empty values of a comma separated annotation value
+func splitAnnotationList(value string) []string {
+	var values []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			values = append(values, item)
+		}
+	}
+	return values
+}
+
+// GetCorsConfig returns the CORS policy of the InferenceService, the given config overridden by the cors annotations.
+func GetCorsConfig(annotations map[string]string, config CorsConfig) (CorsConfig, error) {
+	if value, ok := annotations[constants.CorsAllowOriginsAnnotationKey]; ok {
+		config.AllowOrigins = splitAnnotationList(value)
+	}
+	if value, ok := annotations[constants.CorsAllowMethodsAnnotationKey]; ok {
+		config.AllowMethods = splitAnnotationList(value)
+	}
+	if value, ok := annotations[constants.CorsAllowHeadersAnnotationKey]; ok {
+		config.AllowHeaders = splitAnnotationList(value)
+	}
+	if value, ok := annotations[constants.CorsMaxAgeAnnotationKey]; ok {
+		maxAge, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || maxAge < 0 {
+			return config, fmt.Errorf("invalid value %q for annotation %s, must be a non negative integer", value, constants.CorsMaxAgeAnnotationKey)
+		}
+		config.MaxAge = maxAge
+	}
+	return config, nil
+}
+
+// ValidateCorsOrigin returns an error if the origin is neither * nor a http or https origin without path, e.g.
+// https://example.com:8443
+func ValidateCorsOrigin(origin string) error {
+	if origin == "*" {
+		return nil
+	}
+	u, err := url.Parse(origin)
+	if err != nil {
+		return err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("the scheme must be http or https")
+	}
+	if u.Hostname() == "" || u.User != nil || (u.Path != "" && u.Path != "/") || u.RawQuery != "" || u.Fragment != "" {
+		return fmt.Errorf("must be in the <scheme>://<host>[:<port>] format")
+	}
+	if errs := validation.IsDNS1123Subdomain(u.Hostname()); len(errs) > 0 && net.ParseIP(u.Hostname()) == nil {
+		return fmt.Errorf("invalid host: %s", strings.Join(errs, ", "))
+	}
+	return nil
+}
+
+// Validation of the cors annotations
+func validateCorsAnnotations(isvc *InferenceService) error {
+	annotationsPath := field.NewPath("metadata", "annotations")
+	config, err := GetCorsConfig(isvc.ObjectMeta.Annotations, CorsConfig{})
+	if err != nil {
+		return field.Invalid(annotationsPath.Key(constants.CorsMaxAgeAnnotationKey),
+			isvc.ObjectMeta.Annotations[constants.CorsMaxAgeAnnotationKey], "must be a non negative integer")
+	}
+	for _, origin := range config.AllowOrigins {
+		if err := ValidateCorsOrigin(origin); err != nil {
+			return field.Invalid(annotationsPath.Key(constants.CorsAllowOriginsAnnotationKey), origin, err.Error())
+		}
+	}
+	for _, method := range config.AllowMethods {
+		if !httpMethodRegexp.MatchString(method) {
+			return field.Invalid(annotationsPath.Key(constants.CorsAllowMethodsAnnotationKey), method, "must be an upper case http method")
+		}
+	}
+	return nil
+}
+
+// Validation of the hosts of the additional-hosts annotation
+func validateAdditionalHostsAnnotation(isvc *InferenceService) error {
+	hostsPath := field.NewPath("metadata", "annotations").Key(constants.AdditionalHostsAnnotationKey)
+	for _, host := range GetAdditionalHosts(isvc.ObjectMeta.Annotations) {
+		if errs := validation.IsDNS1123Subdomain(host); len(errs) > 0 {
+			return field.Invalid(hostsPath, host, strings.Join(errs, ", "))
+		}
+	}
+	return nil
+}
+
+// Validation of the tracing annotations
+func validateTracingAnnotations(isvc *InferenceService) error {
+	annotations := isvc.ObjectMeta.Annotations
+	if value, ok := annotations[constants.TracingAnnotationKey]; ok {
+		if _, err := strconv.ParseBool(value); err != nil {
+			return fmt.Errorf("the %s annotation should be a boolean", constants.TracingAnnotationKey)
+		}
+	}
+	if value, ok := annotations[constants.TracingOTLPEndpointAnnotationKey]; ok {
+		if endpoint, err := url.Parse(value); err != nil || endpoint.Scheme == "" || endpoint.Host == "" {
+			return fmt.Errorf("the %s annotation should be an absolute URL, got %q", constants.TracingOTLPEndpointAnnotationKey, value)
+		}
+	}
+	if value, ok := annotations[constants.TracingSamplingRatioAnnotationKey]; ok {
+		if ratio, err := strconv.ParseFloat(value, 64); err != nil || ratio < 0 || ratio > 1 {
+			return fmt.Errorf("the %s annotation should be a number between 0 and 1, got %q",
+				constants.TracingSamplingRatioAnnotationKey, value)
+		}
+	}
+	return nil
+}
+
+// Validation of the storage annotations
+func validateStorageAnnotations(isvc *InferenceService) error {
+	for _, key := range []string{constants.StorageVerifyChecksumAnnotationKey, constants.StoragePvcReadWriteAnnotationKey,
+		constants.StorageProxyEnabledAnnotationKey} {
+		if value, ok := isvc.ObjectMeta.Annotations[key]; ok {
+			if _, err := strconv.ParseBool(value); err != nil {
+				return fmt.Errorf("the %s annotation should be a boolean", key)
+			}
+		}
+	}
+	if value, ok := isvc.ObjectMeta.Annotations[constants.StoragePvcMountModeAnnotationKey]; ok {
+		if value != constants.PvcMountModeDirect && value != constants.PvcMountModeCopy {
+			return fmt.Errorf("the %s annotation should be one of %s or %s, got %q",
+				constants.StoragePvcMountModeAnnotationKey, constants.PvcMountModeDirect, constants.PvcMountModeCopy, value)
+		}
+	}
+	if _, err := GetStorageInitializerResources(isvc.ObjectMeta.Annotations); err != nil {
+		return err
+	}
+	return nil
+}
@@ -17,15 +17,23 @@ limitations under the License.
 package v1beta1
 
 import (
-	"github.com/kserve/kserve/pkg/constants"
+	"context"
+	"fmt"
+	"strings"
 	"testing"
 
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/kserve/kserve/pkg/utils"
+
 	"google.golang.org/protobuf/proto"
 
 	"github.com/onsi/gomega"
+	"github.com/onsi/gomega/types"
 	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
 func makeTestRawInferenceService() InferenceService {
@@ -124,6 +132,186 @@ func TestInvalidTargetUtilizationPercentage(t *testing.T) {
 	g.Expect(warnings).Should(gomega.BeEmpty())
 }
 
+func TestDeploymentTimingAnnotations(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	isvc := makeTestRawInferenceService()
+	isvc.ObjectMeta.Annotations[constants.DeploymentMinReadySecondsAnnotationKey] = "30"
+	isvc.ObjectMeta.Annotations[constants.ProgressDeadlineSecondsAnnotationKey] = "1800"
+	warnings, err := isvc.ValidateCreate()
+	g.Expect(err).Should(gomega.Succeed())
+	g.Expect(warnings).Should(gomega.BeEmpty())
+
+	isvc.ObjectMeta.Annotations[constants.DeploymentMinReadySecondsAnnotationKey] = "-1"
+	_, err = isvc.ValidateCreate()
+	g.Expect(err).ShouldNot(gomega.Succeed())
+
+	isvc.ObjectMeta.Annotations[constants.DeploymentMinReadySecondsAnnotationKey] = "30"
+	isvc.ObjectMeta.Annotations[constants.ProgressDeadlineSecondsAnnotationKey] = "-600"
+	_, err = isvc.ValidateCreate()
+	g.Expect(err).ShouldNot(gomega.Succeed())
+
+	isvc.ObjectMeta.Annotations[constants.ProgressDeadlineSecondsAnnotationKey] = "ten minutes"
+	_, err = isvc.ValidateCreate()
+	g.Expect(err).ShouldNot(gomega.Succeed())
+
+	isvc.ObjectMeta.Annotations[constants.ProgressDeadlineSecondsAnnotationKey] = "30"
+	_, err = isvc.ValidateCreate()
+	g.Expect(err).ShouldNot(gomega.Succeed())
+
+	// the default progress deadline of 600 seconds applies without the annotation
+	delete(isvc.ObjectMeta.Annotations, constants.ProgressDeadlineSecondsAnnotationKey)
+	isvc.ObjectMeta.Annotations[constants.DeploymentMinReadySecondsAnnotationKey] = "600"
+	_, err = isvc.ValidateCreate()
+	g.Expect(err).ShouldNot(gomega.Succeed())
+}
+
+func TestTerminationGracePeriodAnnotation(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	isvc := makeTestRawInferenceService()
+	isvc.ObjectMeta.Annotations[constants.TerminationGracePeriodAnnotationKey] = "120"
+	_, err := isvc.ValidateCreate()
+	g.Expect(err).Should(gomega.Succeed())
+
+	isvc.ObjectMeta.Annotations[constants.TerminationGracePeriodAnnotationKey] = "-1"
+	_, err = isvc.ValidateCreate()
+	g.Expect(err).ShouldNot(gomega.Succeed())
+
+	isvc.ObjectMeta.Annotations[constants.TerminationGracePeriodAnnotationKey] = "2m"
+	_, err = isvc.ValidateCreate()
+	g.Expect(err).ShouldNot(gomega.Succeed())
+}
+
+func TestAutomountServiceAccountTokenAnnotation(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	isvc := makeTestRawInferenceService()
+	for _, value := range []string{"true", "false"} {
+		isvc.ObjectMeta.Annotations[constants.AutomountServiceAccountTokenAnnotationKey] = value
+		_, err := isvc.ValidateCreate()
+		g.Expect(err).Should(gomega.Succeed())
+	}
+
+	isvc.ObjectMeta.Annotations[constants.AutomountServiceAccountTokenAnnotationKey] = "enabled"
+	_, err := isvc.ValidateCreate()
+	g.Expect(err).ShouldNot(gomega.Succeed())
+}
+
+func TestAutoscalingPausedAnnotation(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	isvc := makeTestRawInferenceService()
+	isvc.ObjectMeta.Annotations[constants.AutoscalingPausedAnnotationKey] = "true"
+	_, err := isvc.ValidateCreate()
+	g.Expect(err).Should(gomega.Succeed())
+
+	isvc.ObjectMeta.Annotations[constants.AutoscalingPausedAnnotationKey] = "yes please"
+	_, err = isvc.ValidateCreate()
+	g.Expect(err).ShouldNot(gomega.Succeed())
+}
+
+func TestStorageVerifyChecksumAnnotation(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	isvc := makeTestInferenceService()
+	isvc.ObjectMeta.Annotations = map[string]string{constants.StorageVerifyChecksumAnnotationKey: "true"}
+	_, err := isvc.ValidateCreate()
+	g.Expect(err).Should(gomega.Succeed())
+
+	isvc.ObjectMeta.Annotations[constants.StorageVerifyChecksumAnnotationKey] = "sha256"
+	_, err = isvc.ValidateCreate()
+	g.Expect(err).ShouldNot(gomega.Succeed())
+}
+
+func TestStorageInitializerResourcesAnnotation(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	scenarios := map[string]struct {
+		value   string
+		matcher types.GomegaMatcher
+	}{
+		"limits and requests": {
+			value:   `{"limits": {"memory": "16Gi", "cpu": "4"}, "requests": {"memory": "8Gi"}}`,
+			matcher: gomega.Succeed(),
+		},
+		"invalid json": {
+			value:   `{"limits": "16Gi"}`,
+			matcher: gomega.HaveOccurred(),
+		},
+		"unsupported resource": {
+			value:   `{"limits": {"nvidia.com/gpu": "1"}}`,
+			matcher: gomega.HaveOccurred(),
+		},
+		"request above limit": {
+			value:   `{"limits": {"memory": "1Gi"}, "requests": {"memory": "2Gi"}}`,
+			matcher: gomega.HaveOccurred(),
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			isvc := makeTestInferenceService()
+			isvc.ObjectMeta.Annotations = map[string]string{constants.StorageInitializerResourcesAnnotationKey: scenario.value}
+			_, err := isvc.ValidateCreate()
+			g.Expect(err).To(scenario.matcher)
+		})
+	}
+}
+
+func TestStoragePvcAnnotations(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	scenarios := map[string]struct {
+		annotations map[string]string
+		matcher     types.GomegaMatcher
+	}{
+		"copy mount mode with read-write": {
+			annotations: map[string]string{
+				constants.StoragePvcMountModeAnnotationKey: constants.PvcMountModeCopy,
+				constants.StoragePvcReadWriteAnnotationKey: "true",
+			},
+			matcher: gomega.Succeed(),
+		},
+		"direct mount mode": {
+			annotations: map[string]string{constants.StoragePvcMountModeAnnotationKey: constants.PvcMountModeDirect},
+			matcher:     gomega.Succeed(),
+		},
+		"unknown mount mode": {
+			annotations: map[string]string{constants.StoragePvcMountModeAnnotationKey: "symlink"},
+			matcher:     gomega.HaveOccurred(),
+		},
+		"invalid read-write": {
+			annotations: map[string]string{constants.StoragePvcReadWriteAnnotationKey: "rw"},
+			matcher:     gomega.HaveOccurred(),
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			isvc := makeTestInferenceService()
+			isvc.ObjectMeta.Annotations = scenario.annotations
+			_, err := isvc.ValidateCreate()
+			g.Expect(err).To(scenario.matcher)
+		})
+	}
+}
+
+func TestStorageProxyAnnotation(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	isvc := makeTestInferenceService()
+	isvc.ObjectMeta.Annotations = map[string]string{constants.StorageProxyEnabledAnnotationKey: "false"}
+	_, err := isvc.ValidateCreate()
+	g.Expect(err).Should(gomega.Succeed())
+
+	isvc.ObjectMeta.Annotations[constants.StorageProxyEnabledAnnotationKey] = "disabled"
+	_, err = isvc.ValidateCreate()
+	g.Expect(err).ShouldNot(gomega.Succeed())
+}
+
+func TestHPAContainerMetricAnnotation(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	isvc := makeTestRawInferenceService()
+	isvc.ObjectMeta.Annotations[constants.HPAContainerMetricAnnotationKey] = "true"
+	_, err := isvc.ValidateCreate()
+	g.Expect(err).Should(gomega.Succeed())
+
+	isvc.ObjectMeta.Annotations[constants.HPAContainerMetricAnnotationKey] = "kserve-container"
+	_, err = isvc.ValidateCreate()
+	g.Expect(err).ShouldNot(gomega.Succeed())
+}
+
 func TestInvalidAutoscalerHPAMetrics(t *testing.T) {
 	g := gomega.NewGomegaWithT(t)
 	isvc := makeTestRawInferenceService()
@@ -133,6 +321,260 @@ func TestInvalidAutoscalerHPAMetrics(t *testing.T) {
 	g.Expect(warnings).Should(gomega.BeEmpty())
 }
 
+func TestHPACustomMetricsAnnotation(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	isvc := makeTestRawInferenceService()
+	isvc.ObjectMeta.Annotations[constants.HPACustomMetricsAnnotationKey] = `[
+		{"type": "Pods", "name": "requests_in_flight", "averageValue": "10"},
+		{"type": "External", "name": "queue_depth", "selector": {"matchLabels": {"queue": "inference"}}, "value": "100"}]`
+	warnings, err := isvc.ValidateCreate()
+	g.Expect(err).Should(gomega.Succeed())
+	g.Expect(warnings).Should(gomega.BeEmpty())
+
+	for _, value := range []string{
+		`not json`,
+		`[{"type": "Pods", "averageValue": "10"}]`,
+		`[{"type": "Pods", "name": "requests_in_flight", "value": "10"}]`,
+		`[{"type": "External", "name": "queue_depth"}]`,
+		`[{"type": "External", "name": "queue_depth", "value": "100", "averageValue": "10"}]`,
+		`[{"type": "Object", "name": "requests_per_second", "value": "100"}]`,
+	} {
+		isvc.ObjectMeta.Annotations[constants.HPACustomMetricsAnnotationKey] = value
+		_, err = isvc.ValidateCreate()
+		g.Expect(err).ShouldNot(gomega.Succeed(), value)
+	}
+}
+
+func TestHPAScalingBehaviorAnnotations(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	isvc := makeTestRawInferenceService()
+	isvc.ObjectMeta.Annotations[constants.HPAScaleDownStabilizationAnnotationKey] = "600"
+	isvc.ObjectMeta.Annotations[constants.HPAScaleUpPoliciesAnnotationKey] = `[{"type": "Percent", "value": 100, "periodSeconds": 15}]`
+	warnings, err := isvc.ValidateCreate()
+	g.Expect(err).Should(gomega.Succeed())
+	g.Expect(warnings).Should(gomega.BeEmpty())
+
+	for key, value := range map[string]string{
+		constants.HPAScaleDownStabilizationAnnotationKey: "-60",
+		constants.HPAScaleUpStabilizationAnnotationKey:   "3601",
+		constants.HPAScaleDownPoliciesAnnotationKey:      `[{"type": "Replicas", "value": 1, "periodSeconds": 60}]`,
+		constants.HPAScaleUpPoliciesAnnotationKey:        `[{"type": "Pods", "value": 0, "periodSeconds": 60}]`,
+	} {
+		isvc := makeTestRawInferenceService()
+		isvc.ObjectMeta.Annotations[key] = value
+		_, err = isvc.ValidateCreate()
+		g.Expect(err).ShouldNot(gomega.Succeed(), key)
+	}
+}
+
+func TestKedaAutoscalerClass(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	isvc := makeTestRawInferenceService()
+	isvc.ObjectMeta.Annotations[constants.AutoscalerClass] = string(constants.AutoscalerClassKeda)
+	isvc.ObjectMeta.Annotations[constants.KedaTriggersAnnotationKey] = `[{"type": "prometheus",
+		"metadata": {"serverAddress": "http://prometheus:9090", "query": "sum(rate(requests[1m]))", "threshold": "10"}}]`
+	warnings, err := isvc.ValidateCreate()
+	g.Expect(err).Should(gomega.Succeed())
+	g.Expect(warnings).Should(gomega.BeEmpty())
+
+	for _, value := range []string{`[]`, `[{"type": "prometheus"}]`, `[{"metadata": {"query": "up"}}]`} {
+		isvc.ObjectMeta.Annotations[constants.KedaTriggersAnnotationKey] = value
+		_, err = isvc.ValidateCreate()
+		g.Expect(err).ShouldNot(gomega.Succeed(), value)
+	}
+
+	delete(isvc.ObjectMeta.Annotations, constants.KedaTriggersAnnotationKey)
+	_, err = isvc.ValidateCreate()
+	g.Expect(err).ShouldNot(gomega.Succeed())
+
+	serverless := makeTestInferenceService()
+	serverless.ObjectMeta.Annotations = map[string]string{
+		constants.AutoscalerClass:           string(constants.AutoscalerClassKeda),
+		constants.KedaTriggersAnnotationKey: `[{"type": "cpu", "metadata": {"value": "60"}}]`,
+	}
+	_, err = serverless.ValidateCreate()
+	g.Expect(err).ShouldNot(gomega.Succeed())
+}
+
+func TestVPAAnnotations(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	isvc := makeTestRawInferenceService()
+	isvc.ObjectMeta.Annotations[constants.VPAUpdateModeAnnotationKey] = constants.VPAUpdateModeAuto
+	isvc.ObjectMeta.Annotations[constants.VPAMinAllowedAnnotationKey] = `{"memory": "1Gi"}`
+	isvc.ObjectMeta.Annotations[constants.VPAMaxAllowedAnnotationKey] = `{"memory": "8Gi", "cpu": "2"}`
+	warnings, err := isvc.ValidateCreate()
+	g.Expect(err).Should(gomega.Succeed())
+	g.Expect(warnings).Should(gomega.BeEmpty())
+
+	for key, value := range map[string]string{
+		constants.VPAUpdateModeAnnotationKey: "Always",
+		constants.VPAMinAllowedAnnotationKey: `{"memory": "16Gi"}`,
+		constants.VPAMaxAllowedAnnotationKey: `{"nvidia.com/gpu": "1"}`,
+	} {
+		isvc := makeTestRawInferenceService()
+		isvc.ObjectMeta.Annotations[constants.VPAUpdateModeAnnotationKey] = constants.VPAUpdateModeAuto
+		isvc.ObjectMeta.Annotations[constants.VPAMaxAllowedAnnotationKey] = `{"memory": "8Gi"}`
+		isvc.ObjectMeta.Annotations[key] = value
+		_, err = isvc.ValidateCreate()
+		g.Expect(err).ShouldNot(gomega.Succeed(), key)
+	}
+
+	// a vpa applying its recommendations can not be combined with an hpa on memory
+	isvc.ObjectMeta.Annotations[constants.AutoscalerMetrics] = string(constants.AutoScalerMetricsMemory)
+	_, err = isvc.ValidateCreate()
+	g.Expect(err).ShouldNot(gomega.Succeed())
+
+	isvc.ObjectMeta.Annotations[constants.VPAUpdateModeAnnotationKey] = constants.VPAUpdateModeOff
+	_, err = isvc.ValidateCreate()
+	g.Expect(err).Should(gomega.Succeed())
+}
+
+func TestCustomDomainAnnotation(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	isvc := makeTestInferenceService()
+	isvc.ObjectMeta.Annotations = map[string]string{
+		constants.CustomDomainAnnotationKey:          "model.example.com",
+		constants.CustomDomainTLSSecretAnnotationKey: "model-example-com-tls",
+	}
+	warnings, err := isvc.ValidateCreate()
+	g.Expect(err).Should(gomega.Succeed())
+	g.Expect(warnings).Should(gomega.BeEmpty())
+
+	for _, domain := range []string{"", "model", "Model.example.com", "model_1.example.com"} {
+		isvc.ObjectMeta.Annotations[constants.CustomDomainAnnotationKey] = domain
+		_, err = isvc.ValidateCreate()
+		g.Expect(err).ShouldNot(gomega.Succeed(), domain)
+	}
+
+	// the tls secret is only used together with a custom domain
+	delete(isvc.ObjectMeta.Annotations, constants.CustomDomainAnnotationKey)
+	_, err = isvc.ValidateCreate()
+	g.Expect(err).ShouldNot(gomega.Succeed())
+
+	rawIsvc := makeTestRawInferenceService()
+	rawIsvc.ObjectMeta.Annotations[constants.CustomDomainAnnotationKey] = "model.example.com"
+	_, err = rawIsvc.ValidateCreate()
+	g.Expect(err).ShouldNot(gomega.Succeed())
+}
+
+func TestIngressAnnotations(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	isvc := makeTestRawInferenceService()
+	isvc.ObjectMeta.Annotations[constants.IngressGatewayAnnotationKey] = "kserve/my-gateway"
+	isvc.ObjectMeta.Annotations[constants.IngressClassAnnotationKey] = "nginx"
+	isvc.ObjectMeta.Annotations[constants.TLSSecretAnnotationKey] = "my-model-tls"
+	warnings, err := isvc.ValidateCreate()
+	g.Expect(err).Should(gomega.Succeed())
+	g.Expect(warnings).Should(gomega.BeEmpty())
+
+	for _, gateway := range []string{"", "my-gateway", "/my-gateway", "kserve/", "Kserve/my-gateway", "kserve/my_gateway"} {
+		isvc.ObjectMeta.Annotations[constants.IngressGatewayAnnotationKey] = gateway
+		_, err = isvc.ValidateCreate()
+		g.Expect(err).ShouldNot(gomega.Succeed(), gateway)
+	}
+	delete(isvc.ObjectMeta.Annotations, constants.IngressGatewayAnnotationKey)
+
+	for _, ingressClass := range []string{"", "Nginx", "nginx_internal"} {
+		isvc.ObjectMeta.Annotations[constants.IngressClassAnnotationKey] = ingressClass
+		_, err = isvc.ValidateCreate()
+		g.Expect(err).ShouldNot(gomega.Succeed(), ingressClass)
+	}
+	isvc.ObjectMeta.Annotations[constants.IngressClassAnnotationKey] = "nginx"
+
+	for _, secret := range []string{"", "My-Secret", "my_secret"} {
+		isvc.ObjectMeta.Annotations[constants.TLSSecretAnnotationKey] = secret
+		_, err = isvc.ValidateCreate()
+		g.Expect(err).ShouldNot(gomega.Succeed(), secret)
+	}
+	isvc.ObjectMeta.Annotations[constants.TLSSecretAnnotationKey] = "my-model-tls"
+
+	for _, termination := range []string{constants.RouteTLSTerminationEdge, constants.RouteTLSTerminationReencrypt} {
+		isvc.ObjectMeta.Annotations[constants.RouteTLSTerminationAnnotationKey] = termination
+		_, err = isvc.ValidateCreate()
+		g.Expect(err).Should(gomega.Succeed(), termination)
+	}
+	for _, termination := range []string{"", "Edge", "passthrough"} {
+		isvc.ObjectMeta.Annotations[constants.RouteTLSTerminationAnnotationKey] = termination
+		_, err = isvc.ValidateCreate()
+		g.Expect(err).ShouldNot(gomega.Succeed(), termination)
+	}
+}
+
+func TestAdditionalHostsAnnotation(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	isvc := makeTestInferenceService()
+	isvc.ObjectMeta.Annotations = map[string]string{
+		constants.AdditionalHostsAnnotationKey: "my-model.example.com, my-model.example.org,my-model.example.com",
+	}
+	warnings, err := isvc.ValidateCreate()
+	g.Expect(err).Should(gomega.Succeed())
+	g.Expect(warnings).Should(gomega.BeEmpty())
+	g.Expect(GetAdditionalHosts(isvc.ObjectMeta.Annotations)).Should(gomega.Equal(
+		[]string{"my-model.example.com", "my-model.example.org", "my-model.example.com"}))
+
+	for _, hosts := range []string{"my_model.example.com", "My-Model.example.com", "my-model.example.com,-invalid"} {
+		isvc.ObjectMeta.Annotations[constants.AdditionalHostsAnnotationKey] = hosts
+		_, err = isvc.ValidateCreate()
+		g.Expect(err).ShouldNot(gomega.Succeed(), hosts)
+	}
+}
+
+func TestDestinationRuleAnnotations(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	isvc := makeTestInferenceService()
+	isvc.ObjectMeta.Annotations = map[string]string{
+		constants.DestinationRuleMaxConnectionsAnnotationKey:       "100",
+		constants.DestinationRuleHTTP2MaxRequestsAnnotationKey:     "1000",
+		constants.DestinationRuleConsecutive5xxErrorsAnnotationKey: "5",
+		constants.DestinationRuleBaseEjectionTimeAnnotationKey:     "30s",
+	}
+	warnings, err := isvc.ValidateCreate()
+	g.Expect(err).Should(gomega.Succeed())
+	g.Expect(warnings).Should(gomega.BeEmpty())
+
+	for key, value := range map[string]string{
+		constants.DestinationRuleMaxConnectionsAnnotationKey:       "0",
+		constants.DestinationRuleHTTP2MaxRequestsAnnotationKey:     "many",
+		constants.DestinationRuleConsecutive5xxErrorsAnnotationKey: "-1",
+		constants.DestinationRuleBaseEjectionTimeAnnotationKey:     "30",
+	} {
+		isvc := makeTestInferenceService()
+		isvc.ObjectMeta.Annotations = map[string]string{key: value}
+		_, err = isvc.ValidateCreate()
+		g.Expect(err).ShouldNot(gomega.Succeed(), key)
+	}
+}
+
+func TestCorsAnnotations(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	isvc := makeTestInferenceService()
+	isvc.ObjectMeta.Annotations = map[string]string{
+		constants.CorsAllowOriginsAnnotationKey: "https://example.com, http://localhost:3000,*",
+		constants.CorsAllowMethodsAnnotationKey: "GET,POST,OPTIONS",
+		constants.CorsAllowHeadersAnnotationKey: "Content-Type",
+		constants.CorsMaxAgeAnnotationKey:       "600",
+	}
+	warnings, err := isvc.ValidateCreate()
+	g.Expect(err).Should(gomega.Succeed())
+	g.Expect(warnings).Should(gomega.BeEmpty())
+
+	for value, key := range map[string]string{
+		"example.com":                 constants.CorsAllowOriginsAnnotationKey,
+		"ftp://example.com":           constants.CorsAllowOriginsAnnotationKey,
+		"https://example.com/path":    constants.CorsAllowOriginsAnnotationKey,
+		"https://Example_Host.com":    constants.CorsAllowOriginsAnnotationKey,
+		"https://user@example.com":    constants.CorsAllowOriginsAnnotationKey,
+		"https://example.com?query=1": constants.CorsAllowOriginsAnnotationKey,
+		"get":                         constants.CorsAllowMethodsAnnotationKey,
+		"-1":                          constants.CorsMaxAgeAnnotationKey,
+	} {
+		isvc := makeTestInferenceService()
+		isvc.ObjectMeta.Annotations = map[string]string{key: value}
+		_, err = isvc.ValidateCreate()
+		g.Expect(err).ShouldNot(gomega.Succeed(), value)
+	}
+}
+
 func TestRejectMultipleModelSpecs(t *testing.T) {
 	g := gomega.NewGomegaWithT(t)
 	isvc := makeTestInferenceService()
@@ -181,6 +623,56 @@ func TestBadParallelismValues(t *testing.T) {
 	g.Expect(warnings).Should(gomega.BeEmpty())
 }
 
+func TestKnativeScaleOptions(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	isvc := makeTestInferenceService()
+	isvc.Spec.Predictor.InitialScale = GetIntReference(0)
+	isvc.Spec.Predictor.ScaleDownDelay = proto.String("15m")
+	isvc.Spec.Predictor.ScaleWindow = proto.String("120s")
+	warnings, err := isvc.ValidateCreate()
+	g.Expect(err).Should(gomega.Succeed())
+	g.Expect(warnings).Should(gomega.BeEmpty())
+
+	for name, update := range map[string]func(spec *ComponentExtensionSpec){
+		"negativeInitialScale":  func(spec *ComponentExtensionSpec) { spec.InitialScale = GetIntReference(-1) },
+		"invalidScaleDownDelay": func(spec *ComponentExtensionSpec) { spec.ScaleDownDelay = proto.String("15") },
+		"scaleDownDelayTooLong": func(spec *ComponentExtensionSpec) { spec.ScaleDownDelay = proto.String("2h") },
+		"invalidScaleWindow":    func(spec *ComponentExtensionSpec) { spec.ScaleWindow = proto.String("two minutes") },
+		"scaleWindowTooShort":   func(spec *ComponentExtensionSpec) { spec.ScaleWindow = proto.String("1s") },
+	} {
+		isvc := makeTestInferenceService()
+		update(&isvc.Spec.Predictor.ComponentExtensionSpec)
+		_, err = isvc.ValidateCreate()
+		g.Expect(err).ShouldNot(gomega.Succeed(), name)
+	}
+}
+
+func TestContainerConcurrencyAndScaleTarget(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	for name, scenario := range map[string]struct {
+		containerConcurrency int64
+		scaleMetric          ScaleMetric
+		valid                bool
+	}{
+		"unlimited":            {containerConcurrency: 0, scaleMetric: MetricConcurrency, valid: true},
+		"equal":                {containerConcurrency: 10, scaleMetric: MetricConcurrency, valid: true},
+		"greater":              {containerConcurrency: 20, scaleMetric: MetricConcurrency, valid: true},
+		"lowerThanTarget":      {containerConcurrency: 5, scaleMetric: MetricConcurrency, valid: false},
+		"rpsTargetIndependent": {containerConcurrency: 5, scaleMetric: MetricRPS, valid: true},
+	} {
+		isvc := makeTestInferenceService()
+		isvc.Spec.Predictor.ScaleTarget = GetIntReference(10)
+		isvc.Spec.Predictor.ScaleMetric = &scenario.scaleMetric
+		isvc.Spec.Predictor.ContainerConcurrency = proto.Int64(scenario.containerConcurrency)
+		_, err := isvc.ValidateCreate()
+		if scenario.valid {
+			g.Expect(err).Should(gomega.Succeed(), name)
+		} else {
+			g.Expect(err).ShouldNot(gomega.Succeed(), name)
+		}
+	}
+}
+
 func TestBadReplicaValues(t *testing.T) {
 	g := gomega.NewGomegaWithT(t)
 	isvc := makeTestInferenceService()
@@ -334,6 +826,445 @@ func TestRejectBadNameIncludeDot(t *testing.T) {
 	g.Expect(warnings).Should(gomega.BeEmpty())
 }
 
+func TestValidateGeneratedNamesLength(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	transformer := &TransformerSpec{PodSpec: PodSpec{Containers: []v1.Container{{Name: constants.InferenceServiceContainerName, Image: "transformer:latest"}}}}
+	scenarios := map[string]struct {
+		deploymentMode constants.DeploymentModeType
+		namespace      string
+		name           string
+		transformer    *TransformerSpec
+		truncate       bool
+		errMatcher     types.GomegaMatcher
+	}{
+		"serverless predictor at the limit": {
+			name:       strings.Repeat("a", 39),
+			errMatcher: gomega.BeNil(),
+		},
+		"serverless predictor over the limit": {
+			name: strings.Repeat("a", 40),
+			errMatcher: gomega.MatchError(fmt.Sprintf(InvalidISVCNameLengthError, strings.Repeat("a", 40), constants.Serverless,
+				GeneratedNameMaxLength, 39, constants.TruncateGeneratedNamesAnnotationKey)),
+		},
+		"serverless transformer over the limit": {
+			name:        strings.Repeat("a", 38),
+			transformer: transformer,
+			errMatcher: gomega.MatchError(fmt.Sprintf(InvalidISVCNameLengthError, strings.Repeat("a", 38), constants.Serverless,
+				GeneratedNameMaxLength, 37, constants.TruncateGeneratedNamesAnnotationKey)),
+		},
+		"serverless ignores the namespace": {
+			namespace:  strings.Repeat("n", 40),
+			name:       strings.Repeat("a", 39),
+			errMatcher: gomega.BeNil(),
+		},
+		"raw predictor at the limit": {
+			deploymentMode: constants.RawDeployment,
+			name:           strings.Repeat("a", 48),
+			errMatcher:     gomega.BeNil(),
+		},
+		"raw predictor over the limit": {
+			deploymentMode: constants.RawDeployment,
+			name:           strings.Repeat("a", 49),
+			errMatcher: gomega.MatchError(fmt.Sprintf(InvalidISVCNameLengthError, strings.Repeat("a", 49), constants.RawDeployment,
+				GeneratedNameMaxLength, 48, constants.TruncateGeneratedNamesAnnotationKey)),
+		},
+		"raw leaves the hosts to the domain template": {
+			deploymentMode: constants.RawDeployment,
+			namespace:      strings.Repeat("n", 30),
+			name:           strings.Repeat("a", 48),
+			errMatcher:     gomega.BeNil(),
+		},
+		"modelmesh": {
+			deploymentMode: constants.ModelMeshDeployment,
+			name:           strings.Repeat("a", 63),
+			errMatcher:     gomega.BeNil(),
+		},
+		"truncated": {
+			deploymentMode: constants.RawDeployment,
+			name:           strings.Repeat("a", 63),
+			truncate:       true,
+			errMatcher:     gomega.BeNil(),
+		},
+		"truncated name over the label limit": {
+			name:     strings.Repeat("a", 64),
+			truncate: true,
+			errMatcher: gomega.MatchError(fmt.Sprintf(InvalidISVCNameLengthError, strings.Repeat("a", 64), constants.Serverless,
+				GeneratedNameMaxLength, 63, constants.TruncateGeneratedNamesAnnotationKey)),
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			isvc := makeTestInferenceService()
+			isvc.Name = scenario.name
+			isvc.Annotations = map[string]string{}
+			if scenario.namespace != "" {
+				isvc.Namespace = scenario.namespace
+			}
+			if scenario.deploymentMode != "" {
+				isvc.Annotations[constants.DeploymentMode] = string(scenario.deploymentMode)
+			}
+			if scenario.truncate {
+				isvc.Annotations[constants.TruncateGeneratedNamesAnnotationKey] = "true"
+			}
+			isvc.Spec.Transformer = scenario.transformer
+			g.Expect(validateGeneratedNamesLength(&isvc)).Should(scenario.errMatcher)
+		})
+	}
+}
+
+func TestValidateGeneratedHosts(t *testing.T) {
+	scenarios := map[string]struct {
+		deploymentMode constants.DeploymentModeType
+		domainTemplate string
+		namespace      string
+		name           string
+		truncate       bool
+		matcher        types.GomegaMatcher
+	}{
+		"default template": {
+			deploymentMode: constants.RawDeployment,
+			domainTemplate: DefaultDomainTemplate,
+			name:           strings.Repeat("a", 45),
+			matcher:        gomega.Succeed(),
+		},
+		"default template in a long namespace": {
+			deploymentMode: constants.RawDeployment,
+			domainTemplate: DefaultDomainTemplate,
+			namespace:      strings.Repeat("n", 30),
+			name:           strings.Repeat("a", 23),
+			matcher:        gomega.MatchError(gomega.ContainSubstring(DefaultDomainTemplate)),
+		},
+		"namespace in its own label": {
+			deploymentMode: constants.RawDeployment,
+			domainTemplate: "{{ .Name }}.{{ .Namespace }}.{{ .IngressDomain }}",
+			namespace:      strings.Repeat("n", 30),
+			name:           strings.Repeat("a", 48),
+			matcher:        gomega.Succeed(),
+		},
+		"truncated name": {
+			deploymentMode: constants.RawDeployment,
+			domainTemplate: "{{ .Name }}.{{ .Namespace }}.{{ .IngressDomain }}",
+			name:           strings.Repeat("a", 63),
+			truncate:       true,
+			matcher:        gomega.Succeed(),
+		},
+		"truncated name in the default template": {
+			deploymentMode: constants.RawDeployment,
+			domainTemplate: DefaultDomainTemplate,
+			name:           strings.Repeat("a", 63),
+			truncate:       true,
+			matcher:        gomega.MatchError(gomega.ContainSubstring(DefaultDomainTemplate)),
+		},
+		"serverless": {
+			deploymentMode: constants.Serverless,
+			domainTemplate: DefaultDomainTemplate,
+			namespace:      strings.Repeat("n", 30),
+			name:           strings.Repeat("a", 39),
+			matcher:        gomega.Succeed(),
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			isvc := makeTestInferenceService()
+			isvc.Name = scenario.name
+			isvc.Annotations = map[string]string{constants.DeploymentMode: string(scenario.deploymentMode)}
+			if scenario.namespace != "" {
+				isvc.Namespace = scenario.namespace
+			}
+			if scenario.truncate {
+				isvc.Annotations[constants.TruncateGeneratedNamesAnnotationKey] = "true"
+			}
+			ingressConfig := &IngressConfig{DomainTemplate: scenario.domainTemplate, IngressDomain: DefaultIngressDomain}
+			g.Expect(validateGeneratedHosts(&isvc, ingressConfig)).Should(scenario.matcher)
+		})
+	}
+}
+
+func TestInferenceServiceValidatorChecksHostsOnCreate(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	s := runtime.NewScheme()
+	g.Expect(v1.AddToScheme(s)).Should(gomega.Succeed())
+	configMap := &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: constants.InferenceServiceConfigMapName, Namespace: constants.KServeNamespace},
+		Data: map[string]string{
+			IngressConfigKeyName: `{"ingressGateway": "knative-serving/knative-ingress-gateway", "ingressService": "istio-ingressgateway.istio-system.svc.cluster.local"}`,
+		},
+	}
+	validator := &InferenceServiceValidator{Client: fake.NewClientBuilder().WithScheme(s).WithObjects(configMap).Build()}
+
+	isvc := makeTestRawInferenceService()
+	isvc.Namespace = strings.Repeat("n", 30)
+	isvc.Name = strings.Repeat("a", 23)
+	_, err := validator.ValidateCreate(context.TODO(), &isvc)
+	g.Expect(err).Should(gomega.MatchError(gomega.ContainSubstring(DefaultDomainTemplate)))
+
+	// the names are only checked when the InferenceService is created
+	_, err = validator.ValidateUpdate(context.TODO(), isvc.DeepCopy(), &isvc)
+	g.Expect(err).Should(gomega.Succeed())
+}
+
+func TestInferenceServiceValidatorPvcSources(t *testing.T) {
+	s := runtime.NewScheme()
+	gomega.NewGomegaWithT(t).Expect(v1.AddToScheme(s)).Should(gomega.Succeed())
+	pvc := &v1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: "models", Namespace: "default"}}
+	validator := &InferenceServiceValidator{Client: fake.NewClientBuilder().WithScheme(s).WithObjects(pvc).Build()}
+
+	scenarios := map[string]struct {
+		namespace  string
+		storageUri string
+		matcher    types.GomegaMatcher
+	}{
+		"ExistingPvc": {
+			namespace:  "default",
+			storageUri: "pvc://models/sklearn/model",
+			matcher:    gomega.Succeed(),
+		},
+		"NotPvcUri": {
+			namespace:  "default",
+			storageUri: "s3://models/sklearn",
+			matcher:    gomega.Succeed(),
+		},
+		"MissingPvc": {
+			namespace:  "default",
+			storageUri: "pvc://other-models/sklearn",
+			matcher:    gomega.MatchError(gomega.ContainSubstring("PVC other-models of storage uri pvc://other-models/sklearn not found in namespace default")),
+		},
+		"PvcOfAnotherNamespace": {
+			namespace:  "kserve-test",
+			storageUri: "pvc://models/sklearn",
+			matcher:    gomega.MatchError(gomega.ContainSubstring("the PVC must be in the namespace of the InferenceService")),
+		},
+		"InvalidPvcName": {
+			namespace:  "default",
+			storageUri: "pvc://Models/sklearn",
+			matcher:    gomega.MatchError(gomega.ContainSubstring("invalid PVC name")),
+		},
+		"ParentDirectory": {
+			namespace:  "default",
+			storageUri: "pvc://models/../secrets",
+			matcher:    gomega.MatchError(gomega.ContainSubstring("must not reference a parent directory")),
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			isvc := makeTestInferenceService()
+			isvc.Namespace = scenario.namespace
+			isvc.Spec.Predictor.Tensorflow.StorageURI = proto.String(scenario.storageUri)
+			_, err := validator.ValidateCreate(context.TODO(), &isvc)
+			g.Expect(err).Should(scenario.matcher)
+			_, err = validator.ValidateUpdate(context.TODO(), isvc.DeepCopy(), &isvc)
+			g.Expect(err).Should(scenario.matcher)
+		})
+	}
+}
+
+func TestValidateTruncateGeneratedNamesUpdate(t *testing.T) {
+	scenarios := map[string]struct {
+		oldValue *string
+		newValue *string
+		matcher  types.GomegaMatcher
+	}{
+		"unchanged": {
+			oldValue: proto.String("true"),
+			newValue: proto.String("true"),
+			matcher:  gomega.Succeed(),
+		},
+		"never set": {
+			matcher: gomega.Succeed(),
+		},
+		"added": {
+			newValue: proto.String("true"),
+			matcher: gomega.MatchError(fmt.Sprintf(TruncateGeneratedNamesUpdateError,
+				constants.TruncateGeneratedNamesAnnotationKey, "foo")),
+		},
+		"removed": {
+			oldValue: proto.String("true"),
+			matcher: gomega.MatchError(fmt.Sprintf(TruncateGeneratedNamesUpdateError,
+				constants.TruncateGeneratedNamesAnnotationKey, "foo")),
+		},
+		"changed": {
+			oldValue: proto.String("true"),
+			newValue: proto.String("false"),
+			matcher: gomega.MatchError(fmt.Sprintf(TruncateGeneratedNamesUpdateError,
+				constants.TruncateGeneratedNamesAnnotationKey, "foo")),
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			oldIsvc := makeTestInferenceService()
+			if scenario.oldValue != nil {
+				oldIsvc.Annotations = map[string]string{constants.TruncateGeneratedNamesAnnotationKey: *scenario.oldValue}
+			}
+			isvc := makeTestInferenceService()
+			if scenario.newValue != nil {
+				isvc.Annotations = map[string]string{constants.TruncateGeneratedNamesAnnotationKey: *scenario.newValue}
+			}
+			_, err := isvc.ValidateUpdate(&oldIsvc)
+			g.Expect(err).Should(scenario.matcher)
+		})
+	}
+}
+
+func TestValidateUpdateSkipsGeneratedNamesLength(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	isvc := makeTestInferenceService()
+	isvc.Name = strings.Repeat("a", 40)
+	_, err := isvc.ValidateCreate()
+	g.Expect(err).ShouldNot(gomega.Succeed())
+	_, err = isvc.ValidateUpdate(isvc.DeepCopy())
+	g.Expect(err).Should(gomega.Succeed())
+}
+
+func TestValidateUpdateChecksAddedAnnotations(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	old := makeTestInferenceService()
+	old.Annotations = map[string]string{"serving.kserve.io/autoscalerclass": "hpa"}
+	isvc := old.DeepCopy()
+	_, err := isvc.ValidateUpdate(&old)
+	g.Expect(err).Should(gomega.Succeed())
+
+	isvc.Annotations["serving.kserve.io/custom-domian"] = "example.com"
+	_, err = isvc.ValidateUpdate(&old)
+	g.Expect(err).Should(gomega.MatchError(fmt.Errorf(utils.AnnotationTypoError, "serving.kserve.io/custom-domian",
+		constants.CustomDomainAnnotationKey, constants.KServeAPIGroupName, constants.CustomAnnotationsAnnotationKey)))
+}
+
+func TestGeneratedNamePrefix(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	isvc := makeTestRawInferenceService()
+	isvc.Name = strings.Repeat("a", 60)
+	g.Expect(isvc.GeneratedNamePrefix()).Should(gomega.Equal(isvc.Name))
+
+	isvc.Annotations[constants.TruncateGeneratedNamesAnnotationKey] = "true"
+	prefix := isvc.GeneratedNamePrefix()
+	// the transformer generates the longest names: isvc.aaa...-transformer
+	g.Expect(prefix).Should(gomega.HaveLen(46))
+	g.Expect(prefix).Should(gomega.HavePrefix(strings.Repeat("a", 37) + "-"))
+	for _, component := range allComponents {
+		for _, name := range generatedNames(prefix, constants.RawDeployment, component) {
+			g.Expect(len(name)).Should(gomega.BeNumerically("<=", GeneratedNameMaxLength))
+		}
+	}
+
+	other := isvc.DeepCopy()
+	other.Name = strings.Repeat("a", 59) + "b"
+	g.Expect(other.GeneratedNamePrefix()).ShouldNot(gomega.Equal(prefix))
+
+	isvc.Name = "foo"
+	g.Expect(isvc.GeneratedNamePrefix()).Should(gomega.Equal("foo"))
+}
+
+func TestValidateCollocatedTransformer(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	predictor := v1.Container{Name: constants.InferenceServiceContainerName, Image: "pytorch/torchserve:0.9.0-cpu"}
+	transformer := v1.Container{
+		Name:  constants.TransformerContainerName,
+		Image: "kserve/image-transformer:latest",
+		Args:  []string{"--http_port=8080", "--grpc_port", "8081", "--predictor_host=localhost:8085"},
+		Ports: []v1.ContainerPort{{ContainerPort: 8080, Protocol: v1.ProtocolTCP}},
+	}
+	withPorts := func(container v1.Container, ports ...int32) v1.Container {
+		container.Ports = nil
+		for _, port := range ports {
+			container.Ports = append(container.Ports, v1.ContainerPort{ContainerPort: port})
+		}
+		return container
+	}
+	withArgs := func(container v1.Container, args ...string) v1.Container {
+		container.Args = args
+		return container
+	}
+	scenarios := map[string]struct {
+		containers     []v1.Container
+		deploymentMode constants.DeploymentModeType
+		transformer    *TransformerSpec
+		logger         *LoggerSpec
+		errMatcher     types.GomegaMatcher
+	}{
+		"NoCollocation": {
+			containers: []v1.Container{predictor},
+			errMatcher: gomega.BeNil(),
+		},
+		"ValidCollocation": {
+			containers: []v1.Container{predictor, transformer},
+			errMatcher: gomega.BeNil(),
+		},
+		"TransformerComponent": {
+			containers: []v1.Container{predictor, transformer},
+			transformer: &TransformerSpec{PodSpec: PodSpec{Containers: []v1.Container{
+				{Name: constants.InferenceServiceContainerName, Image: "kserve/image-transformer:latest"},
+			}}},
+			errMatcher: gomega.MatchError(CollocatedTransformerComponentError),
+		},
+		"DuplicateContainerName": {
+			containers: []v1.Container{predictor, transformer, transformer},
+			errMatcher: gomega.MatchError(fmt.Sprintf(DuplicatePredictorContainerNameError, constants.TransformerContainerName)),
+		},
+		"MisnamedTransformer": {
+			containers: []v1.Container{predictor, func() v1.Container {
+				c := transformer
+				c.Name = "transformer"
+				return c
+			}()},
+			errMatcher: gomega.MatchError(fmt.Sprintf(MisnamedCollocatedTransformerError, "transformer")),
+		},
+		"PortConflictWithPredictor": {
+			deploymentMode: constants.RawDeployment,
+			containers:     []v1.Container{withPorts(predictor, 8080), transformer},
+			errMatcher:     gomega.MatchError(fmt.Sprintf(CollocatedTransformerPortConflictError, 8080, constants.InferenceServiceContainerName)),
+		},
+		"GrpcPortConflictWithPredictorArgument": {
+			containers: []v1.Container{withArgs(predictor, "--http_port=8081"), transformer},
+			errMatcher: gomega.MatchError(fmt.Sprintf(CollocatedTransformerPortConflictError, 8081, constants.InferenceServiceContainerName)),
+		},
+		"PortConflictWithAgent": {
+			containers: []v1.Container{predictor, withArgs(withPorts(transformer, 9081), "--http_port=9081", "--predictor_host=localhost:8085")},
+			logger:     &LoggerSpec{Mode: LogAll},
+			errMatcher: gomega.MatchError(fmt.Sprintf(CollocatedTransformerPortConflictError, 9081, "agent")),
+		},
+		"AgentPortWithoutAgent": {
+			containers: []v1.Container{predictor, withArgs(withPorts(transformer, 9081), "--http_port=9081", "--predictor_host=localhost:8085")},
+			errMatcher: gomega.BeNil(),
+		},
+		"PredictorPortsInServerless": {
+			containers: []v1.Container{withPorts(predictor, 8085), transformer},
+			errMatcher: gomega.MatchError(CollocatedPredictorPortsError),
+		},
+		"MissingPredictorHost": {
+			containers: []v1.Container{predictor, withArgs(transformer, "--http_port=8080")},
+			errMatcher: gomega.MatchError(CollocatedTransformerHostError),
+		},
+		"PredictorHostEnv": {
+			containers: []v1.Container{predictor, func() v1.Container {
+				c := withArgs(transformer)
+				c.Env = []v1.EnvVar{{Name: constants.CustomSpecPredictorHostEnvVarKey, Value: "localhost:8085"}}
+				return c
+			}()},
+			errMatcher: gomega.BeNil(),
+		},
+		"DefaultablePredictorHost": {
+			deploymentMode: constants.RawDeployment,
+			containers:     []v1.Container{withPorts(predictor, 8085), withArgs(transformer, "--http_port=8080")},
+			errMatcher:     gomega.BeNil(),
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			isvc := makeTestInferenceService()
+			isvc.Spec.Predictor = PredictorSpec{PodSpec: PodSpec{Containers: scenario.containers}}
+			isvc.Spec.Predictor.Logger = scenario.logger
+			isvc.Spec.Transformer = scenario.transformer
+			if scenario.deploymentMode != "" {
+				isvc.Annotations = map[string]string{constants.DeploymentMode: string(scenario.deploymentMode)}
+			}
+			g.Expect(validateCollocatedTransformer(&isvc)).Should(scenario.errMatcher)
+		})
+	}
+}
+
 func TestValidateCollocationStorageURI(t *testing.T) {
 	g := gomega.NewGomegaWithT(t)
 	scenarios := map[string]struct {
@@ -489,3 +1420,75 @@ func TestValidateCollocationStorageURI(t *testing.T) {
 	}
 
 }
+
+func TestValidateResourceProfile(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	isvcConfig := &InferenceServicesConfig{
+		ResourceProfiles: map[string]v1.ResourceRequirements{
+			"small":  {},
+			"medium": {},
+		},
+	}
+	g.Expect(validateResourceProfile("small", isvcConfig)).Should(gomega.Succeed())
+	g.Expect(validateResourceProfile("large", isvcConfig)).Should(gomega.MatchError(
+		fmt.Sprintf(UnknownResourceProfileError, "large", constants.ResourceProfileAnnotationKey, "medium, small")))
+	g.Expect(validateResourceProfile("small", &InferenceServicesConfig{})).ShouldNot(gomega.Succeed())
+}
+
+func TestAnnotationKeys(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	isvc := makeTestInferenceService()
+	isvc.ObjectMeta.Annotations = map[string]string{"serving.kserve.io/team": "fraud"}
+	warnings, err := isvc.ValidateCreate()
+	g.Expect(err).Should(gomega.Succeed())
+	g.Expect(warnings).Should(gomega.ConsistOf(fmt.Sprintf(utils.UnknownAnnotationWarning, "serving.kserve.io/team")))
+
+	isvc.ObjectMeta.Annotations = map[string]string{"serving.kserve.io/autoscalerclass": "hpa"}
+	_, err = isvc.ValidateCreate()
+	g.Expect(err).Should(gomega.MatchError(gomega.ContainSubstring(
+		fmt.Sprintf("did you mean %q?", constants.AutoscalerClass))))
+
+	isvc.ObjectMeta.Annotations = map[string]string{
+		constants.CustomAnnotationsAnnotationKey: "serving.kserve.io/team",
+		"serving.kserve.io/team":                 "fraud",
+	}
+	warnings, err = isvc.ValidateCreate()
+	g.Expect(err).Should(gomega.Succeed())
+	g.Expect(warnings).Should(gomega.BeEmpty())
+}
+
+func TestTracingAnnotations(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	scenarios := map[string]struct {
+		annotations map[string]string
+		matcher     types.GomegaMatcher
+	}{
+		"Valid": {
+			annotations: map[string]string{
+				constants.TracingAnnotationKey:              "true",
+				constants.TracingOTLPEndpointAnnotationKey:  "http://otel-collector.observability:4317",
+				constants.TracingSamplingRatioAnnotationKey: "0.25",
+			},
+			matcher: gomega.Succeed(),
+		},
+		"InvalidOptOut": {
+			annotations: map[string]string{constants.TracingAnnotationKey: "off"},
+			matcher:     gomega.HaveOccurred(),
+		},
+		"RelativeEndpoint": {
+			annotations: map[string]string{constants.TracingOTLPEndpointAnnotationKey: "otel-collector:4317"},
+			matcher:     gomega.HaveOccurred(),
+		},
+		"SamplingRatioOutOfRange": {
+			annotations: map[string]string{constants.TracingSamplingRatioAnnotationKey: "1.5"},
+			matcher:     gomega.HaveOccurred(),
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			isvc := makeTestInferenceService()
+			isvc.ObjectMeta.Annotations = scenario.annotations
+			g.Expect(validateTracingAnnotations(&isvc)).Should(scenario.matcher)
+		})
+	}
+}
@@ -35,19 +35,28 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"github.com/kserve/kserve/pkg/apis/serving/v1alpha1.ClusterServingRuntimeList":   schema_pkg_apis_serving_v1alpha1_ClusterServingRuntimeList(ref),
 		"github.com/kserve/kserve/pkg/apis/serving/v1alpha1.ClusterStorageContainer":     schema_pkg_apis_serving_v1alpha1_ClusterStorageContainer(ref),
 		"github.com/kserve/kserve/pkg/apis/serving/v1alpha1.ClusterStorageContainerList": schema_pkg_apis_serving_v1alpha1_ClusterStorageContainerList(ref),
+		"github.com/kserve/kserve/pkg/apis/serving/v1alpha1.EnsembleMerge":               schema_pkg_apis_serving_v1alpha1_EnsembleMerge(ref),
 		"github.com/kserve/kserve/pkg/apis/serving/v1alpha1.InferenceGraph":              schema_pkg_apis_serving_v1alpha1_InferenceGraph(ref),
+		"github.com/kserve/kserve/pkg/apis/serving/v1alpha1.InferenceGraphCache":         schema_pkg_apis_serving_v1alpha1_InferenceGraphCache(ref),
 		"github.com/kserve/kserve/pkg/apis/serving/v1alpha1.InferenceGraphList":          schema_pkg_apis_serving_v1alpha1_InferenceGraphList(ref),
+		"github.com/kserve/kserve/pkg/apis/serving/v1alpha1.InferenceGraphLogger":        schema_pkg_apis_serving_v1alpha1_InferenceGraphLogger(ref),
 		"github.com/kserve/kserve/pkg/apis/serving/v1alpha1.InferenceGraphSpec":          schema_pkg_apis_serving_v1alpha1_InferenceGraphSpec(ref),
 		"github.com/kserve/kserve/pkg/apis/serving/v1alpha1.InferenceGraphStatus":        schema_pkg_apis_serving_v1alpha1_InferenceGraphStatus(ref),
 		"github.com/kserve/kserve/pkg/apis/serving/v1alpha1.InferenceRouter":             schema_pkg_apis_serving_v1alpha1_InferenceRouter(ref),
 		"github.com/kserve/kserve/pkg/apis/serving/v1alpha1.InferenceStep":               schema_pkg_apis_serving_v1alpha1_InferenceStep(ref),
+		"github.com/kserve/kserve/pkg/apis/serving/v1alpha1.InferenceStepCache":          schema_pkg_apis_serving_v1alpha1_InferenceStepCache(ref),
+		"github.com/kserve/kserve/pkg/apis/serving/v1alpha1.InferenceStepCircuitBreaker": schema_pkg_apis_serving_v1alpha1_InferenceStepCircuitBreaker(ref),
+		"github.com/kserve/kserve/pkg/apis/serving/v1alpha1.InferenceStepRetry":          schema_pkg_apis_serving_v1alpha1_InferenceStepRetry(ref),
 		"github.com/kserve/kserve/pkg/apis/serving/v1alpha1.InferenceTarget":             schema_pkg_apis_serving_v1alpha1_InferenceTarget(ref),
 		"github.com/kserve/kserve/pkg/apis/serving/v1alpha1.ModelSpec":                   schema_pkg_apis_serving_v1alpha1_ModelSpec(ref),
+		"github.com/kserve/kserve/pkg/apis/serving/v1alpha1.RedisCache":                  schema_pkg_apis_serving_v1alpha1_RedisCache(ref),
+		"github.com/kserve/kserve/pkg/apis/serving/v1alpha1.RedisCacheTLS":               schema_pkg_apis_serving_v1alpha1_RedisCacheTLS(ref),
 		"github.com/kserve/kserve/pkg/apis/serving/v1alpha1.ServingRuntime":              schema_pkg_apis_serving_v1alpha1_ServingRuntime(ref),
 		"github.com/kserve/kserve/pkg/apis/serving/v1alpha1.ServingRuntimeList":          schema_pkg_apis_serving_v1alpha1_ServingRuntimeList(ref),
 		"github.com/kserve/kserve/pkg/apis/serving/v1alpha1.ServingRuntimePodSpec":       schema_pkg_apis_serving_v1alpha1_ServingRuntimePodSpec(ref),
 		"github.com/kserve/kserve/pkg/apis/serving/v1alpha1.ServingRuntimeSpec":          schema_pkg_apis_serving_v1alpha1_ServingRuntimeSpec(ref),
 		"github.com/kserve/kserve/pkg/apis/serving/v1alpha1.ServingRuntimeStatus":        schema_pkg_apis_serving_v1alpha1_ServingRuntimeStatus(ref),
+		"github.com/kserve/kserve/pkg/apis/serving/v1alpha1.SplitterStickiness":          schema_pkg_apis_serving_v1alpha1_SplitterStickiness(ref),
 		"github.com/kserve/kserve/pkg/apis/serving/v1alpha1.StorageContainerSpec":        schema_pkg_apis_serving_v1alpha1_StorageContainerSpec(ref),
 		"github.com/kserve/kserve/pkg/apis/serving/v1alpha1.StorageHelper":               schema_pkg_apis_serving_v1alpha1_StorageHelper(ref),
 		"github.com/kserve/kserve/pkg/apis/serving/v1alpha1.SupportedModelFormat":        schema_pkg_apis_serving_v1alpha1_SupportedModelFormat(ref),
@@ -56,6 +65,7 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"github.com/kserve/kserve/pkg/apis/serving/v1alpha1.TrainedModelList":            schema_pkg_apis_serving_v1alpha1_TrainedModelList(ref),
 		"github.com/kserve/kserve/pkg/apis/serving/v1alpha1.TrainedModelSpec":            schema_pkg_apis_serving_v1alpha1_TrainedModelSpec(ref),
 		"github.com/kserve/kserve/pkg/apis/serving/v1beta1.ARTExplainerSpec":             schema_pkg_apis_serving_v1beta1_ARTExplainerSpec(ref),
+		"github.com/kserve/kserve/pkg/apis/serving/v1beta1.ArtifactStatus":               schema_pkg_apis_serving_v1beta1_ArtifactStatus(ref),
 		"github.com/kserve/kserve/pkg/apis/serving/v1beta1.Batcher":                      schema_pkg_apis_serving_v1beta1_Batcher(ref),
 		"github.com/kserve/kserve/pkg/apis/serving/v1beta1.ComponentExtensionSpec":       schema_pkg_apis_serving_v1beta1_ComponentExtensionSpec(ref),
 		"github.com/kserve/kserve/pkg/apis/serving/v1beta1.ComponentStatusSpec":          schema_pkg_apis_serving_v1beta1_ComponentStatusSpec(ref),
@@ -77,6 +87,7 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"github.com/kserve/kserve/pkg/apis/serving/v1beta1.IngressConfig":                schema_pkg_apis_serving_v1beta1_IngressConfig(ref),
 		"github.com/kserve/kserve/pkg/apis/serving/v1beta1.LightGBMSpec":                 schema_pkg_apis_serving_v1beta1_LightGBMSpec(ref),
 		"github.com/kserve/kserve/pkg/apis/serving/v1beta1.LoggerSpec":                   schema_pkg_apis_serving_v1beta1_LoggerSpec(ref),
+		"github.com/kserve/kserve/pkg/apis/serving/v1beta1.ModelAdapterSpec":             schema_pkg_apis_serving_v1beta1_ModelAdapterSpec(ref),
 		"github.com/kserve/kserve/pkg/apis/serving/v1beta1.ModelCopies":                  schema_pkg_apis_serving_v1beta1_ModelCopies(ref),
 		"github.com/kserve/kserve/pkg/apis/serving/v1beta1.ModelFormat":                  schema_pkg_apis_serving_v1beta1_ModelFormat(ref),
 		"github.com/kserve/kserve/pkg/apis/serving/v1beta1.ModelRevisionStates":          schema_pkg_apis_serving_v1beta1_ModelRevisionStates(ref),
@@ -88,6 +99,9 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"github.com/kserve/kserve/pkg/apis/serving/v1beta1.PodSpec":                      schema_pkg_apis_serving_v1beta1_PodSpec(ref),
 		"github.com/kserve/kserve/pkg/apis/serving/v1beta1.PredictorExtensionSpec":       schema_pkg_apis_serving_v1beta1_PredictorExtensionSpec(ref),
 		"github.com/kserve/kserve/pkg/apis/serving/v1beta1.PredictorSpec":                schema_pkg_apis_serving_v1beta1_PredictorSpec(ref),
+		"github.com/kserve/kserve/pkg/apis/serving/v1beta1.RetrySpec":                    schema_pkg_apis_serving_v1beta1_RetrySpec(ref),
+		"github.com/kserve/kserve/pkg/apis/serving/v1beta1.RevisionModelStatus":          schema_pkg_apis_serving_v1beta1_RevisionModelStatus(ref),
+		"github.com/kserve/kserve/pkg/apis/serving/v1beta1.RuntimeStatus":                schema_pkg_apis_serving_v1beta1_RuntimeStatus(ref),
 		"github.com/kserve/kserve/pkg/apis/serving/v1beta1.SKLearnSpec":                  schema_pkg_apis_serving_v1beta1_SKLearnSpec(ref),
 		"github.com/kserve/kserve/pkg/apis/serving/v1beta1.StorageSpec":                  schema_pkg_apis_serving_v1beta1_StorageSpec(ref),
 		"github.com/kserve/kserve/pkg/apis/serving/v1beta1.TFServingSpec":                schema_pkg_apis_serving_v1beta1_TFServingSpec(ref),
@@ -344,6 +358,42 @@ func schema_pkg_apis_serving_v1alpha1_ClusterStorageContainerList(ref common.Ref
 	}
 }
 
+func schema_pkg_apis_serving_v1alpha1_EnsembleMerge(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "EnsembleMerge defines how the responses of the steps of an Ensemble node are combined",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"strategy": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Strategy used to merge the step responses",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"field": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Field is the dot separated path, e.g. `outputs.0.data`, of the value voted on or averaged in every step response, defaults to `predictions`. Arrays are merged element-wise and the merged value replaces the field in the response of the first step.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"combinerUrl": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CombinerURL of the service receiving the step responses for the custom strategy",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"strategy"},
+			},
+		},
+	}
+}
+
 func schema_pkg_apis_serving_v1alpha1_InferenceGraph(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -391,6 +441,27 @@ func schema_pkg_apis_serving_v1alpha1_InferenceGraph(ref common.ReferenceCallbac
 	}
 }
 
+func schema_pkg_apis_serving_v1alpha1_InferenceGraphCache(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "InferenceGraphCache defines the backend of the caches of the graph steps",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"redis": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Redis stores the cached responses in a Redis server shared by the router replicas.",
+							Ref:         ref("github.com/kserve/kserve/pkg/apis/serving/v1alpha1.RedisCache"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/kserve/kserve/pkg/apis/serving/v1alpha1.RedisCache"},
+	}
+}
+
 func schema_pkg_apis_serving_v1alpha1_InferenceGraphList(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -445,6 +516,49 @@ func schema_pkg_apis_serving_v1alpha1_InferenceGraphList(ref common.ReferenceCal
 	}
 }
 
+func schema_pkg_apis_serving_v1alpha1_InferenceGraphLogger(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "InferenceGraphLogger configures the payload logging of the router, the events are the same as the ones of the InferenceService loggers and carry the name of the graph, the name of the step and the id of the inference.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"url": {
+						SchemaProps: spec.SchemaProps{
+							Description: "URL to send logging events. <br /> The events are posted as cloud events over http, or published to a kafka topic when the url has the form kafka://broker1:9092,broker2:9092/topic",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"mode": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Specifies the scope of the loggers. <br /> Valid values are: <br /> - \"all\" (default): log both request and response; <br /> - \"request\": log only request; <br /> - \"response\": log only response <br /> - \"errors\": log both request and response of the inferences for which the graph returns a non-2xx status or an error response <br />",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"samplingRate": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Fraction of the inferences logged, a decimal number between \"0.0\" and \"1.0\" (default). The events of an inference, including the events of its steps, are either all logged or all skipped.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"steps": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Steps also logs the request and the response of every step of the graph, the events are tagged with the name of the step.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"url"},
+			},
+		},
+	}
+}
+
 func schema_pkg_apis_serving_v1alpha1_InferenceGraphSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -513,12 +627,46 @@ func schema_pkg_apis_serving_v1alpha1_InferenceGraphSpec(ref common.ReferenceCal
 							Format:      "",
 						},
 					},
+					"cache": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Cache configures the backend of the step caches, the cached responses are kept in the memory of the router when not specified.",
+							Ref:         ref("github.com/kserve/kserve/pkg/apis/serving/v1alpha1.InferenceGraphCache"),
+						},
+					},
+					"propagateHeaders": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PropagateHeaders are regular expressions matching the names of the request headers forwarded to the steps, in addition to the headers propagated by the router configuration. The other headers are dropped.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"serviceAccountName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ServiceAccountName is the name of the service account the router runs as. The steps with a token audience are called with a token of this service account.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"logger": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Logger sends the requests and the responses of the graph, and optionally of its steps, as cloud events.",
+							Ref:         ref("github.com/kserve/kserve/pkg/apis/serving/v1alpha1.InferenceGraphLogger"),
+						},
+					},
 				},
 				Required: []string{"nodes"},
 			},
 		},
 		Dependencies: []string{
-			"github.com/kserve/kserve/pkg/apis/serving/v1alpha1.InferenceRouter", "k8s.io/api/core/v1.Affinity", "k8s.io/api/core/v1.ResourceRequirements"},
+			"github.com/kserve/kserve/pkg/apis/serving/v1alpha1.InferenceGraphCache", "github.com/kserve/kserve/pkg/apis/serving/v1alpha1.InferenceGraphLogger", "github.com/kserve/kserve/pkg/apis/serving/v1alpha1.InferenceRouter", "k8s.io/api/core/v1.Affinity", "k8s.io/api/core/v1.ResourceRequirements"},
 	}
 }
 
@@ -615,12 +763,24 @@ func schema_pkg_apis_serving_v1alpha1_InferenceRouter(ref common.ReferenceCallba
 							},
 						},
 					},
+					"merge": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Merge defines how the responses of the steps of an Ensemble node are combined, the responses are returned as a map of step name to response by default",
+							Ref:         ref("github.com/kserve/kserve/pkg/apis/serving/v1alpha1.EnsembleMerge"),
+						},
+					},
+					"stickiness": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Stickiness routes the requests with the same key to the same step of a Splitter node as long as the weights of the steps are unchanged, the requests without a key are routed randomly according to the weights",
+							Ref:         ref("github.com/kserve/kserve/pkg/apis/serving/v1alpha1.SplitterStickiness"),
+						},
+					},
 				},
 				Required: []string{"routerType"},
 			},
 		},
 		Dependencies: []string{
-			"github.com/kserve/kserve/pkg/apis/serving/v1alpha1.InferenceStep"},
+			"github.com/kserve/kserve/pkg/apis/serving/v1alpha1.EnsembleMerge", "github.com/kserve/kserve/pkg/apis/serving/v1alpha1.InferenceStep", "github.com/kserve/kserve/pkg/apis/serving/v1alpha1.SplitterStickiness"},
 	}
 }
 
@@ -680,6 +840,13 @@ func schema_pkg_apis_serving_v1alpha1_InferenceStep(ref common.ReferenceCallback
 							Format:      "",
 						},
 					},
+					"conditionLanguage": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ConditionLanguage is the language of the condition, defaults to gjson. CEL expressions read the parsed JSON of the node input from the request variable and, in a Sequence node, the response of the previous step from the response variable, e.g. response.predictions[0].score > 0.8",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 					"dependency": {
 						SchemaProps: spec.SchemaProps{
 							Description: "to decide whether a step is a hard or a soft dependency in the Inference Graph",
@@ -687,7 +854,164 @@ func schema_pkg_apis_serving_v1alpha1_InferenceStep(ref common.ReferenceCallback
 							Format:      "",
 						},
 					},
+					"protocol": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Protocol used to call the service of the step, defaults to http. With grpc-v2 the model name is taken from the /v2/models/{name} path of the service url, or from the service name when the url has no such path.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"streaming": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Streaming proxies the text/event-stream response of the step to the client as it arrives instead of buffering it, e.g. the tokens generated by an LLM. Only a terminal step of the graph, whose response is returned to the client, can stream, and the stream is bounded by the timeout of the graph.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"tokenAudience": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TokenAudience attaches a projected token of the router service account, issued for this audience, to the calls of the step as a Bearer token in the Authorization header, replacing a propagated Authorization header.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"timeout": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TimeoutSeconds specifies the number of seconds to wait for each attempt of the step.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"retry": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Retry policy of the step, the step is attempted once when not specified.",
+							Ref:         ref("github.com/kserve/kserve/pkg/apis/serving/v1alpha1.InferenceStepRetry"),
+						},
+					},
+					"circuitBreaker": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CircuitBreaker stops calling the step after consecutive failures.",
+							Ref:         ref("github.com/kserve/kserve/pkg/apis/serving/v1alpha1.InferenceStepCircuitBreaker"),
+						},
+					},
+					"cache": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Cache serves the responses of the step to identical requests without calling it.",
+							Ref:         ref("github.com/kserve/kserve/pkg/apis/serving/v1alpha1.InferenceStepCache"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/kserve/kserve/pkg/apis/serving/v1alpha1.InferenceStepCache", "github.com/kserve/kserve/pkg/apis/serving/v1alpha1.InferenceStepCircuitBreaker", "github.com/kserve/kserve/pkg/apis/serving/v1alpha1.InferenceStepRetry"},
+	}
+}
+
+func schema_pkg_apis_serving_v1alpha1_InferenceStepCache(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "InferenceStepCache defines the cache of the successful responses of a step.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"ttlSeconds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TTLSeconds is the number of seconds a response is served from the cache.",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"maxEntries": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MaxEntries is the maximum number of responses kept in the memory of the router, defaults to 1000. The least recently used responses are evicted first.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"keyFields": {
+						SchemaProps: spec.SchemaProps{
+							Description: "KeyFields are the paths of the request fields the cache key is computed from, e.g. inputs.#.data. When not specified the whole request is used, except its id.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+				},
+				Required: []string{"ttlSeconds"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_serving_v1alpha1_InferenceStepCircuitBreaker(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "InferenceStepCircuitBreaker defines when the router stops calling a failing step.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"consecutiveFailures": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ConsecutiveFailures is the number of consecutive failed calls, after retries, which opens the circuit.",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"cooldownSeconds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CooldownSeconds is the number of seconds the circuit stays open before a call is let through to probe the step again. Defaults to 30 seconds.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"fallback": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Fallback is the JSON response returned while the circuit is open. When not specified the step fails immediately with the 503 status code.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"consecutiveFailures"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_serving_v1alpha1_InferenceStepRetry(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "InferenceStepRetry defines how a failed step is retried. A step fails when the call returns an error, times out or responds with a 429 or 5xx status code.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"attempts": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Attempts is the maximum number of attempts of the step, including the first one.",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"backoffMilliseconds": {
+						SchemaProps: spec.SchemaProps{
+							Description: "BackoffMilliseconds is the delay before the first retry, doubled for each subsequent retry. Defaults to 100 milliseconds.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
 				},
+				Required: []string{"attempts"},
 			},
 		},
 	}
@@ -765,6 +1089,76 @@ func schema_pkg_apis_serving_v1alpha1_ModelSpec(ref common.ReferenceCallback) co
 	}
 }
 
+func schema_pkg_apis_serving_v1alpha1_RedisCache(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RedisCache defines the Redis server storing the cached step responses",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"address": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Address of the Redis server in the host:port form.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"database": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Database number, defaults to 0.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"passwordSecretRef": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PasswordSecretRef references the secret key holding the password of the Redis server.",
+							Ref:         ref("k8s.io/api/core/v1.SecretKeySelector"),
+						},
+					},
+					"tls": {
+						SchemaProps: spec.SchemaProps{
+							Description: "TLS connects to the Redis server over TLS, the server certificate is verified against the system roots.",
+							Ref:         ref("github.com/kserve/kserve/pkg/apis/serving/v1alpha1.RedisCacheTLS"),
+						},
+					},
+				},
+				Required: []string{"address"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/kserve/kserve/pkg/apis/serving/v1alpha1.RedisCacheTLS", "k8s.io/api/core/v1.SecretKeySelector"},
+	}
+}
+
+func schema_pkg_apis_serving_v1alpha1_RedisCacheTLS(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RedisCacheTLS defines the TLS connection to the Redis server",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"serverName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ServerName is the name the server certificate is verified against, defaults to the host of the address.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"insecureSkipVerify": {
+						SchemaProps: spec.SchemaProps{
+							Description: "InsecureSkipVerify skips the verification of the server certificate, only for testing.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
 func schema_pkg_apis_serving_v1alpha1_ServingRuntime(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -1182,6 +1576,13 @@ func schema_pkg_apis_serving_v1alpha1_ServingRuntimeSpec(ref common.ReferenceCal
 							},
 						},
 					},
+					"inheritFrom": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name of the ClusterServingRuntime this ServingRuntime inherits from. The fields set in this ServingRuntime are strategic merged over the spec of the ClusterServingRuntime: containers are merged by name, other lists are replaced. Only supported by namespaced ServingRuntimes.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 					"grpcEndpoint": {
 						SchemaProps: spec.SchemaProps{
 							Description: "Grpc endpoint for internal model-management (implementing mmesh.ModelRuntime gRPC service) Assumed to be single-model runtime if omitted",
@@ -1242,6 +1643,33 @@ func schema_pkg_apis_serving_v1alpha1_ServingRuntimeStatus(ref common.ReferenceC
 	}
 }
 
+func schema_pkg_apis_serving_v1alpha1_SplitterStickiness(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "SplitterStickiness defines the key of the requests assigned to the same step of a Splitter node, exactly one of header and cookie must be specified. The steps are assigned by weighted rendezvous hashing of the key, so that changing the weights only moves the keys needed to reach the new traffic shares.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"header": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Header is the name of the request header holding the key",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"cookie": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Cookie is the name of the request cookie holding the key",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
 func schema_pkg_apis_serving_v1alpha1_StorageContainerSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -1256,6 +1684,13 @@ func schema_pkg_apis_serving_v1alpha1_StorageContainerSpec(ref common.ReferenceC
 							Ref:         ref("k8s.io/api/core/v1.Container"),
 						},
 					},
+					"priority": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Priority of this container when several storage containers support the same storage URI, the container with the highest priority is selected. Defaults to 0.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
 					"supportedUriFormats": {
 						SchemaProps: spec.SchemaProps{
 							Description: "List of URI formats that this container supports",
@@ -1313,7 +1748,7 @@ func schema_pkg_apis_serving_v1alpha1_SupportedModelFormat(ref common.ReferenceC
 					},
 					"version": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Version of the model format. Used in validating that a predictor is supported by a runtime. Can be \"major\", \"major.minor\" or \"major.minor.patch\".",
+							Description: "Version of the model format. Used in validating that a predictor is supported by a runtime. Can be \"major\", \"major.minor\" or \"major.minor.patch\", or a range of versions such as \">=1.2, <2\", \"^1.2\" or \"~1.2\", alternatives being separated by \"||\".",
 							Type:        []string{"string"},
 							Format:      "",
 						},
@@ -1333,7 +1768,6 @@ func schema_pkg_apis_serving_v1alpha1_SupportedModelFormat(ref common.ReferenceC
 						},
 					},
 				},
-				
 			},
 		},
 	}
@@ -1812,6 +2246,44 @@ func schema_pkg_apis_serving_v1beta1_ARTExplainerSpec(ref common.ReferenceCallba
 	}
 }
 
+func schema_pkg_apis_serving_v1beta1_ArtifactStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ArtifactStatus is the download state of one of the storage sources of the predictor's model",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name of the adapter, or \"model\" for the storageUri of the model.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"state": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Download state of the artifact: Pending, Loading, Loaded, FailedToLoad",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"failureInfo": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Details of the failure, when the download of the artifact is failed.",
+							Ref:         ref("github.com/kserve/kserve/pkg/apis/serving/v1beta1.FailureInfo"),
+						},
+					},
+				},
+				Required: []string{"name", "state"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/kserve/kserve/pkg/apis/serving/v1beta1.FailureInfo"},
+	}
+}
+
 func schema_pkg_apis_serving_v1beta1_Batcher(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -1826,6 +2298,13 @@ func schema_pkg_apis_serving_v1beta1_Batcher(ref common.ReferenceCallback) commo
 							Format:      "int32",
 						},
 					},
+					"maxInFlight": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Specifies the max number of batches sent concurrently to the model, unlimited if not set",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
 					"maxLatency": {
 						SchemaProps: spec.SchemaProps{
 							Description: "Specifies the max latency to trigger a batch",
@@ -1833,6 +2312,13 @@ func schema_pkg_apis_serving_v1beta1_Batcher(ref common.ReferenceCallback) commo
 							Format:      "int32",
 						},
 					},
+					"maxQueueSize": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Specifies the max number of requests waiting to be sent to the model, the further requests being rejected with the 429 status code, unlimited if not set",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
 					"timeout": {
 						SchemaProps: spec.SchemaProps{
 							Description: "Specifies the timeout of a batch",
@@ -1881,9 +2367,30 @@ func schema_pkg_apis_serving_v1beta1_ComponentExtensionSpec(ref common.Reference
 							Format:      "",
 						},
 					},
+					"initialScale": {
+						SchemaProps: spec.SchemaProps{
+							Description: "InitialScale is the number of replicas a new revision scales to before it is marked ready, it sets the autoscaling.knative.dev/initial-scale annotation. Only applicable for serverless mode.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"scaleDownDelay": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ScaleDownDelay is the duration the request load must stay low before the revision scales down, e.g. 15m, it sets the autoscaling.knative.dev/scale-down-delay annotation. Only applicable for serverless mode.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"scaleWindow": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ScaleWindow is the duration of the window over which the autoscaler averages the metric, e.g. 120s, it sets the autoscaling.knative.dev/window annotation. Only applicable for serverless mode.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 					"containerConcurrency": {
 						SchemaProps: spec.SchemaProps{
-							Description: "ContainerConcurrency specifies how many requests can be processed concurrently, this sets the hard limit of the container concurrency(https://knative.dev/docs/serving/autoscaling/concurrency).",
+							Description: "ContainerConcurrency specifies how many requests can be processed concurrently, this sets the hard limit of the container concurrency(https://knative.dev/docs/serving/autoscaling/concurrency) independently of the soft ScaleTarget, 0 means unlimited.",
 							Type:        []string{"integer"},
 							Format:      "int64",
 						},
@@ -1895,6 +2402,12 @@ func schema_pkg_apis_serving_v1beta1_ComponentExtensionSpec(ref common.Reference
 							Format:      "int64",
 						},
 					},
+					"retries": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Retries defines the retry policy of the requests routed to the component by the Istio VirtualService of the InferenceService. The routes of the Gateway API do not support retries yet.",
+							Ref:         ref("github.com/kserve/kserve/pkg/apis/serving/v1beta1.RetrySpec"),
+						},
+					},
 					"canaryTrafficPercent": {
 						SchemaProps: spec.SchemaProps{
 							Description: "CanaryTrafficPercent defines the traffic split percentage between the candidate revision and the last ready revision",
@@ -1956,7 +2469,7 @@ func schema_pkg_apis_serving_v1beta1_ComponentExtensionSpec(ref common.Reference
 			},
 		},
 		Dependencies: []string{
-			"github.com/kserve/kserve/pkg/apis/serving/v1beta1.Batcher", "github.com/kserve/kserve/pkg/apis/serving/v1beta1.LoggerSpec", "k8s.io/api/apps/v1.DeploymentStrategy"},
+			"github.com/kserve/kserve/pkg/apis/serving/v1beta1.Batcher", "github.com/kserve/kserve/pkg/apis/serving/v1beta1.LoggerSpec", "github.com/kserve/kserve/pkg/apis/serving/v1beta1.RetrySpec", "k8s.io/api/apps/v1.DeploymentStrategy"},
 	}
 }
 
@@ -2033,6 +2546,34 @@ func schema_pkg_apis_serving_v1beta1_ComponentStatusSpec(ref common.ReferenceCal
 							Ref:         ref("knative.dev/pkg/apis/duck/v1.Addressable"),
 						},
 					},
+					"replicas": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Total number of pods targeted by the raw deployment of the component.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"readyReplicas": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Number of pods of the raw deployment of the component with a Ready condition.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"desiredReplicas": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Number of replicas desired by the autoscaler of the component, unset when the component is not scaled by an HPA.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"iamServiceAccountName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name of the service account created for the component to access its storage with a cloud IAM identity, it is deleted once the component no longer uses it.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 				},
 			},
 		},
@@ -3738,7 +4279,6 @@ func schema_pkg_apis_serving_v1beta1_ExplainerExtensionSpec(ref common.Reference
 						},
 					},
 				},
-				
 			},
 		},
 		Dependencies: []string{
@@ -4221,9 +4761,30 @@ func schema_pkg_apis_serving_v1beta1_ExplainerSpec(ref common.ReferenceCallback)
 							Format:      "",
 						},
 					},
+					"initialScale": {
+						SchemaProps: spec.SchemaProps{
+							Description: "InitialScale is the number of replicas a new revision scales to before it is marked ready, it sets the autoscaling.knative.dev/initial-scale annotation. Only applicable for serverless mode.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"scaleDownDelay": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ScaleDownDelay is the duration the request load must stay low before the revision scales down, e.g. 15m, it sets the autoscaling.knative.dev/scale-down-delay annotation. Only applicable for serverless mode.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"scaleWindow": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ScaleWindow is the duration of the window over which the autoscaler averages the metric, e.g. 120s, it sets the autoscaling.knative.dev/window annotation. Only applicable for serverless mode.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 					"containerConcurrency": {
 						SchemaProps: spec.SchemaProps{
-							Description: "ContainerConcurrency specifies how many requests can be processed concurrently, this sets the hard limit of the container concurrency(https://knative.dev/docs/serving/autoscaling/concurrency).",
+							Description: "ContainerConcurrency specifies how many requests can be processed concurrently, this sets the hard limit of the container concurrency(https://knative.dev/docs/serving/autoscaling/concurrency) independently of the soft ScaleTarget, 0 means unlimited.",
 							Type:        []string{"integer"},
 							Format:      "int64",
 						},
@@ -4235,6 +4796,12 @@ func schema_pkg_apis_serving_v1beta1_ExplainerSpec(ref common.ReferenceCallback)
 							Format:      "int64",
 						},
 					},
+					"retries": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Retries defines the retry policy of the requests routed to the component by the Istio VirtualService of the InferenceService. The routes of the Gateway API do not support retries yet.",
+							Ref:         ref("github.com/kserve/kserve/pkg/apis/serving/v1beta1.RetrySpec"),
+						},
+					},
 					"canaryTrafficPercent": {
 						SchemaProps: spec.SchemaProps{
 							Description: "CanaryTrafficPercent defines the traffic split percentage between the candidate revision and the last ready revision",
@@ -4296,7 +4863,7 @@ func schema_pkg_apis_serving_v1beta1_ExplainerSpec(ref common.ReferenceCallback)
 			},
 		},
 		Dependencies: []string{
-			"github.com/kserve/kserve/pkg/apis/serving/v1beta1.ARTExplainerSpec", "github.com/kserve/kserve/pkg/apis/serving/v1beta1.Batcher", "github.com/kserve/kserve/pkg/apis/serving/v1beta1.LoggerSpec", "k8s.io/api/apps/v1.DeploymentStrategy", "k8s.io/api/core/v1.Affinity", "k8s.io/api/core/v1.Container", "k8s.io/api/core/v1.EphemeralContainer", "k8s.io/api/core/v1.HostAlias", "k8s.io/api/core/v1.LocalObjectReference", "k8s.io/api/core/v1.PodDNSConfig", "k8s.io/api/core/v1.PodOS", "k8s.io/api/core/v1.PodReadinessGate", "k8s.io/api/core/v1.PodResourceClaim", "k8s.io/api/core/v1.PodSchedulingGate", "k8s.io/api/core/v1.PodSecurityContext", "k8s.io/api/core/v1.Toleration", "k8s.io/api/core/v1.TopologySpreadConstraint", "k8s.io/api/core/v1.Volume", "k8s.io/apimachinery/pkg/api/resource.Quantity"},
+			"github.com/kserve/kserve/pkg/apis/serving/v1beta1.ARTExplainerSpec", "github.com/kserve/kserve/pkg/apis/serving/v1beta1.Batcher", "github.com/kserve/kserve/pkg/apis/serving/v1beta1.LoggerSpec", "github.com/kserve/kserve/pkg/apis/serving/v1beta1.RetrySpec", "k8s.io/api/apps/v1.DeploymentStrategy", "k8s.io/api/core/v1.Affinity", "k8s.io/api/core/v1.Container", "k8s.io/api/core/v1.EphemeralContainer", "k8s.io/api/core/v1.HostAlias", "k8s.io/api/core/v1.LocalObjectReference", "k8s.io/api/core/v1.PodDNSConfig", "k8s.io/api/core/v1.PodOS", "k8s.io/api/core/v1.PodReadinessGate", "k8s.io/api/core/v1.PodResourceClaim", "k8s.io/api/core/v1.PodSchedulingGate", "k8s.io/api/core/v1.PodSecurityContext", "k8s.io/api/core/v1.Toleration", "k8s.io/api/core/v1.TopologySpreadConstraint", "k8s.io/api/core/v1.Volume", "k8s.io/apimachinery/pkg/api/resource.Quantity"},
 	}
 }
 
@@ -4666,7 +5233,6 @@ func schema_pkg_apis_serving_v1beta1_HuggingFaceRuntimeSpec(ref common.Reference
 						},
 					},
 				},
-				
 			},
 		},
 		Dependencies: []string{
@@ -4866,12 +5432,39 @@ func schema_pkg_apis_serving_v1beta1_InferenceServiceStatus(ref common.Reference
 							Ref:         ref("knative.dev/pkg/apis/duck/v1.Addressable"),
 						},
 					},
+					"addresses": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Addresses holds the addressable endpoints of all the hosts the InferenceService is exposed at, the host of its url followed by the hosts of the additional ingress domains and of the serving.kserve.io/additional-hosts annotation.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("knative.dev/pkg/apis/duck/v1.Addressable"),
+									},
+								},
+							},
+						},
+					},
 					"url": {
 						SchemaProps: spec.SchemaProps{
 							Description: "URL holds the url that will distribute traffic over the provided traffic targets. It generally has the form http[s]://{route-name}.{route-namespace}.{cluster-level-suffix}",
 							Ref:         ref("knative.dev/pkg/apis.URL"),
 						},
 					},
+					"externalUrls": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ExternalURLs holds the additional urls the InferenceService is reachable at, e.g. the custom domain set with the serving.kserve.io/custom-domain annotation.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Ref: ref("knative.dev/pkg/apis.URL"),
+									},
+								},
+							},
+						},
+					},
 					"components": {
 						SchemaProps: spec.SchemaProps{
 							Description: "Statuses for the components of the InferenceService",
@@ -5268,75 +5861,176 @@ func schema_pkg_apis_serving_v1beta1_LightGBMSpec(ref common.ReferenceCallback)
 					},
 					"imagePullPolicy": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Image pull policy. One of Always, Never, IfNotPresent. Defaults to Always if :latest tag is specified, or IfNotPresent otherwise. Cannot be updated. More info: https://kubernetes.io/docs/concepts/containers/images#updating-images",
+							Description: "Image pull policy. One of Always, Never, IfNotPresent. Defaults to Always if :latest tag is specified, or IfNotPresent otherwise. Cannot be updated. More info: https://kubernetes.io/docs/concepts/containers/images#updating-images",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"securityContext": {
+						SchemaProps: spec.SchemaProps{
+							Description: "SecurityContext defines the security options the container should be run with. If set, the fields of SecurityContext override the equivalent fields of PodSecurityContext. More info: https://kubernetes.io/docs/tasks/configure-pod-container/security-context/",
+							Ref:         ref("k8s.io/api/core/v1.SecurityContext"),
+						},
+					},
+					"stdin": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Whether this container should allocate a buffer for stdin in the container runtime. If this is not set, reads from stdin in the container will always result in EOF. Default is false.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"stdinOnce": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Whether the container runtime should close the stdin channel after it has been opened by a single attach. When stdin is true the stdin stream will remain open across multiple attach sessions. If stdinOnce is set to true, stdin is opened on container start, is empty until the first client attaches to stdin, and then remains open and accepts data until the client disconnects, at which time stdin is closed and remains closed until the container is restarted. If this flag is false, a container processes that reads from stdin will never receive an EOF. Default is false",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"tty": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Whether this container should allocate a TTY for itself, also requires 'stdin' to be true. Default is false.",
+							Type:        []string{"boolean"},
+							Format:      "",
+						},
+					},
+					"storage": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Storage Spec for model location",
+							Ref:         ref("github.com/kserve/kserve/pkg/apis/serving/v1beta1.StorageSpec"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"github.com/kserve/kserve/pkg/apis/serving/v1beta1.StorageSpec", "k8s.io/api/core/v1.ContainerPort", "k8s.io/api/core/v1.ContainerResizePolicy", "k8s.io/api/core/v1.EnvFromSource", "k8s.io/api/core/v1.EnvVar", "k8s.io/api/core/v1.Lifecycle", "k8s.io/api/core/v1.Probe", "k8s.io/api/core/v1.ResourceRequirements", "k8s.io/api/core/v1.SecurityContext", "k8s.io/api/core/v1.VolumeDevice", "k8s.io/api/core/v1.VolumeMount"},
+	}
+}
+
+func schema_pkg_apis_serving_v1beta1_LoggerSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "LoggerSpec specifies optional payload logging available for all components",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"url": {
+						SchemaProps: spec.SchemaProps{
+							Description: "URL to send logging events. <br /> The events are posted as cloud events over http, or published to a kafka topic when the url has the form kafka://broker1:9092,broker2:9092/topic",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"secretName": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name of the secret in the namespace of the InferenceService with the SASL/TLS configuration of the kafka logger url. The supported keys are sasl.mechanism (PLAIN, SCRAM-SHA-256 or SCRAM-SHA-512), sasl.username, sasl.password, tls.enabled, ca.crt, tls.crt and tls.key.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"mode": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Specifies the scope of the loggers. <br /> Valid values are: <br /> - \"all\" (default): log both request and response; <br /> - \"request\": log only request; <br /> - \"response\": log only response <br /> - \"errors\": log both request and response of the inferences for which the model returns a non-2xx status or an error response <br />",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"securityContext": {
+					"samplingRate": {
 						SchemaProps: spec.SchemaProps{
-							Description: "SecurityContext defines the security options the container should be run with. If set, the fields of SecurityContext override the equivalent fields of PodSecurityContext. More info: https://kubernetes.io/docs/tasks/configure-pod-container/security-context/",
-							Ref:         ref("k8s.io/api/core/v1.SecurityContext"),
+							Description: "Fraction of the inferences logged, a decimal number between \"0.0\" and \"1.0\" (default). The request and the response of an inference are either both logged or both skipped.",
+							Type:        []string{"string"},
+							Format:      "",
 						},
 					},
-					"stdin": {
+					"maxPayloadBytes": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Whether this container should allocate a buffer for stdin in the container runtime. If this is not set, reads from stdin in the container will always result in EOF. Default is false.",
-							Type:        []string{"boolean"},
-							Format:      "",
+							Description: "Max size in bytes of the logged payloads, the larger payloads are logged according to payloadOverflow.",
+							Type:        []string{"integer"},
+							Format:      "int64",
 						},
 					},
-					"stdinOnce": {
+					"payloadOverflow": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Whether the container runtime should close the stdin channel after it has been opened by a single attach. When stdin is true the stdin stream will remain open across multiple attach sessions. If stdinOnce is set to true, stdin is opened on container start, is empty until the first client attaches to stdin, and then remains open and accepts data until the client disconnects, at which time stdin is closed and remains closed until the container is restarted. If this flag is false, a container processes that reads from stdin will never receive an EOF. Default is false",
-							Type:        []string{"boolean"},
+							Description: "Specifies how the payloads larger than maxPayloadBytes are logged. <br /> Valid values are: <br /> - \"truncate\" (default): the payload is truncated to maxPayloadBytes; <br /> - \"drop\": the event is sent without the payload <br /> The events of these payloads have the payloadlimit and payloadsize attributes.",
+							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"tty": {
+					"contentTypeAllowList": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Whether this container should allocate a TTY for itself, also requires 'stdin' to be true. Default is false.",
-							Type:        []string{"boolean"},
-							Format:      "",
+							Description: "Content types of the logged payloads, e.g. application/json or text/*. All the payloads are logged when empty.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
 						},
 					},
-					"storage": {
+					"metadataHeaders": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Storage Spec for model location",
-							Ref:         ref("github.com/kserve/kserve/pkg/apis/serving/v1beta1.StorageSpec"),
+							Description: "Request headers captured in the metadata attribute of the events, e.g. x-request-id.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
 						},
 					},
 				},
-				
 			},
 		},
-		Dependencies: []string{
-			"github.com/kserve/kserve/pkg/apis/serving/v1beta1.StorageSpec", "k8s.io/api/core/v1.ContainerPort", "k8s.io/api/core/v1.ContainerResizePolicy", "k8s.io/api/core/v1.EnvFromSource", "k8s.io/api/core/v1.EnvVar", "k8s.io/api/core/v1.Lifecycle", "k8s.io/api/core/v1.Probe", "k8s.io/api/core/v1.ResourceRequirements", "k8s.io/api/core/v1.SecurityContext", "k8s.io/api/core/v1.VolumeDevice", "k8s.io/api/core/v1.VolumeMount"},
 	}
 }
 
-func schema_pkg_apis_serving_v1beta1_LoggerSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+func schema_pkg_apis_serving_v1beta1_ModelAdapterSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
 			SchemaProps: spec.SchemaProps{
-				Description: "LoggerSpec specifies optional payload logging available for all components",
+				Description: "ModelAdapterSpec defines an additional model artifact downloaded next to the model of the predictor",
 				Type:        []string{"object"},
 				Properties: map[string]spec.Schema{
-					"url": {
+					"name": {
 						SchemaProps: spec.SchemaProps{
-							Description: "URL to send logging events",
+							Description: "Name of the adapter, a DNS-1123 label unique among the adapters of the model.",
+							Default:     "",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
-					"mode": {
+					"storageUri": {
+						SchemaProps: spec.SchemaProps{
+							Description: "This field points to the location of the adapter which is mounted onto the pod.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"mountPath": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Path where the adapter is mounted in the model server container, a path under /mnt/models which defaults to /mnt/models/<name>.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"secretName": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Specifies the scope of the loggers. <br /> Valid values are: <br /> - \"all\" (default): log both request and response; <br /> - \"request\": log only request; <br /> - \"response\": log only response <br />",
+							Description: "Name of the secret holding the credentials of the adapter storage, the credentials of the service account are used when not set.",
 							Type:        []string{"string"},
 							Format:      "",
 						},
 					},
 				},
+				Required: []string{"name", "storageUri"},
 			},
 		},
 	}
@@ -5392,7 +6086,6 @@ func schema_pkg_apis_serving_v1beta1_ModelFormat(ref common.ReferenceCallback) c
 						},
 					},
 				},
-				
 			},
 		},
 	}
@@ -5445,6 +6138,28 @@ func schema_pkg_apis_serving_v1beta1_ModelSpec(ref common.ReferenceCallback) com
 							Format:      "",
 						},
 					},
+					"adapters": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-map-keys": []interface{}{
+									"name",
+								},
+								"x-kubernetes-list-type": "map",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Adapters downloaded next to the model, e.g. the LoRA adapters of a base model. Each adapter is downloaded by its own storage initializer with the credentials of its secret.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/kserve/kserve/pkg/apis/serving/v1beta1.ModelAdapterSpec"),
+									},
+								},
+							},
+						},
+					},
 					"storageUri": {
 						SchemaProps: spec.SchemaProps{
 							Description: "This field points to the location of the trained model which is mounted onto the pod.",
@@ -5733,7 +6448,7 @@ func schema_pkg_apis_serving_v1beta1_ModelSpec(ref common.ReferenceCallback) com
 			},
 		},
 		Dependencies: []string{
-			"github.com/kserve/kserve/pkg/apis/serving/v1beta1.ModelFormat", "github.com/kserve/kserve/pkg/apis/serving/v1beta1.StorageSpec", "k8s.io/api/core/v1.ContainerPort", "k8s.io/api/core/v1.ContainerResizePolicy", "k8s.io/api/core/v1.EnvFromSource", "k8s.io/api/core/v1.EnvVar", "k8s.io/api/core/v1.Lifecycle", "k8s.io/api/core/v1.Probe", "k8s.io/api/core/v1.ResourceRequirements", "k8s.io/api/core/v1.SecurityContext", "k8s.io/api/core/v1.VolumeDevice", "k8s.io/api/core/v1.VolumeMount"},
+			"github.com/kserve/kserve/pkg/apis/serving/v1beta1.ModelAdapterSpec", "github.com/kserve/kserve/pkg/apis/serving/v1beta1.ModelFormat", "github.com/kserve/kserve/pkg/apis/serving/v1beta1.StorageSpec", "k8s.io/api/core/v1.ContainerPort", "k8s.io/api/core/v1.ContainerResizePolicy", "k8s.io/api/core/v1.EnvFromSource", "k8s.io/api/core/v1.EnvVar", "k8s.io/api/core/v1.Lifecycle", "k8s.io/api/core/v1.Probe", "k8s.io/api/core/v1.ResourceRequirements", "k8s.io/api/core/v1.SecurityContext", "k8s.io/api/core/v1.VolumeDevice", "k8s.io/api/core/v1.VolumeMount"},
 	}
 }
 
@@ -5769,12 +6484,64 @@ func schema_pkg_apis_serving_v1beta1_ModelStatus(ref common.ReferenceCallback) c
 							Ref:         ref("github.com/kserve/kserve/pkg/apis/serving/v1beta1.ModelCopies"),
 						},
 					},
+					"artifacts": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-map-keys": []interface{}{
+									"name",
+								},
+								"x-kubernetes-list-type": "map",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "Download state of the model and of each of its adapters, reported when the predictor has adapters.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("github.com/kserve/kserve/pkg/apis/serving/v1beta1.ArtifactStatus"),
+									},
+								},
+							},
+						},
+					},
+					"lastDownloadDuration": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Wall-clock duration of the last successful download of the model by the storage initializer.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+					"runtime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Runtime serving the model, with the generations its effective spec was built from.",
+							Ref:         ref("github.com/kserve/kserve/pkg/apis/serving/v1beta1.RuntimeStatus"),
+						},
+					},
+					"stableRevision": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Model state of the last rolled out revision, reported while a canary revision is rolled out.",
+							Ref:         ref("github.com/kserve/kserve/pkg/apis/serving/v1beta1.RevisionModelStatus"),
+						},
+					},
+					"canaryRevision": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Model state of the canary revision, reported while it is rolled out.",
+							Ref:         ref("github.com/kserve/kserve/pkg/apis/serving/v1beta1.RevisionModelStatus"),
+						},
+					},
+					"allocatedModelMemory": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Memory requested by the TrainedModels allocated to the multi-model predictor.",
+							Ref:         ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
+						},
+					},
 				},
 				Required: []string{"transitionStatus"},
 			},
 		},
 		Dependencies: []string{
-			"github.com/kserve/kserve/pkg/apis/serving/v1beta1.FailureInfo", "github.com/kserve/kserve/pkg/apis/serving/v1beta1.ModelCopies", "github.com/kserve/kserve/pkg/apis/serving/v1beta1.ModelRevisionStates"},
+			"github.com/kserve/kserve/pkg/apis/serving/v1beta1.ArtifactStatus", "github.com/kserve/kserve/pkg/apis/serving/v1beta1.FailureInfo", "github.com/kserve/kserve/pkg/apis/serving/v1beta1.ModelCopies", "github.com/kserve/kserve/pkg/apis/serving/v1beta1.ModelRevisionStates", "github.com/kserve/kserve/pkg/apis/serving/v1beta1.RevisionModelStatus", "github.com/kserve/kserve/pkg/apis/serving/v1beta1.RuntimeStatus", "k8s.io/apimachinery/pkg/api/resource.Quantity", "k8s.io/apimachinery/pkg/apis/meta/v1.Duration"},
 	}
 }
 
@@ -6069,7 +6836,6 @@ func schema_pkg_apis_serving_v1beta1_ONNXRuntimeSpec(ref common.ReferenceCallbac
 						},
 					},
 				},
-				
 			},
 		},
 		Dependencies: []string{
@@ -6368,7 +7134,6 @@ func schema_pkg_apis_serving_v1beta1_PMMLSpec(ref common.ReferenceCallback) comm
 						},
 					},
 				},
-				
 			},
 		},
 		Dependencies: []string{
@@ -6666,7 +7431,6 @@ func schema_pkg_apis_serving_v1beta1_PaddleServerSpec(ref common.ReferenceCallba
 						},
 					},
 				},
-				
 			},
 		},
 		Dependencies: []string{
@@ -7414,7 +8178,6 @@ func schema_pkg_apis_serving_v1beta1_PredictorExtensionSpec(ref common.Reference
 						},
 					},
 				},
-				
 			},
 		},
 		Dependencies: []string{
@@ -7957,9 +8720,30 @@ func schema_pkg_apis_serving_v1beta1_PredictorSpec(ref common.ReferenceCallback)
 							Format:      "",
 						},
 					},
+					"initialScale": {
+						SchemaProps: spec.SchemaProps{
+							Description: "InitialScale is the number of replicas a new revision scales to before it is marked ready, it sets the autoscaling.knative.dev/initial-scale annotation. Only applicable for serverless mode.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"scaleDownDelay": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ScaleDownDelay is the duration the request load must stay low before the revision scales down, e.g. 15m, it sets the autoscaling.knative.dev/scale-down-delay annotation. Only applicable for serverless mode.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"scaleWindow": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ScaleWindow is the duration of the window over which the autoscaler averages the metric, e.g. 120s, it sets the autoscaling.knative.dev/window annotation. Only applicable for serverless mode.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 					"containerConcurrency": {
 						SchemaProps: spec.SchemaProps{
-							Description: "ContainerConcurrency specifies how many requests can be processed concurrently, this sets the hard limit of the container concurrency(https://knative.dev/docs/serving/autoscaling/concurrency).",
+							Description: "ContainerConcurrency specifies how many requests can be processed concurrently, this sets the hard limit of the container concurrency(https://knative.dev/docs/serving/autoscaling/concurrency) independently of the soft ScaleTarget, 0 means unlimited.",
 							Type:        []string{"integer"},
 							Format:      "int64",
 						},
@@ -7971,6 +8755,12 @@ func schema_pkg_apis_serving_v1beta1_PredictorSpec(ref common.ReferenceCallback)
 							Format:      "int64",
 						},
 					},
+					"retries": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Retries defines the retry policy of the requests routed to the component by the Istio VirtualService of the InferenceService. The routes of the Gateway API do not support retries yet.",
+							Ref:         ref("github.com/kserve/kserve/pkg/apis/serving/v1beta1.RetrySpec"),
+						},
+					},
 					"canaryTrafficPercent": {
 						SchemaProps: spec.SchemaProps{
 							Description: "CanaryTrafficPercent defines the traffic split percentage between the candidate revision and the last ready revision",
@@ -8032,7 +8822,139 @@ func schema_pkg_apis_serving_v1beta1_PredictorSpec(ref common.ReferenceCallback)
 			},
 		},
 		Dependencies: []string{
-			"github.com/kserve/kserve/pkg/apis/serving/v1beta1.Batcher", "github.com/kserve/kserve/pkg/apis/serving/v1beta1.HuggingFaceRuntimeSpec", "github.com/kserve/kserve/pkg/apis/serving/v1beta1.LightGBMSpec", "github.com/kserve/kserve/pkg/apis/serving/v1beta1.LoggerSpec", "github.com/kserve/kserve/pkg/apis/serving/v1beta1.ModelSpec", "github.com/kserve/kserve/pkg/apis/serving/v1beta1.ONNXRuntimeSpec", "github.com/kserve/kserve/pkg/apis/serving/v1beta1.PMMLSpec", "github.com/kserve/kserve/pkg/apis/serving/v1beta1.PaddleServerSpec", "github.com/kserve/kserve/pkg/apis/serving/v1beta1.SKLearnSpec", "github.com/kserve/kserve/pkg/apis/serving/v1beta1.TFServingSpec", "github.com/kserve/kserve/pkg/apis/serving/v1beta1.TorchServeSpec", "github.com/kserve/kserve/pkg/apis/serving/v1beta1.TritonSpec", "github.com/kserve/kserve/pkg/apis/serving/v1beta1.XGBoostSpec", "k8s.io/api/apps/v1.DeploymentStrategy", "k8s.io/api/core/v1.Affinity", "k8s.io/api/core/v1.Container", "k8s.io/api/core/v1.EphemeralContainer", "k8s.io/api/core/v1.HostAlias", "k8s.io/api/core/v1.LocalObjectReference", "k8s.io/api/core/v1.PodDNSConfig", "k8s.io/api/core/v1.PodOS", "k8s.io/api/core/v1.PodReadinessGate", "k8s.io/api/core/v1.PodResourceClaim", "k8s.io/api/core/v1.PodSchedulingGate", "k8s.io/api/core/v1.PodSecurityContext", "k8s.io/api/core/v1.Toleration", "k8s.io/api/core/v1.TopologySpreadConstraint", "k8s.io/api/core/v1.Volume", "k8s.io/apimachinery/pkg/api/resource.Quantity"},
+			"github.com/kserve/kserve/pkg/apis/serving/v1beta1.Batcher", "github.com/kserve/kserve/pkg/apis/serving/v1beta1.HuggingFaceRuntimeSpec", "github.com/kserve/kserve/pkg/apis/serving/v1beta1.LightGBMSpec", "github.com/kserve/kserve/pkg/apis/serving/v1beta1.LoggerSpec", "github.com/kserve/kserve/pkg/apis/serving/v1beta1.ModelSpec", "github.com/kserve/kserve/pkg/apis/serving/v1beta1.ONNXRuntimeSpec", "github.com/kserve/kserve/pkg/apis/serving/v1beta1.PMMLSpec", "github.com/kserve/kserve/pkg/apis/serving/v1beta1.PaddleServerSpec", "github.com/kserve/kserve/pkg/apis/serving/v1beta1.RetrySpec", "github.com/kserve/kserve/pkg/apis/serving/v1beta1.SKLearnSpec", "github.com/kserve/kserve/pkg/apis/serving/v1beta1.TFServingSpec", "github.com/kserve/kserve/pkg/apis/serving/v1beta1.TorchServeSpec", "github.com/kserve/kserve/pkg/apis/serving/v1beta1.TritonSpec", "github.com/kserve/kserve/pkg/apis/serving/v1beta1.XGBoostSpec", "k8s.io/api/apps/v1.DeploymentStrategy", "k8s.io/api/core/v1.Affinity", "k8s.io/api/core/v1.Container", "k8s.io/api/core/v1.EphemeralContainer", "k8s.io/api/core/v1.HostAlias", "k8s.io/api/core/v1.LocalObjectReference", "k8s.io/api/core/v1.PodDNSConfig", "k8s.io/api/core/v1.PodOS", "k8s.io/api/core/v1.PodReadinessGate", "k8s.io/api/core/v1.PodResourceClaim", "k8s.io/api/core/v1.PodSchedulingGate", "k8s.io/api/core/v1.PodSecurityContext", "k8s.io/api/core/v1.Toleration", "k8s.io/api/core/v1.TopologySpreadConstraint", "k8s.io/api/core/v1.Volume", "k8s.io/apimachinery/pkg/api/resource.Quantity"},
+	}
+}
+
+func schema_pkg_apis_serving_v1beta1_RetrySpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RetrySpec defines the retry policy of the requests to a component",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"attempts": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Attempts is the number of retries of a request.",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"perTryTimeout": {
+						SchemaProps: spec.SchemaProps{
+							Description: "PerTryTimeoutSeconds specifies the number of seconds to wait before timing out an attempt, defaults to the timeout of the component.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"retryOn": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RetryOn specifies the conditions under which a request is retried as a comma separated list, e.g. 5xx,connect-failure,refused-stream. See https://istio.io/latest/docs/reference/config/networking/virtual-service/#HTTPRetry",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"attempts"},
+			},
+		},
+	}
+}
+
+func schema_pkg_apis_serving_v1beta1_RevisionModelStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RevisionModelStatus is the state of the predictor's model on one of the revisions of a canary rollout",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name of the revision.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"state": {
+						SchemaProps: spec.SchemaProps{
+							Description: "State of the model on the revision: Pending, Loading, Loaded, FailedToLoad",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"transitionStatus": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Whether the model of the revision is loaded, or is in transition",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"lastFailureInfo": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Details of last failure, when load of the model of the revision is failed.",
+							Ref:         ref("github.com/kserve/kserve/pkg/apis/serving/v1beta1.FailureInfo"),
+						},
+					},
+					"storageUri": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Storage URI of the model downloaded by the revision.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"name", "state", "transitionStatus"},
+			},
+		},
+		Dependencies: []string{
+			"github.com/kserve/kserve/pkg/apis/serving/v1beta1.FailureInfo"},
+	}
+}
+
+func schema_pkg_apis_serving_v1beta1_RuntimeStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RuntimeStatus identifies the ServingRuntime serving the predictor's model",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name of the ServingRuntime.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"generation": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Generation of the ServingRuntime.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"inheritFrom": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name of the ClusterServingRuntime the ServingRuntime inherits from.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"inheritFromGeneration": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Generation of the ClusterServingRuntime the ServingRuntime inherits from.",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+				},
+				Required: []string{"name"},
+			},
+		},
 	}
 }
 
@@ -8327,7 +9249,6 @@ func schema_pkg_apis_serving_v1beta1_SKLearnSpec(ref common.ReferenceCallback) c
 						},
 					},
 				},
-				
 			},
 		},
 		Dependencies: []string{
@@ -8675,7 +9596,6 @@ func schema_pkg_apis_serving_v1beta1_TFServingSpec(ref common.ReferenceCallback)
 						},
 					},
 				},
-				
 			},
 		},
 		Dependencies: []string{
@@ -8974,7 +9894,6 @@ func schema_pkg_apis_serving_v1beta1_TorchServeSpec(ref common.ReferenceCallback
 						},
 					},
 				},
-				
 			},
 		},
 		Dependencies: []string{
@@ -9451,9 +10370,30 @@ func schema_pkg_apis_serving_v1beta1_TransformerSpec(ref common.ReferenceCallbac
 							Format:      "",
 						},
 					},
+					"initialScale": {
+						SchemaProps: spec.SchemaProps{
+							Description: "InitialScale is the number of replicas a new revision scales to before it is marked ready, it sets the autoscaling.knative.dev/initial-scale annotation. Only applicable for serverless mode.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"scaleDownDelay": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ScaleDownDelay is the duration the request load must stay low before the revision scales down, e.g. 15m, it sets the autoscaling.knative.dev/scale-down-delay annotation. Only applicable for serverless mode.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"scaleWindow": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ScaleWindow is the duration of the window over which the autoscaler averages the metric, e.g. 120s, it sets the autoscaling.knative.dev/window annotation. Only applicable for serverless mode.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 					"containerConcurrency": {
 						SchemaProps: spec.SchemaProps{
-							Description: "ContainerConcurrency specifies how many requests can be processed concurrently, this sets the hard limit of the container concurrency(https://knative.dev/docs/serving/autoscaling/concurrency).",
+							Description: "ContainerConcurrency specifies how many requests can be processed concurrently, this sets the hard limit of the container concurrency(https://knative.dev/docs/serving/autoscaling/concurrency) independently of the soft ScaleTarget, 0 means unlimited.",
 							Type:        []string{"integer"},
 							Format:      "int64",
 						},
@@ -9465,6 +10405,12 @@ func schema_pkg_apis_serving_v1beta1_TransformerSpec(ref common.ReferenceCallbac
 							Format:      "int64",
 						},
 					},
+					"retries": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Retries defines the retry policy of the requests routed to the component by the Istio VirtualService of the InferenceService. The routes of the Gateway API do not support retries yet.",
+							Ref:         ref("github.com/kserve/kserve/pkg/apis/serving/v1beta1.RetrySpec"),
+						},
+					},
 					"canaryTrafficPercent": {
 						SchemaProps: spec.SchemaProps{
 							Description: "CanaryTrafficPercent defines the traffic split percentage between the candidate revision and the last ready revision",
@@ -9526,7 +10472,7 @@ func schema_pkg_apis_serving_v1beta1_TransformerSpec(ref common.ReferenceCallbac
 			},
 		},
 		Dependencies: []string{
-			"github.com/kserve/kserve/pkg/apis/serving/v1beta1.Batcher", "github.com/kserve/kserve/pkg/apis/serving/v1beta1.LoggerSpec", "k8s.io/api/apps/v1.DeploymentStrategy", "k8s.io/api/core/v1.Affinity", "k8s.io/api/core/v1.Container", "k8s.io/api/core/v1.EphemeralContainer", "k8s.io/api/core/v1.HostAlias", "k8s.io/api/core/v1.LocalObjectReference", "k8s.io/api/core/v1.PodDNSConfig", "k8s.io/api/core/v1.PodOS", "k8s.io/api/core/v1.PodReadinessGate", "k8s.io/api/core/v1.PodResourceClaim", "k8s.io/api/core/v1.PodSchedulingGate", "k8s.io/api/core/v1.PodSecurityContext", "k8s.io/api/core/v1.Toleration", "k8s.io/api/core/v1.TopologySpreadConstraint", "k8s.io/api/core/v1.Volume", "k8s.io/apimachinery/pkg/api/resource.Quantity"},
+			"github.com/kserve/kserve/pkg/apis/serving/v1beta1.Batcher", "github.com/kserve/kserve/pkg/apis/serving/v1beta1.LoggerSpec", "github.com/kserve/kserve/pkg/apis/serving/v1beta1.RetrySpec", "k8s.io/api/apps/v1.DeploymentStrategy", "k8s.io/api/core/v1.Affinity", "k8s.io/api/core/v1.Container", "k8s.io/api/core/v1.EphemeralContainer", "k8s.io/api/core/v1.HostAlias", "k8s.io/api/core/v1.LocalObjectReference", "k8s.io/api/core/v1.PodDNSConfig", "k8s.io/api/core/v1.PodOS", "k8s.io/api/core/v1.PodReadinessGate", "k8s.io/api/core/v1.PodResourceClaim", "k8s.io/api/core/v1.PodSchedulingGate", "k8s.io/api/core/v1.PodSecurityContext", "k8s.io/api/core/v1.Toleration", "k8s.io/api/core/v1.TopologySpreadConstraint", "k8s.io/api/core/v1.Volume", "k8s.io/apimachinery/pkg/api/resource.Quantity"},
 	}
 }
 
@@ -9821,7 +10767,6 @@ func schema_pkg_apis_serving_v1beta1_TritonSpec(ref common.ReferenceCallback) co
 						},
 					},
 				},
-				
 			},
 		},
 		Dependencies: []string{
@@ -10120,7 +11065,6 @@ func schema_pkg_apis_serving_v1beta1_XGBoostSpec(ref common.ReferenceCallback) c
 						},
 					},
 				},
-				
 			},
 		},
 		Dependencies: []string{
@@ -18,6 +18,7 @@ package v1beta1
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/kserve/kserve/pkg/constants"
@@ -67,6 +68,81 @@ func (c *CustomPredictor) Default(config *InferenceServicesConfig) {
 	}
 	c.Containers[0].Name = constants.InferenceServiceContainerName
 	setResourceRequirementDefaults(&c.Containers[0].Resources)
+	c.defaultCollocatedTransformer()
+}
+
+// defaultCollocatedTransformer points the transformer collocated in the predictor pod to the port declared by the
+// kserve-container when its predictor host is not set
+func (c *CustomPredictor) defaultCollocatedTransformer() {
+	transformer := getContainer(c.Containers, constants.TransformerContainerName)
+	if transformer == nil || hasPredictorHost(transformer) {
+		return
+	}
+	predictor := getContainer(c.Containers, constants.InferenceServiceContainerName)
+	if predictor == nil || len(predictor.Ports) == 0 {
+		return
+	}
+	transformer.Args = append(transformer.Args, constants.ArgumentPredictorHost,
+		fmt.Sprintf("localhost:%d", predictor.Ports[0].ContainerPort))
+}
+
+// getContainer returns the container with the name, nil if there is none
+func getContainer(containers []v1.Container, name string) *v1.Container {
+	for i := range containers {
+		if containers[i].Name == name {
+			return &containers[i]
+		}
+	}
+	return nil
+}
+
+// hasPredictorHost returns whether the predictor host of the transformer container is set by argument or env var
+func hasPredictorHost(container *v1.Container) bool {
+	if utils.IncludesArg(container.Args, constants.ArgumentPredictorHost) ||
+		utils.IncludesArg(container.Command, constants.ArgumentPredictorHost) {
+		return true
+	}
+	for _, envVar := range container.Env {
+		if envVar.Name == constants.CustomSpecPredictorHostEnvVarKey {
+			return true
+		}
+	}
+	return false
+}
+
+// getListeningPorts returns the ports the container listens on: its container ports and the ports given by the
+// --http_port and --grpc_port arguments of the kserve model servers
+func getListeningPorts(container *v1.Container) []int32 {
+	ports := []int32{}
+	addPort := func(port int32) {
+		for _, p := range ports {
+			if p == port {
+				return
+			}
+		}
+		ports = append(ports, port)
+	}
+	for _, port := range container.Ports {
+		addPort(port.ContainerPort)
+	}
+	args := append(append([]string{}, container.Command...), container.Args...)
+	for i, arg := range args {
+		for _, name := range []string{constants.ArgumentHttpPort, constants.ArgumentGrpcPort} {
+			value := ""
+			switch {
+			case strings.HasPrefix(arg, name+"="):
+				value = strings.TrimPrefix(arg, name+"=")
+			case arg == name && i+1 < len(args):
+				value = args[i+1]
+			default:
+				continue
+			}
+			if port, err := strconv.ParseInt(value, 10, 32); err == nil {
+				addPort(int32(port))
+			}
+		}
+	}
+	return ports
 }
 
 func (c *CustomPredictor) GetStorageUri() *string {
@@ -156,6 +156,74 @@ func TestCustomPredictorDefaulter(t *testing.T) {
 				},
 			},
 		},
+		"CollocatedTransformerPredictorHost": {
+			spec: PredictorSpec{
+				PodSpec: PodSpec{
+					Containers: []v1.Container{
+						{
+							Name:  constants.InferenceServiceContainerName,
+							Ports: []v1.ContainerPort{{ContainerPort: 8085}},
+						},
+						{
+							Name: constants.TransformerContainerName,
+							Args: []string{"--model_name=mnist"},
+						},
+					},
+				},
+			},
+			expected: PredictorSpec{
+				PodSpec: PodSpec{
+					Containers: []v1.Container{
+						{
+							Name:  constants.InferenceServiceContainerName,
+							Ports: []v1.ContainerPort{{ContainerPort: 8085}},
+							Resources: v1.ResourceRequirements{
+								Requests: defaultResource,
+								Limits:   defaultResource,
+							},
+						},
+						{
+							Name: constants.TransformerContainerName,
+							Args: []string{"--model_name=mnist", constants.ArgumentPredictorHost, "localhost:8085"},
+						},
+					},
+				},
+			},
+		},
+		"CollocatedTransformerWithPredictorHostEnv": {
+			spec: PredictorSpec{
+				PodSpec: PodSpec{
+					Containers: []v1.Container{
+						{
+							Name:  constants.InferenceServiceContainerName,
+							Ports: []v1.ContainerPort{{ContainerPort: 8085}},
+						},
+						{
+							Name: constants.TransformerContainerName,
+							Env:  []v1.EnvVar{{Name: constants.CustomSpecPredictorHostEnvVarKey, Value: "localhost:9000"}},
+						},
+					},
+				},
+			},
+			expected: PredictorSpec{
+				PodSpec: PodSpec{
+					Containers: []v1.Container{
+						{
+							Name:  constants.InferenceServiceContainerName,
+							Ports: []v1.ContainerPort{{ContainerPort: 8085}},
+							Resources: v1.ResourceRequirements{
+								Requests: defaultResource,
+								Limits:   defaultResource,
+							},
+						},
+						{
+							Name: constants.TransformerContainerName,
+							Env:  []v1.EnvVar{{Name: constants.CustomSpecPredictorHostEnvVarKey, Value: "localhost:9000"}},
+						},
+					},
+				},
+			},
+		},
 	}
 
 	for name, scenario := range scenarios {
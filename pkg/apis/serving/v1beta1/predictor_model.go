@@ -18,13 +18,24 @@ package v1beta1
 
 import (
 	"context"
+	"fmt"
+	"path"
 	"sort"
+	"strings"
 
 	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
 	"github.com/kserve/kserve/pkg/constants"
+	"github.com/kserve/kserve/pkg/utils"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var (
+	// logger for the model predictor
+	predictorModelLogger = logf.Log.WithName("inferenceservice-v1beta1-model-predictor")
 )
 
 type ModelFormat struct {
@@ -38,6 +49,14 @@ type ModelFormat struct {
 	Version *string `json:"version,omitempty"`
 }
 
+const (
+	// ModelArtifactName is the name of the model artifact in the artifact statuses, adapters can not use it
+	ModelArtifactName = "model"
+	// MaxModelAdapterNameLength is the maximum length of the adapter names, which are used in the names of the
+	// storage initializer containers and volumes of the adapters
+	MaxModelAdapterNameLength = 36
+)
+
 type ModelSpec struct {
 	// ModelFormat being served.
 	// +required
@@ -47,9 +66,41 @@ type ModelSpec struct {
 	// +optional
 	Runtime *string `json:"runtime,omitempty"`
 
+	// Adapters downloaded next to the model, e.g. the LoRA adapters of a base model.
+	// Each adapter is downloaded by its own storage initializer with the credentials of its secret.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	Adapters []ModelAdapterSpec `json:"adapters,omitempty"`
+
 	PredictorExtensionSpec `json:",inline"`
 }
 
+// ModelAdapterSpec defines an additional model artifact downloaded next to the model of the predictor
+type ModelAdapterSpec struct {
+	// Name of the adapter, a DNS-1123 label unique among the adapters of the model.
+	// +required
+	Name string `json:"name"`
+	// This field points to the location of the adapter which is mounted onto the pod.
+	// +required
+	StorageURI string `json:"storageUri"`
+	// Path where the adapter is mounted in the model server container, a path under /mnt/models which defaults to /mnt/models/<name>.
+	// +optional
+	MountPath string `json:"mountPath,omitempty"`
+	// Name of the secret holding the credentials of the adapter storage, the credentials of the
+	// service account are used when not set.
+	// +optional
+	SecretName string `json:"secretName,omitempty"`
+}
+
+// GetMountPath returns the path where the adapter is mounted in the model server container
+func (a *ModelAdapterSpec) GetMountPath() string {
+	if a.MountPath != "" {
+		return a.MountPath
+	}
+	return path.Join(constants.DefaultModelLocalMountPath, a.Name)
+}
+
 var (
 	_ ComponentImplementation = &ModelSpec{}
 )
@@ -59,6 +110,14 @@ var (
 
 func (m *ModelSpec) Default(config *InferenceServicesConfig) {}
 
+// Validate returns an error if invalid
+func (m *ModelSpec) Validate() error {
+	return utils.FirstNonNilError([]error{
+		m.PredictorExtensionSpec.Validate(),
+		validateModelAdapters(m.Adapters, m.GetStorageUri(), m.GetStorageSpec()),
+	})
+}
+
 func (m *ModelSpec) GetContainer(metadata metav1.ObjectMeta, extensions *ComponentExtensionSpec, config *InferenceServicesConfig, predictorHost ...string) *v1.Container {
 	return &m.Container
 }
@@ -70,27 +129,52 @@ func (m *ModelSpec) GetProtocol() constants.InferenceServiceProtocol {
 	return constants.ProtocolV1
 }
 
-type stringSet map[string]struct{}
-
-func (ss stringSet) add(s string) {
-	ss[s] = struct{}{}
-}
-
-func (ss stringSet) contains(s string) bool {
-	_, found := ss[s]
-	return found
-}
-
 // GetSupportingRuntimes Get a list of ServingRuntimeSpecs that correspond to ServingRuntimes and ClusterServingRuntimes that
 // support the given model. If the `isMMS` argument is true, this function will only return ServingRuntimes that are
 // ModelMesh compatible, otherwise only single-model serving compatible runtimes will be returned.
 func (m *ModelSpec) GetSupportingRuntimes(cl client.Client, namespace string, isMMS bool) ([]v1alpha1.SupportedRuntime, error) {
+	srSpecs, _, err := m.getSupportingRuntimes(cl, namespace, isMMS)
+	return srSpecs, err
+}
+
+// SelectRuntime returns the runtime automatically selected for the model, the supporting runtime with the highest
+// priority. When no runtime supports the model, it returns nil and why each runtime of the namespace was rejected.
+// When several runtimes support the model with the same highest priority, the first one of the sorted runtimes,
+// the newest one and then the first by name, is selected and a warning is logged.
+func (m *ModelSpec) SelectRuntime(cl client.Client, namespace string, isMMS bool) (*v1alpha1.SupportedRuntime, []string, error) {
+	srSpecs, rejections, err := m.getSupportingRuntimes(cl, namespace, isMMS)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(srSpecs) == 0 {
+		return nil, rejections, nil
+	}
+	priority := m.getRuntimePriority(&srSpecs[0].Spec)
+	if priority != nil {
+		tied := []string{srSpecs[0].Name}
+		for _, srSpec := range srSpecs[1:] {
+			if p := m.getRuntimePriority(&srSpec.Spec); p != nil && *p == *priority {
+				tied = append(tied, srSpec.Name)
+			}
+		}
+		if len(tied) > 1 {
+			predictorModelLogger.Info("several runtimes support the model format with the same priority, "+
+				"set a different priority on one of them or specify the runtime", "namespace", namespace,
+				"modelFormat", m.ModelFormat.Name, "priority", *priority, "runtimes", tied, "selected", srSpecs[0].Name)
+		}
+	}
+	return &srSpecs[0], nil, nil
+}
+
+// getSupportingRuntimes returns the runtimes supporting the model sorted by priority, and why the other runtimes of
+// the namespace were rejected. The runtimes are filtered by model format and version, then by protocol version.
+func (m *ModelSpec) getSupportingRuntimes(cl client.Client, namespace string, isMMS bool) ([]v1alpha1.SupportedRuntime, []string, error) {
 	modelProtocolVersion := m.GetProtocol()
 
 	// List all namespace-scoped runtimes.
 	runtimes := &v1alpha1.ServingRuntimeList{}
 	if err := cl.List(context.TODO(), runtimes, client.InNamespace(namespace)); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	// Sort namespace-scoped runtimes by created timestamp desc and name asc.
 	sortServingRuntimeList(runtimes)
@@ -105,15 +189,35 @@ func (m *ModelSpec) GetSupportingRuntimes(cl client.Client, namespace string, is
 	// sortClusterServingRuntimeList(clusterRuntimes)
 
 	srSpecs := []v1alpha1.SupportedRuntime{}
+	rejections := []string{}
 	// var clusterSrSpecs []v1alpha1.SupportedRuntime
 	for i := range runtimes.Items {
-		rt := &runtimes.Items[i]
-		if !rt.Spec.IsDisabled() && rt.Spec.IsMultiModelRuntime() == isMMS &&
-			m.RuntimeSupportsModel(&rt.Spec) && rt.Spec.IsProtocolVersionSupported(modelProtocolVersion) {
-			srSpecs = append(srSpecs, v1alpha1.SupportedRuntime{Name: rt.GetName(), Spec: rt.Spec})
+		rt, err := v1alpha1.ResolveServingRuntime(cl, &runtimes.Items[i])
+		if err != nil {
+			rejections = append(rejections, fmt.Sprintf("%s cannot be resolved: %v", runtimes.Items[i].GetName(), err))
+			continue
+		}
+		var reason string
+		switch {
+		case rt.Spec.IsDisabled():
+			reason = "is disabled"
+		case rt.Spec.IsMultiModelRuntime() && !isMMS:
+			reason = "is a multi-model runtime"
+		case !rt.Spec.IsMultiModelRuntime() && isMMS:
+			reason = "is not a multi-model runtime"
+		default:
+			if _, reason = m.getSupportedModelFormat(&rt.Spec); reason == "" &&
+				!rt.Spec.IsProtocolVersionSupported(modelProtocolVersion) {
+				reason = fmt.Sprintf("does not support the protocol version %s", modelProtocolVersion)
+			}
+		}
+		if reason != "" {
+			rejections = append(rejections, rt.Name+" "+reason)
+			continue
 		}
+		srSpecs = append(srSpecs, *rt)
 	}
-	sortSupportedRuntimeByPriority(srSpecs, m.ModelFormat)
+	m.sortSupportedRuntimeByPriority(srSpecs)
 	// for i := range clusterRuntimes.Items {
 	//	crt := &clusterRuntimes.Items[i]
 	//	if !crt.Spec.IsDisabled() && crt.Spec.IsMultiModelRuntime() == isMMS &&
@@ -123,40 +227,55 @@ func (m *ModelSpec) GetSupportingRuntimes(cl client.Client, namespace string, is
 	// }
 	// sortSupportedRuntimeByPriority(clusterSrSpecs, m.ModelFormat)
 	// srSpecs = append(srSpecs, clusterSrSpecs...)
-	return srSpecs, nil
+	return srSpecs, rejections, nil
 }
 
 // RuntimeSupportsModel Check if the given runtime supports the specified model.
 func (m *ModelSpec) RuntimeSupportsModel(srSpec *v1alpha1.ServingRuntimeSpec) bool {
-	// assignment to a runtime depends on the model format labels
-	runtimeLabelSet := m.getServingRuntimeSupportedModelFormatLabelSet(srSpec.SupportedModelFormats)
-	modelLabel := m.getModelFormatLabel()
-	// if the runtime has the model's label, then it supports that model.
-	return runtimeLabelSet.contains(modelLabel)
+	supportedModelFormat, _ := m.getSupportedModelFormat(srSpec)
+	return supportedModelFormat != nil
 }
 
-func (m *ModelSpec) getModelFormatLabel() string {
-	mt := m.ModelFormat
-	if mt.Version != nil {
-		return "mt:" + mt.Name + ":" + *mt.Version
+// getSupportedModelFormat returns the model format of the runtime supporting the model, or why none does. The
+// version of the model must be in the version range of the model format of the runtime.
+func (m *ModelSpec) getSupportedModelFormat(srSpec *v1alpha1.ServingRuntimeSpec) (*v1alpha1.SupportedModelFormat, string) {
+	reason := fmt.Sprintf("does not support the model format %s", m.ModelFormat.Name)
+	for i := range srSpec.SupportedModelFormats {
+		t := &srSpec.SupportedModelFormats[i]
+		if t.Name != m.ModelFormat.Name {
+			continue
+		}
+		// If runtime isn't explicitly set, only consider the modelFormats where AutoSelect is true.
+		if m.Runtime == nil && !t.IsAutoSelectEnabled() {
+			reason = fmt.Sprintf("does not auto select the model format %s", t.Name)
+			continue
+		}
+		if m.ModelFormat.Version == nil {
+			return t, ""
+		}
+		if t.Version == nil {
+			reason = fmt.Sprintf("does not declare the versions of the model format %s it supports", t.Name)
+			continue
+		}
+		inRange, err := modelFormatVersionInRange(*t.Version, *m.ModelFormat.Version)
+		if err != nil {
+			reason = err.Error()
+			continue
+		}
+		if inRange {
+			return t, ""
+		}
+		reason = fmt.Sprintf("does not support the version %s of the model format %s", *m.ModelFormat.Version, t.Name)
 	}
-	return "mt:" + mt.Name
+	return nil, reason
 }
 
-func (m *ModelSpec) getServingRuntimeSupportedModelFormatLabelSet(supportedModelFormats []v1alpha1.SupportedModelFormat) stringSet {
-	set := make(stringSet, 2*len(supportedModelFormats)+1)
-
-	// model format labels
-	for _, t := range supportedModelFormats {
-		// If runtime isn't explicitly set, only add labels for modelFormats where AutoSelect is true.
-		if m.Runtime != nil || (t.AutoSelect != nil && *t.AutoSelect) {
-			set.add("mt:" + t.Name)
-			if t.Version != nil {
-				set.add("mt:" + t.Name + ":" + *t.Version)
-			}
-		}
+// getRuntimePriority returns the priority of the model format of the runtime supporting the model
+func (m *ModelSpec) getRuntimePriority(srSpec *v1alpha1.ServingRuntimeSpec) *int32 {
+	if supportedModelFormat, _ := m.getSupportedModelFormat(srSpec); supportedModelFormat != nil {
+		return supportedModelFormat.Priority
 	}
-	return set
+	return nil
 }
 
 func sortServingRuntimeList(runtimes *v1alpha1.ServingRuntimeList) {
@@ -199,10 +318,10 @@ func sortServingRuntimeList(runtimes *v1alpha1.ServingRuntimeList) {
 //	})
 // }
 
-func sortSupportedRuntimeByPriority(runtimes []v1alpha1.SupportedRuntime, modelFormat ModelFormat) {
-	sort.Slice(runtimes, func(i, j int) bool {
-		p1 := runtimes[i].Spec.GetPriority(modelFormat.Name)
-		p2 := runtimes[j].Spec.GetPriority(modelFormat.Name)
+func (m *ModelSpec) sortSupportedRuntimeByPriority(runtimes []v1alpha1.SupportedRuntime) {
+	sort.SliceStable(runtimes, func(i, j int) bool {
+		p1 := m.getRuntimePriority(&runtimes[i].Spec)
+		p2 := m.getRuntimePriority(&runtimes[j].Spec)
 
 		switch {
 		case p1 == nil && p2 == nil: // if both runtimes does not specify the priority, the order is kept.
@@ -227,3 +346,48 @@ func GetProtocolVersionPriority(protocols []constants.InferenceServiceProtocol)
 	sort.Ints(protocolVersions)
 	return protocolVersions[0]
 }
+
+// validateModelAdapters validates the names and the mount paths of the adapters, which must not collide with each
+// other nor with the model mounted at /mnt/models.
+func validateModelAdapters(adapters []ModelAdapterSpec, storageURI *string, storageSpec *StorageSpec) error {
+	if len(adapters) == 0 {
+		return nil
+	}
+	if storageURI == nil && storageSpec == nil {
+		return fmt.Errorf(ModelAdaptersWithoutStorageURIError)
+	}
+	names := map[string]bool{}
+	mountPaths := []string{}
+	for _, adapter := range adapters {
+		if errs := validation.IsDNS1123Label(adapter.Name); len(errs) > 0 || len(adapter.Name) > MaxModelAdapterNameLength {
+			return fmt.Errorf(InvalidModelAdapterNameError, adapter.Name, MaxModelAdapterNameLength)
+		}
+		if adapter.Name == ModelArtifactName {
+			return fmt.Errorf(ReservedModelAdapterNameError, adapter.Name)
+		}
+		if names[adapter.Name] {
+			return fmt.Errorf(DuplicateModelAdapterNameError, adapter.Name)
+		}
+		names[adapter.Name] = true
+		if adapter.StorageURI == "" {
+			return fmt.Errorf(ModelAdapterStorageURIRequiredError, adapter.Name)
+		}
+		// each adapter is downloaded into its own volume, which must not be mounted in the volume of another adapter
+		mountPath := adapter.GetMountPath()
+		if mountPath != path.Clean(mountPath) || !isSubPath(constants.DefaultModelLocalMountPath, mountPath) {
+			return fmt.Errorf(InvalidModelAdapterMountPathError, mountPath, adapter.Name)
+		}
+		for _, other := range mountPaths {
+			if mountPath == other || isSubPath(other, mountPath) || isSubPath(mountPath, other) {
+				return fmt.Errorf(InvalidModelAdapterMountPathError, mountPath, adapter.Name)
+			}
+		}
+		mountPaths = append(mountPaths, mountPath)
+	}
+	return nil
+}
+
+// isSubPath returns whether the clean path is below the directory
+func isSubPath(dir string, cleanPath string) bool {
+	return strings.HasPrefix(cleanPath, dir+"/")
+}
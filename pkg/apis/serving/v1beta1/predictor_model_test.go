@@ -17,7 +17,9 @@ limitations under the License.
 package v1beta1
 
 import (
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
 	"github.com/kserve/kserve/pkg/constants"
@@ -443,6 +445,213 @@ func TestGetSupportingRuntimes(t *testing.T) {
 
 }
 
+func TestModelFormatVersionInRange(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	scenarios := map[string]struct {
+		versionRange string
+		version      string
+		expected     bool
+		errMatcher   types.GomegaMatcher
+	}{
+		"SameMajor":                {versionRange: "1", version: "1", expected: true, errMatcher: gomega.BeNil()},
+		"PrefixMatchesMinor":       {versionRange: "1", version: "1.4.2", expected: true, errMatcher: gomega.BeNil()},
+		"PrefixRejectsOtherMajor":  {versionRange: "1", version: "0.24", expected: false, errMatcher: gomega.BeNil()},
+		"PrefixRejectsLessPrecise": {versionRange: "1.2", version: "1", expected: false, errMatcher: gomega.BeNil()},
+		"Equal":                    {versionRange: "=1.2", version: "1.2.0", expected: true, errMatcher: gomega.BeNil()},
+		"NotEqual":                 {versionRange: "!=1.2", version: "1.2.0", expected: false, errMatcher: gomega.BeNil()},
+		"InClosedRange":            {versionRange: ">=1.2, <2", version: "1.3", expected: true, errMatcher: gomega.BeNil()},
+		"BelowClosedRange":         {versionRange: ">=1.2 <2", version: "1.1.9", expected: false, errMatcher: gomega.BeNil()},
+		"AboveClosedRange":         {versionRange: ">1.2 <=2", version: "2.0.1", expected: false, errMatcher: gomega.BeNil()},
+		"Caret":                    {versionRange: "^1.2", version: "1.9", expected: true, errMatcher: gomega.BeNil()},
+		"CaretNextMajor":           {versionRange: "^1.2", version: "2", expected: false, errMatcher: gomega.BeNil()},
+		"CaretZeroMajor":           {versionRange: "^0.24", version: "0.25", expected: false, errMatcher: gomega.BeNil()},
+		"Tilde":                    {versionRange: "~1.2", version: "1.2.7", expected: true, errMatcher: gomega.BeNil()},
+		"TildeNextMinor":           {versionRange: "~1.2", version: "1.3", expected: false, errMatcher: gomega.BeNil()},
+		"SecondAlternative":        {versionRange: "0 || >=1.2 <2", version: "1.5", expected: true, errMatcher: gomega.BeNil()},
+		"NoAlternative":            {versionRange: "0 || >=1.2 <2", version: "2", expected: false, errMatcher: gomega.BeNil()},
+		"VersionPrefix":            {versionRange: ">=v1", version: "v1.1", expected: true, errMatcher: gomega.BeNil()},
+		"NonNumericSameString":     {versionRange: "2023a", version: "2023a", expected: true, errMatcher: gomega.BeNil()},
+		"NonNumericVersion":        {versionRange: ">=1", version: "latest", expected: false, errMatcher: gomega.HaveOccurred()},
+		"UnknownOperator":          {versionRange: "=>1", version: "1", expected: false, errMatcher: gomega.HaveOccurred()},
+		"EmptyAlternative":         {versionRange: "1 ||", version: "2", expected: false, errMatcher: gomega.HaveOccurred()},
+		"TooManyNumbers":           {versionRange: "1.2.3.4", version: "1", expected: false, errMatcher: gomega.HaveOccurred()},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			res, err := modelFormatVersionInRange(scenario.versionRange, scenario.version)
+			g.Expect(err).To(scenario.errMatcher)
+			g.Expect(res).To(gomega.Equal(scenario.expected))
+		})
+	}
+}
+
+func TestSelectRuntime(t *testing.T) {
+	namespace := "default"
+	makeRuntime := func(name string, protocols []constants.InferenceServiceProtocol, formats ...v1alpha1.SupportedModelFormat) v1alpha1.ServingRuntime {
+		return v1alpha1.ServingRuntime{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec: v1alpha1.ServingRuntimeSpec{
+				SupportedModelFormats: formats,
+				ProtocolVersions:      protocols,
+				ServingRuntimePodSpec: v1alpha1.ServingRuntimePodSpec{
+					Containers: []v1.Container{{Name: constants.InferenceServiceContainerName, Image: name + "-image:latest"}},
+				},
+			},
+		}
+	}
+	v1Only := []constants.InferenceServiceProtocol{constants.ProtocolV1}
+	v2Only := []constants.InferenceServiceProtocol{constants.ProtocolV2}
+	legacySklearn := makeRuntime("legacy-sklearn", v1Only, v1alpha1.SupportedModelFormat{
+		Name: "sklearn", Version: proto.String("0"), AutoSelect: proto.Bool(true), Priority: proto.Int32(3),
+	})
+	sklearn := makeRuntime("sklearn", v1Only, v1alpha1.SupportedModelFormat{
+		Name: "sklearn", Version: proto.String(">=1.0, <2"), AutoSelect: proto.Bool(true), Priority: proto.Int32(1),
+	})
+	mlserver := makeRuntime("mlserver", v2Only, v1alpha1.SupportedModelFormat{
+		Name: "sklearn", Version: proto.String("^1.2"), AutoSelect: proto.Bool(true), Priority: proto.Int32(2),
+	})
+	manual := makeRuntime("manual", v1Only, v1alpha1.SupportedModelFormat{
+		Name: "sklearn", Version: proto.String("1"), Priority: proto.Int32(5),
+	})
+	// the priority of the model format version matching the model is used
+	xgboost := makeRuntime("xgboost", v1Only,
+		v1alpha1.SupportedModelFormat{Name: "xgboost", Version: proto.String("1"), AutoSelect: proto.Bool(true), Priority: proto.Int32(1)},
+		v1alpha1.SupportedModelFormat{Name: "xgboost", Version: proto.String("2"), AutoSelect: proto.Bool(true), Priority: proto.Int32(3)},
+	)
+	xgboostNext := makeRuntime("xgboost-next", v1Only, v1alpha1.SupportedModelFormat{
+		Name: "xgboost", Version: proto.String(">=2"), AutoSelect: proto.Bool(true), Priority: proto.Int32(2),
+	})
+	tiedXGBoost := makeRuntime("tied-xgboost", v1Only, v1alpha1.SupportedModelFormat{
+		Name: "xgboost", Version: proto.String("1.7"), AutoSelect: proto.Bool(true), Priority: proto.Int32(1),
+	})
+	newerXGBoost := *xgboost.DeepCopy()
+	newerXGBoost.CreationTimestamp = metav1.NewTime(time.Now())
+
+	s := runtime.NewScheme()
+	if err := v1alpha1.AddToScheme(s); err != nil {
+		t.Fatalf("unable to add scheme : %v", err)
+	}
+	protocolV1 := constants.ProtocolV1
+	protocolV2 := constants.ProtocolV2
+	scenarios := map[string]struct {
+		runtimes   []v1alpha1.ServingRuntime
+		modelSpec  *ModelSpec
+		expected   string
+		rejections []string
+		errMatcher types.GomegaMatcher
+	}{
+		"VersionRange": {
+			runtimes:   []v1alpha1.ServingRuntime{legacySklearn, sklearn},
+			modelSpec:  &ModelSpec{ModelFormat: ModelFormat{Name: "sklearn", Version: proto.String("1.3")}},
+			expected:   "sklearn",
+			errMatcher: gomega.BeNil(),
+		},
+		"HighestPriorityWithoutVersion": {
+			runtimes:   []v1alpha1.ServingRuntime{legacySklearn, sklearn},
+			modelSpec:  &ModelSpec{ModelFormat: ModelFormat{Name: "sklearn"}},
+			expected:   "legacy-sklearn",
+			errMatcher: gomega.BeNil(),
+		},
+		"ProtocolAfterVersion": {
+			runtimes: []v1alpha1.ServingRuntime{legacySklearn, sklearn, mlserver},
+			modelSpec: &ModelSpec{
+				ModelFormat:            ModelFormat{Name: "sklearn", Version: proto.String("1.3")},
+				PredictorExtensionSpec: PredictorExtensionSpec{ProtocolVersion: &protocolV1},
+			},
+			expected:   "sklearn",
+			errMatcher: gomega.BeNil(),
+		},
+		"PriorityAfterProtocol": {
+			runtimes: []v1alpha1.ServingRuntime{legacySklearn, sklearn, mlserver},
+			modelSpec: &ModelSpec{
+				ModelFormat:            ModelFormat{Name: "sklearn", Version: proto.String("1.3")},
+				PredictorExtensionSpec: PredictorExtensionSpec{ProtocolVersion: &protocolV2},
+			},
+			expected:   "mlserver",
+			errMatcher: gomega.BeNil(),
+		},
+		"AutoSelectDisabled": {
+			runtimes:  []v1alpha1.ServingRuntime{manual},
+			modelSpec: &ModelSpec{ModelFormat: ModelFormat{Name: "sklearn", Version: proto.String("1")}},
+			rejections: []string{
+				"manual does not auto select the model format sklearn",
+			},
+			errMatcher: gomega.BeNil(),
+		},
+		"MatchingVersionPriority": {
+			runtimes:   []v1alpha1.ServingRuntime{xgboost, xgboostNext},
+			modelSpec:  &ModelSpec{ModelFormat: ModelFormat{Name: "xgboost", Version: proto.String("2.0")}},
+			expected:   "xgboost",
+			errMatcher: gomega.BeNil(),
+		},
+		"PriorityTie": {
+			runtimes:   []v1alpha1.ServingRuntime{xgboost, tiedXGBoost},
+			modelSpec:  &ModelSpec{ModelFormat: ModelFormat{Name: "xgboost", Version: proto.String("1.7.6")}},
+			expected:   "tied-xgboost",
+			errMatcher: gomega.BeNil(),
+		},
+		"PriorityTieIgnoresListOrder": {
+			runtimes:   []v1alpha1.ServingRuntime{tiedXGBoost, xgboost},
+			modelSpec:  &ModelSpec{ModelFormat: ModelFormat{Name: "xgboost", Version: proto.String("1.7.6")}},
+			expected:   "tied-xgboost",
+			errMatcher: gomega.BeNil(),
+		},
+		"PriorityTieSelectsNewest": {
+			runtimes:   []v1alpha1.ServingRuntime{newerXGBoost, tiedXGBoost},
+			modelSpec:  &ModelSpec{ModelFormat: ModelFormat{Name: "xgboost", Version: proto.String("1.7.6")}},
+			expected:   "xgboost",
+			errMatcher: gomega.BeNil(),
+		},
+		"NoPriorityTieOnOtherVersion": {
+			runtimes:   []v1alpha1.ServingRuntime{xgboost, tiedXGBoost},
+			modelSpec:  &ModelSpec{ModelFormat: ModelFormat{Name: "xgboost", Version: proto.String("1.6")}},
+			expected:   "xgboost",
+			errMatcher: gomega.BeNil(),
+		},
+		"Rejections": {
+			runtimes: []v1alpha1.ServingRuntime{legacySklearn, sklearn, mlserver, xgboost},
+			modelSpec: &ModelSpec{
+				ModelFormat:            ModelFormat{Name: "sklearn", Version: proto.String("2.1")},
+				PredictorExtensionSpec: PredictorExtensionSpec{ProtocolVersion: &protocolV2},
+			},
+			rejections: []string{
+				"legacy-sklearn does not support the version 2.1 of the model format sklearn",
+				"mlserver does not support the version 2.1 of the model format sklearn",
+				"sklearn does not support the version 2.1 of the model format sklearn",
+				"xgboost does not support the model format sklearn",
+			},
+			errMatcher: gomega.BeNil(),
+		},
+		"ProtocolRejection": {
+			runtimes: []v1alpha1.ServingRuntime{sklearn},
+			modelSpec: &ModelSpec{
+				ModelFormat:            ModelFormat{Name: "sklearn", Version: proto.String("1.3")},
+				PredictorExtensionSpec: PredictorExtensionSpec{ProtocolVersion: &protocolV2},
+			},
+			rejections: []string{"sklearn does not support the protocol version v2"},
+			errMatcher: gomega.BeNil(),
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			mockClient := fake.NewClientBuilder().WithScheme(s).
+				WithLists(&v1alpha1.ServingRuntimeList{Items: scenario.runtimes}).Build()
+			selected, rejections, err := scenario.modelSpec.SelectRuntime(mockClient, namespace, false)
+			g.Expect(err).To(scenario.errMatcher)
+			if scenario.expected == "" {
+				g.Expect(selected).To(gomega.BeNil())
+			} else {
+				g.Expect(selected).NotTo(gomega.BeNil())
+				g.Expect(selected.Name).To(gomega.Equal(scenario.expected))
+			}
+			if scenario.rejections != nil {
+				g.Expect(rejections).To(gomega.ConsistOf(scenario.rejections))
+			}
+		})
+	}
+}
+
 func TestModelPredictorGetContainer(t *testing.T) {
 	g := gomega.NewGomegaWithT(t)
 	var storageUri = "s3://test/model"
@@ -533,3 +742,150 @@ func TestModelPredictorGetProtocol(t *testing.T) {
 		})
 	}
 }
+
+func TestModelPredictorValidateAdapters(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	storageUri := "s3://models/llama"
+	scenarios := map[string]struct {
+		spec    ModelSpec
+		matcher types.GomegaMatcher
+	}{
+		"ValidAdapters": {
+			spec: ModelSpec{
+				Adapters: []ModelAdapterSpec{
+					{Name: "sql-lora", StorageURI: "s3://adapters/sql-lora", SecretName: "lora-secret"},
+					{Name: "chat-lora", StorageURI: "gs://adapters/chat-lora", MountPath: "/mnt/models/adapters/chat"},
+				},
+				PredictorExtensionSpec: PredictorExtensionSpec{StorageURI: &storageUri},
+			},
+			matcher: gomega.BeNil(),
+		},
+		"AdaptersWithoutStorageUri": {
+			spec: ModelSpec{
+				Adapters: []ModelAdapterSpec{{Name: "sql-lora", StorageURI: "s3://adapters/sql-lora"}},
+			},
+			matcher: gomega.MatchError(ModelAdaptersWithoutStorageURIError),
+		},
+		"InvalidName": {
+			spec: ModelSpec{
+				Adapters:               []ModelAdapterSpec{{Name: "SQL_LoRA", StorageURI: "s3://adapters/sql-lora"}},
+				PredictorExtensionSpec: PredictorExtensionSpec{StorageURI: &storageUri},
+			},
+			matcher: gomega.MatchError(fmt.Sprintf(InvalidModelAdapterNameError, "SQL_LoRA", MaxModelAdapterNameLength)),
+		},
+		"ReservedName": {
+			spec: ModelSpec{
+				Adapters:               []ModelAdapterSpec{{Name: ModelArtifactName, StorageURI: "s3://adapters/sql-lora"}},
+				PredictorExtensionSpec: PredictorExtensionSpec{StorageURI: &storageUri},
+			},
+			matcher: gomega.MatchError(fmt.Sprintf(ReservedModelAdapterNameError, ModelArtifactName)),
+		},
+		"DuplicateName": {
+			spec: ModelSpec{
+				Adapters: []ModelAdapterSpec{
+					{Name: "sql-lora", StorageURI: "s3://adapters/sql-lora"},
+					{Name: "sql-lora", StorageURI: "s3://adapters/sql-lora-v2"},
+				},
+				PredictorExtensionSpec: PredictorExtensionSpec{StorageURI: &storageUri},
+			},
+			matcher: gomega.MatchError(fmt.Sprintf(DuplicateModelAdapterNameError, "sql-lora")),
+		},
+		"MissingStorageUri": {
+			spec: ModelSpec{
+				Adapters:               []ModelAdapterSpec{{Name: "sql-lora"}},
+				PredictorExtensionSpec: PredictorExtensionSpec{StorageURI: &storageUri},
+			},
+			matcher: gomega.MatchError(fmt.Sprintf(ModelAdapterStorageURIRequiredError, "sql-lora")),
+		},
+		"MountPathOfTheModel": {
+			spec: ModelSpec{
+				Adapters: []ModelAdapterSpec{
+					{Name: "sql-lora", StorageURI: "s3://adapters/sql-lora", MountPath: "/mnt/models/"},
+				},
+				PredictorExtensionSpec: PredictorExtensionSpec{StorageURI: &storageUri},
+			},
+			matcher: gomega.MatchError(fmt.Sprintf(InvalidModelAdapterMountPathError, "/mnt/models/", "sql-lora")),
+		},
+		"DuplicateMountPath": {
+			spec: ModelSpec{
+				Adapters: []ModelAdapterSpec{
+					{Name: "sql-lora", StorageURI: "s3://adapters/sql-lora"},
+					{Name: "chat-lora", StorageURI: "s3://adapters/chat-lora", MountPath: "/mnt/models/sql-lora"},
+				},
+				PredictorExtensionSpec: PredictorExtensionSpec{StorageURI: &storageUri},
+			},
+			matcher: gomega.MatchError(fmt.Sprintf(InvalidModelAdapterMountPathError, "/mnt/models/sql-lora", "chat-lora")),
+		},
+		"MountPathOutsideTheModel": {
+			spec: ModelSpec{
+				Adapters: []ModelAdapterSpec{
+					{Name: "sql-lora", StorageURI: "s3://adapters/sql-lora", MountPath: "/mnt/adapters/sql"},
+				},
+				PredictorExtensionSpec: PredictorExtensionSpec{StorageURI: &storageUri},
+			},
+			matcher: gomega.MatchError(fmt.Sprintf(InvalidModelAdapterMountPathError, "/mnt/adapters/sql", "sql-lora")),
+		},
+		"RelativeMountPath": {
+			spec: ModelSpec{
+				Adapters: []ModelAdapterSpec{
+					{Name: "sql-lora", StorageURI: "s3://adapters/sql-lora", MountPath: "adapters/sql"},
+				},
+				PredictorExtensionSpec: PredictorExtensionSpec{StorageURI: &storageUri},
+			},
+			matcher: gomega.MatchError(fmt.Sprintf(InvalidModelAdapterMountPathError, "adapters/sql", "sql-lora")),
+		},
+		"MountPathNotClean": {
+			spec: ModelSpec{
+				Adapters: []ModelAdapterSpec{
+					{Name: "sql-lora", StorageURI: "s3://adapters/sql-lora", MountPath: "/mnt/models/../sql"},
+				},
+				PredictorExtensionSpec: PredictorExtensionSpec{StorageURI: &storageUri},
+			},
+			matcher: gomega.MatchError(fmt.Sprintf(InvalidModelAdapterMountPathError, "/mnt/models/../sql", "sql-lora")),
+		},
+		"MountPathTrailingSlash": {
+			spec: ModelSpec{
+				Adapters: []ModelAdapterSpec{
+					{Name: "sql-lora", StorageURI: "s3://adapters/sql-lora", MountPath: "/mnt/models/sql/"},
+				},
+				PredictorExtensionSpec: PredictorExtensionSpec{StorageURI: &storageUri},
+			},
+			matcher: gomega.MatchError(fmt.Sprintf(InvalidModelAdapterMountPathError, "/mnt/models/sql/", "sql-lora")),
+		},
+		"MountPathBesideTheModel": {
+			spec: ModelSpec{
+				Adapters: []ModelAdapterSpec{
+					{Name: "sql-lora", StorageURI: "s3://adapters/sql-lora", MountPath: "/mnt/models-sql"},
+				},
+				PredictorExtensionSpec: PredictorExtensionSpec{StorageURI: &storageUri},
+			},
+			matcher: gomega.MatchError(fmt.Sprintf(InvalidModelAdapterMountPathError, "/mnt/models-sql", "sql-lora")),
+		},
+		"NestedMountPath": {
+			spec: ModelSpec{
+				Adapters: []ModelAdapterSpec{
+					{Name: "sql-lora", StorageURI: "s3://adapters/sql-lora"},
+					{Name: "chat-lora", StorageURI: "s3://adapters/chat-lora", MountPath: "/mnt/models/sql-lora/chat"},
+				},
+				PredictorExtensionSpec: PredictorExtensionSpec{StorageURI: &storageUri},
+			},
+			matcher: gomega.MatchError(fmt.Sprintf(InvalidModelAdapterMountPathError, "/mnt/models/sql-lora/chat", "chat-lora")),
+		},
+		"ContainingMountPath": {
+			spec: ModelSpec{
+				Adapters: []ModelAdapterSpec{
+					{Name: "sql-lora", StorageURI: "s3://adapters/sql-lora", MountPath: "/mnt/models/loras/sql"},
+					{Name: "chat-lora", StorageURI: "s3://adapters/chat-lora", MountPath: "/mnt/models/loras"},
+				},
+				PredictorExtensionSpec: PredictorExtensionSpec{StorageURI: &storageUri},
+			},
+			matcher: gomega.MatchError(fmt.Sprintf(InvalidModelAdapterMountPathError, "/mnt/models/loras", "chat-lora")),
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g.Expect(scenario.spec.Validate()).To(scenario.matcher)
+		})
+	}
+}
@@ -0,0 +1,161 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// modelFormatVersion is a "major", "major.minor" or "major.minor.patch" version of a model format
+type modelFormatVersion []int
+
+func parseModelFormatVersion(version string) (modelFormatVersion, error) {
+	parts := strings.Split(strings.TrimPrefix(version, "v"), ".")
+	if len(parts) > 3 {
+		return nil, fmt.Errorf("invalid version %q: expected major, major.minor or major.minor.patch", version)
+	}
+	parsed := make(modelFormatVersion, len(parts))
+	for i, part := range parts {
+		if part == "" || strings.Trim(part, "0123456789") != "" {
+			return nil, fmt.Errorf("invalid version %q: expected major, major.minor or major.minor.patch", version)
+		}
+		number, err := strconv.Atoi(part)
+		if err != nil {
+			return nil, fmt.Errorf("invalid version %q: %w", version, err)
+		}
+		parsed[i] = number
+	}
+	return parsed, nil
+}
+
+// compare compares the versions, the missing minor and patch numbers being 0
+func (v modelFormatVersion) compare(other modelFormatVersion) int {
+	for i := 0; i < 3; i++ {
+		a, b := 0, 0
+		if i < len(v) {
+			a = v[i]
+		}
+		if i < len(other) {
+			b = other[i]
+		}
+		if a != b {
+			if a < b {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// hasPrefix returns whether the version starts with the numbers of the prefix, e.g. 1.2.3 starts with 1.2
+func (v modelFormatVersion) hasPrefix(prefix modelFormatVersion) bool {
+	if len(v) < len(prefix) {
+		return false
+	}
+	for i := range prefix {
+		if v[i] != prefix[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// bump returns the version made of the first numbers of v up to index, the last one incremented
+func (v modelFormatVersion) bump(index int) modelFormatVersion {
+	bumped := append(modelFormatVersion{}, v[:index+1]...)
+	bumped[index]++
+	return bumped
+}
+
+// modelFormatVersionInRange returns whether the version of a model format is in the version range supported by a
+// runtime. The range lists comparisons separated by spaces or commas which must all hold, alternative lists being
+// separated by "||", e.g. ">=1.2, <2 || 3". A comparison is a version preceded by one of the operators =, !=, >,
+// >=, <, <=, ^ (same major, or same minor for 0.x versions) and ~ (same minor). A version without operator is
+// matched by the versions it is a prefix of, so "1" supports the versions 1, 1.2 and 1.2.3.
+func modelFormatVersionInRange(versionRange string, version string) (bool, error) {
+	// the versions have historically been matched as strings, which keeps working for non numeric versions
+	if strings.TrimSpace(versionRange) == version {
+		return true, nil
+	}
+	parsedVersion, err := parseModelFormatVersion(version)
+	if err != nil {
+		return false, err
+	}
+	for _, alternative := range strings.Split(versionRange, "||") {
+		comparisons := strings.FieldsFunc(alternative, func(r rune) bool { return r == ' ' || r == ',' })
+		if len(comparisons) == 0 {
+			return false, fmt.Errorf("invalid version range %q: empty alternative", versionRange)
+		}
+		matches := true
+		for _, comparison := range comparisons {
+			ok, err := compareModelFormatVersion(comparison, parsedVersion)
+			if err != nil {
+				return false, fmt.Errorf("invalid version range %q: %w", versionRange, err)
+			}
+			matches = matches && ok
+		}
+		if matches {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func compareModelFormatVersion(comparison string, version modelFormatVersion) (bool, error) {
+	index := strings.IndexAny(comparison, "0123456789v")
+	if index < 0 {
+		return false, fmt.Errorf("missing version in %q", comparison)
+	}
+	operator := comparison[:index]
+	bound, err := parseModelFormatVersion(comparison[index:])
+	if err != nil {
+		return false, err
+	}
+	switch operator {
+	case "":
+		return version.hasPrefix(bound), nil
+	case "=":
+		return version.compare(bound) == 0, nil
+	case "!=":
+		return version.compare(bound) != 0, nil
+	case ">":
+		return version.compare(bound) > 0, nil
+	case ">=":
+		return version.compare(bound) >= 0, nil
+	case "<":
+		return version.compare(bound) < 0, nil
+	case "<=":
+		return version.compare(bound) <= 0, nil
+	case "^":
+		// the first non zero number is the one breaking the compatibility
+		index := len(bound) - 1
+		for i, number := range bound {
+			if number != 0 {
+				index = i
+				break
+			}
+		}
+		return version.compare(bound) >= 0 && version.compare(bound.bump(index)) < 0, nil
+	case "~":
+		return version.compare(bound) >= 0 && version.compare(bound.bump(min(1, len(bound)-1))) < 0, nil
+	default:
+		return false, fmt.Errorf("unknown operator %q in %q", operator, comparison)
+	}
+}
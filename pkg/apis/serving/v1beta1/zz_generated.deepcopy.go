@@ -25,6 +25,7 @@ import (
 	"github.com/kserve/kserve/pkg/constants"
 	"k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
@@ -47,6 +48,26 @@ func (in *ARTExplainerSpec) DeepCopy() *ARTExplainerSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ArtifactStatus) DeepCopyInto(out *ArtifactStatus) {
+	*out = *in
+	if in.FailureInfo != nil {
+		in, out := &in.FailureInfo, &out.FailureInfo
+		*out = new(FailureInfo)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ArtifactStatus.
+func (in *ArtifactStatus) DeepCopy() *ArtifactStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ArtifactStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Batcher) DeepCopyInto(out *Batcher) {
 	*out = *in
@@ -55,11 +76,21 @@ func (in *Batcher) DeepCopyInto(out *Batcher) {
 		*out = new(int)
 		**out = **in
 	}
+	if in.MaxInFlight != nil {
+		in, out := &in.MaxInFlight, &out.MaxInFlight
+		*out = new(int)
+		**out = **in
+	}
 	if in.MaxLatency != nil {
 		in, out := &in.MaxLatency, &out.MaxLatency
 		*out = new(int)
 		**out = **in
 	}
+	if in.MaxQueueSize != nil {
+		in, out := &in.MaxQueueSize, &out.MaxQueueSize
+		*out = new(int)
+		**out = **in
+	}
 	if in.Timeout != nil {
 		in, out := &in.Timeout, &out.Timeout
 		*out = new(int)
@@ -95,6 +126,21 @@ func (in *ComponentExtensionSpec) DeepCopyInto(out *ComponentExtensionSpec) {
 		*out = new(ScaleMetric)
 		**out = **in
 	}
+	if in.InitialScale != nil {
+		in, out := &in.InitialScale, &out.InitialScale
+		*out = new(int)
+		**out = **in
+	}
+	if in.ScaleDownDelay != nil {
+		in, out := &in.ScaleDownDelay, &out.ScaleDownDelay
+		*out = new(string)
+		**out = **in
+	}
+	if in.ScaleWindow != nil {
+		in, out := &in.ScaleWindow, &out.ScaleWindow
+		*out = new(string)
+		**out = **in
+	}
 	if in.ContainerConcurrency != nil {
 		in, out := &in.ContainerConcurrency, &out.ContainerConcurrency
 		*out = new(int64)
@@ -105,6 +151,11 @@ func (in *ComponentExtensionSpec) DeepCopyInto(out *ComponentExtensionSpec) {
 		*out = new(int64)
 		**out = **in
 	}
+	if in.Retries != nil {
+		in, out := &in.Retries, &out.Retries
+		*out = new(RetrySpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.CanaryTrafficPercent != nil {
 		in, out := &in.CanaryTrafficPercent, &out.CanaryTrafficPercent
 		*out = new(int64)
@@ -181,6 +232,11 @@ func (in *ComponentStatusSpec) DeepCopyInto(out *ComponentStatusSpec) {
 		*out = new(duckv1.Addressable)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.DesiredReplicas != nil {
+		in, out := &in.DesiredReplicas, &out.DesiredReplicas
+		*out = new(int32)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentStatusSpec.
@@ -425,11 +481,29 @@ func (in *InferenceServiceStatus) DeepCopyInto(out *InferenceServiceStatus) {
 		*out = new(duckv1.Addressable)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Addresses != nil {
+		in, out := &in.Addresses, &out.Addresses
+		*out = make([]duckv1.Addressable, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.URL != nil {
 		in, out := &in.URL, &out.URL
 		*out = new(apis.URL)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ExternalURLs != nil {
+		in, out := &in.ExternalURLs, &out.ExternalURLs
+		*out = make([]*apis.URL, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(apis.URL)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
 	if in.Components != nil {
 		in, out := &in.Components, &out.Components
 		*out = make(map[ComponentType]ComponentStatusSpec, len(*in))
@@ -474,6 +548,31 @@ func (in *LoggerSpec) DeepCopyInto(out *LoggerSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.SecretName != nil {
+		in, out := &in.SecretName, &out.SecretName
+		*out = new(string)
+		**out = **in
+	}
+	if in.SamplingRate != nil {
+		in, out := &in.SamplingRate, &out.SamplingRate
+		*out = new(string)
+		**out = **in
+	}
+	if in.MaxPayloadBytes != nil {
+		in, out := &in.MaxPayloadBytes, &out.MaxPayloadBytes
+		*out = new(int64)
+		**out = **in
+	}
+	if in.ContentTypeAllowList != nil {
+		in, out := &in.ContentTypeAllowList, &out.ContentTypeAllowList
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MetadataHeaders != nil {
+		in, out := &in.MetadataHeaders, &out.MetadataHeaders
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoggerSpec.
@@ -486,6 +585,21 @@ func (in *LoggerSpec) DeepCopy() *LoggerSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelAdapterSpec) DeepCopyInto(out *ModelAdapterSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelAdapterSpec.
+func (in *ModelAdapterSpec) DeepCopy() *ModelAdapterSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelAdapterSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ModelCopies) DeepCopyInto(out *ModelCopies) {
 	*out = *in
@@ -545,6 +659,11 @@ func (in *ModelSpec) DeepCopyInto(out *ModelSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.Adapters != nil {
+		in, out := &in.Adapters, &out.Adapters
+		*out = make([]ModelAdapterSpec, len(*in))
+		copy(*out, *in)
+	}
 	in.PredictorExtensionSpec.DeepCopyInto(&out.PredictorExtensionSpec)
 }
 
@@ -576,6 +695,38 @@ func (in *ModelStatus) DeepCopyInto(out *ModelStatus) {
 		*out = new(ModelCopies)
 		**out = **in
 	}
+	if in.Artifacts != nil {
+		in, out := &in.Artifacts, &out.Artifacts
+		*out = make([]ArtifactStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastDownloadDuration != nil {
+		in, out := &in.LastDownloadDuration, &out.LastDownloadDuration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Runtime != nil {
+		in, out := &in.Runtime, &out.Runtime
+		*out = new(RuntimeStatus)
+		**out = **in
+	}
+	if in.StableRevision != nil {
+		in, out := &in.StableRevision, &out.StableRevision
+		*out = new(RevisionModelStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CanaryRevision != nil {
+		in, out := &in.CanaryRevision, &out.CanaryRevision
+		*out = new(RevisionModelStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AllocatedModelMemory != nil {
+		in, out := &in.AllocatedModelMemory, &out.AllocatedModelMemory
+		x := (*in).DeepCopy()
+		*out = &x
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelStatus.
@@ -914,6 +1065,61 @@ func (in *PredictorSpec) DeepCopy() *PredictorSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RetrySpec) DeepCopyInto(out *RetrySpec) {
+	*out = *in
+	if in.PerTryTimeoutSeconds != nil {
+		in, out := &in.PerTryTimeoutSeconds, &out.PerTryTimeoutSeconds
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RetrySpec.
+func (in *RetrySpec) DeepCopy() *RetrySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RetrySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RevisionModelStatus) DeepCopyInto(out *RevisionModelStatus) {
+	*out = *in
+	if in.LastFailureInfo != nil {
+		in, out := &in.LastFailureInfo, &out.LastFailureInfo
+		*out = new(FailureInfo)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RevisionModelStatus.
+func (in *RevisionModelStatus) DeepCopy() *RevisionModelStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RevisionModelStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RuntimeStatus) DeepCopyInto(out *RuntimeStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RuntimeStatus.
+func (in *RuntimeStatus) DeepCopy() *RuntimeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RuntimeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SKLearnSpec) DeepCopyInto(out *SKLearnSpec) {
 	*out = *in
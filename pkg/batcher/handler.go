@@ -23,17 +23,21 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
-	"regexp"
+	"strconv"
+	"sync/atomic"
 	"time"
 
 	"github.com/gofrs/uuid/v5"
 	"go.uber.org/zap"
+
+	"github.com/kserve/kserve/pkg/constants"
 )
 
 const (
-	SleepTime    = time.Microsecond * 100
 	MaxBatchSize = 32
 	MaxLatency   = 5000
+	// InferenceHeaderContentLengthHeader is set by the v2 requests using the binary tensor data extension
+	InferenceHeaderContentLengthHeader = "Inference-Header-Content-Length"
 )
 
 type Request struct {
@@ -43,19 +47,29 @@ type Request struct {
 type Input struct {
 	ContextInput *context.Context
 	Path         string
-	Instances    *[]interface{}
-	ChannelOut   *chan Response
+	Protocol     constants.InferenceServiceProtocol
+	// BatchKey identifies the batch the input can be merged into
+	BatchKey   string
+	Size       int
+	Instances  *[]interface{}
+	V2Request  *InferenceRequest
+	ChannelOut *chan Response
 }
 
 type InputInfo struct {
 	ChannelOut *chan Response
 	Index      []int
+	// Id is the id of the v2 request
+	Id string
 }
 
 type Response struct {
 	Message     string        `json:"message"`
 	BatchID     string        `json:"batchId"`
 	Predictions []interface{} `json:"predictions"`
+	// StatusCode and V2Response are only set for the v2 protocol, Message then being the error response body
+	StatusCode int                `json:"-"`
+	V2Response *InferenceResponse `json:"-"`
 }
 
 type ResponseError struct {
@@ -68,46 +82,79 @@ type PredictionResponse struct {
 
 type BatcherInfo struct {
 	Path               string
+	Protocol           constants.InferenceServiceProtocol
 	BatchID            string
-	Request            *http.Request
 	Instances          []interface{}
+	V2Request          InferenceRequest
 	PredictionResponse PredictionResponse
 	ContextMap         map[*context.Context]InputInfo
 	Start              time.Time
-	Now                time.Time
 	CurrentInputLen    int
 }
 
+// GetNowTime keeps the monotonic clock reading, so that the batch latency is not affected by wall clock changes
 func GetNowTime() time.Time {
-	return time.Now().UTC()
+	return time.Now()
 }
 
 func GenerateUUID() string {
 	return uuid.Must(uuid.NewV4()).String()
 }
 
-func (batcherInfo *BatcherInfo) InitializeInfo() {
-	batcherInfo.BatchID = ""
-	batcherInfo.CurrentInputLen = 0
-	batcherInfo.Instances = make([]interface{}, 0)
-	batcherInfo.PredictionResponse = PredictionResponse{}
-	batcherInfo.ContextMap = make(map[*context.Context]InputInfo)
-	batcherInfo.Start = GetNowTime()
-	batcherInfo.Now = batcherInfo.Start
+func newBatcherInfo(path string, protocol constants.InferenceServiceProtocol) *BatcherInfo {
+	return &BatcherInfo{
+		Path:       path,
+		Protocol:   protocol,
+		Instances:  make([]interface{}, 0),
+		ContextMap: make(map[*context.Context]InputInfo),
+		Start:      GetNowTime(),
+	}
+}
+
+// add merges the input into the batch, its instances taking the next indices of the batch dimension
+func (batcherInfo *BatcherInfo) add(req Input) {
+	inputInfo := InputInfo{
+		ChannelOut: req.ChannelOut,
+		Index:      make([]int, 0, req.Size),
+	}
+	if req.Protocol == constants.ProtocolV2 {
+		mergeV2Request(&batcherInfo.V2Request, req.V2Request)
+		inputInfo.Id = req.V2Request.Id
+	} else {
+		batcherInfo.Instances = append(batcherInfo.Instances, *req.Instances...)
+	}
+	for i := 0; i < req.Size; i++ {
+		inputInfo.Index = append(inputInfo.Index, batcherInfo.CurrentInputLen+i)
+	}
+	batcherInfo.ContextMap[req.ContextInput] = inputInfo
+	batcherInfo.CurrentInputLen += req.Size
 }
 
-func (handler *BatchHandler) batchPredict() {
-	jsonStr, _ := json.Marshal(Request{
-		handler.batcherInfo.Instances,
-	})
+func (handler *BatchHandler) batchPredict(batcherInfo *BatcherInfo) {
+	var request interface{} = Request{
+		batcherInfo.Instances,
+	}
+	if batcherInfo.Protocol == constants.ProtocolV2 {
+		request = batcherInfo.V2Request
+	}
+	jsonStr, _ := json.Marshal(request)
 	reader := bytes.NewReader(jsonStr)
-	r := httptest.NewRequest("POST", handler.batcherInfo.Path, reader)
+	r := httptest.NewRequest("POST", batcherInfo.Path, reader)
+	r.Header.Set("Content-Type", "application/json")
 	rr := httptest.NewRecorder()
 	handler.next.ServeHTTP(rr, r)
+	if batcherInfo.Protocol == constants.ProtocolV2 {
+		handler.respondV2(batcherInfo, rr)
+	} else {
+		handler.respondV1(batcherInfo, rr)
+	}
+}
+
+func (handler *BatchHandler) respondV1(batcherInfo *BatcherInfo, rr *httptest.ResponseRecorder) {
 	responseBody := rr.Body.Bytes()
 	if rr.Code != http.StatusOK {
 		handler.log.Errorf("error response with code %v", rr)
-		for _, v := range handler.batcherInfo.ContextMap {
+		for _, v := range batcherInfo.ContextMap {
 			res := Response{
 				Message:     string(responseBody),
 				BatchID:     "",
@@ -116,34 +163,34 @@ func (handler *BatchHandler) batchPredict() {
 			*v.ChannelOut <- res
 		}
 	} else {
-		handler.batcherInfo.BatchID = GenerateUUID()
-		err := json.Unmarshal(responseBody, &handler.batcherInfo.PredictionResponse)
+		batcherInfo.BatchID = GenerateUUID()
+		err := json.Unmarshal(responseBody, &batcherInfo.PredictionResponse)
 		if err != nil {
-			for _, v := range handler.batcherInfo.ContextMap {
+			for _, v := range batcherInfo.ContextMap {
 				res := Response{
 					Message: err.Error(),
-					BatchID: handler.batcherInfo.BatchID,
+					BatchID: batcherInfo.BatchID,
 				}
 				*v.ChannelOut <- res
 			}
 		} else {
-			if len(handler.batcherInfo.PredictionResponse.Predictions) != len(handler.batcherInfo.Instances) {
-				for _, v := range handler.batcherInfo.ContextMap {
+			if len(batcherInfo.PredictionResponse.Predictions) != len(batcherInfo.Instances) {
+				for _, v := range batcherInfo.ContextMap {
 					res := Response{
 						Message: "size of prediction is not equal to the size of instances",
-						BatchID: handler.batcherInfo.BatchID,
+						BatchID: batcherInfo.BatchID,
 					}
 					*v.ChannelOut <- res
 				}
 			} else {
-				for _, v := range handler.batcherInfo.ContextMap {
+				for _, v := range batcherInfo.ContextMap {
 					predictions := make([]interface{}, 0)
 					for _, i := range v.Index {
-						predictions = append(predictions, handler.batcherInfo.PredictionResponse.Predictions[i])
+						predictions = append(predictions, batcherInfo.PredictionResponse.Predictions[i])
 					}
 					res := Response{
 						Message:     "",
-						BatchID:     handler.batcherInfo.BatchID,
+						BatchID:     batcherInfo.BatchID,
 						Predictions: predictions,
 					}
 					*v.ChannelOut <- res
@@ -151,50 +198,136 @@ func (handler *BatchHandler) batchPredict() {
 			}
 		}
 	}
-	handler.batcherInfo.InitializeInfo()
+}
+
+// respondV2 splits the outputs of the batch response back to the callers, the model error or the split error being
+// returned to all of them
+func (handler *BatchHandler) respondV2(batcherInfo *BatcherInfo, rr *httptest.ResponseRecorder) {
+	responseBody := rr.Body.Bytes()
+	if rr.Code != http.StatusOK {
+		handler.log.Errorf("error response with code %v", rr.Code)
+		for _, v := range batcherInfo.ContextMap {
+			*v.ChannelOut <- Response{
+				Message:    string(responseBody),
+				StatusCode: rr.Code,
+			}
+		}
+		return
+	}
+	var batchResponse InferenceResponse
+	err := decodeJSON(responseBody, &batchResponse)
+	responses := make(map[*context.Context]*InferenceResponse, len(batcherInfo.ContextMap))
+	for ctx, v := range batcherInfo.ContextMap {
+		if err != nil {
+			break
+		}
+		var response *InferenceResponse
+		response, err = splitV2Response(&batchResponse, batcherInfo.CurrentInputLen, v.Index[0], v.Index[len(v.Index)-1]+1)
+		if err == nil {
+			response.Id = v.Id
+			responses[ctx] = response
+		}
+	}
+	for ctx, v := range batcherInfo.ContextMap {
+		if err != nil {
+			errorBody, _ := json.Marshal(InferenceError{Error: err.Error()})
+			*v.ChannelOut <- Response{
+				Message:    string(errorBody),
+				StatusCode: http.StatusInternalServerError,
+			}
+			continue
+		}
+		*v.ChannelOut <- Response{
+			StatusCode: http.StatusOK,
+			V2Response: responses[ctx],
+		}
+	}
+}
+
+// flush sends the batch to the model once less than MaxInFlight batches are, the batches being independent from
+// each other
+func (handler *BatchHandler) flush(key string, batcherInfo *BatcherInfo) {
+	handler.log.Infof("batch predict with size %d %s", batcherInfo.CurrentInputLen, batcherInfo.Path)
+	delete(handler.batches, key)
+	batchSize.Observe(float64(batcherInfo.CurrentInputLen))
+	go func() {
+		if handler.inFlight != nil {
+			handler.inFlight <- struct{}{}
+			defer func() { <-handler.inFlight }()
+		}
+		handler.queued.Add(-int64(len(batcherInfo.ContextMap)))
+		handler.batchPredict(batcherInfo)
+	}()
+}
+
+// enqueue reserves a place in the queue for a request, returning false when the queue is full
+func (handler *BatchHandler) enqueue() bool {
+	depth := handler.queued.Add(1)
+	if handler.MaxQueueSize > 0 && depth > int64(handler.MaxQueueSize) {
+		handler.queued.Add(-1)
+		return false
+	}
+	queueDepth.Observe(float64(depth))
+	return true
+}
+
+// retryAfter returns the seconds the rejected requests should wait for, which is about the time to send a batch
+func (handler *BatchHandler) retryAfter() string {
+	return strconv.Itoa((handler.MaxLatency + 999) / 1000)
+}
+
+// nextDeadline returns when the oldest pending batch reaches the max latency
+func (handler *BatchHandler) nextDeadline() (time.Time, bool) {
+	var deadline time.Time
+	for _, batcherInfo := range handler.batches {
+		if deadline.IsZero() || batcherInfo.Start.Before(deadline) {
+			deadline = batcherInfo.Start
+		}
+	}
+	return deadline.Add(time.Duration(handler.MaxLatency) * time.Millisecond), !deadline.IsZero()
 }
 
 func (handler *BatchHandler) batch() {
-	handler.log.Infof("Starting batch loop maxLatency:%d, maxBatchSize:%d",
-		handler.MaxLatency, handler.MaxBatchSize)
+	handler.log.Infof("Starting batch loop maxLatency:%d, maxBatchSize:%d, maxInFlight:%d, maxQueueSize:%d",
+		handler.MaxLatency, handler.MaxBatchSize, handler.MaxInFlight, handler.MaxQueueSize)
+	maxLatency := time.Duration(handler.MaxLatency) * time.Millisecond
 	for {
+		// wait for the next request, or until the oldest batch has waited for the max latency
+		var timer *time.Timer
+		var timeout <-chan time.Time
+		if deadline, ok := handler.nextDeadline(); ok {
+			timer = time.NewTimer(time.Until(deadline))
+			timeout = timer.C
+		}
 		select {
 		case req := <-handler.channelIn:
-			if len(handler.batcherInfo.Instances) == 0 {
-				handler.batcherInfo.Start = GetNowTime()
-			}
-			handler.batcherInfo.Path = req.Path
-			handler.batcherInfo.CurrentInputLen = len(handler.batcherInfo.Instances)
-			handler.batcherInfo.Instances = append(handler.batcherInfo.Instances, *req.Instances...)
-			var index = make([]int, 0)
-			for i := 0; i < len(*req.Instances); i++ {
-				index = append(index, handler.batcherInfo.CurrentInputLen+i)
+			batcherInfo, ok := handler.batches[req.BatchKey]
+			// the batch is sent before it would exceed the max batch size
+			if ok && batcherInfo.CurrentInputLen+req.Size > handler.MaxBatchSize {
+				handler.flush(req.BatchKey, batcherInfo)
+				ok = false
 			}
-			handler.batcherInfo.ContextMap[req.ContextInput] = InputInfo{
-				req.ChannelOut,
-				index,
+			if !ok {
+				batcherInfo = newBatcherInfo(req.Path, req.Protocol)
+				handler.batches[req.BatchKey] = batcherInfo
 			}
-			handler.batcherInfo.CurrentInputLen = len(handler.batcherInfo.Instances)
-		case <-time.After(SleepTime):
+			batcherInfo.add(req)
+		case <-timeout:
 		}
-		handler.batcherInfo.Now = GetNowTime()
-		if handler.batcherInfo.CurrentInputLen >= handler.MaxBatchSize ||
-			(handler.batcherInfo.Now.Sub(handler.batcherInfo.Start).Milliseconds() >= int64(handler.MaxLatency) &&
-				handler.batcherInfo.CurrentInputLen > 0) {
-			handler.log.Infof("batch predict with size %d %s", len(handler.batcherInfo.Instances), handler.batcherInfo.Path)
-			handler.batchPredict()
+		if timer != nil {
+			timer.Stop()
+		}
+		now := GetNowTime()
+		for key, batcherInfo := range handler.batches {
+			if batcherInfo.CurrentInputLen >= handler.MaxBatchSize || now.Sub(batcherInfo.Start) >= maxLatency {
+				handler.flush(key, batcherInfo)
+			}
 		}
 	}
 }
 
 func (handler *BatchHandler) Consume() {
-	if handler.MaxBatchSize <= 0 {
-		handler.MaxBatchSize = MaxBatchSize
-	}
-	if handler.MaxLatency <= 0 {
-		handler.MaxLatency = MaxLatency
-	}
-	handler.batcherInfo.InitializeInfo()
+	handler.batches = make(map[string]*BatcherInfo)
 	handler.batch()
 }
 
@@ -204,29 +337,48 @@ type BatchHandler struct {
 	channelIn    chan Input
 	MaxBatchSize int
 	MaxLatency   int
-	batcherInfo  BatcherInfo
+	// MaxInFlight and MaxQueueSize are unlimited if not positive
+	MaxInFlight  int
+	MaxQueueSize int
+	// batches are the pending batches by batch key
+	batches map[string]*BatcherInfo
+	// inFlight holds a token per batch sent to the model
+	inFlight chan struct{}
+	// queued is the number of requests waiting to be sent to the model
+	queued atomic.Int64
 }
 
-func New(maxBatchSize int, maxLatency int, handler http.Handler, logger *zap.SugaredLogger) *BatchHandler {
-	batchHandler := BatchHandler{
+func New(maxBatchSize int, maxLatency int, maxInFlight int, maxQueueSize int, handler http.Handler,
+	logger *zap.SugaredLogger) *BatchHandler {
+	batchHandler := &BatchHandler{
 		next:         handler,
 		log:          logger,
 		channelIn:    make(chan Input),
 		MaxBatchSize: maxBatchSize,
 		MaxLatency:   maxLatency,
+		MaxInFlight:  maxInFlight,
+		MaxQueueSize: maxQueueSize,
+	}
+	if batchHandler.MaxBatchSize <= 0 {
+		batchHandler.MaxBatchSize = MaxBatchSize
+	}
+	if batchHandler.MaxLatency <= 0 {
+		batchHandler.MaxLatency = MaxLatency
+	}
+	if batchHandler.MaxInFlight > 0 {
+		batchHandler.inFlight = make(chan struct{}, batchHandler.MaxInFlight)
 	}
 	go batchHandler.Consume()
-	return &batchHandler
+	return batchHandler
 }
 
 func (handler *BatchHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	// only batch predict requests
-	var predictVerb = regexp.MustCompile(`:predict$`)
-	if !predictVerb.MatchString(r.URL.Path) {
+	// only batch predict requests, the v2 requests with binary tensor data are not batched
+	protocol := ProtocolOf(r.URL.Path)
+	if protocol == constants.ProtocolUnknown || r.Header.Get(InferenceHeaderContentLengthHeader) != "" {
 		handler.next.ServeHTTP(w, r)
 		return
 	}
-	var req Request
 	var err error
 	// Read Payload
 	body, err := io.ReadAll(r.Body)
@@ -234,26 +386,58 @@ func (handler *BatchHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "can't read body", http.StatusBadRequest)
 		return
 	}
-	if err = json.Unmarshal(body, &req); err != nil {
-		http.Error(w, "can't Unmarshal body", http.StatusBadRequest)
-		return
+	var ctx = context.Background()
+	var chl = make(chan Response)
+	input := Input{
+		ContextInput: &ctx,
+		Path:         r.URL.Path,
+		Protocol:     protocol,
+		BatchKey:     r.URL.Path,
+		ChannelOut:   &chl,
+	}
+	if protocol == constants.ProtocolV2 {
+		var req InferenceRequest
+		if err = decodeJSON(body, &req); err != nil {
+			http.Error(w, "can't Unmarshal body", http.StatusBadRequest)
+			return
+		}
+		if input.Size, err = validateV2Request(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if input.BatchKey, err = v2BatchKey(r.URL.Path, &req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		input.V2Request = &req
+	} else {
+		var req Request
+		if err = json.Unmarshal(body, &req); err != nil {
+			http.Error(w, "can't Unmarshal body", http.StatusBadRequest)
+			return
+		}
+		if len(req.Instances) == 0 {
+			http.Error(w, "no instances in the request", http.StatusBadRequest)
+			return
+		}
+		input.Instances = &req.Instances
+		input.Size = len(req.Instances)
 	}
-	if len(req.Instances) == 0 {
-		http.Error(w, "no instances in the request", http.StatusBadRequest)
+	if !handler.enqueue() {
+		requestsRejected.Inc()
+		w.Header().Set("Retry-After", handler.retryAfter())
+		http.Error(w, "the batcher queue is full", http.StatusTooManyRequests)
 		return
 	}
 	handler.log.Infof("serving request %s", r.URL.Path)
-	var ctx = context.Background()
-	var chl = make(chan Response)
-	handler.channelIn <- Input{
-		&ctx,
-		r.URL.Path,
-		&req.Instances,
-		&chl,
-	}
+	handler.channelIn <- input
 
 	response := <-chl
 	close(chl)
+	if protocol == constants.ProtocolV2 {
+		writeV2Response(w, response)
+		return
+	}
 	rspbytes, err := json.Marshal(response)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -264,3 +448,18 @@ func (handler *BatchHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 }
+
+func writeV2Response(w http.ResponseWriter, response Response) {
+	w.Header().Set("Content-Type", "application/json")
+	if response.V2Response == nil {
+		w.WriteHeader(response.StatusCode)
+		_, _ = w.Write([]byte(response.Message))
+		return
+	}
+	rspbytes, err := json.Marshal(response.V2Response)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	_, _ = w.Write(rspbytes)
+}
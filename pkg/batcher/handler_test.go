@@ -28,7 +28,11 @@ import (
 	"net/http/httputil"
 	"net/url"
 	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
 )
 
 func serveRequest(batchHandler *BatchHandler, wg *sync.WaitGroup, index int) {
@@ -76,7 +80,7 @@ func TestBatcher(t *testing.T) {
 	logger.Infof("predictor url %s", predictorSvcUrl)
 	g.Expect(err).To(gomega.BeNil())
 	httpProxy := httputil.NewSingleHostReverseProxy(predictorSvcUrl)
-	batchHandler := New(32, 50, httpProxy, logger)
+	batchHandler := New(32, 50, 0, 0, httpProxy, logger)
 	var wg sync.WaitGroup
 	for i := 0; i < 10; i++ {
 		wg.Add(1)
@@ -116,7 +120,7 @@ func TestBatcherFail(t *testing.T) {
 	logger.Infof("predictor url %s", predictorSvcUrl)
 	g.Expect(err).To(gomega.BeNil())
 	httpProxy := httputil.NewSingleHostReverseProxy(predictorSvcUrl)
-	batchHandler := New(32, 50, httpProxy, logger)
+	batchHandler := New(32, 50, 0, 0, httpProxy, logger)
 	var wg sync.WaitGroup
 	for i := 0; i < 10; i++ {
 		wg.Add(1)
@@ -158,7 +162,7 @@ func TestBatcherDefaults(t *testing.T) {
 	logger.Infof("predictor url %s", predictorSvcUrl)
 	g.Expect(err).To(gomega.BeNil())
 	httpProxy := httputil.NewSingleHostReverseProxy(predictorSvcUrl)
-	batchHandler := New(-1, -1, httpProxy, logger)
+	batchHandler := New(-1, -1, 0, 0, httpProxy, logger)
 	var wg sync.WaitGroup
 	for i := 0; i < 10; i++ {
 		wg.Add(1)
@@ -170,3 +174,266 @@ func TestBatcherDefaults(t *testing.T) {
 	g.Expect(batchHandler.MaxBatchSize).To(gomega.Equal(MaxBatchSize))
 	g.Expect(batchHandler.MaxLatency).To(gomega.Equal(MaxLatency))
 }
+
+func serveV2Request(batchHandler *BatchHandler, id string, rows int) *httptest.ResponseRecorder {
+	data := make([]interface{}, 0, 2*rows)
+	for i := 0; i < rows; i++ {
+		data = append(data, rows, i)
+	}
+	body, _ := json.Marshal(InferenceRequest{
+		Id: id,
+		Inputs: []InferenceTensor{{
+			Name:     "input-0",
+			Shape:    []int64{int64(rows), 2},
+			Datatype: "INT32",
+			Data:     data,
+		}},
+	})
+	r := httptest.NewRequest("POST", "/v2/models/test/infer", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+	batchHandler.ServeHTTP(w, r)
+	return w
+}
+
+// Tests the v2 requests are merged along the batch dimension and their outputs are returned to each caller
+func TestBatcherV2(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	logger, _ := pkglogging.NewLogger("", "INFO")
+
+	batchSizes := make(chan int64, 10)
+	predictor := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		b, err := io.ReadAll(req.Body)
+		g.Expect(err).To(gomega.BeNil())
+		var request InferenceRequest
+		g.Expect(json.Unmarshal(b, &request)).To(gomega.Succeed())
+		batchSizes <- request.Inputs[0].Shape[0]
+		output := request.Inputs[0]
+		output.Name = "output-0"
+		responseBytes, err := json.Marshal(InferenceResponse{
+			ModelName: "test",
+			Id:        request.Id,
+			Outputs:   []InferenceTensor{output},
+		})
+		g.Expect(err).To(gomega.BeNil())
+		_, err = rw.Write(responseBytes)
+		g.Expect(err).To(gomega.BeNil())
+	}))
+	defer predictor.Close()
+	predictorSvcUrl, err := url.Parse(predictor.URL)
+	g.Expect(err).To(gomega.BeNil())
+	batchHandler := New(32, 200, 0, 0, httputil.NewSingleHostReverseProxy(predictorSvcUrl), logger)
+
+	var wg sync.WaitGroup
+	for i := 1; i <= 4; i++ {
+		wg.Add(1)
+		go func(rows int) {
+			defer wg.Done()
+			id := fmt.Sprintf("request-%d", rows)
+			w := serveV2Request(batchHandler, id, rows)
+			g.Expect(w.Code).To(gomega.Equal(http.StatusOK))
+			var response InferenceResponse
+			g.Expect(json.Unmarshal(w.Body.Bytes(), &response)).To(gomega.Succeed())
+			g.Expect(response.Id).To(gomega.Equal(id))
+			g.Expect(response.Outputs).To(gomega.HaveLen(1))
+			g.Expect(response.Outputs[0].Name).To(gomega.Equal("output-0"))
+			g.Expect(response.Outputs[0].Shape).To(gomega.Equal([]int64{int64(rows), 2}))
+			expected := make([]interface{}, 0, 2*rows)
+			for i := 0; i < rows; i++ {
+				expected = append(expected, float64(rows), float64(i))
+			}
+			g.Expect(response.Outputs[0].Data).To(gomega.Equal(expected))
+		}(i)
+	}
+	wg.Wait()
+	close(batchSizes)
+	var total int64
+	calls := 0
+	for size := range batchSizes {
+		total += size
+		calls++
+	}
+	g.Expect(total).To(gomega.Equal(int64(10)))
+	g.Expect(calls).To(gomega.BeNumerically("<", 4))
+}
+
+// Tests the error of the model call is returned to all the callers of the batch
+func TestBatcherV2Fail(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	logger, _ := pkglogging.NewLogger("", "INFO")
+
+	scenarios := map[string]struct {
+		handler    http.HandlerFunc
+		statusCode int
+		body       string
+	}{
+		"ModelError": {
+			handler: func(rw http.ResponseWriter, req *http.Request) {
+				rw.WriteHeader(http.StatusInternalServerError)
+				_, _ = rw.Write([]byte(`{"error":"model crashed"}`))
+			},
+			statusCode: http.StatusInternalServerError,
+			body:       `{"error":"model crashed"}`,
+		},
+		"OutputBatchSizeMismatch": {
+			handler: func(rw http.ResponseWriter, req *http.Request) {
+				_, _ = rw.Write([]byte(`{"model_name":"test","outputs":[` +
+					`{"name":"output-0","shape":[1],"datatype":"INT32","data":[1]}]}`))
+			},
+			statusCode: http.StatusInternalServerError,
+			body:       `{"error":"batch size 1 of output output-0 is not equal to the batch size 3 of the inputs"}`,
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			predictor := httptest.NewServer(scenario.handler)
+			defer predictor.Close()
+			predictorSvcUrl, err := url.Parse(predictor.URL)
+			g.Expect(err).To(gomega.BeNil())
+			// the batch is sent once it has 3 rows
+			batchHandler := New(3, 5000, 0, 0, httputil.NewSingleHostReverseProxy(predictorSvcUrl), logger)
+			var wg sync.WaitGroup
+			for i := 0; i < 3; i++ {
+				wg.Add(1)
+				go func(i int) {
+					defer wg.Done()
+					w := serveV2Request(batchHandler, fmt.Sprintf("request-%d", i), 1)
+					g.Expect(w.Code).To(gomega.Equal(scenario.statusCode))
+					g.Expect(w.Body.String()).To(gomega.MatchJSON(scenario.body))
+				}(i)
+			}
+			wg.Wait()
+		})
+	}
+}
+
+// Tests a lone request is sent to the model once it has waited for the max latency
+func TestBatcherMaxLatency(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	logger, _ := pkglogging.NewLogger("", "INFO")
+
+	predictor := echoPredictor(g, func() {})
+	defer predictor.Close()
+	predictorSvcUrl, err := url.Parse(predictor.URL)
+	g.Expect(err).To(gomega.BeNil())
+	batchHandler := New(32, 100, 0, 0, httputil.NewSingleHostReverseProxy(predictorSvcUrl), logger)
+
+	start := time.Now()
+	w := serveV1Request(batchHandler)
+	elapsed := time.Since(start)
+
+	var res Response
+	g.Expect(json.Unmarshal(w.Body.Bytes(), &res)).To(gomega.Succeed())
+	g.Expect(res.Predictions).To(gomega.Equal([]interface{}{[]interface{}{1.0, 2.0, 3.0}}))
+	g.Expect(elapsed).To(gomega.BeNumerically(">=", 100*time.Millisecond))
+	g.Expect(elapsed).To(gomega.BeNumerically("<", time.Second))
+}
+
+func echoPredictor(g *gomega.WithT, beforeResponse func()) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		var request Request
+		g.Expect(json.NewDecoder(req.Body).Decode(&request)).To(gomega.Succeed())
+		beforeResponse()
+		responseBytes, err := json.Marshal(PredictionResponse{Predictions: request.Instances})
+		g.Expect(err).To(gomega.BeNil())
+		_, err = rw.Write(responseBytes)
+		g.Expect(err).To(gomega.BeNil())
+	}))
+}
+
+func serveV1Request(batchHandler *BatchHandler) *httptest.ResponseRecorder {
+	r := httptest.NewRequest("POST", "/v1/models/test:predict", bytes.NewReader([]byte(`{"instances":[[1,2,3]]}`)))
+	w := httptest.NewRecorder()
+	batchHandler.ServeHTTP(w, r)
+	return w
+}
+
+// Tests the requests are rejected with 429 once MaxQueueSize requests are waiting to be sent to the model
+func TestBatcherQueueFull(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	logger, _ := pkglogging.NewLogger("", "INFO")
+
+	received := make(chan struct{}, 10)
+	release := make(chan struct{})
+	predictor := echoPredictor(g, func() {
+		received <- struct{}{}
+		<-release
+	})
+	defer predictor.Close()
+	predictorSvcUrl, err := url.Parse(predictor.URL)
+	g.Expect(err).To(gomega.BeNil())
+	// each request is a batch, and a single batch is sent to the model at a time
+	batchHandler := New(1, 2500, 1, 4, httputil.NewSingleHostReverseProxy(predictorSvcUrl), logger)
+
+	var accepted sync.WaitGroup
+	codes := make(chan int, 5)
+	serve := func() {
+		defer accepted.Done()
+		codes <- serveV1Request(batchHandler).Code
+	}
+	accepted.Add(1)
+	go serve()
+	// the first batch is in flight, the next requests wait for it
+	<-received
+	for i := 0; i < 4; i++ {
+		accepted.Add(1)
+		go serve()
+	}
+	g.Eventually(batchHandler.queued.Load).Should(gomega.Equal(int64(4)))
+
+	rejectedBefore := testutil.ToFloat64(requestsRejected)
+	var rejected sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		rejected.Add(1)
+		go func() {
+			defer rejected.Done()
+			w := serveV1Request(batchHandler)
+			g.Expect(w.Code).To(gomega.Equal(http.StatusTooManyRequests))
+			g.Expect(w.Header().Get("Retry-After")).To(gomega.Equal("3"))
+		}()
+	}
+	rejected.Wait()
+	g.Expect(testutil.ToFloat64(requestsRejected) - rejectedBefore).To(gomega.Equal(float64(10)))
+
+	close(release)
+	accepted.Wait()
+	close(codes)
+	for code := range codes {
+		g.Expect(code).To(gomega.Equal(http.StatusOK))
+	}
+	g.Expect(batchHandler.queued.Load()).To(gomega.Equal(int64(0)))
+}
+
+// Tests no more than MaxInFlight batches are sent concurrently to the model
+func TestBatcherMaxInFlight(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	logger, _ := pkglogging.NewLogger("", "INFO")
+
+	var current, peak atomic.Int64
+	predictor := echoPredictor(g, func() {
+		n := current.Add(1)
+		for p := peak.Load(); n > p && !peak.CompareAndSwap(p, n); p = peak.Load() {
+		}
+		time.Sleep(20 * time.Millisecond)
+		current.Add(-1)
+	})
+	defer predictor.Close()
+	predictorSvcUrl, err := url.Parse(predictor.URL)
+	g.Expect(err).To(gomega.BeNil())
+	batchHandler := New(1, 5000, 2, 0, httputil.NewSingleHostReverseProxy(predictorSvcUrl), logger)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			g.Expect(serveV1Request(batchHandler).Code).To(gomega.Equal(http.StatusOK))
+		}()
+	}
+	wg.Wait()
+	g.Expect(peak.Load()).To(gomega.BeNumerically("<=", 2))
+}
@@ -0,0 +1,48 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package batcher
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	queueDepth = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name:    "kserve_agent_batcher_queue_depth",
+		Help:    "Number of requests waiting to be sent to the model, observed when a request is queued",
+		Buckets: prometheus.ExponentialBuckets(1, 2, 12),
+	})
+	batchSize = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name:    "kserve_agent_batcher_batch_size",
+		Help:    "Number of instances of the batches sent to the model",
+		Buckets: prometheus.ExponentialBuckets(1, 2, 12),
+	})
+	requestsRejected = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "kserve_agent_batcher_requests_rejected_total",
+		Help: "Number of requests rejected with the 429 status code because the queue was full",
+	})
+)
+
+// RegisterMetrics registers the metrics of the batcher
+func RegisterMetrics(registerer prometheus.Registerer) error {
+	for _, collector := range []prometheus.Collector{queueDepth, batchSize, requestsRejected} {
+		if err := registerer.Register(collector); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,209 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package batcher
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+var (
+	v1PredictPath = regexp.MustCompile(`^/v1/models/[^/]+:predict$`)
+	v2InferPath   = regexp.MustCompile(`^/v2/models/[^/]+(/versions/[^/]+)?/infer$`)
+)
+
+// InferenceTensor is an input or output tensor of the open inference protocol
+type InferenceTensor struct {
+	Name       string                 `json:"name"`
+	Shape      []int64                `json:"shape"`
+	Datatype   string                 `json:"datatype"`
+	Parameters map[string]interface{} `json:"parameters,omitempty"`
+	Data       []interface{}          `json:"data"`
+}
+
+// InferenceRequest is the request body of the open inference protocol
+type InferenceRequest struct {
+	Id         string                 `json:"id,omitempty"`
+	Parameters map[string]interface{} `json:"parameters,omitempty"`
+	Inputs     []InferenceTensor      `json:"inputs"`
+	Outputs    []interface{}          `json:"outputs,omitempty"`
+}
+
+// InferenceResponse is the response body of the open inference protocol
+type InferenceResponse struct {
+	ModelName    string                 `json:"model_name"`
+	ModelVersion string                 `json:"model_version,omitempty"`
+	Id           string                 `json:"id,omitempty"`
+	Parameters   map[string]interface{} `json:"parameters,omitempty"`
+	Outputs      []InferenceTensor      `json:"outputs"`
+}
+
+// InferenceError is the error response body of the open inference protocol
+type InferenceError struct {
+	Error string `json:"error"`
+}
+
+// ProtocolOf returns the protocol of the predict path, or ProtocolUnknown when the path is not batched
+func ProtocolOf(path string) constants.InferenceServiceProtocol {
+	switch {
+	case v1PredictPath.MatchString(path):
+		return constants.ProtocolV1
+	case v2InferPath.MatchString(path):
+		return constants.ProtocolV2
+	default:
+		return constants.ProtocolUnknown
+	}
+}
+
+// decodeJSON decodes the numbers as json.Number, so that the tensor data is forwarded without losing precision
+func decodeJSON(body []byte, v interface{}) error {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	return decoder.Decode(v)
+}
+
+// batchSize returns the size of the batch dimension of the tensor, which is its first dimension
+func (t *InferenceTensor) batchSize() int64 {
+	if len(t.Shape) == 0 {
+		return 0
+	}
+	return t.Shape[0]
+}
+
+// rowLength returns the number of data elements of a row of the batch dimension, the data being either flattened
+// or nested
+func (t *InferenceTensor) rowLength() (int, error) {
+	size := t.batchSize()
+	if size <= 0 || int64(len(t.Data))%size != 0 {
+		return 0, fmt.Errorf("the data of tensor %s does not match its shape %v", t.Name, t.Shape)
+	}
+	return len(t.Data) / int(size), nil
+}
+
+// rows returns the tensor restricted to the rows [start, end) of the batch dimension
+func (t *InferenceTensor) rows(start int, end int) (InferenceTensor, error) {
+	rowLength, err := t.rowLength()
+	if err != nil {
+		return InferenceTensor{}, err
+	}
+	shape := append([]int64{int64(end - start)}, t.Shape[1:]...)
+	return InferenceTensor{
+		Name:       t.Name,
+		Shape:      shape,
+		Datatype:   t.Datatype,
+		Parameters: t.Parameters,
+		Data:       t.Data[start*rowLength : end*rowLength],
+	}, nil
+}
+
+// validateV2Request checks that all the inputs of the request have the same batch size, and returns it
+func validateV2Request(req *InferenceRequest) (int, error) {
+	if len(req.Inputs) == 0 {
+		return 0, fmt.Errorf("no inputs in the request")
+	}
+	size := req.Inputs[0].batchSize()
+	for i := range req.Inputs {
+		input := &req.Inputs[i]
+		if input.batchSize() != size {
+			return 0, fmt.Errorf("the inputs of the request have different batch sizes")
+		}
+		if _, err := input.rowLength(); err != nil {
+			return 0, err
+		}
+	}
+	return int(size), nil
+}
+
+// v2BatchKey returns the key of the batches the request can be merged into: the requests of a batch have the
+// same path, parameters, requested outputs, and input names, datatypes and shapes apart from the batch dimension
+func v2BatchKey(path string, req *InferenceRequest) (string, error) {
+	type inputSignature struct {
+		Name       string                 `json:"name"`
+		Shape      []int64                `json:"shape"`
+		Datatype   string                 `json:"datatype"`
+		Parameters map[string]interface{} `json:"parameters,omitempty"`
+	}
+	inputs := make([]inputSignature, 0, len(req.Inputs))
+	for _, input := range req.Inputs {
+		inputs = append(inputs, inputSignature{input.Name, input.Shape[1:], input.Datatype, input.Parameters})
+	}
+	sort.Slice(inputs, func(i, j int) bool {
+		return inputs[i].Name < inputs[j].Name
+	})
+	signature, err := json.Marshal(struct {
+		Parameters map[string]interface{} `json:"parameters,omitempty"`
+		Inputs     []inputSignature       `json:"inputs"`
+		Outputs    []interface{}          `json:"outputs,omitempty"`
+	}{req.Parameters, inputs, req.Outputs})
+	if err != nil {
+		return "", err
+	}
+	return path + " " + string(signature), nil
+}
+
+// mergeV2Request appends the inputs of the request to the batch request along the batch dimension
+func mergeV2Request(batch *InferenceRequest, req *InferenceRequest) {
+	if len(batch.Inputs) == 0 {
+		batch.Parameters = req.Parameters
+		batch.Outputs = req.Outputs
+		for _, input := range req.Inputs {
+			batch.Inputs = append(batch.Inputs, InferenceTensor{
+				Name:       input.Name,
+				Shape:      append([]int64{0}, input.Shape[1:]...),
+				Datatype:   input.Datatype,
+				Parameters: input.Parameters,
+				Data:       make([]interface{}, 0, len(input.Data)),
+			})
+		}
+	}
+	for i := range batch.Inputs {
+		for _, input := range req.Inputs {
+			if input.Name == batch.Inputs[i].Name {
+				batch.Inputs[i].Shape[0] += input.Shape[0]
+				batch.Inputs[i].Data = append(batch.Inputs[i].Data, input.Data...)
+			}
+		}
+	}
+}
+
+// splitV2Response returns the response of the rows [start, end) of the batch dimension of the batch response
+func splitV2Response(batch *InferenceResponse, batchSize int, start int, end int) (*InferenceResponse, error) {
+	response := &InferenceResponse{
+		ModelName:    batch.ModelName,
+		ModelVersion: batch.ModelVersion,
+		Parameters:   batch.Parameters,
+		Outputs:      make([]InferenceTensor, 0, len(batch.Outputs)),
+	}
+	for i := range batch.Outputs {
+		output := &batch.Outputs[i]
+		if output.batchSize() != int64(batchSize) {
+			return nil, fmt.Errorf("batch size %d of output %s is not equal to the batch size %d of the inputs",
+				output.batchSize(), output.Name, batchSize)
+		}
+		rows, err := output.rows(start, end)
+		if err != nil {
+			return nil, err
+		}
+		response.Outputs = append(response.Outputs, rows)
+	}
+	return response, nil
+}
@@ -0,0 +1,176 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package batcher
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/onsi/gomega"
+
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+func TestProtocolOf(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	scenarios := map[string]struct {
+		path     string
+		expected constants.InferenceServiceProtocol
+	}{
+		"V1Predict": {
+			path:     "/v1/models/sklearn:predict",
+			expected: constants.ProtocolV1,
+		},
+		"V1Explain": {
+			path:     "/v1/models/sklearn:explain",
+			expected: constants.ProtocolUnknown,
+		},
+		"V2Infer": {
+			path:     "/v2/models/sklearn/infer",
+			expected: constants.ProtocolV2,
+		},
+		"V2InferWithVersion": {
+			path:     "/v2/models/sklearn/versions/2/infer",
+			expected: constants.ProtocolV2,
+		},
+		"V2Metadata": {
+			path:     "/v2/models/sklearn",
+			expected: constants.ProtocolUnknown,
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g.Expect(ProtocolOf(scenario.path)).To(gomega.Equal(scenario.expected))
+		})
+	}
+}
+
+func v2Request(t *testing.T, body string) *InferenceRequest {
+	req := &InferenceRequest{}
+	if err := decodeJSON([]byte(body), req); err != nil {
+		t.Fatal(err)
+	}
+	return req
+}
+
+func TestV2BatchKey(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	key := func(body string) string {
+		key, err := v2BatchKey("/v2/models/m/infer", v2Request(t, body))
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+		return key
+	}
+	base := key(`{"inputs":[{"name":"a","shape":[1,2],"datatype":"FP32","data":[1,2]},` +
+		`{"name":"b","shape":[1],"datatype":"INT64","data":[1]}]}`)
+	// the batch size, the data, the id and the order of the inputs do not prevent merging
+	g.Expect(key(`{"id":"2","inputs":[{"name":"b","shape":[3],"datatype":"INT64","data":[1,2,3]},` +
+		`{"name":"a","shape":[3,2],"datatype":"FP32","data":[1,2,3,4,5,6]}]}`)).To(gomega.Equal(base))
+	g.Expect(key(`{"inputs":[{"name":"a","shape":[1,3],"datatype":"FP32","data":[1,2,3]},` +
+		`{"name":"b","shape":[1],"datatype":"INT64","data":[1]}]}`)).NotTo(gomega.Equal(base))
+	g.Expect(key(`{"inputs":[{"name":"a","shape":[1,2],"datatype":"FP64","data":[1,2]},` +
+		`{"name":"b","shape":[1],"datatype":"INT64","data":[1]}]}`)).NotTo(gomega.Equal(base))
+	g.Expect(key(`{"parameters":{"top_k":3},"inputs":[{"name":"a","shape":[1,2],"datatype":"FP32","data":[1,2]},` +
+		`{"name":"b","shape":[1],"datatype":"INT64","data":[1]}]}`)).NotTo(gomega.Equal(base))
+}
+
+func TestValidateV2Request(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	scenarios := map[string]struct {
+		body string
+		size int
+		err  bool
+	}{
+		"FlattenedData": {
+			body: `{"inputs":[{"name":"a","shape":[2,3],"datatype":"FP32","data":[1,2,3,4,5,6]}]}`,
+			size: 2,
+		},
+		"NestedData": {
+			body: `{"inputs":[{"name":"a","shape":[2,3],"datatype":"FP32","data":[[1,2,3],[4,5,6]]}]}`,
+			size: 2,
+		},
+		"NoInputs": {
+			body: `{"inputs":[]}`,
+			err:  true,
+		},
+		"NoShape": {
+			body: `{"inputs":[{"name":"a","shape":[],"datatype":"FP32","data":[1]}]}`,
+			err:  true,
+		},
+		"DataNotMatchingShape": {
+			body: `{"inputs":[{"name":"a","shape":[2,3],"datatype":"FP32","data":[1,2,3,4,5]}]}`,
+			err:  true,
+		},
+		"DifferentBatchSizes": {
+			body: `{"inputs":[{"name":"a","shape":[2],"datatype":"FP32","data":[1,2]},` +
+				`{"name":"b","shape":[1],"datatype":"FP32","data":[1]}]}`,
+			err: true,
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			size, err := validateV2Request(v2Request(t, scenario.body))
+			if scenario.err {
+				g.Expect(err).To(gomega.HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(gomega.HaveOccurred())
+				g.Expect(size).To(gomega.Equal(scenario.size))
+			}
+		})
+	}
+}
+
+func TestMergeAndSplitV2(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	requests := []*InferenceRequest{
+		v2Request(t, `{"inputs":[{"name":"a","shape":[1,2],"datatype":"INT32","data":[1,1]},`+
+			`{"name":"b","shape":[1],"datatype":"BYTES","data":["one"]}]}`),
+		v2Request(t, `{"inputs":[{"name":"b","shape":[2],"datatype":"BYTES","data":["two","three"]},`+
+			`{"name":"a","shape":[2,2],"datatype":"INT32","data":[2,2,3,3]}]}`),
+		v2Request(t, `{"inputs":[{"name":"a","shape":[1,2],"datatype":"INT32","data":[4,4]},`+
+			`{"name":"b","shape":[1],"datatype":"BYTES","data":["four"]}]}`),
+	}
+	batch := &InferenceRequest{}
+	for _, req := range requests {
+		mergeV2Request(batch, req)
+	}
+	merged, err := json.Marshal(batch)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(string(merged)).To(gomega.MatchJSON(`{"inputs":[` +
+		`{"name":"a","shape":[4,2],"datatype":"INT32","data":[1,1,2,2,3,3,4,4]},` +
+		`{"name":"b","shape":[4],"datatype":"BYTES","data":["one","two","three","four"]}]}`))
+
+	// the model echoes the inputs, the outputs are split back in the order of the requests
+	batchResponse := &InferenceResponse{ModelName: "m", Outputs: batch.Inputs}
+	expected := []string{
+		`{"model_name":"m","outputs":[{"name":"a","shape":[1,2],"datatype":"INT32","data":[1,1]},` +
+			`{"name":"b","shape":[1],"datatype":"BYTES","data":["one"]}]}`,
+		`{"model_name":"m","outputs":[{"name":"a","shape":[2,2],"datatype":"INT32","data":[2,2,3,3]},` +
+			`{"name":"b","shape":[2],"datatype":"BYTES","data":["two","three"]}]}`,
+		`{"model_name":"m","outputs":[{"name":"a","shape":[1,2],"datatype":"INT32","data":[4,4]},` +
+			`{"name":"b","shape":[1],"datatype":"BYTES","data":["four"]}]}`,
+	}
+	for i, rows := range [][2]int{{0, 1}, {1, 3}, {3, 4}} {
+		response, err := splitV2Response(batchResponse, 4, rows[0], rows[1])
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+		split, err := json.Marshal(response)
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+		g.Expect(string(split)).To(gomega.MatchJSON(expected[i]))
+	}
+
+	_, err = splitV2Response(batchResponse, 5, 0, 1)
+	g.Expect(err).To(gomega.HaveOccurred())
+}
@@ -17,6 +17,8 @@ limitations under the License.
 package constants
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"os"
 	"regexp"
@@ -51,7 +53,19 @@ var (
 // InferenceGraph Constants
 const (
 	RouterHeadersPropagateEnvVar = "PROPAGATE_HEADERS"
+	RouterRedisPasswordEnvVar    = "REDIS_PASSWORD"
+	RouterGraphNameEnvVar        = "INFERENCE_GRAPH_NAME"
+	RouterGraphNamespaceEnvVar   = "INFERENCE_GRAPH_NAMESPACE"
 	InferenceGraphLabel          = "serving.kserve.io/inferencegraph"
+	RouterReadinessPath          = "/readyz"
+	RouterDefaultHttpPort        = 8080
+)
+
+// InferenceGraph router service account token Constants
+const (
+	RouterServiceAccountTokenVolumeName              = "router-service-account-tokens"
+	RouterServiceAccountTokensMountPath              = "/var/run/secrets/kserve/router"
+	RouterServiceAccountTokenExpirationSeconds int64 = 3600
 )
 
 // TrainedModel Constants
@@ -62,15 +76,24 @@ var (
 // InferenceService MultiModel Constants
 var (
 	ModelConfigFileName = "models.json"
+	// ModelConfigHashAnnotationKey is the annotation of the hash of the models of the model configmap
+	ModelConfigHashAnnotationKey = KServeAPIGroupName + "/model-config-hash"
 )
 
 // Model agent Constants
 const (
-	AgentContainerName    = "agent"
-	AgentConfigMapKeyName = "agent"
-	AgentEnableFlag       = "--enable-puller"
-	AgentConfigDirArgName = "--config-dir"
-	AgentModelDirArgName  = "--model-dir"
+	AgentContainerName         = "agent"
+	AgentConfigMapKeyName      = "agent"
+	AgentEnableFlag            = "--enable-puller"
+	AgentConfigDirArgName      = "--config-dir"
+	AgentModelDirArgName       = "--model-dir"
+	AgentVerifyChecksumFlag    = "--verify-checksum"
+	AgentReclaimModelStoreFlag = "--reclaim-model-store"
+	AgentMetricsPortArgName    = "--metrics-port"
+	// AgentComponentMetricsPortArgName makes the agent serve the metrics of the component on its metrics port
+	AgentComponentMetricsPortArgName = "--component-metrics-port"
+	AgentComponentMetricsPathArgName = "--component-metrics-path"
+	DefaultAgentMetricsPort          = "9082"
 )
 
 // InferenceService Annotations
@@ -94,21 +117,155 @@ var (
 	DefaultPrometheusPath                       = "/metrics"
 	QueueProxyAggregatePrometheusMetricsPort    = 9088
 	DefaultPodPrometheusPort                    = "9091"
-)
+	DeploymentStrategyAnnotationKey             = KServeAPIGroupName + "/deployment-strategy"
+	RollingUpdateMaxSurgeAnnotationKey          = KServeAPIGroupName + "/rolling-update-max-surge"
+	RollingUpdateMaxUnavailableAnnotationKey    = KServeAPIGroupName + "/rolling-update-max-unavailable"
+	PDBMaxUnavailableAnnotationKey              = KServeAPIGroupName + "/pdb-max-unavailable"
+	DisableDefaultTopologySpreadAnnotationKey   = KServeAPIGroupName + "/disable-default-topology-spread"
+	DeploymentMinReadySecondsAnnotationKey      = KServeAPIGroupName + "/deployment-min-ready-seconds"
+	ProgressDeadlineSecondsAnnotationKey        = KServeAPIGroupName + "/progress-deadline-seconds"
+	TerminationGracePeriodAnnotationKey         = KServeAPIGroupName + "/termination-grace-period"
+	AutomountServiceAccountTokenAnnotationKey   = KServeAPIGroupName + "/automount-service-account-token"
+	HPACustomMetricsAnnotationKey               = KServeAPIGroupName + "/hpa-custom-metrics"
+	HPAContainerMetricAnnotationKey             = KServeAPIGroupName + "/hpa-container-metric"
+	HPAScaleUpStabilizationAnnotationKey        = KServeAPIGroupName + "/hpa-scale-up-stabilization"
+	HPAScaleDownStabilizationAnnotationKey      = KServeAPIGroupName + "/hpa-scale-down-stabilization"
+	HPAScaleUpPoliciesAnnotationKey             = KServeAPIGroupName + "/hpa-scale-up-policies"
+	HPAScaleDownPoliciesAnnotationKey           = KServeAPIGroupName + "/hpa-scale-down-policies"
+	KedaTriggersAnnotationKey                   = KServeAPIGroupName + "/keda-triggers"
+	AutoscalingPausedAnnotationKey              = KServeAPIGroupName + "/autoscaling-paused"
+	RuntimePinnedAnnotationKey                  = KServeAPIGroupName + "/runtime-pinned"
+	CanaryRequireModelLoadedAnnotationKey       = KServeAPIGroupName + "/canary-require-model-loaded"
+	VPAUpdateModeAnnotationKey                  = KServeAPIGroupName + "/vpa-update-mode"
+	VPAMinAllowedAnnotationKey                  = KServeAPIGroupName + "/vpa-min-allowed"
+	VPAMaxAllowedAnnotationKey                  = KServeAPIGroupName + "/vpa-max-allowed"
+	CustomDomainAnnotationKey                   = KServeAPIGroupName + "/custom-domain"
+	CustomDomainTLSSecretAnnotationKey          = KServeAPIGroupName + "/custom-domain-tls-secret"
+	IngressGatewayAnnotationKey                 = KServeAPIGroupName + "/ingress-gateway"
+	IngressClassAnnotationKey                   = KServeAPIGroupName + "/ingress-class"
+	AdditionalHostsAnnotationKey                = KServeAPIGroupName + "/additional-hosts"
+	TLSSecretAnnotationKey                      = KServeAPIGroupName + "/tls-secret"
+	CorsAllowOriginsAnnotationKey               = KServeAPIGroupName + "/cors-allow-origins"
+	CorsAllowMethodsAnnotationKey               = KServeAPIGroupName + "/cors-allow-methods"
+	CorsAllowHeadersAnnotationKey               = KServeAPIGroupName + "/cors-allow-headers"
+	CorsMaxAgeAnnotationKey                     = KServeAPIGroupName + "/cors-max-age"
+	RouteTLSTerminationAnnotationKey            = KServeAPIGroupName + "/route-tls-termination"
+	StorageSecretNameAnnotationKey              = KServeAPIGroupName + "/storageSecretName"
+	StorageVerifyChecksumAnnotationKey          = KServeAPIGroupName + "/storage-verify-checksum"
+	StorageInitializerResourcesAnnotationKey    = KServeAPIGroupName + "/storage-initializer-resources"
+	StorageInitializerDurationAnnotationKey     = KServeAPIGroupName + "/storage-initializer-duration"
+	StoragePvcMountModeAnnotationKey            = KServeAPIGroupName + "/storage-pvc-mount-mode"
+	StoragePvcReadWriteAnnotationKey            = KServeAPIGroupName + "/storage-pvc-read-write"
+	StorageProxyEnabledAnnotationKey            = KServeAPIGroupName + "/storage-proxy-enabled"
+	ModelStoreReclaimAnnotationKey              = KServeAPIGroupName + "/model-store-reclaim"
+	DisablePodTemplateInjectionsAnnotationKey   = KServeAPIGroupName + "/disable-pod-template-injections"
+	TruncateGeneratedNamesAnnotationKey         = KServeAPIGroupName + "/truncate-generated-names"
+	ResourceProfileAnnotationKey                = KServeAPIGroupName + "/resource-profile"
+	TracingAnnotationKey                        = KServeAPIGroupName + "/tracing"
+	TracingOTLPEndpointAnnotationKey            = KServeAPIGroupName + "/tracing-otlp-endpoint"
+	TracingSamplingRatioAnnotationKey           = KServeAPIGroupName + "/tracing-sampling-ratio"
+)
+
+// DestinationRule Annotations
+var (
+	DestinationRuleMaxConnectionsAnnotationKey       = KServeAPIGroupName + "/destination-rule-max-connections"
+	DestinationRuleHTTP2MaxRequestsAnnotationKey     = KServeAPIGroupName + "/destination-rule-http2-max-requests"
+	DestinationRuleConsecutive5xxErrorsAnnotationKey = KServeAPIGroupName + "/destination-rule-consecutive-5xx-errors"
+	DestinationRuleBaseEjectionTimeAnnotationKey     = KServeAPIGroupName + "/destination-rule-base-ejection-time"
+)
+
+// CustomAnnotationsAnnotationKey is the comma separated list of the annotations under the KServe prefix which are not
+// known to KServe but set on purpose, so the webhooks neither reject nor warn about them
+var CustomAnnotationsAnnotationKey = KServeAPIGroupName + "/custom-annotations"
+
+// KnownAnnotationKeys is the list of the annotations under the KServe prefix KServe acts on, the webhooks check the
+// annotations of the InferenceServices and InferenceGraphs against it to catch typos
+var KnownAnnotationKeys = []string{
+	InferenceServiceGKEAcceleratorAnnotationKey,
+	DeploymentMode,
+	EnableRoutingTagAnnotationKey,
+	AutoscalerClass,
+	AutoscalerMetrics,
+	TargetUtilizationPercentage,
+	EnableMetricAggregation,
+	SetPrometheusAnnotation,
+	DeploymentStrategyAnnotationKey,
+	RollingUpdateMaxSurgeAnnotationKey,
+	RollingUpdateMaxUnavailableAnnotationKey,
+	PDBMaxUnavailableAnnotationKey,
+	DisableDefaultTopologySpreadAnnotationKey,
+	DeploymentMinReadySecondsAnnotationKey,
+	ProgressDeadlineSecondsAnnotationKey,
+	TerminationGracePeriodAnnotationKey,
+	AutomountServiceAccountTokenAnnotationKey,
+	HPACustomMetricsAnnotationKey,
+	HPAContainerMetricAnnotationKey,
+	HPAScaleUpStabilizationAnnotationKey,
+	HPAScaleDownStabilizationAnnotationKey,
+	HPAScaleUpPoliciesAnnotationKey,
+	HPAScaleDownPoliciesAnnotationKey,
+	KedaTriggersAnnotationKey,
+	AutoscalingPausedAnnotationKey,
+	RuntimePinnedAnnotationKey,
+	CanaryRequireModelLoadedAnnotationKey,
+	VPAUpdateModeAnnotationKey,
+	VPAMinAllowedAnnotationKey,
+	VPAMaxAllowedAnnotationKey,
+	CustomDomainAnnotationKey,
+	CustomDomainTLSSecretAnnotationKey,
+	IngressGatewayAnnotationKey,
+	IngressClassAnnotationKey,
+	AdditionalHostsAnnotationKey,
+	TLSSecretAnnotationKey,
+	CorsAllowOriginsAnnotationKey,
+	CorsAllowMethodsAnnotationKey,
+	CorsAllowHeadersAnnotationKey,
+	CorsMaxAgeAnnotationKey,
+	RouteTLSTerminationAnnotationKey,
+	StorageSecretNameAnnotationKey,
+	StorageVerifyChecksumAnnotationKey,
+	StorageInitializerResourcesAnnotationKey,
+	StorageInitializerDurationAnnotationKey,
+	StoragePvcMountModeAnnotationKey,
+	StoragePvcReadWriteAnnotationKey,
+	StorageProxyEnabledAnnotationKey,
+	ModelStoreReclaimAnnotationKey,
+	DisablePodTemplateInjectionsAnnotationKey,
+	TruncateGeneratedNamesAnnotationKey,
+	ResourceProfileAnnotationKey,
+	TracingAnnotationKey,
+	TracingOTLPEndpointAnnotationKey,
+	TracingSamplingRatioAnnotationKey,
+	DestinationRuleMaxConnectionsAnnotationKey,
+	DestinationRuleHTTP2MaxRequestsAnnotationKey,
+	DestinationRuleConsecutive5xxErrorsAnnotationKey,
+	DestinationRuleBaseEjectionTimeAnnotationKey,
+	IstioSidecarUIDAnnotationKey,
+	CustomAnnotationsAnnotationKey,
+}
 
 // InferenceService Internal Annotations
 var (
 	InferenceServiceInternalAnnotationsPrefix        = "internal." + KServeAPIGroupName
 	StorageInitializerSourceUriInternalAnnotationKey = InferenceServiceInternalAnnotationsPrefix + "/storage-initializer-sourceuri"
+	StorageInitializerAdaptersInternalAnnotationKey  = InferenceServiceInternalAnnotationsPrefix + "/storage-initializer-adapters"
 	StorageSpecAnnotationKey                         = InferenceServiceInternalAnnotationsPrefix + "/storage-spec"
 	StorageSpecParamAnnotationKey                    = InferenceServiceInternalAnnotationsPrefix + "/storage-spec-param"
 	StorageSpecKeyAnnotationKey                      = InferenceServiceInternalAnnotationsPrefix + "/storage-spec-key"
 	LoggerInternalAnnotationKey                      = InferenceServiceInternalAnnotationsPrefix + "/logger"
 	LoggerSinkUrlInternalAnnotationKey               = InferenceServiceInternalAnnotationsPrefix + "/logger-sink-url"
 	LoggerModeInternalAnnotationKey                  = InferenceServiceInternalAnnotationsPrefix + "/logger-mode"
+	LoggerSinkSecretInternalAnnotationKey            = InferenceServiceInternalAnnotationsPrefix + "/logger-sink-secret"
+	LoggerSamplingRateInternalAnnotationKey          = InferenceServiceInternalAnnotationsPrefix + "/logger-sampling-rate"
+	LoggerMaxPayloadBytesInternalAnnotationKey       = InferenceServiceInternalAnnotationsPrefix + "/logger-max-payload-bytes"
+	LoggerPayloadOverflowInternalAnnotationKey       = InferenceServiceInternalAnnotationsPrefix + "/logger-payload-overflow"
+	LoggerContentTypesInternalAnnotationKey          = InferenceServiceInternalAnnotationsPrefix + "/logger-content-types"
+	LoggerMetadataHeadersInternalAnnotationKey       = InferenceServiceInternalAnnotationsPrefix + "/logger-metadata-headers"
 	BatcherInternalAnnotationKey                     = InferenceServiceInternalAnnotationsPrefix + "/batcher"
 	BatcherMaxBatchSizeInternalAnnotationKey         = InferenceServiceInternalAnnotationsPrefix + "/batcher-max-batchsize"
 	BatcherMaxLatencyInternalAnnotationKey           = InferenceServiceInternalAnnotationsPrefix + "/batcher-max-latency"
+	BatcherMaxInFlightInternalAnnotationKey          = InferenceServiceInternalAnnotationsPrefix + "/batcher-max-inflight"
+	BatcherMaxQueueSizeInternalAnnotationKey         = InferenceServiceInternalAnnotationsPrefix + "/batcher-max-queue-size"
 	AgentShouldInjectAnnotationKey                   = InferenceServiceInternalAnnotationsPrefix + "/agent"
 	AgentModelConfigVolumeNameAnnotationKey          = InferenceServiceInternalAnnotationsPrefix + "/configVolumeName"
 	AgentModelConfigMountPathAnnotationKey           = InferenceServiceInternalAnnotationsPrefix + "/configMountPath"
@@ -122,6 +279,14 @@ const (
 	NetworkVisibility      = "networking.kserve.io/visibility"
 	ClusterLocalVisibility = "cluster-local"
 	ClusterLocalDomain     = "svc.cluster.local"
+	// CertManagerClusterIssuerAnnotationKey makes cert-manager issue the certificate of the TLS secrets of an Ingress
+	CertManagerClusterIssuerAnnotationKey = "cert-manager.io/cluster-issuer"
+)
+
+// OpenShift Route TLS terminations of the route-tls-termination annotation
+const (
+	RouteTLSTerminationEdge      = "edge"
+	RouteTLSTerminationReencrypt = "reencrypt"
 )
 
 // StorageSpec Constants
@@ -135,6 +300,8 @@ var (
 	ControllerLabelName             = KServeName + "-controller-manager"
 	DefaultIstioSidecarUID          = int64(1337)
 	DefaultMinReplicas              = 1
+	DefaultPDBMaxUnavailable        = 1
+	DefaultProgressDeadlineSeconds  = int32(600)
 	IstioInitContainerName          = "istio-init"
 	IstioInterceptModeRedirect      = "REDIRECT"
 	IstioInterceptionModeAnnotation = "sidecar.istio.io/interceptionMode"
@@ -160,6 +327,27 @@ var (
 var (
 	AutoscalerClassHPA      AutoscalerClassType = "hpa"
 	AutoscalerClassExternal AutoscalerClassType = "external"
+	AutoscalerClassKeda     AutoscalerClassType = "keda"
+)
+
+// VerticalPodAutoscaler update modes
+const (
+	VPAUpdateModeOff      = "Off"
+	VPAUpdateModeInitial  = "Initial"
+	VPAUpdateModeRecreate = "Recreate"
+	VPAUpdateModeAuto     = "Auto"
+)
+
+// VPAUpdateModes is the list of the supported VerticalPodAutoscaler update modes
+var VPAUpdateModes = []string{VPAUpdateModeOff, VPAUpdateModeInitial, VPAUpdateModeRecreate, VPAUpdateModeAuto}
+
+// PVC storage mount modes
+const (
+	// PvcMountModeDirect mounts the PVC on the model server container without running the storage initializer
+	PvcMountModeDirect = "direct"
+	// PvcMountModeCopy copies the model from the PVC into the shared emptyDir with the storage initializer so that
+	// the model server container does not mount the PVC
+	PvcMountModeCopy = "copy"
 )
 
 // Autoscaler Metrics
@@ -176,6 +364,7 @@ var (
 var AutoscalerAllowedClassList = []AutoscalerClassType{
 	AutoscalerClassHPA,
 	AutoscalerClassExternal,
+	AutoscalerClassKeda,
 }
 
 // Autoscaler Metrics Allowed List
@@ -195,6 +384,12 @@ var (
 	DefaultCPUUtilization int32 = 80
 )
 
+// HPA scaling behavior limits enforced by the Kubernetes API server
+const (
+	MaxHPAStabilizationWindowSeconds = 3600
+	MaxHPAScalingPolicyPeriodSeconds = 1800
+)
+
 // Webhook Constants
 var (
 	PodMutatorWebhookName              = KServeName + "-pod-mutator-webhook"
@@ -210,10 +405,24 @@ const (
 const (
 	CustomSpecStorageUriEnvVarKey                     = "STORAGE_URI"
 	CustomSpecProtocolEnvVarKey                       = "PROTOCOL"
+	CustomSpecPredictorHostEnvVarKey                  = "PREDICTOR_HOST"
 	CustomSpecMultiModelServerEnvVarKey               = "MULTI_MODEL_SERVER"
 	KServeContainerPrometheusMetricsPortEnvVarKey     = "KSERVE_CONTAINER_PROMETHEUS_METRICS_PORT"
 	KServeContainerPrometheusMetricsPathEnvVarKey     = "KSERVE_CONTAINER_PROMETHEUS_METRICS_PATH"
 	QueueProxyAggregatePrometheusMetricsPortEnvVarKey = "AGGREGATE_PROMETHEUS_METRICS_PORT"
+	AgentPrometheusMetricsPortEnvVarKey               = "AGENT_PROMETHEUS_METRICS_PORT"
+	StorageVerifyChecksumEnvVarKey                    = "STORAGE_VERIFY_CHECKSUM"
+	StorageLocalCopyEnvVarKey                         = "STORAGE_LOCAL_COPY"
+	HTTPProxyEnvVarKey                                = "HTTP_PROXY"
+	HTTPSProxyEnvVarKey                               = "HTTPS_PROXY"
+	NoProxyEnvVarKey                                  = "NO_PROXY"
+	OtelExporterOTLPEndpointEnvVarKey                 = "OTEL_EXPORTER_OTLP_ENDPOINT"
+	OtelExporterOTLPProtocolEnvVarKey                 = "OTEL_EXPORTER_OTLP_PROTOCOL"
+	OtelTracesSamplerEnvVarKey                        = "OTEL_TRACES_SAMPLER"
+	OtelTracesSamplerArgEnvVarKey                     = "OTEL_TRACES_SAMPLER_ARG"
+	OtelServiceNameEnvVarKey                          = "OTEL_SERVICE_NAME"
+	OtelResourceAttributesEnvVarKey                   = "OTEL_RESOURCE_ATTRIBUTES"
+	OtelPropagatorsEnvVarKey                          = "OTEL_PROPAGATORS"
 )
 
 type InferenceServiceComponent string
@@ -233,6 +442,14 @@ var (
 	LocalGatewayHost = "knative-local-gateway.istio-system.svc." + network.GetClusterDomainName()
 )
 
+// Activator Constants
+var (
+	// ActivatorServiceName is the service of the activator, the requests sent to a raw deployment component scaled to
+	// zero are routed to it while the component is scaled back up
+	ActivatorServiceName = KServeName + "-activator"
+	ActivatorServiceHost = network.GetServiceHostname(ActivatorServiceName, KServeNamespace)
+)
+
 // InferenceService Component enums
 const (
 	Predictor   InferenceServiceComponent = "predictor"
@@ -256,6 +473,11 @@ const (
 	ProtocolVersionENV                          = "PROTOCOL_VERSION"
 )
 
+// InferenceService health endpoints
+const (
+	V2HealthReadyPath = "/v2/health/ready"
+)
+
 // InferenceService Endpoint Ports
 const (
 	InferenceServiceDefaultHttpPort     = "8080"
@@ -263,6 +485,12 @@ const (
 	InferenceServiceDefaultAgentPort    = 9081
 	CommonDefaultHttpPort               = 80
 	AggregateMetricsPortName            = "aggr-metric"
+	// HttpPortName is the name of the container port serving HTTP/1, following the knative convention
+	HttpPortName = "http1"
+	// GrpcPortName is the name of the container port serving gRPC over h2c, following the knative convention
+	GrpcPortName = "h2c"
+	// H2CAppProtocol is the appProtocol of the service port of the gRPC endpoint
+	H2CAppProtocol = "kubernetes.io/h2c"
 )
 
 // Labels to put on kservice
@@ -291,6 +519,7 @@ const (
 	ArgumentModelClassName = "--model_class_name"
 	ArgumentPredictorHost  = "--predictor_host"
 	ArgumentHttpPort       = "--http_port"
+	ArgumentGrpcPort       = "--grpc_port"
 	ArgumentWorkers        = "--workers"
 )
 
@@ -334,6 +563,8 @@ var (
 		autoscaling.MinScaleAnnotationKey,
 		autoscaling.MaxScaleAnnotationKey,
 		StorageInitializerSourceUriInternalAnnotationKey,
+		StorageInitializerAdaptersInternalAnnotationKey,
+		CanaryRequireModelLoadedAnnotationKey,
 		"kubectl.kubernetes.io/last-applied-configuration",
 	}
 
@@ -436,12 +667,22 @@ const (
 	StateReasonCompleted        = "Completed"
 	StateReasonError            = "Error"
 	StateReasonCrashLoopBackOff = "CrashLoopBackOff"
+	StateReasonImagePullBackOff = "ImagePullBackOff"
+	StateReasonErrImagePull     = "ErrImagePull"
+)
+
+// deployment condition reason
+const (
+	DeploymentReasonProgressDeadlineExceeded = "ProgressDeadlineExceeded"
 )
 
 // CRD Kinds
 const (
-	IstioVirtualServiceKind = "VirtualService"
-	KnativeServiceKind      = "Service"
+	IstioVirtualServiceKind  = "VirtualService"
+	IstioDestinationRuleKind = "DestinationRule"
+	KnativeServiceKind       = "Service"
+	GRPCRouteKind            = "GRPCRoute"
+	OpenShiftRouteKind       = "Route"
 )
 
 // GetRawServiceLabel generate native service label
@@ -521,6 +762,13 @@ func CanaryServiceName(name string, component InferenceServiceComponent) string
 	return name + "-" + component.String() + "-" + InferenceServiceCanary
 }
 
+// RouterServiceAccountTokenFileName returns the name of the file the token of the given audience is projected to
+// in the RouterServiceAccountTokensMountPath directory of the InferenceGraph router
+func RouterServiceAccountTokenFileName(audience string) string {
+	hash := sha256.Sum256([]byte(audience))
+	return "token-" + hex.EncodeToString(hash[:8])
+}
+
 func ModelConfigName(inferenceserviceName string, shardId int) string {
 	return fmt.Sprintf("modelconfig-%s-%d", inferenceserviceName, shardId)
 }
@@ -539,6 +787,14 @@ func PredictPath(name string, protocol InferenceServiceProtocol) string {
 	return path
 }
 
+// ModelReadyPath returns the path of the readiness of the model on the model server of the protocol
+func ModelReadyPath(name string, protocol InferenceServiceProtocol) string {
+	if protocol == ProtocolV2 {
+		return fmt.Sprintf("/v2/models/%s/ready", name)
+	}
+	return fmt.Sprintf("/v1/models/%s", name)
+}
+
 func ExplainPath(name string) string {
 	return fmt.Sprintf("/v1/models/%s:explain", name)
 }
@@ -0,0 +1,205 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package metrics holds the KServe specific metrics of the controllers, registered on the controller-runtime
+// registry next to the default controller-runtime metrics.
+package metrics
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// Names of the instrumented controllers
+const (
+	InferenceServiceController = "inferenceservice"
+	InferenceGraphController   = "inferencegraph"
+	TrainedModelController     = "trainedmodel"
+)
+
+// Kinds of the child resources whose drift is corrected
+const (
+	KindKnativeService          = "KnativeService"
+	KindDeployment              = "Deployment"
+	KindService                 = "Service"
+	KindHorizontalPodAutoscaler = "HorizontalPodAutoscaler"
+)
+
+// Results of a reconcile
+const (
+	ResultSuccess = "success"
+	ResultRequeue = "requeue"
+	ResultError   = "error"
+)
+
+var (
+	reconciles = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "kserve_controller_reconcile_total",
+		Help: "Number of the reconciles of each controller by result: success, requeue or error",
+	}, []string{"controller", "result"})
+
+	driftCorrections = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "kserve_controller_drift_corrections_total",
+		Help: "Number of the updates of child resources which drifted from their desired state, by kind",
+	}, []string{"kind"})
+
+	inferenceServices = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "kserve_inferenceservices",
+		Help: "Number of the InferenceServices by deployment mode and serving runtime",
+	}, []string{"deployment_mode", "runtime"})
+
+	readyDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "kserve_inferenceservice_ready_duration_seconds",
+		Help:    "Time from a change of the generation of an InferenceService to the InferenceService being Ready",
+		Buckets: prometheus.ExponentialBuckets(1, 2, 12),
+	}, []string{"deployment_mode"})
+
+	// inferenceServiceInfo has a series per InferenceService, it is only registered when enabled
+	inferenceServiceInfo = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "kserve_inferenceservice_info",
+		Help: "Deployment mode and serving runtime of each InferenceService",
+	}, []string{"namespace", "name", "deployment_mode", "runtime"})
+)
+
+// trackedInferenceService is the state of an InferenceService counted by the metrics
+type trackedInferenceService struct {
+	deploymentMode string
+	runtime        string
+	generation     int64
+	generationTime time.Time
+	readyObserved  bool
+}
+
+var tracked = struct {
+	sync.Mutex
+	inferenceServices   map[types.NamespacedName]*trackedInferenceService
+	perInferenceService bool
+}{inferenceServices: map[types.NamespacedName]*trackedInferenceService{}}
+
+// now is the clock of the metrics, replaced in tests
+var now = time.Now
+
+func init() {
+	metrics.Registry.MustRegister(reconciles, driftCorrections, inferenceServices, readyDuration)
+}
+
+// EnablePerInferenceServiceMetrics registers the metrics with a series per InferenceService. They are disabled by
+// default as their cardinality grows with the number of InferenceServices.
+func EnablePerInferenceServiceMetrics() {
+	tracked.Lock()
+	defer tracked.Unlock()
+	if !tracked.perInferenceService {
+		metrics.Registry.MustRegister(inferenceServiceInfo)
+		tracked.perInferenceService = true
+	}
+}
+
+// RecordDriftCorrection records the update of a child resource of the given kind which drifted from its desired state
+func RecordDriftCorrection(kind string) {
+	driftCorrections.WithLabelValues(kind).Inc()
+}
+
+// TrackInferenceService records the deployment mode and the serving runtime of the InferenceService, and the time
+// it took to be Ready once after each change of its generation. The first generation is timed from the creation of
+// the InferenceService, the later generations from when the controller observes them.
+func TrackInferenceService(key types.NamespacedName, deploymentMode string, runtime string, generation int64,
+	creationTime time.Time, ready bool) {
+	tracked.Lock()
+	defer tracked.Unlock()
+	isvc, ok := tracked.inferenceServices[key]
+	if !ok {
+		// An InferenceService already Ready when first tracked, e.g. after a restart of the controller, is not timed
+		isvc = &trackedInferenceService{deploymentMode: deploymentMode, runtime: runtime, generation: generation,
+			readyObserved: ready}
+		isvc.generationTime = now()
+		if generation == 1 && !creationTime.IsZero() {
+			isvc.generationTime = creationTime
+		}
+		tracked.inferenceServices[key] = isvc
+		inferenceServices.WithLabelValues(deploymentMode, runtime).Inc()
+		setInferenceServiceInfo(key, isvc)
+	} else if isvc.deploymentMode != deploymentMode || isvc.runtime != runtime {
+		inferenceServices.WithLabelValues(isvc.deploymentMode, isvc.runtime).Dec()
+		deleteInferenceServiceInfo(key, isvc)
+		isvc.deploymentMode, isvc.runtime = deploymentMode, runtime
+		inferenceServices.WithLabelValues(deploymentMode, runtime).Inc()
+		setInferenceServiceInfo(key, isvc)
+	}
+	if isvc.generation != generation {
+		isvc.generation = generation
+		isvc.generationTime = now()
+		isvc.readyObserved = false
+	}
+	if ready && !isvc.readyObserved {
+		readyDuration.WithLabelValues(deploymentMode).Observe(now().Sub(isvc.generationTime).Seconds())
+		isvc.readyObserved = true
+	}
+}
+
+// UntrackInferenceService removes the deleted InferenceService from the metrics
+func UntrackInferenceService(key types.NamespacedName) {
+	tracked.Lock()
+	defer tracked.Unlock()
+	isvc, ok := tracked.inferenceServices[key]
+	if !ok {
+		return
+	}
+	inferenceServices.WithLabelValues(isvc.deploymentMode, isvc.runtime).Dec()
+	deleteInferenceServiceInfo(key, isvc)
+	delete(tracked.inferenceServices, key)
+}
+
+func setInferenceServiceInfo(key types.NamespacedName, isvc *trackedInferenceService) {
+	if tracked.perInferenceService {
+		inferenceServiceInfo.WithLabelValues(key.Namespace, key.Name, isvc.deploymentMode, isvc.runtime).Set(1)
+	}
+}
+
+func deleteInferenceServiceInfo(key types.NamespacedName, isvc *trackedInferenceService) {
+	if tracked.perInferenceService {
+		inferenceServiceInfo.DeleteLabelValues(key.Namespace, key.Name, isvc.deploymentMode, isvc.runtime)
+	}
+}
+
+// instrumentedReconciler records the result of each reconcile of the wrapped reconciler
+type instrumentedReconciler struct {
+	controller string
+	reconciler reconcile.Reconciler
+}
+
+// InstrumentReconciler wraps the reconciler of the controller to record the result of its reconciles
+func InstrumentReconciler(controller string, reconciler reconcile.Reconciler) reconcile.Reconciler {
+	return &instrumentedReconciler{controller: controller, reconciler: reconciler}
+}
+
+func (r *instrumentedReconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	result, err := r.reconciler.Reconcile(ctx, req)
+	switch {
+	case err != nil:
+		reconciles.WithLabelValues(r.controller, ResultError).Inc()
+	case result.Requeue || result.RequeueAfter > 0:
+		reconciles.WithLabelValues(r.controller, ResultRequeue).Inc()
+	default:
+		reconciles.WithLabelValues(r.controller, ResultSuccess).Inc()
+	}
+	return result, err
+}
@@ -0,0 +1,205 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	dto "github.com/prometheus/client_model/go"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// gather scrapes the controller-runtime registry and returns the KServe metric families by name
+func gather(g *gomega.WithT) map[string]*dto.MetricFamily {
+	families, err := metrics.Registry.Gather()
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	kserveFamilies := map[string]*dto.MetricFamily{}
+	for _, family := range families {
+		if strings.HasPrefix(family.GetName(), "kserve_") {
+			kserveFamilies[family.GetName()] = family
+		}
+	}
+	return kserveFamilies
+}
+
+// findMetric returns the metric of the family with the given labels
+func findMetric(family *dto.MetricFamily, labels map[string]string) *dto.Metric {
+	if family == nil {
+		return nil
+	}
+	for _, metric := range family.GetMetric() {
+		matches := 0
+		for _, label := range metric.GetLabel() {
+			if value, ok := labels[label.GetName()]; ok && value == label.GetValue() {
+				matches++
+			}
+		}
+		if matches == len(labels) {
+			return metric
+		}
+	}
+	return nil
+}
+
+func TestInstrumentReconciler(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	results := []struct {
+		result reconcile.Result
+		err    error
+	}{
+		{result: reconcile.Result{}},
+		{result: reconcile.Result{Requeue: true}},
+		{result: reconcile.Result{RequeueAfter: time.Second}},
+		{err: errors.New("fails to reconcile component")},
+		{result: reconcile.Result{}},
+	}
+	i := 0
+	reconciler := InstrumentReconciler("test", reconcile.Func(func(context.Context, reconcile.Request) (reconcile.Result, error) {
+		result := results[i]
+		i++
+		return result.result, result.err
+	}))
+	for range results {
+		_, _ = reconciler.Reconcile(context.Background(), reconcile.Request{})
+	}
+
+	families := gather(g)
+	for result, expected := range map[string]float64{ResultSuccess: 2, ResultRequeue: 2, ResultError: 1} {
+		metric := findMetric(families["kserve_controller_reconcile_total"], map[string]string{"controller": "test", "result": result})
+		g.Expect(metric).NotTo(gomega.BeNil(), result)
+		g.Expect(metric.GetCounter().GetValue()).To(gomega.Equal(expected), result)
+	}
+}
+
+func TestRecordDriftCorrection(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	RecordDriftCorrection(KindDeployment)
+	RecordDriftCorrection(KindDeployment)
+	RecordDriftCorrection(KindKnativeService)
+
+	families := gather(g)
+	metric := findMetric(families["kserve_controller_drift_corrections_total"], map[string]string{"kind": KindDeployment})
+	g.Expect(metric.GetCounter().GetValue()).To(gomega.Equal(2.0))
+	metric = findMetric(families["kserve_controller_drift_corrections_total"], map[string]string{"kind": KindKnativeService})
+	g.Expect(metric.GetCounter().GetValue()).To(gomega.Equal(1.0))
+}
+
+func TestTrackInferenceService(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	clock := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	now = func() time.Time { return clock }
+	defer func() { now = time.Now }()
+	key := types.NamespacedName{Namespace: "default", Name: "sklearn"}
+	count := func(deploymentMode, runtime string) float64 {
+		metric := findMetric(gather(g)["kserve_inferenceservices"],
+			map[string]string{"deployment_mode": deploymentMode, "runtime": runtime})
+		return metric.GetGauge().GetValue()
+	}
+	readyDurations := func() *dto.Histogram {
+		metric := findMetric(gather(g)["kserve_inferenceservice_ready_duration_seconds"],
+			map[string]string{"deployment_mode": "TestMode"})
+		return metric.GetHistogram()
+	}
+
+	// The first generation is timed from the creation of the InferenceService
+	creationTime := clock.Add(-30 * time.Second)
+	TrackInferenceService(key, "TestMode", "kserve-sklearnserver", 1, creationTime, false)
+	g.Expect(count("TestMode", "kserve-sklearnserver")).To(gomega.Equal(1.0))
+	TrackInferenceService(key, "TestMode", "kserve-sklearnserver", 1, creationTime, true)
+	g.Expect(readyDurations().GetSampleCount()).To(gomega.Equal(uint64(1)))
+	g.Expect(readyDurations().GetSampleSum()).To(gomega.Equal(30.0))
+
+	// A reconcile of a Ready InferenceService whose generation did not change is not timed
+	TrackInferenceService(key, "TestMode", "kserve-sklearnserver", 1, creationTime, true)
+	g.Expect(readyDurations().GetSampleCount()).To(gomega.Equal(uint64(1)))
+
+	// A new generation is timed from when it is observed, and the InferenceService is counted with its new runtime
+	TrackInferenceService(key, "TestMode", "kserve-sklearnserver-v2", 2, creationTime, false)
+	g.Expect(count("TestMode", "kserve-sklearnserver")).To(gomega.Equal(0.0))
+	g.Expect(count("TestMode", "kserve-sklearnserver-v2")).To(gomega.Equal(1.0))
+	clock = clock.Add(10 * time.Second)
+	TrackInferenceService(key, "TestMode", "kserve-sklearnserver-v2", 2, creationTime, true)
+	g.Expect(readyDurations().GetSampleCount()).To(gomega.Equal(uint64(2)))
+	g.Expect(readyDurations().GetSampleSum()).To(gomega.Equal(40.0))
+
+	// An InferenceService already Ready when first tracked is not timed
+	restarted := types.NamespacedName{Namespace: "default", Name: "tensorflow"}
+	TrackInferenceService(restarted, "TestMode", "kserve-sklearnserver-v2", 3, creationTime, true)
+	g.Expect(count("TestMode", "kserve-sklearnserver-v2")).To(gomega.Equal(2.0))
+	g.Expect(readyDurations().GetSampleCount()).To(gomega.Equal(uint64(2)))
+
+	UntrackInferenceService(key)
+	UntrackInferenceService(restarted)
+	UntrackInferenceService(restarted)
+	g.Expect(count("TestMode", "kserve-sklearnserver-v2")).To(gomega.Equal(0.0))
+}
+
+func TestLabelCardinality(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	var keys []types.NamespacedName
+	for i := 0; i < 100; i++ {
+		key := types.NamespacedName{Namespace: fmt.Sprintf("ns-%d", i%10), Name: fmt.Sprintf("isvc-%d", i)}
+		keys = append(keys, key)
+		TrackInferenceService(key, "CardinalityMode", fmt.Sprintf("runtime-%d", i%3), 1, time.Time{}, false)
+		TrackInferenceService(key, "CardinalityMode", fmt.Sprintf("runtime-%d", i%3), 1, time.Time{}, true)
+	}
+	defer func() {
+		for _, key := range keys {
+			UntrackInferenceService(key)
+		}
+	}()
+
+	// No metric has a series per InferenceService by default
+	families := gather(g)
+	for name, family := range families {
+		for _, metric := range family.GetMetric() {
+			for _, label := range metric.GetLabel() {
+				g.Expect(label.GetName()).NotTo(gomega.BeElementOf("name", "namespace"), name)
+			}
+		}
+	}
+	g.Expect(families).NotTo(gomega.HaveKey("kserve_inferenceservice_info"))
+	series := 0
+	for _, metric := range families["kserve_inferenceservices"].GetMetric() {
+		for _, label := range metric.GetLabel() {
+			if label.GetName() == "deployment_mode" && label.GetValue() == "CardinalityMode" {
+				series++
+			}
+		}
+	}
+	g.Expect(series).To(gomega.Equal(3))
+
+	// The series per InferenceService are reported once enabled
+	EnablePerInferenceServiceMetrics()
+	EnablePerInferenceServiceMetrics()
+	// The series of an InferenceService is reported when it is tracked with a new runtime
+	TrackInferenceService(keys[0], "CardinalityMode", "runtime-1", 1, time.Time{}, true)
+	families = gather(g)
+	g.Expect(findMetric(families["kserve_inferenceservice_info"], map[string]string{
+		"namespace": "ns-0", "name": "isvc-0", "deployment_mode": "CardinalityMode", "runtime": "runtime-1",
+	}).GetGauge().GetValue()).To(gomega.Equal(1.0))
+	UntrackInferenceService(keys[0])
+	g.Expect(gather(g)).NotTo(gomega.HaveKey("kserve_inferenceservice_info"))
+}
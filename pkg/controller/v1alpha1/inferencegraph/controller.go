@@ -25,8 +25,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sort"
 
 	"github.com/go-logr/logr"
+	"github.com/kserve/kserve/pkg/controller/metrics"
 	"github.com/kserve/kserve/pkg/utils"
 	"github.com/pkg/errors"
 	appsv1 "k8s.io/api/apps/v1"
@@ -93,6 +95,87 @@ type RouterConfig struct {
 	Headers map[string][]string `json:"headers"`
 }
 
+// routerCacheEnvVars returns the environment variables the router needs to reach the cache backend of the graph,
+// the password of the Redis server is read from the referenced secret.
+func routerCacheEnvVars(graph *v1alpha1api.InferenceGraph) []v1.EnvVar {
+	if graph.Spec.Cache == nil || graph.Spec.Cache.Redis == nil || graph.Spec.Cache.Redis.PasswordSecretRef == nil {
+		return nil
+	}
+	return []v1.EnvVar{
+		{
+			Name: constants.RouterRedisPasswordEnvVar,
+			ValueFrom: &v1.EnvVarSource{
+				SecretKeyRef: graph.Spec.Cache.Redis.PasswordSecretRef,
+			},
+		},
+	}
+}
+
+// routerLoggerEnvVars returns the environment variables identifying the graph in the events of the router logger
+func routerLoggerEnvVars(graph *v1alpha1api.InferenceGraph) []v1.EnvVar {
+	if graph.Spec.Logger == nil {
+		return nil
+	}
+	return []v1.EnvVar{
+		{
+			Name:  constants.RouterGraphNameEnvVar,
+			Value: graph.Name,
+		},
+		{
+			Name:  constants.RouterGraphNamespaceEnvVar,
+			Value: graph.Namespace,
+		},
+	}
+}
+
+// setRouterServiceAccountTokens runs the router under the service account of the graph and projects a token of that
+// service account for every distinct step token audience, the router attaches them as bearer tokens to the step requests.
+func setRouterServiceAccountTokens(graph *v1alpha1api.InferenceGraph, podSpec *v1.PodSpec) {
+	podSpec.ServiceAccountName = graph.Spec.ServiceAccountName
+
+	audiences := map[string]bool{}
+	for _, node := range graph.Spec.Nodes {
+		for _, step := range node.Steps {
+			if step.TokenAudience != "" {
+				audiences[step.TokenAudience] = true
+			}
+		}
+	}
+	if len(audiences) == 0 {
+		return
+	}
+	sortedAudiences := make([]string, 0, len(audiences))
+	for audience := range audiences {
+		sortedAudiences = append(sortedAudiences, audience)
+	}
+	sort.Strings(sortedAudiences)
+
+	expirationSeconds := constants.RouterServiceAccountTokenExpirationSeconds
+	sources := make([]v1.VolumeProjection, 0, len(sortedAudiences))
+	for _, audience := range sortedAudiences {
+		sources = append(sources, v1.VolumeProjection{
+			ServiceAccountToken: &v1.ServiceAccountTokenProjection{
+				Audience:          audience,
+				ExpirationSeconds: &expirationSeconds,
+				Path:              constants.RouterServiceAccountTokenFileName(audience),
+			},
+		})
+	}
+	podSpec.Volumes = append(podSpec.Volumes, v1.Volume{
+		Name: constants.RouterServiceAccountTokenVolumeName,
+		VolumeSource: v1.VolumeSource{
+			Projected: &v1.ProjectedVolumeSource{
+				Sources: sources,
+			},
+		},
+	})
+	podSpec.Containers[0].VolumeMounts = append(podSpec.Containers[0].VolumeMounts, v1.VolumeMount{
+		Name:      constants.RouterServiceAccountTokenVolumeName,
+		MountPath: constants.RouterServiceAccountTokensMountPath,
+		ReadOnly:  true,
+	})
+}
+
 func getRouterConfigs(configMap *v1.ConfigMap) (*RouterConfig, error) {
 	routerConfig := &RouterConfig{}
 	if agentConfigValue, ok := configMap.Data["router"]; ok {
@@ -300,5 +383,5 @@ func (r *InferenceGraphReconciler) SetupWithManager(mgr ctrl.Manager, deployConf
 		r.Log.Info("The InferenceGraph controller won't watch serving.knative.dev/v1/Service resources because the CRD is not available.")
 	}
 
-	return ctrlBuilder.Complete(r)
+	return ctrlBuilder.Complete(metrics.InstrumentReconciler(metrics.InferenceGraphController, r))
 }
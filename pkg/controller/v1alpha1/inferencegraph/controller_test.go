@@ -20,11 +20,13 @@ import (
 	"context"
 	"fmt"
 	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
 	"github.com/kserve/kserve/pkg/constants"
 	"github.com/kserve/kserve/pkg/utils"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -575,6 +577,89 @@ var _ = Describe("Inference Graph controller test", func() {
 			Expect(actualK8sDeploymentCreated.Spec.Template.Spec.Containers).To(Not(BeNil()))
 			Expect(actualK8sDeploymentCreated.Spec.Template.Spec.Containers[0].Image).To(Not(BeNil()))
 			Expect(actualK8sDeploymentCreated.Spec.Template.Spec.Containers[0].Args).To(Not(BeNil()))
+			Expect(actualK8sDeploymentCreated.Spec.Template.Spec.Containers[0].ReadinessProbe.HTTPGet.Path).To(Equal(constants.RouterReadinessPath))
+		})
+	})
+
+	Context("When creating an inferencegraph in Raw deployment mode with scaling and resources", func() {
+		It("Should create a probed deployment with the graph resources and an HPA", func() {
+			By("By creating a new InferenceGraph")
+			var configMap = &v1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      constants.InferenceServiceConfigMapName,
+					Namespace: constants.KServeNamespace,
+				},
+				Data: configs,
+			}
+			Expect(k8sClient.Create(context.TODO(), configMap)).NotTo(HaveOccurred())
+			defer k8sClient.Delete(context.TODO(), configMap)
+			graphName := "igraw2"
+			var expectedRequest = reconcile.Request{NamespacedName: types.NamespacedName{Name: graphName, Namespace: "default"}}
+			var serviceKey = expectedRequest.NamespacedName
+			ctx := context.Background()
+			minReplicas := 2
+			scaleTarget := 60
+			cpuMetric := v1alpha1.ScaleMetric(v1beta1.MetricCPU)
+			ig := &v1alpha1.InferenceGraph{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      serviceKey.Name,
+					Namespace: serviceKey.Namespace,
+					Annotations: map[string]string{
+						"serving.kserve.io/deploymentMode": string(constants.RawDeployment),
+					},
+				},
+				Spec: v1alpha1.InferenceGraphSpec{
+					Nodes: map[string]v1alpha1.InferenceRouter{
+						v1alpha1.GraphRootNodeName: {
+							RouterType: v1alpha1.Sequence,
+							Steps: []v1alpha1.InferenceStep{
+								{
+									InferenceTarget: v1alpha1.InferenceTarget{
+										ServiceURL: "http://someservice.exmaple.com",
+									},
+								},
+							},
+						},
+					},
+					Resources: v1.ResourceRequirements{
+						Limits: v1.ResourceList{
+							v1.ResourceCPU:    resource.MustParse("200m"),
+							v1.ResourceMemory: resource.MustParse("1Gi"),
+						},
+						Requests: v1.ResourceList{
+							v1.ResourceCPU:    resource.MustParse("200m"),
+							v1.ResourceMemory: resource.MustParse("200Mi"),
+						},
+					},
+					MinReplicas: &minReplicas,
+					MaxReplicas: 5,
+					ScaleTarget: &scaleTarget,
+					ScaleMetric: &cpuMetric,
+				},
+			}
+			Expect(k8sClient.Create(ctx, ig)).Should(Succeed())
+			defer k8sClient.Delete(ctx, ig)
+
+			actualK8sDeploymentCreated := &appsv1.Deployment{}
+			Eventually(func() error {
+				return k8sClient.Get(ctx, serviceKey, actualK8sDeploymentCreated)
+			}, timeout, interval).Should(Succeed())
+			container := actualK8sDeploymentCreated.Spec.Template.Spec.Containers[0]
+			Expect(container.Resources).To(Equal(ig.Spec.Resources))
+			Expect(container.ReadinessProbe).NotTo(BeNil())
+			Expect(container.ReadinessProbe.HTTPGet.Path).To(Equal(constants.RouterReadinessPath))
+			Expect(container.ReadinessProbe.HTTPGet.Port.IntValue()).To(Equal(constants.RouterDefaultHttpPort))
+
+			actualHPA := &autoscalingv2.HorizontalPodAutoscaler{}
+			Eventually(func() error {
+				return k8sClient.Get(ctx, serviceKey, actualHPA)
+			}, timeout, interval).Should(Succeed())
+			Expect(actualHPA.Spec.ScaleTargetRef.Name).To(Equal(graphName))
+			Expect(*actualHPA.Spec.MinReplicas).To(Equal(int32(2)))
+			Expect(actualHPA.Spec.MaxReplicas).To(Equal(int32(5)))
+			Expect(actualHPA.Spec.Metrics).To(HaveLen(1))
+			Expect(actualHPA.Spec.Metrics[0].Resource.Name).To(Equal(v1.ResourceCPU))
+			Expect(*actualHPA.Spec.Metrics[0].Resource.Target.AverageUtilization).To(Equal(int32(60)))
 		})
 	})
 
@@ -25,6 +25,7 @@ import (
 
 	v1alpha1api "github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
 	"github.com/kserve/kserve/pkg/constants"
+	"github.com/kserve/kserve/pkg/controller/metrics"
 	"github.com/kserve/kserve/pkg/utils"
 	"github.com/pkg/errors"
 	"google.golang.org/protobuf/proto"
@@ -91,6 +92,7 @@ func (r *GraphKnativeServiceReconciler) Reconcile() (*knservingv1.ServiceStatus,
 	desired := r.Service
 	existing := &knservingv1.Service{}
 
+	drifted := false
 	err := retry.RetryOnConflict(retry.DefaultBackoff, func() error {
 		log.Info("Updating inference graph knative service", "namespace", desired.Namespace, "name", desired.Name)
 		if err := r.client.Get(context.TODO(), types.NamespacedName{Name: desired.Name, Namespace: desired.Namespace}, existing); err != nil {
@@ -114,6 +116,7 @@ func (r *GraphKnativeServiceReconciler) Reconcile() (*knservingv1.ServiceStatus,
 			return err
 		}
 
+		drifted = !semanticEquals(desired, existing)
 		if err := reconcileKsvc(desired, existing); err != nil {
 			return err
 		}
@@ -126,6 +129,9 @@ func (r *GraphKnativeServiceReconciler) Reconcile() (*knservingv1.ServiceStatus,
 		}
 		return &existing.Status, errors.Wrapf(err, "fails to reconcile inference graph knative service")
 	}
+	if drifted {
+		metrics.RecordDriftCorrection(metrics.KindKnativeService)
+	}
 	return &existing.Status, nil
 }
 
@@ -214,6 +220,10 @@ func createKnativeService(componentMeta metav1.ObjectMeta, graph *v1alpha1api.In
 			},
 		}
 	}
+	container := &service.Spec.ConfigurationSpec.Template.Spec.PodSpec.Containers[0]
+	container.Env = append(container.Env, routerCacheEnvVars(graph)...)
+	container.Env = append(container.Env, routerLoggerEnvVars(graph)...)
+	setRouterServiceAccountTokens(graph, &service.Spec.ConfigurationSpec.Template.Spec.PodSpec)
 	return service
 }
 
@@ -25,6 +25,7 @@ import (
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/client-go/kubernetes"
 	"knative.dev/pkg/apis"
 	knapis "knative.dev/pkg/apis"
@@ -44,6 +45,8 @@ var logger = logf.Log.WithName("InferenceGraphRawDeployer")
 This function helps to create core podspec for a given inference graph spec and router configuration
 Also propagates headers onto podspec container environment variables.
 
+The router container is probed on its readiness endpoint, so the deployment is only available once the graph is loaded.
+
 This function makes sense to be used in raw k8s deployment mode
 */
 func createInferenceGraphPodSpec(graph *v1alpha1api.InferenceGraph, config *RouterConfig) *v1.PodSpec {
@@ -63,6 +66,18 @@ func createInferenceGraphPodSpec(graph *v1alpha1api.InferenceGraph, config *Rout
 					string(bytes),
 				},
 				Resources: constructResourceRequirements(*graph, *config),
+				ReadinessProbe: &v1.Probe{
+					ProbeHandler: v1.ProbeHandler{
+						HTTPGet: &v1.HTTPGetAction{
+							Path: constants.RouterReadinessPath,
+							Port: intstr.FromInt(constants.RouterDefaultHttpPort),
+						},
+					},
+					TimeoutSeconds:   1,
+					PeriodSeconds:    10,
+					SuccessThreshold: 1,
+					FailureThreshold: 3,
+				},
 			},
 		},
 		Affinity: graph.Spec.Affinity,
@@ -78,6 +93,9 @@ func createInferenceGraphPodSpec(graph *v1alpha1api.InferenceGraph, config *Rout
 			},
 		}
 	}
+	podSpec.Containers[0].Env = append(podSpec.Containers[0].Env, routerCacheEnvVars(graph)...)
+	podSpec.Containers[0].Env = append(podSpec.Containers[0].Env, routerLoggerEnvVars(graph)...)
+	setRouterServiceAccountTokens(graph, podSpec)
 
 	return podSpec
 }
@@ -137,7 +155,7 @@ func handleInferenceGraphRawDeployment(cl client.Client, clientset kubernetes.In
 	reconciler, err := raw.NewRawKubeReconciler(cl, clientset, scheme, objectMeta, &componentExtSpec, desiredSvc)
 
 	if err != nil {
-		return nil, reconciler.URL, errors.Wrapf(err, "fails to create NewRawKubeReconciler for inference graph")
+		return nil, nil, errors.Wrapf(err, "fails to create NewRawKubeReconciler for inference graph")
 	}
 	// set Deployment Controller
 	if err := controllerutil.SetControllerReference(graph, reconciler.Deployment.Deployment, scheme); err != nil {
@@ -149,12 +167,17 @@ func handleInferenceGraphRawDeployment(cl client.Client, clientset kubernetes.In
 	}
 
 	// set autoscaler Controller
-	if err := reconciler.Scaler.Autoscaler.SetControllerReferences(graph, scheme); err != nil {
+	if err := reconciler.Scaler.SetControllerReferences(graph, scheme); err != nil {
 		return nil, reconciler.URL, errors.Wrapf(err, "fails to set autoscaler owner references for inference graph")
 	}
 
+	// set PDB Controller
+	if err := reconciler.PDB.SetControllerReferences(graph, scheme); err != nil {
+		return nil, reconciler.URL, errors.Wrapf(err, "fails to set pdb owner references for inference graph")
+	}
+
 	// reconcile
-	deployment, err := reconciler.Reconcile()
+	deployment, _, err := reconciler.Reconcile()
 	logger.Info("Result of inference graph raw reconcile", "deployment", deployment)
 	logger.Info("Result of reconcile", "err", err)
 
@@ -171,20 +194,23 @@ In raw deployment mode, deployment available denotes the ready status for IG
 */
 func PropagateRawStatus(graphStatus *v1alpha1api.InferenceGraphStatus, deployment *appsv1.Deployment,
 	url *apis.URL) {
+	ready := apis.Condition{
+		Type:   apis.ConditionReady,
+		Status: v1.ConditionFalse,
+	}
 	for _, con := range deployment.Status.Conditions {
 		if con.Type == appsv1.DeploymentAvailable {
-			graphStatus.URL = url
-
-			conditions := []apis.Condition{
-				{
-					Type:   apis.ConditionReady,
-					Status: v1.ConditionTrue,
-				},
+			if con.Status == v1.ConditionTrue {
+				graphStatus.URL = url
+				ready.Status = v1.ConditionTrue
+			} else {
+				ready.Reason = con.Reason
+				ready.Message = con.Message
 			}
-			graphStatus.SetConditions(conditions)
-			logger.Info("status propagated:")
 			break
 		}
 	}
+	graphStatus.SetConditions([]apis.Condition{ready})
+	logger.Info("status propagated:", "ready", ready.Status)
 	graphStatus.ObservedGeneration = deployment.Status.ObservedGeneration
 }
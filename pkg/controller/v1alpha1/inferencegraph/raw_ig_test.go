@@ -21,10 +21,12 @@ import (
 	. "github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
 	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
 	"github.com/kserve/kserve/pkg/constants"
+	"google.golang.org/protobuf/proto"
 	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
 	"testing"
@@ -138,6 +140,106 @@ func TestCreateInferenceGraphPodSpec(t *testing.T) {
 				},
 			},
 		},
+		"withrediscache": {
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "cache-ig",
+				Namespace: "cache-ig-namespace",
+			},
+			Spec: InferenceGraphSpec{
+				Nodes: map[string]InferenceRouter{
+					GraphRootNodeName: {
+						RouterType: Sequence,
+						Steps: []InferenceStep{
+							{
+								InferenceTarget: InferenceTarget{
+									ServiceURL: "http://someservice.exmaple.com",
+								},
+							},
+						},
+					},
+				},
+				Cache: &InferenceGraphCache{
+					Redis: &RedisCache{
+						Address: "redis:6379",
+						PasswordSecretRef: &v1.SecretKeySelector{
+							LocalObjectReference: v1.LocalObjectReference{Name: "redis"},
+							Key:                  "password",
+						},
+					},
+				},
+			},
+		},
+		"withtokenaudience": {
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "token-ig",
+				Namespace: "token-ig-namespace",
+			},
+			Spec: InferenceGraphSpec{
+				Nodes: map[string]InferenceRouter{
+					GraphRootNodeName: {
+						RouterType: Sequence,
+						Steps: []InferenceStep{
+							{
+								InferenceTarget: InferenceTarget{
+									ServiceURL: "http://someservice.exmaple.com",
+								},
+								TokenAudience: "someservice",
+							},
+							{
+								InferenceTarget: InferenceTarget{
+									ServiceURL: "http://otherservice.exmaple.com",
+								},
+								TokenAudience: "otherservice",
+							},
+							{
+								InferenceTarget: InferenceTarget{
+									ServiceURL: "http://someservice.exmaple.com",
+								},
+								TokenAudience: "someservice",
+							},
+						},
+					},
+				},
+				ServiceAccountName: "router-sa",
+			},
+		},
+		"withlogger": {
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "logger-ig",
+				Namespace: "logger-ig-namespace",
+			},
+			Spec: InferenceGraphSpec{
+				Nodes: map[string]InferenceRouter{
+					GraphRootNodeName: {
+						RouterType: Sequence,
+						Steps: []InferenceStep{
+							{
+								InferenceTarget: InferenceTarget{
+									ServiceURL: "http://someservice.exmaple.com",
+								},
+							},
+						},
+					},
+				},
+				Logger: &InferenceGraphLogger{
+					URL:  "http://message-dumper.logger-ig-namespace/",
+					Mode: GraphLogAll,
+				},
+			},
+		},
+	}
+
+	readinessProbe := &v1.Probe{
+		ProbeHandler: v1.ProbeHandler{
+			HTTPGet: &v1.HTTPGetAction{
+				Path: "/readyz",
+				Port: intstr.FromInt(8080),
+			},
+		},
+		TimeoutSeconds:   1,
+		PeriodSeconds:    10,
+		SuccessThreshold: 1,
+		FailureThreshold: 3,
 	}
 
 	expectedPodSpecs := map[string]*v1.PodSpec{
@@ -160,6 +262,7 @@ func TestCreateInferenceGraphPodSpec(t *testing.T) {
 							v1.ResourceMemory: resource.MustParse("100Mi"),
 						},
 					},
+					ReadinessProbe: readinessProbe,
 				},
 			},
 		},
@@ -188,6 +291,130 @@ func TestCreateInferenceGraphPodSpec(t *testing.T) {
 							v1.ResourceMemory: resource.MustParse("100Mi"),
 						},
 					},
+					ReadinessProbe: readinessProbe,
+				},
+			},
+		},
+		"withrediscache": {
+			Containers: []v1.Container{
+				{
+					Image: "kserve/router:v0.10.0",
+					Name:  "cache-ig",
+					Args: []string{
+						"--graph-json",
+						"{\"nodes\":{\"root\":{\"routerType\":\"Sequence\",\"steps\":[{\"serviceUrl\":\"http://someservice.exmaple.com\"}]}},\"resources\":{},\"cache\":{\"redis\":{\"address\":\"redis:6379\",\"passwordSecretRef\":{\"name\":\"redis\",\"key\":\"password\"}}}}",
+					},
+					Env: []v1.EnvVar{
+						{
+							Name: "REDIS_PASSWORD",
+							ValueFrom: &v1.EnvVarSource{
+								SecretKeyRef: &v1.SecretKeySelector{
+									LocalObjectReference: v1.LocalObjectReference{Name: "redis"},
+									Key:                  "password",
+								},
+							},
+						},
+					},
+					Resources: v1.ResourceRequirements{
+						Limits: v1.ResourceList{
+							v1.ResourceCPU:    resource.MustParse("100m"),
+							v1.ResourceMemory: resource.MustParse("500Mi"),
+						},
+						Requests: v1.ResourceList{
+							v1.ResourceCPU:    resource.MustParse("100m"),
+							v1.ResourceMemory: resource.MustParse("100Mi"),
+						},
+					},
+					ReadinessProbe: readinessProbe,
+				},
+			},
+		},
+		"withtokenaudience": {
+			ServiceAccountName: "router-sa",
+			Containers: []v1.Container{
+				{
+					Image: "kserve/router:v0.10.0",
+					Name:  "token-ig",
+					Args: []string{
+						"--graph-json",
+						"{\"nodes\":{\"root\":{\"routerType\":\"Sequence\",\"steps\":[{\"serviceUrl\":\"http://someservice.exmaple.com\",\"tokenAudience\":\"someservice\"},{\"serviceUrl\":\"http://otherservice.exmaple.com\",\"tokenAudience\":\"otherservice\"},{\"serviceUrl\":\"http://someservice.exmaple.com\",\"tokenAudience\":\"someservice\"}]}},\"resources\":{},\"serviceAccountName\":\"router-sa\"}",
+					},
+					Resources: v1.ResourceRequirements{
+						Limits: v1.ResourceList{
+							v1.ResourceCPU:    resource.MustParse("100m"),
+							v1.ResourceMemory: resource.MustParse("500Mi"),
+						},
+						Requests: v1.ResourceList{
+							v1.ResourceCPU:    resource.MustParse("100m"),
+							v1.ResourceMemory: resource.MustParse("100Mi"),
+						},
+					},
+					ReadinessProbe: readinessProbe,
+					VolumeMounts: []v1.VolumeMount{
+						{
+							Name:      "router-service-account-tokens",
+							MountPath: "/var/run/secrets/kserve/router",
+							ReadOnly:  true,
+						},
+					},
+				},
+			},
+			Volumes: []v1.Volume{
+				{
+					Name: "router-service-account-tokens",
+					VolumeSource: v1.VolumeSource{
+						Projected: &v1.ProjectedVolumeSource{
+							Sources: []v1.VolumeProjection{
+								{
+									ServiceAccountToken: &v1.ServiceAccountTokenProjection{
+										Audience:          "otherservice",
+										ExpirationSeconds: proto.Int64(3600),
+										Path:              constants.RouterServiceAccountTokenFileName("otherservice"),
+									},
+								},
+								{
+									ServiceAccountToken: &v1.ServiceAccountTokenProjection{
+										Audience:          "someservice",
+										ExpirationSeconds: proto.Int64(3600),
+										Path:              constants.RouterServiceAccountTokenFileName("someservice"),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		"withlogger": {
+			Containers: []v1.Container{
+				{
+					Image: "kserve/router:v0.10.0",
+					Name:  "logger-ig",
+					Args: []string{
+						"--graph-json",
+						"{\"nodes\":{\"root\":{\"routerType\":\"Sequence\",\"steps\":[{\"serviceUrl\":\"http://someservice.exmaple.com\"}]}},\"resources\":{},\"logger\":{\"url\":\"http://message-dumper.logger-ig-namespace/\",\"mode\":\"all\"}}",
+					},
+					Env: []v1.EnvVar{
+						{
+							Name:  "INFERENCE_GRAPH_NAME",
+							Value: "logger-ig",
+						},
+						{
+							Name:  "INFERENCE_GRAPH_NAMESPACE",
+							Value: "logger-ig-namespace",
+						},
+					},
+					Resources: v1.ResourceRequirements{
+						Limits: v1.ResourceList{
+							v1.ResourceCPU:    resource.MustParse("100m"),
+							v1.ResourceMemory: resource.MustParse("500Mi"),
+						},
+						Requests: v1.ResourceList{
+							v1.ResourceCPU:    resource.MustParse("100m"),
+							v1.ResourceMemory: resource.MustParse("100Mi"),
+						},
+					},
+					ReadinessProbe: readinessProbe,
 				},
 			},
 		},
@@ -210,6 +437,7 @@ func TestCreateInferenceGraphPodSpec(t *testing.T) {
 							v1.ResourceMemory: resource.MustParse("100Mi"),
 						},
 					},
+					ReadinessProbe: readinessProbe,
 				},
 			},
 		},
@@ -241,6 +469,30 @@ func TestCreateInferenceGraphPodSpec(t *testing.T) {
 			},
 			expected: expectedPodSpecs["basicgraphwithheaders"],
 		},
+		{
+			name: "Inference graph with redis cache password",
+			args: args{
+				graph:  testIGSpecs["withrediscache"],
+				config: &routerConfig,
+			},
+			expected: expectedPodSpecs["withrediscache"],
+		},
+		{
+			name: "Inference graph with step token audiences",
+			args: args{
+				graph:  testIGSpecs["withtokenaudience"],
+				config: &routerConfig,
+			},
+			expected: expectedPodSpecs["withtokenaudience"],
+		},
+		{
+			name: "Inference graph with logger",
+			args: args{
+				graph:  testIGSpecs["withlogger"],
+				config: &routerConfig,
+			},
+			expected: expectedPodSpecs["withlogger"],
+		},
 	}
 
 	for _, tt := range scenarios {
@@ -451,6 +703,12 @@ func TestPropagateRawStatus(t *testing.T) {
 				deployment: &appsv1.Deployment{
 					Status: appsv1.DeploymentStatus{
 						AvailableReplicas: 1,
+						Conditions: []appsv1.DeploymentCondition{
+							{
+								Type:   appsv1.DeploymentAvailable,
+								Status: v1.ConditionTrue,
+							},
+						},
 					},
 				},
 				url: &apis.URL{
@@ -467,6 +725,55 @@ func TestPropagateRawStatus(t *testing.T) {
 						},
 					},
 				},
+				URL: &apis.URL{
+					Scheme: "http",
+					Host:   "test.com",
+				},
+			},
+		},
+		{
+			name: "Inference graph becomes not ready when the deployment is no longer available",
+			args: args{
+				graphStatus: &InferenceGraphStatus{
+					Status: duckv1.Status{
+						Conditions: duckv1.Conditions{
+							{
+								Type:   apis.ConditionReady,
+								Status: v1.ConditionTrue,
+							},
+						},
+					},
+				},
+				deployment: &appsv1.Deployment{
+					Status: appsv1.DeploymentStatus{
+						ObservedGeneration: 2,
+						Conditions: []appsv1.DeploymentCondition{
+							{
+								Type:    appsv1.DeploymentAvailable,
+								Status:  v1.ConditionFalse,
+								Reason:  "MinimumReplicasUnavailable",
+								Message: "Deployment does not have minimum availability.",
+							},
+						},
+					},
+				},
+				url: &apis.URL{
+					Scheme: "http",
+					Host:   "test.com",
+				},
+			},
+			expected: &InferenceGraphStatus{
+				Status: duckv1.Status{
+					ObservedGeneration: 2,
+					Conditions: duckv1.Conditions{
+						{
+							Type:    apis.ConditionReady,
+							Status:  v1.ConditionFalse,
+							Reason:  "MinimumReplicasUnavailable",
+							Message: "Deployment does not have minimum availability.",
+						},
+					},
+				},
 			},
 		},
 
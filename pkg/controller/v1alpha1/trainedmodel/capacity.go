@@ -0,0 +1,130 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trainedmodel
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	v1alpha1api "github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	v1beta1api "github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+	v1beta1utils "github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/utils"
+)
+
+// DefaultMemoryCapacityFraction is the fraction of the memory limit of the predictor container the TrainedModels of
+// an InferenceService may request
+const DefaultMemoryCapacityFraction = 1.0
+
+// WaitingForCapacity is the reason of the MemoryResourceAvailable condition of the TrainedModels waiting for the
+// memory of their InferenceService to be freed
+const WaitingForCapacity = "WaitingForCapacity"
+
+// memoryCapacity returns the memory of the predictor container the TrainedModels may request
+func memoryCapacity(isvc *v1beta1api.InferenceService, fraction float64) resource.Quantity {
+	if isvc.Spec.Predictor.GetExtensions() == nil || len(isvc.Spec.Predictor.GetImplementations()) == 0 {
+		return resource.Quantity{}
+	}
+	container := isvc.Spec.Predictor.GetImplementation().GetContainer(isvc.ObjectMeta, isvc.Spec.Predictor.GetExtensions(), nil)
+	limit := container.Resources.Limits.Memory()
+	if fraction <= 0 || fraction >= 1 {
+		return *limit
+	}
+	return *resource.NewQuantity(int64(float64(limit.Value())*fraction), resource.BinarySI)
+}
+
+// allocatedTrainedModels lists the TrainedModels the memory of the InferenceService is allocated to, the ones being
+// deleted have released it
+func (r *TrainedModelReconciler) allocatedTrainedModels(isvc *v1beta1api.InferenceService) (*v1alpha1api.TrainedModelList, error) {
+	trainedModels := &v1alpha1api.TrainedModelList{}
+	if err := r.List(context.TODO(), trainedModels, client.InNamespace(isvc.Namespace),
+		client.MatchingLabels{constants.ParentInferenceServiceLabel: isvc.Name, constants.TrainedModelAllocated: isvc.Name}); err != nil {
+		return nil, err
+	}
+	allocated := trainedModels.Items[:0]
+	for _, tm := range trainedModels.Items {
+		if tm.DeletionTimestamp.IsZero() {
+			allocated = append(allocated, tm)
+		}
+	}
+	trainedModels.Items = allocated
+	return trainedModels, nil
+}
+
+// updateAllocatedMemory reports the memory allocated to the TrainedModels in the status of the InferenceService
+func (r *TrainedModelReconciler) updateAllocatedMemory(isvc *v1beta1api.InferenceService, allocated resource.Quantity) error {
+	current := isvc.Status.ModelStatus.AllocatedModelMemory
+	if current != nil && current.Cmp(allocated) == 0 {
+		return nil
+	}
+	// a merge patch leaves the rest of the status to the InferenceService controller
+	patch := client.MergeFrom(isvc.DeepCopy())
+	isvc.Status.ModelStatus.AllocatedModelMemory = &allocated
+	return r.Status().Patch(context.TODO(), isvc, patch)
+}
+
+// releaseMemory reports the memory allocated to the TrainedModels of the InferenceService once one of them is deleted
+func (r *TrainedModelReconciler) releaseMemory(isvc *v1beta1api.InferenceService) error {
+	r.allocationMu.Lock()
+	defer r.allocationMu.Unlock()
+	trainedModels, err := r.allocatedTrainedModels(isvc)
+	if err != nil {
+		return err
+	}
+	return r.updateAllocatedMemory(isvc, trainedModels.TotalRequestedMemory())
+}
+
+// waitingTrainedModels maps a deleted TrainedModel to the TrainedModels of the same InferenceService waiting for the
+// memory it released
+func (r *TrainedModelReconciler) waitingTrainedModels(ctx context.Context, obj client.Object) []reconcile.Request {
+	deleted, ok := obj.(*v1alpha1api.TrainedModel)
+	if !ok {
+		return nil
+	}
+	trainedModels := &v1alpha1api.TrainedModelList{}
+	if err := r.List(ctx, trainedModels, client.InNamespace(deleted.Namespace),
+		client.MatchingLabels{constants.ParentInferenceServiceLabel: deleted.Spec.InferenceService}); err != nil {
+		r.Log.Error(err, "unable to list trained models", "InferenceService", deleted.Spec.InferenceService)
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, tm := range trainedModels.Items {
+		condition := tm.Status.GetCondition(v1alpha1api.MemoryResourceAvailable)
+		if condition != nil && condition.IsFalse() {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: tm.Name, Namespace: tm.Namespace}})
+		}
+	}
+	return requests
+}
+
+// predictorRuntime returns the resolved ServingRuntime of the predictor model, nil if the predictor has none
+func (r *TrainedModelReconciler) predictorRuntime(isvc *v1beta1api.InferenceService) (*v1alpha1api.SupportedRuntime, error) {
+	var name string
+	if isvc.Status.ModelStatus.Runtime != nil {
+		name = isvc.Status.ModelStatus.Runtime.Name
+	} else if isvc.Spec.Predictor.Model != nil && isvc.Spec.Predictor.Model.Runtime != nil {
+		name = *isvc.Spec.Predictor.Model.Runtime
+	}
+	if name == "" {
+		return nil, nil
+	}
+	return v1beta1utils.GetServingRuntime(r.Client, name, isvc.Namespace)
+}
@@ -0,0 +1,194 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trainedmodel
+
+import (
+	"context"
+	"testing"
+
+	"github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	v1alpha1api "github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	v1beta1api "github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+func newMMSInferenceService(memoryLimit string) *v1beta1api.InferenceService {
+	return &v1beta1api.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{Name: "mms", Namespace: "default"},
+		Spec: v1beta1api.InferenceServiceSpec{
+			Predictor: v1beta1api.PredictorSpec{
+				Model: &v1beta1api.ModelSpec{
+					ModelFormat: v1beta1api.ModelFormat{Name: "sklearn"},
+					PredictorExtensionSpec: v1beta1api.PredictorExtensionSpec{
+						Container: v1.Container{
+							Resources: v1.ResourceRequirements{
+								Limits: v1.ResourceList{v1.ResourceMemory: resource.MustParse(memoryLimit)},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func newTrainedModel(name string, memory string, allocated bool) *v1alpha1api.TrainedModel {
+	tm := &v1alpha1api.TrainedModel{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+			Labels:    map[string]string{constants.ParentInferenceServiceLabel: "mms"},
+		},
+		Spec: v1alpha1api.TrainedModelSpec{
+			InferenceService: "mms",
+			Model: v1alpha1api.ModelSpec{
+				StorageURI: "gs://models/" + name,
+				Framework:  "sklearn",
+				Memory:     resource.MustParse(memory),
+			},
+		},
+	}
+	if allocated {
+		tm.Labels[constants.TrainedModelAllocated] = "mms"
+	}
+	return tm
+}
+
+func newCapacityReconciler(fraction float64, objs ...client.Object) *TrainedModelReconciler {
+	s := runtime.NewScheme()
+	_ = v1alpha1api.AddToScheme(s)
+	_ = v1beta1api.AddToScheme(s)
+	cl := fake.NewClientBuilder().WithScheme(s).WithObjects(objs...).
+		WithStatusSubresource(&v1alpha1api.TrainedModel{}, &v1beta1api.InferenceService{}).Build()
+	return &TrainedModelReconciler{
+		Client:                 cl,
+		Log:                    ctrl.Log.WithName("TrainedModel"),
+		Recorder:               record.NewFakeRecorder(10),
+		MemoryCapacityFraction: fraction,
+	}
+}
+
+// reconcileConditions updates the conditions of the TrainedModel, the parent InferenceService is never ready
+func reconcileConditions(r *TrainedModelReconciler, name string) *v1alpha1api.TrainedModel {
+	key := types.NamespacedName{Name: name, Namespace: "default"}
+	tm := &v1alpha1api.TrainedModel{}
+	_ = r.Get(context.TODO(), key, tm)
+	_ = r.updateConditions(ctrl.Request{NamespacedName: key}, tm)
+	_ = r.Get(context.TODO(), key, tm)
+	return tm
+}
+
+func TestMemoryCapacity(t *testing.T) {
+	scenarios := map[string]struct {
+		fraction float64
+		expected string
+	}{
+		"Unset":  {fraction: 0, expected: "1Gi"},
+		"Half":   {fraction: 0.5, expected: "512Mi"},
+		"Whole":  {fraction: 1, expected: "1Gi"},
+		"Larger": {fraction: 2, expected: "1Gi"},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			capacity := memoryCapacity(newMMSInferenceService("1Gi"), scenario.fraction)
+			g.Expect(capacity.Cmp(resource.MustParse(scenario.expected))).To(gomega.Equal(0))
+		})
+	}
+}
+
+func TestTrainedModelCapacity(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	isvc := newMMSInferenceService("2Gi")
+	r := newCapacityReconciler(0.5, isvc,
+		newTrainedModel("allocated", "600Mi", true),
+		newTrainedModel("small", "300Mi", false),
+		newTrainedModel("large", "600Mi", false))
+
+	// 600Mi + 300Mi fit in the 1Gi of the 2Gi memory limit
+	tm := reconcileConditions(r, "small")
+	g.Expect(tm.Status.IsConditionReady(v1alpha1api.MemoryResourceAvailable)).To(gomega.BeTrue())
+	g.Expect(tm.Labels[constants.TrainedModelAllocated]).To(gomega.Equal("mms"))
+	g.Expect(r.Get(context.TODO(), client.ObjectKeyFromObject(isvc), isvc)).To(gomega.Succeed())
+	g.Expect(isvc.Status.ModelStatus.AllocatedModelMemory.String()).To(gomega.Equal("900Mi"))
+
+	// 600Mi more would exceed it, the model waits for capacity
+	tm = reconcileConditions(r, "large")
+	condition := tm.Status.GetCondition(v1alpha1api.MemoryResourceAvailable)
+	g.Expect(condition.IsFalse()).To(gomega.BeTrue())
+	g.Expect(condition.Reason).To(gomega.Equal(WaitingForCapacity))
+	g.Expect(tm.Labels).NotTo(gomega.HaveKey(constants.TrainedModelAllocated))
+	g.Expect(r.waitingTrainedModels(context.TODO(), newTrainedModel("allocated", "600Mi", true))).To(gomega.ConsistOf(
+		reconcile.Request{NamespacedName: types.NamespacedName{Name: "large", Namespace: "default"}}))
+
+	// Deleting the allocated model frees its memory
+	allocated := &v1alpha1api.TrainedModel{}
+	g.Expect(r.Get(context.TODO(), types.NamespacedName{Name: "allocated", Namespace: "default"}, allocated)).To(gomega.Succeed())
+	g.Expect(r.Delete(context.TODO(), allocated)).To(gomega.Succeed())
+	g.Expect(r.releaseMemory(isvc)).To(gomega.Succeed())
+	g.Expect(isvc.Status.ModelStatus.AllocatedModelMemory.String()).To(gomega.Equal("300Mi"))
+
+	tm = reconcileConditions(r, "large")
+	g.Expect(tm.Status.IsConditionReady(v1alpha1api.MemoryResourceAvailable)).To(gomega.BeTrue())
+	g.Expect(r.Get(context.TODO(), client.ObjectKeyFromObject(isvc), isvc)).To(gomega.Succeed())
+	g.Expect(isvc.Status.ModelStatus.AllocatedModelMemory.String()).To(gomega.Equal("900Mi"))
+}
+
+func TestTrainedModelRuntimeNotMultiModel(t *testing.T) {
+	scenarios := map[string]struct {
+		multiModel *bool
+		expected   bool
+	}{
+		"Unset":         {multiModel: nil, expected: true},
+		"MultiModel":    {multiModel: ptr.To(true), expected: true},
+		"NotMultiModel": {multiModel: ptr.To(false), expected: false},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			isvc := newMMSInferenceService("1Gi")
+			isvc.Spec.Predictor.Model.Runtime = ptr.To("mms-runtime")
+			servingRuntime := &v1alpha1api.ServingRuntime{
+				ObjectMeta: metav1.ObjectMeta{Name: "mms-runtime", Namespace: "default"},
+				Spec: v1alpha1api.ServingRuntimeSpec{
+					SupportedModelFormats: []v1alpha1api.SupportedModelFormat{{Name: "sklearn"}},
+					MultiModel:            scenario.multiModel,
+				},
+			}
+			r := newCapacityReconciler(0, isvc, servingRuntime, newTrainedModel("model", "100Mi", false))
+
+			tm := reconcileConditions(r, "model")
+			g.Expect(tm.Status.IsConditionReady(v1alpha1api.IsMMSPredictor)).To(gomega.Equal(scenario.expected))
+			if !scenario.expected {
+				g.Expect(tm.Status.GetCondition(v1alpha1api.IsMMSPredictor).Reason).To(gomega.Equal("IsNotMultiModelRuntime"))
+			}
+		})
+	}
+}
@@ -23,11 +23,13 @@ limitations under the License.
 // +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;create;update;patch;delete
 // +kubebuilder:rbac:groups=core,resources=namespaces,verbs=get;list;watch
 // +kubebuilder:rbac:groups=core,resources=events,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch
 package trainedmodel
 
 import (
 	"context"
 	"fmt"
+	"sync"
 
 	"github.com/go-logr/logr"
 	v1 "k8s.io/api/core/v1"
@@ -40,13 +42,19 @@ import (
 	"knative.dev/pkg/apis"
 	duckv1 "knative.dev/pkg/apis/duck/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	v1alpha1api "github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
 	v1beta1api "github.com/kserve/kserve/pkg/apis/serving/v1beta1"
 	"github.com/kserve/kserve/pkg/constants"
+	"github.com/kserve/kserve/pkg/controller/metrics"
 	"github.com/kserve/kserve/pkg/controller/v1alpha1/trainedmodel/reconcilers/modelconfig"
 	v1beta1utils "github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/utils"
 	"github.com/kserve/kserve/pkg/utils"
@@ -57,8 +65,13 @@ const (
 	FrameworkNotSupported      = "Inference Service \"%s\" does not support the Trained Model \"%s\" framework \"%s\""
 	MemoryResourceNotAvailable = "Inference Service \"%s\" memory resources are not available. Trained Model \"%s\" cannot deploy"
 	IsNotMMSPredictor          = "Inference Service \"%s\" predictor is not configured for multi-model serving. Trained Model \"%s\" cannot deploy"
+	IsNotMultiModelRuntime     = "Inference Service \"%s\" runtime \"%s\" does not support multi-model serving. Trained Model \"%s\" cannot deploy"
 )
 
+// MaxConcurrentReconciles is the number of TrainedModels reconciled concurrently, so that the model changes of the
+// TrainedModels of an InferenceService are batched by the model config reconciler
+const MaxConcurrentReconciles = 10
+
 var log = logf.Log.WithName("TrainedModel controller")
 
 // TrainedModelReconciler reconciles a TrainedModel object
@@ -69,6 +82,15 @@ type TrainedModelReconciler struct {
 	Scheme                *runtime.Scheme
 	Recorder              record.EventRecorder
 	ModelConfigReconciler *modelconfig.ModelConfigReconciler
+	// ModelStatusReader reads the model states reported by the agents, the ModelLoaded condition is not set if nil
+	ModelStatusReader ModelStatusReader
+	// ModelReadyProber probes the readiness of the model on the model server, the model is considered ready if nil
+	ModelReadyProber *ModelReadyProber
+	// MemoryCapacityFraction is the fraction of the memory limit of the predictor container the TrainedModels may
+	// request, the whole memory limit is used if unset
+	MemoryCapacityFraction float64
+	// allocationMu serializes the allocations of the memory of the InferenceServices to the TrainedModels
+	allocationMu sync.Mutex
 }
 
 func (r *TrainedModelReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
@@ -128,6 +150,10 @@ func (r *TrainedModelReconciler) Reconcile(ctx context.Context, req ctrl.Request
 			if err := r.ModelConfigReconciler.Reconcile(req, tm); err != nil {
 				return reconcile.Result{}, err
 			}
+			// release the memory allocated to the model
+			if err := r.releaseMemory(isvc); err != nil {
+				return reconcile.Result{}, err
+			}
 			// remove our finalizer from the list and update it.
 			tm.SetFinalizers(utils.RemoveString(tm.GetFinalizers(), tmFinalizerName))
 			if err := r.Update(context.Background(), tm); err != nil {
@@ -153,6 +179,14 @@ func (r *TrainedModelReconciler) Reconcile(ctx context.Context, req ctrl.Request
 	if err := r.ModelConfigReconciler.Reconcile(req, tm); err != nil {
 		return ctrl.Result{}, err
 	}
+	// The model server does not notify the model readiness, it is probed again until the model is ready or timed out
+	if condition := tm.Status.GetCondition(v1alpha1api.ModelReady); condition != nil && condition.IsUnknown() {
+		return ctrl.Result{RequeueAfter: ModelReadyProbeInterval}, nil
+	}
+	// The agents do not notify the model state changes, they are read again while the model is loading
+	if condition := tm.Status.GetCondition(v1alpha1api.ModelLoaded); condition != nil && condition.IsUnknown() {
+		return ctrl.Result{RequeueAfter: ModelStatusRequeueInterval}, nil
+	}
 	return ctrl.Result{}, nil
 }
 
@@ -238,12 +272,13 @@ func (r *TrainedModelReconciler) updateConditions(req ctrl.Request, tm *v1alpha1
 	}
 
 	// Update Is MMS Predictor condition
+	sRuntime, err := r.predictorRuntime(isvc)
+	if err != nil {
+		return err
+	}
 	implementations := isvc.Spec.Predictor.GetImplementations()
-	if len(implementations) > 0 && v1beta1utils.IsMMSPredictor(&isvc.Spec.Predictor) {
-		tm.Status.SetCondition(v1alpha1api.IsMMSPredictor, &apis.Condition{
-			Status: v1.ConditionTrue,
-		})
-	} else {
+	switch {
+	case len(implementations) == 0 || !v1beta1utils.IsMMSPredictor(&isvc.Spec.Predictor):
 		tm.Status.SetCondition(v1alpha1api.IsMMSPredictor, &apis.Condition{
 			Type:    v1alpha1api.IsMMSPredictor,
 			Status:  v1.ConditionFalse,
@@ -252,11 +287,53 @@ func (r *TrainedModelReconciler) updateConditions(req ctrl.Request, tm *v1alpha1
 		})
 
 		conditionErr = fmt.Errorf(IsNotMMSPredictor, isvc.Name, tm.Name)
+	case sRuntime != nil && sRuntime.Spec.MultiModel != nil && !*sRuntime.Spec.MultiModel:
+		// The runtimes of the KServe multi-model predictors usually leave multiModel unset, only the runtimes
+		// opting out of multi-model serving are rejected
+		tm.Status.SetCondition(v1alpha1api.IsMMSPredictor, &apis.Condition{
+			Type:    v1alpha1api.IsMMSPredictor,
+			Status:  v1.ConditionFalse,
+			Reason:  "IsNotMultiModelRuntime",
+			Message: fmt.Sprintf("Inference Service runtime %s does not support multi-model serving", sRuntime.Name),
+		})
+
+		conditionErr = fmt.Errorf(IsNotMultiModelRuntime, isvc.Name, sRuntime.Name, tm.Name)
+	default:
+		tm.Status.SetCondition(v1alpha1api.IsMMSPredictor, &apis.Condition{
+			Status: v1.ConditionTrue,
+		})
+	}
+
+	// Update Model Loaded condition from the model states reported by the agents
+	if r.ModelStatusReader != nil && isvc.Status.IsReady() {
+		reports, err := r.ModelStatusReader.ReadModelStatuses(context.TODO(), isvc)
+		if err != nil {
+			return err
+		}
+		if condition := modelLoadedCondition(tm.Name, reports); condition != nil {
+			tm.Status.SetCondition(v1alpha1api.ModelLoaded, condition)
+		}
 	}
 
+	// Update Model Ready condition from the model ready endpoint of the model server
+	if r.ModelReadyProber == nil {
+		tm.Status.SetCondition(v1alpha1api.ModelReady, &apis.Condition{
+			Status: v1.ConditionTrue,
+		})
+	} else if isvc.Status.IsReady() {
+		probeErr := r.ModelReadyProber.Probe(context.TODO(), isvc, tm.Name)
+		if probeErr != nil {
+			log.Info("Trained Model is not ready on the model server", "TrainedModel", tm.Name, "error", probeErr.Error())
+		}
+		condition := r.ModelReadyProber.condition(tm.Name, tm.Status.GetCondition(v1alpha1api.ModelReady), probeErr)
+		tm.Status.SetCondition(v1alpha1api.ModelReady, condition)
+	}
+
+	r.allocationMu.Lock()
+	defer r.allocationMu.Unlock()
 	// Get trained models with same inference service
-	var trainedModels v1alpha1api.TrainedModelList
-	if err := r.List(context.TODO(), &trainedModels, client.InNamespace(tm.Namespace), client.MatchingLabels{constants.ParentInferenceServiceLabel: isvc.Name, constants.TrainedModelAllocated: isvc.Name}); err != nil {
+	trainedModels, err := r.allocatedTrainedModels(isvc)
+	if err != nil {
 		return err
 	}
 
@@ -265,27 +342,38 @@ func (r *TrainedModelReconciler) updateConditions(req ctrl.Request, tm *v1alpha1
 	}
 
 	totalReqMemory := trainedModels.TotalRequestedMemory()
+	capacity := memoryCapacity(isvc, r.MemoryCapacityFraction)
 	// Update Inference Service Resource Available condition
-	if v1beta1utils.IsMemoryResourceAvailable(isvc, totalReqMemory) {
+	if capacity.Cmp(totalReqMemory) >= 0 {
 		log.Info("Parent InferenceService memory resources are available", "TrainedModel", tm.Name, "InferenceService", isvc.Name)
 		if _, ok := tm.Labels[constants.TrainedModelAllocated]; !ok {
 			tm.Labels[constants.TrainedModelAllocated] = isvc.Name
+			// The update returns the stored status, keep the conditions updated above
+			status := tm.Status.DeepCopy()
 			if updateErr := r.Update(context.Background(), tm); updateErr != nil {
 				r.Log.Error(updateErr, "Failed to update TrainedModel label", "TrainedModel", tm.Name)
 				return updateErr
 			}
+			tm.Status = *status
+		}
+
+		if updateErr := r.updateAllocatedMemory(isvc, totalReqMemory); updateErr != nil {
+			r.Log.Error(updateErr, "Failed to update the allocated memory of InferenceService", "InferenceService", isvc.Name)
+			return updateErr
 		}
 
 		tm.Status.SetCondition(v1alpha1api.MemoryResourceAvailable, &apis.Condition{
 			Status: v1.ConditionTrue,
 		})
 	} else {
+		// The model waits for the memory released by the deletion of the other models of the InferenceService
 		log.Info("Parent InferenceService memory resources are not available", "TrainedModel", tm.Name, "InferenceService", isvc.Name)
 		tm.Status.SetCondition(v1alpha1api.MemoryResourceAvailable, &apis.Condition{
-			Type:    v1alpha1api.MemoryResourceAvailable,
-			Status:  v1.ConditionFalse,
-			Reason:  "MemoryResourceNotAvailable",
-			Message: "Inference Service does not have enough memory resources for Trained Model",
+			Type:   v1alpha1api.MemoryResourceAvailable,
+			Status: v1.ConditionFalse,
+			Reason: WaitingForCapacity,
+			Message: fmt.Sprintf("Inference Service does not have enough memory resources for Trained Model, %s is requested of the %s available",
+				totalReqMemory.String(), capacity.String()),
 		})
 
 		conditionErr = fmt.Errorf(MemoryResourceNotAvailable, isvc.Name, tm.Name)
@@ -304,5 +392,12 @@ func (r *TrainedModelReconciler) updateConditions(req ctrl.Request, tm *v1alpha1
 func (r *TrainedModelReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&v1alpha1api.TrainedModel{}).
-		Complete(r)
+		Watches(&v1alpha1api.TrainedModel{}, handler.EnqueueRequestsFromMapFunc(r.waitingTrainedModels),
+			builder.WithPredicates(predicate.Funcs{
+				CreateFunc:  func(event.CreateEvent) bool { return false },
+				UpdateFunc:  func(event.UpdateEvent) bool { return false },
+				GenericFunc: func(event.GenericEvent) bool { return false },
+			})).
+		WithOptions(controller.Options{MaxConcurrentReconciles: MaxConcurrentReconciles}).
+		Complete(metrics.InstrumentReconciler(metrics.TrainedModelController, r))
 }
@@ -0,0 +1,118 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trainedmodel
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"knative.dev/pkg/apis"
+
+	v1alpha1api "github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	v1beta1api "github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+const (
+	ModelNotReady = "Trained Model \"%s\" is not ready on the model server after %v: %s"
+	// DefaultModelReadyTimeout is the default time a model has to become ready on the model server
+	DefaultModelReadyTimeout = 5 * time.Minute
+	// ModelReadyProbeInterval is the interval of the reconciliations while the model is not ready
+	ModelReadyProbeInterval = 5 * time.Second
+	// maxModelReadyBodyLength is the max length of the response body of the model server recorded in the status
+	maxModelReadyBodyLength = 1024
+)
+
+// ModelReadyProber probes the readiness of the models on the model server of the InferenceService with the model
+// ready endpoint of the protocol of its predictor
+type ModelReadyProber struct {
+	HTTPClient *http.Client
+	// Timeout is the time the model has to become ready, the ModelReady condition is false once it is exceeded
+	Timeout time.Duration
+	now     func() time.Time
+}
+
+func NewModelReadyProber(timeout time.Duration) *ModelReadyProber {
+	if timeout <= 0 {
+		timeout = DefaultModelReadyTimeout
+	}
+	return &ModelReadyProber{
+		HTTPClient: &http.Client{Timeout: 5 * time.Second},
+		Timeout:    timeout,
+		now:        time.Now,
+	}
+}
+
+// Probe requests the readiness of the model, the error has the status code and the body of the response when the
+// model is not ready
+func (p *ModelReadyProber) Probe(ctx context.Context, isvc *v1beta1api.InferenceService, modelName string) error {
+	if isvc.Status.Address == nil || isvc.Status.Address.URL == nil {
+		return fmt.Errorf("inference service %s has no address", isvc.Name)
+	}
+	url := isvc.Status.Address.URL.String() +
+		constants.ModelReadyPath(modelName, isvc.Spec.Predictor.GetImplementation().GetProtocol())
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := p.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxModelReadyBodyLength))
+	return fmt.Errorf("model server returned status %d: %s", resp.StatusCode, body)
+}
+
+// condition returns the ModelReady condition from the result of the probe. The model which is not ready stays
+// unknown with the same reason and message, so that the transition time of the previous condition is the time the
+// model started to be probed, until Timeout is exceeded.
+func (p *ModelReadyProber) condition(modelName string, previous *apis.Condition, probeErr error) *apis.Condition {
+	if probeErr == nil {
+		return &apis.Condition{
+			Type:   v1alpha1api.ModelReady,
+			Status: v1.ConditionTrue,
+		}
+	}
+	waiting := &apis.Condition{
+		Type:    v1alpha1api.ModelReady,
+		Status:  v1.ConditionUnknown,
+		Reason:  "ModelNotReady",
+		Message: "Waiting for the model server to report the model ready",
+	}
+	switch {
+	case previous != nil && previous.IsFalse():
+		// the model timed out already, the message is updated with the last failure
+	case previous == nil || previous.Reason != waiting.Reason:
+		return waiting
+	case p.now().Sub(previous.LastTransitionTime.Inner.Time) < p.Timeout:
+		return waiting
+	}
+	return &apis.Condition{
+		Type:    v1alpha1api.ModelReady,
+		Status:  v1.ConditionFalse,
+		Reason:  "ModelReadyTimeout",
+		Message: fmt.Sprintf(ModelNotReady, modelName, p.Timeout, probeErr),
+	}
+}
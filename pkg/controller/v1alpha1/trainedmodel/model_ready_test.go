@@ -0,0 +1,194 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trainedmodel
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+
+	v1alpha1api "github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	v1beta1api "github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+// modelServer serves the readiness of the models, the models are ready once they were probed readyAfter times
+type modelServer struct {
+	readyAfter int
+	probes     []string
+}
+
+func (s *modelServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.probes = append(s.probes, r.URL.Path)
+	if len(s.probes) <= s.readyAfter {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"error": "Model with name model does not exist."}`))
+		return
+	}
+	_, _ = w.Write([]byte(`{"name": "model", "ready": true}`))
+}
+
+func modelReadyTestIsvc(url string, protocol constants.InferenceServiceProtocol) *v1beta1api.InferenceService {
+	address, _ := apis.ParseURL(url)
+	return &v1beta1api.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "isvc",
+			Namespace: "default",
+		},
+		Spec: v1beta1api.InferenceServiceSpec{
+			Predictor: v1beta1api.PredictorSpec{
+				Model: &v1beta1api.ModelSpec{
+					ModelFormat: v1beta1api.ModelFormat{Name: "sklearn"},
+					PredictorExtensionSpec: v1beta1api.PredictorExtensionSpec{
+						ProtocolVersion: &protocol,
+					},
+				},
+			},
+		},
+		Status: v1beta1api.InferenceServiceStatus{
+			Address: &duckv1.Addressable{URL: address},
+		},
+	}
+}
+
+func TestModelReadyProbe(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	scenarios := map[string]struct {
+		protocol     constants.InferenceServiceProtocol
+		readyAfter   int
+		expectedPath string
+		expectedErr  string
+	}{
+		"V1Ready": {
+			protocol:     constants.ProtocolV1,
+			expectedPath: "/v1/models/model",
+		},
+		"V2Ready": {
+			protocol:     constants.ProtocolV2,
+			expectedPath: "/v2/models/model/ready",
+		},
+		"NotFound": {
+			protocol:     constants.ProtocolV2,
+			readyAfter:   1,
+			expectedPath: "/v2/models/model/ready",
+			expectedErr:  `model server returned status 404: {"error": "Model with name model does not exist."}`,
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			server := &modelServer{readyAfter: scenario.readyAfter}
+			ts := httptest.NewServer(server)
+			defer ts.Close()
+
+			prober := NewModelReadyProber(time.Minute)
+			err := prober.Probe(context.TODO(), modelReadyTestIsvc(ts.URL, scenario.protocol), "model")
+			if scenario.expectedErr == "" {
+				g.Expect(err).NotTo(gomega.HaveOccurred())
+			} else {
+				g.Expect(err).To(gomega.MatchError(scenario.expectedErr))
+			}
+			g.Expect(server.probes).To(gomega.Equal([]string{scenario.expectedPath}))
+		})
+	}
+}
+
+func TestModelReadyProbeRequestTimeout(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	release := make(chan struct{})
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer ts.Close()
+	defer close(release)
+
+	prober := NewModelReadyProber(time.Minute)
+	prober.HTTPClient.Timeout = 10 * time.Millisecond
+	err := prober.Probe(context.TODO(), modelReadyTestIsvc(ts.URL, constants.ProtocolV2), "model")
+	g.Expect(err).To(gomega.HaveOccurred())
+}
+
+// TestModelReadyCondition probes the model server the way the reconciliations do, with the condition of the
+// previous reconciliation
+func TestModelReadyCondition(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	scenarios := map[string]struct {
+		readyAfter int
+		// probes are the times of the probes since the first one
+		probes   []time.Duration
+		expected []apis.Condition
+	}{
+		"EventualSuccess": {
+			readyAfter: 2,
+			probes:     []time.Duration{0, 5 * time.Second, 10 * time.Second},
+			expected: []apis.Condition{
+				{Status: v1.ConditionUnknown, Reason: "ModelNotReady"},
+				{Status: v1.ConditionUnknown, Reason: "ModelNotReady"},
+				{Status: v1.ConditionTrue},
+			},
+		},
+		"Timeout": {
+			readyAfter: 3,
+			probes:     []time.Duration{0, 30 * time.Second, 61 * time.Second, 70 * time.Second},
+			expected: []apis.Condition{
+				{Status: v1.ConditionUnknown, Reason: "ModelNotReady"},
+				{Status: v1.ConditionUnknown, Reason: "ModelNotReady"},
+				{Status: v1.ConditionFalse, Reason: "ModelReadyTimeout"},
+				{Status: v1.ConditionTrue},
+			},
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			server := &modelServer{readyAfter: scenario.readyAfter}
+			ts := httptest.NewServer(server)
+			defer ts.Close()
+			isvc := modelReadyTestIsvc(ts.URL, constants.ProtocolV2)
+
+			start := time.Now()
+			now := start
+			prober := NewModelReadyProber(time.Minute)
+			prober.now = func() time.Time { return now }
+			status := &v1alpha1api.TrainedModelStatus{}
+			for i, probe := range scenario.probes {
+				now = start.Add(probe)
+				probeErr := prober.Probe(context.TODO(), isvc, "model")
+				condition := prober.condition("model", status.GetCondition(v1alpha1api.ModelReady), probeErr)
+				g.Expect(condition.Status).To(gomega.Equal(scenario.expected[i].Status))
+				g.Expect(condition.Reason).To(gomega.Equal(scenario.expected[i].Reason))
+				if condition.IsFalse() {
+					// the status records the body of the failure
+					g.Expect(condition.Message).To(gomega.ContainSubstring("Model with name model does not exist."))
+				}
+				status.SetCondition(v1alpha1api.ModelReady, condition)
+				if i == 0 {
+					// the transition time of the first probe is the start of the timeout
+					status.GetCondition(v1alpha1api.ModelReady).LastTransitionTime = apis.VolatileTime{Inner: metav1.NewTime(start)}
+				}
+			}
+		})
+	}
+}
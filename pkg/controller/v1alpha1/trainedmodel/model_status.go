@@ -0,0 +1,147 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trainedmodel
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"knative.dev/pkg/apis"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/kserve/kserve/pkg/agent/status"
+	v1alpha1api "github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	v1beta1api "github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+	v1beta1utils "github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/utils"
+)
+
+const (
+	ModelLoadFailed = "Failed to load the model after %d attempts: %s"
+	// ModelStatusRequeueInterval is the interval of the reconciliations while the model is loading
+	ModelStatusRequeueInterval = 10 * time.Second
+)
+
+// ModelStatusReader reads the model statuses reported by the agents of the InferenceService, one report per agent
+type ModelStatusReader interface {
+	ReadModelStatuses(ctx context.Context, isvc *v1beta1api.InferenceService) ([]map[string]status.ModelStatus, error)
+}
+
+// AgentModelStatusReader reads the model statuses from the agent of each running predictor pod
+type AgentModelStatusReader struct {
+	Client     client.Client
+	HTTPClient *http.Client
+}
+
+func NewAgentModelStatusReader(client client.Client) *AgentModelStatusReader {
+	return &AgentModelStatusReader{
+		Client:     client,
+		HTTPClient: &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+func (r *AgentModelStatusReader) ReadModelStatuses(ctx context.Context, isvc *v1beta1api.InferenceService) ([]map[string]status.ModelStatus, error) {
+	pods, err := v1beta1utils.ListPodsByLabel(r.Client, isvc.Namespace, constants.InferenceServicePodLabelKey, isvc.Name)
+	if err != nil {
+		return nil, err
+	}
+	reports := make([]map[string]status.ModelStatus, 0, len(pods.Items))
+	for i := range pods.Items {
+		pod := &pods.Items[i]
+		if pod.Labels[constants.KServiceComponentLabel] != string(v1beta1api.PredictorComponent) ||
+			pod.Status.Phase != v1.PodRunning || pod.Status.PodIP == "" || pod.DeletionTimestamp != nil {
+			continue
+		}
+		report, err := r.readAgent(ctx, pod)
+		if err != nil {
+			// the agent may not be started yet, the model statuses are read on the next reconciliation
+			log.Info("Failed to read the model statuses of the agent", "pod", pod.Name, "error", err.Error())
+			continue
+		}
+		reports = append(reports, report)
+	}
+	return reports, nil
+}
+
+func (r *AgentModelStatusReader) readAgent(ctx context.Context, pod *v1.Pod) (map[string]status.ModelStatus, error) {
+	url := fmt.Sprintf("http://%s:%d%s", pod.Status.PodIP, status.DefaultPort, status.Path)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := r.HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	report := map[string]status.ModelStatus{}
+	if err := json.NewDecoder(resp.Body).Decode(&report); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// modelLoadedCondition returns the ModelLoaded condition of the model from the reports of the agents, the model
+// failing if an agent failed to load it, and being loaded once all the agents loaded it
+func modelLoadedCondition(modelName string, reports []map[string]status.ModelStatus) *apis.Condition {
+	if len(reports) == 0 {
+		return nil
+	}
+	loaded := 0
+	state := status.Pending
+	for _, report := range reports {
+		modelStatus, ok := report[modelName]
+		switch {
+		case !ok:
+			// the agent did not sync the model config yet
+		case modelStatus.State == status.Failed:
+			reason := "ModelLoadFailed"
+			if modelStatus.Code != "" {
+				reason = modelStatus.Code
+			}
+			return &apis.Condition{
+				Type:    v1alpha1api.ModelLoaded,
+				Status:  v1.ConditionFalse,
+				Reason:  reason,
+				Message: fmt.Sprintf(ModelLoadFailed, modelStatus.Attempts, modelStatus.Reason),
+			}
+		case modelStatus.State == status.Loaded:
+			loaded++
+		case modelStatus.State == status.Loading:
+			state = status.Loading
+		}
+	}
+	if loaded == len(reports) {
+		return &apis.Condition{
+			Type:   v1alpha1api.ModelLoaded,
+			Status: v1.ConditionTrue,
+		}
+	}
+	return &apis.Condition{
+		Type:    v1alpha1api.ModelLoaded,
+		Status:  v1.ConditionUnknown,
+		Reason:  string(state),
+		Message: fmt.Sprintf("The model is loaded by %d of %d replicas", loaded, len(reports)),
+	}
+}
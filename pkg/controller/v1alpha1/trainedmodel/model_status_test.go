@@ -0,0 +1,104 @@
+/*
+Copyright 2021 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package trainedmodel
+
+import (
+	"testing"
+
+	"github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	"knative.dev/pkg/apis"
+
+	"github.com/kserve/kserve/pkg/agent/status"
+	v1alpha1api "github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+)
+
+func TestModelLoadedCondition(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	scenarios := map[string]struct {
+		reports  []map[string]status.ModelStatus
+		expected *apis.Condition
+	}{
+		"NoAgent": {
+			reports:  []map[string]status.ModelStatus{},
+			expected: nil,
+		},
+		"ModelNotSynced": {
+			reports: []map[string]status.ModelStatus{
+				{"other": {State: status.Loaded}},
+			},
+			expected: &apis.Condition{
+				Type:    v1alpha1api.ModelLoaded,
+				Status:  v1.ConditionUnknown,
+				Reason:  string(status.Pending),
+				Message: "The model is loaded by 0 of 1 replicas",
+			},
+		},
+		"ModelLoading": {
+			reports: []map[string]status.ModelStatus{
+				{"model": {State: status.Loaded, Attempts: 1}},
+				{"model": {State: status.Loading, Attempts: 1}},
+			},
+			expected: &apis.Condition{
+				Type:    v1alpha1api.ModelLoaded,
+				Status:  v1.ConditionUnknown,
+				Reason:  string(status.Loading),
+				Message: "The model is loaded by 1 of 2 replicas",
+			},
+		},
+		"ModelLoaded": {
+			reports: []map[string]status.ModelStatus{
+				{"model": {State: status.Loaded, Attempts: 1}},
+				{"model": {State: status.Loaded, Attempts: 2}},
+			},
+			expected: &apis.Condition{
+				Type:   v1alpha1api.ModelLoaded,
+				Status: v1.ConditionTrue,
+			},
+		},
+		"ModelFailed": {
+			reports: []map[string]status.ModelStatus{
+				{"model": {State: status.Loaded, Attempts: 1}},
+				{"model": {State: status.Failed, Attempts: 4, Reason: "failed to download model"}},
+			},
+			expected: &apis.Condition{
+				Type:    v1alpha1api.ModelLoaded,
+				Status:  v1.ConditionFalse,
+				Reason:  "ModelLoadFailed",
+				Message: "Failed to load the model after 4 attempts: failed to download model",
+			},
+		},
+		"InsufficientDiskSpace": {
+			reports: []map[string]status.ModelStatus{
+				{"model": {State: status.Failed, Attempts: 1, Reason: "insufficient disk space", Code: "InsufficientDiskSpace"}},
+			},
+			expected: &apis.Condition{
+				Type:    v1alpha1api.ModelLoaded,
+				Status:  v1.ConditionFalse,
+				Reason:  "InsufficientDiskSpace",
+				Message: "Failed to load the model after 1 attempts: insufficient disk space",
+			},
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g.Expect(modelLoadedCondition("model", scenario.reports)).To(gomega.Equal(scenario.expected))
+		})
+	}
+}
@@ -19,13 +19,20 @@ package modelconfig
 import (
 	"context"
 	"fmt"
+	"sync"
+	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/retry"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
 
 	v1alpha1api "github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
 	"github.com/kserve/kserve/pkg/constants"
@@ -35,60 +42,150 @@ import (
 
 var log = logf.Log.WithName("Reconciler")
 
+// DefaultBatchWindow is the time the model changes are collected before the model configmap is updated
+const DefaultBatchWindow = 100 * time.Millisecond
+
+var modelConfigUpdates = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "kserve_modelconfig_updates_total",
+	Help: "Number of the updates of the model configmaps by result: updated, skipped, conflict or failed",
+}, []string{"result"})
+
+func init() {
+	metrics.Registry.MustRegister(modelConfigUpdates)
+}
+
 type ModelConfigReconciler struct {
 	client    client.Client
 	clientset kubernetes.Interface
 	scheme    *runtime.Scheme
+	// BatchWindow is the time the model changes are collected before the model configmap is updated
+	BatchWindow time.Duration
+	mu          sync.Mutex
+	// batches holds the model changes being collected by model configmap
+	batches map[types.NamespacedName]*configBatch
+}
+
+// configBatch holds the model changes applied to a model configmap by the same update
+type configBatch struct {
+	updated map[string]modelconfig.ModelConfig
+	deleted map[string]bool
+	// done is closed once the update is over, err is its result
+	done chan struct{}
+	err  error
 }
 
 func NewModelConfigReconciler(client client.Client, clientset kubernetes.Interface, scheme *runtime.Scheme) *ModelConfigReconciler {
 	return &ModelConfigReconciler{
-		client:    client,
-		clientset: clientset,
-		scheme:    scheme,
+		client:      client,
+		clientset:   clientset,
+		scheme:      scheme,
+		BatchWindow: DefaultBatchWindow,
+		batches:     map[types.NamespacedName]*configBatch{},
 	}
 }
 
+// Reconcile adds, updates or removes the model of the TrainedModel in the model configmap of its InferenceService.
+// The changes of the TrainedModels reconciled within BatchWindow are applied by a single update, Reconcile returns
+// once the update of its change is over.
 func (c *ModelConfigReconciler) Reconcile(req ctrl.Request, tm *v1alpha1api.TrainedModel) error {
 	log.Info("Reconciling TrainedModel", "apiVersion", tm.APIVersion, "trainedmodel", tm.Spec)
 	shardStrategy := memory.MemoryStrategy{}
 	shardId := shardStrategy.GetOrAssignShard(tm)
 	// Use tm's parent InferenceService field to get the model modelConfig
-	modelConfigName := constants.ModelConfigName(tm.Spec.InferenceService, shardId)
-	log.Info("Reconciling modelConfig", "modelConfigName", modelConfigName, "namespace", req.Namespace)
-	desiredModelConfig, err := c.clientset.CoreV1().ConfigMaps(req.Namespace).Get(context.TODO(), modelConfigName, metav1.GetOptions{})
-	if err != nil {
-		log.Error(err, "Failed to find model ConfigMap to reconcile for InferenceService", "name", tm.Spec.Model, "namespace", req.Namespace)
-		// Error reading the object - requeue the request.
-		return err
+	modelConfigName := types.NamespacedName{
+		Namespace: req.Namespace,
+		Name:      constants.ModelConfigName(tm.Spec.InferenceService, shardId),
+	}
+	log.Info("Reconciling modelConfig", "modelConfigName", modelConfigName.Name, "namespace", req.Namespace)
+	batch := c.enqueue(modelConfigName, tm)
+	<-batch.done
+	return batch.err
+}
+
+// enqueue adds the change of the TrainedModel to the batch of the model configmap, starting the batch if needed
+func (c *ModelConfigReconciler) enqueue(modelConfigName types.NamespacedName, tm *v1alpha1api.TrainedModel) *configBatch {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	batch, ok := c.batches[modelConfigName]
+	if !ok {
+		batch = &configBatch{
+			updated: map[string]modelconfig.ModelConfig{},
+			deleted: map[string]bool{},
+			done:    make(chan struct{}),
+		}
+		c.batches[modelConfigName] = batch
+		time.AfterFunc(c.BatchWindow, func() {
+			c.flush(modelConfigName, batch)
+		})
 	}
 	if tm.DeletionTimestamp != nil {
 		// A TrainedModel is being deleted, remove the model from the model configmap
-		deletedConfigs := []string{tm.Name}
-		configDelta := modelconfig.NewConfigsDelta([]modelconfig.ModelConfig{}, deletedConfigs)
-		err := configDelta.Process(desiredModelConfig)
-		if err != nil {
-			return fmt.Errorf("Can not remove model %v from config because of error %w", tm.Name, err)
-		}
-		// Update the model Config created by the InferenceService controller
-		err = c.client.Update(context.TODO(), desiredModelConfig)
-		if err != nil {
-			return err
-		}
+		delete(batch.updated, tm.Name)
+		batch.deleted[tm.Name] = true
 	} else {
 		// A TrainedModel is created or updated, add or update the model from the model configmap
-		modelConfig := modelconfig.ModelConfig{Name: tm.Name, Spec: tm.Spec.Model}
-		updatedConfigs := []modelconfig.ModelConfig{modelConfig}
-		configDelta := modelconfig.NewConfigsDelta(updatedConfigs, nil)
-		err := configDelta.Process(desiredModelConfig)
+		delete(batch.deleted, tm.Name)
+		batch.updated[tm.Name] = modelconfig.ModelConfig{Name: tm.Name, Spec: tm.Spec.Model}
+	}
+	return batch
+}
+
+// flush applies the changes of the batch, the changes enqueued from now on start a new batch
+func (c *ModelConfigReconciler) flush(modelConfigName types.NamespacedName, batch *configBatch) {
+	c.mu.Lock()
+	delete(c.batches, modelConfigName)
+	c.mu.Unlock()
+	batch.err = c.update(modelConfigName, batch)
+	close(batch.done)
+}
+
+// update applies the changes of the batch to the model configmap, skipping the update when the models are the same.
+// On conflict, the changes are applied again to the latest model configmap so that the concurrent updates are merged.
+func (c *ModelConfigReconciler) update(modelConfigName types.NamespacedName, batch *configBatch) error {
+	updatedConfigs := make(modelconfig.ModelConfigs, 0, len(batch.updated))
+	for _, modelConfig := range batch.updated {
+		updatedConfigs = append(updatedConfigs, modelConfig)
+	}
+	deletedConfigs := make([]string, 0, len(batch.deleted))
+	for name := range batch.deleted {
+		deletedConfigs = append(deletedConfigs, name)
+	}
+	configMaps := c.clientset.CoreV1().ConfigMaps(modelConfigName.Namespace)
+	result := "updated"
+	err := retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		desiredModelConfig, err := configMaps.Get(context.TODO(), modelConfigName.Name, metav1.GetOptions{})
 		if err != nil {
-			return fmt.Errorf("Can not add or update a model %v from config because of error %w", tm.Name, err)
+			log.Error(err, "Failed to find model ConfigMap to reconcile", "name", modelConfigName.Name, "namespace", modelConfigName.Namespace)
+			// Error reading the object - requeue the request.
+			return err
 		}
+		previousModels := desiredModelConfig.Data[constants.ModelConfigFileName]
+		configDelta := modelconfig.NewConfigsDelta(updatedConfigs, deletedConfigs)
+		if err := configDelta.Process(desiredModelConfig); err != nil {
+			return fmt.Errorf("Can not update the models %v and remove the models %v from config because of error %w",
+				batch.updated, deletedConfigs, err)
+		}
+		hash := modelconfig.ContentHash(desiredModelConfig)
+		if desiredModelConfig.Data[constants.ModelConfigFileName] == previousModels &&
+			desiredModelConfig.Annotations[constants.ModelConfigHashAnnotationKey] == hash {
+			result = "skipped"
+			return nil
+		}
+		if desiredModelConfig.Annotations == nil {
+			desiredModelConfig.Annotations = map[string]string{}
+		}
+		desiredModelConfig.Annotations[constants.ModelConfigHashAnnotationKey] = hash
 		// Update the model Config created by the InferenceService controller
-		err = c.client.Update(context.TODO(), desiredModelConfig)
-		if err != nil {
-			return err
+		_, err = configMaps.Update(context.TODO(), desiredModelConfig, metav1.UpdateOptions{})
+		if apierr.IsConflict(err) {
+			modelConfigUpdates.WithLabelValues("conflict").Inc()
 		}
+		return err
+	})
+	if err != nil {
+		modelConfigUpdates.WithLabelValues("failed").Inc()
+		return err
 	}
+	modelConfigUpdates.WithLabelValues(result).Inc()
 	return nil
 }
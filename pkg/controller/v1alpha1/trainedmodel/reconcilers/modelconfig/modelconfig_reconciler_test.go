@@ -0,0 +1,188 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package modelconfig
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	v1 "k8s.io/api/core/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	fakeclientset "k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	v1alpha1api "github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/kserve/kserve/pkg/modelconfig"
+)
+
+const testNamespace = "default"
+
+func newTestModelConfig() *v1.ConfigMap {
+	return &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      constants.ModelConfigName("isvc", 0),
+			Namespace: testNamespace,
+		},
+		Data: map[string]string{
+			constants.ModelConfigFileName: "[]",
+		},
+	}
+}
+
+func newTestTrainedModel(name string) *v1alpha1api.TrainedModel {
+	return &v1alpha1api.TrainedModel{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: testNamespace,
+		},
+		Spec: v1alpha1api.TrainedModelSpec{
+			InferenceService: "isvc",
+			Model: v1alpha1api.ModelSpec{
+				StorageURI: "s3://models/" + name,
+				Framework:  "sklearn",
+				Memory:     resource.MustParse("1Gi"),
+			},
+		},
+	}
+}
+
+// countUpdates counts the updates of the configmaps of the clientset
+func countUpdates(clientset *fakeclientset.Clientset) *int32 {
+	var updates int32
+	clientset.PrependReactor("update", "configmaps", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		atomic.AddInt32(&updates, 1)
+		return false, nil, nil
+	})
+	return &updates
+}
+
+func reconcile(reconciler *ModelConfigReconciler, tm *v1alpha1api.TrainedModel) error {
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Namespace: tm.Namespace, Name: tm.Name}}
+	return reconciler.Reconcile(req, tm)
+}
+
+func readModels(g *gomega.WithT, clientset *fakeclientset.Clientset) []string {
+	configMap, err := clientset.CoreV1().ConfigMaps(testNamespace).Get(context.TODO(), constants.ModelConfigName("isvc", 0), metav1.GetOptions{})
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(configMap.Annotations[constants.ModelConfigHashAnnotationKey]).To(gomega.Equal(modelconfig.ContentHash(configMap)))
+	modelConfigs := modelconfig.ModelConfigs{}
+	g.Expect(json.Unmarshal([]byte(configMap.Data[constants.ModelConfigFileName]), &modelConfigs)).To(gomega.Succeed())
+	names := make([]string, 0, len(modelConfigs))
+	for _, modelConfig := range modelConfigs {
+		names = append(names, modelConfig.Name)
+	}
+	return names
+}
+
+func TestModelConfigReconcilerBatchesConcurrentChanges(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	clientset := fakeclientset.NewSimpleClientset(newTestModelConfig())
+	updates := countUpdates(clientset)
+	reconciler := NewModelConfigReconciler(nil, clientset, nil)
+
+	expected := make([]string, 0, 50)
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		tm := newTestTrainedModel(fmt.Sprintf("model-%02d", i))
+		expected = append(expected, tm.Name)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			g.Expect(reconcile(reconciler, tm)).To(gomega.Succeed())
+		}()
+	}
+	wg.Wait()
+
+	// the creations are applied by a few updates instead of one per TrainedModel
+	g.Expect(atomic.LoadInt32(updates)).To(gomega.BeNumerically("<=", 2))
+	g.Expect(readModels(g, clientset)).To(gomega.Equal(expected))
+}
+
+func TestModelConfigReconcilerSkipsNoOpUpdates(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	clientset := fakeclientset.NewSimpleClientset(newTestModelConfig())
+	updates := countUpdates(clientset)
+	reconciler := NewModelConfigReconciler(nil, clientset, nil)
+	reconciler.BatchWindow = time.Millisecond
+
+	tm := newTestTrainedModel("model")
+	g.Expect(reconcile(reconciler, tm)).To(gomega.Succeed())
+	g.Expect(atomic.LoadInt32(updates)).To(gomega.Equal(int32(1)))
+
+	skipped := testutil.ToFloat64(modelConfigUpdates.WithLabelValues("skipped"))
+	g.Expect(reconcile(reconciler, tm)).To(gomega.Succeed())
+	g.Expect(atomic.LoadInt32(updates)).To(gomega.Equal(int32(1)))
+	g.Expect(testutil.ToFloat64(modelConfigUpdates.WithLabelValues("skipped")) - skipped).To(gomega.Equal(1.0))
+
+	// the deletion changes the models
+	now := metav1.Now()
+	tm.DeletionTimestamp = &now
+	g.Expect(reconcile(reconciler, tm)).To(gomega.Succeed())
+	g.Expect(atomic.LoadInt32(updates)).To(gomega.Equal(int32(2)))
+	g.Expect(readModels(g, clientset)).To(gomega.BeEmpty())
+}
+
+func TestModelConfigReconcilerMergesOnConflict(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	clientset := fakeclientset.NewSimpleClientset(newTestModelConfig())
+	reconciler := NewModelConfigReconciler(nil, clientset, nil)
+	reconciler.BatchWindow = time.Millisecond
+
+	// another writer adds a model before the first update, which conflicts
+	conflicted := false
+	clientset.PrependReactor("update", "configmaps", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if conflicted {
+			return false, nil, nil
+		}
+		conflicted = true
+		concurrent := newTestModelConfig()
+		configDelta := modelconfig.NewConfigsDelta(modelconfig.ModelConfigs{{Name: "other"}}, nil)
+		g.Expect(configDelta.Process(concurrent)).To(gomega.Succeed())
+		gvr := schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}
+		g.Expect(clientset.Tracker().Update(gvr, concurrent, testNamespace)).To(gomega.Succeed())
+		return true, nil, apierr.NewConflict(gvr.GroupResource(), concurrent.Name, fmt.Errorf("the object has been modified"))
+	})
+	conflicts := testutil.ToFloat64(modelConfigUpdates.WithLabelValues("conflict"))
+
+	g.Expect(reconcile(reconciler, newTestTrainedModel("model"))).To(gomega.Succeed())
+	g.Expect(testutil.ToFloat64(modelConfigUpdates.WithLabelValues("conflict")) - conflicts).To(gomega.Equal(1.0))
+	g.Expect(readModels(g, clientset)).To(gomega.Equal([]string{"model", "other"}))
+}
+
+func TestModelConfigReconcilerMissingConfigMap(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	clientset := fakeclientset.NewSimpleClientset()
+	reconciler := NewModelConfigReconciler(nil, clientset, nil)
+	reconciler.BatchWindow = time.Millisecond
+
+	err := reconcile(reconciler, newTestTrainedModel("model"))
+	g.Expect(apierr.IsNotFound(err)).To(gomega.BeTrue())
+}
@@ -62,6 +62,24 @@ func addLoggerAnnotations(logger *v1beta1.LoggerSpec, annotations map[string]str
 		if logger.URL != nil {
 			annotations[constants.LoggerSinkUrlInternalAnnotationKey] = *logger.URL
 		}
+		if logger.SecretName != nil {
+			annotations[constants.LoggerSinkSecretInternalAnnotationKey] = *logger.SecretName
+		}
+		if logger.SamplingRate != nil {
+			annotations[constants.LoggerSamplingRateInternalAnnotationKey] = *logger.SamplingRate
+		}
+		if logger.MaxPayloadBytes != nil {
+			annotations[constants.LoggerMaxPayloadBytesInternalAnnotationKey] = strconv.FormatInt(*logger.MaxPayloadBytes, 10)
+		}
+		if logger.PayloadOverflow != "" {
+			annotations[constants.LoggerPayloadOverflowInternalAnnotationKey] = string(logger.PayloadOverflow)
+		}
+		if len(logger.ContentTypeAllowList) > 0 {
+			annotations[constants.LoggerContentTypesInternalAnnotationKey] = strings.Join(logger.ContentTypeAllowList, ",")
+		}
+		if len(logger.MetadataHeaders) > 0 {
+			annotations[constants.LoggerMetadataHeadersInternalAnnotationKey] = strings.Join(logger.MetadataHeaders, ",")
+		}
 		annotations[constants.LoggerModeInternalAnnotationKey] = string(logger.Mode)
 	}
 }
@@ -78,6 +96,14 @@ func addBatcherAnnotations(batcher *v1beta1.Batcher, annotations map[string]stri
 			s := strconv.Itoa(*batcher.MaxLatency)
 			annotations[constants.BatcherMaxLatencyInternalAnnotationKey] = s
 		}
+		if batcher.MaxInFlight != nil {
+			s := strconv.Itoa(*batcher.MaxInFlight)
+			annotations[constants.BatcherMaxInFlightInternalAnnotationKey] = s
+		}
+		if batcher.MaxQueueSize != nil {
+			s := strconv.Itoa(*batcher.MaxQueueSize)
+			annotations[constants.BatcherMaxQueueSizeInternalAnnotationKey] = s
+		}
 	}
 }
 
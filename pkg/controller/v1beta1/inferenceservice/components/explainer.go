@@ -85,21 +85,21 @@ func (e *Explainer) Reconcile(isvc *v1beta1.InferenceService) (ctrl.Result, erro
 	}
 	addLoggerAnnotations(isvc.Spec.Explainer.Logger, annotations)
 
-	explainerName := constants.ExplainerServiceName(isvc.Name)
-	predictorName := constants.PredictorServiceName(isvc.Name)
+	explainerName := constants.ExplainerServiceName(isvc.GeneratedNamePrefix())
+	predictorName := constants.PredictorServiceName(isvc.GeneratedNamePrefix())
 	if e.deploymentMode == constants.RawDeployment {
 		existing := &v1.Service{}
-		err := e.client.Get(context.TODO(), types.NamespacedName{Name: constants.DefaultExplainerServiceName(isvc.Name), Namespace: isvc.Namespace}, existing)
+		err := e.client.Get(context.TODO(), types.NamespacedName{Name: constants.DefaultExplainerServiceName(isvc.GeneratedNamePrefix()), Namespace: isvc.Namespace}, existing)
 		if err == nil {
-			explainerName = constants.DefaultExplainerServiceName(isvc.Name)
-			predictorName = constants.DefaultPredictorServiceName(isvc.Name)
+			explainerName = constants.DefaultExplainerServiceName(isvc.GeneratedNamePrefix())
+			predictorName = constants.DefaultPredictorServiceName(isvc.GeneratedNamePrefix())
 		}
 	} else {
 		existing := &knservingv1.Service{}
-		err := e.client.Get(context.TODO(), types.NamespacedName{Name: constants.DefaultExplainerServiceName(isvc.Name), Namespace: isvc.Namespace}, existing)
+		err := e.client.Get(context.TODO(), types.NamespacedName{Name: constants.DefaultExplainerServiceName(isvc.GeneratedNamePrefix()), Namespace: isvc.Namespace}, existing)
 		if err == nil {
-			explainerName = constants.DefaultExplainerServiceName(isvc.Name)
-			predictorName = constants.DefaultPredictorServiceName(isvc.Name)
+			explainerName = constants.DefaultExplainerServiceName(isvc.GeneratedNamePrefix())
+			predictorName = constants.DefaultPredictorServiceName(isvc.GeneratedNamePrefix())
 		}
 	}
 
@@ -155,15 +155,20 @@ func (e *Explainer) Reconcile(isvc *v1beta1.InferenceService) (ctrl.Result, erro
 			return ctrl.Result{}, errors.Wrapf(err, "fails to set service owner reference for explainer")
 		}
 		// set autoscaler Controller
-		if err := r.Scaler.Autoscaler.SetControllerReferences(isvc, e.scheme); err != nil {
+		if err := r.Scaler.SetControllerReferences(isvc, e.scheme); err != nil {
 			return ctrl.Result{}, errors.Wrapf(err, "fails to set autoscaler owner references for explainer")
 		}
+		// set PDB Controller
+		if err := r.PDB.SetControllerReferences(isvc, e.scheme); err != nil {
+			return ctrl.Result{}, errors.Wrapf(err, "fails to set pdb owner references for explainer")
+		}
 
-		deployment, err := r.Reconcile()
+		deployment, hpa, err := r.Reconcile()
 		if err != nil {
 			return ctrl.Result{}, errors.Wrapf(err, "fails to reconcile explainer")
 		}
 		isvc.Status.PropagateRawStatus(v1beta1.ExplainerComponent, deployment, r.URL)
+		isvc.Status.PropagateRawAutoscalerStatus(v1beta1.ExplainerComponent, hpa)
 	} else {
 		r := knative.NewKsvcReconciler(e.client, e.scheme, objectMeta, &isvc.Spec.Explainer.ComponentExtensionSpec,
 			&podSpec, isvc.Status.Components[v1beta1.ExplainerComponent])
@@ -0,0 +1,53 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package components
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var inferenceServicesOnDisabledRuntime = prometheus.NewGauge(prometheus.GaugeOpts{
+	Name: "kserve_inferenceservices_on_disabled_runtime",
+	Help: "Number of the InferenceServices still served by the disabled ServingRuntime they reference",
+})
+
+// onDisabledRuntime is the set of the InferenceServices counted by inferenceServicesOnDisabledRuntime
+var onDisabledRuntime = struct {
+	sync.Mutex
+	keys map[types.NamespacedName]struct{}
+}{keys: map[types.NamespacedName]struct{}{}}
+
+func init() {
+	metrics.Registry.MustRegister(inferenceServicesOnDisabledRuntime)
+}
+
+// TrackDisabledRuntime records whether the InferenceService is served by a disabled runtime. The controller
+// untracks the InferenceServices which are deleted.
+func TrackDisabledRuntime(key types.NamespacedName, disabled bool) {
+	onDisabledRuntime.Lock()
+	defer onDisabledRuntime.Unlock()
+	if disabled {
+		onDisabledRuntime.keys[key] = struct{}{}
+	} else {
+		delete(onDisabledRuntime.keys, key)
+	}
+	inferenceServicesOnDisabledRuntime.Set(float64(len(onDisabledRuntime.keys)))
+}
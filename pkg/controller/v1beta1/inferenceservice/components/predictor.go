@@ -18,15 +18,19 @@ package components
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
 	v1 "k8s.io/api/core/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/record"
 	knservingv1 "knative.dev/serving/pkg/apis/serving/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -51,19 +55,23 @@ type Predictor struct {
 	clientset              kubernetes.Interface
 	scheme                 *runtime.Scheme
 	inferenceServiceConfig *v1beta1.InferenceServicesConfig
-	credentialBuilder      *credentials.CredentialBuilder //nolint: unused
+	credentialBuilder      *credentials.CredentialBuilder
 	deploymentMode         constants.DeploymentModeType
+	recorder               record.EventRecorder
 	Log                    logr.Logger
 }
 
 func NewPredictor(client client.Client, clientset kubernetes.Interface, scheme *runtime.Scheme,
-	inferenceServiceConfig *v1beta1.InferenceServicesConfig, deploymentMode constants.DeploymentModeType) Component {
+	inferenceServiceConfig *v1beta1.InferenceServicesConfig, credentialBuilder *credentials.CredentialBuilder,
+	deploymentMode constants.DeploymentModeType, recorder record.EventRecorder) Component {
 	return &Predictor{
 		client:                 client,
 		clientset:              clientset,
 		scheme:                 scheme,
 		inferenceServiceConfig: inferenceServiceConfig,
+		credentialBuilder:      credentialBuilder,
 		deploymentMode:         deploymentMode,
+		recorder:               recorder,
 		Log:                    ctrl.Log.WithName("PredictorReconciler"),
 	}
 }
@@ -111,16 +119,26 @@ func (p *Predictor) Reconcile(isvc *v1beta1.InferenceService) (ctrl.Result, erro
 				return ctrl.Result{}, err
 			}
 
-			if r.IsDisabled() {
-				isvc.Status.UpdateModelTransitionStatus(v1beta1.InvalidSpec, &v1beta1.FailureInfo{
-					Reason:  v1beta1.RuntimeDisabled,
-					Message: "Specified runtime is disabled",
-				})
-				return ctrl.Result{}, fmt.Errorf("specified runtime %s is disabled", *isvc.Spec.Predictor.Model.Runtime)
+			if r.Spec.IsDisabled() {
+				// A disabled runtime is drained: it keeps serving the InferenceServices it was already serving
+				if isvc.Status.ModelStatus.Runtime == nil || isvc.Status.ModelStatus.Runtime.Name != r.Name {
+					TrackDisabledRuntime(types.NamespacedName{Name: isvc.Name, Namespace: isvc.Namespace}, false)
+					isvc.Status.UpdateModelTransitionStatus(v1beta1.InvalidSpec, &v1beta1.FailureInfo{
+						Reason:  v1beta1.RuntimeDisabled,
+						Message: "Specified runtime is disabled",
+					})
+					return ctrl.Result{}, fmt.Errorf("specified runtime %s is disabled", *isvc.Spec.Predictor.Model.Runtime)
+				}
+				if !isvc.Status.IsConditionReady(v1beta1.Degraded) {
+					p.recorder.Eventf(isvc, v1.EventTypeWarning, string(v1beta1.RuntimeDisabled),
+						"The runtime %s is disabled, move the InferenceService to another runtime", r.Name)
+				}
 			}
+			isvc.Status.SetRuntimeDisabled(r.Name, r.Spec.IsDisabled())
+			TrackDisabledRuntime(types.NamespacedName{Name: isvc.Name, Namespace: isvc.Namespace}, r.Spec.IsDisabled())
 
 			if isvc.Spec.Predictor.Model.ProtocolVersion != nil &&
-				!r.IsProtocolVersionSupported(*isvc.Spec.Predictor.Model.ProtocolVersion) {
+				!r.Spec.IsProtocolVersionSupported(*isvc.Spec.Predictor.Model.ProtocolVersion) {
 				isvc.Status.UpdateModelTransitionStatus(v1beta1.InvalidSpec, &v1beta1.FailureInfo{
 					Reason:  v1beta1.NoSupportingRuntime,
 					Message: "Specified runtime does not support specified protocol version",
@@ -129,7 +147,7 @@ func (p *Predictor) Reconcile(isvc *v1beta1.InferenceService) (ctrl.Result, erro
 			}
 
 			// Verify that the selected runtime supports the specified framework.
-			if !isvc.Spec.Predictor.Model.RuntimeSupportsModel(r) {
+			if !isvc.Spec.Predictor.Model.RuntimeSupportsModel(&r.Spec) {
 				isvc.Status.UpdateModelTransitionStatus(v1beta1.InvalidSpec, &v1beta1.FailureInfo{
 					Reason:  v1beta1.NoSupportingRuntime,
 					Message: "Specified runtime does not support specified framework/version",
@@ -137,22 +155,29 @@ func (p *Predictor) Reconcile(isvc *v1beta1.InferenceService) (ctrl.Result, erro
 				return ctrl.Result{}, fmt.Errorf("specified runtime %s does not support specified framework/version", *isvc.Spec.Predictor.Model.Runtime)
 			}
 
-			sRuntime = *r
+			sRuntime = r.Spec
+			isvc.Status.SetRuntimeStatus(r)
 		} else {
-			runtimes, err := isvc.Spec.Predictor.Model.GetSupportingRuntimes(p.client, isvc.Namespace, false)
+			selected, rejections, err := isvc.Spec.Predictor.Model.SelectRuntime(p.client, isvc.Namespace, false)
 			if err != nil {
 				return ctrl.Result{}, err
 			}
-			if len(runtimes) == 0 {
+			if selected == nil {
 				isvc.Status.UpdateModelTransitionStatus(v1beta1.InvalidSpec, &v1beta1.FailureInfo{
 					Reason:  v1beta1.NoSupportingRuntime,
 					Message: "No runtime found to support specified framework/version",
 				})
+				p.recorder.Eventf(isvc, v1.EventTypeWarning, string(v1beta1.NoSupportingRuntime),
+					"No runtime found to support the model format %s: %s", isvc.Spec.Predictor.Model.ModelFormat.Name,
+					strings.Join(rejections, "; "))
 				return ctrl.Result{}, fmt.Errorf("no runtime found to support predictor with model type: %v", isvc.Spec.Predictor.Model.ModelFormat)
 			}
-			// Get first supporting runtime.
-			sRuntime = runtimes[0].Spec
-			isvc.Spec.Predictor.Model.Runtime = &runtimes[0].Name
+			// Get first supporting runtime, auto-selection skips the disabled runtimes.
+			sRuntime = selected.Spec
+			isvc.Status.SetRuntimeDisabled(selected.Name, false)
+			TrackDisabledRuntime(types.NamespacedName{Name: isvc.Name, Namespace: isvc.Namespace}, false)
+			isvc.Spec.Predictor.Model.Runtime = &selected.Name
+			isvc.Status.SetRuntimeStatus(selected)
 
 			// set runtime defaults
 			isvc.SetRuntimeDefaults()
@@ -204,18 +229,25 @@ func (p *Predictor) Reconcile(isvc *v1beta1.InferenceService) (ctrl.Result, erro
 			return ctrl.Result{}, errors.Wrapf(err, "failed to consolidate serving runtime PodSpecs")
 		}
 
-		// Replace placeholders in runtime container by values from inferenceservice metadata
-		if err = isvcutils.ReplacePlaceholders(container, isvc.ObjectMeta); err != nil {
+		// Replace placeholders in runtime container by values from inferenceservice metadata and model spec
+		if err = isvcutils.ReplacePlaceholders(container, isvcutils.NewPlaceholderData(isvc)); err != nil {
 			isvc.Status.UpdateModelTransitionStatus(v1beta1.InvalidSpec, &v1beta1.FailureInfo{
 				Reason:  v1beta1.InvalidPredictorSpec,
 				Message: "Failed to replace placeholders in serving runtime Container",
 			})
+			p.recorder.Eventf(isvc, v1.EventTypeWarning, string(v1beta1.InvalidPredictorSpec),
+				"Failed to replace placeholders in the serving runtime container: %v", err)
 			return ctrl.Result{}, errors.Wrapf(err, "failed to replace placeholders in serving runtime Container")
 		}
 
 		// Update image tag if GPU is enabled or runtime version is provided
 		isvcutils.UpdateImageTag(container, isvc.Spec.Predictor.Model.RuntimeVersion, isvc.Spec.Predictor.Model.Runtime)
 
+		// Raw deployments have no queue proxy to gate traffic, so probe the model server health endpoint
+		if p.deploymentMode == constants.RawDeployment {
+			isvcutils.SetDefaultReadinessProbe(container, *isvc.Spec.Predictor.Model.ProtocolVersion, isvcutils.GetModelName(isvc))
+		}
+
 		podSpec = *mergedPodSpec
 		podSpec.Containers = []v1.Container{
 			*container,
@@ -254,18 +286,67 @@ func (p *Predictor) Reconcile(isvc *v1beta1.InferenceService) (ctrl.Result, erro
 		}
 	}
 
-	predictorName := constants.PredictorServiceName(isvc.Name)
+	// The adapters of the model are provisioned by their own storage initializers, the injector reads them from
+	// an annotation like the model storage uri
+	if isvc.Spec.Predictor.Model != nil && len(isvc.Spec.Predictor.Model.Adapters) > 0 {
+		for _, adapter := range isvc.Spec.Predictor.Model.Adapters {
+			if err := isvcutils.ValidateStorageURI(&adapter.StorageURI, p.client); err != nil {
+				return ctrl.Result{}, fmt.Errorf("StorageURI of adapter %s not supported: %w", adapter.Name, err)
+			}
+		}
+		adapters, err := json.Marshal(isvc.Spec.Predictor.Model.Adapters)
+		if err != nil {
+			return ctrl.Result{}, errors.Wrapf(err, "fails to marshal the adapters of the predictor")
+		}
+		annotations[constants.StorageInitializerAdaptersInternalAnnotationKey] = string(adapters)
+	}
+
+	// Bind the predictor to a service account carrying the cloud IAM identity of the storage key,
+	// a service account specified by the user is never replaced. The service account created earlier
+	// is deleted once the predictor no longer uses it.
+	if p.credentialBuilder != nil {
+		iamServiceAccountName := ""
+		if storageSpec := predictor.GetStorageSpec(); storageSpec != nil && podSpec.ServiceAccountName == "" {
+			var storageKey string
+			if storageSpec.StorageKey != nil {
+				storageKey = *storageSpec.StorageKey
+			}
+			var params map[string]string
+			if storageSpec.Parameters != nil {
+				params = *storageSpec.Parameters
+			}
+			var err error
+			iamServiceAccountName, err = p.credentialBuilder.CreateIAMServiceAccount(isvc, p.scheme, annotations, storageKey, params)
+			if err != nil {
+				return ctrl.Result{}, errors.Wrapf(err, "fails to reconcile IAM service account for predictor")
+			}
+			if iamServiceAccountName != "" {
+				podSpec.ServiceAccountName = iamServiceAccountName
+			}
+		}
+		// the service account is recorded in the status, so that it is only looked up when it was created
+		if recorded := isvc.Status.Components[v1beta1.PredictorComponent].IAMServiceAccountName; recorded != iamServiceAccountName {
+			if recorded != "" && iamServiceAccountName == "" {
+				if err := p.credentialBuilder.DeleteIAMServiceAccount(isvc); err != nil {
+					return ctrl.Result{}, errors.Wrapf(err, "fails to delete IAM service account for predictor")
+				}
+			}
+			isvc.Status.SetIAMServiceAccountName(v1beta1.PredictorComponent, iamServiceAccountName)
+		}
+	}
+
+	predictorName := constants.PredictorServiceName(isvc.GeneratedNamePrefix())
 	if p.deploymentMode == constants.RawDeployment {
 		existing := &v1.Service{}
-		err := p.client.Get(context.TODO(), types.NamespacedName{Name: constants.DefaultPredictorServiceName(isvc.Name), Namespace: isvc.Namespace}, existing)
+		err := p.client.Get(context.TODO(), types.NamespacedName{Name: constants.DefaultPredictorServiceName(isvc.GeneratedNamePrefix()), Namespace: isvc.Namespace}, existing)
 		if err == nil {
-			predictorName = constants.DefaultPredictorServiceName(isvc.Name)
+			predictorName = constants.DefaultPredictorServiceName(isvc.GeneratedNamePrefix())
 		}
 	} else {
 		existing := &knservingv1.Service{}
-		err := p.client.Get(context.TODO(), types.NamespacedName{Name: constants.DefaultPredictorServiceName(isvc.Name), Namespace: isvc.Namespace}, existing)
+		err := p.client.Get(context.TODO(), types.NamespacedName{Name: constants.DefaultPredictorServiceName(isvc.GeneratedNamePrefix()), Namespace: isvc.Namespace}, existing)
 		if err == nil {
-			predictorName = constants.DefaultPredictorServiceName(isvc.Name)
+			predictorName = constants.DefaultPredictorServiceName(isvc.GeneratedNamePrefix())
 		}
 	}
 
@@ -320,15 +401,27 @@ func (p *Predictor) Reconcile(isvc *v1beta1.InferenceService) (ctrl.Result, erro
 			return ctrl.Result{}, errors.Wrapf(err, "fails to set service owner reference for predictor")
 		}
 		// set autoscaler Controller
-		if err := r.Scaler.Autoscaler.SetControllerReferences(isvc, p.scheme); err != nil {
+		if err := r.Scaler.SetControllerReferences(isvc, p.scheme); err != nil {
 			return ctrl.Result{}, errors.Wrapf(err, "fails to set autoscaler owner references for predictor")
 		}
+		// set PDB Controller
+		if err := r.PDB.SetControllerReferences(isvc, p.scheme); err != nil {
+			return ctrl.Result{}, errors.Wrapf(err, "fails to set pdb owner references for predictor")
+		}
+		// set VPA Controller
+		if err := r.VPA.SetControllerReferences(isvc, p.scheme); err != nil {
+			return ctrl.Result{}, errors.Wrapf(err, "fails to set vpa owner references for predictor")
+		}
 
-		deployment, err := r.Reconcile()
+		if err := p.dryRunChildResources(isvc, r.Deployment.Deployment, &r.Deployment.Deployment.Spec.Template); err != nil {
+			return ctrl.Result{}, err
+		}
+		deployment, hpa, err := r.Reconcile()
 		if err != nil {
 			return ctrl.Result{}, errors.Wrapf(err, "fails to reconcile predictor")
 		}
 		isvc.Status.PropagateRawStatus(v1beta1.PredictorComponent, deployment, r.URL)
+		isvc.Status.PropagateRawAutoscalerStatus(v1beta1.PredictorComponent, hpa)
 	} else {
 		podLabelKey = constants.RevisionLabel
 		r := knative.NewKsvcReconciler(p.client, p.scheme, objectMeta, &isvc.Spec.Predictor.ComponentExtensionSpec,
@@ -336,6 +429,21 @@ func (p *Predictor) Reconcile(isvc *v1beta1.InferenceService) (ctrl.Result, erro
 		if err := controllerutil.SetControllerReference(isvc, r.Service, p.scheme); err != nil {
 			return ctrl.Result{}, errors.Wrapf(err, "fails to set owner reference for predictor")
 		}
+		if revision, ok := canaryPromotionHold(isvc); ok {
+			r.HoldRollout(revision)
+			if statusSpec := isvc.Status.Components[v1beta1.PredictorComponent]; statusSpec.LatestCreatedRevision != revision {
+				p.recorder.Eventf(isvc, v1.EventTypeWarning, "CanaryPromotionHeld",
+					"The traffic is held on the revision %s until the model of the revision %s is loaded", revision,
+					statusSpec.LatestCreatedRevision)
+			}
+		}
+		template := &v1.PodTemplateSpec{
+			ObjectMeta: r.Service.Spec.Template.ObjectMeta,
+			Spec:       r.Service.Spec.Template.Spec.PodSpec,
+		}
+		if err := p.dryRunChildResources(isvc, r.Service, template); err != nil {
+			return ctrl.Result{}, err
+		}
 		status, err := r.Reconcile()
 		if err != nil {
 			return ctrl.Result{}, errors.Wrapf(err, "fails to reconcile predictor")
@@ -352,6 +460,65 @@ func (p *Predictor) Reconcile(isvc *v1beta1.InferenceService) (ctrl.Result, erro
 	if err != nil {
 		return ctrl.Result{}, errors.Wrapf(err, "fails to list inferenceservice pods by label")
 	}
+	if err := isvcutils.AnnotateStorageInitializerDuration(p.client, predictorPods); err != nil {
+		return ctrl.Result{}, errors.Wrapf(err, "fails to annotate inferenceservice pods with the storage initializer duration")
+	}
 	isvc.Status.PropagateModelStatus(statusSpec, predictorPods, rawDeployment)
+	// Report the model state of both revisions while a canary revision is rolled out
+	if !rawDeployment {
+		var stablePods *v1.PodList
+		if statusSpec.LatestRolledoutRevision != "" && statusSpec.LatestCreatedRevision != statusSpec.LatestRolledoutRevision {
+			stablePods, err = isvcutils.ListPodsByLabel(p.client, isvc.ObjectMeta.Namespace, podLabelKey,
+				statusSpec.LatestRolledoutRevision)
+			if err != nil {
+				return ctrl.Result{}, errors.Wrapf(err, "fails to list inferenceservice pods by label")
+			}
+		}
+		isvc.Status.PropagateRevisionModelStatus(statusSpec, stablePods, predictorPods)
+	}
 	return ctrl.Result{}, nil
 }
+
+// canaryPromotionHold returns the last rolled out revision to hold the traffic on, when the promotion of the latest
+// revision requires its model to be loaded and the model of the latest revision is not reported as loaded yet
+func canaryPromotionHold(isvc *v1beta1.InferenceService) (string, bool) {
+	if isvc.ObjectMeta.Annotations[constants.CanaryRequireModelLoadedAnnotationKey] != "true" {
+		return "", false
+	}
+	// The traffic is split with the canary traffic percent
+	if percent := isvc.Spec.Predictor.CanaryTrafficPercent; percent != nil && *percent < 100 {
+		return "", false
+	}
+	statusSpec := isvc.Status.Components[v1beta1.PredictorComponent]
+	if statusSpec.LatestRolledoutRevision == "" {
+		return "", false
+	}
+	canary := isvc.Status.ModelStatus.CanaryRevision
+	if canary != nil && canary.Name == statusSpec.LatestCreatedRevision && canary.State == v1beta1.Loaded {
+		return "", false
+	}
+	return statusSpec.LatestRolledoutRevision, true
+}
+
+// dryRunChildResources dry-runs the creation of the predictor child resource when enabled in the deploy config, and
+// fails the model transition with the message of the API server when it is forbidden or invalid.
+func (p *Predictor) dryRunChildResources(isvc *v1beta1.InferenceService, child client.Object, template *v1.PodTemplateSpec) error {
+	deployConfig, err := v1beta1.NewDeployConfig(p.clientset)
+	if err != nil || !deployConfig.DryRunChildResources {
+		return nil
+	}
+	err = isvcutils.DryRunCreate(p.client, child, template)
+	if err == nil {
+		return nil
+	}
+	// a request of the controller denied by its own RBAC is not a rejection of the user spec
+	if (apierr.IsForbidden(err) && !isvcutils.IsRBACForbidden(err)) || apierr.IsInvalid(err) {
+		isvc.Status.UpdateModelTransitionStatus(v1beta1.InvalidSpec, &v1beta1.FailureInfo{
+			Reason:  v1beta1.ResourceRejected,
+			Message: err.Error(),
+		})
+		p.recorder.Eventf(isvc, v1.EventTypeWarning, string(v1beta1.ResourceRejected),
+			"The predictor %s was rejected by the API server: %v", child.GetName(), err)
+	}
+	return errors.Wrapf(err, "fails to dry-run the predictor %s", child.GetName())
+}
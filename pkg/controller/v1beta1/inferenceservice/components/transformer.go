@@ -87,21 +87,21 @@ func (p *Transformer) Reconcile(isvc *v1beta1.InferenceService) (ctrl.Result, er
 	addLoggerAnnotations(isvc.Spec.Transformer.Logger, annotations)
 	addBatcherAnnotations(isvc.Spec.Transformer.Batcher, annotations)
 
-	transformerName := constants.TransformerServiceName(isvc.Name)
-	predictorName := constants.PredictorServiceName(isvc.Name)
+	transformerName := constants.TransformerServiceName(isvc.GeneratedNamePrefix())
+	predictorName := constants.PredictorServiceName(isvc.GeneratedNamePrefix())
 	if p.deploymentMode == constants.RawDeployment {
 		existing := &corev1.Service{}
-		err := p.client.Get(context.TODO(), types.NamespacedName{Name: constants.DefaultTransformerServiceName(isvc.Name), Namespace: isvc.Namespace}, existing)
+		err := p.client.Get(context.TODO(), types.NamespacedName{Name: constants.DefaultTransformerServiceName(isvc.GeneratedNamePrefix()), Namespace: isvc.Namespace}, existing)
 		if err == nil {
-			transformerName = constants.DefaultTransformerServiceName(isvc.Name)
-			predictorName = constants.DefaultPredictorServiceName(isvc.Name)
+			transformerName = constants.DefaultTransformerServiceName(isvc.GeneratedNamePrefix())
+			predictorName = constants.DefaultPredictorServiceName(isvc.GeneratedNamePrefix())
 		}
 	} else {
 		existing := &knservingv1.Service{}
-		err := p.client.Get(context.TODO(), types.NamespacedName{Name: constants.DefaultTransformerServiceName(isvc.Name), Namespace: isvc.Namespace}, existing)
+		err := p.client.Get(context.TODO(), types.NamespacedName{Name: constants.DefaultTransformerServiceName(isvc.GeneratedNamePrefix()), Namespace: isvc.Namespace}, existing)
 		if err == nil {
-			transformerName = constants.DefaultTransformerServiceName(isvc.Name)
-			predictorName = constants.DefaultPredictorServiceName(isvc.Name)
+			transformerName = constants.DefaultTransformerServiceName(isvc.GeneratedNamePrefix())
+			predictorName = constants.DefaultPredictorServiceName(isvc.GeneratedNamePrefix())
 		}
 	}
 
@@ -184,15 +184,20 @@ func (p *Transformer) Reconcile(isvc *v1beta1.InferenceService) (ctrl.Result, er
 			return ctrl.Result{}, errors.Wrapf(err, "fails to set service owner reference for transformer")
 		}
 		// set autoscaler Controller
-		if err := r.Scaler.Autoscaler.SetControllerReferences(isvc, p.scheme); err != nil {
+		if err := r.Scaler.SetControllerReferences(isvc, p.scheme); err != nil {
 			return ctrl.Result{}, errors.Wrapf(err, "fails to set autoscaler owner references for transformer")
 		}
+		// set PDB Controller
+		if err := r.PDB.SetControllerReferences(isvc, p.scheme); err != nil {
+			return ctrl.Result{}, errors.Wrapf(err, "fails to set pdb owner references for transformer")
+		}
 
-		deployment, err := r.Reconcile()
+		deployment, hpa, err := r.Reconcile()
 		if err != nil {
 			return ctrl.Result{}, errors.Wrapf(err, "fails to reconcile transformer")
 		}
 		isvc.Status.PropagateRawStatus(v1beta1.TransformerComponent, deployment, r.URL)
+		isvc.Status.PropagateRawAutoscalerStatus(v1beta1.TransformerComponent, hpa)
 	} else {
 		r := knative.NewKsvcReconciler(p.client, p.scheme, objectMeta, &isvc.Spec.Transformer.ComponentExtensionSpec,
 			&podSpec, isvc.Status.Components[v1beta1.TransformerComponent])
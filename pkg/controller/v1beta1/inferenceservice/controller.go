@@ -20,15 +20,21 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"strconv"
+	"strings"
 
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
 	istioclientv1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
 	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
+	netv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	apierr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
@@ -37,17 +43,25 @@ import (
 	"knative.dev/pkg/apis"
 	knservingv1 "knative.dev/serving/pkg/apis/serving/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	gatewayapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayapiv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
 
 	v1alpha1api "github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
 	v1beta1api "github.com/kserve/kserve/pkg/apis/serving/v1beta1"
 	"github.com/kserve/kserve/pkg/constants"
+	"github.com/kserve/kserve/pkg/controller/metrics"
 	"github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/components"
 	"github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/reconcilers/cabundleconfigmap"
+	"github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/reconcilers/destinationrule"
 	"github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/reconcilers/ingress"
 	modelconfig "github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/reconcilers/modelconfig"
 	isvcutils "github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/utils"
+	"github.com/kserve/kserve/pkg/credentials"
 	"github.com/kserve/kserve/pkg/utils"
 )
 
@@ -58,23 +72,37 @@ import (
 // +kubebuilder:rbac:groups=serving.kserve.io,resources=clusterservingruntimes/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=serving.kserve.io,resources=clusterstoragecontainers,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=apps,resources=deployments/scale,verbs=get;update
 // +kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=networking.k8s.io,resources=ingressclasses,verbs=get;list;watch
+// +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=httproutes,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=grpcroutes,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=gateway.networking.k8s.io,resources=gateways,verbs=get;list;watch
+// +kubebuilder:rbac:groups=route.openshift.io,resources=routes,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=route.openshift.io,resources=routes/custom-host,verbs=create
 // +kubebuilder:rbac:groups=serving.kserve.io,resources=inferenceservices/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=serving.knative.dev,resources=services,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=serving.knative.dev,resources=services/finalizers,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=serving.knative.dev,resources=services/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=serving.knative.dev,resources=domainmappings,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=networking.istio.io,resources=virtualservices,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=networking.istio.io,resources=virtualservices/finalizers,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=networking.istio.io,resources=destinationrules,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=networking.istio.io,resources=virtualservices/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=networking.istio.io,resources=gateways,verbs=get;list;watch
 // +kubebuilder:rbac:groups=admissionregistration.k8s.io,resources=mutatingwebhookconfigurations;validatingwebhookconfigurations,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=autoscaling,resources=horizontalpodautoscalers,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=keda.sh,resources=scaledobjects,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=autoscaling.k8s.io,resources=verticalpodautoscalers,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=core,resources=services,verbs=get;list;watch;create;update;patch;delete
-// +kubebuilder:rbac:groups=core,resources=serviceaccounts,verbs=get
+// +kubebuilder:rbac:groups=core,resources=serviceaccounts,verbs=get;create;update;delete
 // +kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;create
 // +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;create;update;patch;delete
 // +kubebuilder:rbac:groups=core,resources=namespaces,verbs=get;list;watch
 // +kubebuilder:rbac:groups=core,resources=events,verbs=get;list;watch;create;update;patch;delete
-// +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;watch;create;patch
+// +kubebuilder:rbac:groups=core,resources=persistentvolumeclaims,verbs=get
 
 // InferenceState describes the Readiness of the InferenceService
 type InferenceServiceState string
@@ -104,6 +132,8 @@ func (r *InferenceServiceReconciler) Reconcile(ctx context.Context, req ctrl.Req
 		if apierr.IsNotFound(err) {
 			// Object not found, return.  Created objects are automatically garbage collected.
 			// For additional cleanup logic use finalizers.
+			components.TrackDisabledRuntime(req.NamespacedName, false)
+			metrics.UntrackInferenceService(req.NamespacedName)
 			return reconcile.Result{}, nil
 		}
 		return reconcile.Result{}, err
@@ -164,6 +194,7 @@ func (r *InferenceServiceReconciler) Reconcile(ctx context.Context, req ctrl.Req
 		}
 
 		// Stop reconciliation as the item is being deleted
+		metrics.UntrackInferenceService(req.NamespacedName)
 		return ctrl.Result{}, nil
 	}
 
@@ -175,7 +206,7 @@ func (r *InferenceServiceReconciler) Reconcile(ctx context.Context, req ctrl.Req
 		}
 
 		if !ksvcAvailable {
-			r.Recorder.Event(isvc, v1.EventTypeWarning, "ServerlessModeRejected",
+			recordEvent(r.Recorder, isvc, ReasonServerlessModeRejected,
 				"It is not possible to use Serverless deployment mode when Knative Services are not available")
 			return reconcile.Result{Requeue: false}, reconcile.TerminalError(fmt.Errorf("the resolved deployment mode of InferenceService '%s' is Serverless, but Knative Serving is not available", isvc.Name))
 		}
@@ -187,6 +218,11 @@ func (r *InferenceServiceReconciler) Reconcile(ctx context.Context, req ctrl.Req
 	if err != nil {
 		return reconcile.Result{}, errors.Wrapf(err, "fails to create InferenceServicesConfig")
 	}
+	configMap, err := r.Clientset.CoreV1().ConfigMaps(constants.KServeNamespace).Get(context.TODO(), constants.InferenceServiceConfigMapName, metav1.GetOptions{})
+	if err != nil {
+		return reconcile.Result{}, errors.Wrapf(err, "fails to get inferenceservice config map")
+	}
+	credentialBuilder := credentials.NewCredentialBuilder(r.Client, r.Clientset, configMap)
 
 	// Reconcile cabundleConfigMap
 	caBundleConfigMapReconciler := cabundleconfigmap.NewCaBundleConfigMapReconciler(r.Client, r.Clientset, r.Scheme)
@@ -196,7 +232,7 @@ func (r *InferenceServiceReconciler) Reconcile(ctx context.Context, req ctrl.Req
 
 	reconcilers := []components.Component{}
 	if deploymentMode != constants.ModelMeshDeployment {
-		reconcilers = append(reconcilers, components.NewPredictor(r.Client, r.Clientset, r.Scheme, isvcConfig, deploymentMode))
+		reconcilers = append(reconcilers, components.NewPredictor(r.Client, r.Clientset, r.Scheme, isvcConfig, credentialBuilder, deploymentMode, r.Recorder))
 	}
 	if isvc.Spec.Transformer != nil {
 		reconcilers = append(reconcilers, components.NewTransformer(r.Client, r.Clientset, r.Scheme, isvcConfig, deploymentMode))
@@ -208,7 +244,7 @@ func (r *InferenceServiceReconciler) Reconcile(ctx context.Context, req ctrl.Req
 		result, err := reconciler.Reconcile(isvc)
 		if err != nil {
 			r.Log.Error(err, "Failed to reconcile", "reconciler", reflect.ValueOf(reconciler), "Name", isvc.Name)
-			r.Recorder.Eventf(isvc, v1.EventTypeWarning, "InternalError", err.Error())
+			recordEvent(r.Recorder, isvc, ReasonInternalError, "%v", err)
 			if err := r.updateStatus(isvc, deploymentMode); err != nil {
 				r.Log.Error(err, "Error updating status")
 				return result, err
@@ -219,6 +255,9 @@ func (r *InferenceServiceReconciler) Reconcile(ctx context.Context, req ctrl.Req
 			return result, nil
 		}
 	}
+	if deploymentMode == constants.RawDeployment {
+		r.reconcileAutoscalingPaused(isvc)
+	}
 	// reconcile RoutesReady and LatestDeploymentReady conditions for serverless deployment
 	if deploymentMode == constants.Serverless {
 		componentList := []v1beta1api.ComponentType{v1beta1api.PredictorComponent}
@@ -236,9 +275,24 @@ func (r *InferenceServiceReconciler) Reconcile(ctx context.Context, req ctrl.Req
 	if err != nil {
 		return reconcile.Result{}, errors.Wrapf(err, "fails to create IngressConfig")
 	}
+	ingressConfig = ingress.OverrideIngressConfig(isvc, ingressConfig)
+	r.checkIngressOverrides(isvc, ingressConfig, deploymentMode)
 
 	// check raw deployment
 	if deploymentMode == constants.RawDeployment {
+		if ingressConfig.IngressKind == constants.OpenShiftRouteKind {
+			routeFound, err := utils.IsCrdAvailable(r.ClientConfig, ingress.RouteGVK.GroupVersion().String(), constants.OpenShiftRouteKind)
+			if err != nil {
+				return reconcile.Result{}, errors.Wrapf(err, "fails to reconcile ingress")
+			}
+			if !routeFound {
+				// fall back to an Ingress on clusters without OpenShift Routes
+				r.Log.Info("The route.openshift.io/v1/Route CRD is not available, exposing the InferenceService with an Ingress", "isvc", isvc.Name)
+				config := *ingressConfig
+				config.IngressKind = v1beta1api.IngressKindIngress
+				ingressConfig = &config
+			}
+		}
 		reconciler, err := ingress.NewRawIngressReconciler(r.Client, r.Scheme, ingressConfig)
 		if err != nil {
 			return reconcile.Result{}, errors.Wrapf(err, "fails to reconcile ingress")
@@ -254,6 +308,12 @@ func (r *InferenceServiceReconciler) Reconcile(ctx context.Context, req ctrl.Req
 		}
 	}
 
+	// Reconcile destination rules
+	destinationRuleReconciler := destinationrule.NewDestinationRuleReconciler(r.Client, r.Scheme, ingressConfig)
+	if err := destinationRuleReconciler.Reconcile(isvc); err != nil {
+		return reconcile.Result{}, errors.Wrapf(err, "fails to reconcile destination rules")
+	}
+
 	// Reconcile modelConfig
 	configMapReconciler := modelconfig.NewModelConfigReconciler(r.Client, r.Clientset, r.Scheme)
 	if err := configMapReconciler.Reconcile(isvc); err != nil {
@@ -261,7 +321,7 @@ func (r *InferenceServiceReconciler) Reconcile(ctx context.Context, req ctrl.Req
 	}
 
 	if err = r.updateStatus(isvc, deploymentMode); err != nil {
-		r.Recorder.Eventf(isvc, v1.EventTypeWarning, "InternalError", err.Error())
+		recordEvent(r.Recorder, isvc, ReasonInternalError, "%v", err)
 		return reconcile.Result{}, err
 	}
 
@@ -274,7 +334,6 @@ func (r *InferenceServiceReconciler) updateStatus(desiredService *v1beta1api.Inf
 	if err := r.Get(context.TODO(), namespacedName, existingService); err != nil {
 		return err
 	}
-	wasReady := inferenceServiceReadiness(existingService.Status)
 	if inferenceServiceStatusEqual(existingService.Status, desiredService.Status, deploymentMode) {
 		// If we didn't change anything then don't call updateStatus.
 		// This is important because the copy we loaded from the informer's
@@ -282,20 +341,19 @@ func (r *InferenceServiceReconciler) updateStatus(desiredService *v1beta1api.Inf
 		// to status with this stale state.
 	} else if err := r.Status().Update(context.TODO(), desiredService); err != nil {
 		r.Log.Error(err, "Failed to update InferenceService status", "InferenceService", desiredService.Name)
-		r.Recorder.Eventf(desiredService, v1.EventTypeWarning, "UpdateFailed",
+		recordEvent(r.Recorder, desiredService, ReasonUpdateFailed,
 			"Failed to update status for InferenceService %q: %v", desiredService.Name, err)
 		return errors.Wrapf(err, "fails to update InferenceService status")
 	} else {
-		// If there was a difference and there was no error.
-		isReady := inferenceServiceReadiness(desiredService.Status)
-		if wasReady && !isReady { // Moved to NotReady State
-			r.Recorder.Eventf(desiredService, v1.EventTypeWarning, string(InferenceServiceNotReadyState),
-				fmt.Sprintf("InferenceService [%v] is no longer Ready", desiredService.GetName()))
-		} else if !wasReady && isReady { // Moved to Ready State
-			r.Recorder.Eventf(desiredService, v1.EventTypeNormal, string(InferenceServiceReadyState),
-				fmt.Sprintf("InferenceService [%v] is Ready", desiredService.GetName()))
-		}
+		// If there was a difference and there was no error, record the state transitions
+		recordStatusEvents(r.Recorder, desiredService, existingService.Status)
 	}
+	runtime := ""
+	if desiredService.Status.ModelStatus.Runtime != nil {
+		runtime = desiredService.Status.ModelStatus.Runtime.Name
+	}
+	metrics.TrackInferenceService(namespacedName, string(deploymentMode), runtime, desiredService.Generation,
+		desiredService.CreationTimestamp.Time, desiredService.Status.IsReady())
 	return nil
 }
 
@@ -333,7 +391,12 @@ func (r *InferenceServiceReconciler) SetupWithManager(mgr ctrl.Manager, deployCo
 
 	ctrlBuilder := ctrl.NewControllerManagedBy(mgr).
 		For(&v1beta1api.InferenceService{}).
-		Owns(&appsv1.Deployment{})
+		Owns(&appsv1.Deployment{}).
+		Owns(&policyv1.PodDisruptionBudget{}).
+		Watches(&v1alpha1api.ServingRuntime{}, handler.EnqueueRequestsFromMapFunc(r.servingRuntimeToInferenceServices),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&v1alpha1api.ClusterServingRuntime{}, handler.EnqueueRequestsFromMapFunc(r.clusterServingRuntimeToInferenceServices),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{}))
 
 	if ksvcFound {
 		ctrlBuilder = ctrlBuilder.Owns(&knservingv1.Service{})
@@ -347,7 +410,112 @@ func (r *InferenceServiceReconciler) SetupWithManager(mgr ctrl.Manager, deployCo
 		r.Log.Info("The InferenceService controller won't watch networking.istio.io/v1beta1/VirtualService resources because the CRD is not available.")
 	}
 
-	return ctrlBuilder.Complete(r)
+	if ingressConfig.EnableDestinationRule {
+		drFound, err := utils.IsCrdAvailable(r.ClientConfig, istioclientv1beta1.SchemeGroupVersion.String(), constants.IstioDestinationRuleKind)
+		if err != nil {
+			return err
+		}
+		if drFound {
+			ctrlBuilder = ctrlBuilder.Owns(&istioclientv1beta1.DestinationRule{})
+		} else {
+			r.Log.Info("The InferenceService controller won't watch networking.istio.io/v1beta1/DestinationRule resources because the CRD is not available.")
+		}
+	}
+
+	if ingressConfig.EnableGatewayAPI {
+		ctrlBuilder = ctrlBuilder.Owns(&gatewayapiv1.HTTPRoute{})
+		grpcRouteFound, err := utils.IsCrdAvailable(r.ClientConfig, gatewayapiv1alpha2.GroupVersion.String(), constants.GRPCRouteKind)
+		if err != nil {
+			return err
+		}
+		if grpcRouteFound {
+			ctrlBuilder = ctrlBuilder.Owns(&gatewayapiv1alpha2.GRPCRoute{})
+		} else {
+			r.Log.Info("The InferenceService controller won't watch gateway.networking.k8s.io/v1alpha2/GRPCRoute resources because the CRD is not available.")
+		}
+	}
+
+	if ingressConfig.IngressKind == constants.OpenShiftRouteKind {
+		routeFound, err := utils.IsCrdAvailable(r.ClientConfig, ingress.RouteGVK.GroupVersion().String(), constants.OpenShiftRouteKind)
+		if err != nil {
+			return err
+		}
+		if routeFound {
+			route := &unstructured.Unstructured{}
+			route.SetGroupVersionKind(ingress.RouteGVK)
+			ctrlBuilder = ctrlBuilder.Owns(route)
+		} else {
+			r.Log.Info("The InferenceService controller won't watch route.openshift.io/v1/Route resources because the CRD is not available.")
+		}
+	}
+
+	return ctrlBuilder.Complete(metrics.InstrumentReconciler(metrics.InferenceServiceController, r))
+}
+
+// servingRuntimeToInferenceServices maps a ServingRuntime to the InferenceServices of its namespace which reference it
+// by the runtime field or were reconciled with it, so a change of the runtime is rolled out to them.
+func (r *InferenceServiceReconciler) servingRuntimeToInferenceServices(ctx context.Context, obj client.Object) []reconcile.Request {
+	return r.inferenceServicesForRuntime(ctx, func(isvc *v1beta1api.InferenceService) bool {
+		return referencesRuntime(isvc, obj.GetName())
+	}, client.InNamespace(obj.GetNamespace()))
+}
+
+// clusterServingRuntimeToInferenceServices maps a ClusterServingRuntime to the InferenceServices whose ServingRuntime
+// inherits from it.
+func (r *InferenceServiceReconciler) clusterServingRuntimeToInferenceServices(ctx context.Context, obj client.Object) []reconcile.Request {
+	runtimes := &v1alpha1api.ServingRuntimeList{}
+	if err := r.List(ctx, runtimes); err != nil {
+		r.Log.Error(err, "unable to list serving runtimes")
+		return nil
+	}
+	inheriting := map[types.NamespacedName]bool{}
+	for _, sr := range runtimes.Items {
+		if sr.Spec.InheritFrom != nil && *sr.Spec.InheritFrom == obj.GetName() {
+			inheriting[types.NamespacedName{Name: sr.Name, Namespace: sr.Namespace}] = true
+		}
+	}
+	return r.inferenceServicesForRuntime(ctx, func(isvc *v1beta1api.InferenceService) bool {
+		if isvc.Status.ModelStatus.Runtime != nil && isvc.Status.ModelStatus.Runtime.InheritFrom == obj.GetName() {
+			return true
+		}
+		for key := range inheriting {
+			if key.Namespace == isvc.Namespace && referencesRuntime(isvc, key.Name) {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// referencesRuntime tells whether the runtime is set on the predictor model or recorded in the status
+func referencesRuntime(isvc *v1beta1api.InferenceService, name string) bool {
+	if isvc.Spec.Predictor.Model != nil && isvc.Spec.Predictor.Model.Runtime != nil &&
+		*isvc.Spec.Predictor.Model.Runtime == name {
+		return true
+	}
+	return isvc.Status.ModelStatus.Runtime != nil && isvc.Status.ModelStatus.Runtime.Name == name
+}
+
+// inferenceServicesForRuntime lists the InferenceServices matching the runtime, skipping the ones which opted out of
+// the automatic rollout with the runtime pinned annotation.
+func (r *InferenceServiceReconciler) inferenceServicesForRuntime(ctx context.Context, matches func(*v1beta1api.InferenceService) bool,
+	opts ...client.ListOption) []reconcile.Request {
+	isvcs := &v1beta1api.InferenceServiceList{}
+	if err := r.List(ctx, isvcs, opts...); err != nil {
+		r.Log.Error(err, "unable to list inference services")
+		return nil
+	}
+	var requests []reconcile.Request
+	for i := range isvcs.Items {
+		isvc := &isvcs.Items[i]
+		if pinned, _ := strconv.ParseBool(isvc.Annotations[constants.RuntimePinnedAnnotationKey]); pinned {
+			continue
+		}
+		if matches(isvc) {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: isvc.Name, Namespace: isvc.Namespace}})
+		}
+	}
+	return requests
 }
 
 func (r *InferenceServiceReconciler) deleteExternalResources(isvc *v1beta1api.InferenceService) error {
@@ -371,3 +539,55 @@ func (r *InferenceServiceReconciler) deleteExternalResources(isvc *v1beta1api.In
 	}
 	return nil
 }
+
+// reconcileAutoscalingPaused reflects the autoscaling paused annotation in the status and records an event when
+// autoscaling is paused or resumed.
+func (r *InferenceServiceReconciler) reconcileAutoscalingPaused(isvc *v1beta1api.InferenceService) {
+	paused, _ := strconv.ParseBool(isvc.Annotations[constants.AutoscalingPausedAnnotationKey])
+	if paused == isvc.Status.IsConditionReady(v1beta1api.AutoscalingPaused) {
+		return
+	}
+	isvc.Status.SetAutoscalingPaused(paused)
+	if paused {
+		recordEvent(r.Recorder, isvc, ReasonAutoscalingPaused,
+			"Autoscaling is paused by the %s annotation", constants.AutoscalingPausedAnnotationKey)
+	} else {
+		recordEvent(r.Recorder, isvc, ReasonAutoscalingResumed, "Autoscaling is resumed")
+	}
+}
+
+// checkIngressOverrides records a warning event when the ingress gateway or the ingress class referenced by the
+// annotations of the InferenceService does not exist.
+func (r *InferenceServiceReconciler) checkIngressOverrides(isvc *v1beta1api.InferenceService, ingressConfig *v1beta1api.IngressConfig, deploymentMode constants.DeploymentModeType) {
+	if _, ok := isvc.Annotations[constants.IngressGatewayAnnotationKey]; ok {
+		if deploymentMode == constants.RawDeployment && ingressConfig.EnableGatewayAPI {
+			r.checkIngressResourceExists(isvc, &gatewayapiv1.Gateway{},
+				types.NamespacedName{Namespace: ingressConfig.GatewayNamespace, Name: ingressConfig.GatewayName})
+		} else if deploymentMode != constants.RawDeployment && !ingressConfig.DisableIstioVirtualHost {
+			// an istio gateway without namespace refers to the namespace of the virtual service
+			namespace, name, found := strings.Cut(ingressConfig.IngressGateway, "/")
+			if !found {
+				namespace, name = isvc.Namespace, ingressConfig.IngressGateway
+			}
+			r.checkIngressResourceExists(isvc, &istioclientv1beta1.Gateway{}, types.NamespacedName{Namespace: namespace, Name: name})
+		}
+	}
+	if _, ok := isvc.Annotations[constants.IngressClassAnnotationKey]; ok {
+		if deploymentMode == constants.RawDeployment && !ingressConfig.EnableGatewayAPI {
+			r.checkIngressResourceExists(isvc, &netv1.IngressClass{}, types.NamespacedName{Name: *ingressConfig.IngressClassName})
+		}
+	}
+}
+
+func (r *InferenceServiceReconciler) checkIngressResourceExists(isvc *v1beta1api.InferenceService, obj client.Object, key types.NamespacedName) {
+	err := r.Get(context.TODO(), key, obj)
+	if err == nil || meta.IsNoMatchError(err) || runtime.IsNotRegisteredError(err) {
+		return
+	}
+	kind := reflect.TypeOf(obj).Elem().Name()
+	if apierr.IsNotFound(err) {
+		r.Recorder.Eventf(isvc, v1.EventTypeWarning, kind+"NotFound", "%s %s referenced by the InferenceService is not found", kind, key)
+		return
+	}
+	r.Log.Error(err, "Failed to get the ingress resource referenced by the InferenceService", "kind", kind, "name", key)
+}
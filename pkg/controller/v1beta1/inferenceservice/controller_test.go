@@ -45,6 +45,7 @@ import (
 	duckv1 "knative.dev/pkg/apis/duck/v1"
 	"knative.dev/pkg/network"
 	knservingv1 "knative.dev/serving/pkg/apis/serving/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
@@ -1359,6 +1360,9 @@ var _ = Describe("v1beta1 inference service controller", func() {
 			Expect(inferenceService.Status.ModelStatus.TransitionStatus).To(Equal(v1beta1.InvalidSpec))
 			Expect(inferenceService.Status.ModelStatus.ModelRevisionStates.TargetModelState).To(Equal(v1beta1.FailedToLoad))
 			Expect(cmp.Diff(&failureInfo, inferenceService.Status.ModelStatus.LastFailureInfo)).To(gomega.Equal(""))
+			predictorReady := inferenceService.Status.GetCondition(v1beta1.PredictorReady)
+			Expect(predictorReady.Status).To(Equal(v1.ConditionFalse))
+			Expect(predictorReady.Reason).To(Equal(v1beta1.RuntimeNotFound))
 		})
 	})
 
@@ -1467,6 +1471,267 @@ var _ = Describe("v1beta1 inference service controller", func() {
 		})
 	})
 
+	Context("When the ServingRuntime of a running inference service is disabled", func() {
+		newServingRuntime := func(name string, namespace string, modelFormat string) *v1alpha1.ServingRuntime {
+			return &v1alpha1.ServingRuntime{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: namespace,
+				},
+				Spec: v1alpha1.ServingRuntimeSpec{
+					SupportedModelFormats: []v1alpha1.SupportedModelFormat{
+						{
+							Name:       modelFormat,
+							Version:    proto.String("1"),
+							AutoSelect: proto.Bool(true),
+						},
+					},
+					ServingRuntimePodSpec: v1alpha1.ServingRuntimePodSpec{
+						Containers: []v1.Container{
+							{
+								Name:      constants.InferenceServiceContainerName,
+								Image:     "tensorflow/serving:1.14.0",
+								Command:   []string{"/usr/bin/tensorflow_model_server"},
+								Resources: defaultResource,
+							},
+						},
+					},
+				},
+			}
+		}
+		newIsvc := func(name string, namespace string, modelFormat string, runtime *string) *v1beta1.InferenceService {
+			return &v1beta1.InferenceService{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: namespace,
+				},
+				Spec: v1beta1.InferenceServiceSpec{
+					Predictor: v1beta1.PredictorSpec{
+						ComponentExtensionSpec: v1beta1.ComponentExtensionSpec{
+							MinReplicas: v1beta1.GetIntReference(1),
+							MaxReplicas: 3,
+						},
+						Model: &v1beta1.ModelSpec{
+							ModelFormat: v1beta1.ModelFormat{
+								Name: modelFormat,
+							},
+							Runtime: runtime,
+							PredictorExtensionSpec: v1beta1.PredictorExtensionSpec{
+								StorageURI: proto.String("s3://test/mnist/export"),
+							},
+						},
+					},
+				},
+			}
+		}
+		disable := func(key types.NamespacedName) {
+			Expect(retry.RetryOnConflict(retry.DefaultRetry, func() error {
+				servingRuntime := &v1alpha1.ServingRuntime{}
+				if err := k8sClient.Get(ctx, key, servingRuntime); err != nil {
+					return err
+				}
+				servingRuntime.Spec.Disabled = proto.Bool(true)
+				return k8sClient.Update(ctx, servingRuntime)
+			})).NotTo(gomega.HaveOccurred())
+		}
+
+		It("Should keep serving the inference service referencing it explicitly with a Degraded condition", func() {
+			serviceName := "svc-explicit-disabled-runtime"
+			servingRuntimeName := "tf-serving-explicit-disabled"
+			namespace := "default"
+			var predictorServiceKey = types.NamespacedName{Name: serviceName, Namespace: namespace}
+
+			servingRuntime := newServingRuntime(servingRuntimeName, namespace, "tensorflow-explicit")
+			Expect(k8sClient.Create(context.TODO(), servingRuntime)).NotTo(gomega.HaveOccurred())
+			defer k8sClient.Delete(context.TODO(), servingRuntime)
+
+			var configMap = &v1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      constants.InferenceServiceConfigMapName,
+					Namespace: constants.KServeNamespace,
+				},
+				Data: configs,
+			}
+			Expect(k8sClient.Create(context.TODO(), configMap)).NotTo(gomega.HaveOccurred())
+			defer k8sClient.Delete(context.TODO(), configMap)
+
+			isvc := newIsvc(serviceName, namespace, "tensorflow-explicit", proto.String(servingRuntimeName))
+			Expect(k8sClient.Create(context.TODO(), isvc)).NotTo(gomega.HaveOccurred())
+			defer k8sClient.Delete(context.TODO(), isvc)
+
+			inferenceService := &v1beta1.InferenceService{}
+			Eventually(func() bool {
+				if err := k8sClient.Get(ctx, predictorServiceKey, inferenceService); err != nil {
+					return false
+				}
+				return inferenceService.Status.ModelStatus.Runtime != nil
+			}, timeout, interval).Should(BeTrue())
+			Expect(inferenceService.Status.ModelStatus.Runtime.Name).To(Equal(servingRuntimeName))
+
+			disable(types.NamespacedName{Name: servingRuntimeName, Namespace: namespace})
+
+			Eventually(func() bool {
+				if err := k8sClient.Get(ctx, predictorServiceKey, inferenceService); err != nil {
+					return false
+				}
+				return inferenceService.Status.IsConditionReady(v1beta1.Degraded)
+			}, timeout, interval).Should(BeTrue())
+			Expect(inferenceService.Status.GetCondition(v1beta1.Degraded).Reason).To(Equal(string(v1beta1.RuntimeDisabled)))
+			Expect(inferenceService.Status.ModelStatus.TransitionStatus).NotTo(Equal(v1beta1.InvalidSpec))
+		})
+
+		It("Should not auto-select it anymore", func() {
+			serviceName := "svc-auto-selected-disabled-runtime"
+			servingRuntimeName := "tf-serving-auto-selected-disabled"
+			namespace := "default"
+			var predictorServiceKey = types.NamespacedName{Name: serviceName, Namespace: namespace}
+
+			servingRuntime := newServingRuntime(servingRuntimeName, namespace, "tensorflow-auto-selected")
+			Expect(k8sClient.Create(context.TODO(), servingRuntime)).NotTo(gomega.HaveOccurred())
+			defer k8sClient.Delete(context.TODO(), servingRuntime)
+
+			var configMap = &v1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      constants.InferenceServiceConfigMapName,
+					Namespace: constants.KServeNamespace,
+				},
+				Data: configs,
+			}
+			Expect(k8sClient.Create(context.TODO(), configMap)).NotTo(gomega.HaveOccurred())
+			defer k8sClient.Delete(context.TODO(), configMap)
+
+			isvc := newIsvc(serviceName, namespace, "tensorflow-auto-selected", nil)
+			Expect(k8sClient.Create(context.TODO(), isvc)).NotTo(gomega.HaveOccurred())
+			defer k8sClient.Delete(context.TODO(), isvc)
+
+			inferenceService := &v1beta1.InferenceService{}
+			Eventually(func() bool {
+				if err := k8sClient.Get(ctx, predictorServiceKey, inferenceService); err != nil {
+					return false
+				}
+				return inferenceService.Status.ModelStatus.Runtime != nil
+			}, timeout, interval).Should(BeTrue())
+			Expect(inferenceService.Status.ModelStatus.Runtime.Name).To(Equal(servingRuntimeName))
+
+			disable(types.NamespacedName{Name: servingRuntimeName, Namespace: namespace})
+
+			Eventually(func() bool {
+				if err := k8sClient.Get(ctx, predictorServiceKey, inferenceService); err != nil {
+					return false
+				}
+				return inferenceService.Status.ModelStatus.LastFailureInfo != nil
+			}, timeout, interval).Should(BeTrue())
+			Expect(inferenceService.Status.ModelStatus.LastFailureInfo.Reason).To(Equal(v1beta1.NoSupportingRuntime))
+			Expect(inferenceService.Status.GetCondition(v1beta1.Degraded)).To(BeNil())
+		})
+	})
+
+	Context("When a ServingRuntime referenced by inference services is updated", func() {
+		It("Should enqueue the inference services which are not pinned", func() {
+			namespace := "default"
+			parentName := "tf-serving-parent"
+			childName := "tf-serving-child"
+			otherName := "tf-serving-other"
+
+			var clusterServingRuntime = &v1alpha1.ClusterServingRuntime{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: parentName,
+				},
+				Spec: v1alpha1.ServingRuntimeSpec{
+					SupportedModelFormats: []v1alpha1.SupportedModelFormat{
+						{
+							Name:    "tensorflow",
+							Version: proto.String("1"),
+						},
+					},
+					ServingRuntimePodSpec: v1alpha1.ServingRuntimePodSpec{
+						Containers: []v1.Container{
+							{
+								Name:      constants.InferenceServiceContainerName,
+								Image:     "tensorflow/serving:1.14.0",
+								Resources: defaultResource,
+							},
+						},
+					},
+				},
+			}
+			Expect(k8sClient.Create(context.TODO(), clusterServingRuntime)).NotTo(gomega.HaveOccurred())
+			defer k8sClient.Delete(context.TODO(), clusterServingRuntime)
+
+			for _, name := range []string{childName, otherName} {
+				servingRuntime := &v1alpha1.ServingRuntime{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      name,
+						Namespace: namespace,
+					},
+					Spec: *clusterServingRuntime.Spec.DeepCopy(),
+				}
+				if name == childName {
+					servingRuntime.Spec.InheritFrom = proto.String(parentName)
+				}
+				Expect(k8sClient.Create(context.TODO(), servingRuntime)).NotTo(gomega.HaveOccurred())
+				defer k8sClient.Delete(context.TODO(), servingRuntime)
+			}
+
+			newIsvc := func(name string, runtime string, pinned bool) *v1beta1.InferenceService {
+				isvc := &v1beta1.InferenceService{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      name,
+						Namespace: namespace,
+					},
+					Spec: v1beta1.InferenceServiceSpec{
+						Predictor: v1beta1.PredictorSpec{
+							Model: &v1beta1.ModelSpec{
+								ModelFormat: v1beta1.ModelFormat{
+									Name: "tensorflow",
+								},
+								Runtime: proto.String(runtime),
+								PredictorExtensionSpec: v1beta1.PredictorExtensionSpec{
+									StorageURI: proto.String("s3://test/mnist/export"),
+								},
+							},
+						},
+					},
+				}
+				if pinned {
+					isvc.Annotations = map[string]string{constants.RuntimePinnedAnnotationKey: "true"}
+				}
+				return isvc
+			}
+			for _, isvc := range []*v1beta1.InferenceService{
+				newIsvc("isvc-child-runtime", childName, false),
+				newIsvc("isvc-child-runtime-pinned", childName, true),
+				newIsvc("isvc-other-runtime", otherName, false),
+			} {
+				Expect(k8sClient.Create(context.TODO(), isvc)).NotTo(gomega.HaveOccurred())
+				defer k8sClient.Delete(context.TODO(), isvc)
+			}
+
+			r := &InferenceServiceReconciler{
+				Client: k8sClient,
+				Log:    ctrl.Log.WithName("V1beta1InferenceServiceController"),
+			}
+			childRequests := []reconcile.Request{
+				{NamespacedName: types.NamespacedName{Name: "isvc-child-runtime", Namespace: namespace}},
+			}
+			Eventually(func() []reconcile.Request {
+				return r.servingRuntimeToInferenceServices(ctx, &v1alpha1.ServingRuntime{
+					ObjectMeta: metav1.ObjectMeta{Name: childName, Namespace: namespace},
+				})
+			}, timeout, interval).Should(ConsistOf(childRequests))
+			Eventually(func() []reconcile.Request {
+				return r.clusterServingRuntimeToInferenceServices(ctx, clusterServingRuntime)
+			}, timeout, interval).Should(ConsistOf(childRequests))
+			Eventually(func() []reconcile.Request {
+				return r.servingRuntimeToInferenceServices(ctx, &v1alpha1.ServingRuntime{
+					ObjectMeta: metav1.ObjectMeta{Name: otherName, Namespace: namespace},
+				})
+			}, timeout, interval).Should(ConsistOf(reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: "isvc-other-runtime", Namespace: namespace},
+			}))
+		})
+	})
+
 	Context("When creating an inference service with a ServingRuntime which does not support specified model format", func() {
 		It("Should fail with reason NoSupportingRuntime", func() {
 			serviceName := "svc-with-unsupported-servingruntime"
@@ -1767,6 +2032,210 @@ var _ = Describe("v1beta1 inference service controller", func() {
 		})
 	})
 
+	Context("When a canary revision fails to download its model", func() {
+		It("Should report the canary model state and hold the traffic on the rolled out revision", func() {
+			serviceName := "canary-model-test"
+			servingRuntimeName := "tf-serving-canary"
+			namespace := "default"
+			var inferenceServiceKey = types.NamespacedName{Name: serviceName, Namespace: namespace}
+			stableRevision := serviceName + "-predictor-" + namespace + "-00001"
+			canaryRevision := serviceName + "-predictor-" + namespace + "-00002"
+
+			// Create configmap
+			var configMap = &v1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      constants.InferenceServiceConfigMapName,
+					Namespace: constants.KServeNamespace,
+				},
+				Data: configs,
+			}
+			Expect(k8sClient.Create(context.TODO(), configMap)).NotTo(gomega.HaveOccurred())
+			defer k8sClient.Delete(context.TODO(), configMap)
+
+			var servingRuntime = &v1alpha1.ServingRuntime{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      servingRuntimeName,
+					Namespace: namespace,
+				},
+				Spec: v1alpha1.ServingRuntimeSpec{
+					SupportedModelFormats: []v1alpha1.SupportedModelFormat{
+						{
+							Name:       "tensorflow",
+							Version:    proto.String("1"),
+							AutoSelect: proto.Bool(true),
+						},
+					},
+					ServingRuntimePodSpec: v1alpha1.ServingRuntimePodSpec{
+						Containers: []v1.Container{
+							{
+								Name:    constants.InferenceServiceContainerName,
+								Image:   "tensorflow/serving:1.14.0",
+								Command: []string{"/usr/bin/tensorflow_model_server"},
+								Args: []string{
+									"--port=9000",
+									"--rest_api_port=8080",
+									"--model_base_path=/mnt/models",
+									"--rest_api_timeout_in_ms=60000",
+								},
+								Resources: defaultResource,
+							},
+						},
+					},
+					Disabled: proto.Bool(false),
+				},
+			}
+			Expect(k8sClient.Create(context.TODO(), servingRuntime)).NotTo(gomega.HaveOccurred())
+			defer k8sClient.Delete(context.TODO(), servingRuntime)
+
+			var isvc = &v1beta1.InferenceService{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      serviceName,
+					Namespace: namespace,
+					Annotations: map[string]string{
+						constants.CanaryRequireModelLoadedAnnotationKey: "true",
+					},
+				},
+				Spec: v1beta1.InferenceServiceSpec{
+					Predictor: v1beta1.PredictorSpec{
+						ComponentExtensionSpec: v1beta1.ComponentExtensionSpec{
+							MinReplicas: v1beta1.GetIntReference(1),
+							MaxReplicas: 3,
+						},
+						Model: &v1beta1.ModelSpec{
+							ModelFormat: v1beta1.ModelFormat{
+								Name: "tensorflow",
+							},
+							Runtime: &servingRuntimeName,
+							PredictorExtensionSpec: v1beta1.PredictorExtensionSpec{
+								StorageURI: proto.String("s3://test/mnist/invalid"),
+							},
+						},
+					},
+				},
+			}
+			Expect(k8sClient.Create(context.TODO(), isvc)).NotTo(gomega.HaveOccurred())
+			defer k8sClient.Delete(context.TODO(), isvc)
+
+			predictorServiceKey := types.NamespacedName{Name: constants.PredictorServiceName(serviceName),
+				Namespace: namespace}
+			actualService := &knservingv1.Service{}
+			Eventually(func() error { return k8sClient.Get(context.TODO(), predictorServiceKey, actualService) }, timeout).
+				Should(Succeed())
+
+			// Roll out the first revision
+			predictorUrl, _ := apis.ParseURL("http://" + constants.InferenceServiceHostName(constants.PredictorServiceName(serviceName), namespace, domain))
+			Expect(retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+				if err := k8sClient.Get(context.TODO(), predictorServiceKey, actualService); err != nil {
+					return err
+				}
+				actualService.Status.LatestCreatedRevisionName = stableRevision
+				actualService.Status.LatestReadyRevisionName = stableRevision
+				actualService.Status.URL = predictorUrl
+				actualService.Status.Conditions = duckv1.Conditions{
+					{
+						Type:   knservingv1.ServiceConditionReady,
+						Status: "True",
+					},
+				}
+				actualService.Status.Traffic = []knservingv1.TrafficTarget{
+					{
+						RevisionName:   stableRevision,
+						LatestRevision: proto.Bool(true),
+						Percent:        proto.Int64(100),
+					},
+				}
+				return k8sClient.Status().Update(context.TODO(), actualService)
+			})).NotTo(gomega.HaveOccurred())
+
+			inferenceService := &v1beta1.InferenceService{}
+			Eventually(func() string {
+				if err := k8sClient.Get(ctx, inferenceServiceKey, inferenceService); err != nil {
+					return ""
+				}
+				return inferenceService.Status.Components[v1beta1.PredictorComponent].LatestRolledoutRevision
+			}, timeout, interval).Should(Equal(stableRevision))
+
+			// Create the canary revision with a failed model download
+			pod := &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      canaryRevision + "-deployment-76464ds2zpv",
+					Namespace: namespace,
+					Labels:    map[string]string{"serving.knative.dev/revision": canaryRevision},
+				},
+				Spec: v1.PodSpec{
+					InitContainers: []v1.Container{
+						{
+							Name:  "storage-initializer",
+							Image: "kserve/storage-initializer:latest",
+							Args: []string{
+								"s3://test/mnist/invalid",
+								"/mnt/models",
+							},
+							Resources: defaultResource,
+						},
+					},
+					Containers: []v1.Container{
+						{
+							Name:      constants.InferenceServiceContainerName,
+							Image:     "tensorflow/serving:1.14.0",
+							Resources: defaultResource,
+						},
+					},
+				},
+			}
+			Expect(k8sClient.Create(context.TODO(), pod)).NotTo(gomega.HaveOccurred())
+			defer k8sClient.Delete(context.TODO(), pod)
+
+			podStatusPatch := []byte(`{"status":{"containerStatuses":[{"image":"tensorflow/serving:1.14.0","name":"kserve-container","lastState":{},"state":{"waiting":{"reason":"PodInitializing"}}}],"initContainerStatuses":[{"image":"kserve/storage-initializer:latest","name":"storage-initializer","lastState":{"terminated":{"exitCode":1,"message":"Invalid Storage URI provided","reason":"Error"}},"state":{"waiting":{"reason":"CrashLoopBackOff"}}}]}}`)
+			Expect(k8sClient.Status().Patch(context.TODO(), pod, client.RawPatch(types.StrategicMergePatchType, podStatusPatch))).
+				NotTo(gomega.HaveOccurred())
+
+			Expect(retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+				if err := k8sClient.Get(context.TODO(), predictorServiceKey, actualService); err != nil {
+					return err
+				}
+				actualService.Status.LatestCreatedRevisionName = canaryRevision
+				actualService.Status.Conditions = duckv1.Conditions{
+					{
+						Type:   knservingv1.ServiceConditionReady,
+						Status: "False",
+					},
+				}
+				return k8sClient.Status().Update(context.TODO(), actualService)
+			})).NotTo(gomega.HaveOccurred())
+
+			Eventually(func() bool {
+				if err := k8sClient.Get(ctx, inferenceServiceKey, inferenceService); err != nil {
+					return false
+				}
+				canary := inferenceService.Status.ModelStatus.CanaryRevision
+				return canary != nil && canary.State == v1beta1.FailedToLoad
+			}, timeout, interval).Should(BeTrue())
+
+			canaryStatus := inferenceService.Status.ModelStatus.CanaryRevision
+			Expect(canaryStatus.Name).To(Equal(canaryRevision))
+			Expect(canaryStatus.TransitionStatus).To(Equal(v1beta1.BlockedByFailedLoad))
+			Expect(canaryStatus.LastFailureInfo.Reason).To(Equal(v1beta1.ModelLoadFailed))
+			Expect(canaryStatus.LastFailureInfo.Message).To(Equal("Invalid Storage URI provided"))
+			Expect(inferenceService.Status.ModelStatus.StableRevision.Name).To(Equal(stableRevision))
+
+			// The traffic is held on the rolled out revision
+			expectedTraffic := []knservingv1.TrafficTarget{
+				{
+					RevisionName:   stableRevision,
+					LatestRevision: proto.Bool(false),
+					Percent:        proto.Int64(100),
+				},
+			}
+			Eventually(func() []knservingv1.TrafficTarget {
+				if err := k8sClient.Get(context.TODO(), predictorServiceKey, actualService); err != nil {
+					return nil
+				}
+				return actualService.Spec.Traffic
+			}, timeout, interval).Should(Equal(expectedTraffic))
+		})
+	})
+
 	Context("When creating inference service with predictor and without top level istio virtual service", func() {
 		It("Should have knative service created", func() {
 			By("By creating a new InferenceService")
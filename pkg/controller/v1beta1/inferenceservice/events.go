@@ -0,0 +1,215 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inferenceservice
+
+import (
+	"fmt"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/client-go/tools/record"
+	"knative.dev/pkg/apis"
+	knservingv1 "knative.dev/serving/pkg/apis/serving/v1"
+
+	v1beta1api "github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+)
+
+// EventReason is the reason of an event recorded for an InferenceService. Alerting rules key off these reasons, so
+// renaming one is a breaking change.
+type EventReason string
+
+// Reasons of the events recorded for the state transitions of an InferenceService.
+const (
+	ReasonInferenceServiceReady    EventReason = EventReason(InferenceServiceReadyState)
+	ReasonInferenceServiceNotReady EventReason = EventReason(InferenceServiceNotReadyState)
+	ReasonRuntimeSelected          EventReason = "RuntimeSelected"
+	ReasonDeploymentCreated        EventReason = "DeploymentCreated"
+	ReasonDeploymentUpdated        EventReason = "DeploymentUpdated"
+	ReasonDeploymentReady          EventReason = "DeploymentReady"
+	ReasonDeploymentNotReady       EventReason = "DeploymentNotReady"
+	ReasonRolloutCompleted         EventReason = "RolloutCompleted"
+	ReasonTrafficShifted           EventReason = "TrafficShifted"
+	ReasonIngressReady             EventReason = "IngressReady"
+	ReasonIngressNotReady          EventReason = "IngressNotReady"
+	ReasonAutoscalerUpdated        EventReason = "AutoscalerUpdated"
+	ReasonAutoscalingPaused        EventReason = "AutoscalingPaused"
+	ReasonAutoscalingResumed       EventReason = "AutoscalingResumed"
+	ReasonModelLoaded              EventReason = "ModelLoaded"
+	ReasonModelLoadFailed          EventReason = "ModelLoadFailed"
+	ReasonServerlessModeRejected   EventReason = "ServerlessModeRejected"
+	ReasonUpdateFailed             EventReason = "UpdateFailed"
+	ReasonInternalError            EventReason = "InternalError"
+)
+
+// eventTypes is the type of the events of each reason
+var eventTypes = map[EventReason]string{
+	ReasonInferenceServiceReady:    v1.EventTypeNormal,
+	ReasonInferenceServiceNotReady: v1.EventTypeWarning,
+	ReasonRuntimeSelected:          v1.EventTypeNormal,
+	ReasonDeploymentCreated:        v1.EventTypeNormal,
+	ReasonDeploymentUpdated:        v1.EventTypeNormal,
+	ReasonDeploymentReady:          v1.EventTypeNormal,
+	ReasonDeploymentNotReady:       v1.EventTypeWarning,
+	ReasonRolloutCompleted:         v1.EventTypeNormal,
+	ReasonTrafficShifted:           v1.EventTypeNormal,
+	ReasonIngressReady:             v1.EventTypeNormal,
+	ReasonIngressNotReady:          v1.EventTypeWarning,
+	ReasonAutoscalerUpdated:        v1.EventTypeNormal,
+	ReasonAutoscalingPaused:        v1.EventTypeNormal,
+	ReasonAutoscalingResumed:       v1.EventTypeNormal,
+	ReasonModelLoaded:              v1.EventTypeNormal,
+	ReasonModelLoadFailed:          v1.EventTypeWarning,
+	ReasonServerlessModeRejected:   v1.EventTypeWarning,
+	ReasonUpdateFailed:             v1.EventTypeWarning,
+	ReasonInternalError:            v1.EventTypeWarning,
+}
+
+// componentReadyConditions is the ready condition of each component
+var componentReadyConditions = map[v1beta1api.ComponentType]apis.ConditionType{
+	v1beta1api.PredictorComponent:   v1beta1api.PredictorReady,
+	v1beta1api.TransformerComponent: v1beta1api.TransformerReady,
+	v1beta1api.ExplainerComponent:   v1beta1api.ExplainerReady,
+}
+
+// recordEvent records an event of the given reason, with the type of the reason
+func recordEvent(recorder record.EventRecorder, isvc *v1beta1api.InferenceService, reason EventReason,
+	messageFmt string, args ...interface{}) {
+	recorder.Eventf(isvc, eventTypes[reason], string(reason), messageFmt, args...)
+}
+
+// recordStatusEvents records an event for each state transition from the previous status to the current status of
+// the InferenceService
+func recordStatusEvents(recorder record.EventRecorder, isvc *v1beta1api.InferenceService,
+	previous v1beta1api.InferenceServiceStatus) {
+	current := isvc.Status
+	if current.ModelStatus.Runtime != nil && (previous.ModelStatus.Runtime == nil ||
+		previous.ModelStatus.Runtime.Name != current.ModelStatus.Runtime.Name) {
+		recordEvent(recorder, isvc, ReasonRuntimeSelected, "The ServingRuntime %s is selected to serve the model",
+			current.ModelStatus.Runtime.Name)
+	}
+	for _, component := range []v1beta1api.ComponentType{v1beta1api.PredictorComponent,
+		v1beta1api.TransformerComponent, v1beta1api.ExplainerComponent} {
+		recordComponentEvents(recorder, isvc, component, previous)
+	}
+	if transition, ok := conditionTransition(previous, current, v1beta1api.IngressReady); ok {
+		if transition {
+			recordEvent(recorder, isvc, ReasonIngressReady, "The ingress of the InferenceService is ready")
+		} else {
+			recordEvent(recorder, isvc, ReasonIngressNotReady, "The ingress of the InferenceService is not ready: %s",
+				current.GetCondition(v1beta1api.IngressReady).GetMessage())
+		}
+	}
+	if targetModelState(current) != targetModelState(previous) {
+		switch targetModelState(current) {
+		case v1beta1api.Loaded:
+			recordEvent(recorder, isvc, ReasonModelLoaded, "The model is loaded")
+		case v1beta1api.FailedToLoad:
+			message := ""
+			if info := current.ModelStatus.LastFailureInfo; info != nil {
+				message = fmt.Sprintf(": %s %s", info.Reason, info.Message)
+			}
+			recordEvent(recorder, isvc, ReasonModelLoadFailed, "The model failed to load%s", message)
+		}
+	}
+	wasReady, isReady := inferenceServiceReadiness(previous), inferenceServiceReadiness(current)
+	if wasReady && !isReady {
+		recordEvent(recorder, isvc, ReasonInferenceServiceNotReady, "InferenceService [%v] is no longer Ready",
+			isvc.GetName())
+	} else if !wasReady && isReady {
+		recordEvent(recorder, isvc, ReasonInferenceServiceReady, "InferenceService [%v] is Ready", isvc.GetName())
+	}
+}
+
+// recordComponentEvents records an event for each state transition of the deployment, the traffic and the
+// autoscaler of the component
+func recordComponentEvents(recorder record.EventRecorder, isvc *v1beta1api.InferenceService,
+	component v1beta1api.ComponentType, previous v1beta1api.InferenceServiceStatus) {
+	current, ok := isvc.Status.Components[component]
+	if !ok {
+		return
+	}
+	before := previous.Components[component]
+	if current.LatestCreatedRevision != "" && current.LatestCreatedRevision != before.LatestCreatedRevision {
+		if before.LatestCreatedRevision == "" {
+			recordEvent(recorder, isvc, ReasonDeploymentCreated, "The %s revision %s is created", component,
+				current.LatestCreatedRevision)
+		} else {
+			recordEvent(recorder, isvc, ReasonDeploymentUpdated, "The %s is updated from revision %s to revision %s",
+				component, before.LatestCreatedRevision, current.LatestCreatedRevision)
+		}
+	}
+	if transition, ok := conditionTransition(previous, isvc.Status, componentReadyConditions[component]); ok {
+		if transition {
+			recordEvent(recorder, isvc, ReasonDeploymentReady, "The %s is ready", component)
+		} else {
+			recordEvent(recorder, isvc, ReasonDeploymentNotReady, "The %s is not ready: %s", component,
+				isvc.Status.GetCondition(componentReadyConditions[component]).GetMessage())
+		}
+	}
+	if current.LatestRolledoutRevision != "" && current.LatestRolledoutRevision != before.LatestRolledoutRevision {
+		recordEvent(recorder, isvc, ReasonRolloutCompleted, "The %s revision %s is rolled out", component,
+			current.LatestRolledoutRevision)
+	}
+	if len(current.Traffic) > 0 && !equality.Semantic.DeepEqual(current.Traffic, before.Traffic) {
+		recordEvent(recorder, isvc, ReasonTrafficShifted, "The %s traffic is shifted to %s", component,
+			formatTraffic(current.Traffic))
+	}
+	if current.DesiredReplicas != nil && (before.DesiredReplicas == nil ||
+		*before.DesiredReplicas != *current.DesiredReplicas) {
+		recordEvent(recorder, isvc, ReasonAutoscalerUpdated, "The %s autoscaler desires %d replicas", component,
+			*current.DesiredReplicas)
+	}
+}
+
+// conditionTransition returns whether the condition transitioned to true or away from true, and false when its
+// readiness did not change
+func conditionTransition(previous, current v1beta1api.InferenceServiceStatus,
+	conditionType apis.ConditionType) (bool, bool) {
+	condition := current.GetCondition(conditionType)
+	if condition == nil || condition.IsUnknown() {
+		return false, false
+	}
+	wasReady := previous.IsConditionReady(conditionType)
+	isReady := condition.IsTrue()
+	return isReady, wasReady != isReady
+}
+
+// targetModelState returns the target model state of the status, if any
+func targetModelState(status v1beta1api.InferenceServiceStatus) v1beta1api.ModelState {
+	if status.ModelStatus.ModelRevisionStates == nil {
+		return ""
+	}
+	return status.ModelStatus.ModelRevisionStates.TargetModelState
+}
+
+// formatTraffic returns the traffic targets as a list of revision=percent
+func formatTraffic(traffic []knservingv1.TrafficTarget) string {
+	targets := make([]string, 0, len(traffic))
+	for _, target := range traffic {
+		revision := target.RevisionName
+		if target.LatestRevision != nil && *target.LatestRevision && revision == "" {
+			revision = "latest"
+		}
+		percent := int64(0)
+		if target.Percent != nil {
+			percent = *target.Percent
+		}
+		targets = append(targets, fmt.Sprintf("%s=%d%%", revision, percent))
+	}
+	return strings.Join(targets, ", ")
+}
@@ -0,0 +1,226 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package inferenceservice
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/onsi/gomega"
+	"google.golang.org/protobuf/proto"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	knservingv1 "knative.dev/serving/pkg/apis/serving/v1"
+
+	v1beta1api "github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+)
+
+func TestRecordStatusEvents(t *testing.T) {
+	conditions := func(conditions ...apis.Condition) duckv1.Status {
+		return duckv1.Status{Conditions: conditions}
+	}
+	runtime := &v1beta1api.RuntimeStatus{Name: "kserve-sklearnserver"}
+
+	// The steps of the life of an InferenceService, from its creation to its update and a failure to load its model
+	steps := []struct {
+		name           string
+		status         v1beta1api.InferenceServiceStatus
+		expectedEvents []string
+	}{
+		{
+			name: "created",
+			status: v1beta1api.InferenceServiceStatus{
+				Status: conditions(
+					apis.Condition{Type: v1beta1api.PredictorReady, Status: v1.ConditionUnknown},
+					apis.Condition{Type: apis.ConditionReady, Status: v1.ConditionUnknown},
+				),
+				Components: map[v1beta1api.ComponentType]v1beta1api.ComponentStatusSpec{
+					v1beta1api.PredictorComponent: {LatestCreatedRevision: "sklearn-predictor-00001"},
+				},
+				ModelStatus: v1beta1api.ModelStatus{
+					Runtime:             runtime,
+					ModelRevisionStates: &v1beta1api.ModelRevisionStates{TargetModelState: v1beta1api.Loading},
+				},
+			},
+			expectedEvents: []string{
+				"Normal RuntimeSelected The ServingRuntime kserve-sklearnserver is selected to serve the model",
+				"Normal DeploymentCreated The predictor revision sklearn-predictor-00001 is created",
+			},
+		},
+		{
+			name: "ready",
+			status: v1beta1api.InferenceServiceStatus{
+				Status: conditions(
+					apis.Condition{Type: v1beta1api.PredictorReady, Status: v1.ConditionTrue},
+					apis.Condition{Type: v1beta1api.IngressReady, Status: v1.ConditionTrue},
+					apis.Condition{Type: apis.ConditionReady, Status: v1.ConditionTrue},
+				),
+				Components: map[v1beta1api.ComponentType]v1beta1api.ComponentStatusSpec{
+					v1beta1api.PredictorComponent: {
+						LatestCreatedRevision:   "sklearn-predictor-00001",
+						LatestReadyRevision:     "sklearn-predictor-00001",
+						LatestRolledoutRevision: "sklearn-predictor-00001",
+						Traffic: []knservingv1.TrafficTarget{
+							{
+								RevisionName:   "sklearn-predictor-00001",
+								LatestRevision: proto.Bool(true),
+								Percent:        proto.Int64(100),
+							},
+						},
+						DesiredReplicas: proto.Int32(1),
+					},
+				},
+				ModelStatus: v1beta1api.ModelStatus{
+					Runtime:             runtime,
+					ModelRevisionStates: &v1beta1api.ModelRevisionStates{TargetModelState: v1beta1api.Loaded},
+				},
+			},
+			expectedEvents: []string{
+				"Normal DeploymentReady The predictor is ready",
+				"Normal RolloutCompleted The predictor revision sklearn-predictor-00001 is rolled out",
+				"Normal TrafficShifted The predictor traffic is shifted to sklearn-predictor-00001=100%",
+				"Normal AutoscalerUpdated The predictor autoscaler desires 1 replicas",
+				"Normal IngressReady The ingress of the InferenceService is ready",
+				"Normal ModelLoaded The model is loaded",
+				"Normal InferenceServiceReady InferenceService [sklearn] is Ready",
+			},
+		},
+		{
+			name: "updated",
+			status: v1beta1api.InferenceServiceStatus{
+				Status: conditions(
+					apis.Condition{Type: v1beta1api.PredictorReady, Status: v1.ConditionTrue},
+					apis.Condition{Type: v1beta1api.IngressReady, Status: v1.ConditionTrue},
+					apis.Condition{Type: apis.ConditionReady, Status: v1.ConditionTrue},
+				),
+				Components: map[v1beta1api.ComponentType]v1beta1api.ComponentStatusSpec{
+					v1beta1api.PredictorComponent: {
+						LatestCreatedRevision:   "sklearn-predictor-00002",
+						LatestReadyRevision:     "sklearn-predictor-00001",
+						LatestRolledoutRevision: "sklearn-predictor-00001",
+						Traffic: []knservingv1.TrafficTarget{
+							{
+								RevisionName:   "sklearn-predictor-00001",
+								LatestRevision: proto.Bool(true),
+								Percent:        proto.Int64(100),
+							},
+						},
+						DesiredReplicas: proto.Int32(1),
+					},
+				},
+				ModelStatus: v1beta1api.ModelStatus{
+					Runtime:             runtime,
+					ModelRevisionStates: &v1beta1api.ModelRevisionStates{TargetModelState: v1beta1api.Loaded},
+				},
+			},
+			expectedEvents: []string{
+				"Normal DeploymentUpdated The predictor is updated from revision sklearn-predictor-00001 to revision sklearn-predictor-00002",
+			},
+		},
+		{
+			name: "failed",
+			status: v1beta1api.InferenceServiceStatus{
+				Status: conditions(
+					apis.Condition{Type: v1beta1api.PredictorReady, Status: v1.ConditionFalse, Message: "Revision missing"},
+					apis.Condition{Type: v1beta1api.IngressReady, Status: v1.ConditionTrue},
+					apis.Condition{Type: apis.ConditionReady, Status: v1.ConditionFalse},
+				),
+				Components: map[v1beta1api.ComponentType]v1beta1api.ComponentStatusSpec{
+					v1beta1api.PredictorComponent: {
+						LatestCreatedRevision:   "sklearn-predictor-00002",
+						LatestReadyRevision:     "sklearn-predictor-00001",
+						LatestRolledoutRevision: "sklearn-predictor-00001",
+						Traffic: []knservingv1.TrafficTarget{
+							{
+								RevisionName:   "sklearn-predictor-00001",
+								LatestRevision: proto.Bool(true),
+								Percent:        proto.Int64(100),
+							},
+						},
+						DesiredReplicas: proto.Int32(1),
+					},
+				},
+				ModelStatus: v1beta1api.ModelStatus{
+					Runtime:             runtime,
+					ModelRevisionStates: &v1beta1api.ModelRevisionStates{TargetModelState: v1beta1api.FailedToLoad},
+					LastFailureInfo: &v1beta1api.FailureInfo{
+						Reason:  v1beta1api.ModelLoadFailed,
+						Message: "Invalid Storage URI provided",
+					},
+				},
+			},
+			expectedEvents: []string{
+				"Warning DeploymentNotReady The predictor is not ready: Revision missing",
+				"Warning ModelLoadFailed The model failed to load: ModelLoadFailed Invalid Storage URI provided",
+				"Warning InferenceServiceNotReady InferenceService [sklearn] is no longer Ready",
+			},
+		},
+	}
+
+	g := gomega.NewGomegaWithT(t)
+	previous := v1beta1api.InferenceServiceStatus{}
+	for _, step := range steps {
+		recorder := record.NewFakeRecorder(len(step.expectedEvents) + 1)
+		isvc := &v1beta1api.InferenceService{
+			ObjectMeta: metav1.ObjectMeta{Name: "sklearn", Namespace: "default"},
+			Status:     step.status,
+		}
+		recordStatusEvents(recorder, isvc, previous)
+		close(recorder.Events)
+		var events []string
+		for event := range recorder.Events {
+			events = append(events, event)
+		}
+		g.Expect(events).To(gomega.Equal(step.expectedEvents), "step %s: %s", step.name, strings.Join(events, "\n"))
+		previous = step.status
+	}
+
+	// No event is recorded when the status is unchanged
+	recorder := record.NewFakeRecorder(1)
+	recordStatusEvents(recorder, &v1beta1api.InferenceService{Status: previous}, previous)
+	g.Expect(recorder.Events).To(gomega.BeEmpty())
+}
+
+func TestEventReasonTaxonomy(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	// The reasons are keyed off by alerting rules, they must not change
+	expected := map[EventReason]string{
+		"InferenceServiceReady":    v1.EventTypeNormal,
+		"InferenceServiceNotReady": v1.EventTypeWarning,
+		"RuntimeSelected":          v1.EventTypeNormal,
+		"DeploymentCreated":        v1.EventTypeNormal,
+		"DeploymentUpdated":        v1.EventTypeNormal,
+		"DeploymentReady":          v1.EventTypeNormal,
+		"DeploymentNotReady":       v1.EventTypeWarning,
+		"RolloutCompleted":         v1.EventTypeNormal,
+		"TrafficShifted":           v1.EventTypeNormal,
+		"IngressReady":             v1.EventTypeNormal,
+		"IngressNotReady":          v1.EventTypeWarning,
+		"AutoscalerUpdated":        v1.EventTypeNormal,
+		"AutoscalingPaused":        v1.EventTypeNormal,
+		"AutoscalingResumed":       v1.EventTypeNormal,
+		"ModelLoaded":              v1.EventTypeNormal,
+		"ModelLoadFailed":          v1.EventTypeWarning,
+		"ServerlessModeRejected":   v1.EventTypeWarning,
+		"UpdateFailed":             v1.EventTypeWarning,
+		"InternalError":            v1.EventTypeWarning,
+	}
+	g.Expect(eventTypes).To(gomega.Equal(expected))
+}
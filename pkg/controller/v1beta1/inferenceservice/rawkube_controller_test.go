@@ -27,6 +27,7 @@ import (
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
 	"github.com/kserve/kserve/pkg/constants"
+	"github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/reconcilers/keda"
 	. "github.com/onsi/ginkgo/v2"
 	"github.com/onsi/gomega"
 	. "github.com/onsi/gomega"
@@ -35,8 +36,10 @@ import (
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	v1 "k8s.io/api/core/v1"
 	netv1 "k8s.io/api/networking/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"knative.dev/pkg/apis"
@@ -423,6 +426,16 @@ var _ = Describe("v1beta1 inference service controller", func() {
 							Scheme: "http",
 							Host:   "raw-foo-predictor-default.example.com",
 						},
+						RestURL: &apis.URL{
+							Scheme: "http",
+							Host:   fmt.Sprintf("%s-predictor.%s.svc.cluster.local", serviceKey.Name, serviceKey.Namespace),
+						},
+						Address: &duckv1.Addressable{
+							URL: &apis.URL{
+								Scheme: "http",
+								Host:   fmt.Sprintf("%s-predictor.%s.svc.cluster.local", serviceKey.Name, serviceKey.Namespace),
+							},
+						},
 					},
 				},
 				ModelStatus: v1beta1.ModelStatus{
@@ -837,6 +850,16 @@ var _ = Describe("v1beta1 inference service controller", func() {
 							Scheme: "http",
 							Host:   "raw-foo-customized-predictor-default.example.com",
 						},
+						RestURL: &apis.URL{
+							Scheme: "http",
+							Host:   fmt.Sprintf("%s-predictor.%s.svc.cluster.local", serviceKey.Name, serviceKey.Namespace),
+						},
+						Address: &duckv1.Addressable{
+							URL: &apis.URL{
+								Scheme: "http",
+								Host:   fmt.Sprintf("%s-predictor.%s.svc.cluster.local", serviceKey.Name, serviceKey.Namespace),
+							},
+						},
 					},
 				},
 				ModelStatus: v1beta1.ModelStatus{
@@ -1242,6 +1265,16 @@ var _ = Describe("v1beta1 inference service controller", func() {
 							Scheme: "http",
 							Host:   "raw-foo-2-predictor-default.example.com",
 						},
+						RestURL: &apis.URL{
+							Scheme: "http",
+							Host:   fmt.Sprintf("%s-predictor.%s.svc.cluster.local", serviceKey.Name, serviceKey.Namespace),
+						},
+						Address: &duckv1.Addressable{
+							URL: &apis.URL{
+								Scheme: "http",
+								Host:   fmt.Sprintf("%s-predictor.%s.svc.cluster.local", serviceKey.Name, serviceKey.Namespace),
+							},
+						},
 					},
 				},
 				ModelStatus: v1beta1.ModelStatus{
@@ -1616,6 +1649,16 @@ var _ = Describe("v1beta1 inference service controller", func() {
 							Scheme: "http",
 							Host:   fmt.Sprintf("%s-predictor-default.example.com", serviceName),
 						},
+						RestURL: &apis.URL{
+							Scheme: "http",
+							Host:   fmt.Sprintf("%s-predictor.%s.svc.cluster.local", serviceKey.Name, serviceKey.Namespace),
+						},
+						Address: &duckv1.Addressable{
+							URL: &apis.URL{
+								Scheme: "http",
+								Host:   fmt.Sprintf("%s-predictor.%s.svc.cluster.local", serviceKey.Name, serviceKey.Namespace),
+							},
+						},
 					},
 				},
 				ModelStatus: v1beta1.ModelStatus{
@@ -2049,6 +2092,16 @@ var _ = Describe("v1beta1 inference service controller", func() {
 							Scheme: "http",
 							Host:   fmt.Sprintf("%s-predictor.%s.%s", serviceName, serviceKey.Namespace, domain),
 						},
+						RestURL: &apis.URL{
+							Scheme: "http",
+							Host:   fmt.Sprintf("%s-predictor.%s.svc.cluster.local", serviceKey.Name, serviceKey.Namespace),
+						},
+						Address: &duckv1.Addressable{
+							URL: &apis.URL{
+								Scheme: "http",
+								Host:   fmt.Sprintf("%s-predictor.%s.svc.cluster.local", serviceKey.Name, serviceKey.Namespace),
+							},
+						},
 					},
 				},
 				ModelStatus: v1beta1.ModelStatus{
@@ -2130,4 +2183,272 @@ var _ = Describe("v1beta1 inference service controller", func() {
 			Expect(actualHPA.Spec).To(gomega.Equal(expectedHPA.Spec))
 		})
 	})
+	Context("When creating inference service with raw kube predictor and keda autoscaler class", func() {
+		configs := map[string]string{
+			"ingress": `{
+				"ingressGateway": "knative-serving/knative-ingress-gateway",
+				"ingressService": "test-destination",
+				"localGateway": "knative-serving/knative-local-gateway",
+				"localGatewayService": "knative-local-gateway.istio-system.svc.cluster.local"
+			}`,
+			"storageInitializer": `{
+				"image" : "kserve/storage-initializer:latest",
+				"memoryRequest": "100Mi",
+				"memoryLimit": "1Gi",
+				"cpuRequest": "100m",
+				"cpuLimit": "1",
+				"CaBundleConfigMapName": "",
+				"caBundleVolumeMountPath": "/etc/ssl/custom-certs",
+				"enableDirectPvcVolumeMount": false
+			}`,
+		}
+
+		It("Should have a scaled object created instead of the hpa", func() {
+			By("By creating a new InferenceService")
+			var configMap = &v1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      constants.InferenceServiceConfigMapName,
+					Namespace: constants.KServeNamespace,
+				},
+				Data: configs,
+			}
+			Expect(k8sClient.Create(context.TODO(), configMap)).NotTo(HaveOccurred())
+			defer k8sClient.Delete(context.TODO(), configMap)
+			servingRuntime := &v1alpha1.ServingRuntime{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "tf-serving-raw-keda",
+					Namespace: "default",
+				},
+				Spec: v1alpha1.ServingRuntimeSpec{
+					SupportedModelFormats: []v1alpha1.SupportedModelFormat{
+						{
+							Name:       "tensorflow",
+							Version:    proto.String("1"),
+							AutoSelect: proto.Bool(true),
+						},
+					},
+					ServingRuntimePodSpec: v1alpha1.ServingRuntimePodSpec{
+						Containers: []v1.Container{
+							{
+								Name:      "kserve-container",
+								Image:     "tensorflow/serving:1.14.0",
+								Command:   []string{"/usr/bin/tensorflow_model_server"},
+								Resources: defaultResource,
+							},
+						},
+					},
+					Disabled: proto.Bool(false),
+				},
+			}
+			k8sClient.Create(context.TODO(), servingRuntime)
+			defer k8sClient.Delete(context.TODO(), servingRuntime)
+			serviceKey := types.NamespacedName{Name: "raw-keda", Namespace: "default"}
+			storageUri := "s3://test/mnist/export"
+			ctx := context.Background()
+			isvc := &v1beta1.InferenceService{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      serviceKey.Name,
+					Namespace: serviceKey.Namespace,
+					Annotations: map[string]string{
+						"serving.kserve.io/deploymentMode":  "RawDeployment",
+						"serving.kserve.io/autoscalerClass": "keda",
+						constants.KedaTriggersAnnotationKey: `[{"type": "prometheus", "metadata": {
+							"serverAddress": "http://prometheus.monitoring:9090", "query": "sum(rate(requests[1m]))", "threshold": "10"}}]`,
+					},
+				},
+				Spec: v1beta1.InferenceServiceSpec{
+					Predictor: v1beta1.PredictorSpec{
+						ComponentExtensionSpec: v1beta1.ComponentExtensionSpec{
+							MinReplicas: v1beta1.GetIntReference(0),
+							MaxReplicas: 3,
+						},
+						Tensorflow: &v1beta1.TFServingSpec{
+							PredictorExtensionSpec: v1beta1.PredictorExtensionSpec{
+								StorageURI:     &storageUri,
+								RuntimeVersion: proto.String("1.14.0"),
+								Container: v1.Container{
+									Name:      constants.InferenceServiceContainerName,
+									Resources: defaultResource,
+								},
+							},
+						},
+					},
+				},
+			}
+			isvc.DefaultInferenceService(nil, nil)
+			Expect(k8sClient.Create(ctx, isvc)).Should(Succeed())
+			defer k8sClient.Delete(ctx, isvc)
+
+			predictorKey := types.NamespacedName{Name: constants.PredictorServiceName(serviceKey.Name),
+				Namespace: serviceKey.Namespace}
+			scaledObject := &unstructured.Unstructured{}
+			scaledObject.SetGroupVersionKind(keda.ScaledObjectGVK)
+			Eventually(func() error { return k8sClient.Get(ctx, predictorKey, scaledObject) }, timeout).
+				Should(Succeed())
+			minReplicaCount, _, _ := unstructured.NestedInt64(scaledObject.Object, "spec", "minReplicaCount")
+			Expect(minReplicaCount).To(Equal(int64(0)))
+			maxReplicaCount, _, _ := unstructured.NestedInt64(scaledObject.Object, "spec", "maxReplicaCount")
+			Expect(maxReplicaCount).To(Equal(int64(3)))
+			scaleTargetName, _, _ := unstructured.NestedString(scaledObject.Object, "spec", "scaleTargetRef", "name")
+			Expect(scaleTargetName).To(Equal(predictorKey.Name))
+			Consistently(func() bool {
+				err := k8sClient.Get(ctx, predictorKey, &autoscalingv2.HorizontalPodAutoscaler{})
+				return apierr.IsNotFound(err)
+			}, time.Second, interval).Should(BeTrue())
+
+			By("By changing the autoscaler class back to hpa")
+			Eventually(func() error {
+				updatedIsvc := &v1beta1.InferenceService{}
+				if err := k8sClient.Get(ctx, serviceKey, updatedIsvc); err != nil {
+					return err
+				}
+				updatedIsvc.Annotations["serving.kserve.io/autoscalerClass"] = "hpa"
+				return k8sClient.Update(ctx, updatedIsvc)
+			}, timeout, interval).Should(Succeed())
+			Eventually(func() error {
+				return k8sClient.Get(ctx, predictorKey, &autoscalingv2.HorizontalPodAutoscaler{})
+			}, timeout, interval).Should(Succeed())
+			Eventually(func() bool {
+				err := k8sClient.Get(ctx, predictorKey, scaledObject)
+				return apierr.IsNotFound(err)
+			}, timeout, interval).Should(BeTrue())
+		})
+	})
+	Context("When creating inference service with raw kube predictor and transformer", func() {
+		configs := map[string]string{
+			"ingress": `{
+				"ingressGateway": "knative-serving/knative-ingress-gateway",
+				"ingressService": "test-destination",
+				"localGateway": "knative-serving/knative-local-gateway",
+				"localGatewayService": "knative-local-gateway.istio-system.svc.cluster.local"
+			}`,
+			"storageInitializer": `{
+				"image" : "kserve/storage-initializer:latest",
+				"memoryRequest": "100Mi",
+				"memoryLimit": "1Gi",
+				"cpuRequest": "100m",
+				"cpuLimit": "1",
+				"CaBundleConfigMapName": "",
+				"caBundleVolumeMountPath": "/etc/ssl/custom-certs",
+				"enableDirectPvcVolumeMount": false
+			}`,
+		}
+
+		It("Should have a distinct hpa created for each component", func() {
+			By("By creating a new InferenceService")
+			var configMap = &v1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      constants.InferenceServiceConfigMapName,
+					Namespace: constants.KServeNamespace,
+				},
+				Data: configs,
+			}
+			Expect(k8sClient.Create(context.TODO(), configMap)).NotTo(HaveOccurred())
+			defer k8sClient.Delete(context.TODO(), configMap)
+			servingRuntime := &v1alpha1.ServingRuntime{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "tf-serving-raw-hpa",
+					Namespace: "default",
+				},
+				Spec: v1alpha1.ServingRuntimeSpec{
+					SupportedModelFormats: []v1alpha1.SupportedModelFormat{
+						{
+							Name:       "tensorflow",
+							Version:    proto.String("1"),
+							AutoSelect: proto.Bool(true),
+						},
+					},
+					ServingRuntimePodSpec: v1alpha1.ServingRuntimePodSpec{
+						Containers: []v1.Container{
+							{
+								Name:      "kserve-container",
+								Image:     "tensorflow/serving:1.14.0",
+								Command:   []string{"/usr/bin/tensorflow_model_server"},
+								Resources: defaultResource,
+							},
+						},
+					},
+					Disabled: proto.Bool(false),
+				},
+			}
+			k8sClient.Create(context.TODO(), servingRuntime)
+			defer k8sClient.Delete(context.TODO(), servingRuntime)
+			serviceKey := types.NamespacedName{Name: "raw-hpa-components", Namespace: "default"}
+			storageUri := "s3://test/mnist/export"
+			memoryMetric := v1beta1.MetricMemory
+			cpuMetric := v1beta1.MetricCPU
+			ctx := context.Background()
+			isvc := &v1beta1.InferenceService{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      serviceKey.Name,
+					Namespace: serviceKey.Namespace,
+					Annotations: map[string]string{
+						"serving.kserve.io/deploymentMode":  "RawDeployment",
+						"serving.kserve.io/autoscalerClass": "hpa",
+					},
+				},
+				Spec: v1beta1.InferenceServiceSpec{
+					Predictor: v1beta1.PredictorSpec{
+						ComponentExtensionSpec: v1beta1.ComponentExtensionSpec{
+							MinReplicas: v1beta1.GetIntReference(1),
+							MaxReplicas: 2,
+							ScaleMetric: &memoryMetric,
+							ScaleTarget: v1beta1.GetIntReference(90),
+						},
+						Tensorflow: &v1beta1.TFServingSpec{
+							PredictorExtensionSpec: v1beta1.PredictorExtensionSpec{
+								StorageURI:     &storageUri,
+								RuntimeVersion: proto.String("1.14.0"),
+								Container: v1.Container{
+									Name:      constants.InferenceServiceContainerName,
+									Resources: defaultResource,
+								},
+							},
+						},
+					},
+					Transformer: &v1beta1.TransformerSpec{
+						ComponentExtensionSpec: v1beta1.ComponentExtensionSpec{
+							MinReplicas: v1beta1.GetIntReference(2),
+							MaxReplicas: 8,
+							ScaleMetric: &cpuMetric,
+							ScaleTarget: v1beta1.GetIntReference(60),
+						},
+						PodSpec: v1beta1.PodSpec{
+							Containers: []v1.Container{
+								{
+									Image:     "transformer:v1",
+									Resources: defaultResource,
+								},
+							},
+						},
+					},
+				},
+			}
+			isvc.DefaultInferenceService(nil, nil)
+			Expect(k8sClient.Create(ctx, isvc)).Should(Succeed())
+			defer k8sClient.Delete(ctx, isvc)
+
+			expectedMetrics := map[string]struct {
+				resource    v1.ResourceName
+				utilization int32
+				minReplicas int32
+				maxReplicas int32
+			}{
+				constants.PredictorServiceName(serviceKey.Name):   {resource: v1.ResourceMemory, utilization: 90, minReplicas: 1, maxReplicas: 2},
+				constants.TransformerServiceName(serviceKey.Name): {resource: v1.ResourceCPU, utilization: 60, minReplicas: 2, maxReplicas: 8},
+			}
+			for name, expected := range expectedMetrics {
+				actualHPA := &autoscalingv2.HorizontalPodAutoscaler{}
+				hpaKey := types.NamespacedName{Name: name, Namespace: serviceKey.Namespace}
+				Eventually(func() error { return k8sClient.Get(ctx, hpaKey, actualHPA) }, timeout, interval).
+					Should(Succeed())
+				Expect(actualHPA.Spec.ScaleTargetRef.Name).To(Equal(name))
+				Expect(*actualHPA.Spec.MinReplicas).To(Equal(expected.minReplicas))
+				Expect(actualHPA.Spec.MaxReplicas).To(Equal(expected.maxReplicas))
+				Expect(actualHPA.Spec.Metrics).To(HaveLen(1))
+				Expect(actualHPA.Spec.Metrics[0].Resource.Name).To(Equal(expected.resource))
+				Expect(*actualHPA.Spec.Metrics[0].Resource.Target.AverageUtilization).To(Equal(expected.utilization))
+			}
+		})
+	})
 })
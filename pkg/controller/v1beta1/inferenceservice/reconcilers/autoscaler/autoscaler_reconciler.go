@@ -18,10 +18,12 @@ package autoscaler
 
 import (
 	"fmt"
+	"strconv"
 
 	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
 	"github.com/kserve/kserve/pkg/constants"
 	hpa "github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/reconcilers/hpa"
+	"github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/reconcilers/keda"
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -47,10 +49,13 @@ func (a *NoOpAutoscaler) SetControllerReferences(owner metav1.Object, scheme *ru
 
 // AutoscalerReconciler is the struct of Raw K8S Object
 type AutoscalerReconciler struct {
-	client       client.Client
-	scheme       *runtime.Scheme
-	Autoscaler   Autoscaler
-	componentExt *v1beta1.ComponentExtensionSpec
+	client        client.Client
+	scheme        *runtime.Scheme
+	Autoscaler    Autoscaler
+	componentMeta metav1.ObjectMeta
+	componentExt  *v1beta1.ComponentExtensionSpec
+	// owner controls the autoscaler resources, only the resources it controls are cleaned up
+	owner metav1.Object
 }
 
 func NewAutoscalerReconciler(client client.Client,
@@ -62,10 +67,11 @@ func NewAutoscalerReconciler(client client.Client,
 		return nil, err
 	}
 	return &AutoscalerReconciler{
-		client:       client,
-		scheme:       scheme,
-		Autoscaler:   as,
-		componentExt: componentExt,
+		client:        client,
+		scheme:        scheme,
+		Autoscaler:    as,
+		componentMeta: componentMeta,
+		componentExt:  componentExt,
 	}, err
 }
 
@@ -84,18 +90,47 @@ func createAutoscaler(client client.Client,
 	ac := getAutoscalerClass(componentMeta)
 	switch ac {
 	case constants.AutoscalerClassHPA, constants.AutoscalerClassExternal:
-		return hpa.NewHPAReconciler(client, scheme, componentMeta, componentExt), nil
+		return hpa.NewHPAReconciler(client, scheme, componentMeta, componentExt)
+	case constants.AutoscalerClassKeda:
+		return keda.NewKedaReconciler(client, scheme, componentMeta, componentExt)
 	default:
 		return nil, fmt.Errorf("unknown autoscaler class type: %v", ac)
 	}
 }
 
+// isAutoscalingPaused returns true when the autoscaling of the component is paused, e.g. while the replicas of the
+// deployment are pinned manually during an incident.
+func isAutoscalingPaused(metadata metav1.ObjectMeta) bool {
+	paused, _ := strconv.ParseBool(metadata.Annotations[constants.AutoscalingPausedAnnotationKey])
+	return paused
+}
+
 // Reconcile ...
-func (r *AutoscalerReconciler) Reconcile() error {
+func (r *AutoscalerReconciler) Reconcile() (*autoscalingv2.HorizontalPodAutoscaler, error) {
+	// the autoscaler resources are removed while autoscaling is paused so that they do not override the replicas
+	// of the deployment, they are recreated once the annotation is removed
+	if isAutoscalingPaused(r.componentMeta) {
+		if err := hpa.DeleteHPA(r.client, r.componentMeta, r.owner); err != nil {
+			return nil, err
+		}
+		return nil, keda.DeleteScaledObject(r.client, r.componentMeta, r.owner)
+	}
 	// reconcile Autoscaler
-	_, err := r.Autoscaler.Reconcile()
+	scaler, err := r.Autoscaler.Reconcile()
 	if err != nil {
-		return err
+		return nil, err
 	}
-	return nil
+	// clean up the scaled object when the autoscaler class changed from keda
+	if getAutoscalerClass(r.componentMeta) != constants.AutoscalerClassKeda {
+		if err := keda.DeleteScaledObject(r.client, r.componentMeta, r.owner); err != nil {
+			return nil, err
+		}
+	}
+	return scaler, nil
+}
+
+// SetControllerReferences sets the owner of the autoscaler resources
+func (r *AutoscalerReconciler) SetControllerReferences(owner metav1.Object, scheme *runtime.Scheme) error {
+	r.owner = owner
+	return r.Autoscaler.SetControllerReferences(owner, scheme)
 }
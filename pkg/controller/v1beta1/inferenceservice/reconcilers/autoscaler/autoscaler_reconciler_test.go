@@ -17,9 +17,21 @@ limitations under the License.
 package autoscaler
 
 import (
+	"context"
+
 	"github.com/google/go-cmp/cmp"
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
 	"github.com/kserve/kserve/pkg/constants"
+	"github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/reconcilers/keda"
+	"github.com/stretchr/testify/assert"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	"testing"
 )
@@ -61,6 +73,15 @@ func TestGetAutoscalerClass(t *testing.T) {
 			},
 			expectedAutoScalerType: constants.AutoscalerClassExternal,
 		},
+		{
+			name: "Return keda AutoScaler,if the autoscalerClass annotation set keda",
+			isvcMetaData: &metav1.ObjectMeta{
+				Name:        serviceName,
+				Namespace:   namespace,
+				Annotations: map[string]string{"serving.kserve.io/autoscalerClass": "keda"},
+			},
+			expectedAutoScalerType: constants.AutoscalerClassKeda,
+		},
 	}
 
 	for _, tt := range testCases {
@@ -72,3 +93,47 @@ func TestGetAutoscalerClass(t *testing.T) {
 		})
 	}
 }
+
+func TestAutoscalerReconcilePaused(t *testing.T) {
+	scheme := runtime.NewScheme()
+	assert.NoError(t, clientgoscheme.AddToScheme(scheme))
+	assert.NoError(t, v1beta1.AddToScheme(scheme))
+	scheme.AddKnownTypeWithName(keda.ScaledObjectGVK, &unstructured.Unstructured{})
+	owner := &v1beta1.InferenceService{ObjectMeta: metav1.ObjectMeta{Name: "my-model", Namespace: "test", UID: "my-model"}}
+	key := types.NamespacedName{Name: "my-model-predictor", Namespace: "test"}
+	componentMeta := func(annotations map[string]string) metav1.ObjectMeta {
+		return metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace, Annotations: annotations}
+	}
+	componentExt := &v1beta1.ComponentExtensionSpec{MinReplicas: v1beta1.GetIntReference(1), MaxReplicas: 3}
+	client := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+	reconciler, err := NewAutoscalerReconciler(client, scheme, componentMeta(map[string]string{}), componentExt)
+	assert.NoError(t, err)
+	assert.NoError(t, reconciler.SetControllerReferences(owner, scheme))
+	hpa, err := reconciler.Reconcile()
+	assert.NoError(t, err)
+	assert.NotNil(t, hpa)
+	assert.NoError(t, client.Get(context.TODO(), key, &autoscalingv2.HorizontalPodAutoscaler{}))
+
+	// pausing autoscaling deletes the hpa
+	reconciler, err = NewAutoscalerReconciler(client, scheme, componentMeta(map[string]string{
+		constants.AutoscalingPausedAnnotationKey: "true",
+	}), componentExt)
+	assert.NoError(t, err)
+	assert.NoError(t, reconciler.SetControllerReferences(owner, scheme))
+	hpa, err = reconciler.Reconcile()
+	assert.NoError(t, err)
+	assert.Nil(t, hpa)
+	err = client.Get(context.TODO(), key, &autoscalingv2.HorizontalPodAutoscaler{})
+	assert.True(t, apierr.IsNotFound(err))
+
+	// resuming autoscaling recreates the hpa
+	reconciler, err = NewAutoscalerReconciler(client, scheme, componentMeta(map[string]string{
+		constants.AutoscalingPausedAnnotationKey: "false",
+	}), componentExt)
+	assert.NoError(t, err)
+	assert.NoError(t, reconciler.SetControllerReferences(owner, scheme))
+	_, err = reconciler.Reconcile()
+	assert.NoError(t, err)
+	assert.NoError(t, client.Get(context.TODO(), key, &autoscalingv2.HorizontalPodAutoscaler{}))
+}
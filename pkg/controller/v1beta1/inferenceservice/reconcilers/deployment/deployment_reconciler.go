@@ -18,10 +18,14 @@ package deployment
 
 import (
 	"context"
+	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
 	"github.com/kserve/kserve/pkg/constants"
+	"github.com/kserve/kserve/pkg/controller/metrics"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierr "k8s.io/apimachinery/pkg/api/errors"
@@ -42,27 +46,56 @@ type DeploymentReconciler struct {
 	scheme       *runtime.Scheme
 	Deployment   *appsv1.Deployment
 	componentExt *v1beta1.ComponentExtensionSpec
+	scaleToZero  bool
 }
 
 func NewDeploymentReconciler(client kclient.Client,
 	scheme *runtime.Scheme,
 	componentMeta metav1.ObjectMeta,
 	componentExt *v1beta1.ComponentExtensionSpec,
-	podSpec *corev1.PodSpec) *DeploymentReconciler {
+	podSpec *corev1.PodSpec,
+	deployConfig *v1beta1.DeployConfig) (*DeploymentReconciler, error) {
+	deployment, err := createRawDeployment(componentMeta, componentExt, podSpec, deployConfig)
+	if err != nil {
+		return nil, err
+	}
 	return &DeploymentReconciler{
 		client:       client,
 		scheme:       scheme,
-		Deployment:   createRawDeployment(componentMeta, componentExt, podSpec),
+		Deployment:   deployment,
 		componentExt: componentExt,
+		scaleToZero:  ScaleToZeroEnabled(componentMeta, componentExt),
+	}, nil
+}
+
+// ScaleToZeroEnabled returns true if the component may be scaled to zero, i.e. its minReplicas is 0 and its replicas
+// are owned by KEDA or an external autoscaler. The HPA can not scale a deployment to zero.
+func ScaleToZeroEnabled(componentMeta metav1.ObjectMeta, componentExt *v1beta1.ComponentExtensionSpec) bool {
+	if componentExt == nil || componentExt.MinReplicas == nil || *componentExt.MinReplicas != 0 {
+		return false
+	}
+	switch constants.AutoscalerClassType(componentMeta.Annotations[constants.AutoscalerClass]) {
+	case constants.AutoscalerClassKeda, constants.AutoscalerClassExternal:
+		return true
 	}
+	return false
 }
 
 func createRawDeployment(componentMeta metav1.ObjectMeta,
-	componentExt *v1beta1.ComponentExtensionSpec, //nolint:unparam
-	podSpec *corev1.PodSpec) *appsv1.Deployment {
+	componentExt *v1beta1.ComponentExtensionSpec,
+	podSpec *corev1.PodSpec,
+	deployConfig *v1beta1.DeployConfig) (*appsv1.Deployment, error) {
 	podMetadata := componentMeta
 	podMetadata.Labels["app"] = constants.GetRawServiceLabel(componentMeta.Name)
+	if err := setTerminationGracePeriod(componentMeta.Annotations, podSpec); err != nil {
+		return nil, err
+	}
+	if err := setAutomountServiceAccountToken(componentMeta.Annotations, podSpec, deployConfig); err != nil {
+		return nil, err
+	}
 	setDefaultPodSpec(podSpec)
+	setDefaultTopologySpreadConstraints(componentMeta, podSpec, deployConfig)
+	setPreStopHook(componentMeta, podSpec, deployConfig)
 	deployment := &appsv1.Deployment{
 		ObjectMeta: componentMeta,
 		Spec: appsv1.DeploymentSpec{
@@ -79,9 +112,120 @@ func createRawDeployment(componentMeta metav1.ObjectMeta,
 	}
 	if componentExt.DeploymentStrategy != nil {
 		deployment.Spec.Strategy = *componentExt.DeploymentStrategy
+	} else {
+		strategy, err := getDeploymentStrategyFromAnnotations(componentMeta.Annotations)
+		if err != nil {
+			return nil, err
+		}
+		if strategy != nil {
+			deployment.Spec.Strategy = *strategy
+		}
+	}
+	if err := setDeploymentTimings(componentMeta.Annotations, &deployment.Spec); err != nil {
+		return nil, err
 	}
 	setDefaultDeploymentSpec(&deployment.Spec)
-	return deployment
+	return deployment, nil
+}
+
+// setDeploymentTimings sets minReadySeconds and progressDeadlineSeconds from the component annotations,
+// slow loading models need a longer progress deadline than the kubernetes default.
+func setDeploymentTimings(annotations map[string]string, spec *appsv1.DeploymentSpec) error {
+	if value, ok := annotations[constants.DeploymentMinReadySecondsAnnotationKey]; ok {
+		minReadySeconds, err := strconv.ParseInt(value, 10, 32)
+		if err != nil || minReadySeconds < 0 {
+			return fmt.Errorf("invalid value %q for annotation %s, must be a non-negative integer",
+				value, constants.DeploymentMinReadySecondsAnnotationKey)
+		}
+		spec.MinReadySeconds = int32(minReadySeconds)
+	}
+	if value, ok := annotations[constants.ProgressDeadlineSecondsAnnotationKey]; ok {
+		progressDeadlineSeconds, err := strconv.ParseInt(value, 10, 32)
+		if err != nil || progressDeadlineSeconds < 0 {
+			return fmt.Errorf("invalid value %q for annotation %s, must be a non-negative integer",
+				value, constants.ProgressDeadlineSecondsAnnotationKey)
+		}
+		deadline := int32(progressDeadlineSeconds)
+		spec.ProgressDeadlineSeconds = &deadline
+	}
+	// the default progress deadline must also be greater than the annotated minReadySeconds
+	if spec.ProgressDeadlineSeconds == nil {
+		progressDeadlineSeconds := constants.DefaultProgressDeadlineSeconds
+		spec.ProgressDeadlineSeconds = &progressDeadlineSeconds
+	}
+	if *spec.ProgressDeadlineSeconds <= spec.MinReadySeconds {
+		return fmt.Errorf("annotation %s must be greater than %s",
+			constants.ProgressDeadlineSecondsAnnotationKey, constants.DeploymentMinReadySecondsAnnotationKey)
+	}
+	return nil
+}
+
+// getDeploymentStrategyFromAnnotations builds the deployment strategy from the deployment strategy and
+// rolling update annotations. It returns nil if none of the annotations are set.
+func getDeploymentStrategyFromAnnotations(annotations map[string]string) (*appsv1.DeploymentStrategy, error) {
+	strategyType, hasStrategy := annotations[constants.DeploymentStrategyAnnotationKey]
+	maxSurgeValue, hasMaxSurge := annotations[constants.RollingUpdateMaxSurgeAnnotationKey]
+	maxUnavailableValue, hasMaxUnavailable := annotations[constants.RollingUpdateMaxUnavailableAnnotationKey]
+	if !hasStrategy && !hasMaxSurge && !hasMaxUnavailable {
+		return nil, nil
+	}
+
+	switch appsv1.DeploymentStrategyType(strategyType) {
+	case appsv1.RecreateDeploymentStrategyType:
+		if hasMaxSurge || hasMaxUnavailable {
+			return nil, fmt.Errorf("annotations %s and %s are not allowed when %s is %s",
+				constants.RollingUpdateMaxSurgeAnnotationKey, constants.RollingUpdateMaxUnavailableAnnotationKey,
+				constants.DeploymentStrategyAnnotationKey, appsv1.RecreateDeploymentStrategyType)
+		}
+		return &appsv1.DeploymentStrategy{Type: appsv1.RecreateDeploymentStrategyType}, nil
+	case appsv1.RollingUpdateDeploymentStrategyType, "":
+	default:
+		return nil, fmt.Errorf("invalid value %q for annotation %s, must be one of %s or %s", strategyType,
+			constants.DeploymentStrategyAnnotationKey, appsv1.RecreateDeploymentStrategyType, appsv1.RollingUpdateDeploymentStrategyType)
+	}
+
+	rollingUpdate := &appsv1.RollingUpdateDeployment{
+		MaxUnavailable: &intstr.IntOrString{Type: intstr.String, StrVal: "25%"},
+		MaxSurge:       &intstr.IntOrString{Type: intstr.String, StrVal: "25%"},
+	}
+	if hasMaxSurge {
+		maxSurge, err := parseRollingUpdateValue(constants.RollingUpdateMaxSurgeAnnotationKey, maxSurgeValue)
+		if err != nil {
+			return nil, err
+		}
+		rollingUpdate.MaxSurge = maxSurge
+	}
+	if hasMaxUnavailable {
+		maxUnavailable, err := parseRollingUpdateValue(constants.RollingUpdateMaxUnavailableAnnotationKey, maxUnavailableValue)
+		if err != nil {
+			return nil, err
+		}
+		rollingUpdate.MaxUnavailable = maxUnavailable
+	}
+	// Kubernetes rejects a rolling update which can neither surge nor remove pods.
+	surge, _ := intstr.GetScaledValueFromIntOrPercent(rollingUpdate.MaxSurge, 100, true)
+	unavailable, _ := intstr.GetScaledValueFromIntOrPercent(rollingUpdate.MaxUnavailable, 100, true)
+	if surge == 0 && unavailable == 0 {
+		return nil, fmt.Errorf("annotations %s and %s must not both be zero",
+			constants.RollingUpdateMaxSurgeAnnotationKey, constants.RollingUpdateMaxUnavailableAnnotationKey)
+	}
+	return &appsv1.DeploymentStrategy{
+		Type:          appsv1.RollingUpdateDeploymentStrategyType,
+		RollingUpdate: rollingUpdate,
+	}, nil
+}
+
+// parseRollingUpdateValue parses an absolute number or a percentage such as "25%".
+func parseRollingUpdateValue(key string, value string) (*intstr.IntOrString, error) {
+	parsed := intstr.Parse(value)
+	scaled, err := intstr.GetScaledValueFromIntOrPercent(&parsed, 100, true)
+	if err != nil {
+		return nil, fmt.Errorf("invalid value %q for annotation %s: %w", value, key, err)
+	}
+	if scaled < 0 || (parsed.Type == intstr.String && scaled > 100) {
+		return nil, fmt.Errorf("invalid value %q for annotation %s, must be a non-negative number or a percentage between 0%% and 100%%", value, key)
+	}
+	return &parsed, nil
 }
 
 // checkDeploymentExist checks if the deployment exists?
@@ -113,9 +257,47 @@ func (r *DeploymentReconciler) checkDeploymentExist(client kclient.Client) (cons
 		log.Info("Deployment Updated", "Diff", diff)
 		return constants.CheckResultUpdate, existingDeployment, nil
 	}
+	if _, changed := mergeOwnedMetadata(r.Deployment.Labels, existingDeployment.Labels); changed {
+		log.Info("Deployment labels updated", "Deployment", r.Deployment.Name)
+		return constants.CheckResultUpdate, existingDeployment, nil
+	}
+	if _, changed := mergeOwnedMetadata(r.Deployment.Annotations, existingDeployment.Annotations); changed {
+		log.Info("Deployment annotations updated", "Deployment", r.Deployment.Name)
+		return constants.CheckResultUpdate, existingDeployment, nil
+	}
 	return constants.CheckResultExisted, existingDeployment, nil
 }
 
+// isOwnedMetadataKey returns true for the label and annotation keys owned by KServe,
+// keys added by other controllers or tools are preserved on update.
+func isOwnedMetadataKey(key string) bool {
+	return key == "app" ||
+		strings.HasPrefix(key, constants.KServeAPIGroupName+"/") ||
+		strings.HasPrefix(key, constants.InferenceServiceInternalAnnotationsPrefix+"/")
+}
+
+// mergeOwnedMetadata merges the desired labels or annotations into the existing ones. Desired keys are enforced and
+// stale keys owned by KServe are removed, while all other existing keys are kept. It returns the merged map and
+// whether it differs from the existing map.
+func mergeOwnedMetadata(desired map[string]string, existing map[string]string) (map[string]string, bool) {
+	merged := make(map[string]string, len(existing)+len(desired))
+	changed := false
+	for key, value := range existing {
+		if _, ok := desired[key]; !ok && isOwnedMetadataKey(key) {
+			changed = true
+			continue
+		}
+		merged[key] = value
+	}
+	for key, value := range desired {
+		if existingValue, ok := merged[key]; !ok || existingValue != value {
+			changed = true
+		}
+		merged[key] = value
+	}
+	return merged, changed
+}
+
 func setDefaultPodSpec(podSpec *corev1.PodSpec) {
 	if podSpec.DNSPolicy == "" {
 		podSpec.DNSPolicy = corev1.DNSClusterFirst
@@ -181,6 +363,103 @@ func setDefaultPodSpec(podSpec *corev1.PodSpec) {
 	}
 }
 
+// setTerminationGracePeriod sets terminationGracePeriodSeconds from the termination grace period annotation,
+// the annotation takes precedence over the value merged from the serving runtime.
+func setTerminationGracePeriod(annotations map[string]string, podSpec *corev1.PodSpec) error {
+	value, ok := annotations[constants.TerminationGracePeriodAnnotationKey]
+	if !ok {
+		return nil
+	}
+	gracePeriod, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || gracePeriod < 0 {
+		return fmt.Errorf("invalid value %q for annotation %s, must be a non-negative integer",
+			value, constants.TerminationGracePeriodAnnotationKey)
+	}
+	podSpec.TerminationGracePeriodSeconds = &gracePeriod
+	return nil
+}
+
+// setAutomountServiceAccountToken sets automountServiceAccountToken from the annotation or the deploy config default,
+// a value set in the pod spec takes precedence and the field is left nil if none of them is set.
+func setAutomountServiceAccountToken(annotations map[string]string, podSpec *corev1.PodSpec, deployConfig *v1beta1.DeployConfig) error {
+	if podSpec.AutomountServiceAccountToken != nil {
+		return nil
+	}
+	if value, ok := annotations[constants.AutomountServiceAccountTokenAnnotationKey]; ok {
+		automount, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("invalid value %q for annotation %s, must be true or false",
+				value, constants.AutomountServiceAccountTokenAnnotationKey)
+		}
+		podSpec.AutomountServiceAccountToken = &automount
+		return nil
+	}
+	if deployConfig != nil && deployConfig.AutomountServiceAccountToken != nil {
+		automount := *deployConfig.AutomountServiceAccountToken
+		podSpec.AutomountServiceAccountToken = &automount
+	}
+	return nil
+}
+
+// setPreStopHook adds the preStop sleep hook configured in the deploy config to the model server container, so
+// the pod is removed from the service endpoints before the model server receives SIGTERM and in-flight requests
+// are not dropped. The hook runs /bin/sh in the model server image, so it is only added when opted in.
+// The hook is not added when the user defined lifecycle hooks or when the agent or queue proxy sidecar
+// is in front of the model server, as the sidecar handles draining itself.
+func setPreStopHook(componentMeta metav1.ObjectMeta, podSpec *corev1.PodSpec, deployConfig *v1beta1.DeployConfig) {
+	if deployConfig == nil || deployConfig.PreStopSleepSeconds == nil || *deployConfig.PreStopSleepSeconds <= 0 {
+		return
+	}
+	sleepSeconds := *deployConfig.PreStopSleepSeconds
+	for _, key := range []string{
+		constants.AgentShouldInjectAnnotationKey,
+		constants.LoggerInternalAnnotationKey,
+		constants.BatcherInternalAnnotationKey,
+	} {
+		if _, ok := componentMeta.Annotations[key]; ok {
+			return
+		}
+	}
+	for _, container := range podSpec.Containers {
+		if container.Name == constants.AgentContainerName || container.Name == "queue-proxy" {
+			return
+		}
+	}
+	for i := range podSpec.Containers {
+		container := &podSpec.Containers[i]
+		if container.Name != constants.InferenceServiceContainerName || container.Lifecycle != nil {
+			continue
+		}
+		container.Lifecycle = &corev1.Lifecycle{
+			PreStop: &corev1.LifecycleHandler{
+				Exec: &corev1.ExecAction{
+					Command: []string{"/bin/sh", "-c", "sleep " + strconv.FormatInt(sleepSeconds, 10)},
+				},
+			},
+		}
+	}
+}
+
+// setDefaultTopologySpreadConstraints adds the default topology spread constraints from the deploy config
+// if the pod spec does not define any and the defaults are not disabled for the component.
+func setDefaultTopologySpreadConstraints(componentMeta metav1.ObjectMeta, podSpec *corev1.PodSpec, deployConfig *v1beta1.DeployConfig) {
+	if deployConfig == nil || len(deployConfig.DefaultTopologySpreadConstraints) == 0 || len(podSpec.TopologySpreadConstraints) > 0 {
+		return
+	}
+	if disabled, _ := strconv.ParseBool(componentMeta.Annotations[constants.DisableDefaultTopologySpreadAnnotationKey]); disabled {
+		return
+	}
+	for _, defaultConstraint := range deployConfig.DefaultTopologySpreadConstraints {
+		constraint := defaultConstraint.DeepCopy()
+		constraint.LabelSelector = &metav1.LabelSelector{
+			MatchLabels: map[string]string{
+				"app": constants.GetRawServiceLabel(componentMeta.Name),
+			},
+		}
+		podSpec.TopologySpreadConstraints = append(podSpec.TopologySpreadConstraints, *constraint)
+	}
+}
+
 func setDefaultDeploymentSpec(spec *appsv1.DeploymentSpec) {
 	if spec.Strategy.Type == "" {
 		spec.Strategy.Type = appsv1.RollingUpdateDeploymentStrategyType
@@ -196,7 +475,7 @@ func setDefaultDeploymentSpec(spec *appsv1.DeploymentSpec) {
 		spec.RevisionHistoryLimit = &revisionHistoryLimit
 	}
 	if spec.ProgressDeadlineSeconds == nil {
-		progressDeadlineSeconds := int32(600)
+		progressDeadlineSeconds := constants.DefaultProgressDeadlineSeconds
 		spec.ProgressDeadlineSeconds = &progressDeadlineSeconds
 	}
 }
@@ -213,9 +492,21 @@ func (r *DeploymentReconciler) Reconcile() (*appsv1.Deployment, error) {
 	var opErr error
 	switch checkResult {
 	case constants.CheckResultCreate:
-		opErr = r.client.Create(context.TODO(), r.Deployment)
+		deployment = r.Deployment
+		opErr = r.client.Create(context.TODO(), deployment)
 	case constants.CheckResultUpdate:
-		opErr = r.client.Update(context.TODO(), r.Deployment)
+		// Only the spec and the metadata keys owned by KServe are reconciled, so labels and annotations
+		// added to the deployment by other controllers are not stripped.
+		replicas := deployment.Spec.Replicas
+		deployment.Spec = r.Deployment.Spec
+		if r.scaleToZero {
+			// a deployment scaled to zero by KEDA or an external autoscaler, or scaled back up by the activator,
+			// keeps its replicas
+			deployment.Spec.Replicas = replicas
+		}
+		deployment.Labels, _ = mergeOwnedMetadata(r.Deployment.Labels, deployment.Labels)
+		deployment.Annotations, _ = mergeOwnedMetadata(r.Deployment.Annotations, deployment.Annotations)
+		opErr = r.client.Update(context.TODO(), deployment)
 	default:
 		return deployment, nil
 	}
@@ -223,6 +514,9 @@ func (r *DeploymentReconciler) Reconcile() (*appsv1.Deployment, error) {
 	if opErr != nil {
 		return nil, opErr
 	}
+	if checkResult == constants.CheckResultUpdate {
+		metrics.RecordDriftCorrection(metrics.KindDeployment)
+	}
 
-	return r.Deployment, nil
+	return deployment, nil
 }
@@ -0,0 +1,770 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deployment
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"knative.dev/pkg/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestCreateDefaultDeployment(t *testing.T) {
+	type args struct {
+		objectMeta   metav1.ObjectMeta
+		componentExt *v1beta1.ComponentExtensionSpec
+		podSpec      *corev1.PodSpec
+	}
+	testCases := map[string]struct {
+		args     args
+		expected *appsv1.Deployment
+	}{
+		"defaultDeployment": {
+			args: args{
+				objectMeta: metav1.ObjectMeta{
+					Name:      "default-predictor",
+					Namespace: "default-predictor-namespace",
+					Labels: map[string]string{
+						constants.InferenceServicePodLabelKey: "default-predictor",
+					},
+				},
+				componentExt: &v1beta1.ComponentExtensionSpec{},
+				podSpec: &corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name:  constants.InferenceServiceContainerName,
+							Image: "default-predictor-example-image",
+						},
+					},
+				},
+			},
+			expected: &appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "default-predictor",
+					Namespace: "default-predictor-namespace",
+					Labels: map[string]string{
+						constants.InferenceServicePodLabelKey: "default-predictor",
+						"app":                                 "isvc.default-predictor",
+					},
+				},
+				Spec: appsv1.DeploymentSpec{
+					Selector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{
+							"app": "isvc.default-predictor",
+						},
+					},
+					Template: corev1.PodTemplateSpec{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "default-predictor",
+							Namespace: "default-predictor-namespace",
+							Labels: map[string]string{
+								constants.InferenceServicePodLabelKey: "default-predictor",
+								"app":                                 "isvc.default-predictor",
+							},
+						},
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{
+								{
+									Name:                     constants.InferenceServiceContainerName,
+									Image:                    "default-predictor-example-image",
+									TerminationMessagePath:   "/dev/termination-log",
+									TerminationMessagePolicy: corev1.TerminationMessageReadFile,
+									ImagePullPolicy:          corev1.PullIfNotPresent,
+									ReadinessProbe: &corev1.Probe{
+										ProbeHandler: corev1.ProbeHandler{
+											TCPSocket: &corev1.TCPSocketAction{
+												Port: intstr.IntOrString{IntVal: 8080},
+											},
+										},
+										TimeoutSeconds:   1,
+										PeriodSeconds:    10,
+										SuccessThreshold: 1,
+										FailureThreshold: 3,
+									},
+								},
+							},
+							RestartPolicy:                 corev1.RestartPolicyAlways,
+							TerminationGracePeriodSeconds: int64Ptr(corev1.DefaultTerminationGracePeriodSeconds),
+							DNSPolicy:                     corev1.DNSClusterFirst,
+							SecurityContext:               &corev1.PodSecurityContext{},
+							SchedulerName:                 corev1.DefaultSchedulerName,
+						},
+					},
+					Strategy: appsv1.DeploymentStrategy{
+						Type: appsv1.RollingUpdateDeploymentStrategyType,
+						RollingUpdate: &appsv1.RollingUpdateDeployment{
+							MaxUnavailable: &intstr.IntOrString{Type: intstr.String, StrVal: "25%"},
+							MaxSurge:       &intstr.IntOrString{Type: intstr.String, StrVal: "25%"},
+						},
+					},
+					RevisionHistoryLimit:    int32Ptr(10),
+					ProgressDeadlineSeconds: int32Ptr(600),
+				},
+			},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, err := createRawDeployment(tc.args.objectMeta, tc.args.componentExt, tc.args.podSpec, &v1beta1.DeployConfig{})
+			assert.NoError(t, err)
+			if diff := cmp.Diff(tc.expected, got); diff != "" {
+				t.Errorf("Test %q unexpected deployment (-want +got): %v", name, diff)
+			}
+		})
+	}
+}
+
+func TestDeploymentStrategyAnnotations(t *testing.T) {
+	intOrString := func(value string) *intstr.IntOrString {
+		v := intstr.Parse(value)
+		return &v
+	}
+	testCases := map[string]struct {
+		annotations  map[string]string
+		componentExt *v1beta1.ComponentExtensionSpec
+		expected     appsv1.DeploymentStrategy
+		expectedErr  bool
+	}{
+		"noAnnotations": {
+			annotations:  map[string]string{},
+			componentExt: &v1beta1.ComponentExtensionSpec{},
+			expected: appsv1.DeploymentStrategy{
+				Type: appsv1.RollingUpdateDeploymentStrategyType,
+				RollingUpdate: &appsv1.RollingUpdateDeployment{
+					MaxUnavailable: intOrString("25%"),
+					MaxSurge:       intOrString("25%"),
+				},
+			},
+		},
+		"recreate": {
+			annotations: map[string]string{
+				constants.DeploymentStrategyAnnotationKey: "Recreate",
+			},
+			componentExt: &v1beta1.ComponentExtensionSpec{},
+			expected: appsv1.DeploymentStrategy{
+				Type: appsv1.RecreateDeploymentStrategyType,
+			},
+		},
+		"rollingUpdateWithoutSurge": {
+			annotations: map[string]string{
+				constants.DeploymentStrategyAnnotationKey:          "RollingUpdate",
+				constants.RollingUpdateMaxSurgeAnnotationKey:       "0",
+				constants.RollingUpdateMaxUnavailableAnnotationKey: "1",
+			},
+			componentExt: &v1beta1.ComponentExtensionSpec{},
+			expected: appsv1.DeploymentStrategy{
+				Type: appsv1.RollingUpdateDeploymentStrategyType,
+				RollingUpdate: &appsv1.RollingUpdateDeployment{
+					MaxUnavailable: intOrString("1"),
+					MaxSurge:       intOrString("0"),
+				},
+			},
+		},
+		"rollingUpdateImpliedByMaxUnavailable": {
+			annotations: map[string]string{
+				constants.RollingUpdateMaxUnavailableAnnotationKey: "50%",
+			},
+			componentExt: &v1beta1.ComponentExtensionSpec{},
+			expected: appsv1.DeploymentStrategy{
+				Type: appsv1.RollingUpdateDeploymentStrategyType,
+				RollingUpdate: &appsv1.RollingUpdateDeployment{
+					MaxUnavailable: intOrString("50%"),
+					MaxSurge:       intOrString("25%"),
+				},
+			},
+		},
+		"componentExtTakesPrecedence": {
+			annotations: map[string]string{
+				constants.DeploymentStrategyAnnotationKey: "Recreate",
+			},
+			componentExt: &v1beta1.ComponentExtensionSpec{
+				DeploymentStrategy: &appsv1.DeploymentStrategy{
+					Type: appsv1.RollingUpdateDeploymentStrategyType,
+					RollingUpdate: &appsv1.RollingUpdateDeployment{
+						MaxUnavailable: intOrString("0"),
+						MaxSurge:       intOrString("1"),
+					},
+				},
+			},
+			expected: appsv1.DeploymentStrategy{
+				Type: appsv1.RollingUpdateDeploymentStrategyType,
+				RollingUpdate: &appsv1.RollingUpdateDeployment{
+					MaxUnavailable: intOrString("0"),
+					MaxSurge:       intOrString("1"),
+				},
+			},
+		},
+		"recreateWithMaxSurge": {
+			annotations: map[string]string{
+				constants.DeploymentStrategyAnnotationKey:    "Recreate",
+				constants.RollingUpdateMaxSurgeAnnotationKey: "1",
+			},
+			componentExt: &v1beta1.ComponentExtensionSpec{},
+			expectedErr:  true,
+		},
+		"unknownStrategy": {
+			annotations: map[string]string{
+				constants.DeploymentStrategyAnnotationKey: "BlueGreen",
+			},
+			componentExt: &v1beta1.ComponentExtensionSpec{},
+			expectedErr:  true,
+		},
+		"invalidMaxSurge": {
+			annotations: map[string]string{
+				constants.RollingUpdateMaxSurgeAnnotationKey: "abc",
+			},
+			componentExt: &v1beta1.ComponentExtensionSpec{},
+			expectedErr:  true,
+		},
+		"negativeMaxUnavailable": {
+			annotations: map[string]string{
+				constants.RollingUpdateMaxUnavailableAnnotationKey: "-1",
+			},
+			componentExt: &v1beta1.ComponentExtensionSpec{},
+			expectedErr:  true,
+		},
+		"percentageAboveHundred": {
+			annotations: map[string]string{
+				constants.RollingUpdateMaxUnavailableAnnotationKey: "150%",
+			},
+			componentExt: &v1beta1.ComponentExtensionSpec{},
+			expectedErr:  true,
+		},
+		"bothZero": {
+			annotations: map[string]string{
+				constants.RollingUpdateMaxSurgeAnnotationKey:       "0",
+				constants.RollingUpdateMaxUnavailableAnnotationKey: "0%",
+			},
+			componentExt: &v1beta1.ComponentExtensionSpec{},
+			expectedErr:  true,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			objectMeta := metav1.ObjectMeta{
+				Name:        "strategy-predictor",
+				Namespace:   "strategy-predictor-namespace",
+				Labels:      map[string]string{},
+				Annotations: tc.annotations,
+			}
+			podSpec := &corev1.PodSpec{
+				Containers: []corev1.Container{
+					{Name: constants.InferenceServiceContainerName, Image: "strategy-predictor-image"},
+				},
+			}
+			got, err := createRawDeployment(objectMeta, tc.componentExt, podSpec, &v1beta1.DeployConfig{})
+			if tc.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			if diff := cmp.Diff(tc.expected, got.Spec.Strategy); diff != "" {
+				t.Errorf("Test %q unexpected deployment strategy (-want +got): %v", name, diff)
+			}
+		})
+	}
+}
+
+func TestSetDefaultPodSpecKeepsUserProbes(t *testing.T) {
+	readinessProbe := &corev1.Probe{
+		ProbeHandler: corev1.ProbeHandler{
+			HTTPGet: &corev1.HTTPGetAction{Path: "/v2/health/ready", Port: intstr.FromInt(8080)},
+		},
+		InitialDelaySeconds: 30,
+	}
+	podSpec := &corev1.PodSpec{
+		Containers: []corev1.Container{
+			{
+				Name:           constants.InferenceServiceContainerName,
+				ReadinessProbe: readinessProbe.DeepCopy(),
+			},
+			{
+				Name: constants.TransformerContainerName,
+				Ports: []corev1.ContainerPort{
+					{ContainerPort: 9000},
+				},
+			},
+		},
+	}
+	setDefaultPodSpec(podSpec)
+	assert.Equal(t, readinessProbe, podSpec.Containers[0].ReadinessProbe)
+	assert.Equal(t, int32(9000), podSpec.Containers[1].ReadinessProbe.TCPSocket.Port.IntVal)
+}
+
+func TestDeploymentTimingAnnotations(t *testing.T) {
+	testCases := map[string]struct {
+		annotations             map[string]string
+		minReadySeconds         int32
+		progressDeadlineSeconds int32
+		expectedErr             bool
+	}{
+		"defaults": {
+			annotations:             map[string]string{},
+			minReadySeconds:         0,
+			progressDeadlineSeconds: 600,
+		},
+		"slowModel": {
+			annotations: map[string]string{
+				constants.DeploymentMinReadySecondsAnnotationKey: "10",
+				constants.ProgressDeadlineSecondsAnnotationKey:   "1800",
+			},
+			minReadySeconds:         10,
+			progressDeadlineSeconds: 1800,
+		},
+		"negativeMinReadySeconds": {
+			annotations: map[string]string{
+				constants.DeploymentMinReadySecondsAnnotationKey: "-10",
+			},
+			expectedErr: true,
+		},
+		"deadlineNotGreaterThanMinReady": {
+			annotations: map[string]string{
+				constants.DeploymentMinReadySecondsAnnotationKey: "60",
+				constants.ProgressDeadlineSecondsAnnotationKey:   "60",
+			},
+			expectedErr: true,
+		},
+		"minReadyNotLessThanDefaultDeadline": {
+			annotations: map[string]string{
+				constants.DeploymentMinReadySecondsAnnotationKey: "600",
+			},
+			expectedErr: true,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			objectMeta := metav1.ObjectMeta{
+				Name:        "timing-predictor",
+				Namespace:   "timing-predictor-namespace",
+				Labels:      map[string]string{},
+				Annotations: tc.annotations,
+			}
+			podSpec := &corev1.PodSpec{
+				Containers: []corev1.Container{
+					{Name: constants.InferenceServiceContainerName, Image: "timing-predictor-image"},
+				},
+			}
+			got, err := createRawDeployment(objectMeta, &v1beta1.ComponentExtensionSpec{}, podSpec, &v1beta1.DeployConfig{})
+			if tc.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.minReadySeconds, got.Spec.MinReadySeconds)
+			assert.Equal(t, tc.progressDeadlineSeconds, *got.Spec.ProgressDeadlineSeconds)
+		})
+	}
+}
+
+func TestDefaultTopologySpreadConstraints(t *testing.T) {
+	deployConfig := &v1beta1.DeployConfig{
+		DefaultTopologySpreadConstraints: []corev1.TopologySpreadConstraint{
+			{
+				MaxSkew:           1,
+				TopologyKey:       "topology.kubernetes.io/zone",
+				WhenUnsatisfiable: corev1.ScheduleAnyway,
+			},
+		},
+	}
+	explicitConstraint := corev1.TopologySpreadConstraint{
+		MaxSkew:           2,
+		TopologyKey:       "kubernetes.io/hostname",
+		WhenUnsatisfiable: corev1.DoNotSchedule,
+	}
+	testCases := map[string]struct {
+		annotations map[string]string
+		constraints []corev1.TopologySpreadConstraint
+		expected    []corev1.TopologySpreadConstraint
+	}{
+		"defaultsApplied": {
+			annotations: map[string]string{},
+			expected: []corev1.TopologySpreadConstraint{
+				{
+					MaxSkew:           1,
+					TopologyKey:       "topology.kubernetes.io/zone",
+					WhenUnsatisfiable: corev1.ScheduleAnyway,
+					LabelSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{"app": "isvc.spread-predictor"},
+					},
+				},
+			},
+		},
+		"explicitConstraintsKept": {
+			annotations: map[string]string{},
+			constraints: []corev1.TopologySpreadConstraint{explicitConstraint},
+			expected:    []corev1.TopologySpreadConstraint{explicitConstraint},
+		},
+		"disabledByAnnotation": {
+			annotations: map[string]string{
+				constants.DisableDefaultTopologySpreadAnnotationKey: "true",
+			},
+			expected: nil,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			objectMeta := metav1.ObjectMeta{
+				Name:        "spread-predictor",
+				Namespace:   "spread-predictor-namespace",
+				Labels:      map[string]string{},
+				Annotations: tc.annotations,
+			}
+			podSpec := &corev1.PodSpec{
+				Containers: []corev1.Container{
+					{Name: constants.InferenceServiceContainerName, Image: "spread-predictor-image"},
+				},
+				TopologySpreadConstraints: tc.constraints,
+			}
+			got, err := createRawDeployment(objectMeta, &v1beta1.ComponentExtensionSpec{}, podSpec, deployConfig)
+			assert.NoError(t, err)
+			if diff := cmp.Diff(tc.expected, got.Spec.Template.Spec.TopologySpreadConstraints); diff != "" {
+				t.Errorf("Test %q unexpected topology spread constraints (-want +got): %v", name, diff)
+			}
+			for _, constraint := range got.Spec.Template.Spec.TopologySpreadConstraints {
+				if constraint.LabelSelector != nil {
+					assert.Equal(t, got.Spec.Selector.MatchLabels, constraint.LabelSelector.MatchLabels)
+				}
+			}
+		})
+	}
+	// the defaults in the config must not be mutated
+	assert.Nil(t, deployConfig.DefaultTopologySpreadConstraints[0].LabelSelector)
+}
+
+func TestPreStopHookAndTerminationGracePeriod(t *testing.T) {
+	sleepHook := func(seconds string) *corev1.Lifecycle {
+		return &corev1.Lifecycle{
+			PreStop: &corev1.LifecycleHandler{
+				Exec: &corev1.ExecAction{Command: []string{"/bin/sh", "-c", "sleep " + seconds}},
+			},
+		}
+	}
+	userHook := &corev1.Lifecycle{
+		PreStop: &corev1.LifecycleHandler{
+			HTTPGet: &corev1.HTTPGetAction{Path: "/drain", Port: intstr.FromInt(8080)},
+		},
+	}
+	testCases := map[string]struct {
+		annotations       map[string]string
+		deployConfig      *v1beta1.DeployConfig
+		lifecycle         *corev1.Lifecycle
+		sidecar           string
+		expectedLifecycle *corev1.Lifecycle
+		expectedGrace     int64
+		expectedErr       bool
+	}{
+		"hookDisabledByDefault": {
+			deployConfig:  &v1beta1.DeployConfig{},
+			expectedGrace: corev1.DefaultTerminationGracePeriodSeconds,
+		},
+		"configuredHookAndGracePeriod": {
+			annotations: map[string]string{
+				constants.TerminationGracePeriodAnnotationKey: "120",
+			},
+			deployConfig:      &v1beta1.DeployConfig{PreStopSleepSeconds: int64Ptr(15)},
+			expectedLifecycle: sleepHook("15"),
+			expectedGrace:     120,
+		},
+		"hookDisabledByConfig": {
+			deployConfig:  &v1beta1.DeployConfig{PreStopSleepSeconds: int64Ptr(0)},
+			expectedGrace: corev1.DefaultTerminationGracePeriodSeconds,
+		},
+		"userLifecycleKept": {
+			deployConfig:      &v1beta1.DeployConfig{PreStopSleepSeconds: int64Ptr(5)},
+			lifecycle:         userHook,
+			expectedLifecycle: userHook,
+			expectedGrace:     corev1.DefaultTerminationGracePeriodSeconds,
+		},
+		"agentInjected": {
+			annotations: map[string]string{
+				constants.LoggerInternalAnnotationKey: "true",
+			},
+			deployConfig:  &v1beta1.DeployConfig{PreStopSleepSeconds: int64Ptr(5)},
+			expectedGrace: corev1.DefaultTerminationGracePeriodSeconds,
+		},
+		"queueProxySidecar": {
+			deployConfig:  &v1beta1.DeployConfig{PreStopSleepSeconds: int64Ptr(5)},
+			sidecar:       "queue-proxy",
+			expectedGrace: corev1.DefaultTerminationGracePeriodSeconds,
+		},
+		"invalidGracePeriod": {
+			annotations: map[string]string{
+				constants.TerminationGracePeriodAnnotationKey: "-1",
+			},
+			deployConfig: &v1beta1.DeployConfig{},
+			expectedErr:  true,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			objectMeta := metav1.ObjectMeta{
+				Name:        "drain-predictor",
+				Namespace:   "drain-predictor-namespace",
+				Labels:      map[string]string{},
+				Annotations: tc.annotations,
+			}
+			podSpec := &corev1.PodSpec{
+				Containers: []corev1.Container{
+					{
+						Name:      constants.InferenceServiceContainerName,
+						Image:     "drain-predictor-image",
+						Lifecycle: tc.lifecycle.DeepCopy(),
+					},
+				},
+			}
+			if tc.sidecar != "" {
+				podSpec.Containers = append(podSpec.Containers, corev1.Container{Name: tc.sidecar, Image: "sidecar-image"})
+			}
+			got, err := createRawDeployment(objectMeta, &v1beta1.ComponentExtensionSpec{}, podSpec, tc.deployConfig)
+			if tc.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			if diff := cmp.Diff(tc.expectedLifecycle, got.Spec.Template.Spec.Containers[0].Lifecycle); diff != "" {
+				t.Errorf("Test %q unexpected lifecycle (-want +got): %v", name, diff)
+			}
+			assert.Equal(t, tc.expectedGrace, *got.Spec.Template.Spec.TerminationGracePeriodSeconds)
+		})
+	}
+}
+
+func TestDeploymentReconcilePreservesForeignMetadata(t *testing.T) {
+	scheme := runtime.NewScheme()
+	assert.NoError(t, appsv1.AddToScheme(scheme))
+	componentMeta := func() metav1.ObjectMeta {
+		return metav1.ObjectMeta{
+			Name:      "merge-predictor",
+			Namespace: "merge-predictor-namespace",
+			Labels: map[string]string{
+				constants.InferenceServicePodLabelKey: "merge",
+			},
+			Annotations: map[string]string{
+				constants.DeploymentMinReadySecondsAnnotationKey: "10",
+			},
+		}
+	}
+	podSpec := func(image string) *corev1.PodSpec {
+		return &corev1.PodSpec{
+			Containers: []corev1.Container{
+				{Name: constants.InferenceServiceContainerName, Image: image},
+			},
+		}
+	}
+	existing, err := createRawDeployment(componentMeta(), &v1beta1.ComponentExtensionSpec{}, podSpec("drifted-image"), &v1beta1.DeployConfig{})
+	assert.NoError(t, err)
+	existing.Labels["team"] = "ml-platform"
+	existing.Annotations["argocd.argoproj.io/tracking-id"] = "models:apps/Deployment:merge-predictor"
+	existing.Annotations["policies.kyverno.io/last-applied-patches"] = "add-labels.add-labels.kyverno.io: added /metadata/labels/team"
+	existing.Annotations[constants.ProgressDeadlineSecondsAnnotationKey] = "1200"
+	cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(existing).Build()
+
+	r, err := NewDeploymentReconciler(cl, scheme, componentMeta(), &v1beta1.ComponentExtensionSpec{}, podSpec("model-image"), &v1beta1.DeployConfig{})
+	assert.NoError(t, err)
+	_, err = r.Reconcile()
+	assert.NoError(t, err)
+
+	updated := &appsv1.Deployment{}
+	assert.NoError(t, cl.Get(context.TODO(), types.NamespacedName{Name: existing.Name, Namespace: existing.Namespace}, updated))
+	assert.Equal(t, "model-image", updated.Spec.Template.Spec.Containers[0].Image)
+	assert.Equal(t, map[string]string{
+		constants.InferenceServicePodLabelKey: "merge",
+		"app":                                 "isvc.merge-predictor",
+		"team":                                "ml-platform",
+	}, updated.Labels)
+	assert.Equal(t, map[string]string{
+		constants.DeploymentMinReadySecondsAnnotationKey: "10",
+		"argocd.argoproj.io/tracking-id":                 "models:apps/Deployment:merge-predictor",
+		"policies.kyverno.io/last-applied-patches":       "add-labels.add-labels.kyverno.io: added /metadata/labels/team",
+	}, updated.Annotations)
+
+	// foreign metadata alone does not trigger an update
+	r, err = NewDeploymentReconciler(cl, scheme, componentMeta(), &v1beta1.ComponentExtensionSpec{}, podSpec("model-image"), &v1beta1.DeployConfig{})
+	assert.NoError(t, err)
+	checkResult, _, err := r.checkDeploymentExist(cl)
+	assert.NoError(t, err)
+	assert.Equal(t, constants.CheckResultExisted, checkResult)
+}
+
+func TestDeploymentReconcilePreservesReplicas(t *testing.T) {
+	scheme := runtime.NewScheme()
+	assert.NoError(t, appsv1.AddToScheme(scheme))
+	podSpec := func(image string) *corev1.PodSpec {
+		return &corev1.PodSpec{
+			Containers: []corev1.Container{
+				{Name: constants.InferenceServiceContainerName, Image: image},
+			},
+		}
+	}
+	testCases := map[string]struct {
+		autoscalerClass  constants.AutoscalerClassType
+		minReplicas      int
+		expectedReplicas *int32
+	}{
+		"KedaScaledToZero": {
+			autoscalerClass:  constants.AutoscalerClassKeda,
+			minReplicas:      0,
+			expectedReplicas: ptr.Int32(0),
+		},
+		"ExternalScaledToZero": {
+			autoscalerClass:  constants.AutoscalerClassExternal,
+			minReplicas:      0,
+			expectedReplicas: ptr.Int32(0),
+		},
+		"HPAReplicasNotKept": {
+			autoscalerClass: constants.AutoscalerClassHPA,
+			minReplicas:     0,
+		},
+		"KedaMinReplicasNotKept": {
+			autoscalerClass: constants.AutoscalerClassKeda,
+			minReplicas:     1,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			componentMeta := func() metav1.ObjectMeta {
+				return metav1.ObjectMeta{
+					Name:      "scaled-predictor",
+					Namespace: "scaled-predictor-namespace",
+					Labels:    map[string]string{},
+					Annotations: map[string]string{
+						constants.AutoscalerClass: string(tc.autoscalerClass),
+					},
+				}
+			}
+			componentExt := &v1beta1.ComponentExtensionSpec{MinReplicas: v1beta1.GetIntReference(tc.minReplicas)}
+			existing, err := createRawDeployment(componentMeta(), componentExt, podSpec("old-image"), &v1beta1.DeployConfig{})
+			assert.NoError(t, err)
+			// scaled to zero by the autoscaler, or manually
+			existing.Spec.Replicas = ptr.Int32(0)
+			cl := fake.NewClientBuilder().WithScheme(scheme).WithObjects(existing).Build()
+
+			r, err := NewDeploymentReconciler(cl, scheme, componentMeta(), componentExt, podSpec("new-image"), &v1beta1.DeployConfig{})
+			assert.NoError(t, err)
+			_, err = r.Reconcile()
+			assert.NoError(t, err)
+
+			updated := &appsv1.Deployment{}
+			assert.NoError(t, cl.Get(context.TODO(), types.NamespacedName{Name: existing.Name, Namespace: existing.Namespace}, updated))
+			assert.Equal(t, "new-image", updated.Spec.Template.Spec.Containers[0].Image)
+			assert.Equal(t, tc.expectedReplicas, updated.Spec.Replicas)
+		})
+	}
+}
+
+func TestAutomountServiceAccountToken(t *testing.T) {
+	testCases := map[string]struct {
+		annotations  map[string]string
+		deployConfig *v1beta1.DeployConfig
+		podSpecValue *bool
+		expected     *bool
+		expectedErr  bool
+	}{
+		"unset": {
+			deployConfig: &v1beta1.DeployConfig{},
+			expected:     nil,
+		},
+		"enabledByAnnotation": {
+			annotations: map[string]string{
+				constants.AutomountServiceAccountTokenAnnotationKey: "true",
+			},
+			deployConfig: &v1beta1.DeployConfig{},
+			expected:     boolPtr(true),
+		},
+		"disabledByAnnotation": {
+			annotations: map[string]string{
+				constants.AutomountServiceAccountTokenAnnotationKey: "false",
+			},
+			deployConfig: &v1beta1.DeployConfig{},
+			expected:     boolPtr(false),
+		},
+		"configDefault": {
+			deployConfig: &v1beta1.DeployConfig{AutomountServiceAccountToken: boolPtr(false)},
+			expected:     boolPtr(false),
+		},
+		"annotationOverridesConfigDefault": {
+			annotations: map[string]string{
+				constants.AutomountServiceAccountTokenAnnotationKey: "true",
+			},
+			deployConfig: &v1beta1.DeployConfig{AutomountServiceAccountToken: boolPtr(false)},
+			expected:     boolPtr(true),
+		},
+		"podSpecTakesPrecedence": {
+			annotations: map[string]string{
+				constants.AutomountServiceAccountTokenAnnotationKey: "false",
+			},
+			deployConfig: &v1beta1.DeployConfig{},
+			podSpecValue: boolPtr(true),
+			expected:     boolPtr(true),
+		},
+		"invalidAnnotation": {
+			annotations: map[string]string{
+				constants.AutomountServiceAccountTokenAnnotationKey: "yes please",
+			},
+			deployConfig: &v1beta1.DeployConfig{},
+			expectedErr:  true,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			objectMeta := metav1.ObjectMeta{
+				Name:        "token-predictor",
+				Namespace:   "token-predictor-namespace",
+				Labels:      map[string]string{},
+				Annotations: tc.annotations,
+			}
+			podSpec := &corev1.PodSpec{
+				Containers: []corev1.Container{
+					{Name: constants.InferenceServiceContainerName, Image: "token-predictor-image"},
+				},
+				AutomountServiceAccountToken: tc.podSpecValue,
+			}
+			got, err := createRawDeployment(objectMeta, &v1beta1.ComponentExtensionSpec{}, podSpec, tc.deployConfig)
+			if tc.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, got.Spec.Template.Spec.AutomountServiceAccountToken)
+		})
+	}
+}
+
+func int32Ptr(i int32) *int32 {
+	return &i
+}
+
+func int64Ptr(i int64) *int64 {
+	return &i
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
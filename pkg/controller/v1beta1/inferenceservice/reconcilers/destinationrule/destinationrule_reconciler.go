@@ -0,0 +1,252 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package destinationrule
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+	istiov1beta1 "istio.io/api/networking/v1beta1"
+	istioclientv1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"knative.dev/pkg/network"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/kserve/kserve/pkg/utils"
+)
+
+var log = logf.Log.WithName("DestinationRuleReconciler")
+
+// DestinationRuleReconciler reconciles the istio DestinationRules of the InferenceService component services
+type DestinationRuleReconciler struct {
+	client        client.Client
+	scheme        *runtime.Scheme
+	ingressConfig *v1beta1.IngressConfig
+}
+
+func NewDestinationRuleReconciler(client client.Client, scheme *runtime.Scheme, ingressConfig *v1beta1.IngressConfig) *DestinationRuleReconciler {
+	return &DestinationRuleReconciler{
+		client:        client,
+		scheme:        scheme,
+		ingressConfig: ingressConfig,
+	}
+}
+
+// getDestinationRuleConfig returns the destination rule config overridden by the destination-rule annotations
+func getDestinationRuleConfig(annotations map[string]string, config v1beta1.DestinationRuleConfig) (v1beta1.DestinationRuleConfig, error) {
+	for _, setting := range []struct {
+		key   string
+		value *int32
+	}{
+		{constants.DestinationRuleMaxConnectionsAnnotationKey, &config.MaxConnections},
+		{constants.DestinationRuleHTTP2MaxRequestsAnnotationKey, &config.HTTP2MaxRequests},
+		{constants.DestinationRuleConsecutive5xxErrorsAnnotationKey, &config.Consecutive5xxErrors},
+	} {
+		if value, ok := annotations[setting.key]; ok {
+			parsed, err := strconv.ParseInt(value, 10, 32)
+			if err != nil || parsed < 1 {
+				return config, fmt.Errorf("invalid value %q for annotation %s, must be a positive integer", value, setting.key)
+			}
+			*setting.value = int32(parsed)
+		}
+	}
+	if value, ok := annotations[constants.DestinationRuleBaseEjectionTimeAnnotationKey]; ok {
+		config.BaseEjectionTime = value
+	}
+	return config, nil
+}
+
+// parseDuration returns the protobuf duration of the value, nil if the value is empty
+func parseDuration(value string) (*durationpb.Duration, error) {
+	if value == "" {
+		return nil, nil
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return nil, err
+	}
+	return durationpb.New(duration), nil
+}
+
+func createTrafficPolicy(config v1beta1.DestinationRuleConfig) (*istiov1beta1.TrafficPolicy, error) {
+	trafficPolicy := &istiov1beta1.TrafficPolicy{}
+	if config.MaxConnections > 0 || config.HTTP2MaxRequests > 0 {
+		trafficPolicy.ConnectionPool = &istiov1beta1.ConnectionPoolSettings{}
+		if config.MaxConnections > 0 {
+			trafficPolicy.ConnectionPool.Tcp = &istiov1beta1.ConnectionPoolSettings_TCPSettings{
+				MaxConnections: config.MaxConnections,
+			}
+		}
+		if config.HTTP2MaxRequests > 0 {
+			trafficPolicy.ConnectionPool.Http = &istiov1beta1.ConnectionPoolSettings_HTTPSettings{
+				Http2MaxRequests: config.HTTP2MaxRequests,
+			}
+		}
+	}
+	if config.Consecutive5xxErrors > 0 {
+		interval, err := parseDuration(config.Interval)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid outlier detection interval")
+		}
+		baseEjectionTime, err := parseDuration(config.BaseEjectionTime)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid outlier detection base ejection time")
+		}
+		trafficPolicy.OutlierDetection = &istiov1beta1.OutlierDetection{
+			Consecutive_5XxErrors: wrapperspb.UInt32(uint32(config.Consecutive5xxErrors)),
+			Interval:              interval,
+			BaseEjectionTime:      baseEjectionTime,
+			MaxEjectionPercent:    config.MaxEjectionPercent,
+		}
+	}
+	if config.IstioMutualTLS {
+		trafficPolicy.Tls = &istiov1beta1.ClientTLSSettings{
+			Mode: istiov1beta1.ClientTLSSettings_ISTIO_MUTUAL,
+		}
+	}
+	return trafficPolicy, nil
+}
+
+// getComponentServices returns the names of the services of the InferenceService components
+func getComponentServices(isvc *v1beta1.InferenceService, useDefault bool) map[constants.InferenceServiceComponent]string {
+	services := map[constants.InferenceServiceComponent]string{
+		constants.Predictor: constants.PredictorServiceName(isvc.GeneratedNamePrefix()),
+	}
+	if useDefault {
+		services[constants.Predictor] = constants.DefaultPredictorServiceName(isvc.GeneratedNamePrefix())
+	}
+	if isvc.Spec.Transformer != nil {
+		services[constants.Transformer] = constants.TransformerServiceName(isvc.GeneratedNamePrefix())
+		if useDefault {
+			services[constants.Transformer] = constants.DefaultTransformerServiceName(isvc.GeneratedNamePrefix())
+		}
+	}
+	if isvc.Spec.Explainer != nil {
+		services[constants.Explainer] = constants.ExplainerServiceName(isvc.GeneratedNamePrefix())
+		if useDefault {
+			services[constants.Explainer] = constants.DefaultExplainerServiceName(isvc.GeneratedNamePrefix())
+		}
+	}
+	return services
+}
+
+func createDestinationRule(isvc *v1beta1.InferenceService, componentType constants.InferenceServiceComponent,
+	serviceName string, trafficPolicy *istiov1beta1.TrafficPolicy) *istioclientv1beta1.DestinationRule {
+	return &istioclientv1beta1.DestinationRule{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      serviceName,
+			Namespace: isvc.Namespace,
+			Labels: utils.Union(isvc.Labels, map[string]string{
+				constants.InferenceServicePodLabelKey: isvc.Name,
+				constants.KServiceComponentLabel:      string(componentType),
+			}),
+		},
+		Spec: istiov1beta1.DestinationRule{
+			Host:          network.GetServiceHostname(serviceName, isvc.Namespace),
+			TrafficPolicy: trafficPolicy,
+		},
+	}
+}
+
+func semanticDestinationRuleEquals(desired, existing *istioclientv1beta1.DestinationRule) bool {
+	return cmp.Equal(desired.Spec.DeepCopy(), existing.Spec.DeepCopy(), protocmp.Transform()) &&
+		equality.Semantic.DeepEqual(desired.Labels, existing.Labels)
+}
+
+// Reconcile creates or updates a DestinationRule per component service of the InferenceService when DestinationRules
+// are enabled and deletes the DestinationRules of the InferenceService which are no longer desired.
+func (r *DestinationRuleReconciler) Reconcile(isvc *v1beta1.InferenceService) error {
+	desired := map[string]*istioclientv1beta1.DestinationRule{}
+	if r.ingressConfig.EnableDestinationRule {
+		config, err := getDestinationRuleConfig(isvc.Annotations, r.ingressConfig.DestinationRule)
+		if err != nil {
+			return err
+		}
+		trafficPolicy, err := createTrafficPolicy(config)
+		if err != nil {
+			return err
+		}
+		// Check if existing predictor service name has default suffix
+		useDefault := r.client.Get(context.TODO(), types.NamespacedName{Name: constants.DefaultPredictorServiceName(isvc.GeneratedNamePrefix()), Namespace: isvc.Namespace}, &corev1.Service{}) == nil
+		for componentType, serviceName := range getComponentServices(isvc, useDefault) {
+			destinationRule := createDestinationRule(isvc, componentType, serviceName, trafficPolicy)
+			if err := controllerutil.SetControllerReference(isvc, destinationRule, r.scheme); err != nil {
+				return errors.Wrapf(err, "fails to set owner reference for destination rule")
+			}
+			desired[destinationRule.Name] = destinationRule
+		}
+	}
+
+	existingList := &istioclientv1beta1.DestinationRuleList{}
+	if err := r.client.List(context.TODO(), existingList, client.InNamespace(isvc.Namespace),
+		client.MatchingLabels{constants.InferenceServicePodLabelKey: isvc.Name}); err != nil {
+		// the DestinationRule CRD is only required when DestinationRules are enabled
+		if !r.ingressConfig.EnableDestinationRule && (meta.IsNoMatchError(err) || runtime.IsNotRegisteredError(err)) {
+			return nil
+		}
+		return errors.Wrapf(err, "fails to list destination rules")
+	}
+	existingRules := map[string]*istioclientv1beta1.DestinationRule{}
+	for _, existing := range existingList.Items {
+		if !metav1.IsControlledBy(existing, isvc) {
+			continue
+		}
+		if _, ok := desired[existing.Name]; ok {
+			existingRules[existing.Name] = existing
+			continue
+		}
+		log.Info("Deleting DestinationRule for isvc", "namespace", existing.Namespace, "name", existing.Name)
+		if err := r.client.Delete(context.TODO(), existing); err != nil && !apierr.IsNotFound(err) {
+			return errors.Wrapf(err, "fails to delete destination rule")
+		}
+	}
+
+	for name, destinationRule := range desired {
+		var err error
+		if existing, ok := existingRules[name]; !ok {
+			log.Info("Creating DestinationRule for isvc", "namespace", destinationRule.Namespace, "name", name)
+			err = r.client.Create(context.TODO(), destinationRule)
+		} else if !semanticDestinationRuleEquals(destinationRule, existing) {
+			deepCopy := existing.DeepCopy()
+			deepCopy.Spec = *destinationRule.Spec.DeepCopy()
+			deepCopy.Labels = destinationRule.Labels
+			log.Info("Updating DestinationRule for isvc", "namespace", destinationRule.Namespace, "name", name)
+			err = r.client.Update(context.TODO(), deepCopy)
+		}
+		if err != nil {
+			return errors.Wrapf(err, "fails to create or update destination rule")
+		}
+	}
+	return nil
+}
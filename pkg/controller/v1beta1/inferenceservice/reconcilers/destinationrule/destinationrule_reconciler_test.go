@@ -0,0 +1,203 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package destinationrule
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+	istiov1beta1 "istio.io/api/networking/v1beta1"
+	istioclientv1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+func TestCreateTrafficPolicy(t *testing.T) {
+	testCases := map[string]struct {
+		annotations map[string]string
+		config      v1beta1.DestinationRuleConfig
+		expected    *istiov1beta1.TrafficPolicy
+		expectedErr bool
+	}{
+		"empty": {
+			expected: &istiov1beta1.TrafficPolicy{},
+		},
+		"config": {
+			config: v1beta1.DestinationRuleConfig{
+				MaxConnections:       100,
+				HTTP2MaxRequests:     1000,
+				Consecutive5xxErrors: 5,
+				Interval:             "10s",
+				BaseEjectionTime:     "30s",
+				MaxEjectionPercent:   50,
+				IstioMutualTLS:       true,
+			},
+			expected: &istiov1beta1.TrafficPolicy{
+				ConnectionPool: &istiov1beta1.ConnectionPoolSettings{
+					Tcp:  &istiov1beta1.ConnectionPoolSettings_TCPSettings{MaxConnections: 100},
+					Http: &istiov1beta1.ConnectionPoolSettings_HTTPSettings{Http2MaxRequests: 1000},
+				},
+				OutlierDetection: &istiov1beta1.OutlierDetection{
+					Consecutive_5XxErrors: wrapperspb.UInt32(5),
+					Interval:              durationpb.New(10 * time.Second),
+					BaseEjectionTime:      durationpb.New(30 * time.Second),
+					MaxEjectionPercent:    50,
+				},
+				Tls: &istiov1beta1.ClientTLSSettings{Mode: istiov1beta1.ClientTLSSettings_ISTIO_MUTUAL},
+			},
+		},
+		"annotationsOverrideConfig": {
+			annotations: map[string]string{
+				constants.DestinationRuleHTTP2MaxRequestsAnnotationKey:     "200",
+				constants.DestinationRuleConsecutive5xxErrorsAnnotationKey: "3",
+				constants.DestinationRuleBaseEjectionTimeAnnotationKey:     "1m",
+			},
+			config: v1beta1.DestinationRuleConfig{
+				HTTP2MaxRequests: 1000,
+				BaseEjectionTime: "30s",
+			},
+			expected: &istiov1beta1.TrafficPolicy{
+				ConnectionPool: &istiov1beta1.ConnectionPoolSettings{
+					Http: &istiov1beta1.ConnectionPoolSettings_HTTPSettings{Http2MaxRequests: 200},
+				},
+				OutlierDetection: &istiov1beta1.OutlierDetection{
+					Consecutive_5XxErrors: wrapperspb.UInt32(3),
+					BaseEjectionTime:      durationpb.New(time.Minute),
+				},
+			},
+		},
+		"invalidAnnotation": {
+			annotations: map[string]string{constants.DestinationRuleMaxConnectionsAnnotationKey: "0"},
+			expectedErr: true,
+		},
+		"invalidBaseEjectionTime": {
+			annotations: map[string]string{
+				constants.DestinationRuleConsecutive5xxErrorsAnnotationKey: "3",
+				constants.DestinationRuleBaseEjectionTimeAnnotationKey:     "30",
+			},
+			expectedErr: true,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			config, err := getDestinationRuleConfig(tc.annotations, tc.config)
+			var trafficPolicy *istiov1beta1.TrafficPolicy
+			if err == nil {
+				trafficPolicy, err = createTrafficPolicy(config)
+			}
+			if tc.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			if diff := cmp.Diff(tc.expected, trafficPolicy, protocmp.Transform()); diff != "" {
+				t.Errorf("unexpected traffic policy (-want +got): %v", diff)
+			}
+		})
+	}
+}
+
+func TestDestinationRuleReconciler(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = v1beta1.AddToScheme(scheme)
+	_ = istioclientv1beta1.AddToScheme(scheme)
+
+	isvc := &v1beta1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-model",
+			Namespace: "default",
+			UID:       "123",
+			Annotations: map[string]string{
+				constants.DestinationRuleMaxConnectionsAnnotationKey: "10",
+			},
+		},
+		Spec: v1beta1.InferenceServiceSpec{
+			Explainer: &v1beta1.ExplainerSpec{},
+		},
+	}
+	ingressConfig := &v1beta1.IngressConfig{
+		EnableDestinationRule: true,
+		DestinationRule: v1beta1.DestinationRuleConfig{
+			MaxConnections: 100,
+			IstioMutualTLS: true,
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).Build()
+	listDestinationRules := func() map[string]*istioclientv1beta1.DestinationRule {
+		list := &istioclientv1beta1.DestinationRuleList{}
+		assert.NoError(t, c.List(context.TODO(), list, client.InNamespace("default")))
+		destinationRules := map[string]*istioclientv1beta1.DestinationRule{}
+		for _, destinationRule := range list.Items {
+			destinationRules[destinationRule.Name] = destinationRule
+		}
+		return destinationRules
+	}
+
+	assert.NoError(t, NewDestinationRuleReconciler(c, scheme, ingressConfig).Reconcile(isvc))
+	destinationRules := listDestinationRules()
+	assert.Len(t, destinationRules, 2)
+	predictor := destinationRules[constants.PredictorServiceName("my-model")]
+	assert.Equal(t, "my-model-predictor.default.svc.cluster.local", predictor.Spec.Host)
+	assert.Equal(t, int32(10), predictor.Spec.TrafficPolicy.ConnectionPool.Tcp.MaxConnections)
+	assert.Equal(t, istiov1beta1.ClientTLSSettings_ISTIO_MUTUAL, predictor.Spec.TrafficPolicy.Tls.Mode)
+	assert.Equal(t, string(constants.Predictor), predictor.Labels[constants.KServiceComponentLabel])
+	assert.True(t, metav1.IsControlledBy(predictor, isvc))
+	assert.Contains(t, destinationRules, constants.ExplainerServiceName("my-model"))
+
+	// changing the annotations updates the destination rules
+	isvc.Annotations[constants.DestinationRuleMaxConnectionsAnnotationKey] = "20"
+	assert.NoError(t, NewDestinationRuleReconciler(c, scheme, ingressConfig).Reconcile(isvc))
+	predictor = &istioclientv1beta1.DestinationRule{}
+	assert.NoError(t, c.Get(context.TODO(), types.NamespacedName{Name: constants.PredictorServiceName("my-model"), Namespace: "default"}, predictor))
+	assert.Equal(t, int32(20), predictor.Spec.TrafficPolicy.ConnectionPool.Tcp.MaxConnections)
+
+	// removing a component deletes its destination rule
+	isvc.Spec.Explainer = nil
+	assert.NoError(t, NewDestinationRuleReconciler(c, scheme, ingressConfig).Reconcile(isvc))
+	assert.Len(t, listDestinationRules(), 1)
+
+	// disabling the destination rules deletes them
+	assert.NoError(t, NewDestinationRuleReconciler(c, scheme, &v1beta1.IngressConfig{}).Reconcile(isvc))
+	assert.Empty(t, listDestinationRules())
+}
+
+func TestDestinationRuleReconcilerWithoutCRD(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = v1beta1.AddToScheme(scheme)
+
+	isvc := &v1beta1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-model", Namespace: "default", UID: "123"},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).Build()
+	assert.NoError(t, NewDestinationRuleReconciler(c, scheme, &v1beta1.IngressConfig{}).Reconcile(isvc))
+	assert.Error(t, NewDestinationRuleReconciler(c, scheme, &v1beta1.IngressConfig{EnableDestinationRule: true}).Reconcile(isvc))
+}
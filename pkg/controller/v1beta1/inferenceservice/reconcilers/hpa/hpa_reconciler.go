@@ -19,6 +19,8 @@ import (
 
 	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
 	"github.com/kserve/kserve/pkg/constants"
+	"github.com/kserve/kserve/pkg/controller/metrics"
+	"google.golang.org/protobuf/proto"
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
@@ -44,19 +46,42 @@ type HPAReconciler struct {
 func NewHPAReconciler(client client.Client,
 	scheme *runtime.Scheme,
 	componentMeta metav1.ObjectMeta,
-	componentExt *v1beta1.ComponentExtensionSpec) *HPAReconciler {
+	componentExt *v1beta1.ComponentExtensionSpec) (*HPAReconciler, error) {
+	hpa, err := createHPA(componentMeta, componentExt)
+	if err != nil {
+		return nil, err
+	}
 	return &HPAReconciler{
 		client:       client,
 		scheme:       scheme,
-		HPA:          createHPA(componentMeta, componentExt),
+		HPA:          hpa,
 		componentExt: componentExt,
-	}
+	}, nil
 }
 
-func getHPAMetrics(metadata metav1.ObjectMeta, componentExt *v1beta1.ComponentExtensionSpec) []autoscalingv2.MetricSpec {
+func getHPAMetrics(metadata metav1.ObjectMeta, componentExt *v1beta1.ComponentExtensionSpec) ([]autoscalingv2.MetricSpec, error) {
 	var metrics []autoscalingv2.MetricSpec
-	var utilization int32
 	annotations := metadata.Annotations
+
+	customMetrics, err := v1beta1.GetHPACustomMetrics(annotations)
+	if err != nil {
+		return nil, err
+	}
+	_, hasTargetUtilization := annotations[constants.TargetUtilizationPercentage]
+	// Custom metrics replace the default cpu metric unless a resource metric target is set explicitly
+	if len(customMetrics) == 0 || hasTargetUtilization || componentExt.ScaleMetric != nil || componentExt.ScaleTarget != nil {
+		metrics = append(metrics, getResourceMetric(annotations, componentExt))
+	}
+	for _, customMetric := range customMetrics {
+		metrics = append(metrics, getCustomMetric(customMetric))
+	}
+	return metrics, nil
+}
+
+// getResourceMetric returns the resource metric of a component. The scale metric and target of the component
+// extension spec take precedence over the targetUtilizationPercentage annotation shared by all components.
+func getResourceMetric(annotations map[string]string, componentExt *v1beta1.ComponentExtensionSpec) autoscalingv2.MetricSpec {
+	var utilization int32
 	resourceName := corev1.ResourceCPU
 
 	if value, ok := annotations[constants.TargetUtilizationPercentage]; ok {
@@ -79,19 +104,79 @@ func getHPAMetrics(metadata metav1.ObjectMeta, componentExt *v1beta1.ComponentEx
 		AverageUtilization: &utilization,
 	}
 
-	ms := autoscalingv2.MetricSpec{
+	// scale on the model server container only, so that the usage of sidecars does not skew the pod average
+	if containerMetric, _ := strconv.ParseBool(annotations[constants.HPAContainerMetricAnnotationKey]); containerMetric {
+		return autoscalingv2.MetricSpec{
+			Type: autoscalingv2.ContainerResourceMetricSourceType,
+			ContainerResource: &autoscalingv2.ContainerResourceMetricSource{
+				Name:      resourceName,
+				Container: constants.InferenceServiceContainerName,
+				Target:    metricTarget,
+			},
+		}
+	}
+	return autoscalingv2.MetricSpec{
 		Type: autoscalingv2.ResourceMetricSourceType,
 		Resource: &autoscalingv2.ResourceMetricSource{
 			Name:   resourceName,
 			Target: metricTarget,
 		},
 	}
-	metrics = append(metrics, ms)
-	return metrics
+}
+
+// toResourceMetrics replaces the ContainerResource metrics by the equivalent pod Resource metrics.
+func toResourceMetrics(metrics []autoscalingv2.MetricSpec) []autoscalingv2.MetricSpec {
+	resourceMetrics := make([]autoscalingv2.MetricSpec, 0, len(metrics))
+	for _, metric := range metrics {
+		if metric.Type == autoscalingv2.ContainerResourceMetricSourceType && metric.ContainerResource != nil {
+			metric = autoscalingv2.MetricSpec{
+				Type: autoscalingv2.ResourceMetricSourceType,
+				Resource: &autoscalingv2.ResourceMetricSource{
+					Name:   metric.ContainerResource.Name,
+					Target: metric.ContainerResource.Target,
+				},
+			}
+		}
+		resourceMetrics = append(resourceMetrics, metric)
+	}
+	return resourceMetrics
+}
+
+func getCustomMetric(customMetric v1beta1.HPACustomMetric) autoscalingv2.MetricSpec {
+	identifier := autoscalingv2.MetricIdentifier{
+		Name:     customMetric.Name,
+		Selector: customMetric.Selector,
+	}
+	target := autoscalingv2.MetricTarget{
+		Type:         autoscalingv2.AverageValueMetricType,
+		AverageValue: customMetric.AverageValue,
+	}
+	if customMetric.Value != nil {
+		target = autoscalingv2.MetricTarget{
+			Type:  autoscalingv2.ValueMetricType,
+			Value: customMetric.Value,
+		}
+	}
+	if customMetric.Type == autoscalingv2.PodsMetricSourceType {
+		return autoscalingv2.MetricSpec{
+			Type: autoscalingv2.PodsMetricSourceType,
+			Pods: &autoscalingv2.PodsMetricSource{
+				Metric: identifier,
+				Target: target,
+			},
+		}
+	}
+	return autoscalingv2.MetricSpec{
+		Type: autoscalingv2.ExternalMetricSourceType,
+		External: &autoscalingv2.ExternalMetricSource{
+			Metric: identifier,
+			Target: target,
+		},
+	}
 }
 
 func createHPA(componentMeta metav1.ObjectMeta,
-	componentExt *v1beta1.ComponentExtensionSpec) *autoscalingv2.HorizontalPodAutoscaler {
+	componentExt *v1beta1.ComponentExtensionSpec) (*autoscalingv2.HorizontalPodAutoscaler, error) {
 	var minReplicas int32
 	if componentExt.MinReplicas == nil || (*componentExt.MinReplicas) < constants.DefaultMinReplicas {
 		minReplicas = int32(constants.DefaultMinReplicas)
@@ -103,7 +188,17 @@ func createHPA(componentMeta metav1.ObjectMeta,
 	if maxReplicas < minReplicas {
 		maxReplicas = minReplicas
 	}
-	metrics := getHPAMetrics(componentMeta, componentExt)
+	metrics, err := getHPAMetrics(componentMeta, componentExt)
+	if err != nil {
+		return nil, err
+	}
+	behavior, err := v1beta1.GetHPAScalingBehavior(componentMeta.Annotations)
+	if err != nil {
+		return nil, err
+	}
+	if behavior == nil {
+		behavior = &autoscalingv2.HorizontalPodAutoscalerBehavior{}
+	}
 	hpa := &autoscalingv2.HorizontalPodAutoscaler{
 		ObjectMeta: componentMeta,
 		Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
@@ -115,10 +210,10 @@ func createHPA(componentMeta metav1.ObjectMeta,
 			MinReplicas: &minReplicas,
 			MaxReplicas: maxReplicas,
 			Metrics:     metrics,
-			Behavior:    &autoscalingv2.HorizontalPodAutoscalerBehavior{},
+			Behavior:    behavior,
 		},
 	}
-	return hpa
+	return hpa, nil
 }
 
 // checkHPAExist checks if the hpa exists?
@@ -159,7 +254,60 @@ func semanticHPAEquals(desired, existing *autoscalingv2.HorizontalPodAutoscaler)
 	} else if hasDesiredAutoscalerClass || hasExistingAutoscalerClass {
 		autoscalerClassChanged = true
 	}
-	return equality.Semantic.DeepEqual(desired.Spec, existing.Spec) && !autoscalerClassChanged
+	return equality.Semantic.DeepEqual(defaultedHPASpec(desired.Spec), defaultedHPASpec(existing.Spec)) && !autoscalerClassChanged
+}
+
+// defaultedHPASpec returns a copy of the spec with the scaling behavior defaulted as the API server does for the
+// autoscaling/v2 HPAs, so that the rules left unset by KServe do not make the stored HPA differ from the desired one.
+func defaultedHPASpec(spec autoscalingv2.HorizontalPodAutoscalerSpec) autoscalingv2.HorizontalPodAutoscalerSpec {
+	spec = *spec.DeepCopy()
+	if spec.Behavior != nil {
+		spec.Behavior.ScaleUp = defaultedHPAScalingRules(spec.Behavior.ScaleUp, defaultHPAScaleUpRules())
+		spec.Behavior.ScaleDown = defaultedHPAScalingRules(spec.Behavior.ScaleDown, defaultHPAScaleDownRules())
+	}
+	return spec
+}
+
+// defaultedHPAScalingRules fills the fields of the rules left unset with the defaults
+func defaultedHPAScalingRules(rules *autoscalingv2.HPAScalingRules, defaults *autoscalingv2.HPAScalingRules) *autoscalingv2.HPAScalingRules {
+	if rules == nil {
+		return defaults
+	}
+	if rules.SelectPolicy != nil {
+		defaults.SelectPolicy = rules.SelectPolicy
+	}
+	if rules.StabilizationWindowSeconds != nil {
+		defaults.StabilizationWindowSeconds = rules.StabilizationWindowSeconds
+	}
+	if rules.Policies != nil {
+		defaults.Policies = rules.Policies
+	}
+	return defaults
+}
+
+// defaultHPAScaleUpRules are the scale up rules the API server sets on the HPAs with a behavior
+func defaultHPAScaleUpRules() *autoscalingv2.HPAScalingRules {
+	maxPolicy := autoscalingv2.MaxChangePolicySelect
+	return &autoscalingv2.HPAScalingRules{
+		StabilizationWindowSeconds: proto.Int32(0),
+		SelectPolicy:               &maxPolicy,
+		Policies: []autoscalingv2.HPAScalingPolicy{
+			{Type: autoscalingv2.PodsScalingPolicy, Value: 4, PeriodSeconds: 15},
+			{Type: autoscalingv2.PercentScalingPolicy, Value: 100, PeriodSeconds: 15},
+		},
+	}
+}
+
+// defaultHPAScaleDownRules are the scale down rules the API server sets on the HPAs with a behavior, the
+// stabilization window is left unset as it defaults to the one of the controller manager
+func defaultHPAScaleDownRules() *autoscalingv2.HPAScalingRules {
+	maxPolicy := autoscalingv2.MaxChangePolicySelect
+	return &autoscalingv2.HPAScalingRules{
+		SelectPolicy: &maxPolicy,
+		Policies: []autoscalingv2.HPAScalingPolicy{
+			{Type: autoscalingv2.PercentScalingPolicy, Value: 100, PeriodSeconds: 15},
+		},
+	}
 }
 
 func shouldDeleteHPA(desired *autoscalingv2.HorizontalPodAutoscaler) bool {
@@ -172,8 +320,37 @@ func shouldCreateHPA(desired *autoscalingv2.HorizontalPodAutoscaler) bool {
 	return !hasDesiredAutoscalerClass || (hasDesiredAutoscalerClass && constants.AutoscalerClassType(desiredAutoscalerClass) == constants.AutoscalerClassHPA)
 }
 
+// fallbackToResourceMetrics replaces the ContainerResource metrics of the desired HPA by Resource metrics when the
+// cluster does not support them. Clusters without the HPAContainerMetrics feature gate reject them on a dry-run create.
+func (r *HPAReconciler) fallbackToResourceMetrics() error {
+	hasContainerMetric := false
+	for _, metric := range r.HPA.Spec.Metrics {
+		if metric.Type == autoscalingv2.ContainerResourceMetricSourceType {
+			hasContainerMetric = true
+		}
+	}
+	if !hasContainerMetric || !shouldCreateHPA(r.HPA) {
+		return nil
+	}
+	err := r.client.Create(context.TODO(), r.HPA.DeepCopy(), client.DryRunAll)
+	switch {
+	case err == nil, apierr.IsAlreadyExists(err):
+		return nil
+	case apierr.IsInvalid(err):
+		log.Info("ContainerResource metrics are not supported, falling back to Resource metrics",
+			"HorizontalPodAutoscaler", r.HPA.Name, "err", err)
+		r.HPA.Spec.Metrics = toResourceMetrics(r.HPA.Spec.Metrics)
+		return nil
+	default:
+		return err
+	}
+}
+
 // Reconcile ...
 func (r *HPAReconciler) Reconcile() (*autoscalingv2.HorizontalPodAutoscaler, error) {
+	if err := r.fallbackToResourceMetrics(); err != nil {
+		return nil, err
+	}
 	// reconcile HorizontalPodAutoscaler
 	checkResult, existingHPA, err := r.checkHPAExist(r.client)
 	log.Info("HorizontalPodAutoscaler reconcile", "checkResult", checkResult, "err", err)
@@ -188,7 +365,10 @@ func (r *HPAReconciler) Reconcile() (*autoscalingv2.HorizontalPodAutoscaler, err
 	case constants.CheckResultUpdate:
 		opErr = r.client.Update(context.TODO(), r.HPA)
 	case constants.CheckResultDelete:
-		opErr = r.client.Delete(context.TODO(), r.HPA)
+		if err := r.client.Delete(context.TODO(), existingHPA); err != nil && !apierr.IsNotFound(err) {
+			return nil, err
+		}
+		return nil, nil
 	default:
 		return existingHPA, nil
 	}
@@ -196,9 +376,49 @@ func (r *HPAReconciler) Reconcile() (*autoscalingv2.HorizontalPodAutoscaler, err
 	if opErr != nil {
 		return nil, opErr
 	}
+	if checkResult == constants.CheckResultUpdate {
+		metrics.RecordDriftCorrection(metrics.KindHorizontalPodAutoscaler)
+	}
 
-	return r.HPA, nil
+	// the status of the HPA is propagated to the InferenceService, return the HPA as stored in the cluster
+	hpa := &autoscalingv2.HorizontalPodAutoscaler{}
+	if err := r.client.Get(context.TODO(), types.NamespacedName{
+		Namespace: r.HPA.Namespace,
+		Name:      r.HPA.Name,
+	}, hpa); err != nil {
+		if apierr.IsNotFound(err) {
+			// the HPA was just created and is not in the cache yet, it has no status so far
+			return r.HPA, nil
+		}
+		return nil, err
+	}
+	return hpa, nil
 }
+
+// DeleteHPA deletes the HPA of the component if it exists and is controlled by the owner of the component.
+func DeleteHPA(client client.Client, componentMeta metav1.ObjectMeta, owner metav1.Object) error {
+	existingHPA := &autoscalingv2.HorizontalPodAutoscaler{}
+	err := client.Get(context.TODO(), types.NamespacedName{
+		Namespace: componentMeta.Namespace,
+		Name:      componentMeta.Name,
+	}, existingHPA)
+	if err != nil {
+		if apierr.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if owner == nil || !metav1.IsControlledBy(existingHPA, owner) {
+		log.Info("HorizontalPodAutoscaler is not controlled by the component owner, skipping its deletion",
+			"namespace", existingHPA.Namespace, "name", existingHPA.Name)
+		return nil
+	}
+	if err := client.Delete(context.TODO(), existingHPA); err != nil && !apierr.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
 func (r *HPAReconciler) SetControllerReferences(owner metav1.Object, scheme *runtime.Scheme) error {
 	return controllerutil.SetControllerReference(owner, r.HPA, scheme)
 }
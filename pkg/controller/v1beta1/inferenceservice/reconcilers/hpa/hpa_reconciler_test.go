@@ -16,14 +16,27 @@ limitations under the License.
 package hpa
 
 import (
+	"context"
+
 	"github.com/google/go-cmp/cmp"
 	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
 	"github.com/kserve/kserve/pkg/constants"
 	"github.com/stretchr/testify/assert"
 	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	v1 "k8s.io/api/core/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	"knative.dev/pkg/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"testing"
 )
 
@@ -256,7 +269,8 @@ func TestCreateHPA(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := createHPA(tt.args.objectMeta, tt.args.componentExt)
+			got, err := createHPA(tt.args.objectMeta, tt.args.componentExt)
+			assert.NoError(t, err)
 			if diff := cmp.Diff(tt.expected, got); diff != "" {
 				t.Errorf("Test %q unexpected hpa (-want +got): %v", tt.name, diff)
 			}
@@ -264,6 +278,233 @@ func TestCreateHPA(t *testing.T) {
 	}
 }
 
+func TestCreateHPACustomMetrics(t *testing.T) {
+	cpuUtilization := int32(constants.DefaultCPUUtilization)
+	cpuMetric := autoscalingv2.MetricSpec{
+		Type: autoscalingv2.ResourceMetricSourceType,
+		Resource: &autoscalingv2.ResourceMetricSource{
+			Name: v1.ResourceCPU,
+			Target: autoscalingv2.MetricTarget{
+				Type:               "Utilization",
+				AverageUtilization: &cpuUtilization,
+			},
+		},
+	}
+	averageValue := resource.MustParse("10")
+	value := resource.MustParse("100")
+	podsMetric := autoscalingv2.MetricSpec{
+		Type: autoscalingv2.PodsMetricSourceType,
+		Pods: &autoscalingv2.PodsMetricSource{
+			Metric: autoscalingv2.MetricIdentifier{Name: "requests_in_flight"},
+			Target: autoscalingv2.MetricTarget{
+				Type:         autoscalingv2.AverageValueMetricType,
+				AverageValue: &averageValue,
+			},
+		},
+	}
+	externalMetric := autoscalingv2.MetricSpec{
+		Type: autoscalingv2.ExternalMetricSourceType,
+		External: &autoscalingv2.ExternalMetricSource{
+			Metric: autoscalingv2.MetricIdentifier{
+				Name:     "queue_depth",
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"queue": "inference"}},
+			},
+			Target: autoscalingv2.MetricTarget{
+				Type:  autoscalingv2.ValueMetricType,
+				Value: &value,
+			},
+		},
+	}
+	customMetrics := `[{"type": "Pods", "name": "requests_in_flight", "averageValue": "10"},
+		{"type": "External", "name": "queue_depth", "selector": {"matchLabels": {"queue": "inference"}}, "value": "100"}]`
+
+	scenarios := map[string]struct {
+		annotations  map[string]string
+		componentExt *v1beta1.ComponentExtensionSpec
+		expected     []autoscalingv2.MetricSpec
+		expectErr    bool
+	}{
+		"CustomMetricsOnly": {
+			annotations:  map[string]string{constants.HPACustomMetricsAnnotationKey: customMetrics},
+			componentExt: &v1beta1.ComponentExtensionSpec{},
+			expected:     []autoscalingv2.MetricSpec{podsMetric, externalMetric},
+		},
+		"CpuAndCustomMetrics": {
+			annotations:  map[string]string{constants.HPACustomMetricsAnnotationKey: customMetrics},
+			componentExt: &v1beta1.ComponentExtensionSpec{ScaleMetric: &[]v1beta1.ScaleMetric{v1beta1.MetricCPU}[0]},
+			expected:     []autoscalingv2.MetricSpec{cpuMetric, podsMetric, externalMetric},
+		},
+		"InvalidCustomMetrics": {
+			annotations:  map[string]string{constants.HPACustomMetricsAnnotationKey: `[{"type": "External", "name": "queue_depth"}]`},
+			componentExt: &v1beta1.ComponentExtensionSpec{},
+			expectErr:    true,
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			objectMeta := metav1.ObjectMeta{Name: "sklearn-predictor", Namespace: "default", Annotations: scenario.annotations}
+			got, err := createHPA(objectMeta, scenario.componentExt)
+			if scenario.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			if diff := cmp.Diff(scenario.expected, got.Spec.Metrics); diff != "" {
+				t.Errorf("Test %q unexpected hpa metrics (-want +got): %v", name, diff)
+			}
+		})
+	}
+}
+
+func TestCreateHPAScalingBehavior(t *testing.T) {
+	objectMeta := metav1.ObjectMeta{
+		Name:      "sklearn-predictor",
+		Namespace: "default",
+		Annotations: map[string]string{
+			constants.HPAScaleDownStabilizationAnnotationKey: "600",
+			constants.HPAScaleDownPoliciesAnnotationKey:      `[{"type": "Pods", "value": 1, "periodSeconds": 300}]`,
+			constants.HPAScaleUpStabilizationAnnotationKey:   "0",
+		},
+	}
+	got, err := createHPA(objectMeta, &v1beta1.ComponentExtensionSpec{})
+	assert.NoError(t, err)
+	expected := &autoscalingv2.HorizontalPodAutoscalerBehavior{
+		ScaleUp: &autoscalingv2.HPAScalingRules{
+			StabilizationWindowSeconds: ptr.Int32(0),
+		},
+		ScaleDown: &autoscalingv2.HPAScalingRules{
+			StabilizationWindowSeconds: ptr.Int32(600),
+			Policies: []autoscalingv2.HPAScalingPolicy{
+				{Type: autoscalingv2.PodsScalingPolicy, Value: 1, PeriodSeconds: 300},
+			},
+		},
+	}
+	if diff := cmp.Diff(expected, got.Spec.Behavior); diff != "" {
+		t.Errorf("unexpected hpa behavior (-want +got): %v", diff)
+	}
+
+	objectMeta.Annotations[constants.HPAScaleDownStabilizationAnnotationKey] = "-1"
+	_, err = createHPA(objectMeta, &v1beta1.ComponentExtensionSpec{})
+	assert.Error(t, err)
+}
+
+func TestCreateHPAPerComponent(t *testing.T) {
+	// the isvc annotations are shared by all components, the component extension spec takes precedence
+	isvcAnnotations := map[string]string{
+		constants.AutoscalerClass:             string(constants.AutoscalerClassHPA),
+		constants.TargetUtilizationPercentage: "75",
+	}
+	memoryMetric := v1beta1.MetricMemory
+	cpuMetric := v1beta1.MetricCPU
+	predictorHPA, err := createHPA(metav1.ObjectMeta{Name: "model-predictor", Namespace: "default", Annotations: isvcAnnotations},
+		&v1beta1.ComponentExtensionSpec{
+			MinReplicas: v1beta1.GetIntReference(1),
+			MaxReplicas: 2,
+			ScaleMetric: &memoryMetric,
+			ScaleTarget: v1beta1.GetIntReference(90),
+		})
+	assert.NoError(t, err)
+	transformerHPA, err := createHPA(metav1.ObjectMeta{Name: "model-transformer", Namespace: "default", Annotations: isvcAnnotations},
+		&v1beta1.ComponentExtensionSpec{
+			MinReplicas: v1beta1.GetIntReference(2),
+			MaxReplicas: 8,
+			ScaleMetric: &cpuMetric,
+			ScaleTarget: v1beta1.GetIntReference(60),
+		})
+	assert.NoError(t, err)
+	explainerHPA, err := createHPA(metav1.ObjectMeta{Name: "model-explainer", Namespace: "default", Annotations: isvcAnnotations},
+		&v1beta1.ComponentExtensionSpec{})
+	assert.NoError(t, err)
+
+	expected := map[string]struct {
+		hpa         *autoscalingv2.HorizontalPodAutoscaler
+		resource    v1.ResourceName
+		utilization int32
+		minReplicas int32
+		maxReplicas int32
+	}{
+		"model-predictor":   {hpa: predictorHPA, resource: v1.ResourceMemory, utilization: 90, minReplicas: 1, maxReplicas: 2},
+		"model-transformer": {hpa: transformerHPA, resource: v1.ResourceCPU, utilization: 60, minReplicas: 2, maxReplicas: 8},
+		"model-explainer":   {hpa: explainerHPA, resource: v1.ResourceCPU, utilization: 75, minReplicas: 1, maxReplicas: 1},
+	}
+	for name, e := range expected {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, name, e.hpa.Spec.ScaleTargetRef.Name)
+			assert.Equal(t, e.minReplicas, *e.hpa.Spec.MinReplicas)
+			assert.Equal(t, e.maxReplicas, e.hpa.Spec.MaxReplicas)
+			assert.Len(t, e.hpa.Spec.Metrics, 1)
+			assert.Equal(t, e.resource, e.hpa.Spec.Metrics[0].Resource.Name)
+			assert.Equal(t, e.utilization, *e.hpa.Spec.Metrics[0].Resource.Target.AverageUtilization)
+		})
+	}
+}
+
+func TestHPAContainerMetric(t *testing.T) {
+	scheme := runtime.NewScheme()
+	assert.NoError(t, clientgoscheme.AddToScheme(scheme))
+	componentMeta := metav1.ObjectMeta{
+		Name:      "sklearn-predictor",
+		Namespace: "default",
+		Annotations: map[string]string{
+			constants.HPAContainerMetricAnnotationKey: "true",
+		},
+	}
+	componentExt := &v1beta1.ComponentExtensionSpec{MinReplicas: v1beta1.GetIntReference(1), MaxReplicas: 3}
+	utilization := constants.DefaultCPUUtilization
+	target := autoscalingv2.MetricTarget{Type: "Utilization", AverageUtilization: &utilization}
+	// clusters without the HPAContainerMetrics feature gate reject ContainerResource metrics
+	rejectContainerMetrics := interceptor.Funcs{
+		Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+			if hpa, ok := obj.(*autoscalingv2.HorizontalPodAutoscaler); ok && hpa.Spec.Metrics[0].ContainerResource != nil {
+				return apierr.NewInvalid(schema.GroupKind{Group: "autoscaling", Kind: "HorizontalPodAutoscaler"}, hpa.Name,
+					field.ErrorList{field.Required(field.NewPath("spec", "metrics").Index(0).Child("containerResource"),
+						"must populate information for the given metric source")})
+			}
+			return c.Create(ctx, obj, opts...)
+		},
+	}
+
+	scenarios := map[string]struct {
+		interceptor interceptor.Funcs
+		expected    autoscalingv2.MetricSpec
+	}{
+		"ContainerResource": {
+			expected: autoscalingv2.MetricSpec{
+				Type: autoscalingv2.ContainerResourceMetricSourceType,
+				ContainerResource: &autoscalingv2.ContainerResourceMetricSource{
+					Name:      v1.ResourceCPU,
+					Container: constants.InferenceServiceContainerName,
+					Target:    target,
+				},
+			},
+		},
+		"FallbackToResource": {
+			interceptor: rejectContainerMetrics,
+			expected: autoscalingv2.MetricSpec{
+				Type: autoscalingv2.ResourceMetricSourceType,
+				Resource: &autoscalingv2.ResourceMetricSource{
+					Name:   v1.ResourceCPU,
+					Target: target,
+				},
+			},
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			c := fake.NewClientBuilder().WithScheme(scheme).WithInterceptorFuncs(scenario.interceptor).Build()
+			reconciler, err := NewHPAReconciler(c, scheme, componentMeta, componentExt)
+			assert.NoError(t, err)
+			_, err = reconciler.Reconcile()
+			assert.NoError(t, err)
+			actual := &autoscalingv2.HorizontalPodAutoscaler{}
+			assert.NoError(t, c.Get(context.TODO(), types.NamespacedName{Name: componentMeta.Name, Namespace: componentMeta.Namespace}, actual))
+			if diff := cmp.Diff([]autoscalingv2.MetricSpec{scenario.expected}, actual.Spec.Metrics); diff != "" {
+				t.Errorf("unexpected hpa metrics (-want +got): %v", diff)
+			}
+		})
+	}
+}
+
 func TestSemanticHPAEquals(t *testing.T) {
 	assert.True(t, semanticHPAEquals(
 		&autoscalingv2.HorizontalPodAutoscaler{
@@ -281,6 +522,18 @@ func TestSemanticHPAEquals(t *testing.T) {
 			Spec: autoscalingv2.HorizontalPodAutoscalerSpec{MinReplicas: ptr.Int32(4)},
 		}))
 
+	assert.False(t, semanticHPAEquals(
+		&autoscalingv2.HorizontalPodAutoscaler{
+			Spec: autoscalingv2.HorizontalPodAutoscalerSpec{Behavior: &autoscalingv2.HorizontalPodAutoscalerBehavior{
+				ScaleDown: &autoscalingv2.HPAScalingRules{StabilizationWindowSeconds: ptr.Int32(600)},
+			}},
+		},
+		&autoscalingv2.HorizontalPodAutoscaler{
+			Spec: autoscalingv2.HorizontalPodAutoscalerSpec{Behavior: &autoscalingv2.HorizontalPodAutoscalerBehavior{
+				ScaleDown: &autoscalingv2.HPAScalingRules{StabilizationWindowSeconds: ptr.Int32(300)},
+			}},
+		}))
+
 	assert.False(t, semanticHPAEquals(
 		&autoscalingv2.HorizontalPodAutoscaler{
 			ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{constants.AutoscalerClass: "hpa"}},
@@ -331,3 +584,146 @@ func TestSemanticHPAEquals(t *testing.T) {
 			Spec:       autoscalingv2.HorizontalPodAutoscalerSpec{MinReplicas: ptr.Int32(3)},
 		}))
 }
+
+func TestSemanticHPAEqualsDefaultedBehavior(t *testing.T) {
+	maxPolicy := autoscalingv2.MaxChangePolicySelect
+	scaleDownDefaults := &autoscalingv2.HPAScalingRules{
+		SelectPolicy: &maxPolicy,
+		Policies:     []autoscalingv2.HPAScalingPolicy{{Type: autoscalingv2.PercentScalingPolicy, Value: 100, PeriodSeconds: 15}},
+	}
+	scenarios := map[string]struct {
+		desired  *autoscalingv2.HorizontalPodAutoscalerBehavior
+		existing *autoscalingv2.HorizontalPodAutoscalerBehavior
+		expected bool
+	}{
+		"EmptyBehaviorDefaultedByTheAPIServer": {
+			desired: &autoscalingv2.HorizontalPodAutoscalerBehavior{},
+			existing: &autoscalingv2.HorizontalPodAutoscalerBehavior{
+				ScaleUp: &autoscalingv2.HPAScalingRules{
+					StabilizationWindowSeconds: ptr.Int32(0),
+					SelectPolicy:               &maxPolicy,
+					Policies: []autoscalingv2.HPAScalingPolicy{
+						{Type: autoscalingv2.PodsScalingPolicy, Value: 4, PeriodSeconds: 15},
+						{Type: autoscalingv2.PercentScalingPolicy, Value: 100, PeriodSeconds: 15},
+					},
+				},
+				ScaleDown: scaleDownDefaults,
+			},
+			expected: true,
+		},
+		"PartialBehaviorDefaultedByTheAPIServer": {
+			desired: &autoscalingv2.HorizontalPodAutoscalerBehavior{
+				ScaleUp: &autoscalingv2.HPAScalingRules{StabilizationWindowSeconds: ptr.Int32(60)},
+			},
+			existing: &autoscalingv2.HorizontalPodAutoscalerBehavior{
+				ScaleUp: &autoscalingv2.HPAScalingRules{
+					StabilizationWindowSeconds: ptr.Int32(60),
+					SelectPolicy:               &maxPolicy,
+					Policies: []autoscalingv2.HPAScalingPolicy{
+						{Type: autoscalingv2.PodsScalingPolicy, Value: 4, PeriodSeconds: 15},
+						{Type: autoscalingv2.PercentScalingPolicy, Value: 100, PeriodSeconds: 15},
+					},
+				},
+				ScaleDown: scaleDownDefaults,
+			},
+			expected: true,
+		},
+		"ScaleDownPolicyChanged": {
+			desired: &autoscalingv2.HorizontalPodAutoscalerBehavior{},
+			existing: &autoscalingv2.HorizontalPodAutoscalerBehavior{
+				ScaleDown: &autoscalingv2.HPAScalingRules{
+					Policies: []autoscalingv2.HPAScalingPolicy{{Type: autoscalingv2.PodsScalingPolicy, Value: 1, PeriodSeconds: 60}},
+				},
+			},
+			expected: false,
+		},
+		"BehaviorRemoved": {
+			desired:  &autoscalingv2.HorizontalPodAutoscalerBehavior{},
+			existing: nil,
+			expected: false,
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, scenario.expected, semanticHPAEquals(
+				&autoscalingv2.HorizontalPodAutoscaler{
+					Spec: autoscalingv2.HorizontalPodAutoscalerSpec{MinReplicas: ptr.Int32(1), Behavior: scenario.desired},
+				},
+				&autoscalingv2.HorizontalPodAutoscaler{
+					Spec: autoscalingv2.HorizontalPodAutoscalerSpec{MinReplicas: ptr.Int32(1), Behavior: scenario.existing},
+				}))
+		})
+	}
+}
+
+func TestDeleteHPA(t *testing.T) {
+	scheme := runtime.NewScheme()
+	assert.NoError(t, clientgoscheme.AddToScheme(scheme))
+	assert.NoError(t, v1beta1.AddToScheme(scheme))
+	owner := &v1beta1.InferenceService{ObjectMeta: metav1.ObjectMeta{Name: "sklearn", Namespace: "default", UID: "sklearn"}}
+	other := &v1beta1.InferenceService{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "default", UID: "other"}}
+	componentMeta := metav1.ObjectMeta{Name: "sklearn-predictor", Namespace: "default"}
+	key := types.NamespacedName{Name: componentMeta.Name, Namespace: componentMeta.Namespace}
+
+	// nothing to delete
+	cl := fake.NewClientBuilder().WithScheme(scheme).Build()
+	assert.NoError(t, DeleteHPA(cl, componentMeta, owner))
+
+	// an HPA created by the user for the deployment is kept
+	userHPA := &autoscalingv2.HorizontalPodAutoscaler{ObjectMeta: componentMeta}
+	cl = fake.NewClientBuilder().WithScheme(scheme).WithObjects(userHPA).Build()
+	assert.NoError(t, DeleteHPA(cl, componentMeta, owner))
+	assert.NoError(t, cl.Get(context.TODO(), key, &autoscalingv2.HorizontalPodAutoscaler{}))
+
+	// the HPA controlled by the owner is deleted, not the one of another owner
+	ownedHPA := &autoscalingv2.HorizontalPodAutoscaler{ObjectMeta: componentMeta}
+	assert.NoError(t, controllerutil.SetControllerReference(owner, ownedHPA, scheme))
+	cl = fake.NewClientBuilder().WithScheme(scheme).WithObjects(ownedHPA).Build()
+	assert.NoError(t, DeleteHPA(cl, componentMeta, other))
+	assert.NoError(t, cl.Get(context.TODO(), key, &autoscalingv2.HorizontalPodAutoscaler{}))
+	assert.NoError(t, DeleteHPA(cl, componentMeta, owner))
+	err := cl.Get(context.TODO(), key, &autoscalingv2.HorizontalPodAutoscaler{})
+	assert.True(t, apierr.IsNotFound(err))
+}
+
+func TestHPAReconcileReturnsExistingHPA(t *testing.T) {
+	scheme := runtime.NewScheme()
+	assert.NoError(t, clientgoscheme.AddToScheme(scheme))
+	componentMeta := metav1.ObjectMeta{Name: "sklearn-predictor", Namespace: "default"}
+	key := types.NamespacedName{Name: componentMeta.Name, Namespace: componentMeta.Namespace}
+	status := autoscalingv2.HorizontalPodAutoscalerStatus{CurrentReplicas: 2, DesiredReplicas: 3}
+	existing, err := createHPA(componentMeta, &v1beta1.ComponentExtensionSpec{MinReplicas: v1beta1.GetIntReference(1), MaxReplicas: 3})
+	assert.NoError(t, err)
+	existing.Status = status
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(existing).
+		WithStatusSubresource(&autoscalingv2.HorizontalPodAutoscaler{}).Build()
+
+	// the replicas of the existing HPA are propagated when it is unchanged
+	reconciler, err := NewHPAReconciler(c, scheme, componentMeta, &v1beta1.ComponentExtensionSpec{MinReplicas: v1beta1.GetIntReference(1), MaxReplicas: 3})
+	assert.NoError(t, err)
+	hpa, err := reconciler.Reconcile()
+	assert.NoError(t, err)
+	assert.Equal(t, status, hpa.Status)
+
+	// and once it is updated
+	reconciler, err = NewHPAReconciler(c, scheme, componentMeta, &v1beta1.ComponentExtensionSpec{MinReplicas: v1beta1.GetIntReference(1), MaxReplicas: 5})
+	assert.NoError(t, err)
+	hpa, err = reconciler.Reconcile()
+	assert.NoError(t, err)
+	assert.Equal(t, int32(5), hpa.Spec.MaxReplicas)
+	assert.Equal(t, int32(2), hpa.Status.CurrentReplicas)
+	assert.Equal(t, int32(3), hpa.Status.DesiredReplicas)
+
+	// no HPA is returned once it is deleted for an external autoscaler
+	reconciler, err = NewHPAReconciler(c, scheme, metav1.ObjectMeta{
+		Name:        componentMeta.Name,
+		Namespace:   componentMeta.Namespace,
+		Annotations: map[string]string{constants.AutoscalerClass: string(constants.AutoscalerClassExternal)},
+	}, &v1beta1.ComponentExtensionSpec{MinReplicas: v1beta1.GetIntReference(1), MaxReplicas: 5})
+	assert.NoError(t, err)
+	hpa, err = reconciler.Reconcile()
+	assert.NoError(t, err)
+	assert.Nil(t, hpa)
+	err = c.Get(context.TODO(), key, &autoscalingv2.HorizontalPodAutoscaler{})
+	assert.True(t, apierr.IsNotFound(err))
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/types/known/durationpb"
+	istiov1beta1 "istio.io/api/networking/v1beta1"
+	gatewayapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+)
+
+// createCorsPolicy returns the CORS policy of the VirtualService routes, nil when no origin is allowed
+func createCorsPolicy(cors v1beta1.CorsConfig) *istiov1beta1.CorsPolicy {
+	if len(cors.AllowOrigins) == 0 {
+		return nil
+	}
+	corsPolicy := &istiov1beta1.CorsPolicy{
+		AllowMethods: cors.AllowMethods,
+		AllowHeaders: cors.AllowHeaders,
+	}
+	for _, origin := range cors.AllowOrigins {
+		corsPolicy.AllowOrigins = append(corsPolicy.AllowOrigins, &istiov1beta1.StringMatch{
+			MatchType: &istiov1beta1.StringMatch_Exact{Exact: origin},
+		})
+	}
+	if cors.MaxAge > 0 {
+		corsPolicy.MaxAge = durationpb.New(time.Duration(cors.MaxAge) * time.Second)
+	}
+	return corsPolicy
+}
+
+// createCorsHeaderFilter returns the HTTPRoute filter setting the CORS response headers, nil when no origin is
+// allowed. Gateway API has no CORS filter, so unlike the istio CORS policy the filter cannot match the origin of the
+// request and the Access-Control-Allow-Origin header lists all the allowed origins.
+func createCorsHeaderFilter(cors v1beta1.CorsConfig) *gatewayapiv1.HTTPRouteFilter {
+	if len(cors.AllowOrigins) == 0 {
+		return nil
+	}
+	headers := []gatewayapiv1.HTTPHeader{
+		{Name: "Access-Control-Allow-Origin", Value: strings.Join(cors.AllowOrigins, ", ")},
+	}
+	if len(cors.AllowMethods) != 0 {
+		headers = append(headers, gatewayapiv1.HTTPHeader{Name: "Access-Control-Allow-Methods", Value: strings.Join(cors.AllowMethods, ", ")})
+	}
+	if len(cors.AllowHeaders) != 0 {
+		headers = append(headers, gatewayapiv1.HTTPHeader{Name: "Access-Control-Allow-Headers", Value: strings.Join(cors.AllowHeaders, ", ")})
+	}
+	if cors.MaxAge > 0 {
+		headers = append(headers, gatewayapiv1.HTTPHeader{Name: "Access-Control-Max-Age", Value: strconv.FormatInt(cors.MaxAge, 10)})
+	}
+	return &gatewayapiv1.HTTPRouteFilter{
+		Type:                   gatewayapiv1.HTTPRouteFilterResponseHeaderModifier,
+		ResponseHeaderModifier: &gatewayapiv1.HTTPHeaderFilter{Set: headers},
+	}
+}
@@ -0,0 +1,229 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/durationpb"
+	istiov1beta1 "istio.io/api/networking/v1beta1"
+	istioclientv1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	netv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	fakeclientset "k8s.io/client-go/kubernetes/fake"
+	"knative.dev/pkg/apis"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+func TestCreateCorsPolicy(t *testing.T) {
+	config := v1beta1.CorsConfig{
+		AllowOrigins: []string{"https://example.com"},
+		AllowMethods: []string{"GET", "POST"},
+		MaxAge:       600,
+	}
+	testCases := map[string]struct {
+		annotations map[string]string
+		config      v1beta1.CorsConfig
+		expected    *istiov1beta1.CorsPolicy
+	}{
+		"noOrigin": {
+			config:   v1beta1.CorsConfig{AllowMethods: []string{"GET"}},
+			expected: nil,
+		},
+		"config": {
+			config: config,
+			expected: &istiov1beta1.CorsPolicy{
+				AllowOrigins: []*istiov1beta1.StringMatch{
+					{MatchType: &istiov1beta1.StringMatch_Exact{Exact: "https://example.com"}},
+				},
+				AllowMethods: []string{"GET", "POST"},
+				MaxAge:       durationpb.New(10 * time.Minute),
+			},
+		},
+		"annotationsOverrideConfig": {
+			annotations: map[string]string{
+				constants.CorsAllowOriginsAnnotationKey: "https://a.example.com, https://b.example.com",
+				constants.CorsAllowHeadersAnnotationKey: "Content-Type,Authorization",
+				constants.CorsMaxAgeAnnotationKey:       "0",
+			},
+			config: config,
+			expected: &istiov1beta1.CorsPolicy{
+				AllowOrigins: []*istiov1beta1.StringMatch{
+					{MatchType: &istiov1beta1.StringMatch_Exact{Exact: "https://a.example.com"}},
+					{MatchType: &istiov1beta1.StringMatch_Exact{Exact: "https://b.example.com"}},
+				},
+				AllowMethods: []string{"GET", "POST"},
+				AllowHeaders: []string{"Content-Type", "Authorization"},
+			},
+		},
+		"annotationDisablesConfig": {
+			annotations: map[string]string{constants.CorsAllowOriginsAnnotationKey: ""},
+			config:      config,
+			expected:    nil,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			cors, err := v1beta1.GetCorsConfig(tc.annotations, tc.config)
+			assert.NoError(t, err)
+			if diff := cmp.Diff(tc.expected, createCorsPolicy(cors), protocmp.Transform()); diff != "" {
+				t.Errorf("unexpected cors policy (-want +got): %v", diff)
+			}
+		})
+	}
+}
+
+func TestCreateCorsHeaderFilter(t *testing.T) {
+	assert.Nil(t, createCorsHeaderFilter(v1beta1.CorsConfig{AllowMethods: []string{"GET"}}))
+	filter := createCorsHeaderFilter(v1beta1.CorsConfig{
+		AllowOrigins: []string{"https://example.com"},
+		AllowMethods: []string{"GET", "POST"},
+		AllowHeaders: []string{"Content-Type"},
+		MaxAge:       600,
+	})
+	expected := &gatewayapiv1.HTTPRouteFilter{
+		Type: gatewayapiv1.HTTPRouteFilterResponseHeaderModifier,
+		ResponseHeaderModifier: &gatewayapiv1.HTTPHeaderFilter{
+			Set: []gatewayapiv1.HTTPHeader{
+				{Name: "Access-Control-Allow-Origin", Value: "https://example.com"},
+				{Name: "Access-Control-Allow-Methods", Value: "GET, POST"},
+				{Name: "Access-Control-Allow-Headers", Value: "Content-Type"},
+				{Name: "Access-Control-Max-Age", Value: "600"},
+			},
+		},
+	}
+	assert.Equal(t, expected, filter)
+}
+
+func TestIngressReconcilerCorsPolicy(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = v1beta1.AddToScheme(scheme)
+	_ = istioclientv1beta1.AddToScheme(scheme)
+
+	isvc := &v1beta1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-model",
+			Namespace: "default",
+			UID:       "123",
+			Annotations: map[string]string{
+				constants.CorsAllowOriginsAnnotationKey: "https://example.com",
+				constants.CorsAllowMethodsAnnotationKey: "POST",
+			},
+		},
+	}
+	isvc.Status.SetCondition(v1beta1.PredictorReady, &apis.Condition{Type: v1beta1.PredictorReady, Status: corev1.ConditionTrue})
+	isvc.Status.Components = map[v1beta1.ComponentType]v1beta1.ComponentStatusSpec{
+		v1beta1.PredictorComponent: {
+			URL: &apis.URL{Scheme: "http", Host: "my-model-predictor-default.example.com"},
+		},
+	}
+	ingressConfig := &v1beta1.IngressConfig{
+		IngressGateway:          constants.KnativeIngressGateway,
+		LocalGateway:            constants.KnativeLocalGateway,
+		LocalGatewayServiceName: "knative-local-gateway.istio-system.svc.cluster.local",
+		IngressDomain:           "example.com",
+		DomainTemplate:          "{{ .Name }}-{{ .Namespace }}.{{ .IngressDomain }}",
+		UrlScheme:               "http",
+		Cors: v1beta1.CorsConfig{
+			AllowOrigins: []string{"*"},
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).Build()
+	reconciler := NewIngressReconciler(c, fakeclientset.NewSimpleClientset(), scheme, ingressConfig)
+	getVirtualService := func() *istioclientv1beta1.VirtualService {
+		virtualService := &istioclientv1beta1.VirtualService{}
+		assert.NoError(t, c.Get(context.TODO(), types.NamespacedName{Name: "my-model", Namespace: "default"}, virtualService))
+		return virtualService
+	}
+
+	assert.NoError(t, reconciler.Reconcile(isvc))
+	virtualService := getVirtualService()
+	expected := &istiov1beta1.CorsPolicy{
+		AllowOrigins: []*istiov1beta1.StringMatch{
+			{MatchType: &istiov1beta1.StringMatch_Exact{Exact: "https://example.com"}},
+		},
+		AllowMethods: []string{"POST"},
+	}
+	for _, httpRoute := range virtualService.Spec.Http {
+		if diff := cmp.Diff(expected, httpRoute.CorsPolicy, protocmp.Transform()); diff != "" {
+			t.Errorf("unexpected cors policy (-want +got): %v", diff)
+		}
+	}
+
+	// the annotation is the source of truth, reconciling again leaves the virtual service untouched
+	assert.NoError(t, reconciler.Reconcile(isvc))
+	assert.Equal(t, virtualService.ResourceVersion, getVirtualService().ResourceVersion)
+
+	// changing the annotation updates the cors policy
+	isvc.Annotations[constants.CorsAllowOriginsAnnotationKey] = "https://example.org"
+	assert.NoError(t, reconciler.Reconcile(isvc))
+	assert.Equal(t, "https://example.org", getVirtualService().Spec.Http[0].CorsPolicy.AllowOrigins[0].GetExact())
+}
+
+func TestRawIngressReconcilerCorsHeaders(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = netv1.AddToScheme(scheme)
+	_ = v1beta1.AddToScheme(scheme)
+	_ = gatewayapiv1.AddToScheme(scheme)
+
+	isvc := &v1beta1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-model",
+			Namespace: "default",
+			UID:       "123",
+			Annotations: map[string]string{
+				constants.CorsAllowOriginsAnnotationKey: "https://example.com",
+			},
+		},
+	}
+	isvc.Status.SetCondition(v1beta1.PredictorReady, &apis.Condition{Type: v1beta1.PredictorReady, Status: corev1.ConditionTrue})
+	ingressConfig := &v1beta1.IngressConfig{
+		IngressDomain:    "example.com",
+		DomainTemplate:   "{{ .Name }}-{{ .Namespace }}.{{ .IngressDomain }}",
+		UrlScheme:        "http",
+		EnableGatewayAPI: true,
+		GatewayName:      "kserve-ingress-gateway",
+		GatewayNamespace: "kserve",
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).Build()
+	reconciler, _ := NewRawIngressReconciler(c, scheme, ingressConfig)
+	assert.NoError(t, reconciler.Reconcile(isvc))
+
+	routes := &gatewayapiv1.HTTPRouteList{}
+	assert.NoError(t, c.List(context.TODO(), routes))
+	assert.Len(t, routes.Items, 2)
+	expected := []gatewayapiv1.HTTPRouteFilter{*createCorsHeaderFilter(v1beta1.CorsConfig{AllowOrigins: []string{"https://example.com"}})}
+	for _, route := range routes.Items {
+		for _, rule := range route.Spec.Rules {
+			assert.Equal(t, expected, rule.Filters)
+		}
+	}
+}
@@ -17,48 +17,44 @@ limitations under the License.
 package ingress
 
 import (
-	"bytes"
-	"fmt"
-	"text/template"
-
 	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/util/validation"
-	"k8s.io/apimachinery/pkg/util/validation/field"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
 )
 
-type DomainTemplateValues struct {
-	Name          string
-	Namespace     string
-	IngressDomain string
-	Annotations   map[string]string
-	Labels        map[string]string
-}
-
 // GenerateDomainName generate domain name using template configured in IngressConfig
 func GenerateDomainName(name string, obj metav1.ObjectMeta, ingressConfig *v1beta1.IngressConfig) (string, error) {
-	values := DomainTemplateValues{
-		Name:          name,
-		Namespace:     obj.Namespace,
-		IngressDomain: ingressConfig.IngressDomain,
-		Annotations:   obj.Annotations,
-		Labels:        obj.Labels,
-	}
+	return ingressConfig.GenerateDomainName(name, obj)
+}
 
-	tpl, err := template.New("domain-template").Parse(ingressConfig.DomainTemplate)
-	if err != nil {
-		return "", err
+// uniqueHosts returns the hosts without duplicates and without the excluded hosts, in their original order
+func uniqueHosts(hosts []string, excluded ...string) []string {
+	seen := make(map[string]bool, len(hosts)+len(excluded))
+	for _, host := range excluded {
+		seen[host] = true
 	}
-
-	buf := bytes.Buffer{}
-	if err := tpl.Execute(&buf, values); err != nil {
-		return "", fmt.Errorf("error rendering the domain template: %w", err)
+	var result []string
+	for _, host := range hosts {
+		if !seen[host] {
+			seen[host] = true
+			result = append(result, host)
+		}
 	}
+	return result
+}
 
-	urlErrs := validation.IsFullyQualifiedDomainName(field.NewPath("url"), buf.String())
-	if urlErrs != nil {
-		return "", fmt.Errorf("invalid domain name %q: %w", buf.String(), urlErrs.ToAggregate())
+// createAddresses returns the address of the url of the InferenceService followed by the addresses of the additional
+// hosts it is exposed at
+func createAddresses(url *apis.URL, additionalHosts []string) []duckv1.Addressable {
+	addresses := []duckv1.Addressable{{URL: url}}
+	for _, host := range additionalHosts {
+		addresses = append(addresses, duckv1.Addressable{
+			URL: &apis.URL{
+				Scheme: url.Scheme,
+				Host:   host,
+			},
+		})
 	}
-
-	return buf.String(), nil
+	return addresses
 }
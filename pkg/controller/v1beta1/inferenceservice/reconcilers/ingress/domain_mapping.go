@@ -0,0 +1,132 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	knservingv1 "knative.dev/serving/pkg/apis/serving/v1"
+	knservingv1beta1 "knative.dev/serving/pkg/apis/serving/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+// createDomainMapping builds the DomainMapping which routes the custom domain of the InferenceService to its
+// top level knative service, the transformer if there is one and the predictor otherwise.
+func createDomainMapping(isvc *v1beta1.InferenceService, domain string, useDefault bool) *knservingv1beta1.DomainMapping {
+	domainMapping := &knservingv1beta1.DomainMapping{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      domain,
+			Namespace: isvc.Namespace,
+			Labels: map[string]string{
+				constants.InferenceServiceLabel: isvc.Name,
+			},
+		},
+		Spec: knservingv1beta1.DomainMappingSpec{
+			Ref: duckv1.KReference{
+				APIVersion: knservingv1.SchemeGroupVersion.String(),
+				Kind:       constants.KnativeServiceKind,
+				Name:       getHostPrefix(isvc, true, useDefault),
+				Namespace:  isvc.Namespace,
+			},
+		},
+	}
+	if secretName, ok := isvc.Annotations[constants.CustomDomainTLSSecretAnnotationKey]; ok && secretName != "" {
+		domainMapping.Spec.TLS = &knservingv1beta1.SecretTLS{SecretName: secretName}
+	}
+	return domainMapping
+}
+
+// reconcileDomainMapping creates or updates the DomainMapping requested with the serving.kserve.io/custom-domain
+// annotation and deletes the DomainMappings of the InferenceService which are no longer requested.
+func (ir *IngressReconciler) reconcileDomainMapping(isvc *v1beta1.InferenceService) error {
+	domain, hasCustomDomain := isvc.Annotations[constants.CustomDomainAnnotationKey]
+	hasCustomDomain = hasCustomDomain && domain != ""
+
+	existingList := &knservingv1beta1.DomainMappingList{}
+	if err := ir.client.List(context.TODO(), existingList, client.InNamespace(isvc.Namespace),
+		client.MatchingLabels{constants.InferenceServiceLabel: isvc.Name}); err != nil {
+		// the DomainMapping CRD is only required when a custom domain is requested
+		if !hasCustomDomain && (meta.IsNoMatchError(err) || runtime.IsNotRegisteredError(err)) {
+			isvc.Status.ExternalURLs = nil
+			return nil
+		}
+		return errors.Wrapf(err, "fails to list domain mappings")
+	}
+	for i := range existingList.Items {
+		existing := &existingList.Items[i]
+		if hasCustomDomain && existing.Name == domain {
+			continue
+		}
+		log.Info("Deleting DomainMapping for isvc", "namespace", existing.Namespace, "name", existing.Name)
+		if err := ir.client.Delete(context.TODO(), existing); err != nil && !apierr.IsNotFound(err) {
+			return errors.Wrapf(err, "fails to delete domain mapping")
+		}
+	}
+	if !hasCustomDomain {
+		isvc.Status.ExternalURLs = nil
+		return nil
+	}
+
+	// Check if existing knative service name has default suffix
+	useDefault := false
+	if err := ir.client.Get(context.TODO(), types.NamespacedName{Name: constants.DefaultPredictorServiceName(isvc.GeneratedNamePrefix()), Namespace: isvc.Namespace}, &knservingv1.Service{}); err == nil {
+		useDefault = true
+	}
+	desired := createDomainMapping(isvc, domain, useDefault)
+	if err := controllerutil.SetControllerReference(isvc, desired, ir.scheme); err != nil {
+		return errors.Wrapf(err, "fails to set owner reference for domain mapping")
+	}
+
+	existing := &knservingv1beta1.DomainMapping{}
+	err := ir.client.Get(context.TODO(), types.NamespacedName{Name: desired.Name, Namespace: desired.Namespace}, existing)
+	if err != nil {
+		if apierr.IsNotFound(err) {
+			log.Info("Creating DomainMapping for isvc", "namespace", desired.Namespace, "name", desired.Name)
+			err = ir.client.Create(context.TODO(), desired)
+		}
+	} else if !equality.Semantic.DeepEqual(desired.Spec, existing.Spec) ||
+		!equality.Semantic.DeepEqual(desired.Labels, existing.Labels) {
+		deepCopy := existing.DeepCopy()
+		deepCopy.Spec = desired.Spec
+		deepCopy.Labels = desired.Labels
+		log.Info("Update DomainMapping for isvc", "namespace", desired.Namespace, "name", desired.Name)
+		err = ir.client.Update(context.TODO(), deepCopy)
+	}
+	if err != nil {
+		return errors.Wrapf(err, "fails to create or update domain mapping")
+	}
+
+	scheme := "http"
+	if desired.Spec.TLS != nil {
+		scheme = "https"
+	}
+	isvc.Status.ExternalURLs = []*apis.URL{{Scheme: scheme, Host: domain}}
+	return nil
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	knservingv1beta1 "knative.dev/serving/pkg/apis/serving/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+func TestReconcileDomainMapping(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = v1beta1.AddToScheme(scheme)
+	_ = knservingv1beta1.AddToScheme(scheme)
+
+	isvc := &v1beta1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-model",
+			Namespace: "default",
+			UID:       "123",
+			Annotations: map[string]string{
+				constants.CustomDomainAnnotationKey: "model.example.com",
+			},
+		},
+		Spec: v1beta1.InferenceServiceSpec{
+			Transformer: &v1beta1.TransformerSpec{},
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).Build()
+	reconciler := NewIngressReconciler(c, nil, scheme, &v1beta1.IngressConfig{})
+
+	// create the domain mapping pointing at the transformer
+	err := reconciler.reconcileDomainMapping(isvc)
+	assert.NoError(t, err)
+	domainMapping := &knservingv1beta1.DomainMapping{}
+	err = c.Get(context.TODO(), types.NamespacedName{Name: "model.example.com", Namespace: "default"}, domainMapping)
+	assert.NoError(t, err)
+	expectedRef := duckv1.KReference{
+		APIVersion: "serving.knative.dev/v1",
+		Kind:       "Service",
+		Name:       constants.TransformerServiceName("my-model"),
+		Namespace:  "default",
+	}
+	if diff := cmp.Diff(expectedRef, domainMapping.Spec.Ref); diff != "" {
+		t.Errorf("unexpected domain mapping ref (-want +got): %v", diff)
+	}
+	assert.Nil(t, domainMapping.Spec.TLS)
+	assert.Equal(t, "my-model", domainMapping.Labels[constants.InferenceServiceLabel])
+	assert.Equal(t, []*apis.URL{{Scheme: "http", Host: "model.example.com"}}, isvc.Status.ExternalURLs)
+
+	// terminate tls with the referenced secret
+	isvc.Annotations[constants.CustomDomainTLSSecretAnnotationKey] = "model-tls"
+	err = reconciler.reconcileDomainMapping(isvc)
+	assert.NoError(t, err)
+	err = c.Get(context.TODO(), types.NamespacedName{Name: "model.example.com", Namespace: "default"}, domainMapping)
+	assert.NoError(t, err)
+	assert.Equal(t, &knservingv1beta1.SecretTLS{SecretName: "model-tls"}, domainMapping.Spec.TLS)
+	assert.Equal(t, []*apis.URL{{Scheme: "https", Host: "model.example.com"}}, isvc.Status.ExternalURLs)
+
+	// changing the domain replaces the domain mapping
+	isvc.Annotations[constants.CustomDomainAnnotationKey] = "model.example.org"
+	err = reconciler.reconcileDomainMapping(isvc)
+	assert.NoError(t, err)
+	domainMappings := &knservingv1beta1.DomainMappingList{}
+	err = c.List(context.TODO(), domainMappings, client.InNamespace("default"))
+	assert.NoError(t, err)
+	assert.Len(t, domainMappings.Items, 1)
+	assert.Equal(t, "model.example.org", domainMappings.Items[0].Name)
+
+	// removing the annotation deletes the domain mapping
+	delete(isvc.Annotations, constants.CustomDomainAnnotationKey)
+	err = reconciler.reconcileDomainMapping(isvc)
+	assert.NoError(t, err)
+	err = c.List(context.TODO(), domainMappings, client.InNamespace("default"))
+	assert.NoError(t, err)
+	assert.Empty(t, domainMappings.Items)
+	assert.Nil(t, isvc.Status.ExternalURLs)
+}
+
+func TestReconcileDomainMappingWithoutCRD(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = v1beta1.AddToScheme(scheme)
+	c := fake.NewClientBuilder().WithScheme(scheme).Build()
+	reconciler := NewIngressReconciler(c, nil, scheme, &v1beta1.IngressConfig{})
+
+	isvc := &v1beta1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-model", Namespace: "default"},
+	}
+	assert.NoError(t, reconciler.reconcileDomainMapping(isvc))
+
+	isvc.Annotations = map[string]string{constants.CustomDomainAnnotationKey: "model.example.com"}
+	assert.Error(t, reconciler.reconcileDomainMapping(isvc))
+}
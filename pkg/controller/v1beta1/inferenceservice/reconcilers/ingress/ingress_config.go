@@ -0,0 +1,48 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"strings"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+// OverrideIngressConfig returns the ingress config to use for the InferenceService. The global ingress gateway and
+// ingress class are overridden by the ingress-gateway and ingress-class annotations of the InferenceService, the
+// given ingress config is returned as is when none of them is set.
+func OverrideIngressConfig(isvc *v1beta1.InferenceService, ingressConfig *v1beta1.IngressConfig) *v1beta1.IngressConfig {
+	gateway, hasGateway := isvc.Annotations[constants.IngressGatewayAnnotationKey]
+	ingressClass, hasIngressClass := isvc.Annotations[constants.IngressClassAnnotationKey]
+	if !hasGateway && !hasIngressClass {
+		return ingressConfig
+	}
+	config := *ingressConfig
+	if hasGateway {
+		// the gateway is referenced as <namespace>/<name>, which is the format of the istio ingress gateway
+		config.IngressGateway = gateway
+		if namespace, name, found := strings.Cut(gateway, "/"); found {
+			config.GatewayNamespace = namespace
+			config.GatewayName = name
+		}
+	}
+	if hasIngressClass {
+		config.IngressClassName = &ingressClass
+	}
+	return &config
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	netv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"knative.dev/pkg/apis"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+func TestOverrideIngressConfig(t *testing.T) {
+	nginx := "nginx"
+	traefik := "traefik"
+	ingressConfig := &v1beta1.IngressConfig{
+		IngressGateway:   "knative-serving/knative-ingress-gateway",
+		IngressClassName: &nginx,
+		GatewayName:      "kserve-ingress-gateway",
+		GatewayNamespace: "kserve",
+	}
+	testCases := map[string]struct {
+		annotations map[string]string
+		expected    *v1beta1.IngressConfig
+	}{
+		"noOverride": {
+			annotations: map[string]string{},
+			expected:    ingressConfig,
+		},
+		"gatewayOverride": {
+			annotations: map[string]string{
+				constants.IngressGatewayAnnotationKey: "my-namespace/my-gateway",
+			},
+			expected: &v1beta1.IngressConfig{
+				IngressGateway:   "my-namespace/my-gateway",
+				IngressClassName: &nginx,
+				GatewayName:      "my-gateway",
+				GatewayNamespace: "my-namespace",
+			},
+		},
+		"ingressClassOverride": {
+			annotations: map[string]string{
+				constants.IngressClassAnnotationKey: traefik,
+			},
+			expected: &v1beta1.IngressConfig{
+				IngressGateway:   "knative-serving/knative-ingress-gateway",
+				IngressClassName: &traefik,
+				GatewayName:      "kserve-ingress-gateway",
+				GatewayNamespace: "kserve",
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			isvc := &v1beta1.InferenceService{
+				ObjectMeta: metav1.ObjectMeta{Name: "my-model", Namespace: "default", Annotations: tc.annotations},
+			}
+			assert.Equal(t, tc.expected, OverrideIngressConfig(isvc, ingressConfig))
+		})
+	}
+	// the global ingress config is left untouched
+	assert.Equal(t, "knative-serving/knative-ingress-gateway", ingressConfig.IngressGateway)
+	assert.Equal(t, "nginx", *ingressConfig.IngressClassName)
+}
+
+func TestRawIngressReconcilerIngressClassOverride(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = netv1.AddToScheme(scheme)
+	_ = v1beta1.AddToScheme(scheme)
+
+	nginx := "nginx"
+	isvc := &v1beta1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "my-model",
+			Namespace:   "default",
+			UID:         "123",
+			Annotations: map[string]string{},
+		},
+	}
+	isvc.Status.SetCondition(v1beta1.PredictorReady, &apis.Condition{Type: v1beta1.PredictorReady, Status: corev1.ConditionTrue})
+	ingressConfig := &v1beta1.IngressConfig{
+		IngressDomain:    "example.com",
+		DomainTemplate:   "{{ .Name }}-{{ .Namespace }}.{{ .IngressDomain }}",
+		UrlScheme:        "http",
+		IngressClassName: &nginx,
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).Build()
+	reconciler, _ := NewRawIngressReconciler(c, scheme, OverrideIngressConfig(isvc, ingressConfig))
+	assert.NoError(t, reconciler.Reconcile(isvc))
+	ingress := &netv1.Ingress{}
+	assert.NoError(t, c.Get(context.TODO(), types.NamespacedName{Name: "my-model", Namespace: "default"}, ingress))
+	assert.Equal(t, "nginx", *ingress.Spec.IngressClassName)
+
+	// changing the ingress class updates the existing ingress
+	isvc.Annotations[constants.IngressClassAnnotationKey] = "traefik"
+	reconciler, _ = NewRawIngressReconciler(c, scheme, OverrideIngressConfig(isvc, ingressConfig))
+	assert.NoError(t, reconciler.Reconcile(isvc))
+	ingresses := &netv1.IngressList{}
+	assert.NoError(t, c.List(context.TODO(), ingresses))
+	assert.Len(t, ingresses.Items, 1)
+	assert.Equal(t, "traefik", *ingresses.Items[0].Spec.IngressClassName)
+}
@@ -21,10 +21,12 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
 	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/durationpb"
 	istiov1beta1 "istio.io/api/networking/v1beta1"
 	istioclientv1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
 	corev1 "k8s.io/api/core/v1"
@@ -137,6 +139,27 @@ func getAdditionalHosts(domainList *[]string, serviceHost string, config *v1beta
 	}
 }
 
+// isInternalIngress returns true when the InferenceService is only exposed on the cluster local gateway
+func isInternalIngress(isvc *v1beta1.InferenceService, serviceHost string) bool {
+	// if service is labelled with cluster local or knative domain is configured as internal
+	if val, ok := isvc.Labels[constants.VisibilityLabel]; ok && val == constants.ClusterLocalVisibility {
+		return true
+	}
+	return serviceHost == network.GetServiceHostname(isvc.Name, isvc.Namespace)
+}
+
+// getIngressAdditionalHosts returns the hosts the InferenceService is exposed at in addition to its service host, which
+// are derived from the additional ingress domains and set by the additional-hosts annotation. Internal InferenceServices
+// have no additional hosts.
+func getIngressAdditionalHosts(isvc *v1beta1.InferenceService, serviceHost string, config *v1beta1.IngressConfig, domainList *[]string) []string {
+	if isInternalIngress(isvc, serviceHost) {
+		return nil
+	}
+	additionalHosts := &[]string{}
+	getAdditionalHosts(domainList, serviceHost, config, additionalHosts)
+	return uniqueHosts(append(*additionalHosts, v1beta1.GetAdditionalHosts(isvc.Annotations)...), serviceHost)
+}
+
 func getServiceUrl(isvc *v1beta1.InferenceService, config *v1beta1.IngressConfig) string {
 	url := getHostBasedServiceUrl(isvc, config)
 	if url == "" {
@@ -272,6 +295,22 @@ func createHTTPRouteDestination(gatewayService string) *istiov1beta1.HTTPRouteDe
 	return httpRouteDestination
 }
 
+// createHTTPRetry returns the retry policy of the routes to a component, nil when the component does not define one
+// so that the istio default applies.
+func createHTTPRetry(retries *v1beta1.RetrySpec) *istiov1beta1.HTTPRetry {
+	if retries == nil {
+		return nil
+	}
+	retry := &istiov1beta1.HTTPRetry{
+		Attempts: retries.Attempts,
+		RetryOn:  retries.RetryOn,
+	}
+	if retries.PerTryTimeoutSeconds != nil {
+		retry.PerTryTimeout = durationpb.New(time.Duration(*retries.PerTryTimeoutSeconds) * time.Second)
+	}
+	return retry
+}
+
 func createHTTPMatchRequest(prefix, targetHost, internalHost string, additionalHosts *[]string, isInternal bool, config *v1beta1.IngressConfig) []*istiov1beta1.HTTPMatchRequest {
 	var uri *istiov1beta1.StringMatch
 	if prefix != "" {
@@ -330,21 +369,24 @@ func createIngress(isvc *v1beta1.InferenceService, useDefault bool, config *v1be
 			status = corev1.ConditionUnknown
 		}
 		isvc.Status.SetCondition(v1beta1.IngressReady, &apis.Condition{
-			Type:   v1beta1.IngressReady,
-			Status: status,
-			Reason: "Predictor ingress not created",
+			Type:    v1beta1.IngressReady,
+			Status:  status,
+			Reason:  v1beta1.IngressNotReady,
+			Message: "Predictor ingress not created",
 		})
 		return nil
 	}
-	backend := constants.PredictorServiceName(isvc.Name)
+	backend := constants.PredictorServiceName(isvc.GeneratedNamePrefix())
 	if useDefault {
-		backend = constants.DefaultPredictorServiceName(isvc.Name)
+		backend = constants.DefaultPredictorServiceName(isvc.GeneratedNamePrefix())
 	}
+	retries := isvc.Spec.Predictor.Retries
 
 	if isvc.Spec.Transformer != nil {
-		backend = constants.TransformerServiceName(isvc.Name)
+		retries = isvc.Spec.Transformer.Retries
+		backend = constants.TransformerServiceName(isvc.GeneratedNamePrefix())
 		if useDefault {
-			backend = constants.DefaultTransformerServiceName(isvc.Name)
+			backend = constants.DefaultTransformerServiceName(isvc.GeneratedNamePrefix())
 		}
 		if !isvc.Status.IsConditionReady(v1beta1.TransformerReady) {
 			status := corev1.ConditionFalse
@@ -352,37 +394,27 @@ func createIngress(isvc *v1beta1.InferenceService, useDefault bool, config *v1be
 				status = corev1.ConditionUnknown
 			}
 			isvc.Status.SetCondition(v1beta1.IngressReady, &apis.Condition{
-				Type:   v1beta1.IngressReady,
-				Status: status,
-				Reason: "Transformer ingress not created",
+				Type:    v1beta1.IngressReady,
+				Status:  status,
+				Reason:  v1beta1.IngressNotReady,
+				Message: "Transformer ingress not created",
 			})
 			return nil
 		}
 	}
-	isInternal := false
 	serviceHost := getServiceHost(isvc)
-	// if service is labelled with cluster local or knative domain is configured as internal
-	if val, ok := isvc.Labels[constants.VisibilityLabel]; ok && val == constants.ClusterLocalVisibility {
-		isInternal = true
-	}
-	serviceInternalHostName := network.GetServiceHostname(isvc.Name, isvc.Namespace)
-	if serviceHost == serviceInternalHostName {
-		isInternal = true
-	}
+	isInternal := isInternalIngress(isvc, serviceHost)
 	httpRoutes := []*istiov1beta1.HTTPRoute{}
 	// Build explain route
-	expBackend := constants.ExplainerServiceName(isvc.Name)
+	expBackend := constants.ExplainerServiceName(isvc.GeneratedNamePrefix())
 	if useDefault {
-		expBackend = constants.DefaultExplainerServiceName(isvc.Name)
+		expBackend = constants.DefaultExplainerServiceName(isvc.GeneratedNamePrefix())
 	}
 
-	additionalHosts := &[]string{}
+	additionalHosts := getIngressAdditionalHosts(isvc, serviceHost, config, domainList)
 	hosts := []string{
 		network.GetServiceHostname(isvc.Name, isvc.Namespace),
 	}
-	if !isInternal {
-		getAdditionalHosts(domainList, serviceHost, config, additionalHosts)
-	}
 
 	if isvc.Spec.Explainer != nil {
 		if !isvc.Status.IsConditionReady(v1beta1.ExplainerReady) {
@@ -391,15 +423,16 @@ func createIngress(isvc *v1beta1.InferenceService, useDefault bool, config *v1be
 				status = corev1.ConditionUnknown
 			}
 			isvc.Status.SetCondition(v1beta1.IngressReady, &apis.Condition{
-				Type:   v1beta1.IngressReady,
-				Status: status,
-				Reason: "Explainer ingress not created",
+				Type:    v1beta1.IngressReady,
+				Status:  status,
+				Reason:  v1beta1.IngressNotReady,
+				Message: "Explainer ingress not created",
 			})
 			return nil
 		}
 		explainerRouter := istiov1beta1.HTTPRoute{
 			Match: createHTTPMatchRequest(constants.ExplainPrefix(), serviceHost,
-				network.GetServiceHostname(isvc.Name, isvc.Namespace), additionalHosts, isInternal, config),
+				network.GetServiceHostname(isvc.Name, isvc.Namespace), &additionalHosts, isInternal, config),
 			Route: []*istiov1beta1.HTTPRouteDestination{
 				createHTTPRouteDestination(config.LocalGatewayServiceName),
 			},
@@ -410,13 +443,14 @@ func createIngress(isvc *v1beta1.InferenceService, useDefault bool, config *v1be
 					},
 				},
 			},
+			Retries: createHTTPRetry(isvc.Spec.Explainer.Retries),
 		}
 		httpRoutes = append(httpRoutes, &explainerRouter)
 	}
 	// Add predict route
 	httpRoutes = append(httpRoutes, &istiov1beta1.HTTPRoute{
 		Match: createHTTPMatchRequest("", serviceHost,
-			network.GetServiceHostname(isvc.Name, isvc.Namespace), additionalHosts, isInternal, config),
+			network.GetServiceHostname(isvc.Name, isvc.Namespace), &additionalHosts, isInternal, config),
 		Route: []*istiov1beta1.HTTPRouteDestination{
 			createHTTPRouteDestination(config.LocalGatewayServiceName),
 		},
@@ -427,6 +461,7 @@ func createIngress(isvc *v1beta1.InferenceService, useDefault bool, config *v1be
 				},
 			},
 		},
+		Retries: createHTTPRetry(retries),
 	})
 
 	gateways := []string{
@@ -489,6 +524,7 @@ func createIngress(isvc *v1beta1.InferenceService, useDefault bool, config *v1be
 					},
 				},
 			},
+			Retries: createHTTPRetry(retries),
 		})
 		// Include ingressDomain to the domains (both internal and external) derived by KNative
 		hosts = append(hosts, url.Host)
@@ -496,7 +532,17 @@ func createIngress(isvc *v1beta1.InferenceService, useDefault bool, config *v1be
 
 	if !isInternal {
 		// We only append the additional hosts, when the ingress is not internal.
-		hosts = append(hosts, *additionalHosts...)
+		hosts = append(hosts, additionalHosts...)
+	}
+	cors, err := v1beta1.GetCorsConfig(isvc.Annotations, config.Cors)
+	if err != nil {
+		log.Error(err, "Failed to get the CORS policy")
+		return nil
+	}
+	if corsPolicy := createCorsPolicy(cors); corsPolicy != nil {
+		for _, httpRoute := range httpRoutes {
+			httpRoute.CorsPolicy = corsPolicy
+		}
 	}
 	annotations := utils.Filter(isvc.Annotations, func(key string) bool {
 		return !utils.Includes(constants.ServiceAnnotationDisallowedList, key)
@@ -509,7 +555,7 @@ func createIngress(isvc *v1beta1.InferenceService, useDefault bool, config *v1be
 			Labels:      isvc.Labels,
 		},
 		Spec: istiov1beta1.VirtualService{
-			Hosts:    hosts,
+			Hosts:    uniqueHosts(hosts),
 			Gateways: gateways,
 			Http:     httpRoutes,
 		},
@@ -544,17 +590,22 @@ func (ir *IngressReconciler) Reconcile(isvc *v1beta1.InferenceService) error {
 	if serviceHost == "" || serviceUrl == "" {
 		return nil
 	}
+	if err := ir.reconcileDomainMapping(isvc); err != nil {
+		return err
+	}
+	var additionalHosts []string
 	// When Istio virtual host is disabled, we return the underlying component url.
 	// When Istio virtual host is enabled. we return the url using inference service virtual host name and redirect to the corresponding transformer, predictor or explainer url.
 	if !disableIstioVirtualHost {
 		// Check if existing knative service name has default suffix
 		defaultNameExisting := &knservingv1.Service{}
 		useDefault := false
-		err := ir.client.Get(context.TODO(), types.NamespacedName{Name: constants.DefaultPredictorServiceName(isvc.Name), Namespace: isvc.Namespace}, defaultNameExisting)
+		err := ir.client.Get(context.TODO(), types.NamespacedName{Name: constants.DefaultPredictorServiceName(isvc.GeneratedNamePrefix()), Namespace: isvc.Namespace}, defaultNameExisting)
 		if err == nil {
 			useDefault = true
 		}
 		domainList := getDomainList(ir.clientset)
+		additionalHosts = getIngressAdditionalHosts(isvc, serviceHost, ir.ingressConfig, domainList)
 		desiredIngress := createIngress(isvc, useDefault, ir.ingressConfig, domainList)
 		if desiredIngress == nil {
 			return nil
@@ -593,12 +644,13 @@ func (ir *IngressReconciler) Reconcile(isvc *v1beta1.InferenceService) error {
 
 	if url, err := apis.ParseURL(serviceUrl); err == nil {
 		isvc.Status.URL = url
+		isvc.Status.Addresses = createAddresses(url, uniqueHosts(additionalHosts, url.Host))
 		var hostPrefix string
 		if disableIstioVirtualHost {
 			// Check if existing kubernetes service name has default suffix
 			existingServiceWithDefaultSuffix := &corev1.Service{}
 			useDefault := false
-			err := ir.client.Get(context.TODO(), types.NamespacedName{Name: constants.DefaultPredictorServiceName(isvc.Name), Namespace: isvc.Namespace}, existingServiceWithDefaultSuffix)
+			err := ir.client.Get(context.TODO(), types.NamespacedName{Name: constants.DefaultPredictorServiceName(isvc.GeneratedNamePrefix()), Namespace: isvc.Namespace}, existingServiceWithDefaultSuffix)
 			if err == nil {
 				useDefault = true
 			}
@@ -633,14 +685,14 @@ func getHostPrefix(isvc *v1beta1.InferenceService, disableIstioVirtualHost bool,
 	if disableIstioVirtualHost {
 		if useDefault {
 			if isvc.Spec.Transformer != nil {
-				return constants.DefaultTransformerServiceName(isvc.Name)
+				return constants.DefaultTransformerServiceName(isvc.GeneratedNamePrefix())
 			}
-			return constants.DefaultPredictorServiceName(isvc.Name)
+			return constants.DefaultPredictorServiceName(isvc.GeneratedNamePrefix())
 		} else {
 			if isvc.Spec.Transformer != nil {
-				return constants.TransformerServiceName(isvc.Name)
+				return constants.TransformerServiceName(isvc.GeneratedNamePrefix())
 			}
-			return constants.PredictorServiceName(isvc.Name)
+			return constants.PredictorServiceName(isvc.GeneratedNamePrefix())
 		}
 	}
 	return isvc.Name
@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"net/url"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
@@ -27,6 +28,7 @@ import (
 	"github.com/onsi/gomega"
 	gomegaTypes "github.com/onsi/gomega/types"
 	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/durationpb"
 	istiov1beta1 "istio.io/api/networking/v1beta1"
 	istioclientv1beta1 "istio.io/client-go/pkg/apis/networking/v1beta1"
 	corev1 "k8s.io/api/core/v1"
@@ -1157,6 +1159,165 @@ func TestCreateVirtualService(t *testing.T) {
 	}
 }
 
+func TestCreateVirtualServiceRetries(t *testing.T) {
+	serviceName := "my-model"
+	namespace := "test"
+	domain := "example.com"
+	perTryTimeout := int64(10)
+	ingressConfig := &v1beta1.IngressConfig{
+		IngressGateway:          constants.KnativeIngressGateway,
+		IngressServiceName:      "someIngressServiceName",
+		LocalGateway:            constants.KnativeLocalGateway,
+		LocalGatewayServiceName: "knative-local-gateway.istio-system.svc.cluster.local",
+	}
+	cases := map[string]struct {
+		predictorRetries *v1beta1.RetrySpec
+		explainerRetries *v1beta1.RetrySpec
+		expectedRetries  []*istiov1beta1.HTTPRetry
+	}{
+		"retriesUnset": {
+			expectedRetries: []*istiov1beta1.HTTPRetry{nil, nil},
+		},
+		"predictorRetries": {
+			predictorRetries: &v1beta1.RetrySpec{Attempts: 3, PerTryTimeoutSeconds: &perTryTimeout, RetryOn: "5xx,connect-failure"},
+			expectedRetries: []*istiov1beta1.HTTPRetry{
+				nil,
+				{Attempts: 3, PerTryTimeout: durationpb.New(10 * time.Second), RetryOn: "5xx,connect-failure"},
+			},
+		},
+		"explainerRetries": {
+			explainerRetries: &v1beta1.RetrySpec{Attempts: 2, RetryOn: "gateway-error"},
+			expectedRetries: []*istiov1beta1.HTTPRetry{
+				{Attempts: 2, RetryOn: "gateway-error"},
+				nil,
+			},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			isvc := &v1beta1.InferenceService{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      serviceName,
+					Namespace: namespace,
+				},
+				Spec: v1beta1.InferenceServiceSpec{
+					Predictor: v1beta1.PredictorSpec{
+						ComponentExtensionSpec: v1beta1.ComponentExtensionSpec{Retries: tc.predictorRetries},
+					},
+					Explainer: &v1beta1.ExplainerSpec{
+						ComponentExtensionSpec: v1beta1.ComponentExtensionSpec{Retries: tc.explainerRetries},
+					},
+				},
+			}
+			for _, condition := range []apis.ConditionType{v1beta1.PredictorReady, v1beta1.ExplainerReady} {
+				isvc.Status.SetCondition(condition, &apis.Condition{Type: condition, Status: corev1.ConditionTrue})
+			}
+			isvc.Status.Components = map[v1beta1.ComponentType]v1beta1.ComponentStatusSpec{
+				v1beta1.PredictorComponent: {
+					URL: &apis.URL{
+						Scheme: "http",
+						Host:   constants.InferenceServiceHostName(constants.PredictorServiceName(serviceName), namespace, domain),
+					},
+				},
+				v1beta1.ExplainerComponent: {
+					URL: &apis.URL{
+						Scheme: "http",
+						Host:   constants.InferenceServiceHostName(constants.ExplainerServiceName(serviceName), namespace, domain),
+					},
+				},
+			}
+
+			virtualService := createIngress(isvc, false, ingressConfig, &[]string{})
+			if virtualService == nil {
+				t.Fatal("expected a virtual service")
+			}
+			var actualRetries []*istiov1beta1.HTTPRetry
+			for _, route := range virtualService.Spec.Http {
+				actualRetries = append(actualRetries, route.Retries)
+			}
+			if diff := cmp.Diff(tc.expectedRetries, actualRetries, protocmp.Transform()); diff != "" {
+				t.Errorf("unexpected retries (-want +got): %v", diff)
+			}
+		})
+	}
+}
+
+func TestCreateVirtualServiceAdditionalHosts(t *testing.T) {
+	serviceName := "my-model"
+	namespace := "test"
+	domain := "example.com"
+	additionalDomain := "my-additional-domain.com"
+	ingressConfig := &v1beta1.IngressConfig{
+		IngressGateway:           constants.KnativeIngressGateway,
+		IngressServiceName:       "someIngressServiceName",
+		LocalGateway:             constants.KnativeLocalGateway,
+		LocalGatewayServiceName:  "knative-local-gateway.istio-system.svc.cluster.local",
+		AdditionalIngressDomains: &[]string{additionalDomain},
+	}
+	serviceHost := constants.InferenceServiceHostName(serviceName, namespace, domain)
+	additionalDomainHost := constants.InferenceServiceHostName(serviceName, namespace, additionalDomain)
+	isvc := &v1beta1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      serviceName,
+			Namespace: namespace,
+			Annotations: map[string]string{
+				// duplicates of the generated hosts and of the annotation hosts are dropped
+				constants.AdditionalHostsAnnotationKey: "my-model.lb.example.org," + additionalDomainHost + "," + serviceHost + ",my-model.lb.example.org",
+			},
+		},
+	}
+	isvc.Status.SetCondition(v1beta1.PredictorReady, &apis.Condition{Type: v1beta1.PredictorReady, Status: corev1.ConditionTrue})
+	isvc.Status.Components = map[v1beta1.ComponentType]v1beta1.ComponentStatusSpec{
+		v1beta1.PredictorComponent: {
+			URL: &apis.URL{
+				Scheme: "http",
+				Host:   constants.InferenceServiceHostName(constants.PredictorServiceName(serviceName), namespace, domain),
+			},
+		},
+	}
+
+	virtualService := createIngress(isvc, false, ingressConfig, &[]string{domain})
+	if virtualService == nil {
+		t.Fatal("expected a virtual service")
+	}
+	expectedHosts := []string{network.GetServiceHostname(serviceName, namespace), serviceHost, additionalDomainHost, "my-model.lb.example.org"}
+	if diff := cmp.Diff(expectedHosts, virtualService.Spec.Hosts); diff != "" {
+		t.Errorf("unexpected hosts (-want +got): %v", diff)
+	}
+	var authorities []string
+	for _, match := range virtualService.Spec.Http[0].Match {
+		authorities = append(authorities, match.Authority.GetRegex())
+	}
+	expectedAuthorities := []string{
+		constants.HostRegExp(network.GetServiceHostname(serviceName, namespace)),
+		constants.HostRegExp(serviceHost),
+		constants.HostRegExp(additionalDomainHost),
+		constants.HostRegExp("my-model.lb.example.org"),
+	}
+	if diff := cmp.Diff(expectedAuthorities, authorities); diff != "" {
+		t.Errorf("unexpected authorities (-want +got): %v", diff)
+	}
+
+	// the additional hosts are not exposed when the InferenceService is cluster local
+	isvc.Labels = map[string]string{constants.VisibilityLabel: constants.ClusterLocalVisibility}
+	virtualService = createIngress(isvc, false, ingressConfig, &[]string{domain})
+	if diff := cmp.Diff([]string{network.GetServiceHostname(serviceName, namespace)}, virtualService.Spec.Hosts); diff != "" {
+		t.Errorf("unexpected hosts (-want +got): %v", diff)
+	}
+}
+
+func TestCreateAddresses(t *testing.T) {
+	url := &apis.URL{Scheme: "https", Host: "my-model-default.example.com"}
+	addresses := createAddresses(url, []string{"my-model.example.org"})
+	expected := []duckv1.Addressable{
+		{URL: url},
+		{URL: &apis.URL{Scheme: "https", Host: "my-model.example.org"}},
+	}
+	if diff := cmp.Diff(expected, addresses); diff != "" {
+		t.Errorf("unexpected addresses (-want +got): %v", diff)
+	}
+}
+
 func TestGetServiceHost(t *testing.T) {
 
 	testCases := []struct {
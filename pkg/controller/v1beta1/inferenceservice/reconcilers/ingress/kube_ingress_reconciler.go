@@ -71,22 +71,22 @@ func createRawURL(isvc *v1beta1.InferenceService,
 func getRawServiceHost(isvc *v1beta1.InferenceService, client client.Client) string {
 	existingService := &corev1.Service{}
 	if isvc.Spec.Transformer != nil {
-		transformerName := constants.TransformerServiceName(isvc.Name)
+		transformerName := constants.TransformerServiceName(isvc.GeneratedNamePrefix())
 
 		// Check if existing transformer service name has default suffix
-		err := client.Get(context.TODO(), types.NamespacedName{Name: constants.DefaultTransformerServiceName(isvc.Name), Namespace: isvc.Namespace}, existingService)
+		err := client.Get(context.TODO(), types.NamespacedName{Name: constants.DefaultTransformerServiceName(isvc.GeneratedNamePrefix()), Namespace: isvc.Namespace}, existingService)
 		if err == nil {
-			transformerName = constants.DefaultTransformerServiceName(isvc.Name)
+			transformerName = constants.DefaultTransformerServiceName(isvc.GeneratedNamePrefix())
 		}
 		return network.GetServiceHostname(transformerName, isvc.Namespace)
 	}
 
-	predictorName := constants.PredictorServiceName(isvc.Name)
+	predictorName := constants.PredictorServiceName(isvc.GeneratedNamePrefix())
 
 	// Check if existing predictor service name has default suffix
-	err := client.Get(context.TODO(), types.NamespacedName{Name: constants.DefaultPredictorServiceName(isvc.Name), Namespace: isvc.Namespace}, existingService)
+	err := client.Get(context.TODO(), types.NamespacedName{Name: constants.DefaultPredictorServiceName(isvc.GeneratedNamePrefix()), Namespace: isvc.Namespace}, existingService)
 	if err == nil {
-		predictorName = constants.DefaultPredictorServiceName(isvc.Name)
+		predictorName = constants.DefaultPredictorServiceName(isvc.GeneratedNamePrefix())
 	}
 	return network.GetServiceHostname(predictorName, isvc.Namespace)
 }
@@ -149,36 +149,75 @@ func generateIngressHost(ingressConfig *v1beta1.IngressConfig,
 	}
 }
 
+// getRawAdditionalHosts returns the hosts the InferenceService is exposed at in addition to its top level host, which
+// are generated from the additional ingress domains and set by the additional-hosts annotation.
+func getRawAdditionalHosts(isvc *v1beta1.InferenceService, ingressConfig *v1beta1.IngressConfig, topLevelHost string) []string {
+	var hosts []string
+	if ingressConfig.AdditionalIngressDomains != nil {
+		for _, domain := range *ingressConfig.AdditionalIngressDomains {
+			config := *ingressConfig
+			config.IngressDomain = domain
+			host, err := GenerateDomainName(isvc.Name, isvc.ObjectMeta, &config)
+			if err != nil {
+				log.Error(err, "Failed to generate the host of the additional ingress domain", "domain", domain)
+				continue
+			}
+			hosts = append(hosts, host)
+		}
+	}
+	return uniqueHosts(append(hosts, v1beta1.GetAdditionalHosts(isvc.Annotations)...), topLevelHost)
+}
+
+// getRawIngressTLSSecret returns the TLS secret of the Ingress of the InferenceService, which is set by the tls-secret
+// annotation or the default TLS secret of the ingress config. When only a cert-manager issuer is configured, the secret
+// is named after the InferenceService and created by cert-manager. It returns an empty string when the Ingress does not
+// terminate TLS.
+func getRawIngressTLSSecret(isvc *v1beta1.InferenceService, ingressConfig *v1beta1.IngressConfig) string {
+	if secret, ok := isvc.Annotations[constants.TLSSecretAnnotationKey]; ok && secret != "" {
+		return secret
+	}
+	if ingressConfig.DefaultTLSSecret != "" {
+		return ingressConfig.DefaultTLSSecret
+	}
+	if ingressConfig.CertManagerIssuer != "" {
+		return isvc.Name + "-tls"
+	}
+	return ""
+}
+
 func createRawIngress(scheme *runtime.Scheme, isvc *v1beta1.InferenceService,
 	ingressConfig *v1beta1.IngressConfig, client client.Client) (*netv1.Ingress, error) {
 	if !isvc.Status.IsConditionReady(v1beta1.PredictorReady) {
 		isvc.Status.SetCondition(v1beta1.IngressReady, &apis.Condition{
-			Type:   v1beta1.IngressReady,
-			Status: corev1.ConditionFalse,
-			Reason: "Predictor ingress not created",
+			Type:    v1beta1.IngressReady,
+			Status:  corev1.ConditionFalse,
+			Reason:  v1beta1.IngressNotReady,
+			Message: "Predictor ingress not created",
 		})
 		return nil, nil
 	}
 	var rules []netv1.IngressRule
+	var topLevelRule netv1.IngressRule
 	existing := &corev1.Service{}
-	predictorName := constants.PredictorServiceName(isvc.Name)
+	predictorName := constants.PredictorServiceName(isvc.GeneratedNamePrefix())
 	switch {
 	case isvc.Spec.Transformer != nil:
 		if !isvc.Status.IsConditionReady(v1beta1.TransformerReady) {
 			isvc.Status.SetCondition(v1beta1.IngressReady, &apis.Condition{
-				Type:   v1beta1.IngressReady,
-				Status: corev1.ConditionFalse,
-				Reason: "Transformer ingress not created",
+				Type:    v1beta1.IngressReady,
+				Status:  corev1.ConditionFalse,
+				Reason:  v1beta1.IngressNotReady,
+				Message: "Transformer ingress not created",
 			})
 			return nil, nil
 		}
-		transformerName := constants.TransformerServiceName(isvc.Name)
-		explainerName := constants.ExplainerServiceName(isvc.Name)
-		err := client.Get(context.TODO(), types.NamespacedName{Name: constants.DefaultTransformerServiceName(isvc.Name), Namespace: isvc.Namespace}, existing)
+		transformerName := constants.TransformerServiceName(isvc.GeneratedNamePrefix())
+		explainerName := constants.ExplainerServiceName(isvc.GeneratedNamePrefix())
+		err := client.Get(context.TODO(), types.NamespacedName{Name: constants.DefaultTransformerServiceName(isvc.GeneratedNamePrefix()), Namespace: isvc.Namespace}, existing)
 		if err == nil {
-			transformerName = constants.DefaultTransformerServiceName(isvc.Name)
-			predictorName = constants.DefaultPredictorServiceName(isvc.Name)
-			explainerName = constants.DefaultExplainerServiceName(isvc.Name)
+			transformerName = constants.DefaultTransformerServiceName(isvc.GeneratedNamePrefix())
+			predictorName = constants.DefaultPredictorServiceName(isvc.GeneratedNamePrefix())
+			explainerName = constants.DefaultExplainerServiceName(isvc.GeneratedNamePrefix())
 		}
 		host, err := generateIngressHost(ingressConfig, isvc, string(constants.Transformer), true, transformerName)
 		if err != nil {
@@ -196,22 +235,24 @@ func createRawIngress(scheme *runtime.Scheme, isvc *v1beta1.InferenceService,
 			rules = append(rules, generateRule(explainerHost, explainerName, "/", constants.CommonDefaultHttpPort))
 		}
 		// :predict routes to the transformer when there are both predictor and transformer
-		rules = append(rules, generateRule(host, transformerName, "/", constants.CommonDefaultHttpPort))
+		topLevelRule = generateRule(host, transformerName, "/", constants.CommonDefaultHttpPort)
+		rules = append(rules, topLevelRule)
 		rules = append(rules, generateRule(transformerHost, predictorName, "/", constants.CommonDefaultHttpPort))
 	case isvc.Spec.Explainer != nil:
 		if !isvc.Status.IsConditionReady(v1beta1.ExplainerReady) {
 			isvc.Status.SetCondition(v1beta1.IngressReady, &apis.Condition{
-				Type:   v1beta1.IngressReady,
-				Status: corev1.ConditionFalse,
-				Reason: "Explainer ingress not created",
+				Type:    v1beta1.IngressReady,
+				Status:  corev1.ConditionFalse,
+				Reason:  v1beta1.IngressNotReady,
+				Message: "Explainer ingress not created",
 			})
 			return nil, nil
 		}
-		explainerName := constants.ExplainerServiceName(isvc.Name)
-		err := client.Get(context.TODO(), types.NamespacedName{Name: constants.DefaultExplainerServiceName(isvc.Name), Namespace: isvc.Namespace}, existing)
+		explainerName := constants.ExplainerServiceName(isvc.GeneratedNamePrefix())
+		err := client.Get(context.TODO(), types.NamespacedName{Name: constants.DefaultExplainerServiceName(isvc.GeneratedNamePrefix()), Namespace: isvc.Namespace}, existing)
 		if err == nil {
-			explainerName = constants.DefaultExplainerServiceName(isvc.Name)
-			predictorName = constants.DefaultPredictorServiceName(isvc.Name)
+			explainerName = constants.DefaultExplainerServiceName(isvc.GeneratedNamePrefix())
+			predictorName = constants.DefaultPredictorServiceName(isvc.GeneratedNamePrefix())
 		}
 		host, err := generateIngressHost(ingressConfig, isvc, string(constants.Explainer), true, explainerName)
 		if err != nil {
@@ -222,18 +263,20 @@ func createRawIngress(scheme *runtime.Scheme, isvc *v1beta1.InferenceService,
 			return nil, fmt.Errorf("failed creating explainer ingress host: %w", err)
 		}
 		// :predict routes to the predictor when there is only predictor and explainer
-		rules = append(rules, generateRule(host, predictorName, "/", constants.CommonDefaultHttpPort))
+		topLevelRule = generateRule(host, predictorName, "/", constants.CommonDefaultHttpPort)
+		rules = append(rules, topLevelRule)
 		rules = append(rules, generateRule(explainerHost, explainerName, "/", constants.CommonDefaultHttpPort))
 	default:
-		err := client.Get(context.TODO(), types.NamespacedName{Name: constants.DefaultPredictorServiceName(isvc.Name), Namespace: isvc.Namespace}, existing)
+		err := client.Get(context.TODO(), types.NamespacedName{Name: constants.DefaultPredictorServiceName(isvc.GeneratedNamePrefix()), Namespace: isvc.Namespace}, existing)
 		if err == nil {
-			predictorName = constants.DefaultPredictorServiceName(isvc.Name)
+			predictorName = constants.DefaultPredictorServiceName(isvc.GeneratedNamePrefix())
 		}
 		host, err := generateIngressHost(ingressConfig, isvc, string(constants.Predictor), true, predictorName)
 		if err != nil {
 			return nil, fmt.Errorf("failed creating top level predictor ingress host: %w", err)
 		}
-		rules = append(rules, generateRule(host, predictorName, "/", constants.CommonDefaultHttpPort))
+		topLevelRule = generateRule(host, predictorName, "/", constants.CommonDefaultHttpPort)
+		rules = append(rules, topLevelRule)
 	}
 	// add predictor rule
 	predictorHost, err := generateIngressHost(ingressConfig, isvc, string(constants.Predictor), false, predictorName)
@@ -241,16 +284,37 @@ func createRawIngress(scheme *runtime.Scheme, isvc *v1beta1.InferenceService,
 		return nil, fmt.Errorf("failed creating predictor ingress host: %w", err)
 	}
 	rules = append(rules, generateRule(predictorHost, predictorName, "/", constants.CommonDefaultHttpPort))
+	// the additional hosts are routed as the top level host
+	for _, additionalHost := range getRawAdditionalHosts(isvc, ingressConfig, topLevelRule.Host) {
+		rule := *topLevelRule.DeepCopy()
+		rule.Host = additionalHost
+		rules = append(rules, rule)
+	}
 
+	annotations := isvc.Annotations
+	var tls []netv1.IngressTLS
+	if secret := getRawIngressTLSSecret(isvc, ingressConfig); secret != "" {
+		var hosts []string
+		for _, rule := range rules {
+			hosts = append(hosts, rule.Host)
+		}
+		tls = []netv1.IngressTLS{{Hosts: hosts, SecretName: secret}}
+		if ingressConfig.CertManagerIssuer != "" {
+			annotations = utils.Union(isvc.Annotations, map[string]string{
+				constants.CertManagerClusterIssuerAnnotationKey: ingressConfig.CertManagerIssuer,
+			})
+		}
+	}
 	ingress := &netv1.Ingress{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        isvc.ObjectMeta.Name,
 			Namespace:   isvc.ObjectMeta.Namespace,
-			Annotations: isvc.Annotations,
+			Annotations: annotations,
 		},
 		Spec: netv1.IngressSpec{
 			IngressClassName: ingressConfig.IngressClassName,
 			Rules:            rules,
+			TLS:              tls,
 		},
 	}
 	if err := controllerutil.SetControllerReference(isvc, ingress, scheme); err != nil {
@@ -260,20 +324,137 @@ func createRawIngress(scheme *runtime.Scheme, isvc *v1beta1.InferenceService,
 }
 
 func semanticIngressEquals(desired, existing *netv1.Ingress) bool {
-	return equality.Semantic.DeepEqual(desired.Spec, existing.Spec)
+	return equality.Semantic.DeepEqual(desired.Spec, existing.Spec) &&
+		equality.Semantic.DeepEqual(desired.Annotations, existing.Annotations)
+}
+
+// isRawClusterLocal returns true when the InferenceService is labelled with cluster local visibility or the kserve
+// domain is cluster local, in which case it is only exposed by its Services inside the cluster.
+func isRawClusterLocal(isvc *v1beta1.InferenceService, ingressConfig *v1beta1.IngressConfig) bool {
+	for _, label := range []string{constants.NetworkVisibility, constants.VisibilityLabel} {
+		if val, ok := isvc.Labels[label]; ok && val == constants.ClusterLocalVisibility {
+			return true
+		}
+	}
+	return ingressConfig.IngressDomain == constants.ClusterLocalDomain
+}
+
+// deleteIngress deletes the Ingress of the InferenceService when it is no longer exposed by an Ingress
+func (r *RawIngressReconciler) deleteIngress(isvc *v1beta1.InferenceService) error {
+	existing := &netv1.Ingress{}
+	err := r.client.Get(context.TODO(), types.NamespacedName{Namespace: isvc.Namespace, Name: isvc.Name}, existing)
+	if err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if !metav1.IsControlledBy(existing, isvc) {
+		return nil
+	}
+	log.Info("deleting ingress", "namespace", existing.Namespace, "name", existing.Name)
+	return client.IgnoreNotFound(r.client.Delete(context.TODO(), existing))
+}
+
+// setRawComponentAddresses publishes the cluster local endpoints of the services of the InferenceService components in
+// the component statuses: the address and the REST url of the service and the gRPC url when the service exposes a gRPC
+// port. The url of an available component is its cluster local url when the InferenceService is internal.
+func (r *RawIngressReconciler) setRawComponentAddresses(isvc *v1beta1.InferenceService, isInternal bool) error {
+	type componentService struct {
+		component   v1beta1.ComponentType
+		name        string
+		defaultName string
+	}
+	componentServices := []componentService{
+		{v1beta1.PredictorComponent, constants.PredictorServiceName(isvc.GeneratedNamePrefix()), constants.DefaultPredictorServiceName(isvc.GeneratedNamePrefix())},
+	}
+	if isvc.Spec.Transformer != nil {
+		componentServices = append(componentServices, componentService{v1beta1.TransformerComponent,
+			constants.TransformerServiceName(isvc.GeneratedNamePrefix()), constants.DefaultTransformerServiceName(isvc.GeneratedNamePrefix())})
+	}
+	if isvc.Spec.Explainer != nil {
+		componentServices = append(componentServices, componentService{v1beta1.ExplainerComponent,
+			constants.ExplainerServiceName(isvc.GeneratedNamePrefix()), constants.DefaultExplainerServiceName(isvc.GeneratedNamePrefix())})
+	}
+	for _, componentService := range componentServices {
+		service := &corev1.Service{}
+		err := r.client.Get(context.TODO(), types.NamespacedName{Name: componentService.defaultName, Namespace: isvc.Namespace}, service)
+		if apierr.IsNotFound(err) {
+			err = r.client.Get(context.TODO(), types.NamespacedName{Name: componentService.name, Namespace: isvc.Namespace}, service)
+		}
+		if apierr.IsNotFound(err) {
+			// the service of the component is not created yet
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if isvc.Status.Components == nil {
+			isvc.Status.Components = make(map[v1beta1.ComponentType]v1beta1.ComponentStatusSpec)
+		}
+		statusSpec := isvc.Status.Components[componentService.component]
+		host := network.GetServiceHostname(service.Name, service.Namespace)
+		statusSpec.Address = &duckv1.Addressable{URL: &apis.URL{Scheme: "http", Host: host}}
+		statusSpec.RestURL = &apis.URL{Scheme: "http", Host: host}
+		statusSpec.GrpcURL = nil
+		if port := getGrpcServicePort(service); port != nil {
+			statusSpec.GrpcURL = &apis.URL{Scheme: "grpc", Host: fmt.Sprintf("%s:%d", host, *port)}
+		}
+		if isInternal && statusSpec.URL != nil {
+			statusSpec.URL = &apis.URL{Scheme: "http", Host: host}
+		}
+		isvc.Status.Components[componentService.component] = statusSpec
+	}
+	return nil
 }
 
 func (r *RawIngressReconciler) Reconcile(isvc *v1beta1.InferenceService) error {
 	var err error
-	isInternal := false
 	// disable ingress creation if service is labelled with cluster local or kserve domain is cluster local
-	if val, ok := isvc.Labels[constants.NetworkVisibility]; ok && val == constants.ClusterLocalVisibility {
-		isInternal = true
+	isInternal := isRawClusterLocal(isvc, r.ingressConfig)
+	routeAccepted := true
+	admittedHost := ""
+	useGatewayAPI := !isInternal && !r.ingressConfig.DisableIngressCreation && r.ingressConfig.EnableGatewayAPI
+	useRoute := !isInternal && !r.ingressConfig.DisableIngressCreation && !r.ingressConfig.EnableGatewayAPI &&
+		r.ingressConfig.IngressKind == constants.OpenShiftRouteKind
+	useIngress := !isInternal && !r.ingressConfig.DisableIngressCreation && !r.ingressConfig.EnableGatewayAPI && !useRoute
+	// remove the external objects which were created before the visibility or the ingress config changed
+	if !useIngress {
+		if err := r.deleteIngress(isvc); err != nil {
+			return err
+		}
+	}
+	if !useRoute {
+		if err := r.deleteOpenShiftRoute(isvc); err != nil {
+			return err
+		}
 	}
-	if r.ingressConfig.IngressDomain == constants.ClusterLocalDomain {
-		isInternal = true
+	if !useGatewayAPI {
+		if err := r.deleteGatewayRoutes(isvc); err != nil {
+			return err
+		}
 	}
-	if !isInternal && !r.ingressConfig.DisableIngressCreation {
+	if err := r.setRawComponentAddresses(isvc, isInternal); err != nil {
+		return err
+	}
+	if useGatewayAPI {
+		routes, err := r.reconcileHTTPRoutes(isvc)
+		if routes == nil {
+			return err
+		}
+		routeAccepted = isHTTPRouteAccepted(routes)
+		grpcRoute, err := r.reconcileGRPCRoute(isvc)
+		if err != nil {
+			return err
+		}
+		if grpcRoute != nil {
+			routeAccepted = routeAccepted && isRouteStatusAccepted(grpcRoute.Status.RouteStatus)
+		}
+	} else if useRoute {
+		route, err := r.reconcileOpenShiftRoute(isvc)
+		if route == nil {
+			return err
+		}
+		admittedHost = getRouteAdmittedHost(route)
+		routeAccepted = admittedHost != ""
+	} else if useIngress {
 		ingress, err := createRawIngress(r.scheme, isvc, r.ingressConfig, r.client)
 		if ingress == nil {
 			return nil
@@ -296,7 +477,10 @@ func (r *RawIngressReconciler) Reconcile(isvc *v1beta1.InferenceService) error {
 			}
 		} else {
 			if !semanticIngressEquals(ingress, existingIngress) {
-				err = r.client.Update(context.TODO(), ingress)
+				deepCopy := existingIngress.DeepCopy()
+				deepCopy.Spec = ingress.Spec
+				deepCopy.Annotations = ingress.Annotations
+				err = r.client.Update(context.TODO(), deepCopy)
 				log.Info("updating ingress", "ingressName", isvc.Name, "err", err)
 			}
 		}
@@ -304,10 +488,39 @@ func (r *RawIngressReconciler) Reconcile(isvc *v1beta1.InferenceService) error {
 			return err
 		}
 	}
-	isvc.Status.URL, err = createRawURL(isvc, r.ingressConfig)
+	if isInternal {
+		// the InferenceService is only reachable at the address of its service
+		isvc.Status.URL = &apis.URL{
+			Scheme: "http",
+			Host:   getRawServiceHost(isvc, r.client),
+		}
+	} else if useGatewayAPI && r.ingressConfig.PathTemplate != "" {
+		isvc.Status.URL, err = createRawPathBasedURL(isvc, r.ingressConfig)
+	} else {
+		isvc.Status.URL, err = createRawURL(isvc, r.ingressConfig)
+	}
 	if err != nil {
 		return err
 	}
+	if useIngress && getRawIngressTLSSecret(isvc, r.ingressConfig) != "" {
+		// the Ingress terminates TLS
+		isvc.Status.URL.Scheme = "https"
+	}
+	if useRoute {
+		// the router may admit the Route at another host than the requested one
+		if admittedHost != "" {
+			isvc.Status.URL.Host = admittedHost
+		}
+		if getRouteTLSConfig(isvc) != nil {
+			isvc.Status.URL.Scheme = "https"
+		}
+	}
+	var additionalHosts []string
+	// a Route only exposes a single host
+	if !isInternal && !r.ingressConfig.DisableIngressCreation && !useRoute {
+		additionalHosts = getRawAdditionalHosts(isvc, r.ingressConfig, isvc.Status.URL.Host)
+	}
+	isvc.Status.Addresses = createAddresses(isvc.Status.URL, additionalHosts)
 	isvc.Status.Address = &duckv1.Addressable{
 		URL: &apis.URL{
 			Host:   getRawServiceHost(isvc, r.client),
@@ -315,6 +528,19 @@ func (r *RawIngressReconciler) Reconcile(isvc *v1beta1.InferenceService) error {
 			Path:   "",
 		},
 	}
+	if !routeAccepted {
+		message := "HTTPRoutes are not accepted by the gateway yet"
+		if useRoute {
+			message = "Route is not admitted by a router yet"
+		}
+		isvc.Status.SetCondition(v1beta1.IngressReady, &apis.Condition{
+			Type:    v1beta1.IngressReady,
+			Status:  corev1.ConditionFalse,
+			Reason:  v1beta1.IngressNotReady,
+			Message: message,
+		})
+		return nil
+	}
 	isvc.Status.SetCondition(v1beta1.IngressReady, &apis.Condition{
 		Type:   v1beta1.IngressReady,
 		Status: corev1.ConditionTrue,
@@ -0,0 +1,317 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	netv1 "k8s.io/api/networking/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"knative.dev/pkg/network"
+	knservingv1 "knative.dev/serving/pkg/apis/serving/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+func TestRawIngressReconcilerTLS(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = netv1.AddToScheme(scheme)
+	_ = v1beta1.AddToScheme(scheme)
+
+	testCases := map[string]struct {
+		annotations         map[string]string
+		defaultTLSSecret    string
+		certManagerIssuer   string
+		expectedTLS         []netv1.IngressTLS
+		expectedAnnotations map[string]string
+		expectedURL         string
+	}{
+		"noTLS": {
+			annotations: map[string]string{},
+			expectedURL: "http://my-model-default.example.com",
+		},
+		"defaultTLSSecret": {
+			annotations:      map[string]string{},
+			defaultTLSSecret: "wildcard-tls",
+			expectedTLS: []netv1.IngressTLS{
+				{Hosts: []string{"my-model-default.example.com", "my-model-predictor-default.example.com"}, SecretName: "wildcard-tls"},
+			},
+			expectedURL: "https://my-model-default.example.com",
+		},
+		"annotatedTLSSecret": {
+			annotations:      map[string]string{constants.TLSSecretAnnotationKey: "my-model-cert"},
+			defaultTLSSecret: "wildcard-tls",
+			expectedTLS: []netv1.IngressTLS{
+				{Hosts: []string{"my-model-default.example.com", "my-model-predictor-default.example.com"}, SecretName: "my-model-cert"},
+			},
+			expectedAnnotations: map[string]string{constants.TLSSecretAnnotationKey: "my-model-cert"},
+			expectedURL:         "https://my-model-default.example.com",
+		},
+		"certManagerIssuer": {
+			annotations:       map[string]string{},
+			certManagerIssuer: "letsencrypt",
+			expectedTLS: []netv1.IngressTLS{
+				{Hosts: []string{"my-model-default.example.com", "my-model-predictor-default.example.com"}, SecretName: "my-model-tls"},
+			},
+			expectedAnnotations: map[string]string{constants.CertManagerClusterIssuerAnnotationKey: "letsencrypt"},
+			expectedURL:         "https://my-model-default.example.com",
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			isvc := &v1beta1.InferenceService{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "my-model",
+					Namespace:   "default",
+					UID:         "123",
+					Annotations: tc.annotations,
+				},
+			}
+			isvc.Status.SetCondition(v1beta1.PredictorReady, &apis.Condition{Type: v1beta1.PredictorReady, Status: corev1.ConditionTrue})
+			ingressConfig := &v1beta1.IngressConfig{
+				IngressDomain:     "example.com",
+				DomainTemplate:    "{{ .Name }}-{{ .Namespace }}.{{ .IngressDomain }}",
+				UrlScheme:         "http",
+				DefaultTLSSecret:  tc.defaultTLSSecret,
+				CertManagerIssuer: tc.certManagerIssuer,
+			}
+			c := fake.NewClientBuilder().WithScheme(scheme).Build()
+			reconciler, _ := NewRawIngressReconciler(c, scheme, ingressConfig)
+			assert.NoError(t, reconciler.Reconcile(isvc))
+			ingress := &netv1.Ingress{}
+			assert.NoError(t, c.Get(context.TODO(), types.NamespacedName{Name: "my-model", Namespace: "default"}, ingress))
+			assert.Equal(t, tc.expectedTLS, ingress.Spec.TLS)
+			if tc.expectedAnnotations == nil {
+				assert.Empty(t, ingress.Annotations)
+			} else {
+				assert.Equal(t, tc.expectedAnnotations, ingress.Annotations)
+			}
+			assert.Equal(t, tc.expectedURL, isvc.Status.URL.String())
+		})
+	}
+}
+
+func TestRawIngressReconcilerVisibility(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = netv1.AddToScheme(scheme)
+	_ = v1beta1.AddToScheme(scheme)
+	_ = gatewayapiv1.AddToScheme(scheme)
+
+	testCases := map[string]struct {
+		visibilityLabel  string
+		enableGatewayAPI bool
+	}{
+		"ingress":                {visibilityLabel: constants.NetworkVisibility},
+		"ingressKnativeLabel":    {visibilityLabel: constants.VisibilityLabel},
+		"gatewayAPI":             {visibilityLabel: constants.NetworkVisibility, enableGatewayAPI: true},
+		"gatewayAPIKnativeLabel": {visibilityLabel: constants.VisibilityLabel, enableGatewayAPI: true},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			isvc := &v1beta1.InferenceService{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "my-model",
+					Namespace: "default",
+					UID:       "123",
+				},
+			}
+			isvc.Status.SetCondition(v1beta1.PredictorReady, &apis.Condition{Type: v1beta1.PredictorReady, Status: corev1.ConditionTrue})
+			ingressConfig := &v1beta1.IngressConfig{
+				IngressDomain:    "example.com",
+				DomainTemplate:   "{{ .Name }}-{{ .Namespace }}.{{ .IngressDomain }}",
+				UrlScheme:        "http",
+				EnableGatewayAPI: tc.enableGatewayAPI,
+				GatewayName:      "kserve-ingress-gateway",
+				GatewayNamespace: "kserve",
+			}
+			c := fake.NewClientBuilder().WithScheme(scheme).Build()
+			reconciler, _ := NewRawIngressReconciler(c, scheme, ingressConfig)
+			externalObjects := func() int {
+				routes := &gatewayapiv1.HTTPRouteList{}
+				assert.NoError(t, c.List(context.TODO(), routes, client.InNamespace("default")))
+				err := c.Get(context.TODO(), types.NamespacedName{Name: "my-model", Namespace: "default"}, &netv1.Ingress{})
+				if err == nil {
+					return len(routes.Items) + 1
+				}
+				assert.True(t, apierr.IsNotFound(err))
+				return len(routes.Items)
+			}
+
+			assert.NoError(t, reconciler.Reconcile(isvc))
+			assert.NotZero(t, externalObjects())
+			assert.Equal(t, "http://my-model-default.example.com", isvc.Status.URL.String())
+
+			// the external objects are deleted once the InferenceService is cluster local
+			isvc.Labels = map[string]string{tc.visibilityLabel: constants.ClusterLocalVisibility}
+			assert.NoError(t, reconciler.Reconcile(isvc))
+			assert.Zero(t, externalObjects())
+			assert.Equal(t, "http://my-model-predictor.default.svc.cluster.local", isvc.Status.URL.String())
+
+			// and created again when it is exposed
+			isvc.Labels = map[string]string{}
+			assert.NoError(t, reconciler.Reconcile(isvc))
+			assert.NotZero(t, externalObjects())
+			assert.Equal(t, "http://my-model-default.example.com", isvc.Status.URL.String())
+		})
+	}
+}
+
+func TestRawIngressReconcilerComponentAddresses(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = netv1.AddToScheme(scheme)
+	_ = v1beta1.AddToScheme(scheme)
+
+	isvc := &v1beta1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-model",
+			Namespace: "default",
+			UID:       "123",
+		},
+		Spec: v1beta1.InferenceServiceSpec{
+			Transformer: &v1beta1.TransformerSpec{},
+			Explainer:   &v1beta1.ExplainerSpec{},
+		},
+	}
+	for _, condition := range []apis.ConditionType{v1beta1.PredictorReady, v1beta1.TransformerReady, v1beta1.ExplainerReady} {
+		isvc.Status.SetCondition(condition, &apis.Condition{Type: condition, Status: corev1.ConditionTrue})
+	}
+	h2c := constants.H2CAppProtocol
+	services := []client.Object{
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: constants.DefaultPredictorServiceName("my-model"), Namespace: "default"},
+			Spec: corev1.ServiceSpec{
+				Ports: []corev1.ServicePort{
+					{Name: "http", Port: constants.CommonDefaultHttpPort},
+					{Name: constants.GrpcPortName, Port: 9000, AppProtocol: &h2c},
+				},
+			},
+		},
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: constants.TransformerServiceName("my-model"), Namespace: "default"},
+		},
+	}
+	ingressConfig := &v1beta1.IngressConfig{
+		IngressDomain:  "example.com",
+		DomainTemplate: "{{ .Name }}-{{ .Namespace }}.{{ .IngressDomain }}",
+		UrlScheme:      "http",
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(services...).Build()
+	reconciler, _ := NewRawIngressReconciler(c, scheme, ingressConfig)
+
+	assert.NoError(t, reconciler.Reconcile(isvc))
+	predictor := isvc.Status.Components[v1beta1.PredictorComponent]
+	assert.Equal(t, "http://my-model-predictor-default.default.svc.cluster.local", predictor.Address.URL.String())
+	assert.Equal(t, "http://my-model-predictor-default.default.svc.cluster.local", predictor.RestURL.String())
+	assert.Equal(t, "grpc://my-model-predictor-default.default.svc.cluster.local:9000", predictor.GrpcURL.String())
+	transformer := isvc.Status.Components[v1beta1.TransformerComponent]
+	assert.Equal(t, "http://my-model-transformer.default.svc.cluster.local", transformer.Address.URL.String())
+	assert.Equal(t, "http://my-model-transformer.default.svc.cluster.local", transformer.RestURL.String())
+	assert.Nil(t, transformer.GrpcURL)
+	// the explainer service is not created yet
+	assert.NotContains(t, isvc.Status.Components, v1beta1.ExplainerComponent)
+
+	// the url of an available component is the cluster local url once the InferenceService is internal
+	transformer.URL = &apis.URL{Scheme: "http", Host: "my-model-transformer-default.example.com"}
+	isvc.Status.Components[v1beta1.TransformerComponent] = transformer
+	isvc.Labels = map[string]string{constants.NetworkVisibility: constants.ClusterLocalVisibility}
+	assert.NoError(t, reconciler.Reconcile(isvc))
+	assert.Equal(t, "http://my-model-transformer.default.svc.cluster.local", isvc.Status.Components[v1beta1.TransformerComponent].URL.String())
+	assert.Nil(t, isvc.Status.Components[v1beta1.PredictorComponent].URL)
+}
+
+// TestRawComponentStatusMatchesServerless checks that the components of a raw deployment publish the same status
+// fields as the components of a serverless deployment.
+func TestRawComponentStatusMatchesServerless(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = netv1.AddToScheme(scheme)
+	_ = v1beta1.AddToScheme(scheme)
+
+	components := map[v1beta1.ComponentType]string{
+		v1beta1.PredictorComponent:   constants.PredictorServiceName("my-model"),
+		v1beta1.TransformerComponent: constants.TransformerServiceName("my-model"),
+		v1beta1.ExplainerComponent:   constants.ExplainerServiceName("my-model"),
+	}
+	newInferenceService := func() *v1beta1.InferenceService {
+		return &v1beta1.InferenceService{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-model", Namespace: "default", UID: "123"},
+			Spec: v1beta1.InferenceServiceSpec{
+				Transformer: &v1beta1.TransformerSpec{},
+				Explainer:   &v1beta1.ExplainerSpec{},
+			},
+		}
+	}
+
+	serverless := newInferenceService()
+	for component, serviceName := range components {
+		serverless.Status.PropagateStatus(component, &knservingv1.ServiceStatus{
+			Status: duckv1.Status{
+				Conditions: duckv1.Conditions{{Type: knservingv1.ServiceConditionReady, Status: corev1.ConditionTrue}},
+			},
+			RouteStatusFields: knservingv1.RouteStatusFields{
+				URL: &apis.URL{Scheme: "http", Host: serviceName + "-default.example.com"},
+				Address: &duckv1.Addressable{
+					URL: &apis.URL{Scheme: "http", Host: network.GetServiceHostname(serviceName, "default")},
+				},
+			},
+		})
+	}
+
+	raw := newInferenceService()
+	var services []client.Object
+	for component, serviceName := range components {
+		deployment := &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: serviceName, Namespace: "default"},
+			Status: appsv1.DeploymentStatus{
+				Conditions: []appsv1.DeploymentCondition{{Type: appsv1.DeploymentAvailable, Status: corev1.ConditionTrue}},
+			},
+		}
+		raw.Status.PropagateRawStatus(component, deployment, &apis.URL{Scheme: "http", Host: serviceName + "-default.example.com"})
+		services = append(services, &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: serviceName, Namespace: "default"}})
+	}
+	ingressConfig := &v1beta1.IngressConfig{
+		IngressDomain:  "example.com",
+		DomainTemplate: "{{ .Name }}-{{ .Namespace }}.{{ .IngressDomain }}",
+		UrlScheme:      "http",
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(services...).Build()
+	reconciler, _ := NewRawIngressReconciler(c, scheme, ingressConfig)
+	assert.NoError(t, reconciler.Reconcile(raw))
+
+	for component := range components {
+		serverlessStatus := serverless.Status.Components[component]
+		rawStatus := raw.Status.Components[component]
+		assert.Equal(t, serverlessStatus.URL, rawStatus.URL, component)
+		assert.Equal(t, serverlessStatus.Address, rawStatus.Address, component)
+	}
+}
@@ -0,0 +1,203 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"knative.dev/pkg/apis"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	gatewayapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayapiv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+// getGrpcServicePort returns the port of the service which serves gRPC over h2c, nil if there is none
+func getGrpcServicePort(service *corev1.Service) *int32 {
+	for _, port := range service.Spec.Ports {
+		if port.Name == constants.GrpcPortName ||
+			(port.AppProtocol != nil && *port.AppProtocol == constants.H2CAppProtocol) {
+			return &port.Port
+		}
+	}
+	return nil
+}
+
+// generateGrpcHost returns the host of the gRPC endpoint of the InferenceService, which is separate from the host
+// of the REST endpoint as a Gateway does not attach an HTTPRoute and a GRPCRoute with the same hostname.
+func generateGrpcHost(isvc *v1beta1.InferenceService, ingressConfig *v1beta1.IngressConfig) (string, error) {
+	return GenerateDomainName(isvc.Name+"-grpc", isvc.ObjectMeta, ingressConfig)
+}
+
+func createRawGRPCRoute(isvc *v1beta1.InferenceService, ingressConfig *v1beta1.IngressConfig,
+	predictorService *corev1.Service, port int32) (*gatewayapiv1alpha2.GRPCRoute, error) {
+	host, err := generateGrpcHost(isvc, ingressConfig)
+	if err != nil {
+		return nil, err
+	}
+	parentGroup := gatewayapiv1.Group(gatewayapiv1.GroupName)
+	parentKind := gatewayapiv1.Kind("Gateway")
+	parentNamespace := gatewayapiv1.Namespace(ingressConfig.GatewayNamespace)
+	backendGroup := gatewayapiv1.Group("")
+	backendKind := gatewayapiv1.Kind("Service")
+	backendPort := gatewayapiv1.PortNumber(port)
+	weight := int32(1)
+	objectMeta := generateMetadata(isvc, constants.Predictor, isvc.Name)
+	return &gatewayapiv1alpha2.GRPCRoute{
+		ObjectMeta: objectMeta,
+		Spec: gatewayapiv1alpha2.GRPCRouteSpec{
+			CommonRouteSpec: gatewayapiv1.CommonRouteSpec{
+				ParentRefs: []gatewayapiv1.ParentReference{
+					{
+						Group:     &parentGroup,
+						Kind:      &parentKind,
+						Name:      gatewayapiv1.ObjectName(ingressConfig.GatewayName),
+						Namespace: &parentNamespace,
+					},
+				},
+			},
+			Hostnames: []gatewayapiv1.Hostname{gatewayapiv1.Hostname(host)},
+			Rules: []gatewayapiv1alpha2.GRPCRouteRule{
+				{
+					BackendRefs: []gatewayapiv1alpha2.GRPCBackendRef{
+						{
+							BackendRef: gatewayapiv1.BackendRef{
+								BackendObjectReference: gatewayapiv1.BackendObjectReference{
+									Group: &backendGroup,
+									Kind:  &backendKind,
+									Name:  gatewayapiv1.ObjectName(predictorService.Name),
+									Port:  &backendPort,
+								},
+								Weight: &weight,
+							},
+						},
+					},
+				},
+			},
+		},
+	}, nil
+}
+
+// setPredictorGrpcURL sets the gRPC endpoint in the status of the predictor component
+func setPredictorGrpcURL(isvc *v1beta1.InferenceService, url *apis.URL) {
+	if isvc.Status.Components == nil {
+		isvc.Status.Components = make(map[v1beta1.ComponentType]v1beta1.ComponentStatusSpec)
+	}
+	statusSpec := isvc.Status.Components[v1beta1.PredictorComponent]
+	statusSpec.GrpcURL = url
+	isvc.Status.Components[v1beta1.PredictorComponent] = statusSpec
+}
+
+// reconcileGRPCRoute creates or updates the GRPCRoute of the InferenceService when the predictor service exposes
+// an h2c port and deletes it otherwise. The gRPC url of the predictor is the url of the GRPCRoute, it is left to the
+// cluster local gRPC url when there is no GRPCRoute. It returns the GRPCRoute as found in the cluster, nil when there
+// is none.
+func (r *RawIngressReconciler) reconcileGRPCRoute(isvc *v1beta1.InferenceService) (*gatewayapiv1alpha2.GRPCRoute, error) {
+	predictorService := &corev1.Service{}
+	err := r.client.Get(context.TODO(), types.NamespacedName{Name: constants.DefaultPredictorServiceName(isvc.GeneratedNamePrefix()), Namespace: isvc.Namespace}, predictorService)
+	if apierr.IsNotFound(err) {
+		err = r.client.Get(context.TODO(), types.NamespacedName{Name: constants.PredictorServiceName(isvc.GeneratedNamePrefix()), Namespace: isvc.Namespace}, predictorService)
+	}
+	if err != nil && !apierr.IsNotFound(err) {
+		return nil, err
+	}
+	var port *int32
+	if err == nil {
+		port = getGrpcServicePort(predictorService)
+	}
+
+	existing := &gatewayapiv1alpha2.GRPCRoute{}
+	getErr := r.client.Get(context.TODO(), types.NamespacedName{Namespace: isvc.Namespace, Name: isvc.Name}, existing)
+	if getErr != nil && !apierr.IsNotFound(getErr) {
+		if meta.IsNoMatchError(getErr) || runtime.IsNotRegisteredError(getErr) {
+			if port != nil {
+				log.Info("The GRPCRoute CRD is not available, the gRPC endpoint is not exposed", "isvc", isvc.Name)
+			}
+			return nil, nil
+		}
+		return nil, getErr
+	}
+	if port == nil {
+		if getErr == nil && metav1.IsControlledBy(existing, isvc) {
+			log.Info("deleting GRPCRoute", "namespace", existing.Namespace, "name", existing.Name)
+			if err := r.client.Delete(context.TODO(), existing); err != nil && !apierr.IsNotFound(err) {
+				return nil, err
+			}
+		}
+		return nil, nil
+	}
+
+	desired, err := createRawGRPCRoute(isvc, r.ingressConfig, predictorService, *port)
+	if err != nil {
+		return nil, err
+	}
+	if err := controllerutil.SetControllerReference(isvc, desired, r.scheme); err != nil {
+		return nil, err
+	}
+	route := existing
+	if apierr.IsNotFound(getErr) {
+		log.Info("creating GRPCRoute", "namespace", desired.Namespace, "name", desired.Name)
+		if err := r.client.Create(context.TODO(), desired); err != nil {
+			return nil, err
+		}
+		route = desired
+	} else if !equality.Semantic.DeepEqual(desired.Spec, existing.Spec) ||
+		!equality.Semantic.DeepEqual(desired.Labels, existing.Labels) ||
+		!equality.Semantic.DeepEqual(desired.Annotations, existing.Annotations) {
+		deepCopy := existing.DeepCopy()
+		deepCopy.Spec = desired.Spec
+		deepCopy.Labels = desired.Labels
+		deepCopy.Annotations = desired.Annotations
+		log.Info("updating GRPCRoute", "namespace", desired.Namespace, "name", desired.Name)
+		if err := r.client.Update(context.TODO(), deepCopy); err != nil {
+			return nil, err
+		}
+	}
+	setPredictorGrpcURL(isvc, &apis.URL{
+		Scheme: r.ingressConfig.UrlScheme,
+		Host:   string(desired.Spec.Hostnames[0]),
+	})
+	return route, nil
+}
+
+// deleteGRPCRoute deletes the GRPCRoute of the InferenceService and clears the gRPC endpoint of the predictor
+func (r *RawIngressReconciler) deleteGRPCRoute(isvc *v1beta1.InferenceService) error {
+	existing := &gatewayapiv1alpha2.GRPCRoute{}
+	err := r.client.Get(context.TODO(), types.NamespacedName{Namespace: isvc.Namespace, Name: isvc.Name}, existing)
+	if err != nil && !apierr.IsNotFound(err) && !meta.IsNoMatchError(err) && !runtime.IsNotRegisteredError(err) {
+		return err
+	}
+	if err == nil && metav1.IsControlledBy(existing, isvc) {
+		log.Info("deleting GRPCRoute", "namespace", existing.Namespace, "name", existing.Name)
+		if err := r.client.Delete(context.TODO(), existing); err != nil && !apierr.IsNotFound(err) {
+			return err
+		}
+	}
+	if _, ok := isvc.Status.Components[v1beta1.PredictorComponent]; ok {
+		setPredictorGrpcURL(isvc, nil)
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	netv1 "k8s.io/api/networking/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"knative.dev/pkg/apis"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gatewayapiv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+func TestRawIngressReconcilerGRPCRoute(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = netv1.AddToScheme(scheme)
+	_ = v1beta1.AddToScheme(scheme)
+	_ = gatewayapiv1.AddToScheme(scheme)
+	_ = gatewayapiv1alpha2.AddToScheme(scheme)
+
+	isvc := &v1beta1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-model",
+			Namespace: "default",
+			UID:       "123",
+		},
+	}
+	isvc.Status.SetCondition(v1beta1.PredictorReady, &apis.Condition{Type: v1beta1.PredictorReady, Status: corev1.ConditionTrue})
+	h2c := constants.H2CAppProtocol
+	predictorService := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      constants.PredictorServiceName("my-model"),
+			Namespace: "default",
+		},
+		Spec: corev1.ServiceSpec{
+			Ports: []corev1.ServicePort{
+				{Name: "http", Port: constants.CommonDefaultHttpPort},
+				{Name: constants.GrpcPortName, Port: 9000, AppProtocol: &h2c},
+			},
+		},
+	}
+	ingressConfig := &v1beta1.IngressConfig{
+		IngressDomain:    "example.com",
+		DomainTemplate:   "{{ .Name }}-{{ .Namespace }}.{{ .IngressDomain }}",
+		UrlScheme:        "http",
+		EnableGatewayAPI: true,
+		GatewayName:      "kserve-ingress-gateway",
+		GatewayNamespace: "kserve",
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(predictorService).Build()
+	reconciler, _ := NewRawIngressReconciler(c, scheme, ingressConfig)
+
+	err := reconciler.Reconcile(isvc)
+	assert.NoError(t, err)
+	grpcRoute := &gatewayapiv1alpha2.GRPCRoute{}
+	err = c.Get(context.TODO(), types.NamespacedName{Name: "my-model", Namespace: "default"}, grpcRoute)
+	assert.NoError(t, err)
+	assert.Equal(t, []gatewayapiv1.Hostname{"my-model-grpc-default.example.com"}, grpcRoute.Spec.Hostnames)
+	backend := grpcRoute.Spec.Rules[0].BackendRefs[0]
+	assert.Equal(t, gatewayapiv1.ObjectName(constants.PredictorServiceName("my-model")), backend.Name)
+	assert.Equal(t, gatewayapiv1.PortNumber(9000), *backend.Port)
+	assert.Equal(t, "http://my-model-grpc-default.example.com", isvc.Status.Components[v1beta1.PredictorComponent].GrpcURL.String())
+	assert.Equal(t, "http://my-model-default.example.com", isvc.Status.URL.String())
+
+	// the grpc route is removed with the h2c port of the predictor
+	predictorService.Spec.Ports = predictorService.Spec.Ports[:1]
+	assert.NoError(t, c.Update(context.TODO(), predictorService))
+	err = reconciler.Reconcile(isvc)
+	assert.NoError(t, err)
+	err = c.Get(context.TODO(), types.NamespacedName{Name: "my-model", Namespace: "default"}, grpcRoute)
+	assert.True(t, apierr.IsNotFound(err))
+	assert.Nil(t, isvc.Status.Components[v1beta1.PredictorComponent].GrpcURL)
+}
@@ -0,0 +1,372 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"knative.dev/pkg/apis"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	gatewayapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+const (
+	// explainPathRegex matches the v1 protocol explain requests which are routed to the explainer
+	explainPathRegex = `^/v1/models/[\w-]+:explain$`
+)
+
+// httpRouteBackend is a kubernetes service of an InferenceService component an HTTPRoute rule sends traffic to
+type httpRouteBackend struct {
+	serviceName string
+	timeout     *int64
+}
+
+func createHTTPRouteRule(path string, pathMatchType gatewayapiv1.PathMatchType, backend httpRouteBackend) gatewayapiv1.HTTPRouteRule {
+	group := gatewayapiv1.Group("")
+	kind := gatewayapiv1.Kind("Service")
+	port := gatewayapiv1.PortNumber(constants.CommonDefaultHttpPort)
+	weight := int32(1)
+	rule := gatewayapiv1.HTTPRouteRule{
+		Matches: []gatewayapiv1.HTTPRouteMatch{
+			{
+				Path: &gatewayapiv1.HTTPPathMatch{
+					Type:  &pathMatchType,
+					Value: &path,
+				},
+			},
+		},
+		BackendRefs: []gatewayapiv1.HTTPBackendRef{
+			{
+				BackendRef: gatewayapiv1.BackendRef{
+					BackendObjectReference: gatewayapiv1.BackendObjectReference{
+						Group: &group,
+						Kind:  &kind,
+						Name:  gatewayapiv1.ObjectName(backend.serviceName),
+						Port:  &port,
+					},
+					Weight: &weight,
+				},
+			},
+		},
+	}
+	if backend.timeout != nil {
+		timeout := gatewayapiv1.Duration(fmt.Sprintf("%ds", *backend.timeout))
+		rule.Timeouts = &gatewayapiv1.HTTPRouteTimeouts{Request: &timeout}
+	}
+	return rule
+}
+
+func createHTTPRoute(ingressConfig *v1beta1.IngressConfig, objectMeta metav1.ObjectMeta,
+	host string, rules []gatewayapiv1.HTTPRouteRule) *gatewayapiv1.HTTPRoute {
+	group := gatewayapiv1.Group(gatewayapiv1.GroupName)
+	kind := gatewayapiv1.Kind("Gateway")
+	namespace := gatewayapiv1.Namespace(ingressConfig.GatewayNamespace)
+	return &gatewayapiv1.HTTPRoute{
+		ObjectMeta: objectMeta,
+		Spec: gatewayapiv1.HTTPRouteSpec{
+			CommonRouteSpec: gatewayapiv1.CommonRouteSpec{
+				ParentRefs: []gatewayapiv1.ParentReference{
+					{
+						Group:     &group,
+						Kind:      &kind,
+						Name:      gatewayapiv1.ObjectName(ingressConfig.GatewayName),
+						Namespace: &namespace,
+					},
+				},
+			},
+			Hostnames: []gatewayapiv1.Hostname{gatewayapiv1.Hostname(host)},
+			Rules:     rules,
+		},
+	}
+}
+
+// createRawHTTPRoutes returns the top level HTTPRoute of the InferenceService, which routes :explain requests to the
+// explainer and the other requests to the transformer or the predictor, and a HTTPRoute per component.
+func createRawHTTPRoutes(isvc *v1beta1.InferenceService, ingressConfig *v1beta1.IngressConfig,
+	client client.Client) ([]*gatewayapiv1.HTTPRoute, error) {
+	if !isvc.Status.IsConditionReady(v1beta1.PredictorReady) {
+		isvc.Status.SetCondition(v1beta1.IngressReady, &apis.Condition{
+			Type:    v1beta1.IngressReady,
+			Status:  corev1.ConditionFalse,
+			Reason:  v1beta1.IngressNotReady,
+			Message: "Predictor ingress not created",
+		})
+		return nil, nil
+	}
+	existing := &corev1.Service{}
+	useDefault := client.Get(context.TODO(), types.NamespacedName{Name: constants.DefaultPredictorServiceName(isvc.GeneratedNamePrefix()), Namespace: isvc.Namespace}, existing) == nil
+
+	type componentRoute struct {
+		componentType constants.InferenceServiceComponent
+		backend       httpRouteBackend
+	}
+	predictor := componentRoute{constants.Predictor, httpRouteBackend{constants.PredictorServiceName(isvc.GeneratedNamePrefix()), isvc.Spec.Predictor.TimeoutSeconds}}
+	if useDefault {
+		predictor.backend.serviceName = constants.DefaultPredictorServiceName(isvc.GeneratedNamePrefix())
+	}
+	components := []componentRoute{predictor}
+	topLevel := predictor
+	if isvc.Spec.Transformer != nil {
+		if !isvc.Status.IsConditionReady(v1beta1.TransformerReady) {
+			isvc.Status.SetCondition(v1beta1.IngressReady, &apis.Condition{
+				Type:    v1beta1.IngressReady,
+				Status:  corev1.ConditionFalse,
+				Reason:  v1beta1.IngressNotReady,
+				Message: "Transformer ingress not created",
+			})
+			return nil, nil
+		}
+		transformer := componentRoute{constants.Transformer, httpRouteBackend{constants.TransformerServiceName(isvc.GeneratedNamePrefix()), isvc.Spec.Transformer.TimeoutSeconds}}
+		if useDefault {
+			transformer.backend.serviceName = constants.DefaultTransformerServiceName(isvc.GeneratedNamePrefix())
+		}
+		components = append(components, transformer)
+		topLevel = transformer
+	}
+	var explainer *componentRoute
+	if isvc.Spec.Explainer != nil {
+		if !isvc.Status.IsConditionReady(v1beta1.ExplainerReady) {
+			isvc.Status.SetCondition(v1beta1.IngressReady, &apis.Condition{
+				Type:    v1beta1.IngressReady,
+				Status:  corev1.ConditionFalse,
+				Reason:  v1beta1.IngressNotReady,
+				Message: "Explainer ingress not created",
+			})
+			return nil, nil
+		}
+		explainer = &componentRoute{constants.Explainer, httpRouteBackend{constants.ExplainerServiceName(isvc.GeneratedNamePrefix()), isvc.Spec.Explainer.TimeoutSeconds}}
+		if useDefault {
+			explainer.backend.serviceName = constants.DefaultExplainerServiceName(isvc.GeneratedNamePrefix())
+		}
+		components = append(components, *explainer)
+	}
+
+	host, err := generateIngressHost(ingressConfig, isvc, string(topLevel.componentType), true, topLevel.backend.serviceName)
+	if err != nil {
+		return nil, fmt.Errorf("failed creating top level %s ingress host: %w", topLevel.componentType, err)
+	}
+	var rules []gatewayapiv1.HTTPRouteRule
+	if explainer != nil {
+		rules = append(rules, createHTTPRouteRule(explainPathRegex, gatewayapiv1.PathMatchRegularExpression, explainer.backend))
+	}
+	rules = append(rules, createHTTPRouteRule("/", gatewayapiv1.PathMatchPathPrefix, topLevel.backend))
+	topLevelMeta := generateMetadata(isvc, topLevel.componentType, isvc.Name)
+	delete(topLevelMeta.Labels, constants.KServiceComponentLabel)
+	topLevelRoute := createHTTPRoute(ingressConfig, topLevelMeta, host, rules)
+	for _, additionalHost := range getRawAdditionalHosts(isvc, ingressConfig, host) {
+		topLevelRoute.Spec.Hostnames = append(topLevelRoute.Spec.Hostnames, gatewayapiv1.Hostname(additionalHost))
+	}
+	routes := []*gatewayapiv1.HTTPRoute{topLevelRoute}
+	if ingressConfig.PathTemplate != "" {
+		pathRoute, err := createPathBasedHTTPRoute(isvc, ingressConfig, topLevel.componentType, topLevel.backend)
+		if err != nil {
+			return nil, err
+		}
+		routes = append(routes, pathRoute)
+	}
+
+	for _, component := range components {
+		componentHost, err := generateIngressHost(ingressConfig, isvc, string(component.componentType), false, component.backend.serviceName)
+		if err != nil {
+			return nil, fmt.Errorf("failed creating %s ingress host: %w", component.componentType, err)
+		}
+		routes = append(routes, createHTTPRoute(ingressConfig,
+			generateMetadata(isvc, component.componentType, component.backend.serviceName), componentHost,
+			[]gatewayapiv1.HTTPRouteRule{createHTTPRouteRule("/", gatewayapiv1.PathMatchPathPrefix, component.backend)}))
+	}
+
+	cors, err := v1beta1.GetCorsConfig(isvc.Annotations, ingressConfig.Cors)
+	if err != nil {
+		return nil, err
+	}
+	if corsFilter := createCorsHeaderFilter(cors); corsFilter != nil {
+		for _, route := range routes {
+			for i := range route.Spec.Rules {
+				route.Spec.Rules[i].Filters = append(route.Spec.Rules[i].Filters, *corsFilter)
+			}
+		}
+	}
+	return routes, nil
+}
+
+// createPathBasedHTTPRoute returns the HTTPRoute exposing the InferenceService on the ingress domain under the path
+// generated from the pathTemplate, so that multiple InferenceServices share the same host. The path prefix is
+// stripped before the request is forwarded to the transformer or the predictor.
+func createPathBasedHTTPRoute(isvc *v1beta1.InferenceService, ingressConfig *v1beta1.IngressConfig,
+	componentType constants.InferenceServiceComponent, backend httpRouteBackend) (*gatewayapiv1.HTTPRoute, error) {
+	path, err := GenerateUrlPath(isvc.Name, isvc.Namespace, ingressConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed generating url path from pathTemplate: %w", err)
+	}
+	rule := createHTTPRouteRule(strings.TrimSuffix(path, "/"), gatewayapiv1.PathMatchPathPrefix, backend)
+	replacePrefixMatch := "/"
+	rule.Filters = []gatewayapiv1.HTTPRouteFilter{
+		{
+			Type: gatewayapiv1.HTTPRouteFilterURLRewrite,
+			URLRewrite: &gatewayapiv1.HTTPURLRewriteFilter{
+				Path: &gatewayapiv1.HTTPPathModifier{
+					Type:               gatewayapiv1.PrefixMatchHTTPPathModifier,
+					ReplacePrefixMatch: &replacePrefixMatch,
+				},
+			},
+		},
+	}
+	objectMeta := generateMetadata(isvc, componentType, pathBasedHTTPRouteName(isvc.Name))
+	delete(objectMeta.Labels, constants.KServiceComponentLabel)
+	return createHTTPRoute(ingressConfig, objectMeta, ingressConfig.IngressDomain, []gatewayapiv1.HTTPRouteRule{rule}), nil
+}
+
+func pathBasedHTTPRouteName(name string) string {
+	return name + "-path"
+}
+
+// createRawPathBasedURL returns the url of the InferenceService on the ingress domain under the path generated from
+// the pathTemplate
+func createRawPathBasedURL(isvc *v1beta1.InferenceService, ingressConfig *v1beta1.IngressConfig) (*apis.URL, error) {
+	path, err := GenerateUrlPath(isvc.Name, isvc.Namespace, ingressConfig)
+	if err != nil {
+		return nil, err
+	}
+	return &apis.URL{
+		Scheme: ingressConfig.UrlScheme,
+		Host:   ingressConfig.IngressDomain,
+		Path:   path,
+	}, nil
+}
+
+func semanticHTTPRouteEquals(desired, existing *gatewayapiv1.HTTPRoute) bool {
+	return equality.Semantic.DeepEqual(desired.Spec, existing.Spec) &&
+		equality.Semantic.DeepEqual(desired.Labels, existing.Labels) &&
+		equality.Semantic.DeepEqual(desired.Annotations, existing.Annotations)
+}
+
+// isRouteStatusAccepted returns whether all the parent Gateways of a route accepted it
+func isRouteStatusAccepted(status gatewayapiv1.RouteStatus) bool {
+	if len(status.Parents) == 0 {
+		return false
+	}
+	for _, parent := range status.Parents {
+		if !meta.IsStatusConditionTrue(parent.Conditions, string(gatewayapiv1.RouteConditionAccepted)) {
+			return false
+		}
+	}
+	return true
+}
+
+// isHTTPRouteAccepted returns whether all the parent Gateways of the HTTPRoutes accepted them
+func isHTTPRouteAccepted(routes []*gatewayapiv1.HTTPRoute) bool {
+	for _, route := range routes {
+		if !isRouteStatusAccepted(route.Status.RouteStatus) {
+			return false
+		}
+	}
+	return true
+}
+
+// reconcileHTTPRoutes creates or updates the HTTPRoutes of the InferenceService and deletes the ones of removed
+// components. It returns the HTTPRoutes as found in the cluster, nil when the components are not ready yet.
+func (r *RawIngressReconciler) reconcileHTTPRoutes(isvc *v1beta1.InferenceService) ([]*gatewayapiv1.HTTPRoute, error) {
+	desiredRoutes, err := createRawHTTPRoutes(isvc, r.ingressConfig, r.client)
+	if desiredRoutes == nil || err != nil {
+		return nil, err
+	}
+	var routes []*gatewayapiv1.HTTPRoute
+	desiredNames := map[string]bool{}
+	for _, desired := range desiredRoutes {
+		desiredNames[desired.Name] = true
+		if err := controllerutil.SetControllerReference(isvc, desired, r.scheme); err != nil {
+			return nil, err
+		}
+		existing := &gatewayapiv1.HTTPRoute{}
+		err := r.client.Get(context.TODO(), types.NamespacedName{Namespace: desired.Namespace, Name: desired.Name}, existing)
+		if err != nil {
+			if !apierr.IsNotFound(err) {
+				return nil, err
+			}
+			log.Info("creating HTTPRoute", "namespace", desired.Namespace, "name", desired.Name)
+			if err := r.client.Create(context.TODO(), desired); err != nil {
+				return nil, err
+			}
+			routes = append(routes, desired)
+			continue
+		}
+		if !semanticHTTPRouteEquals(desired, existing) {
+			deepCopy := existing.DeepCopy()
+			deepCopy.Spec = desired.Spec
+			deepCopy.Labels = desired.Labels
+			deepCopy.Annotations = desired.Annotations
+			log.Info("updating HTTPRoute", "namespace", desired.Namespace, "name", desired.Name)
+			if err := r.client.Update(context.TODO(), deepCopy); err != nil {
+				return nil, err
+			}
+		}
+		routes = append(routes, existing)
+	}
+
+	existingRoutes := &gatewayapiv1.HTTPRouteList{}
+	if err := r.client.List(context.TODO(), existingRoutes, client.InNamespace(isvc.Namespace),
+		client.MatchingLabels{constants.InferenceServicePodLabelKey: isvc.Name}); err != nil {
+		return nil, err
+	}
+	for i := range existingRoutes.Items {
+		existing := &existingRoutes.Items[i]
+		if desiredNames[existing.Name] || !metav1.IsControlledBy(existing, isvc) {
+			continue
+		}
+		log.Info("deleting HTTPRoute", "namespace", existing.Namespace, "name", existing.Name)
+		if err := r.client.Delete(context.TODO(), existing); err != nil && !apierr.IsNotFound(err) {
+			return nil, err
+		}
+	}
+	return routes, nil
+}
+
+// deleteGatewayRoutes deletes the HTTPRoutes and the GRPCRoute of the InferenceService when it is no longer exposed by
+// the Gateway API. The routes are ignored when their CRDs are not installed.
+func (r *RawIngressReconciler) deleteGatewayRoutes(isvc *v1beta1.InferenceService) error {
+	routes := &gatewayapiv1.HTTPRouteList{}
+	err := r.client.List(context.TODO(), routes, client.InNamespace(isvc.Namespace),
+		client.MatchingLabels{constants.InferenceServicePodLabelKey: isvc.Name})
+	if err != nil && !meta.IsNoMatchError(err) && !runtime.IsNotRegisteredError(err) {
+		return err
+	}
+	for i := range routes.Items {
+		route := &routes.Items[i]
+		if !metav1.IsControlledBy(route, isvc) {
+			continue
+		}
+		log.Info("deleting HTTPRoute", "namespace", route.Namespace, "name", route.Name)
+		if err := r.client.Delete(context.TODO(), route); err != nil && !apierr.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return r.deleteGRPCRoute(isvc)
+}
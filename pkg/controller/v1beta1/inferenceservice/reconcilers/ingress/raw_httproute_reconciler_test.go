@@ -0,0 +1,276 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	netv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"knative.dev/pkg/apis"
+	duckv1 "knative.dev/pkg/apis/duck/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	gatewayapiv1 "sigs.k8s.io/gateway-api/apis/v1"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+func TestRawIngressReconcilerHTTPRoutes(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = netv1.AddToScheme(scheme)
+	_ = v1beta1.AddToScheme(scheme)
+	_ = gatewayapiv1.AddToScheme(scheme)
+
+	timeout := int64(60)
+	isvc := &v1beta1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-model",
+			Namespace: "default",
+			UID:       "123",
+		},
+		Spec: v1beta1.InferenceServiceSpec{
+			Predictor: v1beta1.PredictorSpec{
+				ComponentExtensionSpec: v1beta1.ComponentExtensionSpec{TimeoutSeconds: &timeout},
+			},
+			Transformer: &v1beta1.TransformerSpec{},
+			Explainer:   &v1beta1.ExplainerSpec{},
+		},
+	}
+	for _, condition := range []apis.ConditionType{v1beta1.PredictorReady, v1beta1.TransformerReady, v1beta1.ExplainerReady} {
+		isvc.Status.SetCondition(condition, &apis.Condition{Type: condition, Status: corev1.ConditionTrue})
+	}
+	ingressConfig := &v1beta1.IngressConfig{
+		IngressDomain:    "example.com",
+		DomainTemplate:   "{{ .Name }}-{{ .Namespace }}.{{ .IngressDomain }}",
+		UrlScheme:        "http",
+		EnableGatewayAPI: true,
+		GatewayName:      "kserve-ingress-gateway",
+		GatewayNamespace: "kserve",
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).Build()
+	reconciler, _ := NewRawIngressReconciler(c, scheme, ingressConfig)
+
+	err := reconciler.Reconcile(isvc)
+	assert.NoError(t, err)
+	assert.Equal(t, "http://my-model-default.example.com", isvc.Status.URL.String())
+	assert.Equal(t, v1beta1.IngressNotReady, isvc.Status.GetCondition(v1beta1.IngressReady).Reason)
+	assert.Equal(t, "HTTPRoutes are not accepted by the gateway yet", isvc.Status.GetCondition(v1beta1.IngressReady).Message)
+
+	routes := &gatewayapiv1.HTTPRouteList{}
+	err = c.List(context.TODO(), routes, client.InNamespace("default"))
+	assert.NoError(t, err)
+	assert.Len(t, routes.Items, 4)
+
+	topLevel := &gatewayapiv1.HTTPRoute{}
+	err = c.Get(context.TODO(), types.NamespacedName{Name: "my-model", Namespace: "default"}, topLevel)
+	assert.NoError(t, err)
+	assert.Equal(t, []gatewayapiv1.Hostname{"my-model-default.example.com"}, topLevel.Spec.Hostnames)
+	assert.Equal(t, gatewayapiv1.ObjectName("kserve-ingress-gateway"), topLevel.Spec.ParentRefs[0].Name)
+	assert.Equal(t, gatewayapiv1.Namespace("kserve"), *topLevel.Spec.ParentRefs[0].Namespace)
+	assert.Len(t, topLevel.Spec.Rules, 2)
+	assert.Equal(t, explainPathRegex, *topLevel.Spec.Rules[0].Matches[0].Path.Value)
+	assert.Equal(t, gatewayapiv1.ObjectName(constants.ExplainerServiceName("my-model")), topLevel.Spec.Rules[0].BackendRefs[0].Name)
+	assert.Equal(t, gatewayapiv1.ObjectName(constants.TransformerServiceName("my-model")), topLevel.Spec.Rules[1].BackendRefs[0].Name)
+
+	predictor := &gatewayapiv1.HTTPRoute{}
+	err = c.Get(context.TODO(), types.NamespacedName{Name: constants.PredictorServiceName("my-model"), Namespace: "default"}, predictor)
+	assert.NoError(t, err)
+	assert.Equal(t, []gatewayapiv1.Hostname{"my-model-predictor-default.example.com"}, predictor.Spec.Hostnames)
+	assert.Equal(t, gatewayapiv1.Duration("60s"), *predictor.Spec.Rules[0].Timeouts.Request)
+
+	// the ingress is ready once the gateway accepted all the routes
+	for i := range routes.Items {
+		route := &routes.Items[i]
+		route.Status.Parents = []gatewayapiv1.RouteParentStatus{
+			{
+				ParentRef:      route.Spec.ParentRefs[0],
+				ControllerName: "example.com/gateway-controller",
+				Conditions: []metav1.Condition{
+					{
+						Type:               string(gatewayapiv1.RouteConditionAccepted),
+						Status:             metav1.ConditionTrue,
+						Reason:             string(gatewayapiv1.RouteReasonAccepted),
+						LastTransitionTime: metav1.Now(),
+					},
+				},
+			},
+		}
+		assert.NoError(t, c.Update(context.TODO(), route))
+	}
+	err = reconciler.Reconcile(isvc)
+	assert.NoError(t, err)
+	assert.True(t, isvc.Status.IsConditionReady(v1beta1.IngressReady))
+
+	// removing the explainer deletes its route
+	isvc.Spec.Explainer = nil
+	err = reconciler.Reconcile(isvc)
+	assert.NoError(t, err)
+	err = c.List(context.TODO(), routes, client.InNamespace("default"))
+	assert.NoError(t, err)
+	assert.Len(t, routes.Items, 3)
+	err = c.Get(context.TODO(), types.NamespacedName{Name: "my-model", Namespace: "default"}, topLevel)
+	assert.NoError(t, err)
+	assert.Len(t, topLevel.Spec.Rules, 1)
+}
+
+func TestRawIngressReconcilerPathBasedHTTPRoutes(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = netv1.AddToScheme(scheme)
+	_ = v1beta1.AddToScheme(scheme)
+	_ = gatewayapiv1.AddToScheme(scheme)
+
+	ingressConfig := &v1beta1.IngressConfig{
+		IngressDomain:    "models.example.com",
+		DomainTemplate:   "{{ .Name }}-{{ .Namespace }}.{{ .IngressDomain }}",
+		UrlScheme:        "https",
+		EnableGatewayAPI: true,
+		GatewayName:      "kserve-ingress-gateway",
+		GatewayNamespace: "kserve",
+		PathTemplate:     "/serving/{{ .Namespace }}/{{ .Name }}",
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).Build()
+	reconciler, _ := NewRawIngressReconciler(c, scheme, ingressConfig)
+
+	testCases := map[string]struct {
+		isvc            *v1beta1.InferenceService
+		expectedURL     string
+		expectedBackend string
+	}{
+		"predictor": {
+			isvc: &v1beta1.InferenceService{
+				ObjectMeta: metav1.ObjectMeta{Name: "sklearn", Namespace: "team-a", UID: "123"},
+			},
+			expectedURL:     "https://models.example.com/serving/team-a/sklearn",
+			expectedBackend: constants.PredictorServiceName("sklearn"),
+		},
+		"transformer": {
+			isvc: &v1beta1.InferenceService{
+				ObjectMeta: metav1.ObjectMeta{Name: "torch", Namespace: "team-b", UID: "456"},
+				Spec:       v1beta1.InferenceServiceSpec{Transformer: &v1beta1.TransformerSpec{}},
+			},
+			expectedURL:     "https://models.example.com/serving/team-b/torch",
+			expectedBackend: constants.TransformerServiceName("torch"),
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			for _, condition := range []apis.ConditionType{v1beta1.PredictorReady, v1beta1.TransformerReady} {
+				tc.isvc.Status.SetCondition(condition, &apis.Condition{Type: condition, Status: corev1.ConditionTrue})
+			}
+			err := reconciler.Reconcile(tc.isvc)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedURL, tc.isvc.Status.URL.String())
+
+			route := &gatewayapiv1.HTTPRoute{}
+			err = c.Get(context.TODO(), types.NamespacedName{Name: tc.isvc.Name + "-path", Namespace: tc.isvc.Namespace}, route)
+			assert.NoError(t, err)
+			assert.Equal(t, []gatewayapiv1.Hostname{"models.example.com"}, route.Spec.Hostnames)
+			assert.Len(t, route.Spec.Rules, 1)
+			rule := route.Spec.Rules[0]
+			assert.Equal(t, gatewayapiv1.PathMatchPathPrefix, *rule.Matches[0].Path.Type)
+			assert.Equal(t, "/serving/"+tc.isvc.Namespace+"/"+tc.isvc.Name, *rule.Matches[0].Path.Value)
+			assert.Equal(t, gatewayapiv1.HTTPRouteFilterURLRewrite, rule.Filters[0].Type)
+			assert.Equal(t, "/", *rule.Filters[0].URLRewrite.Path.ReplacePrefixMatch)
+			assert.Equal(t, gatewayapiv1.ObjectName(tc.expectedBackend), rule.BackendRefs[0].Name)
+		})
+	}
+
+	// both InferenceServices share the ingress domain host
+	routes := &gatewayapiv1.HTTPRouteList{}
+	err := c.List(context.TODO(), routes)
+	assert.NoError(t, err)
+	sharedHostRoutes := 0
+	for _, route := range routes.Items {
+		if route.Spec.Hostnames[0] == "models.example.com" {
+			sharedHostRoutes++
+		}
+	}
+	assert.Equal(t, 2, sharedHostRoutes)
+}
+
+func TestRawIngressReconcilerAdditionalHosts(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = netv1.AddToScheme(scheme)
+	_ = v1beta1.AddToScheme(scheme)
+	_ = gatewayapiv1.AddToScheme(scheme)
+
+	isvc := &v1beta1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-model",
+			Namespace: "default",
+			UID:       "123",
+			Annotations: map[string]string{
+				constants.AdditionalHostsAnnotationKey: "my-model.lb.example.org, my-model-default.example.net,my-model-default.example.com",
+			},
+		},
+	}
+	isvc.Status.SetCondition(v1beta1.PredictorReady, &apis.Condition{Type: v1beta1.PredictorReady, Status: corev1.ConditionTrue})
+	ingressConfig := &v1beta1.IngressConfig{
+		IngressDomain:            "example.com",
+		AdditionalIngressDomains: &[]string{"example.net"},
+		DomainTemplate:           "{{ .Name }}-{{ .Namespace }}.{{ .IngressDomain }}",
+		UrlScheme:                "http",
+		GatewayName:              "kserve-ingress-gateway",
+		GatewayNamespace:         "kserve",
+	}
+	expectedAddresses := []duckv1.Addressable{
+		{URL: &apis.URL{Scheme: "http", Host: "my-model-default.example.com"}},
+		{URL: &apis.URL{Scheme: "http", Host: "my-model-default.example.net"}},
+		{URL: &apis.URL{Scheme: "http", Host: "my-model.lb.example.org"}},
+	}
+
+	c := fake.NewClientBuilder().WithScheme(scheme).Build()
+	reconciler, _ := NewRawIngressReconciler(c, scheme, ingressConfig)
+	assert.NoError(t, reconciler.Reconcile(isvc))
+	ingress := &netv1.Ingress{}
+	assert.NoError(t, c.Get(context.TODO(), types.NamespacedName{Name: "my-model", Namespace: "default"}, ingress))
+	var hosts []string
+	for _, rule := range ingress.Spec.Rules {
+		hosts = append(hosts, rule.Host)
+	}
+	assert.Equal(t, []string{"my-model-default.example.com", "my-model-predictor-default.example.com",
+		"my-model-default.example.net", "my-model.lb.example.org"}, hosts)
+	assert.Equal(t, ingress.Spec.Rules[0].HTTP, ingress.Spec.Rules[3].HTTP)
+	assert.Equal(t, expectedAddresses, isvc.Status.Addresses)
+
+	gatewayConfig := *ingressConfig
+	gatewayConfig.EnableGatewayAPI = true
+	reconciler, _ = NewRawIngressReconciler(c, scheme, &gatewayConfig)
+	assert.NoError(t, reconciler.Reconcile(isvc))
+	topLevel := &gatewayapiv1.HTTPRoute{}
+	assert.NoError(t, c.Get(context.TODO(), types.NamespacedName{Name: "my-model", Namespace: "default"}, topLevel))
+	assert.Equal(t, []gatewayapiv1.Hostname{"my-model-default.example.com", "my-model-default.example.net",
+		"my-model.lb.example.org"}, topLevel.Spec.Hostnames)
+	assert.Equal(t, expectedAddresses, isvc.Status.Addresses)
+
+	// a cluster local InferenceService is only addressed by its service
+	isvc.Labels = map[string]string{constants.NetworkVisibility: constants.ClusterLocalVisibility}
+	assert.NoError(t, reconciler.Reconcile(isvc))
+	assert.Equal(t, []duckv1.Addressable{
+		{URL: &apis.URL{Scheme: "http", Host: "my-model-predictor.default.svc.cluster.local"}},
+	}, isvc.Status.Addresses)
+}
@@ -0,0 +1,249 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"knative.dev/pkg/apis"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+// RouteGVK is the GroupVersionKind of the OpenShift Route, Routes are handled as unstructured objects so that the
+// controller does not depend on the OpenShift API.
+var RouteGVK = schema.GroupVersionKind{Group: "route.openshift.io", Version: "v1", Kind: constants.OpenShiftRouteKind}
+
+type routeSpec struct {
+	Host           string               `json:"host"`
+	To             routeTargetReference `json:"to"`
+	Port           *routePort           `json:"port,omitempty"`
+	TLS            *routeTLSConfig      `json:"tls,omitempty"`
+	WildcardPolicy string               `json:"wildcardPolicy"`
+}
+
+type routeTargetReference struct {
+	Kind   string `json:"kind"`
+	Name   string `json:"name"`
+	Weight int32  `json:"weight"`
+}
+
+type routePort struct {
+	TargetPort intstr.IntOrString `json:"targetPort"`
+}
+
+type routeTLSConfig struct {
+	Termination                   string `json:"termination"`
+	InsecureEdgeTerminationPolicy string `json:"insecureEdgeTerminationPolicy,omitempty"`
+}
+
+type routeStatus struct {
+	Ingress []routeIngress `json:"ingress,omitempty"`
+}
+
+type routeIngress struct {
+	Host       string                  `json:"host,omitempty"`
+	Conditions []routeIngressCondition `json:"conditions,omitempty"`
+}
+
+type routeIngressCondition struct {
+	Type   string                 `json:"type"`
+	Status corev1.ConditionStatus `json:"status"`
+}
+
+// getRouteTLSConfig returns the TLS configuration of the Route set by the route-tls-termination annotation, nil when
+// the Route does not terminate TLS. Plain http requests are redirected to https.
+func getRouteTLSConfig(isvc *v1beta1.InferenceService) *routeTLSConfig {
+	termination, ok := isvc.Annotations[constants.RouteTLSTerminationAnnotationKey]
+	if !ok || termination == "" {
+		return nil
+	}
+	return &routeTLSConfig{
+		Termination:                   termination,
+		InsecureEdgeTerminationPolicy: "Redirect",
+	}
+}
+
+// createRawRoute returns the OpenShift Route exposing the InferenceService on its top level host, which routes the
+// requests to the transformer or the predictor. It returns nil while the components are not ready.
+func createRawRoute(isvc *v1beta1.InferenceService, ingressConfig *v1beta1.IngressConfig,
+	client client.Client) (*unstructured.Unstructured, error) {
+	if !isvc.Status.IsConditionReady(v1beta1.PredictorReady) {
+		isvc.Status.SetCondition(v1beta1.IngressReady, &apis.Condition{
+			Type:    v1beta1.IngressReady,
+			Status:  corev1.ConditionFalse,
+			Reason:  v1beta1.IngressNotReady,
+			Message: "Predictor ingress not created",
+		})
+		return nil, nil
+	}
+	componentType := constants.Predictor
+	serviceName := constants.PredictorServiceName(isvc.GeneratedNamePrefix())
+	defaultServiceName := constants.DefaultPredictorServiceName(isvc.GeneratedNamePrefix())
+	if isvc.Spec.Transformer != nil {
+		if !isvc.Status.IsConditionReady(v1beta1.TransformerReady) {
+			isvc.Status.SetCondition(v1beta1.IngressReady, &apis.Condition{
+				Type:    v1beta1.IngressReady,
+				Status:  corev1.ConditionFalse,
+				Reason:  v1beta1.IngressNotReady,
+				Message: "Transformer ingress not created",
+			})
+			return nil, nil
+		}
+		componentType = constants.Transformer
+		serviceName = constants.TransformerServiceName(isvc.GeneratedNamePrefix())
+		defaultServiceName = constants.DefaultTransformerServiceName(isvc.GeneratedNamePrefix())
+	}
+	service := &corev1.Service{}
+	err := client.Get(context.TODO(), types.NamespacedName{Name: defaultServiceName, Namespace: isvc.Namespace}, service)
+	if apierr.IsNotFound(err) {
+		err = client.Get(context.TODO(), types.NamespacedName{Name: serviceName, Namespace: isvc.Namespace}, service)
+	}
+	if err != nil && !apierr.IsNotFound(err) {
+		return nil, err
+	}
+	spec := routeSpec{
+		To: routeTargetReference{
+			Kind:   "Service",
+			Name:   serviceName,
+			Weight: 100,
+		},
+		TLS:            getRouteTLSConfig(isvc),
+		WildcardPolicy: "None",
+	}
+	if err == nil {
+		spec.To.Name = service.Name
+		// the router sends the traffic to the target port of the http port of the service
+		for _, port := range service.Spec.Ports {
+			if port.Port == constants.CommonDefaultHttpPort {
+				spec.Port = &routePort{TargetPort: port.TargetPort}
+			}
+		}
+	}
+	spec.Host, err = generateIngressHost(ingressConfig, isvc, string(componentType), true, spec.To.Name)
+	if err != nil {
+		return nil, fmt.Errorf("failed creating top level %s route host: %w", componentType, err)
+	}
+	unstructuredSpec, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&spec)
+	if err != nil {
+		return nil, err
+	}
+	objectMeta := generateMetadata(isvc, componentType, isvc.Name)
+	delete(objectMeta.Labels, constants.KServiceComponentLabel)
+	route := &unstructured.Unstructured{Object: map[string]interface{}{"spec": unstructuredSpec}}
+	route.SetGroupVersionKind(RouteGVK)
+	route.SetName(objectMeta.Name)
+	route.SetNamespace(objectMeta.Namespace)
+	route.SetLabels(objectMeta.Labels)
+	route.SetAnnotations(objectMeta.Annotations)
+	return route, nil
+}
+
+// getRouteAdmittedHost returns the host the Route is admitted at by a router, an empty string while it is not admitted
+func getRouteAdmittedHost(route *unstructured.Unstructured) string {
+	status := routeStatus{}
+	if unstructuredStatus, ok := route.Object["status"].(map[string]interface{}); ok {
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(unstructuredStatus, &status); err != nil {
+			log.Error(err, "Failed to parse the Route status", "namespace", route.GetNamespace(), "name", route.GetName())
+			return ""
+		}
+	}
+	for _, ingress := range status.Ingress {
+		for _, condition := range ingress.Conditions {
+			if condition.Type == "Admitted" && condition.Status == corev1.ConditionTrue {
+				return ingress.Host
+			}
+		}
+	}
+	return ""
+}
+
+func semanticRouteEquals(desired, existing *unstructured.Unstructured) bool {
+	return equality.Semantic.DeepEqual(desired.Object["spec"], existing.Object["spec"]) &&
+		equality.Semantic.DeepEqual(desired.GetLabels(), existing.GetLabels()) &&
+		equality.Semantic.DeepEqual(desired.GetAnnotations(), existing.GetAnnotations())
+}
+
+// reconcileOpenShiftRoute creates or updates the Route of the InferenceService. It returns the Route as found in the
+// cluster, nil when the components are not ready yet.
+func (r *RawIngressReconciler) reconcileOpenShiftRoute(isvc *v1beta1.InferenceService) (*unstructured.Unstructured, error) {
+	desired, err := createRawRoute(isvc, r.ingressConfig, r.client)
+	if desired == nil || err != nil {
+		return nil, err
+	}
+	if err := controllerutil.SetControllerReference(isvc, desired, r.scheme); err != nil {
+		return nil, err
+	}
+	existing := &unstructured.Unstructured{}
+	existing.SetGroupVersionKind(RouteGVK)
+	err = r.client.Get(context.TODO(), types.NamespacedName{Namespace: desired.GetNamespace(), Name: desired.GetName()}, existing)
+	if err != nil {
+		if !apierr.IsNotFound(err) {
+			return nil, err
+		}
+		log.Info("creating Route", "namespace", desired.GetNamespace(), "name", desired.GetName())
+		if err := r.client.Create(context.TODO(), desired); err != nil {
+			return nil, err
+		}
+		return desired, nil
+	}
+	if !semanticRouteEquals(desired, existing) {
+		deepCopy := existing.DeepCopy()
+		deepCopy.Object["spec"] = desired.Object["spec"]
+		deepCopy.SetLabels(desired.GetLabels())
+		deepCopy.SetAnnotations(desired.GetAnnotations())
+		log.Info("updating Route", "namespace", desired.GetNamespace(), "name", desired.GetName())
+		if err := r.client.Update(context.TODO(), deepCopy); err != nil {
+			return nil, err
+		}
+		return deepCopy, nil
+	}
+	return existing, nil
+}
+
+// deleteOpenShiftRoute deletes the Route of the InferenceService when it is no longer exposed by a Route. The Route
+// is ignored when its CRD is not installed.
+func (r *RawIngressReconciler) deleteOpenShiftRoute(isvc *v1beta1.InferenceService) error {
+	existing := &unstructured.Unstructured{}
+	existing.SetGroupVersionKind(RouteGVK)
+	err := r.client.Get(context.TODO(), types.NamespacedName{Namespace: isvc.Namespace, Name: isvc.Name}, existing)
+	if err != nil {
+		if apierr.IsNotFound(err) || meta.IsNoMatchError(err) || runtime.IsNotRegisteredError(err) {
+			return nil
+		}
+		return err
+	}
+	if !metav1.IsControlledBy(existing, isvc) {
+		return nil
+	}
+	log.Info("deleting Route", "namespace", existing.GetNamespace(), "name", existing.GetName())
+	return client.IgnoreNotFound(r.client.Delete(context.TODO(), existing))
+}
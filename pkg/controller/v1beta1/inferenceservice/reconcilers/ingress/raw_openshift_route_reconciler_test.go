@@ -0,0 +1,191 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ingress
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	netv1 "k8s.io/api/networking/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"knative.dev/pkg/apis"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+func TestGetRouteAdmittedHost(t *testing.T) {
+	testCases := map[string]struct {
+		status   map[string]interface{}
+		expected string
+	}{
+		"noStatus": {
+			expected: "",
+		},
+		"notAdmitted": {
+			status: map[string]interface{}{
+				"ingress": []interface{}{
+					map[string]interface{}{
+						"host":       "my-model-default.example.com",
+						"conditions": []interface{}{map[string]interface{}{"type": "Admitted", "status": "False"}},
+					},
+				},
+			},
+			expected: "",
+		},
+		"admitted": {
+			status: map[string]interface{}{
+				"ingress": []interface{}{
+					map[string]interface{}{
+						"host":       "my-model-default.apps.example.com",
+						"conditions": []interface{}{map[string]interface{}{"type": "Admitted", "status": "True"}},
+					},
+				},
+			},
+			expected: "my-model-default.apps.example.com",
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			route := &unstructured.Unstructured{Object: map[string]interface{}{}}
+			if tc.status != nil {
+				route.Object["status"] = tc.status
+			}
+			assert.Equal(t, tc.expected, getRouteAdmittedHost(route))
+		})
+	}
+}
+
+func TestRawIngressReconcilerOpenShiftRoute(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = netv1.AddToScheme(scheme)
+	_ = v1beta1.AddToScheme(scheme)
+	scheme.AddKnownTypeWithName(RouteGVK, &unstructured.Unstructured{})
+	scheme.AddKnownTypeWithName(RouteGVK.GroupVersion().WithKind("RouteList"), &unstructured.UnstructuredList{})
+
+	isvc := &v1beta1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-model",
+			Namespace: "default",
+			UID:       "123",
+			Annotations: map[string]string{
+				constants.RouteTLSTerminationAnnotationKey: constants.RouteTLSTerminationEdge,
+			},
+		},
+	}
+	isvc.Status.SetCondition(v1beta1.PredictorReady, &apis.Condition{Type: v1beta1.PredictorReady, Status: corev1.ConditionTrue})
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: constants.PredictorServiceName("my-model"), Namespace: "default"},
+		Spec: corev1.ServiceSpec{
+			Ports: []corev1.ServicePort{{Port: constants.CommonDefaultHttpPort, TargetPort: intstr.FromInt(8080)}},
+		},
+	}
+	ingressConfig := &v1beta1.IngressConfig{
+		IngressDomain:  "example.com",
+		DomainTemplate: "{{ .Name }}-{{ .Namespace }}.{{ .IngressDomain }}",
+		UrlScheme:      "http",
+		IngressKind:    constants.OpenShiftRouteKind,
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(service).Build()
+	getRoute := func() *unstructured.Unstructured {
+		route := &unstructured.Unstructured{}
+		route.SetGroupVersionKind(RouteGVK)
+		assert.NoError(t, c.Get(context.TODO(), types.NamespacedName{Name: "my-model", Namespace: "default"}, route))
+		return route
+	}
+
+	// create
+	reconciler, _ := NewRawIngressReconciler(c, scheme, ingressConfig)
+	assert.NoError(t, reconciler.Reconcile(isvc))
+	route := getRoute()
+	assert.True(t, metav1.IsControlledBy(route, isvc))
+	assert.Equal(t, map[string]interface{}{
+		"host":           "my-model-default.example.com",
+		"to":             map[string]interface{}{"kind": "Service", "name": "my-model-predictor", "weight": int64(100)},
+		"port":           map[string]interface{}{"targetPort": int64(8080)},
+		"tls":            map[string]interface{}{"termination": "edge", "insecureEdgeTerminationPolicy": "Redirect"},
+		"wildcardPolicy": "None",
+	}, route.Object["spec"])
+	// the InferenceService is not ready until the route is admitted
+	assert.Equal(t, v1beta1.IngressNotReady, isvc.Status.GetCondition(v1beta1.IngressReady).Reason)
+	assert.Equal(t, "Route is not admitted by a router yet", isvc.Status.GetCondition(v1beta1.IngressReady).Message)
+	assert.False(t, isvc.Status.IsConditionReady(v1beta1.IngressReady))
+
+	// the admitted host is propagated to the status
+	route.Object["status"] = map[string]interface{}{
+		"ingress": []interface{}{
+			map[string]interface{}{
+				"host":       "my-model-default.apps.example.com",
+				"conditions": []interface{}{map[string]interface{}{"type": "Admitted", "status": "True"}},
+			},
+		},
+	}
+	assert.NoError(t, c.Update(context.TODO(), route))
+	assert.NoError(t, reconciler.Reconcile(isvc))
+	assert.True(t, isvc.Status.IsConditionReady(v1beta1.IngressReady))
+	assert.Equal(t, "https://my-model-default.apps.example.com", isvc.Status.URL.String())
+	assert.Equal(t, route.GetResourceVersion(), getRoute().GetResourceVersion())
+
+	// update
+	isvc.Annotations[constants.RouteTLSTerminationAnnotationKey] = constants.RouteTLSTerminationReencrypt
+	assert.NoError(t, reconciler.Reconcile(isvc))
+	tls, _, _ := unstructured.NestedMap(getRoute().Object, "spec", "tls")
+	assert.Equal(t, "reencrypt", tls["termination"])
+
+	// delete when the InferenceService is exposed by an Ingress
+	reconciler, _ = NewRawIngressReconciler(c, scheme, &v1beta1.IngressConfig{
+		IngressDomain:  "example.com",
+		DomainTemplate: "{{ .Name }}-{{ .Namespace }}.{{ .IngressDomain }}",
+		UrlScheme:      "http",
+	})
+	assert.NoError(t, reconciler.Reconcile(isvc))
+	route = &unstructured.Unstructured{}
+	route.SetGroupVersionKind(RouteGVK)
+	err := c.Get(context.TODO(), types.NamespacedName{Name: "my-model", Namespace: "default"}, route)
+	assert.True(t, apierr.IsNotFound(err))
+	assert.NoError(t, c.Get(context.TODO(), types.NamespacedName{Name: "my-model", Namespace: "default"}, &netv1.Ingress{}))
+}
+
+func TestRawIngressReconcilerWithoutRouteCRD(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = netv1.AddToScheme(scheme)
+	_ = v1beta1.AddToScheme(scheme)
+
+	isvc := &v1beta1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-model", Namespace: "default", UID: "123"},
+	}
+	isvc.Status.SetCondition(v1beta1.PredictorReady, &apis.Condition{Type: v1beta1.PredictorReady, Status: corev1.ConditionTrue})
+	c := fake.NewClientBuilder().WithScheme(scheme).Build()
+	reconciler, _ := NewRawIngressReconciler(c, scheme, &v1beta1.IngressConfig{
+		IngressDomain:  "example.com",
+		DomainTemplate: "{{ .Name }}-{{ .Namespace }}.{{ .IngressDomain }}",
+		UrlScheme:      "http",
+	})
+	// standard clusters are unaffected by the missing Route kind
+	assert.NoError(t, reconciler.Reconcile(isvc))
+	assert.True(t, isvc.Status.IsConditionReady(v1beta1.IngressReady))
+}
@@ -0,0 +1,207 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keda
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/reconcilers/hpa"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var log = logf.Log.WithName("KedaReconciler")
+
+// ScaledObjectGVK is the GroupVersionKind of the KEDA ScaledObject, KEDA types are handled as unstructured
+// objects so that the controller does not depend on the KEDA API.
+var ScaledObjectGVK = schema.GroupVersionKind{Group: "keda.sh", Version: "v1alpha1", Kind: "ScaledObject"}
+
+type scaledObjectSpec struct {
+	ScaleTargetRef  scaleTargetRef        `json:"scaleTargetRef"`
+	MinReplicaCount int32                 `json:"minReplicaCount"`
+	MaxReplicaCount int32                 `json:"maxReplicaCount"`
+	Triggers        []v1beta1.KedaTrigger `json:"triggers"`
+}
+
+type scaleTargetRef struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Name       string `json:"name"`
+}
+
+// KedaReconciler reconciles the KEDA ScaledObject of a raw deployment component
+type KedaReconciler struct {
+	client       client.Client
+	scheme       *runtime.Scheme
+	ScaledObject *unstructured.Unstructured
+	componentExt *v1beta1.ComponentExtensionSpec
+	// owner controls the scaled object, only the HPA it controls is cleaned up
+	owner metav1.Object
+}
+
+func NewKedaReconciler(client client.Client,
+	scheme *runtime.Scheme,
+	componentMeta metav1.ObjectMeta,
+	componentExt *v1beta1.ComponentExtensionSpec) (*KedaReconciler, error) {
+	scaledObject, err := createScaledObject(componentMeta, componentExt)
+	if err != nil {
+		return nil, err
+	}
+	return &KedaReconciler{
+		client:       client,
+		scheme:       scheme,
+		ScaledObject: scaledObject,
+		componentExt: componentExt,
+	}, nil
+}
+
+func createScaledObject(componentMeta metav1.ObjectMeta,
+	componentExt *v1beta1.ComponentExtensionSpec) (*unstructured.Unstructured, error) {
+	triggers, err := v1beta1.GetKedaTriggers(componentMeta.Annotations)
+	if err != nil {
+		return nil, err
+	}
+	if len(triggers) == 0 {
+		return nil, fmt.Errorf("the %s annotation is required for the %s autoscaler class",
+			constants.KedaTriggersAnnotationKey, constants.AutoscalerClassKeda)
+	}
+	// unlike the HPA, KEDA scales the deployment to zero when minReplicas is 0
+	minReplicas := int32(constants.DefaultMinReplicas)
+	if componentExt.MinReplicas != nil && *componentExt.MinReplicas >= 0 {
+		minReplicas = int32(*componentExt.MinReplicas)
+	}
+	maxReplicas := int32(componentExt.MaxReplicas)
+	if maxReplicas < minReplicas {
+		maxReplicas = minReplicas
+	}
+	if maxReplicas < 1 {
+		maxReplicas = 1
+	}
+	spec, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&scaledObjectSpec{
+		ScaleTargetRef: scaleTargetRef{
+			APIVersion: "apps/v1",
+			Kind:       "Deployment",
+			Name:       componentMeta.Name,
+		},
+		MinReplicaCount: minReplicas,
+		MaxReplicaCount: maxReplicas,
+		Triggers:        triggers,
+	})
+	if err != nil {
+		return nil, err
+	}
+	scaledObject := &unstructured.Unstructured{Object: map[string]interface{}{"spec": spec}}
+	scaledObject.SetGroupVersionKind(ScaledObjectGVK)
+	scaledObject.SetName(componentMeta.Name)
+	scaledObject.SetNamespace(componentMeta.Namespace)
+	scaledObject.SetLabels(componentMeta.Labels)
+	scaledObject.SetAnnotations(componentMeta.Annotations)
+	return scaledObject, nil
+}
+
+// checkScaledObjectExist checks if the scaled object exists?
+func (r *KedaReconciler) checkScaledObjectExist(client client.Client) (constants.CheckResultType, *unstructured.Unstructured, error) {
+	// get scaled object
+	existing := &unstructured.Unstructured{}
+	existing.SetGroupVersionKind(ScaledObjectGVK)
+	err := client.Get(context.TODO(), types.NamespacedName{
+		Namespace: r.ScaledObject.GetNamespace(),
+		Name:      r.ScaledObject.GetName(),
+	}, existing)
+	if err != nil {
+		if apierr.IsNotFound(err) {
+			return constants.CheckResultCreate, nil, nil
+		}
+		return constants.CheckResultUnknown, nil, err
+	}
+
+	// existed, check equivalent
+	if equality.Semantic.DeepEqual(r.ScaledObject.Object["spec"], existing.Object["spec"]) {
+		return constants.CheckResultExisted, existing, nil
+	}
+	return constants.CheckResultUpdate, existing, nil
+}
+
+// Reconcile ...
+func (r *KedaReconciler) Reconcile() (*autoscalingv2.HorizontalPodAutoscaler, error) {
+	// KEDA manages its own HPA for the scaled object, delete the HPA created while the autoscaler class was hpa
+	if err := hpa.DeleteHPA(r.client, metav1.ObjectMeta{
+		Name:      r.ScaledObject.GetName(),
+		Namespace: r.ScaledObject.GetNamespace(),
+	}, r.owner); err != nil {
+		return nil, err
+	}
+	// reconcile ScaledObject
+	checkResult, existing, err := r.checkScaledObjectExist(r.client)
+	log.Info("ScaledObject reconcile", "checkResult", checkResult, "err", err)
+	if err != nil {
+		return nil, err
+	}
+
+	switch checkResult {
+	case constants.CheckResultCreate:
+		err = r.client.Create(context.TODO(), r.ScaledObject)
+	case constants.CheckResultUpdate:
+		existing.Object["spec"] = r.ScaledObject.Object["spec"]
+		err = r.client.Update(context.TODO(), existing)
+	}
+	return nil, err
+}
+
+func (r *KedaReconciler) SetControllerReferences(owner metav1.Object, scheme *runtime.Scheme) error {
+	r.owner = owner
+	return controllerutil.SetControllerReference(owner, r.ScaledObject, scheme)
+}
+
+// DeleteScaledObject deletes the scaled object of the component when its autoscaler class is no longer keda. Only a
+// scaled object controlled by the owner of the component is deleted. It is a no-op when the KEDA CRDs are not installed.
+func DeleteScaledObject(client client.Client, componentMeta metav1.ObjectMeta, owner metav1.Object) error {
+	existing := &unstructured.Unstructured{}
+	existing.SetGroupVersionKind(ScaledObjectGVK)
+	err := client.Get(context.TODO(), types.NamespacedName{
+		Namespace: componentMeta.Namespace,
+		Name:      componentMeta.Name,
+	}, existing)
+	if err != nil {
+		if apierr.IsNotFound(err) || meta.IsNoMatchError(err) {
+			return nil
+		}
+		return err
+	}
+	if owner == nil || !metav1.IsControlledBy(existing, owner) {
+		log.Info("ScaledObject is not controlled by the component owner, skipping its deletion",
+			"namespace", existing.GetNamespace(), "name", existing.GetName())
+		return nil
+	}
+	if err := client.Delete(context.TODO(), existing); err != nil && !apierr.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,184 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package keda
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/stretchr/testify/assert"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const prometheusTrigger = `[{"type": "prometheus", "metadata": {"serverAddress": "http://prometheus.monitoring:9090",
+	"query": "sum(vllm:num_requests_waiting{service=\"sklearn\"})", "threshold": "10"}}]`
+
+func newScheme(t *testing.T) *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	assert.NoError(t, clientgoscheme.AddToScheme(scheme))
+	assert.NoError(t, v1beta1.AddToScheme(scheme))
+	scheme.AddKnownTypeWithName(ScaledObjectGVK, &unstructured.Unstructured{})
+	return scheme
+}
+
+func newOwner(name string) *v1beta1.InferenceService {
+	return &v1beta1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", UID: types.UID(name)},
+	}
+}
+
+func TestCreateScaledObject(t *testing.T) {
+	componentMeta := metav1.ObjectMeta{
+		Name:      "sklearn-predictor",
+		Namespace: "default",
+		Annotations: map[string]string{
+			constants.AutoscalerClass:           string(constants.AutoscalerClassKeda),
+			constants.KedaTriggersAnnotationKey: prometheusTrigger,
+		},
+	}
+
+	scenarios := map[string]struct {
+		componentExt        *v1beta1.ComponentExtensionSpec
+		expectedMinReplicas int64
+		expectedMaxReplicas int64
+	}{
+		"Default": {
+			componentExt:        &v1beta1.ComponentExtensionSpec{},
+			expectedMinReplicas: 1,
+			expectedMaxReplicas: 1,
+		},
+		"ScaleToZero": {
+			componentExt:        &v1beta1.ComponentExtensionSpec{MinReplicas: v1beta1.GetIntReference(0), MaxReplicas: 5},
+			expectedMinReplicas: 0,
+			expectedMaxReplicas: 5,
+		},
+		"MaxReplicasLowerThanMinReplicas": {
+			componentExt:        &v1beta1.ComponentExtensionSpec{MinReplicas: v1beta1.GetIntReference(3)},
+			expectedMinReplicas: 3,
+			expectedMaxReplicas: 3,
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			scaledObject, err := createScaledObject(componentMeta, scenario.componentExt)
+			assert.NoError(t, err)
+			assert.Equal(t, ScaledObjectGVK, scaledObject.GroupVersionKind())
+			minReplicas, _, _ := unstructured.NestedInt64(scaledObject.Object, "spec", "minReplicaCount")
+			maxReplicas, _, _ := unstructured.NestedInt64(scaledObject.Object, "spec", "maxReplicaCount")
+			assert.Equal(t, scenario.expectedMinReplicas, minReplicas)
+			assert.Equal(t, scenario.expectedMaxReplicas, maxReplicas)
+			target, _, _ := unstructured.NestedStringMap(scaledObject.Object, "spec", "scaleTargetRef")
+			if diff := cmp.Diff(map[string]string{"apiVersion": "apps/v1", "kind": "Deployment", "name": "sklearn-predictor"}, target); diff != "" {
+				t.Errorf("unexpected scale target (-want +got): %v", diff)
+			}
+			triggers, _, _ := unstructured.NestedSlice(scaledObject.Object, "spec", "triggers")
+			assert.Len(t, triggers, 1)
+		})
+	}
+
+	delete(componentMeta.Annotations, constants.KedaTriggersAnnotationKey)
+	_, err := createScaledObject(componentMeta, &v1beta1.ComponentExtensionSpec{})
+	assert.Error(t, err)
+}
+
+func TestKedaReconcile(t *testing.T) {
+	componentMeta := metav1.ObjectMeta{
+		Name:      "sklearn-predictor",
+		Namespace: "default",
+		Annotations: map[string]string{
+			constants.AutoscalerClass:           string(constants.AutoscalerClassKeda),
+			constants.KedaTriggersAnnotationKey: prometheusTrigger,
+		},
+	}
+	owner := newOwner("sklearn")
+	// the HPA created while the autoscaler class was hpa
+	hpa := &autoscalingv2.HorizontalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{Name: "sklearn-predictor", Namespace: "default"},
+	}
+	assert.NoError(t, controllerutil.SetControllerReference(owner, hpa, newScheme(t)))
+	client := fake.NewClientBuilder().WithScheme(newScheme(t)).WithObjects(hpa).Build()
+
+	reconciler, err := NewKedaReconciler(client, client.Scheme(), componentMeta, &v1beta1.ComponentExtensionSpec{
+		MinReplicas: v1beta1.GetIntReference(0),
+		MaxReplicas: 3,
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, reconciler.SetControllerReferences(owner, client.Scheme()))
+	_, err = reconciler.Reconcile()
+	assert.NoError(t, err)
+
+	key := types.NamespacedName{Name: "sklearn-predictor", Namespace: "default"}
+	err = client.Get(context.TODO(), key, &autoscalingv2.HorizontalPodAutoscaler{})
+	assert.True(t, apierr.IsNotFound(err))
+	scaledObject := &unstructured.Unstructured{}
+	scaledObject.SetGroupVersionKind(ScaledObjectGVK)
+	assert.NoError(t, client.Get(context.TODO(), key, scaledObject))
+	minReplicas, _, _ := unstructured.NestedInt64(scaledObject.Object, "spec", "minReplicaCount")
+	assert.Equal(t, int64(0), minReplicas)
+
+	// changing the replicas updates the existing scaled object
+	reconciler, err = NewKedaReconciler(client, client.Scheme(), componentMeta, &v1beta1.ComponentExtensionSpec{
+		MinReplicas: v1beta1.GetIntReference(1),
+		MaxReplicas: 3,
+	})
+	assert.NoError(t, err)
+	_, err = reconciler.Reconcile()
+	assert.NoError(t, err)
+	assert.NoError(t, client.Get(context.TODO(), key, scaledObject))
+	minReplicas, _, _ = unstructured.NestedInt64(scaledObject.Object, "spec", "minReplicaCount")
+	assert.Equal(t, int64(1), minReplicas)
+
+	// the scaled object is only deleted by its owner once the autoscaler class is no longer keda
+	assert.NoError(t, DeleteScaledObject(client, componentMeta, newOwner("other")))
+	assert.NoError(t, client.Get(context.TODO(), key, scaledObject))
+	assert.NoError(t, DeleteScaledObject(client, componentMeta, owner))
+	err = client.Get(context.TODO(), key, scaledObject)
+	assert.True(t, apierr.IsNotFound(err))
+	assert.NoError(t, DeleteScaledObject(client, componentMeta, owner))
+}
+
+func TestDeleteScaledObjectNotControlledByOwner(t *testing.T) {
+	scaledObject := &unstructured.Unstructured{}
+	scaledObject.SetGroupVersionKind(ScaledObjectGVK)
+	scaledObject.SetName("sklearn-predictor")
+	scaledObject.SetNamespace("default")
+	client := fake.NewClientBuilder().WithScheme(newScheme(t)).WithObjects(scaledObject).Build()
+
+	// a scaled object created by the user for the deployment is kept
+	assert.NoError(t, DeleteScaledObject(client, metav1.ObjectMeta{Name: "sklearn-predictor", Namespace: "default"}, newOwner("sklearn")))
+	existing := &unstructured.Unstructured{}
+	existing.SetGroupVersionKind(ScaledObjectGVK)
+	assert.NoError(t, client.Get(context.TODO(), types.NamespacedName{Name: "sklearn-predictor", Namespace: "default"}, existing))
+}
+
+func TestDeleteScaledObjectWithoutKedaInstalled(t *testing.T) {
+	scheme := runtime.NewScheme()
+	assert.NoError(t, clientgoscheme.AddToScheme(scheme))
+	client := fake.NewClientBuilder().WithScheme(scheme).Build()
+	assert.NoError(t, DeleteScaledObject(client, metav1.ObjectMeta{Name: "sklearn-predictor", Namespace: "default"}, newOwner("sklearn")))
+}
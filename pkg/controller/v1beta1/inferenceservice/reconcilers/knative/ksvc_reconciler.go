@@ -22,6 +22,7 @@ import (
 
 	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
 	"github.com/kserve/kserve/pkg/constants"
+	"github.com/kserve/kserve/pkg/controller/metrics"
 	"github.com/kserve/kserve/pkg/utils"
 	"github.com/pkg/errors"
 	"google.golang.org/protobuf/proto"
@@ -71,6 +72,17 @@ func NewKsvcReconciler(client client.Client,
 	}
 }
 
+// HoldRollout routes all the traffic to the given revision, so that the latest revision is not promoted
+func (r *KsvcReconciler) HoldRollout(revision string) {
+	r.Service.Spec.Traffic = []knservingv1.TrafficTarget{
+		{
+			RevisionName:   revision,
+			LatestRevision: proto.Bool(false),
+			Percent:        proto.Int64(100),
+		},
+	}
+}
+
 func createKnativeService(componentMeta metav1.ObjectMeta,
 	componentExtension *v1beta1.ComponentExtensionSpec,
 	podSpec *corev1.PodSpec,
@@ -100,6 +112,20 @@ func createKnativeService(componentMeta metav1.ObjectMeta,
 		annotations[autoscaling.MetricAnnotationKey] = fmt.Sprint(*componentExtension.ScaleMetric)
 	}
 
+	// The scale options are set from the component extension unless the user set the knative annotations directly
+	scaleOptions := map[string]*string{
+		autoscaling.ScaleDownDelayAnnotationKey: componentExtension.ScaleDownDelay,
+		autoscaling.WindowAnnotationKey:         componentExtension.ScaleWindow,
+	}
+	if componentExtension.InitialScale != nil {
+		scaleOptions[autoscaling.InitialScaleAnnotationKey] = proto.String(fmt.Sprint(*componentExtension.InitialScale))
+	}
+	for key, value := range scaleOptions {
+		if _, ok := annotations[key]; !ok && value != nil {
+			annotations[key] = *value
+		}
+	}
+
 	// ksvc metadata.annotations
 	// rollout-duration must be put under metadata.annotations
 	ksvcAnnotations := make(map[string]string)
@@ -217,6 +243,7 @@ func (r *KsvcReconciler) Reconcile() (*knservingv1.ServiceStatus, error) {
 	desired := r.Service
 	existing := &knservingv1.Service{}
 
+	drifted := false
 	err := retry.RetryOnConflict(retry.DefaultBackoff, func() error {
 		log.Info("Updating knative service", "namespace", desired.Namespace, "name", desired.Name)
 		if err := r.client.Get(context.TODO(), types.NamespacedName{Name: desired.Name, Namespace: desired.Namespace}, existing); err != nil {
@@ -239,6 +266,7 @@ func (r *KsvcReconciler) Reconcile() (*knservingv1.ServiceStatus, error) {
 			}
 			return err
 		}
+		drifted = !semanticEquals(desired, existing)
 		if err := reconcileKsvc(desired, existing); err != nil {
 			return err
 		}
@@ -252,6 +280,9 @@ func (r *KsvcReconciler) Reconcile() (*knservingv1.ServiceStatus, error) {
 		}
 		return &existing.Status, errors.Wrapf(err, "fails to reconcile knative service")
 	}
+	if drifted {
+		metrics.RecordDriftCorrection(metrics.KindKnativeService)
+	}
 	return &existing.Status, nil
 }
 
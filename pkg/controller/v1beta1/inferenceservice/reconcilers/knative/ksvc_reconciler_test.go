@@ -0,0 +1,174 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package knative
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+	"google.golang.org/protobuf/proto"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/serving/pkg/apis/autoscaling"
+	knservingv1 "knative.dev/serving/pkg/apis/serving/v1"
+)
+
+func TestCreateKnativeServiceScaleOptions(t *testing.T) {
+	scaleOptionKeys := []string{
+		autoscaling.InitialScaleAnnotationKey,
+		autoscaling.ScaleDownDelayAnnotationKey,
+		autoscaling.WindowAnnotationKey,
+	}
+	testCases := map[string]struct {
+		annotations  map[string]string
+		componentExt *v1beta1.ComponentExtensionSpec
+		expected     map[string]string
+	}{
+		"noScaleOptions": {
+			annotations:  map[string]string{},
+			componentExt: &v1beta1.ComponentExtensionSpec{},
+			expected:     map[string]string{},
+		},
+		"scaleOptionsFromComponentExtension": {
+			annotations: map[string]string{},
+			componentExt: &v1beta1.ComponentExtensionSpec{
+				InitialScale:   v1beta1.GetIntReference(0),
+				ScaleDownDelay: proto.String("15m"),
+				ScaleWindow:    proto.String("120s"),
+			},
+			expected: map[string]string{
+				autoscaling.InitialScaleAnnotationKey:   "0",
+				autoscaling.ScaleDownDelayAnnotationKey: "15m",
+				autoscaling.WindowAnnotationKey:         "120s",
+			},
+		},
+		"annotationsWinOverComponentExtension": {
+			annotations: map[string]string{
+				autoscaling.InitialScaleAnnotationKey:   "2",
+				autoscaling.ScaleDownDelayAnnotationKey: "5m",
+			},
+			componentExt: &v1beta1.ComponentExtensionSpec{
+				InitialScale:   v1beta1.GetIntReference(1),
+				ScaleDownDelay: proto.String("15m"),
+				ScaleWindow:    proto.String("120s"),
+			},
+			expected: map[string]string{
+				autoscaling.InitialScaleAnnotationKey:   "2",
+				autoscaling.ScaleDownDelayAnnotationKey: "5m",
+				autoscaling.WindowAnnotationKey:         "120s",
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			service := createKnativeService(metav1.ObjectMeta{
+				Name:        "sklearn-predictor",
+				Namespace:   "default",
+				Annotations: tc.annotations,
+			}, tc.componentExt, &corev1.PodSpec{}, v1beta1.ComponentStatusSpec{})
+			actual := map[string]string{}
+			for _, key := range scaleOptionKeys {
+				if value, ok := service.Spec.Template.Annotations[key]; ok {
+					actual[key] = value
+				}
+			}
+			if diff := cmp.Diff(tc.expected, actual); diff != "" {
+				t.Errorf("unexpected revision annotations (-want +got): %v", diff)
+			}
+			if service.Spec.Template.Annotations[constants.MinScaleAnnotationKey] != "1" {
+				t.Errorf("expected the default min scale, got %q", service.Spec.Template.Annotations[constants.MinScaleAnnotationKey])
+			}
+		})
+	}
+}
+
+func TestCreateKnativeServiceContainerConcurrency(t *testing.T) {
+	testCases := map[string]struct {
+		componentExt                 *v1beta1.ComponentExtensionSpec
+		expectedContainerConcurrency *int64
+		expectedTarget               string
+	}{
+		"unset": {
+			componentExt:   &v1beta1.ComponentExtensionSpec{ScaleTarget: v1beta1.GetIntReference(10)},
+			expectedTarget: "10",
+		},
+		"unlimited": {
+			componentExt: &v1beta1.ComponentExtensionSpec{
+				ScaleTarget:          v1beta1.GetIntReference(10),
+				ContainerConcurrency: proto.Int64(0),
+			},
+			expectedContainerConcurrency: proto.Int64(0),
+			expectedTarget:               "10",
+		},
+		"equalToScaleTarget": {
+			componentExt: &v1beta1.ComponentExtensionSpec{
+				ScaleTarget:          v1beta1.GetIntReference(10),
+				ContainerConcurrency: proto.Int64(10),
+			},
+			expectedContainerConcurrency: proto.Int64(10),
+			expectedTarget:               "10",
+		},
+		// rejected by the webhook, the reconciler does not adjust one value to the other
+		"lowerThanScaleTarget": {
+			componentExt: &v1beta1.ComponentExtensionSpec{
+				ScaleTarget:          v1beta1.GetIntReference(10),
+				ContainerConcurrency: proto.Int64(4),
+			},
+			expectedContainerConcurrency: proto.Int64(4),
+			expectedTarget:               "10",
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			service := createKnativeService(metav1.ObjectMeta{
+				Name:        "sklearn-predictor",
+				Namespace:   "default",
+				Annotations: map[string]string{},
+			}, tc.componentExt, &corev1.PodSpec{}, v1beta1.ComponentStatusSpec{})
+			if diff := cmp.Diff(tc.expectedContainerConcurrency, service.Spec.Template.Spec.ContainerConcurrency); diff != "" {
+				t.Errorf("unexpected container concurrency (-want +got): %v", diff)
+			}
+			if target := service.Spec.Template.Annotations[autoscaling.TargetAnnotationKey]; target != tc.expectedTarget {
+				t.Errorf("expected the autoscaling target %q, got %q", tc.expectedTarget, target)
+			}
+		})
+	}
+}
+
+func TestHoldRollout(t *testing.T) {
+	r := NewKsvcReconciler(nil, nil, metav1.ObjectMeta{
+		Name:        "sklearn-predictor",
+		Namespace:   "default",
+		Annotations: map[string]string{},
+	}, &v1beta1.ComponentExtensionSpec{}, &corev1.PodSpec{}, v1beta1.ComponentStatusSpec{
+		LatestRolledoutRevision: "sklearn-predictor-00001",
+		LatestCreatedRevision:   "sklearn-predictor-00002",
+	})
+	r.HoldRollout("sklearn-predictor-00001")
+	expected := []knservingv1.TrafficTarget{
+		{
+			RevisionName:   "sklearn-predictor-00001",
+			LatestRevision: proto.Bool(false),
+			Percent:        proto.Int64(100),
+		},
+	}
+	if diff := cmp.Diff(expected, r.Service.Spec.Traffic); diff != "" {
+		t.Errorf("unexpected traffic (-want +got): %v", diff)
+	}
+}
@@ -0,0 +1,164 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdb
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var log = logf.Log.WithName("PDBReconciler")
+
+// PDBReconciler reconciles the PodDisruptionBudget of a raw deployment component
+type PDBReconciler struct {
+	client       client.Client
+	scheme       *runtime.Scheme
+	PDB          *policyv1.PodDisruptionBudget
+	componentExt *v1beta1.ComponentExtensionSpec
+}
+
+func NewPDBReconciler(client client.Client,
+	scheme *runtime.Scheme,
+	componentMeta metav1.ObjectMeta,
+	componentExt *v1beta1.ComponentExtensionSpec) (*PDBReconciler, error) {
+	pdb, err := createPDB(componentMeta)
+	if err != nil {
+		return nil, err
+	}
+	return &PDBReconciler{
+		client:       client,
+		scheme:       scheme,
+		PDB:          pdb,
+		componentExt: componentExt,
+	}, nil
+}
+
+func createPDB(componentMeta metav1.ObjectMeta) (*policyv1.PodDisruptionBudget, error) {
+	maxUnavailable := intstr.FromInt(constants.DefaultPDBMaxUnavailable)
+	if value, ok := componentMeta.Annotations[constants.PDBMaxUnavailableAnnotationKey]; ok {
+		maxUnavailable = intstr.Parse(value)
+		scaled, err := intstr.GetScaledValueFromIntOrPercent(&maxUnavailable, 100, true)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value %q for annotation %s: %w", value, constants.PDBMaxUnavailableAnnotationKey, err)
+		}
+		if scaled < 1 || (maxUnavailable.Type == intstr.String && scaled > 100) {
+			return nil, fmt.Errorf("invalid value %q for annotation %s, must be a positive number or a percentage between 1%% and 100%%",
+				value, constants.PDBMaxUnavailableAnnotationKey)
+		}
+	}
+	pdb := &policyv1.PodDisruptionBudget{
+		ObjectMeta: componentMeta,
+		Spec: policyv1.PodDisruptionBudgetSpec{
+			MaxUnavailable: &maxUnavailable,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					"app": constants.GetRawServiceLabel(componentMeta.Name),
+				},
+			},
+		},
+	}
+	return pdb, nil
+}
+
+// shouldCreatePDB returns true when the component always runs more than one replica,
+// a single replica can not be protected by a PDB without blocking node drains.
+func (r *PDBReconciler) shouldCreatePDB() bool {
+	return r.componentExt.MinReplicas != nil && *r.componentExt.MinReplicas > 1
+}
+
+// checkPDBExist checks if the pdb exists?
+func (r *PDBReconciler) checkPDBExist(client client.Client) (constants.CheckResultType, *policyv1.PodDisruptionBudget, error) {
+	// get pdb
+	existingPDB := &policyv1.PodDisruptionBudget{}
+	err := client.Get(context.TODO(), types.NamespacedName{
+		Namespace: r.PDB.ObjectMeta.Namespace,
+		Name:      r.PDB.ObjectMeta.Name,
+	}, existingPDB)
+	if err != nil {
+		if apierr.IsNotFound(err) {
+			if r.shouldCreatePDB() {
+				return constants.CheckResultCreate, nil, nil
+			}
+			return constants.CheckResultSkipped, nil, nil
+		}
+		return constants.CheckResultUnknown, nil, err
+	}
+
+	// existed, check equivalent
+	if !r.shouldCreatePDB() {
+		return constants.CheckResultDelete, existingPDB, nil
+	}
+	if semanticPDBEquals(r.PDB, existingPDB) {
+		return constants.CheckResultExisted, existingPDB, nil
+	}
+	return constants.CheckResultUpdate, existingPDB, nil
+}
+
+func semanticPDBEquals(desired, existing *policyv1.PodDisruptionBudget) bool {
+	return equality.Semantic.DeepEqual(desired.Spec, existing.Spec) &&
+		equality.Semantic.DeepEqual(desired.Labels, existing.Labels) &&
+		equality.Semantic.DeepEqual(desired.Annotations, existing.Annotations)
+}
+
+// Reconcile ...
+func (r *PDBReconciler) Reconcile() (*policyv1.PodDisruptionBudget, error) {
+	// reconcile PodDisruptionBudget
+	checkResult, existingPDB, err := r.checkPDBExist(r.client)
+	log.Info("PodDisruptionBudget reconcile", "checkResult", checkResult, "err", err)
+	if err != nil {
+		return nil, err
+	}
+
+	var opErr error
+	switch checkResult {
+	case constants.CheckResultCreate:
+		opErr = r.client.Create(context.TODO(), r.PDB)
+	case constants.CheckResultUpdate:
+		r.PDB.ResourceVersion = existingPDB.ResourceVersion
+		opErr = r.client.Update(context.TODO(), r.PDB)
+	case constants.CheckResultDelete:
+		opErr = r.client.Delete(context.TODO(), existingPDB)
+		if opErr == nil {
+			return nil, nil
+		}
+	default:
+		return existingPDB, nil
+	}
+
+	if opErr != nil {
+		return nil, opErr
+	}
+
+	return r.PDB, nil
+}
+
+func (r *PDBReconciler) SetControllerReferences(owner metav1.Object, scheme *runtime.Scheme) error {
+	return controllerutil.SetControllerReference(owner, r.PDB, scheme)
+}
@@ -0,0 +1,157 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/stretchr/testify/assert"
+	policyv1 "k8s.io/api/policy/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestCreatePDB(t *testing.T) {
+	testCases := map[string]struct {
+		annotations map[string]string
+		expected    policyv1.PodDisruptionBudgetSpec
+		expectedErr bool
+	}{
+		"defaultMaxUnavailable": {
+			annotations: map[string]string{},
+			expected: policyv1.PodDisruptionBudgetSpec{
+				MaxUnavailable: &intstr.IntOrString{Type: intstr.Int, IntVal: 1},
+				Selector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{"app": "isvc.sklearn-predictor"},
+				},
+			},
+		},
+		"maxUnavailableFromAnnotation": {
+			annotations: map[string]string{
+				constants.PDBMaxUnavailableAnnotationKey: "50%",
+			},
+			expected: policyv1.PodDisruptionBudgetSpec{
+				MaxUnavailable: &intstr.IntOrString{Type: intstr.String, StrVal: "50%"},
+				Selector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{"app": "isvc.sklearn-predictor"},
+				},
+			},
+		},
+		"zeroMaxUnavailable": {
+			annotations: map[string]string{
+				constants.PDBMaxUnavailableAnnotationKey: "0",
+			},
+			expectedErr: true,
+		},
+		"invalidMaxUnavailable": {
+			annotations: map[string]string{
+				constants.PDBMaxUnavailableAnnotationKey: "half",
+			},
+			expectedErr: true,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			pdb, err := createPDB(metav1.ObjectMeta{
+				Name:        "sklearn-predictor",
+				Namespace:   "default",
+				Annotations: tc.annotations,
+			})
+			if tc.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			if diff := cmp.Diff(tc.expected, pdb.Spec); diff != "" {
+				t.Errorf("Test %q unexpected pdb spec (-want +got): %v", name, diff)
+			}
+		})
+	}
+}
+
+func TestPDBReconcile(t *testing.T) {
+	scheme := runtime.NewScheme()
+	assert.NoError(t, policyv1.AddToScheme(scheme))
+	cl := fake.NewClientBuilder().WithScheme(scheme).Build()
+	componentMeta := metav1.ObjectMeta{
+		Name:      "sklearn-predictor",
+		Namespace: "default",
+	}
+	key := types.NamespacedName{Name: componentMeta.Name, Namespace: componentMeta.Namespace}
+
+	// single replica does not create a PDB
+	r, err := NewPDBReconciler(cl, scheme, componentMeta, &v1beta1.ComponentExtensionSpec{})
+	assert.NoError(t, err)
+	pdb, err := r.Reconcile()
+	assert.NoError(t, err)
+	assert.Nil(t, pdb)
+	assert.True(t, apierr.IsNotFound(cl.Get(context.TODO(), key, &policyv1.PodDisruptionBudget{})))
+
+	// multiple replicas create the PDB
+	r, err = NewPDBReconciler(cl, scheme, componentMeta, &v1beta1.ComponentExtensionSpec{MinReplicas: v1beta1.GetIntReference(3)})
+	assert.NoError(t, err)
+	_, err = r.Reconcile()
+	assert.NoError(t, err)
+	existing := &policyv1.PodDisruptionBudget{}
+	assert.NoError(t, cl.Get(context.TODO(), key, existing))
+	assert.Equal(t, intstr.FromInt(1), *existing.Spec.MaxUnavailable)
+
+	// unchanged spec is not updated
+	r, err = NewPDBReconciler(cl, scheme, componentMeta, &v1beta1.ComponentExtensionSpec{MinReplicas: v1beta1.GetIntReference(3)})
+	assert.NoError(t, err)
+	checkResult, _, err := r.checkPDBExist(cl)
+	assert.NoError(t, err)
+	assert.Equal(t, constants.CheckResultExisted, checkResult)
+
+	// changed maxUnavailable updates the PDB
+	updatedMeta := *componentMeta.DeepCopy()
+	updatedMeta.Annotations = map[string]string{constants.PDBMaxUnavailableAnnotationKey: "2"}
+	r, err = NewPDBReconciler(cl, scheme, updatedMeta, &v1beta1.ComponentExtensionSpec{MinReplicas: v1beta1.GetIntReference(3)})
+	assert.NoError(t, err)
+	_, err = r.Reconcile()
+	assert.NoError(t, err)
+	assert.NoError(t, cl.Get(context.TODO(), key, existing))
+	assert.Equal(t, intstr.FromInt(2), *existing.Spec.MaxUnavailable)
+
+	// changed labels update the PDB
+	labelledMeta := *updatedMeta.DeepCopy()
+	labelledMeta.Labels = map[string]string{"team": "ml-platform"}
+	r, err = NewPDBReconciler(cl, scheme, labelledMeta, &v1beta1.ComponentExtensionSpec{MinReplicas: v1beta1.GetIntReference(3)})
+	assert.NoError(t, err)
+	checkResult, _, err = r.checkPDBExist(cl)
+	assert.NoError(t, err)
+	assert.Equal(t, constants.CheckResultUpdate, checkResult)
+	_, err = r.Reconcile()
+	assert.NoError(t, err)
+	assert.NoError(t, cl.Get(context.TODO(), key, existing))
+	assert.Equal(t, "ml-platform", existing.Labels["team"])
+
+	// dropping to a single replica deletes the PDB
+	r, err = NewPDBReconciler(cl, scheme, componentMeta, &v1beta1.ComponentExtensionSpec{MinReplicas: v1beta1.GetIntReference(1)})
+	assert.NoError(t, err)
+	_, err = r.Reconcile()
+	assert.NoError(t, err)
+	assert.True(t, apierr.IsNotFound(cl.Get(context.TODO(), key, &policyv1.PodDisruptionBudget{})))
+}
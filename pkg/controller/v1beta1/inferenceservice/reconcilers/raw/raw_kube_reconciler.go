@@ -20,6 +20,7 @@ import (
 	"fmt"
 
 	appsv1 "k8s.io/api/apps/v1"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -31,7 +32,9 @@ import (
 	autoscaler "github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/reconcilers/autoscaler"
 	deployment "github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/reconcilers/deployment"
 	"github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/reconcilers/ingress"
+	"github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/reconcilers/pdb"
 	service "github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/reconcilers/service"
+	"github.com/kserve/kserve/pkg/controller/v1beta1/inferenceservice/reconcilers/vpa"
 )
 
 // RawKubeReconciler reconciles the Native K8S Resources
@@ -41,7 +44,11 @@ type RawKubeReconciler struct {
 	Deployment *deployment.DeploymentReconciler
 	Service    *service.ServiceReconciler
 	Scaler     *autoscaler.AutoscalerReconciler
+	PDB        *pdb.PDBReconciler
+	VPA        *vpa.VPAReconciler
 	URL        *knapis.URL
+	// scaleToZero is true when the component may be scaled to zero and woken up by the activator
+	scaleToZero bool
 }
 
 // NewRawKubeReconciler creates raw kubernetes resource reconciler.
@@ -61,13 +68,37 @@ func NewRawKubeReconciler(client client.Client,
 		return nil, err
 	}
 
+	deployConfig, err := v1beta1.NewDeployConfig(clientset)
+	if err != nil {
+		return nil, err
+	}
+
+	depl, err := deployment.NewDeploymentReconciler(client, scheme, componentMeta, componentExt, podSpec, deployConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	pdbReconciler, err := pdb.NewPDBReconciler(client, scheme, componentMeta, componentExt)
+	if err != nil {
+		return nil, err
+	}
+
+	vpaReconciler, err := vpa.NewVPAReconciler(client, scheme, componentMeta)
+	if err != nil {
+		return nil, err
+	}
+
 	return &RawKubeReconciler{
 		client:     client,
 		scheme:     scheme,
-		Deployment: deployment.NewDeploymentReconciler(client, scheme, componentMeta, componentExt, podSpec),
+		Deployment: depl,
 		Service:    service.NewServiceReconciler(client, scheme, componentMeta, componentExt, podSpec),
 		Scaler:     as,
+		PDB:        pdbReconciler,
+		VPA:        vpaReconciler,
 		URL:        url,
+
+		scaleToZero: deployment.ScaleToZeroEnabled(componentMeta, componentExt),
 	}, nil
 }
 
@@ -87,22 +118,36 @@ func createRawURL(clientset kubernetes.Interface, metadata metav1.ObjectMeta) (*
 	return url, nil
 }
 
-// Reconcile ...
-func (r *RawKubeReconciler) Reconcile() (*appsv1.Deployment, error) {
+// Reconcile reconciles the raw kubernetes resources of the component, it returns the deployment and the HPA of the
+// component so that their status can be propagated. The HPA is nil when the component is not scaled by an HPA.
+func (r *RawKubeReconciler) Reconcile() (*appsv1.Deployment, *autoscalingv2.HorizontalPodAutoscaler, error) {
 	// reconcile Deployment
 	deployment, err := r.Deployment.Reconcile()
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+	// reconcile Service, the requests are sent to the activator until the component has an available replica
+	if r.scaleToZero && deployment.Status.AvailableReplicas == 0 {
+		r.Service.RouteToActivator()
 	}
-	// reconcile Service
 	_, err = r.Service.Reconcile()
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	// reconcile HPA
-	err = r.Scaler.Reconcile()
+	hpa, err := r.Scaler.Reconcile()
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+	// reconcile PDB
+	_, err = r.PDB.Reconcile()
+	if err != nil {
+		return nil, nil, err
+	}
+	// reconcile VPA
+	_, err = r.VPA.Reconcile()
+	if err != nil {
+		return nil, nil, err
 	}
-	return deployment, nil
+	return deployment, hpa, nil
 }
@@ -22,6 +22,7 @@ import (
 
 	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
 	"github.com/kserve/kserve/pkg/constants"
+	"github.com/kserve/kserve/pkg/controller/metrics"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	apierr "k8s.io/apimachinery/pkg/api/errors"
@@ -109,6 +110,12 @@ func createService(componentMeta metav1.ObjectMeta, componentExt *v1beta1.Compon
 			})
 		}
 	}
+	for i := range servicePorts {
+		if servicePorts[i].Name == constants.GrpcPortName {
+			appProtocol := constants.H2CAppProtocol
+			servicePorts[i].AppProtocol = &appProtocol
+		}
+	}
 	if componentExt != nil && componentExt.Batcher != nil {
 		servicePorts[0].TargetPort = intstr.IntOrString{
 			Type:   intstr.Int,
@@ -162,7 +169,25 @@ func (r *ServiceReconciler) checkServiceExist(client client.Client) (constants.C
 
 func semanticServiceEquals(desired, existing *corev1.Service) bool {
 	return equality.Semantic.DeepEqual(desired.Spec.Ports, existing.Spec.Ports) &&
-		equality.Semantic.DeepEqual(desired.Spec.Selector, existing.Spec.Selector)
+		equality.Semantic.DeepEqual(desired.Spec.Selector, existing.Spec.Selector) &&
+		serviceType(desired) == serviceType(existing) &&
+		desired.Spec.ExternalName == existing.Spec.ExternalName
+}
+
+func serviceType(service *corev1.Service) corev1.ServiceType {
+	if service.Spec.Type == "" {
+		return corev1.ServiceTypeClusterIP
+	}
+	return service.Spec.Type
+}
+
+// RouteToActivator turns the desired service into an ExternalName service of the activator, the requests sent to
+// the component while it is scaled to zero reach the activator which scales it back up and forwards them.
+func (r *ServiceReconciler) RouteToActivator() {
+	r.Service.Spec.Type = corev1.ServiceTypeExternalName
+	r.Service.Spec.ExternalName = constants.ActivatorServiceHost
+	r.Service.Spec.Selector = nil
+	r.Service.Spec.ClusterIP = ""
 }
 
 // Reconcile ...
@@ -187,6 +212,9 @@ func (r *ServiceReconciler) Reconcile() (*corev1.Service, error) {
 	if opErr != nil {
 		return nil, opErr
 	}
+	if checkResult == constants.CheckResultUpdate {
+		metrics.RecordDriftCorrection(metrics.KindService)
+	}
 
 	return r.Service, nil
 }
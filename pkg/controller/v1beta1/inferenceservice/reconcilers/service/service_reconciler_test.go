@@ -0,0 +1,118 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestCreateServiceGrpcPort(t *testing.T) {
+	h2c := constants.H2CAppProtocol
+	testCases := map[string]struct {
+		ports    []corev1.ContainerPort
+		expected []corev1.ServicePort
+	}{
+		"restOnly": {
+			ports: []corev1.ContainerPort{
+				{Name: "http1", ContainerPort: 8080, Protocol: corev1.ProtocolTCP},
+			},
+			expected: []corev1.ServicePort{
+				{Name: "http1", Port: constants.CommonDefaultHttpPort, TargetPort: intstr.FromInt(8080), Protocol: corev1.ProtocolTCP},
+			},
+		},
+		"restAndGrpc": {
+			ports: []corev1.ContainerPort{
+				{Name: "http1", ContainerPort: 8080, Protocol: corev1.ProtocolTCP},
+				{Name: constants.GrpcPortName, ContainerPort: 9000},
+			},
+			expected: []corev1.ServicePort{
+				{Name: "http1", Port: constants.CommonDefaultHttpPort, TargetPort: intstr.FromInt(8080), Protocol: corev1.ProtocolTCP},
+				{Name: constants.GrpcPortName, Port: 9000, TargetPort: intstr.FromInt(9000), Protocol: corev1.ProtocolTCP, AppProtocol: &h2c},
+			},
+		},
+		"grpcOnly": {
+			ports: []corev1.ContainerPort{
+				{Name: constants.GrpcPortName, ContainerPort: 9000, Protocol: corev1.ProtocolTCP},
+			},
+			expected: []corev1.ServicePort{
+				{Name: constants.GrpcPortName, Port: constants.CommonDefaultHttpPort, TargetPort: intstr.FromInt(9000), Protocol: corev1.ProtocolTCP, AppProtocol: &h2c},
+			},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			service := createService(metav1.ObjectMeta{Name: "sklearn-predictor", Namespace: "default"}, nil, &corev1.PodSpec{
+				Containers: []corev1.Container{
+					{Name: constants.InferenceServiceContainerName, Ports: tc.ports},
+				},
+			})
+			if diff := cmp.Diff(tc.expected, service.Spec.Ports); diff != "" {
+				t.Errorf("unexpected service ports (-want +got): %v", diff)
+			}
+		})
+	}
+}
+
+func TestServiceRouteToActivator(t *testing.T) {
+	scheme := runtime.NewScheme()
+	assert.NoError(t, corev1.AddToScheme(scheme))
+	componentMeta := metav1.ObjectMeta{Name: "sklearn-predictor", Namespace: "default"}
+	podSpec := &corev1.PodSpec{
+		Containers: []corev1.Container{{
+			Name:  constants.InferenceServiceContainerName,
+			Ports: []corev1.ContainerPort{{Name: "http1", ContainerPort: 8080, Protocol: corev1.ProtocolTCP}},
+		}},
+	}
+	cl := fake.NewClientBuilder().WithScheme(scheme).Build()
+	get := func() *corev1.Service {
+		service := &corev1.Service{}
+		assert.NoError(t, cl.Get(context.TODO(), types.NamespacedName{Name: componentMeta.Name, Namespace: componentMeta.Namespace}, service))
+		return service
+	}
+
+	// the component scaled to zero is routed to the activator
+	r := NewServiceReconciler(cl, scheme, componentMeta, &v1beta1.ComponentExtensionSpec{}, podSpec)
+	r.RouteToActivator()
+	_, err := r.Reconcile()
+	assert.NoError(t, err)
+	service := get()
+	assert.Equal(t, corev1.ServiceTypeExternalName, service.Spec.Type)
+	assert.Equal(t, constants.ActivatorServiceHost, service.Spec.ExternalName)
+	assert.Empty(t, service.Spec.Selector)
+	assert.Equal(t, intstr.FromInt(8080), service.Spec.Ports[0].TargetPort)
+
+	// the service selects the pods again once the component has an available replica
+	r = NewServiceReconciler(cl, scheme, componentMeta, &v1beta1.ComponentExtensionSpec{}, podSpec)
+	_, err = r.Reconcile()
+	assert.NoError(t, err)
+	service = get()
+	assert.NotEqual(t, corev1.ServiceTypeExternalName, service.Spec.Type)
+	assert.Empty(t, service.Spec.ExternalName)
+	assert.Equal(t, map[string]string{"app": constants.GetRawServiceLabel(componentMeta.Name)}, service.Spec.Selector)
+}
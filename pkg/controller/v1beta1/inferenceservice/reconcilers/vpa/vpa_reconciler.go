@@ -0,0 +1,213 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vpa
+
+import (
+	"context"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var log = logf.Log.WithName("VPAReconciler")
+
+// VerticalPodAutoscalerGVK is the GroupVersionKind of the VerticalPodAutoscaler, VPA types are handled as
+// unstructured objects so that the controller does not depend on the VPA API.
+var VerticalPodAutoscalerGVK = schema.GroupVersionKind{Group: "autoscaling.k8s.io", Version: "v1", Kind: "VerticalPodAutoscaler"}
+
+type verticalPodAutoscalerSpec struct {
+	TargetRef      targetRef      `json:"targetRef"`
+	UpdatePolicy   updatePolicy   `json:"updatePolicy"`
+	ResourcePolicy resourcePolicy `json:"resourcePolicy"`
+}
+
+type targetRef struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Name       string `json:"name"`
+}
+
+type updatePolicy struct {
+	UpdateMode string `json:"updateMode"`
+}
+
+type resourcePolicy struct {
+	ContainerPolicies []containerPolicy `json:"containerPolicies"`
+}
+
+type containerPolicy struct {
+	ContainerName string              `json:"containerName"`
+	Mode          string              `json:"mode,omitempty"`
+	MinAllowed    corev1.ResourceList `json:"minAllowed,omitempty"`
+	MaxAllowed    corev1.ResourceList `json:"maxAllowed,omitempty"`
+}
+
+// VPAReconciler reconciles the VerticalPodAutoscaler of a raw deployment predictor
+type VPAReconciler struct {
+	client client.Client
+	scheme *runtime.Scheme
+	// VPA is nil when the component should not have a VerticalPodAutoscaler
+	VPA           *unstructured.Unstructured
+	componentMeta metav1.ObjectMeta
+	// owner controls the VPA, only a VPA it controls is deleted
+	owner metav1.Object
+}
+
+func NewVPAReconciler(client client.Client,
+	scheme *runtime.Scheme,
+	componentMeta metav1.ObjectMeta) (*VPAReconciler, error) {
+	vpa, err := createVPA(componentMeta)
+	if err != nil {
+		return nil, err
+	}
+	return &VPAReconciler{
+		client:        client,
+		scheme:        scheme,
+		VPA:           vpa,
+		componentMeta: componentMeta,
+	}, nil
+}
+
+func createVPA(componentMeta metav1.ObjectMeta) (*unstructured.Unstructured, error) {
+	updateMode, ok := componentMeta.Annotations[constants.VPAUpdateModeAnnotationKey]
+	if !ok || componentMeta.Labels[constants.KServiceComponentLabel] != string(v1beta1.PredictorComponent) {
+		return nil, nil
+	}
+	minAllowed, err := v1beta1.GetVPAResourceList(componentMeta.Annotations, constants.VPAMinAllowedAnnotationKey)
+	if err != nil {
+		return nil, err
+	}
+	maxAllowed, err := v1beta1.GetVPAResourceList(componentMeta.Annotations, constants.VPAMaxAllowedAnnotationKey)
+	if err != nil {
+		return nil, err
+	}
+	spec, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&verticalPodAutoscalerSpec{
+		TargetRef: targetRef{
+			APIVersion: "apps/v1",
+			Kind:       "Deployment",
+			Name:       componentMeta.Name,
+		},
+		UpdatePolicy: updatePolicy{UpdateMode: updateMode},
+		// only the model server container is right-sized, sidecars keep their resources
+		ResourcePolicy: resourcePolicy{
+			ContainerPolicies: []containerPolicy{
+				{
+					ContainerName: constants.InferenceServiceContainerName,
+					MinAllowed:    minAllowed,
+					MaxAllowed:    maxAllowed,
+				},
+				{
+					ContainerName: "*",
+					Mode:          "Off",
+				},
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	vpa := &unstructured.Unstructured{Object: map[string]interface{}{"spec": spec}}
+	vpa.SetGroupVersionKind(VerticalPodAutoscalerGVK)
+	vpa.SetName(componentMeta.Name)
+	vpa.SetNamespace(componentMeta.Namespace)
+	vpa.SetLabels(componentMeta.Labels)
+	vpa.SetAnnotations(componentMeta.Annotations)
+	return vpa, nil
+}
+
+// checkVPAExist checks if the vpa exists?
+func (r *VPAReconciler) checkVPAExist(client client.Client) (constants.CheckResultType, *unstructured.Unstructured, error) {
+	// get vpa
+	existing := &unstructured.Unstructured{}
+	existing.SetGroupVersionKind(VerticalPodAutoscalerGVK)
+	err := client.Get(context.TODO(), types.NamespacedName{
+		Namespace: r.componentMeta.Namespace,
+		Name:      r.componentMeta.Name,
+	}, existing)
+	if err != nil {
+		if r.VPA == nil && (apierr.IsNotFound(err) || meta.IsNoMatchError(err)) {
+			// nothing to clean up, the VPA CRDs are not required unless a VPA is requested
+			return constants.CheckResultSkipped, nil, nil
+		}
+		if apierr.IsNotFound(err) {
+			return constants.CheckResultCreate, nil, nil
+		}
+		return constants.CheckResultUnknown, nil, err
+	}
+
+	// existed, check equivalent
+	if r.VPA == nil {
+		// a VPA created by the user for the deployment is left alone
+		if r.owner == nil || !metav1.IsControlledBy(existing, r.owner) {
+			return constants.CheckResultSkipped, nil, nil
+		}
+		return constants.CheckResultDelete, existing, nil
+	}
+	if equality.Semantic.DeepEqual(r.VPA.Object["spec"], existing.Object["spec"]) {
+		return constants.CheckResultExisted, existing, nil
+	}
+	return constants.CheckResultUpdate, existing, nil
+}
+
+// Reconcile ...
+func (r *VPAReconciler) Reconcile() (*unstructured.Unstructured, error) {
+	// reconcile VerticalPodAutoscaler
+	checkResult, existing, err := r.checkVPAExist(r.client)
+	log.Info("VerticalPodAutoscaler reconcile", "checkResult", checkResult, "err", err)
+	if err != nil {
+		return nil, err
+	}
+
+	switch checkResult {
+	case constants.CheckResultCreate:
+		err = r.client.Create(context.TODO(), r.VPA)
+	case constants.CheckResultUpdate:
+		existing.Object["spec"] = r.VPA.Object["spec"]
+		err = r.client.Update(context.TODO(), existing)
+	case constants.CheckResultDelete:
+		err = r.client.Delete(context.TODO(), existing)
+		if err == nil || apierr.IsNotFound(err) {
+			return nil, nil
+		}
+	default:
+		return existing, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return r.VPA, nil
+}
+
+func (r *VPAReconciler) SetControllerReferences(owner metav1.Object, scheme *runtime.Scheme) error {
+	r.owner = owner
+	if r.VPA == nil {
+		return nil
+	}
+	return controllerutil.SetControllerReference(owner, r.VPA, scheme)
+}
@@ -0,0 +1,225 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package vpa
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/stretchr/testify/assert"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newScheme(t *testing.T) *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	assert.NoError(t, clientgoscheme.AddToScheme(scheme))
+	assert.NoError(t, v1beta1.AddToScheme(scheme))
+	scheme.AddKnownTypeWithName(VerticalPodAutoscalerGVK, &unstructured.Unstructured{})
+	return scheme
+}
+
+func newOwner(name string) *v1beta1.InferenceService {
+	return &v1beta1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", UID: types.UID(name)},
+	}
+}
+
+func TestCreateVPA(t *testing.T) {
+	scenarios := map[string]struct {
+		componentMeta    metav1.ObjectMeta
+		expectedVPA      bool
+		expectedMode     string
+		expectedPolicies []interface{}
+		expectedErr      bool
+	}{
+		"NoAnnotation": {
+			componentMeta: metav1.ObjectMeta{
+				Name:      "sklearn-predictor",
+				Namespace: "default",
+				Labels:    map[string]string{constants.KServiceComponentLabel: string(v1beta1.PredictorComponent)},
+			},
+			expectedVPA: false,
+		},
+		"Transformer": {
+			componentMeta: metav1.ObjectMeta{
+				Name:        "sklearn-transformer",
+				Namespace:   "default",
+				Labels:      map[string]string{constants.KServiceComponentLabel: string(v1beta1.TransformerComponent)},
+				Annotations: map[string]string{constants.VPAUpdateModeAnnotationKey: constants.VPAUpdateModeOff},
+			},
+			expectedVPA: false,
+		},
+		"RecommendationOnly": {
+			componentMeta: metav1.ObjectMeta{
+				Name:        "sklearn-predictor",
+				Namespace:   "default",
+				Labels:      map[string]string{constants.KServiceComponentLabel: string(v1beta1.PredictorComponent)},
+				Annotations: map[string]string{constants.VPAUpdateModeAnnotationKey: constants.VPAUpdateModeOff},
+			},
+			expectedVPA:  true,
+			expectedMode: constants.VPAUpdateModeOff,
+			expectedPolicies: []interface{}{
+				map[string]interface{}{"containerName": constants.InferenceServiceContainerName},
+				map[string]interface{}{"containerName": "*", "mode": "Off"},
+			},
+		},
+		"AutoWithMinMaxAllowed": {
+			componentMeta: metav1.ObjectMeta{
+				Name:      "sklearn-predictor",
+				Namespace: "default",
+				Labels:    map[string]string{constants.KServiceComponentLabel: string(v1beta1.PredictorComponent)},
+				Annotations: map[string]string{
+					constants.VPAUpdateModeAnnotationKey: constants.VPAUpdateModeAuto,
+					constants.VPAMinAllowedAnnotationKey: `{"memory": "1Gi"}`,
+					constants.VPAMaxAllowedAnnotationKey: `{"memory": "8Gi", "cpu": "2"}`,
+				},
+			},
+			expectedVPA:  true,
+			expectedMode: constants.VPAUpdateModeAuto,
+			expectedPolicies: []interface{}{
+				map[string]interface{}{
+					"containerName": constants.InferenceServiceContainerName,
+					"minAllowed":    map[string]interface{}{"memory": "1Gi"},
+					"maxAllowed":    map[string]interface{}{"memory": "8Gi", "cpu": "2"},
+				},
+				map[string]interface{}{"containerName": "*", "mode": "Off"},
+			},
+		},
+		"UnsupportedResource": {
+			componentMeta: metav1.ObjectMeta{
+				Name:      "sklearn-predictor",
+				Namespace: "default",
+				Labels:    map[string]string{constants.KServiceComponentLabel: string(v1beta1.PredictorComponent)},
+				Annotations: map[string]string{
+					constants.VPAUpdateModeAnnotationKey: constants.VPAUpdateModeAuto,
+					constants.VPAMaxAllowedAnnotationKey: `{"nvidia.com/gpu": "1"}`,
+				},
+			},
+			expectedErr: true,
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			vpa, err := createVPA(scenario.componentMeta)
+			if scenario.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			if !scenario.expectedVPA {
+				assert.Nil(t, vpa)
+				return
+			}
+			assert.Equal(t, VerticalPodAutoscalerGVK, vpa.GroupVersionKind())
+			target, _, _ := unstructured.NestedStringMap(vpa.Object, "spec", "targetRef")
+			if diff := cmp.Diff(map[string]string{"apiVersion": "apps/v1", "kind": "Deployment", "name": "sklearn-predictor"}, target); diff != "" {
+				t.Errorf("unexpected target (-want +got): %v", diff)
+			}
+			mode, _, _ := unstructured.NestedString(vpa.Object, "spec", "updatePolicy", "updateMode")
+			assert.Equal(t, scenario.expectedMode, mode)
+			policies, _, _ := unstructured.NestedSlice(vpa.Object, "spec", "resourcePolicy", "containerPolicies")
+			if diff := cmp.Diff(scenario.expectedPolicies, policies); diff != "" {
+				t.Errorf("unexpected container policies (-want +got): %v", diff)
+			}
+		})
+	}
+}
+
+func TestVPAReconcile(t *testing.T) {
+	componentMeta := metav1.ObjectMeta{
+		Name:        "sklearn-predictor",
+		Namespace:   "default",
+		Labels:      map[string]string{constants.KServiceComponentLabel: string(v1beta1.PredictorComponent)},
+		Annotations: map[string]string{constants.VPAUpdateModeAnnotationKey: constants.VPAUpdateModeOff},
+	}
+	client := fake.NewClientBuilder().WithScheme(newScheme(t)).Build()
+	key := types.NamespacedName{Name: "sklearn-predictor", Namespace: "default"}
+	owner := newOwner("sklearn")
+
+	reconciler, err := NewVPAReconciler(client, client.Scheme(), componentMeta)
+	assert.NoError(t, err)
+	assert.NoError(t, reconciler.SetControllerReferences(owner, client.Scheme()))
+	_, err = reconciler.Reconcile()
+	assert.NoError(t, err)
+	vpa := &unstructured.Unstructured{}
+	vpa.SetGroupVersionKind(VerticalPodAutoscalerGVK)
+	assert.NoError(t, client.Get(context.TODO(), key, vpa))
+	mode, _, _ := unstructured.NestedString(vpa.Object, "spec", "updatePolicy", "updateMode")
+	assert.Equal(t, constants.VPAUpdateModeOff, mode)
+
+	// changing the update mode updates the existing vpa
+	componentMeta.Annotations[constants.VPAUpdateModeAnnotationKey] = constants.VPAUpdateModeAuto
+	reconciler, err = NewVPAReconciler(client, client.Scheme(), componentMeta)
+	assert.NoError(t, err)
+	assert.NoError(t, reconciler.SetControllerReferences(owner, client.Scheme()))
+	_, err = reconciler.Reconcile()
+	assert.NoError(t, err)
+	assert.NoError(t, client.Get(context.TODO(), key, vpa))
+	mode, _, _ = unstructured.NestedString(vpa.Object, "spec", "updatePolicy", "updateMode")
+	assert.Equal(t, constants.VPAUpdateModeAuto, mode)
+
+	// removing the annotation deletes the vpa once the owner is known
+	delete(componentMeta.Annotations, constants.VPAUpdateModeAnnotationKey)
+	reconciler, err = NewVPAReconciler(client, client.Scheme(), componentMeta)
+	assert.NoError(t, err)
+	assert.NoError(t, reconciler.SetControllerReferences(newOwner("other"), client.Scheme()))
+	_, err = reconciler.Reconcile()
+	assert.NoError(t, err)
+	assert.NoError(t, client.Get(context.TODO(), key, vpa))
+	reconciler, err = NewVPAReconciler(client, client.Scheme(), componentMeta)
+	assert.NoError(t, err)
+	assert.NoError(t, reconciler.SetControllerReferences(owner, client.Scheme()))
+	_, err = reconciler.Reconcile()
+	assert.NoError(t, err)
+	err = client.Get(context.TODO(), key, vpa)
+	assert.True(t, apierr.IsNotFound(err))
+}
+
+func TestVPAReconcileKeepsVPANotControlledByOwner(t *testing.T) {
+	vpa := &unstructured.Unstructured{}
+	vpa.SetGroupVersionKind(VerticalPodAutoscalerGVK)
+	vpa.SetName("sklearn-predictor")
+	vpa.SetNamespace("default")
+	client := fake.NewClientBuilder().WithScheme(newScheme(t)).WithObjects(vpa).Build()
+
+	reconciler, err := NewVPAReconciler(client, client.Scheme(), metav1.ObjectMeta{Name: "sklearn-predictor", Namespace: "default"})
+	assert.NoError(t, err)
+	assert.NoError(t, reconciler.SetControllerReferences(newOwner("sklearn"), client.Scheme()))
+	result, err := reconciler.Reconcile()
+	assert.NoError(t, err)
+	assert.Nil(t, result)
+	assert.NoError(t, client.Get(context.TODO(), types.NamespacedName{Name: "sklearn-predictor", Namespace: "default"}, vpa))
+}
+
+func TestVPAReconcileWithoutVPAInstalled(t *testing.T) {
+	scheme := runtime.NewScheme()
+	assert.NoError(t, clientgoscheme.AddToScheme(scheme))
+	client := fake.NewClientBuilder().WithScheme(scheme).Build()
+	reconciler, err := NewVPAReconciler(client, scheme, metav1.ObjectMeta{Name: "sklearn-predictor", Namespace: "default"})
+	assert.NoError(t, err)
+	_, err = reconciler.Reconcile()
+	assert.NoError(t, err)
+}
@@ -21,10 +21,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"html/template"
 	"regexp"
 	"sort"
 	"strings"
+	"text/template"
 
 	"k8s.io/apimachinery/pkg/util/strategicpatch"
 
@@ -39,6 +39,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -211,9 +212,68 @@ func MergeRuntimeContainers(runtimeContainer *v1.Container, predictorContainer *
 	// Strategic merge patch will replace args but more useful behaviour here is to concatenate
 	mergedContainer.Args = append(append([]string{}, runtimeContainer.Args...), predictorContainer.Args...)
 
+	// Strategic merge patch would combine the handlers of both probes which results in an invalid probe,
+	// so a probe specified in the predictor replaces the runtime probe as a whole.
+	mergedContainer.ReadinessProbe = mergeProbe(runtimeContainer.ReadinessProbe, predictorContainer.ReadinessProbe)
+	mergedContainer.LivenessProbe = mergeProbe(runtimeContainer.LivenessProbe, predictorContainer.LivenessProbe)
+	mergedContainer.StartupProbe = mergeProbe(runtimeContainer.StartupProbe, predictorContainer.StartupProbe)
+
 	return &mergedContainer, nil
 }
 
+func mergeProbe(runtimeProbe *v1.Probe, predictorProbe *v1.Probe) *v1.Probe {
+	if predictorProbe != nil {
+		return predictorProbe.DeepCopy()
+	}
+	return runtimeProbe.DeepCopy()
+}
+
+// SetDefaultReadinessProbe sets an HTTP readiness probe derived from the protocol version on the container
+// if it does not define one. Protocols without an HTTP health endpoint are left untouched.
+func SetDefaultReadinessProbe(container *v1.Container, protocol constants.InferenceServiceProtocol, modelName string) {
+	if container.ReadinessProbe != nil {
+		return
+	}
+	var path string
+	switch protocol {
+	case constants.ProtocolV1:
+		path = constants.InferenceServicePrefix(modelName)
+	case constants.ProtocolV2:
+		path = constants.V2HealthReadyPath
+	default:
+		return
+	}
+	container.ReadinessProbe = &v1.Probe{
+		ProbeHandler: v1.ProbeHandler{
+			HTTPGet: &v1.HTTPGetAction{
+				Path:   path,
+				Port:   httpServingPort(container),
+				Scheme: v1.URISchemeHTTP,
+			},
+		},
+		TimeoutSeconds:   1,
+		PeriodSeconds:    10,
+		SuccessThreshold: 1,
+		FailureThreshold: 3,
+	}
+}
+
+// httpServingPort returns the container port serving the HTTP protocols: the port named http1, else the first TCP
+// port which is not the gRPC port, else the default HTTP port.
+func httpServingPort(container *v1.Container) intstr.IntOrString {
+	for _, port := range container.Ports {
+		if port.Name == constants.HttpPortName {
+			return intstr.FromInt(int(port.ContainerPort))
+		}
+	}
+	for _, port := range container.Ports {
+		if port.Name != constants.GrpcPortName && (port.Protocol == "" || port.Protocol == v1.ProtocolTCP) {
+			return intstr.FromInt(int(port.ContainerPort))
+		}
+	}
+	return intstr.Parse(constants.InferenceServiceDefaultHttpPort)
+}
+
 // MergePodSpec Merge the predictor PodSpec struct with the runtime PodSpec struct, allowing users
 // to override runtime PodSpec settings from the predictor spec.
 func MergePodSpec(runtimePodSpec *v1alpha1.ServingRuntimePodSpec, predictorPodSpec *v1beta1.PodSpec) (*v1.PodSpec, error) {
@@ -249,11 +309,12 @@ func MergePodSpec(runtimePodSpec *v1alpha1.ServingRuntimePodSpec, predictorPodSp
 
 // GetServingRuntime Get a ServingRuntime by name. First, ServingRuntimes in the given namespace will be checked.
 // If a resource of the specified name is not found, then ClusterServingRuntimes will be checked.
-func GetServingRuntime(cl client.Client, name string, namespace string) (*v1alpha1.ServingRuntimeSpec, error) {
+// The spec of a ServingRuntime inheriting from a ClusterServingRuntime is merged over the spec of the latter.
+func GetServingRuntime(cl client.Client, name string, namespace string) (*v1alpha1.SupportedRuntime, error) {
 	runtime := &v1alpha1.ServingRuntime{}
 	err := cl.Get(context.TODO(), client.ObjectKey{Name: name, Namespace: namespace}, runtime)
 	if err == nil {
-		return &runtime.Spec, nil
+		return v1alpha1.ResolveServingRuntime(cl, runtime)
 	} else if !errors.IsNotFound(err) {
 		return nil, err
 	}
@@ -270,19 +331,92 @@ func GetServingRuntime(cl client.Client, name string, namespace string) (*v1alph
 	return nil, goerrors.New("No ServingRuntimes with the name: " + name)
 }
 
-// ReplacePlaceholders Replace placeholders in runtime container by values from inferenceservice metadata
-func ReplacePlaceholders(container *v1.Container, meta metav1.ObjectMeta) error {
-	data, _ := json.Marshal(container)
-	tmpl, err := template.New("container-tmpl").Parse(string(data))
+// Variables available to the templates in the args and env values of ServingRuntime containers,
+// e.g. "--model_name={{.ModelName}}" or "{{.Labels.modelClass}}".
+const (
+	// PlaceholderName is the name of the InferenceService
+	PlaceholderName = "Name"
+	// PlaceholderNamespace is the namespace of the InferenceService
+	PlaceholderNamespace = "Namespace"
+	// PlaceholderLabels is the label map of the InferenceService, a missing key is an error
+	PlaceholderLabels = "Labels"
+	// PlaceholderAnnotations is the annotation map of the InferenceService, a missing key is an error
+	PlaceholderAnnotations = "Annotations"
+	// PlaceholderModelName is the name the model is served under
+	PlaceholderModelName = "ModelName"
+	// PlaceholderStorageUri is the storage uri of the predictor model
+	PlaceholderStorageUri = "StorageUri"
+	// PlaceholderProtocol is the inference protocol of the predictor model
+	PlaceholderProtocol = "Protocol"
+)
+
+// SupportedPlaceholders lists the variables of PlaceholderData
+var SupportedPlaceholders = []string{PlaceholderName, PlaceholderNamespace, PlaceholderLabels, PlaceholderAnnotations,
+	PlaceholderModelName, PlaceholderStorageUri, PlaceholderProtocol}
+
+// PlaceholderData is the data the templates in the runtime container are executed against.
+// A literal "{{" can be written as {{"{{"}}.
+type PlaceholderData struct {
+	Name        string
+	Namespace   string
+	Labels      map[string]string
+	Annotations map[string]string
+	ModelName   string
+	StorageUri  string
+	Protocol    string
+}
+
+// NewPlaceholderData builds the template variables of the predictor model of the inferenceservice
+func NewPlaceholderData(isvc *v1beta1api.InferenceService) PlaceholderData {
+	data := PlaceholderData{
+		Name:        isvc.Name,
+		Namespace:   isvc.Namespace,
+		Labels:      isvc.Labels,
+		Annotations: isvc.Annotations,
+		ModelName:   GetModelName(isvc),
+	}
+	if isvc.Spec.Predictor.Model != nil {
+		if isvc.Spec.Predictor.Model.StorageURI != nil {
+			data.StorageUri = *isvc.Spec.Predictor.Model.StorageURI
+		}
+		data.Protocol = string(isvc.Spec.Predictor.Model.GetProtocol())
+	}
+	return data
+}
+
+// ReplacePlaceholders Replace placeholders in the args and env values of the runtime container by the template variables.
+// Unknown variables and missing label or annotation keys are reported as errors.
+func ReplacePlaceholders(container *v1.Container, data PlaceholderData) error {
+	for i, arg := range container.Args {
+		value, err := expandPlaceholders(arg, data)
+		if err != nil {
+			return fmt.Errorf("invalid arg %q of the container %s: %w", arg, container.Name, err)
+		}
+		container.Args[i] = value
+	}
+	for i, env := range container.Env {
+		value, err := expandPlaceholders(env.Value, data)
+		if err != nil {
+			return fmt.Errorf("invalid value %q of the env %s of the container %s: %w", env.Value, env.Name, container.Name, err)
+		}
+		container.Env[i].Value = value
+	}
+	return nil
+}
+
+func expandPlaceholders(value string, data PlaceholderData) (string, error) {
+	if !strings.Contains(value, "{{") {
+		return value, nil
+	}
+	tmpl, err := template.New("container-tmpl").Option("missingkey=error").Parse(value)
 	if err != nil {
-		return err
+		return "", err
 	}
 	buf := &bytes.Buffer{}
-	err = tmpl.Execute(buf, meta)
-	if err != nil {
-		return err
+	if err = tmpl.Execute(buf, data); err != nil {
+		return "", fmt.Errorf("%w, supported variables are %s", err, strings.Join(SupportedPlaceholders, ", "))
 	}
-	return json.Unmarshal(buf.Bytes(), container)
+	return buf.String(), nil
 }
 
 // UpdateImageTag Update image tag if GPU is enabled or runtime version is provided
@@ -325,6 +459,82 @@ func ListPodsByLabel(cl client.Client, namespace string, labelKey string, labelV
 	return podList, nil
 }
 
+// AnnotateStorageInitializerDuration sets the storage-initializer-duration annotation on the pods whose storage
+// initializer reported the duration of the model download in its termination message.
+func AnnotateStorageInitializerDuration(cl client.Client, podList *v1.PodList) error {
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		if _, ok := pod.Annotations[constants.StorageInitializerDurationAnnotationKey]; ok {
+			continue
+		}
+		for _, cs := range pod.Status.InitContainerStatuses {
+			if cs.Name != constants.StorageInitializerContainerName {
+				continue
+			}
+			duration, ok := v1beta1.GetStorageInitializerDownloadDuration(cs)
+			if !ok {
+				break
+			}
+			patch := client.MergeFrom(pod.DeepCopy())
+			if pod.Annotations == nil {
+				pod.Annotations = map[string]string{}
+			}
+			pod.Annotations[constants.StorageInitializerDurationAnnotationKey] = duration.String()
+			if err := cl.Patch(context.TODO(), pod, patch); err != nil && !errors.IsNotFound(err) {
+				return err
+			}
+			break
+		}
+	}
+	return nil
+}
+
+// DryRunCreate performs a server side dry-run create of a child resource which does not exist yet and of a pod
+// built from its pod template, since quotas, limit ranges and pod security admission only act on pods. It returns
+// the error of the API server, nil when the child already exists or both dry-runs succeed.
+func DryRunCreate(cl client.Client, child client.Object, template *v1.PodTemplateSpec) error {
+	existing := child.DeepCopyObject().(client.Object)
+	err := cl.Get(context.TODO(), client.ObjectKeyFromObject(child), existing)
+	if err == nil {
+		return nil
+	}
+	if !errors.IsNotFound(err) {
+		return err
+	}
+	if err := cl.Create(context.TODO(), child.DeepCopyObject().(client.Object), client.DryRunAll); err != nil {
+		return err
+	}
+	if template == nil {
+		return nil
+	}
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: child.GetName() + "-",
+			Namespace:    child.GetNamespace(),
+			Labels:       template.Labels,
+			Annotations:  template.Annotations,
+		},
+		Spec: *template.Spec.DeepCopy(),
+	}
+	// Knative names the user container when creating the revision
+	for i := range pod.Spec.Containers {
+		if pod.Spec.Containers[i].Name == "" {
+			pod.Spec.Containers[i].Name = fmt.Sprintf("%s-%d", constants.InferenceServiceContainerName, i)
+		}
+	}
+	return cl.Create(context.TODO(), pod, client.DryRunAll)
+}
+
+// rbacForbiddenRegex matches the message of the Forbidden errors of the RBAC authorizer, e.g.
+// `pods is forbidden: User "system:serviceaccount:kserve:kserve-controller-manager" cannot create resource "pods"`
+var rbacForbiddenRegex = regexp.MustCompile(`User "[^"]*" cannot [a-z]+ resource`)
+
+// IsRBACForbidden returns whether the error is a Forbidden error of the RBAC authorizer, i.e. the request of the
+// controller itself was not authorized, as opposed to the request being rejected by a quota or an admission policy.
+func IsRBACForbidden(err error) bool {
+	return errors.IsForbidden(err) && rbacForbiddenRegex.MatchString(err.Error())
+}
+
 func sortPodsByCreatedTimestampDesc(pods *v1.PodList) {
 	sort.Slice(pods.Items, func(i, j int) bool {
 		return pods.Items[j].ObjectMeta.CreationTimestamp.Before(&pods.Items[i].ObjectMeta.CreationTimestamp)
@@ -17,6 +17,9 @@ limitations under the License.
 package utils
 
 import (
+	"context"
+	"fmt"
+	"reflect"
 	"strconv"
 	"testing"
 
@@ -29,12 +32,21 @@ import (
 	. "github.com/kserve/kserve/pkg/apis/serving/v1beta1"
 	"github.com/kserve/kserve/pkg/constants"
 	"github.com/onsi/gomega"
+	goerrors "github.com/pkg/errors"
 	"google.golang.org/protobuf/proto"
+	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 )
 
 func TestIsMMSPredictor(t *testing.T) {
@@ -765,6 +777,79 @@ func TestMergeRuntimeContainers(t *testing.T) {
 				},
 			},
 		},
+		"PredictorProbeReplacesRuntimeProbe": {
+			containerBase: &v1.Container{
+				Name:  "kserve-container",
+				Image: "default-image",
+				ReadinessProbe: &v1.Probe{
+					ProbeHandler: v1.ProbeHandler{
+						HTTPGet: &v1.HTTPGetAction{Path: "/v2/health/ready", Port: intstr.FromInt(8080)},
+					},
+					PeriodSeconds: 5,
+				},
+			},
+			containerOverride: &v1.Container{
+				ReadinessProbe: &v1.Probe{
+					ProbeHandler: v1.ProbeHandler{
+						TCPSocket: &v1.TCPSocketAction{Port: intstr.FromInt(8081)},
+					},
+				},
+			},
+			expected: &v1.Container{
+				Name:  "kserve-container",
+				Image: "default-image",
+				Args:  []string{},
+				ReadinessProbe: &v1.Probe{
+					ProbeHandler: v1.ProbeHandler{
+						TCPSocket: &v1.TCPSocketAction{Port: intstr.FromInt(8081)},
+					},
+				},
+			},
+		},
+		"RuntimeProbesPropagated": {
+			containerBase: &v1.Container{
+				Name:  "kserve-container",
+				Image: "default-image",
+				ReadinessProbe: &v1.Probe{
+					ProbeHandler: v1.ProbeHandler{
+						HTTPGet: &v1.HTTPGetAction{Path: "/v2/health/ready", Port: intstr.FromInt(8080)},
+					},
+				},
+				LivenessProbe: &v1.Probe{
+					ProbeHandler: v1.ProbeHandler{
+						HTTPGet: &v1.HTTPGetAction{Path: "/v2/health/live", Port: intstr.FromInt(8080)},
+					},
+				},
+				StartupProbe: &v1.Probe{
+					ProbeHandler: v1.ProbeHandler{
+						HTTPGet: &v1.HTTPGetAction{Path: "/v2/health/ready", Port: intstr.FromInt(8080)},
+					},
+					FailureThreshold: 60,
+				},
+			},
+			containerOverride: &v1.Container{},
+			expected: &v1.Container{
+				Name:  "kserve-container",
+				Image: "default-image",
+				Args:  []string{},
+				ReadinessProbe: &v1.Probe{
+					ProbeHandler: v1.ProbeHandler{
+						HTTPGet: &v1.HTTPGetAction{Path: "/v2/health/ready", Port: intstr.FromInt(8080)},
+					},
+				},
+				LivenessProbe: &v1.Probe{
+					ProbeHandler: v1.ProbeHandler{
+						HTTPGet: &v1.HTTPGetAction{Path: "/v2/health/live", Port: intstr.FromInt(8080)},
+					},
+				},
+				StartupProbe: &v1.Probe{
+					ProbeHandler: v1.ProbeHandler{
+						HTTPGet: &v1.HTTPGetAction{Path: "/v2/health/ready", Port: intstr.FromInt(8080)},
+					},
+					FailureThreshold: 60,
+				},
+			},
+		},
 	}
 
 	for name, scenario := range scenarios {
@@ -777,9 +862,180 @@ func TestMergeRuntimeContainers(t *testing.T) {
 	}
 }
 
+func TestSetDefaultReadinessProbe(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	userProbe := &v1.Probe{
+		ProbeHandler: v1.ProbeHandler{
+			Exec: &v1.ExecAction{Command: []string{"cat", "/tmp/ready"}},
+		},
+	}
+	scenarios := map[string]struct {
+		container *v1.Container
+		protocol  constants.InferenceServiceProtocol
+		expected  *v1.Probe
+	}{
+		"ProtocolV1": {
+			container: &v1.Container{Name: constants.InferenceServiceContainerName},
+			protocol:  constants.ProtocolV1,
+			expected: &v1.Probe{
+				ProbeHandler: v1.ProbeHandler{
+					HTTPGet: &v1.HTTPGetAction{
+						Path:   "/v1/models/sklearn",
+						Port:   intstr.FromInt(8080),
+						Scheme: v1.URISchemeHTTP,
+					},
+				},
+				TimeoutSeconds:   1,
+				PeriodSeconds:    10,
+				SuccessThreshold: 1,
+				FailureThreshold: 3,
+			},
+		},
+		"ProtocolV2WithContainerPort": {
+			container: &v1.Container{
+				Name:  constants.InferenceServiceContainerName,
+				Ports: []v1.ContainerPort{{ContainerPort: 9000}},
+			},
+			protocol: constants.ProtocolV2,
+			expected: &v1.Probe{
+				ProbeHandler: v1.ProbeHandler{
+					HTTPGet: &v1.HTTPGetAction{
+						Path:   "/v2/health/ready",
+						Port:   intstr.FromInt(9000),
+						Scheme: v1.URISchemeHTTP,
+					},
+				},
+				TimeoutSeconds:   1,
+				PeriodSeconds:    10,
+				SuccessThreshold: 1,
+				FailureThreshold: 3,
+			},
+		},
+		"GRPCPortFirst": {
+			container: &v1.Container{
+				Name:  constants.InferenceServiceContainerName,
+				Ports: []v1.ContainerPort{{Name: constants.GrpcPortName, ContainerPort: 8081}, {ContainerPort: 9000}},
+			},
+			protocol: constants.ProtocolV2,
+			expected: &v1.Probe{
+				ProbeHandler: v1.ProbeHandler{
+					HTTPGet: &v1.HTTPGetAction{
+						Path:   "/v2/health/ready",
+						Port:   intstr.FromInt(9000),
+						Scheme: v1.URISchemeHTTP,
+					},
+				},
+				TimeoutSeconds:   1,
+				PeriodSeconds:    10,
+				SuccessThreshold: 1,
+				FailureThreshold: 3,
+			},
+		},
+		"NamedHTTPPort": {
+			container: &v1.Container{
+				Name:  constants.InferenceServiceContainerName,
+				Ports: []v1.ContainerPort{{Name: "metrics", ContainerPort: 8082}, {Name: constants.HttpPortName, ContainerPort: 9000}},
+			},
+			protocol: constants.ProtocolV1,
+			expected: &v1.Probe{
+				ProbeHandler: v1.ProbeHandler{
+					HTTPGet: &v1.HTTPGetAction{
+						Path:   "/v1/models/sklearn",
+						Port:   intstr.FromInt(9000),
+						Scheme: v1.URISchemeHTTP,
+					},
+				},
+				TimeoutSeconds:   1,
+				PeriodSeconds:    10,
+				SuccessThreshold: 1,
+				FailureThreshold: 3,
+			},
+		},
+		"OnlyGRPCPort": {
+			container: &v1.Container{
+				Name:  constants.InferenceServiceContainerName,
+				Ports: []v1.ContainerPort{{Name: constants.GrpcPortName, ContainerPort: 8081}},
+			},
+			protocol: constants.ProtocolV2,
+			expected: &v1.Probe{
+				ProbeHandler: v1.ProbeHandler{
+					HTTPGet: &v1.HTTPGetAction{
+						Path:   "/v2/health/ready",
+						Port:   intstr.FromInt(8080),
+						Scheme: v1.URISchemeHTTP,
+					},
+				},
+				TimeoutSeconds:   1,
+				PeriodSeconds:    10,
+				SuccessThreshold: 1,
+				FailureThreshold: 3,
+			},
+		},
+		"UDPPortFirst": {
+			container: &v1.Container{
+				Name:  constants.InferenceServiceContainerName,
+				Ports: []v1.ContainerPort{{ContainerPort: 5000, Protocol: v1.ProtocolUDP}, {ContainerPort: 9000}},
+			},
+			protocol: constants.ProtocolV2,
+			expected: &v1.Probe{
+				ProbeHandler: v1.ProbeHandler{
+					HTTPGet: &v1.HTTPGetAction{
+						Path:   "/v2/health/ready",
+						Port:   intstr.FromInt(9000),
+						Scheme: v1.URISchemeHTTP,
+					},
+				},
+				TimeoutSeconds:   1,
+				PeriodSeconds:    10,
+				SuccessThreshold: 1,
+				FailureThreshold: 3,
+			},
+		},
+		"ProtocolGRPCV2": {
+			container: &v1.Container{Name: constants.InferenceServiceContainerName},
+			protocol:  constants.ProtocolGRPCV2,
+			expected:  nil,
+		},
+		"UserProbeNotOverwritten": {
+			container: &v1.Container{
+				Name:           constants.InferenceServiceContainerName,
+				ReadinessProbe: userProbe.DeepCopy(),
+			},
+			protocol: constants.ProtocolV2,
+			expected: userProbe,
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			SetDefaultReadinessProbe(scenario.container, scenario.protocol, "sklearn")
+			g.Expect(scenario.container.ReadinessProbe).To(gomega.Equal(scenario.expected))
+		})
+	}
+}
+
 func TestMergePodSpec(t *testing.T) {
 	g := gomega.NewGomegaWithT(t)
 
+	nodeAffinity := func(key string, values ...string) *v1.Affinity {
+		return &v1.Affinity{
+			NodeAffinity: &v1.NodeAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution: &v1.NodeSelector{
+					NodeSelectorTerms: []v1.NodeSelectorTerm{
+						{
+							MatchExpressions: []v1.NodeSelectorRequirement{
+								{Key: key, Operator: v1.NodeSelectorOpIn, Values: values},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+	gpuAffinity := nodeAffinity("nvidia.com/gpu.product", "NVIDIA-A100-SXM4-80GB", "NVIDIA-H100-80GB-HBM3")
+	a100Affinity := nodeAffinity("nvidia.com/gpu.product", "NVIDIA-A100-SXM4-80GB")
+
 	scenarios := map[string]struct {
 		podSpecBase     *v1alpha1.ServingRuntimePodSpec
 		podSpecOverride *v1beta1.PodSpec
@@ -887,6 +1143,56 @@ func TestMergePodSpec(t *testing.T) {
 				},
 			},
 		},
+		"RuntimeSchedulingApplied": {
+			podSpecBase: &v1alpha1.ServingRuntimePodSpec{
+				NodeSelector: map[string]string{
+					"nvidia.com/gpu.present": "true",
+				},
+				Tolerations: []v1.Toleration{
+					{Key: "nvidia.com/gpu", Operator: v1.TolerationOpExists, Effect: v1.TaintEffectNoSchedule},
+				},
+				Affinity: gpuAffinity,
+			},
+			podSpecOverride: &v1beta1.PodSpec{},
+			expected: &v1.PodSpec{
+				NodeSelector: map[string]string{
+					"nvidia.com/gpu.present": "true",
+				},
+				Tolerations: []v1.Toleration{
+					{Key: "nvidia.com/gpu", Operator: v1.TolerationOpExists, Effect: v1.TaintEffectNoSchedule},
+				},
+				Affinity: gpuAffinity,
+			},
+		},
+		"InferenceServiceSchedulingTakesPrecedence": {
+			podSpecBase: &v1alpha1.ServingRuntimePodSpec{
+				NodeSelector: map[string]string{
+					"nvidia.com/gpu.present": "true",
+				},
+				Tolerations: []v1.Toleration{
+					{Key: "nvidia.com/gpu", Operator: v1.TolerationOpExists, Effect: v1.TaintEffectNoSchedule},
+				},
+				Affinity: gpuAffinity,
+			},
+			podSpecOverride: &v1beta1.PodSpec{
+				NodeSelector: map[string]string{
+					"nvidia.com/gpu.present": "false",
+				},
+				Tolerations: []v1.Toleration{
+					{Key: "dedicated", Operator: v1.TolerationOpEqual, Value: "inference", Effect: v1.TaintEffectNoSchedule},
+				},
+				Affinity: a100Affinity,
+			},
+			expected: &v1.PodSpec{
+				NodeSelector: map[string]string{
+					"nvidia.com/gpu.present": "false",
+				},
+				Tolerations: []v1.Toleration{
+					{Key: "dedicated", Operator: v1.TolerationOpEqual, Value: "inference", Effect: v1.TaintEffectNoSchedule},
+				},
+				Affinity: a100Affinity,
+			},
+		},
 	}
 
 	for name, scenario := range scenarios {
@@ -988,8 +1294,8 @@ func TestGetServingRuntime(t *testing.T) {
 	for name, scenario := range scenarios {
 		t.Run(name, func(t *testing.T) {
 			res, _ := GetServingRuntime(mockClient, scenario.runtimeName, namespace)
-			if !g.Expect(res).To(gomega.Equal(&scenario.expected)) {
-				t.Errorf("got %v, want %v", res, &scenario.expected)
+			if !g.Expect(&res.Spec).To(gomega.Equal(&scenario.expected)) {
+				t.Errorf("got %v, want %v", res.Spec, &scenario.expected)
 			}
 		})
 	}
@@ -1005,12 +1311,67 @@ func TestGetServingRuntime(t *testing.T) {
 
 }
 
+func TestGetServingRuntimeInheritFrom(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	clusterRuntime := &v1alpha1.ClusterServingRuntime{
+		ObjectMeta: metav1.ObjectMeta{Name: "kserve-sklearnserver", Generation: 2},
+		Spec: v1alpha1.ServingRuntimeSpec{
+			SupportedModelFormats: []v1alpha1.SupportedModelFormat{{Name: "sklearn", Version: proto.String("1")}},
+			ServingRuntimePodSpec: v1alpha1.ServingRuntimePodSpec{
+				Containers: []v1.Container{
+					{
+						Name:  constants.InferenceServiceContainerName,
+						Image: "kserve/sklearnserver:v0.11.0",
+						Args:  []string{"--model_name={{.Name}}"},
+					},
+				},
+			},
+		},
+	}
+	servingRuntime := &v1alpha1.ServingRuntime{
+		ObjectMeta: metav1.ObjectMeta{Name: "sklearn-debug", Namespace: "default", Generation: 5},
+		Spec: v1alpha1.ServingRuntimeSpec{
+			InheritFrom: proto.String("kserve-sklearnserver"),
+			ServingRuntimePodSpec: v1alpha1.ServingRuntimePodSpec{
+				Containers: []v1.Container{
+					{
+						Name: constants.InferenceServiceContainerName,
+						Env:  []v1.EnvVar{{Name: "LOG_LEVEL", Value: "debug"}},
+					},
+				},
+			},
+		},
+	}
+	s := runtime.NewScheme()
+	g.Expect(v1alpha1.AddToScheme(s)).To(gomega.Succeed())
+	mockClient := fake.NewClientBuilder().WithObjects(clusterRuntime, servingRuntime).WithScheme(s).Build()
+
+	res, err := GetServingRuntime(mockClient, "sklearn-debug", "default")
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(res.Name).To(gomega.Equal("sklearn-debug"))
+	g.Expect(res.Generation).To(gomega.Equal(int64(5)))
+	g.Expect(res.InheritFromGeneration).To(gomega.Equal(int64(2)))
+	g.Expect(res.Spec.SupportedModelFormats).To(gomega.Equal(clusterRuntime.Spec.SupportedModelFormats))
+	g.Expect(res.Spec.Containers).To(gomega.Equal([]v1.Container{
+		{
+			Name:  constants.InferenceServiceContainerName,
+			Image: "kserve/sklearnserver:v0.11.0",
+			Args:  []string{"--model_name={{.Name}}"},
+			Env:   []v1.EnvVar{{Name: "LOG_LEVEL", Value: "debug"}},
+		},
+	}))
+
+	g.Expect(mockClient.Delete(context.TODO(), clusterRuntime)).To(gomega.Succeed())
+	_, err = GetServingRuntime(mockClient, "sklearn-debug", "default")
+	g.Expect(err).To(gomega.MatchError(fmt.Sprintf(v1alpha1.InheritFromNotFoundError, "kserve-sklearnserver", "sklearn-debug")))
+}
+
 func TestReplacePlaceholders(t *testing.T) {
 	g := gomega.NewGomegaWithT(t)
 
 	scenarios := map[string]struct {
 		container *v1.Container
-		meta      metav1.ObjectMeta
+		data      PlaceholderData
 		expected  *v1.Container
 	}{
 		"ReplaceArgsAndEnvPlaceholders": {
@@ -1037,7 +1398,7 @@ func TestReplacePlaceholders(t *testing.T) {
 					},
 				},
 			},
-			meta: metav1.ObjectMeta{
+			data: PlaceholderData{
 				Name: "bar",
 				Labels: map[string]string{
 					"modelDir": "/mnt/models",
@@ -1067,10 +1428,61 @@ func TestReplacePlaceholders(t *testing.T) {
 				},
 			},
 		},
+		"ReplaceModelPlaceholders": {
+			container: &v1.Container{
+				Name: "kserve-container",
+				Args: []string{
+					"--model_name={{.ModelName}}",
+					"--protocol={{.Protocol}}",
+				},
+				Env: []v1.EnvVar{
+					{Name: "STORAGE_URI", Value: "{{.StorageUri}}"},
+					{Name: "NAMESPACE", Value: "{{.Namespace}}"},
+				},
+			},
+			data: PlaceholderData{
+				Name:       "bar",
+				Namespace:  "default",
+				ModelName:  "my-model",
+				StorageUri: "s3://bucket/model",
+				Protocol:   "v2",
+			},
+			expected: &v1.Container{
+				Name: "kserve-container",
+				Args: []string{
+					"--model_name=my-model",
+					"--protocol=v2",
+				},
+				Env: []v1.EnvVar{
+					{Name: "STORAGE_URI", Value: "s3://bucket/model"},
+					{Name: "NAMESPACE", Value: "default"},
+				},
+			},
+		},
+		"EscapedPlaceholders": {
+			container: &v1.Container{
+				Name: "kserve-container",
+				Args: []string{
+					`--pattern={{"{{"}}id{{"}}"}}`,
+					`--json={"name":"{{.Name}}","url":"a?b=c&d=<e>"}`,
+				},
+			},
+			data: PlaceholderData{
+				Name: "bar",
+			},
+			expected: &v1.Container{
+				Name: "kserve-container",
+				Args: []string{
+					"--pattern={{id}}",
+					`--json={"name":"bar","url":"a?b=c&d=<e>"}`,
+				},
+			},
+		},
 	}
 	for name, scenario := range scenarios {
 		t.Run(name, func(t *testing.T) {
-			_ = ReplacePlaceholders(scenario.container, scenario.meta)
+			err := ReplacePlaceholders(scenario.container, scenario.data)
+			g.Expect(err).ToNot(gomega.HaveOccurred())
 			if !g.Expect(scenario.container).To(gomega.Equal(scenario.expected)) {
 				t.Errorf("got %v, want %v", scenario.container, scenario.expected)
 			}
@@ -1078,6 +1490,46 @@ func TestReplacePlaceholders(t *testing.T) {
 	}
 }
 
+func TestReplacePlaceholdersErrors(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	scenarios := map[string]struct {
+		container *v1.Container
+		expected  string
+	}{
+		"UnknownVariable": {
+			container: &v1.Container{
+				Name: "kserve-container",
+				Args: []string{"--uid={{.UID}}"},
+			},
+			expected: "invalid arg \"--uid={{.UID}}\" of the container kserve-container",
+		},
+		"MissingLabel": {
+			container: &v1.Container{
+				Name: "kserve-container",
+				Env: []v1.EnvVar{
+					{Name: "MODEL_CLASS", Value: "{{.Labels.modelClass}}"},
+				},
+			},
+			expected: "map has no entry for key \"modelClass\"",
+		},
+		"MalformedTemplate": {
+			container: &v1.Container{
+				Name: "kserve-container",
+				Args: []string{"--model_name={{.ModelName"},
+			},
+			expected: "invalid arg",
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			err := ReplacePlaceholders(scenario.container, PlaceholderData{Name: "bar"})
+			g.Expect(err).To(gomega.HaveOccurred())
+			g.Expect(err.Error()).To(gomega.ContainSubstring(scenario.expected))
+		})
+	}
+}
+
 func TestUpdateImageTag(t *testing.T) {
 	g := gomega.NewGomegaWithT(t)
 
@@ -1816,3 +2268,179 @@ func TestValidateStorageURIForDefaultStorageInitializerCRD(t *testing.T) {
 		}
 	}
 }
+
+func TestAnnotateStorageInitializerDuration(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	newPod := func(name string, annotations map[string]string, state v1.ContainerState) *v1.Pod {
+		return &v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        name,
+				Namespace:   "default",
+				Annotations: annotations,
+			},
+			Status: v1.PodStatus{
+				InitContainerStatuses: []v1.ContainerStatus{
+					{
+						Name:  constants.StorageInitializerContainerName,
+						State: state,
+					},
+				},
+			},
+		}
+	}
+	downloaded := v1.ContainerState{
+		Terminated: &v1.ContainerStateTerminated{
+			ExitCode: 0,
+			Message:  `{"bytes": 1024, "files": 2, "durationSeconds": 92.5}`,
+		},
+	}
+	pods := []*v1.Pod{
+		newPod("downloaded", nil, downloaded),
+		newPod("annotated", map[string]string{constants.StorageInitializerDurationAnnotationKey: "1s"}, downloaded),
+		newPod("downloading", nil, v1.ContainerState{Running: &v1.ContainerStateRunning{}}),
+	}
+	s := runtime.NewScheme()
+	if err := v1.AddToScheme(s); err != nil {
+		t.Errorf("Failed to add core v1 to scheme %s", err)
+	}
+	builder := fake.NewClientBuilder().WithScheme(s)
+	podList := &v1.PodList{}
+	for _, pod := range pods {
+		builder = builder.WithObjects(pod)
+		podList.Items = append(podList.Items, *pod)
+	}
+	mockClient := builder.Build()
+
+	g.Expect(AnnotateStorageInitializerDuration(mockClient, podList)).To(gomega.Succeed())
+
+	expectedAnnotations := map[string]string{
+		"downloaded":  "1m32.5s",
+		"annotated":   "1s",
+		"downloading": "",
+	}
+	for name, expected := range expectedAnnotations {
+		pod := &v1.Pod{}
+		g.Expect(mockClient.Get(context.TODO(), k8stypes.NamespacedName{Name: name, Namespace: "default"}, pod)).To(gomega.Succeed())
+		g.Expect(pod.Annotations[constants.StorageInitializerDurationAnnotationKey]).To(gomega.Equal(expected))
+	}
+}
+
+func TestDryRunCreate(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	newDeployment := func() *appsv1.Deployment {
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "sklearn-predictor",
+				Namespace: "default",
+			},
+			Spec: appsv1.DeploymentSpec{
+				Template: v1.PodTemplateSpec{
+					Spec: v1.PodSpec{
+						Containers: []v1.Container{{Name: constants.InferenceServiceContainerName, Image: "kserve/sklearnserver"}},
+					},
+				},
+			},
+		}
+	}
+	s := runtime.NewScheme()
+	if err := v1.AddToScheme(s); err != nil {
+		t.Errorf("Failed to add core v1 to scheme %s", err)
+	}
+	if err := appsv1.AddToScheme(s); err != nil {
+		t.Errorf("Failed to add apps v1 to scheme %s", err)
+	}
+	quotaExceeded := apierr.NewForbidden(schema.GroupResource{Resource: "pods"}, "sklearn-predictor-abcde",
+		goerrors.New("exceeded quota: compute-resources, requested: limits.cpu=4, used: limits.cpu=0, limited: limits.cpu=2"))
+	invalidDeployment := apierr.NewInvalid(schema.GroupKind{Group: "apps", Kind: "Deployment"}, "sklearn-predictor",
+		field.ErrorList{field.Invalid(field.NewPath("spec", "template", "spec", "containers").Index(0).Child("image"), "", "must be set")})
+
+	scenarios := map[string]struct {
+		existing        []client.Object
+		rejectKind      string
+		rejection       error
+		matcher         types.GomegaMatcher
+		expectedCreates []string
+	}{
+		"ExistingChildIsSkipped": {
+			existing:        []client.Object{newDeployment()},
+			rejectKind:      "Deployment",
+			rejection:       invalidDeployment,
+			matcher:         gomega.Succeed(),
+			expectedCreates: nil,
+		},
+		"PodForbiddenByQuota": {
+			rejectKind:      "Pod",
+			rejection:       quotaExceeded,
+			matcher:         gomega.MatchError(quotaExceeded),
+			expectedCreates: []string{"Deployment", "Pod"},
+		},
+		"InvalidDeployment": {
+			rejectKind:      "Deployment",
+			rejection:       invalidDeployment,
+			matcher:         gomega.MatchError(invalidDeployment),
+			expectedCreates: []string{"Deployment"},
+		},
+		"Admitted": {
+			matcher:         gomega.Succeed(),
+			expectedCreates: []string{"Deployment", "Pod"},
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			var creates []string
+			mockClient := fake.NewClientBuilder().WithScheme(s).WithObjects(scenario.existing...).WithInterceptorFuncs(interceptor.Funcs{
+				Create: func(ctx context.Context, cl client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+					createOptions := &client.CreateOptions{}
+					createOptions.ApplyOptions(opts)
+					g.Expect(createOptions.DryRun).To(gomega.Equal([]string{metav1.DryRunAll}))
+					kind := reflect.TypeOf(obj).Elem().Name()
+					creates = append(creates, kind)
+					if kind == scenario.rejectKind {
+						return scenario.rejection
+					}
+					return cl.Create(ctx, obj, opts...)
+				},
+			}).Build()
+			deployment := newDeployment()
+
+			g.Expect(DryRunCreate(mockClient, deployment, &deployment.Spec.Template)).To(scenario.matcher)
+			g.Expect(creates).To(gomega.Equal(scenario.expectedCreates))
+			pods := &v1.PodList{}
+			g.Expect(mockClient.List(context.TODO(), pods)).To(gomega.Succeed())
+			g.Expect(pods.Items).To(gomega.BeEmpty())
+		})
+	}
+}
+
+func TestIsRBACForbidden(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	scenarios := map[string]struct {
+		err      error
+		expected bool
+	}{
+		"ControllerNotAllowed": {
+			err: apierr.NewForbidden(schema.GroupResource{Resource: "pods"}, "",
+				goerrors.New(`User "system:serviceaccount:kserve:kserve-controller-manager" cannot create resource "pods" in API group "" in the namespace "default"`)),
+			expected: true,
+		},
+		"QuotaExceeded": {
+			err: apierr.NewForbidden(schema.GroupResource{Resource: "pods"}, "sklearn-predictor-abcde",
+				goerrors.New("exceeded quota: compute-resources, requested: limits.cpu=4, used: limits.cpu=0, limited: limits.cpu=2")),
+			expected: false,
+		},
+		"PodSecurity": {
+			err: apierr.NewForbidden(schema.GroupResource{Resource: "pods"}, "sklearn-predictor-abcde",
+				goerrors.New(`violates PodSecurity "restricted:latest": allowPrivilegeEscalation != false`)),
+			expected: false,
+		},
+		"NotForbidden": {
+			err:      goerrors.New(`User "alice" cannot create resource "pods"`),
+			expected: false,
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g.Expect(IsRBACForbidden(scenario.err)).To(gomega.Equal(scenario.expected))
+		})
+	}
+}
@@ -22,6 +22,8 @@ import (
 
 const (
 	AzureStorageAccessKey = "AZURE_STORAGE_ACCESS_KEY"
+	// AzureStorageSasToken is the shared access signature token appended to the blob urls
+	AzureStorageSasToken = "AZURE_STORAGE_SAS_TOKEN" // #nosec G101
 	// Legacy keys for backward compatibility
 	LegacyAzureSubscriptionId = "AZ_SUBSCRIPTION_ID"
 	LegacyAzureTenantId       = "AZ_TENANT_ID"
@@ -90,3 +92,21 @@ func BuildStorageAccessKeySecretEnv(secret *v1.Secret) []v1.EnvVar {
 
 	return envs
 }
+
+func BuildStorageSasTokenSecretEnv(secret *v1.Secret) []v1.EnvVar {
+	envs := []v1.EnvVar{
+		{
+			Name: AzureStorageSasToken,
+			ValueFrom: &v1.EnvVarSource{
+				SecretKeyRef: &v1.SecretKeySelector{
+					LocalObjectReference: v1.LocalObjectReference{
+						Name: secret.Name,
+					},
+					Key: AzureStorageSasToken,
+				},
+			},
+		},
+	}
+
+	return envs
+}
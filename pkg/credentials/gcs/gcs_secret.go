@@ -25,6 +25,11 @@ const (
 	GCSCredentialVolumeName      = "user-gcp-sa"                         // #nosec G101
 	GCSCredentialVolumeMountPath = "/var/secrets/"                       // #nosec G101
 	GCSCredentialEnvKey          = "GOOGLE_APPLICATION_CREDENTIALS"      // #nosec G101
+	// HMAC interoperability keys, used to access the buckets through the XML API instead of a service account key
+	GCSHMACAccessKeyIdName   = "gcsHMACAccessKeyID"
+	GCSHMACSecretName        = "gcsHMACSecret" // #nosec G101
+	GCSHMACAccessKeyIdEnvKey = "GCS_HMAC_ACCESS_KEY_ID"
+	GCSHMACSecretEnvKey      = "GCS_HMAC_SECRET" // #nosec G101
 )
 
 type GCSConfig struct {
@@ -47,3 +52,32 @@ func BuildSecretVolume(secret *v1.Secret) (v1.Volume, v1.VolumeMount) {
 	}
 	return volume, volumeMount
 }
+
+func BuildHMACSecretEnvs(secret *v1.Secret) []v1.EnvVar {
+	envs := []v1.EnvVar{
+		{
+			Name: GCSHMACAccessKeyIdEnvKey,
+			ValueFrom: &v1.EnvVarSource{
+				SecretKeyRef: &v1.SecretKeySelector{
+					LocalObjectReference: v1.LocalObjectReference{
+						Name: secret.Name,
+					},
+					Key: GCSHMACAccessKeyIdName,
+				},
+			},
+		},
+		{
+			Name: GCSHMACSecretEnvKey,
+			ValueFrom: &v1.EnvVarSource{
+				SecretKeyRef: &v1.SecretKeySelector{
+					LocalObjectReference: v1.LocalObjectReference{
+						Name: secret.Name,
+					},
+					Key: GCSHMACSecretName,
+				},
+			},
+		},
+	}
+
+	return envs
+}
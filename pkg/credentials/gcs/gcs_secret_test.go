@@ -66,3 +66,54 @@ func TestGcsSecret(t *testing.T) {
 		}
 	}
 }
+
+func TestGcsHMACSecret(t *testing.T) {
+	scenarios := map[string]struct {
+		secret   *v1.Secret
+		expected []v1.EnvVar
+	}{
+		"GCSHMACSecretEnvs": {
+			secret: &v1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "gcs-hmac",
+				},
+				Data: map[string][]byte{
+					GCSHMACAccessKeyIdName: {},
+					GCSHMACSecretName:      {},
+				},
+			},
+			expected: []v1.EnvVar{
+				{
+					Name: GCSHMACAccessKeyIdEnvKey,
+					ValueFrom: &v1.EnvVarSource{
+						SecretKeyRef: &v1.SecretKeySelector{
+							LocalObjectReference: v1.LocalObjectReference{
+								Name: "gcs-hmac",
+							},
+							Key: GCSHMACAccessKeyIdName,
+						},
+					},
+				},
+				{
+					Name: GCSHMACSecretEnvKey,
+					ValueFrom: &v1.EnvVarSource{
+						SecretKeyRef: &v1.SecretKeySelector{
+							LocalObjectReference: v1.LocalObjectReference{
+								Name: "gcs-hmac",
+							},
+							Key: GCSHMACSecretName,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for name, scenario := range scenarios {
+		envs := BuildHMACSecretEnvs(scenario.secret)
+
+		if diff := cmp.Diff(scenario.expected, envs); diff != "" {
+			t.Errorf("Test %q unexpected result (-want +got): %v", name, diff)
+		}
+	}
+}
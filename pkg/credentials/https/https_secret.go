@@ -22,17 +22,21 @@ import (
 
 // Create constants -- baseURI
 const (
-	HTTPSHost = "https-host"
-	HEADERS   = "headers"
-	NEWLINE   = "\n"
+	HTTPSHost    = "https-host"
+	HEADERS      = "headers"
+	HTTPSHeaders = "https-headers"
+	TOKEN        = "token"
+	NEWLINE      = "\n"
 )
 
 var (
 	HeadersSuffix  = "-" + HEADERS
+	TokenSuffix    = "-" + TOKEN
 	ColonSeparator = ": "
 )
 
-// Can be used for http and https uris
+// Can be used for http and https uris. The headers are read from the headers or https-headers key, the bearer token
+// sent in the Authorization header from the token key.
 func BuildSecretEnvs(secret *v1.Secret) []v1.EnvVar {
 	envs := []v1.EnvVar{}
 	uriHost, ok := secret.Data[HTTPSHost]
@@ -42,15 +46,29 @@ func BuildSecretEnvs(secret *v1.Secret) []v1.EnvVar {
 	}
 
 	headers, ok := secret.Data[HEADERS]
-
 	if !ok {
-		return envs
+		headers, ok = secret.Data[HTTPSHeaders]
+	}
+	if ok {
+		envs = append(envs, v1.EnvVar{
+			Name:  string(uriHost) + HeadersSuffix,
+			Value: string(headers),
+		})
 	}
 
-	envs = append(envs, v1.EnvVar{
-		Name:  string(uriHost) + HeadersSuffix,
-		Value: string(headers),
-	})
+	if _, ok := secret.Data[TOKEN]; ok {
+		envs = append(envs, v1.EnvVar{
+			Name: string(uriHost) + TokenSuffix,
+			ValueFrom: &v1.EnvVarSource{
+				SecretKeyRef: &v1.SecretKeySelector{
+					LocalObjectReference: v1.LocalObjectReference{
+						Name: secret.Name,
+					},
+					Key: TOKEN,
+				},
+			},
+		})
+	}
 
 	return envs
 }
@@ -69,6 +69,50 @@ func TestHTTPSSecret(t *testing.T) {
 				},
 			},
 		},
+		"httpsHeadersKey": {
+			secret: &v1.Secret{
+				ObjectMeta: metav1.ObjectMeta{},
+				Data: map[string][]byte{
+					HTTPSHost:    []byte(uriHost),
+					HTTPSHeaders: []byte(`{"` + header1 + `": "` + headerValue1 + `"}`),
+				},
+			},
+			expected: []v1.EnvVar{
+				{
+					Name:  uriHost + HeadersSuffix,
+					Value: `{"` + header1 + `": "` + headerValue1 + `"}`,
+				},
+			},
+		},
+		"token": {
+			secret: &v1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "hf-secret",
+				},
+				Data: map[string][]byte{
+					HTTPSHost: []byte(uriHost),
+					HEADERS:   []byte(`{"` + header1 + `": "` + headerValue1 + `"}`),
+					TOKEN:     []byte("someToken"),
+				},
+			},
+			expected: []v1.EnvVar{
+				{
+					Name:  uriHost + HeadersSuffix,
+					Value: `{"` + header1 + `": "` + headerValue1 + `"}`,
+				},
+				{
+					Name: uriHost + TokenSuffix,
+					ValueFrom: &v1.EnvVarSource{
+						SecretKeyRef: &v1.SecretKeySelector{
+							LocalObjectReference: v1.LocalObjectReference{
+								Name: "hf-secret",
+							},
+							Key: TOKEN,
+						},
+					},
+				},
+			},
+		},
 	}
 
 	for name, scenario := range scenarios {
@@ -0,0 +1,161 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credentials
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+	apierr "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	// Storage config keys of the cloud IAM identity used to access the storage
+	StorageConfigAwsRoleArnKey        = "aws_role_arn"
+	StorageConfigGcpServiceAccountKey = "gcp_service_account"
+	IAMServiceAccountNameSuffix       = "-sa"
+)
+
+// iamAnnotationKeys maps the storage config keys to the service account annotations read by the
+// EKS pod identity webhook and GKE workload identity.
+var iamAnnotationKeys = map[string]string{
+	StorageConfigAwsRoleArnKey:        AwsIrsaAnnotationKey,
+	StorageConfigGcpServiceAccountKey: GcpWorkloadIdentityAnnotationKey,
+}
+
+// CreateIAMServiceAccount creates or updates the service account <owner>-sa annotated with the IAM role or
+// GCP service account configured in the storage key of the storage secret. It returns the name of the service
+// account, or an empty string if the storage key does not configure a cloud IAM identity.
+func (c *CredentialBuilder) CreateIAMServiceAccount(owner metav1.Object, scheme *runtime.Scheme, annotations map[string]string,
+	storageKey string, overrideParams map[string]string) (string, error) {
+	namespace := owner.GetNamespace()
+	storageSecretName := c.getStorageSpecSecretName(annotations)
+	secret, err := c.clientset.CoreV1().Secrets(namespace).Get(context.TODO(), storageSecretName, metav1.GetOptions{})
+	if err != nil {
+		if apierr.IsNotFound(err) {
+			return "", nil
+		}
+		return "", fmt.Errorf("can't read storage secret %s: %w", storageSecretName, err)
+	}
+	if storageKey == "" {
+		storageKey = DefaultStorageSecretKey
+		if stype := overrideParams["type"]; stype != "" {
+			storageKey = fmt.Sprintf("%s_%s", DefaultStorageSecretKey, stype)
+		}
+	}
+	storageData, ok := secret.Data[storageKey]
+	if !ok {
+		return "", nil
+	}
+	var storageDataJson map[string]string
+	if err := json.Unmarshal(storageData, &storageDataJson); err != nil {
+		return "", fmt.Errorf("invalid json encountered in key %s of storage secret %s: %w",
+			storageKey, storageSecretName, err)
+	}
+	iamAnnotations := map[string]string{}
+	for configKey, annotationKey := range iamAnnotationKeys {
+		if value, ok := storageDataJson[configKey]; ok && value != "" {
+			iamAnnotations[annotationKey] = value
+		}
+	}
+	if len(iamAnnotations) == 0 {
+		return "", nil
+	}
+
+	desired := &v1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        owner.GetName() + IAMServiceAccountNameSuffix,
+			Namespace:   namespace,
+			Annotations: iamAnnotations,
+		},
+	}
+	if err := controllerutil.SetControllerReference(owner, desired, scheme); err != nil {
+		return "", err
+	}
+	existing, err := c.clientset.CoreV1().ServiceAccounts(namespace).Get(context.TODO(), desired.Name, metav1.GetOptions{})
+	if err != nil {
+		if !apierr.IsNotFound(err) {
+			return "", err
+		}
+		log.Info("Creating IAM service account", "ServiceAccountName", desired.Name, "Namespace", namespace)
+		if _, err := c.clientset.CoreV1().ServiceAccounts(namespace).Create(context.TODO(), desired, metav1.CreateOptions{}); err != nil {
+			return "", err
+		}
+		return desired.Name, nil
+	}
+	if !metav1.IsControlledBy(existing, owner) {
+		return "", fmt.Errorf("service account %s already exists and is not owned by %s", desired.Name, owner.GetName())
+	}
+	if mergeIAMAnnotations(existing, iamAnnotations) {
+		log.Info("Updating IAM service account", "ServiceAccountName", desired.Name, "Namespace", namespace)
+		if _, err := c.clientset.CoreV1().ServiceAccounts(namespace).Update(context.TODO(), existing, metav1.UpdateOptions{}); err != nil {
+			return "", err
+		}
+	}
+	return desired.Name, nil
+}
+
+// DeleteIAMServiceAccount deletes the service account <owner>-sa created by CreateIAMServiceAccount once the owner no
+// longer uses a cloud IAM identity. A service account of the same name which is not controlled by the owner is kept.
+// The callers record the created service account so that it is only looked up when it may exist.
+func (c *CredentialBuilder) DeleteIAMServiceAccount(owner metav1.Object) error {
+	namespace := owner.GetNamespace()
+	name := owner.GetName() + IAMServiceAccountNameSuffix
+	existing, err := c.clientset.CoreV1().ServiceAccounts(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+	if err != nil {
+		if apierr.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if !metav1.IsControlledBy(existing, owner) {
+		return nil
+	}
+	log.Info("Deleting IAM service account", "ServiceAccountName", name, "Namespace", namespace)
+	err = c.clientset.CoreV1().ServiceAccounts(namespace).Delete(context.TODO(), name, metav1.DeleteOptions{})
+	if err != nil && !apierr.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// mergeIAMAnnotations sets the desired IAM annotations on the service account and removes IAM annotations which are
+// no longer configured, other annotations are kept. It returns true if the service account was changed.
+func mergeIAMAnnotations(serviceAccount *v1.ServiceAccount, iamAnnotations map[string]string) bool {
+	changed := false
+	if serviceAccount.Annotations == nil {
+		serviceAccount.Annotations = map[string]string{}
+	}
+	for _, annotationKey := range iamAnnotationKeys {
+		desiredValue, desiredOk := iamAnnotations[annotationKey]
+		existingValue, existingOk := serviceAccount.Annotations[annotationKey]
+		switch {
+		case desiredOk && (!existingOk || existingValue != desiredValue):
+			serviceAccount.Annotations[annotationKey] = desiredValue
+			changed = true
+		case !desiredOk && existingOk:
+			delete(serviceAccount.Annotations, annotationKey)
+			changed = true
+		}
+	}
+	return changed
+}
@@ -0,0 +1,185 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package credentials
+
+import (
+	"context"
+	"testing"
+
+	"github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakeclientset "k8s.io/client-go/kubernetes/fake"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+)
+
+func TestCreateIAMServiceAccount(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	scheme := runtime.NewScheme()
+	g.Expect(v1beta1.AddToScheme(scheme)).Should(gomega.Succeed())
+	isvc := &v1beta1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{Name: "sklearn", Namespace: "default", UID: "8e8e9b2b-0001"},
+	}
+	storageSecret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "storage-secret", Namespace: "default"},
+		Data: map[string][]byte{
+			"eks":    []byte(`{"type": "s3", "bucket": "models", "aws_role_arn": "arn:aws:iam::123456789012:role/models-reader"}`),
+			"gke":    []byte(`{"type": "gs", "gcp_service_account": "models-reader@project.iam.gserviceaccount.com"}`),
+			"static": []byte(`{"type": "s3", "bucket": "models", "access_key_id": "AKIAEXAMPLE"}`),
+		},
+	}
+
+	scenarios := map[string]struct {
+		storageKey          string
+		expectedName        string
+		expectedAnnotations map[string]string
+	}{
+		"AwsIrsa": {
+			storageKey:   "eks",
+			expectedName: "sklearn-sa",
+			expectedAnnotations: map[string]string{
+				AwsIrsaAnnotationKey: "arn:aws:iam::123456789012:role/models-reader",
+			},
+		},
+		"GcpWorkloadIdentity": {
+			storageKey:   "gke",
+			expectedName: "sklearn-sa",
+			expectedAnnotations: map[string]string{
+				GcpWorkloadIdentityAnnotationKey: "models-reader@project.iam.gserviceaccount.com",
+			},
+		},
+		"NoIAMIdentity": {
+			storageKey:   "static",
+			expectedName: "",
+		},
+		"MissingStorageKey": {
+			storageKey:   "unknown",
+			expectedName: "",
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			clientset := fakeclientset.NewSimpleClientset(storageSecret.DeepCopy())
+			builder := NewCredentialBuilder(nil, clientset, configMap)
+
+			// calling twice must be idempotent
+			for i := 0; i < 2; i++ {
+				serviceAccountName, err := builder.CreateIAMServiceAccount(isvc, scheme, nil, scenario.storageKey, nil)
+				g.Expect(err).Should(gomega.Succeed())
+				g.Expect(serviceAccountName).Should(gomega.Equal(scenario.expectedName))
+			}
+			serviceAccounts, err := clientset.CoreV1().ServiceAccounts("default").List(context.TODO(), metav1.ListOptions{})
+			g.Expect(err).Should(gomega.Succeed())
+			if scenario.expectedName == "" {
+				g.Expect(serviceAccounts.Items).Should(gomega.BeEmpty())
+				return
+			}
+			g.Expect(serviceAccounts.Items).Should(gomega.HaveLen(1))
+			serviceAccount := serviceAccounts.Items[0]
+			g.Expect(serviceAccount.Annotations).Should(gomega.Equal(scenario.expectedAnnotations))
+			g.Expect(metav1.IsControlledBy(&serviceAccount, isvc)).Should(gomega.BeTrue())
+		})
+	}
+}
+
+func TestCreateIAMServiceAccountUpdate(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	scheme := runtime.NewScheme()
+	g.Expect(v1beta1.AddToScheme(scheme)).Should(gomega.Succeed())
+	isvc := &v1beta1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{Name: "sklearn", Namespace: "default", UID: "8e8e9b2b-0001"},
+	}
+	storageSecret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "storage-secret", Namespace: "default"},
+		Data: map[string][]byte{
+			"eks": []byte(`{"type": "s3", "bucket": "models", "aws_role_arn": "arn:aws:iam::123456789012:role/models-reader"}`),
+			"gke": []byte(`{"type": "gs", "gcp_service_account": "models-reader@project.iam.gserviceaccount.com"}`),
+		},
+	}
+	clientset := fakeclientset.NewSimpleClientset(storageSecret)
+	builder := NewCredentialBuilder(nil, clientset, configMap)
+
+	_, err := builder.CreateIAMServiceAccount(isvc, scheme, nil, "eks", nil)
+	g.Expect(err).Should(gomega.Succeed())
+	serviceAccount, err := clientset.CoreV1().ServiceAccounts("default").Get(context.TODO(), "sklearn-sa", metav1.GetOptions{})
+	g.Expect(err).Should(gomega.Succeed())
+	serviceAccount.Annotations["example.com/team"] = "ml-platform"
+	_, err = clientset.CoreV1().ServiceAccounts("default").Update(context.TODO(), serviceAccount, metav1.UpdateOptions{})
+	g.Expect(err).Should(gomega.Succeed())
+
+	// switching the storage key replaces the IAM annotation and keeps other annotations
+	_, err = builder.CreateIAMServiceAccount(isvc, scheme, nil, "gke", nil)
+	g.Expect(err).Should(gomega.Succeed())
+	serviceAccount, err = clientset.CoreV1().ServiceAccounts("default").Get(context.TODO(), "sklearn-sa", metav1.GetOptions{})
+	g.Expect(err).Should(gomega.Succeed())
+	g.Expect(serviceAccount.Annotations).Should(gomega.Equal(map[string]string{
+		GcpWorkloadIdentityAnnotationKey: "models-reader@project.iam.gserviceaccount.com",
+		"example.com/team":               "ml-platform",
+	}))
+
+	// a service account not owned by the inference service is not taken over
+	otherIsvc := &v1beta1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{Name: "sklearn", Namespace: "default", UID: "8e8e9b2b-0002"},
+	}
+	_, err = builder.CreateIAMServiceAccount(otherIsvc, scheme, nil, "eks", nil)
+	g.Expect(err).ShouldNot(gomega.Succeed())
+}
+
+func TestDeleteIAMServiceAccount(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	scheme := runtime.NewScheme()
+	g.Expect(v1beta1.AddToScheme(scheme)).Should(gomega.Succeed())
+	isvc := &v1beta1.InferenceService{
+		ObjectMeta: metav1.ObjectMeta{Name: "sklearn", Namespace: "default", UID: "8e8e9b2b-0001"},
+	}
+	storageSecret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "storage-secret", Namespace: "default"},
+		Data: map[string][]byte{
+			"eks":    []byte(`{"type": "s3", "bucket": "models", "aws_role_arn": "arn:aws:iam::123456789012:role/models-reader"}`),
+			"static": []byte(`{"type": "s3", "bucket": "models", "access_key_id": "AKIAEXAMPLE"}`),
+		},
+	}
+	clientset := fakeclientset.NewSimpleClientset(storageSecret)
+	builder := NewCredentialBuilder(nil, clientset, configMap)
+
+	serviceAccountName, err := builder.CreateIAMServiceAccount(isvc, scheme, nil, "eks", nil)
+	g.Expect(err).Should(gomega.Succeed())
+	g.Expect(serviceAccountName).Should(gomega.Equal("sklearn-sa"))
+
+	// switching to a storage key without IAM identity keeps the service account until the caller deletes it
+	serviceAccountName, err = builder.CreateIAMServiceAccount(isvc, scheme, nil, "static", nil)
+	g.Expect(err).Should(gomega.Succeed())
+	g.Expect(serviceAccountName).Should(gomega.BeEmpty())
+	serviceAccounts, err := clientset.CoreV1().ServiceAccounts("default").List(context.TODO(), metav1.ListOptions{})
+	g.Expect(err).Should(gomega.Succeed())
+	g.Expect(serviceAccounts.Items).Should(gomega.HaveLen(1))
+	g.Expect(builder.DeleteIAMServiceAccount(isvc)).Should(gomega.Succeed())
+	serviceAccounts, err = clientset.CoreV1().ServiceAccounts("default").List(context.TODO(), metav1.ListOptions{})
+	g.Expect(err).Should(gomega.Succeed())
+	g.Expect(serviceAccounts.Items).Should(gomega.BeEmpty())
+
+	// a service account of the same name not owned by the inference service is kept
+	_, err = clientset.CoreV1().ServiceAccounts("default").Create(context.TODO(), &v1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{Name: "sklearn-sa", Namespace: "default"},
+	}, metav1.CreateOptions{})
+	g.Expect(err).Should(gomega.Succeed())
+	g.Expect(builder.DeleteIAMServiceAccount(isvc)).Should(gomega.Succeed())
+	_, err = clientset.CoreV1().ServiceAccounts("default").Get(context.TODO(), "sklearn-sa", metav1.GetOptions{})
+	g.Expect(err).Should(gomega.Succeed())
+}
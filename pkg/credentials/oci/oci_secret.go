@@ -0,0 +1,52 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package oci
+
+import (
+	v1 "k8s.io/api/core/v1"
+)
+
+const (
+	OCIDockerConfigFileName        = "config.json"
+	OCIDockerConfigVolumeName      = "oci-registry-credentials"     // #nosec G101
+	OCIDockerConfigVolumeMountPath = "/var/secrets/kserve-ocicreds" // #nosec G101
+	OCIDockerConfigEnvKey          = "OCI_DOCKER_CONFIG"            // #nosec G101
+)
+
+// BuildSecretVolume projects the dockerconfigjson of the pull secret as the config.json file of the mounted volume
+func BuildSecretVolume(secret *v1.Secret) (v1.Volume, v1.VolumeMount) {
+	volume := v1.Volume{
+		Name: OCIDockerConfigVolumeName,
+		VolumeSource: v1.VolumeSource{
+			Secret: &v1.SecretVolumeSource{
+				SecretName: secret.Name,
+				Items: []v1.KeyToPath{
+					{
+						Key:  v1.DockerConfigJsonKey,
+						Path: OCIDockerConfigFileName,
+					},
+				},
+			},
+		},
+	}
+	volumeMount := v1.VolumeMount{
+		MountPath: OCIDockerConfigVolumeMountPath,
+		Name:      OCIDockerConfigVolumeName,
+		ReadOnly:  true,
+	}
+	return volume, volumeMount
+}
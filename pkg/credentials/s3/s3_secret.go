@@ -41,6 +41,12 @@ const (
 	AWSAnonymousCredential = "awsAnonymousCredential"
 	AWSCABundle            = "AWS_CA_BUNDLE"
 	AWSCABundleConfigMap   = "AWS_CA_BUNDLE_CONFIGMAP"
+	AWSCABundleName        = "awsCABundle"
+	AWSAssumeRoleArn       = "AWS_ASSUME_ROLE_ARN"
+	S3ForcePathStyle       = "S3_FORCE_PATH_STYLE"
+
+	AWSCABundleVolumeName      = "s3-cabundle"
+	AWSCABundleVolumeMountPath = "/etc/ssl/s3-cabundle"
 )
 
 type S3Config struct {
@@ -55,6 +61,8 @@ type S3Config struct {
 	S3UseAnonymousCredential string `json:"s3UseAnonymousCredential,omitempty"`
 	S3CABundleConfigMap      string `json:"s3CABundleConfigMap,omitempty"`
 	S3CABundle               string `json:"s3CABundle,omitempty"`
+	S3ForcePathStyle         string `json:"s3ForcePathStyle,omitempty"`
+	S3AssumeRoleArn          string `json:"s3AssumeRoleArn,omitempty"`
 }
 
 var (
@@ -67,6 +75,8 @@ var (
 	InferenceServiceS3UseAnonymousCredential      = constants.KServeAPIGroupName + "/" + "s3-useanoncredential"
 	InferenceServiceS3CABundleConfigMapAnnotation = constants.KServeAPIGroupName + "/" + "s3-cabundle-configmap"
 	InferenceServiceS3CABundleAnnotation          = constants.KServeAPIGroupName + "/" + "s3-cabundle"
+	InferenceServiceS3ForcePathStyleAnnotation    = constants.KServeAPIGroupName + "/" + "s3-forcepathstyle"
+	InferenceServiceS3AssumeRoleArnAnnotation     = constants.KServeAPIGroupName + "/" + "s3-assumerolearn"
 )
 
 func BuildSecretEnvs(secret *v1.Secret, s3Config *S3Config) []v1.EnvVar {
@@ -106,5 +116,47 @@ func BuildSecretEnvs(secret *v1.Secret, s3Config *S3Config) []v1.EnvVar {
 
 	envs = append(envs, BuildS3EnvVars(secret.Annotations, s3Config)...)
 
+	// The CA bundle stored in the secret takes precedence over the CA bundle path of the annotations
+	if _, ok := secret.Data[AWSCABundleName]; ok {
+		caBundle := v1.EnvVar{
+			Name:  AWSCABundle,
+			Value: AWSCABundleVolumeMountPath + "/" + constants.DefaultCaBundleFileName,
+		}
+		replaced := false
+		for i := range envs {
+			if envs[i].Name == AWSCABundle {
+				envs[i] = caBundle
+				replaced = true
+			}
+		}
+		if !replaced {
+			envs = append(envs, caBundle)
+		}
+	}
+
 	return envs
 }
+
+// BuildCABundleSecretVolume returns the volume mounting the CA bundle stored in the awsCABundle key of the secret
+func BuildCABundleSecretVolume(secret *v1.Secret) (v1.Volume, v1.VolumeMount) {
+	volume := v1.Volume{
+		Name: AWSCABundleVolumeName,
+		VolumeSource: v1.VolumeSource{
+			Secret: &v1.SecretVolumeSource{
+				SecretName: secret.Name,
+				Items: []v1.KeyToPath{
+					{
+						Key:  AWSCABundleName,
+						Path: constants.DefaultCaBundleFileName,
+					},
+				},
+			},
+		},
+	}
+	volumeMount := v1.VolumeMount{
+		MountPath: AWSCABundleVolumeMountPath,
+		Name:      AWSCABundleVolumeName,
+		ReadOnly:  true,
+	}
+	return volume, volumeMount
+}
@@ -223,6 +223,47 @@ func TestS3Secret(t *testing.T) {
 				},
 			},
 		},
+		"S3SecretEnvsWithCABundle": {
+			secret: &v1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "s3-secret",
+					Annotations: map[string]string{
+						InferenceServiceS3CABundleAnnotation: "/etc/ssl/custom-certs/cabundle.crt",
+					},
+				},
+				Data: map[string][]byte{
+					AWSCABundleName: []byte("-----BEGIN CERTIFICATE-----"),
+				},
+			},
+			expected: []v1.EnvVar{
+				{
+					Name: AWSAccessKeyId,
+					ValueFrom: &v1.EnvVarSource{
+						SecretKeyRef: &v1.SecretKeySelector{
+							LocalObjectReference: v1.LocalObjectReference{
+								Name: "s3-secret",
+							},
+							Key: AWSAccessKeyIdName,
+						},
+					},
+				},
+				{
+					Name: AWSSecretAccessKey,
+					ValueFrom: &v1.EnvVarSource{
+						SecretKeyRef: &v1.SecretKeySelector{
+							LocalObjectReference: v1.LocalObjectReference{
+								Name: "s3-secret",
+							},
+							Key: AWSSecretAccessKeyName,
+						},
+					},
+				},
+				{
+					Name:  AWSCABundle,
+					Value: "/etc/ssl/s3-cabundle/cabundle.crt",
+				},
+			},
+		},
 	}
 
 	for name, scenario := range scenarios {
@@ -233,3 +274,38 @@ func TestS3Secret(t *testing.T) {
 		}
 	}
 }
+
+func TestBuildCABundleSecretVolume(t *testing.T) {
+	secret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "s3-secret",
+		},
+	}
+	expectedVolume := v1.Volume{
+		Name: AWSCABundleVolumeName,
+		VolumeSource: v1.VolumeSource{
+			Secret: &v1.SecretVolumeSource{
+				SecretName: "s3-secret",
+				Items: []v1.KeyToPath{
+					{
+						Key:  AWSCABundleName,
+						Path: "cabundle.crt",
+					},
+				},
+			},
+		},
+	}
+	expectedVolumeMount := v1.VolumeMount{
+		MountPath: "/etc/ssl/s3-cabundle",
+		Name:      AWSCABundleVolumeName,
+		ReadOnly:  true,
+	}
+
+	volume, volumeMount := BuildCABundleSecretVolume(secret)
+	if diff := cmp.Diff(expectedVolume, volume); diff != "" {
+		t.Errorf("unexpected volume (-want +got): %v", diff)
+	}
+	if diff := cmp.Diff(expectedVolumeMount, volumeMount); diff != "" {
+		t.Errorf("unexpected volume mount (-want +got): %v", diff)
+	}
+}
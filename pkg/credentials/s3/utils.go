@@ -21,12 +21,16 @@ import v1 "k8s.io/api/core/v1"
 func BuildS3EnvVars(annotations map[string]string, s3Config *S3Config) []v1.EnvVar {
 	envs := []v1.EnvVar{}
 
-	if s3Endpoint, ok := annotations[InferenceServiceS3SecretEndpointAnnotation]; ok {
+	// The endpoint and its scheme are resolved separately, so that an endpoint annotation is honored along with the
+	// s3UseHttps default of the inferenceservice configmap and the other way around.
+	s3Endpoint := lookupS3Option(annotations, InferenceServiceS3SecretEndpointAnnotation, s3Config.S3Endpoint)
+	s3UseHttps := lookupS3Option(annotations, InferenceServiceS3SecretHttpsAnnotation, s3Config.S3UseHttps)
+	if s3Endpoint != "" {
 		s3EndpointUrl := "https://" + s3Endpoint
-		if s3UseHttps, ok := annotations[InferenceServiceS3SecretHttpsAnnotation]; ok {
-			if s3UseHttps == "0" {
-				s3EndpointUrl = "http://" + annotations[InferenceServiceS3SecretEndpointAnnotation]
-			}
+		if s3UseHttps == "0" {
+			s3EndpointUrl = "http://" + s3Endpoint
+		}
+		if s3UseHttps != "" {
 			envs = append(envs, v1.EnvVar{
 				Name:  S3UseHttps,
 				Value: s3UseHttps,
@@ -40,23 +44,6 @@ func BuildS3EnvVars(annotations map[string]string, s3Config *S3Config) []v1.EnvV
 			Name:  AWSEndpointUrl,
 			Value: s3EndpointUrl,
 		})
-	} else if s3Config.S3Endpoint != "" {
-		s3EndpointUrl := "https://" + s3Config.S3Endpoint
-		if s3Config.S3UseHttps == "0" {
-			s3EndpointUrl = "http://" + s3Config.S3Endpoint
-			envs = append(envs, v1.EnvVar{
-				Name:  S3UseHttps,
-				Value: s3Config.S3UseHttps,
-			})
-		}
-		envs = append(envs, v1.EnvVar{
-			Name:  S3Endpoint,
-			Value: s3Config.S3Endpoint,
-		})
-		envs = append(envs, v1.EnvVar{
-			Name:  AWSEndpointUrl,
-			Value: s3EndpointUrl,
-		})
 	}
 
 	// For each variable, prefer the value from the annotation, otherwise default to the value from the inferenceservice configmap if set.
@@ -115,6 +102,28 @@ func BuildS3EnvVars(annotations map[string]string, s3Config *S3Config) []v1.EnvV
 		})
 	}
 
+	forcePathStyle, ok := annotations[InferenceServiceS3ForcePathStyleAnnotation]
+	if !ok {
+		forcePathStyle = s3Config.S3ForcePathStyle
+	}
+	if forcePathStyle != "" {
+		envs = append(envs, v1.EnvVar{
+			Name:  S3ForcePathStyle,
+			Value: forcePathStyle,
+		})
+	}
+
+	assumeRoleArn, ok := annotations[InferenceServiceS3AssumeRoleArnAnnotation]
+	if !ok {
+		assumeRoleArn = s3Config.S3AssumeRoleArn
+	}
+	if assumeRoleArn != "" {
+		envs = append(envs, v1.EnvVar{
+			Name:  AWSAssumeRoleArn,
+			Value: assumeRoleArn,
+		})
+	}
+
 	customCABundle, ok := annotations[InferenceServiceS3CABundleAnnotation]
 	if !ok {
 		customCABundle = s3Config.S3CABundle
@@ -139,3 +148,34 @@ func BuildS3EnvVars(annotations map[string]string, s3Config *S3Config) []v1.EnvV
 
 	return envs
 }
+
+// lookupS3Option returns the value of the annotation, otherwise the default value from the inferenceservice configmap
+func lookupS3Option(annotations map[string]string, annotation string, defaultValue string) string {
+	if value, ok := annotations[annotation]; ok {
+		return value
+	}
+	return defaultValue
+}
+
+// WithAnnotations returns a copy of the configuration whose defaults are overridden by the s3 annotations. It is used
+// to apply the service account annotations, so that the secret annotations take precedence over the service account
+// annotations, which take precedence over the inferenceservice configmap.
+func (c *S3Config) WithAnnotations(annotations map[string]string) *S3Config {
+	config := *c
+	for annotation, value := range map[string]*string{
+		InferenceServiceS3SecretEndpointAnnotation:    &config.S3Endpoint,
+		InferenceServiceS3SecretHttpsAnnotation:       &config.S3UseHttps,
+		InferenceServiceS3SecretRegionAnnotation:      &config.S3Region,
+		InferenceServiceS3SecretSSLAnnotation:         &config.S3VerifySSL,
+		InferenceServiceS3UseVirtualBucketAnnotation:  &config.S3UseVirtualBucket,
+		InferenceServiceS3UseAccelerateAnnotation:     &config.S3UseAccelerate,
+		InferenceServiceS3UseAnonymousCredential:      &config.S3UseAnonymousCredential,
+		InferenceServiceS3CABundleConfigMapAnnotation: &config.S3CABundleConfigMap,
+		InferenceServiceS3CABundleAnnotation:          &config.S3CABundle,
+		InferenceServiceS3ForcePathStyleAnnotation:    &config.S3ForcePathStyle,
+		InferenceServiceS3AssumeRoleArnAnnotation:     &config.S3AssumeRoleArn,
+	} {
+		*value = lookupS3Option(annotations, annotation, *value)
+	}
+	return &config
+}
@@ -99,6 +99,70 @@ func TestBuildS3EnvVars(t *testing.T) {
 				},
 			},
 		},
+		"EndpointAnnotationWithConfigUseHttps": {
+			config: S3Config{
+				S3UseHttps: "0",
+			},
+			annotations: map[string]string{
+				InferenceServiceS3SecretEndpointAnnotation: "minio.local:9000",
+			},
+			expected: []v1.EnvVar{
+				{
+					Name:  S3UseHttps,
+					Value: "0",
+				},
+				{
+					Name:  S3Endpoint,
+					Value: "minio.local:9000",
+				},
+				{
+					Name:  AWSEndpointUrl,
+					Value: "http://minio.local:9000",
+				},
+			},
+		},
+		"UseHttpsAnnotationWithConfigEndpoint": {
+			config: S3Config{
+				S3Endpoint: "minio.local:9000",
+				S3UseHttps: "0",
+			},
+			annotations: map[string]string{
+				InferenceServiceS3SecretHttpsAnnotation: "1",
+			},
+			expected: []v1.EnvVar{
+				{
+					Name:  S3UseHttps,
+					Value: "1",
+				},
+				{
+					Name:  S3Endpoint,
+					Value: "minio.local:9000",
+				},
+				{
+					Name:  AWSEndpointUrl,
+					Value: "https://minio.local:9000",
+				},
+			},
+		},
+		"ForcePathStyleAndAssumeRole": {
+			config: S3Config{
+				S3ForcePathStyle: "false",
+				S3AssumeRoleArn:  "arn:aws:iam::123456789012:role/default",
+			},
+			annotations: map[string]string{
+				InferenceServiceS3ForcePathStyleAnnotation: "true",
+			},
+			expected: []v1.EnvVar{
+				{
+					Name:  S3ForcePathStyle,
+					Value: "true",
+				},
+				{
+					Name:  AWSAssumeRoleArn,
+					Value: "arn:aws:iam::123456789012:role/default",
+				},
+			},
+		},
 	}
 	for name, scenario := range scenarios {
 		envs := BuildS3EnvVars(scenario.annotations, &scenario.config)
@@ -108,3 +172,51 @@ func TestBuildS3EnvVars(t *testing.T) {
 		}
 	}
 }
+
+func TestS3ConfigWithAnnotations(t *testing.T) {
+	config := &S3Config{
+		S3Endpoint:       "configmap.local",
+		S3Region:         "us-east-1",
+		S3ForcePathStyle: "false",
+		S3AssumeRoleArn:  "arn:aws:iam::123456789012:role/configmap",
+	}
+	serviceAccountAnnotations := map[string]string{
+		InferenceServiceS3SecretEndpointAnnotation: "serviceaccount.local",
+		InferenceServiceS3AssumeRoleArnAnnotation:  "arn:aws:iam::123456789012:role/serviceaccount",
+		InferenceServiceS3ForcePathStyleAnnotation: "true",
+	}
+	secretAnnotations := map[string]string{
+		InferenceServiceS3AssumeRoleArnAnnotation: "arn:aws:iam::123456789012:role/secret",
+	}
+	expected := []v1.EnvVar{
+		{
+			Name:  S3Endpoint,
+			Value: "serviceaccount.local",
+		},
+		{
+			Name:  AWSEndpointUrl,
+			Value: "https://serviceaccount.local",
+		},
+		{
+			Name:  AWSRegion,
+			Value: "us-east-1",
+		},
+		{
+			Name:  S3ForcePathStyle,
+			Value: "true",
+		},
+		{
+			Name:  AWSAssumeRoleArn,
+			Value: "arn:aws:iam::123456789012:role/secret",
+		},
+	}
+
+	envs := BuildS3EnvVars(secretAnnotations, config.WithAnnotations(serviceAccountAnnotations))
+	if diff := cmp.Diff(expected, envs); diff != "" {
+		t.Errorf("unexpected result (-want +got): %v", diff)
+	}
+	// the configmap defaults are not modified
+	if config.S3Endpoint != "configmap.local" {
+		t.Errorf("unexpected configmap endpoint %q", config.S3Endpoint)
+	}
+}
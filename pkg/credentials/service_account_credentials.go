@@ -21,6 +21,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"path/filepath"
 	"strings"
 
 	v1 "k8s.io/api/core/v1"
@@ -34,20 +35,24 @@ import (
 	"github.com/kserve/kserve/pkg/credentials/azure"
 	"github.com/kserve/kserve/pkg/credentials/gcs"
 	"github.com/kserve/kserve/pkg/credentials/hdfs"
+	"github.com/kserve/kserve/pkg/credentials/hf"
 	"github.com/kserve/kserve/pkg/credentials/https"
+	"github.com/kserve/kserve/pkg/credentials/oci"
 	"github.com/kserve/kserve/pkg/credentials/s3"
+	"github.com/kserve/kserve/pkg/credentials/sftp"
 	"github.com/kserve/kserve/pkg/utils"
 )
 
 const (
-	CredentialConfigKeyName     = "credentials"
-	UriSchemePlaceholder        = "<scheme-placeholder>"
-	StorageConfigEnvKey         = "STORAGE_CONFIG"
-	StorageOverrideConfigEnvKey = "STORAGE_OVERRIDE_CONFIG"
-	DefaultStorageSecretKey     = "default"
-	UnsupportedStorageSpecType  = "storage type must be one of [%s]. storage type [%s] is not supported"
-	MissingBucket               = "format [%s] requires a bucket but one wasn't found in storage data or parameters"
-	AwsIrsaAnnotationKey        = "eks.amazonaws.com/role-arn"
+	CredentialConfigKeyName          = "credentials"
+	UriSchemePlaceholder             = "<scheme-placeholder>"
+	StorageConfigEnvKey              = "STORAGE_CONFIG"
+	StorageOverrideConfigEnvKey      = "STORAGE_OVERRIDE_CONFIG"
+	DefaultStorageSecretKey          = "default"
+	UnsupportedStorageSpecType       = "storage type must be one of [%s]. storage type [%s] is not supported"
+	MissingBucket                    = "format [%s] requires a bucket but one wasn't found in storage data or parameters"
+	AwsIrsaAnnotationKey             = "eks.amazonaws.com/role-arn"
+	GcpWorkloadIdentityAnnotationKey = "iam.gke.io/gcp-service-account"
 )
 
 var (
@@ -91,16 +96,7 @@ func (c *CredentialBuilder) CreateStorageSpecSecretEnvs(namespace string, annota
 	stype := overrideParams["type"]
 	bucket := overrideParams["bucket"]
 
-	storageSecretName := constants.DefaultStorageSpecSecret
-	if c.config.StorageSpecSecretName != "" {
-		storageSecretName = c.config.StorageSpecSecretName
-	}
-	// secret annotation takes precedence
-	if annotations != nil {
-		if secretName, ok := annotations[c.config.StorageSecretNameAnnotation]; ok {
-			storageSecretName = secretName
-		}
-	}
+	storageSecretName := c.getStorageSpecSecretName(annotations)
 	secret, err := c.clientset.CoreV1().Secrets(namespace).Get(context.TODO(), storageSecretName, metav1.GetOptions{})
 
 	var storageData []byte
@@ -193,6 +189,21 @@ func (c *CredentialBuilder) CreateStorageSpecSecretEnvs(namespace string, annota
 	return nil
 }
 
+// getStorageSpecSecretName returns the name of the storage secret used for storage specs,
+// the secret name annotation takes precedence over the configured secret name.
+func (c *CredentialBuilder) getStorageSpecSecretName(annotations map[string]string) string {
+	storageSecretName := constants.DefaultStorageSpecSecret
+	if c.config.StorageSpecSecretName != "" {
+		storageSecretName = c.config.StorageSpecSecretName
+	}
+	if annotations != nil {
+		if secretName, ok := annotations[c.config.StorageSecretNameAnnotation]; ok {
+			storageSecretName = secretName
+		}
+	}
+	return storageSecretName
+}
+
 func (c *CredentialBuilder) CreateSecretVolumeAndEnv(namespace string, annotations map[string]string, serviceAccountName string,
 	container *v1.Container, volumes *[]v1.Volume) error {
 	if serviceAccountName == "" {
@@ -216,7 +227,7 @@ func (c *CredentialBuilder) CreateSecretVolumeAndEnv(namespace string, annotatio
 	// secret name annotation takes precedence
 	if annotations != nil && c.config.StorageSecretNameAnnotation != "" {
 		if secretName, ok := annotations[c.config.StorageSecretNameAnnotation]; ok {
-			err := c.mountSecretCredential(secretName, namespace, container, volumes)
+			err := c.mountSecretCredential(secretName, namespace, serviceAccount, container, volumes)
 			if err != nil {
 				log.Error(err, "Failed to amount the secret credentials", "secretName", secretName)
 				return err
@@ -227,7 +238,7 @@ func (c *CredentialBuilder) CreateSecretVolumeAndEnv(namespace string, annotatio
 
 	// Find the secret references from service account
 	for _, secretRef := range serviceAccount.Secrets {
-		err := c.mountSecretCredential(secretRef.Name, namespace, container, volumes)
+		err := c.mountSecretCredential(secretRef.Name, namespace, serviceAccount, container, volumes)
 		if err != nil {
 			return err
 		}
@@ -236,7 +247,24 @@ func (c *CredentialBuilder) CreateSecretVolumeAndEnv(namespace string, annotatio
 	return nil
 }
 
-func (c *CredentialBuilder) mountSecretCredential(secretName string, namespace string,
+// CreateSecretVolumeAndEnvFromSecret sets up the credentials of the given secret on the container, used for the
+// storage sources which have their own secret instead of the ones of the service account
+func (c *CredentialBuilder) CreateSecretVolumeAndEnvFromSecret(namespace string, secretName string, serviceAccountName string,
+	container *v1.Container, volumes *[]v1.Volume) error {
+	if serviceAccountName == "" {
+		serviceAccountName = "default"
+	}
+	// The service account annotations configure the s3 credentials of the secret
+	serviceAccount, err := c.clientset.CoreV1().ServiceAccounts(namespace).Get(context.TODO(), serviceAccountName, metav1.GetOptions{})
+	if err != nil {
+		log.Error(err, "Failed to find service account", "ServiceAccountName", serviceAccountName,
+			"Namespace", namespace)
+		serviceAccount = &v1.ServiceAccount{}
+	}
+	return c.mountSecretCredential(secretName, namespace, serviceAccount, container, volumes)
+}
+
+func (c *CredentialBuilder) mountSecretCredential(secretName string, namespace string, serviceAccount *v1.ServiceAccount,
 	container *v1.Container, volumes *[]v1.Volume) error {
 	secret, err := c.clientset.CoreV1().Secrets(namespace).Get(context.TODO(), secretName, metav1.GetOptions{})
 	if err != nil {
@@ -257,9 +285,16 @@ func (c *CredentialBuilder) mountSecretCredential(secretName string, namespace s
 	}
 	if _, ok := secret.Data[s3SecretAccessKeyName]; ok {
 		log.Info("Setting secret envs for s3", "S3Secret", secret.Name)
-		envs := s3.BuildSecretEnvs(secret, &c.config.S3)
+		// The secret annotations take precedence over the service account annotations
+		envs := s3.BuildSecretEnvs(secret, c.config.S3.WithAnnotations(serviceAccount.Annotations))
 		// Merge envs here to override values possibly present from IAM Role annotations with values from secret annotations
 		container.Env = utils.MergeEnvs(container.Env, envs)
+		if _, ok := secret.Data[s3.AWSCABundleName]; ok {
+			log.Info("Setting secret volume for s3 CA bundle", "S3Secret", secret.Name)
+			volume, volumeMount := s3.BuildCABundleSecretVolume(secret)
+			*volumes = utils.AppendVolumeIfNotExists(*volumes, volume)
+			container.VolumeMounts = append(container.VolumeMounts, volumeMount)
+		}
 	} else if _, ok := secret.Data[gcsCredentialFileName]; ok {
 		log.Info("Setting secret volume for gcs", "GCSSecret", secret.Name)
 		volume, volumeMount := gcs.BuildSecretVolume(secret)
@@ -271,6 +306,10 @@ func (c *CredentialBuilder) mountSecretCredential(secretName string, namespace s
 				Name:  gcs.GCSCredentialEnvKey,
 				Value: gcs.GCSCredentialVolumeMountPath + gcsCredentialFileName,
 			})
+	} else if _, ok := secret.Data[gcs.GCSHMACSecretName]; ok {
+		log.Info("Setting secret envs with HMAC keys for gcs", "GCSSecret", secret.Name)
+		envs := gcs.BuildHMACSecretEnvs(secret)
+		container.Env = append(container.Env, envs...)
 	} else if _, ok := secret.Data[azure.LegacyAzureClientId]; ok {
 		log.Info("Setting secret envs for azure", "AzureSecret", secret.Name)
 		envs := azure.BuildSecretEnvs(secret)
@@ -283,15 +322,40 @@ func (c *CredentialBuilder) mountSecretCredential(secretName string, namespace s
 		log.Info("Setting secret envs with azure storage access key for azure", "AzureSecret", secret.Name)
 		envs := azure.BuildStorageAccessKeySecretEnv(secret)
 		container.Env = append(container.Env, envs...)
+	} else if _, ok := secret.Data[azure.AzureStorageSasToken]; ok {
+		log.Info("Setting secret envs with azure storage sas token for azure", "AzureSecret", secret.Name)
+		envs := azure.BuildStorageSasTokenSecretEnv(secret)
+		container.Env = append(container.Env, envs...)
 	} else if _, ok := secret.Data[https.HTTPSHost]; ok {
 		log.Info("Setting secret volume from uri", "HTTP(S)Secret", secret.Name)
 		envs := https.BuildSecretEnvs(secret)
 		container.Env = append(container.Env, envs...)
+	} else if _, ok := secret.Data[hf.HFTokenKey]; ok {
+		log.Info("Setting secret envs for hugging face hub", "HFSecret", secret.Name)
+		envs := hf.BuildSecretEnvs(secret)
+		container.Env = append(container.Env, envs...)
+	} else if sftp.IsSFTPSecret(secret) {
+		log.Info("Setting secret envs and volume for sftp", "SFTPSecret", secret.Name)
+		container.Env = append(container.Env, sftp.BuildSecretEnvs(secret)...)
+		if volume, volumeMount, ok := sftp.BuildSecretVolume(secret); ok {
+			*volumes = utils.AppendVolumeIfNotExists(*volumes, volume)
+			container.VolumeMounts = append(container.VolumeMounts, volumeMount)
+		}
 	} else if _, ok := secret.Data[hdfs.HdfsNamenode]; ok {
 		log.Info("Setting secret for hdfs", "HdfsSecret", secret.Name)
 		volume, volumeMount := hdfs.BuildSecret(secret)
 		*volumes = utils.AppendVolumeIfNotExists(*volumes, volume)
 		container.VolumeMounts = append(container.VolumeMounts, volumeMount)
+	} else if _, ok := secret.Data[v1.DockerConfigJsonKey]; ok {
+		log.Info("Setting secret volume for oci registry", "OCISecret", secret.Name)
+		volume, volumeMount := oci.BuildSecretVolume(secret)
+		*volumes = utils.AppendVolumeIfNotExists(*volumes, volume)
+		container.VolumeMounts = append(container.VolumeMounts, volumeMount)
+		container.Env = append(container.Env,
+			v1.EnvVar{
+				Name:  oci.OCIDockerConfigEnvKey,
+				Value: filepath.Join(oci.OCIDockerConfigVolumeMountPath, oci.OCIDockerConfigFileName),
+			})
 	} else {
 		log.V(5).Info("Skipping unsupported secret", "Secret", secret.Name)
 	}
@@ -0,0 +1,107 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sftp
+
+import (
+	"path/filepath"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+const (
+	SFTPUsername = "SFTP_USERNAME"
+	SFTPPassword = "SFTP_PASSWORD" // #nosec G101
+	// SFTPPrivateKeyName is the key of the private key in the secret, the key of the kubernetes.io/ssh-auth secrets
+	SFTPPrivateKeyName  = v1.SSHAuthPrivateKey
+	SFTPKnownHostsName  = "known_hosts"
+	SFTPPrivateKeyFile  = "SFTP_PRIVATE_KEY_FILE"
+	SFTPKnownHostsFile  = "SFTP_KNOWN_HOSTS_FILE"
+	SFTPVolumeName      = "sftp-credentials"              // #nosec G101
+	SFTPVolumeMountPath = "/var/secrets/kserve-sftpcreds" // #nosec G101
+)
+
+// IsSFTPSecret returns whether the secret holds the credentials of a sftp server, a username with either a password
+// or a private key
+func IsSFTPSecret(secret *v1.Secret) bool {
+	_, ok := secret.Data[SFTPUsername]
+	return ok
+}
+
+// BuildSecretEnvs returns the username and password envs of the secret and the envs of the paths of the private key
+// and known_hosts files of the volume returned by BuildSecretVolume
+func BuildSecretEnvs(secret *v1.Secret) []v1.EnvVar {
+	envs := []v1.EnvVar{}
+	for _, key := range []string{SFTPUsername, SFTPPassword} {
+		if _, ok := secret.Data[key]; ok {
+			envs = append(envs, v1.EnvVar{
+				Name: key,
+				ValueFrom: &v1.EnvVarSource{
+					SecretKeyRef: &v1.SecretKeySelector{
+						LocalObjectReference: v1.LocalObjectReference{
+							Name: secret.Name,
+						},
+						Key: key,
+					},
+				},
+			})
+		}
+	}
+	for _, file := range []struct{ env, key string }{
+		{SFTPPrivateKeyFile, SFTPPrivateKeyName},
+		{SFTPKnownHostsFile, SFTPKnownHostsName},
+	} {
+		if _, ok := secret.Data[file.key]; ok {
+			envs = append(envs, v1.EnvVar{
+				Name:  file.env,
+				Value: filepath.Join(SFTPVolumeMountPath, file.key),
+			})
+		}
+	}
+	return envs
+}
+
+// BuildSecretVolume projects the private key and the known_hosts of the secret as the files of the mounted volume,
+// it returns false when the secret has neither of them
+func BuildSecretVolume(secret *v1.Secret) (v1.Volume, v1.VolumeMount, bool) {
+	var items []v1.KeyToPath
+	for _, key := range []string{SFTPPrivateKeyName, SFTPKnownHostsName} {
+		if _, ok := secret.Data[key]; ok {
+			items = append(items, v1.KeyToPath{
+				Key:  key,
+				Path: key,
+			})
+		}
+	}
+	if len(items) == 0 {
+		return v1.Volume{}, v1.VolumeMount{}, false
+	}
+	volume := v1.Volume{
+		Name: SFTPVolumeName,
+		VolumeSource: v1.VolumeSource{
+			Secret: &v1.SecretVolumeSource{
+				SecretName: secret.Name,
+				Items:      items,
+			},
+		},
+	}
+	volumeMount := v1.VolumeMount{
+		MountPath: SFTPVolumeMountPath,
+		Name:      SFTPVolumeName,
+		ReadOnly:  true,
+	}
+	return volume, volumeMount, true
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sftp
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func secretEnv(name string) v1.EnvVar {
+	return v1.EnvVar{
+		Name: name,
+		ValueFrom: &v1.EnvVarSource{
+			SecretKeyRef: &v1.SecretKeySelector{
+				LocalObjectReference: v1.LocalObjectReference{
+					Name: "sftp-secret",
+				},
+				Key: name,
+			},
+		},
+	}
+}
+
+func TestSFTPSecret(t *testing.T) {
+	scenarios := map[string]struct {
+		secret         *v1.Secret
+		expectedEnvs   []v1.EnvVar
+		expectedVolume *v1.Volume
+	}{
+		"Password": {
+			secret: &v1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "sftp-secret",
+				},
+				Data: map[string][]byte{
+					SFTPUsername: []byte("models"),
+					SFTPPassword: []byte("password"),
+				},
+			},
+			expectedEnvs: []v1.EnvVar{secretEnv(SFTPUsername), secretEnv(SFTPPassword)},
+		},
+		"PrivateKeyAndKnownHosts": {
+			secret: &v1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "sftp-secret",
+				},
+				Type: v1.SecretTypeSSHAuth,
+				Data: map[string][]byte{
+					SFTPUsername:       []byte("models"),
+					SFTPPrivateKeyName: []byte("private key"),
+					SFTPKnownHostsName: []byte("sftp.example.com ssh-ed25519 AAAA"),
+				},
+			},
+			expectedEnvs: []v1.EnvVar{
+				secretEnv(SFTPUsername),
+				{Name: SFTPPrivateKeyFile, Value: "/var/secrets/kserve-sftpcreds/ssh-privatekey"},
+				{Name: SFTPKnownHostsFile, Value: "/var/secrets/kserve-sftpcreds/known_hosts"},
+			},
+			expectedVolume: &v1.Volume{
+				Name: SFTPVolumeName,
+				VolumeSource: v1.VolumeSource{
+					Secret: &v1.SecretVolumeSource{
+						SecretName: "sftp-secret",
+						Items: []v1.KeyToPath{
+							{Key: SFTPPrivateKeyName, Path: SFTPPrivateKeyName},
+							{Key: SFTPKnownHostsName, Path: SFTPKnownHostsName},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for name, scenario := range scenarios {
+		if !IsSFTPSecret(scenario.secret) {
+			t.Errorf("Test %q expected a sftp secret", name)
+		}
+		if diff := cmp.Diff(scenario.expectedEnvs, BuildSecretEnvs(scenario.secret)); diff != "" {
+			t.Errorf("Test %q unexpected envs (-want +got): %v", name, diff)
+		}
+		volume, volumeMount, ok := BuildSecretVolume(scenario.secret)
+		if scenario.expectedVolume == nil {
+			if ok {
+				t.Errorf("Test %q unexpected volume %v", name, volume)
+			}
+			continue
+		}
+		if diff := cmp.Diff(*scenario.expectedVolume, volume); diff != "" {
+			t.Errorf("Test %q unexpected volume (-want +got): %v", name, diff)
+		}
+		expectedVolumeMount := v1.VolumeMount{
+			Name:      SFTPVolumeName,
+			ReadOnly:  true,
+			MountPath: SFTPVolumeMountPath,
+		}
+		if diff := cmp.Diff(expectedVolumeMount, volumeMount); diff != "" {
+			t.Errorf("Test %q unexpected volumeMount (-want +got): %v", name, diff)
+		}
+	}
+}
@@ -0,0 +1,208 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logger
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+)
+
+const diskBufferFileSuffix = ".json"
+
+// ErrDiskBufferFull is returned when a log request does not fit in the disk buffer
+var ErrDiskBufferFull = errors.New("the logger disk buffer is full")
+
+// bufferedLogRequest is the serialized form of a log request in the disk buffer
+type bufferedLogRequest struct {
+	Url              string                        `json:"url"`
+	Bytes            []byte                        `json:"bytes"`
+	ContentType      string                        `json:"contentType"`
+	ReqType          string                        `json:"reqType"`
+	Id               string                        `json:"id"`
+	SourceUri        string                        `json:"sourceUri"`
+	InferenceService string                        `json:"inferenceService"`
+	Namespace        string                        `json:"namespace"`
+	Component        string                        `json:"component"`
+	Endpoint         string                        `json:"endpoint"`
+	PayloadLimit     v1beta1.LoggerPayloadOverflow `json:"payloadLimit,omitempty"`
+	PayloadSize      int                           `json:"payloadSize,omitempty"`
+	Metadata         map[string][]string           `json:"metadata,omitempty"`
+	InferenceGraph   string                        `json:"inferenceGraph,omitempty"`
+	Step             string                        `json:"step,omitempty"`
+	InferenceId      string                        `json:"inferenceId,omitempty"`
+}
+
+// DiskBuffer stores the log requests which do not fit in the in-memory queue, one file per request, until they
+// can be queued again. The log requests left by a previous run of the agent are delivered too.
+type DiskBuffer struct {
+	dir      string
+	maxBytes int64
+
+	mu   sync.Mutex
+	size int64
+	seq  uint64
+}
+
+// NewDiskBuffer returns a disk buffer storing at most maxBytes of log requests in dir
+func NewDiskBuffer(dir string, maxBytes int64) (*DiskBuffer, error) {
+	if err := os.MkdirAll(dir, 0750); err != nil {
+		return nil, fmt.Errorf("failed to create the logger disk buffer %s: %w", dir, err)
+	}
+	buffer := &DiskBuffer{dir: dir, maxBytes: maxBytes}
+	names, err := buffer.files()
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range names {
+		info, err := os.Stat(filepath.Join(dir, name))
+		if err != nil {
+			return nil, err
+		}
+		buffer.size += info.Size()
+	}
+	return buffer, nil
+}
+
+// files returns the names of the buffered log requests, oldest first
+func (b *DiskBuffer) files() ([]string, error) {
+	entries, err := os.ReadDir(b.dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the logger disk buffer %s: %w", b.dir, err)
+	}
+	names := []string{}
+	for _, entry := range entries {
+		if entry.Type().IsRegular() && strings.HasSuffix(entry.Name(), diskBufferFileSuffix) {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// Push writes a log request to the buffer, or returns ErrDiskBufferFull when the buffer has no room for it
+func (b *DiskBuffer) Push(logReq LogRequest) error {
+	content, err := json.Marshal(bufferedLogRequest{
+		Url:              logReq.Url.String(),
+		Bytes:            *logReq.Bytes,
+		ContentType:      logReq.ContentType,
+		ReqType:          logReq.ReqType,
+		Id:               logReq.Id,
+		SourceUri:        logReq.SourceUri.String(),
+		InferenceService: logReq.InferenceService,
+		Namespace:        logReq.Namespace,
+		Component:        logReq.Component,
+		Endpoint:         logReq.Endpoint,
+		PayloadLimit:     logReq.PayloadLimit,
+		PayloadSize:      logReq.PayloadSize,
+		Metadata:         logReq.Metadata,
+		InferenceGraph:   logReq.InferenceGraph,
+		Step:             logReq.Step,
+		InferenceId:      logReq.InferenceId,
+	})
+	if err != nil {
+		return err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.size+int64(len(content)) > b.maxBytes {
+		return ErrDiskBufferFull
+	}
+	// the names sort in the order of the writes
+	b.seq++
+	name := fmt.Sprintf("%020d-%010d%s", time.Now().UnixNano(), b.seq, diskBufferFileSuffix)
+	// the file is renamed once complete so that a partially written request is never read
+	tmp := filepath.Join(b.dir, "."+name)
+	if err := os.WriteFile(tmp, content, 0600); err != nil {
+		return fmt.Errorf("failed to write the log request %s to the disk buffer: %w", logReq.Id, err)
+	}
+	if err := os.Rename(tmp, filepath.Join(b.dir, name)); err != nil {
+		return fmt.Errorf("failed to write the log request %s to the disk buffer: %w", logReq.Id, err)
+	}
+	b.size += int64(len(content))
+	return nil
+}
+
+// Oldest returns the name and the content of the oldest log request of the buffer, or an empty name when the buffer
+// is empty. The request stays in the buffer until it is removed.
+func (b *DiskBuffer) Oldest() (string, *LogRequest, error) {
+	names, err := b.files()
+	if err != nil || len(names) == 0 {
+		return "", nil, err
+	}
+	name := names[0]
+	content, err := os.ReadFile(filepath.Join(b.dir, name))
+	if err != nil {
+		return name, nil, err
+	}
+	buffered := bufferedLogRequest{}
+	if err := json.Unmarshal(content, &buffered); err != nil {
+		return name, nil, fmt.Errorf("failed to parse the buffered log request %s: %w", name, err)
+	}
+	logUrl, err := url.Parse(buffered.Url)
+	if err != nil {
+		return name, nil, err
+	}
+	sourceUri, err := url.Parse(buffered.SourceUri)
+	if err != nil {
+		return name, nil, err
+	}
+	return name, &LogRequest{
+		Url:              logUrl,
+		Bytes:            &buffered.Bytes,
+		ContentType:      buffered.ContentType,
+		ReqType:          buffered.ReqType,
+		Id:               buffered.Id,
+		SourceUri:        sourceUri,
+		InferenceService: buffered.InferenceService,
+		Namespace:        buffered.Namespace,
+		Component:        buffered.Component,
+		Endpoint:         buffered.Endpoint,
+		PayloadLimit:     buffered.PayloadLimit,
+		PayloadSize:      buffered.PayloadSize,
+		Metadata:         buffered.Metadata,
+		InferenceGraph:   buffered.InferenceGraph,
+		Step:             buffered.Step,
+		InferenceId:      buffered.InferenceId,
+	}, nil
+}
+
+// Remove deletes a log request from the buffer
+func (b *DiskBuffer) Remove(name string) error {
+	path := filepath.Join(b.dir, name)
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil {
+		return err
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.size -= info.Size()
+	return nil
+}
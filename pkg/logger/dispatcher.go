@@ -17,33 +17,149 @@ limitations under the License.
 package logger
 
 import (
+	"errors"
+	"fmt"
+	"time"
+
 	"go.uber.org/zap"
 )
 
+const (
+	DefaultLoggerMaxRetries     = 3
+	DefaultLoggerInitialBackoff = 500 * time.Millisecond
+	DefaultLoggerMaxBackoff     = 30 * time.Second
+)
+
 var WorkerQueue chan chan LogRequest
 
-func StartDispatcher(nworkers int, logger *zap.SugaredLogger) {
+// diskBuffer stores the log requests which do not fit in the work queue, it is nil when the disk buffer is disabled
+var diskBuffer *DiskBuffer
+
+// diskBufferPollInterval is the delay between the reads of an empty disk buffer
+var diskBufferPollInterval = time.Second
+
+// DeliveryConfig configures the queueing and the retries of the log requests
+type DeliveryConfig struct {
+	// QueueSize is the number of log requests waiting in memory for a worker
+	QueueSize int
+	// MaxRetries is the number of retries of a log request the sink failed to deliver before it is dropped
+	MaxRetries int
+	// InitialBackoff is the delay before the first retry of a log request, it doubles at each retry up to MaxBackoff
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	// DiskBufferPath is the directory storing the log requests when the queue is full, the log requests are
+	// dropped when it is empty
+	DiskBufferPath string
+	// DiskBufferMaxBytes is the max size of the log requests stored in the disk buffer
+	DiskBufferMaxBytes int64
+}
+
+// DefaultDeliveryConfig returns the delivery configuration used when the logger settings are not set
+func DefaultDeliveryConfig() DeliveryConfig {
+	return DeliveryConfig{
+		QueueSize:      LoggerWorkerQueueSize,
+		MaxRetries:     DefaultLoggerMaxRetries,
+		InitialBackoff: DefaultLoggerInitialBackoff,
+		MaxBackoff:     DefaultLoggerMaxBackoff,
+	}
+}
+
+// backoff returns the delay before the given retry of a log request, starting at 0
+func (c DeliveryConfig) backoff(retry int) time.Duration {
+	backoff := c.InitialBackoff
+	for i := 0; i < retry && backoff < c.MaxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > c.MaxBackoff {
+		return c.MaxBackoff
+	}
+	return backoff
+}
+
+func StartDispatcher(nworkers int, sink Sink, delivery DeliveryConfig, logger *zap.SugaredLogger) error {
 	// First, initialize the channel we are going to but the workers' work channels into.
 	WorkerQueue = make(chan chan LogRequest, nworkers)
+	WorkQueue = make(chan LogRequest, delivery.QueueSize)
+	diskBuffer = nil
+	if delivery.DiskBufferPath != "" {
+		buffer, err := NewDiskBuffer(delivery.DiskBufferPath, delivery.DiskBufferMaxBytes)
+		if err != nil {
+			return err
+		}
+		diskBuffer = buffer
+	}
 
 	// Now, create all of our workers.
 	for i := 0; i < nworkers; i++ {
 		logger.Info("Starting worker ", i+1)
-		worker := NewWorker(i+1, WorkerQueue, logger)
+		worker := NewWorker(i+1, WorkerQueue, sink, delivery, logger)
 		worker.Start()
 	}
 
-	// nolint: gosimple
+	// The work requests are only taken from the work queue when a worker is available, so that the work queue
+	// fills up when the sink is slow or down.
+	workQueue, workerQueue := WorkQueue, WorkerQueue
 	go func() {
 		for {
-			select {
-			case work := <-WorkQueue:
-				go func() {
-					worker := <-WorkerQueue
+			worker := <-workerQueue
+			worker <- <-workQueue
+		}
+	}()
+	if diskBuffer != nil {
+		go drainDiskBuffer(diskBuffer, workQueue, logger)
+	}
+	return nil
+}
 
-					worker <- work
-				}()
+// drainDiskBuffer moves the log requests of the disk buffer back to the work queue, oldest first
+func drainDiskBuffer(buffer *DiskBuffer, workQueue chan LogRequest, logger *zap.SugaredLogger) {
+	for {
+		name, logReq, err := buffer.Oldest()
+		if err != nil {
+			logger.Errorf("Failed to read the logger disk buffer: %v", err)
+			// an unreadable log request is dropped so that it does not block the others
+			if name != "" {
+				eventsDropped.WithLabelValues(DropReasonBufferError).Inc()
+				if err := buffer.Remove(name); err != nil {
+					logger.Errorf("Failed to remove %s from the logger disk buffer: %v", name, err)
+				}
 			}
+			time.Sleep(diskBufferPollInterval)
+			continue
 		}
-	}()
+		if name == "" {
+			time.Sleep(diskBufferPollInterval)
+			continue
+		}
+		workQueue <- *logReq
+		eventsEnqueued.Inc()
+		if err := buffer.Remove(name); err != nil {
+			logger.Errorf("Failed to remove %s from the logger disk buffer: %v", name, err)
+			time.Sleep(diskBufferPollInterval)
+		}
+	}
+}
+
+// QueueLogRequest queues a log request for the workers without blocking. The log request is stored in the disk
+// buffer when the work queue is full, or dropped when the disk buffer is disabled or full.
+func QueueLogRequest(req LogRequest) error {
+	select {
+	case WorkQueue <- req:
+		eventsEnqueued.Inc()
+		return nil
+	default:
+	}
+	if diskBuffer != nil {
+		err := diskBuffer.Push(req)
+		if err == nil {
+			eventsBuffered.Inc()
+			return nil
+		}
+		if !errors.Is(err, ErrDiskBufferFull) {
+			eventsDropped.WithLabelValues(DropReasonBufferError).Inc()
+			return err
+		}
+	}
+	eventsDropped.WithLabelValues(DropReasonQueueFull).Inc()
+	return fmt.Errorf("the logger queue is full, the log request %s of type %s is dropped", req.Id, req.ReqType)
 }
@@ -0,0 +1,242 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	pkglogging "knative.dev/pkg/logging"
+)
+
+// fakeSink fails the first sends then delivers the log requests, once released
+type fakeSink struct {
+	mu        sync.Mutex
+	failures  int
+	attempts  int
+	release   chan struct{}
+	delivered chan LogRequest
+}
+
+func (s *fakeSink) Send(_ context.Context, logReq LogRequest) error {
+	if s.release != nil {
+		<-s.release
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.attempts++
+	if s.attempts <= s.failures {
+		return errors.New("sink is down")
+	}
+	s.delivered <- logReq
+	return nil
+}
+
+func newLogRequest(g *gomega.WithT, id string) LogRequest {
+	logUrl, err := url.Parse("http://message-dumper.default")
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	sourceUri, err := url.Parse("http://localhost:9081/")
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	payload := []byte(`{"instances":[[0,0,0]]}`)
+	return LogRequest{
+		Url:              logUrl,
+		Bytes:            &payload,
+		ContentType:      "application/json",
+		ReqType:          CEInferenceRequest,
+		Id:               id,
+		SourceUri:        sourceUri,
+		InferenceService: "mymodel",
+		Namespace:        "default",
+		Component:        "predictor",
+		Endpoint:         "default",
+	}
+}
+
+func TestDeliveryBackoff(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	delivery := DefaultDeliveryConfig()
+	g.Expect(delivery.backoff(0)).To(gomega.Equal(500 * time.Millisecond))
+	g.Expect(delivery.backoff(1)).To(gomega.Equal(time.Second))
+	g.Expect(delivery.backoff(3)).To(gomega.Equal(4 * time.Second))
+	g.Expect(delivery.backoff(10)).To(gomega.Equal(30 * time.Second))
+	g.Expect(delivery.backoff(100)).To(gomega.Equal(30 * time.Second))
+}
+
+func TestDeliveryRetries(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	logger, _ := pkglogging.NewLogger("", "INFO")
+	scenarios := map[string]struct {
+		failures  int
+		delivered bool
+		retried   int
+	}{
+		"SinkUp": {
+			failures:  0,
+			delivered: true,
+		},
+		"SinkRecovers": {
+			failures:  2,
+			delivered: true,
+			retried:   2,
+		},
+		"SinkRecoversAtTheLastRetry": {
+			failures:  3,
+			delivered: true,
+			retried:   3,
+		},
+		"SinkDown": {
+			failures: 5,
+			retried:  3,
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			sink := &fakeSink{failures: scenario.failures, delivered: make(chan LogRequest, 1)}
+			delivery := DefaultDeliveryConfig()
+			delivery.InitialBackoff = time.Millisecond
+			g.Expect(StartDispatcher(1, sink, delivery, logger)).To(gomega.Succeed())
+
+			deliveredBefore := testutil.ToFloat64(eventsDelivered)
+			retriedBefore := testutil.ToFloat64(eventsRetried)
+			droppedBefore := testutil.ToFloat64(eventsDropped.WithLabelValues(DropReasonRetriesExhausted))
+			g.Expect(QueueLogRequest(newLogRequest(g, name))).To(gomega.Succeed())
+
+			if scenario.delivered {
+				g.Eventually(sink.delivered).Should(gomega.Receive(gomega.HaveField("Id", name)))
+				g.Eventually(func() float64 {
+					return testutil.ToFloat64(eventsDelivered) - deliveredBefore
+				}).Should(gomega.Equal(float64(1)))
+			} else {
+				g.Eventually(func() float64 {
+					return testutil.ToFloat64(eventsDropped.WithLabelValues(DropReasonRetriesExhausted)) - droppedBefore
+				}).Should(gomega.Equal(float64(1)))
+				g.Consistently(sink.delivered, 50*time.Millisecond).ShouldNot(gomega.Receive())
+			}
+			g.Expect(testutil.ToFloat64(eventsRetried) - retriedBefore).To(gomega.Equal(float64(scenario.retried)))
+		})
+	}
+}
+
+func TestQueueFull(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	logger, _ := pkglogging.NewLogger("", "INFO")
+	sink := &fakeSink{release: make(chan struct{}), delivered: make(chan LogRequest, 10)}
+	delivery := DefaultDeliveryConfig()
+	delivery.QueueSize = 1
+	g.Expect(StartDispatcher(1, sink, delivery, logger)).To(gomega.Succeed())
+
+	// the worker is blocked by the first request, the second one waits in the queue
+	g.Expect(QueueLogRequest(newLogRequest(g, "request-0"))).To(gomega.Succeed())
+	g.Eventually(func() int { return len(WorkQueue) }).Should(gomega.Equal(0))
+	g.Expect(QueueLogRequest(newLogRequest(g, "request-1"))).To(gomega.Succeed())
+
+	droppedBefore := testutil.ToFloat64(eventsDropped.WithLabelValues(DropReasonQueueFull))
+	g.Expect(QueueLogRequest(newLogRequest(g, "request-2"))).To(gomega.MatchError(gomega.ContainSubstring("queue is full")))
+	g.Expect(testutil.ToFloat64(eventsDropped.WithLabelValues(DropReasonQueueFull)) - droppedBefore).To(gomega.Equal(float64(1)))
+
+	close(sink.release)
+	g.Eventually(sink.delivered).Should(gomega.Receive(gomega.HaveField("Id", "request-0")))
+	g.Eventually(sink.delivered).Should(gomega.Receive(gomega.HaveField("Id", "request-1")))
+	g.Consistently(sink.delivered, 50*time.Millisecond).ShouldNot(gomega.Receive())
+}
+
+func TestQueueOverflowToDiskBuffer(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	logger, _ := pkglogging.NewLogger("", "INFO")
+	diskBufferPollInterval = 10 * time.Millisecond
+	defer func() { diskBufferPollInterval = time.Second }()
+
+	sink := &fakeSink{failures: 1, release: make(chan struct{}), delivered: make(chan LogRequest, 10)}
+	delivery := DefaultDeliveryConfig()
+	delivery.QueueSize = 1
+	delivery.InitialBackoff = time.Millisecond
+	delivery.DiskBufferPath = filepath.Join(t.TempDir(), "buffer")
+	delivery.DiskBufferMaxBytes = 1 << 20
+	g.Expect(StartDispatcher(1, sink, delivery, logger)).To(gomega.Succeed())
+
+	bufferedBefore := testutil.ToFloat64(eventsBuffered)
+	for i := 0; i < 5; i++ {
+		g.Expect(QueueLogRequest(newLogRequest(g, fmt.Sprintf("request-%d", i)))).To(gomega.Succeed())
+	}
+	g.Expect(testutil.ToFloat64(eventsBuffered) - bufferedBefore).To(gomega.BeNumerically(">=", 2))
+
+	// the sink recovers after failing once, all the requests are delivered including the buffered ones
+	close(sink.release)
+	delivered := []string{}
+	for i := 0; i < 5; i++ {
+		logReq := LogRequest{}
+		g.Eventually(sink.delivered).Should(gomega.Receive(&logReq))
+		g.Expect(*logReq.Bytes).To(gomega.Equal([]byte(`{"instances":[[0,0,0]]}`)))
+		g.Expect(logReq.Url.String()).To(gomega.Equal("http://message-dumper.default"))
+		delivered = append(delivered, logReq.Id)
+	}
+	g.Expect(delivered).To(gomega.ConsistOf("request-0", "request-1", "request-2", "request-3", "request-4"))
+	g.Eventually(func() ([]os.DirEntry, error) {
+		return os.ReadDir(delivery.DiskBufferPath)
+	}).Should(gomega.BeEmpty())
+}
+
+func TestDiskBuffer(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	dir := filepath.Join(t.TempDir(), "buffer")
+	buffer, err := NewDiskBuffer(dir, 1024)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+
+	name, logReq, err := buffer.Oldest()
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(name).To(gomega.BeEmpty())
+	g.Expect(logReq).To(gomega.BeNil())
+
+	for i := 0; i < 3; i++ {
+		g.Expect(buffer.Push(newLogRequest(g, fmt.Sprintf("request-%d", i)))).To(gomega.Succeed())
+	}
+	g.Expect(buffer.Push(newLogRequest(g, "request-3"))).To(gomega.MatchError(ErrDiskBufferFull))
+
+	// the requests left by a previous run count in the size of the buffer
+	reopened, err := NewDiskBuffer(dir, 1024)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(reopened.size).To(gomega.Equal(buffer.size))
+
+	name, logReq, err = reopened.Oldest()
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	expected := newLogRequest(g, "request-0")
+	g.Expect(*logReq).To(gomega.Equal(expected))
+	g.Expect(reopened.Remove(name)).To(gomega.Succeed())
+	g.Expect(reopened.Push(newLogRequest(g, "request-3"))).To(gomega.Succeed())
+
+	ids := []string{}
+	for {
+		name, logReq, err := reopened.Oldest()
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+		if name == "" {
+			break
+		}
+		ids = append(ids, logReq.Id)
+		g.Expect(reopened.Remove(name)).To(gomega.Succeed())
+	}
+	g.Expect(ids).To(gomega.Equal([]string{"request-1", "request-2", "request-3"}))
+	g.Expect(reopened.size).To(gomega.BeZero())
+}
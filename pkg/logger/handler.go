@@ -18,10 +18,12 @@ package logger
 
 import (
 	"bytes"
+	"encoding/json"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"strings"
 
 	"github.com/go-logr/logr"
 	guuid "github.com/google/uuid"
@@ -40,11 +42,14 @@ type LoggerHandler struct {
 	namespace        string
 	component        string
 	endpoint         string
+	policy           *Policy
+	metadataHeaders  []string
 	next             http.Handler
 }
 
 func New(logUrl *url.URL, sourceUri *url.URL, logMode v1beta1.LoggerType,
-	inferenceService string, namespace string, endpoint string, component string, next http.Handler) http.Handler {
+	inferenceService string, namespace string, endpoint string, component string, policy *Policy,
+	metadataHeaders []string, next http.Handler) http.Handler {
 	logf.SetLogger(zap.New())
 	return &LoggerHandler{
 		log:              logf.Log.WithName("Logger"),
@@ -55,6 +60,8 @@ func New(logUrl *url.URL, sourceUri *url.URL, logMode v1beta1.LoggerType,
 		namespace:        namespace,
 		component:        component,
 		endpoint:         endpoint,
+		policy:           policy,
+		metadataHeaders:  metadataHeaders,
 		next:             next,
 	}
 }
@@ -67,6 +74,54 @@ func getOrCreateID(r *http.Request) string {
 	return id
 }
 
+// captureMetadata returns the values of the metadata headers of the request, by lowercase header name
+func (eh *LoggerHandler) captureMetadata(r *http.Request) map[string][]string {
+	var metadata map[string][]string
+	for _, name := range eh.metadataHeaders {
+		if values := r.Header.Values(name); len(values) > 0 {
+			if metadata == nil {
+				metadata = map[string][]string{}
+			}
+			metadata[strings.ToLower(name)] = values
+		}
+	}
+	return metadata
+}
+
+// IsErrorResponse returns whether the model failed the inference, either with a non-2xx status or with a 2xx
+// response having the error field of the v2 protocol
+func IsErrorResponse(statusCode int, body []byte) bool {
+	if statusCode < http.StatusOK || statusCode >= http.StatusMultipleChoices {
+		return true
+	}
+	if !bytes.HasPrefix(bytes.TrimSpace(body), []byte("{")) {
+		return false
+	}
+	response := struct {
+		Error json.RawMessage `json:"error"`
+	}{}
+	return json.Unmarshal(body, &response) == nil && len(response.Error) > 0 && string(response.Error) != "null"
+}
+
+// logPayload queues the log request of a payload unless the inference is sampled out or the content type of the
+// payload is not logged
+func (eh *LoggerHandler) logPayload(id string, sampled bool, reqType string, contentType string, payload *[]byte,
+	metadata map[string][]string) error {
+	return eh.policy.Queue(LogRequest{
+		Url:              eh.logUrl,
+		Bytes:            payload,
+		ContentType:      contentType,
+		ReqType:          reqType,
+		Id:               id,
+		SourceUri:        eh.sourceUri,
+		InferenceService: eh.inferenceService,
+		Namespace:        eh.namespace,
+		Endpoint:         eh.endpoint,
+		Component:        eh.component,
+		Metadata:         metadata,
+	}, sampled)
+}
+
 // call svc and add send request/responses to logUrl
 func (eh *LoggerHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	if network.IsKubeletProbe(r) {
@@ -84,21 +139,13 @@ func (eh *LoggerHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 	// Get or Create an ID
 	id := getOrCreateID(r)
-	contentType := r.Header.Get("Content-Type")
+	// the request and the response are sampled together
+	sampled := eh.policy.Sampled(id)
+	metadata := eh.captureMetadata(r)
+	requestContentType := r.Header.Get("Content-Type")
 	// log Request
 	if eh.logMode == v1beta1.LogAll || eh.logMode == v1beta1.LogRequest {
-		if err := QueueLogRequest(LogRequest{
-			Url:              eh.logUrl,
-			Bytes:            &body,
-			ContentType:      contentType,
-			ReqType:          CEInferenceRequest,
-			Id:               id,
-			SourceUri:        eh.sourceUri,
-			InferenceService: eh.inferenceService,
-			Namespace:        eh.namespace,
-			Endpoint:         eh.endpoint,
-			Component:        eh.component,
-		}); err != nil {
+		if err := eh.logPayload(id, sampled, CEInferenceRequest, requestContentType, &body, metadata); err != nil {
 			eh.log.Error(err, "Failed to log request")
 		}
 	}
@@ -108,25 +155,23 @@ func (eh *LoggerHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	rr := httptest.NewRecorder()
 	eh.next.ServeHTTP(rr, r)
 	responseBody := rr.Body.Bytes()
-	contentType = rr.Header().Get("Content-Type")
+	contentType := rr.Header().Get("Content-Type")
 	if contentType != "" {
 		w.Header().Set("Content-Type", contentType)
 	}
+	// in errors mode, the request is only logged once the response is known to be an error
+	if eh.logMode == v1beta1.LogErrors && IsErrorResponse(rr.Code, responseBody) {
+		if err := eh.logPayload(id, sampled, CEInferenceRequest, requestContentType, &body, metadata); err != nil {
+			eh.log.Error(err, "Failed to log request")
+		}
+		if err := eh.logPayload(id, sampled, CEInferenceResponse, contentType, &responseBody, metadata); err != nil {
+			eh.log.Error(err, "Failed to log response")
+		}
+	}
 	// log response if OK
 	if rr.Code == http.StatusOK {
 		if eh.logMode == v1beta1.LogAll || eh.logMode == v1beta1.LogResponse {
-			if err := QueueLogRequest(LogRequest{
-				Url:              eh.logUrl,
-				Bytes:            &responseBody,
-				ContentType:      contentType,
-				ReqType:          CEInferenceResponse,
-				Id:               id,
-				SourceUri:        eh.sourceUri,
-				InferenceService: eh.inferenceService,
-				Namespace:        eh.namespace,
-				Endpoint:         eh.endpoint,
-				Component:        eh.component,
-			}); err != nil {
+			if err := eh.logPayload(id, sampled, CEInferenceResponse, contentType, &responseBody, metadata); err != nil {
 				eh.log.Error(err, "Failed to log response")
 			}
 		}
@@ -24,9 +24,11 @@ import (
 	"net/http/httputil"
 	"net/url"
 	"testing"
+	"time"
 
 	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
 	"github.com/onsi/gomega"
+	"github.com/segmentio/kafka-go"
 	pkglogging "knative.dev/pkg/logging"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
@@ -76,9 +78,9 @@ func TestLogger(t *testing.T) {
 	targetUri, err := url.Parse(predictor.URL)
 	g.Expect(err).To(gomega.BeNil())
 
-	StartDispatcher(5, logger)
+	g.Expect(StartDispatcher(5, &HTTPSink{}, DefaultDeliveryConfig(), logger)).To(gomega.Succeed())
 	httpProxy := httputil.NewSingleHostReverseProxy(targetUri)
-	oh := New(logSvcUrl, sourceUri, v1beta1.LogAll, "mymodel", "default", "default", "default", httpProxy)
+	oh := New(logSvcUrl, sourceUri, v1beta1.LogAll, "mymodel", "default", "default", "default", nil, nil, httpProxy)
 
 	oh.ServeHTTP(w, r)
 
@@ -119,11 +121,74 @@ func TestBadResponse(t *testing.T) {
 	targetUri, err := url.Parse(predictor.URL)
 	g.Expect(err).To(gomega.BeNil())
 
-	StartDispatcher(1, logger)
+	g.Expect(StartDispatcher(1, &HTTPSink{}, DefaultDeliveryConfig(), logger)).To(gomega.Succeed())
 	httpProxy := httputil.NewSingleHostReverseProxy(targetUri)
-	oh := New(logSvcUrl, sourceUri, v1beta1.LogAll, "mymodel", "default", "default", "default", httpProxy)
+	oh := New(logSvcUrl, sourceUri, v1beta1.LogAll, "mymodel", "default", "default", "default", nil, nil, httpProxy)
 
 	oh.ServeHTTP(w, r)
 	g.Expect(w.Code).To(gomega.Equal(400))
 	g.Expect(w.Body.String()).To(gomega.Equal(predictorResponse))
 }
+
+func TestLoggerErrorsMode(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	predictorRequest := []byte(`{"inputs":[{"name":"input-0","shape":[1],"datatype":"INT32","data":[1]}]}`)
+	predictor := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.Header().Set("Content-Type", "application/json")
+		switch req.URL.Path {
+		case "/fail":
+			rw.WriteHeader(http.StatusInternalServerError)
+			_, _ = rw.Write([]byte(`{"error":"model crashed"}`))
+		case "/v2-error":
+			_, _ = rw.Write([]byte(`{"error":"invalid input shape"}`))
+		default:
+			_, _ = rw.Write([]byte(`{"model_name":"mymodel","outputs":[]}`))
+		}
+	}))
+	defer predictor.Close()
+
+	writer := &fakeKafkaWriter{messages: make(chan kafka.Message, 10)}
+	logger, _ := pkglogging.NewLogger("", "INFO")
+	logUrl, err := url.Parse("kafka://kafka:9092/inference-logs")
+	g.Expect(err).To(gomega.BeNil())
+	sourceUri, err := url.Parse("http://localhost:9081/")
+	g.Expect(err).To(gomega.BeNil())
+	targetUri, err := url.Parse(predictor.URL)
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(StartDispatcher(1, &KafkaSink{Writer: writer}, DefaultDeliveryConfig(), logger)).To(gomega.Succeed())
+	oh := New(logUrl, sourceUri, v1beta1.LogErrors, "mymodel", "default", "default", "predictor", nil,
+		[]string{"X-Tenant-Id", "X-Missing"}, httputil.NewSingleHostReverseProxy(targetUri))
+
+	scenarios := map[string]struct {
+		path   string
+		logged bool
+	}{
+		"InternalServerError": {path: "/fail", logged: true},
+		"V2ErrorResponse":     {path: "/v2-error", logged: true},
+		"Success":             {path: "/", logged: false},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			r := httptest.NewRequest("POST", "http://a"+scenario.path, bytes.NewReader(predictorRequest))
+			r.Header.Set(CloudEventsIdHeader, name)
+			r.Header.Add("X-Tenant-Id", "tenant-a")
+			r.Header.Add("X-Tenant-Id", "tenant-b")
+			w := httptest.NewRecorder()
+			oh.ServeHTTP(w, r)
+
+			if !scenario.logged {
+				g.Consistently(writer.messages, 100*time.Millisecond).ShouldNot(gomega.Receive())
+				return
+			}
+			types := map[string]bool{}
+			for i := 0; i < 2; i++ {
+				msg := <-writer.messages
+				headers := kafkaHeaders(msg)
+				g.Expect(headers["ce_id"]).To(gomega.Equal(name))
+				g.Expect(headers["ce_metadata"]).To(gomega.Equal(`{"x-tenant-id":["tenant-a","tenant-b"]}`))
+				types[headers["ce_type"]] = true
+			}
+			g.Expect(types).To(gomega.Equal(map[string]bool{CEInferenceRequest: true, CEInferenceResponse: true}))
+		})
+	}
+}
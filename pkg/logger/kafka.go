@@ -0,0 +1,258 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logger
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cloudevents/sdk-go/v2/types"
+	"github.com/segmentio/kafka-go"
+	"github.com/segmentio/kafka-go/sasl"
+	"github.com/segmentio/kafka-go/sasl/plain"
+	"github.com/segmentio/kafka-go/sasl/scram"
+)
+
+const (
+	// KafkaScheme is the scheme of the logger urls published to kafka, kafka://broker1:9092,broker2:9092/topic
+	KafkaScheme = "kafka"
+
+	// Keys of the secret with the SASL/TLS configuration of the kafka sink
+	KafkaSaslMechanismKey = "sasl.mechanism"
+	KafkaSaslUsernameKey  = "sasl.username"
+	KafkaSaslPasswordKey  = "sasl.password"
+	KafkaTLSEnabledKey    = "tls.enabled"
+	KafkaCACertKey        = "ca.crt"
+	KafkaClientCertKey    = "tls.crt"
+	KafkaClientKeyKey     = "tls.key"
+
+	// SASL mechanisms supported by the kafka sink
+	KafkaSaslPlain       = "PLAIN"
+	KafkaSaslScramSHA256 = "SCRAM-SHA-256"
+	KafkaSaslScramSHA512 = "SCRAM-SHA-512"
+
+	// cloud events attributes are sent as kafka headers prefixed by ce_ in the binary content mode
+	kafkaHeaderPrefix      = "ce_"
+	kafkaContentTypeHeader = "content-type"
+	kafkaBatchTimeout      = 10 * time.Millisecond
+)
+
+// KafkaWriter writes messages to the topic of the kafka sink, it is implemented by kafka.Writer
+type KafkaWriter interface {
+	WriteMessages(ctx context.Context, msgs ...kafka.Message) error
+}
+
+// KafkaSink publishes the log requests to a kafka topic as binary cloud events, the cloud events attributes
+// are mapped to the kafka headers and the payload is the value of the message
+type KafkaSink struct {
+	Writer KafkaWriter
+}
+
+// ParseKafkaURL returns the brokers and the topic of a kafka://broker1:9092,broker2:9092/topic logger url
+func ParseKafkaURL(logUrl *url.URL) ([]string, string, error) {
+	if logUrl.Scheme != KafkaScheme {
+		return nil, "", fmt.Errorf("the scheme of the kafka logger url %s must be %s://", logUrl, KafkaScheme)
+	}
+	brokers := []string{}
+	for _, broker := range strings.Split(logUrl.Host, ",") {
+		if broker != "" {
+			brokers = append(brokers, broker)
+		}
+	}
+	if len(brokers) == 0 {
+		return nil, "", fmt.Errorf("the kafka logger url %s has no brokers", logUrl)
+	}
+	topic := strings.TrimPrefix(logUrl.Path, "/")
+	if topic == "" || strings.Contains(topic, "/") {
+		return nil, "", fmt.Errorf("the kafka logger url %s must have exactly one topic", logUrl)
+	}
+	return brokers, topic, nil
+}
+
+// NewKafkaSink returns a sink writing to the brokers and the topic of the kafka logger url, the SASL and TLS
+// configuration is read from the files of secretDir when it is set
+func NewKafkaSink(logUrl *url.URL, secretDir string) (*KafkaSink, error) {
+	brokers, topic, err := ParseKafkaURL(logUrl)
+	if err != nil {
+		return nil, err
+	}
+	transport, err := newKafkaTransport(secretDir)
+	if err != nil {
+		return nil, err
+	}
+	return &KafkaSink{
+		Writer: &kafka.Writer{
+			Addr:  kafka.TCP(brokers...),
+			Topic: topic,
+			// the request and the response of an inference have the same key and land in the same partition
+			Balancer:     &kafka.Hash{},
+			RequiredAcks: kafka.RequireOne,
+			BatchTimeout: kafkaBatchTimeout,
+			Transport:    transport,
+		},
+	}, nil
+}
+
+func newKafkaTransport(secretDir string) (*kafka.Transport, error) {
+	transport := &kafka.Transport{}
+	if secretDir == "" {
+		return transport, nil
+	}
+	mechanism, err := newSaslMechanism(secretDir)
+	if err != nil {
+		return nil, err
+	}
+	transport.SASL = mechanism
+	transport.TLS, err = newKafkaTLSConfig(secretDir)
+	if err != nil {
+		return nil, err
+	}
+	return transport, nil
+}
+
+func newSaslMechanism(secretDir string) (sasl.Mechanism, error) {
+	mechanism, err := readSecretKey(secretDir, KafkaSaslMechanismKey)
+	if err != nil || mechanism == "" {
+		return nil, err
+	}
+	username, err := readSecretKey(secretDir, KafkaSaslUsernameKey)
+	if err != nil {
+		return nil, err
+	}
+	password, err := readSecretKey(secretDir, KafkaSaslPasswordKey)
+	if err != nil {
+		return nil, err
+	}
+	switch strings.ToUpper(mechanism) {
+	case KafkaSaslPlain:
+		return plain.Mechanism{Username: username, Password: password}, nil
+	case KafkaSaslScramSHA256:
+		return scram.Mechanism(scram.SHA256, username, password)
+	case KafkaSaslScramSHA512:
+		return scram.Mechanism(scram.SHA512, username, password)
+	default:
+		return nil, fmt.Errorf("unsupported kafka SASL mechanism %s, must be one of %s, %s or %s",
+			mechanism, KafkaSaslPlain, KafkaSaslScramSHA256, KafkaSaslScramSHA512)
+	}
+}
+
+// newKafkaTLSConfig returns the TLS configuration of the kafka sink, TLS is enabled by tls.enabled or when
+// a CA or a client certificate is provided
+func newKafkaTLSConfig(secretDir string) (*tls.Config, error) {
+	enabled, err := readSecretKey(secretDir, KafkaTLSEnabledKey)
+	if err != nil {
+		return nil, err
+	}
+	caCert, err := readSecretKey(secretDir, KafkaCACertKey)
+	if err != nil {
+		return nil, err
+	}
+	clientCert, err := readSecretKey(secretDir, KafkaClientCertKey)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.EqualFold(enabled, "true") && caCert == "" && clientCert == "" {
+		return nil, nil
+	}
+
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if caCert != "" {
+		tlsConfig.RootCAs = x509.NewCertPool()
+		if !tlsConfig.RootCAs.AppendCertsFromPEM([]byte(caCert)) {
+			return nil, fmt.Errorf("failed to parse the kafka CA certificate %s", KafkaCACertKey)
+		}
+	}
+	if clientCert != "" {
+		clientKey, err := readSecretKey(secretDir, KafkaClientKeyKey)
+		if err != nil {
+			return nil, err
+		}
+		certificate, err := tls.X509KeyPair([]byte(clientCert), []byte(clientKey))
+		if err != nil {
+			return nil, fmt.Errorf("failed to load the kafka client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+	return tlsConfig, nil
+}
+
+// readSecretKey returns the content of a key of the mounted secret, or an empty string if it is not set
+func readSecretKey(secretDir string, key string) (string, error) {
+	content, err := os.ReadFile(filepath.Join(secretDir, key))
+	if errors.Is(err, os.ErrNotExist) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read the key %s of the logger secret: %w", key, err)
+	}
+	return strings.TrimSpace(string(content)), nil
+}
+
+func (s *KafkaSink) Send(ctx context.Context, logReq LogRequest) error {
+	event, err := newCloudEvent(logReq)
+	if err != nil {
+		return err
+	}
+	event.SetTime(time.Now())
+
+	attributes := map[string]interface{}{
+		"specversion": event.SpecVersion(),
+		"id":          event.ID(),
+		"type":        event.Type(),
+		"source":      event.Source(),
+		"time":        event.Time(),
+	}
+	for name, value := range event.Extensions() {
+		attributes[name] = value
+	}
+	names := make([]string, 0, len(attributes))
+	for name := range attributes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	headers := make([]kafka.Header, 0, len(attributes)+1)
+	for _, name := range names {
+		value, err := types.Format(attributes[name])
+		if err != nil {
+			return fmt.Errorf("while formatting cloudevents attribute %s: %w", name, err)
+		}
+		headers = append(headers, kafka.Header{Key: kafkaHeaderPrefix + name, Value: []byte(value)})
+	}
+	if contentType := event.DataContentType(); contentType != "" {
+		headers = append(headers, kafka.Header{Key: kafkaContentTypeHeader, Value: []byte(contentType)})
+	}
+
+	message := kafka.Message{
+		Key:     []byte(logReq.Id),
+		Value:   event.Data(),
+		Headers: headers,
+	}
+	if err := s.Writer.WriteMessages(ctx, message); err != nil {
+		return fmt.Errorf("while writing kafka message: %w", err)
+	}
+	return nil
+}
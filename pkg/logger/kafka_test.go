@@ -0,0 +1,272 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logger
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httputil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/onsi/gomega"
+	"github.com/segmentio/kafka-go"
+	"github.com/segmentio/kafka-go/sasl/plain"
+	pkglogging "knative.dev/pkg/logging"
+)
+
+type fakeKafkaWriter struct {
+	messages chan kafka.Message
+}
+
+func (w *fakeKafkaWriter) WriteMessages(_ context.Context, msgs ...kafka.Message) error {
+	for _, msg := range msgs {
+		w.messages <- msg
+	}
+	return nil
+}
+
+func kafkaHeaders(msg kafka.Message) map[string]string {
+	headers := map[string]string{}
+	for _, header := range msg.Headers {
+		headers[header.Key] = string(header.Value)
+	}
+	return headers
+}
+
+func TestParseKafkaURL(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	scenarios := map[string]struct {
+		url     string
+		brokers []string
+		topic   string
+		err     bool
+	}{
+		"SingleBroker": {
+			url:     "kafka://kafka:9092/inference-logs",
+			brokers: []string{"kafka:9092"},
+			topic:   "inference-logs",
+		},
+		"MultipleBrokers": {
+			url:     "kafka://kafka-0:9092,kafka-1:9092/inference-logs",
+			brokers: []string{"kafka-0:9092", "kafka-1:9092"},
+			topic:   "inference-logs",
+		},
+		"NoBroker": {
+			url: "kafka:///inference-logs",
+			err: true,
+		},
+		"NoTopic": {
+			url: "kafka://kafka:9092",
+			err: true,
+		},
+		"NestedTopic": {
+			url: "kafka://kafka:9092/inference/logs",
+			err: true,
+		},
+		"HttpScheme": {
+			url: "http://kafka:9092/inference-logs",
+			err: true,
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			logUrl, err := url.Parse(scenario.url)
+			g.Expect(err).NotTo(gomega.HaveOccurred())
+			brokers, topic, err := ParseKafkaURL(logUrl)
+			if scenario.err {
+				g.Expect(err).To(gomega.HaveOccurred())
+				return
+			}
+			g.Expect(err).NotTo(gomega.HaveOccurred())
+			g.Expect(brokers).To(gomega.Equal(scenario.brokers))
+			g.Expect(topic).To(gomega.Equal(scenario.topic))
+		})
+	}
+}
+
+func TestNewKafkaSink(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	logUrl, err := url.Parse("kafka://kafka-0:9092,kafka-1:9092/inference-logs")
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+
+	sink, err := NewSink(logUrl, "")
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	writer := sink.(*KafkaSink).Writer.(*kafka.Writer)
+	g.Expect(writer.Addr.String()).To(gomega.Equal("kafka-0:9092,kafka-1:9092"))
+	g.Expect(writer.Topic).To(gomega.Equal("inference-logs"))
+	g.Expect(writer.Transport.(*kafka.Transport).SASL).To(gomega.BeNil())
+	g.Expect(writer.Transport.(*kafka.Transport).TLS).To(gomega.BeNil())
+
+	secretDir := t.TempDir()
+	for key, value := range map[string]string{
+		KafkaSaslMechanismKey: "plain",
+		KafkaSaslUsernameKey:  "user",
+		KafkaSaslPasswordKey:  "password\n",
+		KafkaTLSEnabledKey:    "true",
+	} {
+		g.Expect(os.WriteFile(filepath.Join(secretDir, key), []byte(value), 0600)).To(gomega.Succeed())
+	}
+	sink, err = NewSink(logUrl, secretDir)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	transport := sink.(*KafkaSink).Writer.(*kafka.Writer).Transport.(*kafka.Transport)
+	g.Expect(transport.SASL).To(gomega.Equal(plain.Mechanism{Username: "user", Password: "password"}))
+	g.Expect(transport.TLS).NotTo(gomega.BeNil())
+
+	g.Expect(os.WriteFile(filepath.Join(secretDir, KafkaSaslMechanismKey), []byte("GSSAPI"), 0600)).To(gomega.Succeed())
+	_, err = NewSink(logUrl, secretDir)
+	g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("unsupported kafka SASL mechanism GSSAPI")))
+
+	g.Expect(os.Remove(filepath.Join(secretDir, KafkaSaslMechanismKey))).To(gomega.Succeed())
+	g.Expect(os.WriteFile(filepath.Join(secretDir, KafkaCACertKey), []byte("not a certificate"), 0600)).To(gomega.Succeed())
+	_, err = NewSink(logUrl, secretDir)
+	g.Expect(err).To(gomega.MatchError(gomega.ContainSubstring("failed to parse the kafka CA certificate")))
+
+	httpUrl, err := url.Parse("http://message-dumper.default")
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	sink, err = NewSink(httpUrl, "")
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(sink).To(gomega.BeAssignableToTypeOf(&HTTPSink{}))
+}
+
+func TestKafkaSinkSend(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	writer := &fakeKafkaWriter{messages: make(chan kafka.Message, 1)}
+	sink := &KafkaSink{Writer: writer}
+	logUrl, err := url.Parse("kafka://kafka:9092/inference-logs")
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	sourceUri, err := url.Parse("http://localhost:9081/")
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	payload := []byte(`{"instances":[[0,0,0]]}`)
+
+	err = sink.Send(context.Background(), LogRequest{
+		Url:              logUrl,
+		Bytes:            &payload,
+		ContentType:      "application/json",
+		ReqType:          CEInferenceRequest,
+		Id:               "0a6b3bd9-5ab7-4d2a-9e5c-5c1d2b2f3e61",
+		SourceUri:        sourceUri,
+		InferenceService: "mymodel",
+		Namespace:        "default",
+		Component:        "predictor",
+		Endpoint:         "default",
+	})
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+
+	msg := <-writer.messages
+	g.Expect(msg.Key).To(gomega.Equal([]byte("0a6b3bd9-5ab7-4d2a-9e5c-5c1d2b2f3e61")))
+	g.Expect(msg.Value).To(gomega.Equal(payload))
+	headers := kafkaHeaders(msg)
+	g.Expect(headers).To(gomega.HaveKey("ce_time"))
+	delete(headers, "ce_time")
+	g.Expect(headers).To(gomega.Equal(map[string]string{
+		"ce_specversion":          "1.0",
+		"ce_id":                   "0a6b3bd9-5ab7-4d2a-9e5c-5c1d2b2f3e61",
+		"ce_type":                 CEInferenceRequest,
+		"ce_source":               "http://localhost:9081/",
+		"ce_inferenceservicename": "mymodel",
+		"ce_namespace":            "default",
+		"ce_component":            "predictor",
+		"ce_endpoint":             "default",
+		"content-type":            "application/json",
+	}))
+}
+
+func TestKafkaSinkSendInferenceGraphEvent(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	writer := &fakeKafkaWriter{messages: make(chan kafka.Message, 1)}
+	sink := &KafkaSink{Writer: writer}
+	logUrl, err := url.Parse("kafka://kafka:9092/inference-logs")
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	sourceUri, err := url.Parse("http://localhost:8080/")
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	payload := []byte(`{"instances":[[0,0,0]]}`)
+
+	err = sink.Send(context.Background(), LogRequest{
+		Url:            logUrl,
+		Bytes:          &payload,
+		ContentType:    "application/json",
+		ReqType:        CEInferenceResponse,
+		Id:             "0a6b3bd9-5ab7-4d2a-9e5c-5c1d2b2f3e61-classifier",
+		SourceUri:      sourceUri,
+		Namespace:      "default",
+		InferenceGraph: "mygraph",
+		Step:           "classifier",
+		InferenceId:    "0a6b3bd9-5ab7-4d2a-9e5c-5c1d2b2f3e61",
+	})
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+
+	headers := kafkaHeaders(<-writer.messages)
+	g.Expect(headers).To(gomega.HaveKeyWithValue("ce_inferencegraphname", "mygraph"))
+	g.Expect(headers).To(gomega.HaveKeyWithValue("ce_step", "classifier"))
+	g.Expect(headers).To(gomega.HaveKeyWithValue("ce_inferenceid", "0a6b3bd9-5ab7-4d2a-9e5c-5c1d2b2f3e61"))
+	g.Expect(headers).To(gomega.HaveKeyWithValue("ce_type", CEInferenceResponse))
+}
+
+func TestLoggerKafkaSink(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	predictorRequest := []byte(`{"instances":[[0,0,0]]}`)
+	predictorResponse := []byte(`{"instances":[[4,5,6]]}`)
+
+	predictor := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		_, err := rw.Write(predictorResponse)
+		g.Expect(err).To(gomega.BeNil())
+	}))
+	defer predictor.Close()
+
+	writer := &fakeKafkaWriter{messages: make(chan kafka.Message, 2)}
+	logger, _ := pkglogging.NewLogger("", "INFO")
+	logUrl, err := url.Parse("kafka://kafka:9092/inference-logs")
+	g.Expect(err).To(gomega.BeNil())
+	sourceUri, err := url.Parse("http://localhost:9081/")
+	g.Expect(err).To(gomega.BeNil())
+	targetUri, err := url.Parse(predictor.URL)
+	g.Expect(err).To(gomega.BeNil())
+
+	g.Expect(StartDispatcher(1, &KafkaSink{Writer: writer}, DefaultDeliveryConfig(), logger)).To(gomega.Succeed())
+	oh := New(logUrl, sourceUri, v1beta1.LogAll, "mymodel", "default", "canary", "predictor", nil, nil,
+		httputil.NewSingleHostReverseProxy(targetUri))
+
+	r := httptest.NewRequest("POST", "http://a", bytes.NewReader(predictorRequest))
+	r.Header.Set(CloudEventsIdHeader, "request-1")
+	w := httptest.NewRecorder()
+	oh.ServeHTTP(w, r)
+	b, _ := io.ReadAll(w.Result().Body)
+	g.Expect(b).To(gomega.Equal(predictorResponse))
+
+	payloads := map[string][]byte{}
+	for i := 0; i < 2; i++ {
+		msg := <-writer.messages
+		headers := kafkaHeaders(msg)
+		g.Expect(msg.Key).To(gomega.Equal([]byte("request-1")))
+		g.Expect(headers["ce_id"]).To(gomega.Equal("request-1"))
+		g.Expect(headers["ce_component"]).To(gomega.Equal("predictor"))
+		g.Expect(headers["ce_endpoint"]).To(gomega.Equal("canary"))
+		payloads[headers["ce_type"]] = msg.Value
+	}
+	g.Expect(payloads).To(gomega.Equal(map[string][]byte{
+		CEInferenceRequest:  predictorRequest,
+		CEInferenceResponse: predictorResponse,
+	}))
+}
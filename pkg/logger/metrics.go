@@ -0,0 +1,68 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logger
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Reasons of the events which are not sent to the sink
+const (
+	DropReasonSampled          = "sampled"
+	DropReasonContentType      = "content_type"
+	DropReasonQueueFull        = "queue_full"
+	DropReasonRetriesExhausted = "retries_exhausted"
+	DropReasonBufferError      = "buffer_error"
+)
+
+var (
+	eventsEnqueued = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "kserve_agent_logger_events_enqueued_total",
+		Help: "Number of request/response events queued for the sink",
+	})
+	eventsBuffered = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "kserve_agent_logger_events_buffered_total",
+		Help: "Number of request/response events stored in the disk buffer because the queue was full",
+	})
+	eventsDelivered = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "kserve_agent_logger_events_delivered_total",
+		Help: "Number of request/response events delivered to the sink",
+	})
+	eventsRetried = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "kserve_agent_logger_events_retried_total",
+		Help: "Number of retries of the request/response events the sink failed to receive",
+	})
+	eventsDropped = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "kserve_agent_logger_events_dropped_total",
+		Help: "Number of request/response events which are not logged",
+	}, []string{"reason"})
+	payloadsLimited = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "kserve_agent_logger_payloads_limited_total",
+		Help: "Number of logged payloads truncated or dropped because of their size",
+	}, []string{"action"})
+)
+
+// RegisterMetrics registers the metrics of the logger
+func RegisterMetrics(registerer prometheus.Registerer) error {
+	for _, collector := range []prometheus.Collector{eventsEnqueued, eventsBuffered, eventsDelivered, eventsRetried, eventsDropped,
+		payloadsLimited} {
+		if err := registerer.Register(collector); err != nil {
+			return err
+		}
+	}
+	return nil
+}
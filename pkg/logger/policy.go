@@ -0,0 +1,108 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logger
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"math"
+	"mime"
+	"strings"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+)
+
+// Policy selects the inferences and the payloads sent to the sink of the logger, a nil policy logs everything
+type Policy struct {
+	// SamplingRate is the fraction of the inferences logged, between 0 and 1
+	SamplingRate float64
+	// MaxPayloadBytes is the max size of the logged payloads, the payloads are not limited when it is 0
+	MaxPayloadBytes int64
+	// PayloadOverflow specifies how the payloads larger than MaxPayloadBytes are logged
+	PayloadOverflow v1beta1.LoggerPayloadOverflow
+	// ContentTypeAllowList are the media types of the logged payloads, type/* matches all the subtypes of a type.
+	// All the payloads are logged when it is empty.
+	ContentTypeAllowList []string
+}
+
+// Sampled returns whether the inference with the given id is logged. The decision only depends on the id so that
+// the request and the response of an inference are either both logged or both skipped.
+func (p *Policy) Sampled(id string) bool {
+	if p == nil || p.SamplingRate >= 1 {
+		return true
+	}
+	if p.SamplingRate <= 0 {
+		return false
+	}
+	// the ids differing by a few characters, e.g. sequence numbers, must be spread over the whole range
+	sum := sha256.Sum256([]byte(id))
+	return float64(binary.BigEndian.Uint64(sum[:8]))/math.MaxUint64 < p.SamplingRate
+}
+
+// AllowsContentType returns whether the payloads with the given content type are logged
+func (p *Policy) AllowsContentType(contentType string) bool {
+	if p == nil || len(p.ContentTypeAllowList) == 0 {
+		return true
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		mediaType = strings.ToLower(strings.TrimSpace(strings.Split(contentType, ";")[0]))
+	}
+	for _, allowed := range p.ContentTypeAllowList {
+		allowed = strings.ToLower(allowed)
+		if allowed == mediaType || allowed == "*/*" {
+			return true
+		}
+		if prefix, found := strings.CutSuffix(allowed, "/*"); found && strings.HasPrefix(mediaType, prefix+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// limitPayload truncates or drops the payload of the log request when it is larger than MaxPayloadBytes, and marks
+// the request with the action and the original size of the payload
+func (p *Policy) limitPayload(logReq *LogRequest) {
+	size := len(*logReq.Bytes)
+	if p == nil || p.MaxPayloadBytes <= 0 || int64(size) <= p.MaxPayloadBytes {
+		return
+	}
+	payload := []byte{}
+	logReq.PayloadLimit = v1beta1.LogPayloadDrop
+	if p.PayloadOverflow != v1beta1.LogPayloadDrop {
+		payload = (*logReq.Bytes)[:p.MaxPayloadBytes]
+		logReq.PayloadLimit = v1beta1.LogPayloadTruncate
+	}
+	logReq.Bytes = &payload
+	logReq.PayloadSize = size
+	payloadsLimited.WithLabelValues(string(logReq.PayloadLimit)).Inc()
+}
+
+// Queue queues the log request unless the inference is sampled out or the content type of the payload is not
+// logged, the payload is limited to the max payload size
+func (p *Policy) Queue(logReq LogRequest, sampled bool) error {
+	if !sampled {
+		eventsDropped.WithLabelValues(DropReasonSampled).Inc()
+		return nil
+	}
+	if !p.AllowsContentType(logReq.ContentType) {
+		eventsDropped.WithLabelValues(DropReasonContentType).Inc()
+		return nil
+	}
+	p.limitPayload(&logReq)
+	return QueueLogRequest(logReq)
+}
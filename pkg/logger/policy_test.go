@@ -0,0 +1,213 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logger
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httputil"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
+	"github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/segmentio/kafka-go"
+	pkglogging "knative.dev/pkg/logging"
+)
+
+func TestPolicySampled(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	var nilPolicy *Policy
+	g.Expect(nilPolicy.Sampled("request-1")).To(gomega.BeTrue())
+	g.Expect((&Policy{SamplingRate: 1}).Sampled("request-1")).To(gomega.BeTrue())
+	g.Expect((&Policy{SamplingRate: 0}).Sampled("request-1")).To(gomega.BeFalse())
+
+	policy := &Policy{SamplingRate: 0.25}
+	sampled := 0
+	for i := 0; i < 10000; i++ {
+		id := fmt.Sprintf("request-%d", i)
+		decision := policy.Sampled(id)
+		// the decision is the same for every event of an inference
+		g.Expect(policy.Sampled(id)).To(gomega.Equal(decision))
+		if decision {
+			sampled++
+		}
+	}
+	g.Expect(sampled).To(gomega.BeNumerically("~", 2500, 200))
+	// the inferences sampled at a rate are also sampled at the higher rates
+	for i := 0; i < 100; i++ {
+		id := fmt.Sprintf("request-%d", i)
+		if policy.Sampled(id) {
+			g.Expect((&Policy{SamplingRate: 0.5}).Sampled(id)).To(gomega.BeTrue())
+		}
+	}
+}
+
+func TestPolicyAllowsContentType(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	policy := &Policy{ContentTypeAllowList: []string{"application/json", "text/*"}}
+	scenarios := map[string]struct {
+		contentType string
+		allowed     bool
+	}{
+		"ExactMatch":        {contentType: "application/json", allowed: true},
+		"WithParameters":    {contentType: "Application/JSON; charset=utf-8", allowed: true},
+		"WildcardSubtype":   {contentType: "text/csv", allowed: true},
+		"NotAllowed":        {contentType: "image/png", allowed: false},
+		"PrefixIsNotAType":  {contentType: "textual/plain", allowed: false},
+		"EmptyContentType":  {contentType: "", allowed: false},
+		"MalformedInHeader": {contentType: "application/json;;", allowed: true},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g.Expect(policy.AllowsContentType(scenario.contentType)).To(gomega.Equal(scenario.allowed))
+		})
+	}
+	g.Expect((&Policy{}).AllowsContentType("image/png")).To(gomega.BeTrue())
+}
+
+func TestPolicyLimitPayload(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	scenarios := map[string]struct {
+		policy       *Policy
+		payload      string
+		expected     string
+		payloadLimit v1beta1.LoggerPayloadOverflow
+	}{
+		"Unlimited": {
+			policy:   &Policy{},
+			payload:  "0123456789",
+			expected: "0123456789",
+		},
+		"UnderTheLimit": {
+			policy:   &Policy{MaxPayloadBytes: 10},
+			payload:  "0123456789",
+			expected: "0123456789",
+		},
+		"Truncated": {
+			policy:       &Policy{MaxPayloadBytes: 4},
+			payload:      "0123456789",
+			expected:     "0123",
+			payloadLimit: v1beta1.LogPayloadTruncate,
+		},
+		"Dropped": {
+			policy:       &Policy{MaxPayloadBytes: 4, PayloadOverflow: v1beta1.LogPayloadDrop},
+			payload:      "0123456789",
+			expected:     "",
+			payloadLimit: v1beta1.LogPayloadDrop,
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			payload := []byte(scenario.payload)
+			logReq := LogRequest{Bytes: &payload}
+			scenario.policy.limitPayload(&logReq)
+			g.Expect(string(*logReq.Bytes)).To(gomega.Equal(scenario.expected))
+			g.Expect(logReq.PayloadLimit).To(gomega.Equal(scenario.payloadLimit))
+			if scenario.payloadLimit != "" {
+				g.Expect(logReq.PayloadSize).To(gomega.Equal(len(scenario.payload)))
+			}
+			// the payload of the proxied request is not modified
+			g.Expect(string(payload)).To(gomega.Equal(scenario.payload))
+		})
+	}
+}
+
+func TestLoggerPolicy(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	predictorResponse := []byte(`{"predictions":[[4,5,6]]}`)
+	predictor := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.Header().Set("Content-Type", "application/json")
+		_, err := rw.Write(predictorResponse)
+		g.Expect(err).To(gomega.BeNil())
+	}))
+	defer predictor.Close()
+
+	writer := &fakeKafkaWriter{messages: make(chan kafka.Message, 100)}
+	logger, _ := pkglogging.NewLogger("", "INFO")
+	logUrl, err := url.Parse("kafka://kafka:9092/inference-logs")
+	g.Expect(err).To(gomega.BeNil())
+	sourceUri, err := url.Parse("http://localhost:9081/")
+	g.Expect(err).To(gomega.BeNil())
+	targetUri, err := url.Parse(predictor.URL)
+	g.Expect(err).To(gomega.BeNil())
+	g.Expect(StartDispatcher(1, &KafkaSink{Writer: writer}, DefaultDeliveryConfig(), logger)).To(gomega.Succeed())
+
+	policy := &Policy{
+		SamplingRate:         0.5,
+		MaxPayloadBytes:      16,
+		ContentTypeAllowList: []string{"application/json"},
+	}
+	oh := New(logUrl, sourceUri, v1beta1.LogAll, "mymodel", "default", "default", "predictor", policy, nil,
+		httputil.NewSingleHostReverseProxy(targetUri))
+	serve := func(id string, contentType string) {
+		r := httptest.NewRequest("POST", "http://a", bytes.NewReader([]byte(`{"instances":[[0,0,0]]}`)))
+		r.Header.Set(CloudEventsIdHeader, id)
+		r.Header.Set("Content-Type", contentType)
+		w := httptest.NewRecorder()
+		oh.ServeHTTP(w, r)
+		g.Expect(w.Body.Bytes()).To(gomega.Equal(predictorResponse))
+	}
+
+	sampledBefore := testutil.ToFloat64(eventsDropped.WithLabelValues(DropReasonSampled))
+	truncatedBefore := testutil.ToFloat64(payloadsLimited.WithLabelValues(string(v1beta1.LogPayloadTruncate)))
+	expected := map[string]int{}
+	for i := 0; i < 20; i++ {
+		id := fmt.Sprintf("request-%d", i)
+		serve(id, "application/json")
+		if policy.Sampled(id) {
+			expected[id] = 2
+		}
+	}
+	g.Expect(expected).NotTo(gomega.BeEmpty())
+
+	logged := map[string]int{}
+	for i := 0; i < 2*len(expected); i++ {
+		msg := <-writer.messages
+		headers := kafkaHeaders(msg)
+		logged[headers["ce_id"]]++
+		g.Expect(msg.Value).To(gomega.HaveLen(16))
+		g.Expect(headers["ce_payloadlimit"]).To(gomega.Equal("truncate"))
+		g.Expect(headers["ce_payloadsize"]).To(gomega.Or(gomega.Equal("23"), gomega.Equal("25")))
+	}
+	// both the request and the response of the sampled inferences are logged, and only them
+	g.Expect(logged).To(gomega.Equal(expected))
+	g.Consistently(writer.messages, 100*time.Millisecond).ShouldNot(gomega.Receive())
+	g.Expect(testutil.ToFloat64(eventsDropped.WithLabelValues(DropReasonSampled)) - sampledBefore).To(
+		gomega.Equal(float64(40 - 2*len(expected))))
+	g.Expect(testutil.ToFloat64(payloadsLimited.WithLabelValues(string(v1beta1.LogPayloadTruncate))) - truncatedBefore).To(
+		gomega.Equal(float64(2 * len(expected))))
+
+	// the request payload is not logged when its content type is not allowed, the response is
+	contentTypeBefore := testutil.ToFloat64(eventsDropped.WithLabelValues(DropReasonContentType))
+	var id string
+	for i := 0; id == ""; i++ {
+		if policy.Sampled(fmt.Sprintf("image-%d", i)) {
+			id = fmt.Sprintf("image-%d", i)
+		}
+	}
+	serve(id, "image/png")
+	msg := <-writer.messages
+	g.Expect(kafkaHeaders(msg)["ce_type"]).To(gomega.Equal(CEInferenceResponse))
+	g.Consistently(writer.messages, 100*time.Millisecond).ShouldNot(gomega.Receive())
+	g.Expect(testutil.ToFloat64(eventsDropped.WithLabelValues(DropReasonContentType)) - contentTypeBefore).To(
+		gomega.Equal(float64(1)))
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logger
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+)
+
+// Sink sends the logged requests and responses to the destination of the logger
+type Sink interface {
+	Send(ctx context.Context, logReq LogRequest) error
+}
+
+// NewSink returns the sink of the logger url, kafka:// urls are published to a kafka topic and the other urls
+// receive the cloud events over http. secretDir is the directory of the mounted sink credentials, if any.
+func NewSink(logUrl *url.URL, secretDir string) (Sink, error) {
+	if logUrl.Scheme == KafkaScheme {
+		return NewKafkaSink(logUrl, secretDir)
+	}
+	return &HTTPSink{}, nil
+}
+
+// newCloudEvent builds the cloud event envelope of a log request, it is the same for all the sinks
+func newCloudEvent(logReq LogRequest) (cloudevents.Event, error) {
+	event := cloudevents.NewEvent(cloudevents.VersionV1)
+	event.SetID(logReq.Id)
+	event.SetType(logReq.ReqType)
+
+	event.SetExtension(InferenceServiceAttr, logReq.InferenceService)
+	event.SetExtension(NamespaceAttr, logReq.Namespace)
+	event.SetExtension(ComponentAttr, logReq.Component)
+	event.SetExtension(EndpointAttr, logReq.Endpoint)
+	if logReq.PayloadLimit != "" {
+		event.SetExtension(PayloadLimitAttr, string(logReq.PayloadLimit))
+		event.SetExtension(PayloadSizeAttr, logReq.PayloadSize)
+	}
+	if logReq.InferenceGraph != "" {
+		event.SetExtension(InferenceGraphAttr, logReq.InferenceGraph)
+	}
+	if logReq.Step != "" {
+		event.SetExtension(StepAttr, logReq.Step)
+	}
+	if logReq.InferenceId != "" {
+		event.SetExtension(InferenceIdAttr, logReq.InferenceId)
+	}
+	if len(logReq.Metadata) > 0 {
+		metadata, err := json.Marshal(logReq.Metadata)
+		if err != nil {
+			return event, fmt.Errorf("while encoding the metadata headers: %w", err)
+		}
+		event.SetExtension(MetadataAttr, string(metadata))
+	}
+
+	event.SetSource(logReq.SourceUri.String())
+	if err := event.SetData(logReq.ContentType, *logReq.Bytes); err != nil {
+		return event, fmt.Errorf("while setting cloudevents data: %w", err)
+	}
+	return event, nil
+}
+
+// HTTPSink posts the log requests as binary cloud events to the url of the request
+type HTTPSink struct{}
+
+func (s *HTTPSink) Send(ctx context.Context, logReq LogRequest) error {
+	t, err := cloudevents.NewHTTP(
+		cloudevents.WithTarget(logReq.Url.String()),
+	)
+	if err != nil {
+		return fmt.Errorf("while creating http transport: %w", err)
+	}
+	c, err := cloudevents.NewClient(t,
+		cloudevents.WithTimeNow(),
+	)
+	if err != nil {
+		return fmt.Errorf("while creating new cloudevents client: %w", err)
+	}
+
+	event, err := newCloudEvent(logReq)
+	if err != nil {
+		return err
+	}
+	if result := c.Send(ctx, event); cloudevents.IsUndelivered(result) {
+		return fmt.Errorf("while sending event: %w", result)
+	}
+	return nil
+}
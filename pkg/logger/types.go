@@ -18,6 +18,8 @@ package logger
 
 import (
 	"net/url"
+
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
 )
 
 type LogRequest struct {
@@ -31,4 +33,16 @@ type LogRequest struct {
 	Namespace        string
 	Component        string
 	Endpoint         string
+	// PayloadLimit is the action applied to the payload when it exceeds the max payload size
+	PayloadLimit v1beta1.LoggerPayloadOverflow
+	// PayloadSize is the original size of a limited payload
+	PayloadSize int
+	// Metadata are the values of the captured request headers, by lowercase header name
+	Metadata map[string][]string
+	// InferenceGraph is the name of the InferenceGraph routing the logged inference
+	InferenceGraph string
+	// Step is the name of the InferenceGraph step the payload was sent to or received from
+	Step string
+	// InferenceId correlates the events of the steps with the events of the InferenceGraph inference
+	InferenceId string
 }
@@ -19,6 +19,7 @@ package logger
 import (
 	"context"
 	"fmt"
+	"time"
 
 	cloudevents "github.com/cloudevents/sdk-go/v2"
 	"go.uber.org/zap"
@@ -35,6 +36,15 @@ const (
 	ComponentAttr        = "component"
 	// endpoint would be either default or canary
 	EndpointAttr = "endpoint"
+	// action and original size of the payloads exceeding the max payload size
+	PayloadLimitAttr = "payloadlimit"
+	PayloadSizeAttr  = "payloadsize"
+	// json object of the captured request headers
+	MetadataAttr = "metadata"
+	// name of the InferenceGraph, of its step and id of the graph inference of the InferenceGraph events
+	InferenceGraphAttr = "inferencegraphname"
+	StepAttr           = "step"
+	InferenceIdAttr    = "inferenceid"
 
 	LoggerWorkerQueueSize = 100
 	CloudEventsIdHeader   = "Ce-Id"
@@ -43,15 +53,11 @@ const (
 // A buffered channel that we can send work requests on.
 var WorkQueue = make(chan LogRequest, LoggerWorkerQueueSize)
 
-func QueueLogRequest(req LogRequest) error {
-	WorkQueue <- req
-	return nil
-}
-
-// NewWorker creates, and returns a new Worker object. Its only argument
-// is a channel that the worker can add itself to whenever it is done its
-// work.
-func NewWorker(id int, workerQueue chan chan LogRequest, logger *zap.SugaredLogger) Worker {
+// NewWorker creates, and returns a new Worker object. Its arguments are
+// a channel that the worker can add itself to whenever it is done its
+// work, the sink the work requests are sent to and the retries of the
+// failed sends.
+func NewWorker(id int, workerQueue chan chan LogRequest, sink Sink, delivery DeliveryConfig, logger *zap.SugaredLogger) Worker {
 	// Create, and return the worker.
 	return Worker{
 		Log:         logger,
@@ -59,6 +65,8 @@ func NewWorker(id int, workerQueue chan chan LogRequest, logger *zap.SugaredLogg
 		Work:        make(chan LogRequest),
 		WorkerQueue: workerQueue,
 		QuitChan:    make(chan bool),
+		Sink:        sink,
+		Delivery:    delivery,
 		CeCtx:       cloudevents.WithEncodingBinary(context.Background()),
 	}
 }
@@ -69,42 +77,32 @@ type Worker struct {
 	Work        chan LogRequest
 	WorkerQueue chan chan LogRequest
 	QuitChan    chan bool
+	Sink        Sink
+	Delivery    DeliveryConfig
 	CeCtx       context.Context
 }
 
-func (w *Worker) sendCloudEvent(logReq LogRequest) error {
-	t, err := cloudevents.NewHTTP(
-		cloudevents.WithTarget(logReq.Url.String()),
-	)
-
-	if err != nil {
-		return fmt.Errorf("while creating http transport: %w", err)
-	}
-
-	c, err := cloudevents.NewClient(t,
-		cloudevents.WithTimeNow(),
-	)
-	if err != nil {
-		return fmt.Errorf("while creating new cloudevents client: %w", err)
-	}
-	event := cloudevents.NewEvent(cloudevents.VersionV1)
-	event.SetID(logReq.Id)
-	event.SetType(logReq.ReqType)
-
-	event.SetExtension(InferenceServiceAttr, logReq.InferenceService)
-	event.SetExtension(NamespaceAttr, logReq.Namespace)
-	event.SetExtension(ComponentAttr, logReq.Component)
-	event.SetExtension(EndpointAttr, logReq.Endpoint)
-
-	event.SetSource(logReq.SourceUri.String())
-	if err := event.SetData(logReq.ContentType, *logReq.Bytes); err != nil {
-		return fmt.Errorf("while setting cloudevents data: %w", err)
-	}
-
-	if result := c.Send(w.CeCtx, event); cloudevents.IsUndelivered(result) {
-		return fmt.Errorf("while sending event: %w", result)
+// deliver sends a work request to the sink, the failed sends are retried with an exponential backoff
+// until the max retries is reached
+func (w *Worker) deliver(work LogRequest) {
+	for retry := 0; ; retry++ {
+		err := w.Sink.Send(w.CeCtx, work)
+		if err == nil {
+			eventsDelivered.Inc()
+			return
+		}
+		if retry >= w.Delivery.MaxRetries {
+			w.Log.Errorf("Failed to send cloud event after %d retries, dropping it, url: %s, requestId: %s: %v",
+				retry, work.Url.String(), work.Id, err)
+			eventsDropped.WithLabelValues(DropReasonRetriesExhausted).Inc()
+			return
+		}
+		backoff := w.Delivery.backoff(retry)
+		w.Log.Warnf("Failed to send cloud event, retrying in %v, url: %s, requestId: %s: %v",
+			backoff, work.Url.String(), work.Id, err)
+		eventsRetried.Inc()
+		time.Sleep(backoff)
 	}
-	return nil
 }
 
 // This function "starts" the worker by starting a goroutine, that is
@@ -120,9 +118,7 @@ func (w *Worker) Start() {
 				// Receive a work request.
 				w.Log.Infof("Received work request %d, url: %s, requestId: %s", w.ID, work.Url.String(), work.Id)
 
-				if err := w.sendCloudEvent(work); err != nil {
-					w.Log.Error(err, "Failed to send cloud event, url: %s", work.Url.String())
-				}
+				w.deliver(work)
 
 			case <-w.QuitChan:
 				// We have been asked to stop.
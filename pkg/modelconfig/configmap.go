@@ -17,7 +17,10 @@ limitations under the License.
 package modelconfig
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"sort"
 
 	jsoniter "github.com/json-iterator/go"
 	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
@@ -129,6 +132,12 @@ func CreateEmptyModelConfig(isvc *v1beta1.InferenceService, shardId int) (*v1.Co
 	return multiModelConfigMap, nil
 }
 
+// ContentHash returns the hash of the models of the model configmap
+func ContentHash(configMap *v1.ConfigMap) string {
+	hash := sha256.Sum256([]byte(configMap.Data[constants.ModelConfigFileName]))
+	return hex.EncodeToString(hash[:])
+}
+
 func slice2Map(from ModelConfigs) map[string]ModelConfig {
 	to := make(map[string]ModelConfig)
 	for _, config := range from {
@@ -142,6 +151,10 @@ func map2Slice(from map[string]ModelConfig) ModelConfigs {
 	for _, config := range from {
 		to = append(to, config)
 	}
+	// the models are sorted so that the same models are always encoded the same way
+	sort.Slice(to, func(i, j int) bool {
+		return to[i].Name < to[j].Name
+	})
 	return to
 }
 
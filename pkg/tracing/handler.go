@@ -0,0 +1,155 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	mathrand "math/rand"
+	"net/http"
+	"strings"
+
+	guuid "github.com/google/uuid"
+	"knative.dev/pkg/network"
+)
+
+const (
+	// TraceParentHeader carries the W3C trace context of the request, see https://www.w3.org/TR/trace-context/
+	TraceParentHeader = "Traceparent"
+	TraceStateHeader  = "Tracestate"
+	// RequestIdHeader carries the id of the inference across the components of the inference service
+	RequestIdHeader = "X-Request-Id"
+
+	traceParentVersion = "00"
+	traceParentLength  = 55
+	sampledFlag        = 0x01
+)
+
+// TraceParent is the parsed traceparent header of a request
+type TraceParent struct {
+	TraceID [16]byte
+	SpanID  [8]byte
+	Flags   byte
+}
+
+// Sampled returns whether the caller recorded the trace
+func (tp TraceParent) Sampled() bool {
+	return tp.Flags&sampledFlag != 0
+}
+
+func (tp TraceParent) String() string {
+	return fmt.Sprintf("%s-%s-%s-%02x", traceParentVersion, hex.EncodeToString(tp.TraceID[:]),
+		hex.EncodeToString(tp.SpanID[:]), tp.Flags)
+}
+
+// ParseTraceParent parses a traceparent header. The headers of future versions are parsed as version 00 headers as
+// required by the specification, the all zero trace and span ids are invalid.
+func ParseTraceParent(value string) (TraceParent, bool) {
+	tp := TraceParent{}
+	value = strings.TrimSpace(value)
+	if len(value) < traceParentLength || (len(value) > traceParentLength && value[traceParentLength] != '-') {
+		return tp, false
+	}
+	parts := strings.Split(value[:traceParentLength], "-")
+	if len(parts) != 4 || parts[0] == "ff" || (parts[0] == traceParentVersion && len(value) != traceParentLength) {
+		return tp, false
+	}
+	for _, part := range parts {
+		if strings.ToLower(part) != part {
+			return tp, false
+		}
+	}
+	var version, flags [1]byte
+	if !decodeHex(version[:], parts[0]) || !decodeHex(tp.TraceID[:], parts[1]) ||
+		!decodeHex(tp.SpanID[:], parts[2]) || !decodeHex(flags[:], parts[3]) {
+		return tp, false
+	}
+	if tp.TraceID == [16]byte{} || tp.SpanID == [8]byte{} {
+		return tp, false
+	}
+	tp.Flags = flags[0]
+	return tp, true
+}
+
+func decodeHex(dst []byte, value string) bool {
+	if hex.DecodedLen(len(value)) != len(dst) {
+		return false
+	}
+	_, err := hex.Decode(dst, []byte(value))
+	return err == nil
+}
+
+// TracingHandler continues the trace of the requests it proxies, or starts a new one sampled at the configured
+// ratio, so that the traces span the components of the inference service. It also makes sure that the requests carry
+// an inference id, returning it in the response.
+type TracingHandler struct {
+	samplingRatio float64
+	next          http.Handler
+}
+
+func New(samplingRatio float64, next http.Handler) http.Handler {
+	return &TracingHandler{
+		samplingRatio: samplingRatio,
+		next:          next,
+	}
+}
+
+func (th *TracingHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if network.IsKubeletProbe(r) {
+		th.next.ServeHTTP(w, r)
+		return
+	}
+
+	parent, ok := ParseTraceParent(r.Header.Get(TraceParentHeader))
+	if !ok {
+		// the trace state is only meaningful along a valid trace parent
+		r.Header.Del(TraceStateHeader)
+		parent = th.newTrace()
+	}
+	// the span of the agent becomes the parent of the span of the component
+	r.Header.Set(TraceParentHeader, TraceParent{TraceID: parent.TraceID, SpanID: newSpanID(), Flags: parent.Flags}.String())
+
+	id := r.Header.Get(RequestIdHeader)
+	if id == "" {
+		id = guuid.New().String()
+		r.Header.Set(RequestIdHeader, id)
+	}
+	w.Header().Set(RequestIdHeader, id)
+
+	th.next.ServeHTTP(w, r)
+}
+
+// newTrace returns the root of a new trace, sampled at the sampling ratio
+func (th *TracingHandler) newTrace() TraceParent {
+	tp := TraceParent{SpanID: newSpanID()}
+	for tp.TraceID == [16]byte{} {
+		_, _ = rand.Read(tp.TraceID[:])
+	}
+	if th.samplingRatio > 0 && mathrand.Float64() < th.samplingRatio { // #nosec G404 the sampling decision is not a secret
+		tp.Flags = sampledFlag
+	}
+	return tp
+}
+
+func newSpanID() [8]byte {
+	var id [8]byte
+	for id == [8]byte{} {
+		_, _ = rand.Read(id[:])
+	}
+	return id
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/http/httputil"
+	"net/url"
+	"testing"
+
+	"github.com/onsi/gomega"
+)
+
+func TestParseTraceParent(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	scenarios := map[string]struct {
+		value   string
+		valid   bool
+		sampled bool
+	}{
+		"Sampled":       {value: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", valid: true, sampled: true},
+		"NotSampled":    {value: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00", valid: true},
+		"FutureVersion": {value: "01-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra", valid: true, sampled: true},
+		"Empty":         {value: ""},
+		"InvalidVersion": {
+			value: "ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		},
+		"TrailingDataInVersion00": {value: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra"},
+		"UpperCase":               {value: "00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01"},
+		"ZeroTraceID":             {value: "00-00000000000000000000000000000000-00f067aa0ba902b7-01"},
+		"ZeroSpanID":              {value: "00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01"},
+		"NotHex":                  {value: "00-4bf92f3577b34da6a3ce929d0e0e473z-00f067aa0ba902b7-01"},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			tp, ok := ParseTraceParent(scenario.value)
+			g.Expect(ok).Should(gomega.Equal(scenario.valid))
+			if scenario.valid {
+				g.Expect(tp.Sampled()).Should(gomega.Equal(scenario.sampled))
+				g.Expect(tp.String()[3:]).Should(gomega.Equal(scenario.value[3:55]))
+			}
+		})
+	}
+}
+
+func TestTracingHandler(t *testing.T) {
+	const traceParent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	scenarios := map[string]struct {
+		samplingRatio float64
+		headers       map[string]string
+		traceID       string
+		sampled       bool
+		traceState    string
+		requestID     string
+	}{
+		"ContinuesTrace": {
+			samplingRatio: 0,
+			headers:       map[string]string{TraceParentHeader: traceParent, TraceStateHeader: "vendor=value"},
+			traceID:       "4bf92f3577b34da6a3ce929d0e0e4736",
+			sampled:       true,
+			traceState:    "vendor=value",
+		},
+		"KeepsRequestID": {
+			samplingRatio: 1,
+			headers:       map[string]string{TraceParentHeader: traceParent, RequestIdHeader: "inference-1"},
+			traceID:       "4bf92f3577b34da6a3ce929d0e0e4736",
+			sampled:       true,
+			requestID:     "inference-1",
+		},
+		"StartsSampledTrace": {
+			samplingRatio: 1,
+			headers:       map[string]string{TraceStateHeader: "vendor=value"},
+			sampled:       true,
+		},
+		"StartsTraceNotSampled": {
+			samplingRatio: 0,
+			headers:       map[string]string{TraceParentHeader: "invalid"},
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			var received http.Header
+			predictor := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				received = req.Header.Clone()
+				rw.WriteHeader(http.StatusOK)
+			}))
+			defer predictor.Close()
+			predictorURL, err := url.Parse(predictor.URL)
+			g.Expect(err).Should(gomega.Succeed())
+
+			handler := New(scenario.samplingRatio, httputil.NewSingleHostReverseProxy(predictorURL))
+			r := httptest.NewRequest(http.MethodPost, "/v1/models/test:predict", nil)
+			for key, value := range scenario.headers {
+				r.Header.Set(key, value)
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+
+			tp, ok := ParseTraceParent(received.Get(TraceParentHeader))
+			g.Expect(ok).Should(gomega.BeTrue())
+			g.Expect(tp.Sampled()).Should(gomega.Equal(scenario.sampled))
+			g.Expect(tp.String()).ShouldNot(gomega.Equal(traceParent))
+			if scenario.traceID != "" {
+				g.Expect(tp.String()[3:35]).Should(gomega.Equal(scenario.traceID))
+			}
+			g.Expect(received.Get(TraceStateHeader)).Should(gomega.Equal(scenario.traceState))
+
+			requestID := received.Get(RequestIdHeader)
+			g.Expect(requestID).ShouldNot(gomega.BeEmpty())
+			if scenario.requestID != "" {
+				g.Expect(requestID).Should(gomega.Equal(scenario.requestID))
+			}
+			g.Expect(w.Result().Header.Get(RequestIdHeader)).Should(gomega.Equal(requestID))
+		})
+	}
+}
@@ -17,6 +17,10 @@ limitations under the License.
 package utils
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/kserve/kserve/pkg/constants"
@@ -33,6 +37,19 @@ import (
  * Please add functional style container operations sparingly and intentionally.
  */
 
+// TruncatedNameHashLength is the length of the hash TruncateWithHash ends the truncated names with
+const TruncatedNameHashLength = 8
+
+// annotationTypoMaxDistance is the maximum edit distance between an unknown annotation and a known one for the unknown
+// annotation to be considered a typo of the known one
+const annotationTypoMaxDistance = 2
+
+// Unknown annotation messages
+const (
+	AnnotationTypoError      = "the annotation %q is not known to KServe, did you mean %q? Annotations under the %s/ prefix set on purpose can be listed in the %s annotation"
+	UnknownAnnotationWarning = "the annotation %q is not known to KServe and is ignored"
+)
+
 var gvResourcesCache map[string]*metav1.APIResourceList
 
 func Filter(origin map[string]string, predicate func(string) bool) map[string]string {
@@ -97,6 +114,95 @@ func FirstNonNilError(objects []error) error {
 	return nil
 }
 
+// TruncateWithHash truncates the name to maxLength characters, replacing its end with a hash of the whole name so that
+// distinct names remain distinct. maxLength must leave room for at least one character of the name and the hash.
+func TruncateWithHash(name string, maxLength int) string {
+	if len(name) <= maxLength {
+		return name
+	}
+	hash := sha256.Sum256([]byte(name))
+	suffix := hex.EncodeToString(hash[:])[:TruncatedNameHashLength]
+	return strings.TrimRight(name[:maxLength-len(suffix)-1], "-") + "-" + suffix
+}
+
+// EditDistance returns the Levenshtein distance between a and b, the number of single character insertions, deletions
+// and substitutions turning a into b.
+func EditDistance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
+
+// CheckAnnotationKeys checks the annotations under the KServe prefix against the annotations KServe knows. An unknown
+// annotation within a small case insensitive edit distance of a known one is most likely a typo and is rejected with
+// the known one as suggestion, any other unknown annotation is ignored by KServe and returned as a warning. The
+// annotations listed in the custom annotations annotation are neither rejected nor warned about.
+func CheckAnnotationKeys(annotations map[string]string) ([]string, error) {
+	prefix := constants.KServeAPIGroupName + "/"
+	custom := map[string]bool{}
+	for _, key := range strings.Split(annotations[constants.CustomAnnotationsAnnotationKey], ",") {
+		custom[strings.TrimSpace(key)] = true
+	}
+	keys := make([]string, 0, len(annotations))
+	for key := range annotations {
+		if strings.HasPrefix(key, prefix) && !custom[key] && !Includes(constants.KnownAnnotationKeys, key) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	var warnings []string
+	for _, key := range keys {
+		if suggestion, ok := closestAnnotationKey(key); ok {
+			return warnings, fmt.Errorf(AnnotationTypoError, key, suggestion, constants.KServeAPIGroupName,
+				constants.CustomAnnotationsAnnotationKey)
+		}
+		warnings = append(warnings, fmt.Sprintf(UnknownAnnotationWarning, key))
+	}
+	return warnings, nil
+}
+
+// CheckAddedAnnotationKeys checks the annotations added by an update like CheckAnnotationKeys, the annotations the
+// object already had were checked when they were added and do not block its update.
+func CheckAddedAnnotationKeys(annotations map[string]string, oldAnnotations map[string]string) ([]string, error) {
+	added := make(map[string]string, len(annotations))
+	for key, value := range annotations {
+		if _, ok := oldAnnotations[key]; !ok {
+			added[key] = value
+		}
+	}
+	// the custom annotations listed on the object also apply to the added annotations
+	if custom, ok := annotations[constants.CustomAnnotationsAnnotationKey]; ok {
+		added[constants.CustomAnnotationsAnnotationKey] = custom
+	}
+	return CheckAnnotationKeys(added)
+}
+
+// closestAnnotationKey returns the known annotation closest to key when it is within the typo edit distance
+func closestAnnotationKey(key string) (string, bool) {
+	closest, distance := "", annotationTypoMaxDistance+1
+	for _, known := range constants.KnownAnnotationKeys {
+		if d := EditDistance(strings.ToLower(key), strings.ToLower(known)); d < distance {
+			closest, distance = known, d
+		}
+	}
+	return closest, closest != ""
+}
+
 // RemoveString Helper functions to remove string from a slice of strings.
 func RemoveString(slice []string, s string) (result []string) {
 	for _, item := range slice {
@@ -18,6 +18,7 @@ package utils
 
 import (
 	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/kserve/kserve/pkg/constants"
@@ -513,6 +514,37 @@ func TestRemoveString(t *testing.T) {
 	g.Expect(res).Should(gomega.Equal(expected))
 }
 
+func TestTruncateWithHash(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	scenarios := map[string]struct {
+		name      string
+		maxLength int
+		expected  string
+	}{
+		"ShortName": {
+			name:      "sklearn-iris",
+			maxLength: 20,
+			expected:  "sklearn-iris",
+		},
+		"LongName": {
+			name:      "sklearn-iris-with-a-long-name",
+			maxLength: 20,
+			expected:  "sklearn-iri-80497cb7",
+		},
+		"TrailingDash": {
+			name:      "sklearn-iris-with-a-long-name",
+			maxLength: 17,
+			expected:  "sklearn-80497cb7",
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			res := TruncateWithHash(scenario.name, scenario.maxLength)
+			g.Expect(res).Should(gomega.Equal(scenario.expected))
+		})
+	}
+}
+
 func TestIsPrefixSupported(t *testing.T) {
 	g := gomega.NewGomegaWithT(t)
 	prefixes := []string{
@@ -541,3 +573,139 @@ func TestIsPrefixSupported(t *testing.T) {
 		})
 	}
 }
+
+func TestEditDistance(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	scenarios := map[string]struct {
+		a        string
+		b        string
+		expected int
+	}{
+		"Equal":        {a: "autoscalerClass", b: "autoscalerClass", expected: 0},
+		"Empty":        {a: "", b: "metrics", expected: 7},
+		"Substitution": {a: "autoscalerclass", b: "autoscalerClass", expected: 1},
+		"Insertion":    {a: "metric", b: "metrics", expected: 1},
+		"Deletion":     {a: "custom-domainn", b: "custom-domain", expected: 1},
+		"Unrelated":    {a: "kitten", b: "sitting", expected: 3},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g.Expect(EditDistance(scenario.a, scenario.b)).Should(gomega.Equal(scenario.expected))
+		})
+	}
+}
+
+func TestCheckAnnotationKeys(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	scenarios := map[string]struct {
+		annotations map[string]string
+		warnings    []string
+		err         error
+	}{
+		"KnownAnnotations": {
+			annotations: map[string]string{
+				constants.AutoscalerClass:                "hpa",
+				constants.DeploymentMode:                 "RawDeployment",
+				constants.StorageSecretNameAnnotationKey: "storage-config",
+				"prometheus.io/port":                     "8080",
+				"example.com/owner":                      "fraud",
+			},
+		},
+		"CaseTypo": {
+			annotations: map[string]string{"serving.kserve.io/autoscalerclass": "hpa"},
+			err: fmt.Errorf(AnnotationTypoError, "serving.kserve.io/autoscalerclass", constants.AutoscalerClass,
+				constants.KServeAPIGroupName, constants.CustomAnnotationsAnnotationKey),
+		},
+		"SpellingTypo": {
+			annotations: map[string]string{"serving.kserve.io/custom-domian": "example.com"},
+			err: fmt.Errorf(AnnotationTypoError, "serving.kserve.io/custom-domian", constants.CustomDomainAnnotationKey,
+				constants.KServeAPIGroupName, constants.CustomAnnotationsAnnotationKey),
+		},
+		"UnknownAnnotation": {
+			annotations: map[string]string{
+				"serving.kserve.io/team-cost-center": "fraud",
+				"serving.kserve.io/owner":            "alice",
+			},
+			warnings: []string{
+				fmt.Sprintf(UnknownAnnotationWarning, "serving.kserve.io/owner"),
+				fmt.Sprintf(UnknownAnnotationWarning, "serving.kserve.io/team-cost-center"),
+			},
+		},
+		"CustomAnnotations": {
+			annotations: map[string]string{
+				constants.CustomAnnotationsAnnotationKey: "serving.kserve.io/team-cost-center, serving.kserve.io/metric",
+				"serving.kserve.io/team-cost-center":     "fraud",
+				"serving.kserve.io/metric":               "latency",
+			},
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			warnings, err := CheckAnnotationKeys(scenario.annotations)
+			if scenario.err == nil {
+				g.Expect(err).Should(gomega.Succeed())
+			} else {
+				g.Expect(err).Should(gomega.MatchError(scenario.err.Error()))
+			}
+			g.Expect(warnings).Should(gomega.Equal(scenario.warnings))
+		})
+	}
+}
+
+func TestCheckAddedAnnotationKeys(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	scenarios := map[string]struct {
+		annotations    map[string]string
+		oldAnnotations map[string]string
+		warnings       []string
+		err            error
+	}{
+		"ExistingTypo": {
+			annotations:    map[string]string{"serving.kserve.io/autoscalerclass": "hpa"},
+			oldAnnotations: map[string]string{"serving.kserve.io/autoscalerclass": "hpa"},
+		},
+		"ChangedTypo": {
+			annotations:    map[string]string{"serving.kserve.io/autoscalerclass": "keda"},
+			oldAnnotations: map[string]string{"serving.kserve.io/autoscalerclass": "hpa"},
+		},
+		"AddedTypo": {
+			annotations: map[string]string{
+				"serving.kserve.io/autoscalerclass": "hpa",
+				"serving.kserve.io/custom-domian":   "example.com",
+			},
+			oldAnnotations: map[string]string{"serving.kserve.io/autoscalerclass": "hpa"},
+			err: fmt.Errorf(AnnotationTypoError, "serving.kserve.io/custom-domian", constants.CustomDomainAnnotationKey,
+				constants.KServeAPIGroupName, constants.CustomAnnotationsAnnotationKey),
+		},
+		"AddedUnknownAnnotation": {
+			annotations: map[string]string{
+				"serving.kserve.io/owner":            "alice",
+				"serving.kserve.io/team-cost-center": "fraud",
+			},
+			oldAnnotations: map[string]string{"serving.kserve.io/owner": "alice"},
+			warnings: []string{
+				fmt.Sprintf(UnknownAnnotationWarning, "serving.kserve.io/team-cost-center"),
+			},
+		},
+		"AddedCustomAnnotation": {
+			annotations: map[string]string{
+				constants.CustomAnnotationsAnnotationKey: "serving.kserve.io/team-cost-center",
+				"serving.kserve.io/team-cost-center":     "fraud",
+			},
+			oldAnnotations: map[string]string{
+				constants.CustomAnnotationsAnnotationKey: "serving.kserve.io/team-cost-center",
+			},
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			warnings, err := CheckAddedAnnotationKeys(scenario.annotations, scenario.oldAnnotations)
+			if scenario.err == nil {
+				g.Expect(err).Should(gomega.Succeed())
+			} else {
+				g.Expect(err).Should(gomega.MatchError(scenario.err.Error()))
+			}
+			g.Expect(warnings).Should(gomega.Equal(scenario.warnings))
+		})
+	}
+}
@@ -19,6 +19,7 @@ package pod
 import (
 	"encoding/json"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"k8s.io/apimachinery/pkg/util/intstr"
@@ -39,6 +40,19 @@ const (
 	LoggerArgumentNamespace        = "--namespace"
 	LoggerArgumentEndpoint         = "--endpoint"
 	LoggerArgumentComponent        = "--component"
+	LoggerArgumentSecretDir        = "--log-secret-dir"
+	LoggerArgumentSamplingRate     = "--log-sampling-rate"
+	LoggerArgumentMaxPayloadBytes  = "--log-max-payload-bytes"
+	LoggerArgumentPayloadOverflow  = "--log-payload-overflow"
+	LoggerArgumentContentTypes     = "--log-content-types"
+	LoggerArgumentMetadataHeaders  = "--log-metadata-headers"
+	LoggerArgumentMaxRetries       = "--log-max-retries"
+	LoggerArgumentQueueSize        = "--log-queue-size"
+	LoggerArgumentDiskBufferPath   = "--log-disk-buffer-path"
+	LoggerArgumentDiskBufferSize   = "--log-disk-buffer-size"
+	LoggerDiskBufferVolumeName     = "kserve-logger-buffer"
+	LoggerSecretVolumeName         = "kserve-logger-secret"
+	LoggerSecretMountPath          = "/var/secrets/kserve-logger"
 )
 
 type AgentConfig struct {
@@ -56,13 +70,26 @@ type LoggerConfig struct {
 	MemoryRequest string `json:"memoryRequest"`
 	MemoryLimit   string `json:"memoryLimit"`
 	DefaultUrl    string `json:"defaultUrl"`
+	// DefaultSecretName is the secret with the SASL/TLS configuration of the kafka logger urls of the
+	// InferenceServices which do not set the secretName of their logger
+	DefaultSecretName string `json:"defaultSecretName,omitempty"`
+	// MaxRetries is the number of retries of the events the sink failed to receive
+	MaxRetries *int `json:"maxRetries,omitempty"`
+	// QueueSize is the number of events waiting in memory for delivery
+	QueueSize *int `json:"queueSize,omitempty"`
+	// DiskBufferPath is the directory of the agent container storing the events which do not fit in the queue,
+	// the events are dropped when it is not set
+	DiskBufferPath string `json:"diskBufferPath,omitempty"`
+	// DiskBufferSize is the size of the disk buffer, 1Gi by default
+	DiskBufferSize string `json:"diskBufferSize,omitempty"`
 }
 
 type AgentInjector struct {
-	credentialBuilder *credentials.CredentialBuilder
-	agentConfig       *AgentConfig
-	loggerConfig      *LoggerConfig
-	batcherConfig     *BatcherConfig
+	credentialBuilder        *credentials.CredentialBuilder
+	agentConfig              *AgentConfig
+	loggerConfig             *LoggerConfig
+	batcherConfig            *BatcherConfig
+	storageInitializerConfig *StorageInitializerConfig
 }
 
 // TODO agent config
@@ -111,6 +138,14 @@ func getLoggerConfigs(configMap *v1.ConfigMap) (*LoggerConfig, error) {
 			return loggerConfig, fmt.Errorf("Failed to parse resource configuration for %q: %q", LoggerConfigMapKeyName, err.Error())
 		}
 	}
+	if loggerConfig.DiskBufferSize != "" {
+		if _, err := resource.ParseQuantity(loggerConfig.DiskBufferSize); err != nil {
+			return loggerConfig, fmt.Errorf("Failed to parse diskBufferSize for %q: %q", LoggerConfigMapKeyName, err.Error())
+		}
+	}
+	if loggerConfig.DiskBufferPath != "" && !strings.HasPrefix(loggerConfig.DiskBufferPath, "/") {
+		return loggerConfig, fmt.Errorf("diskBufferPath %q for %q must be an absolute path", loggerConfig.DiskBufferPath, LoggerConfigMapKeyName)
+	}
 
 	return loggerConfig, nil
 }
@@ -146,6 +181,14 @@ func (ag *AgentInjector) InjectAgent(pod *v1.Pod) error {
 			args = append(args, constants.AgentModelDirArgName)
 			args = append(args, modelDir)
 		}
+
+		if verifyChecksumEnabled(pod, ag.storageInitializerConfig) {
+			args = append(args, constants.AgentVerifyChecksumFlag)
+		}
+
+		if reclaim, err := strconv.ParseBool(pod.ObjectMeta.Annotations[constants.ModelStoreReclaimAnnotationKey]); err == nil && reclaim {
+			args = append(args, constants.AgentReclaimModelStoreFlag)
+		}
 	}
 	// Only inject if the batcher required annotations are set
 	if injectBatcher {
@@ -161,8 +204,21 @@ func (ag *AgentInjector) InjectAgent(pod *v1.Pod) error {
 			args = append(args, BatcherArgumentMaxLatency)
 			args = append(args, maxLatency)
 		}
+
+		maxInFlight, ok := pod.ObjectMeta.Annotations[constants.BatcherMaxInFlightInternalAnnotationKey]
+		if ok {
+			args = append(args, BatcherArgumentMaxInFlight)
+			args = append(args, maxInFlight)
+		}
+
+		maxQueueSize, ok := pod.ObjectMeta.Annotations[constants.BatcherMaxQueueSizeInternalAnnotationKey]
+		if ok {
+			args = append(args, BatcherArgumentMaxQueueSize)
+			args = append(args, maxQueueSize)
+		}
 	}
 	// Only inject if the logger required annotations are set
+	var logSecretName string
 	if injectLogger {
 		logUrl, ok := pod.ObjectMeta.Annotations[constants.LoggerSinkUrlInternalAnnotationKey]
 		if !ok {
@@ -196,6 +252,42 @@ func (ag *AgentInjector) InjectAgent(pod *v1.Pod) error {
 			component,
 		}
 		args = append(args, loggerArgs...)
+
+		// The sampling, payload size, content type and metadata header settings are optional
+		for _, option := range []struct{ annotation, argument string }{
+			{constants.LoggerSamplingRateInternalAnnotationKey, LoggerArgumentSamplingRate},
+			{constants.LoggerMaxPayloadBytesInternalAnnotationKey, LoggerArgumentMaxPayloadBytes},
+			{constants.LoggerPayloadOverflowInternalAnnotationKey, LoggerArgumentPayloadOverflow},
+			{constants.LoggerContentTypesInternalAnnotationKey, LoggerArgumentContentTypes},
+			{constants.LoggerMetadataHeadersInternalAnnotationKey, LoggerArgumentMetadataHeaders},
+		} {
+			if value, ok := pod.ObjectMeta.Annotations[option.annotation]; ok {
+				args = append(args, option.argument, value)
+			}
+		}
+
+		// The SASL/TLS configuration of the kafka sink is mounted from the logger secret
+		logSecretName = pod.ObjectMeta.Annotations[constants.LoggerSinkSecretInternalAnnotationKey]
+		if logSecretName == "" && strings.HasPrefix(logUrl, v1beta1.LoggerKafkaURLPrefix) {
+			logSecretName = ag.loggerConfig.DefaultSecretName
+		}
+		if logSecretName != "" {
+			args = append(args, LoggerArgumentSecretDir, LoggerSecretMountPath)
+		}
+
+		// The retries and the buffering of the events are configured in the logger config
+		if ag.loggerConfig.MaxRetries != nil {
+			args = append(args, LoggerArgumentMaxRetries, strconv.Itoa(*ag.loggerConfig.MaxRetries))
+		}
+		if ag.loggerConfig.QueueSize != nil {
+			args = append(args, LoggerArgumentQueueSize, strconv.Itoa(*ag.loggerConfig.QueueSize))
+		}
+		if ag.loggerConfig.DiskBufferPath != "" {
+			args = append(args, LoggerArgumentDiskBufferPath, ag.loggerConfig.DiskBufferPath)
+			if ag.loggerConfig.DiskBufferSize != "" {
+				args = append(args, LoggerArgumentDiskBufferSize, ag.loggerConfig.DiskBufferSize)
+			}
+		}
 	}
 
 	var queueProxyEnvs []v1.EnvVar
@@ -234,6 +326,11 @@ func (ag *AgentInjector) InjectAgent(pod *v1.Pod) error {
 		}
 	}
 
+	// The model puller downloads the models through the egress proxy
+	if injectPuller {
+		agentEnvs = append(agentEnvs, getStorageProxyEnvs(pod, ag.storageInitializerConfig)...)
+	}
+
 	// Make sure securityContext is initialized and valid
 	securityContext := pod.Spec.Containers[0].SecurityContext.DeepCopy()
 
@@ -277,6 +374,37 @@ func (ag *AgentInjector) InjectAgent(pod *v1.Pod) error {
 		},
 	}
 
+	if logSecretName != "" {
+		pod.Spec.Volumes = appendVolume(pod.Spec.Volumes, v1.Volume{
+			Name: LoggerSecretVolumeName,
+			VolumeSource: v1.VolumeSource{
+				Secret: &v1.SecretVolumeSource{
+					SecretName: logSecretName,
+				},
+			},
+		})
+		agentContainer.VolumeMounts = append(agentContainer.VolumeMounts, v1.VolumeMount{
+			Name:      LoggerSecretVolumeName,
+			ReadOnly:  true,
+			MountPath: LoggerSecretMountPath,
+		})
+	}
+
+	// The disk buffer is an emptyDir so that the buffered events survive the restarts of the agent container.
+	// Its size is bounded by the agent rather than by a size limit, which would evict the pod when reached.
+	if injectLogger && ag.loggerConfig.DiskBufferPath != "" {
+		pod.Spec.Volumes = appendVolume(pod.Spec.Volumes, v1.Volume{
+			Name: LoggerDiskBufferVolumeName,
+			VolumeSource: v1.VolumeSource{
+				EmptyDir: &v1.EmptyDirVolumeSource{},
+			},
+		})
+		agentContainer.VolumeMounts = append(agentContainer.VolumeMounts, v1.VolumeMount{
+			Name:      LoggerDiskBufferVolumeName,
+			MountPath: ag.loggerConfig.DiskBufferPath,
+		})
+	}
+
 	// Inject credentials
 	if err := ag.credentialBuilder.CreateSecretVolumeAndEnv(
 		pod.Namespace,
@@ -18,6 +18,7 @@ package pod
 
 import (
 	fakeclientset "k8s.io/client-go/kubernetes/fake"
+	"strings"
 	"testing"
 
 	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
@@ -1112,6 +1113,7 @@ func TestAgentInjector(t *testing.T) {
 			agentConfig,
 			loggerConfig,
 			batcherTestConfig,
+			storageInitializerConfig,
 		}
 		injector.InjectAgent(scenario.original)
 		if diff, _ := kmp.SafeDiff(scenario.expected.Spec, scenario.original.Spec); diff != "" {
@@ -1122,6 +1124,7 @@ func TestAgentInjector(t *testing.T) {
 
 func TestGetLoggerConfigs(t *testing.T) {
 	g := gomega.NewGomegaWithT(t)
+	maxRetries, queueSize := 5, 200
 	cases := []struct {
 		name      string
 		configMap *v1.ConfigMap
@@ -1181,6 +1184,66 @@ func TestGetLoggerConfigs(t *testing.T) {
 				gomega.HaveOccurred(),
 			},
 		},
+		{
+			name: "Logger Delivery Config",
+			configMap: &v1.ConfigMap{
+				Data: map[string]string{
+					LoggerConfigMapKeyName: `{
+						"Image":          "gcr.io/kfserving/logger:latest",
+						"CpuRequest":     "100m",
+						"CpuLimit":       "1",
+						"MemoryRequest":  "200Mi",
+						"MemoryLimit":    "1Gi",
+						"maxRetries":     5,
+						"queueSize":      200,
+						"diskBufferPath": "/var/lib/kserve/logger-buffer",
+						"diskBufferSize": "512Mi"
+					}`,
+				},
+			},
+			matchers: []types.GomegaMatcher{
+				gomega.Equal(&LoggerConfig{
+					Image:          "gcr.io/kfserving/logger:latest",
+					CpuRequest:     "100m",
+					CpuLimit:       "1",
+					MemoryRequest:  "200Mi",
+					MemoryLimit:    "1Gi",
+					MaxRetries:     &maxRetries,
+					QueueSize:      &queueSize,
+					DiskBufferPath: "/var/lib/kserve/logger-buffer",
+					DiskBufferSize: "512Mi",
+				}),
+				gomega.BeNil(),
+			},
+		},
+		{
+			name: "Invalid Disk Buffer Size",
+			configMap: &v1.ConfigMap{
+				Data: map[string]string{
+					LoggerConfigMapKeyName: `{
+						"Image":          "gcr.io/kfserving/logger:latest",
+						"CpuRequest":     "100m",
+						"CpuLimit":       "1",
+						"MemoryRequest":  "200Mi",
+						"MemoryLimit":    "1Gi",
+						"diskBufferPath": "/var/lib/kserve/logger-buffer",
+						"diskBufferSize": "1 GB"
+					}`,
+				},
+			},
+			matchers: []types.GomegaMatcher{
+				gomega.Equal(&LoggerConfig{
+					Image:          "gcr.io/kfserving/logger:latest",
+					CpuRequest:     "100m",
+					CpuLimit:       "1",
+					MemoryRequest:  "200Mi",
+					MemoryLimit:    "1Gi",
+					DiskBufferPath: "/var/lib/kserve/logger-buffer",
+					DiskBufferSize: "1 GB",
+				}),
+				gomega.HaveOccurred(),
+			},
+		},
 	}
 
 	for _, tc := range cases {
@@ -1259,3 +1322,414 @@ func TestGetAgentConfigs(t *testing.T) {
 		g.Expect(loggerConfigs).Should(tc.matchers[0])
 	}
 }
+
+func TestAgentInjectorProxyEnvs(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	proxyConfig := &StorageInitializerConfig{}
+	*proxyConfig = *storageInitializerConfig
+	proxyConfig.HttpsProxy = "http://proxy.example.com:3128"
+	proxyConfig.NoProxy = ".svc.cluster.local"
+
+	scenarios := map[string]struct {
+		annotations map[string]string
+		expected    []v1.EnvVar
+	}{
+		"PullerUsesProxy": {
+			annotations: map[string]string{
+				constants.AgentShouldInjectAnnotationKey:          "true",
+				constants.AgentModelConfigVolumeNameAnnotationKey: "modelconfig-deployment-0",
+				constants.AgentModelDirAnnotationKey:              "/mnt/models",
+			},
+			expected: []v1.EnvVar{
+				{Name: constants.HTTPSProxyEnvVarKey, Value: "http://proxy.example.com:3128"},
+				{Name: constants.NoProxyEnvVarKey, Value: ".svc.cluster.local"},
+			},
+		},
+		"PullerOptsOutOfProxy": {
+			annotations: map[string]string{
+				constants.AgentShouldInjectAnnotationKey:          "true",
+				constants.AgentModelConfigVolumeNameAnnotationKey: "modelconfig-deployment-0",
+				constants.AgentModelDirAnnotationKey:              "/mnt/models",
+				constants.StorageProxyEnabledAnnotationKey:        "false",
+			},
+		},
+		"LoggerDoesNotUseProxy": {
+			annotations: map[string]string{
+				constants.LoggerInternalAnnotationKey:        "true",
+				constants.LoggerSinkUrlInternalAnnotationKey: "http://logger.default.svc.cluster.local",
+				constants.LoggerModeInternalAnnotationKey:    string(v1beta1.LogAll),
+			},
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			pod := &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "deployment",
+					Namespace:   "default",
+					Annotations: scenario.annotations,
+				},
+				Spec: v1.PodSpec{
+					Containers: []v1.Container{
+						{
+							Name: constants.InferenceServiceContainerName,
+						},
+					},
+				},
+			}
+			injector := &AgentInjector{
+				credentials.NewCredentialBuilder(c, fakeclientset.NewSimpleClientset(), &v1.ConfigMap{
+					Data: map[string]string{},
+				}),
+				agentConfig,
+				loggerConfig,
+				batcherTestConfig,
+				proxyConfig,
+			}
+			g.Expect(injector.InjectAgent(pod)).To(gomega.Succeed())
+			agentContainer := getContainerWithName(pod, constants.AgentContainerName)
+			g.Expect(agentContainer).NotTo(gomega.BeNil())
+			var proxyEnvs []v1.EnvVar
+			for _, env := range agentContainer.Env {
+				if strings.HasSuffix(env.Name, "_PROXY") {
+					proxyEnvs = append(proxyEnvs, env)
+				}
+			}
+			g.Expect(proxyEnvs).To(gomega.Equal(scenario.expected))
+		})
+	}
+}
+
+func TestAgentInjectorLoggerSecret(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	kafkaLoggerConfig := &LoggerConfig{}
+	*kafkaLoggerConfig = *loggerConfig
+	kafkaLoggerConfig.DefaultSecretName = "default-kafka-secret"
+
+	scenarios := map[string]struct {
+		annotations map[string]string
+		secretName  string
+	}{
+		"KafkaLoggerWithSecret": {
+			annotations: map[string]string{
+				constants.LoggerSinkUrlInternalAnnotationKey:    "kafka://kafka:9092/inference-logs",
+				constants.LoggerSinkSecretInternalAnnotationKey: "kafka-secret",
+			},
+			secretName: "kafka-secret",
+		},
+		"KafkaLoggerWithDefaultSecret": {
+			annotations: map[string]string{
+				constants.LoggerSinkUrlInternalAnnotationKey: "kafka://kafka:9092/inference-logs",
+			},
+			secretName: "default-kafka-secret",
+		},
+		"HttpLoggerDoesNotUseDefaultSecret": {
+			annotations: map[string]string{
+				constants.LoggerSinkUrlInternalAnnotationKey: "http://logger.default.svc.cluster.local",
+			},
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			scenario.annotations[constants.LoggerInternalAnnotationKey] = "true"
+			scenario.annotations[constants.LoggerModeInternalAnnotationKey] = string(v1beta1.LogAll)
+			pod := &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "deployment",
+					Namespace:   "default",
+					Annotations: scenario.annotations,
+				},
+				Spec: v1.PodSpec{
+					Containers: []v1.Container{
+						{
+							Name: constants.InferenceServiceContainerName,
+						},
+					},
+				},
+			}
+			injector := &AgentInjector{
+				credentials.NewCredentialBuilder(c, fakeclientset.NewSimpleClientset(), &v1.ConfigMap{
+					Data: map[string]string{},
+				}),
+				agentConfig,
+				kafkaLoggerConfig,
+				batcherTestConfig,
+				storageInitializerConfig,
+			}
+			g.Expect(injector.InjectAgent(pod)).To(gomega.Succeed())
+			agentContainer := getContainerWithName(pod, constants.AgentContainerName)
+			g.Expect(agentContainer).NotTo(gomega.BeNil())
+			if scenario.secretName == "" {
+				g.Expect(agentContainer.Args).NotTo(gomega.ContainElement(LoggerArgumentSecretDir))
+				g.Expect(agentContainer.VolumeMounts).To(gomega.BeEmpty())
+				g.Expect(pod.Spec.Volumes).To(gomega.BeEmpty())
+				return
+			}
+			g.Expect(strings.Join(agentContainer.Args, " ")).To(
+				gomega.ContainSubstring(LoggerArgumentSecretDir + " " + LoggerSecretMountPath))
+			g.Expect(agentContainer.VolumeMounts).To(gomega.Equal([]v1.VolumeMount{
+				{Name: LoggerSecretVolumeName, ReadOnly: true, MountPath: LoggerSecretMountPath},
+			}))
+			g.Expect(pod.Spec.Volumes).To(gomega.Equal([]v1.Volume{
+				{
+					Name: LoggerSecretVolumeName,
+					VolumeSource: v1.VolumeSource{
+						Secret: &v1.SecretVolumeSource{SecretName: scenario.secretName},
+					},
+				},
+			}))
+		})
+	}
+}
+
+func TestAgentInjectorLoggerPolicyArgs(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	scenarios := map[string]struct {
+		annotations map[string]string
+		expected    []string
+	}{
+		"NoPolicy": {
+			annotations: map[string]string{},
+			expected:    []string{},
+		},
+		"AllSettings": {
+			annotations: map[string]string{
+				constants.LoggerContentTypesInternalAnnotationKey:    "application/json,text/*",
+				constants.LoggerSamplingRateInternalAnnotationKey:    "0.1",
+				constants.LoggerPayloadOverflowInternalAnnotationKey: "drop",
+				constants.LoggerMaxPayloadBytesInternalAnnotationKey: "1024",
+				constants.LoggerMetadataHeadersInternalAnnotationKey: "X-Request-Id,X-Tenant-Id",
+			},
+			expected: []string{
+				LoggerArgumentSamplingRate, "0.1",
+				LoggerArgumentMaxPayloadBytes, "1024",
+				LoggerArgumentPayloadOverflow, "drop",
+				LoggerArgumentContentTypes, "application/json,text/*",
+				LoggerArgumentMetadataHeaders, "X-Request-Id,X-Tenant-Id",
+			},
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			scenario.annotations[constants.LoggerInternalAnnotationKey] = "true"
+			scenario.annotations[constants.LoggerSinkUrlInternalAnnotationKey] = "http://logger.default.svc.cluster.local"
+			scenario.annotations[constants.LoggerModeInternalAnnotationKey] = string(v1beta1.LogAll)
+			pod := &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "deployment",
+					Namespace:   "default",
+					Annotations: scenario.annotations,
+				},
+				Spec: v1.PodSpec{
+					Containers: []v1.Container{
+						{
+							Name: constants.InferenceServiceContainerName,
+						},
+					},
+				},
+			}
+			injector := &AgentInjector{
+				credentials.NewCredentialBuilder(c, fakeclientset.NewSimpleClientset(), &v1.ConfigMap{
+					Data: map[string]string{},
+				}),
+				agentConfig,
+				loggerConfig,
+				batcherTestConfig,
+				storageInitializerConfig,
+			}
+			g.Expect(injector.InjectAgent(pod)).To(gomega.Succeed())
+			agentContainer := getContainerWithName(pod, constants.AgentContainerName)
+			g.Expect(agentContainer).NotTo(gomega.BeNil())
+			args := []string{}
+			for i, arg := range agentContainer.Args {
+				if strings.HasPrefix(arg, "--log-") && arg != LoggerArgumentLogUrl && arg != LoggerArgumentMode {
+					args = append(args, arg, agentContainer.Args[i+1])
+				}
+			}
+			g.Expect(args).To(gomega.Equal(scenario.expected))
+		})
+	}
+}
+
+func TestAgentInjectorBatcherLimits(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	scenarios := map[string]struct {
+		annotations map[string]string
+		expected    []string
+	}{
+		"NoLimits": {
+			annotations: map[string]string{
+				constants.BatcherMaxBatchSizeInternalAnnotationKey: "30",
+			},
+			expected: []string{
+				BatcherEnableFlag,
+				BatcherArgumentMaxBatchSize, "30",
+				"--component-port", "8080",
+			},
+		},
+		"InFlightAndQueueLimits": {
+			annotations: map[string]string{
+				constants.BatcherMaxBatchSizeInternalAnnotationKey: "30",
+				constants.BatcherMaxInFlightInternalAnnotationKey:  "2",
+				constants.BatcherMaxQueueSizeInternalAnnotationKey: "500",
+			},
+			expected: []string{
+				BatcherEnableFlag,
+				BatcherArgumentMaxBatchSize, "30",
+				BatcherArgumentMaxInFlight, "2",
+				BatcherArgumentMaxQueueSize, "500",
+				"--component-port", "8080",
+			},
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			scenario.annotations[constants.BatcherInternalAnnotationKey] = "true"
+			pod := &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "deployment",
+					Namespace:   "default",
+					Annotations: scenario.annotations,
+				},
+				Spec: v1.PodSpec{
+					Containers: []v1.Container{
+						{
+							Name: constants.InferenceServiceContainerName,
+						},
+					},
+				},
+			}
+			injector := &AgentInjector{
+				credentials.NewCredentialBuilder(c, fakeclientset.NewSimpleClientset(), &v1.ConfigMap{
+					Data: map[string]string{},
+				}),
+				agentConfig,
+				loggerConfig,
+				batcherTestConfig,
+				storageInitializerConfig,
+			}
+			g.Expect(injector.InjectAgent(pod)).To(gomega.Succeed())
+			agentContainer := getContainerWithName(pod, constants.AgentContainerName)
+			g.Expect(agentContainer).NotTo(gomega.BeNil())
+			g.Expect(agentContainer.Args).To(gomega.Equal(scenario.expected))
+		})
+	}
+}
+
+func TestAgentInjectorReclaimModelStore(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	scenarios := map[string]struct {
+		annotations map[string]string
+		expected    bool
+	}{
+		"ReclaimDisabledByDefault": {
+			annotations: map[string]string{},
+			expected:    false,
+		},
+		"ReclaimEnabled": {
+			annotations: map[string]string{
+				constants.ModelStoreReclaimAnnotationKey: "true",
+			},
+			expected: true,
+		},
+		"ReclaimMalformed": {
+			annotations: map[string]string{
+				constants.ModelStoreReclaimAnnotationKey: "yes please",
+			},
+			expected: false,
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			scenario.annotations[constants.AgentShouldInjectAnnotationKey] = "true"
+			scenario.annotations[constants.AgentModelDirAnnotationKey] = "/mnt/models"
+			scenario.annotations[constants.AgentModelConfigVolumeNameAnnotationKey] = "modelconfig-deployment-0"
+			pod := &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "deployment",
+					Namespace:   "default",
+					Annotations: scenario.annotations,
+				},
+				Spec: v1.PodSpec{
+					Containers: []v1.Container{
+						{
+							Name: constants.InferenceServiceContainerName,
+						},
+					},
+				},
+			}
+			injector := &AgentInjector{
+				credentials.NewCredentialBuilder(c, fakeclientset.NewSimpleClientset(), &v1.ConfigMap{
+					Data: map[string]string{},
+				}),
+				agentConfig,
+				loggerConfig,
+				batcherTestConfig,
+				storageInitializerConfig,
+			}
+			g.Expect(injector.InjectAgent(pod)).To(gomega.Succeed())
+			agentContainer := getContainerWithName(pod, constants.AgentContainerName)
+			g.Expect(agentContainer).NotTo(gomega.BeNil())
+			if scenario.expected {
+				g.Expect(agentContainer.Args).To(gomega.ContainElement(constants.AgentReclaimModelStoreFlag))
+			} else {
+				g.Expect(agentContainer.Args).NotTo(gomega.ContainElement(constants.AgentReclaimModelStoreFlag))
+			}
+		})
+	}
+}
+
+func TestAgentInjectorLoggerDelivery(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	deliveryLoggerConfig := &LoggerConfig{}
+	*deliveryLoggerConfig = *loggerConfig
+	maxRetries, queueSize := 5, 200
+	deliveryLoggerConfig.MaxRetries = &maxRetries
+	deliveryLoggerConfig.QueueSize = &queueSize
+	deliveryLoggerConfig.DiskBufferPath = "/var/lib/kserve/logger-buffer"
+	deliveryLoggerConfig.DiskBufferSize = "512Mi"
+
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "deployment",
+			Namespace: "default",
+			Annotations: map[string]string{
+				constants.LoggerInternalAnnotationKey:        "true",
+				constants.LoggerSinkUrlInternalAnnotationKey: "http://logger.default.svc.cluster.local",
+				constants.LoggerModeInternalAnnotationKey:    string(v1beta1.LogAll),
+			},
+		},
+		Spec: v1.PodSpec{
+			Containers: []v1.Container{
+				{
+					Name: constants.InferenceServiceContainerName,
+				},
+			},
+		},
+	}
+	injector := &AgentInjector{
+		credentials.NewCredentialBuilder(c, fakeclientset.NewSimpleClientset(), &v1.ConfigMap{
+			Data: map[string]string{},
+		}),
+		agentConfig,
+		deliveryLoggerConfig,
+		batcherTestConfig,
+		storageInitializerConfig,
+	}
+	g.Expect(injector.InjectAgent(pod)).To(gomega.Succeed())
+	agentContainer := getContainerWithName(pod, constants.AgentContainerName)
+	g.Expect(agentContainer).NotTo(gomega.BeNil())
+	g.Expect(strings.Join(agentContainer.Args, " ")).To(gomega.ContainSubstring(
+		"--log-max-retries 5 --log-queue-size 200 --log-disk-buffer-path /var/lib/kserve/logger-buffer --log-disk-buffer-size 512Mi"))
+	g.Expect(agentContainer.VolumeMounts).To(gomega.Equal([]v1.VolumeMount{
+		{Name: LoggerDiskBufferVolumeName, MountPath: "/var/lib/kserve/logger-buffer"},
+	}))
+	g.Expect(pod.Spec.Volumes).To(gomega.Equal([]v1.Volume{
+		{
+			Name: LoggerDiskBufferVolumeName,
+			VolumeSource: v1.VolumeSource{
+				EmptyDir: &v1.EmptyDirVolumeSource{},
+			},
+		},
+	}))
+}
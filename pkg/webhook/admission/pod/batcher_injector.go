@@ -32,6 +32,8 @@ const (
 	BatcherEnableFlag           = "--enable-batcher"
 	BatcherArgumentMaxBatchSize = "--max-batchsize"
 	BatcherArgumentMaxLatency   = "--max-latency"
+	BatcherArgumentMaxInFlight  = "--max-inflight"
+	BatcherArgumentMaxQueueSize = "--max-queue-size"
 )
 
 type BatcherConfig struct {
@@ -20,6 +20,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/kserve/kserve/pkg/constants"
 	"github.com/kserve/kserve/pkg/utils"
@@ -29,6 +30,7 @@ import (
 const (
 	defaultKserveContainerPrometheusPort = "8080"
 	MetricsAggregatorConfigMapKeyName    = "metricsAggregator"
+	queueProxyContainerName              = "queue-proxy"
 )
 
 type MetricsAggregator struct {
@@ -49,40 +51,78 @@ func newMetricsAggregator(configMap *v1.ConfigMap) (*MetricsAggregator, error) {
 	return ma, nil
 }
 
-func setMetricAggregationEnvVarsAndPorts(pod *v1.Pod) {
-	for i, container := range pod.Spec.Containers {
-		if container.Name == "queue-proxy" {
-			// The kserve-container prometheus port/path is inherited from the ClusterServingRuntime YAML.
-			// If no port is defined (transformer using python SDK), use the default port/path for the kserve-container.
-			kserveContainerPromPort := defaultKserveContainerPrometheusPort
-			if port, ok := pod.ObjectMeta.Annotations[constants.KserveContainerPrometheusPortKey]; ok {
-				kserveContainerPromPort = port
-			}
-
-			kserveContainerPromPath := constants.DefaultPrometheusPath
-			if path, ok := pod.ObjectMeta.Annotations[constants.KServeContainerPrometheusPathKey]; ok {
-				kserveContainerPromPath = path
-			}
-
-			// The kserve container port/path is set as an EnvVar in the queue-proxy container
-			// so that it knows which port/path to scrape from the kserve-container.
-			pod.Spec.Containers[i].Env = append(pod.Spec.Containers[i].Env, v1.EnvVar{Name: constants.KServeContainerPrometheusMetricsPortEnvVarKey, Value: kserveContainerPromPort})
-			pod.Spec.Containers[i].Env = append(pod.Spec.Containers[i].Env, v1.EnvVar{Name: constants.KServeContainerPrometheusMetricsPathEnvVarKey, Value: kserveContainerPromPath})
+// kserveContainerMetrics returns the port and the path of the kserve-container metrics, inherited from the annotations
+// of the ClusterServingRuntime. If no port is defined (transformer using python SDK), the default port/path is used.
+func kserveContainerMetrics(pod *v1.Pod) (string, string) {
+	kserveContainerPromPort := defaultKserveContainerPrometheusPort
+	if port, ok := pod.ObjectMeta.Annotations[constants.KserveContainerPrometheusPortKey]; ok {
+		kserveContainerPromPort = port
+	}
+
+	kserveContainerPromPath := constants.DefaultPrometheusPath
+	if path, ok := pod.ObjectMeta.Annotations[constants.KServeContainerPrometheusPathKey]; ok {
+		kserveContainerPromPath = path
+	}
+	return kserveContainerPromPort, kserveContainerPromPath
+}
+
+// agentMetricsPort returns the port of the metrics endpoint of the agent container, from its metrics port flag
+func agentMetricsPort(agent *v1.Container) string {
+	for i, arg := range agent.Args {
+		if arg == constants.AgentMetricsPortArgName && i+1 < len(agent.Args) {
+			return agent.Args[i+1]
+		}
+		if value, ok := strings.CutPrefix(arg, constants.AgentMetricsPortArgName+"="); ok {
+			return value
+		}
+	}
+	return constants.DefaultAgentMetricsPort
+}
 
+// setMetricAggregation configures the container of the pod aggregating the metrics of the kserve-container and of the
+// agent, and returns the port the aggregated metrics are served on. The queue-proxy aggregates the metrics of the
+// serverless pods, the agent the ones of the raw deployment pods. It returns false when no container of the pod
+// aggregates the metrics.
+func setMetricAggregation(pod *v1.Pod) (string, bool) {
+	kserveContainerPromPort, kserveContainerPromPath := kserveContainerMetrics(pod)
+	agent := getContainerWithName(pod, constants.AgentContainerName)
+
+	if queueProxy := getContainerWithName(pod, queueProxyContainerName); queueProxy != nil {
+		// The kserve container port/path is set as an EnvVar in the queue-proxy container
+		// so that it knows which port/path to scrape from the kserve-container.
+		queueProxy.Env = utils.AppendEnvVarIfNotExists(queueProxy.Env,
+			v1.EnvVar{Name: constants.KServeContainerPrometheusMetricsPortEnvVarKey, Value: kserveContainerPromPort},
+			v1.EnvVar{Name: constants.KServeContainerPrometheusMetricsPathEnvVarKey, Value: kserveContainerPromPath},
 			// Set the port that queue-proxy will use to expose the aggregate metrics.
-			pod.Spec.Containers[i].Env = append(pod.Spec.Containers[i].Env, v1.EnvVar{Name: constants.QueueProxyAggregatePrometheusMetricsPortEnvVarKey, Value: strconv.Itoa(constants.QueueProxyAggregatePrometheusMetricsPort)})
+			v1.EnvVar{Name: constants.QueueProxyAggregatePrometheusMetricsPortEnvVarKey, Value: strconv.Itoa(constants.QueueProxyAggregatePrometheusMetricsPort)},
+		)
+		if agent != nil {
+			queueProxy.Env = utils.AppendEnvVarIfNotExists(queueProxy.Env,
+				v1.EnvVar{Name: constants.AgentPrometheusMetricsPortEnvVarKey, Value: agentMetricsPort(agent)})
+		}
+
+		queueProxy.Ports = utils.AppendPortIfNotExists(queueProxy.Ports, v1.ContainerPort{
+			Name:          constants.AggregateMetricsPortName,
+			ContainerPort: int32(constants.QueueProxyAggregatePrometheusMetricsPort),
+			Protocol:      "TCP",
+		})
+		return strconv.Itoa(constants.QueueProxyAggregatePrometheusMetricsPort), true
+	}
 
-			pod.Spec.Containers[i].Ports = utils.AppendPortIfNotExists(pod.Spec.Containers[i].Ports, v1.ContainerPort{
-				Name:          constants.AggregateMetricsPortName,
-				ContainerPort: int32(constants.QueueProxyAggregatePrometheusMetricsPort),
-				Protocol:      "TCP",
-			})
+	if agent != nil {
+		// The agent serves the kserve-container metrics along its own on its metrics port.
+		if !utils.IncludesArg(agent.Args, constants.AgentComponentMetricsPortArgName) {
+			agent.Args = append(agent.Args,
+				constants.AgentComponentMetricsPortArgName, kserveContainerPromPort,
+				constants.AgentComponentMetricsPathArgName, kserveContainerPromPath)
 		}
+		return agentMetricsPort(agent), true
 	}
+	return "", false
 }
 
-// InjectMetricsAggregator looks for the annotations to enable aggregate kserve-container and queue-proxy metrics and
-// if specified, sets port-related EnvVars in queue-proxy and the aggregate prometheus annotation.
+// InjectMetricsAggregator looks for the annotations to enable aggregate kserve-container, agent and queue-proxy metrics
+// and if specified, configures the aggregating container and sets the aggregate prometheus annotation.
 func (ma *MetricsAggregator) InjectMetricsAggregator(pod *v1.Pod) error {
 	// Only set metric configs if the required annotations are set
 	enableMetricAggregation, ok := pod.ObjectMeta.Annotations[constants.EnableMetricAggregation]
@@ -93,8 +133,9 @@ func (ma *MetricsAggregator) InjectMetricsAggregator(pod *v1.Pod) error {
 		pod.ObjectMeta.Annotations[constants.EnableMetricAggregation] = ma.EnableMetricAggregation
 		enableMetricAggregation = ma.EnableMetricAggregation
 	}
+	aggregatePromPort, aggregated := "", false
 	if enableMetricAggregation == "true" {
-		setMetricAggregationEnvVarsAndPorts(pod)
+		aggregatePromPort, aggregated = setMetricAggregation(pod)
 	}
 
 	// Handle setting the pod prometheus annotations
@@ -104,14 +145,18 @@ func (ma *MetricsAggregator) InjectMetricsAggregator(pod *v1.Pod) error {
 		setPromAnnotation = ma.EnablePrometheusScraping
 	}
 	if setPromAnnotation == "true" {
-		// Set prometheus port to default queue proxy prometheus metrics port.
-		// If enableMetricAggregation is true, set it as the queue proxy metrics aggregation port.
-		podPromPort := constants.DefaultPodPrometheusPort
-		if enableMetricAggregation == "true" {
-			podPromPort = strconv.Itoa(constants.QueueProxyAggregatePrometheusMetricsPort)
+		// Set prometheus port to the aggregate metrics port when the metrics are aggregated. Otherwise set it to the
+		// default queue proxy prometheus metrics port, or to the kserve-container metrics port when there is no
+		// queue proxy in raw deployment mode.
+		podPromPort, podPromPath := constants.DefaultPodPrometheusPort, constants.DefaultPrometheusPath
+		switch {
+		case aggregated:
+			podPromPort = aggregatePromPort
+		case getContainerWithName(pod, queueProxyContainerName) == nil:
+			podPromPort, podPromPath = kserveContainerMetrics(pod)
 		}
 		pod.ObjectMeta.Annotations[constants.PrometheusPortAnnotationKey] = podPromPort
-		pod.ObjectMeta.Annotations[constants.PrometheusPathAnnotationKey] = constants.DefaultPrometheusPath
+		pod.ObjectMeta.Annotations[constants.PrometheusPathAnnotationKey] = podPromPath
 	}
 
 	return nil
@@ -318,3 +318,125 @@ func TestInjectMetricsAggregator(t *testing.T) {
 		}
 	}
 }
+
+func TestInjectMetricsAggregatorMultiContainer(t *testing.T) {
+	aggregationAnnotations := func(extra map[string]string) map[string]string {
+		annotations := map[string]string{
+			constants.EnableMetricAggregation: "true",
+			constants.SetPrometheusAnnotation: "true",
+		}
+		for key, value := range extra {
+			annotations[key] = value
+		}
+		return annotations
+	}
+	runtimeAnnotations := map[string]string{
+		constants.KserveContainerPrometheusPortKey: "8082",
+		constants.KServeContainerPrometheusPathKey: "/stats",
+	}
+	scenarios := map[string]struct {
+		annotations        map[string]string
+		containers         []v1.Container
+		expectedContainers []v1.Container
+		expectedPort       string
+		expectedPath       string
+	}{
+		"ServerlessWithAgent": {
+			annotations: aggregationAnnotations(runtimeAnnotations),
+			containers: []v1.Container{
+				{Name: constants.InferenceServiceContainerName},
+				{Name: constants.AgentContainerName, Args: []string{constants.AgentMetricsPortArgName, "9092"}},
+				{Name: "queue-proxy"},
+			},
+			expectedContainers: []v1.Container{
+				{Name: constants.InferenceServiceContainerName},
+				{Name: constants.AgentContainerName, Args: []string{constants.AgentMetricsPortArgName, "9092"}},
+				{
+					Name: "queue-proxy",
+					Env: []v1.EnvVar{
+						{Name: constants.KServeContainerPrometheusMetricsPortEnvVarKey, Value: "8082"},
+						{Name: constants.KServeContainerPrometheusMetricsPathEnvVarKey, Value: "/stats"},
+						{Name: constants.QueueProxyAggregatePrometheusMetricsPortEnvVarKey, Value: strconv.Itoa(constants.QueueProxyAggregatePrometheusMetricsPort)},
+						{Name: constants.AgentPrometheusMetricsPortEnvVarKey, Value: "9092"},
+					},
+					Ports: []v1.ContainerPort{
+						{Name: constants.AggregateMetricsPortName, ContainerPort: int32(constants.QueueProxyAggregatePrometheusMetricsPort), Protocol: "TCP"},
+					},
+				},
+			},
+			expectedPort: strconv.Itoa(constants.QueueProxyAggregatePrometheusMetricsPort),
+			expectedPath: constants.DefaultPrometheusPath,
+		},
+		"RawDeploymentWithAgent": {
+			annotations: aggregationAnnotations(runtimeAnnotations),
+			containers: []v1.Container{
+				{Name: constants.InferenceServiceContainerName},
+				{Name: constants.AgentContainerName, Args: []string{constants.AgentEnableFlag}},
+			},
+			expectedContainers: []v1.Container{
+				{Name: constants.InferenceServiceContainerName},
+				{Name: constants.AgentContainerName, Args: []string{constants.AgentEnableFlag,
+					constants.AgentComponentMetricsPortArgName, "8082", constants.AgentComponentMetricsPathArgName, "/stats"}},
+			},
+			expectedPort: constants.DefaultAgentMetricsPort,
+			expectedPath: constants.DefaultPrometheusPath,
+		},
+		"RawDeploymentWithoutAgent": {
+			annotations:        aggregationAnnotations(runtimeAnnotations),
+			containers:         []v1.Container{{Name: constants.InferenceServiceContainerName}},
+			expectedContainers: []v1.Container{{Name: constants.InferenceServiceContainerName}},
+			expectedPort:       "8082",
+			expectedPath:       "/stats",
+		},
+		"RawDeploymentRuntimeWithoutMetrics": {
+			annotations:        aggregationAnnotations(nil),
+			containers:         []v1.Container{{Name: constants.InferenceServiceContainerName}},
+			expectedContainers: []v1.Container{{Name: constants.InferenceServiceContainerName}},
+			expectedPort:       defaultKserveContainerPrometheusPort,
+			expectedPath:       constants.DefaultPrometheusPath,
+		},
+		"RawDeploymentWithAgentNotAggregated": {
+			annotations: map[string]string{
+				constants.EnableMetricAggregation:          "false",
+				constants.SetPrometheusAnnotation:          "true",
+				constants.KserveContainerPrometheusPortKey: "8082",
+			},
+			containers: []v1.Container{
+				{Name: constants.InferenceServiceContainerName},
+				{Name: constants.AgentContainerName},
+			},
+			expectedContainers: []v1.Container{
+				{Name: constants.InferenceServiceContainerName},
+				{Name: constants.AgentContainerName},
+			},
+			expectedPort: "8082",
+			expectedPath: constants.DefaultPrometheusPath,
+		},
+	}
+
+	ma := &MetricsAggregator{}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			pod := &v1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "deployment", Namespace: "default", Annotations: scenario.annotations},
+				Spec:       v1.PodSpec{Containers: scenario.containers},
+			}
+			if err := ma.InjectMetricsAggregator(pod); err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+			// the mutator is reinvoked when other webhooks mutate the pod
+			if err := ma.InjectMetricsAggregator(pod); err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+			if diff, _ := kmp.SafeDiff(scenario.expectedContainers, pod.Spec.Containers); diff != "" {
+				t.Errorf("unexpected containers (-want +got): %v", diff)
+			}
+			if port := pod.Annotations[constants.PrometheusPortAnnotationKey]; port != scenario.expectedPort {
+				t.Errorf("expected the prometheus port %q, got %q", scenario.expectedPort, port)
+			}
+			if path := pod.Annotations[constants.PrometheusPathAnnotationKey]; path != scenario.expectedPath {
+				t.Errorf("expected the prometheus path %q, got %q", scenario.expectedPath, path)
+			}
+		})
+	}
+}
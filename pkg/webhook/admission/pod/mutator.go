@@ -108,10 +108,11 @@ func (mutator *Mutator) mutate(pod *v1.Pod, configMap *v1.ConfigMap) error {
 	}
 
 	agentInjector := &AgentInjector{
-		credentialBuilder: credentialBuilder,
-		agentConfig:       agentConfig,
-		loggerConfig:      loggerConfig,
-		batcherConfig:     batcherConfig,
+		credentialBuilder:        credentialBuilder,
+		agentConfig:              agentConfig,
+		loggerConfig:             loggerConfig,
+		batcherConfig:            batcherConfig,
+		storageInitializerConfig: storageInitializerConfig,
 	}
 
 	metricsAggregator, err := newMetricsAggregator(configMap)
@@ -119,18 +120,32 @@ func (mutator *Mutator) mutate(pod *v1.Pod, configMap *v1.ConfigMap) error {
 		return err
 	}
 
+	tracingInjector, err := newTracingInjector(configMap)
+	if err != nil {
+		return err
+	}
+
+	podTemplateInjector, err := newPodTemplateInjector(configMap, mutator.Clientset)
+	if err != nil {
+		return err
+	}
+
 	mutators := []func(pod *v1.Pod) error{
 		InjectGKEAcceleratorSelector,
 		storageInitializer.InjectStorageInitializer,
 		storageInitializer.SetIstioCniSecurityContext,
 		agentInjector.InjectAgent,
 		metricsAggregator.InjectMetricsAggregator,
+		tracingInjector.InjectTracing,
 	}
 
 	if storageInitializer.config.EnableOciImageSource {
 		mutators = append(mutators, storageInitializer.InjectModelcar)
 	}
 
+	// the templates are merged once the kserve containers are injected
+	mutators = append(mutators, podTemplateInjector.InjectPodTemplates)
+
 	for _, mutator := range mutators {
 		if err := mutator(pod); err != nil {
 			return err
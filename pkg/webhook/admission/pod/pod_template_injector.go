@@ -0,0 +1,241 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/yaml"
+
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+const (
+	PodTemplateInjectionsConfigMapKeyName = "podTemplateInjections"
+	// PodTemplateConfigMapKey is the key of the template in the config maps referenced by the injections
+	PodTemplateConfigMapKey = "template"
+)
+
+// kserveOwnedContainerNames are the containers injected or managed by kserve, the templates cannot define them
+var kserveOwnedContainerNames = sets.New(
+	constants.InferenceServiceContainerName,
+	constants.TransformerContainerName,
+	constants.AgentContainerName,
+	constants.StorageInitializerContainerName,
+	ModelcarContainerName,
+	"queue-proxy",
+)
+
+// PodTemplateInjection selects the pods the template of a config map is merged into
+type PodTemplateInjection struct {
+	// Name identifies the injection, the pods opt out of it by listing it in the disable annotation
+	Name string `json:"name"`
+	// ConfigMapName is the name of the config map holding the template under the template key
+	ConfigMapName string `json:"configMapName"`
+	// Namespaced reads the config map from the namespace of the pod instead of the kserve namespace
+	Namespaced bool `json:"namespaced,omitempty"`
+	// Selector selects the pods by label, all the pods are selected when it is not set
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+	// Annotation only selects the pods having this annotation set to "true"
+	Annotation string `json:"annotation,omitempty"`
+}
+
+// PodTemplate holds the containers and the volumes merged into the selected pods. The containers are appended to
+// the pod, or have their environment variables merged by name into the container of the pod with the same name.
+// The volumes are appended, a volume already in the pod must be identical.
+type PodTemplate struct {
+	InitContainers []v1.Container `json:"initContainers,omitempty"`
+	Containers     []v1.Container `json:"containers,omitempty"`
+	Volumes        []v1.Volume    `json:"volumes,omitempty"`
+}
+
+type PodTemplateInjector struct {
+	clientset  kubernetes.Interface
+	injections []PodTemplateInjection
+}
+
+func getPodTemplateInjections(configMap *v1.ConfigMap) ([]PodTemplateInjection, error) {
+	injections := []PodTemplateInjection{}
+	value, ok := configMap.Data[PodTemplateInjectionsConfigMapKeyName]
+	if !ok {
+		return injections, nil
+	}
+	if err := json.Unmarshal([]byte(value), &injections); err != nil {
+		return nil, fmt.Errorf("Unable to unmarshall %v json string due to %w ", PodTemplateInjectionsConfigMapKeyName, err)
+	}
+	names := sets.New[string]()
+	for _, injection := range injections {
+		if injection.Name == "" || injection.ConfigMapName == "" {
+			return nil, fmt.Errorf("the pod template injections of %q require a name and a configMapName", PodTemplateInjectionsConfigMapKeyName)
+		}
+		if names.Has(injection.Name) {
+			return nil, fmt.Errorf("the pod template injection %q is defined more than once", injection.Name)
+		}
+		names.Insert(injection.Name)
+		if _, err := metav1.LabelSelectorAsSelector(injection.Selector); err != nil {
+			return nil, fmt.Errorf("invalid selector of the pod template injection %q: %w", injection.Name, err)
+		}
+	}
+	return injections, nil
+}
+
+func newPodTemplateInjector(configMap *v1.ConfigMap, clientset kubernetes.Interface) (*PodTemplateInjector, error) {
+	injections, err := getPodTemplateInjections(configMap)
+	if err != nil {
+		return nil, err
+	}
+	return &PodTemplateInjector{clientset: clientset, injections: injections}, nil
+}
+
+// InjectPodTemplates merges the templates of the injections selecting the pod, unless the pod opts out of them with
+// the disable annotation set to "true" or to a comma separated list of injection names
+func (pi *PodTemplateInjector) InjectPodTemplates(pod *v1.Pod) error {
+	disabled := pod.ObjectMeta.Annotations[constants.DisablePodTemplateInjectionsAnnotationKey]
+	if disabled == "true" {
+		return nil
+	}
+	disabledNames := sets.New[string]()
+	for _, name := range strings.Split(disabled, ",") {
+		disabledNames.Insert(strings.TrimSpace(name))
+	}
+	for _, injection := range pi.injections {
+		if disabledNames.Has(injection.Name) || !injection.selects(pod) {
+			continue
+		}
+		template, err := pi.getPodTemplate(injection, pod.Namespace)
+		if err != nil {
+			return err
+		}
+		if err := mergePodTemplate(pod, template); err != nil {
+			return fmt.Errorf("failed to inject the pod template %q: %w", injection.Name, err)
+		}
+	}
+	return nil
+}
+
+func (injection *PodTemplateInjection) selects(pod *v1.Pod) bool {
+	if injection.Annotation != "" && pod.ObjectMeta.Annotations[injection.Annotation] != "true" {
+		return false
+	}
+	if injection.Selector == nil {
+		return true
+	}
+	// the selector was validated when the injections were loaded
+	selector, err := metav1.LabelSelectorAsSelector(injection.Selector)
+	if err != nil {
+		return false
+	}
+	return selector.Matches(labels.Set(pod.ObjectMeta.Labels))
+}
+
+func (pi *PodTemplateInjector) getPodTemplate(injection PodTemplateInjection, podNamespace string) (*PodTemplate, error) {
+	namespace := constants.KServeNamespace
+	if injection.Namespaced {
+		namespace = podNamespace
+	}
+	configMap, err := pi.clientset.CoreV1().ConfigMaps(namespace).Get(context.TODO(), injection.ConfigMapName, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the config map %s/%s of the pod template injection %q: %w",
+			namespace, injection.ConfigMapName, injection.Name, err)
+	}
+	value, ok := configMap.Data[PodTemplateConfigMapKey]
+	if !ok {
+		return nil, fmt.Errorf("the config map %s/%s of the pod template injection %q has no %q key",
+			namespace, injection.ConfigMapName, injection.Name, PodTemplateConfigMapKey)
+	}
+	template := &PodTemplate{}
+	if err := yaml.UnmarshalStrict([]byte(value), template); err != nil {
+		return nil, fmt.Errorf("invalid template in the config map %s/%s of the pod template injection %q: %w",
+			namespace, injection.ConfigMapName, injection.Name, err)
+	}
+	return template, nil
+}
+
+func mergePodTemplate(pod *v1.Pod, template *PodTemplate) error {
+	var err error
+	if pod.Spec.InitContainers, err = mergeContainers(pod.Spec.InitContainers, pod.Spec.Containers, template.InitContainers); err != nil {
+		return err
+	}
+	if pod.Spec.Containers, err = mergeContainers(pod.Spec.Containers, pod.Spec.InitContainers, template.Containers); err != nil {
+		return err
+	}
+	for _, volume := range template.Volumes {
+		found := false
+		for _, existing := range pod.Spec.Volumes {
+			if existing.Name != volume.Name {
+				continue
+			}
+			if !equality.Semantic.DeepEqual(existing, volume) {
+				return fmt.Errorf("the volume %q already exists in the pod with a different source", volume.Name)
+			}
+			found = true
+		}
+		if !found {
+			pod.Spec.Volumes = append(pod.Spec.Volumes, volume)
+		}
+	}
+	return nil
+}
+
+// mergeContainers appends the template containers to the containers of the pod, or merges their environment
+// variables into the container with the same name. The names of the kserve containers and of the containers of the
+// other list of the pod are rejected.
+func mergeContainers(containers []v1.Container, otherContainers []v1.Container, templateContainers []v1.Container) ([]v1.Container, error) {
+	for _, templateContainer := range templateContainers {
+		if kserveOwnedContainerNames.Has(templateContainer.Name) {
+			return nil, fmt.Errorf("the container %q is managed by kserve and cannot be injected", templateContainer.Name)
+		}
+		for _, other := range otherContainers {
+			if other.Name == templateContainer.Name {
+				return nil, fmt.Errorf("the container %q already exists in the pod as a different kind of container", templateContainer.Name)
+			}
+		}
+		merged := false
+		for i := range containers {
+			if containers[i].Name != templateContainer.Name {
+				continue
+			}
+			for _, envVar := range templateContainer.Env {
+				addOrReplaceEnvVar(&containers[i], envVar)
+			}
+			merged = true
+		}
+		if !merged {
+			containers = append(containers, templateContainer)
+		}
+	}
+	return containers, nil
+}
+
+func addOrReplaceEnvVar(container *v1.Container, envVar v1.EnvVar) {
+	for i := range container.Env {
+		if container.Env[i].Name == envVar.Name {
+			container.Env[i] = envVar
+			return
+		}
+	}
+	container.Env = append(container.Env, envVar)
+}
@@ -0,0 +1,301 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"testing"
+
+	"github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakeclientset "k8s.io/client-go/kubernetes/fake"
+
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+const (
+	vaultTemplate = `
+initContainers:
+- name: vault-agent-init
+  image: vault:1.15
+containers:
+- name: vault-agent
+  image: vault:1.15
+  env:
+  - name: VAULT_ADDR
+    value: http://vault:8200
+volumes:
+- name: vault-secrets
+  emptyDir:
+    medium: Memory
+`
+	fluentBitTemplate = `
+containers:
+- name: fluent-bit
+  image: fluent/fluent-bit:2.2
+`
+)
+
+func templateConfigMap(name string, namespace string, template string) *v1.ConfigMap {
+	return &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Data:       map[string]string{PodTemplateConfigMapKey: template},
+	}
+}
+
+func makeInjectedPod(annotations map[string]string, containers ...v1.Container) *v1.Pod {
+	if len(containers) == 0 {
+		containers = []v1.Container{{Name: constants.InferenceServiceContainerName}}
+	}
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "isvc-predictor",
+			Namespace:   "user-namespace",
+			Labels:      map[string]string{constants.InferenceServicePodLabelKey: "isvc", "team": "fraud"},
+			Annotations: annotations,
+		},
+		Spec: v1.PodSpec{Containers: containers},
+	}
+}
+
+func TestGetPodTemplateInjections(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	scenarios := map[string]struct {
+		value      string
+		expected   []PodTemplateInjection
+		errMatcher gomega.OmegaMatcher
+	}{
+		"valid": {
+			value: `[{"name": "vault", "configMapName": "vault-template", "selector": {"matchLabels": {"team": "fraud"}}},
+				{"name": "logs", "configMapName": "logs-template", "namespaced": true, "annotation": "example.com/logs"}]`,
+			expected: []PodTemplateInjection{
+				{Name: "vault", ConfigMapName: "vault-template", Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "fraud"}}},
+				{Name: "logs", ConfigMapName: "logs-template", Namespaced: true, Annotation: "example.com/logs"},
+			},
+			errMatcher: gomega.BeNil(),
+		},
+		"malformed": {
+			value:      `{"name": "vault"}`,
+			errMatcher: gomega.HaveOccurred(),
+		},
+		"missing config map name": {
+			value:      `[{"name": "vault"}]`,
+			errMatcher: gomega.MatchError(`the pod template injections of "podTemplateInjections" require a name and a configMapName`),
+		},
+		"duplicate name": {
+			value:      `[{"name": "vault", "configMapName": "a"}, {"name": "vault", "configMapName": "b"}]`,
+			errMatcher: gomega.MatchError(`the pod template injection "vault" is defined more than once`),
+		},
+		"invalid selector": {
+			value:      `[{"name": "vault", "configMapName": "a", "selector": {"matchExpressions": [{"key": "team", "operator": "Near"}]}}]`,
+			errMatcher: gomega.HaveOccurred(),
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			injections, err := getPodTemplateInjections(&v1.ConfigMap{
+				Data: map[string]string{PodTemplateInjectionsConfigMapKeyName: scenario.value},
+			})
+			g.Expect(err).To(scenario.errMatcher)
+			if scenario.expected != nil {
+				g.Expect(injections).To(gomega.Equal(scenario.expected))
+			}
+		})
+	}
+
+	injections, err := getPodTemplateInjections(&v1.ConfigMap{})
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(injections).To(gomega.BeEmpty())
+}
+
+func TestInjectPodTemplates(t *testing.T) {
+	vaultInjection := PodTemplateInjection{Name: "vault", ConfigMapName: "vault-template"}
+	fluentBitInjection := PodTemplateInjection{Name: "fluent-bit", ConfigMapName: "fluent-bit-template", Namespaced: true}
+	vaultContainer := v1.Container{
+		Name:  "vault-agent",
+		Image: "vault:1.15",
+		Env:   []v1.EnvVar{{Name: "VAULT_ADDR", Value: "http://vault:8200"}},
+	}
+	vaultVolume := v1.Volume{
+		Name:         "vault-secrets",
+		VolumeSource: v1.VolumeSource{EmptyDir: &v1.EmptyDirVolumeSource{Medium: v1.StorageMediumMemory}},
+	}
+	kserveContainer := v1.Container{Name: constants.InferenceServiceContainerName}
+	configMaps := []*v1.ConfigMap{
+		templateConfigMap("vault-template", constants.KServeNamespace, vaultTemplate),
+		templateConfigMap("fluent-bit-template", "user-namespace", fluentBitTemplate),
+		templateConfigMap("agent-template", constants.KServeNamespace, "containers:\n- name: agent\n"),
+		templateConfigMap("init-collision-template", constants.KServeNamespace, "initContainers:\n- name: sidecar\n"),
+		templateConfigMap("volume-collision-template", constants.KServeNamespace, "volumes:\n- name: vault-secrets\n  emptyDir: {}\n"),
+		templateConfigMap("invalid-template", constants.KServeNamespace, "containers: sidecar\n"),
+		{ObjectMeta: metav1.ObjectMeta{Name: "empty-config-map", Namespace: constants.KServeNamespace}},
+	}
+
+	scenarios := map[string]struct {
+		injections []PodTemplateInjection
+		original   *v1.Pod
+		expected   *v1.Pod
+		errMatcher gomega.OmegaMatcher
+	}{
+		"no injection": {
+			original:   makeInjectedPod(nil),
+			expected:   makeInjectedPod(nil),
+			errMatcher: gomega.BeNil(),
+		},
+		"appends the containers and the volumes": {
+			injections: []PodTemplateInjection{vaultInjection},
+			original:   makeInjectedPod(nil),
+			expected: func() *v1.Pod {
+				pod := makeInjectedPod(nil, kserveContainer, vaultContainer)
+				pod.Spec.InitContainers = []v1.Container{{Name: "vault-agent-init", Image: "vault:1.15"}}
+				pod.Spec.Volumes = []v1.Volume{vaultVolume}
+				return pod
+			}(),
+			errMatcher: gomega.BeNil(),
+		},
+		"reads the namespaced template from the namespace of the pod": {
+			injections: []PodTemplateInjection{fluentBitInjection},
+			original:   makeInjectedPod(nil),
+			expected:   makeInjectedPod(nil, kserveContainer, v1.Container{Name: "fluent-bit", Image: "fluent/fluent-bit:2.2"}),
+			errMatcher: gomega.BeNil(),
+		},
+		"selector not matching": {
+			injections: []PodTemplateInjection{{
+				Name:          "vault",
+				ConfigMapName: "vault-template",
+				Selector:      &metav1.LabelSelector{MatchLabels: map[string]string{"team": "search"}},
+			}},
+			original:   makeInjectedPod(nil),
+			expected:   makeInjectedPod(nil),
+			errMatcher: gomega.BeNil(),
+		},
+		"missing selection annotation": {
+			injections: []PodTemplateInjection{{Name: "fluent-bit", ConfigMapName: "fluent-bit-template", Namespaced: true, Annotation: "example.com/logs"}},
+			original:   makeInjectedPod(map[string]string{"example.com/logs": "false"}),
+			expected:   makeInjectedPod(map[string]string{"example.com/logs": "false"}),
+			errMatcher: gomega.BeNil(),
+		},
+		"matching selector and annotation": {
+			injections: []PodTemplateInjection{{
+				Name:          "fluent-bit",
+				ConfigMapName: "fluent-bit-template",
+				Namespaced:    true,
+				Selector:      &metav1.LabelSelector{MatchLabels: map[string]string{"team": "fraud"}},
+				Annotation:    "example.com/logs",
+			}},
+			original:   makeInjectedPod(map[string]string{"example.com/logs": "true"}),
+			expected:   makeInjectedPod(map[string]string{"example.com/logs": "true"}, kserveContainer, v1.Container{Name: "fluent-bit", Image: "fluent/fluent-bit:2.2"}),
+			errMatcher: gomega.BeNil(),
+		},
+		"opted out of all the injections": {
+			injections: []PodTemplateInjection{vaultInjection, fluentBitInjection},
+			original:   makeInjectedPod(map[string]string{constants.DisablePodTemplateInjectionsAnnotationKey: "true"}),
+			expected:   makeInjectedPod(map[string]string{constants.DisablePodTemplateInjectionsAnnotationKey: "true"}),
+			errMatcher: gomega.BeNil(),
+		},
+		"opted out of one injection": {
+			injections: []PodTemplateInjection{vaultInjection, fluentBitInjection},
+			original:   makeInjectedPod(map[string]string{constants.DisablePodTemplateInjectionsAnnotationKey: "other, vault"}),
+			expected: makeInjectedPod(map[string]string{constants.DisablePodTemplateInjectionsAnnotationKey: "other, vault"},
+				kserveContainer, v1.Container{Name: "fluent-bit", Image: "fluent/fluent-bit:2.2"}),
+			errMatcher: gomega.BeNil(),
+		},
+		"merges the environment into the container with the same name": {
+			injections: []PodTemplateInjection{vaultInjection},
+			original: func() *v1.Pod {
+				pod := makeInjectedPod(nil, kserveContainer, v1.Container{
+					Name:  "vault-agent",
+					Image: "vault:custom",
+					Env:   []v1.EnvVar{{Name: "VAULT_ADDR", Value: "http://old-vault:8200"}, {Name: "VAULT_ROLE", Value: "isvc"}},
+				})
+				pod.Spec.Volumes = []v1.Volume{vaultVolume}
+				return pod
+			}(),
+			expected: func() *v1.Pod {
+				pod := makeInjectedPod(nil, kserveContainer, v1.Container{
+					Name:  "vault-agent",
+					Image: "vault:custom",
+					Env:   []v1.EnvVar{{Name: "VAULT_ADDR", Value: "http://vault:8200"}, {Name: "VAULT_ROLE", Value: "isvc"}},
+				})
+				pod.Spec.InitContainers = []v1.Container{{Name: "vault-agent-init", Image: "vault:1.15"}}
+				pod.Spec.Volumes = []v1.Volume{vaultVolume}
+				return pod
+			}(),
+			errMatcher: gomega.BeNil(),
+		},
+		"container managed by kserve": {
+			injections: []PodTemplateInjection{{Name: "agent", ConfigMapName: "agent-template"}},
+			original:   makeInjectedPod(nil),
+			errMatcher: gomega.MatchError(`failed to inject the pod template "agent": the container "agent" is managed by kserve and cannot be injected`),
+		},
+		"init container named like a container of the pod": {
+			injections: []PodTemplateInjection{{Name: "sidecar", ConfigMapName: "init-collision-template"}},
+			original:   makeInjectedPod(nil, kserveContainer, v1.Container{Name: "sidecar"}),
+			errMatcher: gomega.MatchError(`failed to inject the pod template "sidecar": the container "sidecar" already exists in the pod as a different kind of container`),
+		},
+		"volume with a different source": {
+			injections: []PodTemplateInjection{vaultInjection, {Name: "volume", ConfigMapName: "volume-collision-template"}},
+			original:   makeInjectedPod(nil),
+			errMatcher: gomega.MatchError(`failed to inject the pod template "volume": the volume "vault-secrets" already exists in the pod with a different source`),
+		},
+		"missing config map": {
+			injections: []PodTemplateInjection{{Name: "missing", ConfigMapName: "missing-template"}},
+			original:   makeInjectedPod(nil),
+			errMatcher: gomega.HaveOccurred(),
+		},
+		"missing template key": {
+			injections: []PodTemplateInjection{{Name: "empty", ConfigMapName: "empty-config-map"}},
+			original:   makeInjectedPod(nil),
+			errMatcher: gomega.MatchError(`the config map ` + constants.KServeNamespace + `/empty-config-map of the pod template injection "empty" has no "template" key`),
+		},
+		"invalid template": {
+			injections: []PodTemplateInjection{{Name: "invalid", ConfigMapName: "invalid-template"}},
+			original:   makeInjectedPod(nil),
+			errMatcher: gomega.HaveOccurred(),
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			clientset := fakeclientset.NewSimpleClientset()
+			for _, configMap := range configMaps {
+				g.Expect(clientset.Tracker().Add(configMap)).To(gomega.Succeed())
+			}
+			injector := &PodTemplateInjector{clientset: clientset, injections: scenario.injections}
+			err := injector.InjectPodTemplates(scenario.original)
+			g.Expect(err).To(scenario.errMatcher)
+			if scenario.expected != nil {
+				g.Expect(scenario.original).To(gomega.Equal(scenario.expected))
+			}
+		})
+	}
+}
+
+func TestInjectPodTemplatesIsIdempotent(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	clientset := fakeclientset.NewSimpleClientset(templateConfigMap("vault-template", constants.KServeNamespace, vaultTemplate))
+	injector := &PodTemplateInjector{clientset: clientset, injections: []PodTemplateInjection{{Name: "vault", ConfigMapName: "vault-template"}}}
+
+	// the webhook is invoked again when another webhook mutates the pod
+	pod := makeInjectedPod(nil)
+	g.Expect(injector.InjectPodTemplates(pod)).To(gomega.Succeed())
+	injected := pod.DeepCopy()
+	g.Expect(injector.InjectPodTemplates(pod)).To(gomega.Succeed())
+	g.Expect(pod).To(gomega.Equal(injected))
+	g.Expect(pod.Spec.Containers).To(gomega.HaveLen(2))
+}
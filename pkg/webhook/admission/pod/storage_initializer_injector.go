@@ -21,6 +21,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"path/filepath"
+	"reflect"
 	"strconv"
 	"strings"
 
@@ -28,6 +29,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/strategicpatch"
 
 	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
+	"github.com/kserve/kserve/pkg/apis/serving/v1beta1"
 	"github.com/kserve/kserve/pkg/constants"
 	"github.com/kserve/kserve/pkg/credentials"
 	"github.com/kserve/kserve/pkg/credentials/s3"
@@ -66,6 +68,10 @@ type StorageInitializerConfig struct {
 	EnableDirectPvcVolumeMount bool   `json:"enableDirectPvcVolumeMount"`
 	EnableOciImageSource       bool   `json:"enableModelcar"`
 	UidModelcar                *int64 `json:"uidModelcar"`
+	VerifyChecksum             bool   `json:"verifyChecksum"`
+	HttpProxy                  string `json:"httpProxy"`
+	HttpsProxy                 string `json:"httpsProxy"`
+	NoProxy                    string `json:"noProxy"`
 }
 
 type StorageInitializerInjector struct {
@@ -75,7 +81,10 @@ type StorageInitializerInjector struct {
 }
 
 func getStorageInitializerConfigs(configMap *v1.ConfigMap) (*StorageInitializerConfig, error) {
-	storageInitializerConfig := &StorageInitializerConfig{}
+	// pvcs are mounted directly on the kserve-container unless disabled
+	storageInitializerConfig := &StorageInitializerConfig{
+		EnableDirectPvcVolumeMount: true,
+	}
 	if initializerConfig, ok := configMap.Data[StorageInitializerConfigMapKeyName]; ok {
 		err := json.Unmarshal([]byte(initializerConfig), &storageInitializerConfig)
 		if err != nil {
@@ -97,13 +106,19 @@ func getStorageInitializerConfigs(configMap *v1.ConfigMap) (*StorageInitializerC
 	return storageInitializerConfig, nil
 }
 
+// GetContainerSpecForStorageUri returns the container spec of the enabled storage container supporting storageUri. When
+// several storage containers support it, the one with the highest priority is selected and an error is returned if
+// the highest priority is shared by more than one of them.
 func GetContainerSpecForStorageUri(storageUri string, client client.Client) (*v1.Container, error) {
 	storageContainers := &v1alpha1.ClusterStorageContainerList{}
 	if err := client.List(context.TODO(), storageContainers); err != nil {
 		return nil, err
 	}
 
-	for _, sc := range storageContainers.Items {
+	var selected *v1alpha1.ClusterStorageContainer
+	var ambiguous []string
+	for i := range storageContainers.Items {
+		sc := &storageContainers.Items[i]
 		if sc.IsDisabled() {
 			continue
 		}
@@ -111,12 +126,27 @@ func GetContainerSpecForStorageUri(storageUri string, client client.Client) (*v1
 		if err != nil {
 			return nil, fmt.Errorf("error checking storage container %s: %w", sc.Name, err)
 		}
-		if supported {
-			return &sc.Spec.Container, nil
+		if !supported {
+			continue
+		}
+		switch {
+		case selected == nil || sc.Spec.GetPriority() > selected.Spec.GetPriority():
+			selected = sc
+			ambiguous = nil
+		case sc.Spec.GetPriority() == selected.Spec.GetPriority():
+			ambiguous = append(ambiguous, sc.Name)
 		}
 	}
 
-	return nil, nil
+	if selected == nil {
+		return nil, nil
+	}
+	if len(ambiguous) > 0 {
+		return nil, fmt.Errorf("storage uri %s is supported by the storage containers %s with the same priority %d, "+
+			"set a different priority on one of them", storageUri,
+			strings.Join(append([]string{selected.Name}, ambiguous...), ", "), selected.Spec.GetPriority())
+	}
+	return &selected.Spec.Container, nil
 }
 
 // InjectModelcar injects a sidecar with the full model included to the Pod.
@@ -182,6 +212,82 @@ func (mi *StorageInitializerInjector) InjectModelcar(pod *v1.Pod) error {
 	return nil
 }
 
+// verifyChecksumEnabled returns whether the downloaded model files are verified against their checksum manifest. The
+// storage-verify-checksum annotation takes precedence over the default of the storage initializer config.
+func verifyChecksumEnabled(pod *v1.Pod, config *StorageInitializerConfig) bool {
+	if value, ok := pod.ObjectMeta.Annotations[constants.StorageVerifyChecksumAnnotationKey]; ok {
+		if enabled, err := strconv.ParseBool(value); err == nil {
+			return enabled
+		}
+	}
+	return config != nil && config.VerifyChecksum
+}
+
+// getStorageProxyEnvs returns the proxy envs of the storage initializer config injected into the containers
+// downloading the models, the storage-proxy-enabled annotation set to false opts the pod out of the proxy.
+func getStorageProxyEnvs(pod *v1.Pod, config *StorageInitializerConfig) []v1.EnvVar {
+	if config == nil {
+		return nil
+	}
+	if enabled, err := strconv.ParseBool(pod.ObjectMeta.Annotations[constants.StorageProxyEnabledAnnotationKey]); err == nil && !enabled {
+		return nil
+	}
+	var envs []v1.EnvVar
+	for _, env := range []v1.EnvVar{
+		{Name: constants.HTTPProxyEnvVarKey, Value: config.HttpProxy},
+		{Name: constants.HTTPSProxyEnvVarKey, Value: config.HttpsProxy},
+		{Name: constants.NoProxyEnvVarKey, Value: config.NoProxy},
+	} {
+		if env.Value != "" {
+			envs = append(envs, env)
+		}
+	}
+	return envs
+}
+
+// pvcReadWriteEnabled returns whether the storage-pvc-read-write annotation requests the model of a pvc to be writable
+// by the kserve-container.
+func pvcReadWriteEnabled(pod *v1.Pod) bool {
+	enabled, err := strconv.ParseBool(pod.ObjectMeta.Annotations[constants.StoragePvcReadWriteAnnotationKey])
+	return err == nil && enabled
+}
+
+// getPvcMountMode returns how the model of a pvc is provided to the kserve-container. The storage-pvc-mount-mode
+// annotation takes precedence over the enableDirectPvcVolumeMount config, an empty mode means that the storage
+// initializer links the model from the pvc mounted on the kserve-container.
+func getPvcMountMode(pod *v1.Pod, config *StorageInitializerConfig) string {
+	switch mode := pod.ObjectMeta.Annotations[constants.StoragePvcMountModeAnnotationKey]; mode {
+	case constants.PvcMountModeDirect, constants.PvcMountModeCopy:
+		return mode
+	}
+	if config.EnableDirectPvcVolumeMount {
+		return constants.PvcMountModeDirect
+	}
+	return ""
+}
+
+// overrideStorageInitializerResources applies the resources of the storage-initializer-resources annotation to the
+// storage initializer container, they take precedence over the storage initializer config and the storage container CR.
+func overrideStorageInitializerResources(pod *v1.Pod, initContainer *v1.Container) error {
+	resources, err := v1beta1.GetStorageInitializerResources(pod.ObjectMeta.Annotations)
+	if err != nil || resources == nil {
+		return err
+	}
+	if len(resources.Limits) > 0 && initContainer.Resources.Limits == nil {
+		initContainer.Resources.Limits = v1.ResourceList{}
+	}
+	for name, quantity := range resources.Limits {
+		initContainer.Resources.Limits[name] = quantity
+	}
+	if len(resources.Requests) > 0 && initContainer.Resources.Requests == nil {
+		initContainer.Resources.Requests = v1.ResourceList{}
+	}
+	for name, quantity := range resources.Requests {
+		initContainer.Resources.Requests[name] = quantity
+	}
+	return nil
+}
+
 // InjectStorageInitializer injects an init container to provision model data
 // for the serving container in a unified way across storage tech by injecting
 // a provisioning INIT container. This is a work around because KNative does not
@@ -220,6 +326,7 @@ func (mi *StorageInitializerInjector) InjectStorageInitializer(pod *v1.Pod) erro
 
 	podVolumes := []v1.Volume{}
 	storageInitializerMounts := []v1.VolumeMount{}
+	pvcMountMode := ""
 
 	// For PVC source URIs we need to mount the source to be able to access it
 	// See design and discussion here: https://github.com/kserve/kserve/issues/148
@@ -240,9 +347,12 @@ func (mi *StorageInitializerInjector) InjectStorageInitializer(pod *v1.Pod) erro
 		}
 		podVolumes = append(podVolumes, pvcSourceVolume)
 
+		readOnly := !pvcReadWriteEnabled(pod)
+		pvcMountMode = getPvcMountMode(pod, mi.config)
+
 		// check if using direct volume mount to mount the pvc
 		// if yes, mount the pvc to model local mount path and return
-		if mi.config.EnableDirectPvcVolumeMount {
+		if pvcMountMode == constants.PvcMountModeDirect {
 			// add a corresponding pvc volume mount to the userContainer
 			// pvc will be mount to /mnt/models rather than /mnt/pvc
 			// pvcPath will be injected via SubPath, pvcPath must be a root or Dir
@@ -265,7 +375,10 @@ func (mi *StorageInitializerInjector) InjectStorageInitializer(pod *v1.Pod) erro
 				}
 			}
 
-			userContainer.VolumeMounts = append(userContainer.VolumeMounts, pvcSourceVolumeMount)
+			// the model server container may write next to the model if the pvc is mounted read-write
+			userPvcSourceVolumeMount := pvcSourceVolumeMount
+			userPvcSourceVolumeMount.ReadOnly = readOnly
+			userContainer.VolumeMounts = append(userContainer.VolumeMounts, userPvcSourceVolumeMount)
 			if transformerContainer != nil {
 				// Check if PVC source URIs is already mounted
 				if transformerContainer.VolumeMounts != nil {
@@ -289,8 +402,8 @@ func (mi *StorageInitializerInjector) InjectStorageInitializer(pod *v1.Pod) erro
 			// add volumes to the PodSpec
 			pod.Spec.Volumes = append(pod.Spec.Volumes, podVolumes...)
 
-			// not inject the storage initializer
-			return nil
+			// not inject the storage initializer of the model
+			return mi.injectAdapterStorageInitializers(pod, userContainer, transformerContainer)
 		}
 
 		// below use storage initializer to handle the pvc
@@ -302,10 +415,15 @@ func (mi *StorageInitializerInjector) InjectStorageInitializer(pod *v1.Pod) erro
 		}
 		storageInitializerMounts = append(storageInitializerMounts, pvcSourceVolumeMount)
 
-		// Since the model path is linked from source pvc, userContainer also need to mount the pvc.
-		userContainer.VolumeMounts = append(userContainer.VolumeMounts, pvcSourceVolumeMount)
-		if transformerContainer != nil {
-			transformerContainer.VolumeMounts = append(transformerContainer.VolumeMounts, pvcSourceVolumeMount)
+		// In copy mode the model is copied into the shared volume so that only the INIT container mounts the pvc,
+		// otherwise the model path is linked from source pvc and userContainer also need to mount the pvc.
+		if pvcMountMode != constants.PvcMountModeCopy {
+			userPvcSourceVolumeMount := pvcSourceVolumeMount
+			userPvcSourceVolumeMount.ReadOnly = readOnly
+			userContainer.VolumeMounts = append(userContainer.VolumeMounts, userPvcSourceVolumeMount)
+			if transformerContainer != nil {
+				transformerContainer.VolumeMounts = append(transformerContainer.VolumeMounts, pvcSourceVolumeMount)
+			}
 		}
 		// modify the sourceURI to point to the PVC path
 		srcURI = PvcSourceMountPath + "/" + pvcPath
@@ -328,34 +446,9 @@ func (mi *StorageInitializerInjector) InjectStorageInitializer(pod *v1.Pod) erro
 	}
 	storageInitializerMounts = append(storageInitializerMounts, sharedVolumeWriteMount)
 
-	storageInitializerImage := StorageInitializerContainerImage + ":" + StorageInitializerContainerImageVersion
-	if mi.config != nil && mi.config.Image != "" {
-		storageInitializerImage = mi.config.Image
-	}
-
-	securityContext := userContainer.SecurityContext.DeepCopy()
 	// Add an init container to run provisioning logic to the PodSpec
-	initContainer := &v1.Container{
-		Name:  StorageInitializerContainerName,
-		Image: storageInitializerImage,
-		Args: []string{
-			srcURI,
-			constants.DefaultModelLocalMountPath,
-		},
-		TerminationMessagePolicy: v1.TerminationMessageFallbackToLogsOnError,
-		VolumeMounts:             storageInitializerMounts,
-		Resources: v1.ResourceRequirements{
-			Limits: map[v1.ResourceName]resource.Quantity{
-				v1.ResourceCPU:    resource.MustParse(mi.config.CpuLimit),
-				v1.ResourceMemory: resource.MustParse(mi.config.MemoryLimit),
-			},
-			Requests: map[v1.ResourceName]resource.Quantity{
-				v1.ResourceCPU:    resource.MustParse(mi.config.CpuRequest),
-				v1.ResourceMemory: resource.MustParse(mi.config.MemoryRequest),
-			},
-		},
-		SecurityContext: securityContext,
-	}
+	initContainer := mi.newStorageInitializerContainer(StorageInitializerContainerName, srcURI,
+		constants.DefaultModelLocalMountPath, storageInitializerMounts, userContainer.SecurityContext)
 
 	// Add a mount the shared volume on the kserve-container, update the PodSpec
 	sharedVolumeReadMount := v1.VolumeMount{
@@ -363,7 +456,10 @@ func (mi *StorageInitializerInjector) InjectStorageInitializer(pod *v1.Pod) erro
 		MountPath: constants.DefaultModelLocalMountPath,
 		ReadOnly:  true,
 	}
-	userContainer.VolumeMounts = append(userContainer.VolumeMounts, sharedVolumeReadMount)
+	// The model copied from a pvc is writable by the kserve-container if the pvc is requested read-write
+	userSharedVolumeMount := sharedVolumeReadMount
+	userSharedVolumeMount.ReadOnly = pvcMountMode != constants.PvcMountModeCopy || !pvcReadWriteEnabled(pod)
+	userContainer.VolumeMounts = append(userContainer.VolumeMounts, userSharedVolumeMount)
 	if transformerContainer != nil {
 		transformerContainer.VolumeMounts = append(transformerContainer.VolumeMounts, sharedVolumeReadMount)
 	}
@@ -414,60 +510,30 @@ func (mi *StorageInitializerInjector) InjectStorageInitializer(pod *v1.Pod) erro
 	}
 
 	// Inject CA bundle configMap if caBundleConfigMapName or constants.DefaultGlobalCaBundleConfigMapName annotation is set
-	caBundleConfigMapName := mi.config.CaBundleConfigMapName
-	if ok := needCaBundleMount(caBundleConfigMapName, initContainer); ok {
-		if pod.Namespace != constants.KServeNamespace {
-			caBundleConfigMapName = constants.DefaultGlobalCaBundleConfigMapName
-		}
-
-		caBundleVolumeMountPath := mi.config.CaBundleVolumeMountPath
-		if caBundleVolumeMountPath == "" {
-			caBundleVolumeMountPath = constants.DefaultCaBundleVolumeMountPath
-		}
-
-		for _, envVar := range initContainer.Env {
-			if envVar.Name == s3.AWSCABundleConfigMap {
-				caBundleConfigMapName = envVar.Value
-			}
-			if envVar.Name == s3.AWSCABundle {
-				caBundleVolumeMountPath = filepath.Dir(envVar.Value)
-			}
-		}
+	mi.injectCaBundle(pod.Namespace, initContainer, &pod.Spec.Volumes)
 
+	// Verify the downloaded model files against their checksum manifest if enabled
+	if verifyChecksumEnabled(pod, mi.config) {
 		initContainer.Env = append(initContainer.Env, v1.EnvVar{
-			Name:  constants.CaBundleConfigMapNameEnvVarKey,
-			Value: caBundleConfigMapName,
+			Name:  constants.StorageVerifyChecksumEnvVarKey,
+			Value: "true",
 		})
+	}
+
+	// Download the model through the egress proxy, the envs of a storage container CR take precedence
+	initContainer.Env = append(initContainer.Env, getStorageProxyEnvs(pod, mi.config)...)
 
+	// Copy the model from the pvc instead of linking it
+	if pvcMountMode == constants.PvcMountModeCopy {
 		initContainer.Env = append(initContainer.Env, v1.EnvVar{
-			Name:  constants.CaBundleVolumeMountPathEnvVarKey,
-			Value: caBundleVolumeMountPath,
+			Name:  constants.StorageLocalCopyEnvVarKey,
+			Value: "true",
 		})
-
-		caBundleVolume := v1.Volume{
-			Name: CaBundleVolumeName,
-			VolumeSource: v1.VolumeSource{
-				ConfigMap: &v1.ConfigMapVolumeSource{
-					LocalObjectReference: v1.LocalObjectReference{
-						Name: caBundleConfigMapName,
-					},
-				},
-			},
-		}
-
-		caBundleVolumeMount := v1.VolumeMount{
-			Name:      CaBundleVolumeName,
-			MountPath: caBundleVolumeMountPath,
-			ReadOnly:  true,
-		}
-
-		pod.Spec.Volumes = append(pod.Spec.Volumes, caBundleVolume)
-		initContainer.VolumeMounts = append(initContainer.VolumeMounts, caBundleVolumeMount)
 	}
 
 	// Update initContainer (container spec) from a storage container CR if there is a match,
 	// otherwise initContainer is not updated.
-	// Priority: CR > configMap
+	// Priority: CR > configMap, the resources of the storage-initializer-resources annotation take precedence over both
 	storageContainerSpec, err := GetContainerSpecForStorageUri(srcURI, mi.client)
 	if err != nil {
 		return err
@@ -478,27 +544,269 @@ func (mi *StorageInitializerInjector) InjectStorageInitializer(pod *v1.Pod) erro
 			return err
 		}
 	}
+	if err := overrideStorageInitializerResources(pod, initContainer); err != nil {
+		return err
+	}
 
 	// Add init container to the spec
 	pod.Spec.InitContainers = append(pod.Spec.InitContainers, *initContainer)
 
+	return mi.injectAdapterStorageInitializers(pod, userContainer, transformerContainer)
+}
+
+// newStorageInitializerContainer returns a storage initializer container downloading srcURI into destPath
+func (mi *StorageInitializerInjector) newStorageInitializerContainer(name string, srcURI string, destPath string,
+	volumeMounts []v1.VolumeMount, securityContext *v1.SecurityContext) *v1.Container {
+	storageInitializerImage := StorageInitializerContainerImage + ":" + StorageInitializerContainerImageVersion
+	if mi.config != nil && mi.config.Image != "" {
+		storageInitializerImage = mi.config.Image
+	}
+
+	return &v1.Container{
+		Name:  name,
+		Image: storageInitializerImage,
+		Args: []string{
+			srcURI,
+			destPath,
+		},
+		TerminationMessagePolicy: v1.TerminationMessageFallbackToLogsOnError,
+		VolumeMounts:             volumeMounts,
+		Resources: v1.ResourceRequirements{
+			Limits: map[v1.ResourceName]resource.Quantity{
+				v1.ResourceCPU:    resource.MustParse(mi.config.CpuLimit),
+				v1.ResourceMemory: resource.MustParse(mi.config.MemoryLimit),
+			},
+			Requests: map[v1.ResourceName]resource.Quantity{
+				v1.ResourceCPU:    resource.MustParse(mi.config.CpuRequest),
+				v1.ResourceMemory: resource.MustParse(mi.config.MemoryRequest),
+			},
+		},
+		SecurityContext: securityContext.DeepCopy(),
+	}
+}
+
+// injectCaBundle mounts the CA bundle configMap on the storage initializer when caBundleConfigMapName is configured
+// or the s3 credentials of the container point to a CA bundle
+func (mi *StorageInitializerInjector) injectCaBundle(namespace string, initContainer *v1.Container, volumes *[]v1.Volume) {
+	caBundleConfigMapName := mi.config.CaBundleConfigMapName
+	if ok := needCaBundleMount(caBundleConfigMapName, initContainer); !ok {
+		return
+	}
+	if namespace != constants.KServeNamespace {
+		caBundleConfigMapName = constants.DefaultGlobalCaBundleConfigMapName
+	}
+
+	caBundleVolumeMountPath := mi.config.CaBundleVolumeMountPath
+	if caBundleVolumeMountPath == "" {
+		caBundleVolumeMountPath = constants.DefaultCaBundleVolumeMountPath
+	}
+
+	for _, envVar := range initContainer.Env {
+		if envVar.Name == s3.AWSCABundleConfigMap {
+			caBundleConfigMapName = envVar.Value
+		}
+		// The CA bundle of the s3 secret is mounted separately
+		if envVar.Name == s3.AWSCABundle && filepath.Dir(envVar.Value) != s3.AWSCABundleVolumeMountPath {
+			caBundleVolumeMountPath = filepath.Dir(envVar.Value)
+		}
+	}
+
+	initContainer.Env = append(initContainer.Env, v1.EnvVar{
+		Name:  constants.CaBundleConfigMapNameEnvVarKey,
+		Value: caBundleConfigMapName,
+	})
+
+	initContainer.Env = append(initContainer.Env, v1.EnvVar{
+		Name:  constants.CaBundleVolumeMountPathEnvVarKey,
+		Value: caBundleVolumeMountPath,
+	})
+
+	caBundleVolume := v1.Volume{
+		Name: CaBundleVolumeName,
+		VolumeSource: v1.VolumeSource{
+			ConfigMap: &v1.ConfigMapVolumeSource{
+				LocalObjectReference: v1.LocalObjectReference{
+					Name: caBundleConfigMapName,
+				},
+			},
+		},
+	}
+
+	caBundleVolumeMount := v1.VolumeMount{
+		Name:      CaBundleVolumeName,
+		MountPath: caBundleVolumeMountPath,
+		ReadOnly:  true,
+	}
+
+	*volumes = append(*volumes, caBundleVolume)
+	initContainer.VolumeMounts = append(initContainer.VolumeMounts, caBundleVolumeMount)
+}
+
+// injectAdapterStorageInitializers injects a storage initializer per adapter of the model, each adapter is downloaded
+// into its own volume mounted at the mount path of the adapter, with the credentials of the secret of the adapter or
+// of the service account when it has none.
+func (mi *StorageInitializerInjector) injectAdapterStorageInitializers(pod *v1.Pod, userContainer *v1.Container,
+	transformerContainer *v1.Container) error {
+	adaptersJSON, ok := pod.ObjectMeta.Annotations[constants.StorageInitializerAdaptersInternalAnnotationKey]
+	if !ok {
+		return nil
+	}
+	var adapters []v1beta1.ModelAdapterSpec
+	if err := json.Unmarshal([]byte(adaptersJSON), &adapters); err != nil {
+		return fmt.Errorf("invalid %s annotation: %w", constants.StorageInitializerAdaptersInternalAnnotationKey, err)
+	}
+
+	for _, adapter := range adapters {
+		srcURI := adapter.StorageURI
+		volumeName := StorageInitializerVolumeName + "-" + adapter.Name
+		mountPath := adapter.GetMountPath()
+		volumes := []v1.Volume{
+			{
+				Name: volumeName,
+				VolumeSource: v1.VolumeSource{
+					EmptyDir: &v1.EmptyDirVolumeSource{},
+				},
+			},
+		}
+		initContainerMounts := []v1.VolumeMount{
+			{
+				Name:      volumeName,
+				MountPath: mountPath,
+				ReadOnly:  false,
+			},
+		}
+
+		// The PVC of the adapter is mounted on its storage initializer only, which copies the adapter
+		if strings.HasPrefix(srcURI, PvcURIPrefix) {
+			pvcName, pvcPath, err := parsePvcURI(srcURI)
+			if err != nil {
+				return err
+			}
+			pvcVolumeName := PvcSourceMountName + "-" + adapter.Name
+			volumes = append(volumes, v1.Volume{
+				Name: pvcVolumeName,
+				VolumeSource: v1.VolumeSource{
+					PersistentVolumeClaim: &v1.PersistentVolumeClaimVolumeSource{
+						ClaimName: pvcName,
+					},
+				},
+			})
+			initContainerMounts = append(initContainerMounts, v1.VolumeMount{
+				Name:      pvcVolumeName,
+				MountPath: PvcSourceMountPath,
+				ReadOnly:  true,
+			})
+			srcURI = PvcSourceMountPath + "/" + pvcPath
+		}
+
+		initContainer := mi.newStorageInitializerContainer(StorageInitializerContainerName+"-"+adapter.Name, srcURI,
+			mountPath, initContainerMounts, userContainer.SecurityContext)
+
+		// Inject the credentials of the adapter secret, or of the service account if the adapter has no secret
+		if adapter.SecretName != "" {
+			if err := mi.credentialBuilder.CreateSecretVolumeAndEnvFromSecret(
+				pod.Namespace,
+				adapter.SecretName,
+				pod.Spec.ServiceAccountName,
+				initContainer,
+				&volumes,
+			); err != nil {
+				return err
+			}
+		} else {
+			if err := mi.credentialBuilder.CreateSecretVolumeAndEnv(
+				pod.Namespace,
+				pod.Annotations,
+				pod.Spec.ServiceAccountName,
+				initContainer,
+				&volumes,
+			); err != nil {
+				return err
+			}
+		}
+
+		mi.injectCaBundle(pod.Namespace, initContainer, &volumes)
+
+		if verifyChecksumEnabled(pod, mi.config) {
+			initContainer.Env = append(initContainer.Env, v1.EnvVar{
+				Name:  constants.StorageVerifyChecksumEnvVarKey,
+				Value: "true",
+			})
+		}
+		initContainer.Env = append(initContainer.Env, getStorageProxyEnvs(pod, mi.config)...)
+
+		storageContainerSpec, err := GetContainerSpecForStorageUri(srcURI, mi.client)
+		if err != nil {
+			return err
+		}
+		if storageContainerSpec != nil {
+			initContainer, err = mergeContainerSpecs(initContainer, storageContainerSpec)
+			if err != nil {
+				return err
+			}
+		}
+		if err := overrideStorageInitializerResources(pod, initContainer); err != nil {
+			return err
+		}
+
+		// The volumes of the adapter which collide with different volumes of the pod, e.g. the secret volume of
+		// another gcs secret, are renamed after the adapter
+		for _, volume := range volumes {
+			if existing := getVolumeWithName(pod, volume.Name); existing != nil {
+				if reflect.DeepEqual(*existing, volume) {
+					continue
+				}
+				name := volume.Name + "-" + adapter.Name
+				for i := range initContainer.VolumeMounts {
+					if initContainer.VolumeMounts[i].Name == volume.Name {
+						initContainer.VolumeMounts[i].Name = name
+					}
+				}
+				volume.Name = name
+			}
+			pod.Spec.Volumes = append(pod.Spec.Volumes, volume)
+		}
+
+		// Mount the adapter read only on the kserve-container and the transformer container
+		adapterReadMount := v1.VolumeMount{
+			Name:      volumeName,
+			MountPath: mountPath,
+			ReadOnly:  true,
+		}
+		userContainer.VolumeMounts = append(userContainer.VolumeMounts, adapterReadMount)
+		if transformerContainer != nil {
+			transformerContainer.VolumeMounts = append(transformerContainer.VolumeMounts, adapterReadMount)
+		}
+
+		pod.Spec.InitContainers = append(pod.Spec.InitContainers, *initContainer)
+	}
+	return nil
+}
+
+// getVolumeWithName returns the volume of the pod with the name, nil if there is none
+func getVolumeWithName(pod *v1.Pod, name string) *v1.Volume {
+	for i := range pod.Spec.Volumes {
+		if pod.Spec.Volumes[i].Name == name {
+			return &pod.Spec.Volumes[i]
+		}
+	}
 	return nil
 }
 
 // SetIstioCniSecurityContext determines if Istio is installed in using the CNI plugin. If so,
-// the UserID of the storage initializer is changed to match the UserID of the Istio sidecar.
-// This is to ensure that the storage initializer can access the network.
+// the UserID of the storage initializers of the model and of its adapters is changed to match the UserID of the
+// Istio sidecar. This is to ensure that the storage initializers can access the network.
 func (mi *StorageInitializerInjector) SetIstioCniSecurityContext(pod *v1.Pod) error {
-	// Find storage initializer container
-	var storageInitializerContainer *v1.Container
+	// Find storage initializer containers
+	var storageInitializerContainers []*v1.Container
 	for idx, c := range pod.Spec.InitContainers {
-		if c.Name == StorageInitializerContainerName {
-			storageInitializerContainer = &pod.Spec.InitContainers[idx]
+		if c.Name == StorageInitializerContainerName || strings.HasPrefix(c.Name, StorageInitializerContainerName+"-") {
+			storageInitializerContainers = append(storageInitializerContainers, &pod.Spec.InitContainers[idx])
 		}
 	}
 
 	// If the storage initializer is not injected, there is no action to do
-	if storageInitializerContainer == nil {
+	if len(storageInitializerContainers) == 0 {
 		return nil
 	}
 
@@ -506,10 +814,7 @@ func (mi *StorageInitializerInjector) SetIstioCniSecurityContext(pod *v1.Pod) er
 	// See for more: https://istio.io/latest/docs/setup/additional-setup/cni/#compatibility-with-application-init-containers.
 	if value, ok := pod.GetAnnotations()[constants.IstioSidecarUIDAnnotationKey]; ok {
 		if uid, err := strconv.ParseInt(value, 10, 64); err == nil {
-			if storageInitializerContainer.SecurityContext == nil {
-				storageInitializerContainer.SecurityContext = &v1.SecurityContext{}
-			}
-			storageInitializerContainer.SecurityContext.RunAsUser = ptr.Int64(uid)
+			setRunAsUser(storageInitializerContainers, uid)
 		}
 	} else {
 		// When Istio CNI is disabled, the istio-init container would be present.
@@ -574,21 +879,18 @@ func (mi *StorageInitializerInjector) SetIstioCniSecurityContext(pod *v1.Pod) er
 			}
 		}
 
-		// Set the UserID of the storage initializer to the same as the Istio sidecar
+		// Set the UserID of the storage initializers to the same as the Istio sidecar
 		if istioSidecarContainer != nil {
-			if storageInitializerContainer.SecurityContext == nil {
-				storageInitializerContainer.SecurityContext = &v1.SecurityContext{}
-			}
+			var uid int64
 			if istioSidecarContainer.SecurityContext == nil || istioSidecarContainer.SecurityContext.RunAsUser == nil {
 				// If the Istio sidecar does not explicitly have a UID set, use 1337 which is the
 				// UID hardcoded in Istio. This would require privileges to run with AnyUID, which should
 				// be OK because, otherwise, the Istio sidecar also would not work correctly.
-				storageInitializerContainer.SecurityContext.RunAsUser = ptr.Int64(constants.DefaultIstioSidecarUID)
+				uid = constants.DefaultIstioSidecarUID
 			} else {
 				// If the Istio sidecar has a UID copy it to the storage initializer because this
 				// would be the UID that allows access the network.
-				sidecarUID := *istioSidecarContainer.SecurityContext.RunAsUser
-				storageInitializerContainer.SecurityContext.RunAsUser = ptr.Int64(sidecarUID)
+				uid = *istioSidecarContainer.SecurityContext.RunAsUser
 
 				// Notice that despite in standard Istio the 1337 UID is hardcoded, there exist
 				// other flavors, like Maistra, that allow using arbitrary UIDs on the sidecar.
@@ -597,13 +899,25 @@ func (mi *StorageInitializerInjector) SetIstioCniSecurityContext(pod *v1.Pod) er
 				// preferred over using the default UID of 1337.
 			}
 
-			log.V(1).Info("Storage initializer UID is set", "pod", pod.Name, "uid", storageInitializerContainer.SecurityContext.RunAsUser)
+			setRunAsUser(storageInitializerContainers, uid)
+
+			log.V(1).Info("Storage initializer UID is set", "pod", pod.Name, "uid", uid)
 		}
 	}
 
 	return nil
 }
 
+// setRunAsUser sets the UserID of the containers
+func setRunAsUser(containers []*v1.Container, uid int64) {
+	for _, container := range containers {
+		if container.SecurityContext == nil {
+			container.SecurityContext = &v1.SecurityContext{}
+		}
+		container.SecurityContext.RunAsUser = ptr.Int64(uid)
+	}
+}
+
 func getContainerWithName(pod *v1.Pod, name string) *v1.Container {
 	for idx, container := range pod.Spec.Containers {
 		if strings.Compare(container.Name, name) == 0 {
@@ -762,6 +1076,16 @@ func mergeContainerSpecs(defaultContainer *v1.Container, crdContainer *v1.Contai
 		mergedContainer.Name = containerName
 	}
 
+	// The env vars of the CR replace the ones with the same name as a whole, otherwise e.g. the valueFrom of a CR
+	// env var would be merged next to the value set by the default container.
+	for _, env := range crdContainer.Env {
+		for i := range mergedContainer.Env {
+			if mergedContainer.Env[i].Name == env.Name {
+				mergedContainer.Env[i] = env
+			}
+		}
+	}
+
 	return &mergedContainer, nil
 }
 
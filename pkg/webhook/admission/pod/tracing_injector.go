@@ -0,0 +1,160 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/kserve/kserve/pkg/constants"
+	"github.com/kserve/kserve/pkg/utils"
+)
+
+const (
+	ObservabilityConfigMapKeyName = "observability"
+	DefaultOTLPProtocol           = "grpc"
+	// TracesSampler samples the traces started by the containers at the sampling ratio and follows the sampling
+	// decision of the caller otherwise
+	TracesSampler = "parentbased_traceidratio"
+	// TracePropagators propagate the W3C trace context and baggage, which the inference id is carried in
+	TracePropagators = "tracecontext,baggage"
+)
+
+// OTLPProtocols are the protocols of the OpenTelemetry exporters
+var OTLPProtocols = []string{"grpc", "http/protobuf", "http/json"}
+
+// tracedContainerNames are the containers configured to export their traces
+var tracedContainerNames = []string{
+	constants.InferenceServiceContainerName,
+	constants.TransformerContainerName,
+	constants.AgentContainerName,
+}
+
+// ObservabilityConfig configures the OpenTelemetry tracing of the containers, tracing is disabled when no OTLP
+// endpoint is set
+type ObservabilityConfig struct {
+	// OTLPEndpoint is the endpoint of the collector the traces are exported to
+	OTLPEndpoint string `json:"otlpEndpoint,omitempty"`
+	// Protocol is the protocol of the OTLP exporter, one of grpc, http/protobuf and http/json
+	Protocol string `json:"protocol,omitempty"`
+	// Sampling is the ratio of the traces started by the containers which are sampled, between 0 and 1
+	Sampling *float64 `json:"sampling,omitempty"`
+}
+
+type TracingInjector struct {
+	config *ObservabilityConfig
+}
+
+func getObservabilityConfig(configMap *v1.ConfigMap) (*ObservabilityConfig, error) {
+	config := &ObservabilityConfig{}
+	if value, ok := configMap.Data[ObservabilityConfigMapKeyName]; ok {
+		if err := json.Unmarshal([]byte(value), config); err != nil {
+			return nil, fmt.Errorf("Unable to unmarshall %v json string due to %w ", ObservabilityConfigMapKeyName, err)
+		}
+	}
+	if config.Protocol == "" {
+		config.Protocol = DefaultOTLPProtocol
+	}
+	if !utils.Includes(OTLPProtocols, config.Protocol) {
+		return nil, fmt.Errorf("invalid %s protocol %q, must be one of %v", ObservabilityConfigMapKeyName,
+			config.Protocol, OTLPProtocols)
+	}
+	if config.Sampling != nil && (*config.Sampling < 0 || *config.Sampling > 1) {
+		return nil, fmt.Errorf("invalid %s sampling %v, must be between 0 and 1", ObservabilityConfigMapKeyName,
+			*config.Sampling)
+	}
+	return config, nil
+}
+
+func newTracingInjector(configMap *v1.ConfigMap) (*TracingInjector, error) {
+	config, err := getObservabilityConfig(configMap)
+	if err != nil {
+		return nil, err
+	}
+	return &TracingInjector{config: config}, nil
+}
+
+// InjectTracing sets the OpenTelemetry environment of the kserve containers and of the agent, so that the traces
+// span the components of the inference service. The pod overrides the endpoint and the sampling ratio with the
+// tracing annotations and opts out of tracing with the tracing annotation set to "false". The environment variables
+// already set on the containers are kept.
+func (ti *TracingInjector) InjectTracing(pod *v1.Pod) error {
+	annotations := pod.ObjectMeta.Annotations
+	if annotations[constants.TracingAnnotationKey] == "false" {
+		return nil
+	}
+	endpoint := ti.config.OTLPEndpoint
+	if value, ok := annotations[constants.TracingOTLPEndpointAnnotationKey]; ok {
+		endpoint = value
+	}
+	if endpoint == "" {
+		return nil
+	}
+	samplingRatio := "1"
+	if ti.config.Sampling != nil {
+		samplingRatio = strconv.FormatFloat(*ti.config.Sampling, 'f', -1, 64)
+	}
+	if value, ok := annotations[constants.TracingSamplingRatioAnnotationKey]; ok {
+		ratio, err := strconv.ParseFloat(value, 64)
+		if err != nil || ratio < 0 || ratio > 1 {
+			return fmt.Errorf("invalid %s annotation %q, must be a number between 0 and 1",
+				constants.TracingSamplingRatioAnnotationKey, value)
+		}
+		samplingRatio = value
+	}
+
+	isvcName := pod.ObjectMeta.Labels[constants.InferenceServicePodLabelKey]
+	podComponent := pod.ObjectMeta.Labels[constants.KServiceComponentLabel]
+	for i := range pod.Spec.Containers {
+		container := &pod.Spec.Containers[i]
+		if !utils.Includes(tracedContainerNames, container.Name) {
+			continue
+		}
+		component := podComponent
+		if container.Name == constants.TransformerContainerName {
+			component = string(constants.Transformer)
+		}
+		container.Env = utils.AppendEnvVarIfNotExists(container.Env,
+			v1.EnvVar{Name: constants.OtelExporterOTLPEndpointEnvVarKey, Value: endpoint},
+			v1.EnvVar{Name: constants.OtelExporterOTLPProtocolEnvVarKey, Value: ti.config.Protocol},
+			v1.EnvVar{Name: constants.OtelTracesSamplerEnvVarKey, Value: TracesSampler},
+			v1.EnvVar{Name: constants.OtelTracesSamplerArgEnvVarKey, Value: samplingRatio},
+			v1.EnvVar{Name: constants.OtelPropagatorsEnvVarKey, Value: TracePropagators},
+			v1.EnvVar{Name: constants.OtelServiceNameEnvVarKey, Value: tracingServiceName(isvcName, component)},
+			v1.EnvVar{Name: constants.OtelResourceAttributesEnvVarKey, Value: strings.Join([]string{
+				"service.namespace=" + pod.Namespace,
+				"k8s.namespace.name=" + pod.Namespace,
+				"k8s.container.name=" + container.Name,
+				"kserve.inferenceservice=" + isvcName,
+				"kserve.component=" + component,
+			}, ",")},
+		)
+	}
+	return nil
+}
+
+// tracingServiceName is the service name of the traces of a component, e.g. sklearn-iris-predictor
+func tracingServiceName(isvcName string, component string) string {
+	if component == "" {
+		return isvcName
+	}
+	return isvcName + "-" + component
+}
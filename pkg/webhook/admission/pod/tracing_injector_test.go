@@ -0,0 +1,201 @@
+/*
+Copyright 2024 The KServe Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"testing"
+
+	"github.com/onsi/gomega"
+	"google.golang.org/protobuf/proto"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kserve/kserve/pkg/constants"
+)
+
+func makeTracingTestPod(annotations map[string]string, containers ...v1.Container) *v1.Pod {
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "sklearn-iris-predictor",
+			Namespace: "default",
+			Labels: map[string]string{
+				constants.InferenceServicePodLabelKey: "sklearn-iris",
+				constants.KServiceComponentLabel:      string(constants.Predictor),
+			},
+			Annotations: annotations,
+		},
+		Spec: v1.PodSpec{Containers: containers},
+	}
+}
+
+func tracingEnv(endpoint string, protocol string, samplingRatio string, component string, container string) []v1.EnvVar {
+	return []v1.EnvVar{
+		{Name: constants.OtelExporterOTLPEndpointEnvVarKey, Value: endpoint},
+		{Name: constants.OtelExporterOTLPProtocolEnvVarKey, Value: protocol},
+		{Name: constants.OtelTracesSamplerEnvVarKey, Value: TracesSampler},
+		{Name: constants.OtelTracesSamplerArgEnvVarKey, Value: samplingRatio},
+		{Name: constants.OtelPropagatorsEnvVarKey, Value: TracePropagators},
+		{Name: constants.OtelServiceNameEnvVarKey, Value: "sklearn-iris-" + component},
+		{Name: constants.OtelResourceAttributesEnvVarKey, Value: "service.namespace=default,k8s.namespace.name=default," +
+			"k8s.container.name=" + container + ",kserve.inferenceservice=sklearn-iris,kserve.component=" + component},
+	}
+}
+
+func withoutEnv(envs []v1.EnvVar, name string) []v1.EnvVar {
+	result := []v1.EnvVar{}
+	for _, env := range envs {
+		if env.Name != name {
+			result = append(result, env)
+		}
+	}
+	return result
+}
+
+func TestGetObservabilityConfig(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	scenarios := map[string]struct {
+		data     string
+		expected *ObservabilityConfig
+		valid    bool
+	}{
+		"NotConfigured": {
+			expected: &ObservabilityConfig{Protocol: DefaultOTLPProtocol},
+			valid:    true,
+		},
+		"Configured": {
+			data:     `{"otlpEndpoint": "http://otel-collector:4318", "protocol": "http/protobuf", "sampling": 0.25}`,
+			expected: &ObservabilityConfig{OTLPEndpoint: "http://otel-collector:4318", Protocol: "http/protobuf", Sampling: proto.Float64(0.25)},
+			valid:    true,
+		},
+		"InvalidJSON":     {data: `{"otlpEndpoint": `},
+		"InvalidProtocol": {data: `{"otlpEndpoint": "http://otel-collector:4317", "protocol": "zipkin"}`},
+		"InvalidSampling": {data: `{"otlpEndpoint": "http://otel-collector:4317", "sampling": 2}`},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			configMap := &v1.ConfigMap{Data: map[string]string{}}
+			if scenario.data != "" {
+				configMap.Data[ObservabilityConfigMapKeyName] = scenario.data
+			}
+			config, err := getObservabilityConfig(configMap)
+			if !scenario.valid {
+				g.Expect(err).Should(gomega.HaveOccurred())
+				return
+			}
+			g.Expect(err).Should(gomega.Succeed())
+			g.Expect(config).Should(gomega.Equal(scenario.expected))
+		})
+	}
+}
+
+func TestInjectTracing(t *testing.T) {
+	const endpoint = "http://otel-collector.observability:4317"
+	config := &ObservabilityConfig{OTLPEndpoint: endpoint, Protocol: DefaultOTLPProtocol, Sampling: proto.Float64(0.1)}
+	scenarios := map[string]struct {
+		config   *ObservabilityConfig
+		original *v1.Pod
+		expected map[string][]v1.EnvVar
+		valid    bool
+	}{
+		"InjectsKServeContainers": {
+			config: config,
+			original: makeTracingTestPod(nil,
+				v1.Container{Name: constants.InferenceServiceContainerName},
+				v1.Container{Name: constants.TransformerContainerName},
+				v1.Container{Name: constants.AgentContainerName},
+				v1.Container{Name: "queue-proxy"},
+			),
+			expected: map[string][]v1.EnvVar{
+				constants.InferenceServiceContainerName: tracingEnv(endpoint, "grpc", "0.1", "predictor", constants.InferenceServiceContainerName),
+				constants.TransformerContainerName:      tracingEnv(endpoint, "grpc", "0.1", "transformer", constants.TransformerContainerName),
+				constants.AgentContainerName:            tracingEnv(endpoint, "grpc", "0.1", "predictor", constants.AgentContainerName),
+				"queue-proxy":                           nil,
+			},
+			valid: true,
+		},
+		"KeepsUserEnv": {
+			config: config,
+			original: makeTracingTestPod(nil, v1.Container{
+				Name: constants.InferenceServiceContainerName,
+				Env:  []v1.EnvVar{{Name: constants.OtelServiceNameEnvVarKey, Value: "my-model"}},
+			}),
+			expected: map[string][]v1.EnvVar{
+				constants.InferenceServiceContainerName: append([]v1.EnvVar{{Name: constants.OtelServiceNameEnvVarKey, Value: "my-model"}},
+					withoutEnv(tracingEnv(endpoint, "grpc", "0.1", "predictor", constants.InferenceServiceContainerName), constants.OtelServiceNameEnvVarKey)...),
+			},
+			valid: true,
+		},
+		"AnnotationOverrides": {
+			config: config,
+			original: makeTracingTestPod(map[string]string{
+				constants.TracingOTLPEndpointAnnotationKey:  "http://team-collector:4317",
+				constants.TracingSamplingRatioAnnotationKey: "1",
+			}, v1.Container{Name: constants.InferenceServiceContainerName}),
+			expected: map[string][]v1.EnvVar{
+				constants.InferenceServiceContainerName: tracingEnv("http://team-collector:4317", "grpc", "1", "predictor", constants.InferenceServiceContainerName),
+			},
+			valid: true,
+		},
+		"AnnotationEnablesTracing": {
+			config: &ObservabilityConfig{Protocol: DefaultOTLPProtocol},
+			original: makeTracingTestPod(map[string]string{constants.TracingOTLPEndpointAnnotationKey: endpoint},
+				v1.Container{Name: constants.InferenceServiceContainerName}),
+			expected: map[string][]v1.EnvVar{
+				constants.InferenceServiceContainerName: tracingEnv(endpoint, "grpc", "1", "predictor", constants.InferenceServiceContainerName),
+			},
+			valid: true,
+		},
+		"OptOut": {
+			config: config,
+			original: makeTracingTestPod(map[string]string{constants.TracingAnnotationKey: "false"},
+				v1.Container{Name: constants.InferenceServiceContainerName}),
+			expected: map[string][]v1.EnvVar{constants.InferenceServiceContainerName: nil},
+			valid:    true,
+		},
+		"NotConfigured": {
+			config:   &ObservabilityConfig{Protocol: DefaultOTLPProtocol},
+			original: makeTracingTestPod(nil, v1.Container{Name: constants.InferenceServiceContainerName}),
+			expected: map[string][]v1.EnvVar{constants.InferenceServiceContainerName: nil},
+			valid:    true,
+		},
+		"InvalidSamplingRatio": {
+			config: config,
+			original: makeTracingTestPod(map[string]string{constants.TracingSamplingRatioAnnotationKey: "all"},
+				v1.Container{Name: constants.InferenceServiceContainerName}),
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			injector := &TracingInjector{config: scenario.config}
+			err := injector.InjectTracing(scenario.original)
+			if !scenario.valid {
+				g.Expect(err).Should(gomega.HaveOccurred())
+				return
+			}
+			g.Expect(err).Should(gomega.Succeed())
+			for _, container := range scenario.original.Spec.Containers {
+				g.Expect(container.Env).Should(gomega.Equal(scenario.expected[container.Name]), container.Name)
+			}
+
+			// the mutator is reinvoked when other webhooks mutate the pod
+			reinvoked := scenario.original.DeepCopy()
+			g.Expect(injector.InjectTracing(reinvoked)).Should(gomega.Succeed())
+			g.Expect(reinvoked).Should(gomega.Equal(scenario.original))
+		})
+	}
+}
@@ -18,11 +18,14 @@ package servingruntime
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"strings"
 
 	"github.com/kserve/kserve/pkg/constants"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
@@ -34,20 +37,28 @@ import (
 var log = logf.Log.WithName(constants.ServingRuntimeValidatorWebhookName)
 
 const (
-	InvalidPriorityError               = "Same priority assigned for the model format %s"
-	InvalidPriorityServingRuntimeError = "%s in the servingruntimes %s and %s in namespace %s"
-	// InvalidPriorityClusterServingRuntimeError  = "%s in the clusterservingruntimes %s and %s"
+	InvalidPriorityError                       = "Same priority assigned for the model format %s"
+	InvalidPriorityServingRuntimeError         = "%s in the servingruntimes %s and %s in namespace %s"
+	InvalidPriorityClusterServingRuntimeError  = "%s in the clusterservingruntimes %s and %s"
 	ProrityIsNotSameError                      = "Different priorities assigned for the model format %s"
 	ProrityIsNotSameServingRuntimeError        = "%s under the servingruntime %s"
 	ProrityIsNotSameClusterServingRuntimeError = "%s under the clusterservingruntime %s"
+	InvalidServingRuntimeSpecError             = "%s in the servingruntime %s"
+	InvalidClusterServingRuntimeSpecError      = "%s in the clusterservingruntime %s"
+	NoContainerError                           = "No container defined"
+	MissingKServeContainerError                = "No container named %s defined for the single model runtime"
+	DuplicateContainerPortError                = "Port %d/%s is declared more than once in the container %s"
+	ConflictingContainerPortError              = "Port %d/%s is declared by both the containers %s and %s"
+	DuplicateEnvError                          = "Environment variable %s is declared more than once in the container %s"
+	InheritFromNotSupportedError               = "inheritFrom is only supported by namespaced servingruntimes"
 )
 
-// // kubebuilder:webhook:verbs=create;update,path=/validate-serving-kserve-io-v1alpha1-clusterservingruntime,mutating=false,failurePolicy=fail,groups=serving.kserve.io,resources=clusterservingruntimes,versions=v1alpha1,name=clusterservingruntime.kserve-webhook-server.validator
-//
-// type ClusterServingRuntimeValidator struct {
-//	 Client  client.Client
-//	 Decoder *admission.Decoder
-// }
+// +kubebuilder:webhook:verbs=create;update,path=/validate-serving-kserve-io-v1alpha1-clusterservingruntime,mutating=false,failurePolicy=fail,groups=serving.kserve.io,resources=clusterservingruntimes,versions=v1alpha1,name=clusterservingruntime.kserve-webhook-server.validator
+
+type ClusterServingRuntimeValidator struct {
+	Client  client.Client
+	Decoder *admission.Decoder
+}
 
 // +kubebuilder:webhook:verbs=create;update,path=/validate-serving-kserve-io-v1alpha1-servingruntime,mutating=false,failurePolicy=fail,groups=serving.kserve.io,resources=servingruntimes,versions=v1alpha1,name=servingruntime.kserve-webhook-server.validator
 
@@ -69,52 +80,80 @@ func (sr *ServingRuntimeValidator) Handle(ctx context.Context, req admission.Req
 		return admission.Errored(http.StatusInternalServerError, err)
 	}
 
+	// Validate the effective spec of runtimes inheriting from a ClusterServingRuntime
+	resolved, err := v1alpha1.ResolveServingRuntime(sr.Client, servingRuntime)
+	if err != nil {
+		if _, ok := err.(apierrors.APIStatus); ok {
+			log.Error(err, "Failed to get the inherited cluster serving runtime", "name", servingRuntime.Name, "namespace", servingRuntime.Namespace)
+			return admission.Errored(http.StatusInternalServerError, err)
+		}
+		return admission.Denied(fmt.Sprintf(InvalidServingRuntimeSpecError, err.Error(), servingRuntime.Name))
+	}
+	spec := &resolved.Spec
+
+	if err := validateServingRuntimeSpec(spec); err != nil {
+		return admission.Denied(fmt.Sprintf(InvalidServingRuntimeSpecError, err.Error(), servingRuntime.Name))
+	}
+
 	// Only validate for priority if the new serving runtime is not disabled
-	if servingRuntime.Spec.IsDisabled() {
+	if spec.IsDisabled() {
 		return admission.Allowed("")
 	}
 
+	if err := validateModelFormatPrioritySame(spec); err != nil {
+		return admission.Denied(fmt.Sprintf(ProrityIsNotSameServingRuntimeError, err.Error(), servingRuntime.Name))
+	}
+
 	for i := range ExistingRuntimes.Items {
-		if err := validateModelFormatPrioritySame(&servingRuntime.Spec); err != nil {
-			return admission.Denied(fmt.Sprintf(ProrityIsNotSameServingRuntimeError, err.Error(), servingRuntime.Name))
+		existingSpec := &ExistingRuntimes.Items[i].Spec
+		if existing, err := v1alpha1.ResolveServingRuntime(sr.Client, &ExistingRuntimes.Items[i]); err == nil {
+			existingSpec = &existing.Spec
 		}
-
-		if err := validateServingRuntimePriority(&servingRuntime.Spec, &ExistingRuntimes.Items[i].Spec, servingRuntime.Name, ExistingRuntimes.Items[i].Name); err != nil {
+		if err := validateServingRuntimePriority(spec, existingSpec, servingRuntime.Name, ExistingRuntimes.Items[i].Name); err != nil {
 			return admission.Denied(fmt.Sprintf(InvalidPriorityServingRuntimeError, err.Error(), ExistingRuntimes.Items[i].Name, servingRuntime.Name, servingRuntime.Namespace))
 		}
 	}
 	return admission.Allowed("")
 }
 
-// // Handle validates the incoming request
-// func (csr *ClusterServingRuntimeValidator) Handle(ctx context.Context, req admission.Request) admission.Response {
-// 	clusterServingRuntime := &v1alpha1.ClusterServingRuntime{}
-// 	if err := csr.Decoder.Decode(req, clusterServingRuntime); err != nil {
-//		log.Error(err, "Failed to decode cluster serving runtime", "name", clusterServingRuntime.Name)
-//		return admission.Errored(http.StatusBadRequest, err)
-//	}
-//
-//	ExistingRuntimes := &v1alpha1.ClusterServingRuntimeList{}
-//	if err := csr.Client.List(context.TODO(), ExistingRuntimes); err != nil {
-//		log.Error(err, "Failed to get cluster serving runtime list")
-//		return admission.Errored(http.StatusInternalServerError, err)
-//	}
-//
-//	// Only validate for priority if the new cluster serving runtime is not disabled
-//	if clusterServingRuntime.Spec.IsDisabled() {
-//		return admission.Allowed("")
-//	}
-//
-//	for i := range ExistingRuntimes.Items {
-//		if err := validateModelFormatPrioritySame(&clusterServingRuntime.Spec, clusterServingRuntime.Name); err != nil {
-//			return admission.Denied(fmt.Sprintf(ProrityIsNotSameClusterServingRuntimeError, err.Error(), clusterServingRuntime.Name))
-//		}
-//		if err := validateServingRuntimePriority(&clusterServingRuntime.Spec, &ExistingRuntimes.Items[i].Spec, clusterServingRuntime.Name, ExistingRuntimes.Items[i].Name); err != nil {
-//			return admission.Denied(fmt.Sprintf(InvalidPriorityClusterServingRuntimeError, err.Error(), ExistingRuntimes.Items[i].Name, clusterServingRuntime.Name))
-//		}
-//	}
-//	return admission.Allowed("")
-// }
+// Handle validates the incoming request
+func (csr *ClusterServingRuntimeValidator) Handle(ctx context.Context, req admission.Request) admission.Response {
+	clusterServingRuntime := &v1alpha1.ClusterServingRuntime{}
+	if err := csr.Decoder.Decode(req, clusterServingRuntime); err != nil {
+		log.Error(err, "Failed to decode cluster serving runtime", "name", clusterServingRuntime.Name)
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+
+	ExistingRuntimes := &v1alpha1.ClusterServingRuntimeList{}
+	if err := csr.Client.List(context.TODO(), ExistingRuntimes); err != nil {
+		log.Error(err, "Failed to get cluster serving runtime list")
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+
+	if clusterServingRuntime.Spec.InheritFrom != nil {
+		return admission.Denied(fmt.Sprintf(InvalidClusterServingRuntimeSpecError, InheritFromNotSupportedError, clusterServingRuntime.Name))
+	}
+
+	if err := validateServingRuntimeSpec(&clusterServingRuntime.Spec); err != nil {
+		return admission.Denied(fmt.Sprintf(InvalidClusterServingRuntimeSpecError, err.Error(), clusterServingRuntime.Name))
+	}
+
+	// Only validate for priority if the new cluster serving runtime is not disabled
+	if clusterServingRuntime.Spec.IsDisabled() {
+		return admission.Allowed("")
+	}
+
+	if err := validateModelFormatPrioritySame(&clusterServingRuntime.Spec); err != nil {
+		return admission.Denied(fmt.Sprintf(ProrityIsNotSameClusterServingRuntimeError, err.Error(), clusterServingRuntime.Name))
+	}
+
+	for i := range ExistingRuntimes.Items {
+		if err := validateServingRuntimePriority(&clusterServingRuntime.Spec, &ExistingRuntimes.Items[i].Spec, clusterServingRuntime.Name, ExistingRuntimes.Items[i].Name); err != nil {
+			return admission.Denied(fmt.Sprintf(InvalidPriorityClusterServingRuntimeError, err.Error(), ExistingRuntimes.Items[i].Name, clusterServingRuntime.Name))
+		}
+	}
+	return admission.Allowed("")
+}
 
 func areSupportedModelFormatsEqual(m1 v1alpha1.SupportedModelFormat, m2 v1alpha1.SupportedModelFormat) bool {
 	if strings.EqualFold(m1.Name, m2.Name) && ((m1.Version == nil && m2.Version == nil) ||
@@ -144,6 +183,53 @@ func validateModelFormatPrioritySame(newSpec *v1alpha1.ServingRuntimeSpec) error
 	return nil
 }
 
+// validateServingRuntimeSpec checks the containers of a runtime: single model runtimes need a kserve-container, and
+// the container ports and the environment variables of each container must be unique.
+func validateServingRuntimeSpec(spec *v1alpha1.ServingRuntimeSpec) error {
+	if len(spec.Containers) == 0 {
+		return errors.New(NoContainerError)
+	}
+	if !spec.IsMultiModelRuntime() && !contains(containerNames(spec.Containers), constants.InferenceServiceContainerName) {
+		return fmt.Errorf(MissingKServeContainerError, constants.InferenceServiceContainerName)
+	}
+	type portKey struct {
+		port     int32
+		protocol corev1.Protocol
+	}
+	portOwners := make(map[portKey]string)
+	for _, container := range spec.Containers {
+		for _, port := range container.Ports {
+			key := portKey{port: port.ContainerPort, protocol: port.Protocol}
+			if key.protocol == "" {
+				key.protocol = corev1.ProtocolTCP
+			}
+			if owner, ok := portOwners[key]; ok {
+				if owner == container.Name {
+					return fmt.Errorf(DuplicateContainerPortError, key.port, key.protocol, container.Name)
+				}
+				return fmt.Errorf(ConflictingContainerPortError, key.port, key.protocol, owner, container.Name)
+			}
+			portOwners[key] = container.Name
+		}
+		envNames := make(map[string]bool, len(container.Env))
+		for _, env := range container.Env {
+			if envNames[env.Name] {
+				return fmt.Errorf(DuplicateEnvError, env.Name, container.Name)
+			}
+			envNames[env.Name] = true
+		}
+	}
+	return nil
+}
+
+func containerNames(containers []corev1.Container) []string {
+	names := make([]string, 0, len(containers))
+	for _, container := range containers {
+		names = append(names, container.Name)
+	}
+	return names
+}
+
 func validateServingRuntimePriority(newSpec *v1alpha1.ServingRuntimeSpec, existingSpec *v1alpha1.ServingRuntimeSpec, existingRuntimeName string, newRuntimeName string) error {
 	// Skip the runtime if it is disabled or both are not multi model runtime and in update scenario skip the existing runtime if it is same as the new runtime
 	if (newSpec.IsMultiModelRuntime() != existingSpec.IsMultiModelRuntime()) || (existingSpec.IsDisabled()) || (existingRuntimeName == newRuntimeName) {
@@ -17,13 +17,20 @@ limitations under the License.
 package servingruntime
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/kserve/kserve/pkg/apis/serving/v1alpha1"
 	"github.com/kserve/kserve/pkg/constants"
 	"github.com/onsi/gomega"
 	"google.golang.org/protobuf/proto"
+	admissionv1 "k8s.io/api/admission/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 	"testing"
 )
 
@@ -1447,3 +1454,200 @@ func TestValidateModelFormatPrioritySame(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateServingRuntimeSpec(t *testing.T) {
+	kserveContainer := func(ports []corev1.ContainerPort, env []corev1.EnvVar) corev1.Container {
+		return corev1.Container{
+			Name:  constants.InferenceServiceContainerName,
+			Image: "kserve/sklearnserver:latest",
+			Ports: ports,
+			Env:   env,
+		}
+	}
+	scenarios := map[string]struct {
+		spec     v1alpha1.ServingRuntimeSpec
+		expected gomega.OmegaMatcher
+	}{
+		"When the runtime has no container then it should return error": {
+			spec:     v1alpha1.ServingRuntimeSpec{},
+			expected: gomega.Equal(errors.New(NoContainerError)),
+		},
+		"When the single model runtime has no kserve-container then it should return error": {
+			spec: v1alpha1.ServingRuntimeSpec{
+				ServingRuntimePodSpec: v1alpha1.ServingRuntimePodSpec{
+					Containers: []corev1.Container{{Name: "sklearn", Image: "kserve/sklearnserver:latest"}},
+				},
+			},
+			expected: gomega.Equal(fmt.Errorf(MissingKServeContainerError, constants.InferenceServiceContainerName)),
+		},
+		"When the multi model runtime has no kserve-container then it should return nil": {
+			spec: v1alpha1.ServingRuntimeSpec{
+				MultiModel: proto.Bool(true),
+				ServingRuntimePodSpec: v1alpha1.ServingRuntimePodSpec{
+					Containers: []corev1.Container{{Name: "mlserver", Image: "seldonio/mlserver:1.3.2"}},
+				},
+			},
+			expected: gomega.BeNil(),
+		},
+		"When the grpc port is the http port then it should return error": {
+			spec: v1alpha1.ServingRuntimeSpec{
+				ServingRuntimePodSpec: v1alpha1.ServingRuntimePodSpec{
+					Containers: []corev1.Container{
+						kserveContainer([]corev1.ContainerPort{
+							{Name: "http1", ContainerPort: 8080},
+							{Name: "h2c", ContainerPort: 8080, Protocol: corev1.ProtocolTCP},
+						}, nil),
+					},
+				},
+			},
+			expected: gomega.Equal(fmt.Errorf(DuplicateContainerPortError, 8080, corev1.ProtocolTCP, constants.InferenceServiceContainerName)),
+		},
+		"When two containers claim the same port then it should return error": {
+			spec: v1alpha1.ServingRuntimeSpec{
+				ServingRuntimePodSpec: v1alpha1.ServingRuntimePodSpec{
+					Containers: []corev1.Container{
+						kserveContainer([]corev1.ContainerPort{{ContainerPort: 8080}}, nil),
+						{Name: "sidecar", Image: "sidecar:latest", Ports: []corev1.ContainerPort{{ContainerPort: 8080}}},
+					},
+				},
+			},
+			expected: gomega.Equal(fmt.Errorf(ConflictingContainerPortError, 8080, corev1.ProtocolTCP, constants.InferenceServiceContainerName, "sidecar")),
+		},
+		"When the same port is used with different protocols then it should return nil": {
+			spec: v1alpha1.ServingRuntimeSpec{
+				ServingRuntimePodSpec: v1alpha1.ServingRuntimePodSpec{
+					Containers: []corev1.Container{
+						kserveContainer([]corev1.ContainerPort{
+							{ContainerPort: 8080},
+							{ContainerPort: 8080, Protocol: corev1.ProtocolUDP},
+						}, nil),
+					},
+				},
+			},
+			expected: gomega.BeNil(),
+		},
+		"When an environment variable is declared twice then it should return error": {
+			spec: v1alpha1.ServingRuntimeSpec{
+				ServingRuntimePodSpec: v1alpha1.ServingRuntimePodSpec{
+					Containers: []corev1.Container{
+						kserveContainer(nil, []corev1.EnvVar{
+							{Name: "MODELS_DIR", Value: "/mnt/models"},
+							{Name: "MODELS_DIR", Value: "/mnt/data"},
+						}),
+					},
+				},
+			},
+			expected: gomega.Equal(fmt.Errorf(DuplicateEnvError, "MODELS_DIR", constants.InferenceServiceContainerName)),
+		},
+		"When the runtime is valid then it should return nil": {
+			spec: v1alpha1.ServingRuntimeSpec{
+				ServingRuntimePodSpec: v1alpha1.ServingRuntimePodSpec{
+					Containers: []corev1.Container{
+						kserveContainer([]corev1.ContainerPort{
+							{Name: "http1", ContainerPort: 8080},
+							{Name: "h2c", ContainerPort: 8081},
+						}, []corev1.EnvVar{{Name: "MODELS_DIR", Value: "/mnt/models"}}),
+						{Name: "sidecar", Image: "sidecar:latest", Env: []corev1.EnvVar{{Name: "MODELS_DIR", Value: "/mnt/models"}}},
+					},
+				},
+			},
+			expected: gomega.BeNil(),
+		},
+	}
+
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			err := validateServingRuntimeSpec(&scenario.spec)
+			g.Expect(err).To(scenario.expected)
+		})
+	}
+}
+
+func TestServingRuntimeValidatorInheritFrom(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+	s := runtime.NewScheme()
+	g.Expect(v1alpha1.AddToScheme(s)).To(gomega.Succeed())
+	clusterRuntime := &v1alpha1.ClusterServingRuntime{
+		ObjectMeta: metav1.ObjectMeta{Name: "kserve-sklearnserver"},
+		Spec: v1alpha1.ServingRuntimeSpec{
+			ServingRuntimePodSpec: v1alpha1.ServingRuntimePodSpec{
+				Containers: []corev1.Container{
+					{
+						Name:  constants.InferenceServiceContainerName,
+						Image: "kserve/sklearnserver:latest",
+						Ports: []corev1.ContainerPort{{ContainerPort: 8080}},
+					},
+				},
+			},
+		},
+	}
+	mockClient := fake.NewClientBuilder().WithScheme(s).WithObjects(clusterRuntime).Build()
+	newRequest := func(obj runtime.Object) admission.Request {
+		raw, err := json.Marshal(obj)
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+		return admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+			Operation: admissionv1.Create,
+			Object:    runtime.RawExtension{Raw: raw},
+		}}
+	}
+	newServingRuntime := func(inheritFrom string, containers []corev1.Container) *v1alpha1.ServingRuntime {
+		return &v1alpha1.ServingRuntime{
+			ObjectMeta: metav1.ObjectMeta{Name: "sklearn-debug", Namespace: "test"},
+			Spec: v1alpha1.ServingRuntimeSpec{
+				InheritFrom:           proto.String(inheritFrom),
+				ServingRuntimePodSpec: v1alpha1.ServingRuntimePodSpec{Containers: containers},
+			},
+		}
+	}
+
+	scenarios := map[string]struct {
+		request  admission.Request
+		cluster  bool
+		allowed  bool
+		expected string
+	}{
+		"Override of an existing ClusterServingRuntime is allowed": {
+			request: newRequest(newServingRuntime("kserve-sklearnserver", []corev1.Container{
+				{Name: constants.InferenceServiceContainerName, Image: "kserve/sklearnserver:debug"},
+			})),
+			allowed: true,
+		},
+		"Missing ClusterServingRuntime is denied": {
+			request:  newRequest(newServingRuntime("kserve-missing", nil)),
+			expected: fmt.Sprintf(InvalidServingRuntimeSpecError, fmt.Sprintf(v1alpha1.InheritFromNotFoundError, "kserve-missing", "sklearn-debug"), "sklearn-debug"),
+		},
+		"Effective spec is validated": {
+			request: newRequest(newServingRuntime("kserve-sklearnserver", []corev1.Container{
+				{Name: "sidecar", Image: "sidecar:latest", Ports: []corev1.ContainerPort{{ContainerPort: 8080}}},
+			})),
+			expected: fmt.Sprintf(InvalidServingRuntimeSpecError,
+				fmt.Sprintf(ConflictingContainerPortError, 8080, corev1.ProtocolTCP, "sidecar", constants.InferenceServiceContainerName), "sklearn-debug"),
+		},
+		"ClusterServingRuntime with inheritFrom is denied": {
+			request: newRequest(&v1alpha1.ClusterServingRuntime{
+				ObjectMeta: metav1.ObjectMeta{Name: "sklearn-chained"},
+				Spec:       v1alpha1.ServingRuntimeSpec{InheritFrom: proto.String("kserve-sklearnserver")},
+			}),
+			cluster:  true,
+			expected: fmt.Sprintf(InvalidClusterServingRuntimeSpecError, InheritFromNotSupportedError, "sklearn-chained"),
+		},
+	}
+	for name, scenario := range scenarios {
+		t.Run(name, func(t *testing.T) {
+			g := gomega.NewGomegaWithT(t)
+			var response admission.Response
+			if scenario.cluster {
+				validator := &ClusterServingRuntimeValidator{Client: mockClient, Decoder: admission.NewDecoder(s)}
+				response = validator.Handle(context.TODO(), scenario.request)
+			} else {
+				validator := &ServingRuntimeValidator{Client: mockClient, Decoder: admission.NewDecoder(s)}
+				response = validator.Handle(context.TODO(), scenario.request)
+			}
+			g.Expect(response.Allowed).To(gomega.Equal(scenario.allowed))
+			if !scenario.allowed {
+				g.Expect(response.Result.Message).To(gomega.Equal(scenario.expected))
+			}
+		})
+	}
+}
@@ -42,18 +42,27 @@ from .models.v1alpha1_cluster_serving_runtime_list import (
     V1alpha1ClusterServingRuntimeList,
 )
 from .models.v1alpha1_container import V1alpha1Container
+from .models.v1alpha1_ensemble_merge import V1alpha1EnsembleMerge
 from .models.v1alpha1_inference_graph import V1alpha1InferenceGraph
+from .models.v1alpha1_inference_graph_cache import V1alpha1InferenceGraphCache
 from .models.v1alpha1_inference_graph_list import V1alpha1InferenceGraphList
+from .models.v1alpha1_inference_graph_logger import V1alpha1InferenceGraphLogger
 from .models.v1alpha1_inference_graph_spec import V1alpha1InferenceGraphSpec
 from .models.v1alpha1_inference_graph_status import V1alpha1InferenceGraphStatus
 from .models.v1alpha1_inference_router import V1alpha1InferenceRouter
 from .models.v1alpha1_inference_step import V1alpha1InferenceStep
+from .models.v1alpha1_inference_step_cache import V1alpha1InferenceStepCache
+from .models.v1alpha1_inference_step_circuit_breaker import V1alpha1InferenceStepCircuitBreaker
+from .models.v1alpha1_inference_step_retry import V1alpha1InferenceStepRetry
 from .models.v1alpha1_inference_target import V1alpha1InferenceTarget
 from .models.v1alpha1_model_spec import V1alpha1ModelSpec
+from .models.v1alpha1_redis_cache import V1alpha1RedisCache
+from .models.v1alpha1_redis_cache_tls import V1alpha1RedisCacheTLS
 from .models.v1alpha1_serving_runtime import V1alpha1ServingRuntime
 from .models.v1alpha1_serving_runtime_list import V1alpha1ServingRuntimeList
 from .models.v1alpha1_serving_runtime_pod_spec import V1alpha1ServingRuntimePodSpec
 from .models.v1alpha1_serving_runtime_spec import V1alpha1ServingRuntimeSpec
+from .models.v1alpha1_splitter_stickiness import V1alpha1SplitterStickiness
 from .models.v1alpha1_storage_helper import V1alpha1StorageHelper
 from .models.v1alpha1_supported_model_format import V1alpha1SupportedModelFormat
 from .models.v1alpha1_trained_model import V1alpha1TrainedModel
@@ -67,6 +76,7 @@ from .models.knative_url import KnativeURL
 from .models.knative_volatile_time import KnativeVolatileTime
 from .models.net_url_userinfo import NetUrlUserinfo
 from .models.v1beta1_art_explainer_spec import V1beta1ARTExplainerSpec
+from .models.v1beta1_artifact_status import V1beta1ArtifactStatus
 from .models.v1beta1_batcher import V1beta1Batcher
 from .models.v1beta1_component_extension_spec import V1beta1ComponentExtensionSpec
 from .models.v1beta1_component_status_spec import V1beta1ComponentStatusSpec
@@ -86,6 +96,7 @@ from .models.v1beta1_inference_services_config import V1beta1InferenceServicesCo
 from .models.v1beta1_ingress_config import V1beta1IngressConfig
 from .models.v1beta1_light_gbm_spec import V1beta1LightGBMSpec
 from .models.v1beta1_logger_spec import V1beta1LoggerSpec
+from .models.v1beta1_model_adapter_spec import V1beta1ModelAdapterSpec
 from .models.v1beta1_model_format import V1beta1ModelFormat
 from .models.v1beta1_model_spec import V1beta1ModelSpec
 from .models.v1beta1_onnx_runtime_spec import V1beta1ONNXRuntimeSpec
@@ -97,6 +108,7 @@ from .models.v1beta1_predictor_extension_spec import V1beta1PredictorExtensionSp
 from .models.v1beta1_predictor_protocols import V1beta1PredictorProtocols
 from .models.v1beta1_predictor_spec import V1beta1PredictorSpec
 from .models.v1beta1_predictors_config import V1beta1PredictorsConfig
+from .models.v1beta1_retry_spec import V1beta1RetrySpec
 from .models.v1beta1_sk_learn_spec import V1beta1SKLearnSpec
 from .models.v1beta1_tf_serving_spec import V1beta1TFServingSpec
 from .models.v1beta1_torch_serve_spec import V1beta1TorchServeSpec
@@ -33,18 +33,27 @@ from kserve.models.v1alpha1_cluster_serving_runtime import V1alpha1ClusterServin
 from kserve.models.v1alpha1_cluster_serving_runtime_list import V1alpha1ClusterServingRuntimeList
 from kserve.models.v1alpha1_cluster_storage_container import V1alpha1ClusterStorageContainer
 from kserve.models.v1alpha1_cluster_storage_container_list import V1alpha1ClusterStorageContainerList
+from kserve.models.v1alpha1_ensemble_merge import V1alpha1EnsembleMerge
 from kserve.models.v1alpha1_inference_graph import V1alpha1InferenceGraph
+from kserve.models.v1alpha1_inference_graph_cache import V1alpha1InferenceGraphCache
 from kserve.models.v1alpha1_inference_graph_list import V1alpha1InferenceGraphList
+from kserve.models.v1alpha1_inference_graph_logger import V1alpha1InferenceGraphLogger
 from kserve.models.v1alpha1_inference_graph_spec import V1alpha1InferenceGraphSpec
 from kserve.models.v1alpha1_inference_graph_status import V1alpha1InferenceGraphStatus
 from kserve.models.v1alpha1_inference_router import V1alpha1InferenceRouter
 from kserve.models.v1alpha1_inference_step import V1alpha1InferenceStep
+from kserve.models.v1alpha1_inference_step_cache import V1alpha1InferenceStepCache
+from kserve.models.v1alpha1_inference_step_circuit_breaker import V1alpha1InferenceStepCircuitBreaker
+from kserve.models.v1alpha1_inference_step_retry import V1alpha1InferenceStepRetry
 from kserve.models.v1alpha1_inference_target import V1alpha1InferenceTarget
 from kserve.models.v1alpha1_model_spec import V1alpha1ModelSpec
+from kserve.models.v1alpha1_redis_cache import V1alpha1RedisCache
+from kserve.models.v1alpha1_redis_cache_tls import V1alpha1RedisCacheTLS
 from kserve.models.v1alpha1_serving_runtime import V1alpha1ServingRuntime
 from kserve.models.v1alpha1_serving_runtime_list import V1alpha1ServingRuntimeList
 from kserve.models.v1alpha1_serving_runtime_pod_spec import V1alpha1ServingRuntimePodSpec
 from kserve.models.v1alpha1_serving_runtime_spec import V1alpha1ServingRuntimeSpec
+from kserve.models.v1alpha1_splitter_stickiness import V1alpha1SplitterStickiness
 from kserve.models.v1alpha1_storage_container_spec import V1alpha1StorageContainerSpec
 from kserve.models.v1alpha1_storage_helper import V1alpha1StorageHelper
 from kserve.models.v1alpha1_supported_model_format import V1alpha1SupportedModelFormat
@@ -53,6 +62,7 @@ from kserve.models.v1alpha1_trained_model import V1alpha1TrainedModel
 from kserve.models.v1alpha1_trained_model_list import V1alpha1TrainedModelList
 from kserve.models.v1alpha1_trained_model_spec import V1alpha1TrainedModelSpec
 from kserve.models.v1beta1_art_explainer_spec import V1beta1ARTExplainerSpec
+from kserve.models.v1beta1_artifact_status import V1beta1ArtifactStatus
 from kserve.models.v1beta1_batcher import V1beta1Batcher
 from kserve.models.v1beta1_component_extension_spec import V1beta1ComponentExtensionSpec
 from kserve.models.v1beta1_component_status_spec import V1beta1ComponentStatusSpec
@@ -74,6 +84,7 @@ from kserve.models.v1beta1_inference_services_config import V1beta1InferenceServ
 from kserve.models.v1beta1_ingress_config import V1beta1IngressConfig
 from kserve.models.v1beta1_light_gbm_spec import V1beta1LightGBMSpec
 from kserve.models.v1beta1_logger_spec import V1beta1LoggerSpec
+from kserve.models.v1beta1_model_adapter_spec import V1beta1ModelAdapterSpec
 from kserve.models.v1beta1_model_copies import V1beta1ModelCopies
 from kserve.models.v1beta1_model_format import V1beta1ModelFormat
 from kserve.models.v1beta1_model_revision_states import V1beta1ModelRevisionStates
@@ -85,6 +96,9 @@ from kserve.models.v1beta1_paddle_server_spec import V1beta1PaddleServerSpec
 from kserve.models.v1beta1_pod_spec import V1beta1PodSpec
 from kserve.models.v1beta1_predictor_extension_spec import V1beta1PredictorExtensionSpec
 from kserve.models.v1beta1_predictor_spec import V1beta1PredictorSpec
+from kserve.models.v1beta1_retry_spec import V1beta1RetrySpec
+from kserve.models.v1beta1_revision_model_status import V1beta1RevisionModelStatus
+from kserve.models.v1beta1_runtime_status import V1beta1RuntimeStatus
 from kserve.models.v1beta1_sk_learn_spec import V1beta1SKLearnSpec
 from kserve.models.v1beta1_storage_spec import V1beta1StorageSpec
 from kserve.models.v1beta1_tf_serving_spec import V1beta1TFServingSpec
@@ -0,0 +1,193 @@
+# Copyright 2024 The KServe Authors.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#    http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+# coding: utf-8
+
+"""
+    KServe
+
+    Python SDK for KServe  # noqa: E501
+
+    The version of the OpenAPI document: v0.1
+    Generated by: https://openapi-generator.tech
+"""
+
+
+import pprint
+import re  # noqa: F401
+
+import six
+
+from kserve.configuration import Configuration
+
+
+class V1alpha1EnsembleMerge(object):
+    """NOTE: This class is auto generated by OpenAPI Generator.
+    Ref: https://openapi-generator.tech
+
+    Do not edit the class manually.
+    """
+
+    """
+    Attributes:
+      openapi_types (dict): The key is attribute name
+                            and the value is attribute type.
+      attribute_map (dict): The key is attribute name
+                            and the value is json key in definition.
+    """
+    openapi_types = {
+        'combiner_url': 'str',
+        'field': 'str',
+        'strategy': 'str'
+    }
+
+    attribute_map = {
+        'combiner_url': 'combinerUrl',
+        'field': 'field',
+        'strategy': 'strategy'
+    }
+
+    def __init__(self, combiner_url=None, field=None, strategy='', local_vars_configuration=None):  # noqa: E501
+        """V1alpha1EnsembleMerge - a model defined in OpenAPI"""  # noqa: E501
+        if local_vars_configuration is None:
+            local_vars_configuration = Configuration()
+        self.local_vars_configuration = local_vars_configuration
+
+        self._combiner_url = None
+        self._field = None
+        self._strategy = None
+        self.discriminator = None
+
+        if combiner_url is not None:
+            self.combiner_url = combiner_url
+        if field is not None:
+            self.field = field
+        self.strategy = strategy
+
+    @property
+    def combiner_url(self):
+        """Gets the combiner_url of this V1alpha1EnsembleMerge.  # noqa: E501
+
+        CombinerURL of the service receiving the step responses for the custom strategy  # noqa: E501
+
+        :return: The combiner_url of this V1alpha1EnsembleMerge.  # noqa: E501
+        :rtype: str
+        """
+        return self._combiner_url
+
+    @combiner_url.setter
+    def combiner_url(self, combiner_url):
+        """Sets the combiner_url of this V1alpha1EnsembleMerge.
+
+        CombinerURL of the service receiving the step responses for the custom strategy  # noqa: E501
+
+        :param combiner_url: The combiner_url of this V1alpha1EnsembleMerge.  # noqa: E501
+        :type: str
+        """
+
+        self._combiner_url = combiner_url
+
+    @property
+    def field(self):
+        """Gets the field of this V1alpha1EnsembleMerge.  # noqa: E501
+
+        Field is the dot separated path, e.g. `outputs.0.data`, of the value voted on or averaged in every step response, defaults to `predictions`. Arrays are merged element-wise and the merged value replaces the field in the response of the first step.  # noqa: E501
+
+        :return: The field of this V1alpha1EnsembleMerge.  # noqa: E501
+        :rtype: str
+        """
+        return self._field
+
+    @field.setter
+    def field(self, field):
+        """Sets the field of this V1alpha1EnsembleMerge.
+
+        Field is the dot separated path, e.g. `outputs.0.data`, of the value voted on or averaged in every step response, defaults to `predictions`. Arrays are merged element-wise and the merged value replaces the field in the response of the first step.  # noqa: E501
+
+        :param field: The field of this V1alpha1EnsembleMerge.  # noqa: E501
+        :type: str
+        """
+
+        self._field = field
+
+    @property
+    def strategy(self):
+        """Gets the strategy of this V1alpha1EnsembleMerge.  # noqa: E501
+
+        Strategy used to merge the step responses  # noqa: E501
+
+        :return: The strategy of this V1alpha1EnsembleMerge.  # noqa: E501
+        :rtype: str
+        """
+        return self._strategy
+
+    @strategy.setter
+    def strategy(self, strategy):
+        """Sets the strategy of this V1alpha1EnsembleMerge.
+
+        Strategy used to merge the step responses  # noqa: E501
+
+        :param strategy: The strategy of this V1alpha1EnsembleMerge.  # noqa: E501
+        :type: str
+        """
+        if self.local_vars_configuration.client_side_validation and strategy is None:  # noqa: E501
+            raise ValueError("Invalid value for `strategy`, must not be `None`")  # noqa: E501
+
+        self._strategy = strategy
+
+    def to_dict(self):
+        """Returns the model properties as a dict"""
+        result = {}
+
+        for attr, _ in six.iteritems(self.openapi_types):
+            value = getattr(self, attr)
+            if isinstance(value, list):
+                result[attr] = list(map(
+                    lambda x: x.to_dict() if hasattr(x, "to_dict") else x,
+                    value
+                ))
+            elif hasattr(value, "to_dict"):
+                result[attr] = value.to_dict()
+            elif isinstance(value, dict):
+                result[attr] = dict(map(
+                    lambda item: (item[0], item[1].to_dict())
+                    if hasattr(item[1], "to_dict") else item,
+                    value.items()
+                ))
+            else:
+                result[attr] = value
+
+        return result
+
+    def to_str(self):
+        """Returns the string representation of the model"""
+        return pprint.pformat(self.to_dict())
+
+    def __repr__(self):
+        """For `print` and `pprint`"""
+        return self.to_str()
+
+    def __eq__(self, other):
+        """Returns true if both objects are equal"""
+        if not isinstance(other, V1alpha1EnsembleMerge):
+            return False
+
+        return self.to_dict() == other.to_dict()
+
+    def __ne__(self, other):
+        """Returns true if both objects are not equal"""
+        if not isinstance(other, V1alpha1EnsembleMerge):
+            return True
+
+        return self.to_dict() != other.to_dict()
@@ -48,55 +48,75 @@ class V1alpha1InferenceGraphSpec(object):
     """
     openapi_types = {
         'affinity': 'V1Affinity',
+        'cache': 'V1alpha1InferenceGraphCache',
+        'logger': 'V1alpha1InferenceGraphLogger',
         'max_replicas': 'int',
         'min_replicas': 'int',
         'nodes': 'dict(str, V1alpha1InferenceRouter)',
+        'propagate_headers': 'list[str]',
         'resources': 'V1ResourceRequirements',
         'scale_metric': 'str',
         'scale_target': 'int',
+        'service_account_name': 'str',
         'timeout': 'int'
     }
 
     attribute_map = {
         'affinity': 'affinity',
+        'cache': 'cache',
+        'logger': 'logger',
         'max_replicas': 'maxReplicas',
         'min_replicas': 'minReplicas',
         'nodes': 'nodes',
+        'propagate_headers': 'propagateHeaders',
         'resources': 'resources',
         'scale_metric': 'scaleMetric',
         'scale_target': 'scaleTarget',
+        'service_account_name': 'serviceAccountName',
         'timeout': 'timeout'
     }
 
-    def __init__(self, affinity=None, max_replicas=None, min_replicas=None, nodes=None, resources=None, scale_metric=None, scale_target=None, timeout=None, local_vars_configuration=None):  # noqa: E501
+    def __init__(self, affinity=None, cache=None, logger=None, max_replicas=None, min_replicas=None, nodes=None, propagate_headers=None, resources=None, scale_metric=None, scale_target=None, service_account_name=None, timeout=None, local_vars_configuration=None):  # noqa: E501
         """V1alpha1InferenceGraphSpec - a model defined in OpenAPI"""  # noqa: E501
         if local_vars_configuration is None:
             local_vars_configuration = Configuration()
         self.local_vars_configuration = local_vars_configuration
 
         self._affinity = None
+        self._cache = None
+        self._logger = None
         self._max_replicas = None
         self._min_replicas = None
         self._nodes = None
+        self._propagate_headers = None
         self._resources = None
         self._scale_metric = None
         self._scale_target = None
+        self._service_account_name = None
         self._timeout = None
         self.discriminator = None
 
         if affinity is not None:
             self.affinity = affinity
+        if cache is not None:
+            self.cache = cache
+        if logger is not None:
+            self.logger = logger
         if max_replicas is not None:
             self.max_replicas = max_replicas
         if min_replicas is not None:
             self.min_replicas = min_replicas
         self.nodes = nodes
+        if propagate_headers is not None:
+            self.propagate_headers = propagate_headers
         if resources is not None:
             self.resources = resources
         if scale_metric is not None:
             self.scale_metric = scale_metric
         if scale_target is not None:
             self.scale_target = scale_target
+        if service_account_name is not None:
+            self.service_account_name = service_account_name
         if timeout is not None:
             self.timeout = timeout
 
@@ -121,6 +141,52 @@ class V1alpha1InferenceGraphSpec(object):
 
         self._affinity = affinity
 
+    @property
+    def cache(self):
+        """Gets the cache of this V1alpha1InferenceGraphSpec.  # noqa: E501
+
+        Cache configures the backend of the step caches, the cached responses are kept in the memory of the router when not specified.  # noqa: E501
+
+        :return: The cache of this V1alpha1InferenceGraphSpec.  # noqa: E501
+        :rtype: V1alpha1InferenceGraphCache
+        """
+        return self._cache
+
+    @cache.setter
+    def cache(self, cache):
+        """Sets the cache of this V1alpha1InferenceGraphSpec.
+
+        Cache configures the backend of the step caches, the cached responses are kept in the memory of the router when not specified.  # noqa: E501
+
+        :param cache: The cache of this V1alpha1InferenceGraphSpec.  # noqa: E501
+        :type: V1alpha1InferenceGraphCache
+        """
+
+        self._cache = cache
+
+    @property
+    def logger(self):
+        """Gets the logger of this V1alpha1InferenceGraphSpec.  # noqa: E501
+
+        Logger sends the requests and the responses of the graph, and optionally of its steps, as cloud events.  # noqa: E501
+
+        :return: The logger of this V1alpha1InferenceGraphSpec.  # noqa: E501
+        :rtype: V1alpha1InferenceGraphLogger
+        """
+        return self._logger
+
+    @logger.setter
+    def logger(self, logger):
+        """Sets the logger of this V1alpha1InferenceGraphSpec.
+
+        Logger sends the requests and the responses of the graph, and optionally of its steps, as cloud events.  # noqa: E501
+
+        :param logger: The logger of this V1alpha1InferenceGraphSpec.  # noqa: E501
+        :type: V1alpha1InferenceGraphLogger
+        """
+
+        self._logger = logger
+
     @property
     def max_replicas(self):
         """Gets the max_replicas of this V1alpha1InferenceGraphSpec.  # noqa: E501
@@ -192,6 +258,29 @@ class V1alpha1InferenceGraphSpec(object):
 
         self._nodes = nodes
 
+    @property
+    def propagate_headers(self):
+        """Gets the propagate_headers of this V1alpha1InferenceGraphSpec.  # noqa: E501
+
+        PropagateHeaders are regular expressions matching the names of the request headers forwarded to the steps, in addition to the headers propagated by the router configuration. The other headers are dropped.  # noqa: E501
+
+        :return: The propagate_headers of this V1alpha1InferenceGraphSpec.  # noqa: E501
+        :rtype: list[str]
+        """
+        return self._propagate_headers
+
+    @propagate_headers.setter
+    def propagate_headers(self, propagate_headers):
+        """Sets the propagate_headers of this V1alpha1InferenceGraphSpec.
+
+        PropagateHeaders are regular expressions matching the names of the request headers forwarded to the steps, in addition to the headers propagated by the router configuration. The other headers are dropped.  # noqa: E501
+
+        :param propagate_headers: The propagate_headers of this V1alpha1InferenceGraphSpec.  # noqa: E501
+        :type: list[str]
+        """
+
+        self._propagate_headers = propagate_headers
+
     @property
     def resources(self):
         """Gets the resources of this V1alpha1InferenceGraphSpec.  # noqa: E501
@@ -259,6 +348,29 @@ class V1alpha1InferenceGraphSpec(object):
 
         self._scale_target = scale_target
 
+    @property
+    def service_account_name(self):
+        """Gets the service_account_name of this V1alpha1InferenceGraphSpec.  # noqa: E501
+
+        ServiceAccountName is the name of the service account the router runs as. The steps with a token audience are called with a token of this service account.  # noqa: E501
+
+        :return: The service_account_name of this V1alpha1InferenceGraphSpec.  # noqa: E501
+        :rtype: str
+        """
+        return self._service_account_name
+
+    @service_account_name.setter
+    def service_account_name(self, service_account_name):
+        """Sets the service_account_name of this V1alpha1InferenceGraphSpec.
+
+        ServiceAccountName is the name of the service account the router runs as. The steps with a token audience are called with a token of this service account.  # noqa: E501
+
+        :param service_account_name: The service_account_name of this V1alpha1InferenceGraphSpec.  # noqa: E501
+        :type: str
+        """
+
+        self._service_account_name = service_account_name
+
     @property
     def timeout(self):
         """Gets the timeout of this V1alpha1InferenceGraphSpec.  # noqa: E501
@@ -47,28 +47,59 @@ class V1alpha1InferenceRouter(object):
                             and the value is json key in definition.
     """
     openapi_types = {
+        'merge': 'V1alpha1EnsembleMerge',
         'router_type': 'str',
-        'steps': 'list[V1alpha1InferenceStep]'
+        'steps': 'list[V1alpha1InferenceStep]',
+        'stickiness': 'V1alpha1SplitterStickiness'
     }
 
     attribute_map = {
+        'merge': 'merge',
         'router_type': 'routerType',
-        'steps': 'steps'
+        'steps': 'steps',
+        'stickiness': 'stickiness'
     }
 
-    def __init__(self, router_type='', steps=None, local_vars_configuration=None):  # noqa: E501
+    def __init__(self, merge=None, router_type=None, steps=None, stickiness=None, local_vars_configuration=None):  # noqa: E501
         """V1alpha1InferenceRouter - a model defined in OpenAPI"""  # noqa: E501
         if local_vars_configuration is None:
             local_vars_configuration = Configuration()
         self.local_vars_configuration = local_vars_configuration
 
+        self._merge = None
         self._router_type = None
         self._steps = None
+        self._stickiness = None
         self.discriminator = None
 
         self.router_type = router_type
         if steps is not None:
             self.steps = steps
+        if stickiness is not None:
+            self.stickiness = stickiness
+
+    @property
+    def merge(self):
+        """Gets the merge of this V1alpha1InferenceRouter.  # noqa: E501
+
+        Merge defines how the responses of the steps of an Ensemble node are combined, the responses are returned as a map of step name to response by default  # noqa: E501
+
+        :return: The merge of this V1alpha1InferenceRouter.  # noqa: E501
+        :rtype: V1alpha1EnsembleMerge
+        """
+        return self._merge
+
+    @merge.setter
+    def merge(self, merge):
+        """Sets the merge of this V1alpha1InferenceRouter.
+
+        Merge defines how the responses of the steps of an Ensemble node are combined, the responses are returned as a map of step name to response by default  # noqa: E501
+
+        :param merge: The merge of this V1alpha1InferenceRouter.  # noqa: E501
+        :type: V1alpha1EnsembleMerge
+        """
+
+        self._merge = merge
 
     @property
     def router_type(self):
@@ -118,6 +149,29 @@ class V1alpha1InferenceRouter(object):
 
         self._steps = steps
 
+    @property
+    def stickiness(self):
+        """Gets the stickiness of this V1alpha1InferenceRouter.  # noqa: E501
+
+        Stickiness routes the requests with the same key to the same step of a Splitter node as long as the weights of the steps are unchanged, the requests without a key are routed randomly according to the weights  # noqa: E501
+
+        :return: The stickiness of this V1alpha1InferenceRouter.  # noqa: E501
+        :rtype: V1alpha1SplitterStickiness
+        """
+        return self._stickiness
+
+    @stickiness.setter
+    def stickiness(self, stickiness):
+        """Sets the stickiness of this V1alpha1InferenceRouter.
+
+        Stickiness routes the requests with the same key to the same step of a Splitter node as long as the weights of the steps are unchanged, the requests without a key are routed randomly according to the weights  # noqa: E501
+
+        :param stickiness: The stickiness of this V1alpha1InferenceRouter.  # noqa: E501
+        :type: V1alpha1SplitterStickiness
+        """
+
+        self._stickiness = stickiness
+
     def to_dict(self):
         """Returns the model properties as a dict"""
         result = {}
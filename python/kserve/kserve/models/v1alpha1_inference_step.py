@@ -47,45 +47,75 @@ class V1alpha1InferenceStep(object):
                             and the value is json key in definition.
     """
     openapi_types = {
+        'cache': 'V1alpha1InferenceStepCache',
+        'circuit_breaker': 'V1alpha1InferenceStepCircuitBreaker',
         'condition': 'str',
+        'condition_language': 'str',
         'data': 'str',
         'dependency': 'str',
         'name': 'str',
         'node_name': 'str',
+        'protocol': 'str',
+        'retry': 'V1alpha1InferenceStepRetry',
         'service_name': 'str',
         'service_url': 'str',
+        'streaming': 'bool',
+        'timeout': 'int',
+        'token_audience': 'str',
         'weight': 'int'
     }
 
     attribute_map = {
+        'cache': 'cache',
+        'circuit_breaker': 'circuitBreaker',
         'condition': 'condition',
+        'condition_language': 'conditionLanguage',
         'data': 'data',
         'dependency': 'dependency',
         'name': 'name',
         'node_name': 'nodeName',
+        'protocol': 'protocol',
+        'retry': 'retry',
         'service_name': 'serviceName',
         'service_url': 'serviceUrl',
+        'streaming': 'streaming',
+        'timeout': 'timeout',
+        'token_audience': 'tokenAudience',
         'weight': 'weight'
     }
 
-    def __init__(self, condition=None, data=None, dependency=None, name=None, node_name=None, service_name=None, service_url=None, weight=None, local_vars_configuration=None):  # noqa: E501
+    def __init__(self, cache=None, circuit_breaker=None, condition=None, condition_language=None, data=None, dependency=None, name=None, node_name=None, protocol=None, retry=None, service_name=None, service_url=None, streaming=None, timeout=None, token_audience=None, weight=None, local_vars_configuration=None):  # noqa: E501
         """V1alpha1InferenceStep - a model defined in OpenAPI"""  # noqa: E501
         if local_vars_configuration is None:
             local_vars_configuration = Configuration()
         self.local_vars_configuration = local_vars_configuration
 
+        self._cache = None
+        self._circuit_breaker = None
         self._condition = None
+        self._condition_language = None
         self._data = None
         self._dependency = None
         self._name = None
         self._node_name = None
+        self._protocol = None
+        self._retry = None
         self._service_name = None
         self._service_url = None
+        self._streaming = None
+        self._timeout = None
+        self._token_audience = None
         self._weight = None
         self.discriminator = None
 
+        if cache is not None:
+            self.cache = cache
+        if circuit_breaker is not None:
+            self.circuit_breaker = circuit_breaker
         if condition is not None:
             self.condition = condition
+        if condition_language is not None:
+            self.condition_language = condition_language
         if data is not None:
             self.data = data
         if dependency is not None:
@@ -94,13 +124,69 @@ class V1alpha1InferenceStep(object):
             self.name = name
         if node_name is not None:
             self.node_name = node_name
+        if protocol is not None:
+            self.protocol = protocol
+        if retry is not None:
+            self.retry = retry
         if service_name is not None:
             self.service_name = service_name
         if service_url is not None:
             self.service_url = service_url
+        if streaming is not None:
+            self.streaming = streaming
+        if timeout is not None:
+            self.timeout = timeout
+        if token_audience is not None:
+            self.token_audience = token_audience
         if weight is not None:
             self.weight = weight
 
+    @property
+    def cache(self):
+        """Gets the cache of this V1alpha1InferenceStep.  # noqa: E501
+
+        Cache serves the responses of the step to identical requests without calling it.  # noqa: E501
+
+        :return: The cache of this V1alpha1InferenceStep.  # noqa: E501
+        :rtype: V1alpha1InferenceStepCache
+        """
+        return self._cache
+
+    @cache.setter
+    def cache(self, cache):
+        """Sets the cache of this V1alpha1InferenceStep.
+
+        Cache serves the responses of the step to identical requests without calling it.  # noqa: E501
+
+        :param cache: The cache of this V1alpha1InferenceStep.  # noqa: E501
+        :type: V1alpha1InferenceStepCache
+        """
+
+        self._cache = cache
+
+    @property
+    def circuit_breaker(self):
+        """Gets the circuit_breaker of this V1alpha1InferenceStep.  # noqa: E501
+
+        CircuitBreaker stops calling the step after consecutive failures.  # noqa: E501
+
+        :return: The circuit_breaker of this V1alpha1InferenceStep.  # noqa: E501
+        :rtype: V1alpha1InferenceStepCircuitBreaker
+        """
+        return self._circuit_breaker
+
+    @circuit_breaker.setter
+    def circuit_breaker(self, circuit_breaker):
+        """Sets the circuit_breaker of this V1alpha1InferenceStep.
+
+        CircuitBreaker stops calling the step after consecutive failures.  # noqa: E501
+
+        :param circuit_breaker: The circuit_breaker of this V1alpha1InferenceStep.  # noqa: E501
+        :type: V1alpha1InferenceStepCircuitBreaker
+        """
+
+        self._circuit_breaker = circuit_breaker
+
     @property
     def condition(self):
         """Gets the condition of this V1alpha1InferenceStep.  # noqa: E501
@@ -124,6 +210,29 @@ class V1alpha1InferenceStep(object):
 
         self._condition = condition
 
+    @property
+    def condition_language(self):
+        """Gets the condition_language of this V1alpha1InferenceStep.  # noqa: E501
+
+        ConditionLanguage is the language of the condition, defaults to gjson. CEL expressions read the parsed JSON of the node input from the request variable and, in a Sequence node, the response of the previous step from the response variable, e.g. response.predictions[0].score > 0.8  # noqa: E501
+
+        :return: The condition_language of this V1alpha1InferenceStep.  # noqa: E501
+        :rtype: str
+        """
+        return self._condition_language
+
+    @condition_language.setter
+    def condition_language(self, condition_language):
+        """Sets the condition_language of this V1alpha1InferenceStep.
+
+        ConditionLanguage is the language of the condition, defaults to gjson. CEL expressions read the parsed JSON of the node input from the request variable and, in a Sequence node, the response of the previous step from the response variable, e.g. response.predictions[0].score > 0.8  # noqa: E501
+
+        :param condition_language: The condition_language of this V1alpha1InferenceStep.  # noqa: E501
+        :type: str
+        """
+
+        self._condition_language = condition_language
+
     @property
     def data(self):
         """Gets the data of this V1alpha1InferenceStep.  # noqa: E501
@@ -216,6 +325,52 @@ class V1alpha1InferenceStep(object):
 
         self._node_name = node_name
 
+    @property
+    def protocol(self):
+        """Gets the protocol of this V1alpha1InferenceStep.  # noqa: E501
+
+        Protocol used to call the service of the step, defaults to http. With grpc-v2 the model name is taken from the /v2/models/{name} path of the service url, or from the service name when the url has no such path.  # noqa: E501
+
+        :return: The protocol of this V1alpha1InferenceStep.  # noqa: E501
+        :rtype: str
+        """
+        return self._protocol
+
+    @protocol.setter
+    def protocol(self, protocol):
+        """Sets the protocol of this V1alpha1InferenceStep.
+
+        Protocol used to call the service of the step, defaults to http. With grpc-v2 the model name is taken from the /v2/models/{name} path of the service url, or from the service name when the url has no such path.  # noqa: E501
+
+        :param protocol: The protocol of this V1alpha1InferenceStep.  # noqa: E501
+        :type: str
+        """
+
+        self._protocol = protocol
+
+    @property
+    def retry(self):
+        """Gets the retry of this V1alpha1InferenceStep.  # noqa: E501
+
+        Retry policy of the step, the step is attempted once when not specified.  # noqa: E501
+
+        :return: The retry of this V1alpha1InferenceStep.  # noqa: E501
+        :rtype: V1alpha1InferenceStepRetry
+        """
+        return self._retry
+
+    @retry.setter
+    def retry(self, retry):
+        """Sets the retry of this V1alpha1InferenceStep.
+
+        Retry policy of the step, the step is attempted once when not specified.  # noqa: E501
+
+        :param retry: The retry of this V1alpha1InferenceStep.  # noqa: E501
+        :type: V1alpha1InferenceStepRetry
+        """
+
+        self._retry = retry
+
     @property
     def service_name(self):
         """Gets the service_name of this V1alpha1InferenceStep.  # noqa: E501
@@ -262,6 +417,75 @@ class V1alpha1InferenceStep(object):
 
         self._service_url = service_url
 
+    @property
+    def streaming(self):
+        """Gets the streaming of this V1alpha1InferenceStep.  # noqa: E501
+
+        Streaming proxies the text/event-stream response of the step to the client as it arrives instead of buffering it, e.g. the tokens generated by an LLM. Only a terminal step of the graph, whose response is returned to the client, can stream, and the stream is bounded by the timeout of the graph.  # noqa: E501
+
+        :return: The streaming of this V1alpha1InferenceStep.  # noqa: E501
+        :rtype: bool
+        """
+        return self._streaming
+
+    @streaming.setter
+    def streaming(self, streaming):
+        """Sets the streaming of this V1alpha1InferenceStep.
+
+        Streaming proxies the text/event-stream response of the step to the client as it arrives instead of buffering it, e.g. the tokens generated by an LLM. Only a terminal step of the graph, whose response is returned to the client, can stream, and the stream is bounded by the timeout of the graph.  # noqa: E501
+
+        :param streaming: The streaming of this V1alpha1InferenceStep.  # noqa: E501
+        :type: bool
+        """
+
+        self._streaming = streaming
+
+    @property
+    def timeout(self):
+        """Gets the timeout of this V1alpha1InferenceStep.  # noqa: E501
+
+        TimeoutSeconds specifies the number of seconds to wait for each attempt of the step.  # noqa: E501
+
+        :return: The timeout of this V1alpha1InferenceStep.  # noqa: E501
+        :rtype: int
+        """
+        return self._timeout
+
+    @timeout.setter
+    def timeout(self, timeout):
+        """Sets the timeout of this V1alpha1InferenceStep.
+
+        TimeoutSeconds specifies the number of seconds to wait for each attempt of the step.  # noqa: E501
+
+        :param timeout: The timeout of this V1alpha1InferenceStep.  # noqa: E501
+        :type: int
+        """
+
+        self._timeout = timeout
+
+    @property
+    def token_audience(self):
+        """Gets the token_audience of this V1alpha1InferenceStep.  # noqa: E501
+
+        TokenAudience attaches a projected token of the router service account, issued for this audience, to the calls of the step as a Bearer token in the Authorization header, replacing a propagated Authorization header.  # noqa: E501
+
+        :return: The token_audience of this V1alpha1InferenceStep.  # noqa: E501
+        :rtype: str
+        """
+        return self._token_audience
+
+    @token_audience.setter
+    def token_audience(self, token_audience):
+        """Sets the token_audience of this V1alpha1InferenceStep.
+
+        TokenAudience attaches a projected token of the router service account, issued for this audience, to the calls of the step as a Bearer token in the Authorization header, replacing a propagated Authorization header.  # noqa: E501
+
+        :param token_audience: The token_audience of this V1alpha1InferenceStep.  # noqa: E501
+        :type: str
+        """
+
+        self._token_audience = token_audience
+
     @property
     def weight(self):
         """Gets the weight of this V1alpha1InferenceStep.  # noqa: E501
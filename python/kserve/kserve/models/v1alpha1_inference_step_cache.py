@@ -0,0 +1,193 @@
+# Copyright 2024 The KServe Authors.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#    http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+# coding: utf-8
+
+"""
+    KServe
+
+    Python SDK for KServe  # noqa: E501
+
+    The version of the OpenAPI document: v0.1
+    Generated by: https://openapi-generator.tech
+"""
+
+
+import pprint
+import re  # noqa: F401
+
+import six
+
+from kserve.configuration import Configuration
+
+
+class V1alpha1InferenceStepCache(object):
+    """NOTE: This class is auto generated by OpenAPI Generator.
+    Ref: https://openapi-generator.tech
+
+    Do not edit the class manually.
+    """
+
+    """
+    Attributes:
+      openapi_types (dict): The key is attribute name
+                            and the value is attribute type.
+      attribute_map (dict): The key is attribute name
+                            and the value is json key in definition.
+    """
+    openapi_types = {
+        'key_fields': 'list[str]',
+        'max_entries': 'int',
+        'ttl_seconds': 'int'
+    }
+
+    attribute_map = {
+        'key_fields': 'keyFields',
+        'max_entries': 'maxEntries',
+        'ttl_seconds': 'ttlSeconds'
+    }
+
+    def __init__(self, key_fields=None, max_entries=None, ttl_seconds=0, local_vars_configuration=None):  # noqa: E501
+        """V1alpha1InferenceStepCache - a model defined in OpenAPI"""  # noqa: E501
+        if local_vars_configuration is None:
+            local_vars_configuration = Configuration()
+        self.local_vars_configuration = local_vars_configuration
+
+        self._key_fields = None
+        self._max_entries = None
+        self._ttl_seconds = None
+        self.discriminator = None
+
+        if key_fields is not None:
+            self.key_fields = key_fields
+        if max_entries is not None:
+            self.max_entries = max_entries
+        self.ttl_seconds = ttl_seconds
+
+    @property
+    def key_fields(self):
+        """Gets the key_fields of this V1alpha1InferenceStepCache.  # noqa: E501
+
+        KeyFields are the paths of the request fields the cache key is computed from, e.g. inputs.#.data. When not specified the whole request is used, except its id.  # noqa: E501
+
+        :return: The key_fields of this V1alpha1InferenceStepCache.  # noqa: E501
+        :rtype: list[str]
+        """
+        return self._key_fields
+
+    @key_fields.setter
+    def key_fields(self, key_fields):
+        """Sets the key_fields of this V1alpha1InferenceStepCache.
+
+        KeyFields are the paths of the request fields the cache key is computed from, e.g. inputs.#.data. When not specified the whole request is used, except its id.  # noqa: E501
+
+        :param key_fields: The key_fields of this V1alpha1InferenceStepCache.  # noqa: E501
+        :type: list[str]
+        """
+
+        self._key_fields = key_fields
+
+    @property
+    def max_entries(self):
+        """Gets the max_entries of this V1alpha1InferenceStepCache.  # noqa: E501
+
+        MaxEntries is the maximum number of responses kept in the memory of the router, defaults to 1000. The least recently used responses are evicted first.  # noqa: E501
+
+        :return: The max_entries of this V1alpha1InferenceStepCache.  # noqa: E501
+        :rtype: int
+        """
+        return self._max_entries
+
+    @max_entries.setter
+    def max_entries(self, max_entries):
+        """Sets the max_entries of this V1alpha1InferenceStepCache.
+
+        MaxEntries is the maximum number of responses kept in the memory of the router, defaults to 1000. The least recently used responses are evicted first.  # noqa: E501
+
+        :param max_entries: The max_entries of this V1alpha1InferenceStepCache.  # noqa: E501
+        :type: int
+        """
+
+        self._max_entries = max_entries
+
+    @property
+    def ttl_seconds(self):
+        """Gets the ttl_seconds of this V1alpha1InferenceStepCache.  # noqa: E501
+
+        TTLSeconds is the number of seconds a response is served from the cache.  # noqa: E501
+
+        :return: The ttl_seconds of this V1alpha1InferenceStepCache.  # noqa: E501
+        :rtype: int
+        """
+        return self._ttl_seconds
+
+    @ttl_seconds.setter
+    def ttl_seconds(self, ttl_seconds):
+        """Sets the ttl_seconds of this V1alpha1InferenceStepCache.
+
+        TTLSeconds is the number of seconds a response is served from the cache.  # noqa: E501
+
+        :param ttl_seconds: The ttl_seconds of this V1alpha1InferenceStepCache.  # noqa: E501
+        :type: int
+        """
+        if self.local_vars_configuration.client_side_validation and ttl_seconds is None:  # noqa: E501
+            raise ValueError("Invalid value for `ttl_seconds`, must not be `None`")  # noqa: E501
+
+        self._ttl_seconds = ttl_seconds
+
+    def to_dict(self):
+        """Returns the model properties as a dict"""
+        result = {}
+
+        for attr, _ in six.iteritems(self.openapi_types):
+            value = getattr(self, attr)
+            if isinstance(value, list):
+                result[attr] = list(map(
+                    lambda x: x.to_dict() if hasattr(x, "to_dict") else x,
+                    value
+                ))
+            elif hasattr(value, "to_dict"):
+                result[attr] = value.to_dict()
+            elif isinstance(value, dict):
+                result[attr] = dict(map(
+                    lambda item: (item[0], item[1].to_dict())
+                    if hasattr(item[1], "to_dict") else item,
+                    value.items()
+                ))
+            else:
+                result[attr] = value
+
+        return result
+
+    def to_str(self):
+        """Returns the string representation of the model"""
+        return pprint.pformat(self.to_dict())
+
+    def __repr__(self):
+        """For `print` and `pprint`"""
+        return self.to_str()
+
+    def __eq__(self, other):
+        """Returns true if both objects are equal"""
+        if not isinstance(other, V1alpha1InferenceStepCache):
+            return False
+
+        return self.to_dict() == other.to_dict()
+
+    def __ne__(self, other):
+        """Returns true if both objects are not equal"""
+        if not isinstance(other, V1alpha1InferenceStepCache):
+            return True
+
+        return self.to_dict() != other.to_dict()
@@ -0,0 +1,193 @@
+# Copyright 2024 The KServe Authors.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#    http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+# coding: utf-8
+
+"""
+    KServe
+
+    Python SDK for KServe  # noqa: E501
+
+    The version of the OpenAPI document: v0.1
+    Generated by: https://openapi-generator.tech
+"""
+
+
+import pprint
+import re  # noqa: F401
+
+import six
+
+from kserve.configuration import Configuration
+
+
+class V1alpha1InferenceStepCircuitBreaker(object):
+    """NOTE: This class is auto generated by OpenAPI Generator.
+    Ref: https://openapi-generator.tech
+
+    Do not edit the class manually.
+    """
+
+    """
+    Attributes:
+      openapi_types (dict): The key is attribute name
+                            and the value is attribute type.
+      attribute_map (dict): The key is attribute name
+                            and the value is json key in definition.
+    """
+    openapi_types = {
+        'consecutive_failures': 'int',
+        'cooldown_seconds': 'int',
+        'fallback': 'str'
+    }
+
+    attribute_map = {
+        'consecutive_failures': 'consecutiveFailures',
+        'cooldown_seconds': 'cooldownSeconds',
+        'fallback': 'fallback'
+    }
+
+    def __init__(self, consecutive_failures=0, cooldown_seconds=None, fallback=None, local_vars_configuration=None):  # noqa: E501
+        """V1alpha1InferenceStepCircuitBreaker - a model defined in OpenAPI"""  # noqa: E501
+        if local_vars_configuration is None:
+            local_vars_configuration = Configuration()
+        self.local_vars_configuration = local_vars_configuration
+
+        self._consecutive_failures = None
+        self._cooldown_seconds = None
+        self._fallback = None
+        self.discriminator = None
+
+        self.consecutive_failures = consecutive_failures
+        if cooldown_seconds is not None:
+            self.cooldown_seconds = cooldown_seconds
+        if fallback is not None:
+            self.fallback = fallback
+
+    @property
+    def consecutive_failures(self):
+        """Gets the consecutive_failures of this V1alpha1InferenceStepCircuitBreaker.  # noqa: E501
+
+        ConsecutiveFailures is the number of consecutive failed calls, after retries, which opens the circuit.  # noqa: E501
+
+        :return: The consecutive_failures of this V1alpha1InferenceStepCircuitBreaker.  # noqa: E501
+        :rtype: int
+        """
+        return self._consecutive_failures
+
+    @consecutive_failures.setter
+    def consecutive_failures(self, consecutive_failures):
+        """Sets the consecutive_failures of this V1alpha1InferenceStepCircuitBreaker.
+
+        ConsecutiveFailures is the number of consecutive failed calls, after retries, which opens the circuit.  # noqa: E501
+
+        :param consecutive_failures: The consecutive_failures of this V1alpha1InferenceStepCircuitBreaker.  # noqa: E501
+        :type: int
+        """
+        if self.local_vars_configuration.client_side_validation and consecutive_failures is None:  # noqa: E501
+            raise ValueError("Invalid value for `consecutive_failures`, must not be `None`")  # noqa: E501
+
+        self._consecutive_failures = consecutive_failures
+
+    @property
+    def cooldown_seconds(self):
+        """Gets the cooldown_seconds of this V1alpha1InferenceStepCircuitBreaker.  # noqa: E501
+
+        CooldownSeconds is the number of seconds the circuit stays open before a call is let through to probe the step again. Defaults to 30 seconds.  # noqa: E501
+
+        :return: The cooldown_seconds of this V1alpha1InferenceStepCircuitBreaker.  # noqa: E501
+        :rtype: int
+        """
+        return self._cooldown_seconds
+
+    @cooldown_seconds.setter
+    def cooldown_seconds(self, cooldown_seconds):
+        """Sets the cooldown_seconds of this V1alpha1InferenceStepCircuitBreaker.
+
+        CooldownSeconds is the number of seconds the circuit stays open before a call is let through to probe the step again. Defaults to 30 seconds.  # noqa: E501
+
+        :param cooldown_seconds: The cooldown_seconds of this V1alpha1InferenceStepCircuitBreaker.  # noqa: E501
+        :type: int
+        """
+
+        self._cooldown_seconds = cooldown_seconds
+
+    @property
+    def fallback(self):
+        """Gets the fallback of this V1alpha1InferenceStepCircuitBreaker.  # noqa: E501
+
+        Fallback is the JSON response returned while the circuit is open. When not specified the step fails immediately with the 503 status code.  # noqa: E501
+
+        :return: The fallback of this V1alpha1InferenceStepCircuitBreaker.  # noqa: E501
+        :rtype: str
+        """
+        return self._fallback
+
+    @fallback.setter
+    def fallback(self, fallback):
+        """Sets the fallback of this V1alpha1InferenceStepCircuitBreaker.
+
+        Fallback is the JSON response returned while the circuit is open. When not specified the step fails immediately with the 503 status code.  # noqa: E501
+
+        :param fallback: The fallback of this V1alpha1InferenceStepCircuitBreaker.  # noqa: E501
+        :type: str
+        """
+
+        self._fallback = fallback
+
+    def to_dict(self):
+        """Returns the model properties as a dict"""
+        result = {}
+
+        for attr, _ in six.iteritems(self.openapi_types):
+            value = getattr(self, attr)
+            if isinstance(value, list):
+                result[attr] = list(map(
+                    lambda x: x.to_dict() if hasattr(x, "to_dict") else x,
+                    value
+                ))
+            elif hasattr(value, "to_dict"):
+                result[attr] = value.to_dict()
+            elif isinstance(value, dict):
+                result[attr] = dict(map(
+                    lambda item: (item[0], item[1].to_dict())
+                    if hasattr(item[1], "to_dict") else item,
+                    value.items()
+                ))
+            else:
+                result[attr] = value
+
+        return result
+
+    def to_str(self):
+        """Returns the string representation of the model"""
+        return pprint.pformat(self.to_dict())
+
+    def __repr__(self):
+        """For `print` and `pprint`"""
+        return self.to_str()
+
+    def __eq__(self, other):
+        """Returns true if both objects are equal"""
+        if not isinstance(other, V1alpha1InferenceStepCircuitBreaker):
+            return False
+
+        return self.to_dict() == other.to_dict()
+
+    def __ne__(self, other):
+        """Returns true if both objects are not equal"""
+        if not isinstance(other, V1alpha1InferenceStepCircuitBreaker):
+            return True
+
+        return self.to_dict() != other.to_dict()
@@ -0,0 +1,165 @@
+# Copyright 2024 The KServe Authors.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#    http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+# coding: utf-8
+
+"""
+    KServe
+
+    Python SDK for KServe  # noqa: E501
+
+    The version of the OpenAPI document: v0.1
+    Generated by: https://openapi-generator.tech
+"""
+
+
+import pprint
+import re  # noqa: F401
+
+import six
+
+from kserve.configuration import Configuration
+
+
+class V1alpha1InferenceStepRetry(object):
+    """NOTE: This class is auto generated by OpenAPI Generator.
+    Ref: https://openapi-generator.tech
+
+    Do not edit the class manually.
+    """
+
+    """
+    Attributes:
+      openapi_types (dict): The key is attribute name
+                            and the value is attribute type.
+      attribute_map (dict): The key is attribute name
+                            and the value is json key in definition.
+    """
+    openapi_types = {
+        'attempts': 'int',
+        'backoff_milliseconds': 'int'
+    }
+
+    attribute_map = {
+        'attempts': 'attempts',
+        'backoff_milliseconds': 'backoffMilliseconds'
+    }
+
+    def __init__(self, attempts=0, backoff_milliseconds=None, local_vars_configuration=None):  # noqa: E501
+        """V1alpha1InferenceStepRetry - a model defined in OpenAPI"""  # noqa: E501
+        if local_vars_configuration is None:
+            local_vars_configuration = Configuration()
+        self.local_vars_configuration = local_vars_configuration
+
+        self._attempts = None
+        self._backoff_milliseconds = None
+        self.discriminator = None
+
+        self.attempts = attempts
+        if backoff_milliseconds is not None:
+            self.backoff_milliseconds = backoff_milliseconds
+
+    @property
+    def attempts(self):
+        """Gets the attempts of this V1alpha1InferenceStepRetry.  # noqa: E501
+
+        Attempts is the maximum number of attempts of the step, including the first one.  # noqa: E501
+
+        :return: The attempts of this V1alpha1InferenceStepRetry.  # noqa: E501
+        :rtype: int
+        """
+        return self._attempts
+
+    @attempts.setter
+    def attempts(self, attempts):
+        """Sets the attempts of this V1alpha1InferenceStepRetry.
+
+        Attempts is the maximum number of attempts of the step, including the first one.  # noqa: E501
+
+        :param attempts: The attempts of this V1alpha1InferenceStepRetry.  # noqa: E501
+        :type: int
+        """
+        if self.local_vars_configuration.client_side_validation and attempts is None:  # noqa: E501
+            raise ValueError("Invalid value for `attempts`, must not be `None`")  # noqa: E501
+
+        self._attempts = attempts
+
+    @property
+    def backoff_milliseconds(self):
+        """Gets the backoff_milliseconds of this V1alpha1InferenceStepRetry.  # noqa: E501
+
+        BackoffMilliseconds is the delay before the first retry, doubled for each subsequent retry. Defaults to 100 milliseconds.  # noqa: E501
+
+        :return: The backoff_milliseconds of this V1alpha1InferenceStepRetry.  # noqa: E501
+        :rtype: int
+        """
+        return self._backoff_milliseconds
+
+    @backoff_milliseconds.setter
+    def backoff_milliseconds(self, backoff_milliseconds):
+        """Sets the backoff_milliseconds of this V1alpha1InferenceStepRetry.
+
+        BackoffMilliseconds is the delay before the first retry, doubled for each subsequent retry. Defaults to 100 milliseconds.  # noqa: E501
+
+        :param backoff_milliseconds: The backoff_milliseconds of this V1alpha1InferenceStepRetry.  # noqa: E501
+        :type: int
+        """
+
+        self._backoff_milliseconds = backoff_milliseconds
+
+    def to_dict(self):
+        """Returns the model properties as a dict"""
+        result = {}
+
+        for attr, _ in six.iteritems(self.openapi_types):
+            value = getattr(self, attr)
+            if isinstance(value, list):
+                result[attr] = list(map(
+                    lambda x: x.to_dict() if hasattr(x, "to_dict") else x,
+                    value
+                ))
+            elif hasattr(value, "to_dict"):
+                result[attr] = value.to_dict()
+            elif isinstance(value, dict):
+                result[attr] = dict(map(
+                    lambda item: (item[0], item[1].to_dict())
+                    if hasattr(item[1], "to_dict") else item,
+                    value.items()
+                ))
+            else:
+                result[attr] = value
+
+        return result
+
+    def to_str(self):
+        """Returns the string representation of the model"""
+        return pprint.pformat(self.to_dict())
+
+    def __repr__(self):
+        """For `print` and `pprint`"""
+        return self.to_str()
+
+    def __eq__(self, other):
+        """Returns true if both objects are equal"""
+        if not isinstance(other, V1alpha1InferenceStepRetry):
+            return False
+
+        return self.to_dict() == other.to_dict()
+
+    def __ne__(self, other):
+        """Returns true if both objects are not equal"""
+        if not isinstance(other, V1alpha1InferenceStepRetry):
+            return True
+
+        return self.to_dict() != other.to_dict()
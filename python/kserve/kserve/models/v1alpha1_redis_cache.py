@@ -0,0 +1,219 @@
+# Copyright 2024 The KServe Authors.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#    http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+# coding: utf-8
+
+"""
+    KServe
+
+    Python SDK for KServe  # noqa: E501
+
+    The version of the OpenAPI document: v0.1
+    Generated by: https://openapi-generator.tech
+"""
+
+
+import pprint
+import re  # noqa: F401
+
+import six
+
+from kserve.configuration import Configuration
+
+
+class V1alpha1RedisCache(object):
+    """NOTE: This class is auto generated by OpenAPI Generator.
+    Ref: https://openapi-generator.tech
+
+    Do not edit the class manually.
+    """
+
+    """
+    Attributes:
+      openapi_types (dict): The key is attribute name
+                            and the value is attribute type.
+      attribute_map (dict): The key is attribute name
+                            and the value is json key in definition.
+    """
+    openapi_types = {
+        'address': 'str',
+        'database': 'int',
+        'password_secret_ref': 'V1SecretKeySelector',
+        'tls': 'V1alpha1RedisCacheTLS'
+    }
+
+    attribute_map = {
+        'address': 'address',
+        'database': 'database',
+        'password_secret_ref': 'passwordSecretRef',
+        'tls': 'tls'
+    }
+
+    def __init__(self, address='', database=None, password_secret_ref=None, tls=None, local_vars_configuration=None):  # noqa: E501
+        """V1alpha1RedisCache - a model defined in OpenAPI"""  # noqa: E501
+        if local_vars_configuration is None:
+            local_vars_configuration = Configuration()
+        self.local_vars_configuration = local_vars_configuration
+
+        self._address = None
+        self._database = None
+        self._password_secret_ref = None
+        self._tls = None
+        self.discriminator = None
+
+        self.address = address
+        if database is not None:
+            self.database = database
+        if password_secret_ref is not None:
+            self.password_secret_ref = password_secret_ref
+        if tls is not None:
+            self.tls = tls
+
+    @property
+    def address(self):
+        """Gets the address of this V1alpha1RedisCache.  # noqa: E501
+
+        Address of the Redis server in the host:port form.  # noqa: E501
+
+        :return: The address of this V1alpha1RedisCache.  # noqa: E501
+        :rtype: str
+        """
+        return self._address
+
+    @address.setter
+    def address(self, address):
+        """Sets the address of this V1alpha1RedisCache.
+
+        Address of the Redis server in the host:port form.  # noqa: E501
+
+        :param address: The address of this V1alpha1RedisCache.  # noqa: E501
+        :type: str
+        """
+        if self.local_vars_configuration.client_side_validation and address is None:  # noqa: E501
+            raise ValueError("Invalid value for `address`, must not be `None`")  # noqa: E501
+
+        self._address = address
+
+    @property
+    def database(self):
+        """Gets the database of this V1alpha1RedisCache.  # noqa: E501
+
+        Database number, defaults to 0.  # noqa: E501
+
+        :return: The database of this V1alpha1RedisCache.  # noqa: E501
+        :rtype: int
+        """
+        return self._database
+
+    @database.setter
+    def database(self, database):
+        """Sets the database of this V1alpha1RedisCache.
+
+        Database number, defaults to 0.  # noqa: E501
+
+        :param database: The database of this V1alpha1RedisCache.  # noqa: E501
+        :type: int
+        """
+
+        self._database = database
+
+    @property
+    def password_secret_ref(self):
+        """Gets the password_secret_ref of this V1alpha1RedisCache.  # noqa: E501
+
+        PasswordSecretRef references the secret key holding the password of the Redis server.  # noqa: E501
+
+        :return: The password_secret_ref of this V1alpha1RedisCache.  # noqa: E501
+        :rtype: V1SecretKeySelector
+        """
+        return self._password_secret_ref
+
+    @password_secret_ref.setter
+    def password_secret_ref(self, password_secret_ref):
+        """Sets the password_secret_ref of this V1alpha1RedisCache.
+
+        PasswordSecretRef references the secret key holding the password of the Redis server.  # noqa: E501
+
+        :param password_secret_ref: The password_secret_ref of this V1alpha1RedisCache.  # noqa: E501
+        :type: V1SecretKeySelector
+        """
+
+        self._password_secret_ref = password_secret_ref
+
+    @property
+    def tls(self):
+        """Gets the tls of this V1alpha1RedisCache.  # noqa: E501
+
+
+        :return: The tls of this V1alpha1RedisCache.  # noqa: E501
+        :rtype: V1alpha1RedisCacheTLS
+        """
+        return self._tls
+
+    @tls.setter
+    def tls(self, tls):
+        """Sets the tls of this V1alpha1RedisCache.
+
+
+        :param tls: The tls of this V1alpha1RedisCache.  # noqa: E501
+        :type: V1alpha1RedisCacheTLS
+        """
+
+        self._tls = tls
+
+    def to_dict(self):
+        """Returns the model properties as a dict"""
+        result = {}
+
+        for attr, _ in six.iteritems(self.openapi_types):
+            value = getattr(self, attr)
+            if isinstance(value, list):
+                result[attr] = list(map(
+                    lambda x: x.to_dict() if hasattr(x, "to_dict") else x,
+                    value
+                ))
+            elif hasattr(value, "to_dict"):
+                result[attr] = value.to_dict()
+            elif isinstance(value, dict):
+                result[attr] = dict(map(
+                    lambda item: (item[0], item[1].to_dict())
+                    if hasattr(item[1], "to_dict") else item,
+                    value.items()
+                ))
+            else:
+                result[attr] = value
+
+        return result
+
+    def to_str(self):
+        """Returns the string representation of the model"""
+        return pprint.pformat(self.to_dict())
+
+    def __repr__(self):
+        """For `print` and `pprint`"""
+        return self.to_str()
+
+    def __eq__(self, other):
+        """Returns true if both objects are equal"""
+        if not isinstance(other, V1alpha1RedisCache):
+            return False
+
+        return self.to_dict() == other.to_dict()
+
+    def __ne__(self, other):
+        """Returns true if both objects are not equal"""
+        if not isinstance(other, V1alpha1RedisCache):
+            return True
+
+        return self.to_dict() != other.to_dict()
@@ -56,6 +56,7 @@ class V1alpha1ServingRuntimeSpec(object):
         'grpc_endpoint': 'str',
         'http_data_endpoint': 'str',
         'image_pull_secrets': 'list[V1LocalObjectReference]',
+        'inherit_from': 'str',
         'labels': 'dict(str, str)',
         'multi_model': 'bool',
         'node_selector': 'dict(str, str)',
@@ -77,6 +78,7 @@ class V1alpha1ServingRuntimeSpec(object):
         'grpc_endpoint': 'grpcEndpoint',
         'http_data_endpoint': 'httpDataEndpoint',
         'image_pull_secrets': 'imagePullSecrets',
+        'inherit_from': 'inheritFrom',
         'labels': 'labels',
         'multi_model': 'multiModel',
         'node_selector': 'nodeSelector',
@@ -88,7 +90,7 @@ class V1alpha1ServingRuntimeSpec(object):
         'volumes': 'volumes'
     }
 
-    def __init__(self, affinity=None, annotations=None, built_in_adapter=None, containers=None, disabled=None, grpc_data_endpoint=None, grpc_endpoint=None, http_data_endpoint=None, image_pull_secrets=None, labels=None, multi_model=None, node_selector=None, protocol_versions=None, replicas=None, storage_helper=None, supported_model_formats=None, tolerations=None, volumes=None, local_vars_configuration=None):  # noqa: E501
+    def __init__(self, affinity=None, annotations=None, built_in_adapter=None, containers=None, disabled=None, grpc_data_endpoint=None, grpc_endpoint=None, http_data_endpoint=None, image_pull_secrets=None, inherit_from=None, labels=None, multi_model=None, node_selector=None, protocol_versions=None, replicas=None, storage_helper=None, supported_model_formats=None, tolerations=None, volumes=None, local_vars_configuration=None):  # noqa: E501
         """V1alpha1ServingRuntimeSpec - a model defined in OpenAPI"""  # noqa: E501
         if local_vars_configuration is None:
             local_vars_configuration = Configuration()
@@ -103,6 +105,7 @@ class V1alpha1ServingRuntimeSpec(object):
         self._grpc_endpoint = None
         self._http_data_endpoint = None
         self._image_pull_secrets = None
+        self._inherit_from = None
         self._labels = None
         self._multi_model = None
         self._node_selector = None
@@ -131,6 +134,8 @@ class V1alpha1ServingRuntimeSpec(object):
             self.http_data_endpoint = http_data_endpoint
         if image_pull_secrets is not None:
             self.image_pull_secrets = image_pull_secrets
+        if inherit_from is not None:
+            self.inherit_from = inherit_from
         if labels is not None:
             self.labels = labels
         if multi_model is not None:
@@ -355,6 +360,29 @@ class V1alpha1ServingRuntimeSpec(object):
 
         self._image_pull_secrets = image_pull_secrets
 
+    @property
+    def inherit_from(self):
+        """Gets the inherit_from of this V1alpha1ServingRuntimeSpec.  # noqa: E501
+
+        Name of the ClusterServingRuntime this ServingRuntime inherits from. The fields set in this ServingRuntime are strategic merged over the spec of the ClusterServingRuntime: containers are merged by name, other lists are replaced. Only supported by namespaced ServingRuntimes.  # noqa: E501
+
+        :return: The inherit_from of this V1alpha1ServingRuntimeSpec.  # noqa: E501
+        :rtype: str
+        """
+        return self._inherit_from
+
+    @inherit_from.setter
+    def inherit_from(self, inherit_from):
+        """Sets the inherit_from of this V1alpha1ServingRuntimeSpec.
+
+        Name of the ClusterServingRuntime this ServingRuntime inherits from. The fields set in this ServingRuntime are strategic merged over the spec of the ClusterServingRuntime: containers are merged by name, other lists are replaced. Only supported by namespaced ServingRuntimes.  # noqa: E501
+
+        :param inherit_from: The inherit_from of this V1alpha1ServingRuntimeSpec.  # noqa: E501
+        :type: str
+        """
+
+        self._inherit_from = inherit_from
+
     @property
     def labels(self):
         """Gets the labels of this V1alpha1ServingRuntimeSpec.  # noqa: E501
@@ -48,25 +48,30 @@ class V1alpha1StorageContainerSpec(object):
     """
     openapi_types = {
         'container': 'V1Container',
+        'priority': 'int',
         'supported_uri_formats': 'list[V1alpha1SupportedUriFormat]'
     }
 
     attribute_map = {
         'container': 'container',
+        'priority': 'priority',
         'supported_uri_formats': 'supportedUriFormats'
     }
 
-    def __init__(self, container=None, supported_uri_formats=None, local_vars_configuration=None):  # noqa: E501
+    def __init__(self, container=None, priority=None, supported_uri_formats=None, local_vars_configuration=None):  # noqa: E501
         """V1alpha1StorageContainerSpec - a model defined in OpenAPI"""  # noqa: E501
         if local_vars_configuration is None:
             local_vars_configuration = Configuration()
         self.local_vars_configuration = local_vars_configuration
 
         self._container = None
+        self._priority = None
         self._supported_uri_formats = None
         self.discriminator = None
 
         self.container = container
+        if priority is not None:
+            self.priority = priority
         self.supported_uri_formats = supported_uri_formats
 
     @property
@@ -92,6 +97,29 @@ class V1alpha1StorageContainerSpec(object):
 
         self._container = container
 
+    @property
+    def priority(self):
+        """Gets the priority of this V1alpha1StorageContainerSpec.  # noqa: E501
+
+        Priority of this container when several storage containers support the same storage URI, the container with the highest priority is selected. Defaults to 0.  # noqa: E501
+
+        :return: The priority of this V1alpha1StorageContainerSpec.  # noqa: E501
+        :rtype: int
+        """
+        return self._priority
+
+    @priority.setter
+    def priority(self, priority):
+        """Sets the priority of this V1alpha1StorageContainerSpec.
+
+        Priority of this container when several storage containers support the same storage URI, the container with the highest priority is selected. Defaults to 0.  # noqa: E501
+
+        :param priority: The priority of this V1alpha1StorageContainerSpec.  # noqa: E501
+        :type: int
+        """
+
+        self._priority = priority
+
     @property
     def supported_uri_formats(self):
         """Gets the supported_uri_formats of this V1alpha1StorageContainerSpec.  # noqa: E501
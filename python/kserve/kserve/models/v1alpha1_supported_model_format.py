@@ -154,7 +154,7 @@ class V1alpha1SupportedModelFormat(object):
     def version(self):
         """Gets the version of this V1alpha1SupportedModelFormat.  # noqa: E501
 
-        Version of the model format. Used in validating that a predictor is supported by a runtime. Can be \"major\", \"major.minor\" or \"major.minor.patch\".  # noqa: E501
+        Version of the model format. Used in validating that a predictor is supported by a runtime. Can be \"major\", \"major.minor\" or \"major.minor.patch\", or a range of versions such as \">=1.2, <2\", \"^1.2\" or \"~1.2\", alternatives being separated by \"||\".  # noqa: E501
 
         :return: The version of this V1alpha1SupportedModelFormat.  # noqa: E501
         :rtype: str
@@ -165,7 +165,7 @@ class V1alpha1SupportedModelFormat(object):
     def version(self, version):
         """Sets the version of this V1alpha1SupportedModelFormat.
 
-        Version of the model format. Used in validating that a predictor is supported by a runtime. Can be \"major\", \"major.minor\" or \"major.minor.patch\".  # noqa: E501
+        Version of the model format. Used in validating that a predictor is supported by a runtime. Can be \"major\", \"major.minor\" or \"major.minor.patch\", or a range of versions such as \">=1.2, <2\", \"^1.2\" or \"~1.2\", alternatives being separated by \"||\".  # noqa: E501
 
         :param version: The version of this V1alpha1SupportedModelFormat.  # noqa: E501
         :type: str
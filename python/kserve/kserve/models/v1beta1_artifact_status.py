@@ -0,0 +1,194 @@
+# Copyright 2024 The KServe Authors.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#    http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+# coding: utf-8
+
+"""
+    KServe
+
+    Python SDK for KServe  # noqa: E501
+
+    The version of the OpenAPI document: v0.1
+    Generated by: https://openapi-generator.tech
+"""
+
+
+import pprint
+import re  # noqa: F401
+
+import six
+
+from kserve.configuration import Configuration
+
+
+class V1beta1ArtifactStatus(object):
+    """NOTE: This class is auto generated by OpenAPI Generator.
+    Ref: https://openapi-generator.tech
+
+    Do not edit the class manually.
+    """
+
+    """
+    Attributes:
+      openapi_types (dict): The key is attribute name
+                            and the value is attribute type.
+      attribute_map (dict): The key is attribute name
+                            and the value is json key in definition.
+    """
+    openapi_types = {
+        'failure_info': 'V1beta1FailureInfo',
+        'name': 'str',
+        'state': 'str'
+    }
+
+    attribute_map = {
+        'failure_info': 'failureInfo',
+        'name': 'name',
+        'state': 'state'
+    }
+
+    def __init__(self, failure_info=None, name='', state='', local_vars_configuration=None):  # noqa: E501
+        """V1beta1ArtifactStatus - a model defined in OpenAPI"""  # noqa: E501
+        if local_vars_configuration is None:
+            local_vars_configuration = Configuration()
+        self.local_vars_configuration = local_vars_configuration
+
+        self._failure_info = None
+        self._name = None
+        self._state = None
+        self.discriminator = None
+
+        if failure_info is not None:
+            self.failure_info = failure_info
+        self.name = name
+        self.state = state
+
+    @property
+    def failure_info(self):
+        """Gets the failure_info of this V1beta1ArtifactStatus.  # noqa: E501
+
+        Details of the failure, when the download of the artifact is failed.  # noqa: E501
+
+        :return: The failure_info of this V1beta1ArtifactStatus.  # noqa: E501
+        :rtype: V1beta1FailureInfo
+        """
+        return self._failure_info
+
+    @failure_info.setter
+    def failure_info(self, failure_info):
+        """Sets the failure_info of this V1beta1ArtifactStatus.
+
+        Details of the failure, when the download of the artifact is failed.  # noqa: E501
+
+        :param failure_info: The failure_info of this V1beta1ArtifactStatus.  # noqa: E501
+        :type: V1beta1FailureInfo
+        """
+
+        self._failure_info = failure_info
+
+    @property
+    def name(self):
+        """Gets the name of this V1beta1ArtifactStatus.  # noqa: E501
+
+        Name of the adapter, or "model" for the storageUri of the model.  # noqa: E501
+
+        :return: The name of this V1beta1ArtifactStatus.  # noqa: E501
+        :rtype: str
+        """
+        return self._name
+
+    @name.setter
+    def name(self, name):
+        """Sets the name of this V1beta1ArtifactStatus.
+
+        Name of the adapter, or "model" for the storageUri of the model.  # noqa: E501
+
+        :param name: The name of this V1beta1ArtifactStatus.  # noqa: E501
+        :type: str
+        """
+        if self.local_vars_configuration.client_side_validation and name is None:  # noqa: E501
+            raise ValueError("Invalid value for `name`, must not be `None`")  # noqa: E501
+
+        self._name = name
+
+    @property
+    def state(self):
+        """Gets the state of this V1beta1ArtifactStatus.  # noqa: E501
+
+        Download state of the artifact: Pending, Loading, Loaded, FailedToLoad  # noqa: E501
+
+        :return: The state of this V1beta1ArtifactStatus.  # noqa: E501
+        :rtype: str
+        """
+        return self._state
+
+    @state.setter
+    def state(self, state):
+        """Sets the state of this V1beta1ArtifactStatus.
+
+        Download state of the artifact: Pending, Loading, Loaded, FailedToLoad  # noqa: E501
+
+        :param state: The state of this V1beta1ArtifactStatus.  # noqa: E501
+        :type: str
+        """
+        if self.local_vars_configuration.client_side_validation and state is None:  # noqa: E501
+            raise ValueError("Invalid value for `state`, must not be `None`")  # noqa: E501
+
+        self._state = state
+
+    def to_dict(self):
+        """Returns the model properties as a dict"""
+        result = {}
+
+        for attr, _ in six.iteritems(self.openapi_types):
+            value = getattr(self, attr)
+            if isinstance(value, list):
+                result[attr] = list(map(
+                    lambda x: x.to_dict() if hasattr(x, "to_dict") else x,
+                    value
+                ))
+            elif hasattr(value, "to_dict"):
+                result[attr] = value.to_dict()
+            elif isinstance(value, dict):
+                result[attr] = dict(map(
+                    lambda item: (item[0], item[1].to_dict())
+                    if hasattr(item[1], "to_dict") else item,
+                    value.items()
+                ))
+            else:
+                result[attr] = value
+
+        return result
+
+    def to_str(self):
+        """Returns the string representation of the model"""
+        return pprint.pformat(self.to_dict())
+
+    def __repr__(self):
+        """For `print` and `pprint`"""
+        return self.to_str()
+
+    def __eq__(self, other):
+        """Returns true if both objects are equal"""
+        if not isinstance(other, V1beta1ArtifactStatus):
+            return False
+
+        return self.to_dict() == other.to_dict()
+
+    def __ne__(self, other):
+        """Returns true if both objects are not equal"""
+        if not isinstance(other, V1beta1ArtifactStatus):
+            return True
+
+        return self.to_dict() != other.to_dict()
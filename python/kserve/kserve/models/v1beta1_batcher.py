@@ -48,31 +48,41 @@ class V1beta1Batcher(object):
     """
     openapi_types = {
         'max_batch_size': 'int',
+        'max_in_flight': 'int',
         'max_latency': 'int',
+        'max_queue_size': 'int',
         'timeout': 'int'
     }
 
     attribute_map = {
         'max_batch_size': 'maxBatchSize',
+        'max_in_flight': 'maxInFlight',
         'max_latency': 'maxLatency',
+        'max_queue_size': 'maxQueueSize',
         'timeout': 'timeout'
     }
 
-    def __init__(self, max_batch_size=None, max_latency=None, timeout=None, local_vars_configuration=None):  # noqa: E501
+    def __init__(self, max_batch_size=None, max_in_flight=None, max_latency=None, max_queue_size=None, timeout=None, local_vars_configuration=None):  # noqa: E501
         """V1beta1Batcher - a model defined in OpenAPI"""  # noqa: E501
         if local_vars_configuration is None:
             local_vars_configuration = Configuration()
         self.local_vars_configuration = local_vars_configuration
 
         self._max_batch_size = None
+        self._max_in_flight = None
         self._max_latency = None
+        self._max_queue_size = None
         self._timeout = None
         self.discriminator = None
 
         if max_batch_size is not None:
             self.max_batch_size = max_batch_size
+        if max_in_flight is not None:
+            self.max_in_flight = max_in_flight
         if max_latency is not None:
             self.max_latency = max_latency
+        if max_queue_size is not None:
+            self.max_queue_size = max_queue_size
         if timeout is not None:
             self.timeout = timeout
 
@@ -99,6 +109,29 @@ class V1beta1Batcher(object):
 
         self._max_batch_size = max_batch_size
 
+    @property
+    def max_in_flight(self):
+        """Gets the max_in_flight of this V1beta1Batcher.  # noqa: E501
+
+        Specifies the max number of batches sent concurrently to the model, unlimited if not set  # noqa: E501
+
+        :return: The max_in_flight of this V1beta1Batcher.  # noqa: E501
+        :rtype: int
+        """
+        return self._max_in_flight
+
+    @max_in_flight.setter
+    def max_in_flight(self, max_in_flight):
+        """Sets the max_in_flight of this V1beta1Batcher.
+
+        Specifies the max number of batches sent concurrently to the model, unlimited if not set  # noqa: E501
+
+        :param max_in_flight: The max_in_flight of this V1beta1Batcher.  # noqa: E501
+        :type: int
+        """
+
+        self._max_in_flight = max_in_flight
+
     @property
     def max_latency(self):
         """Gets the max_latency of this V1beta1Batcher.  # noqa: E501
@@ -122,6 +155,29 @@ class V1beta1Batcher(object):
 
         self._max_latency = max_latency
 
+    @property
+    def max_queue_size(self):
+        """Gets the max_queue_size of this V1beta1Batcher.  # noqa: E501
+
+        Specifies the max number of requests waiting to be sent to the model, the further requests being rejected with the 429 status code, unlimited if not set  # noqa: E501
+
+        :return: The max_queue_size of this V1beta1Batcher.  # noqa: E501
+        :rtype: int
+        """
+        return self._max_queue_size
+
+    @max_queue_size.setter
+    def max_queue_size(self, max_queue_size):
+        """Sets the max_queue_size of this V1beta1Batcher.
+
+        Specifies the max number of requests waiting to be sent to the model, the further requests being rejected with the 429 status code, unlimited if not set  # noqa: E501
+
+        :param max_queue_size: The max_queue_size of this V1beta1Batcher.  # noqa: E501
+        :type: int
+        """
+
+        self._max_queue_size = max_queue_size
+
     @property
     def timeout(self):
         """Gets the timeout of this V1beta1Batcher.  # noqa: E501
@@ -52,12 +52,16 @@ class V1beta1ComponentExtensionSpec(object):
         'canary_traffic_percent': 'int',
         'container_concurrency': 'int',
         'deployment_strategy': 'K8sIoApiAppsV1DeploymentStrategy',
+        'initial_scale': 'int',
         'labels': 'dict(str, str)',
         'logger': 'V1beta1LoggerSpec',
         'max_replicas': 'int',
         'min_replicas': 'int',
+        'retries': 'V1beta1RetrySpec',
+        'scale_down_delay': 'str',
         'scale_metric': 'str',
         'scale_target': 'int',
+        'scale_window': 'str',
         'timeout': 'int'
     }
 
@@ -67,16 +71,20 @@ class V1beta1ComponentExtensionSpec(object):
         'canary_traffic_percent': 'canaryTrafficPercent',
         'container_concurrency': 'containerConcurrency',
         'deployment_strategy': 'deploymentStrategy',
+        'initial_scale': 'initialScale',
         'labels': 'labels',
         'logger': 'logger',
         'max_replicas': 'maxReplicas',
         'min_replicas': 'minReplicas',
+        'retries': 'retries',
+        'scale_down_delay': 'scaleDownDelay',
         'scale_metric': 'scaleMetric',
         'scale_target': 'scaleTarget',
+        'scale_window': 'scaleWindow',
         'timeout': 'timeout'
     }
 
-    def __init__(self, annotations=None, batcher=None, canary_traffic_percent=None, container_concurrency=None, deployment_strategy=None, labels=None, logger=None, max_replicas=None, min_replicas=None, scale_metric=None, scale_target=None, timeout=None, local_vars_configuration=None):  # noqa: E501
+    def __init__(self, annotations=None, batcher=None, canary_traffic_percent=None, container_concurrency=None, deployment_strategy=None, initial_scale=None, labels=None, logger=None, max_replicas=None, min_replicas=None, retries=None, scale_down_delay=None, scale_metric=None, scale_target=None, scale_window=None, timeout=None, local_vars_configuration=None):  # noqa: E501
         """V1beta1ComponentExtensionSpec - a model defined in OpenAPI"""  # noqa: E501
         if local_vars_configuration is None:
             local_vars_configuration = Configuration()
@@ -87,12 +95,16 @@ class V1beta1ComponentExtensionSpec(object):
         self._canary_traffic_percent = None
         self._container_concurrency = None
         self._deployment_strategy = None
+        self._initial_scale = None
         self._labels = None
         self._logger = None
         self._max_replicas = None
         self._min_replicas = None
+        self._retries = None
+        self._scale_down_delay = None
         self._scale_metric = None
         self._scale_target = None
+        self._scale_window = None
         self._timeout = None
         self.discriminator = None
 
@@ -106,6 +118,8 @@ class V1beta1ComponentExtensionSpec(object):
             self.container_concurrency = container_concurrency
         if deployment_strategy is not None:
             self.deployment_strategy = deployment_strategy
+        if initial_scale is not None:
+            self.initial_scale = initial_scale
         if labels is not None:
             self.labels = labels
         if logger is not None:
@@ -114,10 +128,16 @@ class V1beta1ComponentExtensionSpec(object):
             self.max_replicas = max_replicas
         if min_replicas is not None:
             self.min_replicas = min_replicas
+        if retries is not None:
+            self.retries = retries
+        if scale_down_delay is not None:
+            self.scale_down_delay = scale_down_delay
         if scale_metric is not None:
             self.scale_metric = scale_metric
         if scale_target is not None:
             self.scale_target = scale_target
+        if scale_window is not None:
+            self.scale_window = scale_window
         if timeout is not None:
             self.timeout = timeout
 
@@ -192,7 +212,7 @@ class V1beta1ComponentExtensionSpec(object):
     def container_concurrency(self):
         """Gets the container_concurrency of this V1beta1ComponentExtensionSpec.  # noqa: E501
 
-        ContainerConcurrency specifies how many requests can be processed concurrently, this sets the hard limit of the container concurrency(https://knative.dev/docs/serving/autoscaling/concurrency).  # noqa: E501
+        ContainerConcurrency specifies how many requests can be processed concurrently, this sets the hard limit of the container concurrency(https://knative.dev/docs/serving/autoscaling/concurrency) independently of the soft ScaleTarget, 0 means unlimited.  # noqa: E501
 
         :return: The container_concurrency of this V1beta1ComponentExtensionSpec.  # noqa: E501
         :rtype: int
@@ -203,7 +223,7 @@ class V1beta1ComponentExtensionSpec(object):
     def container_concurrency(self, container_concurrency):
         """Sets the container_concurrency of this V1beta1ComponentExtensionSpec.
 
-        ContainerConcurrency specifies how many requests can be processed concurrently, this sets the hard limit of the container concurrency(https://knative.dev/docs/serving/autoscaling/concurrency).  # noqa: E501
+        ContainerConcurrency specifies how many requests can be processed concurrently, this sets the hard limit of the container concurrency(https://knative.dev/docs/serving/autoscaling/concurrency) independently of the soft ScaleTarget, 0 means unlimited.  # noqa: E501
 
         :param container_concurrency: The container_concurrency of this V1beta1ComponentExtensionSpec.  # noqa: E501
         :type: int
@@ -232,6 +252,29 @@ class V1beta1ComponentExtensionSpec(object):
 
         self._deployment_strategy = deployment_strategy
 
+    @property
+    def initial_scale(self):
+        """Gets the initial_scale of this V1beta1ComponentExtensionSpec.  # noqa: E501
+
+        InitialScale is the number of replicas a new revision scales to before it is marked ready, it sets the autoscaling.knative.dev/initial-scale annotation. Only applicable for serverless mode.  # noqa: E501
+
+        :return: The initial_scale of this V1beta1ComponentExtensionSpec.  # noqa: E501
+        :rtype: int
+        """
+        return self._initial_scale
+
+    @initial_scale.setter
+    def initial_scale(self, initial_scale):
+        """Sets the initial_scale of this V1beta1ComponentExtensionSpec.
+
+        InitialScale is the number of replicas a new revision scales to before it is marked ready, it sets the autoscaling.knative.dev/initial-scale annotation. Only applicable for serverless mode.  # noqa: E501
+
+        :param initial_scale: The initial_scale of this V1beta1ComponentExtensionSpec.  # noqa: E501
+        :type: int
+        """
+
+        self._initial_scale = initial_scale
+
     @property
     def labels(self):
         """Gets the labels of this V1beta1ComponentExtensionSpec.  # noqa: E501
@@ -322,6 +365,50 @@ class V1beta1ComponentExtensionSpec(object):
 
         self._min_replicas = min_replicas
 
+    @property
+    def retries(self):
+        """Gets the retries of this V1beta1ComponentExtensionSpec.  # noqa: E501
+
+
+        :return: The retries of this V1beta1ComponentExtensionSpec.  # noqa: E501
+        :rtype: V1beta1RetrySpec
+        """
+        return self._retries
+
+    @retries.setter
+    def retries(self, retries):
+        """Sets the retries of this V1beta1ComponentExtensionSpec.
+
+
+        :param retries: The retries of this V1beta1ComponentExtensionSpec.  # noqa: E501
+        :type: V1beta1RetrySpec
+        """
+
+        self._retries = retries
+
+    @property
+    def scale_down_delay(self):
+        """Gets the scale_down_delay of this V1beta1ComponentExtensionSpec.  # noqa: E501
+
+        ScaleDownDelay is the duration the request load must stay low before the revision scales down, e.g. 15m, it sets the autoscaling.knative.dev/scale-down-delay annotation. Only applicable for serverless mode.  # noqa: E501
+
+        :return: The scale_down_delay of this V1beta1ComponentExtensionSpec.  # noqa: E501
+        :rtype: str
+        """
+        return self._scale_down_delay
+
+    @scale_down_delay.setter
+    def scale_down_delay(self, scale_down_delay):
+        """Sets the scale_down_delay of this V1beta1ComponentExtensionSpec.
+
+        ScaleDownDelay is the duration the request load must stay low before the revision scales down, e.g. 15m, it sets the autoscaling.knative.dev/scale-down-delay annotation. Only applicable for serverless mode.  # noqa: E501
+
+        :param scale_down_delay: The scale_down_delay of this V1beta1ComponentExtensionSpec.  # noqa: E501
+        :type: str
+        """
+
+        self._scale_down_delay = scale_down_delay
+
     @property
     def scale_metric(self):
         """Gets the scale_metric of this V1beta1ComponentExtensionSpec.  # noqa: E501
@@ -368,6 +455,29 @@ class V1beta1ComponentExtensionSpec(object):
 
         self._scale_target = scale_target
 
+    @property
+    def scale_window(self):
+        """Gets the scale_window of this V1beta1ComponentExtensionSpec.  # noqa: E501
+
+        ScaleWindow is the duration of the window over which the autoscaler averages the metric, e.g. 120s, it sets the autoscaling.knative.dev/window annotation. Only applicable for serverless mode.  # noqa: E501
+
+        :return: The scale_window of this V1beta1ComponentExtensionSpec.  # noqa: E501
+        :rtype: str
+        """
+        return self._scale_window
+
+    @scale_window.setter
+    def scale_window(self, scale_window):
+        """Sets the scale_window of this V1beta1ComponentExtensionSpec.
+
+        ScaleWindow is the duration of the window over which the autoscaler averages the metric, e.g. 120s, it sets the autoscaling.knative.dev/window annotation. Only applicable for serverless mode.  # noqa: E501
+
+        :param scale_window: The scale_window of this V1beta1ComponentExtensionSpec.  # noqa: E501
+        :type: str
+        """
+
+        self._scale_window = scale_window
+
     @property
     def timeout(self):
         """Gets the timeout of this V1beta1ComponentExtensionSpec.  # noqa: E501
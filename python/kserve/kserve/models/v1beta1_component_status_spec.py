@@ -48,11 +48,15 @@ class V1beta1ComponentStatusSpec(object):
     """
     openapi_types = {
         'address': 'KnativeAddressable',
+        'desired_replicas': 'int',
         'grpc_url': 'KnativeURL',
+        'iam_service_account_name': 'str',
         'latest_created_revision': 'str',
         'latest_ready_revision': 'str',
         'latest_rolledout_revision': 'str',
         'previous_rolledout_revision': 'str',
+        'ready_replicas': 'int',
+        'replicas': 'int',
         'rest_url': 'KnativeURL',
         'traffic': 'list[KnativeDevServingPkgApisServingV1TrafficTarget]',
         'url': 'KnativeURL'
@@ -60,28 +64,36 @@ class V1beta1ComponentStatusSpec(object):
 
     attribute_map = {
         'address': 'address',
+        'desired_replicas': 'desiredReplicas',
         'grpc_url': 'grpcUrl',
+        'iam_service_account_name': 'iamServiceAccountName',
         'latest_created_revision': 'latestCreatedRevision',
         'latest_ready_revision': 'latestReadyRevision',
         'latest_rolledout_revision': 'latestRolledoutRevision',
         'previous_rolledout_revision': 'previousRolledoutRevision',
+        'ready_replicas': 'readyReplicas',
+        'replicas': 'replicas',
         'rest_url': 'restUrl',
         'traffic': 'traffic',
         'url': 'url'
     }
 
-    def __init__(self, address=None, grpc_url=None, latest_created_revision=None, latest_ready_revision=None, latest_rolledout_revision=None, previous_rolledout_revision=None, rest_url=None, traffic=None, url=None, local_vars_configuration=None):  # noqa: E501
+    def __init__(self, address=None, desired_replicas=None, grpc_url=None, iam_service_account_name=None, latest_created_revision=None, latest_ready_revision=None, latest_rolledout_revision=None, previous_rolledout_revision=None, ready_replicas=None, replicas=None, rest_url=None, traffic=None, url=None, local_vars_configuration=None):  # noqa: E501
         """V1beta1ComponentStatusSpec - a model defined in OpenAPI"""  # noqa: E501
         if local_vars_configuration is None:
             local_vars_configuration = Configuration()
         self.local_vars_configuration = local_vars_configuration
 
         self._address = None
+        self._desired_replicas = None
         self._grpc_url = None
+        self._iam_service_account_name = None
         self._latest_created_revision = None
         self._latest_ready_revision = None
         self._latest_rolledout_revision = None
         self._previous_rolledout_revision = None
+        self._ready_replicas = None
+        self._replicas = None
         self._rest_url = None
         self._traffic = None
         self._url = None
@@ -89,8 +101,12 @@ class V1beta1ComponentStatusSpec(object):
 
         if address is not None:
             self.address = address
+        if desired_replicas is not None:
+            self.desired_replicas = desired_replicas
         if grpc_url is not None:
             self.grpc_url = grpc_url
+        if iam_service_account_name is not None:
+            self.iam_service_account_name = iam_service_account_name
         if latest_created_revision is not None:
             self.latest_created_revision = latest_created_revision
         if latest_ready_revision is not None:
@@ -99,6 +115,10 @@ class V1beta1ComponentStatusSpec(object):
             self.latest_rolledout_revision = latest_rolledout_revision
         if previous_rolledout_revision is not None:
             self.previous_rolledout_revision = previous_rolledout_revision
+        if ready_replicas is not None:
+            self.ready_replicas = ready_replicas
+        if replicas is not None:
+            self.replicas = replicas
         if rest_url is not None:
             self.rest_url = rest_url
         if traffic is not None:
@@ -127,6 +147,29 @@ class V1beta1ComponentStatusSpec(object):
 
         self._address = address
 
+    @property
+    def desired_replicas(self):
+        """Gets the desired_replicas of this V1beta1ComponentStatusSpec.  # noqa: E501
+
+        Number of replicas desired by the autoscaler of the component, unset when the component is not scaled by an HPA.  # noqa: E501
+
+        :return: The desired_replicas of this V1beta1ComponentStatusSpec.  # noqa: E501
+        :rtype: int
+        """
+        return self._desired_replicas
+
+    @desired_replicas.setter
+    def desired_replicas(self, desired_replicas):
+        """Sets the desired_replicas of this V1beta1ComponentStatusSpec.
+
+        Number of replicas desired by the autoscaler of the component, unset when the component is not scaled by an HPA.  # noqa: E501
+
+        :param desired_replicas: The desired_replicas of this V1beta1ComponentStatusSpec.  # noqa: E501
+        :type: int
+        """
+
+        self._desired_replicas = desired_replicas
+
     @property
     def grpc_url(self):
         """Gets the grpc_url of this V1beta1ComponentStatusSpec.  # noqa: E501
@@ -148,6 +191,29 @@ class V1beta1ComponentStatusSpec(object):
 
         self._grpc_url = grpc_url
 
+    @property
+    def iam_service_account_name(self):
+        """Gets the iam_service_account_name of this V1beta1ComponentStatusSpec.  # noqa: E501
+
+        Name of the service account created for the component to access its storage with a cloud IAM identity, it is deleted once the component no longer uses it.  # noqa: E501
+
+        :return: The iam_service_account_name of this V1beta1ComponentStatusSpec.  # noqa: E501
+        :rtype: str
+        """
+        return self._iam_service_account_name
+
+    @iam_service_account_name.setter
+    def iam_service_account_name(self, iam_service_account_name):
+        """Sets the iam_service_account_name of this V1beta1ComponentStatusSpec.
+
+        Name of the service account created for the component to access its storage with a cloud IAM identity, it is deleted once the component no longer uses it.  # noqa: E501
+
+        :param iam_service_account_name: The iam_service_account_name of this V1beta1ComponentStatusSpec.  # noqa: E501
+        :type: str
+        """
+
+        self._iam_service_account_name = iam_service_account_name
+
     @property
     def latest_created_revision(self):
         """Gets the latest_created_revision of this V1beta1ComponentStatusSpec.  # noqa: E501
@@ -240,6 +306,52 @@ class V1beta1ComponentStatusSpec(object):
 
         self._previous_rolledout_revision = previous_rolledout_revision
 
+    @property
+    def ready_replicas(self):
+        """Gets the ready_replicas of this V1beta1ComponentStatusSpec.  # noqa: E501
+
+        Number of pods of the raw deployment of the component with a Ready condition.  # noqa: E501
+
+        :return: The ready_replicas of this V1beta1ComponentStatusSpec.  # noqa: E501
+        :rtype: int
+        """
+        return self._ready_replicas
+
+    @ready_replicas.setter
+    def ready_replicas(self, ready_replicas):
+        """Sets the ready_replicas of this V1beta1ComponentStatusSpec.
+
+        Number of pods of the raw deployment of the component with a Ready condition.  # noqa: E501
+
+        :param ready_replicas: The ready_replicas of this V1beta1ComponentStatusSpec.  # noqa: E501
+        :type: int
+        """
+
+        self._ready_replicas = ready_replicas
+
+    @property
+    def replicas(self):
+        """Gets the replicas of this V1beta1ComponentStatusSpec.  # noqa: E501
+
+        Total number of pods targeted by the raw deployment of the component.  # noqa: E501
+
+        :return: The replicas of this V1beta1ComponentStatusSpec.  # noqa: E501
+        :rtype: int
+        """
+        return self._replicas
+
+    @replicas.setter
+    def replicas(self, replicas):
+        """Sets the replicas of this V1beta1ComponentStatusSpec.
+
+        Total number of pods targeted by the raw deployment of the component.  # noqa: E501
+
+        :param replicas: The replicas of this V1beta1ComponentStatusSpec.  # noqa: E501
+        :type: int
+        """
+
+        self._replicas = replicas
+
     @property
     def rest_url(self):
         """Gets the rest_url of this V1beta1ComponentStatusSpec.  # noqa: E501
@@ -69,6 +69,7 @@ class V1beta1ExplainerSpec(object):
         'hostname': 'str',
         'image_pull_secrets': 'list[V1LocalObjectReference]',
         'init_containers': 'list[V1Container]',
+        'initial_scale': 'int',
         'labels': 'dict(str, str)',
         'logger': 'V1beta1LoggerSpec',
         'max_replicas': 'int',
@@ -83,9 +84,12 @@ class V1beta1ExplainerSpec(object):
         'readiness_gates': 'list[V1PodReadinessGate]',
         'resource_claims': 'list[V1PodResourceClaim]',
         'restart_policy': 'str',
+        'retries': 'V1beta1RetrySpec',
         'runtime_class_name': 'str',
+        'scale_down_delay': 'str',
         'scale_metric': 'str',
         'scale_target': 'int',
+        'scale_window': 'str',
         'scheduler_name': 'str',
         'scheduling_gates': 'list[V1PodSchedulingGate]',
         'security_context': 'V1PodSecurityContext',
@@ -124,6 +128,7 @@ class V1beta1ExplainerSpec(object):
         'hostname': 'hostname',
         'image_pull_secrets': 'imagePullSecrets',
         'init_containers': 'initContainers',
+        'initial_scale': 'initialScale',
         'labels': 'labels',
         'logger': 'logger',
         'max_replicas': 'maxReplicas',
@@ -138,9 +143,12 @@ class V1beta1ExplainerSpec(object):
         'readiness_gates': 'readinessGates',
         'resource_claims': 'resourceClaims',
         'restart_policy': 'restartPolicy',
+        'retries': 'retries',
         'runtime_class_name': 'runtimeClassName',
+        'scale_down_delay': 'scaleDownDelay',
         'scale_metric': 'scaleMetric',
         'scale_target': 'scaleTarget',
+        'scale_window': 'scaleWindow',
         'scheduler_name': 'schedulerName',
         'scheduling_gates': 'schedulingGates',
         'security_context': 'securityContext',
@@ -156,7 +164,7 @@ class V1beta1ExplainerSpec(object):
         'volumes': 'volumes'
     }
 
-    def __init__(self, active_deadline_seconds=None, affinity=None, annotations=None, art=None, automount_service_account_token=None, batcher=None, canary_traffic_percent=None, container_concurrency=None, containers=None, deployment_strategy=None, dns_config=None, dns_policy=None, enable_service_links=None, ephemeral_containers=None, host_aliases=None, host_ipc=None, host_network=None, host_pid=None, host_users=None, hostname=None, image_pull_secrets=None, init_containers=None, labels=None, logger=None, max_replicas=None, min_replicas=None, node_name=None, node_selector=None, os=None, overhead=None, preemption_policy=None, priority=None, priority_class_name=None, readiness_gates=None, resource_claims=None, restart_policy=None, runtime_class_name=None, scale_metric=None, scale_target=None, scheduler_name=None, scheduling_gates=None, security_context=None, service_account=None, service_account_name=None, set_hostname_as_fqdn=None, share_process_namespace=None, subdomain=None, termination_grace_period_seconds=None, timeout=None, tolerations=None, topology_spread_constraints=None, volumes=None, local_vars_configuration=None):  # noqa: E501
+    def __init__(self, active_deadline_seconds=None, affinity=None, annotations=None, art=None, automount_service_account_token=None, batcher=None, canary_traffic_percent=None, container_concurrency=None, containers=None, deployment_strategy=None, dns_config=None, dns_policy=None, enable_service_links=None, ephemeral_containers=None, host_aliases=None, host_ipc=None, host_network=None, host_pid=None, host_users=None, hostname=None, image_pull_secrets=None, init_containers=None, initial_scale=None, labels=None, logger=None, max_replicas=None, min_replicas=None, node_name=None, node_selector=None, os=None, overhead=None, preemption_policy=None, priority=None, priority_class_name=None, readiness_gates=None, resource_claims=None, restart_policy=None, retries=None, runtime_class_name=None, scale_down_delay=None, scale_metric=None, scale_target=None, scale_window=None, scheduler_name=None, scheduling_gates=None, security_context=None, service_account=None, service_account_name=None, set_hostname_as_fqdn=None, share_process_namespace=None, subdomain=None, termination_grace_period_seconds=None, timeout=None, tolerations=None, topology_spread_constraints=None, volumes=None, local_vars_configuration=None):  # noqa: E501
         """V1beta1ExplainerSpec - a model defined in OpenAPI"""  # noqa: E501
         if local_vars_configuration is None:
             local_vars_configuration = Configuration()
@@ -184,6 +192,7 @@ class V1beta1ExplainerSpec(object):
         self._hostname = None
         self._image_pull_secrets = None
         self._init_containers = None
+        self._initial_scale = None
         self._labels = None
         self._logger = None
         self._max_replicas = None
@@ -198,9 +207,12 @@ class V1beta1ExplainerSpec(object):
         self._readiness_gates = None
         self._resource_claims = None
         self._restart_policy = None
+        self._retries = None
         self._runtime_class_name = None
+        self._scale_down_delay = None
         self._scale_metric = None
         self._scale_target = None
+        self._scale_window = None
         self._scheduler_name = None
         self._scheduling_gates = None
         self._security_context = None
@@ -260,6 +272,8 @@ class V1beta1ExplainerSpec(object):
             self.image_pull_secrets = image_pull_secrets
         if init_containers is not None:
             self.init_containers = init_containers
+        if initial_scale is not None:
+            self.initial_scale = initial_scale
         if labels is not None:
             self.labels = labels
         if logger is not None:
@@ -288,12 +302,18 @@ class V1beta1ExplainerSpec(object):
             self.resource_claims = resource_claims
         if restart_policy is not None:
             self.restart_policy = restart_policy
+        if retries is not None:
+            self.retries = retries
         if runtime_class_name is not None:
             self.runtime_class_name = runtime_class_name
+        if scale_down_delay is not None:
+            self.scale_down_delay = scale_down_delay
         if scale_metric is not None:
             self.scale_metric = scale_metric
         if scale_target is not None:
             self.scale_target = scale_target
+        if scale_window is not None:
+            self.scale_window = scale_window
         if scheduler_name is not None:
             self.scheduler_name = scheduler_name
         if scheduling_gates is not None:
@@ -480,7 +500,7 @@ class V1beta1ExplainerSpec(object):
     def container_concurrency(self):
         """Gets the container_concurrency of this V1beta1ExplainerSpec.  # noqa: E501
 
-        ContainerConcurrency specifies how many requests can be processed concurrently, this sets the hard limit of the container concurrency(https://knative.dev/docs/serving/autoscaling/concurrency).  # noqa: E501
+        ContainerConcurrency specifies how many requests can be processed concurrently, this sets the hard limit of the container concurrency(https://knative.dev/docs/serving/autoscaling/concurrency) independently of the soft ScaleTarget, 0 means unlimited.  # noqa: E501
 
         :return: The container_concurrency of this V1beta1ExplainerSpec.  # noqa: E501
         :rtype: int
@@ -491,7 +511,7 @@ class V1beta1ExplainerSpec(object):
     def container_concurrency(self, container_concurrency):
         """Sets the container_concurrency of this V1beta1ExplainerSpec.
 
-        ContainerConcurrency specifies how many requests can be processed concurrently, this sets the hard limit of the container concurrency(https://knative.dev/docs/serving/autoscaling/concurrency).  # noqa: E501
+        ContainerConcurrency specifies how many requests can be processed concurrently, this sets the hard limit of the container concurrency(https://knative.dev/docs/serving/autoscaling/concurrency) independently of the soft ScaleTarget, 0 means unlimited.  # noqa: E501
 
         :param container_concurrency: The container_concurrency of this V1beta1ExplainerSpec.  # noqa: E501
         :type: int
@@ -817,6 +837,29 @@ class V1beta1ExplainerSpec(object):
 
         self._init_containers = init_containers
 
+    @property
+    def initial_scale(self):
+        """Gets the initial_scale of this V1beta1ExplainerSpec.  # noqa: E501
+
+        InitialScale is the number of replicas a new revision scales to before it is marked ready, it sets the autoscaling.knative.dev/initial-scale annotation. Only applicable for serverless mode.  # noqa: E501
+
+        :return: The initial_scale of this V1beta1ExplainerSpec.  # noqa: E501
+        :rtype: int
+        """
+        return self._initial_scale
+
+    @initial_scale.setter
+    def initial_scale(self, initial_scale):
+        """Sets the initial_scale of this V1beta1ExplainerSpec.
+
+        InitialScale is the number of replicas a new revision scales to before it is marked ready, it sets the autoscaling.knative.dev/initial-scale annotation. Only applicable for serverless mode.  # noqa: E501
+
+        :param initial_scale: The initial_scale of this V1beta1ExplainerSpec.  # noqa: E501
+        :type: int
+        """
+
+        self._initial_scale = initial_scale
+
     @property
     def labels(self):
         """Gets the labels of this V1beta1ExplainerSpec.  # noqa: E501
@@ -1135,6 +1178,27 @@ class V1beta1ExplainerSpec(object):
 
         self._restart_policy = restart_policy
 
+    @property
+    def retries(self):
+        """Gets the retries of this V1beta1ExplainerSpec.  # noqa: E501
+
+
+        :return: The retries of this V1beta1ExplainerSpec.  # noqa: E501
+        :rtype: V1beta1RetrySpec
+        """
+        return self._retries
+
+    @retries.setter
+    def retries(self, retries):
+        """Sets the retries of this V1beta1ExplainerSpec.
+
+
+        :param retries: The retries of this V1beta1ExplainerSpec.  # noqa: E501
+        :type: V1beta1RetrySpec
+        """
+
+        self._retries = retries
+
     @property
     def runtime_class_name(self):
         """Gets the runtime_class_name of this V1beta1ExplainerSpec.  # noqa: E501
@@ -1158,6 +1222,29 @@ class V1beta1ExplainerSpec(object):
 
         self._runtime_class_name = runtime_class_name
 
+    @property
+    def scale_down_delay(self):
+        """Gets the scale_down_delay of this V1beta1ExplainerSpec.  # noqa: E501
+
+        ScaleDownDelay is the duration the request load must stay low before the revision scales down, e.g. 15m, it sets the autoscaling.knative.dev/scale-down-delay annotation. Only applicable for serverless mode.  # noqa: E501
+
+        :return: The scale_down_delay of this V1beta1ExplainerSpec.  # noqa: E501
+        :rtype: str
+        """
+        return self._scale_down_delay
+
+    @scale_down_delay.setter
+    def scale_down_delay(self, scale_down_delay):
+        """Sets the scale_down_delay of this V1beta1ExplainerSpec.
+
+        ScaleDownDelay is the duration the request load must stay low before the revision scales down, e.g. 15m, it sets the autoscaling.knative.dev/scale-down-delay annotation. Only applicable for serverless mode.  # noqa: E501
+
+        :param scale_down_delay: The scale_down_delay of this V1beta1ExplainerSpec.  # noqa: E501
+        :type: str
+        """
+
+        self._scale_down_delay = scale_down_delay
+
     @property
     def scale_metric(self):
         """Gets the scale_metric of this V1beta1ExplainerSpec.  # noqa: E501
@@ -1204,6 +1291,29 @@ class V1beta1ExplainerSpec(object):
 
         self._scale_target = scale_target
 
+    @property
+    def scale_window(self):
+        """Gets the scale_window of this V1beta1ExplainerSpec.  # noqa: E501
+
+        ScaleWindow is the duration of the window over which the autoscaler averages the metric, e.g. 120s, it sets the autoscaling.knative.dev/window annotation. Only applicable for serverless mode.  # noqa: E501
+
+        :return: The scale_window of this V1beta1ExplainerSpec.  # noqa: E501
+        :rtype: str
+        """
+        return self._scale_window
+
+    @scale_window.setter
+    def scale_window(self, scale_window):
+        """Sets the scale_window of this V1beta1ExplainerSpec.
+
+        ScaleWindow is the duration of the window over which the autoscaler averages the metric, e.g. 120s, it sets the autoscaling.knative.dev/window annotation. Only applicable for serverless mode.  # noqa: E501
+
+        :param scale_window: The scale_window of this V1beta1ExplainerSpec.  # noqa: E501
+        :type: str
+        """
+
+        self._scale_window = scale_window
+
     @property
     def scheduler_name(self):
         """Gets the scheduler_name of this V1beta1ExplainerSpec.  # noqa: E501
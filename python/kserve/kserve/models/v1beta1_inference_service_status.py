@@ -48,9 +48,11 @@ class V1beta1InferenceServiceStatus(object):
     """
     openapi_types = {
         'address': 'KnativeAddressable',
+        'addresses': 'list[KnativeAddressable]',
         'annotations': 'dict(str, str)',
         'components': 'dict(str, V1beta1ComponentStatusSpec)',
         'conditions': 'list[KnativeCondition]',
+        'external_urls': 'list[KnativeURL]',
         'model_status': 'V1beta1ModelStatus',
         'observed_generation': 'int',
         'url': 'KnativeURL'
@@ -58,24 +60,28 @@ class V1beta1InferenceServiceStatus(object):
 
     attribute_map = {
         'address': 'address',
+        'addresses': 'addresses',
         'annotations': 'annotations',
         'components': 'components',
         'conditions': 'conditions',
+        'external_urls': 'externalUrls',
         'model_status': 'modelStatus',
         'observed_generation': 'observedGeneration',
         'url': 'url'
     }
 
-    def __init__(self, address=None, annotations=None, components=None, conditions=None, model_status=None, observed_generation=None, url=None, local_vars_configuration=None):  # noqa: E501
+    def __init__(self, address=None, addresses=None, annotations=None, components=None, conditions=None, external_urls=None, model_status=None, observed_generation=None, url=None, local_vars_configuration=None):  # noqa: E501
         """V1beta1InferenceServiceStatus - a model defined in OpenAPI"""  # noqa: E501
         if local_vars_configuration is None:
             local_vars_configuration = Configuration()
         self.local_vars_configuration = local_vars_configuration
 
         self._address = None
+        self._addresses = None
         self._annotations = None
         self._components = None
         self._conditions = None
+        self._external_urls = None
         self._model_status = None
         self._observed_generation = None
         self._url = None
@@ -83,12 +89,16 @@ class V1beta1InferenceServiceStatus(object):
 
         if address is not None:
             self.address = address
+        if addresses is not None:
+            self.addresses = addresses
         if annotations is not None:
             self.annotations = annotations
         if components is not None:
             self.components = components
         if conditions is not None:
             self.conditions = conditions
+        if external_urls is not None:
+            self.external_urls = external_urls
         if model_status is not None:
             self.model_status = model_status
         if observed_generation is not None:
@@ -117,6 +127,29 @@ class V1beta1InferenceServiceStatus(object):
 
         self._address = address
 
+    @property
+    def addresses(self):
+        """Gets the addresses of this V1beta1InferenceServiceStatus.  # noqa: E501
+
+        Addresses holds the addressable endpoints of all the hosts the InferenceService is exposed at, the host of its url followed by the hosts of the additional ingress domains and of the serving.kserve.io/additional-hosts annotation.  # noqa: E501
+
+        :return: The addresses of this V1beta1InferenceServiceStatus.  # noqa: E501
+        :rtype: list[KnativeAddressable]
+        """
+        return self._addresses
+
+    @addresses.setter
+    def addresses(self, addresses):
+        """Sets the addresses of this V1beta1InferenceServiceStatus.
+
+        Addresses holds the addressable endpoints of all the hosts the InferenceService is exposed at, the host of its url followed by the hosts of the additional ingress domains and of the serving.kserve.io/additional-hosts annotation.  # noqa: E501
+
+        :param addresses: The addresses of this V1beta1InferenceServiceStatus.  # noqa: E501
+        :type: list[KnativeAddressable]
+        """
+
+        self._addresses = addresses
+
     @property
     def annotations(self):
         """Gets the annotations of this V1beta1InferenceServiceStatus.  # noqa: E501
@@ -186,6 +219,29 @@ class V1beta1InferenceServiceStatus(object):
 
         self._conditions = conditions
 
+    @property
+    def external_urls(self):
+        """Gets the external_urls of this V1beta1InferenceServiceStatus.  # noqa: E501
+
+        ExternalURLs holds the additional urls the InferenceService is reachable at, e.g. the custom domain set with the serving.kserve.io/custom-domain annotation.  # noqa: E501
+
+        :return: The external_urls of this V1beta1InferenceServiceStatus.  # noqa: E501
+        :rtype: list[KnativeURL]
+        """
+        return self._external_urls
+
+    @external_urls.setter
+    def external_urls(self, external_urls):
+        """Sets the external_urls of this V1beta1InferenceServiceStatus.
+
+        ExternalURLs holds the additional urls the InferenceService is reachable at, e.g. the custom domain set with the serving.kserve.io/custom-domain annotation.  # noqa: E501
+
+        :param external_urls: The external_urls of this V1beta1InferenceServiceStatus.  # noqa: E501
+        :type: list[KnativeURL]
+        """
+
+        self._external_urls = external_urls
+
     @property
     def model_status(self):
         """Gets the model_status of this V1beta1InferenceServiceStatus.  # noqa: E501
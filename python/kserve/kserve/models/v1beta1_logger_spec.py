@@ -47,35 +47,134 @@ class V1beta1LoggerSpec(object):
                             and the value is json key in definition.
     """
     openapi_types = {
+        'content_type_allow_list': 'list[str]',
+        'max_payload_bytes': 'int',
+        'metadata_headers': 'list[str]',
         'mode': 'str',
+        'payload_overflow': 'str',
+        'sampling_rate': 'str',
+        'secret_name': 'str',
         'url': 'str'
     }
 
     attribute_map = {
+        'content_type_allow_list': 'contentTypeAllowList',
+        'max_payload_bytes': 'maxPayloadBytes',
+        'metadata_headers': 'metadataHeaders',
         'mode': 'mode',
+        'payload_overflow': 'payloadOverflow',
+        'sampling_rate': 'samplingRate',
+        'secret_name': 'secretName',
         'url': 'url'
     }
 
-    def __init__(self, mode=None, url=None, local_vars_configuration=None):  # noqa: E501
+    def __init__(self, content_type_allow_list=None, max_payload_bytes=None, metadata_headers=None, mode=None, payload_overflow=None, sampling_rate=None, secret_name=None, url=None, local_vars_configuration=None):  # noqa: E501
         """V1beta1LoggerSpec - a model defined in OpenAPI"""  # noqa: E501
         if local_vars_configuration is None:
             local_vars_configuration = Configuration()
         self.local_vars_configuration = local_vars_configuration
 
+        self._content_type_allow_list = None
+        self._max_payload_bytes = None
+        self._metadata_headers = None
         self._mode = None
+        self._payload_overflow = None
+        self._sampling_rate = None
+        self._secret_name = None
         self._url = None
         self.discriminator = None
 
+        if content_type_allow_list is not None:
+            self.content_type_allow_list = content_type_allow_list
+        if max_payload_bytes is not None:
+            self.max_payload_bytes = max_payload_bytes
+        if metadata_headers is not None:
+            self.metadata_headers = metadata_headers
         if mode is not None:
             self.mode = mode
+        if payload_overflow is not None:
+            self.payload_overflow = payload_overflow
+        if sampling_rate is not None:
+            self.sampling_rate = sampling_rate
+        if secret_name is not None:
+            self.secret_name = secret_name
         if url is not None:
             self.url = url
 
+    @property
+    def content_type_allow_list(self):
+        """Gets the content_type_allow_list of this V1beta1LoggerSpec.  # noqa: E501
+
+        Content types of the logged payloads, e.g. application/json or text/*. All the payloads are logged when empty.  # noqa: E501
+
+        :return: The content_type_allow_list of this V1beta1LoggerSpec.  # noqa: E501
+        :rtype: list[str]
+        """
+        return self._content_type_allow_list
+
+    @content_type_allow_list.setter
+    def content_type_allow_list(self, content_type_allow_list):
+        """Sets the content_type_allow_list of this V1beta1LoggerSpec.
+
+        Content types of the logged payloads, e.g. application/json or text/*. All the payloads are logged when empty.  # noqa: E501
+
+        :param content_type_allow_list: The content_type_allow_list of this V1beta1LoggerSpec.  # noqa: E501
+        :type: list[str]
+        """
+
+        self._content_type_allow_list = content_type_allow_list
+
+    @property
+    def max_payload_bytes(self):
+        """Gets the max_payload_bytes of this V1beta1LoggerSpec.  # noqa: E501
+
+        Max size in bytes of the logged payloads, the larger payloads are logged according to payloadOverflow.  # noqa: E501
+
+        :return: The max_payload_bytes of this V1beta1LoggerSpec.  # noqa: E501
+        :rtype: int
+        """
+        return self._max_payload_bytes
+
+    @max_payload_bytes.setter
+    def max_payload_bytes(self, max_payload_bytes):
+        """Sets the max_payload_bytes of this V1beta1LoggerSpec.
+
+        Max size in bytes of the logged payloads, the larger payloads are logged according to payloadOverflow.  # noqa: E501
+
+        :param max_payload_bytes: The max_payload_bytes of this V1beta1LoggerSpec.  # noqa: E501
+        :type: int
+        """
+
+        self._max_payload_bytes = max_payload_bytes
+
+    @property
+    def metadata_headers(self):
+        """Gets the metadata_headers of this V1beta1LoggerSpec.  # noqa: E501
+
+        Request headers captured in the metadata attribute of the events, e.g. x-request-id.  # noqa: E501
+
+        :return: The metadata_headers of this V1beta1LoggerSpec.  # noqa: E501
+        :rtype: list[str]
+        """
+        return self._metadata_headers
+
+    @metadata_headers.setter
+    def metadata_headers(self, metadata_headers):
+        """Sets the metadata_headers of this V1beta1LoggerSpec.
+
+        Request headers captured in the metadata attribute of the events, e.g. x-request-id.  # noqa: E501
+
+        :param metadata_headers: The metadata_headers of this V1beta1LoggerSpec.  # noqa: E501
+        :type: list[str]
+        """
+
+        self._metadata_headers = metadata_headers
+
     @property
     def mode(self):
         """Gets the mode of this V1beta1LoggerSpec.  # noqa: E501
 
-        Specifies the scope of the loggers. <br /> Valid values are: <br /> - \"all\" (default): log both request and response; <br /> - \"request\": log only request; <br /> - \"response\": log only response <br />  # noqa: E501
+        Specifies the scope of the loggers. <br /> Valid values are: <br /> - \"all\" (default): log both request and response; <br /> - \"request\": log only request; <br /> - \"response\": log only response <br /> - \"errors\": log both request and response of the inferences for which the model returns a non-2xx status or an error response <br />  # noqa: E501
 
         :return: The mode of this V1beta1LoggerSpec.  # noqa: E501
         :rtype: str
@@ -86,7 +185,7 @@ class V1beta1LoggerSpec(object):
     def mode(self, mode):
         """Sets the mode of this V1beta1LoggerSpec.
 
-        Specifies the scope of the loggers. <br /> Valid values are: <br /> - \"all\" (default): log both request and response; <br /> - \"request\": log only request; <br /> - \"response\": log only response <br />  # noqa: E501
+        Specifies the scope of the loggers. <br /> Valid values are: <br /> - \"all\" (default): log both request and response; <br /> - \"request\": log only request; <br /> - \"response\": log only response <br /> - \"errors\": log both request and response of the inferences for which the model returns a non-2xx status or an error response <br />  # noqa: E501
 
         :param mode: The mode of this V1beta1LoggerSpec.  # noqa: E501
         :type: str
@@ -94,11 +193,80 @@ class V1beta1LoggerSpec(object):
 
         self._mode = mode
 
+    @property
+    def payload_overflow(self):
+        """Gets the payload_overflow of this V1beta1LoggerSpec.  # noqa: E501
+
+        Specifies how the payloads larger than maxPayloadBytes are logged. <br /> Valid values are: <br /> - \"truncate\" (default): the payload is truncated to maxPayloadBytes; <br /> - \"drop\": the event is sent without the payload <br /> The events of these payloads have the payloadlimit and payloadsize attributes.  # noqa: E501
+
+        :return: The payload_overflow of this V1beta1LoggerSpec.  # noqa: E501
+        :rtype: str
+        """
+        return self._payload_overflow
+
+    @payload_overflow.setter
+    def payload_overflow(self, payload_overflow):
+        """Sets the payload_overflow of this V1beta1LoggerSpec.
+
+        Specifies how the payloads larger than maxPayloadBytes are logged. <br /> Valid values are: <br /> - \"truncate\" (default): the payload is truncated to maxPayloadBytes; <br /> - \"drop\": the event is sent without the payload <br /> The events of these payloads have the payloadlimit and payloadsize attributes.  # noqa: E501
+
+        :param payload_overflow: The payload_overflow of this V1beta1LoggerSpec.  # noqa: E501
+        :type: str
+        """
+
+        self._payload_overflow = payload_overflow
+
+    @property
+    def sampling_rate(self):
+        """Gets the sampling_rate of this V1beta1LoggerSpec.  # noqa: E501
+
+        Fraction of the inferences logged, a decimal number between \"0.0\" and \"1.0\" (default). The request and the response of an inference are either both logged or both skipped.  # noqa: E501
+
+        :return: The sampling_rate of this V1beta1LoggerSpec.  # noqa: E501
+        :rtype: str
+        """
+        return self._sampling_rate
+
+    @sampling_rate.setter
+    def sampling_rate(self, sampling_rate):
+        """Sets the sampling_rate of this V1beta1LoggerSpec.
+
+        Fraction of the inferences logged, a decimal number between \"0.0\" and \"1.0\" (default). The request and the response of an inference are either both logged or both skipped.  # noqa: E501
+
+        :param sampling_rate: The sampling_rate of this V1beta1LoggerSpec.  # noqa: E501
+        :type: str
+        """
+
+        self._sampling_rate = sampling_rate
+
+    @property
+    def secret_name(self):
+        """Gets the secret_name of this V1beta1LoggerSpec.  # noqa: E501
+
+        Name of the secret in the namespace of the InferenceService with the SASL/TLS configuration of the kafka logger url. The supported keys are sasl.mechanism (PLAIN, SCRAM-SHA-256 or SCRAM-SHA-512), sasl.username, sasl.password, tls.enabled, ca.crt, tls.crt and tls.key.  # noqa: E501
+
+        :return: The secret_name of this V1beta1LoggerSpec.  # noqa: E501
+        :rtype: str
+        """
+        return self._secret_name
+
+    @secret_name.setter
+    def secret_name(self, secret_name):
+        """Sets the secret_name of this V1beta1LoggerSpec.
+
+        Name of the secret in the namespace of the InferenceService with the SASL/TLS configuration of the kafka logger url. The supported keys are sasl.mechanism (PLAIN, SCRAM-SHA-256 or SCRAM-SHA-512), sasl.username, sasl.password, tls.enabled, ca.crt, tls.crt and tls.key.  # noqa: E501
+
+        :param secret_name: The secret_name of this V1beta1LoggerSpec.  # noqa: E501
+        :type: str
+        """
+
+        self._secret_name = secret_name
+
     @property
     def url(self):
         """Gets the url of this V1beta1LoggerSpec.  # noqa: E501
 
-        URL to send logging events  # noqa: E501
+        URL to send logging events. <br /> The events are posted as cloud events over http, or published to a kafka topic when the url has the form kafka://broker1:9092,broker2:9092/topic  # noqa: E501
 
         :return: The url of this V1beta1LoggerSpec.  # noqa: E501
         :rtype: str
@@ -109,7 +277,7 @@ class V1beta1LoggerSpec(object):
     def url(self, url):
         """Sets the url of this V1beta1LoggerSpec.
 
-        URL to send logging events  # noqa: E501
+        URL to send logging events. <br /> The events are posted as cloud events over http, or published to a kafka topic when the url has the form kafka://broker1:9092,broker2:9092/topic  # noqa: E501
 
         :param url: The url of this V1beta1LoggerSpec.  # noqa: E501
         :type: str
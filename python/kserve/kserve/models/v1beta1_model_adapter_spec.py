@@ -0,0 +1,222 @@
+# Copyright 2024 The KServe Authors.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#    http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+# coding: utf-8
+
+"""
+    KServe
+
+    Python SDK for KServe  # noqa: E501
+
+    The version of the OpenAPI document: v0.1
+    Generated by: https://openapi-generator.tech
+"""
+
+
+import pprint
+import re  # noqa: F401
+
+import six
+
+from kserve.configuration import Configuration
+
+
+class V1beta1ModelAdapterSpec(object):
+    """NOTE: This class is auto generated by OpenAPI Generator.
+    Ref: https://openapi-generator.tech
+
+    Do not edit the class manually.
+    """
+
+    """
+    Attributes:
+      openapi_types (dict): The key is attribute name
+                            and the value is attribute type.
+      attribute_map (dict): The key is attribute name
+                            and the value is json key in definition.
+    """
+    openapi_types = {
+        'mount_path': 'str',
+        'name': 'str',
+        'secret_name': 'str',
+        'storage_uri': 'str'
+    }
+
+    attribute_map = {
+        'mount_path': 'mountPath',
+        'name': 'name',
+        'secret_name': 'secretName',
+        'storage_uri': 'storageUri'
+    }
+
+    def __init__(self, mount_path=None, name='', secret_name=None, storage_uri='', local_vars_configuration=None):  # noqa: E501
+        """V1beta1ModelAdapterSpec - a model defined in OpenAPI"""  # noqa: E501
+        if local_vars_configuration is None:
+            local_vars_configuration = Configuration()
+        self.local_vars_configuration = local_vars_configuration
+
+        self._mount_path = None
+        self._name = None
+        self._secret_name = None
+        self._storage_uri = None
+        self.discriminator = None
+
+        if mount_path is not None:
+            self.mount_path = mount_path
+        self.name = name
+        if secret_name is not None:
+            self.secret_name = secret_name
+        self.storage_uri = storage_uri
+
+    @property
+    def mount_path(self):
+        """Gets the mount_path of this V1beta1ModelAdapterSpec.  # noqa: E501
+
+        Path where the adapter is mounted in the model server container, a path under /mnt/models which defaults to /mnt/models/<name>.  # noqa: E501
+
+        :return: The mount_path of this V1beta1ModelAdapterSpec.  # noqa: E501
+        :rtype: str
+        """
+        return self._mount_path
+
+    @mount_path.setter
+    def mount_path(self, mount_path):
+        """Sets the mount_path of this V1beta1ModelAdapterSpec.
+
+        Path where the adapter is mounted in the model server container, a path under /mnt/models which defaults to /mnt/models/<name>.  # noqa: E501
+
+        :param mount_path: The mount_path of this V1beta1ModelAdapterSpec.  # noqa: E501
+        :type: str
+        """
+
+        self._mount_path = mount_path
+
+    @property
+    def name(self):
+        """Gets the name of this V1beta1ModelAdapterSpec.  # noqa: E501
+
+        Name of the adapter, a DNS-1123 label unique among the adapters of the model.  # noqa: E501
+
+        :return: The name of this V1beta1ModelAdapterSpec.  # noqa: E501
+        :rtype: str
+        """
+        return self._name
+
+    @name.setter
+    def name(self, name):
+        """Sets the name of this V1beta1ModelAdapterSpec.
+
+        Name of the adapter, a DNS-1123 label unique among the adapters of the model.  # noqa: E501
+
+        :param name: The name of this V1beta1ModelAdapterSpec.  # noqa: E501
+        :type: str
+        """
+        if self.local_vars_configuration.client_side_validation and name is None:  # noqa: E501
+            raise ValueError("Invalid value for `name`, must not be `None`")  # noqa: E501
+
+        self._name = name
+
+    @property
+    def secret_name(self):
+        """Gets the secret_name of this V1beta1ModelAdapterSpec.  # noqa: E501
+
+        Name of the secret holding the credentials of the adapter storage, the credentials of the service account are used when not set.  # noqa: E501
+
+        :return: The secret_name of this V1beta1ModelAdapterSpec.  # noqa: E501
+        :rtype: str
+        """
+        return self._secret_name
+
+    @secret_name.setter
+    def secret_name(self, secret_name):
+        """Sets the secret_name of this V1beta1ModelAdapterSpec.
+
+        Name of the secret holding the credentials of the adapter storage, the credentials of the service account are used when not set.  # noqa: E501
+
+        :param secret_name: The secret_name of this V1beta1ModelAdapterSpec.  # noqa: E501
+        :type: str
+        """
+
+        self._secret_name = secret_name
+
+    @property
+    def storage_uri(self):
+        """Gets the storage_uri of this V1beta1ModelAdapterSpec.  # noqa: E501
+
+        This field points to the location of the adapter which is mounted onto the pod.  # noqa: E501
+
+        :return: The storage_uri of this V1beta1ModelAdapterSpec.  # noqa: E501
+        :rtype: str
+        """
+        return self._storage_uri
+
+    @storage_uri.setter
+    def storage_uri(self, storage_uri):
+        """Sets the storage_uri of this V1beta1ModelAdapterSpec.
+
+        This field points to the location of the adapter which is mounted onto the pod.  # noqa: E501
+
+        :param storage_uri: The storage_uri of this V1beta1ModelAdapterSpec.  # noqa: E501
+        :type: str
+        """
+        if self.local_vars_configuration.client_side_validation and storage_uri is None:  # noqa: E501
+            raise ValueError("Invalid value for `storage_uri`, must not be `None`")  # noqa: E501
+
+        self._storage_uri = storage_uri
+
+    def to_dict(self):
+        """Returns the model properties as a dict"""
+        result = {}
+
+        for attr, _ in six.iteritems(self.openapi_types):
+            value = getattr(self, attr)
+            if isinstance(value, list):
+                result[attr] = list(map(
+                    lambda x: x.to_dict() if hasattr(x, "to_dict") else x,
+                    value
+                ))
+            elif hasattr(value, "to_dict"):
+                result[attr] = value.to_dict()
+            elif isinstance(value, dict):
+                result[attr] = dict(map(
+                    lambda item: (item[0], item[1].to_dict())
+                    if hasattr(item[1], "to_dict") else item,
+                    value.items()
+                ))
+            else:
+                result[attr] = value
+
+        return result
+
+    def to_str(self):
+        """Returns the string representation of the model"""
+        return pprint.pformat(self.to_dict())
+
+    def __repr__(self):
+        """For `print` and `pprint`"""
+        return self.to_str()
+
+    def __eq__(self, other):
+        """Returns true if both objects are equal"""
+        if not isinstance(other, V1beta1ModelAdapterSpec):
+            return False
+
+        return self.to_dict() == other.to_dict()
+
+    def __ne__(self, other):
+        """Returns true if both objects are not equal"""
+        if not isinstance(other, V1beta1ModelAdapterSpec):
+            return True
+
+        return self.to_dict() != other.to_dict()
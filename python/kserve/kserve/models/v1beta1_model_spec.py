@@ -47,6 +47,7 @@ class V1beta1ModelSpec(object):
                             and the value is json key in definition.
     """
     openapi_types = {
+        'adapters': 'list[V1beta1ModelAdapterSpec]',
         'args': 'list[str]',
         'command': 'list[str]',
         'env': 'list[V1EnvVar]',
@@ -80,6 +81,7 @@ class V1beta1ModelSpec(object):
     }
 
     attribute_map = {
+        'adapters': 'adapters',
         'args': 'args',
         'command': 'command',
         'env': 'env',
@@ -112,12 +114,13 @@ class V1beta1ModelSpec(object):
         'working_dir': 'workingDir'
     }
 
-    def __init__(self, args=None, command=None, env=None, env_from=None, image=None, image_pull_policy=None, lifecycle=None, liveness_probe=None, model_format=None, name='', ports=None, protocol_version=None, readiness_probe=None, resize_policy=None, resources=None, restart_policy=None, runtime=None, runtime_version=None, security_context=None, startup_probe=None, stdin=None, stdin_once=None, storage=None, storage_uri=None, termination_message_path=None, termination_message_policy=None, tty=None, volume_devices=None, volume_mounts=None, working_dir=None, local_vars_configuration=None):  # noqa: E501
+    def __init__(self, adapters=None, args=None, command=None, env=None, env_from=None, image=None, image_pull_policy=None, lifecycle=None, liveness_probe=None, model_format=None, name='', ports=None, protocol_version=None, readiness_probe=None, resize_policy=None, resources=None, restart_policy=None, runtime=None, runtime_version=None, security_context=None, startup_probe=None, stdin=None, stdin_once=None, storage=None, storage_uri=None, termination_message_path=None, termination_message_policy=None, tty=None, volume_devices=None, volume_mounts=None, working_dir=None, local_vars_configuration=None):  # noqa: E501
         """V1beta1ModelSpec - a model defined in OpenAPI"""  # noqa: E501
         if local_vars_configuration is None:
             local_vars_configuration = Configuration()
         self.local_vars_configuration = local_vars_configuration
 
+        self._adapters = None
         self._args = None
         self._command = None
         self._env = None
@@ -150,6 +153,8 @@ class V1beta1ModelSpec(object):
         self._working_dir = None
         self.discriminator = None
 
+        if adapters is not None:
+            self.adapters = adapters
         if args is not None:
             self.args = args
         if command is not None:
@@ -210,6 +215,29 @@ class V1beta1ModelSpec(object):
         if working_dir is not None:
             self.working_dir = working_dir
 
+    @property
+    def adapters(self):
+        """Gets the adapters of this V1beta1ModelSpec.  # noqa: E501
+
+        Adapters downloaded next to the model, e.g. the LoRA adapters of a base model. Each adapter is downloaded by its own storage initializer with the credentials of its secret.  # noqa: E501
+
+        :return: The adapters of this V1beta1ModelSpec.  # noqa: E501
+        :rtype: list[V1beta1ModelAdapterSpec]
+        """
+        return self._adapters
+
+    @adapters.setter
+    def adapters(self, adapters):
+        """Sets the adapters of this V1beta1ModelSpec.
+
+        Adapters downloaded next to the model, e.g. the LoRA adapters of a base model. Each adapter is downloaded by its own storage initializer with the credentials of its secret.  # noqa: E501
+
+        :param adapters: The adapters of this V1beta1ModelSpec.  # noqa: E501
+        :type: list[V1beta1ModelAdapterSpec]
+        """
+
+        self._adapters = adapters
+
     @property
     def args(self):
         """Gets the args of this V1beta1ModelSpec.  # noqa: E501
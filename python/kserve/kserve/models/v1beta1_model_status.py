@@ -47,39 +47,136 @@ class V1beta1ModelStatus(object):
                             and the value is json key in definition.
     """
     openapi_types = {
+        'allocated_model_memory': 'ResourceQuantity',
+        'artifacts': 'list[V1beta1ArtifactStatus]',
+        'canary_revision': 'V1beta1RevisionModelStatus',
         'copies': 'V1beta1ModelCopies',
+        'last_download_duration': 'V1Duration',
         'last_failure_info': 'V1beta1FailureInfo',
+        'runtime': 'V1beta1RuntimeStatus',
+        'stable_revision': 'V1beta1RevisionModelStatus',
         'states': 'V1beta1ModelRevisionStates',
         'transition_status': 'str'
     }
 
     attribute_map = {
+        'allocated_model_memory': 'allocatedModelMemory',
+        'artifacts': 'artifacts',
+        'canary_revision': 'canaryRevision',
         'copies': 'copies',
+        'last_download_duration': 'lastDownloadDuration',
         'last_failure_info': 'lastFailureInfo',
+        'runtime': 'runtime',
+        'stable_revision': 'stableRevision',
         'states': 'states',
         'transition_status': 'transitionStatus'
     }
 
-    def __init__(self, copies=None, last_failure_info=None, states=None, transition_status='', local_vars_configuration=None):  # noqa: E501
+    def __init__(self, allocated_model_memory=None, artifacts=None, canary_revision=None, copies=None, last_download_duration=None, last_failure_info=None, runtime=None, stable_revision=None, states=None, transition_status='', local_vars_configuration=None):  # noqa: E501
         """V1beta1ModelStatus - a model defined in OpenAPI"""  # noqa: E501
         if local_vars_configuration is None:
             local_vars_configuration = Configuration()
         self.local_vars_configuration = local_vars_configuration
 
+        self._allocated_model_memory = None
+        self._artifacts = None
+        self._canary_revision = None
         self._copies = None
+        self._last_download_duration = None
         self._last_failure_info = None
+        self._runtime = None
+        self._stable_revision = None
         self._states = None
         self._transition_status = None
         self.discriminator = None
 
+        if allocated_model_memory is not None:
+            self.allocated_model_memory = allocated_model_memory
+        if artifacts is not None:
+            self.artifacts = artifacts
+        if canary_revision is not None:
+            self.canary_revision = canary_revision
         if copies is not None:
             self.copies = copies
+        if last_download_duration is not None:
+            self.last_download_duration = last_download_duration
         if last_failure_info is not None:
             self.last_failure_info = last_failure_info
+        if runtime is not None:
+            self.runtime = runtime
+        if stable_revision is not None:
+            self.stable_revision = stable_revision
         if states is not None:
             self.states = states
         self.transition_status = transition_status
 
+    @property
+    def allocated_model_memory(self):
+        """Gets the allocated_model_memory of this V1beta1ModelStatus.  # noqa: E501
+
+
+        :return: The allocated_model_memory of this V1beta1ModelStatus.  # noqa: E501
+        :rtype: ResourceQuantity
+        """
+        return self._allocated_model_memory
+
+    @allocated_model_memory.setter
+    def allocated_model_memory(self, allocated_model_memory):
+        """Sets the allocated_model_memory of this V1beta1ModelStatus.
+
+
+        :param allocated_model_memory: The allocated_model_memory of this V1beta1ModelStatus.  # noqa: E501
+        :type: ResourceQuantity
+        """
+
+        self._allocated_model_memory = allocated_model_memory
+
+    @property
+    def artifacts(self):
+        """Gets the artifacts of this V1beta1ModelStatus.  # noqa: E501
+
+        Download state of the model and of each of its adapters, reported when the predictor has adapters.  # noqa: E501
+
+        :return: The artifacts of this V1beta1ModelStatus.  # noqa: E501
+        :rtype: list[V1beta1ArtifactStatus]
+        """
+        return self._artifacts
+
+    @artifacts.setter
+    def artifacts(self, artifacts):
+        """Sets the artifacts of this V1beta1ModelStatus.
+
+        Download state of the model and of each of its adapters, reported when the predictor has adapters.  # noqa: E501
+
+        :param artifacts: The artifacts of this V1beta1ModelStatus.  # noqa: E501
+        :type: list[V1beta1ArtifactStatus]
+        """
+
+        self._artifacts = artifacts
+
+    @property
+    def canary_revision(self):
+        """Gets the canary_revision of this V1beta1ModelStatus.  # noqa: E501
+
+        Model state of the canary revision, reported while it is rolled out.  # noqa: E501
+
+        :return: The canary_revision of this V1beta1ModelStatus.  # noqa: E501
+        :rtype: V1beta1RevisionModelStatus
+        """
+        return self._canary_revision
+
+    @canary_revision.setter
+    def canary_revision(self, canary_revision):
+        """Sets the canary_revision of this V1beta1ModelStatus.
+
+        Model state of the canary revision, reported while it is rolled out.  # noqa: E501
+
+        :param canary_revision: The canary_revision of this V1beta1ModelStatus.  # noqa: E501
+        :type: V1beta1RevisionModelStatus
+        """
+
+        self._canary_revision = canary_revision
+
     @property
     def copies(self):
         """Gets the copies of this V1beta1ModelStatus.  # noqa: E501
@@ -101,6 +198,29 @@ class V1beta1ModelStatus(object):
 
         self._copies = copies
 
+    @property
+    def last_download_duration(self):
+        """Gets the last_download_duration of this V1beta1ModelStatus.  # noqa: E501
+
+        Wall-clock duration of the last successful download of the model by the storage initializer.  # noqa: E501
+
+        :return: The last_download_duration of this V1beta1ModelStatus.  # noqa: E501
+        :rtype: V1Duration
+        """
+        return self._last_download_duration
+
+    @last_download_duration.setter
+    def last_download_duration(self, last_download_duration):
+        """Sets the last_download_duration of this V1beta1ModelStatus.
+
+        Wall-clock duration of the last successful download of the model by the storage initializer.  # noqa: E501
+
+        :param last_download_duration: The last_download_duration of this V1beta1ModelStatus.  # noqa: E501
+        :type: V1Duration
+        """
+
+        self._last_download_duration = last_download_duration
+
     @property
     def last_failure_info(self):
         """Gets the last_failure_info of this V1beta1ModelStatus.  # noqa: E501
@@ -122,6 +242,52 @@ class V1beta1ModelStatus(object):
 
         self._last_failure_info = last_failure_info
 
+    @property
+    def runtime(self):
+        """Gets the runtime of this V1beta1ModelStatus.  # noqa: E501
+
+        Runtime serving the model, with the generations its effective spec was built from.  # noqa: E501
+
+        :return: The runtime of this V1beta1ModelStatus.  # noqa: E501
+        :rtype: V1beta1RuntimeStatus
+        """
+        return self._runtime
+
+    @runtime.setter
+    def runtime(self, runtime):
+        """Sets the runtime of this V1beta1ModelStatus.
+
+        Runtime serving the model, with the generations its effective spec was built from.  # noqa: E501
+
+        :param runtime: The runtime of this V1beta1ModelStatus.  # noqa: E501
+        :type: V1beta1RuntimeStatus
+        """
+
+        self._runtime = runtime
+
+    @property
+    def stable_revision(self):
+        """Gets the stable_revision of this V1beta1ModelStatus.  # noqa: E501
+
+        Model state of the last rolled out revision, reported while a canary revision is rolled out.  # noqa: E501
+
+        :return: The stable_revision of this V1beta1ModelStatus.  # noqa: E501
+        :rtype: V1beta1RevisionModelStatus
+        """
+        return self._stable_revision
+
+    @stable_revision.setter
+    def stable_revision(self, stable_revision):
+        """Sets the stable_revision of this V1beta1ModelStatus.
+
+        Model state of the last rolled out revision, reported while a canary revision is rolled out.  # noqa: E501
+
+        :param stable_revision: The stable_revision of this V1beta1ModelStatus.  # noqa: E501
+        :type: V1beta1RevisionModelStatus
+        """
+
+        self._stable_revision = stable_revision
+
     @property
     def states(self):
         """Gets the states of this V1beta1ModelStatus.  # noqa: E501
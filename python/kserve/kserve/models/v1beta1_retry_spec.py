@@ -0,0 +1,193 @@
+# Copyright 2024 The KServe Authors.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#    http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+# coding: utf-8
+
+"""
+    KServe
+
+    Python SDK for KServe  # noqa: E501
+
+    The version of the OpenAPI document: v0.1
+    Generated by: https://openapi-generator.tech
+"""
+
+
+import pprint
+import re  # noqa: F401
+
+import six
+
+from kserve.configuration import Configuration
+
+
+class V1beta1RetrySpec(object):
+    """NOTE: This class is auto generated by OpenAPI Generator.
+    Ref: https://openapi-generator.tech
+
+    Do not edit the class manually.
+    """
+
+    """
+    Attributes:
+      openapi_types (dict): The key is attribute name
+                            and the value is attribute type.
+      attribute_map (dict): The key is attribute name
+                            and the value is json key in definition.
+    """
+    openapi_types = {
+        'attempts': 'int',
+        'per_try_timeout': 'int',
+        'retry_on': 'str'
+    }
+
+    attribute_map = {
+        'attempts': 'attempts',
+        'per_try_timeout': 'perTryTimeout',
+        'retry_on': 'retryOn'
+    }
+
+    def __init__(self, attempts=0, per_try_timeout=None, retry_on=None, local_vars_configuration=None):  # noqa: E501
+        """V1beta1RetrySpec - a model defined in OpenAPI"""  # noqa: E501
+        if local_vars_configuration is None:
+            local_vars_configuration = Configuration()
+        self.local_vars_configuration = local_vars_configuration
+
+        self._attempts = None
+        self._per_try_timeout = None
+        self._retry_on = None
+        self.discriminator = None
+
+        self.attempts = attempts
+        if per_try_timeout is not None:
+            self.per_try_timeout = per_try_timeout
+        if retry_on is not None:
+            self.retry_on = retry_on
+
+    @property
+    def attempts(self):
+        """Gets the attempts of this V1beta1RetrySpec.  # noqa: E501
+
+        Attempts is the number of retries of a request.  # noqa: E501
+
+        :return: The attempts of this V1beta1RetrySpec.  # noqa: E501
+        :rtype: int
+        """
+        return self._attempts
+
+    @attempts.setter
+    def attempts(self, attempts):
+        """Sets the attempts of this V1beta1RetrySpec.
+
+        Attempts is the number of retries of a request.  # noqa: E501
+
+        :param attempts: The attempts of this V1beta1RetrySpec.  # noqa: E501
+        :type: int
+        """
+        if self.local_vars_configuration.client_side_validation and attempts is None:  # noqa: E501
+            raise ValueError("Invalid value for `attempts`, must not be `None`")  # noqa: E501
+
+        self._attempts = attempts
+
+    @property
+    def per_try_timeout(self):
+        """Gets the per_try_timeout of this V1beta1RetrySpec.  # noqa: E501
+
+        PerTryTimeoutSeconds specifies the number of seconds to wait before timing out an attempt, defaults to the timeout of the component.  # noqa: E501
+
+        :return: The per_try_timeout of this V1beta1RetrySpec.  # noqa: E501
+        :rtype: int
+        """
+        return self._per_try_timeout
+
+    @per_try_timeout.setter
+    def per_try_timeout(self, per_try_timeout):
+        """Sets the per_try_timeout of this V1beta1RetrySpec.
+
+        PerTryTimeoutSeconds specifies the number of seconds to wait before timing out an attempt, defaults to the timeout of the component.  # noqa: E501
+
+        :param per_try_timeout: The per_try_timeout of this V1beta1RetrySpec.  # noqa: E501
+        :type: int
+        """
+
+        self._per_try_timeout = per_try_timeout
+
+    @property
+    def retry_on(self):
+        """Gets the retry_on of this V1beta1RetrySpec.  # noqa: E501
+
+        RetryOn specifies the conditions under which a request is retried as a comma separated list, e.g. 5xx,connect-failure,refused-stream. See https://istio.io/latest/docs/reference/config/networking/virtual-service/#HTTPRetry  # noqa: E501
+
+        :return: The retry_on of this V1beta1RetrySpec.  # noqa: E501
+        :rtype: str
+        """
+        return self._retry_on
+
+    @retry_on.setter
+    def retry_on(self, retry_on):
+        """Sets the retry_on of this V1beta1RetrySpec.
+
+        RetryOn specifies the conditions under which a request is retried as a comma separated list, e.g. 5xx,connect-failure,refused-stream. See https://istio.io/latest/docs/reference/config/networking/virtual-service/#HTTPRetry  # noqa: E501
+
+        :param retry_on: The retry_on of this V1beta1RetrySpec.  # noqa: E501
+        :type: str
+        """
+
+        self._retry_on = retry_on
+
+    def to_dict(self):
+        """Returns the model properties as a dict"""
+        result = {}
+
+        for attr, _ in six.iteritems(self.openapi_types):
+            value = getattr(self, attr)
+            if isinstance(value, list):
+                result[attr] = list(map(
+                    lambda x: x.to_dict() if hasattr(x, "to_dict") else x,
+                    value
+                ))
+            elif hasattr(value, "to_dict"):
+                result[attr] = value.to_dict()
+            elif isinstance(value, dict):
+                result[attr] = dict(map(
+                    lambda item: (item[0], item[1].to_dict())
+                    if hasattr(item[1], "to_dict") else item,
+                    value.items()
+                ))
+            else:
+                result[attr] = value
+
+        return result
+
+    def to_str(self):
+        """Returns the string representation of the model"""
+        return pprint.pformat(self.to_dict())
+
+    def __repr__(self):
+        """For `print` and `pprint`"""
+        return self.to_str()
+
+    def __eq__(self, other):
+        """Returns true if both objects are equal"""
+        if not isinstance(other, V1beta1RetrySpec):
+            return False
+
+        return self.to_dict() == other.to_dict()
+
+    def __ne__(self, other):
+        """Returns true if both objects are not equal"""
+        if not isinstance(other, V1beta1RetrySpec):
+            return True
+
+        return self.to_dict() != other.to_dict()
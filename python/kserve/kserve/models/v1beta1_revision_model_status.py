@@ -0,0 +1,251 @@
+# Copyright 2024 The KServe Authors.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#    http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+# coding: utf-8
+
+"""
+    KServe
+
+    Python SDK for KServe  # noqa: E501
+
+    The version of the OpenAPI document: v0.1
+    Generated by: https://openapi-generator.tech
+"""
+
+
+import pprint
+import re  # noqa: F401
+
+import six
+
+from kserve.configuration import Configuration
+
+
+class V1beta1RevisionModelStatus(object):
+    """NOTE: This class is auto generated by OpenAPI Generator.
+    Ref: https://openapi-generator.tech
+
+    Do not edit the class manually.
+    """
+
+    """
+    Attributes:
+      openapi_types (dict): The key is attribute name
+                            and the value is attribute type.
+      attribute_map (dict): The key is attribute name
+                            and the value is json key in definition.
+    """
+    openapi_types = {
+        'last_failure_info': 'V1beta1FailureInfo',
+        'name': 'str',
+        'state': 'str',
+        'storage_uri': 'str',
+        'transition_status': 'str'
+    }
+
+    attribute_map = {
+        'last_failure_info': 'lastFailureInfo',
+        'name': 'name',
+        'state': 'state',
+        'storage_uri': 'storageUri',
+        'transition_status': 'transitionStatus'
+    }
+
+    def __init__(self, last_failure_info=None, name='', state='', storage_uri=None, transition_status='', local_vars_configuration=None):  # noqa: E501
+        """V1beta1RevisionModelStatus - a model defined in OpenAPI"""  # noqa: E501
+        if local_vars_configuration is None:
+            local_vars_configuration = Configuration()
+        self.local_vars_configuration = local_vars_configuration
+
+        self._last_failure_info = None
+        self._name = None
+        self._state = None
+        self._storage_uri = None
+        self._transition_status = None
+        self.discriminator = None
+
+        if last_failure_info is not None:
+            self.last_failure_info = last_failure_info
+        self.name = name
+        self.state = state
+        if storage_uri is not None:
+            self.storage_uri = storage_uri
+        self.transition_status = transition_status
+
+    @property
+    def last_failure_info(self):
+        """Gets the last_failure_info of this V1beta1RevisionModelStatus.  # noqa: E501
+
+        Details of last failure, when load of the model of the revision is failed.  # noqa: E501
+
+        :return: The last_failure_info of this V1beta1RevisionModelStatus.  # noqa: E501
+        :rtype: V1beta1FailureInfo
+        """
+        return self._last_failure_info
+
+    @last_failure_info.setter
+    def last_failure_info(self, last_failure_info):
+        """Sets the last_failure_info of this V1beta1RevisionModelStatus.
+
+        Details of last failure, when load of the model of the revision is failed.  # noqa: E501
+
+        :param last_failure_info: The last_failure_info of this V1beta1RevisionModelStatus.  # noqa: E501
+        :type: V1beta1FailureInfo
+        """
+
+        self._last_failure_info = last_failure_info
+
+    @property
+    def name(self):
+        """Gets the name of this V1beta1RevisionModelStatus.  # noqa: E501
+
+        Name of the revision.  # noqa: E501
+
+        :return: The name of this V1beta1RevisionModelStatus.  # noqa: E501
+        :rtype: str
+        """
+        return self._name
+
+    @name.setter
+    def name(self, name):
+        """Sets the name of this V1beta1RevisionModelStatus.
+
+        Name of the revision.  # noqa: E501
+
+        :param name: The name of this V1beta1RevisionModelStatus.  # noqa: E501
+        :type: str
+        """
+        if self.local_vars_configuration.client_side_validation and name is None:  # noqa: E501
+            raise ValueError("Invalid value for `name`, must not be `None`")  # noqa: E501
+
+        self._name = name
+
+    @property
+    def state(self):
+        """Gets the state of this V1beta1RevisionModelStatus.  # noqa: E501
+
+        State of the model on the revision: Pending, Loading, Loaded, FailedToLoad  # noqa: E501
+
+        :return: The state of this V1beta1RevisionModelStatus.  # noqa: E501
+        :rtype: str
+        """
+        return self._state
+
+    @state.setter
+    def state(self, state):
+        """Sets the state of this V1beta1RevisionModelStatus.
+
+        State of the model on the revision: Pending, Loading, Loaded, FailedToLoad  # noqa: E501
+
+        :param state: The state of this V1beta1RevisionModelStatus.  # noqa: E501
+        :type: str
+        """
+        if self.local_vars_configuration.client_side_validation and state is None:  # noqa: E501
+            raise ValueError("Invalid value for `state`, must not be `None`")  # noqa: E501
+
+        self._state = state
+
+    @property
+    def storage_uri(self):
+        """Gets the storage_uri of this V1beta1RevisionModelStatus.  # noqa: E501
+
+        Storage URI of the model downloaded by the revision.  # noqa: E501
+
+        :return: The storage_uri of this V1beta1RevisionModelStatus.  # noqa: E501
+        :rtype: str
+        """
+        return self._storage_uri
+
+    @storage_uri.setter
+    def storage_uri(self, storage_uri):
+        """Sets the storage_uri of this V1beta1RevisionModelStatus.
+
+        Storage URI of the model downloaded by the revision.  # noqa: E501
+
+        :param storage_uri: The storage_uri of this V1beta1RevisionModelStatus.  # noqa: E501
+        :type: str
+        """
+
+        self._storage_uri = storage_uri
+
+    @property
+    def transition_status(self):
+        """Gets the transition_status of this V1beta1RevisionModelStatus.  # noqa: E501
+
+        Whether the model of the revision is loaded, or is in transition  # noqa: E501
+
+        :return: The transition_status of this V1beta1RevisionModelStatus.  # noqa: E501
+        :rtype: str
+        """
+        return self._transition_status
+
+    @transition_status.setter
+    def transition_status(self, transition_status):
+        """Sets the transition_status of this V1beta1RevisionModelStatus.
+
+        Whether the model of the revision is loaded, or is in transition  # noqa: E501
+
+        :param transition_status: The transition_status of this V1beta1RevisionModelStatus.  # noqa: E501
+        :type: str
+        """
+        if self.local_vars_configuration.client_side_validation and transition_status is None:  # noqa: E501
+            raise ValueError("Invalid value for `transition_status`, must not be `None`")  # noqa: E501
+
+        self._transition_status = transition_status
+
+    def to_dict(self):
+        """Returns the model properties as a dict"""
+        result = {}
+
+        for attr, _ in six.iteritems(self.openapi_types):
+            value = getattr(self, attr)
+            if isinstance(value, list):
+                result[attr] = list(map(
+                    lambda x: x.to_dict() if hasattr(x, "to_dict") else x,
+                    value
+                ))
+            elif hasattr(value, "to_dict"):
+                result[attr] = value.to_dict()
+            elif isinstance(value, dict):
+                result[attr] = dict(map(
+                    lambda item: (item[0], item[1].to_dict())
+                    if hasattr(item[1], "to_dict") else item,
+                    value.items()
+                ))
+            else:
+                result[attr] = value
+
+        return result
+
+    def to_str(self):
+        """Returns the string representation of the model"""
+        return pprint.pformat(self.to_dict())
+
+    def __repr__(self):
+        """For `print` and `pprint`"""
+        return self.to_str()
+
+    def __eq__(self, other):
+        """Returns true if both objects are equal"""
+        if not isinstance(other, V1beta1RevisionModelStatus):
+            return False
+
+        return self.to_dict() == other.to_dict()
+
+    def __ne__(self, other):
+        """Returns true if both objects are not equal"""
+        if not isinstance(other, V1beta1RevisionModelStatus):
+            return True
+
+        return self.to_dict() != other.to_dict()
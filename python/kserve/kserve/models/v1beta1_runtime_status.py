@@ -0,0 +1,221 @@
+# Copyright 2024 The KServe Authors.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#    http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+# coding: utf-8
+
+"""
+    KServe
+
+    Python SDK for KServe  # noqa: E501
+
+    The version of the OpenAPI document: v0.1
+    Generated by: https://openapi-generator.tech
+"""
+
+
+import pprint
+import re  # noqa: F401
+
+import six
+
+from kserve.configuration import Configuration
+
+
+class V1beta1RuntimeStatus(object):
+    """NOTE: This class is auto generated by OpenAPI Generator.
+    Ref: https://openapi-generator.tech
+
+    Do not edit the class manually.
+    """
+
+    """
+    Attributes:
+      openapi_types (dict): The key is attribute name
+                            and the value is attribute type.
+      attribute_map (dict): The key is attribute name
+                            and the value is json key in definition.
+    """
+    openapi_types = {
+        'generation': 'int',
+        'inherit_from': 'str',
+        'inherit_from_generation': 'int',
+        'name': 'str'
+    }
+
+    attribute_map = {
+        'generation': 'generation',
+        'inherit_from': 'inheritFrom',
+        'inherit_from_generation': 'inheritFromGeneration',
+        'name': 'name'
+    }
+
+    def __init__(self, generation=None, inherit_from=None, inherit_from_generation=None, name='', local_vars_configuration=None):  # noqa: E501
+        """V1beta1RuntimeStatus - a model defined in OpenAPI"""  # noqa: E501
+        if local_vars_configuration is None:
+            local_vars_configuration = Configuration()
+        self.local_vars_configuration = local_vars_configuration
+
+        self._generation = None
+        self._inherit_from = None
+        self._inherit_from_generation = None
+        self._name = None
+        self.discriminator = None
+
+        if generation is not None:
+            self.generation = generation
+        if inherit_from is not None:
+            self.inherit_from = inherit_from
+        if inherit_from_generation is not None:
+            self.inherit_from_generation = inherit_from_generation
+        self.name = name
+
+    @property
+    def generation(self):
+        """Gets the generation of this V1beta1RuntimeStatus.  # noqa: E501
+
+        Generation of the ServingRuntime.  # noqa: E501
+
+        :return: The generation of this V1beta1RuntimeStatus.  # noqa: E501
+        :rtype: int
+        """
+        return self._generation
+
+    @generation.setter
+    def generation(self, generation):
+        """Sets the generation of this V1beta1RuntimeStatus.
+
+        Generation of the ServingRuntime.  # noqa: E501
+
+        :param generation: The generation of this V1beta1RuntimeStatus.  # noqa: E501
+        :type: int
+        """
+
+        self._generation = generation
+
+    @property
+    def inherit_from(self):
+        """Gets the inherit_from of this V1beta1RuntimeStatus.  # noqa: E501
+
+        Name of the ClusterServingRuntime the ServingRuntime inherits from.  # noqa: E501
+
+        :return: The inherit_from of this V1beta1RuntimeStatus.  # noqa: E501
+        :rtype: str
+        """
+        return self._inherit_from
+
+    @inherit_from.setter
+    def inherit_from(self, inherit_from):
+        """Sets the inherit_from of this V1beta1RuntimeStatus.
+
+        Name of the ClusterServingRuntime the ServingRuntime inherits from.  # noqa: E501
+
+        :param inherit_from: The inherit_from of this V1beta1RuntimeStatus.  # noqa: E501
+        :type: str
+        """
+
+        self._inherit_from = inherit_from
+
+    @property
+    def inherit_from_generation(self):
+        """Gets the inherit_from_generation of this V1beta1RuntimeStatus.  # noqa: E501
+
+        Generation of the ClusterServingRuntime the ServingRuntime inherits from.  # noqa: E501
+
+        :return: The inherit_from_generation of this V1beta1RuntimeStatus.  # noqa: E501
+        :rtype: int
+        """
+        return self._inherit_from_generation
+
+    @inherit_from_generation.setter
+    def inherit_from_generation(self, inherit_from_generation):
+        """Sets the inherit_from_generation of this V1beta1RuntimeStatus.
+
+        Generation of the ClusterServingRuntime the ServingRuntime inherits from.  # noqa: E501
+
+        :param inherit_from_generation: The inherit_from_generation of this V1beta1RuntimeStatus.  # noqa: E501
+        :type: int
+        """
+
+        self._inherit_from_generation = inherit_from_generation
+
+    @property
+    def name(self):
+        """Gets the name of this V1beta1RuntimeStatus.  # noqa: E501
+
+        Name of the ServingRuntime.  # noqa: E501
+
+        :return: The name of this V1beta1RuntimeStatus.  # noqa: E501
+        :rtype: str
+        """
+        return self._name
+
+    @name.setter
+    def name(self, name):
+        """Sets the name of this V1beta1RuntimeStatus.
+
+        Name of the ServingRuntime.  # noqa: E501
+
+        :param name: The name of this V1beta1RuntimeStatus.  # noqa: E501
+        :type: str
+        """
+        if self.local_vars_configuration.client_side_validation and name is None:  # noqa: E501
+            raise ValueError("Invalid value for `name`, must not be `None`")  # noqa: E501
+
+        self._name = name
+
+    def to_dict(self):
+        """Returns the model properties as a dict"""
+        result = {}
+
+        for attr, _ in six.iteritems(self.openapi_types):
+            value = getattr(self, attr)
+            if isinstance(value, list):
+                result[attr] = list(map(
+                    lambda x: x.to_dict() if hasattr(x, "to_dict") else x,
+                    value
+                ))
+            elif hasattr(value, "to_dict"):
+                result[attr] = value.to_dict()
+            elif isinstance(value, dict):
+                result[attr] = dict(map(
+                    lambda item: (item[0], item[1].to_dict())
+                    if hasattr(item[1], "to_dict") else item,
+                    value.items()
+                ))
+            else:
+                result[attr] = value
+
+        return result
+
+    def to_str(self):
+        """Returns the string representation of the model"""
+        return pprint.pformat(self.to_dict())
+
+    def __repr__(self):
+        """For `print` and `pprint`"""
+        return self.to_str()
+
+    def __eq__(self, other):
+        """Returns true if both objects are equal"""
+        if not isinstance(other, V1beta1RuntimeStatus):
+            return False
+
+        return self.to_dict() == other.to_dict()
+
+    def __ne__(self, other):
+        """Returns true if both objects are not equal"""
+        if not isinstance(other, V1beta1RuntimeStatus):
+            return True
+
+        return self.to_dict() != other.to_dict()
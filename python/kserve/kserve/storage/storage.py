@@ -15,6 +15,7 @@
 import base64
 import glob
 import gzip
+import hashlib
 import json
 import mimetypes
 import os
@@ -41,6 +42,9 @@ from google.cloud import storage
 from ..logging import logger
 
 MODEL_MOUNT_DIRS = "/mnt/models"
+# Statistics of the download, written at the root of the model. The file is hidden so that it is not picked up as a
+# model file by the model servers.
+DOWNLOAD_STATUS_FILE = ".kserve-download-status.json"
 
 _GCS_PREFIX = "gs://"
 _S3_PREFIX = "s3://"
@@ -52,8 +56,15 @@ _LOCAL_PREFIX = "file://"
 _URI_RE = "https?://(.+)/(.+)"
 _HTTP_PREFIX = "http(s)://"
 _HEADERS_SUFFIX = "-headers"
+_TOKEN_SUFFIX = "-token"
 _PVC_PREFIX = "/mnt/pvc"
 
+_VERIFY_CHECKSUM_ENV = "STORAGE_VERIFY_CHECKSUM"
+_LOCAL_COPY_ENV = "STORAGE_LOCAL_COPY"
+_KSERVE_MANIFEST = "kserve-manifest.json"
+_SHA256SUMS = "SHA256SUMS"
+_SHA256SUMS_LINE_RE = re.compile(r"^([0-9a-fA-F]{64}) [ *](.+)$")
+
 _HDFS_SECRET_DIRECTORY = "/var/secrets/kserve-hdfscreds"
 _HDFS_FILE_SECRETS = ["KERBEROS_KEYTAB", "TLS_CERT", "TLS_KEY", "TLS_CA"]
 
@@ -91,9 +102,9 @@ class Storage(object):
         elif re.search(_AZURE_FILE_RE, uri):
             Storage._download_azure_file_share(uri, out_dir)
         elif is_local:
-            return Storage._download_local(uri, out_dir)
+            Storage._download_local(uri, out_dir)
         elif re.search(_URI_RE, uri):
-            return Storage._download_from_uri(uri, out_dir)
+            Storage._download_from_uri(uri, out_dir)
         elif uri.startswith(MODEL_MOUNT_DIRS):
             # Don't need to download models if this InferenceService is running in the multi-model
             # serving mode. The model agent will download models.
@@ -106,10 +117,117 @@ class Storage(object):
                 % (_GCS_PREFIX, _S3_PREFIX, _LOCAL_PREFIX, _HTTP_PREFIX)
             )
 
+        if os.getenv(_VERIFY_CHECKSUM_ENV, "false").lower() == "true":
+            Storage._verify_checksums(out_dir)
+
         logger.info("Successfully copied %s to %s", uri, out_dir)
         logger.info(f"Model downloaded in {time.monotonic() - start} seconds.")
         return out_dir
 
+    @staticmethod
+    def write_download_status(model_dir: str, duration: float) -> Dict:
+        """Counts the files and bytes of the downloaded model and writes them along with the duration of the
+        download in seconds to the download status file of the model.
+        """
+        files = 0
+        size = 0
+        for root, _, names in os.walk(model_dir, followlinks=True):
+            for name in names:
+                path = os.path.join(root, name)
+                if name == DOWNLOAD_STATUS_FILE or not os.path.isfile(path):
+                    continue
+                files += 1
+                size += os.path.getsize(path)
+        status = {"bytes": size, "files": files, "durationSeconds": duration}
+        with open(os.path.join(model_dir, DOWNLOAD_STATUS_FILE), "w") as f:
+            json.dump(status, f)
+        logger.info(
+            "Downloaded %d files (%d bytes) to %s in %.3f seconds",
+            files,
+            size,
+            model_dir,
+            duration,
+        )
+        return status
+
+    @staticmethod
+    def _verify_checksums(model_dir: str):
+        """Verifies the sha256 digests of the files listed by the checksum manifest
+        found at the root of the model, the verification is skipped when there is none.
+        """
+        manifest_path = None
+        for name in (_KSERVE_MANIFEST, _SHA256SUMS):
+            if os.path.isfile(os.path.join(model_dir, name)):
+                manifest_path = os.path.join(model_dir, name)
+                break
+        if manifest_path is None:
+            logger.info(
+                "No checksum manifest found in %s, skipping checksum verification",
+                model_dir,
+            )
+            return
+
+        checksums = Storage._read_checksum_manifest(manifest_path)
+        mismatches = []
+        for path in sorted(checksums):
+            file_path = os.path.join(model_dir, path)
+            if not os.path.isfile(file_path):
+                mismatches.append(f"{path} (missing)")
+                continue
+            sha256 = hashlib.sha256()
+            with open(file_path, "rb") as f:
+                for chunk in iter(lambda: f.read(1024 * 1024), b""):
+                    sha256.update(chunk)
+            if sha256.hexdigest() != checksums[path]:
+                mismatches.append(
+                    f"{path} (expected sha256 {checksums[path]}, got {sha256.hexdigest()})"
+                )
+        if mismatches:
+            raise RuntimeError(
+                f"Checksum verification against {os.path.basename(manifest_path)} failed "
+                f"for {len(mismatches)} file(s): {', '.join(mismatches)}"
+            )
+        logger.info(
+            "Verified the checksums of %s files against %s",
+            len(checksums),
+            manifest_path,
+        )
+
+    @staticmethod
+    def _read_checksum_manifest(manifest_path: str) -> Dict[str, str]:
+        entries = {}
+        with open(manifest_path) as f:
+            if os.path.basename(manifest_path) == _KSERVE_MANIFEST:
+                for entry in json.load(f).get("files", []):
+                    entries[entry["path"]] = entry["sha256"]
+            else:
+                for line in f:
+                    line = line.strip()
+                    if line == "" or line.startswith("#"):
+                        continue
+                    match = _SHA256SUMS_LINE_RE.match(line)
+                    if match is None:
+                        raise RuntimeError(
+                            f"Invalid line {line!r} in checksum manifest {manifest_path}"
+                        )
+                    entries[match.group(2)] = match.group(1)
+
+        checksums = {}
+        for path, digest in entries.items():
+            clean_path = os.path.normpath(path)
+            if os.path.isabs(clean_path) or clean_path.split(os.sep)[0] == "..":
+                raise RuntimeError(
+                    f"Invalid path {path!r} in checksum manifest {manifest_path}, "
+                    "paths must be relative to the model root"
+                )
+            if not re.fullmatch("[0-9a-fA-F]{64}", digest):
+                raise RuntimeError(
+                    f"Invalid sha256 digest {digest!r} of {path} "
+                    f"in checksum manifest {manifest_path}"
+                )
+            checksums[clean_path] = digest.lower()
+        return checksums
+
     @staticmethod
     def _update_with_storage_spec():
         storage_secret_json = json.loads(os.environ.get("STORAGE_CONFIG", "{}"))
@@ -161,19 +279,42 @@ class Storage(object):
         # S3UseVirtualBucket environment variable defined in s3_secret.go
         # use virtual hosted-style URLs if enabled
         virtual = "true" == os.getenv("S3_USER_VIRTUAL_BUCKET", "false").lower()
+        # S3ForcePathStyle environment variable defined in s3_secret.go
+        # use path-style URLs if enabled, it takes precedence over S3UseVirtualBucket
+        path_style = "true" == os.getenv("S3_FORCE_PATH_STYLE", "false").lower()
         # S3UseAccelerate environment variable defined in s3_secret.go
         # use transfer acceleration if enabled
         accelerate = "true" == os.getenv("S3_USE_ACCELERATE", "false").lower()
 
         if anon:
             c = c.merge(Config(signature_version=UNSIGNED))
-        if virtual:
+        if path_style:
+            c = c.merge(Config(s3={"addressing_style": "path"}))
+        elif virtual:
             c = c.merge(Config(s3={"addressing_style": "virtual"}))
         if accelerate:
             c = c.merge(Config(s3={"use_accelerate_endpoint": accelerate}))
 
         return c
 
+    @staticmethod
+    def _assume_s3_role(role_arn: str, kwargs: dict) -> dict:
+        # The role is assumed with the credentials of the environment. The STS
+        # requests are sent to the s3 endpoint when set, as done by MinIO.
+        sts = boto3.client(
+            "sts",
+            endpoint_url=kwargs.get("endpoint_url"),
+            verify=kwargs.get("verify"),
+        )
+        credentials = sts.assume_role(
+            RoleArn=role_arn, RoleSessionName="kserve-storage-initializer"
+        )["Credentials"]
+        return {
+            "aws_access_key_id": credentials["AccessKeyId"],
+            "aws_secret_access_key": credentials["SecretAccessKey"],
+            "aws_session_token": credentials["SessionToken"],
+        }
+
     @staticmethod
     def _download_s3(uri, temp_dir: str):
         # Boto3 looks at various configuration locations until it finds configuration values.
@@ -214,6 +355,13 @@ class Storage(object):
                     raise RuntimeError(
                         "Failed to find ca bundle file(%s)." % ca_bundle_full_path
                     )
+            elif os.getenv("AWS_CA_BUNDLE"):
+                # boto3 ignores AWS_CA_BUNDLE when verify is passed explicitly
+                kwargs.update({"verify": os.getenv("AWS_CA_BUNDLE")})
+        # AssumeRole environment variable defined in s3_secret.go
+        role_arn = os.getenv("AWS_ASSUME_ROLE_ARN")
+        if role_arn:
+            kwargs.update(Storage._assume_s3_role(role_arn, kwargs))
         s3 = boto3.resource("s3", **kwargs)
         parsed = urlparse(uri, scheme="s3")
         bucket_name = parsed.netloc
@@ -441,14 +589,7 @@ class Storage(object):
             container_name,
             prefix,
         )
-        token = (
-            Storage._get_azure_storage_token()
-            or Storage._get_azure_storage_access_key()
-        )
-        if token is None:
-            logger.warning(
-                "Azure credentials or shared access signature token not found, retrying anonymous access"
-            )
+        account_url, token = Storage._get_azure_blob_credential(account_url)
 
         blob_service_client = BlobServiceClient(account_url, credential=token)
         container_client = blob_service_client.get_container_client(container_name)
@@ -586,6 +727,47 @@ class Storage(object):
     def _get_azure_storage_access_key():
         return os.getenv("AZURE_STORAGE_ACCESS_KEY")
 
+    @staticmethod
+    def _get_azure_storage_sas_token():
+        sas_token = os.getenv("AZURE_STORAGE_SAS_TOKEN", "")
+        return sas_token.lstrip("?") or None
+
+    @staticmethod
+    def _get_azure_blob_credential(account_url: str):
+        """Returns the account url and the credential of the blob service client.
+
+        The credentials are selected in order of precedence: the service principal,
+        the storage access key, the shared access signature token of the uri or of
+        the secret, which is appended to the account url, and the workload identity
+        when the federated token is mounted. The blobs are accessed anonymously
+        otherwise.
+        """
+        token = (
+            Storage._get_azure_storage_token()
+            or Storage._get_azure_storage_access_key()
+        )
+        if token is not None:
+            return account_url, token
+
+        if "?" in account_url:
+            return account_url, None
+        sas_token = Storage._get_azure_storage_sas_token()
+        if sas_token:
+            logger.info("Using the shared access signature token of the secret")
+            return f"{account_url}?{sas_token}", None
+
+        # The workload identity webhook mounts the federated token and sets its path
+        if os.getenv("AZURE_FEDERATED_TOKEN_FILE"):
+            from azure.identity import DefaultAzureCredential
+
+            logger.info("Using the workload identity token credential")
+            return account_url, DefaultAzureCredential()
+
+        logger.warning(
+            "Azure credentials or shared access signature token not found, retrying anonymous access"
+        )
+        return account_url, None
+
     @staticmethod
     def _download_local(uri, out_dir=None):
         local_path = uri.replace(_LOCAL_PREFIX, "", 1)
@@ -600,15 +782,23 @@ class Storage(object):
         if os.path.isdir(local_path):
             local_path = os.path.join(local_path, "*")
 
+        # Copy the files instead of linking them when the source volume is not mounted on the model server
+        copy = os.getenv(_LOCAL_COPY_ENV, "false").lower() == "true"
         file_count = 0
         for src in glob.glob(local_path):
             _, tail = os.path.split(src)
             dest_path = os.path.join(out_dir, tail)
-            logger.info("Linking: %s to %s", src, dest_path)
-            if not os.path.exists(dest_path):
-                os.symlink(src, dest_path)
-            else:
+            if os.path.exists(dest_path):
                 logger.info("File %s already exist", dest_path)
+            elif copy:
+                logger.info("Copying: %s to %s", src, dest_path)
+                if os.path.isdir(src):
+                    shutil.copytree(src, dest_path)
+                else:
+                    shutil.copy2(src, dest_path)
+            else:
+                logger.info("Linking: %s to %s", src, dest_path)
+                os.symlink(src, dest_path)
             file_count += 1
         if file_count == 0:
             raise RuntimeError("Failed to fetch model. No model found in %s." % (uri))
@@ -640,6 +830,10 @@ class Storage(object):
 
         headers_json = os.getenv(host_uri + _HEADERS_SUFFIX, "{}")
         headers = json.loads(headers_json)
+        # The bearer token is sent unless the headers already set the Authorization header
+        token = os.getenv(host_uri + _TOKEN_SUFFIX)
+        if token and not any(key.lower() == "authorization" for key in headers):
+            headers["Authorization"] = f"Bearer {token}"
 
         with requests.get(uri, stream=True, headers=headers) as response:
             if response.status_code != 200:
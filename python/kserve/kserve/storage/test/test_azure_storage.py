@@ -385,3 +385,87 @@ def test_file_share_no_prefix(
     mock_storage.assert_called_with(
         "https://accountname.file.core.windows.net", credential="some_token"
     )
+
+
+@mock.patch(STORAGE_MODULE + ".os.makedirs")
+@mock.patch.dict(
+    STORAGE_MODULE + ".os.environ",
+    {"AZURE_STORAGE_SAS_TOKEN": "?sv=2022-11-02&sig=abc"},
+)
+@mock.patch(STORAGE_MODULE + ".BlobServiceClient")
+def test_blob_sas_token(mock_storage, mock_makedirs):  # pylint: disable=unused-argument
+
+    # given
+    blob_path = "https://accountname.blob.core.windows.net/container/somefile.text"
+    mock_blob, mock_container = create_mock_blob(mock_storage, ["somefile.text"])
+
+    # when
+    Storage._download_azure_blob(blob_path, "dest_path")
+
+    # then
+    mock_storage.assert_called_with(
+        "https://accountname.blob.core.windows.net?sv=2022-11-02&sig=abc",
+        credential=None,
+    )
+
+
+azure_blob_credential_testparams = [
+    # the service principal takes precedence over the other credentials
+    (
+        {
+            "AZURE_CLIENT_ID": "client-id",
+            "AZURE_STORAGE_ACCESS_KEY": "access-key",
+            "AZURE_STORAGE_SAS_TOKEN": "sig=abc",
+        },
+        "https://account.blob.core.windows.net",
+        ("https://account.blob.core.windows.net", "service-principal"),
+    ),
+    # the access key takes precedence over the shared access signature token
+    (
+        {
+            "AZURE_STORAGE_ACCESS_KEY": "access-key",
+            "AZURE_STORAGE_SAS_TOKEN": "sig=abc",
+        },
+        "https://account.blob.core.windows.net",
+        ("https://account.blob.core.windows.net", "access-key"),
+    ),
+    # the shared access signature token of the uri takes precedence over the secret
+    (
+        {"AZURE_STORAGE_SAS_TOKEN": "sig=abc"},
+        "https://account.blob.core.windows.net?sig=uri",
+        ("https://account.blob.core.windows.net?sig=uri", None),
+    ),
+    (
+        {
+            "AZURE_STORAGE_SAS_TOKEN": "sig=abc",
+            "AZURE_FEDERATED_TOKEN_FILE": "/var/run/secrets/azure/tokens/token",
+        },
+        "https://account.blob.core.windows.net",
+        ("https://account.blob.core.windows.net?sig=abc", None),
+    ),
+    # the workload identity is used when no credentials are set
+    (
+        {"AZURE_FEDERATED_TOKEN_FILE": "/var/run/secrets/azure/tokens/token"},
+        "https://account.blob.core.windows.net",
+        ("https://account.blob.core.windows.net", "default-credential"),
+    ),
+    (
+        {},
+        "https://account.blob.core.windows.net",
+        ("https://account.blob.core.windows.net", None),
+    ),
+]
+
+
+@pytest.mark.parametrize("env,account_url,expected", azure_blob_credential_testparams)
+def test_azure_blob_credential(env, account_url, expected):
+    def get_storage_token():
+        return "service-principal" if env.get("AZURE_CLIENT_ID") else None
+
+    with mock.patch.dict(STORAGE_MODULE + ".os.environ", env, clear=True), mock.patch(
+        STORAGE_MODULE + ".Storage._get_azure_storage_token",
+        side_effect=get_storage_token,
+    ), mock.patch(
+        "azure.identity.DefaultAzureCredential", return_value="default-credential"
+    ):
+        assert Storage._get_azure_blob_credential(account_url) == expected
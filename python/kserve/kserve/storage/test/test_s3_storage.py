@@ -212,6 +212,14 @@ def test_get_S3_config():
         config7 = Storage.get_S3_config()
     assert config7.s3["addressing_style"] == VIRTUAL_CONFIG.s3["addressing_style"]
 
+    path_style_and_virtual = {
+        "S3_FORCE_PATH_STYLE": "True",
+        "S3_USER_VIRTUAL_BUCKET": "True",
+    }
+    with mock.patch.dict(os.environ, path_style_and_virtual):
+        config8 = Storage.get_S3_config()
+    assert config8.s3["addressing_style"] == "path"
+
     with mock.patch.dict(os.environ, {"S3_USE_ACCELERATE": "False"}):
         config6 = Storage.get_S3_config()
     assert vars(config6) == vars(DEFAULT_CONFIG)
@@ -288,3 +296,48 @@ def test_target_startswith_parent_folder_name(mock_storage):
         == expected_call_args_list("test/artifacts/model", "dest_path", paths)[0]
     )
     mock_boto3_bucket.objects.filter.assert_called_with(Prefix="test/artifacts/model")
+
+
+@mock.patch(STORAGE_MODULE + ".boto3")
+def test_assume_role(mock_storage):
+    bucket_name = "foo"
+    mock_storage.client.return_value.assume_role.return_value = {
+        "Credentials": {
+            "AccessKeyId": "assumed-key",
+            "SecretAccessKey": "assumed-secret",
+            "SessionToken": "token",
+        }
+    }
+    create_mock_boto3_bucket(mock_storage, ["model.pkl"])
+    env = {
+        "AWS_ASSUME_ROLE_ARN": "arn:aws:iam::123456789012:role/models",
+        "AWS_ENDPOINT_URL": "https://minio.local:9000",
+    }
+    with mock.patch.dict(os.environ, env, clear=True):
+        Storage._download_s3(f"s3://{bucket_name}/model.pkl", "dest_path")
+
+    mock_storage.client.assert_called_with(
+        "sts", endpoint_url="https://minio.local:9000", verify=None
+    )
+    mock_storage.client.return_value.assume_role.assert_called_with(
+        RoleArn="arn:aws:iam::123456789012:role/models",
+        RoleSessionName="kserve-storage-initializer",
+    )
+    _, kwargs = mock_storage.resource.call_args
+    assert kwargs["aws_access_key_id"] == "assumed-key"
+    assert kwargs["aws_secret_access_key"] == "assumed-secret"
+    assert kwargs["aws_session_token"] == "token"
+
+
+@mock.patch(STORAGE_MODULE + ".boto3")
+def test_ca_bundle_with_verify_ssl(mock_storage):
+    create_mock_boto3_bucket(mock_storage, ["model.pkl"])
+    env = {
+        "S3_VERIFY_SSL": "1",
+        "AWS_CA_BUNDLE": "/etc/ssl/s3-cabundle/cabundle.crt",
+    }
+    with mock.patch.dict(os.environ, env, clear=True):
+        Storage._download_s3("s3://foo/model.pkl", "dest_path")
+
+    _, kwargs = mock_storage.resource.call_args
+    assert kwargs["verify"] == "/etc/ssl/s3-cabundle/cabundle.crt"
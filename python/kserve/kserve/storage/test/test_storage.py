@@ -12,7 +12,9 @@
 # See the License for the specific language governing permissions and
 # limitations under the License.
 
+import hashlib
 import io
+import json
 import os
 import tempfile
 import binascii
@@ -23,7 +25,7 @@ from pathlib import Path
 import botocore
 import pytest
 
-from kserve.storage import Storage
+from kserve.storage import Storage, DOWNLOAD_STATUS_FILE
 
 STORAGE_MODULE = "kserve.storage.storage"
 HTTPS_URI_TARGZ = "https://foo.bar/model.tar.gz"
@@ -65,6 +67,26 @@ def test_no_prefix_local_path():
     assert Storage.download(relative_path) == relative_path
 
 
+def test_storage_local_path_link():
+    with tempfile.TemporaryDirectory() as src, tempfile.TemporaryDirectory() as out_dir:
+        Path(src, "model.pkl").write_text("model")
+        Storage.download(src, out_dir)
+        assert os.path.islink(os.path.join(out_dir, "model.pkl"))
+
+
+def test_storage_local_path_copy(monkeypatch):
+    monkeypatch.setenv("STORAGE_LOCAL_COPY", "true")
+    with tempfile.TemporaryDirectory() as src, tempfile.TemporaryDirectory() as out_dir:
+        Path(src, "model.pkl").write_text("model")
+        Path(src, "tokenizer").mkdir()
+        Path(src, "tokenizer", "vocab.txt").write_text("vocab")
+        Storage.download(src, out_dir)
+        assert not os.path.islink(os.path.join(out_dir, "model.pkl"))
+        assert not os.path.islink(os.path.join(out_dir, "tokenizer"))
+        assert Path(out_dir, "model.pkl").read_text() == "model"
+        assert Path(out_dir, "tokenizer", "vocab.txt").read_text() == "vocab"
+
+
 class MockHttpResponse(object):
     def __init__(self, status_code=404, raw=b"", content_type=""):
         self.status_code = status_code
@@ -108,6 +130,22 @@ def test_https_uri_path(_):
     os.remove("./model.joblib")
 
 
+@mock.patch.dict(
+    os.environ,
+    {"foo.bar-headers": '{"X-Api-Key": "some-key"}', "foo.bar-token": "some-token"},
+)
+def test_http_uri_path_token():
+    response = MockHttpResponse(status_code=200, content_type="application/octet-stream")
+    with tempfile.TemporaryDirectory() as out_dir, mock.patch(
+        "requests.get", return_value=response
+    ) as mock_get:
+        Storage.download("https://foo.bar/model.joblib", out_dir=out_dir)
+    assert mock_get.call_args.kwargs["headers"] == {
+        "X-Api-Key": "some-key",
+        "Authorization": "Bearer some-token",
+    }
+
+
 http_uri_path_testparams = [
     (
         HTTPS_URI_TARGZ,
@@ -291,3 +329,100 @@ def test_unpack_zip_file():
     Storage._unpack_archive_file(tar_file, mimetype, out_dir)
     assert os.path.exists(os.path.join(out_dir, "model.pth"))
     os.remove(os.path.join(out_dir, "model.pth"))
+
+
+def _write_model(model_dir, files):
+    for name, content in files.items():
+        path = os.path.join(model_dir, name)
+        os.makedirs(os.path.dirname(path), exist_ok=True)
+        Path(path).write_bytes(content)
+
+
+def _sha256(content):
+    return hashlib.sha256(content).hexdigest()
+
+
+MODEL_FILES = {
+    "config.json": b'{"model_type": "bert"}',
+    "shards/model.safetensors": b"weights",
+}
+
+
+def test_verify_checksum_without_manifest(monkeypatch):
+    monkeypatch.setenv("STORAGE_VERIFY_CHECKSUM", "true")
+    with tempfile.TemporaryDirectory() as src, tempfile.TemporaryDirectory() as out:
+        _write_model(src, MODEL_FILES)
+        assert Storage.download(src, out) == out
+
+
+@pytest.mark.parametrize(
+    "manifest",
+    [
+        {
+            "SHA256SUMS": (
+                f"{_sha256(MODEL_FILES['config.json'])}  config.json\n"
+                f"{_sha256(b'weights')} *./shards/model.safetensors\n"
+            ).encode()
+        },
+        {
+            "kserve-manifest.json": json.dumps(
+                {
+                    "files": [
+                        {
+                            "path": "config.json",
+                            "sha256": _sha256(MODEL_FILES["config.json"]),
+                        },
+                        {
+                            "path": "shards/model.safetensors",
+                            "sha256": _sha256(b"weights"),
+                        },
+                    ]
+                }
+            ).encode()
+        },
+    ],
+)
+def test_verify_checksum_match(monkeypatch, manifest):
+    monkeypatch.setenv("STORAGE_VERIFY_CHECKSUM", "true")
+    with tempfile.TemporaryDirectory() as src, tempfile.TemporaryDirectory() as out:
+        _write_model(src, {**MODEL_FILES, **manifest})
+        assert Storage.download(src, out) == out
+
+
+def test_verify_checksum_mismatch(monkeypatch):
+    manifest = (
+        f"{_sha256(MODEL_FILES['config.json'])}  config.json\n"
+        f"{_sha256(b'weigh')}  shards/model.safetensors\n"
+        f"{_sha256(b'tokenizer')}  tokenizer.json\n"
+    ).encode()
+    with tempfile.TemporaryDirectory() as src:
+        _write_model(src, {**MODEL_FILES, "SHA256SUMS": manifest})
+
+        # the verification is disabled by default
+        with tempfile.TemporaryDirectory() as out:
+            assert Storage.download(src, out) == out
+
+        monkeypatch.setenv("STORAGE_VERIFY_CHECKSUM", "true")
+        with tempfile.TemporaryDirectory() as out:
+            with pytest.raises(RuntimeError) as e:
+                Storage.download(src, out)
+        assert "failed for 2 file(s)" in str(e.value)
+        assert (
+            f"shards/model.safetensors (expected sha256 {_sha256(b'weigh')}, "
+            f"got {_sha256(b'weights')})" in str(e.value)
+        )
+        assert "tokenizer.json (missing)" in str(e.value)
+
+
+def test_write_download_status():
+    with tempfile.TemporaryDirectory() as src, tempfile.TemporaryDirectory() as out:
+        _write_model(src, MODEL_FILES)
+        Storage.download(src, out)
+
+        status = Storage.write_download_status(out, 1.5)
+        assert status == {"bytes": 29, "files": 2, "durationSeconds": 1.5}
+        with open(os.path.join(out, DOWNLOAD_STATUS_FILE)) as f:
+            assert json.load(f) == status
+
+        # the status file of a previous download is not counted
+        assert Storage.write_download_status(out, 1)["files"] == 2
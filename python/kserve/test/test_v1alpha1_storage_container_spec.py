@@ -58,6 +58,7 @@ class TestV1alpha1StorageContainerSpec(unittest.TestCase):
                 container=V1Container(
                     name="test-container", image="kserve/testimage:0.11"
                 ),
+                priority=56,
                 supported_uri_formats=[
                     kserve.models.v1alpha1_supported_uri_format.V1alpha1SupportedUriFormat(
                         prefix="0",
@@ -51,10 +51,12 @@ class TestV1beta1ModelStatus(unittest.TestCase):
         # model = kserve.models.v1beta1_model_status.V1beta1ModelStatus()  # noqa: E501
         if include_optional:
             return V1beta1ModelStatus(
+                allocated_model_memory=None,
                 copies=kserve.models.v1beta1_model_copies.V1beta1ModelCopies(
                     failed_copies=56,
                     total_copies=56,
                 ),
+                last_download_duration=None,
                 last_failure_info=kserve.models.v1beta1_failure_info.V1beta1FailureInfo(
                     location="0",
                     message="0",
@@ -45,6 +45,8 @@ const (
 	KServeContainerPrometheusMetricsPortEnvVarKey     = "KSERVE_CONTAINER_PROMETHEUS_METRICS_PORT"
 	KServeContainerPrometheusMetricsPathEnvVarKey     = "KSERVE_CONTAINER_PROMETHEUS_METRICS_PATH"
 	QueueProxyAggregatePrometheusMetricsPortEnvVarKey = "AGGREGATE_PROMETHEUS_METRICS_PORT"
+	AgentPrometheusMetricsPortEnvVarKey               = "AGENT_PROMETHEUS_METRICS_PORT"
+	DefaultAgentMetricsPath                           = "/metrics"
 	QueueProxyMetricsPort                             = "9091"
 	DefaultQueueProxyMetricsPath                      = "/metrics"
 	prometheusTimeoutHeader                           = "X-Prometheus-Scrape-Timeout-Seconds"
@@ -56,6 +58,7 @@ type ScrapeConfigurations struct {
 	QueueProxyPort string `json:"port"`
 	AppPort        string
 	AppPath        string
+	AgentPort      string
 }
 
 func getURL(port string, path string) string {
@@ -229,20 +232,33 @@ func scrape(url string, header http.Header, logger *zap.Logger) (io.ReadCloser,
 	return resp.Body, cancel, format, nil
 }
 
-func NewScrapeConfigs(logger *zap.Logger, queueProxyPort string, appPort string, appPath string) *ScrapeConfigurations {
+func NewScrapeConfigs(logger *zap.Logger, queueProxyPort string, appPort string, appPath string, agentPort string) *ScrapeConfigurations {
 	return &ScrapeConfigurations{
 		logger:         logger,
 		QueueProxyPath: DefaultQueueProxyMetricsPath,
 		QueueProxyPort: queueProxyPort,
 		AppPort:        appPort,
 		AppPath:        appPath,
+		AgentPort:      agentPort,
+	}
+}
+
+// writeScrapedMetrics writes the metrics scraped from a container of the pod with the serverless labels added
+func (sc *ScrapeConfigurations) writeScrapedMetrics(w io.Writer, metrics io.Reader, format expfmt.Format) {
+	var parser expfmt.TextParser
+	mfs, err := parser.TextToMetricFamilies(metrics)
+	if err != nil {
+		sc.logger.Error("error converting text to metric families", zap.Error(err), zap.Any("metric families return value", mfs))
+	}
+	if err = scrapeAndWriteAppMetrics(mfs, w, format, sc.logger); err != nil {
+		sc.logger.Error("failed scraping and writing metrics", zap.Error(err))
 	}
 }
 
 func (sc *ScrapeConfigurations) handleStats(w http.ResponseWriter, r *http.Request) {
 	var err error
-	var queueProxy, application io.ReadCloser
-	var queueProxyCancel, appCancel context.CancelFunc
+	var queueProxy, application, agent io.ReadCloser
+	var queueProxyCancel, appCancel, agentCancel context.CancelFunc
 
 	defer func() {
 		if queueProxy != nil {
@@ -257,12 +273,21 @@ func (sc *ScrapeConfigurations) handleStats(w http.ResponseWriter, r *http.Reque
 				sc.logger.Error("application connection is not closed", zap.Error(err))
 			}
 		}
+		if agent != nil {
+			err = agent.Close()
+			if err != nil {
+				sc.logger.Error("agent connection is not closed", zap.Error(err))
+			}
+		}
 		if queueProxyCancel != nil {
 			queueProxyCancel()
 		}
 		if appCancel != nil {
 			appCancel()
 		}
+		if agentCancel != nil {
+			agentCancel()
+		}
 	}()
 
 	// Gather all the metrics we will merge
@@ -282,6 +307,14 @@ func (sc *ScrapeConfigurations) handleStats(w http.ResponseWriter, r *http.Reque
 		}
 	}
 
+	// Scrape agent metrics if the pod runs the agent
+	if sc.AgentPort != "" {
+		agentURL := getURL(sc.AgentPort, DefaultAgentMetricsPath)
+		if agent, agentCancel, _, err = scrape(agentURL, r.Header, sc.logger); err != nil {
+			sc.logger.Error("failed scraping agent metrics", zap.Error(err))
+		}
+	}
+
 	// Since we convert the scraped metrics to text, set the format as text even if
 	// the content type is originally open metrics.
 	format := expfmt.FmtText
@@ -295,16 +328,11 @@ func (sc *ScrapeConfigurations) handleStats(w http.ResponseWriter, r *http.Reque
 	}
 
 	if application != nil {
-		var err error
-		var parser expfmt.TextParser
-		var mfs map[string]*io_prometheus_client.MetricFamily
-		mfs, err = parser.TextToMetricFamilies(application)
-		if err != nil {
-			sc.logger.Error("error converting text to metric families", zap.Error(err), zap.Any("metric families return value", mfs))
-		}
-		if err = scrapeAndWriteAppMetrics(mfs, w, format, sc.logger); err != nil {
-			sc.logger.Error("failed scraping and writing metrics", zap.Error(err))
-		}
+		sc.writeScrapedMetrics(w, application, format)
+	}
+
+	if agent != nil {
+		sc.writeScrapedMetrics(w, agent, format)
 	}
 }
 
@@ -318,6 +346,7 @@ func main() {
 		QueueProxyMetricsPort,
 		os.Getenv(KServeContainerPrometheusMetricsPortEnvVarKey),
 		os.Getenv(KServeContainerPrometheusMetricsPathEnvVarKey),
+		os.Getenv(AgentPrometheusMetricsPortEnvVarKey),
 	)
 	mux.HandleFunc(`/metrics`, sc.handleStats)
 	l, err := net.Listen("tcp", fmt.Sprintf(":%v", aggregateMetricsPort))
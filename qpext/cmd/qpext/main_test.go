@@ -341,6 +341,7 @@ request_preprocess_seconds_count{model_name="custom-server-test",service_name="s
 		name             string
 		queueproxy       string
 		app              string
+		agent            string
 		output           string
 		expectParseError bool
 	}{
@@ -366,6 +367,12 @@ request_preprocess_seconds_count{model_name="custom-server-test",service_name="s
 			// since app metrics adds labels, the output should contain labels only for the app metrics
 			output: otherMetricExample + metricExampleWLabels,
 		},
+		{
+			name:       "agent metric",
+			queueproxy: otherMetricExample,
+			agent:      metricExample,
+			output:     otherMetricExample + metricExampleWLabels,
+		},
 		// when app and queueproxy share a metric, Prometheus will fail.
 		{
 			name:             "conflict metric",
@@ -392,10 +399,17 @@ request_preprocess_seconds_count{model_name="custom-server-test",service_name="s
 			}))
 			defer app.Close()
 
+			agent := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, err := w.Write([]byte(test.agent))
+				assert.NoError(t, err)
+			}))
+			defer agent.Close()
+
 			psc := &ScrapeConfigurations{
 				logger:         zapLogger,
 				QueueProxyPort: strings.Split(qp.URL, ":")[2],
 				AppPort:        strings.Split(app.URL, ":")[2],
+				AgentPort:      strings.Split(agent.URL, ":")[2],
 			}
 			req := &http.Request{}
 			psc.handleStats(rec, req)
@@ -431,15 +445,18 @@ func TestHandleStatsErr(t *testing.T) {
 		name       string
 		queueproxy string
 		app        string
+		agent      string
 	}{
-		{"both pass", passPort, passPort},
-		{"queue proxy pass", passPort, failPort},
-		{"app pass", failPort, passPort},
-		{"both fail", failPort, failPort},
+		{"both pass", passPort, passPort, ""},
+		{"queue proxy pass", passPort, failPort, ""},
+		{"app pass", failPort, passPort, ""},
+		{"both fail", failPort, failPort, ""},
+		{"agent fail", passPort, passPort, failPort},
+		{"all fail", failPort, failPort, failPort},
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			sc := NewScrapeConfigs(zapLogger, test.queueproxy, test.app, DefaultQueueProxyMetricsPath)
+			sc := NewScrapeConfigs(zapLogger, test.queueproxy, test.app, DefaultQueueProxyMetricsPath, test.agent)
 			req := &http.Request{}
 			rec := httptest.NewRecorder()
 			sc.handleStats(rec, req)